@@ -26,6 +26,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-1.1",
@@ -36,6 +40,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-1.2",
@@ -46,6 +54,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-1.3",
@@ -56,6 +68,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-2.0",
@@ -66,6 +82,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-2.1",
@@ -76,6 +96,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-3-5-haiku-20241022",
@@ -86,6 +110,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-3-5-sonnet-20240620",
@@ -96,6 +124,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-3-5-sonnet-20241022",
@@ -106,6 +138,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-3-7-sonnet-20250219",
@@ -116,6 +152,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-3-haiku-20240307",
@@ -126,6 +166,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-3-opus-20240229",
@@ -136,6 +180,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-3-sonnet-20240229",
@@ -146,6 +194,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-haiku-4-5-20251001",
@@ -160,6 +212,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("The fastest model with near-frontier intelligence."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(64000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "claude-instant-1.0",
@@ -170,6 +226,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-instant-1.1",
@@ -180,6 +240,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-instant-1.2",
@@ -190,6 +254,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-opus-4-1-20250805",
@@ -200,6 +268,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-opus-4-20250514",
@@ -210,6 +282,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-opus-4-5-20251101",
@@ -220,6 +296,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-opus-4-6",
@@ -230,6 +310,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("The most intelligent model for building agents and coding."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "claude-sonnet-4-20250514",
@@ -240,6 +324,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-sonnet-4-5-20250929",
@@ -250,6 +338,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "claude-sonnet-4-6",
@@ -260,6 +352,10 @@ pub(crate) const ANTHROPIC_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("The best combination of speed and intelligence."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(64000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
 ];
 
@@ -26,6 +26,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax-M2.5",
@@ -36,6 +40,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax/MiniMax-M2.1",
@@ -46,6 +54,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax/MiniMax-M2.5",
@@ -56,6 +68,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Moonshot-Kimi-K2-Instruct",
@@ -66,6 +82,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "abab6.5g-chat",
@@ -76,6 +96,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "abab6.5s-chat",
@@ -86,6 +110,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "abab6.5t-chat",
@@ -96,6 +124,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "aitryon",
@@ -108,6 +140,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "aitryon-parsing-v1",
@@ -120,6 +156,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "aitryon-plus",
@@ -132,6 +172,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "aitryon-refiner",
@@ -144,6 +188,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "animate-anyone",
@@ -156,6 +204,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "animate-anyone-detect",
@@ -168,6 +220,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "animate-anyone-detect-gen2",
@@ -180,6 +236,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "animate-anyone-gen2",
@@ -192,6 +252,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "animate-anyone-template-gen2",
@@ -204,6 +268,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cosyvoice-v1",
@@ -216,6 +284,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cosyvoice-v2",
@@ -228,6 +300,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cosyvoice-v3-flash",
@@ -240,6 +316,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cosyvoice-v3-plus",
@@ -252,6 +332,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cosyvoice-v3.5-flash",
@@ -264,6 +348,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cosyvoice-v3.5-plus",
@@ -276,6 +364,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1",
@@ -286,6 +378,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-0528",
@@ -296,6 +392,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-distill-llama-70b",
@@ -306,6 +406,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-distill-llama-8b",
@@ -316,6 +420,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-distill-qwen-1.5b",
@@ -326,6 +434,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-distill-qwen-14b",
@@ -336,6 +448,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-distill-qwen-32b",
@@ -346,6 +462,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-distill-qwen-7b",
@@ -356,6 +476,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3",
@@ -366,6 +490,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3.1",
@@ -376,6 +504,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3.2",
@@ -386,6 +518,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3.2-exp",
@@ -396,6 +532,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "emo",
@@ -408,6 +548,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "emo-detect",
@@ -420,6 +564,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "emo-detect-v1",
@@ -432,6 +580,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "emo-v1",
@@ -444,6 +596,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "emoji-detect-v1",
@@ -456,6 +612,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "emoji-v1",
@@ -468,6 +628,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "facechain-facedetect",
@@ -480,6 +644,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "facechain-finetune",
@@ -492,6 +660,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "facechain-generation",
@@ -504,6 +676,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "farui-plus",
@@ -514,6 +690,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "flux-dev",
@@ -526,6 +706,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "flux-merged",
@@ -538,6 +722,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "flux-schnell",
@@ -550,6 +738,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr",
@@ -562,6 +754,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-2025-08-25",
@@ -574,6 +770,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-2025-11-07",
@@ -586,6 +786,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-flash-8k-realtime",
@@ -598,6 +802,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-flash-8k-realtime-2026-01-28",
@@ -610,6 +818,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-mtl",
@@ -622,6 +834,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-mtl-2025-08-25",
@@ -634,6 +850,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-realtime",
@@ -646,6 +866,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-realtime-2025-09-15",
@@ -658,6 +882,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-realtime-2025-11-07",
@@ -670,6 +898,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "fun-asr-realtime-2026-02-28",
@@ -682,6 +914,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-4.5",
@@ -692,6 +928,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-4.5-air",
@@ -702,6 +942,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-4.6",
@@ -712,6 +956,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-4.7",
@@ -722,6 +970,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-5",
@@ -732,6 +984,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gte-rerank-v2",
@@ -744,6 +1000,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::RERANK,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gui-plus",
@@ -754,6 +1014,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gummy-chat-v1",
@@ -766,6 +1030,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gummy-realtime-v1",
@@ -778,6 +1046,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "image-erase-completion",
@@ -790,6 +1062,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "image-instance-segmentation",
@@ -802,6 +1078,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "image-out-painting",
@@ -814,6 +1094,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "kimi-k2-thinking",
@@ -824,6 +1108,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "kimi-k2.5",
@@ -834,6 +1122,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "kimi/kimi-k2.5",
@@ -844,6 +1136,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "liveportrait",
@@ -856,6 +1152,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "liveportrait-detect",
@@ -868,6 +1168,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "multimodal-embedding-v1",
@@ -880,6 +1184,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "opennlu-v1",
@@ -892,6 +1200,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::CLASSIFICATION_OR_EXTRACTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paraformer-8k-v1",
@@ -904,6 +1216,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paraformer-8k-v2",
@@ -916,6 +1232,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paraformer-mtl-v1",
@@ -928,6 +1248,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paraformer-realtime-8k-v1",
@@ -940,6 +1264,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paraformer-realtime-8k-v2",
@@ -952,6 +1280,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paraformer-realtime-v1",
@@ -964,6 +1296,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paraformer-realtime-v2",
@@ -976,6 +1312,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paraformer-v1",
@@ -988,6 +1328,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paraformer-v2",
@@ -1000,6 +1344,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qvq-72b-preview",
@@ -1010,6 +1358,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qvq-max",
@@ -1020,6 +1372,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qvq-max-2025-03-25",
@@ -1030,6 +1386,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qvq-max-2025-05-15",
@@ -1040,6 +1400,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qvq-max-latest",
@@ -1050,6 +1414,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qvq-plus",
@@ -1060,6 +1428,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qvq-plus-2025-05-15",
@@ -1070,6 +1442,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qvq-plus-latest",
@@ -1080,6 +1456,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-audio-asr",
@@ -1092,6 +1472,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-audio-asr-latest",
@@ -1104,6 +1488,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-audio-chat",
@@ -1114,6 +1502,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-audio-turbo",
@@ -1124,6 +1516,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-audio-turbo-latest",
@@ -1134,6 +1530,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-coder-plus",
@@ -1144,6 +1544,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-coder-plus-2024-11-06",
@@ -1154,6 +1558,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-coder-plus-latest",
@@ -1164,6 +1572,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-coder-turbo",
@@ -1174,6 +1586,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-coder-turbo-2024-09-19",
@@ -1184,6 +1600,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-coder-turbo-latest",
@@ -1194,6 +1614,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-deep-research",
@@ -1204,6 +1628,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-doc-turbo",
@@ -1214,6 +1642,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-flash",
@@ -1224,6 +1656,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-flash-2025-07-28",
@@ -1234,6 +1670,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-flash-2025-07-28-us",
@@ -1244,6 +1684,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-flash-character",
@@ -1254,6 +1698,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-flash-character-2026-02-26",
@@ -1264,6 +1712,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-flash-us",
@@ -1274,6 +1726,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image",
@@ -1286,6 +1742,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-2.0",
@@ -1299,6 +1759,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_GENERATION),
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_EDIT),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-2.0-2026-03-03",
@@ -1312,6 +1776,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_GENERATION),
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_EDIT),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-2.0-pro",
@@ -1325,6 +1793,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_GENERATION),
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_EDIT),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-2.0-pro-2026-03-03",
@@ -1338,6 +1810,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_GENERATION),
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_EDIT),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-edit",
@@ -1350,6 +1826,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-edit-max",
@@ -1362,6 +1842,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-edit-max-2026-01-16",
@@ -1374,6 +1858,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-edit-plus",
@@ -1386,6 +1874,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-edit-plus-2025-10-30",
@@ -1398,6 +1890,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-edit-plus-2025-12-15",
@@ -1410,6 +1906,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-max",
@@ -1422,6 +1922,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-max-2025-12-30",
@@ -1434,6 +1938,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-plus",
@@ -1446,6 +1954,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-plus-2026-01-09",
@@ -1458,6 +1970,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-long",
@@ -1468,6 +1984,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-long-2025-01-25",
@@ -1478,6 +1998,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-long-latest",
@@ -1488,6 +2012,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-math-plus",
@@ -1498,6 +2026,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-math-turbo",
@@ -1508,6 +2040,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-max",
@@ -1518,6 +2054,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-max-2024-04-28",
@@ -1528,6 +2068,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-max-2024-09-19",
@@ -1538,6 +2082,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-max-2025-01-25",
@@ -1548,6 +2096,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-max-latest",
@@ -1558,6 +2110,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-mt-flash",
@@ -1568,6 +2124,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-mt-image",
@@ -1580,6 +2140,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_TRANSLATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-mt-lite",
@@ -1590,6 +2154,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-mt-plus",
@@ -1600,6 +2168,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-mt-turbo",
@@ -1610,6 +2182,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-omni-turbo",
@@ -1620,6 +2196,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-omni-turbo-2025-01-19",
@@ -1630,6 +2210,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-omni-turbo-2025-03-26",
@@ -1640,6 +2224,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-omni-turbo-latest",
@@ -1650,6 +2238,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-omni-turbo-realtime",
@@ -1660,6 +2252,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-omni-turbo-realtime-latest",
@@ -1670,6 +2266,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus",
@@ -1680,6 +2280,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-2024-12-20",
@@ -1690,6 +2294,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-2025-01-12",
@@ -1700,6 +2308,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-2025-01-25",
@@ -1710,6 +2322,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-2025-04-28",
@@ -1720,6 +2336,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-2025-07-14",
@@ -1730,6 +2350,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-2025-07-28",
@@ -1740,6 +2364,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-2025-09-11",
@@ -1750,6 +2378,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-2025-12-01",
@@ -1760,6 +2392,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-2025-12-01-us",
@@ -1770,6 +2406,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-character",
@@ -1780,6 +2420,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-character-ja",
@@ -1790,6 +2434,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-latest",
@@ -1800,6 +2448,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-plus-us",
@@ -1810,6 +2462,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-tts",
@@ -1822,6 +2478,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-tts-2025-04-10",
@@ -1834,6 +2494,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-tts-2025-05-22",
@@ -1846,6 +2510,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-tts-latest",
@@ -1858,6 +2526,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-tts-realtime",
@@ -1870,6 +2542,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-tts-realtime-2025-07-15",
@@ -1882,6 +2558,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-tts-realtime-latest",
@@ -1894,6 +2574,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-turbo",
@@ -1904,6 +2588,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-turbo-2024-11-01",
@@ -1914,6 +2602,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-turbo-2025-02-11",
@@ -1924,6 +2616,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-turbo-2025-04-28",
@@ -1934,6 +2630,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-turbo-2025-07-15",
@@ -1944,6 +2644,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-turbo-latest",
@@ -1954,6 +2658,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-chat-v1",
@@ -1964,6 +2672,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-max",
@@ -1974,6 +2686,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-max-2024-11-19",
@@ -1984,6 +2700,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-max-2024-12-30",
@@ -1994,6 +2714,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-max-2025-01-25",
@@ -2004,6 +2728,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-max-2025-04-02",
@@ -2014,6 +2742,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-max-2025-04-08",
@@ -2024,6 +2756,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-max-2025-08-13",
@@ -2034,6 +2770,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-max-latest",
@@ -2044,6 +2784,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-ocr",
@@ -2054,6 +2798,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-ocr-2024-10-28",
@@ -2064,6 +2812,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-ocr-2025-04-13",
@@ -2074,6 +2826,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-ocr-2025-08-28",
@@ -2084,6 +2840,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-ocr-2025-11-20",
@@ -2094,6 +2854,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-ocr-latest",
@@ -2104,6 +2868,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-plus",
@@ -2114,6 +2882,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-plus-2025-01-02",
@@ -2124,6 +2896,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-plus-2025-01-25",
@@ -2134,6 +2910,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-plus-2025-05-07",
@@ -2144,6 +2924,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-plus-2025-07-10",
@@ -2154,6 +2938,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-plus-2025-08-15",
@@ -2164,6 +2952,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-plus-latest",
@@ -2174,6 +2966,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-vl-v1",
@@ -2184,6 +2980,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-voice-design",
@@ -2196,6 +2996,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-voice-enrollment",
@@ -2208,6 +3012,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen1.5-0.5b-chat",
@@ -2218,6 +3026,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen1.5-1.8b-chat",
@@ -2228,6 +3040,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen1.5-110b-chat",
@@ -2238,6 +3054,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen1.5-14b-chat",
@@ -2248,6 +3068,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen1.5-32b-chat",
@@ -2258,6 +3082,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen1.5-72b-chat",
@@ -2268,6 +3096,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen1.5-7b-chat",
@@ -2278,6 +3110,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2-0.5b-instruct",
@@ -2288,6 +3124,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2-1.5b-instruct",
@@ -2298,6 +3138,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2-57b-a14b-instruct",
@@ -2308,6 +3152,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2-72b-instruct",
@@ -2318,6 +3166,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2-7b-instruct",
@@ -2328,6 +3180,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2-audio-instruct",
@@ -2338,6 +3194,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2-vl-2b-instruct",
@@ -2348,6 +3208,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2-vl-72b-instruct",
@@ -2358,6 +3222,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2-vl-7b-instruct",
@@ -2368,6 +3236,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-0.5b-instruct",
@@ -2378,6 +3250,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-1.5b-instruct",
@@ -2388,6 +3264,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-14b-instruct",
@@ -2398,6 +3278,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-14b-instruct-1m",
@@ -2408,6 +3292,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-32b-instruct",
@@ -2418,6 +3306,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-3b-instruct",
@@ -2428,6 +3320,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-72b-instruct",
@@ -2438,6 +3334,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-7b-instruct",
@@ -2448,6 +3348,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-7b-instruct-1m",
@@ -2458,6 +3362,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-coder-0.5b-instruct",
@@ -2468,6 +3376,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-coder-1.5b-instruct",
@@ -2478,6 +3390,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-coder-14b-instruct",
@@ -2488,6 +3404,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-coder-32b-instruct",
@@ -2498,6 +3418,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-coder-3b-instruct",
@@ -2508,6 +3432,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-coder-7b-instruct",
@@ -2518,6 +3446,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-math-1.5b-instruct",
@@ -2528,6 +3460,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-math-72b-instruct",
@@ -2538,6 +3474,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-math-7b-instruct",
@@ -2548,6 +3488,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-omni-7b",
@@ -2558,6 +3502,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-vl-32b-instruct",
@@ -2568,6 +3516,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-vl-3b-instruct",
@@ -2578,6 +3530,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-vl-72b-instruct",
@@ -2588,6 +3544,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-vl-7b-instruct",
@@ -2598,6 +3558,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-vl-embedding",
@@ -2610,6 +3574,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-0.6b",
@@ -2620,6 +3588,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-1.7b",
@@ -2630,6 +3602,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-14b",
@@ -2640,6 +3616,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-235b-a22b",
@@ -2650,6 +3630,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-235b-a22b-instruct-2507",
@@ -2660,6 +3644,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-235b-a22b-thinking-2507",
@@ -2670,6 +3658,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-30b-a3b",
@@ -2680,6 +3672,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-30b-a3b-instruct-2507",
@@ -2690,6 +3686,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-30b-a3b-thinking-2507",
@@ -2700,6 +3700,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-32b",
@@ -2710,6 +3714,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-4b",
@@ -2720,6 +3728,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-8b",
@@ -2730,6 +3742,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash",
@@ -2742,6 +3758,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash-2025-09-08",
@@ -2754,6 +3774,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash-2025-09-08-us",
@@ -2766,6 +3790,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash-2026-02-10",
@@ -2778,6 +3806,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash-filetrans",
@@ -2790,6 +3822,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash-filetrans-2025-11-17",
@@ -2802,6 +3838,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash-realtime",
@@ -2814,6 +3854,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash-realtime-2025-10-27",
@@ -2826,6 +3870,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash-realtime-2026-02-10",
@@ -2838,6 +3886,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-asr-flash-us",
@@ -2850,6 +3902,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-30b-a3b-instruct",
@@ -2860,6 +3916,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-480b-a35b-instruct",
@@ -2870,6 +3930,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-flash",
@@ -2880,6 +3944,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-flash-2025-07-28",
@@ -2890,6 +3958,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-next",
@@ -2900,6 +3972,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-plus",
@@ -2910,6 +3986,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-plus-2025-07-22",
@@ -2920,6 +4000,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-plus-2025-09-23",
@@ -2930,6 +4014,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-livetranslate-flash",
@@ -2942,6 +4030,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-livetranslate-flash-2025-12-01",
@@ -2954,6 +4046,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-livetranslate-flash-realtime",
@@ -2966,6 +4062,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-livetranslate-flash-realtime-2025-09-22",
@@ -2978,6 +4078,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-max",
@@ -2988,6 +4092,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-max-2025-09-23",
@@ -2998,6 +4106,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-max-2026-01-23",
@@ -3008,6 +4120,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-max-preview",
@@ -3018,6 +4134,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-next-80b-a3b-instruct",
@@ -3028,6 +4148,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-next-80b-a3b-thinking",
@@ -3038,6 +4162,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-omni-30b-a3b-captioner",
@@ -3048,6 +4176,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-omni-flash",
@@ -3058,6 +4190,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-omni-flash-2025-09-15",
@@ -3068,6 +4204,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-omni-flash-2025-12-01",
@@ -3078,6 +4218,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-omni-flash-realtime",
@@ -3088,6 +4232,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-rerank",
@@ -3100,6 +4248,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::RERANK,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-flash",
@@ -3112,6 +4264,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-flash-2025-09-18",
@@ -3124,6 +4280,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-flash-2025-11-27",
@@ -3136,6 +4296,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-flash-realtime",
@@ -3148,6 +4312,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-flash-realtime-2025-09-18",
@@ -3160,6 +4328,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-flash-realtime-2025-11-27",
@@ -3172,6 +4344,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-instruct-flash",
@@ -3184,6 +4360,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-instruct-flash-2026-01-26",
@@ -3196,6 +4376,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-instruct-flash-realtime",
@@ -3208,6 +4392,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-instruct-flash-realtime-2026-01-22",
@@ -3220,6 +4408,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-vc-2026-01-22",
@@ -3232,6 +4424,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-vc-realtime-2025-11-27",
@@ -3244,6 +4440,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-vc-realtime-2026-01-15",
@@ -3256,6 +4456,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-vd-2026-01-26",
@@ -3268,6 +4472,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-vd-realtime-2025-12-16",
@@ -3280,6 +4488,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-tts-vd-realtime-2026-01-15",
@@ -3292,6 +4504,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-235b-a22b-instruct",
@@ -3302,6 +4518,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-235b-a22b-thinking",
@@ -3312,6 +4532,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-30b-a3b-instruct",
@@ -3322,6 +4546,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-30b-a3b-thinking",
@@ -3332,6 +4560,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-32b-instruct",
@@ -3342,6 +4574,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-32b-thinking",
@@ -3352,6 +4588,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-8b-instruct",
@@ -3362,6 +4602,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-8b-thinking",
@@ -3372,6 +4616,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-embedding",
@@ -3384,6 +4632,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-flash",
@@ -3394,6 +4646,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-flash-2025-10-15",
@@ -3404,6 +4660,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-flash-2025-10-15-us",
@@ -3414,6 +4674,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-flash-2026-01-22",
@@ -3424,6 +4688,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-flash-us",
@@ -3434,6 +4702,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-plus",
@@ -3444,6 +4716,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-plus-2025-09-23",
@@ -3454,6 +4730,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-plus-2025-12-19",
@@ -3464,6 +4744,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-122b-a10b",
@@ -3474,6 +4758,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-27b",
@@ -3484,6 +4772,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-35b-a3b",
@@ -3494,6 +4786,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-397b-a17b",
@@ -3504,6 +4800,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-flash",
@@ -3514,6 +4814,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-flash-2026-02-23",
@@ -3524,6 +4828,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-plus",
@@ -3534,6 +4842,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-plus-2026-02-15",
@@ -3544,6 +4856,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwq-32b",
@@ -3554,6 +4870,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwq-32b-preview",
@@ -3564,6 +4884,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwq-plus",
@@ -3574,6 +4898,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwq-plus-2025-03-05",
@@ -3584,6 +4912,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwq-plus-latest",
@@ -3594,6 +4926,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "sensevoice-v1",
@@ -3606,6 +4942,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "shoemodel-v1",
@@ -3618,6 +4958,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "siliconflow/deepseek-r1-0528",
@@ -3628,6 +4972,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "siliconflow/deepseek-v3-0324",
@@ -3638,6 +4986,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "siliconflow/deepseek-v3.1-terminus",
@@ -3648,6 +5000,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "siliconflow/deepseek-v3.2",
@@ -3658,6 +5014,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "stable-diffusion-3.5-large",
@@ -3670,6 +5030,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "stable-diffusion-3.5-large-turbo",
@@ -3682,6 +5046,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "stable-diffusion-v1.5",
@@ -3694,6 +5062,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "stable-diffusion-xl",
@@ -3706,6 +5078,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "text-embedding-async-v1",
@@ -3718,6 +5094,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "text-embedding-async-v2",
@@ -3730,6 +5110,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "text-embedding-v1",
@@ -3742,6 +5126,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "text-embedding-v2",
@@ -3754,6 +5142,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "text-embedding-v3",
@@ -3766,6 +5158,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "text-embedding-v4",
@@ -3778,6 +5174,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "tongyi-embedding-vision-flash",
@@ -3790,6 +5190,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "tongyi-embedding-vision-plus",
@@ -3802,6 +5206,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "tongyi-intent-detect-v3",
@@ -3812,6 +5220,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "tongyi-xiaomi-analysis-flash",
@@ -3822,6 +5234,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "tongyi-xiaomi-analysis-pro",
@@ -3832,6 +5248,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "video-style-transform",
@@ -3844,6 +5264,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "videoretalk",
@@ -3856,6 +5280,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "virtualmodel-v2",
@@ -3868,6 +5296,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.1-i2v-plus",
@@ -3880,6 +5312,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.1-i2v-turbo",
@@ -3892,6 +5328,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.1-kf2v-plus",
@@ -3904,6 +5344,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.1-t2i-plus",
@@ -3916,6 +5360,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.1-t2i-turbo",
@@ -3928,6 +5376,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.1-t2v-plus",
@@ -3940,6 +5392,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.1-t2v-turbo",
@@ -3952,6 +5408,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.1-vace-plus",
@@ -3964,6 +5424,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-animate-mix",
@@ -3976,6 +5440,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-animate-move",
@@ -3988,6 +5456,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-i2v-flash",
@@ -4000,6 +5472,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-i2v-plus",
@@ -4012,6 +5488,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-kf2v-flash",
@@ -4024,6 +5504,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-s2v",
@@ -4036,6 +5520,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-s2v-detect",
@@ -4048,6 +5536,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-t2i-flash",
@@ -4060,6 +5552,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-t2i-plus",
@@ -4072,6 +5568,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.2-t2v-plus",
@@ -4084,6 +5584,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.5-i2i-preview",
@@ -4096,6 +5600,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.5-i2v-preview",
@@ -4108,6 +5616,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.5-t2i-preview",
@@ -4120,6 +5632,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.5-t2v-preview",
@@ -4132,6 +5648,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.6-i2v",
@@ -4144,6 +5664,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.6-i2v-flash",
@@ -4156,6 +5680,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.6-i2v-us",
@@ -4168,6 +5696,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.6-image",
@@ -4180,6 +5712,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.6-r2v",
@@ -4192,6 +5728,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.6-r2v-flash",
@@ -4204,6 +5744,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.6-t2i",
@@ -4216,6 +5760,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.6-t2v",
@@ -4228,6 +5776,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wan2.6-t2v-us",
@@ -4240,6 +5792,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx-background-generation-v2",
@@ -4252,6 +5808,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx-poster-generation-v1",
@@ -4264,6 +5824,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx-sketch-to-image-lite",
@@ -4276,6 +5840,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx-style-repaint-v1",
@@ -4288,6 +5856,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx-v1",
@@ -4300,6 +5872,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx-virtualmodel",
@@ -4312,6 +5888,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx-x-painting",
@@ -4324,6 +5904,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.0-t2i-turbo",
@@ -4336,6 +5920,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.1-i2v-plus",
@@ -4348,6 +5936,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.1-i2v-turbo",
@@ -4360,6 +5952,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.1-imageedit",
@@ -4372,6 +5968,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.1-kf2v-plus",
@@ -4384,6 +5984,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.1-t2i-plus",
@@ -4396,6 +6000,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.1-t2i-turbo",
@@ -4408,6 +6016,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.1-t2v-plus",
@@ -4420,6 +6032,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.1-t2v-turbo",
@@ -4432,6 +6048,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wanx2.1-vace-plus",
@@ -4444,6 +6064,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wordart-semantic",
@@ -4456,6 +6080,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "wordart-texture",
@@ -4468,6 +6096,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "z-image-turbo",
@@ -4480,6 +6112,10 @@ pub(crate) const BAILIAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
 ];
 
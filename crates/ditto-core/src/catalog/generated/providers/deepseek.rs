@@ -35,6 +35,10 @@ pub(crate) const DEEPSEEK_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::LLM),
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
         ],
+        context_window: Some(128000),
+        max_output_tokens: Some(8000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek-reasoner",
@@ -50,6 +54,10 @@ pub(crate) const DEEPSEEK_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::LLM),
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
         ],
+        context_window: Some(128000),
+        max_output_tokens: Some(64000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
 ];
 
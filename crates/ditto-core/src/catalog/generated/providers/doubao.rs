@@ -35,6 +35,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3-1-250821",
@@ -54,6 +58,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3-1-terminus",
@@ -73,6 +81,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3-2-251201",
@@ -92,6 +104,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3-250324",
@@ -111,6 +127,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-lite-32k-250115",
@@ -130,6 +150,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-pro-32k-250115",
@@ -149,6 +173,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-pro-32k-character-250228",
@@ -168,6 +196,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-pro-32k-character-250715",
@@ -187,6 +219,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-thinking-pro-250415",
@@ -197,6 +233,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::RESPONSE, OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-thinking-pro-m-250428",
@@ -207,6 +247,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::RESPONSE, OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-thinking-vision-pro-250428",
@@ -217,6 +261,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::RESPONSE, OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-ui-tars-250428",
@@ -227,6 +275,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::RESPONSE, OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-vision-lite-250315",
@@ -237,6 +289,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::RESPONSE, OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-vision-pro-250328",
@@ -247,6 +303,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::RESPONSE, OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-1-5-vision-pro-32k-250115",
@@ -257,6 +317,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::RESPONSE, OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-embedding-large-text-240915",
@@ -269,6 +333,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-embedding-large-text-250515",
@@ -281,6 +349,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-embedding-text-240715",
@@ -293,6 +365,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-embedding-vision-250328",
@@ -305,6 +381,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-embedding-vision-250615",
@@ -317,6 +397,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-embedding-vision-251215",
@@ -329,6 +413,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-lite-32k-character-250228",
@@ -339,6 +427,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::RESPONSE, OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-6-250615",
@@ -358,6 +450,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-6-251015",
@@ -377,6 +473,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-6-flash-250615",
@@ -396,6 +496,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-6-flash-250715",
@@ -415,6 +519,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-6-flash-250828",
@@ -434,6 +542,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-6-lite-251015",
@@ -453,6 +565,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-6-thinking-250615",
@@ -472,6 +588,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-6-thinking-250715",
@@ -491,6 +611,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-6-vision-250815",
@@ -510,6 +634,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-1-8-251228",
@@ -529,6 +657,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-2-0-code-preview-260215",
@@ -548,6 +680,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-2-0-lite-260215",
@@ -567,6 +703,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-2-0-mini-260215",
@@ -586,6 +726,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-2-0-pro-260215",
@@ -605,6 +749,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-code-preview-251028",
@@ -624,6 +772,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed-translation-250915",
@@ -634,6 +786,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::RESPONSE, OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seed3d-1-0-250928",
@@ -646,6 +802,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::THREE_D_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedance-1-0-lite-i2v-250428",
@@ -658,6 +818,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedance-1-0-lite-t2v-250428",
@@ -670,6 +834,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedance-1-0-pro-250528",
@@ -682,6 +850,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedance-1-0-pro-fast-251015",
@@ -694,6 +866,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedance-1-5-pro-251215",
@@ -706,6 +882,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seededit-3-0-i2i-250628",
@@ -718,6 +898,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedream-3-0-t2i-250415",
@@ -730,6 +914,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedream-4-0-250828",
@@ -742,6 +930,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedream-4-5-251128",
@@ -754,6 +946,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedream-5-0-260128",
@@ -766,6 +962,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "doubao-seedream-5-0-lite-260128",
@@ -778,6 +978,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-4-7-251222",
@@ -797,6 +1001,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "kimi-k2-250905",
@@ -816,6 +1024,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "kimi-k2-thinking-251104",
@@ -835,6 +1047,10 @@ pub(crate) const DOUBAO_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::BATCH),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
 ];
 
@@ -28,6 +28,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-2.5-flash",
@@ -40,6 +44,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-2.5-flash-exp-native-audio-thinking-dialog",
@@ -54,6 +62,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-2.5-flash-image",
@@ -66,6 +78,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-2.5-flash-lite-preview-06-17",
@@ -78,6 +94,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-2.5-flash-live",
@@ -92,6 +112,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-2.5-flash-preview-native-audio-dialog",
@@ -104,6 +128,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-2.5-flash-preview-tts",
@@ -114,6 +142,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Faster text-to-speech preview for low-latency speech generation."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-2.5-pro",
@@ -126,6 +158,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-2.5-pro-preview-tts",
@@ -138,6 +174,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-3.1-flash",
@@ -150,6 +190,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-3.1-pro",
@@ -162,6 +206,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-embedding",
@@ -176,6 +224,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-embedding-exp",
@@ -190,6 +242,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemini-robotics-er",
@@ -202,6 +258,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gemma-3",
@@ -214,6 +274,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "imagen-4",
@@ -228,6 +292,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "lyria",
@@ -240,6 +308,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "medgemma",
@@ -252,6 +324,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "signgemma",
@@ -264,6 +340,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "veo-2",
@@ -276,6 +356,10 @@ pub(crate) const GOOGLE_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
 ];
 
@@ -28,6 +28,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-2.0-thinking-20251109",
@@ -40,6 +44,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(64000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-a13b",
@@ -52,6 +60,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(224000),
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-code",
@@ -64,6 +76,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::THREAD_RUN],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-embedding",
@@ -78,6 +94,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-functioncall",
@@ -90,6 +110,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::THREAD_RUN],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-image",
@@ -104,6 +128,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-image-chat",
@@ -116,6 +144,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-image-lite",
@@ -128,6 +160,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-large-role-group",
@@ -138,6 +174,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("群聊角色扮演模型，来自腾讯云 GroupChatCompletions 接口的官方枚举值。"),
         supported_operations: &[OperationKind::GROUP_CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-large-role-latest",
@@ -150,6 +190,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(28000),
+        max_output_tokens: Some(4000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-lite",
@@ -162,6 +206,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(250000),
+        max_output_tokens: Some(6000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-pro",
@@ -174,6 +222,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::THREAD_RUN],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-role",
@@ -186,6 +238,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::THREAD_RUN],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-standard",
@@ -198,6 +254,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::THREAD_RUN],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-standard-256K",
@@ -210,6 +270,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::THREAD_RUN],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-t1-latest",
@@ -222,6 +286,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(64000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-t1-vision-20250916",
@@ -234,6 +302,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(28000),
+        max_output_tokens: Some(20000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-translation",
@@ -246,6 +318,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_TRANSLATION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(4000),
+        max_output_tokens: Some(4000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-translation-lite",
@@ -258,6 +334,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_TRANSLATION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(4000),
+        max_output_tokens: Some(4000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-turbo",
@@ -270,6 +350,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::THREAD_RUN],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-turbos",
@@ -282,6 +366,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-turbos-latest",
@@ -294,6 +382,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(16000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-turbos-vision-video",
@@ -306,6 +398,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(24000),
+        max_output_tokens: Some(8000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-vision",
@@ -318,6 +414,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::THREAD_RUN],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-vision-1.5-instruct",
@@ -330,6 +430,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(24000),
+        max_output_tokens: Some(16000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "hunyuan-vision-image-question",
@@ -342,6 +446,10 @@ pub(crate) const HUNYUAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_QUESTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
 ];
 
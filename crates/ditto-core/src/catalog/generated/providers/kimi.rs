@@ -28,6 +28,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "kimi-k2-0905-preview",
@@ -40,6 +44,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "kimi-k2-thinking",
@@ -52,6 +60,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "kimi-k2-thinking-turbo",
@@ -64,6 +76,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "kimi-k2-turbo-preview",
@@ -76,6 +92,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "kimi-k2.5",
@@ -88,6 +108,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text", "image", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshot-v1-128k",
@@ -98,6 +122,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Text generation model for very long-context workloads."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshot-v1-128k-vision-preview",
@@ -110,6 +138,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshot-v1-32k",
@@ -120,6 +152,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Text generation model for longer text workloads."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshot-v1-32k-vision-preview",
@@ -132,6 +168,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshot-v1-8k",
@@ -142,6 +182,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Text generation model for short prompts and responses."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshot-v1-8k-vision-preview",
@@ -154,6 +198,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshot-v1-auto",
@@ -166,6 +214,10 @@ pub(crate) const KIMI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
 ];
 
@@ -26,6 +26,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("文本对话模型，专为角色扮演、多轮对话等场景设计"),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax-Hailuo-02",
@@ -38,6 +42,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax-Hailuo-2.3",
@@ -50,6 +58,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax-Hailuo-2.3-Fast",
@@ -62,6 +74,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax-M2",
@@ -75,6 +91,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::LLM),
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
         ],
+        context_window: Some(204800),
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax-M2.1",
@@ -88,6 +108,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::LLM),
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
         ],
+        context_window: Some(204800),
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax-M2.1-highspeed",
@@ -101,6 +125,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::LLM),
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
         ],
+        context_window: Some(204800),
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax-M2.5",
@@ -114,6 +142,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::LLM),
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
         ],
+        context_window: Some(204800),
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MiniMax-M2.5-highspeed",
@@ -127,6 +159,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::LLM),
             CapabilityStatusDescriptor::implemented(CapabilityKind::CONTEXT_CACHE),
         ],
+        context_window: Some(204800),
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "image-01",
@@ -139,6 +175,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "image-01-live",
@@ -151,6 +191,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "music-2.0",
@@ -163,6 +207,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::MUSIC_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "music-2.5",
@@ -175,6 +223,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::MUSIC_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "music-2.5+",
@@ -187,6 +239,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::MUSIC_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "speech-02-hd",
@@ -205,6 +261,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_CLONE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_DESIGN),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "speech-02-turbo",
@@ -223,6 +283,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_CLONE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_DESIGN),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "speech-2.6-hd",
@@ -241,6 +305,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_CLONE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_DESIGN),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "speech-2.6-turbo",
@@ -259,6 +327,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_CLONE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_DESIGN),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "speech-2.8-hd",
@@ -277,6 +349,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_CLONE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_DESIGN),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "speech-2.8-turbo",
@@ -295,6 +371,10 @@ pub(crate) const MINIMAX_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_CLONE),
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_DESIGN),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
 ];
 
@@ -21,6 +21,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::TEXT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "chatgpt-4o-latest",
@@ -33,6 +37,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "chatgpt-image-latest",
@@ -46,6 +54,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_GENERATION),
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_EDIT),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text", "image"],
     },
     ProviderModelDescriptor {
         id: "codex-mini-latest",
@@ -58,6 +70,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "computer-use-preview",
@@ -70,6 +86,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: Some(1024),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "dall-e-2",
@@ -84,6 +104,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["image"],
     },
     ProviderModelDescriptor {
         id: "dall-e-3",
@@ -98,6 +122,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["image"],
     },
     ProviderModelDescriptor {
         id: "davinci-002",
@@ -110,6 +138,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::TEXT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-3.5-turbo",
@@ -122,6 +154,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(16385),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4",
@@ -134,6 +170,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4-turbo",
@@ -146,6 +186,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4-turbo-preview",
@@ -158,6 +202,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4.1",
@@ -170,6 +218,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1047576),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4.1-mini",
@@ -182,6 +234,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1047576),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4.1-nano",
@@ -194,6 +250,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1047576),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4.5-preview",
@@ -206,6 +266,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o",
@@ -218,6 +282,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-audio-preview",
@@ -230,6 +298,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-mini",
@@ -242,6 +314,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-mini-audio-preview",
@@ -254,6 +330,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-mini-realtime-preview",
@@ -268,6 +348,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: Some(16000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-mini-search-preview",
@@ -280,6 +364,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-mini-transcribe",
@@ -294,6 +382,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: Some(16000),
+        max_output_tokens: Some(2000),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-mini-tts",
@@ -308,6 +400,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-realtime-preview",
@@ -322,6 +418,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: Some(32000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-search-preview",
@@ -334,6 +434,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-transcribe",
@@ -348,6 +452,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: Some(16000),
+        max_output_tokens: Some(2000),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-4o-transcribe-diarize",
@@ -362,6 +470,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: Some(16000),
+        max_output_tokens: Some(2000),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5",
@@ -374,6 +486,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5-chat-latest",
@@ -386,6 +502,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5-codex",
@@ -398,6 +518,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5-mini",
@@ -410,6 +534,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5-nano",
@@ -422,6 +550,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5-pro",
@@ -434,6 +566,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(272000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.1",
@@ -446,6 +582,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.1-chat-latest",
@@ -458,6 +598,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.1-codex",
@@ -470,6 +614,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.1-codex-max",
@@ -482,6 +630,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.1-codex-mini",
@@ -494,6 +646,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.2",
@@ -506,6 +662,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.2-chat-latest",
@@ -518,6 +678,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.2-codex",
@@ -530,6 +694,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.2-pro",
@@ -542,6 +710,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.3-chat-latest",
@@ -554,6 +726,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.3-codex",
@@ -566,6 +742,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.4",
@@ -578,6 +758,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1050000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-5.4-pro",
@@ -590,6 +774,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1050000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-audio",
@@ -602,6 +790,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-audio-1.5",
@@ -614,6 +806,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-audio-mini",
@@ -626,6 +822,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-image-1",
@@ -641,6 +841,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_GENERATION),
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_EDIT),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["image"],
     },
     ProviderModelDescriptor {
         id: "gpt-image-1-mini",
@@ -656,6 +860,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_GENERATION),
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_EDIT),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["image"],
     },
     ProviderModelDescriptor {
         id: "gpt-image-1.5",
@@ -671,6 +879,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_GENERATION),
             CapabilityStatusDescriptor::implemented(CapabilityKind::IMAGE_EDIT),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text", "image"],
     },
     ProviderModelDescriptor {
         id: "gpt-oss-120b",
@@ -683,6 +895,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-oss-20b",
@@ -695,6 +911,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gpt-realtime",
@@ -709,6 +929,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: Some(32000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text", "image", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-realtime-1.5",
@@ -723,6 +947,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: Some(32000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text", "image", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "gpt-realtime-mini",
@@ -737,6 +965,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: Some(32000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text", "image", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "o1",
@@ -749,6 +981,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "o1-mini",
@@ -761,6 +997,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "o1-preview",
@@ -773,6 +1013,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "o1-pro",
@@ -785,6 +1029,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "o3",
@@ -797,6 +1045,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "o3-deep-research",
@@ -809,6 +1061,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "o3-mini",
@@ -821,6 +1077,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "o3-pro",
@@ -833,6 +1093,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "o4-mini",
@@ -845,6 +1109,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "o4-mini-deep-research",
@@ -857,6 +1125,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "omni-moderation-latest",
@@ -871,6 +1143,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::MODERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "sora-2",
@@ -885,6 +1161,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["audio", "video"],
     },
     ProviderModelDescriptor {
         id: "sora-2-pro",
@@ -899,6 +1179,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["audio", "video"],
     },
     ProviderModelDescriptor {
         id: "text-embedding-3-large",
@@ -913,6 +1197,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "text-embedding-3-small",
@@ -927,6 +1215,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "text-embedding-ada-002",
@@ -941,6 +1233,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "text-moderation-latest",
@@ -955,6 +1251,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::MODERATION,
         )],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "text-moderation-stable",
@@ -969,6 +1269,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::MODERATION,
         )],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "tts-1",
@@ -983,6 +1287,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["audio"],
     },
     ProviderModelDescriptor {
         id: "tts-1-hd",
@@ -997,6 +1305,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["audio"],
     },
     ProviderModelDescriptor {
         id: "whisper-1",
@@ -1011,6 +1323,10 @@ pub(crate) const OPENAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["audio"],
+        output_modalities: &["text"],
     },
 ];
 
@@ -28,6 +28,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "aion-labs/aion-1.0",
@@ -40,6 +44,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "aion-labs/aion-2.0",
@@ -52,6 +60,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "aion-labs/aion-rp-llama-3.1-8b",
@@ -64,6 +76,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "alibaba/tongyi-deepresearch-30b-a3b",
@@ -76,6 +92,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "allenai/molmo-2-8b",
@@ -88,6 +108,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(36864),
+        max_output_tokens: Some(36864),
+        input_modalities: &["text", "image", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "allenai/olmo-3-7b-instruct",
@@ -100,6 +124,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "allenai/olmo-3-7b-think",
@@ -112,6 +140,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "allenai/olmo-3.1-32b-instruct",
@@ -124,6 +156,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "allenai/olmo-3.1-32b-think",
@@ -136,6 +172,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "alpindale/goliath-120b",
@@ -148,6 +188,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(6144),
+        max_output_tokens: Some(1024),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "amazon/nova-2-lite-v1",
@@ -160,6 +204,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(65535),
+        input_modalities: &["text", "image", "video", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "amazon/nova-lite-v1",
@@ -172,6 +220,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(300000),
+        max_output_tokens: Some(5120),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "amazon/nova-micro-v1",
@@ -184,6 +236,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(5120),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "amazon/nova-premier-v1",
@@ -196,6 +252,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(32000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "amazon/nova-pro-v1",
@@ -208,6 +268,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(300000),
+        max_output_tokens: Some(5120),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthracite-org/magnum-v4-72b",
@@ -220,6 +284,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(16384),
+        max_output_tokens: Some(2048),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-3-haiku",
@@ -232,6 +300,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-3.5-haiku",
@@ -244,6 +316,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-3.5-sonnet",
@@ -256,6 +332,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-3.7-sonnet:thinking",
@@ -268,6 +348,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(64000),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-haiku-4.5",
@@ -280,6 +364,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(64000),
+        input_modalities: &["image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-opus-4",
@@ -292,6 +380,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(32000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-opus-4.1",
@@ -304,6 +396,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(32000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-opus-4.5",
@@ -316,6 +412,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(64000),
+        input_modalities: &["file", "image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-opus-4.6",
@@ -328,6 +428,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-sonnet-4",
@@ -340,6 +444,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(64000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-sonnet-4.5",
@@ -352,6 +460,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(64000),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "anthropic/claude-sonnet-4.6",
@@ -364,6 +476,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "arcee-ai/trinity-large-preview:free",
@@ -376,6 +492,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "arcee-ai/trinity-mini",
@@ -388,6 +508,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "baidu/ernie-4.5-21b-a3b",
@@ -400,6 +524,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(120000),
+        max_output_tokens: Some(8000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "baidu/ernie-4.5-21b-a3b-thinking",
@@ -412,6 +540,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "baidu/ernie-4.5-300b-a47b",
@@ -424,6 +556,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(123000),
+        max_output_tokens: Some(12000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "baidu/ernie-4.5-vl-28b-a3b",
@@ -436,6 +572,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(30000),
+        max_output_tokens: Some(8000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "baidu/ernie-4.5-vl-424b-a47b",
@@ -448,6 +588,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(123000),
+        max_output_tokens: Some(16000),
+        input_modalities: &["image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "bytedance-seed/seed-1.6",
@@ -460,6 +604,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(32768),
+        input_modalities: &["image", "text", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "bytedance-seed/seed-1.6-flash",
@@ -472,6 +620,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(32768),
+        input_modalities: &["image", "text", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "bytedance-seed/seed-2.0-mini",
@@ -484,6 +636,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text", "image", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "bytedance/ui-tars-1.5-7b",
@@ -496,6 +652,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(2048),
+        input_modalities: &["image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "cognitivecomputations/dolphin-mistral-24b-venice-edition:free",
@@ -508,6 +668,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "cohere/command-a",
@@ -520,6 +684,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "cohere/command-r-08-2024",
@@ -532,6 +700,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "cohere/command-r-plus-08-2024",
@@ -544,6 +716,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "cohere/command-r7b-12-2024",
@@ -556,6 +732,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepcogito/cogito-v2.1-671b",
@@ -568,6 +748,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-chat",
@@ -580,6 +764,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(163840),
+        max_output_tokens: Some(163840),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-chat-v3-0324",
@@ -592,6 +780,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(163840),
+        max_output_tokens: Some(163840),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-chat-v3.1",
@@ -604,6 +796,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(7168),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-r1",
@@ -616,6 +812,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(64000),
+        max_output_tokens: Some(16000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-r1-0528",
@@ -628,6 +828,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(163840),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-r1-distill-llama-70b",
@@ -640,6 +844,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-r1-distill-qwen-32b",
@@ -652,6 +860,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-v3.1-terminus",
@@ -664,6 +876,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(163840),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-v3.2",
@@ -676,6 +892,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(163840),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-v3.2-exp",
@@ -688,6 +908,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(163840),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "deepseek/deepseek-v3.2-speciale",
@@ -700,6 +924,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(163840),
+        max_output_tokens: Some(163840),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "essentialai/rnj-1-instruct",
@@ -712,6 +940,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-2.0-flash-001",
@@ -724,6 +956,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text", "image", "file", "audio", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-2.0-flash-lite-001",
@@ -736,6 +972,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text", "image", "file", "audio", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-2.5-flash",
@@ -748,6 +988,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(65535),
+        input_modalities: &["file", "image", "text", "audio", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-2.5-flash-image",
@@ -760,6 +1004,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["image", "text"],
+        output_modalities: &["image", "text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-2.5-flash-lite",
@@ -772,6 +1020,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(65535),
+        input_modalities: &["text", "image", "file", "audio", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-2.5-flash-lite-preview-09-2025",
@@ -784,6 +1036,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "file", "audio", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-2.5-pro",
@@ -796,6 +1052,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "file", "audio", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-3-flash-preview",
@@ -808,6 +1068,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "file", "audio", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-3-pro-image-preview",
@@ -820,6 +1084,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: Some(32768),
+        input_modalities: &["image", "text"],
+        output_modalities: &["image", "text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-3-pro-preview",
@@ -832,6 +1100,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "file", "audio", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-3.1-flash-image-preview",
@@ -844,6 +1116,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: Some(65536),
+        input_modalities: &["image", "text"],
+        output_modalities: &["image", "text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-3.1-flash-lite-preview",
@@ -856,6 +1132,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "video", "file", "audio"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-3.1-pro-preview",
@@ -868,6 +1148,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(65536),
+        input_modalities: &["audio", "file", "image", "text", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemini-3.1-pro-preview-customtools",
@@ -880,6 +1164,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "audio", "image", "video", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemma-2-27b-it",
@@ -892,6 +1180,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: Some(2048),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemma-2-9b-it",
@@ -904,6 +1196,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemma-3-12b-it",
@@ -916,6 +1212,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemma-3-27b-it",
@@ -928,6 +1228,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemma-3-4b-it",
@@ -940,6 +1244,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemma-3n-e2b-it:free",
@@ -952,6 +1260,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: Some(2048),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "google/gemma-3n-e4b-it",
@@ -964,6 +1276,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "gryphe/mythomax-l2-13b",
@@ -976,6 +1292,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(4096),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "ibm-granite/granite-4.0-h-micro",
@@ -988,6 +1308,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "inception/mercury",
@@ -1000,6 +1324,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(32000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "inception/mercury-2",
@@ -1012,6 +1340,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(50000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "inception/mercury-coder",
@@ -1024,6 +1356,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(32000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "inflection/inflection-3-pi",
@@ -1036,6 +1372,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(1024),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "inflection/inflection-3-productivity",
@@ -1048,6 +1388,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(1024),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "kwaipilot/kat-coder-pro",
@@ -1060,6 +1404,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "liquid/lfm-2-24b-a2b",
@@ -1072,6 +1420,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "liquid/lfm-2.2-6b",
@@ -1084,6 +1436,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "liquid/lfm-2.5-1.2b-instruct:free",
@@ -1096,6 +1452,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "liquid/lfm-2.5-1.2b-thinking:free",
@@ -1108,6 +1468,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "liquid/lfm2-8b-a1b",
@@ -1120,6 +1484,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mancer/weaver",
@@ -1132,6 +1500,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(2000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meituan/longcat-flash-chat",
@@ -1144,6 +1516,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3-70b-instruct",
@@ -1156,6 +1532,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: Some(8000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3-8b-instruct",
@@ -1168,6 +1548,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3.1-405b",
@@ -1180,6 +1564,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3.1-405b-instruct",
@@ -1192,6 +1580,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3.1-70b-instruct",
@@ -1204,6 +1596,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3.1-8b-instruct",
@@ -1216,6 +1612,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(16384),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3.2-11b-vision-instruct",
@@ -1228,6 +1628,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3.2-1b-instruct",
@@ -1240,6 +1644,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(60000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3.2-3b-instruct",
@@ -1252,6 +1660,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(80000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-3.3-70b-instruct",
@@ -1264,6 +1676,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-4-maverick",
@@ -1276,6 +1692,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1048576),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-4-scout",
@@ -1288,6 +1708,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(327680),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-guard-2-8b",
@@ -1300,6 +1724,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-guard-3-8b",
@@ -1312,6 +1740,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "meta-llama/llama-guard-4-12b",
@@ -1324,6 +1756,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(163840),
+        max_output_tokens: None,
+        input_modalities: &["image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "microsoft/phi-4",
@@ -1336,6 +1772,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(16384),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "microsoft/wizardlm-2-8x22b",
@@ -1348,6 +1788,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65535),
+        max_output_tokens: Some(8000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "minimax/minimax-01",
@@ -1360,6 +1804,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000192),
+        max_output_tokens: Some(1000192),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "minimax/minimax-m1",
@@ -1372,6 +1820,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(40000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "minimax/minimax-m2",
@@ -1384,6 +1836,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(196608),
+        max_output_tokens: Some(196608),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "minimax/minimax-m2-her",
@@ -1396,6 +1852,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: Some(2048),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "minimax/minimax-m2.1",
@@ -1408,6 +1868,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(196608),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "minimax/minimax-m2.5",
@@ -1420,6 +1884,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(196608),
+        max_output_tokens: Some(196608),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/codestral-2508",
@@ -1432,6 +1900,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/devstral-2512",
@@ -1444,6 +1916,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/devstral-medium",
@@ -1456,6 +1932,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/devstral-small",
@@ -1468,6 +1948,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/ministral-14b-2512",
@@ -1480,6 +1964,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/ministral-3b-2512",
@@ -1492,6 +1980,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/ministral-8b-2512",
@@ -1504,6 +1996,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-7b-instruct-v0.1",
@@ -1516,6 +2012,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(2824),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-large",
@@ -1528,6 +2028,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-large-2407",
@@ -1540,6 +2044,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-large-2411",
@@ -1552,6 +2060,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-large-2512",
@@ -1564,6 +2076,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-medium-3",
@@ -1576,6 +2092,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-medium-3.1",
@@ -1588,6 +2108,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-nemo",
@@ -1600,6 +2124,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-saba",
@@ -1612,6 +2140,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-small-24b-instruct-2501",
@@ -1624,6 +2156,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-small-3.1-24b-instruct",
@@ -1636,6 +2172,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-small-3.2-24b-instruct",
@@ -1648,6 +2188,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mistral-small-creative",
@@ -1660,6 +2204,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mixtral-8x22b-instruct",
@@ -1672,6 +2220,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/mixtral-8x7b-instruct",
@@ -1684,6 +2236,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/pixtral-large-2411",
@@ -1696,6 +2252,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "mistralai/voxtral-small-24b-2507",
@@ -1708,6 +2268,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: None,
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshotai/kimi-k2",
@@ -1720,6 +2284,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshotai/kimi-k2-0905",
@@ -1732,6 +2300,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshotai/kimi-k2-thinking",
@@ -1744,6 +2316,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "moonshotai/kimi-k2.5",
@@ -1756,6 +2332,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(65535),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "morph/morph-v3-fast",
@@ -1768,6 +2348,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(81920),
+        max_output_tokens: Some(38000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "morph/morph-v3-large",
@@ -1780,6 +2364,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "neversleep/llama-3.1-lumimaid-8b",
@@ -1792,6 +2380,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "neversleep/noromaid-20b",
@@ -1804,6 +2396,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(4096),
+        max_output_tokens: Some(2048),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nex-agi/deepseek-v3.1-nex-n1",
@@ -1816,6 +2412,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(163840),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nousresearch/hermes-2-pro-llama-3-8b",
@@ -1828,6 +2428,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nousresearch/hermes-3-llama-3.1-405b",
@@ -1840,6 +2444,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nousresearch/hermes-3-llama-3.1-70b",
@@ -1852,6 +2460,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nousresearch/hermes-4-405b",
@@ -1864,6 +2476,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nousresearch/hermes-4-70b",
@@ -1876,6 +2492,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nvidia/llama-3.1-nemotron-70b-instruct",
@@ -1888,6 +2508,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nvidia/llama-3.3-nemotron-super-49b-v1.5",
@@ -1900,6 +2524,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nvidia/nemotron-3-nano-30b-a3b",
@@ -1912,6 +2540,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nvidia/nemotron-nano-12b-v2-vl",
@@ -1924,6 +2556,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["image", "text", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "nvidia/nemotron-nano-9b-v2",
@@ -1936,6 +2572,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-3.5-turbo",
@@ -1948,6 +2588,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(16385),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-3.5-turbo-0613",
@@ -1960,6 +2604,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(4095),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-3.5-turbo-16k",
@@ -1972,6 +2620,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(16385),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-3.5-turbo-instruct",
@@ -1984,6 +2636,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(4095),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4",
@@ -1996,6 +2652,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8191),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4-1106-preview",
@@ -2008,6 +2668,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4-turbo",
@@ -2020,6 +2684,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4-turbo-preview",
@@ -2032,6 +2700,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4.1",
@@ -2044,6 +2716,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1047576),
+        max_output_tokens: Some(32768),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4.1-mini",
@@ -2056,6 +2732,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1047576),
+        max_output_tokens: Some(32768),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4.1-nano",
@@ -2068,6 +2748,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1047576),
+        max_output_tokens: Some(32768),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4o",
@@ -2080,6 +2764,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4o-2024-05-13",
@@ -2092,6 +2780,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4o-2024-08-06",
@@ -2104,6 +2796,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4o-2024-11-20",
@@ -2116,6 +2812,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4o-audio-preview",
@@ -2128,6 +2828,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["audio", "text"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4o-mini",
@@ -2140,6 +2844,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4o-mini-2024-07-18",
@@ -2152,6 +2860,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4o-mini-search-preview",
@@ -2164,6 +2876,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-4o-search-preview",
@@ -2176,6 +2892,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5",
@@ -2188,6 +2908,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5-chat",
@@ -2200,6 +2924,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["file", "image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5-codex",
@@ -2212,6 +2940,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5-image",
@@ -2224,6 +2956,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["image", "text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5-image-mini",
@@ -2236,6 +2972,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["file", "image", "text"],
+        output_modalities: &["image", "text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5-mini",
@@ -2248,6 +2988,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5-nano",
@@ -2260,6 +3004,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5-pro",
@@ -2272,6 +3020,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.1",
@@ -2284,6 +3036,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.1-chat",
@@ -2296,6 +3052,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["file", "image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.1-codex",
@@ -2308,6 +3068,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.1-codex-max",
@@ -2320,6 +3084,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.1-codex-mini",
@@ -2330,6 +3098,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("GPT-5.1-Codex-Mini is a smaller and faster version of GPT-5.1-Codex"),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.2",
@@ -2342,6 +3114,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["file", "image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.2-chat",
@@ -2354,6 +3130,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["file", "image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.2-codex",
@@ -2366,6 +3146,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.2-pro",
@@ -2378,6 +3162,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.3-chat",
@@ -2390,6 +3178,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.3-codex",
@@ -2402,6 +3194,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(400000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.4",
@@ -2414,6 +3210,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1050000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-5.4-pro",
@@ -2426,6 +3226,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1050000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-audio",
@@ -2438,6 +3242,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-audio-mini",
@@ -2450,6 +3258,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "audio"],
+        output_modalities: &["text", "audio"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-oss-120b",
@@ -2462,6 +3274,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-oss-20b",
@@ -2474,6 +3290,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/gpt-oss-safeguard-20b",
@@ -2486,6 +3306,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/o1",
@@ -2498,6 +3322,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "image", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/o3",
@@ -2510,6 +3338,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/o3-mini",
@@ -2522,6 +3354,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/o3-mini-high",
@@ -2534,6 +3370,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/o3-pro",
@@ -2546,6 +3386,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["text", "file", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/o4-mini",
@@ -2558,6 +3402,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/o4-mini-deep-research",
@@ -2570,6 +3418,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["file", "image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "openai/o4-mini-high",
@@ -2582,6 +3434,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(100000),
+        input_modalities: &["image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "perplexity/sonar",
@@ -2594,6 +3450,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(127072),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "perplexity/sonar-deep-research",
@@ -2606,6 +3466,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "perplexity/sonar-pro",
@@ -2618,6 +3482,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(8000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "perplexity/sonar-pro-search",
@@ -2630,6 +3498,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(8000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "perplexity/sonar-reasoning-pro",
@@ -2642,6 +3514,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "prime-intellect/intellect-3",
@@ -2654,6 +3530,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-2.5-72b-instruct",
@@ -2666,6 +3546,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-2.5-7b-instruct",
@@ -2678,6 +3562,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-2.5-coder-32b-instruct",
@@ -2690,6 +3578,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-2.5-vl-7b-instruct",
@@ -2702,6 +3594,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-max",
@@ -2714,6 +3610,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-plus",
@@ -2726,6 +3626,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-plus-2025-07-28",
@@ -2738,6 +3642,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-turbo",
@@ -2750,6 +3658,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-vl-max",
@@ -2762,6 +3674,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen-vl-plus",
@@ -2774,6 +3690,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen2.5-coder-7b-instruct",
@@ -2786,6 +3706,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen2.5-vl-32b-instruct",
@@ -2798,6 +3722,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen2.5-vl-72b-instruct",
@@ -2810,6 +3738,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-14b",
@@ -2822,6 +3754,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(40960),
+        max_output_tokens: Some(40960),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-235b-a22b",
@@ -2834,6 +3770,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-235b-a22b-2507",
@@ -2846,6 +3786,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-235b-a22b-thinking-2507",
@@ -2858,6 +3802,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(262144),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-30b-a3b",
@@ -2870,6 +3818,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(40960),
+        max_output_tokens: Some(40960),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-30b-a3b-instruct-2507",
@@ -2882,6 +3834,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(262144),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-30b-a3b-thinking-2507",
@@ -2894,6 +3850,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-32b",
@@ -2906,6 +3866,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(40960),
+        max_output_tokens: Some(40960),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-4b:free",
@@ -2918,6 +3882,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(40960),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-8b",
@@ -2930,6 +3898,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(40960),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-coder-30b-a3b-instruct",
@@ -2942,6 +3914,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(160000),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-coder-flash",
@@ -2954,6 +3930,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-coder-next",
@@ -2966,6 +3946,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-coder-plus",
@@ -2978,6 +3962,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-coder:exacto",
@@ -2990,6 +3978,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-max",
@@ -3002,6 +3994,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-max-thinking",
@@ -3014,6 +4010,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-next-80b-a3b-instruct",
@@ -3026,6 +4026,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-next-80b-a3b-thinking",
@@ -3038,6 +4042,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-vl-235b-a22b-instruct",
@@ -3050,6 +4058,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-vl-235b-a22b-thinking",
@@ -3062,6 +4074,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-vl-30b-a3b-instruct",
@@ -3074,6 +4090,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-vl-30b-a3b-thinking",
@@ -3086,6 +4106,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-vl-32b-instruct",
@@ -3098,6 +4122,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-vl-8b-instruct",
@@ -3110,6 +4138,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(32768),
+        input_modalities: &["image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3-vl-8b-thinking",
@@ -3122,6 +4154,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(32768),
+        input_modalities: &["image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3.5-122b-a10b",
@@ -3134,6 +4170,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3.5-27b",
@@ -3146,6 +4186,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3.5-35b-a3b",
@@ -3158,6 +4202,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3.5-397b-a17b",
@@ -3170,6 +4218,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3.5-flash-02-23",
@@ -3182,6 +4234,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwen3.5-plus-02-15",
@@ -3194,6 +4250,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text", "image", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "qwen/qwq-32b",
@@ -3206,6 +4266,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "raifle/sorcererlm-8x22b",
@@ -3218,6 +4282,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(16000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "relace/relace-apply-3",
@@ -3230,6 +4298,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "relace/relace-search",
@@ -3242,6 +4314,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "sao10k/l3-euryale-70b",
@@ -3254,6 +4330,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "sao10k/l3-lunaris-8b",
@@ -3266,6 +4346,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8192),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "sao10k/l3.1-euryale-70b",
@@ -3278,6 +4362,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "sao10k/l3.3-euryale-70b",
@@ -3290,6 +4378,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "stepfun/step-3.5-flash",
@@ -3302,6 +4394,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: Some(256000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "switchpoint/router",
@@ -3314,6 +4410,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "tencent/hunyuan-a13b-instruct",
@@ -3326,6 +4426,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "thedrummer/cydonia-24b-v4.1",
@@ -3338,6 +4442,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "thedrummer/rocinante-12b",
@@ -3350,6 +4458,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "thedrummer/skyfall-36b-v2",
@@ -3362,6 +4474,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "thedrummer/unslopnemo-12b",
@@ -3374,6 +4490,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32768),
+        max_output_tokens: Some(32768),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "tngtech/deepseek-r1t2-chimera",
@@ -3386,6 +4506,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(163840),
+        max_output_tokens: Some(163840),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "undi95/remm-slerp-l2-13b",
@@ -3398,6 +4522,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(6144),
+        max_output_tokens: Some(4096),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "upstage/solar-pro-3",
@@ -3410,6 +4538,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "writer/palmyra-x5",
@@ -3422,6 +4554,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1040000),
+        max_output_tokens: Some(8192),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "x-ai/grok-3",
@@ -3434,6 +4570,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "x-ai/grok-3-mini",
@@ -3446,6 +4586,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "x-ai/grok-4",
@@ -3458,6 +4602,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: None,
+        input_modalities: &["image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "x-ai/grok-4-fast",
@@ -3470,6 +4618,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(2000000),
+        max_output_tokens: Some(30000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "x-ai/grok-4.1-fast",
@@ -3482,6 +4634,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(2000000),
+        max_output_tokens: Some(30000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "x-ai/grok-code-fast-1",
@@ -3494,6 +4650,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: Some(10000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "xiaomi/mimo-v2-flash",
@@ -3506,6 +4666,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(262144),
+        max_output_tokens: Some(65536),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "z-ai/glm-4-32b",
@@ -3518,6 +4682,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "z-ai/glm-4.5",
@@ -3530,6 +4698,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(98304),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "z-ai/glm-4.5-air",
@@ -3542,6 +4714,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(98304),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "z-ai/glm-4.5v",
@@ -3554,6 +4730,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(65536),
+        max_output_tokens: Some(16384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "z-ai/glm-4.6",
@@ -3566,6 +4746,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(204800),
+        max_output_tokens: Some(204800),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "z-ai/glm-4.6v",
@@ -3578,6 +4762,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: Some(131072),
+        input_modalities: &["image", "text", "video"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "z-ai/glm-4.7",
@@ -3590,6 +4778,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(202752),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "z-ai/glm-4.7-flash",
@@ -3602,6 +4794,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(202752),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "z-ai/glm-5",
@@ -3614,6 +4810,10 @@ pub(crate) const OPENROUTER_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(202752),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
 ];
 
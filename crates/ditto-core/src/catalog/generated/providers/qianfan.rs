@@ -26,6 +26,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "BLOOMZ-7B",
@@ -36,6 +40,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Baichuan2-7B-Chat",
@@ -46,6 +54,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ChatGLM2-6B-32K",
@@ -56,6 +68,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Chatglm2-6B",
@@ -66,6 +82,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Chatglm2-6B-Int4",
@@ -76,6 +96,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Chatglm3-6B",
@@ -86,6 +110,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Chatglm3-6B-32K",
@@ -96,6 +124,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "CodeLlama-7B-Instruct",
@@ -106,6 +138,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DISC-MedLLM",
@@ -116,6 +152,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-R1-Distill-Llama-70B",
@@ -126,6 +166,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-R1-Distill-Llama-8B",
@@ -136,6 +180,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-R1-Distill-Qianfan-70B",
@@ -146,6 +194,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-R1-Distill-Qianfan-8B",
@@ -156,6 +208,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-R1-Distill-Qianfan-Llama-70B",
@@ -166,6 +222,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-R1-Distill-Qianfan-Llama-8B",
@@ -176,6 +236,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-R1-Distill-Qwen-1.5B",
@@ -186,6 +250,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-R1-Distill-Qwen-7B",
@@ -196,6 +264,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-V3-241226",
@@ -206,6 +278,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-VL2",
@@ -216,6 +292,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "DeepSeek-VL2-Small",
@@ -226,6 +306,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Dolly-V2-12B",
@@ -236,6 +320,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-3.5-4K-0205",
@@ -246,6 +334,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-3.5-8K(抢占式)",
@@ -256,6 +348,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-3.5-8K-1222",
@@ -266,6 +362,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-4.0-8K(抢占式)",
@@ -276,6 +376,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-4.5-Turbo-Latest",
@@ -286,6 +390,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-4.5-Turbo-VL-Latest",
@@ -296,6 +404,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-Character-Fiction-8K",
@@ -306,6 +418,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-Character-Fiction-8K-Preview",
@@ -316,6 +432,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-Lite-8K",
@@ -326,6 +446,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-Lite-8k-0922",
@@ -336,6 +460,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-Novel-8K",
@@ -346,6 +474,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-Speed-128K",
@@ -356,6 +488,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-Speed-8K",
@@ -366,6 +502,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-Tiny-8K",
@@ -376,6 +516,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ERNIE-iRAG",
@@ -386,6 +530,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Falcon-40B-Instruct",
@@ -396,6 +544,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Falcon-7B-V5-FP16",
@@ -406,6 +558,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Fuyu-8B",
@@ -416,6 +572,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "GLM-4-32B-0414",
@@ -426,6 +586,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "GLM-4.5V",
@@ -436,6 +600,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "GLM-Z1-32B-0414",
@@ -446,6 +614,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "GLM-Z1-Rumination-32B-0414",
@@ -456,6 +628,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "GPT-OSS-120B",
@@ -466,6 +642,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "GPT-OSS-20B",
@@ -476,6 +656,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Gemma-2B-It",
@@ -486,6 +670,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Gemma-7B-It",
@@ -496,6 +684,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "InternLM-XComposer2-VL-7B",
@@ -506,6 +698,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "InternVL2_5-38B-MPO",
@@ -516,6 +712,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "InternVL3-14B",
@@ -526,6 +726,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "InternVL3-1B",
@@ -536,6 +740,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Linly-Chinese-LLaMA-2-13B",
@@ -546,6 +754,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Linly-Chinese-LLaMA-2-7B",
@@ -556,6 +768,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Llama-2-13B-Chat",
@@ -566,6 +782,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Llama-2-70B-Chat",
@@ -576,6 +796,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Llama-2-7B-Chat",
@@ -586,6 +810,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Llama-4-Maverick-17B-128E-Instruct",
@@ -596,6 +824,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Llama-4-Scout-17B-16E-Instruct",
@@ -606,6 +838,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MMuseSteamer-2.0-Turbo-I2V-Effect",
@@ -616,6 +852,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MPT-30B-Instruct",
@@ -626,6 +866,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MPT-7B-Instruct",
@@ -636,6 +880,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Meta-Llama-3-70B",
@@ -646,6 +894,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Meta-Llama-3-8B",
@@ -656,6 +908,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Mistral-7B-Instruct-v0.1",
@@ -666,6 +922,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Mixtral-8x7B-Instruct",
@@ -676,6 +936,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MuseSteamer-2.1-Lite-I2V",
@@ -686,6 +950,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "MuseSteamer-2.1-Turbo-I2V",
@@ -696,6 +964,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "OASST-SFT4-Pythia-12B-Epoch-3.5",
@@ -706,6 +978,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "OpenLLaMA-7B-V5-FP16",
@@ -716,6 +992,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-70B",
@@ -726,6 +1006,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-8B",
@@ -736,6 +1020,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Agent-Intent-32K",
@@ -746,6 +1034,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Agent-Lite-8K",
@@ -756,6 +1048,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Agent-Speed-32K",
@@ -766,6 +1062,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Agent-Speed-8K",
@@ -776,6 +1076,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-BLOOMZ-7B-compressed",
@@ -786,6 +1090,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Chinese-Llama-2-1.3B",
@@ -796,6 +1104,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Chinese-Llama-2-13B",
@@ -806,6 +1118,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Chinese-Llama-2-13B-V2",
@@ -816,6 +1132,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Chinese-Llama-2-70B",
@@ -826,6 +1146,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Chinese-Llama-2-7B",
@@ -836,6 +1160,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Chinese-Llama-2-7B-32K",
@@ -846,6 +1174,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Dynamic-8k",
@@ -856,6 +1188,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Lightning-128B-A19B",
@@ -866,6 +1202,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-Llama-2-70B-Compressed",
@@ -876,6 +1216,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-NLU",
@@ -886,6 +1230,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qianfan-PublicOpinion-Classification",
@@ -896,6 +1244,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "QianfanHuijin-70B-32K",
@@ -906,6 +1258,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "QianfanHuijin-Reason-8B-32K",
@@ -916,6 +1272,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Qwen3-235B-A22B",
@@ -926,6 +1286,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "RWKV-4-Pile-14B",
@@ -936,6 +1300,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "RWKV-4-World",
@@ -946,6 +1314,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "RWKV-Raven-14B",
@@ -956,6 +1328,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "SQLCoder-7B",
@@ -966,6 +1342,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Wan-2.1-I2V-14B-480P",
@@ -976,6 +1356,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Wan-2.1-I2V-14B-720P",
@@ -986,6 +1370,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Wan-2.1-StartEnd2Video-14B-720P",
@@ -998,6 +1386,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Wan-2.1-T2V-14B",
@@ -1008,6 +1400,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "XVERSE-13B-Chat",
@@ -1018,6 +1414,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "XuanYuan-70B-Chat-4bit",
@@ -1028,6 +1428,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "Yi-34B-Chat",
@@ -1038,6 +1442,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "bce-reranker-base",
@@ -1050,6 +1458,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::RERANK,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "bge-large-en",
@@ -1062,6 +1474,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "bge-large-zh",
@@ -1074,6 +1490,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-ocr",
@@ -1084,6 +1504,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::OCR],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::OCR)],
+        context_window: None,
+        max_output_tokens: Some(8000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1",
@@ -1096,6 +1520,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(144000),
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-250528",
@@ -1106,6 +1534,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-distill-qwen-14b",
@@ -1116,6 +1548,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-r1-distill-qwen-32b",
@@ -1126,6 +1562,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3",
@@ -1136,6 +1576,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(16000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3.1-250821",
@@ -1146,6 +1590,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3.1-think-250821",
@@ -1156,6 +1604,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3.2",
@@ -1166,6 +1618,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "deepseek-v3.2-think",
@@ -1176,6 +1632,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "embedding-v1",
@@ -1188,6 +1648,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-3.5-8k",
@@ -1198,6 +1662,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Official Qianfan quickstart examples still reference this legacy model id."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.0-turbo-8k",
@@ -1208,6 +1676,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Official Qianfan quickstart examples still reference this legacy model id."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-0.3b",
@@ -1218,6 +1690,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-21b-a3b",
@@ -1228,6 +1704,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-21b-a3b-thinking",
@@ -1238,6 +1718,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-8k-preview",
@@ -1248,6 +1732,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(2048),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-turbo-128k",
@@ -1260,6 +1748,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(12288),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-turbo-128k-preview",
@@ -1270,6 +1762,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(12288),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-turbo-32k",
@@ -1280,6 +1776,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(12288),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-turbo-vl",
@@ -1290,6 +1790,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-turbo-vl-32k",
@@ -1300,6 +1804,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(12288),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-turbo-vl-32k-preview",
@@ -1310,6 +1818,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(16384),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-turbo-vl-preview",
@@ -1320,6 +1832,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16384),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-4.5-vl-28b-a3b",
@@ -1330,6 +1846,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-5.0",
@@ -1342,6 +1862,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-5.0-thinking-latest",
@@ -1352,6 +1876,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-5.0-thinking-preview",
@@ -1362,6 +1890,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-char-8k",
@@ -1372,6 +1904,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(2048),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-lite-pro-128k",
@@ -1382,6 +1918,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4096),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-speed-pro-128k",
@@ -1392,6 +1932,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4096),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-x1-turbo-32k",
@@ -1402,6 +1946,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(28160),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-x1-turbo-32k-preview",
@@ -1412,6 +1960,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(28160),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-x1.1",
@@ -1424,6 +1976,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(64000),
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "ernie-x1.1-preview",
@@ -1434,6 +1990,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "flux.1-schnell",
@@ -1446,6 +2006,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-4.7",
@@ -1456,6 +2020,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(131072),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-5",
@@ -1466,6 +2034,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(131072),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "gme-qwen2-vl-2b-instruct",
@@ -1478,6 +2050,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "internvl3-38b",
@@ -1488,6 +2064,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "kimi-k2-instruct",
@@ -1498,6 +2078,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "kimi-k2.5",
@@ -1508,6 +2092,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "minimax-m2.1",
@@ -1518,6 +2106,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(131072),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "minimax-m2.5",
@@ -1528,6 +2120,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(131072),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "musesteamer-2.0-lite-i2v",
@@ -1540,6 +2136,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "musesteamer-2.0-pro-i2v",
@@ -1552,6 +2152,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "musesteamer-2.0-turbo-i2v",
@@ -1564,6 +2168,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "musesteamer-2.0-turbo-i2v-audio",
@@ -1576,6 +2184,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "musesteamer-2.0-turbo-i2v-effect",
@@ -1588,6 +2200,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "musesteamer-air-image",
@@ -1600,6 +2216,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "paddleocr-vl-0.9b",
@@ -1610,6 +2230,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::OCR],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::OCR)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "pp-structurev3",
@@ -1620,6 +2244,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::OCR],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::OCR)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-check-vl",
@@ -1630,6 +2258,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(131072),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-composition",
@@ -1640,6 +2272,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-correct",
@@ -1650,6 +2286,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(8000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-engcard-vl",
@@ -1660,6 +2300,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(4000),
+        max_output_tokens: Some(4000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-multipicocr",
@@ -1670,6 +2314,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-ocr",
@@ -1680,6 +2328,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::OCR],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::OCR)],
+        context_window: None,
+        max_output_tokens: Some(28000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-ocr-fast",
@@ -1690,6 +2342,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::OCR],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::OCR)],
+        context_window: None,
+        max_output_tokens: Some(28000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-qi-vl",
@@ -1700,6 +2356,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(131072),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-singlepicocr",
@@ -1710,6 +2370,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(4000),
+        max_output_tokens: Some(4096),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-sug-8k",
@@ -1720,6 +2384,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(2048),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-toytalk",
@@ -1730,6 +2398,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-vl-1.5-flash",
@@ -1740,6 +2412,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-vl-70b",
@@ -1750,6 +2426,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(28672),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qianfan-vl-8b",
@@ -1760,6 +2440,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: Some(28672),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image",
@@ -1772,6 +2456,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen-image-edit",
@@ -1784,6 +2472,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_EDIT,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-7b-instruct",
@@ -1794,6 +2486,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-vl-32b-instruct",
@@ -1804,6 +2500,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen2.5-vl-7b-instruct",
@@ -1814,6 +2514,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(4000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-0.6b",
@@ -1824,6 +2528,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-1.7b",
@@ -1834,6 +2542,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-14b",
@@ -1844,6 +2556,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-235b-a22b-instruct-2507",
@@ -1854,6 +2570,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-235b-a22b-thinking-2507",
@@ -1864,6 +2584,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-30b-a3b",
@@ -1874,6 +2598,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Listed in the official Qianfan retirement schedule."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-30b-a3b-instruct-2507",
@@ -1884,6 +2612,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-30b-a3b-thinking-2507",
@@ -1894,6 +2626,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-32b",
@@ -1904,6 +2640,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-4b",
@@ -1914,6 +2654,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-8b",
@@ -1924,6 +2668,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-30b-a3b-instruct",
@@ -1934,6 +2682,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-coder-480b-a35b-instruct",
@@ -1944,6 +2696,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-embedding-0.6b",
@@ -1956,6 +2712,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-embedding-4b",
@@ -1968,6 +2728,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-embedding-8b",
@@ -1980,6 +2744,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-next-80b-a3b-instruct",
@@ -1990,6 +2758,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-next-80b-a3b-thinking",
@@ -2000,6 +2772,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32768),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-reranker-0.6b",
@@ -2012,6 +2788,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::RERANK,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-reranker-4b",
@@ -2024,6 +2804,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::RERANK,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-reranker-8b",
@@ -2036,6 +2820,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::RERANK,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-235b-a22b-instruct",
@@ -2046,6 +2834,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-235b-a22b-thinking",
@@ -2056,6 +2848,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-30b-a3b-instruct",
@@ -2066,6 +2862,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-30b-a3b-thinking",
@@ -2076,6 +2876,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-32b-instruct",
@@ -2086,6 +2890,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-32b-thinking",
@@ -2096,6 +2904,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-8b-instruct",
@@ -2106,6 +2918,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3-vl-8b-thinking",
@@ -2116,6 +2932,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-122b-a10b",
@@ -2126,6 +2946,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-27b",
@@ -2136,6 +2960,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-35b-a3b",
@@ -2146,6 +2974,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwen3.5-397b-a17b",
@@ -2156,6 +2988,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(65536),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "qwq-32b",
@@ -2166,6 +3002,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         summary: None,
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: Some(8192),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "tao-8k",
@@ -2178,6 +3018,10 @@ pub(crate) const QIANFAN_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
 ];
 
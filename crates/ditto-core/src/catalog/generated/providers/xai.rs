@@ -30,6 +30,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["image"],
     },
     ProviderModelDescriptor {
         id: "grok-2-latest",
@@ -40,6 +44,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Official latest alias listed in xAI Models and Pricing."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "grok-2-vision-latest",
@@ -50,6 +58,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Official latest alias listed in xAI Models and Pricing."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "grok-3",
@@ -60,6 +72,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Official latest alias listed in xAI Models and Pricing."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "grok-3-fast-20251025",
@@ -72,6 +88,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(2000000),
+        max_output_tokens: Some(16000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "grok-3-mini",
@@ -82,6 +102,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Official latest alias listed in xAI Models and Pricing."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "grok-4-0709",
@@ -94,6 +118,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(256000),
+        max_output_tokens: Some(16000),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "grok-4-fast-reasoning-0709",
@@ -106,6 +134,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(2000000),
+        max_output_tokens: Some(64000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "grok-beta",
@@ -116,6 +148,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Official Grok beta alias still listed in xAI Models and Pricing."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "grok-code-fast-1-0825",
@@ -128,6 +164,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(131072),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "grok-imagine-2",
@@ -142,6 +182,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text", "image"],
+        output_modalities: &["video"],
     },
     ProviderModelDescriptor {
         id: "grok-vision-beta",
@@ -152,6 +196,10 @@ pub(crate) const XAI_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("Official Grok vision beta alias still listed in xAI Models and Pricing."),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
 ];
 
@@ -28,6 +28,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(20000),
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "charglm-4",
@@ -40,6 +44,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(4000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "codegeex-4",
@@ -50,6 +58,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("适用于代码自动补全任务"),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(32000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cogvideox-2",
@@ -62,6 +74,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cogvideox-3",
@@ -76,6 +92,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["image", "text", "start_end_frame"],
+        output_modalities: &["video"],
     },
     ProviderModelDescriptor {
         id: "cogvideox-flash",
@@ -90,6 +110,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cogview-3-flash",
@@ -104,6 +128,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "cogview-4",
@@ -118,6 +146,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["image"],
     },
     ProviderModelDescriptor {
         id: "cogview-4-250304",
@@ -130,6 +162,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "embedding-2",
@@ -144,6 +180,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: Some(8000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "embedding-3",
@@ -158,6 +198,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::EMBEDDING,
         )],
+        context_window: Some(8000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "emohaa",
@@ -170,6 +214,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(4000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4-air-250414",
@@ -182,6 +230,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4-airx",
@@ -194,6 +246,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4-flash-250414",
@@ -206,6 +262,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4-flashx-250414",
@@ -218,6 +278,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(16000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4-long",
@@ -230,6 +294,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(1000000),
+        max_output_tokens: Some(4000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4-plus",
@@ -242,6 +310,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(4000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4-voice",
@@ -254,6 +326,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(8000),
+        max_output_tokens: Some(4000),
+        input_modalities: &["audio", "text"],
+        output_modalities: &["audio"],
     },
     ProviderModelDescriptor {
         id: "glm-4.1v-thinking-flash",
@@ -266,6 +342,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(64000),
+        max_output_tokens: Some(16000),
+        input_modalities: &["video", "image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.1v-thinking-flashx",
@@ -278,6 +358,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(64000),
+        max_output_tokens: Some(16000),
+        input_modalities: &["video", "image", "text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.5",
@@ -288,6 +372,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("我们强大的推理模型，3550亿参数"),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(96000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.5-air",
@@ -298,6 +386,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("高性价比 轻量级 强性能"),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(96000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.5-airx",
@@ -308,6 +400,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("轻量级 强性能 极速响应"),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(96000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.5-flash",
@@ -320,6 +416,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(96000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.5-x",
@@ -330,6 +430,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         summary: Some("高性能 强推理 极速响应"),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(96000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.6",
@@ -342,6 +446,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.6v",
@@ -354,6 +462,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(32000),
+        input_modalities: &["video", "image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.6v-flash",
@@ -366,6 +478,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(32000),
+        input_modalities: &["video", "image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.6v-flashx",
@@ -378,6 +494,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["video", "image", "text", "file"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.7",
@@ -390,6 +510,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.7-flash",
@@ -402,6 +526,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4.7-flashx",
@@ -414,6 +542,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-4v-flash",
@@ -426,6 +558,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(16000),
+        max_output_tokens: Some(1000),
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-5",
@@ -438,6 +574,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(200000),
+        max_output_tokens: Some(128000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-asr-2512",
@@ -452,6 +592,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_TRANSCRIPTION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["audio"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-image",
@@ -466,6 +610,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::IMAGE_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &["image"],
     },
     ProviderModelDescriptor {
         id: "glm-ocr",
@@ -478,6 +626,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::OCR],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::OCR)],
+        context_window: Some(10000000),
+        max_output_tokens: None,
+        input_modalities: &["pdf"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-realtime-air",
@@ -492,6 +644,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: Some(8000),
+        max_output_tokens: Some(1000),
+        input_modalities: &["video", "audio", "text"],
+        output_modalities: &["audio"],
     },
     ProviderModelDescriptor {
         id: "glm-realtime-flash",
@@ -506,6 +662,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::REALTIME,
         )],
+        context_window: Some(8000),
+        max_output_tokens: Some(1000),
+        input_modalities: &["video", "audio", "text"],
+        output_modalities: &["audio"],
     },
     ProviderModelDescriptor {
         id: "glm-tts",
@@ -520,6 +680,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::AUDIO_SPEECH,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["audio"],
     },
     ProviderModelDescriptor {
         id: "glm-tts-clone",
@@ -538,6 +702,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_SPEECH),
             CapabilityStatusDescriptor::implemented(CapabilityKind::AUDIO_VOICE_CLONE),
         ],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "glm-z1-air",
@@ -550,6 +718,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: Some(32000),
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-z1-airx",
@@ -562,6 +734,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(32000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-z1-flash",
@@ -574,6 +750,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "glm-z1-flashx",
@@ -586,6 +766,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         ),
         supported_operations: &[OperationKind::CHAT_COMPLETION],
         capability_statuses: &[CapabilityStatusDescriptor::implemented(CapabilityKind::LLM)],
+        context_window: Some(128000),
+        max_output_tokens: None,
+        input_modalities: &["text"],
+        output_modalities: &["text"],
     },
     ProviderModelDescriptor {
         id: "rerank",
@@ -598,6 +782,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::RERANK,
         )],
+        context_window: Some(4000),
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "vidu2-image",
@@ -612,6 +800,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "vidu2-reference",
@@ -626,6 +818,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "vidu2-start-end",
@@ -640,6 +836,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "viduq1-image",
@@ -654,6 +854,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "viduq1-start-end",
@@ -668,6 +872,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
     ProviderModelDescriptor {
         id: "viduq1-text",
@@ -682,6 +890,10 @@ pub(crate) const ZHIPU_MODELS: &[ProviderModelDescriptor] = &[
         capability_statuses: &[CapabilityStatusDescriptor::implemented(
             CapabilityKind::VIDEO_GENERATION,
         )],
+        context_window: None,
+        max_output_tokens: None,
+        input_modalities: &[],
+        output_modalities: &[],
     },
 ];
 
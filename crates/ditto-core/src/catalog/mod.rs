@@ -130,6 +130,12 @@ pub struct ProviderModelDescriptor {
     pub summary: Option<&'static str>,
     pub supported_operations: &'static [OperationKind],
     pub capability_statuses: &'static [CapabilityStatusDescriptor],
+    /// Input token limit from the reference catalog, when documented.
+    pub context_window: Option<u64>,
+    pub max_output_tokens: Option<u64>,
+    /// Lower-case modality names (`text`, `image`, `audio`, `video`, `file`).
+    pub input_modalities: &'static [&'static str],
+    pub output_modalities: &'static [&'static str],
 }
 
 impl ProviderModelDescriptor {
@@ -403,6 +409,10 @@ mod tests {
             summary: None,
             supported_operations: &[OperationKind::CHAT_COMPLETION],
             capability_statuses: &[],
+            context_window: None,
+            max_output_tokens: None,
+            input_modalities: &[],
+            output_modalities: &[],
         }];
         const BINDINGS: &[ModelBinding] = &[
             ModelBinding {
@@ -643,6 +643,10 @@ mod tests {
                 OperationKind::EMBEDDING,
             ],
             capability_statuses: &[],
+            context_window: None,
+            max_output_tokens: None,
+            input_modalities: &[],
+            output_modalities: &[],
         };
 
         let descriptor = MODEL.capability_descriptor(ProviderId::new("demo-provider"));
@@ -722,6 +726,10 @@ mod tests {
                 CapabilityStatusDescriptor::blocked(CapabilityKind::EMBEDDING),
                 CapabilityStatusDescriptor::planned(CapabilityKind::IMAGE_GENERATION),
             ],
+            context_window: None,
+            max_output_tokens: None,
+            input_modalities: &[],
+            output_modalities: &[],
         }];
         const PLUGIN: ProviderPluginDescriptor = ProviderPluginDescriptor {
             id: "status-provider",
@@ -848,6 +856,10 @@ mod tests {
             summary: None,
             supported_operations: &[OperationKind::CHAT_COMPLETION, OperationKind::EMBEDDING],
             capability_statuses: &[],
+            context_window: None,
+            max_output_tokens: None,
+            input_modalities: &[],
+            output_modalities: &[],
         }];
         const PLUGIN: ProviderPluginDescriptor = ProviderPluginDescriptor {
             id: "demo-provider",
@@ -986,6 +998,10 @@ mod tests {
             summary: None,
             supported_operations: &[OperationKind::CHAT_COMPLETION],
             capability_statuses: &[],
+            context_window: None,
+            max_output_tokens: None,
+            input_modalities: &[],
+            output_modalities: &[],
         }];
         const PLUGIN: ProviderPluginDescriptor = ProviderPluginDescriptor {
             id: "demo-provider",
@@ -1107,6 +1123,10 @@ mod tests {
                 summary: None,
                 supported_operations: &[OperationKind::CHAT_COMPLETION],
                 capability_statuses: &[],
+                context_window: None,
+                max_output_tokens: None,
+                input_modalities: &[],
+                output_modalities: &[],
             },
             ProviderModelDescriptor {
                 id: "embed-only",
@@ -1117,6 +1137,10 @@ mod tests {
                 summary: None,
                 supported_operations: &[OperationKind::EMBEDDING],
                 capability_statuses: &[],
+                context_window: None,
+                max_output_tokens: None,
+                input_modalities: &[],
+                output_modalities: &[],
             },
         ];
         const PLUGIN: ProviderPluginDescriptor = ProviderPluginDescriptor {
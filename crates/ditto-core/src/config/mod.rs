@@ -13,9 +13,9 @@ pub(crate) mod routing_policy;
 pub use auth::{resolve_auth_token, resolve_auth_token_with_default_keys};
 pub use env::{Env, parse_dotenv};
 pub use provider_config::{
-    ModelConfig, OpenAiCompatibleConfig, ProviderApi, ProviderAuth, ProviderCapabilities,
-    ProviderConfig, ThinkingIntensity, filter_models_whitelist, merge_provider_config,
-    normalize_string_list, select_model_config,
+    MockProviderConfig, ModelConfig, OpenAiCompatibleConfig, ProviderApi, ProviderAuth,
+    ProviderCapabilities, ProviderConfig, ThinkingIntensity, filter_models_whitelist,
+    merge_provider_config, normalize_string_list, select_model_config,
 };
 pub use routing_policy::{
     ProviderRoutingConfig, ResolvedRoutingPlan, ResolvedRoutingTarget, RoutingConfigFormat,
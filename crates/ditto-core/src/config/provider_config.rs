@@ -291,6 +291,31 @@ pub struct OpenAiCompatibleConfig {
     pub send_tool_call_thought_signature: Option<bool>,
 }
 
+/// Canned responses for `provider = "mock"`: no network calls, keys or cost,
+/// for integration and load tests.
+#[derive(Debug, Clone, PartialEq, Eq, Serialize, Deserialize, Default)]
+pub struct MockProviderConfig {
+    /// Response templates, used in turn. `{{model}}`, `{{prompt}}` (the last
+    /// user message text) and `{{request_index}}` are substituted.
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub responses: Vec<String>,
+    /// Delay before the response, or before the first stream chunk.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub latency_ms: Option<u64>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub stream_chunk_chars: Option<usize>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub stream_chunk_delay_ms: Option<u64>,
+    /// Share of requests (0-100) that fail, spread evenly over the request
+    /// sequence so runs are reproducible.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub error_percent: Option<u8>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub error_status: Option<u16>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub error_message: Option<String>,
+}
+
 impl ProviderConfig {
     pub fn runtime_hints(&self) -> RuntimeProviderHints<'_> {
         RuntimeProviderHints {
@@ -333,6 +358,8 @@ pub struct ProviderConfig {
     pub normalize_endpoint: Option<String>,
     #[serde(default, skip_serializing_if = "Option::is_none")]
     pub openai_compatible: Option<OpenAiCompatibleConfig>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub mock: Option<MockProviderConfig>,
 }
 
 pub fn merge_provider_config(
@@ -399,6 +426,9 @@ pub fn merge_provider_config(
     if let Some(openai_compatible) = overrides.openai_compatible.clone() {
         base.openai_compatible = Some(openai_compatible);
     }
+    if let Some(mock) = overrides.mock.clone() {
+        base.mock = Some(mock);
+    }
     base
 }
 
@@ -603,6 +633,7 @@ keys = ["TEST_API_KEY"]
             normalize_to: None,
             normalize_endpoint: None,
             openai_compatible: None,
+            mock: None,
         };
         let overrides = ProviderConfig {
             provider: None,
@@ -618,6 +649,7 @@ keys = ["TEST_API_KEY"]
             normalize_to: None,
             normalize_endpoint: None,
             openai_compatible: None,
+            mock: None,
         };
 
         let resolved = merge_provider_config(base, &overrides);
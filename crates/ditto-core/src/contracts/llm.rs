@@ -193,6 +193,29 @@ pub struct GenerateResponse {
     pub warnings: Vec<Warning>,
     #[serde(default, skip_serializing_if = "Option::is_none")]
     pub provider_metadata: Option<Value>,
+    /// Per-token log probabilities, when requested and returned.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub logprobs: Option<Vec<TokenLogprob>>,
+}
+
+/// Log probability of one generated token, with the most likely alternatives
+/// at that position (OpenAI `logprobs.content[]` shape).
+#[derive(Debug, Clone, Serialize, Deserialize, PartialEq, Default)]
+pub struct TokenLogprob {
+    pub token: String,
+    pub logprob: f64,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub bytes: Option<Vec<u8>>,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub top_logprobs: Vec<TopLogprob>,
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize, PartialEq, Default)]
+pub struct TopLogprob {
+    pub token: String,
+    pub logprob: f64,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub bytes: Option<Vec<u8>>,
 }
 
 impl GenerateResponse {
@@ -216,6 +239,7 @@ pub enum StreamChunk {
     ToolCallStart { id: String, name: String },
     ToolCallDelta { id: String, arguments_delta: String },
     ReasoningDelta { text: String },
+    Logprobs { logprobs: Vec<TokenLogprob> },
     FinishReason(FinishReason),
     Usage(Usage),
 }
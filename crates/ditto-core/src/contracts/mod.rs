@@ -19,7 +19,7 @@ pub use ids::{
 };
 pub use llm::{
     ContentPart, FileSource, GenerateRequest, GenerateResponse, ImageSource, Message, Role,
-    StreamChunk, Tool, ToolChoice,
+    StreamChunk, TokenLogprob, Tool, ToolChoice, TopLogprob,
 };
 pub use outcome::{FinishReason, Usage, Warning};
 pub use provider::{
@@ -115,6 +115,7 @@ mod tests {
                 usage: Usage::default(),
                 warnings: Vec::new(),
                 provider_metadata: None,
+                logprobs: None,
             })
         }
 
@@ -10,7 +10,8 @@ use futures_util::stream;
 use futures_util::task::AtomicWaker;
 
 use crate::contracts::{
-    ContentPart, FinishReason, GenerateRequest, GenerateResponse, StreamChunk, Usage, Warning,
+    ContentPart, FinishReason, GenerateRequest, GenerateResponse, StreamChunk, TokenLogprob, Usage,
+    Warning,
 };
 
 use super::model::{LanguageModel, StreamResult};
@@ -62,6 +63,7 @@ pub(crate) struct StreamCollector {
     response_id: Option<String>,
     finish_reason: FinishReason,
     usage: Usage,
+    logprobs: Option<Vec<TokenLogprob>>,
     parts: Vec<CollectedPart>,
     tool_calls: HashMap<String, ToolCallBuffer>,
     limits: StreamCollectorLimits,
@@ -289,6 +291,14 @@ impl StreamCollector {
                     self.parts.push(CollectedPart::ToolCall { id: id.clone() });
                 }
             }
+            StreamChunk::Logprobs { logprobs } => {
+                let bytes = logprobs.iter().map(|logprob| logprob.token.len()).sum();
+                if self.try_add_bytes(bytes) {
+                    self.logprobs
+                        .get_or_insert_with(Vec::new)
+                        .extend(logprobs.iter().cloned());
+                }
+            }
             StreamChunk::FinishReason(reason) => self.finish_reason = *reason,
             StreamChunk::Usage(usage) => self.usage = usage.clone(),
         }
@@ -432,6 +442,12 @@ impl StreamCollector {
                     self.parts.push(CollectedPart::ToolCall { id });
                 }
             }
+            StreamChunk::Logprobs { logprobs } => {
+                let bytes = logprobs.iter().map(|logprob| logprob.token.len()).sum();
+                if self.try_add_bytes(bytes) {
+                    self.logprobs.get_or_insert_with(Vec::new).extend(logprobs);
+                }
+            }
             StreamChunk::FinishReason(reason) => self.finish_reason = reason,
             StreamChunk::Usage(usage) => self.usage = usage,
         }
@@ -501,6 +517,7 @@ impl StreamCollector {
             usage: self.usage,
             warnings: self.warnings,
             provider_metadata,
+            logprobs: self.logprobs,
         }
     }
 
@@ -505,7 +505,7 @@ pub(super) fn stream_object_from_stream_with_config_and_limits(
                                     }
                                 }
                             }
-                            StreamChunk::ReasoningDelta { .. } => {}
+                            StreamChunk::ReasoningDelta { .. } | StreamChunk::Logprobs { .. } => {}
                         }
 
                         let mut parsed = None;
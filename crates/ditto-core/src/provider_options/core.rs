@@ -53,6 +53,34 @@ pub struct ProviderOptions {
     pub parallel_tool_calls: Option<bool>,
     #[serde(default, skip_serializing_if = "Option::is_none")]
     pub prompt_cache_key: Option<String>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub cache_control: Option<CacheControlOptions>,
+}
+
+/// Anthropic prompt-caching breakpoints. Every marked block is sent with
+/// `cache_control: {"type": "ephemeral"}`, which caches the prompt prefix
+/// ending at that block.
+#[derive(Debug, Clone, Serialize, Deserialize, Default, PartialEq)]
+pub struct CacheControlOptions {
+    /// Marks the system prompt.
+    #[serde(default, skip_serializing_if = "is_false")]
+    pub system: bool,
+    /// Marks the last tool definition, which caches all tools.
+    #[serde(default, skip_serializing_if = "is_false")]
+    pub tools: bool,
+    /// Indexes into the request messages whose last content block is marked.
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub messages: Vec<usize>,
+    /// Marks the system prompt once it is at least this many characters long.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub auto_system_min_chars: Option<usize>,
+    /// Cache lifetime such as `"1h"`; Anthropic defaults to five minutes.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub ttl: Option<String>,
+}
+
+fn is_false(value: &bool) -> bool {
+    !*value
 }
 
 impl ProviderOptions {
@@ -11,7 +11,8 @@ mod request;
 mod support;
 
 pub use core::{
-    JsonSchemaFormat, ProviderOptions, ReasoningEffort, ReasoningSummary, ResponseFormat,
+    CacheControlOptions, JsonSchemaFormat, ProviderOptions, ReasoningEffort, ReasoningSummary,
+    ResponseFormat,
 };
 
 pub use envelope::ProviderOptionsEnvelope;
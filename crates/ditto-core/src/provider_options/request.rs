@@ -96,6 +96,7 @@ mod tests {
                 }),
                 parallel_tool_calls: Some(false),
                 prompt_cache_key: Some("cache_key".to_string()),
+                cache_control: None,
             },
         )?;
 
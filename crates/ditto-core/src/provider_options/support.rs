@@ -29,6 +29,7 @@ pub(crate) struct ProviderOptionsSupport {
     pub reasoning_effort: bool,
     pub response_format: bool,
     pub parallel_tool_calls: bool,
+    pub cache_control: bool,
 }
 
 #[cfg(any(
@@ -42,6 +43,7 @@ impl ProviderOptionsSupport {
         reasoning_effort: false,
         response_format: false,
         parallel_tool_calls: false,
+        cache_control: false,
     };
 }
 
@@ -81,4 +83,12 @@ pub(crate) fn warn_unsupported_provider_options(
             )),
         });
     }
+    if provider_options.cache_control.is_some() && !supported.cache_control {
+        warnings.push(Warning::Unsupported {
+            feature: "cache_control".to_string(),
+            details: Some(format!(
+                "{provider_display} does not support cache_control breakpoints"
+            )),
+        });
+    }
 }
@@ -387,12 +387,6 @@ impl Anthropic {
     }
 
     fn parse_usage(value: &Value) -> Usage {
-        let mut usage = Usage::default();
-        if let Some(obj) = value.as_object() {
-            usage.input_tokens = obj.get("input_tokens").and_then(Value::as_u64);
-            usage.output_tokens = obj.get("output_tokens").and_then(Value::as_u64);
-        }
-        usage.merge_total();
-        usage
+        crate::providers::anthropic_messages_common::parse_usage(value)
     }
 }
@@ -81,7 +81,11 @@ impl LanguageModel for Anthropic {
         crate::provider_options::warn_unsupported_provider_options(
             "Anthropic Messages API",
             &provider_options,
-            crate::provider_options::ProviderOptionsSupport::NONE,
+            crate::provider_options::ProviderOptionsSupport {
+                parallel_tool_calls: true,
+                cache_control: true,
+                ..crate::provider_options::ProviderOptionsSupport::NONE
+            },
             &mut warnings,
         );
         crate::types::warn_unsupported_generate_request_options(
@@ -95,8 +99,9 @@ impl LanguageModel for Anthropic {
         let mut system = Vec::<String>::new();
         let mut saw_non_system = false;
         let mut messages = Vec::<Value>::new();
+        let mut message_sources = Vec::<usize>::new();
 
-        for message in &request.messages {
+        for (idx, message) in request.messages.iter().enumerate() {
             if message.role == Role::System && !saw_non_system {
                 if let Some(text) = Self::extract_system_text(message, &mut warnings) {
                     system.push(text);
@@ -109,6 +114,7 @@ impl LanguageModel for Anthropic {
                 Self::message_to_anthropic_blocks(message, &tool_names, &mut warnings)
             {
                 messages.push(serde_json::json!({ "role": role, "content": content }));
+                message_sources.push(idx);
             }
         }
 
@@ -188,11 +194,26 @@ impl LanguageModel for Anthropic {
                 });
             }
         }
+        if provider_options.parallel_tool_calls == Some(false) {
+            crate::providers::anthropic_messages_common::disable_parallel_tool_use(&mut body);
+        }
+        if let Some(cache_control) = provider_options.cache_control.as_ref() {
+            crate::providers::anthropic_messages_common::apply_cache_control(
+                &mut body,
+                cache_control,
+                &message_sources,
+            );
+        }
 
         crate::provider_options::merge_provider_options_into_body(
             &mut body,
             selected_provider_options.as_ref(),
-            &["reasoning_effort", "response_format", "parallel_tool_calls"],
+            &[
+                "reasoning_effort",
+                "response_format",
+                "parallel_tool_calls",
+                "cache_control",
+            ],
             "generate.provider_options",
             &mut warnings,
         );
@@ -226,6 +247,7 @@ impl LanguageModel for Anthropic {
             usage,
             warnings,
             provider_metadata: parsed.id.map(|id| serde_json::json!({ "id": id })),
+            logprobs: None,
         })
     }
 
@@ -257,7 +279,11 @@ impl LanguageModel for Anthropic {
             crate::provider_options::warn_unsupported_provider_options(
                 "Anthropic Messages API",
                 &provider_options,
-                crate::provider_options::ProviderOptionsSupport::NONE,
+                crate::provider_options::ProviderOptionsSupport {
+                    parallel_tool_calls: true,
+                    cache_control: true,
+                    ..crate::provider_options::ProviderOptionsSupport::NONE
+                },
                 &mut warnings,
             );
             crate::types::warn_unsupported_generate_request_options(
@@ -271,8 +297,9 @@ impl LanguageModel for Anthropic {
             let mut system = Vec::<String>::new();
             let mut saw_non_system = false;
             let mut messages = Vec::<Value>::new();
+            let mut message_sources = Vec::<usize>::new();
 
-            for message in &request.messages {
+            for (idx, message) in request.messages.iter().enumerate() {
                 if message.role == Role::System && !saw_non_system {
                     if let Some(text) = Self::extract_system_text(message, &mut warnings) {
                         system.push(text);
@@ -285,6 +312,7 @@ impl LanguageModel for Anthropic {
                     Self::message_to_anthropic_blocks(message, &tool_names, &mut warnings)
                 {
                     messages.push(serde_json::json!({ "role": role, "content": content }));
+                    message_sources.push(idx);
                 }
             }
 
@@ -352,11 +380,26 @@ impl LanguageModel for Anthropic {
                         body.insert("tool_choice".to_string(), mapped);
                     }
                 }
+            if provider_options.parallel_tool_calls == Some(false) {
+                crate::providers::anthropic_messages_common::disable_parallel_tool_use(&mut body);
+            }
+            if let Some(cache_control) = provider_options.cache_control.as_ref() {
+                crate::providers::anthropic_messages_common::apply_cache_control(
+                    &mut body,
+                    cache_control,
+                    &message_sources,
+                );
+            }
 
             crate::provider_options::merge_provider_options_into_body(
                 &mut body,
                 selected_provider_options.as_ref(),
-                &["reasoning_effort", "response_format", "parallel_tool_calls"],
+                &[
+                    "reasoning_effort",
+                    "response_format",
+                    "parallel_tool_calls",
+                    "cache_control",
+                ],
                 "stream.provider_options",
                 &mut warnings,
             );
@@ -519,9 +562,21 @@ impl LanguageModel for Anthropic {
                                             _ => {}
                                         }
                                     }
+                                    "message_start" => {
+                                        if let Some(usage) =
+                                            event.message.as_ref().and_then(|m| m.get("usage"))
+                                        {
+                                            pending_usage = Some(Self::parse_usage(usage));
+                                        }
+                                    }
                                     "message_delta" => {
                                         if let Some(usage) = event.usage.as_ref() {
-                                            pending_usage = Some(Self::parse_usage(usage));
+                                            pending_usage = Some(
+                                                crate::providers::anthropic_messages_common::merge_stream_usage(
+                                                    pending_usage.take(),
+                                                    Self::parse_usage(usage),
+                                                ),
+                                            );
                                         }
                                         if let Some(message) =
                                             event.message.as_ref().or(event.delta.as_ref())
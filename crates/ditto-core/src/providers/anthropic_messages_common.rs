@@ -0,0 +1,212 @@
+//! Request/response shaping shared by the Anthropic Messages API and Claude on
+//! Bedrock, which speak the same body format.
+
+use serde_json::{Map, Value};
+
+use crate::contracts::Usage;
+use crate::provider_options::CacheControlOptions;
+
+/// Anthropic reports `input_tokens` excluding cache reads and writes; the
+/// canonical usage counts them as part of the input, with the cached share
+/// broken out like OpenAI's `prompt_tokens_details`.
+pub(crate) fn parse_usage(value: &Value) -> Usage {
+    let mut usage = Usage::default();
+    if let Some(obj) = value.as_object() {
+        let uncached = obj.get("input_tokens").and_then(Value::as_u64);
+        usage.cache_input_tokens = obj.get("cache_read_input_tokens").and_then(Value::as_u64);
+        usage.cache_creation_input_tokens = obj
+            .get("cache_creation_input_tokens")
+            .and_then(Value::as_u64);
+        usage.input_tokens = uncached.map(|uncached| {
+            uncached
+                .saturating_add(usage.cache_input_tokens.unwrap_or(0))
+                .saturating_add(usage.cache_creation_input_tokens.unwrap_or(0))
+        });
+        usage.output_tokens = obj.get("output_tokens").and_then(Value::as_u64);
+    }
+    usage.merge_total();
+    usage
+}
+
+/// Anthropic may call several tools per turn unless the tool choice sets
+/// `disable_parallel_tool_use`, which OpenAI spells `parallel_tool_calls: false`.
+pub(crate) fn disable_parallel_tool_use(body: &mut Map<String, Value>) {
+    if !body.contains_key("tools") {
+        return;
+    }
+    if let Some(choice) = body
+        .entry("tool_choice")
+        .or_insert_with(|| serde_json::json!({ "type": "auto" }))
+        .as_object_mut()
+    {
+        choice.insert("disable_parallel_tool_use".to_string(), Value::Bool(true));
+    }
+}
+
+/// Streams report input usage on `message_start` and output usage on
+/// `message_delta`; fields missing from the later event keep their earlier
+/// values.
+pub(crate) fn merge_stream_usage(earlier: Option<Usage>, later: Usage) -> Usage {
+    let Some(earlier) = earlier else {
+        return later;
+    };
+    let mut usage = Usage {
+        input_tokens: later.input_tokens.or(earlier.input_tokens),
+        cache_input_tokens: later.cache_input_tokens.or(earlier.cache_input_tokens),
+        cache_creation_input_tokens: later
+            .cache_creation_input_tokens
+            .or(earlier.cache_creation_input_tokens),
+        output_tokens: later.output_tokens.or(earlier.output_tokens),
+        total_tokens: None,
+    };
+    usage.merge_total();
+    usage
+}
+
+/// Adds `cache_control` breakpoints to a built Messages body. `sources[i]` is
+/// the request message index that produced `body.messages[i]`.
+pub(crate) fn apply_cache_control(
+    body: &mut Map<String, Value>,
+    options: &CacheControlOptions,
+    sources: &[usize],
+) {
+    let mut marker = Map::<String, Value>::new();
+    marker.insert("type".to_string(), Value::String("ephemeral".to_string()));
+    if let Some(ttl) = options.ttl.as_deref().filter(|ttl| !ttl.trim().is_empty()) {
+        marker.insert("ttl".to_string(), Value::String(ttl.trim().to_string()));
+    }
+    let marker = Value::Object(marker);
+
+    if let Some(Value::String(system)) = body.get("system") {
+        let auto = options
+            .auto_system_min_chars
+            .is_some_and(|min_chars| system.chars().count() >= min_chars);
+        if options.system || auto {
+            let block = serde_json::json!({
+                "type": "text",
+                "text": system,
+                "cache_control": marker.clone(),
+            });
+            body.insert("system".to_string(), Value::Array(vec![block]));
+        }
+    }
+
+    if options.tools
+        && let Some(tool) = body
+            .get_mut("tools")
+            .and_then(Value::as_array_mut)
+            .and_then(|tools| tools.last_mut())
+            .and_then(Value::as_object_mut)
+    {
+        tool.insert("cache_control".to_string(), marker.clone());
+    }
+
+    if options.messages.is_empty() {
+        return;
+    }
+    let Some(messages) = body.get_mut("messages").and_then(Value::as_array_mut) else {
+        return;
+    };
+    for (message, source) in messages.iter_mut().zip(sources) {
+        if !options.messages.contains(source) {
+            continue;
+        }
+        if let Some(block) = message
+            .get_mut("content")
+            .and_then(Value::as_array_mut)
+            .and_then(|content| content.last_mut())
+            .and_then(Value::as_object_mut)
+        {
+            block.insert("cache_control".to_string(), marker.clone());
+        }
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use serde_json::json;
+
+    #[test]
+    fn parse_usage_counts_cache_reads_and_writes_as_input() {
+        let usage = parse_usage(&json!({
+            "input_tokens": 10,
+            "cache_read_input_tokens": 100,
+            "cache_creation_input_tokens": 20,
+            "output_tokens": 5,
+        }));
+        assert_eq!(usage.input_tokens, Some(130));
+        assert_eq!(usage.cache_input_tokens, Some(100));
+        assert_eq!(usage.cache_creation_input_tokens, Some(20));
+        assert_eq!(usage.output_tokens, Some(5));
+        assert_eq!(usage.total_tokens, Some(135));
+
+        let delta = parse_usage(&json!({ "output_tokens": 7 }));
+        let merged = merge_stream_usage(Some(usage), delta);
+        assert_eq!(merged.input_tokens, Some(130));
+        assert_eq!(merged.cache_input_tokens, Some(100));
+        assert_eq!(merged.output_tokens, Some(7));
+        assert_eq!(merged.total_tokens, Some(137));
+    }
+
+    #[test]
+    fn disables_parallel_tool_use_only_when_tools_are_sent() {
+        let mut body = Map::new();
+        disable_parallel_tool_use(&mut body);
+        assert!(body.is_empty());
+
+        body.insert("tools".to_string(), json!([{ "name": "lookup" }]));
+        disable_parallel_tool_use(&mut body);
+        assert_eq!(
+            body.get("tool_choice"),
+            Some(&json!({ "type": "auto", "disable_parallel_tool_use": true }))
+        );
+
+        body.insert("tool_choice".to_string(), json!({ "type": "any" }));
+        disable_parallel_tool_use(&mut body);
+        assert_eq!(
+            body.get("tool_choice"),
+            Some(&json!({ "type": "any", "disable_parallel_tool_use": true }))
+        );
+    }
+
+    #[test]
+    fn apply_cache_control_marks_system_tools_and_messages() {
+        let mut body = json!({
+            "system": "You are terse.",
+            "tools": [{ "name": "a" }, { "name": "b" }],
+            "messages": [
+                { "role": "user", "content": [{ "type": "text", "text": "one" }] },
+                { "role": "assistant", "content": [{ "type": "text", "text": "two" }] },
+            ],
+        });
+        let body = body.as_object_mut().expect("object");
+        let options = CacheControlOptions {
+            tools: true,
+            messages: vec![1],
+            auto_system_min_chars: Some(8),
+            ttl: Some("1h".to_string()),
+            ..CacheControlOptions::default()
+        };
+        apply_cache_control(body, &options, &[0, 1]);
+
+        let marker = json!({ "type": "ephemeral", "ttl": "1h" });
+        assert_eq!(
+            body["system"],
+            json!([{ "type": "text", "text": "You are terse.", "cache_control": marker }])
+        );
+        assert!(body["tools"][0].get("cache_control").is_none());
+        assert_eq!(body["tools"][1]["cache_control"], marker);
+        assert!(
+            body["messages"][0]["content"][0]
+                .get("cache_control")
+                .is_none()
+        );
+        assert_eq!(body["messages"][1]["content"][0]["cache_control"], marker);
+
+        let mut short = json!({ "system": "short" });
+        let short = short.as_object_mut().expect("object");
+        apply_cache_control(short, &options, &[]);
+        assert_eq!(short["system"], json!("short"));
+    }
+}
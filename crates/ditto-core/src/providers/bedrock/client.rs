@@ -409,13 +409,7 @@ impl Bedrock {
     }
 
     fn parse_usage(value: &Value) -> Usage {
-        let mut usage = Usage::default();
-        if let Some(obj) = value.as_object() {
-            usage.input_tokens = obj.get("input_tokens").and_then(Value::as_u64);
-            usage.output_tokens = obj.get("output_tokens").and_then(Value::as_u64);
-        }
-        usage.merge_total();
-        usage
+        crate::providers::anthropic_messages_common::parse_usage(value)
     }
 
     fn parse_anthropic_content(blocks: &[Value]) -> Vec<ContentPart> {
@@ -474,8 +468,9 @@ impl Bedrock {
         let mut system = Vec::<String>::new();
         let mut saw_non_system = false;
         let mut messages = Vec::<Value>::new();
+        let mut message_sources = Vec::<usize>::new();
 
-        for message in &request.messages {
+        for (idx, message) in request.messages.iter().enumerate() {
             if message.role == Role::System && !saw_non_system {
                 if let Some(text) = Self::extract_system_text(message, warnings) {
                     system.push(text);
@@ -488,6 +483,7 @@ impl Bedrock {
                 Self::message_to_anthropic_blocks(message, &tool_names, warnings)
             {
                 messages.push(serde_json::json!({ "role": role, "content": content }));
+                message_sources.push(idx);
             }
         }
 
@@ -563,11 +559,33 @@ impl Bedrock {
             }
         }
 
+        let selected_provider_options =
+            crate::provider_options::request_provider_options_value_for(request, "bedrock")?;
+        let provider_options = selected_provider_options
+            .as_ref()
+            .map(crate::provider_options::ProviderOptions::from_value_ref)
+            .transpose()?
+            .unwrap_or_default();
+        if provider_options.parallel_tool_calls == Some(false) {
+            crate::providers::anthropic_messages_common::disable_parallel_tool_use(&mut body);
+        }
+        if let Some(cache_control) = provider_options.cache_control.as_ref() {
+            crate::providers::anthropic_messages_common::apply_cache_control(
+                &mut body,
+                cache_control,
+                &message_sources,
+            );
+        }
+
         crate::provider_options::merge_provider_options_into_body(
             &mut body,
-            crate::provider_options::request_provider_options_value_for(request, "bedrock")?
-                .as_ref(),
-            &["reasoning_effort", "response_format", "parallel_tool_calls"],
+            selected_provider_options.as_ref(),
+            &[
+                "reasoning_effort",
+                "response_format",
+                "parallel_tool_calls",
+                "cache_control",
+            ],
             "bedrock.provider_options",
             warnings,
         );
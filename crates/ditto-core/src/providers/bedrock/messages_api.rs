@@ -34,7 +34,10 @@ impl LanguageModel for Bedrock {
         crate::provider_options::warn_unsupported_provider_options(
             "Bedrock Anthropic",
             &provider_options,
-            crate::provider_options::ProviderOptionsSupport::NONE,
+            crate::provider_options::ProviderOptionsSupport {
+                cache_control: true,
+                ..crate::provider_options::ProviderOptionsSupport::NONE
+            },
             &mut warnings,
         );
         crate::types::warn_unsupported_generate_request_options(
@@ -63,6 +66,7 @@ impl LanguageModel for Bedrock {
             usage,
             warnings,
             provider_metadata: parsed.id.map(|id| serde_json::json!({ "id": id })),
+            logprobs: None,
         })
     }
 
@@ -94,7 +98,10 @@ impl LanguageModel for Bedrock {
             crate::provider_options::warn_unsupported_provider_options(
                 "Bedrock Anthropic",
                 &provider_options,
-                crate::provider_options::ProviderOptionsSupport::NONE,
+                crate::provider_options::ProviderOptionsSupport {
+                    cache_control: true,
+                    ..crate::provider_options::ProviderOptionsSupport::NONE
+                },
                 &mut warnings,
             );
             crate::types::warn_unsupported_generate_request_options(
@@ -256,9 +263,21 @@ impl LanguageModel for Bedrock {
                                             _ => {}
                                         }
                                     }
+                                    "message_start" => {
+                                        if let Some(usage) =
+                                            event.message.as_ref().and_then(|m| m.get("usage"))
+                                        {
+                                            pending_usage = Some(Self::parse_usage(usage));
+                                        }
+                                    }
                                     "message_delta" => {
                                         if let Some(usage) = event.usage.as_ref() {
-                                            pending_usage = Some(Self::parse_usage(usage));
+                                            pending_usage = Some(
+                                                crate::providers::anthropic_messages_common::merge_stream_usage(
+                                                    pending_usage.take(),
+                                                    Self::parse_usage(usage),
+                                                ),
+                                            );
                                         }
                                         if let Some(message) =
                                             event.message.as_ref().or(event.delta.as_ref())
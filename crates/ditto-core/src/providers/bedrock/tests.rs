@@ -84,6 +84,89 @@ mod tests {
         assert_eq!(parsed, vec![first, second]);
     }
 
+    #[cfg(feature = "cap-llm-tools")]
+    #[test]
+    fn bedrock_body_maps_parallel_tool_calls_false_to_tool_choice() -> Result<()> {
+        let mut request = GenerateRequest::from(vec![Message::user("hi")]);
+        request.tools = Some(vec![Tool {
+            name: "lookup".to_string(),
+            description: None,
+            parameters: json!({ "type": "object" }),
+            strict: None,
+        }]);
+        let request = crate::provider_options::request_with_provider_options(
+            request,
+            crate::provider_options::ProviderOptions {
+                parallel_tool_calls: Some(false),
+                ..Default::default()
+            },
+        )?;
+
+        let mut warnings = Vec::new();
+        let body = Bedrock::build_bedrock_body(&request, "claude-test", &mut warnings)?;
+        assert_eq!(
+            body.get("tool_choice"),
+            Some(&json!({ "type": "auto", "disable_parallel_tool_use": true }))
+        );
+        assert!(body.get("parallel_tool_calls").is_none());
+        assert!(warnings.is_empty(), "{warnings:?}");
+
+        let mut request = request;
+        request.tool_choice = Some(ToolChoice::Tool {
+            name: "lookup".to_string(),
+        });
+        let body = Bedrock::build_bedrock_body(&request, "claude-test", &mut warnings)?;
+        assert_eq!(
+            body.get("tool_choice"),
+            Some(&json!({ "type": "tool", "name": "lookup", "disable_parallel_tool_use": true }))
+        );
+        Ok(())
+    }
+
+    #[test]
+    fn bedrock_body_applies_cache_control_breakpoints() -> Result<()> {
+        let request = GenerateRequest::from(vec![
+            Message::system("You are a careful assistant."),
+            Message::user("first"),
+            Message::assistant("ok"),
+            Message::user("second"),
+        ]);
+        let request = crate::provider_options::request_with_provider_options(
+            request,
+            crate::provider_options::ProviderOptions {
+                cache_control: Some(crate::provider_options::CacheControlOptions {
+                    system: true,
+                    messages: vec![2],
+                    ..Default::default()
+                }),
+                ..Default::default()
+            },
+        )?;
+
+        let mut warnings = Vec::new();
+        let body = Bedrock::build_bedrock_body(&request, "claude-test", &mut warnings)?;
+        assert_eq!(
+            body["system"],
+            json!([{
+                "type": "text",
+                "text": "You are a careful assistant.",
+                "cache_control": { "type": "ephemeral" },
+            }])
+        );
+        assert!(
+            body["messages"][0]["content"][0]
+                .get("cache_control")
+                .is_none()
+        );
+        assert_eq!(
+            body["messages"][1]["content"][0]["cache_control"],
+            json!({ "type": "ephemeral" })
+        );
+        assert!(body.get("cache_control").is_none());
+        assert!(warnings.is_empty(), "{warnings:?}");
+        Ok(())
+    }
+
     #[tokio::test]
     async fn bedrock_generate_maps_anthropic_body() -> Result<()> {
         if crate::utils::test_support::should_skip_httpmock() {
@@ -139,7 +139,12 @@ impl LanguageModel for Cohere {
         crate::provider_options::merge_provider_options_into_body(
             &mut body,
             selected_provider_options.as_ref(),
-            &["reasoning_effort", "response_format", "parallel_tool_calls"],
+            &[
+                "reasoning_effort",
+                "response_format",
+                "parallel_tool_calls",
+                "cache_control",
+            ],
             "cohere.provider_options",
             &mut warnings,
         );
@@ -230,6 +235,7 @@ impl LanguageModel for Cohere {
             usage,
             warnings,
             provider_metadata: Some(Value::Object(provider_metadata)),
+            logprobs: None,
         })
     }
 
@@ -325,7 +331,12 @@ impl LanguageModel for Cohere {
             crate::provider_options::merge_provider_options_into_body(
                 &mut body,
                 selected_provider_options.as_ref(),
-                &["reasoning_effort", "response_format", "parallel_tool_calls"],
+                &[
+                    "reasoning_effort",
+                    "response_format",
+                    "parallel_tool_calls",
+                    "cache_control",
+                ],
                 "cohere.provider_options",
                 &mut warnings,
             );
@@ -3,8 +3,8 @@ use std::collections::HashMap;
 use serde_json::{Map, Value};
 
 use crate::contracts::{
-    ContentPart, FileSource, FinishReason, ImageSource, Message, Role, Tool, ToolChoice, Usage,
-    Warning,
+    ContentPart, FileSource, FinishReason, GenerateRequest, ImageSource, Message, Role,
+    TokenLogprob, Tool, ToolChoice, TopLogprob, Usage, Warning,
 };
 use crate::error::Result;
 
@@ -436,6 +436,74 @@ pub(crate) fn parse_usage_metadata(value: &Value) -> Usage {
     usage
 }
 
+/// `generationConfig.responseLogprobs` / `logprobs` (Gemini allows 0..=20
+/// alternatives per token).
+pub(crate) fn insert_logprobs_config(
+    request: &GenerateRequest,
+    generation_config: &mut Map<String, Value>,
+) {
+    if request.logprobs != Some(true) {
+        return;
+    }
+    generation_config.insert("responseLogprobs".to_string(), Value::Bool(true));
+    if let Some(top_logprobs) = request.top_logprobs {
+        generation_config.insert(
+            "logprobs".to_string(),
+            Value::Number(top_logprobs.min(20).into()),
+        );
+    }
+}
+
+/// A candidate's `logprobsResult`: `chosenCandidates[i]` is the sampled token
+/// and `topCandidates[i]` its alternatives. Gemini reports no token bytes, so
+/// they are the token's UTF-8 encoding.
+pub(crate) fn parse_candidate_logprobs(candidate: &Value) -> Option<Vec<TokenLogprob>> {
+    let result = candidate.get("logprobsResult")?;
+    let chosen = result.get("chosenCandidates").and_then(Value::as_array)?;
+    let top = result.get("topCandidates").and_then(Value::as_array);
+
+    // Protobuf JSON omits a log probability of exactly 0.
+    let token_and_logprob = |candidate: &Value| {
+        let token = candidate.get("token").and_then(Value::as_str)?;
+        let logprob = candidate
+            .get("logProbability")
+            .and_then(Value::as_f64)
+            .unwrap_or(0.0);
+        Some((token.to_string(), logprob))
+    };
+
+    let logprobs: Vec<TokenLogprob> = chosen
+        .iter()
+        .enumerate()
+        .filter_map(|(idx, candidate)| {
+            let (token, logprob) = token_and_logprob(candidate)?;
+            let top_logprobs = top
+                .and_then(|top| top.get(idx))
+                .and_then(|top| top.get("candidates"))
+                .and_then(Value::as_array)
+                .map(|alternatives| {
+                    alternatives
+                        .iter()
+                        .filter_map(token_and_logprob)
+                        .map(|(token, logprob)| TopLogprob {
+                            bytes: Some(token.as_bytes().to_vec()),
+                            token,
+                            logprob,
+                        })
+                        .collect()
+                })
+                .unwrap_or_default();
+            Some(TokenLogprob {
+                bytes: Some(token.as_bytes().to_vec()),
+                token,
+                logprob,
+                top_logprobs,
+            })
+        })
+        .collect();
+    (!logprobs.is_empty()).then_some(logprobs)
+}
+
 pub(crate) fn parse_google_candidate(
     candidate: &Value,
     tool_call_seq: &mut u64,
@@ -125,6 +125,9 @@ fn stream_chunks_from_generate_response(
         }
     }
 
+    if let Some(logprobs) = response.logprobs {
+        out.push(Ok(crate::contracts::StreamChunk::Logprobs { logprobs }));
+    }
     if usage_has_token_counts(&response.usage) {
         out.push(Ok(crate::contracts::StreamChunk::Usage(response.usage)));
     }
@@ -159,7 +162,10 @@ impl Google {
         crate::types::warn_unsupported_generate_request_options(
             "Google GenAI",
             request,
-            crate::types::GenerateRequestSupport::NONE,
+            crate::types::GenerateRequestSupport {
+                logprobs: true,
+                ..crate::types::GenerateRequestSupport::NONE
+            },
             &mut warnings,
         );
         let tool_names = Self::build_tool_name_map(&request.messages);
@@ -210,6 +216,7 @@ impl Google {
                 );
             }
         }
+        genai::insert_logprobs_config(request, &mut generation_config);
         if !generation_config.is_empty() {
             body.insert(
                 "generationConfig".to_string(),
@@ -245,7 +252,12 @@ impl Google {
         crate::provider_options::merge_provider_options_into_body(
             &mut body,
             selected_provider_options.as_ref(),
-            &["reasoning_effort", "response_format", "parallel_tool_calls"],
+            &[
+                "reasoning_effort",
+                "response_format",
+                "parallel_tool_calls",
+                "cache_control",
+            ],
             provider_options_scope,
             &mut warnings,
         );
@@ -313,6 +325,10 @@ impl LanguageModel for Google {
             usage,
             warnings,
             provider_metadata: None,
+            logprobs: parsed
+                .candidates
+                .first()
+                .and_then(genai::parse_candidate_logprobs),
         })
     }
 
@@ -487,6 +503,13 @@ impl LanguageModel for Google {
                                                 }));
                                             }
                                         }
+
+                                        if let Some(logprobs) =
+                                            genai::parse_candidate_logprobs(candidate)
+                                        {
+                                            buffer
+                                                .push_back(Ok(StreamChunk::Logprobs { logprobs }));
+                                        }
                                     }
                                 }
                                 Err(err) => {
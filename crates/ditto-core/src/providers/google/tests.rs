@@ -307,6 +307,36 @@ mod tests {
         }
     }
 
+    #[test]
+    fn parse_candidate_logprobs_reads_logprobs_result() {
+        let candidate = json!({
+            "logprobsResult": {
+                "chosenCandidates": [
+                    { "token": "Hi", "logProbability": -0.25 },
+                    { "token": "!" }
+                ],
+                "topCandidates": [
+                    { "candidates": [
+                        { "token": "Hi", "logProbability": -0.25 },
+                        { "token": "Hello", "logProbability": -1.5 }
+                    ] }
+                ]
+            }
+        });
+        let logprobs =
+            crate::providers::genai::parse_candidate_logprobs(&candidate).expect("logprobs");
+        assert_eq!(logprobs.len(), 2);
+        assert_eq!(logprobs[0].token, "Hi");
+        assert_eq!(logprobs[0].logprob, -0.25);
+        assert_eq!(logprobs[0].bytes.as_deref(), Some(b"Hi".as_slice()));
+        assert_eq!(logprobs[0].top_logprobs.len(), 2);
+        assert_eq!(logprobs[0].top_logprobs[1].token, "Hello");
+        assert_eq!(logprobs[1].logprob, 0.0);
+        assert!(logprobs[1].top_logprobs.is_empty());
+
+        assert!(crate::providers::genai::parse_candidate_logprobs(&json!({})).is_none());
+    }
+
     #[test]
     fn parse_usage_metadata_maps_cached_content_tokens() {
         let usage = Google::parse_usage_metadata(&json!({
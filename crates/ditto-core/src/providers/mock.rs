@@ -0,0 +1,342 @@
+use std::sync::Arc;
+use std::sync::atomic::{AtomicU64, Ordering};
+use std::time::Duration;
+
+use async_trait::async_trait;
+use futures_util::stream;
+
+use crate::config::{Env, MockProviderConfig, ProviderConfig};
+use crate::contracts::{
+    ContentPart, FinishReason, GenerateRequest, GenerateResponse, Role, StreamChunk, Usage,
+};
+use crate::error::{DittoError, Result};
+use crate::llm_core::model::{LanguageModel, StreamResult};
+
+const DEFAULT_MODEL: &str = "mock";
+const DEFAULT_RESPONSE: &str = "mock response to: {{prompt}}";
+const DEFAULT_STREAM_CHUNK_CHARS: usize = 16;
+const DEFAULT_ERROR_STATUS: u16 = 500;
+const DEFAULT_ERROR_MESSAGE: &str = "mock provider injected error";
+
+// MOCK-PROVIDER: answers locally from `ProviderConfig.mock`. Clones share the
+// request counter so response rotation and error injection stay in sequence.
+#[derive(Clone)]
+pub struct MockProvider {
+    default_model: String,
+    config: MockProviderConfig,
+    requests: Arc<AtomicU64>,
+}
+
+impl MockProvider {
+    pub fn new(config: MockProviderConfig) -> Self {
+        Self {
+            default_model: DEFAULT_MODEL.to_string(),
+            config,
+            requests: Arc::new(AtomicU64::new(0)),
+        }
+    }
+
+    pub fn with_model(mut self, model: impl Into<String>) -> Self {
+        self.default_model = model.into();
+        self
+    }
+
+    pub async fn from_config(config: &ProviderConfig, _env: &Env) -> Result<Self> {
+        let mock = config.mock.clone().unwrap_or_default();
+        if let Some(percent) = mock.error_percent
+            && percent > 100
+        {
+            return Err(crate::config_error!(
+                "error_detail.mock.invalid_error_percent",
+                "value" => percent.to_string()
+            ));
+        }
+        if let Some(status) = mock.error_status
+            && !(400..=599).contains(&status)
+        {
+            return Err(crate::config_error!(
+                "error_detail.mock.invalid_error_status",
+                "status" => status.to_string()
+            ));
+        }
+
+        let mut out = Self::new(mock);
+        if let Some(model) = config
+            .default_model
+            .as_deref()
+            .map(str::trim)
+            .filter(|model| !model.is_empty())
+        {
+            out = out.with_model(model);
+        }
+        Ok(out)
+    }
+
+    async fn respond(&self, request: &GenerateRequest) -> Result<(String, Usage)> {
+        let index = self.requests.fetch_add(1, Ordering::Relaxed);
+        if let Some(latency_ms) = self.config.latency_ms.filter(|ms| *ms > 0) {
+            tokio::time::sleep(Duration::from_millis(latency_ms)).await;
+        }
+        if self.injects_error(index) {
+            let status = self.config.error_status.unwrap_or(DEFAULT_ERROR_STATUS);
+            let message = self
+                .config
+                .error_message
+                .as_deref()
+                .unwrap_or(DEFAULT_ERROR_MESSAGE);
+            return Err(DittoError::Api {
+                status: reqwest::StatusCode::from_u16(status)
+                    .unwrap_or(reqwest::StatusCode::INTERNAL_SERVER_ERROR),
+                body: message.to_string(),
+            });
+        }
+
+        let model = request
+            .model
+            .as_deref()
+            .unwrap_or(self.default_model.as_str());
+        let prompt = last_user_text(request);
+        let template = if self.config.responses.is_empty() {
+            DEFAULT_RESPONSE
+        } else {
+            let slot = index % self.config.responses.len() as u64;
+            self.config.responses[slot as usize].as_str()
+        };
+        let text = template
+            .replace("{{model}}", model)
+            .replace("{{prompt}}", &prompt)
+            .replace("{{request_index}}", &index.to_string());
+
+        let input_tokens = estimate_tokens(
+            request
+                .messages
+                .iter()
+                .flat_map(|message| message.content.iter())
+                .filter_map(|part| match part {
+                    ContentPart::Text { text } => Some(text.len()),
+                    _ => None,
+                })
+                .sum(),
+        );
+        let mut usage = Usage {
+            input_tokens: Some(input_tokens),
+            output_tokens: Some(estimate_tokens(text.len())),
+            ..Usage::default()
+        };
+        usage.merge_total();
+        Ok((text, usage))
+    }
+
+    /// Fails request `index` when the running failure count crosses an integer,
+    /// so `error_percent = 25` fails exactly every fourth request.
+    fn injects_error(&self, index: u64) -> bool {
+        let percent = u64::from(self.config.error_percent.unwrap_or(0).min(100));
+        (index + 1) * percent / 100 > index * percent / 100
+    }
+}
+
+fn last_user_text(request: &GenerateRequest) -> String {
+    request
+        .messages
+        .iter()
+        .rev()
+        .find(|message| message.role == Role::User)
+        .map(|message| {
+            message
+                .content
+                .iter()
+                .filter_map(|part| match part {
+                    ContentPart::Text { text } => Some(text.as_str()),
+                    _ => None,
+                })
+                .collect::<Vec<_>>()
+                .join("\n")
+        })
+        .unwrap_or_default()
+}
+
+fn estimate_tokens(chars: usize) -> u64 {
+    chars.div_ceil(4) as u64
+}
+
+fn split_chunks(text: &str, chunk_chars: usize) -> Vec<String> {
+    let chars = text.chars().collect::<Vec<_>>();
+    chars
+        .chunks(chunk_chars.max(1))
+        .map(|chunk| chunk.iter().collect())
+        .collect()
+}
+
+#[async_trait]
+impl LanguageModel for MockProvider {
+    fn provider(&self) -> &str {
+        "mock"
+    }
+
+    fn model_id(&self) -> &str {
+        self.default_model.as_str()
+    }
+
+    async fn generate(&self, request: GenerateRequest) -> Result<GenerateResponse> {
+        let (text, usage) = self.respond(&request).await?;
+        Ok(GenerateResponse {
+            content: vec![ContentPart::Text { text }],
+            finish_reason: FinishReason::Stop,
+            usage,
+            ..GenerateResponse::default()
+        })
+    }
+
+    async fn stream(&self, request: GenerateRequest) -> Result<StreamResult> {
+        let (text, usage) = self.respond(&request).await?;
+        let chunk_chars = self
+            .config
+            .stream_chunk_chars
+            .unwrap_or(DEFAULT_STREAM_CHUNK_CHARS);
+        let delay = self
+            .config
+            .stream_chunk_delay_ms
+            .filter(|ms| *ms > 0)
+            .map(Duration::from_millis);
+
+        let mut chunks = split_chunks(&text, chunk_chars)
+            .into_iter()
+            .map(|text| StreamChunk::TextDelta { text })
+            .collect::<Vec<_>>();
+        chunks.push(StreamChunk::FinishReason(FinishReason::Stop));
+        chunks.push(StreamChunk::Usage(usage));
+
+        let stream = stream::unfold(
+            (chunks.into_iter(), true),
+            move |(mut chunks, first)| async move {
+                let chunk = chunks.next()?;
+                if let Some(delay) = delay
+                    && !first
+                    && matches!(chunk, StreamChunk::TextDelta { .. })
+                {
+                    tokio::time::sleep(delay).await;
+                }
+                Some((Ok(chunk), (chunks, false)))
+            },
+        );
+        Ok(Box::pin(stream))
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use futures_util::StreamExt;
+
+    use super::*;
+    use crate::contracts::Message;
+
+    fn provider(config: MockProviderConfig) -> MockProvider {
+        MockProvider::new(config).with_model("mock-model")
+    }
+
+    #[tokio::test]
+    async fn generate_renders_templates_in_turn() -> Result<()> {
+        let mock = provider(MockProviderConfig {
+            responses: vec![
+                "{{model}} #{{request_index}}: {{prompt}}".to_string(),
+                "second".to_string(),
+            ],
+            ..MockProviderConfig::default()
+        });
+
+        let request = GenerateRequest::from(vec![Message::user("hello")]);
+        let first = mock.generate(request.clone()).await?;
+        assert_eq!(first.text(), "mock-model #0: hello");
+        assert_eq!(first.finish_reason, FinishReason::Stop);
+        assert_eq!(first.usage.input_tokens, Some(2));
+        assert_eq!(first.usage.total_tokens, Some(2 + 5));
+
+        let second = mock.clone().generate(request.clone()).await?;
+        assert_eq!(second.text(), "second");
+        let third = mock.generate(request).await?;
+        assert_eq!(third.text(), "mock-model #2: hello");
+        Ok(())
+    }
+
+    #[tokio::test]
+    async fn stream_splits_text_into_chunks() -> Result<()> {
+        let mock = provider(MockProviderConfig {
+            responses: vec!["abcdefgh".to_string()],
+            stream_chunk_chars: Some(3),
+            ..MockProviderConfig::default()
+        });
+
+        let chunks = mock
+            .stream(GenerateRequest::from(vec![Message::user("hi")]))
+            .await?
+            .collect::<Vec<_>>()
+            .await;
+        let chunks = chunks.into_iter().collect::<Result<Vec<_>>>()?;
+        let deltas = chunks
+            .iter()
+            .filter_map(|chunk| match chunk {
+                StreamChunk::TextDelta { text } => Some(text.as_str()),
+                _ => None,
+            })
+            .collect::<Vec<_>>();
+        assert_eq!(deltas, vec!["abc", "def", "gh"]);
+        assert!(matches!(
+            chunks.last(),
+            Some(StreamChunk::Usage(Usage {
+                output_tokens: Some(2),
+                ..
+            }))
+        ));
+        Ok(())
+    }
+
+    #[tokio::test]
+    async fn error_percent_fails_an_even_share_of_requests() {
+        let mock = provider(MockProviderConfig {
+            error_percent: Some(25),
+            error_status: Some(429),
+            ..MockProviderConfig::default()
+        });
+
+        let mut failures = Vec::new();
+        for index in 0..8 {
+            let result = mock
+                .generate(GenerateRequest::from(vec![Message::user("hi")]))
+                .await;
+            if let Err(DittoError::Api { status, .. }) = result {
+                assert_eq!(status.as_u16(), 429);
+                failures.push(index);
+            }
+        }
+        assert_eq!(failures, vec![3, 7]);
+    }
+
+    #[tokio::test]
+    async fn from_config_rejects_out_of_range_error_settings() {
+        let config = ProviderConfig {
+            mock: Some(MockProviderConfig {
+                error_percent: Some(150),
+                ..MockProviderConfig::default()
+            }),
+            ..ProviderConfig::default()
+        };
+        assert!(
+            MockProvider::from_config(&config, &Env::default())
+                .await
+                .is_err()
+        );
+
+        let config = ProviderConfig {
+            mock: Some(MockProviderConfig {
+                error_status: Some(200),
+                ..MockProviderConfig::default()
+            }),
+            ..ProviderConfig::default()
+        };
+        assert!(
+            MockProvider::from_config(&config, &Env::default())
+                .await
+                .is_err()
+        );
+    }
+}
@@ -1,5 +1,7 @@
 #[cfg(feature = "provider-anthropic")]
 pub mod anthropic;
+#[cfg(any(feature = "provider-anthropic", feature = "provider-bedrock"))]
+mod anthropic_messages_common;
 #[cfg(feature = "provider-bedrock")]
 pub mod bedrock;
 #[cfg(feature = "provider-cohere")]
@@ -8,6 +10,8 @@ pub mod cohere;
 mod genai;
 #[cfg(feature = "provider-google")]
 pub mod google;
+#[cfg(feature = "provider-mock")]
+pub mod mock;
 mod model_resolution;
 #[cfg(any(feature = "provider-openai", feature = "provider-openai-compatible"))]
 pub mod openai;
@@ -86,6 +90,8 @@ pub use google::GoogleImages;
 pub use google::GoogleRealtime;
 #[cfg(all(feature = "provider-google", feature = "cap-video-generation"))]
 pub use google::GoogleVideos;
+#[cfg(feature = "provider-mock")]
+pub use mock::MockProvider;
 pub(crate) use model_resolution::resolve_model_or_default;
 #[cfg(feature = "provider-openai")]
 pub use openai::OpenAI;
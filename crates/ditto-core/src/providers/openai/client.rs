@@ -505,7 +505,10 @@ impl OpenAI {
         crate::types::warn_unsupported_generate_request_options(
             "OpenAI Responses API",
             request,
-            crate::types::GenerateRequestSupport::NONE,
+            crate::types::GenerateRequestSupport {
+                logprobs: true,
+                ..crate::types::GenerateRequestSupport::NONE
+            },
             &mut warnings,
         );
 
@@ -580,6 +583,7 @@ impl OpenAI {
             provider_options_context,
             &mut warnings,
         );
+        apply_responses_logprobs(&mut body, request, &mut warnings);
 
         Ok((body, warnings))
     }
@@ -725,6 +729,43 @@ pub(super) fn apply_provider_options(
     Ok(())
 }
 
+/// The Responses API has no `logprobs` flag: token logprobs are an `include`
+/// of the output text, with `top_logprobs` alternatives (0..=20).
+#[cfg(feature = "provider-openai")]
+fn apply_responses_logprobs(
+    body: &mut Map<String, Value>,
+    request: &GenerateRequest,
+    warnings: &mut Vec<Warning>,
+) {
+    if request.logprobs != Some(true) {
+        if request.top_logprobs.is_some() {
+            warnings.push(Warning::Compatibility {
+                feature: "top_logprobs".to_string(),
+                details: "top_logprobs requires logprobs=true; dropping".to_string(),
+            });
+        }
+        return;
+    }
+
+    const INCLUDE: &str = "message.output_text.logprobs";
+    match body.get_mut("include") {
+        Some(Value::Array(include)) => {
+            if !include.iter().any(|value| value.as_str() == Some(INCLUDE)) {
+                include.push(Value::String(INCLUDE.to_string()));
+            }
+        }
+        _ => {
+            body.insert("include".to_string(), serde_json::json!([INCLUDE]));
+        }
+    }
+    if let Some(top_logprobs) = request.top_logprobs {
+        body.insert(
+            "top_logprobs".to_string(),
+            Value::Number(top_logprobs.min(20).into()),
+        );
+    }
+}
+
 #[cfg(feature = "provider-openai")]
 pub(super) fn sanitize_openai_responses_provider_options(
     selected_provider_options: Option<Value>,
@@ -210,7 +210,9 @@ mod tests {
     };
     #[cfg(feature = "cap-llm-streaming")]
     use super::super::responses::finish_reason_for_final_event;
-    use super::super::responses::{map_responses_finish_reason, parse_openai_output};
+    use super::super::responses::{
+        map_responses_finish_reason, parse_openai_output, parse_openai_output_logprobs,
+    };
     use super::*;
     use crate::config::{Env, ProviderConfig};
     use crate::contracts::{
@@ -704,6 +706,29 @@ mod tests {
         }
     }
 
+    #[test]
+    fn parses_output_text_logprobs() {
+        let output = vec![serde_json::json!({
+            "type": "message",
+            "content": [{
+                "type": "output_text",
+                "text": "Hi",
+                "logprobs": [{
+                    "token": "Hi",
+                    "logprob": -0.5,
+                    "bytes": [72, 105],
+                    "top_logprobs": [{"token": "Hey", "logprob": -2.0, "bytes": [72, 101, 121]}]
+                }]
+            }]
+        })];
+
+        let logprobs = parse_openai_output_logprobs(&output).expect("logprobs");
+        assert_eq!(logprobs.len(), 1);
+        assert_eq!(logprobs[0].token, "Hi");
+        assert_eq!(logprobs[0].top_logprobs[0].token, "Hey");
+        assert!(parse_openai_output_logprobs(&[]).is_none());
+    }
+
     #[test]
     fn parses_function_call_with_thought_signature_into_encoded_call_id() {
         let output = vec![serde_json::json!({
@@ -768,6 +793,7 @@ mod tests {
             }),
             parallel_tool_calls: Some(false),
             prompt_cache_key: None,
+            cache_control: None,
         };
 
         apply_provider_options(&mut body, &options)?;
@@ -853,6 +879,34 @@ mod tests {
         Ok(())
     }
 
+    #[test]
+    fn build_responses_body_requests_output_text_logprobs() -> crate::error::Result<()> {
+        let mut request = GenerateRequest::from(vec![Message::user("hello")]);
+        request.logprobs = Some(true);
+        request.top_logprobs = Some(3);
+        let (body, warnings) = OpenAI::build_responses_body(
+            &request,
+            "gpt-test",
+            &crate::provider_options::ProviderOptions::default(),
+            Some(&json!({ "include": ["reasoning.encrypted_content"] })),
+            false,
+            "generate.provider_options",
+            false,
+        )?;
+
+        assert!(warnings.is_empty(), "{warnings:?}");
+        assert_eq!(
+            body.get("include"),
+            Some(&json!([
+                "reasoning.encrypted_content",
+                "message.output_text.logprobs"
+            ]))
+        );
+        assert_eq!(body.get("top_logprobs"), Some(&json!(3)));
+        assert!(body.get("logprobs").is_none());
+        Ok(())
+    }
+
     #[test]
     fn parse_usage_reads_cache_read_input_tokens_alias() {
         let usage = OpenAI::parse_usage(&json!({
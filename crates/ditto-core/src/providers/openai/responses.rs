@@ -13,7 +13,7 @@ use super::client::{
 
 #[cfg(feature = "cap-llm-streaming")]
 use crate::contracts::StreamChunk;
-use crate::contracts::{ContentPart, GenerateRequest, GenerateResponse};
+use crate::contracts::{ContentPart, GenerateRequest, GenerateResponse, TokenLogprob};
 use crate::contracts::{FinishReason, Warning};
 use crate::error::Result;
 use crate::llm_core::model::{LanguageModel, StreamResult};
@@ -42,6 +42,8 @@ struct ResponsesStreamEvent {
     item: Option<Value>,
     #[serde(default)]
     delta: Option<String>,
+    #[serde(default)]
+    logprobs: Option<Vec<TokenLogprob>>,
 }
 
 pub(super) fn map_responses_finish_reason(
@@ -156,6 +158,21 @@ pub(super) fn parse_openai_output(
     content
 }
 
+/// The `logprobs` of every `output_text` part, in output order.
+pub(super) fn parse_openai_output_logprobs(output: &[Value]) -> Option<Vec<TokenLogprob>> {
+    let logprobs: Vec<TokenLogprob> = output
+        .iter()
+        .filter(|item| item.get("type").and_then(Value::as_str) == Some("message"))
+        .filter_map(|item| item.get("content").and_then(Value::as_array))
+        .flatten()
+        .filter(|part| part.get("type").and_then(Value::as_str) == Some("output_text"))
+        .filter_map(|part| part.get("logprobs").and_then(Value::as_array))
+        .flatten()
+        .filter_map(|logprob| serde_json::from_value(logprob.clone()).ok())
+        .collect();
+    (!logprobs.is_empty()).then_some(logprobs)
+}
+
 #[async_trait]
 impl LanguageModel for OpenAI {
     fn provider(&self) -> &str {
@@ -198,6 +215,7 @@ impl LanguageModel for OpenAI {
         )
         .await?;
         let content = parse_openai_output(&parsed.output, &mut warnings);
+        let logprobs = parse_openai_output_logprobs(&parsed.output);
         let has_tool_calls = content
             .iter()
             .any(|part| matches!(part, ContentPart::ToolCall { .. }));
@@ -222,6 +240,7 @@ impl LanguageModel for OpenAI {
             usage,
             warnings,
             provider_metadata: Some(serde_json::json!({ "id": parsed.id })),
+            logprobs,
         })
     }
 
@@ -315,6 +334,14 @@ impl LanguageModel for OpenAI {
                                                     text: delta,
                                                 }));
                                             }
+                                            if let Some(logprobs) = event
+                                                .logprobs
+                                                .filter(|logprobs| !logprobs.is_empty())
+                                            {
+                                                buffer.push_back(Ok(StreamChunk::Logprobs {
+                                                    logprobs,
+                                                }));
+                                            }
                                         }
                                         "response.reasoning_text.delta" => {
                                             if let Some(delta) = event.delta {
@@ -14,8 +14,8 @@ use crate::contracts::GenerateResponse;
 #[cfg(all(feature = "provider-openai", feature = "cap-llm-streaming"))]
 use crate::contracts::StreamChunk;
 use crate::contracts::{
-    ContentPart, FileSource, FinishReason, GenerateRequest, ImageSource, Message, Role, Tool,
-    ToolChoice, Usage, Warning,
+    ContentPart, FileSource, FinishReason, GenerateRequest, ImageSource, Message, Role,
+    TokenLogprob, Tool, ToolChoice, Usage, Warning,
 };
 use crate::error::Result;
 #[cfg(feature = "provider-openai")]
@@ -444,6 +444,15 @@ fn tool_call_arguments_to_openai_string(arguments: &Value) -> String {
     }
 }
 
+/// Chat Completions `input_audio.format` for an audio file part.
+fn input_audio_format(media_type: &str) -> Option<&'static str> {
+    match media_type {
+        "audio/wav" | "audio/x-wav" | "audio/wave" => Some("wav"),
+        "audio/mpeg" | "audio/mp3" => Some("mp3"),
+        _ => None,
+    }
+}
+
 pub(crate) fn messages_to_chat_messages(
     messages: &[Message],
     _model: &str,
@@ -504,6 +513,16 @@ pub(crate) fn messages_to_chat_messages(
                             media_type,
                             source,
                         } => {
+                            if let (Some(format), FileSource::Base64 { data }) =
+                                (input_audio_format(media_type), source)
+                            {
+                                has_non_text = true;
+                                parts.push(serde_json::json!({
+                                    "type": "input_audio",
+                                    "input_audio": { "data": data, "format": format }
+                                }));
+                                continue;
+                            }
                             if media_type != "application/pdf" {
                                 warnings.push(Warning::Unsupported {
                                     feature: "file".to_string(),
@@ -941,6 +960,21 @@ struct ChatChoice {
     message: ChatMessage,
     #[serde(default)]
     finish_reason: Option<String>,
+    #[serde(default)]
+    logprobs: Option<ChatLogprobs>,
+}
+
+/// `choices[].logprobs` of a Chat Completions response or chunk.
+#[derive(Debug, serde::Deserialize, Default)]
+pub(crate) struct ChatLogprobs {
+    #[serde(default)]
+    pub(crate) content: Option<Vec<TokenLogprob>>,
+}
+
+impl ChatLogprobs {
+    pub(crate) fn tokens(&self) -> Option<Vec<TokenLogprob>> {
+        self.content.clone().filter(|content| !content.is_empty())
+    }
 }
 
 #[derive(Debug, Deserialize, Default)]
@@ -1009,6 +1043,8 @@ struct ChatChoiceChunk {
     delta: ChatDelta,
     #[serde(default)]
     finish_reason: Option<String>,
+    #[serde(default)]
+    logprobs: Option<ChatLogprobs>,
 }
 
 #[cfg(feature = "cap-llm-streaming")]
@@ -1197,6 +1233,10 @@ fn parse_stream_data(state: &mut StreamState, data: &str) -> Result<(Vec<StreamC
         });
     }
 
+    if let Some(logprobs) = choice.logprobs.as_ref().and_then(ChatLogprobs::tokens) {
+        out.push(StreamChunk::Logprobs { logprobs });
+    }
+
     if let Some(tool_calls) = choice.delta.tool_calls.as_ref() {
         for tool_call in tool_calls {
             let idx = tool_call.index;
@@ -1467,6 +1507,7 @@ where
     }
 
     let usage = parsed.usage.as_ref().map(parse_usage).unwrap_or_default();
+    let logprobs = choice.logprobs.as_ref().and_then(ChatLogprobs::tokens);
     let finish_reason = parse_finish_reason(choice.finish_reason.as_deref());
 
     Ok(GenerateResponse {
@@ -1475,6 +1516,7 @@ where
         usage,
         warnings,
         provider_metadata: Some(serde_json::json!({ "id": parsed.id, "model": parsed.model })),
+        logprobs,
     })
 }
 
@@ -463,6 +463,35 @@ mod tests {
         );
     }
 
+    #[test]
+    fn converts_audio_file_part_to_chat_input_audio() {
+        let messages = vec![Message {
+            role: Role::User,
+            content: vec![ContentPart::File {
+                filename: None,
+                media_type: "audio/mpeg".to_string(),
+                source: FileSource::Base64 {
+                    data: "SUQz".to_string(),
+                },
+            }],
+        }];
+
+        let (mapped, warnings) =
+            OpenAICompatible::messages_to_chat_messages(&messages, "gpt-4o", Default::default());
+        assert!(warnings.is_empty());
+        let content = mapped[0]
+            .get("content")
+            .and_then(Value::as_array)
+            .expect("content array");
+        assert_eq!(
+            content[0],
+            serde_json::json!({
+                "type": "input_audio",
+                "input_audio": { "data": "SUQz", "format": "mp3" }
+            })
+        );
+    }
+
     #[cfg(feature = "cap-llm-streaming")]
     #[test]
     fn parses_streaming_tool_call_deltas() -> Result<()> {
@@ -15,6 +15,8 @@ struct ChatChoice {
     message: ChatMessage,
     #[serde(default)]
     finish_reason: Option<String>,
+    #[serde(default)]
+    logprobs: Option<ChatLogprobs>,
 }
 
 #[derive(Debug, Deserialize, Default)]
@@ -77,6 +79,8 @@ struct ChatChoiceChunk {
     delta: ChatDelta,
     #[serde(default)]
     finish_reason: Option<String>,
+    #[serde(default)]
+    logprobs: Option<ChatLogprobs>,
 }
 
 #[cfg(feature = "cap-llm-streaming")]
@@ -251,6 +255,10 @@ fn parse_stream_data(state: &mut StreamState, data: &str) -> Result<(Vec<StreamC
             });
         }
 
+    if let Some(logprobs) = choice.logprobs.as_ref().and_then(ChatLogprobs::tokens) {
+        out.push(StreamChunk::Logprobs { logprobs });
+    }
+
     if let Some(tool_calls) = choice.delta.tool_calls.as_ref() {
         for tool_call in tool_calls {
             let idx = tool_call.index;
@@ -524,6 +532,7 @@ impl LanguageModel for OpenAICompatible {
             .unwrap_or_default();
 
         let finish_reason = Self::parse_finish_reason(choice.finish_reason.as_deref());
+        let logprobs = choice.logprobs.as_ref().and_then(ChatLogprobs::tokens);
 
         Ok(GenerateResponse {
             content,
@@ -531,6 +540,7 @@ impl LanguageModel for OpenAICompatible {
             usage,
             warnings,
             provider_metadata: Some(serde_json::json!({ "id": parsed.id, "model": parsed.model })),
+            logprobs,
         })
     }
 
@@ -647,6 +657,37 @@ impl LanguageModel for OpenAICompatible {
 mod chat_completions_tests {
     use super::*;
 
+    #[test]
+    fn streaming_logprobs_emit_logprobs_chunk() {
+        let mut state = StreamState::default();
+
+        let data = r#"{
+            "id": "resp_1",
+            "choices": [{
+                "delta": {"content": "Hi"},
+                "logprobs": {"content": [{
+                    "token": "Hi",
+                    "logprob": -0.25,
+                    "bytes": [72, 105],
+                    "top_logprobs": [{"token": "Hello", "logprob": -1.5, "bytes": null}]
+                }]}
+            }]
+        }"#;
+        let (chunks, _) = parse_stream_data(&mut state, data).unwrap();
+        let logprobs = chunks
+            .iter()
+            .find_map(|c| match c {
+                StreamChunk::Logprobs { logprobs } => Some(logprobs),
+                _ => None,
+            })
+            .expect("logprobs chunk");
+        assert_eq!(logprobs[0].token, "Hi");
+        assert_eq!(logprobs[0].logprob, -0.25);
+        assert_eq!(logprobs[0].bytes.as_deref(), Some(&[72u8, 105][..]));
+        assert_eq!(logprobs[0].top_logprobs[0].token, "Hello");
+        assert_eq!(logprobs[0].top_logprobs[0].bytes, None);
+    }
+
     #[test]
     fn streaming_finish_reason_can_arrive_before_text() {
         let mut state = StreamState::default();
@@ -732,6 +773,31 @@ mod chat_completions_tests {
 mod chat_completions_generate_tests {
     use super::*;
 
+    #[test]
+    fn choice_logprobs_parse_into_token_logprobs() {
+        let raw = r#"{
+            "id": "resp_1",
+            "choices": [{
+                "message": {"content": "OK"},
+                "logprobs": {"content": [{"token": "OK", "logprob": -0.01, "top_logprobs": []}]}
+            }]
+        }"#;
+        let parsed = serde_json::from_str::<ChatCompletionsResponse>(raw).unwrap();
+        let logprobs = parsed.choices[0]
+            .logprobs
+            .as_ref()
+            .and_then(ChatLogprobs::tokens)
+            .expect("logprobs");
+        assert_eq!(logprobs.len(), 1);
+        assert_eq!(logprobs[0].token, "OK");
+        assert!(logprobs[0].top_logprobs.is_empty());
+
+        let raw =
+            r#"{"id": "resp_1", "choices": [{"message": {"content": "OK"}, "logprobs": null}]}"#;
+        let parsed = serde_json::from_str::<ChatCompletionsResponse>(raw).unwrap();
+        assert!(parsed.choices[0].logprobs.is_none());
+    }
+
     #[test]
     fn message_reasoning_content_parses_into_reasoning_part() {
         let raw = r#"{
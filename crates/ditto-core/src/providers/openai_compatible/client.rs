@@ -23,7 +23,7 @@ use crate::error::Result;
 use crate::llm_core::model::{LanguageModel, StreamResult};
 #[cfg(test)]
 use crate::providers::openai_chat_completions_core::{
-    OPENAI_CHAT_COMPLETIONS_DUMMY_THOUGHT_SIGNATURE,
+    ChatLogprobs, OPENAI_CHAT_COMPLETIONS_DUMMY_THOUGHT_SIGNATURE,
     messages_to_chat_messages as shared_messages_to_chat_messages,
     split_tool_call_id_and_thought_signature,
 };
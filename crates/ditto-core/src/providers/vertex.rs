@@ -248,7 +248,10 @@ impl LanguageModel for Vertex {
         crate::types::warn_unsupported_generate_request_options(
             "Vertex GenAI",
             &request,
-            crate::types::GenerateRequestSupport::NONE,
+            crate::types::GenerateRequestSupport {
+                logprobs: true,
+                ..crate::types::GenerateRequestSupport::NONE
+            },
             &mut warnings,
         );
 
@@ -302,6 +305,7 @@ impl LanguageModel for Vertex {
                 );
             }
         }
+        genai::insert_logprobs_config(&request, &mut generation_config);
         if !generation_config.is_empty() {
             body.insert(
                 "generationConfig".to_string(),
@@ -337,7 +341,12 @@ impl LanguageModel for Vertex {
         crate::provider_options::merge_provider_options_into_body(
             &mut body,
             selected_provider_options.as_ref(),
-            &["reasoning_effort", "response_format", "parallel_tool_calls"],
+            &[
+                "reasoning_effort",
+                "response_format",
+                "parallel_tool_calls",
+                "cache_control",
+            ],
             "generate.provider_options",
             &mut warnings,
         );
@@ -381,6 +390,10 @@ impl LanguageModel for Vertex {
             usage,
             warnings,
             provider_metadata: None,
+            logprobs: parsed
+                .candidates
+                .first()
+                .and_then(genai::parse_candidate_logprobs),
         })
     }
 
@@ -415,7 +428,10 @@ impl LanguageModel for Vertex {
             crate::types::warn_unsupported_generate_request_options(
                 "Vertex GenAI",
                 &request,
-                crate::types::GenerateRequestSupport::NONE,
+                crate::types::GenerateRequestSupport {
+                    logprobs: true,
+                    ..crate::types::GenerateRequestSupport::NONE
+                },
                 &mut warnings,
             );
 
@@ -472,6 +488,7 @@ impl LanguageModel for Vertex {
                     );
                 }
             }
+            genai::insert_logprobs_config(&request, &mut generation_config);
             if !generation_config.is_empty() {
                 body.insert(
                     "generationConfig".to_string(),
@@ -502,7 +519,12 @@ impl LanguageModel for Vertex {
             crate::provider_options::merge_provider_options_into_body(
                 &mut body,
                 selected_provider_options.as_ref(),
-                &["reasoning_effort", "response_format", "parallel_tool_calls"],
+                &[
+                    "reasoning_effort",
+                    "response_format",
+                    "parallel_tool_calls",
+                    "cache_control",
+                ],
                 "stream.provider_options",
                 &mut warnings,
             );
@@ -629,6 +651,13 @@ impl LanguageModel for Vertex {
                                                 }));
                                             }
                                         }
+
+                                        if let Some(logprobs) =
+                                            genai::parse_candidate_logprobs(candidate)
+                                        {
+                                            buffer
+                                                .push_back(Ok(StreamChunk::Logprobs { logprobs }));
+                                        }
                                     }
                                 }
                                 Err(err) => {
@@ -156,6 +156,18 @@ pub(super) async fn build_language_model(
                 Err(provider_feature_missing("vertex"))
             }
         }
+        "mock" => {
+            #[cfg(feature = "provider-mock")]
+            {
+                Ok(Arc::new(
+                    crate::providers::mock::MockProvider::from_config(_config, _env).await?,
+                ))
+            }
+            #[cfg(not(feature = "provider-mock"))]
+            {
+                Err(provider_feature_missing("mock"))
+            }
+        }
         other => Err(unsupported_provider_backend(other)),
     }
 }
@@ -87,12 +87,13 @@ fn direct_builtin_builder_provider(provider: &str) -> Option<&'static str> {
         // runtime even when they do not have dedicated catalog truth.
         "openai_compatible" | "litellm" | "azure" | "azure-openai" | "azure_openai" | "qwen"
         | "groq" | "mistral" | "together" | "together-ai" | "together_ai" | "fireworks"
-        | "perplexity" | "ollama" => Some("openai-compatible"),
+        | "perplexity" | "ollama" | "vllm" => Some("openai-compatible"),
         // These backends are buildable today even though they do not yet expose
         // a builtin runtime catalog plugin.
         "cohere" => Some("cohere"),
         "bedrock" => Some("bedrock"),
         "vertex" => Some("vertex"),
+        "mock" => Some("mock"),
         _ => None,
     }
 }
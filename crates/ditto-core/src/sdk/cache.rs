@@ -414,10 +414,27 @@ fn approx_generate_response_bytes(resp: &GenerateResponse) -> usize {
             .map(approx_json_value_bytes)
             .unwrap_or(0),
     );
+    total = total.saturating_add(
+        resp.logprobs
+            .as_deref()
+            .map(approx_logprobs_bytes)
+            .unwrap_or(0),
+    );
     total = total.saturating_add(64);
     total
 }
 
+fn approx_logprobs_bytes(logprobs: &[crate::contracts::TokenLogprob]) -> usize {
+    logprobs.iter().fold(0usize, |total, logprob| {
+        let top = logprob.top_logprobs.iter().fold(0usize, |total, top| {
+            total.saturating_add(top.token.len() + 16)
+        });
+        total
+            .saturating_add(logprob.token.len() + 16)
+            .saturating_add(top)
+    })
+}
+
 fn approx_stream_chunk_bytes(chunk: &StreamChunk) -> usize {
     match chunk {
         StreamChunk::Warnings { warnings } => warnings.iter().fold(0usize, |total, warning| {
@@ -431,6 +448,7 @@ fn approx_stream_chunk_bytes(chunk: &StreamChunk) -> usize {
             arguments_delta,
         } => id.len().saturating_add(arguments_delta.len()),
         StreamChunk::ReasoningDelta { text } => text.len(),
+        StreamChunk::Logprobs { logprobs } => approx_logprobs_bytes(logprobs),
         StreamChunk::FinishReason(_) => 16,
         StreamChunk::Usage(_) => 64,
     }
@@ -583,6 +601,7 @@ mod tests {
                 usage: Usage::default(),
                 warnings: Vec::new(),
                 provider_metadata: None,
+                logprobs: None,
             })
         }
 
@@ -658,6 +677,7 @@ mod tests {
                     usage: Usage::default(),
                     warnings: Vec::new(),
                     provider_metadata: None,
+                    logprobs: None,
                 })
             }
 
@@ -760,6 +780,7 @@ mod tests {
                     usage: Usage::default(),
                     warnings: Vec::new(),
                     provider_metadata: None,
+                    logprobs: None,
                 })
             }
 
@@ -830,6 +851,7 @@ mod tests {
             usage: Usage::default(),
             warnings: Vec::new(),
             provider_metadata: None,
+            logprobs: None,
         };
         let now = Instant::now();
 
@@ -875,6 +897,7 @@ mod tests {
             usage: Usage::default(),
             warnings: Vec::new(),
             provider_metadata: None,
+            logprobs: None,
         };
         let now = Instant::now();
         let mut state = CacheState {
@@ -910,6 +933,7 @@ mod tests {
             usage: Usage::default(),
             warnings: Vec::new(),
             provider_metadata: None,
+            logprobs: None,
         };
 
         let layer = CacheLayer::new().with_ttl(Duration::from_secs(5));
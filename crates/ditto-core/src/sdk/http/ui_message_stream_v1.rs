@@ -307,6 +307,7 @@ impl UiStreamState {
                     "delta": text,
                 }));
             }
+            StreamChunk::Logprobs { .. } => {}
             StreamChunk::FinishReason(reason) => {
                 self.finish_reason = reason;
             }
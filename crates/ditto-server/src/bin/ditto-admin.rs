@@ -0,0 +1,721 @@
+use ditto_core::resources::{MESSAGE_CATALOG, bootstrap_cli_runtime_from_args_with_defaults};
+use i18n_kit::{Locale, TemplateArg};
+#[cfg(feature = "gateway")]
+use serde_json::{Value, json};
+
+#[cfg(feature = "gateway")]
+const MAX_RESPONSE_BYTES: usize = 16 * 1024 * 1024;
+
+#[cfg(feature = "gateway")]
+#[tokio::main]
+async fn main() {
+    let raw_args = std::env::args().skip(1).collect::<Vec<_>>();
+    if let Err(err) = bootstrap_cli_runtime_from_args_with_defaults(
+        &raw_args,
+        ditto_server::data_root::default_server_data_root_files(),
+    ) {
+        eprintln!("{err:?}");
+        std::process::exit(2);
+    }
+    let (locale, args) = match MESSAGE_CATALOG.resolve_cli_locale(raw_args, "DITTO_LOCALE") {
+        Ok(parsed) => parsed,
+        Err(err) => {
+            eprintln!("{err}");
+            std::process::exit(2);
+        }
+    };
+
+    if let Err(err) = run(locale, args).await {
+        eprintln!("{}", render_error(err.as_ref(), locale));
+        std::process::exit(1);
+    }
+}
+
+#[cfg(feature = "gateway")]
+#[derive(Default)]
+struct AdminArgs {
+    command: Vec<String>,
+    id: Option<String>,
+    token: Option<String>,
+    tenant_id: Option<String>,
+    project_id: Option<String>,
+    user_id: Option<String>,
+    tags: Vec<String>,
+    disabled: bool,
+    id_prefix: Option<String>,
+    enabled: Option<bool>,
+    group_by: Option<String>,
+    model: Option<String>,
+    tag: Option<String>,
+    key_id: Option<String>,
+    bucket: Option<String>,
+    since_ts_ms: Option<u64>,
+    before_ts_ms: Option<u64>,
+    limit: Option<usize>,
+    file: Option<String>,
+    prune: bool,
+    dry_run: bool,
+}
+
+#[cfg(feature = "gateway")]
+async fn run(locale: Locale, raw_args: Vec<String>) -> Result<(), Box<dyn std::error::Error>> {
+    let usage = admin_cli_usage(locale);
+    let mut args = raw_args.into_iter();
+
+    let mut base_url: Option<String> = None;
+    let mut admin_token: Option<String> = None;
+    let mut admin_token_env: Option<String> = None;
+    let mut parsed = AdminArgs::default();
+
+    while let Some(arg) = args.next() {
+        match arg.as_str() {
+            "--base-url" => {
+                base_url = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--base-url"))?,
+                )
+            }
+            "--admin-token" => {
+                admin_token = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--admin-token"))?,
+                )
+            }
+            "--admin-token-env" => {
+                admin_token_env = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--admin-token-env"))?,
+                )
+            }
+            "--id" => {
+                parsed.id = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--id"))?,
+                )
+            }
+            "--token" => {
+                parsed.token = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--token"))?,
+                )
+            }
+            "--tenant-id" => {
+                parsed.tenant_id = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--tenant-id"))?,
+                )
+            }
+            "--project-id" => {
+                parsed.project_id = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--project-id"))?,
+                )
+            }
+            "--user-id" => {
+                parsed.user_id = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--user-id"))?,
+                )
+            }
+            "--tag" => {
+                let tag = args
+                    .next()
+                    .ok_or_else(|| cli_missing_value(locale, "--tag"))?;
+                parsed.tag = Some(tag.clone());
+                parsed.tags.push(tag);
+            }
+            "--disabled" => parsed.disabled = true,
+            "--id-prefix" => {
+                parsed.id_prefix = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--id-prefix"))?,
+                )
+            }
+            "--enabled" => {
+                parsed.enabled = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--enabled"))?
+                        .parse()
+                        .map_err(|_| cli_invalid_value(locale, "--enabled"))?,
+                )
+            }
+            "--group-by" => {
+                parsed.group_by = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--group-by"))?,
+                )
+            }
+            "--model" => {
+                parsed.model = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--model"))?,
+                )
+            }
+            "--key-id" => {
+                parsed.key_id = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--key-id"))?,
+                )
+            }
+            "--bucket" => {
+                parsed.bucket = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--bucket"))?,
+                )
+            }
+            "--since-ts-ms" => {
+                parsed.since_ts_ms = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--since-ts-ms"))?
+                        .parse()
+                        .map_err(|_| cli_invalid_value(locale, "--since-ts-ms"))?,
+                )
+            }
+            "--before-ts-ms" => {
+                parsed.before_ts_ms = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--before-ts-ms"))?
+                        .parse()
+                        .map_err(|_| cli_invalid_value(locale, "--before-ts-ms"))?,
+                )
+            }
+            "--limit" => {
+                parsed.limit = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--limit"))?
+                        .parse()
+                        .map_err(|_| cli_invalid_value(locale, "--limit"))?,
+                )
+            }
+            "--file" => {
+                parsed.file = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--file"))?,
+                )
+            }
+            "--prune" => parsed.prune = true,
+            "--dry-run" => parsed.dry_run = true,
+            "--help" | "-h" => {
+                println!("{usage}");
+                return Ok(());
+            }
+            other if other.starts_with("--") => {
+                return Err(cli_unknown_arg(locale, other, Some(&usage)).into());
+            }
+            _ => parsed.command.push(arg),
+        }
+    }
+
+    let base_url = base_url.ok_or_else(|| usage.clone())?;
+    let admin_token = match (admin_token, admin_token_env) {
+        (Some(token), _) => token,
+        (None, Some(env)) => std::env::var(&env)
+            .map_err(|err| admin_cli_failed_to_read_admin_token(locale, &format!("{env}:{err}")))?,
+        (None, None) => return Err(admin_cli_missing_admin_token(locale).into()),
+    };
+    let client = AdminClient {
+        http: reqwest::Client::new(),
+        base_url: base_url.trim_end_matches('/').to_string(),
+        admin_token,
+        locale,
+    };
+
+    let command = parsed
+        .command
+        .iter()
+        .map(String::as_str)
+        .collect::<Vec<_>>();
+    let output = match command.as_slice() {
+        ["keys", "list"] => keys_list(&client, &parsed).await?,
+        ["keys", "create"] => keys_create(&client, &parsed, &usage).await?,
+        ["keys", "revoke"] => keys_revoke(&client, &parsed, &usage).await?,
+        ["spend", "report"] => spend_report(&client, &parsed).await?,
+        ["models", "list"] => models_list(&client).await?,
+        ["apply"] => apply(&client, &parsed, &usage).await?,
+        _ => return Err(usage.into()),
+    };
+    println!("{}", serde_json::to_string_pretty(&output)?);
+    Ok(())
+}
+
+#[cfg(feature = "gateway")]
+struct AdminClient {
+    http: reqwest::Client,
+    base_url: String,
+    admin_token: String,
+    locale: Locale,
+}
+
+#[cfg(feature = "gateway")]
+impl AdminClient {
+    async fn send(
+        &self,
+        method: reqwest::Method,
+        path: &str,
+        query: &[(&str, String)],
+        body: Option<&Value>,
+    ) -> Result<Value, Box<dyn std::error::Error>> {
+        let mut url = reqwest::Url::parse(&format!("{}{}", self.base_url, path))?;
+        if !query.is_empty() {
+            let mut pairs = url.query_pairs_mut();
+            for (name, value) in query {
+                pairs.append_pair(name, value);
+            }
+        }
+        let mut request = self
+            .http
+            .request(method, url)
+            .header("authorization", format!("Bearer {}", self.admin_token));
+        if let Some(body) = body {
+            request = request.json(body);
+        }
+        let response = request.send().await?;
+        let status = response.status();
+        if !status.is_success() {
+            let body = http_kit::read_text_body_limited(response, 64 * 1024)
+                .await
+                .unwrap_or_default();
+            return Err(admin_cli_request_failed(self.locale, &status.to_string(), &body).into());
+        }
+        let bytes = http_kit::read_reqwest_body_bytes_limited(response, MAX_RESPONSE_BYTES)
+            .await
+            .map_err(|err| err.to_string())?;
+        if bytes.is_empty() {
+            return Ok(Value::Null);
+        }
+        Ok(serde_json::from_slice(&bytes)?)
+    }
+}
+
+#[cfg(feature = "gateway")]
+async fn keys_list(
+    client: &AdminClient,
+    args: &AdminArgs,
+) -> Result<Value, Box<dyn std::error::Error>> {
+    let mut query = Vec::new();
+    push_query(&mut query, "tenant_id", args.tenant_id.as_ref());
+    push_query(&mut query, "project_id", args.project_id.as_ref());
+    push_query(&mut query, "user_id", args.user_id.as_ref());
+    push_query(&mut query, "id_prefix", args.id_prefix.as_ref());
+    push_query(&mut query, "enabled", args.enabled.as_ref());
+    push_query(&mut query, "limit", args.limit.as_ref());
+    client
+        .send(reqwest::Method::GET, "/admin/keys", &query, None)
+        .await
+}
+
+/// Creates a key and prints it once with its token. Refuses ids that already
+/// exist, since `POST /admin/keys` would silently overwrite them.
+#[cfg(feature = "gateway")]
+async fn keys_create(
+    client: &AdminClient,
+    args: &AdminArgs,
+    usage: &str,
+) -> Result<Value, Box<dyn std::error::Error>> {
+    let id = args
+        .id
+        .as_deref()
+        .map(str::trim)
+        .filter(|id| !id.is_empty())
+        .ok_or_else(|| usage.to_string())?;
+    let existing = client
+        .send(
+            reqwest::Method::GET,
+            "/admin/keys",
+            &[("id_prefix", id.to_string())],
+            None,
+        )
+        .await?;
+    if existing
+        .as_array()
+        .is_some_and(|keys| keys.iter().any(|key| key["id"].as_str() == Some(id)))
+    {
+        return Err(admin_cli_key_exists(client.locale, id).into());
+    }
+
+    let token = args.token.clone().unwrap_or_else(generate_key_token);
+    let mut key = ditto_server::gateway::VirtualKeyConfig::new(id, token);
+    key.enabled = !args.disabled;
+    key.tenant_id = args.tenant_id.clone();
+    key.project_id = args.project_id.clone();
+    key.user_id = args.user_id.clone();
+    key.tags = args.tags.clone();
+    client
+        .send(
+            reqwest::Method::POST,
+            "/admin/keys",
+            &[],
+            Some(&serde_json::to_value(&key)?),
+        )
+        .await
+}
+
+/// Revoking deletes the key; its spend stays in the audit log.
+#[cfg(feature = "gateway")]
+async fn keys_revoke(
+    client: &AdminClient,
+    args: &AdminArgs,
+    usage: &str,
+) -> Result<Value, Box<dyn std::error::Error>> {
+    let id = args
+        .id
+        .as_deref()
+        .map(str::trim)
+        .filter(|id| !id.is_empty())
+        .ok_or_else(|| usage.to_string())?;
+    client
+        .send(reqwest::Method::DELETE, &key_path(id)?, &[], None)
+        .await?;
+    Ok(json!({ "id": id, "revoked": true }))
+}
+
+/// `/admin/keys/{id}`, with `Url` percent-encoding the id as a single path
+/// segment.
+#[cfg(feature = "gateway")]
+fn key_path(id: &str) -> Result<String, Box<dyn std::error::Error>> {
+    let mut url = reqwest::Url::parse("http://localhost/admin/keys")?;
+    url.path_segments_mut()
+        .map_err(|()| "invalid admin keys url")?
+        .push(id);
+    Ok(url.path().to_string())
+}
+
+/// Makes the gateway's keys match a resources file: creates and updates the
+/// keys it lists and, with `--prune`, deletes the rest. Running it again
+/// without edits changes nothing. Generated tokens of new keys are printed
+/// this once.
+#[cfg(feature = "gateway")]
+async fn apply(
+    client: &AdminClient,
+    args: &AdminArgs,
+    usage: &str,
+) -> Result<Value, Box<dyn std::error::Error>> {
+    use ditto_server::gateway::VirtualKeyConfig;
+
+    let path = args.file.as_deref().ok_or_else(|| usage.to_string())?;
+    let resources = load_resources_file(client.locale, std::path::Path::new(path))?;
+    resources.validate()?;
+
+    let mut tokens = std::collections::BTreeMap::new();
+    for key in &resources.keys {
+        if let Some(env) = key.token_env.as_deref() {
+            let token = std::env::var(env).map_err(|err| {
+                admin_cli_failed_to_read_key_token(client.locale, &key.id, &format!("{env}:{err}"))
+            })?;
+            tokens.insert(key.id.clone(), token);
+        }
+    }
+
+    // Updates rewrite whole keys, so the current tokens are needed to keep
+    // them.
+    let existing = client
+        .send(
+            reqwest::Method::GET,
+            "/admin/keys",
+            &[("include_tokens", "true".to_string())],
+            None,
+        )
+        .await?;
+    let existing = serde_json::from_value::<Vec<VirtualKeyConfig>>(existing)?;
+    let plan = resources.plan(&existing, &tokens, args.prune, generate_key_token)?;
+
+    let ids = |keys: &[VirtualKeyConfig]| keys.iter().map(|key| key.id.clone()).collect::<Vec<_>>();
+    let created = plan
+        .create
+        .iter()
+        .map(|key| {
+            if args.dry_run || tokens.contains_key(&key.id) {
+                json!({ "id": &key.id })
+            } else {
+                json!({ "id": &key.id, "token": &key.token })
+            }
+        })
+        .collect::<Vec<_>>();
+    let output = json!({
+        "dry_run": args.dry_run,
+        "created": created,
+        "updated": ids(&plan.update),
+        "deleted": &plan.delete,
+        "unchanged": &plan.unchanged,
+    });
+    if args.dry_run {
+        return Ok(output);
+    }
+
+    for key in plan.create.iter().chain(&plan.update) {
+        client
+            .send(
+                reqwest::Method::PUT,
+                &key_path(&key.id)?,
+                &[],
+                Some(&serde_json::to_value(key)?),
+            )
+            .await?;
+    }
+    for id in &plan.delete {
+        client
+            .send(reqwest::Method::DELETE, &key_path(id)?, &[], None)
+            .await?;
+    }
+    Ok(output)
+}
+
+#[cfg(feature = "gateway")]
+fn load_resources_file(
+    _locale: Locale,
+    path: &std::path::Path,
+) -> Result<ditto_server::gateway::provisioning::ResourcesFile, Box<dyn std::error::Error>> {
+    use config_kit::{ConfigFormat, ConfigLoadOptions, load_config_document};
+
+    let options = match path.extension().and_then(|ext| ext.to_str()) {
+        Some(ext) if ext.eq_ignore_ascii_case("yaml") || ext.eq_ignore_ascii_case("yml") => {
+            #[cfg(feature = "gateway-config-yaml")]
+            {
+                ConfigLoadOptions::new().with_format(ConfigFormat::Yaml)
+            }
+            #[cfg(not(feature = "gateway-config-yaml"))]
+            {
+                return Err(cli_feature_disabled(
+                    _locale,
+                    "yaml resources",
+                    "--features gateway-config-yaml",
+                )
+                .into());
+            }
+        }
+        _ => ConfigLoadOptions::new().with_format(ConfigFormat::Json),
+    };
+    let document = load_config_document(path, options).map_err(|err| err.to_string())?;
+    document
+        .parse::<ditto_server::gateway::provisioning::ResourcesFile>()
+        .map_err(|err| err.to_string().into())
+}
+
+#[cfg(feature = "gateway")]
+async fn spend_report(
+    client: &AdminClient,
+    args: &AdminArgs,
+) -> Result<Value, Box<dyn std::error::Error>> {
+    let path = match args.group_by.as_deref().unwrap_or("key") {
+        "key" => "/admin/spend",
+        "tenant" | "team" => "/admin/spend/tenants",
+        "project" => "/admin/spend/projects",
+        "user" => "/admin/spend/users",
+        "model" => "/admin/spend/models",
+        "tag" => "/admin/spend/tags",
+        _ => return Err(cli_invalid_value(client.locale, "--group-by").into()),
+    };
+    let mut query = Vec::new();
+    push_query(&mut query, "since_ts_ms", args.since_ts_ms.as_ref());
+    push_query(&mut query, "before_ts_ms", args.before_ts_ms.as_ref());
+    push_query(&mut query, "bucket", args.bucket.as_ref());
+    push_query(&mut query, "key_id", args.key_id.as_ref());
+    push_query(&mut query, "tenant_id", args.tenant_id.as_ref());
+    push_query(&mut query, "project_id", args.project_id.as_ref());
+    push_query(&mut query, "user_id", args.user_id.as_ref());
+    push_query(&mut query, "model", args.model.as_ref());
+    push_query(&mut query, "tag", args.tag.as_ref());
+    push_query(&mut query, "limit", args.limit.as_ref());
+    client.send(reqwest::Method::GET, path, &query, None).await
+}
+
+/// Lists the models the router serves, read from the current config export:
+/// one entry per rule plus `*` for the default backends.
+#[cfg(feature = "gateway")]
+async fn models_list(client: &AdminClient) -> Result<Value, Box<dyn std::error::Error>> {
+    let export = client
+        .send(reqwest::Method::GET, "/admin/config/export", &[], None)
+        .await?;
+    let router = &export["router"];
+    let backend_names = |entry: &Value| {
+        let mut names = entry["backends"]
+            .as_array()
+            .into_iter()
+            .flatten()
+            .filter_map(|backend| backend["backend"].as_str())
+            .collect::<Vec<_>>();
+        if let Some(backend) = entry["backend"].as_str().filter(|name| !name.is_empty()) {
+            names.insert(0, backend);
+        }
+        names
+    };
+
+    let mut models = Vec::new();
+    for rule in router["rules"].as_array().into_iter().flatten() {
+        let Some(model) = rule["model_prefix"].as_str() else {
+            continue;
+        };
+        let matching = if rule["exact"].as_bool().unwrap_or(false) {
+            "exact"
+        } else {
+            "prefix"
+        };
+        models.push(json!({
+            "model": model,
+            "match": matching,
+            "backends": backend_names(rule),
+        }));
+    }
+    let defaults = router["default_backends"]
+        .as_array()
+        .into_iter()
+        .flatten()
+        .filter_map(|backend| backend["backend"].as_str())
+        .collect::<Vec<_>>();
+    if !defaults.is_empty() {
+        models.push(json!({
+            "model": "*",
+            "match": "default",
+            "backends": defaults,
+        }));
+    }
+    Ok(Value::Array(models))
+}
+
+#[cfg(feature = "gateway")]
+fn push_query<T: ToString>(
+    query: &mut Vec<(&'static str, String)>,
+    name: &'static str,
+    value: Option<&T>,
+) {
+    if let Some(value) = value {
+        query.push((name, value.to_string()));
+    }
+}
+
+#[cfg(feature = "gateway")]
+fn generate_key_token() -> String {
+    const HEX: &[u8; 16] = b"0123456789abcdef";
+    let mut bytes = [0u8; 32];
+    if getrandom::fill(&mut bytes).is_err() {
+        let ts_ms = std::time::SystemTime::now()
+            .duration_since(std::time::UNIX_EPOCH)
+            .map(|duration| duration.as_millis())
+            .unwrap_or(0);
+        return format!("sk_fallback_{ts_ms}_{}", std::process::id());
+    }
+    let mut out = String::with_capacity(3 + bytes.len() * 2);
+    out.push_str("sk-");
+    for b in bytes {
+        out.push(HEX[(b >> 4) as usize] as char);
+        out.push(HEX[(b & 0x0f) as usize] as char);
+    }
+    out
+}
+
+#[cfg(feature = "gateway")]
+fn cli_missing_value(locale: Locale, flag: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.missing_value",
+        &[TemplateArg::new("flag", flag)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn cli_invalid_value(locale: Locale, label: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.invalid_value",
+        &[TemplateArg::new("label", label)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn cli_unknown_arg(locale: Locale, arg: &str, usage: Option<&str>) -> String {
+    let message =
+        MESSAGE_CATALOG.render(locale, "cli.unknown_arg", &[TemplateArg::new("arg", arg)]);
+    match usage {
+        Some(usage) if !usage.trim().is_empty() => format!("{message}\n{usage}"),
+        _ => message,
+    }
+}
+
+#[cfg(feature = "gateway")]
+fn admin_cli_usage(locale: Locale) -> String {
+    MESSAGE_CATALOG.render(locale, "admin_cli.usage", &[])
+}
+
+#[cfg(feature = "gateway")]
+fn admin_cli_missing_admin_token(locale: Locale) -> String {
+    MESSAGE_CATALOG.render(locale, "admin_cli.missing_admin_token", &[])
+}
+
+#[cfg(feature = "gateway")]
+fn admin_cli_failed_to_read_admin_token(locale: Locale, error: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "admin_cli.failed_to_read_admin_token",
+        &[TemplateArg::new("error", error)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn admin_cli_request_failed(locale: Locale, status: &str, body: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "admin_cli.request_failed",
+        &[
+            TemplateArg::new("status", status),
+            TemplateArg::new("body", body),
+        ],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn admin_cli_failed_to_read_key_token(locale: Locale, id: &str, error: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "admin_cli.failed_to_read_key_token",
+        &[TemplateArg::new("id", id), TemplateArg::new("error", error)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn admin_cli_key_exists(locale: Locale, id: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "admin_cli.key_exists",
+        &[TemplateArg::new("id", id)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn render_error(error: &(dyn std::error::Error + 'static), locale: Locale) -> String {
+    if let Some(error) = error.downcast_ref::<ditto_core::error::DittoError>() {
+        return error.render(locale);
+    }
+    if let Some(error) = error.downcast_ref::<ditto_core::error::ProviderResolutionError>() {
+        return error.render(locale);
+    }
+    MESSAGE_CATALOG.render(
+        locale,
+        "error.generic",
+        &[TemplateArg::new("error", error.to_string())],
+    )
+}
+
+#[cfg(not(feature = "gateway"))]
+fn main() {
+    eprintln!(
+        "{}",
+        cli_feature_disabled(
+            MESSAGE_CATALOG.default_locale().unwrap_or(Locale::EN_US),
+            "admin",
+            "--features gateway"
+        )
+    );
+    std::process::exit(2);
+}
+
+#[cfg(any(not(feature = "gateway"), not(feature = "gateway-config-yaml")))]
+fn cli_feature_disabled(locale: Locale, feature: &str, rebuild_hint: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.feature_disabled",
+        &[
+            TemplateArg::new("feature", feature),
+            TemplateArg::new("rebuild_hint", rebuild_hint),
+        ],
+    )
+}
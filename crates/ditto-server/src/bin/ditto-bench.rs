@@ -0,0 +1,613 @@
+use ditto_core::resources::{MESSAGE_CATALOG, bootstrap_cli_runtime_from_args_with_defaults};
+use i18n_kit::{Locale, TemplateArg};
+#[cfg(feature = "gateway")]
+use serde_json::{Value, json};
+
+#[cfg(feature = "gateway")]
+const MAX_RESPONSE_BYTES: usize = 16 * 1024 * 1024;
+
+#[cfg(feature = "gateway")]
+#[tokio::main]
+async fn main() {
+    let raw_args = std::env::args().skip(1).collect::<Vec<_>>();
+    if let Err(err) = bootstrap_cli_runtime_from_args_with_defaults(
+        &raw_args,
+        ditto_server::data_root::default_server_data_root_files(),
+    ) {
+        eprintln!("{err:?}");
+        std::process::exit(2);
+    }
+    let (locale, args) = match MESSAGE_CATALOG.resolve_cli_locale(raw_args, "DITTO_LOCALE") {
+        Ok(parsed) => parsed,
+        Err(err) => {
+            eprintln!("{err}");
+            std::process::exit(2);
+        }
+    };
+
+    if let Err(err) = run(locale, args).await {
+        eprintln!("{}", render_error(err.as_ref(), locale));
+        std::process::exit(1);
+    }
+}
+
+#[cfg(feature = "gateway")]
+async fn run(locale: Locale, raw_args: Vec<String>) -> Result<(), Box<dyn std::error::Error>> {
+    use std::sync::Arc;
+    use std::sync::atomic::{AtomicUsize, Ordering};
+    use std::time::{Duration, Instant};
+
+    let usage = bench_usage(locale);
+    let mut args = raw_args.into_iter();
+
+    let mut base_url: Option<String> = None;
+    let mut token: Option<String> = None;
+    let mut token_env: Option<String> = None;
+    let mut model: Option<String> = None;
+    let mut path = "/v1/chat/completions".to_string();
+    let mut prompt = "Write a haiku about load testing.".to_string();
+    let mut max_tokens: Option<u64> = None;
+    let mut stream = false;
+    let mut requests: Option<usize> = None;
+    let mut duration_secs: Option<u64> = None;
+    let mut concurrency = 4usize;
+    let mut qps: Option<f64> = None;
+    let mut output: Option<String> = None;
+
+    while let Some(arg) = args.next() {
+        match arg.as_str() {
+            "--base-url" => {
+                base_url = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--base-url"))?,
+                )
+            }
+            "--token" => {
+                token = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--token"))?,
+                )
+            }
+            "--token-env" => {
+                token_env = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--token-env"))?,
+                )
+            }
+            "--model" => {
+                model = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--model"))?,
+                )
+            }
+            "--path" => {
+                path = args
+                    .next()
+                    .ok_or_else(|| cli_missing_value(locale, "--path"))?
+            }
+            "--prompt" => {
+                prompt = args
+                    .next()
+                    .ok_or_else(|| cli_missing_value(locale, "--prompt"))?
+            }
+            "--max-tokens" => {
+                max_tokens = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--max-tokens"))?
+                        .parse()
+                        .map_err(|_| cli_invalid_value(locale, "--max-tokens"))?,
+                )
+            }
+            "--stream" => stream = true,
+            "--requests" => {
+                requests = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--requests"))?
+                        .parse()
+                        .map_err(|_| cli_invalid_value(locale, "--requests"))?,
+                )
+            }
+            "--duration-secs" => {
+                duration_secs = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--duration-secs"))?
+                        .parse()
+                        .map_err(|_| cli_invalid_value(locale, "--duration-secs"))?,
+                )
+            }
+            "--concurrency" => {
+                concurrency = args
+                    .next()
+                    .ok_or_else(|| cli_missing_value(locale, "--concurrency"))?
+                    .parse()
+                    .map_err(|_| cli_invalid_value(locale, "--concurrency"))?
+            }
+            "--qps" => {
+                qps = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--qps"))?
+                        .parse()
+                        .map_err(|_| cli_invalid_value(locale, "--qps"))?,
+                )
+            }
+            "--output" => {
+                output = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--output"))?,
+                )
+            }
+            "--help" | "-h" => {
+                println!("{usage}");
+                return Ok(());
+            }
+            other => {
+                return Err(cli_unknown_arg(locale, other, Some(&usage)).into());
+            }
+        }
+    }
+
+    let base_url = base_url.ok_or_else(|| usage.clone())?;
+    let model = model.ok_or_else(|| usage.clone())?;
+    if concurrency == 0 {
+        return Err(cli_invalid_value(locale, "--concurrency").into());
+    }
+    if qps.is_some_and(|qps| !qps.is_finite() || qps <= 0.0) {
+        return Err(cli_invalid_value(locale, "--qps").into());
+    }
+    if duration_secs == Some(0) {
+        return Err(cli_invalid_value(locale, "--duration-secs").into());
+    }
+    // Without an explicit budget, run a fixed batch rather than forever.
+    let requests = match (requests, duration_secs) {
+        (None, None) => Some(100),
+        (requests, _) => requests,
+    };
+
+    let token = match (token, token_env) {
+        (Some(token), _) => Some(token),
+        (None, Some(env)) => Some(
+            std::env::var(&env)
+                .map_err(|err| bench_failed_to_read_token(locale, &format!("{env}:{err}")))?,
+        ),
+        (None, None) => None,
+    };
+    let target = Arc::new(BenchTarget {
+        url: format!("{}{}", base_url.trim_end_matches('/'), path),
+        token,
+        body: bench_request_body(&path, &model, &prompt, max_tokens, stream),
+        stream,
+    });
+
+    let client = reqwest::Client::new();
+    let started = Instant::now();
+    let deadline = duration_secs.map(|secs| started + Duration::from_secs(secs));
+    let issued = Arc::new(AtomicUsize::new(0));
+    // QPS pacing: every worker waits for the next shared tick, so the whole run
+    // is open-loop at `qps` as long as concurrency keeps up.
+    let pacer = qps.map(|qps| {
+        let mut interval = tokio::time::interval(Duration::from_secs_f64(1.0 / qps));
+        interval.set_missed_tick_behavior(tokio::time::MissedTickBehavior::Delay);
+        Arc::new(tokio::sync::Mutex::new(interval))
+    });
+
+    let mut workers = Vec::with_capacity(concurrency);
+    for _ in 0..concurrency {
+        let client = client.clone();
+        let target = target.clone();
+        let issued = issued.clone();
+        let pacer = pacer.clone();
+        workers.push(tokio::spawn(async move {
+            let mut samples = Vec::new();
+            loop {
+                if let Some(pacer) = pacer.as_ref() {
+                    pacer.lock().await.tick().await;
+                }
+                if deadline.is_some_and(|deadline| Instant::now() >= deadline) {
+                    break;
+                }
+                let index = issued.fetch_add(1, Ordering::Relaxed);
+                if requests.is_some_and(|requests| index >= requests) {
+                    break;
+                }
+                samples.push(bench_once(&client, &target, index).await);
+            }
+            samples
+        }));
+    }
+    let mut samples = Vec::new();
+    for worker in workers {
+        samples.extend(worker.await?);
+    }
+
+    let report = BenchReport::from_samples(
+        &samples,
+        started.elapsed(),
+        BenchSettings {
+            url: target.url.clone(),
+            model,
+            stream,
+            concurrency,
+            qps,
+        },
+    );
+    let json = serde_json::to_string_pretty(&report)?;
+    if let Some(path) = output.as_deref().filter(|path| *path != "-") {
+        std::fs::write(path, &json)?;
+    }
+    println!("{json}");
+    Ok(())
+}
+
+#[cfg(feature = "gateway")]
+struct BenchTarget {
+    url: String,
+    token: Option<String>,
+    body: Value,
+    stream: bool,
+}
+
+/// Builds a Responses request for `/v1/responses` paths and a Chat
+/// Completions request otherwise.
+#[cfg(feature = "gateway")]
+fn bench_request_body(
+    path: &str,
+    model: &str,
+    prompt: &str,
+    max_tokens: Option<u64>,
+    stream: bool,
+) -> Value {
+    let mut body = if path.trim_end_matches('/').ends_with("/responses") {
+        let mut body = json!({ "model": model, "input": prompt });
+        if let Some(max_tokens) = max_tokens {
+            body["max_output_tokens"] = json!(max_tokens);
+        }
+        body
+    } else {
+        let mut body = json!({
+            "model": model,
+            "messages": [{ "role": "user", "content": prompt }],
+        });
+        if let Some(max_tokens) = max_tokens {
+            body["max_tokens"] = json!(max_tokens);
+        }
+        if stream {
+            body["stream_options"] = json!({ "include_usage": true });
+        }
+        body
+    };
+    body["stream"] = json!(stream);
+    body
+}
+
+#[cfg(feature = "gateway")]
+#[derive(Debug, Default)]
+struct BenchSample {
+    latency_ms: f64,
+    ttft_ms: Option<f64>,
+    output_tokens: Option<u64>,
+    error: Option<String>,
+}
+
+#[cfg(feature = "gateway")]
+async fn bench_once(client: &reqwest::Client, target: &BenchTarget, index: usize) -> BenchSample {
+    use futures_util::StreamExt;
+
+    let mut builder = client
+        .post(&target.url)
+        .header("x-request-id", format!("ditto-bench-{index}"))
+        .json(&target.body);
+    if let Some(token) = target.token.as_deref() {
+        builder = builder.bearer_auth(token);
+    }
+    let started = std::time::Instant::now();
+    let elapsed_ms = || started.elapsed().as_secs_f64() * 1000.0;
+    let mut sample = BenchSample::default();
+    let response = match builder.send().await {
+        Ok(response) => response,
+        Err(err) => {
+            sample.latency_ms = elapsed_ms();
+            sample.error = Some(if err.is_timeout() {
+                "timeout".to_string()
+            } else {
+                "network".to_string()
+            });
+            return sample;
+        }
+    };
+
+    let status = response.status();
+    if !status.is_success() {
+        let _ = http_kit::read_reqwest_body_bytes_limited(response, MAX_RESPONSE_BYTES).await;
+        sample.latency_ms = elapsed_ms();
+        sample.error = Some(format!("http_{}", status.as_u16()));
+        return sample;
+    }
+
+    if !target.stream {
+        let bytes = http_kit::read_reqwest_body_bytes_limited(response, MAX_RESPONSE_BYTES).await;
+        sample.latency_ms = elapsed_ms();
+        match bytes
+            .ok()
+            .and_then(|bytes| serde_json::from_slice::<Value>(&bytes).ok())
+        {
+            Some(value) => sample.output_tokens = usage_output_tokens(&value),
+            None => sample.error = Some("invalid_response".to_string()),
+        }
+        return sample;
+    }
+
+    let mut body = response.bytes_stream();
+    let mut buffer = Vec::<u8>::new();
+    let mut delta_chars = 0usize;
+    while let Some(chunk) = body.next().await {
+        let chunk = match chunk {
+            Ok(chunk) => chunk,
+            Err(_) => {
+                sample.error = Some("stream_interrupted".to_string());
+                break;
+            }
+        };
+        buffer.extend_from_slice(&chunk);
+        while let Some(pos) = buffer.iter().position(|byte| *byte == b'\n') {
+            let line = buffer.drain(..=pos).collect::<Vec<_>>();
+            let Some(data) = std::str::from_utf8(&line)
+                .ok()
+                .map(str::trim)
+                .and_then(|line| line.strip_prefix("data:"))
+                .map(str::trim)
+            else {
+                continue;
+            };
+            if data == "[DONE]" {
+                continue;
+            }
+            let Ok(event) = serde_json::from_str::<Value>(data) else {
+                continue;
+            };
+            if let Some(text) = stream_event_text(&event) {
+                if sample.ttft_ms.is_none() {
+                    sample.ttft_ms = Some(elapsed_ms());
+                }
+                delta_chars += text.chars().count();
+            }
+            if let Some(tokens) = usage_output_tokens(&event)
+                .or_else(|| event.get("response").and_then(usage_output_tokens))
+            {
+                sample.output_tokens = Some(tokens);
+            }
+        }
+    }
+    sample.latency_ms = elapsed_ms();
+    // Upstreams that omit stream usage still get a rough count (~4 chars/token).
+    if sample.output_tokens.is_none() && delta_chars > 0 {
+        sample.output_tokens = Some(delta_chars.div_ceil(4) as u64);
+    }
+    sample
+}
+
+/// The output text carried by one Chat Completions chunk or Responses stream
+/// event, if any.
+#[cfg(feature = "gateway")]
+fn stream_event_text(event: &Value) -> Option<&str> {
+    if let Some(content) = event
+        .pointer("/choices/0/delta/content")
+        .and_then(Value::as_str)
+    {
+        return Some(content).filter(|content| !content.is_empty());
+    }
+    match event.get("type").and_then(Value::as_str) {
+        Some("response.output_text.delta") => event
+            .get("delta")
+            .and_then(Value::as_str)
+            .filter(|delta| !delta.is_empty()),
+        _ => None,
+    }
+}
+
+#[cfg(feature = "gateway")]
+fn usage_output_tokens(value: &Value) -> Option<u64> {
+    let usage = value.get("usage")?;
+    usage
+        .get("completion_tokens")
+        .or_else(|| usage.get("output_tokens"))
+        .and_then(Value::as_u64)
+}
+
+#[cfg(feature = "gateway")]
+#[derive(serde::Serialize)]
+struct BenchSettings {
+    url: String,
+    model: String,
+    stream: bool,
+    concurrency: usize,
+    qps: Option<f64>,
+}
+
+#[cfg(feature = "gateway")]
+#[derive(serde::Serialize)]
+struct BenchReport {
+    settings: BenchSettings,
+    requests: usize,
+    succeeded: usize,
+    failed: usize,
+    duration_ms: u64,
+    achieved_qps: f64,
+    latency_ms: Option<BenchPercentiles>,
+    ttft_ms: Option<BenchPercentiles>,
+    output_tokens: u64,
+    output_tokens_per_sec: f64,
+    errors: std::collections::BTreeMap<String, usize>,
+}
+
+#[cfg(feature = "gateway")]
+#[derive(Debug, serde::Serialize)]
+struct BenchPercentiles {
+    mean: f64,
+    p50: f64,
+    p90: f64,
+    p95: f64,
+    p99: f64,
+    max: f64,
+}
+
+#[cfg(feature = "gateway")]
+impl BenchPercentiles {
+    /// Nearest-rank percentiles; `None` when there are no samples.
+    fn from_values(mut values: Vec<f64>) -> Option<Self> {
+        if values.is_empty() {
+            return None;
+        }
+        values.sort_by(f64::total_cmp);
+        let rank = |percentile: f64| {
+            let rank = (percentile / 100.0 * values.len() as f64).ceil() as usize;
+            round_ms(values[rank.clamp(1, values.len()) - 1])
+        };
+        Some(Self {
+            mean: round_ms(values.iter().sum::<f64>() / values.len() as f64),
+            p50: rank(50.0),
+            p90: rank(90.0),
+            p95: rank(95.0),
+            p99: rank(99.0),
+            max: round_ms(values[values.len() - 1]),
+        })
+    }
+}
+
+#[cfg(feature = "gateway")]
+impl BenchReport {
+    /// Latency percentiles cover successful requests only, so fast failures
+    /// (429s, connection refusals) do not flatter the numbers.
+    fn from_samples(
+        samples: &[BenchSample],
+        elapsed: std::time::Duration,
+        settings: BenchSettings,
+    ) -> Self {
+        let succeeded = samples
+            .iter()
+            .filter(|sample| sample.error.is_none())
+            .collect::<Vec<_>>();
+        let mut errors = std::collections::BTreeMap::new();
+        for error in samples.iter().filter_map(|sample| sample.error.as_ref()) {
+            *errors.entry(error.clone()).or_insert(0) += 1;
+        }
+        let output_tokens = succeeded
+            .iter()
+            .filter_map(|sample| sample.output_tokens)
+            .sum::<u64>();
+        let secs = elapsed.as_secs_f64();
+        let per_sec = |count: f64| {
+            if secs > 0.0 {
+                (count / secs * 100.0).round() / 100.0
+            } else {
+                0.0
+            }
+        };
+        Self {
+            settings,
+            requests: samples.len(),
+            succeeded: succeeded.len(),
+            failed: samples.len() - succeeded.len(),
+            duration_ms: elapsed.as_millis() as u64,
+            achieved_qps: per_sec(samples.len() as f64),
+            latency_ms: BenchPercentiles::from_values(
+                succeeded.iter().map(|sample| sample.latency_ms).collect(),
+            ),
+            ttft_ms: BenchPercentiles::from_values(
+                succeeded
+                    .iter()
+                    .filter_map(|sample| sample.ttft_ms)
+                    .collect(),
+            ),
+            output_tokens,
+            output_tokens_per_sec: per_sec(output_tokens as f64),
+            errors,
+        }
+    }
+}
+
+#[cfg(feature = "gateway")]
+fn round_ms(value: f64) -> f64 {
+    (value * 10.0).round() / 10.0
+}
+
+#[cfg(feature = "gateway")]
+fn cli_missing_value(locale: Locale, flag: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.missing_value",
+        &[TemplateArg::new("flag", flag)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn cli_invalid_value(locale: Locale, label: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.invalid_value",
+        &[TemplateArg::new("label", label)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn cli_unknown_arg(locale: Locale, arg: &str, usage: Option<&str>) -> String {
+    let message =
+        MESSAGE_CATALOG.render(locale, "cli.unknown_arg", &[TemplateArg::new("arg", arg)]);
+    match usage {
+        Some(usage) if !usage.trim().is_empty() => format!("{message}\n{usage}"),
+        _ => message,
+    }
+}
+
+#[cfg(feature = "gateway")]
+fn bench_usage(locale: Locale) -> String {
+    MESSAGE_CATALOG.render(locale, "bench.usage", &[])
+}
+
+#[cfg(feature = "gateway")]
+fn bench_failed_to_read_token(locale: Locale, error: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "bench.failed_to_read_token",
+        &[TemplateArg::new("error", error)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn render_error(error: &(dyn std::error::Error + 'static), locale: Locale) -> String {
+    if let Some(error) = error.downcast_ref::<ditto_core::error::DittoError>() {
+        return error.render(locale);
+    }
+    if let Some(error) = error.downcast_ref::<ditto_core::error::ProviderResolutionError>() {
+        return error.render(locale);
+    }
+    MESSAGE_CATALOG.render(
+        locale,
+        "error.generic",
+        &[TemplateArg::new("error", error.to_string())],
+    )
+}
+
+#[cfg(not(feature = "gateway"))]
+fn main() {
+    eprintln!(
+        "{}",
+        cli_feature_disabled(
+            MESSAGE_CATALOG.default_locale().unwrap_or(Locale::EN_US),
+            "bench",
+            "--features gateway"
+        )
+    );
+    std::process::exit(2);
+}
+
+#[cfg(not(feature = "gateway"))]
+fn cli_feature_disabled(locale: Locale, feature: &str, rebuild_hint: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.feature_disabled",
+        &[
+            TemplateArg::new("feature", feature),
+            TemplateArg::new("rebuild_hint", rebuild_hint),
+        ],
+    )
+}
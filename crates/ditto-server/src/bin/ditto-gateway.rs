@@ -3,9 +3,10 @@ mod ditto_gateway;
 
 #[cfg(feature = "gateway")]
 use ditto_gateway::attach::{
-    ProxyCacheCliOptions, ProxyRoutingCliOptions, attach_devtools, attach_otel,
+    ProxyCacheCliOptions, ProxyRoutingCliOptions, SessionCliOptions, attach_devtools, attach_otel,
     attach_pricing_table, attach_prometheus_metrics, attach_proxy_backpressure, attach_proxy_cache,
-    attach_proxy_max_body_bytes, attach_proxy_routing, attach_proxy_usage_max_body_bytes,
+    attach_proxy_max_body_bytes, attach_proxy_routing, attach_proxy_sse_keepalive_secs,
+    attach_proxy_usage_max_body_bytes, attach_sessions, attach_wasm_plugins,
 };
 
 #[cfg(feature = "gateway")]
@@ -107,9 +108,16 @@ async fn run_gateway(
         redis_prefix,
         audit_retention_secs: _audit_retention_secs,
         db_doctor,
+        virtual_key_master_key_env,
+        migrate_virtual_keys,
+        validate_config,
         backend_specs,
         upstream_specs,
         json_logs,
+        readiness_model_groups,
+        shutdown_drain_secs,
+        secret_refresh_secs,
+        trust_forwarded_for,
         proxy_cache_enabled,
         proxy_cache_ttl_seconds,
         proxy_cache_max_entries,
@@ -117,10 +125,16 @@ async fn run_gateway(
         proxy_cache_max_total_body_bytes,
         proxy_cache_streaming_enabled,
         proxy_cache_max_stream_body_bytes,
+        sessions_enabled,
+        session_ttl_secs,
+        session_max_messages,
+        session_max_bytes,
         proxy_max_body_bytes,
         proxy_usage_max_body_bytes,
+        proxy_sse_keepalive_secs,
         proxy_max_in_flight,
         pricing_litellm_path,
+        pricing_overrides_path,
         prometheus_metrics_enabled,
         prometheus_max_key_series,
         prometheus_max_model_series,
@@ -143,7 +157,10 @@ async fn run_gateway(
         proxy_health_check_path,
         proxy_health_check_interval_secs,
         proxy_health_check_timeout_secs,
+        proxy_fixtures_path,
+        proxy_fixture_mode,
         devtools_path,
+        wasm_plugin_paths,
         otel_enabled,
         otel_endpoint,
         otel_json,
@@ -344,18 +361,71 @@ async fn run_gateway(
         mysql_url.is_some(),
         redis_url.is_some(),
     )?;
+    if (virtual_key_master_key_env.is_some() || migrate_virtual_keys)
+        && _sqlite_path.is_none()
+        && postgres_url.is_none()
+        && mysql_url.is_none()
+        && redis_url.is_none()
+    {
+        let flag = if migrate_virtual_keys {
+            "--migrate-virtual-keys"
+        } else {
+            "--virtual-key-master-key-env"
+        };
+        return Err(cli_requires(
+            locale,
+            flag,
+            "at least one store flag (--sqlite/--pg/--mysql/--redis)",
+        )
+        .into());
+    }
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    let virtual_key_envelope = match virtual_key_master_key_env.as_deref() {
+        Some(key) => {
+            let master_key = env
+                .get(key)
+                .ok_or_else(|| cli_missing_env(locale, "--virtual-key-master-key-env", key))?;
+            if master_key.trim().is_empty() {
+                return Err(cli_env_empty(locale, "virtual key master key env var", key).into());
+            }
+            let master_key =
+                resolve_cli_secret(master_key, &env, "virtual key master key", locale).await?;
+            Some(std::sync::Arc::new(
+                ditto_server::gateway::VirtualKeyEnvelope::from_base64(&master_key)?,
+            ))
+        }
+        None => None,
+    };
 
     let mut config = load_gateway_config(locale, &path)?;
 
+    if validate_config {
+        // Check the file as written: stores and CLI backends are not merged in.
+        config.resolve_env(&env)?;
+        config.resolve_secrets(&env).await?;
+        config.validate()?;
+        println!("{}", cli_config_checks_passed(locale, &path));
+        return Ok(());
+    }
+
     if let Some(_sqlite_path_ref) = _sqlite_path.as_ref() {
         #[cfg(feature = "gateway-store-sqlite")]
         {
             let existed = _sqlite_path_ref.exists();
-            let store = ditto_server::gateway::SqliteStore::new(_sqlite_path_ref);
+            let store = ditto_server::gateway::SqliteStore::new(_sqlite_path_ref)
+                .with_virtual_key_envelope(virtual_key_envelope.clone());
             store.init().await?;
             store.verify_schema().await?;
             if existed {
                 config.virtual_keys = store.load_virtual_keys().await?;
+                if migrate_virtual_keys {
+                    store.replace_virtual_keys(&config.virtual_keys).await?;
+                }
                 if let Some(router) = store.load_router_config().await? {
                     config.router = router;
                 } else {
@@ -380,7 +450,9 @@ async fn run_gateway(
     if let Some(_postgres_url_ref) = postgres_url.as_ref() {
         #[cfg(feature = "gateway-store-postgres")]
         {
-            let store = ditto_server::gateway::PostgresStore::connect(_postgres_url_ref).await?;
+            let store = ditto_server::gateway::PostgresStore::connect(_postgres_url_ref)
+                .await?
+                .with_virtual_key_envelope(virtual_key_envelope.clone());
             store.ping().await?;
             store.init().await?;
             store.verify_schema().await?;
@@ -389,6 +461,9 @@ async fn run_gateway(
             let loaded_router = store.load_router_config().await?;
             if loaded_router.is_some() || !loaded_keys.is_empty() {
                 config.virtual_keys = loaded_keys;
+                if migrate_virtual_keys {
+                    store.replace_virtual_keys(&config.virtual_keys).await?;
+                }
                 if let Some(router) = loaded_router {
                     config.router = router;
                 } else {
@@ -413,7 +488,9 @@ async fn run_gateway(
     if let Some(_mysql_url_ref) = mysql_url.as_ref() {
         #[cfg(feature = "gateway-store-mysql")]
         {
-            let store = ditto_server::gateway::MySqlStore::connect(_mysql_url_ref).await?;
+            let store = ditto_server::gateway::MySqlStore::connect(_mysql_url_ref)
+                .await?
+                .with_virtual_key_envelope(virtual_key_envelope.clone());
             store.ping().await?;
             store.init().await?;
             store.verify_schema().await?;
@@ -422,6 +499,9 @@ async fn run_gateway(
             let loaded_router = store.load_router_config().await?;
             if loaded_router.is_some() || !loaded_keys.is_empty() {
                 config.virtual_keys = loaded_keys;
+                if migrate_virtual_keys {
+                    store.replace_virtual_keys(&config.virtual_keys).await?;
+                }
                 if let Some(router) = loaded_router {
                     config.router = router;
                 } else {
@@ -446,7 +526,8 @@ async fn run_gateway(
     if let Some(_redis_url_ref) = redis_url.as_ref() {
         #[cfg(feature = "gateway-store-redis")]
         {
-            let mut store = ditto_server::gateway::RedisStore::new(_redis_url_ref)?;
+            let mut store = ditto_server::gateway::RedisStore::new(_redis_url_ref)?
+                .with_virtual_key_envelope(virtual_key_envelope.clone());
             if let Some(prefix) = redis_prefix.as_ref() {
                 store = store.with_prefix(prefix.clone());
             }
@@ -455,6 +536,9 @@ async fn run_gateway(
             let loaded_router = store.load_router_config().await?;
             if loaded_router.is_some() || !loaded_keys.is_empty() {
                 config.virtual_keys = loaded_keys;
+                if migrate_virtual_keys {
+                    store.replace_virtual_keys(&config.virtual_keys).await?;
+                }
                 if let Some(router) = loaded_router {
                     config.router = router;
                 } else {
@@ -476,6 +560,14 @@ async fn run_gateway(
         }
     }
 
+    if migrate_virtual_keys {
+        println!(
+            "{}",
+            cli_virtual_keys_migrated(locale, config.virtual_keys.len())
+        );
+        return Ok(());
+    }
+
     if db_doctor {
         if _sqlite_path.is_none()
             && postgres_url.is_none()
@@ -493,6 +585,7 @@ async fn run_gateway(
         return Ok(());
     }
 
+    let mut state_prompts = Vec::new();
     if let Some(state_path) = state_path.as_ref() {
         if state_path.exists() {
             let state = ditto_server::gateway::GatewayStateFile::load(state_path)?;
@@ -500,16 +593,22 @@ async fn run_gateway(
             if let Some(router) = state.router {
                 config.router = router;
             }
+            state_prompts = state.prompts;
         } else {
             ditto_server::gateway::GatewayStateFile {
                 virtual_keys: config.virtual_keys.clone(),
                 router: Some(config.router.clone()),
+                prompts: Vec::new(),
             }
             .save(state_path)?;
         }
     }
 
     config.resolve_env(&env)?;
+    // Keep the secret specs so rotated values can be fetched again later.
+    let unresolved_backends = secret_refresh_secs
+        .filter(|secs| *secs > 0)
+        .map(|secs| (secs, config.backends.clone()));
     config.resolve_secrets(&env).await?;
 
     config.validate()?;
@@ -534,6 +633,36 @@ async fn run_gateway(
                 )
                 .into());
             }
+            if backend.tls.is_some() {
+                return Err(cli_cannot_set_both(
+                    locale,
+                    "backend",
+                    &backend.name,
+                    "tls",
+                    "provider",
+                )
+                .into());
+            }
+            if backend.connect_timeout_seconds.is_some() {
+                return Err(cli_cannot_set_both(
+                    locale,
+                    "backend",
+                    &backend.name,
+                    "connect_timeout_seconds",
+                    "provider",
+                )
+                .into());
+            }
+            if backend.transport.is_some() {
+                return Err(cli_cannot_set_both(
+                    locale,
+                    "backend",
+                    &backend.name,
+                    "transport",
+                    "provider",
+                )
+                .into());
+            }
 
             #[cfg(feature = "gateway-translation")]
             {
@@ -545,7 +674,17 @@ async fn run_gateway(
                 let backend_model = ditto_server::gateway::TranslationBackend::new(provider, model)
                     .with_env(env.clone())
                     .with_provider_config(provider_config)
-                    .with_model_map(backend.model_map.clone());
+                    .with_model_map(backend.model_map.clone())
+                    .with_request_timeout_seconds(backend.timeout_seconds)
+                    .with_first_token_timeout_seconds(backend.first_token_timeout_seconds)
+                    .with_structured_output_max_attempts(
+                        backend
+                            .structured_output
+                            .clone()
+                            .unwrap_or_default()
+                            .max_attempts,
+                    )
+                    .with_prompt_cache(backend.prompt_cache.clone());
                 if translation_backends
                     .insert(backend.name.clone(), backend_model)
                     .is_some()
@@ -573,6 +712,14 @@ async fn run_gateway(
         client = client.with_headers(backend.headers.clone())?;
         client = client.with_query_params(backend.query_params.clone());
         client = client.with_request_timeout_seconds(backend.timeout_seconds);
+        client = client.with_connect_timeout_seconds(backend.connect_timeout_seconds)?;
+        client = client.with_first_token_timeout_seconds(backend.first_token_timeout_seconds);
+        if let Some(tls) = backend.tls.as_ref() {
+            client = client.with_tls(tls)?;
+        }
+        if let Some(transport) = backend.transport.as_ref() {
+            client = client.with_transport(transport)?;
+        }
         if proxy_backends
             .insert(backend.name.clone(), client)
             .is_some()
@@ -589,6 +736,17 @@ async fn run_gateway(
         proxy_backends.insert(name.to_string(), client);
     }
 
+    if let Some(path) = proxy_fixtures_path.as_deref() {
+        let mode = proxy_fixture_mode
+            .as_deref()
+            .and_then(ditto_server::gateway::ProxyFixtureMode::parse)
+            .unwrap_or(ditto_server::gateway::ProxyFixtureMode::Replay);
+        let fixtures = std::sync::Arc::new(ditto_server::gateway::ProxyFixtures::new(path, mode));
+        for client in proxy_backends.values_mut() {
+            *client = client.clone().with_fixtures(fixtures.clone());
+        }
+    }
+
     let mut a2a_agents = std::collections::HashMap::new();
     for agent in &config.a2a_agents {
         let agent_id = agent.agent_id.trim();
@@ -685,6 +843,19 @@ async fn run_gateway(
     if json_logs {
         state = state.with_json_logs();
     }
+    if !readiness_model_groups.is_empty() {
+        state = state.with_readiness_model_groups(readiness_model_groups);
+    }
+    if trust_forwarded_for {
+        state = state.with_trusted_forwarded_for();
+    }
+    if let Some((secs, backends)) = unresolved_backends {
+        state = state.with_backend_secret_refresh(
+            &backends,
+            env.clone(),
+            std::time::Duration::from_secs(secs),
+        );
+    }
     state = attach_proxy_cache(
         state,
         ProxyCacheCliOptions {
@@ -698,10 +869,20 @@ async fn run_gateway(
         },
         locale,
     )?;
+    state = attach_sessions(
+        state,
+        SessionCliOptions {
+            enabled: sessions_enabled,
+            ttl_secs: session_ttl_secs,
+            max_messages: session_max_messages,
+            max_bytes: session_max_bytes,
+        },
+    );
     state = attach_proxy_max_body_bytes(state, proxy_max_body_bytes, locale)?;
     state = attach_proxy_usage_max_body_bytes(state, proxy_usage_max_body_bytes)?;
+    state = attach_proxy_sse_keepalive_secs(state, proxy_sse_keepalive_secs);
     state = attach_proxy_backpressure(state, proxy_max_in_flight, locale)?;
-    state = attach_pricing_table(state, pricing_litellm_path, locale)?;
+    state = attach_pricing_table(state, pricing_litellm_path, pricing_overrides_path, locale)?;
     state = attach_prometheus_metrics(
         state,
         prometheus_metrics_enabled,
@@ -753,12 +934,15 @@ async fn run_gateway(
         None => None,
     };
     if let Some(path) = state_path {
-        state = state.with_state_file(path);
+        state = state
+            .with_state_file(path)
+            .with_prompt_templates(state_prompts);
     }
     #[cfg(feature = "gateway-store-sqlite")]
     if let Some(path) = _sqlite_path {
         let store = ditto_server::gateway::SqliteStore::new(path)
-            .with_audit_retention_secs(effective_audit_retention_secs);
+            .with_audit_retention_secs(effective_audit_retention_secs)
+            .with_virtual_key_envelope(virtual_key_envelope.clone());
         store.verify_schema().await?;
         state = state.with_sqlite_store(store);
     }
@@ -766,7 +950,8 @@ async fn run_gateway(
     if let Some(postgres_url) = postgres_url {
         let store = ditto_server::gateway::PostgresStore::connect(postgres_url)
             .await?
-            .with_audit_retention_secs(effective_audit_retention_secs);
+            .with_audit_retention_secs(effective_audit_retention_secs)
+            .with_virtual_key_envelope(virtual_key_envelope.clone());
         store.verify_schema().await?;
         state = state.with_postgres_store(store);
     }
@@ -774,7 +959,8 @@ async fn run_gateway(
     if let Some(mysql_url) = mysql_url {
         let store = ditto_server::gateway::MySqlStore::connect(mysql_url)
             .await?
-            .with_audit_retention_secs(effective_audit_retention_secs);
+            .with_audit_retention_secs(effective_audit_retention_secs)
+            .with_virtual_key_envelope(virtual_key_envelope.clone());
         store.verify_schema().await?;
         state = state.with_mysql_store(store);
     }
@@ -784,20 +970,103 @@ async fn run_gateway(
         if let Some(prefix) = redis_prefix {
             store = store.with_prefix(prefix);
         }
-        store = store.with_audit_retention_secs(effective_audit_retention_secs);
+        store = store
+            .with_audit_retention_secs(effective_audit_retention_secs)
+            .with_virtual_key_envelope(virtual_key_envelope);
         state = state.with_redis_store(store);
     }
     state = attach_devtools(state, devtools_path, locale)?;
+    state = attach_wasm_plugins(state, wasm_plugin_paths, locale)?;
 
     let _otel_guard = attach_otel(otel_enabled, otel_endpoint.as_deref(), otel_json, locale)?;
 
+    let drain_timeout =
+        std::time::Duration::from_secs(shutdown_drain_secs.unwrap_or(DEFAULT_SHUTDOWN_DRAIN_SECS));
+    let pending_records = state.clone();
     let app = ditto_server::gateway::http::router(state);
     let listener = tokio::net::TcpListener::bind(&listen).await?;
     println!("{}", cli_listening_on(locale, &listen));
-    axum::serve(listener, app).await?;
+
+    // On SIGTERM/Ctrl-C the listener stops accepting and in-flight requests
+    // (including SSE streams) get `drain_timeout` to finish; connections still
+    // open after that are cut when the process exits.
+    let draining = std::sync::Arc::new(tokio::sync::Notify::new());
+    let server = axum::serve(
+        listener,
+        app.into_make_service_with_connect_info::<std::net::SocketAddr>(),
+    )
+    .with_graceful_shutdown({
+        let draining = draining.clone();
+        async move {
+            shutdown_signal().await;
+            eprintln!(
+                "{}",
+                cli_shutdown_message(locale, "cli.shutdown_draining", drain_timeout)
+            );
+            draining.notify_one();
+        }
+    });
+    tokio::select! {
+        result = std::future::IntoFuture::into_future(server) => result?,
+        () = async {
+            draining.notified().await;
+            tokio::time::sleep(drain_timeout).await;
+        } => {
+            eprintln!(
+                "{}",
+                cli_shutdown_message(locale, "cli.shutdown_drain_timeout", drain_timeout)
+            );
+        }
+    }
+
+    if tokio::time::timeout(
+        SHUTDOWN_FLUSH_TIMEOUT,
+        pending_records.flush_pending_records(),
+    )
+    .await
+    .is_err()
+    {
+        eprintln!(
+            "{}",
+            cli_shutdown_message(locale, "cli.shutdown_flush_timeout", SHUTDOWN_FLUSH_TIMEOUT)
+        );
+    }
     Ok(())
 }
 
+#[cfg(feature = "gateway")]
+const DEFAULT_SHUTDOWN_DRAIN_SECS: u64 = 30;
+
+#[cfg(feature = "gateway")]
+const SHUTDOWN_FLUSH_TIMEOUT: std::time::Duration = std::time::Duration::from_secs(10);
+
+/// Resolves on Ctrl-C or, on Unix, SIGTERM (what Kubernetes sends on pod
+/// termination). A handler that fails to install never fires.
+#[cfg(feature = "gateway")]
+async fn shutdown_signal() {
+    let ctrl_c = async {
+        if tokio::signal::ctrl_c().await.is_err() {
+            std::future::pending::<()>().await;
+        }
+    };
+    #[cfg(unix)]
+    let terminate = async {
+        match tokio::signal::unix::signal(tokio::signal::unix::SignalKind::terminate()) {
+            Ok(mut signal) => {
+                signal.recv().await;
+            }
+            Err(_) => std::future::pending::<()>().await,
+        }
+    };
+    #[cfg(not(unix))]
+    let terminate = std::future::pending::<()>();
+
+    tokio::select! {
+        () = ctrl_c => {}
+        () = terminate => {}
+    }
+}
+
 #[cfg(feature = "gateway")]
 fn load_gateway_config(
     locale: Locale,
@@ -1087,6 +1356,24 @@ fn cli_schema_checks_passed(locale: Locale) -> String {
     MESSAGE_CATALOG.render(locale, "cli.schema_checks_passed", &[])
 }
 
+#[cfg(feature = "gateway")]
+fn cli_virtual_keys_migrated(locale: Locale, count: usize) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.virtual_keys_migrated",
+        &[TemplateArg::new("count", count.to_string())],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn cli_config_checks_passed(locale: Locale, path: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.config_checks_passed",
+        &[TemplateArg::new("path", path)],
+    )
+}
+
 #[cfg(feature = "gateway")]
 fn cli_listening_on(locale: Locale, listen: &str) -> String {
     MESSAGE_CATALOG.render(
@@ -1096,6 +1383,15 @@ fn cli_listening_on(locale: Locale, listen: &str) -> String {
     )
 }
 
+#[cfg(feature = "gateway")]
+fn cli_shutdown_message(locale: Locale, key: &str, timeout: std::time::Duration) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        key,
+        &[TemplateArg::new("secs", timeout.as_secs().to_string())],
+    )
+}
+
 #[cfg(feature = "gateway")]
 fn render_error(error: &(dyn std::error::Error + 'static), locale: Locale) -> String {
     if let Some(error) = error.downcast_ref::<ditto_core::error::DittoError>() {
@@ -1169,6 +1465,11 @@ router:
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let err = config
@@ -1189,8 +1490,11 @@ router:
                 base_url: String::new(),
                 max_in_flight: None,
                 timeout_seconds: None,
+                connect_timeout_seconds: None,
+                first_token_timeout_seconds: None,
                 headers: std::collections::BTreeMap::new(),
                 query_params: std::collections::BTreeMap::new(),
+                tls: None,
                 provider: Some("openai-compatible".to_string()),
                 provider_config: Some(ditto_core::config::ProviderConfig {
                     base_url: Some("https://proxy.example/v1".to_string()),
@@ -1198,6 +1502,12 @@ router:
                     ..Default::default()
                 }),
                 model_map: std::collections::BTreeMap::new(),
+                model_info: std::collections::BTreeMap::new(),
+                embeddings_max_batch: None,
+                structured_output: None,
+                prompt_cache: None,
+                transport: None,
+                region: None,
             }],
             virtual_keys: vec![ditto_server::gateway::VirtualKeyConfig::new(
                 "key-1", "vk-1",
@@ -1212,6 +1522,11 @@ router:
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         config
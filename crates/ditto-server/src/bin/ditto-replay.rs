@@ -0,0 +1,585 @@
+use ditto_core::resources::{MESSAGE_CATALOG, bootstrap_cli_runtime_from_args_with_defaults};
+use i18n_kit::{Locale, TemplateArg};
+#[cfg(feature = "gateway")]
+use serde_json::{Map, Value};
+
+#[cfg(feature = "gateway")]
+const MAX_RESPONSE_BYTES: usize = 16 * 1024 * 1024;
+
+#[cfg(feature = "gateway")]
+#[tokio::main]
+async fn main() {
+    let raw_args = std::env::args().skip(1).collect::<Vec<_>>();
+    if let Err(err) = bootstrap_cli_runtime_from_args_with_defaults(
+        &raw_args,
+        ditto_server::data_root::default_server_data_root_files(),
+    ) {
+        eprintln!("{err:?}");
+        std::process::exit(2);
+    }
+    let (locale, args) = match MESSAGE_CATALOG.resolve_cli_locale(raw_args, "DITTO_LOCALE") {
+        Ok(parsed) => parsed,
+        Err(err) => {
+            eprintln!("{err}");
+            std::process::exit(2);
+        }
+    };
+
+    if let Err(err) = run(locale, args).await {
+        eprintln!("{}", render_error(err.as_ref(), locale));
+        std::process::exit(1);
+    }
+}
+
+#[cfg(feature = "gateway")]
+async fn run(locale: Locale, raw_args: Vec<String>) -> Result<(), Box<dyn std::error::Error>> {
+    use std::io::BufRead;
+
+    let usage = replay_usage(locale);
+    let mut args = raw_args.into_iter();
+
+    let mut input: Option<String> = None;
+    let mut base_url: Option<String> = None;
+    let mut candidate_base_url: Option<String> = None;
+    let mut token: Option<String> = None;
+    let mut token_env: Option<String> = None;
+    let mut model: Option<String> = None;
+    let mut limit: Option<usize> = None;
+    let mut output: Option<String> = None;
+
+    while let Some(arg) = args.next() {
+        match arg.as_str() {
+            "--input" => {
+                input = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--input"))?,
+                )
+            }
+            "--base-url" => {
+                base_url = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--base-url"))?,
+                )
+            }
+            "--candidate-base-url" => {
+                candidate_base_url = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--candidate-base-url"))?,
+                )
+            }
+            "--token" => {
+                token = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--token"))?,
+                )
+            }
+            "--token-env" => {
+                token_env = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--token-env"))?,
+                )
+            }
+            "--model" => {
+                model = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--model"))?,
+                )
+            }
+            "--limit" => {
+                limit = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--limit"))?
+                        .parse()
+                        .map_err(|_| cli_invalid_value(locale, "--limit"))?,
+                )
+            }
+            "--output" => {
+                output = Some(
+                    args.next()
+                        .ok_or_else(|| cli_missing_value(locale, "--output"))?,
+                )
+            }
+            "--help" | "-h" => {
+                println!("{usage}");
+                return Ok(());
+            }
+            other => {
+                return Err(cli_unknown_arg(locale, other, Some(&usage)).into());
+            }
+        }
+    }
+
+    let input = input.ok_or_else(|| usage.clone())?;
+    let base_url = base_url.ok_or_else(|| usage.clone())?;
+    if model.is_none() && candidate_base_url.is_none() {
+        return Err(replay_nothing_to_compare(locale).into());
+    }
+
+    let token = match (token, token_env) {
+        (Some(token), _) => Some(token),
+        (None, Some(env)) => Some(
+            std::env::var(&env)
+                .map_err(|err| replay_failed_to_read_token(locale, &format!("{env}:{err}")))?,
+        ),
+        (None, None) => None,
+    };
+    let baseline_target = ReplayTarget {
+        base_url: base_url.trim_end_matches('/').to_string(),
+        token: token.clone(),
+        model: None,
+    };
+    let candidate_target = ReplayTarget {
+        base_url: candidate_base_url
+            .as_deref()
+            .unwrap_or(&base_url)
+            .trim_end_matches('/')
+            .to_string(),
+        token,
+        model,
+    };
+
+    let reader: Box<dyn BufRead> = if input == "-" {
+        Box::new(std::io::BufReader::new(std::io::stdin()))
+    } else {
+        let file = std::fs::File::open(&input)?;
+        Box::new(std::io::BufReader::new(file))
+    };
+
+    let mut requests = Vec::new();
+    let mut seen = std::collections::HashSet::new();
+    let mut skipped = 0usize;
+    for (line_no, line) in reader.lines().enumerate() {
+        let line = line?;
+        let line = line.trim();
+        if line.is_empty() {
+            continue;
+        }
+        let value: Value = serde_json::from_str(line)
+            .map_err(|err| replay_invalid_record(locale, line_no + 1, &err.to_string()))?;
+        let Some(request) = parse_replay_request(value) else {
+            skipped += 1;
+            continue;
+        };
+        // Devtools logs one `proxy.request` per backend attempt; replay the
+        // client request once.
+        if let Some(request_id) = request.request_id.as_ref()
+            && !seen.insert(request_id.clone())
+        {
+            continue;
+        }
+        requests.push(request);
+        if limit.is_some_and(|limit| requests.len() >= limit) {
+            break;
+        }
+    }
+
+    let client = reqwest::Client::new();
+    let mut entries = Vec::with_capacity(requests.len());
+    for (idx, request) in requests.into_iter().enumerate() {
+        let request_id = request
+            .request_id
+            .clone()
+            .unwrap_or_else(|| format!("replay-{}", idx + 1));
+        let baseline = replay_once(
+            &client,
+            &baseline_target,
+            &request,
+            &format!("{request_id}-replay-baseline"),
+        )
+        .await;
+        let candidate = replay_once(
+            &client,
+            &candidate_target,
+            &request,
+            &format!("{request_id}-replay-candidate"),
+        )
+        .await;
+        let output_changed = baseline.output != candidate.output;
+        entries.push(ReplayEntry {
+            request_id,
+            path: request.path,
+            output_changed,
+            baseline,
+            candidate,
+        });
+    }
+
+    let report = ReplayReport {
+        summary: ReplaySummary::from_entries(&entries, skipped),
+        requests: entries,
+    };
+    match output.as_deref() {
+        Some(path) if path != "-" => {
+            std::fs::write(path, serde_json::to_vec_pretty(&report)?)?;
+            println!("{}", serde_json::to_string_pretty(&report.summary)?);
+        }
+        _ => println!("{}", serde_json::to_string_pretty(&report)?),
+    }
+    Ok(())
+}
+
+#[cfg(feature = "gateway")]
+struct ReplayTarget {
+    base_url: String,
+    token: Option<String>,
+    /// Replaces the recorded `model` when set.
+    model: Option<String>,
+}
+
+#[cfg(feature = "gateway")]
+struct ReplayRequest {
+    request_id: Option<String>,
+    path: String,
+    body: Map<String, Value>,
+}
+
+/// Accepts a devtools record (`{"kind":"proxy.request","payload":{...}}`) or a
+/// bare `{"path":"/v1/...","body":{...}}` object. Other devtools events and
+/// non-POST requests are skipped.
+#[cfg(feature = "gateway")]
+fn parse_replay_request(value: Value) -> Option<ReplayRequest> {
+    let Value::Object(mut record) = value else {
+        return None;
+    };
+    if let Some(kind) = record.get("kind").and_then(Value::as_str) {
+        if kind != "proxy.request" {
+            return None;
+        }
+        match record.remove("payload") {
+            Some(Value::Object(payload)) => record = payload,
+            _ => return None,
+        }
+    }
+    if record
+        .get("method")
+        .and_then(Value::as_str)
+        .is_some_and(|method| !method.eq_ignore_ascii_case("POST"))
+    {
+        return None;
+    }
+    let path = record.get("path").and_then(Value::as_str)?;
+    if !path.starts_with("/v1/") {
+        return None;
+    }
+    let path = path.to_string();
+    let Some(Value::Object(body)) = record.remove("body") else {
+        return None;
+    };
+    Some(ReplayRequest {
+        request_id: record
+            .get("request_id")
+            .and_then(Value::as_str)
+            .map(str::to_string),
+        path,
+        body,
+    })
+}
+
+#[cfg(feature = "gateway")]
+#[derive(Debug, Default, serde::Serialize)]
+struct ReplayOutcome {
+    model: Option<String>,
+    status: Option<u16>,
+    latency_ms: u64,
+    cost_usd: Option<f64>,
+    input_tokens: Option<u64>,
+    output_tokens: Option<u64>,
+    output: Option<String>,
+    error: Option<String>,
+}
+
+/// Sends one request non-streaming so outputs and usage can be compared.
+#[cfg(feature = "gateway")]
+async fn replay_once(
+    client: &reqwest::Client,
+    target: &ReplayTarget,
+    request: &ReplayRequest,
+    request_id: &str,
+) -> ReplayOutcome {
+    let mut body = request.body.clone();
+    body.insert("stream".to_string(), Value::Bool(false));
+    body.remove("stream_options");
+    if let Some(model) = target.model.as_ref() {
+        body.insert("model".to_string(), Value::String(model.clone()));
+    }
+    let mut outcome = ReplayOutcome {
+        model: body
+            .get("model")
+            .and_then(Value::as_str)
+            .map(str::to_string),
+        ..ReplayOutcome::default()
+    };
+
+    let mut builder = client
+        .post(format!("{}{}", target.base_url, request.path))
+        .header("x-request-id", request_id)
+        .json(&Value::Object(body));
+    if let Some(token) = target.token.as_deref() {
+        builder = builder.bearer_auth(token);
+    }
+    let started = std::time::Instant::now();
+    let response = match builder.send().await {
+        Ok(response) => response,
+        Err(err) => {
+            outcome.latency_ms = started.elapsed().as_millis() as u64;
+            outcome.error = Some(err.to_string());
+            return outcome;
+        }
+    };
+    let status = response.status();
+    outcome.status = Some(status.as_u16());
+    outcome.cost_usd = response
+        .headers()
+        .get("x-ditto-cost")
+        .and_then(|value| value.to_str().ok())
+        .and_then(|value| value.parse().ok());
+    let bytes = http_kit::read_reqwest_body_bytes_limited(response, MAX_RESPONSE_BYTES).await;
+    outcome.latency_ms = started.elapsed().as_millis() as u64;
+    let value = match bytes {
+        Ok(bytes) => serde_json::from_slice::<Value>(&bytes).ok(),
+        Err(err) => {
+            outcome.error = Some(err.to_string());
+            return outcome;
+        }
+    };
+
+    if !status.is_success() {
+        outcome.error = Some(
+            value
+                .as_ref()
+                .and_then(|value| value.pointer("/error/message"))
+                .and_then(Value::as_str)
+                .map(str::to_string)
+                .unwrap_or_else(|| format!("HTTP {status}")),
+        );
+        return outcome;
+    }
+    if let Some(value) = value.as_ref() {
+        outcome.output = response_output_text(value);
+        let usage = value.get("usage");
+        let tokens = |fields: [&str; 2]| {
+            fields
+                .iter()
+                .find_map(|field| usage.and_then(|usage| usage.get(*field)))
+                .and_then(Value::as_u64)
+        };
+        outcome.input_tokens = tokens(["prompt_tokens", "input_tokens"]);
+        outcome.output_tokens = tokens(["completion_tokens", "output_tokens"]);
+    }
+    outcome
+}
+
+/// The text a response produced: the chat message (or its tool calls), the
+/// completion text, or the Responses output text and function calls.
+#[cfg(feature = "gateway")]
+fn response_output_text(value: &Value) -> Option<String> {
+    if let Some(choice) = value.pointer("/choices/0") {
+        if let Some(content) = choice.pointer("/message/content").and_then(Value::as_str) {
+            return Some(content.to_string());
+        }
+        if let Some(calls) = choice.pointer("/message/tool_calls") {
+            return Some(calls.to_string());
+        }
+        return choice
+            .get("text")
+            .and_then(Value::as_str)
+            .map(str::to_string);
+    }
+    let items = value.get("output").and_then(Value::as_array)?;
+    let mut text = String::new();
+    for item in items {
+        match item.get("type").and_then(Value::as_str) {
+            Some("message") => {
+                for part in item
+                    .get("content")
+                    .and_then(Value::as_array)
+                    .into_iter()
+                    .flatten()
+                {
+                    if let Some(part) = part.get("text").and_then(Value::as_str) {
+                        text.push_str(part);
+                    }
+                }
+            }
+            Some("function_call") => text.push_str(&item.to_string()),
+            _ => {}
+        }
+    }
+    Some(text)
+}
+
+#[cfg(feature = "gateway")]
+#[derive(serde::Serialize)]
+struct ReplayEntry {
+    request_id: String,
+    path: String,
+    output_changed: bool,
+    baseline: ReplayOutcome,
+    candidate: ReplayOutcome,
+}
+
+#[cfg(feature = "gateway")]
+#[derive(serde::Serialize)]
+struct ReplayReport {
+    summary: ReplaySummary,
+    requests: Vec<ReplayEntry>,
+}
+
+#[cfg(feature = "gateway")]
+#[derive(Debug, Default, serde::Serialize)]
+struct ReplaySummary {
+    requests: usize,
+    skipped_records: usize,
+    output_changed: usize,
+    baseline: ReplaySideSummary,
+    candidate: ReplaySideSummary,
+}
+
+#[cfg(feature = "gateway")]
+#[derive(Debug, Default, serde::Serialize)]
+struct ReplaySideSummary {
+    errors: usize,
+    avg_latency_ms: u64,
+    max_latency_ms: u64,
+    input_tokens: u64,
+    output_tokens: u64,
+    cost_usd: f64,
+}
+
+#[cfg(feature = "gateway")]
+impl ReplaySummary {
+    fn from_entries(entries: &[ReplayEntry], skipped_records: usize) -> Self {
+        Self {
+            requests: entries.len(),
+            skipped_records,
+            output_changed: entries.iter().filter(|entry| entry.output_changed).count(),
+            baseline: ReplaySideSummary::from_outcomes(entries.iter().map(|entry| &entry.baseline)),
+            candidate: ReplaySideSummary::from_outcomes(
+                entries.iter().map(|entry| &entry.candidate),
+            ),
+        }
+    }
+}
+
+#[cfg(feature = "gateway")]
+impl ReplaySideSummary {
+    fn from_outcomes<'a>(outcomes: impl Iterator<Item = &'a ReplayOutcome>) -> Self {
+        let mut summary = Self::default();
+        let mut count = 0u64;
+        let mut total_latency_ms = 0u64;
+        for outcome in outcomes {
+            count += 1;
+            total_latency_ms = total_latency_ms.saturating_add(outcome.latency_ms);
+            summary.max_latency_ms = summary.max_latency_ms.max(outcome.latency_ms);
+            if outcome.error.is_some() {
+                summary.errors += 1;
+            }
+            summary.input_tokens += outcome.input_tokens.unwrap_or(0);
+            summary.output_tokens += outcome.output_tokens.unwrap_or(0);
+            summary.cost_usd += outcome.cost_usd.unwrap_or(0.0);
+        }
+        if count > 0 {
+            summary.avg_latency_ms = total_latency_ms / count;
+        }
+        summary
+    }
+}
+
+#[cfg(feature = "gateway")]
+fn cli_missing_value(locale: Locale, flag: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.missing_value",
+        &[TemplateArg::new("flag", flag)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn cli_invalid_value(locale: Locale, label: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.invalid_value",
+        &[TemplateArg::new("label", label)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn cli_unknown_arg(locale: Locale, arg: &str, usage: Option<&str>) -> String {
+    let message =
+        MESSAGE_CATALOG.render(locale, "cli.unknown_arg", &[TemplateArg::new("arg", arg)]);
+    match usage {
+        Some(usage) if !usage.trim().is_empty() => format!("{message}\n{usage}"),
+        _ => message,
+    }
+}
+
+#[cfg(feature = "gateway")]
+fn replay_usage(locale: Locale) -> String {
+    MESSAGE_CATALOG.render(locale, "replay.usage", &[])
+}
+
+#[cfg(feature = "gateway")]
+fn replay_nothing_to_compare(locale: Locale) -> String {
+    MESSAGE_CATALOG.render(locale, "replay.nothing_to_compare", &[])
+}
+
+#[cfg(feature = "gateway")]
+fn replay_failed_to_read_token(locale: Locale, error: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "replay.failed_to_read_token",
+        &[TemplateArg::new("error", error)],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn replay_invalid_record(locale: Locale, line_no: usize, error: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "replay.invalid_record",
+        &[
+            TemplateArg::new("line_no", line_no.to_string()),
+            TemplateArg::new("error", error),
+        ],
+    )
+}
+
+#[cfg(feature = "gateway")]
+fn render_error(error: &(dyn std::error::Error + 'static), locale: Locale) -> String {
+    if let Some(error) = error.downcast_ref::<ditto_core::error::DittoError>() {
+        return error.render(locale);
+    }
+    if let Some(error) = error.downcast_ref::<ditto_core::error::ProviderResolutionError>() {
+        return error.render(locale);
+    }
+    MESSAGE_CATALOG.render(
+        locale,
+        "error.generic",
+        &[TemplateArg::new("error", error.to_string())],
+    )
+}
+
+#[cfg(not(feature = "gateway"))]
+fn main() {
+    eprintln!(
+        "{}",
+        cli_feature_disabled(
+            MESSAGE_CATALOG.default_locale().unwrap_or(Locale::EN_US),
+            "replay",
+            "--features gateway"
+        )
+    );
+    std::process::exit(2);
+}
+
+#[cfg(not(feature = "gateway"))]
+fn cli_feature_disabled(locale: Locale, feature: &str, rebuild_hint: &str) -> String {
+    MESSAGE_CATALOG.render(
+        locale,
+        "cli.feature_disabled",
+        &[
+            TemplateArg::new("feature", feature),
+            TemplateArg::new("rebuild_hint", rebuild_hint),
+        ],
+    )
+}
@@ -86,6 +86,17 @@ pub(crate) fn attach_proxy_usage_max_body_bytes(
     Ok(state.with_proxy_usage_max_body_bytes(max))
 }
 
+#[cfg(feature = "gateway")]
+pub(crate) fn attach_proxy_sse_keepalive_secs(
+    state: ditto_server::gateway::GatewayHttpState,
+    keepalive_secs: Option<u64>,
+) -> ditto_server::gateway::GatewayHttpState {
+    match keepalive_secs {
+        Some(secs) => state.with_proxy_sse_keepalive_seconds(secs),
+        None => state,
+    }
+}
+
 #[cfg(feature = "gateway")]
 #[cfg_attr(not(feature = "gateway-proxy-cache"), allow(dead_code))]
 #[derive(Default)]
@@ -141,6 +152,31 @@ pub(crate) fn attach_proxy_cache(
     Ok(state)
 }
 
+#[cfg(feature = "gateway")]
+#[derive(Default)]
+pub(crate) struct SessionCliOptions {
+    pub(crate) enabled: bool,
+    pub(crate) ttl_secs: Option<u64>,
+    pub(crate) max_messages: Option<usize>,
+    pub(crate) max_bytes: Option<usize>,
+}
+
+#[cfg(feature = "gateway")]
+pub(crate) fn attach_sessions(
+    state: ditto_server::gateway::GatewayHttpState,
+    opts: SessionCliOptions,
+) -> ditto_server::gateway::GatewayHttpState {
+    if !opts.enabled {
+        return state;
+    }
+
+    let mut config = ditto_server::gateway::SessionConfig::default();
+    config.ttl_seconds = opts.ttl_secs.unwrap_or(config.ttl_seconds).max(1);
+    config.max_messages = opts.max_messages.unwrap_or(config.max_messages).max(1);
+    config.max_bytes = opts.max_bytes.unwrap_or(config.max_bytes).max(1);
+    state.with_sessions(config)
+}
+
 #[cfg(feature = "gateway")]
 #[derive(Default)]
 pub(crate) struct ProxyRoutingCliOptions {
@@ -271,17 +307,59 @@ pub(crate) fn attach_proxy_routing(
     Ok(state)
 }
 
+#[cfg(all(feature = "gateway", feature = "gateway-wasm-plugins"))]
+pub(crate) fn attach_wasm_plugins(
+    state: ditto_server::gateway::GatewayHttpState,
+    wasm_plugin_paths: Vec<String>,
+    _locale: Locale,
+) -> Result<ditto_server::gateway::GatewayHttpState, Box<dyn std::error::Error>> {
+    if wasm_plugin_paths.is_empty() {
+        return Ok(state);
+    }
+    let plugins = wasm_plugin_paths
+        .iter()
+        .map(ditto_server::gateway::WasmPlugin::from_file)
+        .collect::<Result<Vec<_>, _>>()?;
+    Ok(state.with_wasm_plugins(plugins))
+}
+
+#[cfg(all(feature = "gateway", not(feature = "gateway-wasm-plugins")))]
+pub(crate) fn attach_wasm_plugins(
+    state: ditto_server::gateway::GatewayHttpState,
+    wasm_plugin_paths: Vec<String>,
+    locale: Locale,
+) -> Result<ditto_server::gateway::GatewayHttpState, Box<dyn std::error::Error>> {
+    if !wasm_plugin_paths.is_empty() {
+        return Err(feature_disabled(
+            locale,
+            "wasm plugins",
+            "--features gateway-wasm-plugins",
+        ));
+    }
+    Ok(state)
+}
+
 #[cfg(all(feature = "gateway", feature = "gateway-costing"))]
 pub(crate) fn attach_pricing_table(
     state: ditto_server::gateway::GatewayHttpState,
     litellm_pricing_path: Option<String>,
+    pricing_overrides_path: Option<String>,
     _locale: Locale,
 ) -> Result<ditto_server::gateway::GatewayHttpState, Box<dyn std::error::Error>> {
-    let Some(path) = litellm_pricing_path else {
+    if litellm_pricing_path.is_none() && pricing_overrides_path.is_none() {
         return Ok(state);
+    }
+    let mut pricing = match litellm_pricing_path {
+        Some(path) => {
+            let raw = std::fs::read_to_string(path)?;
+            ditto_server::gateway::PricingTable::from_litellm_json_str(&raw)?
+        }
+        None => ditto_server::gateway::PricingTable::default(),
     };
-    let raw = std::fs::read_to_string(path)?;
-    let pricing = ditto_server::gateway::PricingTable::from_litellm_json_str(&raw)?;
+    if let Some(path) = pricing_overrides_path {
+        let raw = std::fs::read_to_string(path)?;
+        pricing = pricing.with_litellm_overrides_json_str(&raw)?;
+    }
     Ok(state.with_pricing_table(pricing))
 }
 
@@ -289,9 +367,10 @@ pub(crate) fn attach_pricing_table(
 pub(crate) fn attach_pricing_table(
     state: ditto_server::gateway::GatewayHttpState,
     litellm_pricing_path: Option<String>,
+    pricing_overrides_path: Option<String>,
     locale: Locale,
 ) -> Result<ditto_server::gateway::GatewayHttpState, Box<dyn std::error::Error>> {
-    if litellm_pricing_path.is_some() {
+    if litellm_pricing_path.is_some() || pricing_overrides_path.is_some() {
         return Err(feature_disabled(
             locale,
             "pricing",
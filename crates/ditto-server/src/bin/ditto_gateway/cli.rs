@@ -31,9 +31,16 @@ pub(crate) struct GatewayCliArgs {
     pub redis_prefix: Option<String>,
     pub audit_retention_secs: Option<u64>,
     pub db_doctor: bool,
+    pub virtual_key_master_key_env: Option<String>,
+    pub migrate_virtual_keys: bool,
+    pub validate_config: bool,
     pub backend_specs: Vec<String>,
     pub upstream_specs: Vec<String>,
     pub json_logs: bool,
+    pub readiness_model_groups: Vec<String>,
+    pub shutdown_drain_secs: Option<u64>,
+    pub secret_refresh_secs: Option<u64>,
+    pub trust_forwarded_for: bool,
     pub proxy_cache_enabled: bool,
     pub proxy_cache_ttl_seconds: Option<u64>,
     pub proxy_cache_max_entries: Option<usize>,
@@ -41,10 +48,16 @@ pub(crate) struct GatewayCliArgs {
     pub proxy_cache_max_total_body_bytes: Option<usize>,
     pub proxy_cache_streaming_enabled: bool,
     pub proxy_cache_max_stream_body_bytes: Option<usize>,
+    pub sessions_enabled: bool,
+    pub session_ttl_secs: Option<u64>,
+    pub session_max_messages: Option<usize>,
+    pub session_max_bytes: Option<usize>,
     pub proxy_max_body_bytes: Option<usize>,
     pub proxy_usage_max_body_bytes: Option<usize>,
+    pub proxy_sse_keepalive_secs: Option<u64>,
     pub proxy_max_in_flight: Option<usize>,
     pub pricing_litellm_path: Option<String>,
+    pub pricing_overrides_path: Option<String>,
     pub prometheus_metrics_enabled: bool,
     pub prometheus_max_key_series: Option<usize>,
     pub prometheus_max_model_series: Option<usize>,
@@ -67,7 +80,10 @@ pub(crate) struct GatewayCliArgs {
     pub proxy_health_check_path: Option<String>,
     pub proxy_health_check_interval_secs: Option<u64>,
     pub proxy_health_check_timeout_secs: Option<u64>,
+    pub proxy_fixtures_path: Option<String>,
+    pub proxy_fixture_mode: Option<String>,
     pub devtools_path: Option<String>,
+    pub wasm_plugin_paths: Vec<String>,
     pub otel_enabled: bool,
     pub otel_endpoint: Option<String>,
     pub otel_json: bool,
@@ -130,9 +146,16 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
     )))]
     let audit_retention_secs: Option<u64> = None;
     let mut db_doctor = false;
+    let mut virtual_key_master_key_env: Option<String> = None;
+    let mut migrate_virtual_keys = false;
+    let mut validate_config = false;
     let mut backend_specs: Vec<String> = Vec::new();
     let mut upstream_specs: Vec<String> = Vec::new();
     let mut json_logs = false;
+    let mut readiness_model_groups: Vec<String> = Vec::new();
+    let mut shutdown_drain_secs: Option<u64> = None;
+    let mut secret_refresh_secs: Option<u64> = None;
+    let mut trust_forwarded_for = false;
     let mut proxy_cache_enabled = false;
     let mut proxy_cache_ttl_seconds: Option<u64> = None;
     let mut proxy_cache_max_entries: Option<usize> = None;
@@ -140,10 +163,16 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
     let mut proxy_cache_max_total_body_bytes: Option<usize> = None;
     let mut proxy_cache_streaming_enabled = false;
     let mut proxy_cache_max_stream_body_bytes: Option<usize> = None;
+    let mut sessions_enabled = false;
+    let mut session_ttl_secs: Option<u64> = None;
+    let mut session_max_messages: Option<usize> = None;
+    let mut session_max_bytes: Option<usize> = None;
     let mut proxy_max_body_bytes: Option<usize> = None;
     let mut proxy_usage_max_body_bytes: Option<usize> = None;
+    let mut proxy_sse_keepalive_secs: Option<u64> = None;
     let mut proxy_max_in_flight: Option<usize> = None;
     let mut pricing_litellm_path: Option<String> = None;
+    let mut pricing_overrides_path: Option<String> = None;
     let mut prometheus_metrics_enabled = false;
     let mut prometheus_max_key_series: Option<usize> = None;
     let mut prometheus_max_model_series: Option<usize> = None;
@@ -166,7 +195,10 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
     let mut proxy_health_check_path: Option<String> = None;
     let mut proxy_health_check_interval_secs: Option<u64> = None;
     let mut proxy_health_check_timeout_secs: Option<u64> = None;
+    let mut proxy_fixtures_path: Option<String> = None;
+    let mut proxy_fixture_mode: Option<String> = None;
     let mut devtools_path: Option<String> = None;
+    let mut wasm_plugin_paths: Vec<String> = Vec::new();
     let mut otel_enabled = false;
     let mut otel_endpoint: Option<String> = None;
     let mut otel_json = false;
@@ -283,6 +315,19 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
             "--db-doctor" => {
                 db_doctor = true;
             }
+            "--virtual-key-master-key-env" => {
+                virtual_key_master_key_env = Some(next_value(
+                    &mut args,
+                    locale,
+                    "--virtual-key-master-key-env",
+                )?);
+            }
+            "--migrate-virtual-keys" => {
+                migrate_virtual_keys = true;
+            }
+            "--validate-config" => {
+                validate_config = true;
+            }
             "--backend" => {
                 backend_specs.push(next_value(&mut args, locale, "--backend")?);
             }
@@ -292,6 +337,30 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
             "--json-logs" => {
                 json_logs = true;
             }
+            "--readiness-model-group" => {
+                readiness_model_groups.push(next_value(
+                    &mut args,
+                    locale,
+                    "--readiness-model-group",
+                )?);
+            }
+            "--shutdown-drain-secs" => {
+                shutdown_drain_secs = Some(parse_next::<u64>(
+                    &mut args,
+                    locale,
+                    "--shutdown-drain-secs",
+                )?);
+            }
+            "--secret-refresh-secs" => {
+                secret_refresh_secs = Some(parse_next::<u64>(
+                    &mut args,
+                    locale,
+                    "--secret-refresh-secs",
+                )?);
+            }
+            "--trust-x-forwarded-for" => {
+                trust_forwarded_for = true;
+            }
             "--proxy-cache" => {
                 proxy_cache_enabled = true;
             }
@@ -336,6 +405,30 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
                     "--proxy-cache-max-stream-body-bytes",
                 )?);
             }
+            "--sessions" => {
+                sessions_enabled = true;
+            }
+            "--session-ttl-secs" => {
+                sessions_enabled = true;
+                session_ttl_secs =
+                    Some(parse_next::<u64>(&mut args, locale, "--session-ttl-secs")?);
+            }
+            "--session-max-messages" => {
+                sessions_enabled = true;
+                session_max_messages = Some(parse_next::<usize>(
+                    &mut args,
+                    locale,
+                    "--session-max-messages",
+                )?);
+            }
+            "--session-max-bytes" => {
+                sessions_enabled = true;
+                session_max_bytes = Some(parse_next::<usize>(
+                    &mut args,
+                    locale,
+                    "--session-max-bytes",
+                )?);
+            }
             "--proxy-max-in-flight" => {
                 proxy_max_in_flight = Some(parse_next::<usize>(
                     &mut args,
@@ -357,9 +450,20 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
                     "--proxy-usage-max-body-bytes",
                 )?);
             }
+            "--proxy-sse-keepalive-secs" => {
+                proxy_sse_keepalive_secs = Some(parse_next::<u64>(
+                    &mut args,
+                    locale,
+                    "--proxy-sse-keepalive-secs",
+                )?);
+            }
             "--pricing-litellm" => {
                 pricing_litellm_path = Some(next_value(&mut args, locale, "--pricing-litellm")?);
             }
+            "--pricing-overrides" => {
+                pricing_overrides_path =
+                    Some(next_value(&mut args, locale, "--pricing-overrides")?);
+            }
             "--prometheus-metrics" => {
                 prometheus_metrics_enabled = true;
             }
@@ -493,9 +597,23 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
                     "--proxy-health-check-timeout-secs",
                 )?);
             }
+            "--proxy-fixtures" => {
+                proxy_fixtures_path = Some(next_value(&mut args, locale, "--proxy-fixtures")?);
+            }
+            "--proxy-fixture-mode" => {
+                let raw = next_value(&mut args, locale, "--proxy-fixture-mode")?;
+                let normalized = raw.trim().to_ascii_lowercase();
+                if normalized != "record" && normalized != "replay" {
+                    return Err(invalid_value(locale, "--proxy-fixture-mode"));
+                }
+                proxy_fixture_mode = Some(normalized);
+            }
             "--devtools" => {
                 devtools_path = Some(next_value(&mut args, locale, "--devtools")?);
             }
+            "--wasm-plugin" => {
+                wasm_plugin_paths.push(next_value(&mut args, locale, "--wasm-plugin")?);
+            }
             "--otel" => {
                 otel_enabled = true;
             }
@@ -543,9 +661,16 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
         redis_prefix,
         audit_retention_secs,
         db_doctor,
+        virtual_key_master_key_env,
+        migrate_virtual_keys,
+        validate_config,
         backend_specs,
         upstream_specs,
         json_logs,
+        readiness_model_groups,
+        shutdown_drain_secs,
+        secret_refresh_secs,
+        trust_forwarded_for,
         proxy_cache_enabled,
         proxy_cache_ttl_seconds,
         proxy_cache_max_entries,
@@ -553,10 +678,16 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
         proxy_cache_max_total_body_bytes,
         proxy_cache_streaming_enabled,
         proxy_cache_max_stream_body_bytes,
+        sessions_enabled,
+        session_ttl_secs,
+        session_max_messages,
+        session_max_bytes,
         proxy_max_body_bytes,
         proxy_usage_max_body_bytes,
+        proxy_sse_keepalive_secs,
         proxy_max_in_flight,
         pricing_litellm_path,
+        pricing_overrides_path,
         prometheus_metrics_enabled,
         prometheus_max_key_series,
         prometheus_max_model_series,
@@ -579,7 +710,10 @@ pub(crate) fn parse_gateway_cli_args_with_locale(
         proxy_health_check_path,
         proxy_health_check_interval_secs,
         proxy_health_check_timeout_secs,
+        proxy_fixtures_path,
+        proxy_fixture_mode,
         devtools_path,
+        wasm_plugin_paths,
         otel_enabled,
         otel_endpoint,
         otel_json,
@@ -674,11 +808,11 @@ pub(crate) fn gateway_cli_usage(locale: Locale) -> String {
 fn usage_syntax() -> &'static str {
     #[cfg(feature = "gateway-config-yaml")]
     {
-        "ditto-gateway [config.(json|yaml)] [--dotenv PATH] [--listen|--addr HOST:PORT] [--admin-token TOKEN] [--admin-token-env ENV] [--admin-read-token TOKEN] [--admin-read-token-env ENV] [--admin-tenant-token TENANT=TOKEN] [--admin-tenant-token-env TENANT=ENV] [--admin-tenant-read-token TENANT=TOKEN] [--admin-tenant-read-token-env TENANT=ENV] [--state PATH] [--sqlite PATH] [--pg URL] [--pg-env ENV] [--mysql URL] [--mysql-env ENV] [--redis URL] [--redis-env ENV] [--redis-prefix PREFIX] [--audit-retention-secs SECS] [--db-doctor] [--backend name=url] [--upstream name=base_url] [--json-logs] [--proxy-cache] [--proxy-cache-ttl SECS] [--proxy-cache-max-entries N] [--proxy-cache-max-body-bytes N] [--proxy-cache-max-total-body-bytes N] [--proxy-cache-streaming] [--proxy-cache-max-stream-body-bytes N] [--proxy-max-body-bytes N] [--proxy-usage-max-body-bytes N] [--proxy-max-in-flight N] [--proxy-retry] [--proxy-retry-status-codes CODES] [--proxy-fallback-status-codes CODES] [--proxy-network-error-action ACTION] [--proxy-timeout-error-action ACTION] [--proxy-retry-max-attempts N] [--proxy-circuit-breaker] [--proxy-cb-failure-threshold N] [--proxy-cb-cooldown-secs SECS] [--proxy-cb-failure-status-codes CODES] [--proxy-cb-no-network-errors] [--proxy-cb-no-timeout-errors] [--proxy-cb-no-server-errors] [--proxy-health-checks] [--proxy-health-check-path PATH] [--proxy-health-check-interval-secs SECS] [--proxy-health-check-timeout-secs SECS] [--pricing-litellm PATH] [--prometheus-metrics] [--prometheus-max-key-series N] [--prometheus-max-model-series N] [--prometheus-max-backend-series N] [--prometheus-max-path-series N] [--devtools PATH] [--otel] [--otel-endpoint URL] [--otel-json]"
+        "ditto-gateway [config.(json|yaml)] [--dotenv PATH] [--listen|--addr HOST:PORT] [--admin-token TOKEN] [--admin-token-env ENV] [--admin-read-token TOKEN] [--admin-read-token-env ENV] [--admin-tenant-token TENANT=TOKEN] [--admin-tenant-token-env TENANT=ENV] [--admin-tenant-read-token TENANT=TOKEN] [--admin-tenant-read-token-env TENANT=ENV] [--state PATH] [--sqlite PATH] [--pg URL] [--pg-env ENV] [--mysql URL] [--mysql-env ENV] [--redis URL] [--redis-env ENV] [--redis-prefix PREFIX] [--audit-retention-secs SECS] [--db-doctor] [--virtual-key-master-key-env ENV] [--migrate-virtual-keys] [--validate-config] [--backend name=url] [--upstream name=base_url] [--json-logs] [--readiness-model-group GROUP] [--shutdown-drain-secs SECS] [--secret-refresh-secs SECS] [--trust-x-forwarded-for] [--proxy-cache] [--proxy-cache-ttl SECS] [--proxy-cache-max-entries N] [--proxy-cache-max-body-bytes N] [--proxy-cache-max-total-body-bytes N] [--proxy-cache-streaming] [--proxy-cache-max-stream-body-bytes N] [--sessions] [--session-ttl-secs SECS] [--session-max-messages N] [--session-max-bytes N] [--proxy-max-body-bytes N] [--proxy-usage-max-body-bytes N] [--proxy-sse-keepalive-secs SECS] [--proxy-max-in-flight N] [--proxy-retry] [--proxy-retry-status-codes CODES] [--proxy-fallback-status-codes CODES] [--proxy-network-error-action ACTION] [--proxy-timeout-error-action ACTION] [--proxy-retry-max-attempts N] [--proxy-circuit-breaker] [--proxy-cb-failure-threshold N] [--proxy-cb-cooldown-secs SECS] [--proxy-cb-failure-status-codes CODES] [--proxy-cb-no-network-errors] [--proxy-cb-no-timeout-errors] [--proxy-cb-no-server-errors] [--proxy-health-checks] [--proxy-health-check-path PATH] [--proxy-health-check-interval-secs SECS] [--proxy-health-check-timeout-secs SECS] [--proxy-fixtures DIR] [--proxy-fixture-mode record|replay] [--pricing-litellm PATH] [--pricing-overrides PATH] [--prometheus-metrics] [--prometheus-max-key-series N] [--prometheus-max-model-series N] [--prometheus-max-backend-series N] [--prometheus-max-path-series N] [--devtools PATH] [--wasm-plugin PATH] [--otel] [--otel-endpoint URL] [--otel-json]"
     }
     #[cfg(not(feature = "gateway-config-yaml"))]
     {
-        "ditto-gateway [config.json] [--dotenv PATH] [--listen|--addr HOST:PORT] [--admin-token TOKEN] [--admin-token-env ENV] [--admin-read-token TOKEN] [--admin-read-token-env ENV] [--admin-tenant-token TENANT=TOKEN] [--admin-tenant-token-env TENANT=ENV] [--admin-tenant-read-token TENANT=TOKEN] [--admin-tenant-read-token-env TENANT=ENV] [--state PATH] [--sqlite PATH] [--pg URL] [--pg-env ENV] [--mysql URL] [--mysql-env ENV] [--redis URL] [--redis-env ENV] [--redis-prefix PREFIX] [--audit-retention-secs SECS] [--db-doctor] [--backend name=url] [--upstream name=base_url] [--json-logs] [--proxy-cache] [--proxy-cache-ttl SECS] [--proxy-cache-max-entries N] [--proxy-cache-max-body-bytes N] [--proxy-cache-max-total-body-bytes N] [--proxy-cache-streaming] [--proxy-cache-max-stream-body-bytes N] [--proxy-max-body-bytes N] [--proxy-usage-max-body-bytes N] [--proxy-max-in-flight N] [--proxy-retry] [--proxy-retry-status-codes CODES] [--proxy-fallback-status-codes CODES] [--proxy-network-error-action ACTION] [--proxy-timeout-error-action ACTION] [--proxy-retry-max-attempts N] [--proxy-circuit-breaker] [--proxy-cb-failure-threshold N] [--proxy-cb-cooldown-secs SECS] [--proxy-cb-failure-status-codes CODES] [--proxy-cb-no-network-errors] [--proxy-cb-no-timeout-errors] [--proxy-cb-no-server-errors] [--proxy-health-checks] [--proxy-health-check-path PATH] [--proxy-health-check-interval-secs SECS] [--proxy-health-check-timeout-secs SECS] [--pricing-litellm PATH] [--prometheus-metrics] [--prometheus-max-key-series N] [--prometheus-max-model-series N] [--prometheus-max-backend-series N] [--prometheus-max-path-series N] [--devtools PATH] [--otel] [--otel-endpoint URL] [--otel-json]"
+        "ditto-gateway [config.json] [--dotenv PATH] [--listen|--addr HOST:PORT] [--admin-token TOKEN] [--admin-token-env ENV] [--admin-read-token TOKEN] [--admin-read-token-env ENV] [--admin-tenant-token TENANT=TOKEN] [--admin-tenant-token-env TENANT=ENV] [--admin-tenant-read-token TENANT=TOKEN] [--admin-tenant-read-token-env TENANT=ENV] [--state PATH] [--sqlite PATH] [--pg URL] [--pg-env ENV] [--mysql URL] [--mysql-env ENV] [--redis URL] [--redis-env ENV] [--redis-prefix PREFIX] [--audit-retention-secs SECS] [--db-doctor] [--virtual-key-master-key-env ENV] [--migrate-virtual-keys] [--validate-config] [--backend name=url] [--upstream name=base_url] [--json-logs] [--readiness-model-group GROUP] [--shutdown-drain-secs SECS] [--secret-refresh-secs SECS] [--trust-x-forwarded-for] [--proxy-cache] [--proxy-cache-ttl SECS] [--proxy-cache-max-entries N] [--proxy-cache-max-body-bytes N] [--proxy-cache-max-total-body-bytes N] [--proxy-cache-streaming] [--proxy-cache-max-stream-body-bytes N] [--proxy-max-body-bytes N] [--proxy-usage-max-body-bytes N] [--proxy-sse-keepalive-secs SECS] [--proxy-max-in-flight N] [--proxy-retry] [--proxy-retry-status-codes CODES] [--proxy-fallback-status-codes CODES] [--proxy-network-error-action ACTION] [--proxy-timeout-error-action ACTION] [--proxy-retry-max-attempts N] [--proxy-circuit-breaker] [--proxy-cb-failure-threshold N] [--proxy-cb-cooldown-secs SECS] [--proxy-cb-failure-status-codes CODES] [--proxy-cb-no-network-errors] [--proxy-cb-no-timeout-errors] [--proxy-cb-no-server-errors] [--proxy-health-checks] [--proxy-health-check-path PATH] [--proxy-health-check-interval-secs SECS] [--proxy-health-check-timeout-secs SECS] [--proxy-fixtures DIR] [--proxy-fixture-mode record|replay] [--pricing-litellm PATH] [--pricing-overrides PATH] [--prometheus-metrics] [--prometheus-max-key-series N] [--prometheus-max-model-series N] [--prometheus-max-backend-series N] [--prometheus-max-path-series N] [--devtools PATH] [--wasm-plugin PATH] [--otel] [--otel-endpoint URL] [--otel-json]"
     }
 }
 
@@ -840,6 +974,25 @@ mod tests {
         assert_eq!(cli.proxy_cache_max_stream_body_bytes, Some(2048));
     }
 
+    #[test]
+    fn session_flags_enable_sessions() {
+        let cli = parse_gateway_cli_args(
+            vec![
+                "gateway.json".to_string(),
+                "--session-ttl-secs".to_string(),
+                "600".to_string(),
+                "--session-max-messages".to_string(),
+                "50".to_string(),
+            ]
+            .into_iter(),
+        )
+        .expect("parse");
+        assert!(cli.sessions_enabled);
+        assert_eq!(cli.session_ttl_secs, Some(600));
+        assert_eq!(cli.session_max_messages, Some(50));
+        assert_eq!(cli.session_max_bytes, None);
+    }
+
     #[test]
     fn addr_alias_sets_listen() {
         let cli = parse_gateway_cli_args(
@@ -895,6 +1048,50 @@ mod tests {
         assert!(cli.db_doctor);
     }
 
+    #[test]
+    fn parses_virtual_key_encryption_flags() {
+        let cli = parse_gateway_cli_args(
+            vec![
+                "gateway.json".to_string(),
+                "--virtual-key-master-key-env".to_string(),
+                "DITTO_VK_MASTER_KEY".to_string(),
+                "--migrate-virtual-keys".to_string(),
+            ]
+            .into_iter(),
+        )
+        .expect("parse");
+        assert_eq!(
+            cli.virtual_key_master_key_env.as_deref(),
+            Some("DITTO_VK_MASTER_KEY")
+        );
+        assert!(cli.migrate_virtual_keys);
+        assert!(!cli.db_doctor);
+    }
+
+    #[test]
+    fn parses_validate_config_flag() {
+        let cli = parse_gateway_cli_args(
+            vec!["gateway.json".to_string(), "--validate-config".to_string()].into_iter(),
+        )
+        .expect("parse");
+        assert!(cli.validate_config);
+        assert!(!cli.db_doctor);
+    }
+
+    #[test]
+    fn parses_trust_x_forwarded_for_flag() {
+        let cli = parse_gateway_cli_args(
+            vec![
+                "gateway.json".to_string(),
+                "--trust-x-forwarded-for".to_string(),
+            ]
+            .into_iter(),
+        )
+        .expect("parse");
+        assert!(cli.trust_forwarded_for);
+        assert!(!cli.json_logs);
+    }
+
     #[test]
     fn parses_proxy_fallback_status_codes() {
         let cli = parse_gateway_cli_args(
@@ -910,6 +1107,91 @@ mod tests {
         assert!(!cli.proxy_retry_enabled);
     }
 
+    #[test]
+    fn parses_proxy_fixture_flags() {
+        let cli = parse_gateway_cli_args(
+            vec![
+                "gateway.json".to_string(),
+                "--proxy-fixtures".to_string(),
+                "fixtures".to_string(),
+                "--proxy-fixture-mode".to_string(),
+                "Record".to_string(),
+            ]
+            .into_iter(),
+        )
+        .expect("parse");
+        assert_eq!(cli.proxy_fixtures_path.as_deref(), Some("fixtures"));
+        assert_eq!(cli.proxy_fixture_mode.as_deref(), Some("record"));
+
+        assert!(
+            parse_gateway_cli_args(
+                vec![
+                    "gateway.json".to_string(),
+                    "--proxy-fixture-mode".to_string(),
+                    "live".to_string(),
+                ]
+                .into_iter(),
+            )
+            .is_err()
+        );
+    }
+
+    #[test]
+    fn parses_repeated_readiness_model_groups() {
+        let cli = parse_gateway_cli_args(
+            vec![
+                "gateway.json".to_string(),
+                "--readiness-model-group".to_string(),
+                "gpt-4o".to_string(),
+                "--readiness-model-group".to_string(),
+                "*".to_string(),
+            ]
+            .into_iter(),
+        )
+        .expect("parse");
+        assert_eq!(cli.readiness_model_groups, vec!["gpt-4o", "*"]);
+    }
+
+    #[test]
+    fn parses_shutdown_drain_secs() {
+        let cli = parse_gateway_cli_args(
+            vec![
+                "gateway.json".to_string(),
+                "--shutdown-drain-secs".to_string(),
+                "45".to_string(),
+            ]
+            .into_iter(),
+        )
+        .expect("parse");
+        assert_eq!(cli.shutdown_drain_secs, Some(45));
+
+        assert!(
+            parse_gateway_cli_args(
+                vec![
+                    "gateway.json".to_string(),
+                    "--shutdown-drain-secs".to_string(),
+                    "soon".to_string(),
+                ]
+                .into_iter(),
+            )
+            .is_err()
+        );
+    }
+
+    #[test]
+    fn parses_secret_refresh_secs() {
+        let cli = parse_gateway_cli_args(
+            vec![
+                "gateway.json".to_string(),
+                "--secret-refresh-secs".to_string(),
+                "300".to_string(),
+            ]
+            .into_iter(),
+        )
+        .expect("parse");
+        assert_eq!(cli.secret_refresh_secs, Some(300));
+    }
+
     #[test]
     fn parses_proxy_transport_and_circuit_breaker_failure_flags() {
         let cli = parse_gateway_cli_args(
@@ -0,0 +1,254 @@
+use std::path::PathBuf;
+
+use bytes::Bytes;
+use omne_integrity_primitives::Sha256Hasher;
+use serde::{Deserialize, Serialize};
+
+use super::GatewayError;
+
+/// Response headers worth keeping in a fixture; everything else (dates,
+/// request ids, rate-limit counters) changes per call and is dropped so
+/// recordings stay stable across runs.
+const RECORDED_HEADERS: &[&str] = &["content-type", "cache-control"];
+
+#[derive(Clone, Copy, Debug, PartialEq, Eq)]
+pub enum ProxyFixtureMode {
+    /// Forward upstream and save each response under its request hash.
+    Record,
+    /// Serve saved responses only; a miss fails without touching upstream.
+    Replay,
+}
+
+impl ProxyFixtureMode {
+    pub fn parse(raw: &str) -> Option<Self> {
+        match raw.trim().to_ascii_lowercase().as_str() {
+            "record" => Some(Self::Record),
+            "replay" => Some(Self::Replay),
+            _ => None,
+        }
+    }
+}
+
+/// Directory of recorded upstream responses keyed by request hash. The key
+/// covers method, path and body (JSON bodies are canonicalized first), but not
+/// headers or the backend base URL, so a suite recorded against one upstream
+/// replays against any backend name.
+#[derive(Clone, Debug)]
+pub struct ProxyFixtures {
+    dir: PathBuf,
+    mode: ProxyFixtureMode,
+}
+
+#[derive(Debug, Serialize, Deserialize)]
+struct FixtureRecord {
+    method: String,
+    path: String,
+    status: u16,
+    #[serde(default)]
+    headers: Vec<(String, String)>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    body: Option<String>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    body_bytes: Option<Vec<u8>>,
+}
+
+impl ProxyFixtures {
+    pub fn new(dir: impl Into<PathBuf>, mode: ProxyFixtureMode) -> Self {
+        Self {
+            dir: dir.into(),
+            mode,
+        }
+    }
+
+    pub fn mode(&self) -> ProxyFixtureMode {
+        self.mode
+    }
+
+    pub fn key(method: &reqwest::Method, path: &str, body: Option<&[u8]>) -> String {
+        let body = body.unwrap_or_default();
+        let canonical = serde_json::from_slice::<serde_json::Value>(body)
+            .ok()
+            .and_then(|value| serde_json::to_vec(&value).ok());
+        let mut hasher = Sha256Hasher::new();
+        hasher.update(b"ditto-proxy-fixture-v1|");
+        hasher.update(method.as_str().as_bytes());
+        hasher.update(b"\n");
+        hasher.update(path.as_bytes());
+        hasher.update(b"\n");
+        hasher.update(canonical.as_deref().unwrap_or(body));
+        hasher.finalize().to_string()
+    }
+
+    fn fixture_path(&self, key: &str) -> PathBuf {
+        self.dir.join(format!("{key}.json"))
+    }
+
+    pub(super) async fn replay(
+        &self,
+        method: &reqwest::Method,
+        path: &str,
+        body: Option<&[u8]>,
+    ) -> Result<reqwest::Response, GatewayError> {
+        let key = Self::key(method, path, body);
+        let fixture_path = self.fixture_path(&key);
+        let raw = tokio::fs::read(&fixture_path)
+            .await
+            .map_err(|_| GatewayError::Backend {
+                message: format!("no recorded fixture for {method} {path} (key {key})"),
+            })?;
+        let record: FixtureRecord =
+            serde_json::from_slice(&raw).map_err(|err| GatewayError::Backend {
+                message: format!("invalid fixture {}: {err}", fixture_path.display()),
+            })?;
+        let body = match (record.body, record.body_bytes) {
+            (_, Some(bytes)) => bytes,
+            (Some(text), None) => text.into_bytes(),
+            (None, None) => Vec::new(),
+        };
+        build_response(record.status, &record.headers, body)
+    }
+
+    pub(super) async fn record(
+        &self,
+        method: &reqwest::Method,
+        path: &str,
+        body: Option<&[u8]>,
+        response: reqwest::Response,
+    ) -> Result<reqwest::Response, GatewayError> {
+        let status = response.status().as_u16();
+        let headers = RECORDED_HEADERS
+            .iter()
+            .filter_map(|name| {
+                let value = response.headers().get(*name)?.to_str().ok()?;
+                Some((name.to_string(), value.to_string()))
+            })
+            .collect::<Vec<_>>();
+        let bytes: Bytes = response
+            .bytes()
+            .await
+            .map_err(|err| GatewayError::Backend {
+                message: format!("backend response failed while recording fixture: {err}"),
+            })?;
+
+        let (text, binary) = match std::str::from_utf8(&bytes) {
+            Ok(text) => (Some(text.to_string()), None),
+            Err(_) => (None, Some(bytes.to_vec())),
+        };
+        let record = FixtureRecord {
+            method: method.to_string(),
+            path: path.to_string(),
+            status,
+            headers,
+            body: text,
+            body_bytes: binary,
+        };
+        let key = Self::key(method, path, body);
+        self.write(&key, &record).await?;
+        build_response(status, &record.headers, bytes.to_vec())
+    }
+
+    async fn write(&self, key: &str, record: &FixtureRecord) -> Result<(), GatewayError> {
+        let failed = |err: std::io::Error| GatewayError::Backend {
+            message: format!(
+                "failed to write fixture under {}: {err}",
+                self.dir.display()
+            ),
+        };
+        let payload = serde_json::to_vec_pretty(record).map_err(|err| GatewayError::Backend {
+            message: format!("failed to encode fixture: {err}"),
+        })?;
+        tokio::fs::create_dir_all(&self.dir).await.map_err(failed)?;
+        let path = self.fixture_path(key);
+        let tmp = self.dir.join(format!("{key}.json.tmp"));
+        tokio::fs::write(&tmp, payload).await.map_err(failed)?;
+        tokio::fs::rename(&tmp, &path).await.map_err(failed)
+    }
+}
+
+fn build_response(
+    status: u16,
+    headers: &[(String, String)],
+    body: Vec<u8>,
+) -> Result<reqwest::Response, GatewayError> {
+    let mut builder = axum::http::Response::builder().status(status);
+    for (name, value) in headers {
+        builder = builder.header(name.as_str(), value.as_str());
+    }
+    let response = builder.body(body).map_err(|err| GatewayError::Backend {
+        message: format!("invalid fixture response: {err}"),
+    })?;
+    Ok(reqwest::Response::from(response))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn key_ignores_json_formatting_and_key_order() {
+        let method = reqwest::Method::POST;
+        let compact = ProxyFixtures::key(
+            &method,
+            "/v1/chat/completions",
+            Some(br#"{"model":"m","messages":[]}"#),
+        );
+        let spaced = ProxyFixtures::key(
+            &method,
+            "/v1/chat/completions",
+            Some(b"{ \"messages\": [],\n  \"model\": \"m\" }"),
+        );
+        assert_eq!(compact, spaced);
+
+        let other_path = ProxyFixtures::key(&method, "/v1/responses", Some(br#"{"model":"m"}"#));
+        assert_ne!(compact, other_path);
+        let get = ProxyFixtures::key(&reqwest::Method::GET, "/v1/chat/completions", None);
+        assert_ne!(compact, get);
+    }
+
+    #[tokio::test]
+    async fn replay_serves_recorded_response() {
+        let dir = tempfile::tempdir().expect("tempdir");
+        let method = reqwest::Method::POST;
+        let body = br#"{"model":"m"}"#;
+        let upstream = build_response(
+            201,
+            &[("content-type".to_string(), "application/json".to_string())],
+            br#"{"id":"recorded"}"#.to_vec(),
+        )
+        .expect("response");
+
+        let recorder = ProxyFixtures::new(dir.path(), ProxyFixtureMode::Record);
+        let recorded = recorder
+            .record(&method, "/v1/chat/completions", Some(body), upstream)
+            .await
+            .expect("record");
+        assert_eq!(recorded.status().as_u16(), 201);
+        assert_eq!(recorded.text().await.expect("body"), r#"{"id":"recorded"}"#);
+
+        let replayer = ProxyFixtures::new(dir.path(), ProxyFixtureMode::Replay);
+        let replayed = replayer
+            .replay(&method, "/v1/chat/completions", Some(body))
+            .await
+            .expect("replay");
+        assert_eq!(replayed.status().as_u16(), 201);
+        assert_eq!(
+            replayed
+                .headers()
+                .get("content-type")
+                .and_then(|value| value.to_str().ok()),
+            Some("application/json")
+        );
+        assert_eq!(replayed.text().await.expect("body"), r#"{"id":"recorded"}"#);
+
+        let err = replayer
+            .replay(
+                &method,
+                "/v1/chat/completions",
+                Some(br#"{"model":"other"}"#),
+            )
+            .await
+            .err()
+            .expect("miss");
+        assert!(err.to_string().contains("no recorded fixture"));
+    }
+}
@@ -1,9 +1,14 @@
 //! Gateway backend adapters.
 
+pub mod fixtures;
 pub mod http;
 pub mod proxy;
 
-use super::super::{Backend, GatewayError, GatewayRequest, GatewayResponse};
+use super::super::{
+    Backend, BackendHttpVersion, BackendTlsConfig, BackendTransportConfig, GatewayError,
+    GatewayRequest, GatewayResponse,
+};
 
+pub use fixtures::{ProxyFixtureMode, ProxyFixtures};
 pub use http::HttpBackend;
 pub use proxy::ProxyBackend;
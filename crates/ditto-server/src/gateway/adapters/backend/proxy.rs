@@ -1,57 +1,186 @@
 use std::collections::BTreeMap;
+use std::sync::{Arc, RwLock};
 use std::time::Duration;
 
 use axum::http::HeaderMap;
 use bytes::Bytes;
 use reqwest::Body as ReqwestBody;
 
-use super::GatewayError;
+use super::fixtures::{ProxyFixtureMode, ProxyFixtures};
+use super::{BackendHttpVersion, BackendTlsConfig, BackendTransportConfig, GatewayError};
 
 #[derive(Clone)]
 pub struct ProxyBackend {
     base_url: String,
     client: reqwest::Client,
-    headers: HeaderMap,
-    query_params: BTreeMap<String, String>,
+    credentials: Arc<RwLock<ProxyCredentials>>,
     request_timeout: Option<Duration>,
+    connect_timeout: Option<Duration>,
+    first_token_timeout: Option<Duration>,
+    tls: Option<BackendTlsConfig>,
+    transport: Option<BackendTransportConfig>,
+    fixtures: Option<Arc<ProxyFixtures>>,
 }
 
 impl ProxyBackend {
     pub fn new(base_url: impl Into<String>) -> Result<Self, GatewayError> {
-        let client = reqwest::Client::builder()
-            .timeout(Duration::from_secs(300))
-            .build()
-            .map_err(|err| GatewayError::Backend {
-                message: format!("backend http client error: {err}"),
-            })?;
+        let client = build_client(proxy_client_builder().http1_only())?;
         Ok(Self {
             base_url: base_url.into(),
             client,
-            headers: HeaderMap::new(),
-            query_params: BTreeMap::new(),
+            credentials: Arc::default(),
             request_timeout: None,
+            connect_timeout: None,
+            first_token_timeout: None,
+            tls: None,
+            transport: None,
+            fixtures: None,
         })
     }
 
     pub fn with_request_timeout_seconds(mut self, timeout_seconds: Option<u64>) -> Self {
-        self.request_timeout = timeout_seconds
-            .filter(|seconds| *seconds > 0)
-            .map(Duration::from_secs);
+        self.request_timeout = timeout_from_seconds(timeout_seconds);
+        self
+    }
+
+    pub fn with_connect_timeout_seconds(
+        mut self,
+        timeout_seconds: Option<u64>,
+    ) -> Result<Self, GatewayError> {
+        self.connect_timeout = timeout_from_seconds(timeout_seconds);
+        self.client = build_client(self.client_builder()?)?;
+        Ok(self)
+    }
+
+    /// Bounds how long a streaming request may wait for its first chunk. The
+    /// proxy enforces it; requests sent through this type are not affected.
+    pub fn with_first_token_timeout_seconds(mut self, timeout_seconds: Option<u64>) -> Self {
+        self.first_token_timeout = timeout_from_seconds(timeout_seconds);
         self
     }
 
-    pub fn with_headers(mut self, headers: BTreeMap<String, String>) -> Result<Self, GatewayError> {
-        self.headers = parse_headers(&headers)?;
+    pub fn with_headers(self, headers: BTreeMap<String, String>) -> Result<Self, GatewayError> {
+        let headers = parse_headers(&headers)?;
+        self.write_credentials().headers = headers;
+        Ok(self)
+    }
+
+    pub fn with_query_params(self, params: BTreeMap<String, String>) -> Self {
+        self.write_credentials().query_params = normalize_query_params(&params);
+        self
+    }
+
+    /// Swaps the headers and query params attached to every request, e.g.
+    /// after a rotated API key was re-resolved. Clones share the credentials,
+    /// so the change reaches every handle; invalid headers leave the current
+    /// values in place.
+    pub fn replace_credentials(
+        &self,
+        headers: &BTreeMap<String, String>,
+        query_params: &BTreeMap<String, String>,
+    ) -> Result<(), GatewayError> {
+        let headers = parse_headers(headers)?;
+        let query_params = normalize_query_params(query_params);
+        *self.write_credentials() = ProxyCredentials {
+            headers,
+            query_params,
+        };
+        Ok(())
+    }
+
+    fn read_credentials(&self) -> std::sync::RwLockReadGuard<'_, ProxyCredentials> {
+        self.credentials
+            .read()
+            .unwrap_or_else(|poisoned| poisoned.into_inner())
+    }
+
+    fn write_credentials(&self) -> std::sync::RwLockWriteGuard<'_, ProxyCredentials> {
+        self.credentials
+            .write()
+            .unwrap_or_else(|poisoned| poisoned.into_inner())
+    }
+
+    /// Rebuilds the HTTP client with the backend's extra CAs and client
+    /// certificate. Files are read once, so rotating them needs a restart.
+    pub fn with_tls(mut self, tls: &BackendTlsConfig) -> Result<Self, GatewayError> {
+        self.tls = Some(tls.clone());
+        self.client = build_client(self.client_builder()?)?;
+        Ok(self)
+    }
+
+    /// Rebuilds the HTTP client with the backend's pool, keepalive, HTTP
+    /// version and proxy settings. Clones share the resulting pool.
+    pub fn with_transport(
+        mut self,
+        transport: &BackendTransportConfig,
+    ) -> Result<Self, GatewayError> {
+        self.transport = Some(transport.clone());
+        self.client = build_client(self.client_builder()?)?;
         Ok(self)
     }
 
-    pub fn with_query_params(mut self, params: BTreeMap<String, String>) -> Self {
-        self.query_params = normalize_query_params(&params);
+    /// Records upstream responses into, or replays them from, a fixture
+    /// directory. Replay never contacts upstream, so a missing fixture fails
+    /// the request.
+    pub fn with_fixtures(mut self, fixtures: Arc<ProxyFixtures>) -> Self {
+        self.fixtures = Some(fixtures);
         self
     }
 
-    pub fn headers(&self) -> &HeaderMap {
-        &self.headers
+    fn client_builder(&self) -> Result<reqwest::ClientBuilder, GatewayError> {
+        let mut builder = proxy_client_builder();
+        if let Some(timeout) = self.connect_timeout {
+            builder = builder.connect_timeout(timeout);
+        }
+        builder = match self.transport.as_ref() {
+            Some(transport) => apply_transport(builder, transport)?,
+            None => builder.http1_only(),
+        };
+        let Some(tls) = self.tls.as_ref() else {
+            return Ok(builder);
+        };
+        if let Some(path) = tls.ca_cert_path.as_deref() {
+            let pem = read_tls_file("ca_cert_path", path)?;
+            let certs = reqwest::Certificate::from_pem_bundle(&pem)
+                .map_err(|err| invalid_tls_file("ca_cert_path", path, err))?;
+            for cert in certs {
+                builder = builder.add_root_certificate(cert);
+            }
+        }
+        match (
+            tls.client_cert_path.as_deref(),
+            tls.client_key_path.as_deref(),
+        ) {
+            (Some(cert_path), key_path) => {
+                let mut pem = read_tls_file("client_cert_path", cert_path)?;
+                if let Some(key_path) = key_path {
+                    pem.push(b'\n');
+                    pem.extend(read_tls_file("client_key_path", key_path)?);
+                }
+                let identity = reqwest::Identity::from_pem(&pem)
+                    .map_err(|err| invalid_tls_file("client_cert_path", cert_path, err))?;
+                builder = builder.identity(identity);
+            }
+            (None, Some(_)) => {
+                return Err(GatewayError::InvalidRequest {
+                    reason: "backend tls client_key_path requires client_cert_path".to_string(),
+                });
+            }
+            (None, None) => {}
+        }
+        Ok(builder)
+    }
+
+    pub fn headers(&self) -> HeaderMap {
+        self.read_credentials().headers.clone()
+    }
+
+    pub fn request_timeout(&self) -> Option<Duration> {
+        self.request_timeout
+    }
+
+    pub fn first_token_timeout(&self) -> Option<Duration> {
+        self.first_token_timeout
     }
 
     pub async fn request(
@@ -95,11 +224,66 @@ impl ProxyBackend {
         headers: HeaderMap,
         body: Option<ReqwestBody>,
         timeout: Option<Duration>,
+    ) -> Result<reqwest::Response, GatewayError> {
+        if let Some(fixtures) = self.fixtures.as_deref() {
+            return self
+                .request_with_fixtures(fixtures, method, path, headers, body, timeout)
+                .await;
+        }
+        self.send(method, path, headers, body, timeout).await
+    }
+
+    async fn request_with_fixtures(
+        &self,
+        fixtures: &ProxyFixtures,
+        method: reqwest::Method,
+        path: &str,
+        headers: HeaderMap,
+        body: Option<ReqwestBody>,
+        timeout: Option<Duration>,
+    ) -> Result<reqwest::Response, GatewayError> {
+        let streaming = body.as_ref().is_some_and(|body| body.as_bytes().is_none());
+        if streaming {
+            if fixtures.mode() == ProxyFixtureMode::Replay {
+                return Err(GatewayError::Backend {
+                    message: format!("streaming request bodies cannot be replayed: {path}"),
+                });
+            }
+            // Streaming uploads have no stable key; forward them unrecorded.
+            return self.send(method, path, headers, body, timeout).await;
+        }
+        let body_bytes = body
+            .as_ref()
+            .and_then(ReqwestBody::as_bytes)
+            .map(Bytes::copy_from_slice);
+        match fixtures.mode() {
+            ProxyFixtureMode::Replay => fixtures.replay(&method, path, body_bytes.as_deref()).await,
+            ProxyFixtureMode::Record => {
+                let response = self
+                    .send(method.clone(), path, headers, body, timeout)
+                    .await?;
+                fixtures
+                    .record(&method, path, body_bytes.as_deref(), response)
+                    .await
+            }
+        }
+    }
+
+    async fn send(
+        &self,
+        method: reqwest::Method,
+        path: &str,
+        headers: HeaderMap,
+        body: Option<ReqwestBody>,
+        timeout: Option<Duration>,
     ) -> Result<reqwest::Response, GatewayError> {
         let url = join_base_url(&self.base_url, path);
         let mut req = self.client.request(method, url).headers(headers);
-        if !self.query_params.is_empty() {
-            req = req.query(&self.query_params);
+        {
+            let credentials = self.read_credentials();
+            if !credentials.query_params.is_empty() {
+                req = req.query(&credentials.query_params);
+            }
         }
         let timeout = timeout.or(self.request_timeout);
         if let Some(timeout) = timeout {
@@ -119,6 +303,78 @@ impl ProxyBackend {
     }
 }
 
+/// Headers and query params that authenticate requests to the backend.
+#[derive(Default)]
+struct ProxyCredentials {
+    headers: HeaderMap,
+    query_params: BTreeMap<String, String>,
+}
+
+fn timeout_from_seconds(timeout_seconds: Option<u64>) -> Option<Duration> {
+    timeout_seconds
+        .filter(|seconds| *seconds > 0)
+        .map(Duration::from_secs)
+}
+
+fn proxy_client_builder() -> reqwest::ClientBuilder {
+    reqwest::Client::builder().timeout(Duration::from_secs(300))
+}
+
+fn apply_transport(
+    mut builder: reqwest::ClientBuilder,
+    transport: &BackendTransportConfig,
+) -> Result<reqwest::ClientBuilder, GatewayError> {
+    if let Some(max_idle) = transport.pool_max_idle_per_host {
+        builder = builder.pool_max_idle_per_host(max_idle);
+    }
+    if let Some(seconds) = transport.pool_idle_timeout_seconds {
+        builder = builder.pool_idle_timeout(Duration::from_secs(seconds));
+    }
+    if let Some(interval) = timeout_from_seconds(transport.tcp_keepalive_seconds) {
+        builder = builder.tcp_keepalive(interval);
+    }
+    builder = match transport.http_version.unwrap_or_default() {
+        BackendHttpVersion::Http1 => builder.http1_only(),
+        BackendHttpVersion::Auto => builder,
+        BackendHttpVersion::Http2 => builder.http2_prior_knowledge(),
+    };
+    if let Some(interval) = timeout_from_seconds(transport.http2_keepalive_interval_seconds) {
+        builder = builder
+            .http2_keep_alive_interval(interval)
+            .http2_keep_alive_while_idle(true);
+    }
+    if let Some(url) = transport
+        .proxy_url
+        .as_deref()
+        .map(str::trim)
+        .filter(|url| !url.is_empty())
+    {
+        let proxy = reqwest::Proxy::all(url).map_err(|err| GatewayError::InvalidRequest {
+            reason: format!("invalid backend transport proxy_url: {err}"),
+        })?;
+        builder = builder.proxy(proxy);
+    }
+    Ok(builder)
+}
+
+fn build_client(builder: reqwest::ClientBuilder) -> Result<reqwest::Client, GatewayError> {
+    builder.build().map_err(|err| GatewayError::Backend {
+        message: format!("backend http client error: {err}"),
+    })
+}
+
+fn read_tls_file(field: &str, path: &str) -> Result<Vec<u8>, GatewayError> {
+    std::fs::read(path).map_err(|err| GatewayError::InvalidRequest {
+        reason: format!("failed to read backend tls {field} {path}: {err}"),
+    })
+}
+
+fn invalid_tls_file(field: &str, path: &str, err: reqwest::Error) -> GatewayError {
+    GatewayError::InvalidRequest {
+        reason: format!("invalid backend tls {field} {path}: {err}"),
+    }
+}
+
 fn join_base_url(base_url: &str, path: &str) -> String {
     let base = base_url.trim_end_matches('/');
     let path_no_leading_slash = path.strip_prefix('/').unwrap_or(path);
@@ -205,4 +461,65 @@ mod tests {
             "http://localhost:8080/v1"
         );
     }
+
+    #[test]
+    fn with_transport_builds_client_and_rejects_invalid_proxy_url() {
+        let transport = BackendTransportConfig {
+            pool_max_idle_per_host: Some(8),
+            pool_idle_timeout_seconds: Some(30),
+            tcp_keepalive_seconds: Some(60),
+            http_version: Some(BackendHttpVersion::Http2),
+            http2_keepalive_interval_seconds: Some(20),
+            proxy_url: Some("http://127.0.0.1:3128".to_string()),
+        };
+        ProxyBackend::new("https://upstream")
+            .expect("backend")
+            .with_transport(&transport)
+            .expect("transport");
+
+        let invalid = BackendTransportConfig {
+            proxy_url: Some("not a url".to_string()),
+            ..Default::default()
+        };
+        let err = ProxyBackend::new("https://upstream")
+            .expect("backend")
+            .with_transport(&invalid)
+            .err()
+            .expect("invalid proxy url");
+        assert!(
+            err.to_string()
+                .contains("invalid backend transport proxy_url")
+        );
+    }
+
+    #[test]
+    fn with_tls_rejects_invalid_client_identity() {
+        let key_only = BackendTlsConfig {
+            client_key_path: Some("client.key".to_string()),
+            ..Default::default()
+        };
+        let err = ProxyBackend::new("https://upstream")
+            .expect("backend")
+            .with_tls(&key_only)
+            .err()
+            .expect("key without cert");
+        assert!(err.to_string().contains("requires client_cert_path"));
+
+        let dir = tempfile::tempdir().expect("tempdir");
+        let cert_path = dir.path().join("client.pem");
+        std::fs::write(&cert_path, "not a pem").expect("write");
+        let invalid = BackendTlsConfig {
+            client_cert_path: Some(cert_path.display().to_string()),
+            ..Default::default()
+        };
+        let err = ProxyBackend::new("https://upstream")
+            .expect("backend")
+            .with_tls(&invalid)
+            .err()
+            .expect("invalid pem");
+        assert!(
+            err.to_string()
+                .contains("invalid backend tls client_cert_path")
+        );
+    }
 }
@@ -7,7 +7,7 @@ use std::os::unix::fs::{OpenOptionsExt, PermissionsExt};
 use serde::{Deserialize, Serialize};
 use thiserror::Error;
 
-use super::{RouterConfig, VirtualKeyConfig};
+use super::{PromptTemplate, RouterConfig, VirtualKeyConfig};
 
 #[derive(Clone, Debug, Default, Serialize, Deserialize)]
 pub struct GatewayStateFile {
@@ -15,6 +15,8 @@ pub struct GatewayStateFile {
     pub virtual_keys: Vec<VirtualKeyConfig>,
     #[serde(default, skip_serializing_if = "Option::is_none")]
     pub router: Option<RouterConfig>,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub prompts: Vec<PromptTemplate>,
 }
 
 #[derive(Debug, Error)]
@@ -49,6 +51,7 @@ impl GatewayStateFile {
                 .map(VirtualKeyConfig::sanitized_for_persistence)
                 .collect(),
             router: self.router.clone(),
+            prompts: self.prompts.clone(),
         })
         .map_err(GatewayStateFileError::Parse)?;
         let tmp_path = path.with_extension("tmp");
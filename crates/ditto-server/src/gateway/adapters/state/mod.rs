@@ -2,6 +2,6 @@
 
 pub mod file;
 
-use super::super::{RouterConfig, VirtualKeyConfig};
+use super::super::{PromptTemplate, RouterConfig, VirtualKeyConfig};
 
 pub use file::{GatewayStateFile, GatewayStateFileError};
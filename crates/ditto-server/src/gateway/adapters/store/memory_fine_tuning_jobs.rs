@@ -0,0 +1,46 @@
+use std::collections::HashMap;
+use std::sync::{Arc, Mutex as StdMutex};
+
+use async_trait::async_trait;
+
+use crate::gateway::{
+    FineTuningJobRecord, FineTuningJobStore, FineTuningJobStoreError, lock_unpoisoned,
+};
+
+/// Process-local fine-tuning job ownership, used when no shared store is
+/// configured.
+#[derive(Clone, Default)]
+pub(crate) struct LocalFineTuningJobStore {
+    inner: Arc<StdMutex<HashMap<String, FineTuningJobRecord>>>,
+}
+
+#[async_trait]
+impl FineTuningJobStore for LocalFineTuningJobStore {
+    async fn load_fine_tuning_job(
+        &self,
+        job_id: &str,
+    ) -> Result<Option<FineTuningJobRecord>, FineTuningJobStoreError> {
+        Ok(lock_unpoisoned(&self.inner).get(job_id).cloned())
+    }
+
+    async fn list_fine_tuning_jobs(
+        &self,
+        owner: &str,
+    ) -> Result<Vec<FineTuningJobRecord>, FineTuningJobStoreError> {
+        let mut records: Vec<FineTuningJobRecord> = lock_unpoisoned(&self.inner)
+            .values()
+            .filter(|record| record.owner == owner)
+            .cloned()
+            .collect();
+        records.sort_by(|a, b| b.created_at_ms.cmp(&a.created_at_ms));
+        Ok(records)
+    }
+
+    async fn save_fine_tuning_job(
+        &self,
+        record: &FineTuningJobRecord,
+    ) -> Result<(), FineTuningJobStoreError> {
+        lock_unpoisoned(&self.inner).insert(record.job_id.clone(), record.clone());
+        Ok(())
+    }
+}
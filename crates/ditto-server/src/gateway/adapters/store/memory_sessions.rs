@@ -0,0 +1,51 @@
+use std::collections::HashMap;
+use std::sync::{Arc, Mutex as StdMutex};
+
+use async_trait::async_trait;
+use serde_json::Value;
+
+use crate::gateway::{SessionStore, SessionStoreError, lock_unpoisoned};
+
+struct LocalSession {
+    messages: Vec<Value>,
+    expires_at_ms: u64,
+}
+
+/// Process-local session store, used when no shared store is configured.
+#[derive(Clone, Default)]
+pub(crate) struct LocalSessionStore {
+    inner: Arc<StdMutex<HashMap<String, LocalSession>>>,
+}
+
+#[async_trait]
+impl SessionStore for LocalSessionStore {
+    async fn load_session_messages(
+        &self,
+        session_key: &str,
+        now_ms: u64,
+    ) -> Result<Option<Vec<Value>>, SessionStoreError> {
+        Ok(lock_unpoisoned(&self.inner)
+            .get(session_key)
+            .filter(|session| session.expires_at_ms >= now_ms)
+            .map(|session| session.messages.clone()))
+    }
+
+    async fn save_session_messages(
+        &self,
+        session_key: &str,
+        messages: &[Value],
+        now_ms: u64,
+        ttl_ms: u64,
+    ) -> Result<(), SessionStoreError> {
+        let mut sessions = lock_unpoisoned(&self.inner);
+        sessions.retain(|_, session| session.expires_at_ms >= now_ms);
+        sessions.insert(
+            session_key.to_string(),
+            LocalSession {
+                messages: messages.to_vec(),
+                expires_at_ms: now_ms.saturating_add(ttl_ms),
+            },
+        );
+        Ok(())
+    }
+}
@@ -1,6 +1,8 @@
 //! Gateway persistence adapters.
 
+mod memory_fine_tuning_jobs;
 mod memory_request_idempotency;
+mod memory_sessions;
 
 #[cfg(any(
     feature = "gateway-store-sqlite",
@@ -26,8 +28,13 @@ use super::super::{
 use super::super::{
     CachedProxyResponse, ProxyCacheEntryMetadata, ProxyCachePurgeSelector, ProxyCacheStoredResponse,
 };
+#[cfg(any(feature = "gateway-store-postgres", feature = "gateway-store-redis"))]
+use super::super::{
+    FineTuningJobRecord, FineTuningJobStore, FineTuningJobStoreError, SessionStore,
+    SessionStoreError,
+};
 #[cfg(feature = "gateway-store-redis")]
-use super::super::{GatewayError, LimitsConfig};
+use super::super::{GatewayError, LimitsConfig, RateLimitStatus};
 
 #[cfg(feature = "gateway-store-mysql")]
 pub mod mysql;
@@ -37,8 +44,17 @@ pub mod postgres;
 pub mod redis;
 #[cfg(feature = "gateway-store-sqlite")]
 pub mod sqlite;
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+mod virtual_key_envelope;
 
+pub(crate) use memory_fine_tuning_jobs::LocalFineTuningJobStore;
 pub(crate) use memory_request_idempotency::LocalProxyRequestIdempotencyStore;
+pub(crate) use memory_sessions::LocalSessionStore;
 #[cfg(feature = "gateway-store-mysql")]
 pub use mysql::{MySqlStore, MySqlStoreError};
 #[cfg(feature = "gateway-store-postgres")]
@@ -47,6 +63,20 @@ pub use postgres::{PostgresStore, PostgresStoreError};
 pub use redis::{RedisStore, RedisStoreError};
 #[cfg(feature = "gateway-store-sqlite")]
 pub use sqlite::{SqliteStore, SqliteStoreError};
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+pub use virtual_key_envelope::{VirtualKeyEnvelope, VirtualKeyEnvelopeError};
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+use virtual_key_envelope::{persist_virtual_key, restore_virtual_key};
 
 #[cfg(feature = "gateway-store-sqlite")]
 #[async_trait]
@@ -383,3 +413,117 @@ impl ProxyRequestIdempotencyStore for RedisStore {
             .map_err(|err| ProxyRequestIdempotencyStoreError::new(err.to_string()))
     }
 }
+
+#[cfg(feature = "gateway-store-postgres")]
+#[async_trait]
+impl SessionStore for PostgresStore {
+    async fn load_session_messages(
+        &self,
+        session_key: &str,
+        now_ms: u64,
+    ) -> Result<Option<Vec<serde_json::Value>>, SessionStoreError> {
+        PostgresStore::load_session_messages(self, session_key, now_ms)
+            .await
+            .map_err(|err| SessionStoreError::new(err.to_string()))
+    }
+
+    async fn save_session_messages(
+        &self,
+        session_key: &str,
+        messages: &[serde_json::Value],
+        now_ms: u64,
+        ttl_ms: u64,
+    ) -> Result<(), SessionStoreError> {
+        PostgresStore::save_session_messages(self, session_key, messages, now_ms, ttl_ms)
+            .await
+            .map_err(|err| SessionStoreError::new(err.to_string()))
+    }
+}
+
+#[cfg(feature = "gateway-store-redis")]
+#[async_trait]
+impl SessionStore for RedisStore {
+    async fn load_session_messages(
+        &self,
+        session_key: &str,
+        _now_ms: u64,
+    ) -> Result<Option<Vec<serde_json::Value>>, SessionStoreError> {
+        RedisStore::load_session_messages(self, session_key)
+            .await
+            .map_err(|err| SessionStoreError::new(err.to_string()))
+    }
+
+    async fn save_session_messages(
+        &self,
+        session_key: &str,
+        messages: &[serde_json::Value],
+        _now_ms: u64,
+        ttl_ms: u64,
+    ) -> Result<(), SessionStoreError> {
+        RedisStore::save_session_messages(self, session_key, messages, ttl_ms)
+            .await
+            .map_err(|err| SessionStoreError::new(err.to_string()))
+    }
+}
+
+#[cfg(feature = "gateway-store-postgres")]
+#[async_trait]
+impl FineTuningJobStore for PostgresStore {
+    async fn load_fine_tuning_job(
+        &self,
+        job_id: &str,
+    ) -> Result<Option<FineTuningJobRecord>, FineTuningJobStoreError> {
+        PostgresStore::load_fine_tuning_job(self, job_id)
+            .await
+            .map_err(|err| FineTuningJobStoreError::new(err.to_string()))
+    }
+
+    async fn list_fine_tuning_jobs(
+        &self,
+        owner: &str,
+    ) -> Result<Vec<FineTuningJobRecord>, FineTuningJobStoreError> {
+        PostgresStore::list_fine_tuning_jobs(self, owner)
+            .await
+            .map_err(|err| FineTuningJobStoreError::new(err.to_string()))
+    }
+
+    async fn save_fine_tuning_job(
+        &self,
+        record: &FineTuningJobRecord,
+    ) -> Result<(), FineTuningJobStoreError> {
+        PostgresStore::save_fine_tuning_job(self, record)
+            .await
+            .map_err(|err| FineTuningJobStoreError::new(err.to_string()))
+    }
+}
+
+#[cfg(feature = "gateway-store-redis")]
+#[async_trait]
+impl FineTuningJobStore for RedisStore {
+    async fn load_fine_tuning_job(
+        &self,
+        job_id: &str,
+    ) -> Result<Option<FineTuningJobRecord>, FineTuningJobStoreError> {
+        RedisStore::load_fine_tuning_job(self, job_id)
+            .await
+            .map_err(|err| FineTuningJobStoreError::new(err.to_string()))
+    }
+
+    async fn list_fine_tuning_jobs(
+        &self,
+        owner: &str,
+    ) -> Result<Vec<FineTuningJobRecord>, FineTuningJobStoreError> {
+        RedisStore::list_fine_tuning_jobs(self, owner)
+            .await
+            .map_err(|err| FineTuningJobStoreError::new(err.to_string()))
+    }
+
+    async fn save_fine_tuning_job(
+        &self,
+        record: &FineTuningJobRecord,
+    ) -> Result<(), FineTuningJobStoreError> {
+        RedisStore::save_fine_tuning_job(self, record)
+            .await
+            .map_err(|err| FineTuningJobStoreError::new(err.to_string()))
+    }
+}
@@ -9,6 +9,7 @@ use super::{
     AuditLogRecord, BudgetLedgerRecord, CostLedgerRecord, ProxyRequestFingerprint,
     ProxyRequestIdempotencyBeginOutcome, ProxyRequestIdempotencyRecord,
     ProxyRequestIdempotencyState, ProxyRequestReplayOutcome, RouterConfig, VirtualKeyConfig,
+    VirtualKeyEnvelope, VirtualKeyEnvelopeError, persist_virtual_key, restore_virtual_key,
 };
 
 #[derive(Clone, Debug)]
@@ -16,6 +17,7 @@ pub struct MySqlStore {
     pool: sqlx::MySqlPool,
     audit_retention_secs: Option<u64>,
     audit_last_retention_reap_ms: Arc<AtomicI64>,
+    virtual_key_envelope: Option<Arc<VirtualKeyEnvelope>>,
 }
 
 const AUDIT_RETENTION_REAP_INTERVAL_MS: i64 = 30_000;
@@ -26,6 +28,8 @@ pub enum MySqlStoreError {
     MySql(#[from] sqlx::Error),
     #[error("json error: {0}")]
     Json(#[from] serde_json::Error),
+    #[error(transparent)]
+    Envelope(#[from] VirtualKeyEnvelopeError),
     #[error("schema check failed: {0}")]
     Schema(String),
     #[error("budget exceeded: limit={limit} attempted={attempted}")]
@@ -49,6 +53,7 @@ impl MySqlStore {
             pool,
             audit_retention_secs: None,
             audit_last_retention_reap_ms: Arc::new(AtomicI64::new(0)),
+            virtual_key_envelope: None,
         })
     }
 
@@ -57,6 +62,13 @@ impl MySqlStore {
         self
     }
 
+    /// Seals virtual key records written from now on; existing sealed
+    /// records need the same master key to load.
+    pub fn with_virtual_key_envelope(mut self, envelope: Option<Arc<VirtualKeyEnvelope>>) -> Self {
+        self.virtual_key_envelope = envelope;
+        self
+    }
+
     pub async fn ping(&self) -> Result<(), MySqlStoreError> {
         sqlx::query("SELECT 1").execute(&self.pool).await?;
         Ok(())
@@ -514,7 +526,10 @@ impl MySqlStore {
         let mut keys = Vec::with_capacity(rows.len());
         for row in rows {
             let raw: String = row.try_get("value_json")?;
-            keys.push(serde_json::from_str::<VirtualKeyConfig>(&raw)?);
+            keys.push(restore_virtual_key(
+                self.virtual_key_envelope.as_deref(),
+                serde_json::from_str(&raw)?,
+            )?);
         }
         Ok(keys)
     }
@@ -528,8 +543,8 @@ impl MySqlStore {
             .execute(&mut *tx)
             .await?;
         for key in keys {
-            let key = key.sanitized_for_persistence();
-            let value_json = serde_json::to_string(&key)?;
+            let record = persist_virtual_key(self.virtual_key_envelope.as_deref(), key)?;
+            let value_json = serde_json::to_string(&record)?;
             sqlx::query("INSERT INTO virtual_keys (id, value_json) VALUES (?, ?)")
                 .bind(&key.id)
                 .bind(value_json)
@@ -580,8 +595,8 @@ impl MySqlStore {
             .execute(&mut *tx)
             .await?;
         for key in keys {
-            let key = key.sanitized_for_persistence();
-            let value_json = serde_json::to_string(&key)?;
+            let record = persist_virtual_key(self.virtual_key_envelope.as_deref(), key)?;
+            let value_json = serde_json::to_string(&record)?;
             sqlx::query("INSERT INTO virtual_keys (id, value_json) VALUES (?, ?)")
                 .bind(&key.id)
                 .bind(value_json)
@@ -1049,6 +1064,58 @@ impl MySqlStore {
         Ok(())
     }
 
+    pub async fn get_budget_ledger(
+        &self,
+        key_id: &str,
+    ) -> Result<Option<BudgetLedgerRecord>, MySqlStoreError> {
+        let row = sqlx::query(
+            "SELECT spent_tokens, reserved_tokens, updated_at_ms
+             FROM budget_ledger
+             WHERE key_id = ?",
+        )
+        .bind(key_id)
+        .fetch_optional(&self.pool)
+        .await?;
+        let Some(row) = row else {
+            return Ok(None);
+        };
+        let spent_tokens: i64 = row.try_get("spent_tokens")?;
+        let reserved_tokens: i64 = row.try_get("reserved_tokens")?;
+        let updated_at_ms: i64 = row.try_get("updated_at_ms")?;
+        Ok(Some(BudgetLedgerRecord {
+            key_id: key_id.to_string(),
+            spent_tokens: i64_to_u64(spent_tokens),
+            reserved_tokens: i64_to_u64(reserved_tokens),
+            updated_at_ms: i64_to_u64(updated_at_ms),
+        }))
+    }
+
+    pub async fn get_cost_ledger(
+        &self,
+        key_id: &str,
+    ) -> Result<Option<CostLedgerRecord>, MySqlStoreError> {
+        let row = sqlx::query(
+            "SELECT spent_usd_micros, reserved_usd_micros, updated_at_ms
+             FROM cost_ledger
+             WHERE key_id = ?",
+        )
+        .bind(key_id)
+        .fetch_optional(&self.pool)
+        .await?;
+        let Some(row) = row else {
+            return Ok(None);
+        };
+        let spent_usd_micros: i64 = row.try_get("spent_usd_micros")?;
+        let reserved_usd_micros: i64 = row.try_get("reserved_usd_micros")?;
+        let updated_at_ms: i64 = row.try_get("updated_at_ms")?;
+        Ok(Some(CostLedgerRecord {
+            key_id: key_id.to_string(),
+            spent_usd_micros: i64_to_u64(spent_usd_micros),
+            reserved_usd_micros: i64_to_u64(reserved_usd_micros),
+            updated_at_ms: i64_to_u64(updated_at_ms),
+        }))
+    }
+
     pub async fn list_budget_ledgers(&self) -> Result<Vec<BudgetLedgerRecord>, MySqlStoreError> {
         let rows = sqlx::query(
             "SELECT CAST(key_id AS CHAR) AS key_id,
@@ -7,9 +7,10 @@ use sqlx::types::Json;
 use thiserror::Error;
 
 use super::{
-    AuditLogRecord, BudgetLedgerRecord, CostLedgerRecord, ProxyRequestFingerprint,
-    ProxyRequestIdempotencyBeginOutcome, ProxyRequestIdempotencyRecord,
+    AuditLogRecord, BudgetLedgerRecord, CostLedgerRecord, FineTuningJobRecord,
+    ProxyRequestFingerprint, ProxyRequestIdempotencyBeginOutcome, ProxyRequestIdempotencyRecord,
     ProxyRequestIdempotencyState, ProxyRequestReplayOutcome, RouterConfig, VirtualKeyConfig,
+    VirtualKeyEnvelope, VirtualKeyEnvelopeError, persist_virtual_key, restore_virtual_key,
 };
 
 #[derive(Clone, Debug)]
@@ -17,6 +18,7 @@ pub struct PostgresStore {
     pool: sqlx::PgPool,
     audit_retention_secs: Option<u64>,
     audit_last_retention_reap_ms: Arc<AtomicI64>,
+    virtual_key_envelope: Option<Arc<VirtualKeyEnvelope>>,
 }
 
 const AUDIT_RETENTION_REAP_INTERVAL_MS: i64 = 30_000;
@@ -27,6 +29,8 @@ pub enum PostgresStoreError {
     Postgres(#[from] sqlx::Error),
     #[error("json error: {0}")]
     Json(#[from] serde_json::Error),
+    #[error(transparent)]
+    Envelope(#[from] VirtualKeyEnvelopeError),
     #[error("schema check failed: {0}")]
     Schema(String),
     #[error("budget exceeded: limit={limit} attempted={attempted}")]
@@ -50,6 +54,7 @@ impl PostgresStore {
             pool,
             audit_retention_secs: None,
             audit_last_retention_reap_ms: Arc::new(AtomicI64::new(0)),
+            virtual_key_envelope: None,
         })
     }
 
@@ -58,6 +63,13 @@ impl PostgresStore {
         self
     }
 
+    /// Seals virtual key records written from now on; existing sealed
+    /// records need the same master key to load.
+    pub fn with_virtual_key_envelope(mut self, envelope: Option<Arc<VirtualKeyEnvelope>>) -> Self {
+        self.virtual_key_envelope = envelope;
+        self
+    }
+
     pub async fn ping(&self) -> Result<(), PostgresStoreError> {
         sqlx::query("SELECT 1").execute(&self.pool).await?;
         Ok(())
@@ -203,6 +215,39 @@ impl PostgresStore {
         )
         .execute(&self.pool)
         .await?;
+        sqlx::query(
+            "CREATE TABLE IF NOT EXISTS gateway_sessions (
+                session_key TEXT PRIMARY KEY NOT NULL,
+                messages_json JSONB NOT NULL,
+                expires_at_ms BIGINT NOT NULL,
+                updated_at_ms BIGINT NOT NULL
+            )",
+        )
+        .execute(&self.pool)
+        .await?;
+        sqlx::query(
+            "CREATE INDEX IF NOT EXISTS idx_gateway_sessions_expires_at_ms
+             ON gateway_sessions(expires_at_ms)",
+        )
+        .execute(&self.pool)
+        .await?;
+        sqlx::query(
+            "CREATE TABLE IF NOT EXISTS gateway_fine_tuning_jobs (
+                job_id TEXT PRIMARY KEY NOT NULL,
+                backend TEXT NOT NULL,
+                owner TEXT NOT NULL,
+                virtual_key_id TEXT NOT NULL,
+                created_at_ms BIGINT NOT NULL
+            )",
+        )
+        .execute(&self.pool)
+        .await?;
+        sqlx::query(
+            "CREATE INDEX IF NOT EXISTS idx_gateway_fine_tuning_jobs_owner_created_at_ms
+             ON gateway_fine_tuning_jobs(owner, created_at_ms)",
+        )
+        .execute(&self.pool)
+        .await?;
 
         // Best-effort in-place upgrades for deployments that created TEXT columns earlier.
         sqlx::query(
@@ -310,6 +355,8 @@ impl PostgresStore {
         require_pg_table(&self.pool, "cost_ledger").await?;
         require_pg_table(&self.pool, "cost_reservations").await?;
         require_pg_table(&self.pool, "proxy_request_idempotency").await?;
+        require_pg_table(&self.pool, "gateway_sessions").await?;
+        require_pg_table(&self.pool, "gateway_fine_tuning_jobs").await?;
 
         require_pg_column_udt(&self.pool, "virtual_keys", "value_json", "jsonb").await?;
         require_pg_column_udt(&self.pool, "config_state", "value_json", "jsonb").await?;
@@ -329,6 +376,18 @@ impl PostgresStore {
             "idx_proxy_request_idempotency_state_lease_until_ms",
         )
         .await?;
+        require_pg_index(
+            &self.pool,
+            "gateway_sessions",
+            "idx_gateway_sessions_expires_at_ms",
+        )
+        .await?;
+        require_pg_index(
+            &self.pool,
+            "gateway_fine_tuning_jobs",
+            "idx_gateway_fine_tuning_jobs_owner_created_at_ms",
+        )
+        .await?;
         require_pg_index(
             &self.pool,
             "budget_reservations",
@@ -410,7 +469,10 @@ impl PostgresStore {
         let mut keys = Vec::with_capacity(rows.len());
         for row in rows {
             let Json(raw): Json<serde_json::Value> = row.try_get("value_json")?;
-            keys.push(serde_json::from_value::<VirtualKeyConfig>(raw)?);
+            keys.push(restore_virtual_key(
+                self.virtual_key_envelope.as_deref(),
+                raw,
+            )?);
         }
         Ok(keys)
     }
@@ -424,8 +486,7 @@ impl PostgresStore {
             .execute(&mut *tx)
             .await?;
         for key in keys {
-            let key = key.sanitized_for_persistence();
-            let value_json = serde_json::to_value(&key)?;
+            let value_json = persist_virtual_key(self.virtual_key_envelope.as_deref(), key)?;
             sqlx::query("INSERT INTO virtual_keys (id, value_json) VALUES ($1, $2)")
                 .bind(&key.id)
                 .bind(Json(value_json))
@@ -472,8 +533,7 @@ impl PostgresStore {
             .execute(&mut *tx)
             .await?;
         for key in keys {
-            let key = key.sanitized_for_persistence();
-            let value_json = serde_json::to_value(&key)?;
+            let value_json = persist_virtual_key(self.virtual_key_envelope.as_deref(), key)?;
             sqlx::query("INSERT INTO virtual_keys (id, value_json) VALUES ($1, $2)")
                 .bind(&key.id)
                 .bind(Json(value_json))
@@ -950,6 +1010,58 @@ impl PostgresStore {
         Ok(())
     }
 
+    pub async fn get_budget_ledger(
+        &self,
+        key_id: &str,
+    ) -> Result<Option<BudgetLedgerRecord>, PostgresStoreError> {
+        let row = sqlx::query(
+            "SELECT spent_tokens, reserved_tokens, updated_at_ms
+             FROM budget_ledger
+             WHERE key_id = $1",
+        )
+        .bind(key_id)
+        .fetch_optional(&self.pool)
+        .await?;
+        let Some(row) = row else {
+            return Ok(None);
+        };
+        let spent_tokens: i64 = row.try_get("spent_tokens")?;
+        let reserved_tokens: i64 = row.try_get("reserved_tokens")?;
+        let updated_at_ms: i64 = row.try_get("updated_at_ms")?;
+        Ok(Some(BudgetLedgerRecord {
+            key_id: key_id.to_string(),
+            spent_tokens: i64_to_u64(spent_tokens),
+            reserved_tokens: i64_to_u64(reserved_tokens),
+            updated_at_ms: i64_to_u64(updated_at_ms),
+        }))
+    }
+
+    pub async fn get_cost_ledger(
+        &self,
+        key_id: &str,
+    ) -> Result<Option<CostLedgerRecord>, PostgresStoreError> {
+        let row = sqlx::query(
+            "SELECT spent_usd_micros, reserved_usd_micros, updated_at_ms
+             FROM cost_ledger
+             WHERE key_id = $1",
+        )
+        .bind(key_id)
+        .fetch_optional(&self.pool)
+        .await?;
+        let Some(row) = row else {
+            return Ok(None);
+        };
+        let spent_usd_micros: i64 = row.try_get("spent_usd_micros")?;
+        let reserved_usd_micros: i64 = row.try_get("reserved_usd_micros")?;
+        let updated_at_ms: i64 = row.try_get("updated_at_ms")?;
+        Ok(Some(CostLedgerRecord {
+            key_id: key_id.to_string(),
+            spent_usd_micros: i64_to_u64(spent_usd_micros),
+            reserved_usd_micros: i64_to_u64(reserved_usd_micros),
+            updated_at_ms: i64_to_u64(updated_at_ms),
+        }))
+    }
+
     pub async fn list_budget_ledgers(&self) -> Result<Vec<BudgetLedgerRecord>, PostgresStoreError> {
         let rows = sqlx::query(
             "SELECT key_id, spent_tokens, reserved_tokens, updated_at_ms
@@ -1628,6 +1740,132 @@ impl PostgresStore {
         .rows_affected();
         Ok(deleted > 0)
     }
+
+    pub async fn load_session_messages(
+        &self,
+        session_key: &str,
+        now_ms: u64,
+    ) -> Result<Option<Vec<serde_json::Value>>, PostgresStoreError> {
+        let row = sqlx::query(
+            "SELECT messages_json
+             FROM gateway_sessions
+             WHERE session_key = $1 AND expires_at_ms >= $2",
+        )
+        .bind(session_key)
+        .bind(u64_to_i64(now_ms))
+        .fetch_optional(&self.pool)
+        .await?;
+        let Some(row) = row else {
+            return Ok(None);
+        };
+        let Json(messages): Json<Vec<serde_json::Value>> = row.try_get("messages_json")?;
+        Ok(Some(messages))
+    }
+
+    /// Replaces the session and clears expired ones.
+    pub async fn save_session_messages(
+        &self,
+        session_key: &str,
+        messages: &[serde_json::Value],
+        now_ms: u64,
+        ttl_ms: u64,
+    ) -> Result<(), PostgresStoreError> {
+        sqlx::query("DELETE FROM gateway_sessions WHERE expires_at_ms < $1")
+            .bind(u64_to_i64(now_ms))
+            .execute(&self.pool)
+            .await?;
+        sqlx::query(
+            "INSERT INTO gateway_sessions (session_key, messages_json, expires_at_ms, updated_at_ms)
+             VALUES ($1, $2, $3, $4)
+             ON CONFLICT (session_key) DO UPDATE SET
+                messages_json = EXCLUDED.messages_json,
+                expires_at_ms = EXCLUDED.expires_at_ms,
+                updated_at_ms = EXCLUDED.updated_at_ms",
+        )
+        .bind(session_key)
+        .bind(Json(messages))
+        .bind(u64_to_i64(now_ms.saturating_add(ttl_ms)))
+        .bind(u64_to_i64(now_ms))
+        .execute(&self.pool)
+        .await?;
+        Ok(())
+    }
+
+    pub async fn load_fine_tuning_job(
+        &self,
+        job_id: &str,
+    ) -> Result<Option<FineTuningJobRecord>, PostgresStoreError> {
+        let row = sqlx::query(
+            "SELECT job_id, backend, owner, virtual_key_id, created_at_ms
+             FROM gateway_fine_tuning_jobs
+             WHERE job_id = $1",
+        )
+        .bind(job_id)
+        .fetch_optional(&self.pool)
+        .await?;
+        let Some(row) = row else {
+            return Ok(None);
+        };
+        let created_at_ms: i64 = row.try_get("created_at_ms")?;
+        Ok(Some(FineTuningJobRecord {
+            job_id: row.try_get("job_id")?,
+            backend: row.try_get("backend")?,
+            owner: row.try_get("owner")?,
+            virtual_key_id: row.try_get("virtual_key_id")?,
+            created_at_ms: created_at_ms.max(0) as u64,
+        }))
+    }
+
+    pub async fn list_fine_tuning_jobs(
+        &self,
+        owner: &str,
+    ) -> Result<Vec<FineTuningJobRecord>, PostgresStoreError> {
+        let rows = sqlx::query(
+            "SELECT job_id, backend, owner, virtual_key_id, created_at_ms
+             FROM gateway_fine_tuning_jobs
+             WHERE owner = $1
+             ORDER BY created_at_ms DESC",
+        )
+        .bind(owner)
+        .fetch_all(&self.pool)
+        .await?;
+
+        let mut out = Vec::with_capacity(rows.len());
+        for row in rows {
+            let created_at_ms: i64 = row.try_get("created_at_ms")?;
+            out.push(FineTuningJobRecord {
+                job_id: row.try_get("job_id")?,
+                backend: row.try_get("backend")?,
+                owner: row.try_get("owner")?,
+                virtual_key_id: row.try_get("virtual_key_id")?,
+                created_at_ms: created_at_ms.max(0) as u64,
+            });
+        }
+        Ok(out)
+    }
+
+    pub async fn save_fine_tuning_job(
+        &self,
+        record: &FineTuningJobRecord,
+    ) -> Result<(), PostgresStoreError> {
+        sqlx::query(
+            "INSERT INTO gateway_fine_tuning_jobs (job_id, backend, owner, virtual_key_id, created_at_ms)
+             VALUES ($1, $2, $3, $4, $5)
+             ON CONFLICT (job_id) DO UPDATE SET
+                backend = EXCLUDED.backend,
+                owner = EXCLUDED.owner,
+                virtual_key_id = EXCLUDED.virtual_key_id,
+                created_at_ms = EXCLUDED.created_at_ms",
+        )
+        .bind(&record.job_id)
+        .bind(&record.backend)
+        .bind(&record.owner)
+        .bind(&record.virtual_key_id)
+        .bind(u64_to_i64(record.created_at_ms))
+        .execute(&self.pool)
+        .await?;
+        Ok(())
+    }
 }
 
 async fn ensure_pg_check_constraint(
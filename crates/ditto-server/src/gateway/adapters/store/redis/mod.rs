@@ -8,9 +8,10 @@ use redis::AsyncCommands;
 use thiserror::Error;
 
 use super::{
-    AuditLogRecord, BudgetLedgerRecord, CostLedgerRecord, ProxyRequestFingerprint,
-    ProxyRequestIdempotencyBeginOutcome, ProxyRequestIdempotencyRecord,
+    AuditLogRecord, BudgetLedgerRecord, CostLedgerRecord, FineTuningJobRecord,
+    ProxyRequestFingerprint, ProxyRequestIdempotencyBeginOutcome, ProxyRequestIdempotencyRecord,
     ProxyRequestIdempotencyState, ProxyRequestReplayOutcome, RouterConfig, VirtualKeyConfig,
+    VirtualKeyEnvelope, VirtualKeyEnvelopeError, persist_virtual_key, restore_virtual_key,
 };
 
 #[cfg(feature = "gateway-proxy-cache")]
@@ -156,6 +157,7 @@ pub struct RedisStore {
     prefix: String,
     audit_retention_secs: Option<u64>,
     audit_last_retention_reap_ms: Arc<AtomicI64>,
+    virtual_key_envelope: Option<Arc<VirtualKeyEnvelope>>,
 }
 
 #[derive(Debug, Error)]
@@ -164,6 +166,8 @@ pub enum RedisStoreError {
     Redis(#[from] redis::RedisError),
     #[error("json error: {0}")]
     Json(#[from] serde_json::Error),
+    #[error(transparent)]
+    Envelope(#[from] VirtualKeyEnvelopeError),
     #[error("utf8 error: {0}")]
     Utf8(#[from] std::str::Utf8Error),
     #[error("integer parse error: {0}")]
@@ -238,6 +242,7 @@ impl RedisStore {
             prefix: "ditto".to_string(),
             audit_retention_secs: None,
             audit_last_retention_reap_ms: Arc::new(AtomicI64::new(0)),
+            virtual_key_envelope: None,
         })
     }
 
@@ -251,6 +256,13 @@ impl RedisStore {
         self
     }
 
+    /// Seals virtual key records written from now on; existing sealed
+    /// records need the same master key to load.
+    pub fn with_virtual_key_envelope(mut self, envelope: Option<Arc<VirtualKeyEnvelope>>) -> Self {
+        self.virtual_key_envelope = envelope;
+        self
+    }
+
     pub fn prefix(&self) -> &str {
         &self.prefix
     }
@@ -313,6 +325,18 @@ impl RedisStore {
         format!("{}:proxy_request_idempotency:{request_id}", self.prefix)
     }
 
+    fn key_session(&self, session_key: &str) -> String {
+        format!("{}:session:{session_key}", self.prefix)
+    }
+
+    fn key_fine_tuning_job(&self, job_id: &str) -> String {
+        format!("{}:fine_tuning_job:{job_id}", self.prefix)
+    }
+
+    fn key_fine_tuning_owner_jobs(&self, owner: &str) -> String {
+        format!("{}:fine_tuning_owner_jobs:{owner}", self.prefix)
+    }
+
     #[cfg(feature = "gateway-proxy-cache")]
     fn key_proxy_cache_response(&self, cache_key: &str) -> String {
         format!("{}:proxy_cache:{cache_key}", self.prefix)
@@ -371,7 +395,10 @@ impl RedisStore {
         let raw_map: HashMap<String, String> = conn.hgetall(key).await?;
         let mut out: Vec<VirtualKeyConfig> = Vec::with_capacity(raw_map.len());
         for (_id, raw) in raw_map {
-            out.push(serde_json::from_str(&raw)?);
+            out.push(restore_virtual_key(
+                self.virtual_key_envelope.as_deref(),
+                serde_json::from_str(&raw)?,
+            )?);
         }
         out.sort_by(|a, b| a.id.cmp(&b.id));
         Ok(out)
@@ -387,8 +414,8 @@ impl RedisStore {
         let mut pipe = redis::pipe();
         pipe.atomic().del(&redis_key);
         for key in keys {
-            let key = key.sanitized_for_persistence();
-            pipe.hset(&redis_key, &key.id, serde_json::to_string(&key)?);
+            let record = persist_virtual_key(self.virtual_key_envelope.as_deref(), key)?;
+            pipe.hset(&redis_key, &key.id, serde_json::to_string(&record)?);
         }
         let _: () = pipe.query_async(&mut conn).await?;
         Ok(())
@@ -427,8 +454,8 @@ impl RedisStore {
         let mut pipe = redis::pipe();
         pipe.atomic().del(&virtual_keys_key);
         for key in keys {
-            let key = key.sanitized_for_persistence();
-            pipe.hset(&virtual_keys_key, &key.id, serde_json::to_string(&key)?);
+            let record = persist_virtual_key(self.virtual_key_envelope.as_deref(), key)?;
+            pipe.hset(&virtual_keys_key, &key.id, serde_json::to_string(&record)?);
         }
         pipe.set(&router_key, router_json);
         let _: () = pipe.query_async(&mut conn).await?;
@@ -591,6 +618,87 @@ impl RedisStore {
         Ok(deleted > 0)
     }
 
+    pub async fn load_session_messages(
+        &self,
+        session_key: &str,
+    ) -> Result<Option<Vec<serde_json::Value>>, RedisStoreError> {
+        let mut conn = self.connection().await?;
+        let raw: Option<Vec<u8>> = conn.get(self.key_session(session_key)).await?;
+        let Some(raw) = raw else {
+            return Ok(None);
+        };
+        Ok(Some(serde_json::from_slice(&raw)?))
+    }
+
+    /// Replaces the session; Redis expires it `ttl_ms` after this turn.
+    pub async fn save_session_messages(
+        &self,
+        session_key: &str,
+        messages: &[serde_json::Value],
+        ttl_ms: u64,
+    ) -> Result<(), RedisStoreError> {
+        let mut conn = self.connection().await?;
+        let payload = serde_json::to_vec(messages)?;
+        let _: () = conn
+            .pset_ex(self.key_session(session_key), payload, ttl_ms.max(1))
+            .await?;
+        Ok(())
+    }
+
+    pub async fn load_fine_tuning_job(
+        &self,
+        job_id: &str,
+    ) -> Result<Option<FineTuningJobRecord>, RedisStoreError> {
+        let mut conn = self.connection().await?;
+        let raw: Option<Vec<u8>> = conn.get(self.key_fine_tuning_job(job_id)).await?;
+        let Some(raw) = raw else {
+            return Ok(None);
+        };
+        Ok(Some(serde_json::from_slice(&raw)?))
+    }
+
+    pub async fn save_fine_tuning_job(
+        &self,
+        record: &FineTuningJobRecord,
+    ) -> Result<(), RedisStoreError> {
+        let mut conn = self.connection().await?;
+        let payload = serde_json::to_vec(record)?;
+        let _: () = redis::pipe()
+            .atomic()
+            .set(self.key_fine_tuning_job(&record.job_id), payload)
+            .sadd(
+                self.key_fine_tuning_owner_jobs(&record.owner),
+                &record.job_id,
+            )
+            .query_async(&mut conn)
+            .await?;
+        Ok(())
+    }
+
+    pub async fn list_fine_tuning_jobs(
+        &self,
+        owner: &str,
+    ) -> Result<Vec<FineTuningJobRecord>, RedisStoreError> {
+        let mut conn = self.connection().await?;
+        let job_ids: Vec<String> = conn
+            .smembers(self.key_fine_tuning_owner_jobs(owner))
+            .await?;
+        let mut records = Vec::with_capacity(job_ids.len());
+        for job_id in job_ids {
+            let raw: Option<Vec<u8>> = conn.get(self.key_fine_tuning_job(&job_id)).await?;
+            let Some(raw) = raw else {
+                continue;
+            };
+            let record: FineTuningJobRecord = serde_json::from_slice(&raw)?;
+            // A job saved again under another owner stays in the old set.
+            if record.owner == owner {
+                records.push(record);
+            }
+        }
+        records.sort_by(|a, b| b.created_at_ms.cmp(&a.created_at_ms));
+        Ok(records)
+    }
+
     #[cfg(feature = "gateway-proxy-cache")]
     pub async fn get_proxy_cache_response(
         &self,
@@ -1238,6 +1346,28 @@ return { "OK", key_id }
         Ok(())
     }
 
+    pub async fn get_budget_ledger(
+        &self,
+        key_id: &str,
+    ) -> Result<Option<BudgetLedgerRecord>, RedisStoreError> {
+        let mut conn = self.connection().await?;
+        let raw: HashMap<String, String> = conn.hgetall(self.key_budget_ledger(key_id)).await?;
+        if raw.is_empty() {
+            return Ok(None);
+        }
+        let field = |name: &str| {
+            raw.get(name)
+                .and_then(|value| value.parse::<u64>().ok())
+                .unwrap_or(0)
+        };
+        Ok(Some(BudgetLedgerRecord {
+            key_id: key_id.to_string(),
+            spent_tokens: field("spent_tokens"),
+            reserved_tokens: field("reserved_tokens"),
+            updated_at_ms: field("updated_at_ms"),
+        }))
+    }
+
     pub async fn list_budget_ledgers(&self) -> Result<Vec<BudgetLedgerRecord>, RedisStoreError> {
         let mut conn = self.connection().await?;
         let mut key_ids: Vec<String> = conn.smembers(self.key_budget_keys()).await?;
@@ -1492,16 +1622,25 @@ for scope_index = 1, scope_count do
     return {3, scope_index}
   end
 
-  updates[scope_index] = {next_req_cur, next_tok_cur}
+  updates[scope_index] = {
+    next_req_cur,
+    next_tok_cur,
+    math.ceil(weighted_req / 60),
+    math.ceil(weighted_tok / 60),
+  }
 end
 
+-- on success also return each scope's weighted usage, in scope order
+local result = {1, 0}
 for scope_index = 1, scope_count do
   local key_offset = (scope_index - 1) * 4
   redis.call("SET", KEYS[key_offset + 1], updates[scope_index][1], "EX", ttl)
   redis.call("SET", KEYS[key_offset + 3], updates[scope_index][2], "EX", ttl)
+  table.insert(result, updates[scope_index][3])
+  table.insert(result, updates[scope_index][4])
 end
 
-return {1, 0}
+return result
 "#;
 
 const RATE_LIMIT_REFUND_MANY_SCRIPT: &str = r#"
@@ -1625,7 +1764,7 @@ impl RedisStore {
         route: &str,
         tokens: u32,
         now_epoch_seconds: u64,
-    ) -> Result<(), super::GatewayError>
+    ) -> Result<super::RateLimitStatus, super::GatewayError>
     where
         I: IntoIterator<Item = (&'a str, &'a super::LimitsConfig)>,
     {
@@ -1634,7 +1773,7 @@ impl RedisStore {
             .filter(|(_, limits)| limits.rpm.is_some() || limits.tpm.is_some())
             .collect::<Vec<_>>();
         if scoped_limits.is_empty() {
-            return Ok(());
+            return Ok(super::RateLimitStatus::default());
         }
 
         let minute = now_epoch_seconds / 60;
@@ -1677,7 +1816,15 @@ impl RedisStore {
             .and_then(|index| usize::try_from(index).ok());
 
         match code {
-            1 => Ok(()),
+            1 => {
+                let usage = result.get(2..).unwrap_or_default();
+                let clamp = |value: i64| u32::try_from(value.max(0)).unwrap_or(u32::MAX);
+                let mut status = super::RateLimitStatus::default();
+                for ((_, limits), used) in scoped_limits.iter().zip(usage.chunks_exact(2)) {
+                    status.observe(limits, clamp(used[0]), clamp(used[1]));
+                }
+                Ok(status)
+            }
             2 => {
                 let Some(scope_offset) = scope_index.and_then(|index| index.checked_sub(1)) else {
                     return Err(super::GatewayError::Backend {
@@ -1896,6 +2043,28 @@ impl RedisStore {
         Ok(out)
     }
 
+    pub async fn get_cost_ledger(
+        &self,
+        key_id: &str,
+    ) -> Result<Option<CostLedgerRecord>, RedisStoreError> {
+        let mut conn = self.connection().await?;
+        let raw: HashMap<String, String> = conn.hgetall(self.key_cost_ledger(key_id)).await?;
+        if raw.is_empty() {
+            return Ok(None);
+        }
+        let field = |name: &str| {
+            raw.get(name)
+                .and_then(|value| value.parse::<u64>().ok())
+                .unwrap_or(0)
+        };
+        Ok(Some(CostLedgerRecord {
+            key_id: key_id.to_string(),
+            spent_usd_micros: field("spent_usd_micros"),
+            reserved_usd_micros: field("reserved_usd_micros"),
+            updated_at_ms: field("updated_at_ms"),
+        }))
+    }
+
     pub async fn list_cost_ledgers(&self) -> Result<Vec<CostLedgerRecord>, RedisStoreError> {
         let mut conn = self.connection().await?;
         let mut key_ids: Vec<String> = conn.smembers(self.key_cost_keys()).await?;
@@ -2373,7 +2542,7 @@ mod tests {
             tpm: Some(1000),
         };
 
-        store
+        let status = store
             .check_and_consume_rate_limits_many(
                 [("key-many", &shared_limits), ("tenant:t1", &shared_limits)],
                 route,
@@ -2382,6 +2551,13 @@ mod tests {
             )
             .await
             .expect("first batched request allowed");
+        assert_eq!(
+            status.requests,
+            Some(crate::gateway::RateLimitRemaining {
+                limit: 2,
+                remaining: 1,
+            })
+        );
 
         store
             .check_and_consume_rate_limits("user:u1", route, &tight_limits, 1, now_epoch_seconds)
@@ -10,6 +10,7 @@ use super::{
     AuditLogRecord, BudgetLedgerRecord, CostLedgerRecord, ProxyRequestFingerprint,
     ProxyRequestIdempotencyBeginOutcome, ProxyRequestIdempotencyRecord,
     ProxyRequestIdempotencyState, ProxyRequestReplayOutcome, RouterConfig, VirtualKeyConfig,
+    VirtualKeyEnvelope, VirtualKeyEnvelopeError, persist_virtual_key, restore_virtual_key,
 };
 
 #[derive(Clone, Debug)]
@@ -17,6 +18,7 @@ pub struct SqliteStore {
     path: PathBuf,
     audit_retention_secs: Option<u64>,
     audit_last_retention_reap_ms: Arc<AtomicI64>,
+    virtual_key_envelope: Option<Arc<VirtualKeyEnvelope>>,
 }
 
 const AUDIT_RETENTION_REAP_INTERVAL_MS: i64 = 30_000;
@@ -31,6 +33,8 @@ pub enum SqliteStoreError {
     Json(#[from] serde_json::Error),
     #[error("schema check failed: {0}")]
     Schema(String),
+    #[error(transparent)]
+    Envelope(#[from] VirtualKeyEnvelopeError),
     #[error("budget exceeded: limit={limit} attempted={attempted}")]
     BudgetExceeded { limit: u64, attempted: u64 },
     #[error(
@@ -48,6 +52,7 @@ impl SqliteStore {
             path: path.into(),
             audit_retention_secs: None,
             audit_last_retention_reap_ms: Arc::new(AtomicI64::new(0)),
+            virtual_key_envelope: None,
         }
     }
 
@@ -60,6 +65,13 @@ impl SqliteStore {
         self
     }
 
+    /// Seals virtual key records written from now on; existing sealed
+    /// records need the same master key to load.
+    pub fn with_virtual_key_envelope(mut self, envelope: Option<Arc<VirtualKeyEnvelope>>) -> Self {
+        self.virtual_key_envelope = envelope;
+        self
+    }
+
     pub async fn init(&self) -> Result<(), SqliteStoreError> {
         let path = self.path.clone();
         tokio::task::spawn_blocking(move || -> Result<(), SqliteStoreError> {
@@ -144,6 +156,7 @@ impl SqliteStore {
 
     pub async fn load_virtual_keys(&self) -> Result<Vec<VirtualKeyConfig>, SqliteStoreError> {
         let path = self.path.clone();
+        let envelope = self.virtual_key_envelope.clone();
         tokio::task::spawn_blocking(move || -> Result<Vec<VirtualKeyConfig>, SqliteStoreError> {
             let conn = open_connection(path)?;
             init_schema(&conn)?;
@@ -154,7 +167,7 @@ impl SqliteStore {
             let mut keys = Vec::new();
             for row in rows {
                 let raw = row?;
-                let key: VirtualKeyConfig = serde_json::from_str(&raw)?;
+                let key = restore_virtual_key(envelope.as_deref(), serde_json::from_str(&raw)?)?;
                 keys.push(key);
             }
             Ok(keys)
@@ -170,10 +183,10 @@ impl SqliteStore {
         let serialized: Vec<(String, String)> = keys
             .iter()
             .map(|key| {
-                let key = key.sanitized_for_persistence();
-                Ok((key.id.clone(), serde_json::to_string(&key)?))
+                let record = persist_virtual_key(self.virtual_key_envelope.as_deref(), key)?;
+                Ok((key.id.clone(), serde_json::to_string(&record)?))
             })
-            .collect::<Result<_, serde_json::Error>>()?;
+            .collect::<Result<_, SqliteStoreError>>()?;
 
         tokio::task::spawn_blocking(move || -> Result<(), SqliteStoreError> {
             let mut conn = open_connection(path)?;
@@ -244,10 +257,10 @@ impl SqliteStore {
         let serialized_keys: Vec<(String, String)> = keys
             .iter()
             .map(|key| {
-                let key = key.sanitized_for_persistence();
-                Ok((key.id.clone(), serde_json::to_string(&key)?))
+                let record = persist_virtual_key(self.virtual_key_envelope.as_deref(), key)?;
+                Ok((key.id.clone(), serde_json::to_string(&record)?))
             })
-            .collect::<Result<_, serde_json::Error>>()?;
+            .collect::<Result<_, SqliteStoreError>>()?;
         let router_json = serde_json::to_string(router)?;
 
         tokio::task::spawn_blocking(move || -> Result<(), SqliteStoreError> {
@@ -1085,6 +1098,84 @@ impl SqliteStore {
         .await?
     }
 
+    pub async fn get_budget_ledger(
+        &self,
+        key_id: &str,
+    ) -> Result<Option<BudgetLedgerRecord>, SqliteStoreError> {
+        let path = self.path.clone();
+        let key_id = key_id.to_string();
+        tokio::task::spawn_blocking(
+            move || -> Result<Option<BudgetLedgerRecord>, SqliteStoreError> {
+                let conn = open_connection(path)?;
+                init_schema(&conn)?;
+
+                let row = conn
+                    .query_row(
+                        "SELECT spent_tokens, reserved_tokens, updated_at_ms
+                     FROM budget_ledger
+                     WHERE key_id = ?1",
+                        [&key_id],
+                        |row| {
+                            Ok((
+                                row.get::<_, i64>(0)?,
+                                row.get::<_, i64>(1)?,
+                                row.get::<_, i64>(2)?,
+                            ))
+                        },
+                    )
+                    .optional()?;
+                Ok(row.map(
+                    |(spent_tokens, reserved_tokens, updated_at_ms)| BudgetLedgerRecord {
+                        key_id,
+                        spent_tokens: i64_to_u64(spent_tokens),
+                        reserved_tokens: i64_to_u64(reserved_tokens),
+                        updated_at_ms: i64_to_u64(updated_at_ms),
+                    },
+                ))
+            },
+        )
+        .await?
+    }
+
+    pub async fn get_cost_ledger(
+        &self,
+        key_id: &str,
+    ) -> Result<Option<CostLedgerRecord>, SqliteStoreError> {
+        let path = self.path.clone();
+        let key_id = key_id.to_string();
+        tokio::task::spawn_blocking(
+            move || -> Result<Option<CostLedgerRecord>, SqliteStoreError> {
+                let conn = open_connection(path)?;
+                init_schema(&conn)?;
+
+                let row = conn
+                    .query_row(
+                        "SELECT spent_usd_micros, reserved_usd_micros, updated_at_ms
+                     FROM cost_ledger
+                     WHERE key_id = ?1",
+                        [&key_id],
+                        |row| {
+                            Ok((
+                                row.get::<_, i64>(0)?,
+                                row.get::<_, i64>(1)?,
+                                row.get::<_, i64>(2)?,
+                            ))
+                        },
+                    )
+                    .optional()?;
+                Ok(
+                    row.map(|(spent, reserved, updated_at_ms)| CostLedgerRecord {
+                        key_id,
+                        spent_usd_micros: i64_to_u64(spent),
+                        reserved_usd_micros: i64_to_u64(reserved),
+                        updated_at_ms: i64_to_u64(updated_at_ms),
+                    }),
+                )
+            },
+        )
+        .await?
+    }
+
     pub async fn begin_proxy_request_idempotency(
         &self,
         request_id: &str,
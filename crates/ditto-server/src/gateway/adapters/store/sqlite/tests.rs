@@ -18,7 +18,10 @@ async fn sqlite_store_round_trips_virtual_keys() {
     let loaded = store.load_virtual_keys().await.expect("load");
     assert_eq!(loaded.len(), 1);
     assert_eq!(loaded[0].id, "key-1");
-    assert_eq!(loaded[0].token, key.sanitized_for_persistence().token);
+    assert!(crate::gateway::config::virtual_key_token_is_persisted_hash(
+        &loaded[0].token
+    ));
+    assert!(loaded[0].matches_token("vk-1"));
 
     store
         .replace_virtual_keys(&[])
@@ -28,6 +31,49 @@ async fn sqlite_store_round_trips_virtual_keys() {
     assert!(loaded.is_empty());
 }
 
+#[tokio::test]
+async fn sqlite_store_seals_virtual_keys_with_envelope() {
+    let dir = tempfile::tempdir().expect("tempdir");
+    let path = dir.path().join("gateway.sqlite");
+    let plain = SqliteStore::new(&path);
+    plain.init().await.expect("init");
+
+    let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+    key.tenant_id = Some("tenant-a".to_string());
+    plain
+        .replace_virtual_keys(std::slice::from_ref(&key))
+        .await
+        .expect("persist plaintext");
+
+    let envelope = Arc::new(
+        VirtualKeyEnvelope::from_base64("AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=")
+            .expect("envelope"),
+    );
+    let sealed = SqliteStore::new(&path).with_virtual_key_envelope(Some(envelope));
+    let loaded = sealed.load_virtual_keys().await.expect("load plaintext");
+    assert_eq!(loaded[0].tenant_id.as_deref(), Some("tenant-a"));
+    sealed
+        .replace_virtual_keys(&loaded)
+        .await
+        .expect("migrate to sealed");
+
+    let raw: String = rusqlite::Connection::open(&path)
+        .expect("open")
+        .query_row("SELECT value_json FROM virtual_keys", [], |row| row.get(0))
+        .expect("raw row");
+    assert!(!raw.contains("tenant-a"));
+
+    let loaded = sealed.load_virtual_keys().await.expect("load sealed");
+    assert_eq!(loaded[0].tenant_id.as_deref(), Some("tenant-a"));
+    assert!(loaded[0].matches_token("vk-1"));
+
+    let err = plain.load_virtual_keys().await.unwrap_err();
+    assert!(matches!(
+        err,
+        SqliteStoreError::Envelope(VirtualKeyEnvelopeError::MissingMasterKey { .. })
+    ));
+}
+
 #[tokio::test]
 async fn sqlite_store_round_trips_router_config() {
     let dir = tempfile::tempdir().expect("tempdir");
@@ -0,0 +1,328 @@
+//! Envelope encryption for virtual key records at rest.
+//!
+//! Each record gets a fresh data key that encrypts everything except `id`,
+//! `token` (already a salted hash) and `enabled`; the data key itself is
+//! wrapped by the master key. The clear fields are bound as associated data,
+//! so editing them in the store makes the record fail to unseal.
+
+use base64::Engine as _;
+use base64::engine::general_purpose::STANDARD as BASE64;
+use omne_integrity_primitives::hash_sha256;
+use ring::aead::{AES_256_GCM, Aad, LessSafeKey, NONCE_LEN, Nonce, UnboundKey};
+use ring::rand::{SecureRandom, SystemRandom};
+use serde::{Deserialize, Serialize};
+use serde_json::{Map, Value};
+use thiserror::Error;
+
+use super::VirtualKeyConfig;
+
+const SEALED_FIELD: &str = "sealed";
+const SEALED_VERSION: u32 = 1;
+const CLEAR_FIELDS: [&str; 3] = ["id", "token", "enabled"];
+const KEY_LEN: usize = 32;
+const KID_HEX_LEN: usize = 8;
+
+#[derive(Debug, Error)]
+pub enum VirtualKeyEnvelopeError {
+    #[error("invalid virtual key master key: {0}")]
+    InvalidMasterKey(String),
+    #[error("virtual key `{id}` is sealed but no master key is configured")]
+    MissingMasterKey { id: String },
+    #[error(
+        "virtual key `{id}` is sealed with master key `{kid}`, not the configured `{expected}`"
+    )]
+    KeyMismatch {
+        id: String,
+        kid: String,
+        expected: String,
+    },
+    #[error("virtual key `{id}` could not be unsealed: {reason}")]
+    Unseal { id: String, reason: String },
+    #[error("virtual key sealing failed: {0}")]
+    Seal(String),
+    #[error("json error: {0}")]
+    Json(#[from] serde_json::Error),
+}
+
+/// Master key used to seal virtual key records. Records carry the key id
+/// (`kid`, a digest prefix of the master key) they were sealed with.
+pub struct VirtualKeyEnvelope {
+    kid: String,
+    kek: LessSafeKey,
+    rng: SystemRandom,
+}
+
+impl std::fmt::Debug for VirtualKeyEnvelope {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        f.debug_struct("VirtualKeyEnvelope")
+            .field("kid", &self.kid)
+            .field("kek", &"<redacted>")
+            .finish()
+    }
+}
+
+#[derive(Debug, Serialize, Deserialize)]
+struct SealedVirtualKey {
+    v: u32,
+    kid: String,
+    dek: String,
+    data: String,
+}
+
+impl VirtualKeyEnvelope {
+    /// `master_key` is 32 random bytes, base64-encoded
+    /// (`openssl rand -base64 32`).
+    pub fn from_base64(master_key: &str) -> Result<Self, VirtualKeyEnvelopeError> {
+        let bytes = BASE64
+            .decode(master_key.trim())
+            .map_err(|err| VirtualKeyEnvelopeError::InvalidMasterKey(err.to_string()))?;
+        if bytes.len() != KEY_LEN {
+            return Err(VirtualKeyEnvelopeError::InvalidMasterKey(format!(
+                "expected {KEY_LEN} bytes, got {}",
+                bytes.len()
+            )));
+        }
+        let kid = hash_sha256(&bytes).to_string()[..KID_HEX_LEN].to_string();
+        let kek = aead_key(&bytes).ok_or_else(|| {
+            VirtualKeyEnvelopeError::InvalidMasterKey("unusable key material".to_string())
+        })?;
+        Ok(Self {
+            kid,
+            kek,
+            rng: SystemRandom::new(),
+        })
+    }
+
+    pub fn kid(&self) -> &str {
+        &self.kid
+    }
+
+    fn seal(&self, key: &VirtualKeyConfig) -> Result<Value, VirtualKeyEnvelopeError> {
+        let Value::Object(mut fields) = serde_json::to_value(key.sanitized_for_persistence())?
+        else {
+            return Err(VirtualKeyEnvelopeError::Seal(
+                "virtual key did not serialize to an object".to_string(),
+            ));
+        };
+        let mut record = Map::new();
+        for field in CLEAR_FIELDS {
+            if let Some(value) = fields.remove(field) {
+                record.insert(field.to_string(), value);
+            }
+        }
+
+        let mut dek = [0u8; KEY_LEN];
+        self.rng
+            .fill(&mut dek)
+            .map_err(|_| VirtualKeyEnvelopeError::Seal("data key generation failed".to_string()))?;
+        let data_key = aead_key(&dek)
+            .ok_or_else(|| VirtualKeyEnvelopeError::Seal("unusable data key".to_string()))?;
+        let data = self.seal_bytes(
+            &data_key,
+            &clear_fields_aad(&record)?,
+            &serde_json::to_vec(&fields)?,
+        )?;
+        let wrapped = self.seal_bytes(&self.kek, self.kid.as_bytes(), &dek)?;
+
+        let sealed = SealedVirtualKey {
+            v: SEALED_VERSION,
+            kid: self.kid.clone(),
+            dek: BASE64.encode(wrapped),
+            data: BASE64.encode(data),
+        };
+        record.insert(SEALED_FIELD.to_string(), serde_json::to_value(sealed)?);
+        Ok(Value::Object(record))
+    }
+
+    fn open(
+        &self,
+        id: &str,
+        sealed: SealedVirtualKey,
+        mut record: Map<String, Value>,
+    ) -> Result<VirtualKeyConfig, VirtualKeyEnvelopeError> {
+        let unseal = |reason: &str| VirtualKeyEnvelopeError::Unseal {
+            id: id.to_string(),
+            reason: reason.to_string(),
+        };
+        if sealed.v != SEALED_VERSION {
+            return Err(unseal(&format!("unsupported sealed version {}", sealed.v)));
+        }
+        if sealed.kid != self.kid {
+            return Err(VirtualKeyEnvelopeError::KeyMismatch {
+                id: id.to_string(),
+                kid: sealed.kid,
+                expected: self.kid.clone(),
+            });
+        }
+
+        let wrapped = BASE64
+            .decode(&sealed.dek)
+            .map_err(|_| unseal("data key is not valid base64"))?;
+        let dek = open_bytes(&self.kek, self.kid.as_bytes(), wrapped)
+            .ok_or_else(|| unseal("master key rejected the data key"))?;
+        let data_key = aead_key(&dek).ok_or_else(|| unseal("unusable data key"))?;
+        let data = BASE64
+            .decode(&sealed.data)
+            .map_err(|_| unseal("data is not valid base64"))?;
+        let data = open_bytes(&data_key, &clear_fields_aad(&record)?, data)
+            .ok_or_else(|| unseal("record failed authentication"))?;
+
+        let fields: Map<String, Value> = serde_json::from_slice(&data)?;
+        for (field, value) in fields {
+            record.entry(field).or_insert(value);
+        }
+        Ok(serde_json::from_value(Value::Object(record))?)
+    }
+
+    fn seal_bytes(
+        &self,
+        key: &LessSafeKey,
+        aad: &[u8],
+        plaintext: &[u8],
+    ) -> Result<Vec<u8>, VirtualKeyEnvelopeError> {
+        let mut nonce = [0u8; NONCE_LEN];
+        self.rng
+            .fill(&mut nonce)
+            .map_err(|_| VirtualKeyEnvelopeError::Seal("nonce generation failed".to_string()))?;
+        let mut in_out = plaintext.to_vec();
+        key.seal_in_place_append_tag(
+            Nonce::assume_unique_for_key(nonce),
+            Aad::from(aad),
+            &mut in_out,
+        )
+        .map_err(|_| VirtualKeyEnvelopeError::Seal("encryption failed".to_string()))?;
+        let mut out = nonce.to_vec();
+        out.extend_from_slice(&in_out);
+        Ok(out)
+    }
+}
+
+fn aead_key(bytes: &[u8]) -> Option<LessSafeKey> {
+    UnboundKey::new(&AES_256_GCM, bytes)
+        .ok()
+        .map(LessSafeKey::new)
+}
+
+fn open_bytes(key: &LessSafeKey, aad: &[u8], mut sealed: Vec<u8>) -> Option<Vec<u8>> {
+    if sealed.len() < NONCE_LEN {
+        return None;
+    }
+    let mut in_out = sealed.split_off(NONCE_LEN);
+    let nonce = Nonce::try_assume_unique_for_key(&sealed).ok()?;
+    let plaintext = key.open_in_place(nonce, Aad::from(aad), &mut in_out).ok()?;
+    Some(plaintext.to_vec())
+}
+
+fn clear_fields_aad(record: &Map<String, Value>) -> Result<Vec<u8>, VirtualKeyEnvelopeError> {
+    let clear = CLEAR_FIELDS
+        .iter()
+        .map(|field| record.get(*field).cloned().unwrap_or(Value::Null))
+        .collect::<Vec<_>>();
+    Ok(serde_json::to_vec(&clear)?)
+}
+
+/// Store form of `key`: sealed when a master key is configured, otherwise the
+/// plain record with its token hashed.
+pub(crate) fn persist_virtual_key(
+    envelope: Option<&VirtualKeyEnvelope>,
+    key: &VirtualKeyConfig,
+) -> Result<Value, VirtualKeyEnvelopeError> {
+    match envelope {
+        Some(envelope) => envelope.seal(key),
+        None => Ok(serde_json::to_value(key.sanitized_for_persistence())?),
+    }
+}
+
+/// Inverse of [`persist_virtual_key`]. Unsealed records load as they are, so
+/// stores written before a master key was configured keep working until they
+/// are rewritten.
+pub(crate) fn restore_virtual_key(
+    envelope: Option<&VirtualKeyEnvelope>,
+    record: Value,
+) -> Result<VirtualKeyConfig, VirtualKeyEnvelopeError> {
+    let Value::Object(mut record) = record else {
+        return Ok(serde_json::from_value(record)?);
+    };
+    let Some(sealed) = record.remove(SEALED_FIELD) else {
+        return Ok(serde_json::from_value(Value::Object(record))?);
+    };
+    let id = record
+        .get("id")
+        .and_then(Value::as_str)
+        .unwrap_or_default()
+        .to_string();
+    let Some(envelope) = envelope else {
+        return Err(VirtualKeyEnvelopeError::MissingMasterKey { id });
+    };
+    let sealed: SealedVirtualKey = serde_json::from_value(sealed)?;
+    envelope.open(&id, sealed, record)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    const MASTER_KEY: &str = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=";
+    const OTHER_MASTER_KEY: &str = "HxAdHBsaGRgXFhUUExIREA8ODQwLCgkIBwYFBAMCAQA=";
+
+    fn tagged_key() -> VirtualKeyConfig {
+        let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+        key.tenant_id = Some("tenant-a".to_string());
+        key.tags = vec!["team:search".to_string()];
+        key
+    }
+
+    #[test]
+    fn sealed_records_round_trip_and_hide_metadata() {
+        let envelope = VirtualKeyEnvelope::from_base64(MASTER_KEY).expect("envelope");
+        let record = persist_virtual_key(Some(&envelope), &tagged_key()).expect("seal");
+
+        let raw = record.to_string();
+        assert!(!raw.contains("tenant-a"));
+        assert!(!raw.contains("team:search"));
+        assert!(!raw.contains("vk-1"));
+        assert_eq!(record["id"], "key-1");
+        assert_eq!(record["sealed"]["kid"], envelope.kid());
+
+        let restored = restore_virtual_key(Some(&envelope), record).expect("open");
+        assert_eq!(restored.tenant_id.as_deref(), Some("tenant-a"));
+        assert_eq!(restored.tags, vec!["team:search"]);
+        assert!(restored.matches_token("vk-1"));
+    }
+
+    #[test]
+    fn plaintext_records_load_without_a_master_key() {
+        let envelope = VirtualKeyEnvelope::from_base64(MASTER_KEY).expect("envelope");
+        let record = persist_virtual_key(None, &tagged_key()).expect("persist");
+        assert!(record.get(SEALED_FIELD).is_none());
+
+        let restored = restore_virtual_key(Some(&envelope), record.clone()).expect("restore");
+        assert_eq!(restored.tenant_id.as_deref(), Some("tenant-a"));
+        let restored = restore_virtual_key(None, record).expect("restore");
+        assert!(restored.matches_token("vk-1"));
+    }
+
+    #[test]
+    fn sealed_records_reject_missing_wrong_or_tampered_keys() {
+        let envelope = VirtualKeyEnvelope::from_base64(MASTER_KEY).expect("envelope");
+        let record = persist_virtual_key(Some(&envelope), &tagged_key()).expect("seal");
+
+        let err = restore_virtual_key(None, record.clone()).unwrap_err();
+        assert!(matches!(err, VirtualKeyEnvelopeError::MissingMasterKey { id } if id == "key-1"));
+
+        let other = VirtualKeyEnvelope::from_base64(OTHER_MASTER_KEY).expect("envelope");
+        let err = restore_virtual_key(Some(&other), record.clone()).unwrap_err();
+        assert!(matches!(err, VirtualKeyEnvelopeError::KeyMismatch { .. }));
+
+        let mut tampered = record;
+        tampered["enabled"] = Value::Bool(false);
+        let err = restore_virtual_key(Some(&envelope), tampered).unwrap_err();
+        assert!(matches!(err, VirtualKeyEnvelopeError::Unseal { .. }));
+    }
+
+    #[test]
+    fn master_key_must_be_32_base64_bytes() {
+        assert!(VirtualKeyEnvelope::from_base64("not base64!").is_err());
+        assert!(VirtualKeyEnvelope::from_base64("c2hvcnQ=").is_err());
+    }
+}
@@ -46,6 +46,10 @@ pub struct PrometheusMetrics {
     proxy_budget_exceeded_by_model: HashMap<String, u64>,
     proxy_budget_exceeded_by_path: HashMap<String, u64>,
 
+    proxy_access_denied_total: u64,
+    proxy_access_denied_by_key: HashMap<String, u64>,
+    proxy_access_denied_by_reason: HashMap<String, u64>,
+
     proxy_cache_lookups_total: u64,
     proxy_cache_lookups_by_path: HashMap<String, u64>,
 
@@ -92,6 +96,17 @@ pub struct PrometheusMetrics {
     proxy_responses_by_path_status: HashMap<String, HashMap<u16, u64>>,
     proxy_responses_by_backend_status: HashMap<String, HashMap<u16, u64>>,
     proxy_responses_by_model_status: HashMap<String, HashMap<u16, u64>>,
+
+    proxy_experiment_arms: HashMap<String, HashMap<String, ExperimentArmMetrics>>,
+
+    proxy_tier_admissions: HashMap<String, HashMap<String, u64>>,
+    proxy_tier_queue_wait_seconds: HashMap<String, DurationHistogram>,
+}
+
+#[derive(Debug, Default)]
+struct ExperimentArmMetrics {
+    responses_by_status: HashMap<u16, u64>,
+    duration_seconds: DurationHistogram,
 }
 
 impl PrometheusMetrics {
@@ -114,6 +129,9 @@ impl PrometheusMetrics {
             proxy_budget_exceeded_by_key: HashMap::new(),
             proxy_budget_exceeded_by_model: HashMap::new(),
             proxy_budget_exceeded_by_path: HashMap::new(),
+            proxy_access_denied_total: 0,
+            proxy_access_denied_by_key: HashMap::new(),
+            proxy_access_denied_by_reason: HashMap::new(),
             proxy_cache_lookups_total: 0,
             proxy_cache_lookups_by_path: HashMap::new(),
             proxy_cache_hits_total: 0,
@@ -150,6 +168,9 @@ impl PrometheusMetrics {
             proxy_responses_by_path_status: HashMap::new(),
             proxy_responses_by_backend_status: HashMap::new(),
             proxy_responses_by_model_status: HashMap::new(),
+            proxy_experiment_arms: HashMap::new(),
+            proxy_tier_admissions: HashMap::new(),
+            proxy_tier_queue_wait_seconds: HashMap::new(),
         }
     }
 
@@ -257,6 +278,16 @@ impl PrometheusMetrics {
         );
     }
 
+    pub fn record_proxy_access_denied(&mut self, virtual_key_id: Option<&str>, reason: &str) {
+        self.proxy_access_denied_total = self.proxy_access_denied_total.saturating_add(1);
+        bump_limited(
+            &mut self.proxy_access_denied_by_key,
+            virtual_key_id.unwrap_or("public"),
+            self.config.max_key_series,
+        );
+        bump_limited(&mut self.proxy_access_denied_by_reason, reason, 8);
+    }
+
     pub fn record_proxy_cache_lookup(&mut self, path: &str) {
         self.proxy_cache_lookups_total = self.proxy_cache_lookups_total.saturating_add(1);
         bump_limited(
@@ -513,6 +544,51 @@ impl PrometheusMetrics {
             *entry = entry.saturating_add(1);
         }
     }
+
+    pub fn record_proxy_experiment_response(
+        &mut self,
+        experiment: &str,
+        arm: &str,
+        status: u16,
+        duration: Duration,
+    ) {
+        let Some(arms) = entry_limited(
+            &mut self.proxy_experiment_arms,
+            experiment,
+            self.config.max_model_series,
+        ) else {
+            return;
+        };
+        if let Some(metrics) = entry_limited(arms, arm, self.config.max_backend_series) {
+            let entry = metrics.responses_by_status.entry(status).or_default();
+            *entry = entry.saturating_add(1);
+            metrics.duration_seconds.observe(duration);
+        }
+    }
+
+    pub fn record_proxy_tier_admission(
+        &mut self,
+        tier: &str,
+        decision: &str,
+        queue_wait: Duration,
+    ) {
+        let Some(decisions) = entry_limited(
+            &mut self.proxy_tier_admissions,
+            tier,
+            self.config.max_backend_series,
+        ) else {
+            return;
+        };
+        let entry = decisions.entry(decision.to_string()).or_default();
+        *entry = entry.saturating_add(1);
+        if let Some(hist) = entry_limited(
+            &mut self.proxy_tier_queue_wait_seconds,
+            tier,
+            self.config.max_backend_series,
+        ) {
+            hist.observe(queue_wait);
+        }
+    }
 }
 // end inline: ../../../metrics_prometheus/core.rs
 // inlined from ../../../metrics_prometheus/render.rs
@@ -650,6 +726,31 @@ impl PrometheusMetrics {
             &self.proxy_budget_exceeded_by_path,
         );
 
+        out.push_str(
+            "# HELP ditto_gateway_proxy_access_denied_total Total proxy requests rejected by virtual key IP or origin allowlists.\n",
+        );
+        out.push_str("# TYPE ditto_gateway_proxy_access_denied_total counter\n");
+        out.push_str(&format!(
+            "ditto_gateway_proxy_access_denied_total {}\n",
+            self.proxy_access_denied_total
+        ));
+
+        write_counter_map(
+            &mut out,
+            "ditto_gateway_proxy_access_denied_by_key_total",
+            "Proxy access denied responses grouped by virtual key id.",
+            "virtual_key_id",
+            &self.proxy_access_denied_by_key,
+        );
+
+        write_counter_map(
+            &mut out,
+            "ditto_gateway_proxy_access_denied_by_reason_total",
+            "Proxy access denied responses grouped by reason.",
+            "reason",
+            &self.proxy_access_denied_by_reason,
+        );
+
         out.push_str("# HELP ditto_gateway_proxy_cache_lookups_total Total proxy cache lookups.\n");
         out.push_str("# TYPE ditto_gateway_proxy_cache_lookups_total counter\n");
         out.push_str(&format!(
@@ -971,6 +1072,85 @@ impl PrometheusMetrics {
             }
         }
 
+        let mut experiment_arms: Vec<(&String, &String, &ExperimentArmMetrics)> = self
+            .proxy_experiment_arms
+            .iter()
+            .flat_map(|(experiment, arms)| {
+                arms.iter()
+                    .map(move |(arm, metrics)| (experiment, arm, metrics))
+            })
+            .collect();
+        experiment_arms.sort_by(|(a, b, _), (c, d, _)| (a, b).cmp(&(c, d)));
+
+        out.push_str(
+            "# HELP ditto_gateway_proxy_experiment_responses_total Proxy responses grouped by route experiment, arm and status.\n",
+        );
+        out.push_str("# TYPE ditto_gateway_proxy_experiment_responses_total counter\n");
+        for (experiment, arm, metrics) in &experiment_arms {
+            let mut status_entries: Vec<_> = metrics.responses_by_status.iter().collect();
+            status_entries.sort_by_key(|(status, _)| *status);
+            for (status, count) in status_entries {
+                out.push_str(&format!(
+                    "ditto_gateway_proxy_experiment_responses_total{{experiment=\"{}\",arm=\"{}\",status=\"{}\"}} {count}\n",
+                    escape_label_value(experiment),
+                    escape_label_value(arm),
+                    status
+                ));
+            }
+        }
+
+        let metric = "ditto_gateway_proxy_experiment_request_duration_seconds";
+        out.push_str(&format!(
+            "# HELP {metric} Proxy request duration in seconds grouped by route experiment and arm.\n"
+        ));
+        out.push_str(&format!("# TYPE {metric} histogram\n"));
+        for (experiment, arm, metrics) in &experiment_arms {
+            let labels = format!(
+                "experiment=\"{}\",arm=\"{}\"",
+                escape_label_value(experiment),
+                escape_label_value(arm)
+            );
+            let hist = &metrics.duration_seconds;
+            for (idx, bound) in hist.buckets.iter().enumerate() {
+                out.push_str(&format!(
+                    "{metric}_bucket{{{labels},le=\"{bound}\"}} {}\n",
+                    hist.bucket_counts[idx]
+                ));
+            }
+            out.push_str(&format!(
+                "{metric}_bucket{{{labels},le=\"+Inf\"}} {}\n",
+                hist.count
+            ));
+            out.push_str(&format!("{metric}_sum{{{labels}}} {}\n", hist.sum_seconds));
+            out.push_str(&format!("{metric}_count{{{labels}}} {}\n", hist.count));
+        }
+
+        out.push_str(
+            "# HELP ditto_gateway_proxy_tier_admissions_total Tier admission decisions grouped by key tier and decision.\n",
+        );
+        out.push_str("# TYPE ditto_gateway_proxy_tier_admissions_total counter\n");
+        let mut tier_entries: Vec<_> = self.proxy_tier_admissions.iter().collect();
+        tier_entries.sort_by(|(a, _), (b, _)| a.cmp(b));
+        for (tier, decisions) in tier_entries {
+            let mut decision_entries: Vec<_> = decisions.iter().collect();
+            decision_entries.sort_by(|(a, _), (b, _)| a.cmp(b));
+            for (decision, count) in decision_entries {
+                out.push_str(&format!(
+                    "ditto_gateway_proxy_tier_admissions_total{{tier=\"{}\",decision=\"{}\"}} {count}\n",
+                    escape_label_value(tier),
+                    escape_label_value(decision)
+                ));
+            }
+        }
+
+        write_histogram_map(
+            &mut out,
+            "ditto_gateway_proxy_tier_queue_wait_seconds",
+            "Time key tier requests waited for admission, in seconds.",
+            "tier",
+            &self.proxy_tier_queue_wait_seconds,
+        );
+
         out
     }
 }
@@ -1271,6 +1451,7 @@ mod tests {
             "/v1/chat/completions",
         );
         metrics.record_proxy_budget_exceeded(Some("vk-1"), Some("model-1"), "/v1/chat/completions");
+        metrics.record_proxy_access_denied(Some("vk-1"), "ip_not_allowed");
         metrics.record_proxy_cache_lookup("/v1/chat/completions");
         metrics.record_proxy_cache_hit_by_source("memory");
         metrics.record_proxy_cache_hit_by_path("/v1/chat/completions");
@@ -1292,11 +1473,19 @@ mod tests {
         metrics.record_proxy_response_status_by_path("/v1/chat/completions", 200);
         metrics.record_proxy_response_status_by_backend("backend-a", 200);
         metrics.record_proxy_response_status_by_model("model-1", 200);
+        metrics.record_proxy_experiment_response(
+            "exp-1",
+            "backend-a",
+            200,
+            Duration::from_millis(10),
+        );
+        metrics.record_proxy_tier_admission("batch", "queued", Duration::from_millis(10));
 
         assert_eq!(metrics.proxy_requests_total, 1);
         assert_eq!(metrics.proxy_rate_limited_total, 1);
         assert_eq!(metrics.proxy_guardrail_blocked_total, 1);
         assert_eq!(metrics.proxy_budget_exceeded_total, 1);
+        assert_eq!(metrics.proxy_access_denied_total, 1);
         assert_eq!(metrics.proxy_cache_lookups_total, 1);
         assert_eq!(metrics.proxy_stream_bytes_total, 128);
         assert_eq!(metrics.proxy_stream_connections, 0);
@@ -1316,6 +1505,7 @@ mod tests {
         assert!(metrics.proxy_budget_exceeded_by_key.is_empty());
         assert!(metrics.proxy_budget_exceeded_by_model.is_empty());
         assert!(metrics.proxy_budget_exceeded_by_path.is_empty());
+        assert!(metrics.proxy_access_denied_by_key.is_empty());
         assert!(metrics.proxy_cache_lookups_by_path.is_empty());
         assert_eq!(metrics.proxy_cache_hits_by_source.get("memory"), Some(&1));
         assert!(metrics.proxy_cache_hits_by_path.is_empty());
@@ -1340,6 +1530,66 @@ mod tests {
         assert!(metrics.proxy_responses_by_path_status.is_empty());
         assert!(metrics.proxy_responses_by_backend_status.is_empty());
         assert!(metrics.proxy_responses_by_model_status.is_empty());
+        assert!(metrics.proxy_experiment_arms.is_empty());
+        assert!(metrics.proxy_tier_admissions.is_empty());
+        assert!(metrics.proxy_tier_queue_wait_seconds.is_empty());
+    }
+
+    #[test]
+    fn experiment_responses_render_per_arm() {
+        let mut metrics = PrometheusMetrics::new(PrometheusMetricsConfig::default());
+        metrics.record_proxy_experiment_response(
+            "new-model",
+            "control",
+            200,
+            Duration::from_millis(20),
+        );
+        metrics.record_proxy_experiment_response(
+            "new-model",
+            "candidate",
+            502,
+            Duration::from_millis(3),
+        );
+        metrics.record_proxy_experiment_response(
+            "new-model",
+            "control",
+            200,
+            Duration::from_millis(40),
+        );
+
+        let rendered = metrics.render();
+        assert!(rendered.contains(
+            "ditto_gateway_proxy_experiment_responses_total{experiment=\"new-model\",arm=\"candidate\",status=\"502\"} 1\n"
+        ));
+        assert!(rendered.contains(
+            "ditto_gateway_proxy_experiment_responses_total{experiment=\"new-model\",arm=\"control\",status=\"200\"} 2\n"
+        ));
+        assert!(rendered.contains(
+            "ditto_gateway_proxy_experiment_request_duration_seconds_bucket{experiment=\"new-model\",arm=\"control\",le=\"0.025\"} 1\n"
+        ));
+        assert!(rendered.contains(
+            "ditto_gateway_proxy_experiment_request_duration_seconds_count{experiment=\"new-model\",arm=\"control\"} 2\n"
+        ));
+    }
+
+    #[test]
+    fn tier_admissions_render_per_decision() {
+        let mut metrics = PrometheusMetrics::new(PrometheusMetricsConfig::default());
+        metrics.record_proxy_tier_admission("batch", "admitted", Duration::ZERO);
+        metrics.record_proxy_tier_admission("batch", "queued", Duration::from_millis(200));
+        metrics.record_proxy_tier_admission("batch", "shed", Duration::from_secs(2));
+
+        let rendered = metrics.render();
+        assert!(rendered.contains(
+            "ditto_gateway_proxy_tier_admissions_total{tier=\"batch\",decision=\"queued\"} 1\n"
+        ));
+        assert!(rendered.contains(
+            "ditto_gateway_proxy_tier_admissions_total{tier=\"batch\",decision=\"shed\"} 1\n"
+        ));
+        assert!(
+            rendered
+                .contains("ditto_gateway_proxy_tier_queue_wait_seconds_count{tier=\"batch\"} 3\n")
+        );
     }
 
     #[test]
@@ -0,0 +1,350 @@
+//! Built-in alert rules (`observability.alerts`): the windowed signals they
+//! are judged on and the payloads sent to Slack, PagerDuty and generic
+//! webhooks. Sampling, scheduling and delivery live in the HTTP transport.
+
+use std::collections::{HashMap, VecDeque};
+
+use serde::Serialize;
+use serde_json::{Value, json};
+
+use super::config::{AlertCondition, AlertRuleConfig, AlertTargetSink};
+
+/// Outcome of one proxied request, kept for the longest rule window.
+#[derive(Clone, Debug)]
+pub(crate) struct AlertSample {
+    pub(crate) ts_ms: u64,
+    /// `None` when the request failed before any backend answered; such
+    /// samples only count towards rules without a `backend`.
+    pub(crate) backend: Option<String>,
+    pub(crate) error: bool,
+    pub(crate) ttft_ms: Option<u64>,
+}
+
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Serialize)]
+#[serde(rename_all = "snake_case")]
+pub(crate) enum AlertStatus {
+    Firing,
+    Resolved,
+}
+
+/// A rule changing state, as delivered to its targets.
+#[derive(Clone, Debug, Serialize)]
+pub(crate) struct AlertNotification {
+    pub(crate) rule: String,
+    pub(crate) status: AlertStatus,
+    pub(crate) condition: &'static str,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub(crate) backend: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub(crate) virtual_key_id: Option<String>,
+    pub(crate) value: f64,
+    pub(crate) threshold: f64,
+    pub(crate) window_seconds: u64,
+    pub(crate) ts_ms: u64,
+}
+
+/// An HTTP request that delivers one notification to a target.
+#[derive(Debug)]
+pub(crate) struct AlertRequest {
+    pub(crate) url: String,
+    pub(crate) headers: Vec<(String, String)>,
+    pub(crate) body: Value,
+}
+
+/// The current value of `rule`'s signal, or `None` while the window holds
+/// fewer than `min_requests` samples and the rule keeps its state.
+/// `cooldown_since_ms` maps each backend in cooldown to when it entered it.
+pub(crate) fn observe_rule(
+    rule: &AlertRuleConfig,
+    samples: &VecDeque<AlertSample>,
+    cooldown_since_ms: &HashMap<String, u64>,
+    now_ms: u64,
+) -> Option<f64> {
+    if rule.condition == AlertCondition::CooldownSeconds {
+        let longest = cooldown_since_ms
+            .iter()
+            .filter(|(backend, _)| rule.matches_backend(backend))
+            .map(|(_, since)| now_ms.saturating_sub(*since))
+            .max()
+            .unwrap_or(0);
+        return Some(longest as f64 / 1000.0);
+    }
+
+    let window_start = now_ms.saturating_sub(rule.window_seconds.saturating_mul(1000));
+    let window = samples.iter().filter(|sample| {
+        sample.ts_ms >= window_start
+            && match sample.backend.as_deref() {
+                Some(backend) => rule.matches_backend(backend),
+                None => rule.backend.is_none(),
+            }
+    });
+    let min_requests = rule.min_requests.max(1);
+    match rule.condition {
+        AlertCondition::ErrorRate => {
+            let (total, errors) = window.fold((0usize, 0usize), |(total, errors), sample| {
+                (total + 1, errors + usize::from(sample.error))
+            });
+            (total >= min_requests).then(|| errors as f64 / total as f64)
+        }
+        AlertCondition::P95TtftMs => {
+            let mut ttfts = window
+                .filter(|sample| !sample.error)
+                .filter_map(|sample| sample.ttft_ms)
+                .collect::<Vec<_>>();
+            if ttfts.len() < min_requests {
+                return None;
+            }
+            ttfts.sort_unstable();
+            let rank = (ttfts.len() * 95).div_ceil(100).max(1);
+            Some(ttfts[rank - 1] as f64)
+        }
+        AlertCondition::CooldownSeconds => unreachable!("handled above"),
+    }
+}
+
+pub(crate) fn alert_summary(notification: &AlertNotification) -> String {
+    let status = match notification.status {
+        AlertStatus::Firing => "FIRING",
+        AlertStatus::Resolved => "RESOLVED",
+    };
+    let mut scope = notification
+        .backend
+        .as_deref()
+        .map(|backend| format!(" on backend `{backend}`"))
+        .unwrap_or_default();
+    if let Some(key) = notification.virtual_key_id.as_deref() {
+        scope.push_str(&format!(" for key `{key}`"));
+    }
+    format!(
+        "[{status}] {}: {} is {} (threshold {}){scope}",
+        notification.rule,
+        notification.condition,
+        format_value(notification.value),
+        format_value(notification.threshold),
+    )
+}
+
+pub(crate) fn encode_alert(
+    sink: &AlertTargetSink,
+    notification: &AlertNotification,
+) -> AlertRequest {
+    match sink {
+        AlertTargetSink::Slack { webhook_url } => AlertRequest {
+            url: webhook_url.clone(),
+            headers: Vec::new(),
+            body: json!({ "text": alert_summary(notification) }),
+        },
+        AlertTargetSink::Pagerduty {
+            routing_key,
+            events_url,
+        } => {
+            let dedup_key = match notification.virtual_key_id.as_deref() {
+                Some(key) => format!("ditto-gateway/{}/{key}", notification.rule),
+                None => format!("ditto-gateway/{}", notification.rule),
+            };
+            let body = match notification.status {
+                AlertStatus::Firing => json!({
+                    "routing_key": routing_key,
+                    "event_action": "trigger",
+                    "dedup_key": dedup_key,
+                    "payload": {
+                        "summary": alert_summary(notification),
+                        "source": "ditto-gateway",
+                        "severity": "error",
+                        "component": notification.backend,
+                        "custom_details": notification,
+                    },
+                }),
+                AlertStatus::Resolved => json!({
+                    "routing_key": routing_key,
+                    "event_action": "resolve",
+                    "dedup_key": dedup_key,
+                }),
+            };
+            AlertRequest {
+                url: events_url.clone(),
+                headers: Vec::new(),
+                body,
+            }
+        }
+        AlertTargetSink::Webhook { url, headers } => AlertRequest {
+            url: url.clone(),
+            headers: headers
+                .iter()
+                .map(|(name, value)| (name.clone(), value.clone()))
+                .collect(),
+            body: json!({
+                "summary": alert_summary(notification),
+                "alert": notification,
+            }),
+        },
+    }
+}
+
+fn format_value(value: f64) -> String {
+    let formatted = format!("{value:.4}");
+    formatted
+        .trim_end_matches('0')
+        .trim_end_matches('.')
+        .to_string()
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn rule(condition: AlertCondition, threshold: f64) -> AlertRuleConfig {
+        AlertRuleConfig {
+            name: "openai-degraded".to_string(),
+            condition,
+            threshold,
+            backend: Some("openai".to_string()),
+            window_seconds: 60,
+            min_requests: 4,
+            targets: vec!["oncall".to_string()],
+        }
+    }
+
+    fn sample(ts_ms: u64, backend: Option<&str>, error: bool, ttft_ms: u64) -> AlertSample {
+        AlertSample {
+            ts_ms,
+            backend: backend.map(str::to_string),
+            error,
+            ttft_ms: (!error).then_some(ttft_ms),
+        }
+    }
+
+    #[test]
+    fn observes_error_rate_and_p95_ttft_within_window() {
+        let now = 100_000;
+        let samples = VecDeque::from(vec![
+            // Outside the 60s window.
+            sample(30_000, Some("openai"), true, 0),
+            sample(50_000, Some("openai"), false, 100),
+            sample(60_000, Some("openai"), true, 0),
+            sample(70_000, Some("anthropic"), true, 0),
+            sample(80_000, Some("openai"), false, 300),
+            sample(90_000, Some("openai"), false, 200),
+            sample(95_000, None, true, 0),
+        ]);
+        let none = HashMap::new();
+
+        let error_rate = rule(AlertCondition::ErrorRate, 0.2);
+        assert_eq!(observe_rule(&error_rate, &samples, &none, now), Some(0.25));
+
+        let mut all_backends = error_rate.clone();
+        all_backends.backend = None;
+        assert_eq!(observe_rule(&all_backends, &samples, &none, now), Some(0.5));
+
+        let mut busy = error_rate.clone();
+        busy.min_requests = 5;
+        assert_eq!(observe_rule(&busy, &samples, &none, now), None);
+
+        let mut ttft = rule(AlertCondition::P95TtftMs, 250.0);
+        ttft.min_requests = 3;
+        assert_eq!(observe_rule(&ttft, &samples, &none, now), Some(300.0));
+    }
+
+    #[test]
+    fn observes_longest_cooldown_for_matching_backends() {
+        let cooldown = HashMap::from([
+            ("openai".to_string(), 40_000),
+            ("anthropic".to_string(), 10_000),
+        ]);
+        let samples = VecDeque::new();
+        let rule = rule(AlertCondition::CooldownSeconds, 30.0);
+        assert_eq!(
+            observe_rule(&rule, &samples, &cooldown, 100_000),
+            Some(60.0)
+        );
+        assert_eq!(
+            observe_rule(&rule, &samples, &HashMap::new(), 100_000),
+            Some(0.0)
+        );
+    }
+
+    #[test]
+    fn encodes_slack_pagerduty_and_webhook_payloads() {
+        let mut notification = AlertNotification {
+            rule: "openai-degraded".to_string(),
+            status: AlertStatus::Firing,
+            condition: "error_rate",
+            backend: Some("openai".to_string()),
+            virtual_key_id: None,
+            value: 0.25,
+            threshold: 0.1,
+            window_seconds: 60,
+            ts_ms: 1_709_211_600_000,
+        };
+        assert_eq!(
+            alert_summary(&notification),
+            "[FIRING] openai-degraded: error_rate is 0.25 (threshold 0.1) on backend `openai`"
+        );
+
+        let slack = encode_alert(
+            &AlertTargetSink::Slack {
+                webhook_url: "https://hooks.slack.com/services/T/B/X".to_string(),
+            },
+            &notification,
+        );
+        assert_eq!(slack.url, "https://hooks.slack.com/services/T/B/X");
+        assert!(slack.body["text"].as_str().unwrap().starts_with("[FIRING]"));
+
+        let pagerduty = AlertTargetSink::Pagerduty {
+            routing_key: "rk-1".to_string(),
+            events_url: "https://events.pagerduty.com/v2/enqueue".to_string(),
+        };
+        let trigger = encode_alert(&pagerduty, &notification);
+        assert_eq!(trigger.body["event_action"], "trigger");
+        assert_eq!(trigger.body["dedup_key"], "ditto-gateway/openai-degraded");
+        assert_eq!(trigger.body["payload"]["component"], "openai");
+        notification.status = AlertStatus::Resolved;
+        let resolve = encode_alert(&pagerduty, &notification);
+        assert_eq!(resolve.body["event_action"], "resolve");
+        assert_eq!(resolve.body["dedup_key"], "ditto-gateway/openai-degraded");
+
+        let webhook = encode_alert(
+            &AlertTargetSink::Webhook {
+                url: "https://alerts.example.com/hook".to_string(),
+                headers: [("x-token".to_string(), "t-1".to_string())].into(),
+            },
+            &notification,
+        );
+        assert_eq!(
+            webhook.headers,
+            vec![("x-token".to_string(), "t-1".to_string())]
+        );
+        assert_eq!(webhook.body["alert"]["status"], "resolved");
+        assert_eq!(webhook.body["alert"]["value"], 0.25);
+    }
+
+    #[test]
+    fn key_scoped_alerts_dedup_per_key() {
+        let notification = AlertNotification {
+            rule: "spend_anomaly".to_string(),
+            status: AlertStatus::Firing,
+            condition: "hourly_spend_usd",
+            backend: None,
+            virtual_key_id: Some("key-1".to_string()),
+            value: 42.5,
+            threshold: 10.0,
+            window_seconds: 3600,
+            ts_ms: 1_709_211_600_000,
+        };
+        assert_eq!(
+            alert_summary(&notification),
+            "[FIRING] spend_anomaly: hourly_spend_usd is 42.5 (threshold 10) for key `key-1`"
+        );
+        let trigger = encode_alert(
+            &AlertTargetSink::Pagerduty {
+                routing_key: "rk-1".to_string(),
+                events_url: "https://events.pagerduty.com/v2/enqueue".to_string(),
+            },
+            &notification,
+        );
+        assert_eq!(
+            trigger.body["dedup_key"],
+            "ditto-gateway/spend_anomaly/key-1"
+        );
+    }
+}
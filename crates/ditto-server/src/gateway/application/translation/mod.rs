@@ -4,12 +4,16 @@ mod endpoint_routing;
 mod files_api;
 mod openai_provider_options;
 mod owned_resources;
+mod remote_images;
 mod request_shaping;
 mod response_mapping;
 mod response_store;
+mod stream_usage;
+mod structured_output;
 
 use std::collections::{BTreeMap, HashMap, VecDeque};
 use std::sync::Arc;
+use std::time::Duration;
 
 use bytes::Bytes;
 use futures_util::StreamExt;
@@ -29,7 +33,7 @@ use ditto_core::capabilities::{
 use ditto_core::config::{Env, ProviderConfig};
 use ditto_core::contracts::{
     CapabilityKind, ContentPart, FinishReason, GenerateRequest, GenerateResponse, ImageSource,
-    Message, OperationKind, Role, RuntimeRouteRequest, Usage,
+    Message, OperationKind, Role, RuntimeRouteRequest, TokenLogprob, Usage,
 };
 use ditto_core::llm_core::model::{LanguageModel, StreamResult};
 use ditto_core::object::{LanguageModelObjectExt, ObjectOptions, ObjectOutput};
@@ -53,22 +57,32 @@ use openai_provider_options::apply_openai_request_provider_options;
 pub(crate) use owned_resources::{
     TranslationOwnedResourceKind, scoped_owned_resource_backend_name,
 };
+pub use remote_images::inline_remote_images;
 use response_mapping::{
-    chat_chunk_bytes, chat_usage_chunk_bytes, completion_chunk_bytes,
+    chat_chunk_bytes, chat_chunk_bytes_with_logprobs, completion_chunk_bytes,
     finish_reason_to_chat_finish_reason, finish_reason_to_responses_status, sse_event_bytes,
-    usage_to_chat_usage, usage_to_responses_usage,
+    token_logprobs_to_chat_logprobs, token_logprobs_to_completions_logprobs,
+    token_logprobs_to_openai, usage_chunk_bytes, usage_to_chat_usage, usage_to_responses_usage,
 };
 use response_store::TranslationResponseStore;
 pub(crate) use response_store::{
     TranslationResponseOwner, delete_stored_response_from_translation_backends,
     find_stored_response_from_translation_backends, gateway_scoped_response_id,
 };
+pub use stream_usage::{StreamUsageCostFn, StreamUsageParams};
+pub use structured_output::{
+    StructuredOutputError, add_json_schema_instructions, emulated_json_schema,
+    generate_with_emulated_json_schema,
+};
 
 type ParseResult<T> = std::result::Result<T, String>;
 type IoResult<T> = std::result::Result<T, std::io::Error>;
 
 pub const HTTP_STATUS_BAD_REQUEST: u16 = 400;
+pub const HTTP_STATUS_TOO_MANY_REQUESTS: u16 = 429;
 pub const HTTP_STATUS_BAD_GATEWAY: u16 = 502;
+/// Anthropic's "overloaded" status.
+pub const HTTP_STATUS_OVERLOADED: u16 = 529;
 
 const DEFAULT_TRANSLATION_MODEL_CACHE_MAX_ENTRIES: usize = 64;
 const MAX_TRANSLATION_MODEL_CACHE_KEY_BYTES: usize = 256;
@@ -649,6 +663,10 @@ pub struct TranslationBackend {
     pub model: Arc<dyn LanguageModel>,
     pub provider: String,
     pub model_map: BTreeMap<String, String>,
+    structured_output_max_attempts: u32,
+    prompt_cache: Option<crate::gateway::PromptCacheConfig>,
+    request_timeout: Option<Duration>,
+    first_token_timeout: Option<Duration>,
     bindings: TranslationBackendBindings,
     runtime: TranslationBackendRuntime,
 }
@@ -659,6 +677,11 @@ impl TranslationBackend {
             model,
             provider: provider.into(),
             model_map: BTreeMap::new(),
+            structured_output_max_attempts: crate::gateway::StructuredOutputConfig::default()
+                .max_attempts,
+            prompt_cache: None,
+            request_timeout: None,
+            first_token_timeout: None,
             bindings: TranslationBackendBindings::default(),
             runtime: TranslationBackendRuntime::default(),
         }
@@ -684,6 +707,38 @@ impl TranslationBackend {
         self
     }
 
+    /// Generations allowed per request when `response_format: json_schema`
+    /// is emulated for a provider without native structured outputs.
+    pub fn with_structured_output_max_attempts(mut self, max_attempts: u32) -> Self {
+        self.structured_output_max_attempts = max_attempts.max(1);
+        self
+    }
+
+    /// Marks large system prompts as Anthropic `cache_control` breakpoints.
+    pub fn with_prompt_cache(
+        mut self,
+        prompt_cache: Option<crate::gateway::PromptCacheConfig>,
+    ) -> Self {
+        self.prompt_cache = prompt_cache;
+        self
+    }
+
+    /// Bounds a chat/completions/responses generation, or a whole stream.
+    pub fn with_request_timeout_seconds(mut self, timeout_seconds: Option<u64>) -> Self {
+        self.request_timeout = timeout_seconds
+            .filter(|seconds| *seconds > 0)
+            .map(Duration::from_secs);
+        self
+    }
+
+    /// Bounds how long a stream may take to yield its first chunk.
+    pub fn with_first_token_timeout_seconds(mut self, timeout_seconds: Option<u64>) -> Self {
+        self.first_token_timeout = timeout_seconds
+            .filter(|seconds| *seconds > 0)
+            .map(Duration::from_secs);
+        self
+    }
+
     pub fn with_embedding_model(mut self, embedding_model: Arc<dyn EmbeddingModel>) -> Self {
         self.bindings.embedding_model = Some(embedding_model);
         self
@@ -747,6 +802,25 @@ impl TranslationBackend {
         self.provider.trim()
     }
 
+    pub fn structured_output_max_attempts(&self) -> u32 {
+        self.structured_output_max_attempts
+    }
+
+    pub fn apply_prompt_cache(&self, request: &mut GenerateRequest) -> ParseResult<()> {
+        match self.prompt_cache.as_ref() {
+            Some(config) => openai_provider_options::apply_prompt_cache_config(request, config),
+            None => Ok(()),
+        }
+    }
+
+    pub fn request_timeout(&self) -> Option<Duration> {
+        self.request_timeout
+    }
+
+    pub fn first_token_timeout(&self) -> Option<Duration> {
+        self.first_token_timeout
+    }
+
     pub fn default_model_id(&self) -> &str {
         self.model.model_id().trim()
     }
@@ -1793,6 +1867,12 @@ pub fn generate_response_to_chat_completions(
     let mut choice = Map::<String, Value>::new();
     choice.insert("index".to_string(), Value::Number(0.into()));
     choice.insert("message".to_string(), Value::Object(message));
+    if let Some(logprobs) = response.logprobs.as_deref() {
+        choice.insert(
+            "logprobs".to_string(),
+            token_logprobs_to_chat_logprobs(logprobs),
+        );
+    }
     if let Some(finish_reason) = finish_reason {
         choice.insert(
             "finish_reason".to_string(),
@@ -1843,7 +1923,14 @@ pub fn generate_response_to_completions(
     let mut choice = Map::<String, Value>::new();
     choice.insert("index".to_string(), Value::Number(0.into()));
     choice.insert("text".to_string(), Value::String(text));
-    choice.insert("logprobs".to_string(), Value::Null);
+    choice.insert(
+        "logprobs".to_string(),
+        response
+            .logprobs
+            .as_deref()
+            .map(|logprobs| token_logprobs_to_completions_logprobs(logprobs, 0))
+            .unwrap_or(Value::Null),
+    );
     if let Some(finish_reason) = finish_reason {
         choice.insert(
             "finish_reason".to_string(),
@@ -1880,16 +1967,21 @@ fn responses_payload_from_parts(
     model: &str,
     created: u64,
     output_text: String,
+    logprobs: Option<&[TokenLogprob]>,
     tool_calls: Vec<Value>,
     finish_reason: FinishReason,
     usage: &Usage,
 ) -> Value {
     let mut output_items = Vec::<Value>::new();
     if !output_text.is_empty() {
+        let mut part = serde_json::json!({"type":"output_text", "text": output_text});
+        if let Some(logprobs) = logprobs {
+            part["logprobs"] = token_logprobs_to_openai(logprobs);
+        }
         output_items.push(serde_json::json!({
             "type": "message",
             "role": "assistant",
-            "content": [{"type":"output_text", "text": output_text}],
+            "content": [part],
         }));
     }
     output_items.extend(tool_calls);
@@ -1955,6 +2047,7 @@ pub fn generate_response_to_responses(
         model,
         created,
         output_text,
+        response.logprobs.as_deref(),
         tool_calls,
         response.finish_reason,
         &response.usage,
@@ -1966,7 +2059,7 @@ pub fn stream_to_chat_completions_sse(
     fallback_id: String,
     model: String,
     created: u64,
-    include_usage: bool,
+    usage_params: StreamUsageParams,
 ) -> futures_util::stream::BoxStream<'static, IoResult<Bytes>> {
     #[derive(Default)]
     struct State {
@@ -1974,6 +2067,7 @@ pub fn stream_to_chat_completions_sse(
         tool_call_index: HashMap<String, usize>,
         finish_reason: Option<FinishReason>,
         usage: Option<Usage>,
+        output: String,
     }
 
     stream::unfold(
@@ -1988,6 +2082,7 @@ pub fn stream_to_chat_completions_sse(
         ),
         move |(mut inner, mut buffer, mut state, mut done)| {
             let model = model.clone();
+            let usage_params = usage_params.clone();
             async move {
                 loop {
                     if let Some(item) = buffer.pop_front() {
@@ -2009,6 +2104,7 @@ pub fn stream_to_chat_completions_sse(
                                 ditto_core::contracts::StreamChunk::Warnings { .. } => {}
                                 ditto_core::contracts::StreamChunk::TextDelta { text } => {
                                     if !text.is_empty() {
+                                        state.output.push_str(&text);
                                         buffer.push_back(Ok(chat_chunk_bytes(
                                             &state.response_id,
                                             &model,
@@ -2019,7 +2115,19 @@ pub fn stream_to_chat_completions_sse(
                                         )));
                                     }
                                 }
+                                ditto_core::contracts::StreamChunk::Logprobs { logprobs } => {
+                                    buffer.push_back(Ok(chat_chunk_bytes_with_logprobs(
+                                        &state.response_id,
+                                        &model,
+                                        created,
+                                        serde_json::json!({}),
+                                        Some(token_logprobs_to_chat_logprobs(&logprobs)),
+                                        None,
+                                        None,
+                                    )));
+                                }
                                 ditto_core::contracts::StreamChunk::ToolCallStart { id, name } => {
+                                    state.output.push_str(&name);
                                     let idx = if let Some(idx) =
                                         state.tool_call_index.get(&id).copied()
                                     {
@@ -2059,6 +2167,7 @@ pub fn stream_to_chat_completions_sse(
                                         idx
                                     };
                                     if !arguments_delta.is_empty() {
+                                        state.output.push_str(&arguments_delta);
                                         buffer.push_back(Ok(chat_chunk_bytes(
                                             &state.response_id,
                                             &model,
@@ -2078,6 +2187,7 @@ pub fn stream_to_chat_completions_sse(
                                 }
                                 ditto_core::contracts::StreamChunk::ReasoningDelta { text } => {
                                     if !text.is_empty() {
+                                        state.output.push_str(&text);
                                         buffer.push_back(Ok(chat_chunk_bytes(
                                             &state.response_id,
                                             &model,
@@ -2112,16 +2222,21 @@ pub fn stream_to_chat_completions_sse(
                                 Some(finish_reason),
                                 None,
                             )));
-                            if include_usage
-                                && let Some(usage) =
-                                    state.usage.as_ref().and_then(usage_to_chat_usage)
-                            {
-                                buffer.push_back(Ok(chat_usage_chunk_bytes(
-                                    &state.response_id,
+                            if usage_params.include_usage {
+                                let usage = usage_params.complete(
                                     &model,
-                                    created,
-                                    usage,
-                                )));
+                                    state.usage.as_ref(),
+                                    &state.output,
+                                );
+                                if let Some(rendered) = usage_to_chat_usage(&usage) {
+                                    buffer.push_back(Ok(usage_chunk_bytes(
+                                        "chat.completion.chunk",
+                                        &state.response_id,
+                                        &model,
+                                        created,
+                                        usage_params.with_cost(rendered, &usage),
+                                    )));
+                                }
                             }
                             buffer.push_back(Ok(Bytes::from("data: [DONE]\n\n")));
                             done = true;
@@ -2140,11 +2255,15 @@ pub fn stream_to_completions_sse(
     fallback_id: String,
     model: String,
     created: u64,
+    usage_params: StreamUsageParams,
 ) -> futures_util::stream::BoxStream<'static, IoResult<Bytes>> {
     #[derive(Default)]
     struct State {
         response_id: String,
         finish_reason: Option<FinishReason>,
+        usage: Option<Usage>,
+        output: String,
+        logprobs_offset: usize,
     }
 
     stream::unfold(
@@ -2159,6 +2278,7 @@ pub fn stream_to_completions_sse(
         ),
         move |(mut inner, mut buffer, mut state, mut done)| {
             let model = model.clone();
+            let usage_params = usage_params.clone();
             async move {
                 loop {
                     if let Some(item) = buffer.pop_front() {
@@ -2180,22 +2300,44 @@ pub fn stream_to_completions_sse(
                                 ditto_core::contracts::StreamChunk::Warnings { .. } => {}
                                 ditto_core::contracts::StreamChunk::TextDelta { text } => {
                                     if !text.is_empty() {
+                                        state.output.push_str(&text);
                                         buffer.push_back(Ok(completion_chunk_bytes(
                                             &state.response_id,
                                             &model,
                                             created,
                                             &text,
                                             None,
+                                            None,
                                         )));
                                     }
                                 }
+                                ditto_core::contracts::StreamChunk::Logprobs { logprobs } => {
+                                    let mapped = token_logprobs_to_completions_logprobs(
+                                        &logprobs,
+                                        state.logprobs_offset,
+                                    );
+                                    state.logprobs_offset += logprobs
+                                        .iter()
+                                        .map(|logprob| logprob.token.chars().count())
+                                        .sum::<usize>();
+                                    buffer.push_back(Ok(completion_chunk_bytes(
+                                        &state.response_id,
+                                        &model,
+                                        created,
+                                        "",
+                                        Some(mapped),
+                                        None,
+                                    )));
+                                }
                                 ditto_core::contracts::StreamChunk::ToolCallStart { .. } => {}
                                 ditto_core::contracts::StreamChunk::ToolCallDelta { .. } => {}
                                 ditto_core::contracts::StreamChunk::ReasoningDelta { .. } => {}
                                 ditto_core::contracts::StreamChunk::FinishReason(reason) => {
                                     state.finish_reason = Some(reason);
                                 }
-                                ditto_core::contracts::StreamChunk::Usage(_) => {}
+                                ditto_core::contracts::StreamChunk::Usage(usage) => {
+                                    state.usage = Some(usage);
+                                }
                             }
                             continue;
                         }
@@ -2211,8 +2353,25 @@ pub fn stream_to_completions_sse(
                                 &model,
                                 created,
                                 "",
+                                None,
                                 Some(finish_reason),
                             )));
+                            if usage_params.include_usage {
+                                let usage = usage_params.complete(
+                                    &model,
+                                    state.usage.as_ref(),
+                                    &state.output,
+                                );
+                                if let Some(rendered) = usage_to_chat_usage(&usage) {
+                                    buffer.push_back(Ok(usage_chunk_bytes(
+                                        "text_completion",
+                                        &state.response_id,
+                                        &model,
+                                        created,
+                                        usage_params.with_cost(rendered, &usage),
+                                    )));
+                                }
+                            }
                             buffer.push_back(Ok(Bytes::from("data: [DONE]\n\n")));
                             done = true;
                             continue;
@@ -2233,6 +2392,7 @@ pub(crate) struct ResponsesSseStreamParams {
     pub input_items: Vec<Value>,
     pub response_owner: TranslationResponseOwner,
     pub response_store_backend: TranslationBackend,
+    pub usage: StreamUsageParams,
 }
 
 pub(crate) fn stream_to_responses_sse(
@@ -2253,6 +2413,8 @@ pub(crate) fn stream_to_responses_sse(
         tool_call_index: HashMap<String, usize>,
         tool_calls: Vec<ToolCallState>,
         output_text: String,
+        logprobs: Option<Vec<TokenLogprob>>,
+        reasoning_text: String,
         finish_reason: Option<FinishReason>,
         usage: Option<Usage>,
     }
@@ -2275,6 +2437,7 @@ pub(crate) fn stream_to_responses_sse(
             let input_items = params.input_items.clone();
             let response_owner = params.response_owner.clone();
             let response_store_backend = params.response_store_backend.clone();
+            let usage_params = params.usage.clone();
             async move {
                 loop {
                     if let Some(item) = buffer.pop_front() {
@@ -2319,6 +2482,14 @@ pub(crate) fn stream_to_responses_sse(
                                         }))));
                                     }
                                 }
+                                ditto_core::contracts::StreamChunk::Logprobs { logprobs } => {
+                                    buffer.push_back(Ok(sse_event_bytes(serde_json::json!({
+                                        "type": "response.output_text.delta",
+                                        "delta": "",
+                                        "logprobs": token_logprobs_to_openai(&logprobs),
+                                    }))));
+                                    state.logprobs.get_or_insert_with(Vec::new).extend(logprobs);
+                                }
                                 ditto_core::contracts::StreamChunk::ToolCallStart { id, name } => {
                                     let idx = state
                                         .tool_call_index
@@ -2358,6 +2529,7 @@ pub(crate) fn stream_to_responses_sse(
                                 }
                                 ditto_core::contracts::StreamChunk::ReasoningDelta { text } => {
                                     if !text.is_empty() {
+                                        state.reasoning_text.push_str(&text);
                                         buffer.push_back(Ok(sse_event_bytes(serde_json::json!({
                                             "type": "response.reasoning_text.delta",
                                             "delta": text,
@@ -2458,13 +2630,22 @@ pub(crate) fn stream_to_responses_sse(
                                     arguments,
                                 });
                             }
+                            let mut output = state.reasoning_text.clone();
+                            output.push_str(&state.output_text);
+                            for slot in &state.tool_calls {
+                                output.push_str(&slot.name);
+                                output.push_str(&slot.pending_arguments);
+                            }
+                            let usage =
+                                usage_params.complete(&model, state.usage.as_ref(), &output);
                             let streamed_response = generate_response_to_responses(
                                 &GenerateResponse {
                                     content,
                                     finish_reason,
-                                    usage: state.usage.clone().unwrap_or_default(),
+                                    usage: usage.clone(),
                                     warnings: Vec::new(),
                                     provider_metadata: None,
+                                    logprobs: state.logprobs.clone(),
                                 },
                                 &state.response_id,
                                 &model,
@@ -2488,10 +2669,11 @@ pub(crate) fn stream_to_responses_sse(
                                 response
                                     .insert("incomplete_details".to_string(), incomplete_details);
                             }
-                            if let Some(usage) =
-                                state.usage.as_ref().and_then(usage_to_responses_usage)
-                            {
-                                response.insert("usage".to_string(), usage);
+                            if let Some(rendered) = usage_to_responses_usage(&usage) {
+                                response.insert(
+                                    "usage".to_string(),
+                                    usage_params.with_cost(rendered, &usage),
+                                );
                             }
 
                             let event_kind = if status == "completed" {
@@ -2544,6 +2726,7 @@ mod openai_protocol_reasoning_tests {
                 usage: Usage::default(),
                 warnings: Vec::new(),
                 provider_metadata: None,
+                logprobs: None,
             })
         }
 
@@ -2579,7 +2762,7 @@ mod openai_protocol_reasoning_tests {
             "fallback".to_string(),
             "stub".to_string(),
             0,
-            false,
+            StreamUsageParams::default(),
         );
         while let Some(item) = s.next().await {
             out.extend_from_slice(&item?);
@@ -2589,6 +2772,48 @@ mod openai_protocol_reasoning_tests {
         Ok(())
     }
 
+    #[tokio::test]
+    async fn chat_completions_sse_estimates_usage_the_provider_did_not_stream()
+    -> Result<(), Box<dyn std::error::Error>> {
+        let inner: StreamResult = Box::pin(futures_util::stream::iter(vec![
+            Ok(ditto_core::contracts::StreamChunk::TextDelta {
+                text: "hello there".to_string(),
+            }),
+            Ok(ditto_core::contracts::StreamChunk::FinishReason(
+                FinishReason::Stop,
+            )),
+        ]));
+
+        let mut out = Vec::<u8>::new();
+        let mut s = stream_to_chat_completions_sse(
+            inner,
+            "fallback".to_string(),
+            "stub".to_string(),
+            0,
+            StreamUsageParams {
+                include_usage: true,
+                estimated_prompt_tokens: 9,
+                cost_usd_micros: Some(Arc::new(|_: &Usage| Some(1_500))),
+            },
+        );
+        while let Some(item) = s.next().await {
+            out.extend_from_slice(&item?);
+        }
+        let text = String::from_utf8(out)?;
+        let usage_chunk = text
+            .lines()
+            .filter_map(|line| line.strip_prefix("data: "))
+            .filter_map(|data| serde_json::from_str::<Value>(data).ok())
+            .find(|chunk| chunk.get("usage").is_some())
+            .ok_or("missing usage chunk")?;
+        assert_eq!(usage_chunk["choices"], serde_json::json!([]));
+        assert_eq!(usage_chunk["usage"]["prompt_tokens"], 9);
+        assert!(usage_chunk["usage"]["completion_tokens"].as_u64() > Some(0));
+        assert_eq!(usage_chunk["usage"]["cost"], serde_json::json!(0.0015));
+        assert!(text.ends_with("data: [DONE]\n\n"));
+        Ok(())
+    }
+
     #[tokio::test]
     async fn responses_sse_emits_reasoning_text_delta_event()
     -> Result<(), Box<dyn std::error::Error>> {
@@ -2616,6 +2841,7 @@ mod openai_protocol_reasoning_tests {
                 input_items: Vec::new(),
                 response_owner: TranslationResponseOwner::default(),
                 response_store_backend: backend,
+                usage: StreamUsageParams::default(),
             },
         );
         while let Some(item) = s.next().await {
@@ -2640,19 +2866,81 @@ pub fn provider_response_id(response: &GenerateResponse, fallback: &str) -> Stri
         .unwrap_or_else(|| fallback.to_string())
 }
 
+/// Upstream status codes keep their OpenAI error `type`, so clients can tell an
+/// upstream rate limit or auth failure apart regardless of the provider.
+fn openai_error_type_for_upstream_status(status: u16) -> &'static str {
+    match status {
+        400 | 404 | 409 | 413 | 422 => "invalid_request_error",
+        401 => "authentication_error",
+        403 => "permission_error",
+        429 => "rate_limit_error",
+        _ => "api_error",
+    }
+}
+
+/// Whether a provider error is an overload signal rather than a failure:
+/// 429s, Anthropic's 529 and the throttling errors AWS and Google send with
+/// other statuses.
+pub fn is_provider_overload_error(status: u16, body: &str) -> bool {
+    status == HTTP_STATUS_TOO_MANY_REQUESTS
+        || status == HTTP_STATUS_OVERLOADED
+        || [
+            "ThrottlingException",
+            "TooManyRequestsException",
+            "overloaded_error",
+            "RESOURCE_EXHAUSTED",
+        ]
+        .iter()
+        .any(|marker| body.contains(marker))
+}
+
+/// The human-readable part of a provider error body: `error.message`
+/// (OpenAI, Anthropic, Google), `message` / `Message` (Bedrock) or a bare
+/// `error` string. Anything else is returned as-is.
+pub fn provider_error_message(body: &str) -> String {
+    let Ok(value) = serde_json::from_str::<Value>(body) else {
+        return body.to_string();
+    };
+    value
+        .get("error")
+        .and_then(|error| error.get("message"))
+        .or_else(|| value.get("message"))
+        .or_else(|| value.get("Message"))
+        .or_else(|| value.get("error"))
+        .and_then(Value::as_str)
+        .map(str::trim)
+        .filter(|message| !message.is_empty())
+        .map(ToString::to_string)
+        .unwrap_or_else(|| body.to_string())
+}
+
+/// Provider overloads all map to a 429 `rate_limit_error`, so client retry
+/// logic does not depend on which provider pushed back. Provider bodies are
+/// reduced to their message so the OpenAI envelope does not nest another
+/// provider's error object.
 pub fn map_provider_error_to_openai(
     err: ditto_core::error::DittoError,
 ) -> (u16, &'static str, Option<&'static str>, String) {
     match err {
         ditto_core::error::DittoError::Api { status, body } => {
             let status = status.as_u16();
+            let overloaded = is_provider_overload_error(status, &body);
+            let body = provider_error_message(&body);
+            if overloaded {
+                return (
+                    HTTP_STATUS_TOO_MANY_REQUESTS,
+                    "rate_limit_error",
+                    Some("provider_error"),
+                    body,
+                );
+            }
             (
                 if status == 0 {
                     HTTP_STATUS_BAD_GATEWAY
                 } else {
                     status
                 },
-                "api_error",
+                openai_error_type_for_upstream_status(status),
                 Some("provider_error"),
                 body,
             )
@@ -2688,8 +2976,8 @@ pub fn map_provider_error_to_openai(
 
 #[cfg(test)]
 mod error_mapping_tests {
-    use super::map_provider_error_to_openai;
     use super::{HTTP_STATUS_BAD_GATEWAY, HTTP_STATUS_BAD_REQUEST};
+    use super::{map_provider_error_to_openai, provider_error_message};
 
     #[test]
     fn maps_provider_model_missing_as_bad_request() {
@@ -2706,6 +2994,78 @@ mod error_mapping_tests {
         assert!(message.contains("with_model"));
     }
 
+    #[test]
+    fn maps_upstream_api_status_to_openai_error_type() {
+        for (status, expected) in [
+            (429, "rate_limit_error"),
+            (401, "authentication_error"),
+            (400, "invalid_request_error"),
+            (503, "api_error"),
+        ] {
+            let (mapped, kind, code, message) =
+                map_provider_error_to_openai(ditto_core::error::DittoError::Api {
+                    status: reqwest::StatusCode::from_u16(status).expect("status"),
+                    body: "upstream said no".to_string(),
+                });
+
+            assert_eq!(mapped, status);
+            assert_eq!(kind, expected);
+            assert_eq!(code, Some("provider_error"));
+            assert_eq!(message, "upstream said no");
+        }
+    }
+
+    #[test]
+    fn maps_provider_overloads_to_rate_limit_errors() {
+        for (status, body, expected_message) in [
+            (
+                529,
+                r#"{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}"#,
+                "Overloaded",
+            ),
+            (
+                400,
+                r#"{"__type":"ThrottlingException","message":"slow down"}"#,
+                "slow down",
+            ),
+            (
+                503,
+                r#"{"error":{"code":503,"status":"RESOURCE_EXHAUSTED"}}"#,
+                r#"{"error":{"code":503,"status":"RESOURCE_EXHAUSTED"}}"#,
+            ),
+        ] {
+            let (mapped, kind, code, message) =
+                map_provider_error_to_openai(ditto_core::error::DittoError::Api {
+                    status: reqwest::StatusCode::from_u16(status).expect("status"),
+                    body: body.to_string(),
+                });
+
+            assert_eq!(mapped, 429);
+            assert_eq!(kind, "rate_limit_error");
+            assert_eq!(code, Some("provider_error"));
+            assert_eq!(message, expected_message);
+        }
+    }
+
+    #[test]
+    fn extracts_provider_error_messages() {
+        for (body, expected) in [
+            (
+                r#"{"error":{"type":"invalid_request_error","message":"bad model"}}"#,
+                "bad model",
+            ),
+            (
+                r#"{"Message":"User is not authorized"}"#,
+                "User is not authorized",
+            ),
+            (r#"{"error":"quota"}"#, "quota"),
+            ("upstream said no", "upstream said no"),
+            (r#"{"detail":"x"}"#, r#"{"detail":"x"}"#),
+        ] {
+            assert_eq!(provider_error_message(body), expected);
+        }
+    }
+
     #[test]
     fn maps_provider_config_errors_as_provider_errors() {
         let (status, kind, code, message) = map_provider_error_to_openai(
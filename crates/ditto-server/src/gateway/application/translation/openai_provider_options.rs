@@ -2,10 +2,12 @@ use serde_json::{Map, Value};
 
 use ditto_core::contracts::GenerateRequest;
 use ditto_core::provider_options::{
-    ProviderOptions, ProviderOptionsEnvelope, ReasoningEffort, ResponseFormat,
+    CacheControlOptions, ProviderOptions, ProviderOptionsEnvelope, ReasoningEffort, ResponseFormat,
     request_parsed_provider_options,
 };
 
+use crate::gateway::PromptCacheConfig;
+
 pub(super) fn apply_openai_request_provider_options(
     request: &mut GenerateRequest,
     obj: &Map<String, Value>,
@@ -26,6 +28,31 @@ pub(super) fn apply_openai_request_provider_options(
     Ok(())
 }
 
+/// Adds a backend's automatic system-prompt breakpoint to the request,
+/// keeping any `cache_control` markers the client sent.
+pub(super) fn apply_prompt_cache_config(
+    request: &mut GenerateRequest,
+    config: &PromptCacheConfig,
+) -> super::ParseResult<()> {
+    let mut provider_options = request_parsed_provider_options(request)
+        .ok()
+        .flatten()
+        .unwrap_or_default();
+    let cache_control = provider_options
+        .cache_control
+        .get_or_insert_with(CacheControlOptions::default);
+    cache_control.auto_system_min_chars = Some(config.min_system_chars);
+    if cache_control.ttl.is_none() {
+        cache_control.ttl = config.ttl.clone();
+    }
+
+    request.provider_options = Some(
+        ProviderOptionsEnvelope::from_options(provider_options)
+            .map_err(|err| format!("failed to serialize provider_options: {err}"))?,
+    );
+    Ok(())
+}
+
 fn merge_openai_request_provider_options(
     provider_options: &mut ProviderOptions,
     obj: &Map<String, Value>,
@@ -48,6 +75,64 @@ fn merge_openai_request_provider_options(
     {
         provider_options.response_format = Some(parsed);
     }
+
+    if let Some(cache_control) = parse_openai_cache_control(obj) {
+        provider_options.cache_control = Some(cache_control);
+    }
+}
+
+/// Collects the Anthropic-style `cache_control` markers clients put on chat
+/// messages, their content parts or tools. A marked message caches through
+/// its last content block.
+fn parse_openai_cache_control(obj: &Map<String, Value>) -> Option<CacheControlOptions> {
+    let mut out = CacheControlOptions::default();
+    let mut markers = Vec::<&Map<String, Value>>::new();
+
+    for (idx, message) in obj
+        .get("messages")
+        .and_then(Value::as_array)
+        .into_iter()
+        .flatten()
+        .enumerate()
+    {
+        let marker = cache_control_marker(message).or_else(|| {
+            message
+                .get("content")
+                .and_then(Value::as_array)
+                .and_then(|parts| parts.iter().find_map(cache_control_marker))
+        });
+        let Some(marker) = marker else {
+            continue;
+        };
+        markers.push(marker);
+        if message.get("role").and_then(Value::as_str) == Some("system") {
+            out.system = true;
+        } else {
+            out.messages.push(idx);
+        }
+    }
+
+    if let Some(marker) = obj
+        .get("tools")
+        .and_then(Value::as_array)
+        .and_then(|tools| tools.iter().find_map(cache_control_marker))
+    {
+        markers.push(marker);
+        out.tools = true;
+    }
+
+    if markers.is_empty() {
+        return None;
+    }
+    out.ttl = markers
+        .iter()
+        .find_map(|marker| marker.get("ttl").and_then(Value::as_str))
+        .map(str::to_string);
+    Some(out)
+}
+
+fn cache_control_marker(value: &Value) -> Option<&Map<String, Value>> {
+    value.get("cache_control").and_then(Value::as_object)
 }
 
 fn parse_reasoning_effort(value: &str) -> Option<ReasoningEffort> {
@@ -137,4 +222,38 @@ mod tests {
         assert_eq!(parsed.reasoning_effort, Some(ReasoningEffort::Medium));
         assert_eq!(parsed.parallel_tool_calls, Some(true));
     }
+
+    #[test]
+    fn collects_cache_control_markers_and_backend_defaults() {
+        let mut request = GenerateRequest::from(vec![]);
+        let obj = json!({
+            "messages": [
+                {"role": "system", "content": [
+                    {"type": "text", "text": "rules", "cache_control": {"type": "ephemeral", "ttl": "1h"}}
+                ]},
+                {"role": "user", "content": "hi"},
+                {"role": "user", "content": "docs", "cache_control": {"type": "ephemeral"}}
+            ],
+            "tools": [{"type": "function", "function": {"name": "a"}, "cache_control": {"type": "ephemeral"}}]
+        });
+        let obj = obj.as_object().expect("object");
+
+        apply_openai_request_provider_options(&mut request, obj).expect("provider options");
+        apply_prompt_cache_config(&mut request, &PromptCacheConfig::default())
+            .expect("prompt cache");
+
+        let parsed = request_parsed_provider_options(&request)
+            .expect("parsed provider options")
+            .expect("provider options present");
+        assert_eq!(
+            parsed.cache_control,
+            Some(CacheControlOptions {
+                system: true,
+                tools: true,
+                messages: vec![2],
+                auto_system_min_chars: Some(4096),
+                ttl: Some("1h".to_string()),
+            })
+        );
+    }
 }
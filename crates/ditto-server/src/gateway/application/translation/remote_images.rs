@@ -0,0 +1,254 @@
+use std::net::{IpAddr, SocketAddr};
+use std::time::Duration;
+
+use base64::Engine as _;
+use base64::engine::general_purpose::STANDARD as BASE64;
+
+use ditto_core::contracts::{ContentPart, GenerateRequest, ImageSource};
+
+/// Providers whose adapters cannot pass an `http(s)` image URL upstream and
+/// need the bytes inline instead.
+const PROVIDERS_WITHOUT_IMAGE_URLS: &[&str] = &["bedrock", "google", "vertex"];
+
+const MAX_REMOTE_IMAGES: usize = 8;
+const MAX_REMOTE_IMAGE_BYTES: usize = 20 * 1024 * 1024;
+const REMOTE_IMAGE_TIMEOUT: Duration = Duration::from_secs(15);
+
+/// Replaces the `http(s)` image URLs of `request` with their base64 bytes when
+/// `provider` cannot fetch them itself. Only public hosts are fetched, without
+/// redirects, and the body must be a PNG, JPEG, GIF or WebP image of at most
+/// `MAX_REMOTE_IMAGE_BYTES`.
+pub async fn inline_remote_images(
+    provider: &str,
+    request: &mut GenerateRequest,
+) -> Result<(), String> {
+    if !PROVIDERS_WITHOUT_IMAGE_URLS.contains(&provider) {
+        return Ok(());
+    }
+
+    let mut fetched = 0usize;
+    for message in &mut request.messages {
+        for part in &mut message.content {
+            let ContentPart::Image { source } = part else {
+                continue;
+            };
+            let ImageSource::Url { url } = source else {
+                continue;
+            };
+            let Ok(parsed) = reqwest::Url::parse(url) else {
+                return Err(format!("invalid image URL: {url}"));
+            };
+            if !matches!(parsed.scheme(), "http" | "https") {
+                // Provider-native references (e.g. `gs://`) pass through.
+                continue;
+            }
+            fetched += 1;
+            if fetched > MAX_REMOTE_IMAGES {
+                return Err(format!(
+                    "too many remote image URLs (at most {MAX_REMOTE_IMAGES} per request)"
+                ));
+            }
+            let (media_type, bytes) = fetch_remote_image(&parsed).await?;
+            *source = ImageSource::Base64 {
+                media_type: media_type.to_string(),
+                data: BASE64.encode(bytes),
+            };
+        }
+    }
+    Ok(())
+}
+
+async fn fetch_remote_image(url: &reqwest::Url) -> Result<(&'static str, Vec<u8>), String> {
+    let host = url
+        .host_str()
+        .ok_or_else(|| format!("image URL has no host: {url}"))?;
+    let port = url.port_or_known_default().unwrap_or(443);
+    let addr = resolve_public_addr(host.trim_start_matches('[').trim_end_matches(']'), port)
+        .await
+        .map_err(|err| format!("image URL {url}: {err}"))?;
+
+    // Pinning the checked address keeps a second DNS answer from pointing the
+    // fetch somewhere else.
+    let client = reqwest::Client::builder()
+        .redirect(reqwest::redirect::Policy::none())
+        .timeout(REMOTE_IMAGE_TIMEOUT)
+        .resolve(host, addr)
+        .build()
+        .map_err(|err| format!("image URL {url}: {err}"))?;
+    let mut response = client
+        .get(url.clone())
+        .send()
+        .await
+        .map_err(|err| format!("failed to fetch image URL {url}: {err}"))?;
+    if !response.status().is_success() {
+        return Err(format!(
+            "failed to fetch image URL {url}: upstream returned {}",
+            response.status()
+        ));
+    }
+    if response
+        .content_length()
+        .is_some_and(|len| len > MAX_REMOTE_IMAGE_BYTES as u64)
+    {
+        return Err(image_too_large(url));
+    }
+
+    let mut bytes = Vec::new();
+    while let Some(chunk) = response
+        .chunk()
+        .await
+        .map_err(|err| format!("failed to fetch image URL {url}: {err}"))?
+    {
+        if bytes.len() + chunk.len() > MAX_REMOTE_IMAGE_BYTES {
+            return Err(image_too_large(url));
+        }
+        bytes.extend_from_slice(&chunk);
+    }
+
+    let media_type = sniff_image_media_type(&bytes)
+        .ok_or_else(|| format!("image URL {url} is not a PNG, JPEG, GIF or WebP image"))?;
+    Ok((media_type, bytes))
+}
+
+fn image_too_large(url: &reqwest::Url) -> String {
+    format!("image URL {url} exceeds {MAX_REMOTE_IMAGE_BYTES} bytes")
+}
+
+async fn resolve_public_addr(host: &str, port: u16) -> Result<SocketAddr, String> {
+    let addrs: Vec<SocketAddr> = match host.parse::<IpAddr>() {
+        Ok(ip) => vec![SocketAddr::new(ip, port)],
+        Err(_) => tokio::net::lookup_host((host, port))
+            .await
+            .map_err(|err| format!("failed to resolve host: {err}"))?
+            .collect(),
+    };
+    if addrs.iter().any(|addr| !is_public_ip(addr.ip())) {
+        return Err("host resolves to a non-public address".to_string());
+    }
+    addrs
+        .into_iter()
+        .next()
+        .ok_or_else(|| "host has no addresses".to_string())
+}
+
+fn is_public_ip(ip: IpAddr) -> bool {
+    match ip {
+        IpAddr::V4(ip) => {
+            let [a, b, ..] = ip.octets();
+            !(ip.is_loopback()
+                || ip.is_private()
+                || ip.is_link_local()
+                || ip.is_unspecified()
+                || ip.is_broadcast()
+                || ip.is_documentation()
+                || ip.is_multicast()
+                // 100.64.0.0/10 (carrier-grade NAT) and 0.0.0.0/8.
+                || (a == 100 && (b & 0xc0) == 64)
+                || a == 0)
+        }
+        IpAddr::V6(ip) => {
+            if let Some(mapped) = ip.to_ipv4_mapped() {
+                return is_public_ip(IpAddr::V4(mapped));
+            }
+            let first = ip.segments()[0];
+            !(ip.is_loopback()
+                || ip.is_unspecified()
+                || ip.is_multicast()
+                // fc00::/7 (unique local) and fe80::/10 (link-local).
+                || (first & 0xfe00) == 0xfc00
+                || (first & 0xffc0) == 0xfe80)
+        }
+    }
+}
+
+fn sniff_image_media_type(bytes: &[u8]) -> Option<&'static str> {
+    if bytes.starts_with(b"\x89PNG\r\n\x1a\n") {
+        Some("image/png")
+    } else if bytes.starts_with(&[0xff, 0xd8, 0xff]) {
+        Some("image/jpeg")
+    } else if bytes.starts_with(b"GIF87a") || bytes.starts_with(b"GIF89a") {
+        Some("image/gif")
+    } else if bytes.len() >= 12 && bytes.starts_with(b"RIFF") && &bytes[8..12] == b"WEBP" {
+        Some("image/webp")
+    } else {
+        None
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    use ditto_core::contracts::{Message, Role};
+
+    fn image_request(url: &str) -> GenerateRequest {
+        GenerateRequest::from(vec![Message {
+            role: Role::User,
+            content: vec![ContentPart::Image {
+                source: ImageSource::Url {
+                    url: url.to_string(),
+                },
+            }],
+        }])
+    }
+
+    #[test]
+    fn sniffs_supported_image_types() {
+        assert_eq!(
+            sniff_image_media_type(b"\x89PNG\r\n\x1a\n...."),
+            Some("image/png")
+        );
+        assert_eq!(
+            sniff_image_media_type(&[0xff, 0xd8, 0xff, 0xe0]),
+            Some("image/jpeg")
+        );
+        assert_eq!(sniff_image_media_type(b"GIF89a.."), Some("image/gif"));
+        assert_eq!(
+            sniff_image_media_type(b"RIFF\0\0\0\0WEBPVP8 "),
+            Some("image/webp")
+        );
+        assert_eq!(sniff_image_media_type(b"<html></html>"), None);
+    }
+
+    #[test]
+    fn only_public_addresses_are_fetched() {
+        for ip in [
+            "127.0.0.1",
+            "10.1.2.3",
+            "169.254.169.254",
+            "100.64.0.1",
+            "::1",
+            "fd00::1",
+            "::ffff:192.168.0.1",
+        ] {
+            assert!(!is_public_ip(ip.parse().unwrap()), "{ip}");
+        }
+        for ip in ["8.8.8.8", "2606:4700::1111"] {
+            assert!(is_public_ip(ip.parse().unwrap()), "{ip}");
+        }
+    }
+
+    #[tokio::test]
+    async fn inlines_only_for_providers_without_image_urls() {
+        let mut request = image_request("http://127.0.0.1/cat.png");
+        inline_remote_images("anthropic", &mut request)
+            .await
+            .expect("url passes through");
+        assert!(matches!(
+            &request.messages[0].content[0],
+            ContentPart::Image {
+                source: ImageSource::Url { .. }
+            }
+        ));
+
+        let err = inline_remote_images("google", &mut request)
+            .await
+            .expect_err("loopback host is rejected");
+        assert!(err.contains("non-public"), "{err}");
+
+        let mut request = image_request("gs://bucket/cat.png");
+        inline_remote_images("vertex", &mut request)
+            .await
+            .expect("gs:// passes through");
+    }
+}
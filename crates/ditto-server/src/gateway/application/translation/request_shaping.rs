@@ -807,9 +807,7 @@ fn parse_openai_content_parts(value: &Value) -> Vec<ContentPart> {
                                     .filter(|s| !s.is_empty())
                                 {
                                     out.push(ContentPart::Image {
-                                        source: ImageSource::Url {
-                                            url: url.to_string(),
-                                        },
+                                        source: parse_image_source(url),
                                     });
                                 }
                             }
@@ -825,7 +823,28 @@ fn parse_openai_content_parts(value: &Value) -> Vec<ContentPart> {
                                 };
                                 if let Some(url) = image_url.filter(|url| !url.is_empty()) {
                                     out.push(ContentPart::Image {
-                                        source: ImageSource::Url { url },
+                                        source: parse_image_source(&url),
+                                    });
+                                }
+                            }
+                            "input_audio" => {
+                                let audio = obj.get("input_audio").and_then(Value::as_object);
+                                let data = audio
+                                    .and_then(|audio| audio.get("data"))
+                                    .and_then(Value::as_str)
+                                    .map(str::trim)
+                                    .filter(|data| !data.is_empty());
+                                let media_type = audio
+                                    .and_then(|audio| audio.get("format"))
+                                    .and_then(Value::as_str)
+                                    .and_then(audio_format_media_type);
+                                if let (Some(data), Some(media_type)) = (data, media_type) {
+                                    out.push(ContentPart::File {
+                                        filename: None,
+                                        media_type: media_type.to_string(),
+                                        source: ditto_core::contracts::FileSource::Base64 {
+                                            data: data.to_string(),
+                                        },
                                     });
                                 }
                             }
@@ -904,6 +923,26 @@ fn parse_openai_content_parts(value: &Value) -> Vec<ContentPart> {
     }
 }
 
+/// Inline `data:` image URLs become base64 sources so providers that only
+/// take raw bytes (or reject data URLs) receive them as such.
+fn parse_image_source(url: &str) -> ImageSource {
+    match parse_base64_data_url(url) {
+        Some((media_type, data)) => ImageSource::Base64 { media_type, data },
+        None => ImageSource::Url {
+            url: url.to_string(),
+        },
+    }
+}
+
+/// Media type of an OpenAI `input_audio.format`.
+fn audio_format_media_type(format: &str) -> Option<&'static str> {
+    match format.trim() {
+        "wav" => Some("audio/wav"),
+        "mp3" => Some("audio/mpeg"),
+        _ => None,
+    }
+}
+
 fn parse_base64_data_url(value: &str) -> Option<(String, String)> {
     let rest = value.trim().strip_prefix("data:")?;
     let (media_type, data) = rest.split_once(";base64,")?;
@@ -1101,4 +1140,48 @@ mod tests {
             } if filename == "doc.pdf" && media_type == "application/pdf" && data == "AQID"
         ));
     }
+
+    #[test]
+    fn parse_openai_content_parts_normalizes_images_and_audio() {
+        let parts = parse_openai_content_parts(&serde_json::json!([
+            {
+                "type": "image_url",
+                "image_url": { "url": "data:image/png;base64,iVBORw==" }
+            },
+            {
+                "type": "input_image",
+                "image_url": "https://example.com/cat.jpg"
+            },
+            {
+                "type": "input_audio",
+                "input_audio": { "data": "UklGRg==", "format": "wav" }
+            },
+            {
+                "type": "input_audio",
+                "input_audio": { "data": "AAAA", "format": "flac" }
+            }
+        ]));
+
+        assert_eq!(parts.len(), 3);
+        assert!(matches!(
+            &parts[0],
+            ContentPart::Image {
+                source: ImageSource::Base64 { media_type, data },
+            } if media_type == "image/png" && data == "iVBORw=="
+        ));
+        assert!(matches!(
+            &parts[1],
+            ContentPart::Image {
+                source: ImageSource::Url { url },
+            } if url == "https://example.com/cat.jpg"
+        ));
+        assert!(matches!(
+            &parts[2],
+            ContentPart::File {
+                filename: None,
+                media_type,
+                source: FileSource::Base64 { data },
+            } if media_type == "audio/wav" && data == "UklGRg=="
+        ));
+    }
 }
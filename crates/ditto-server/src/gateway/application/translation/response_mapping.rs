@@ -1,7 +1,7 @@
 use bytes::Bytes;
 use serde_json::{Map, Value};
 
-use ditto_core::contracts::{FinishReason, Usage};
+use ditto_core::contracts::{FinishReason, TokenLogprob, Usage};
 
 pub(super) fn usage_to_chat_usage(usage: &Usage) -> Option<Value> {
     let prompt = usage.input_tokens?;
@@ -9,11 +9,18 @@ pub(super) fn usage_to_chat_usage(usage: &Usage) -> Option<Value> {
     let total = usage
         .total_tokens
         .or_else(|| Some(prompt.saturating_add(completion)))?;
-    Some(serde_json::json!({
+    let mut out = serde_json::json!({
         "prompt_tokens": prompt,
         "completion_tokens": completion,
         "total_tokens": total,
-    }))
+    });
+    if let Some(cached) = usage.cache_input_tokens {
+        out["prompt_tokens_details"] = serde_json::json!({ "cached_tokens": cached });
+    }
+    if let Some(created) = usage.cache_creation_input_tokens {
+        out["cache_creation_input_tokens"] = Value::Number(created.into());
+    }
+    Some(out)
 }
 
 pub(super) fn usage_to_responses_usage(usage: &Usage) -> Option<Value> {
@@ -24,6 +31,18 @@ pub(super) fn usage_to_responses_usage(usage: &Usage) -> Option<Value> {
             Value::Number((input_tokens as i64).into()),
         );
     }
+    if let Some(cached) = usage.cache_input_tokens {
+        out.insert(
+            "input_tokens_details".to_string(),
+            serde_json::json!({ "cached_tokens": cached }),
+        );
+    }
+    if let Some(created) = usage.cache_creation_input_tokens {
+        out.insert(
+            "cache_creation_input_tokens".to_string(),
+            Value::Number(created.into()),
+        );
+    }
     if let Some(output_tokens) = usage.output_tokens {
         out.insert(
             "output_tokens".to_string(),
@@ -59,6 +78,85 @@ pub(super) fn finish_reason_to_chat_finish_reason(reason: FinishReason) -> Optio
     }
 }
 
+fn token_logprob_entry(token: &str, logprob: f64, bytes: Option<&[u8]>) -> Map<String, Value> {
+    let bytes = bytes.unwrap_or(token.as_bytes());
+    let mut entry = Map::<String, Value>::new();
+    entry.insert("token".to_string(), Value::String(token.to_string()));
+    entry.insert("logprob".to_string(), serde_json::json!(logprob));
+    entry.insert(
+        "bytes".to_string(),
+        Value::Array(bytes.iter().map(|b| Value::Number((*b).into())).collect()),
+    );
+    entry
+}
+
+/// OpenAI `logprobs.content[]` entries, also used as the Responses
+/// `output_text.logprobs` array.
+pub(super) fn token_logprobs_to_openai(logprobs: &[TokenLogprob]) -> Value {
+    Value::Array(
+        logprobs
+            .iter()
+            .map(|logprob| {
+                let mut entry =
+                    token_logprob_entry(&logprob.token, logprob.logprob, logprob.bytes.as_deref());
+                let top_logprobs = logprob
+                    .top_logprobs
+                    .iter()
+                    .map(|top| {
+                        Value::Object(token_logprob_entry(
+                            &top.token,
+                            top.logprob,
+                            top.bytes.as_deref(),
+                        ))
+                    })
+                    .collect();
+                entry.insert("top_logprobs".to_string(), Value::Array(top_logprobs));
+                Value::Object(entry)
+            })
+            .collect(),
+    )
+}
+
+pub(super) fn token_logprobs_to_chat_logprobs(logprobs: &[TokenLogprob]) -> Value {
+    serde_json::json!({
+        "content": token_logprobs_to_openai(logprobs),
+        "refusal": null,
+    })
+}
+
+/// Legacy `/v1/completions` logprobs; `text_offset` is the character offset
+/// of the first token within the completion text.
+pub(super) fn token_logprobs_to_completions_logprobs(
+    logprobs: &[TokenLogprob],
+    text_offset: usize,
+) -> Value {
+    let mut offset = text_offset;
+    let mut tokens = Vec::<Value>::new();
+    let mut token_logprobs = Vec::<Value>::new();
+    let mut top_logprobs = Vec::<Value>::new();
+    let mut text_offsets = Vec::<Value>::new();
+    for logprob in logprobs {
+        tokens.push(Value::String(logprob.token.clone()));
+        token_logprobs.push(serde_json::json!(logprob.logprob));
+        let mut top = Map::<String, Value>::new();
+        for alternative in &logprob.top_logprobs {
+            top.insert(
+                alternative.token.clone(),
+                serde_json::json!(alternative.logprob),
+            );
+        }
+        top_logprobs.push(Value::Object(top));
+        text_offsets.push(Value::Number(offset.into()));
+        offset += logprob.token.chars().count();
+    }
+    serde_json::json!({
+        "tokens": tokens,
+        "token_logprobs": token_logprobs,
+        "top_logprobs": top_logprobs,
+        "text_offset": text_offsets,
+    })
+}
+
 pub(super) fn finish_reason_to_responses_status(
     reason: FinishReason,
 ) -> (&'static str, Option<Value>) {
@@ -81,12 +179,13 @@ pub(super) fn completion_chunk_bytes(
     model: &str,
     created: u64,
     text: &str,
+    logprobs: Option<Value>,
     finish_reason: Option<FinishReason>,
 ) -> Bytes {
     let mut choice = Map::<String, Value>::new();
     choice.insert("index".to_string(), Value::Number(0.into()));
     choice.insert("text".to_string(), Value::String(text.to_string()));
-    choice.insert("logprobs".to_string(), Value::Null);
+    choice.insert("logprobs".to_string(), logprobs.unwrap_or(Value::Null));
     if let Some(finish_reason) = finish_reason {
         if let Some(mapped) = finish_reason_to_chat_finish_reason(finish_reason) {
             choice.insert(
@@ -127,10 +226,25 @@ pub(super) fn chat_chunk_bytes(
     delta: Value,
     finish_reason: Option<FinishReason>,
     usage: Option<Value>,
+) -> Bytes {
+    chat_chunk_bytes_with_logprobs(id, model, created, delta, None, finish_reason, usage)
+}
+
+pub(super) fn chat_chunk_bytes_with_logprobs(
+    id: &str,
+    model: &str,
+    created: u64,
+    delta: Value,
+    logprobs: Option<Value>,
+    finish_reason: Option<FinishReason>,
+    usage: Option<Value>,
 ) -> Bytes {
     let mut choice = Map::<String, Value>::new();
     choice.insert("index".to_string(), Value::Number(0.into()));
     choice.insert("delta".to_string(), delta);
+    if let Some(logprobs) = logprobs {
+        choice.insert("logprobs".to_string(), logprobs);
+    }
     if let Some(finish_reason) = finish_reason {
         if let Some(mapped) = finish_reason_to_chat_finish_reason(finish_reason) {
             choice.insert(
@@ -167,13 +281,18 @@ pub(super) fn chat_chunk_bytes(
     Bytes::from(format!("data: {json}\n\n"))
 }
 
-pub(super) fn chat_usage_chunk_bytes(id: &str, model: &str, created: u64, usage: Value) -> Bytes {
+/// The final `choices: []` chunk carrying usage; `object` is
+/// `chat.completion.chunk` or `text_completion`.
+pub(super) fn usage_chunk_bytes(
+    object: &str,
+    id: &str,
+    model: &str,
+    created: u64,
+    usage: Value,
+) -> Bytes {
     let mut out = Map::<String, Value>::new();
     out.insert("id".to_string(), Value::String(id.to_string()));
-    out.insert(
-        "object".to_string(),
-        Value::String("chat.completion.chunk".to_string()),
-    );
+    out.insert("object".to_string(), Value::String(object.to_string()));
     out.insert(
         "created".to_string(),
         Value::Number((created as i64).into()),
@@ -213,10 +332,87 @@ mod tests {
         );
     }
 
+    #[test]
+    fn usage_reports_cache_reads_and_writes() {
+        let usage = Usage {
+            input_tokens: Some(130),
+            cache_input_tokens: Some(100),
+            cache_creation_input_tokens: Some(20),
+            output_tokens: Some(5),
+            total_tokens: Some(135),
+        };
+
+        assert_eq!(
+            usage_to_chat_usage(&usage),
+            Some(serde_json::json!({
+                "prompt_tokens": 130,
+                "completion_tokens": 5,
+                "total_tokens": 135,
+                "prompt_tokens_details": { "cached_tokens": 100 },
+                "cache_creation_input_tokens": 20
+            }))
+        );
+        assert_eq!(
+            usage_to_responses_usage(&usage),
+            Some(serde_json::json!({
+                "input_tokens": 130,
+                "input_tokens_details": { "cached_tokens": 100 },
+                "cache_creation_input_tokens": 20,
+                "output_tokens": 5,
+                "total_tokens": 135
+            }))
+        );
+    }
+
+    #[test]
+    fn token_logprobs_map_to_chat_and_completions_shapes() {
+        let logprobs = vec![
+            TokenLogprob {
+                token: "Hi".to_string(),
+                logprob: -0.5,
+                bytes: None,
+                top_logprobs: vec![ditto_core::contracts::TopLogprob {
+                    token: "Hello".to_string(),
+                    logprob: -1.0,
+                    bytes: None,
+                }],
+            },
+            TokenLogprob {
+                token: "!".to_string(),
+                logprob: -0.25,
+                ..TokenLogprob::default()
+            },
+        ];
+
+        let chat = token_logprobs_to_chat_logprobs(&logprobs);
+        assert_eq!(chat["content"][0]["token"], "Hi");
+        assert_eq!(chat["content"][0]["bytes"], serde_json::json!([72, 105]));
+        assert_eq!(chat["content"][0]["top_logprobs"][0]["token"], "Hello");
+        assert_eq!(chat["content"][1]["top_logprobs"], serde_json::json!([]));
+
+        let completions = token_logprobs_to_completions_logprobs(&logprobs, 3);
+        assert_eq!(completions["tokens"], serde_json::json!(["Hi", "!"]));
+        assert_eq!(
+            completions["token_logprobs"],
+            serde_json::json!([-0.5, -0.25])
+        );
+        assert_eq!(
+            completions["top_logprobs"],
+            serde_json::json!([{ "Hello": -1.0 }, {}])
+        );
+        assert_eq!(completions["text_offset"], serde_json::json!([3, 5]));
+    }
+
     #[test]
     fn completion_chunk_bytes_maps_finish_reason() {
-        let chunk =
-            completion_chunk_bytes("cmpl_123", "gpt-4.1", 42, "ok", Some(FinishReason::Stop));
+        let chunk = completion_chunk_bytes(
+            "cmpl_123",
+            "gpt-4.1",
+            42,
+            "ok",
+            None,
+            Some(FinishReason::Stop),
+        );
         let text = String::from_utf8(chunk.to_vec()).expect("utf8");
 
         assert!(text.contains("\"finish_reason\":\"stop\""));
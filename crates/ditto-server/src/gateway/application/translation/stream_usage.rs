@@ -0,0 +1,119 @@
+use std::sync::Arc;
+
+use serde_json::Value;
+
+use ditto_core::contracts::Usage;
+
+/// Prices the final usage of a stream, in USD micros.
+pub type StreamUsageCostFn = Arc<dyn Fn(&Usage) -> Option<u64> + Send + Sync>;
+
+/// How a translated stream reports usage when it ends. Providers that stream
+/// no usage, or only part of it, get the gateway's prompt estimate and a count
+/// of the streamed output instead, so every adapter reports the same shape.
+#[derive(Clone, Default)]
+pub struct StreamUsageParams {
+    /// Emits the final usage chunk of chat/text completions
+    /// (`stream_options.include_usage`). Responses streams always carry usage
+    /// in `response.completed`.
+    pub include_usage: bool,
+    /// The gateway's estimate of the prompt tokens.
+    pub estimated_prompt_tokens: u32,
+    /// Prices the final usage, reported as `usage.cost` in USD.
+    pub cost_usd_micros: Option<StreamUsageCostFn>,
+}
+
+impl StreamUsageParams {
+    /// The provider's usage with missing token counts filled in. `output` is
+    /// everything the stream produced: text, reasoning and tool calls.
+    pub(super) fn complete(&self, model: &str, reported: Option<&Usage>, output: &str) -> Usage {
+        let mut usage = reported.cloned().unwrap_or_default();
+        if usage.input_tokens.is_none() {
+            usage.input_tokens = Some(u64::from(self.estimated_prompt_tokens));
+            usage.total_tokens = None;
+        }
+        if usage.output_tokens.is_none() {
+            usage.output_tokens = Some(count_output_tokens(model, output));
+            usage.total_tokens = None;
+        }
+        usage.merge_total();
+        usage
+    }
+
+    /// Adds `cost` (USD) to a rendered usage object when the usage is priced.
+    pub(super) fn with_cost(&self, mut rendered: Value, usage: &Usage) -> Value {
+        let cost = self
+            .cost_usd_micros
+            .as_ref()
+            .and_then(|cost_usd_micros| cost_usd_micros(usage));
+        if let (Some(cost), Some(fields)) = (cost, rendered.as_object_mut()) {
+            fields.insert(
+                "cost".to_string(),
+                serde_json::json!(cost as f64 / 1_000_000.0),
+            );
+        }
+        rendered
+    }
+}
+
+fn count_output_tokens(model: &str, output: &str) -> u64 {
+    if output.is_empty() {
+        return 0;
+    }
+    #[cfg(feature = "gateway-tokenizer")]
+    {
+        u64::from(crate::gateway::token_count::count_text_tokens(
+            model, output,
+        ))
+    }
+    #[cfg(not(feature = "gateway-tokenizer"))]
+    {
+        let _ = model;
+        (output.len() as u64).div_ceil(4)
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn fills_missing_counts_and_prices_the_result() {
+        let params = StreamUsageParams {
+            include_usage: true,
+            estimated_prompt_tokens: 12,
+            cost_usd_micros: Some(Arc::new(|usage: &Usage| usage.total_tokens)),
+        };
+
+        let usage = params.complete("stub", None, "");
+        assert_eq!(usage.input_tokens, Some(12));
+        assert_eq!(usage.output_tokens, Some(0));
+        assert_eq!(usage.total_tokens, Some(12));
+
+        let reported = Usage {
+            input_tokens: Some(40),
+            output_tokens: Some(7),
+            total_tokens: Some(47),
+            ..Usage::default()
+        };
+        assert_eq!(
+            params.complete("stub", Some(&reported), "ignored"),
+            reported
+        );
+
+        let partial = Usage {
+            input_tokens: Some(40),
+            total_tokens: Some(40),
+            ..Usage::default()
+        };
+        let usage = params.complete("stub", Some(&partial), "hello world");
+        assert_eq!(usage.input_tokens, Some(40));
+        assert!(usage.output_tokens.is_some_and(|tokens| tokens > 0));
+        assert_eq!(
+            usage.total_tokens,
+            Some(40 + usage.output_tokens.unwrap_or_default())
+        );
+
+        let rendered = params.with_cost(serde_json::json!({"total_tokens": 47}), &reported);
+        assert_eq!(rendered["cost"], serde_json::json!(0.000047));
+    }
+}
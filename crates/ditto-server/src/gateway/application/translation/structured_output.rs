@@ -0,0 +1,497 @@
+use serde_json::{Map, Value};
+
+use ditto_core::contracts::{ContentPart, GenerateRequest, GenerateResponse, Message, Role};
+use ditto_core::error::DittoError;
+use ditto_core::llm_core::model::LanguageModel;
+use ditto_core::provider_options::{
+    JsonSchemaFormat, ResponseFormat, request_parsed_provider_options_for,
+};
+
+/// Providers whose adapters ignore `response_format: json_schema`.
+const PROVIDERS_WITHOUT_JSON_SCHEMA: &[&str] =
+    &["anthropic", "bedrock", "cohere", "google", "vertex"];
+
+const MAX_SCHEMA_ERRORS: usize = 8;
+const MAX_SCHEMA_DEPTH: usize = 64;
+
+pub enum StructuredOutputError {
+    Provider(DittoError),
+    /// The last reply still did not match the schema.
+    Invalid {
+        attempts: u32,
+        errors: Vec<String>,
+    },
+}
+
+/// The `json_schema` response format the gateway has to emulate: one the
+/// request asks for but `provider` cannot enforce natively.
+pub fn emulated_json_schema(provider: &str, request: &GenerateRequest) -> Option<JsonSchemaFormat> {
+    if !PROVIDERS_WITHOUT_JSON_SCHEMA.contains(&provider) {
+        return None;
+    }
+    let options = request_parsed_provider_options_for(request, provider)
+        .ok()
+        .flatten()?;
+    match options.response_format? {
+        ResponseFormat::JsonSchema { json_schema } => Some(json_schema),
+    }
+}
+
+/// Appends the schema instructions after the request's leading system
+/// messages.
+pub fn add_json_schema_instructions(request: &mut GenerateRequest, schema: &JsonSchemaFormat) {
+    let rendered = serde_json::to_string(&schema.schema).unwrap_or_else(|_| "{}".to_string());
+    let instructions = format!(
+        "Respond only with a JSON value that conforms to the JSON Schema `{}` below. \
+         Do not wrap it in Markdown code fences or add any other text.\n\n{rendered}",
+        schema.name
+    );
+    let at = request
+        .messages
+        .iter()
+        .take_while(|message| message.role == Role::System)
+        .count();
+    request.messages.insert(at, Message::system(instructions));
+}
+
+/// Generates with the schema in the prompt and validates the reply, asking
+/// the model to correct it until `max_attempts` generations have been made.
+/// The returned content is the validated JSON; usage covers every attempt.
+pub async fn generate_with_emulated_json_schema(
+    model: &dyn LanguageModel,
+    mut request: GenerateRequest,
+    schema: &JsonSchemaFormat,
+    max_attempts: u32,
+) -> Result<GenerateResponse, StructuredOutputError> {
+    let max_attempts = max_attempts.max(1);
+    add_json_schema_instructions(&mut request, schema);
+
+    let mut usage = ditto_core::contracts::Usage::default();
+    let mut attempt = 0;
+    loop {
+        attempt += 1;
+        let mut response = model
+            .generate(request.clone())
+            .await
+            .map_err(StructuredOutputError::Provider)?;
+        add_usage(&mut usage, &response.usage);
+
+        let text = response.text();
+        let errors = match parse_json_reply(&text) {
+            Some(value) => {
+                let errors = json_schema_errors(&schema.schema, &value);
+                if errors.is_empty() {
+                    response
+                        .content
+                        .retain(|part| !matches!(part, ContentPart::Text { .. }));
+                    response.content.insert(
+                        0,
+                        ContentPart::Text {
+                            text: value.to_string(),
+                        },
+                    );
+                    response.usage = usage;
+                    return Ok(response);
+                }
+                errors
+            }
+            None => vec!["$: the reply is not valid JSON".to_string()],
+        };
+
+        if attempt >= max_attempts {
+            return Err(StructuredOutputError::Invalid {
+                attempts: attempt,
+                errors,
+            });
+        }
+        request.messages.push(Message::assistant(text));
+        request.messages.push(Message::user(format!(
+            "Your reply did not match the JSON Schema:\n- {}\nRespond again with only the corrected JSON value.",
+            errors.join("\n- ")
+        )));
+    }
+}
+
+fn add_usage(total: &mut ditto_core::contracts::Usage, usage: &ditto_core::contracts::Usage) {
+    fn add(total: &mut Option<u64>, value: Option<u64>) {
+        if let Some(value) = value {
+            *total = Some(total.unwrap_or_default().saturating_add(value));
+        }
+    }
+
+    add(&mut total.input_tokens, usage.input_tokens);
+    add(&mut total.cache_input_tokens, usage.cache_input_tokens);
+    add(
+        &mut total.cache_creation_input_tokens,
+        usage.cache_creation_input_tokens,
+    );
+    add(&mut total.output_tokens, usage.output_tokens);
+    add(&mut total.total_tokens, usage.total_tokens);
+}
+
+/// Parses a reply that should be bare JSON, tolerating Markdown fences and
+/// prose around a single object or array.
+fn parse_json_reply(text: &str) -> Option<Value> {
+    let mut text = text.trim();
+    if let Some(fenced) = text.strip_prefix("```") {
+        let body = fenced.split_once('\n').map_or("", |(_, body)| body);
+        text = body.trim_end().strip_suffix("```").unwrap_or(body).trim();
+    }
+    if let Ok(value) = serde_json::from_str(text) {
+        return Some(value);
+    }
+    let start = text.find(['{', '['])?;
+    let end = text.rfind(['}', ']'])?;
+    serde_json::from_str(text.get(start..=end)?).ok()
+}
+
+/// Checks `instance` against the JSON Schema keywords structured outputs use:
+/// `type`, `enum`, `const`, `properties`, `required`, `additionalProperties`,
+/// `items`, `prefixItems`, length/size/range bounds, `allOf` / `anyOf` /
+/// `oneOf` / `not` and local `$ref`s. Other keywords (`pattern`, `format`,
+/// ...) are not enforced. Returns at most `MAX_SCHEMA_ERRORS` errors.
+pub(super) fn json_schema_errors(schema: &Value, instance: &Value) -> Vec<String> {
+    let mut errors = Vec::new();
+    validate(schema, schema, instance, "$", 0, &mut errors);
+    errors
+}
+
+fn validate(
+    root: &Value,
+    schema: &Value,
+    instance: &Value,
+    path: &str,
+    depth: usize,
+    errors: &mut Vec<String>,
+) {
+    if errors.len() >= MAX_SCHEMA_ERRORS {
+        return;
+    }
+    let schema = match schema {
+        Value::Bool(false) => {
+            errors.push(format!("{path}: no value is allowed here"));
+            return;
+        }
+        Value::Object(schema) => schema,
+        _ => return,
+    };
+    if depth > MAX_SCHEMA_DEPTH {
+        errors.push(format!("{path}: schema nesting is too deep"));
+        return;
+    }
+
+    if let Some(reference) = schema.get("$ref").and_then(Value::as_str) {
+        match reference
+            .strip_prefix('#')
+            .and_then(|pointer| root.pointer(pointer))
+        {
+            Some(target) => validate(root, target, instance, path, depth + 1, errors),
+            None => errors.push(format!("{path}: cannot resolve $ref {reference}")),
+        }
+    }
+
+    if let Some(types) = schema.get("type") {
+        let allowed: Vec<&str> = match types {
+            Value::String(ty) => vec![ty.as_str()],
+            Value::Array(types) => types.iter().filter_map(Value::as_str).collect(),
+            _ => Vec::new(),
+        };
+        if !allowed.is_empty() && !allowed.iter().any(|ty| type_matches(ty, instance)) {
+            errors.push(format!(
+                "{path}: expected {}, got {}",
+                allowed.join(" or "),
+                type_name(instance)
+            ));
+            return;
+        }
+    }
+    if let Some(values) = schema.get("enum").and_then(Value::as_array)
+        && !values.contains(instance)
+    {
+        errors.push(format!(
+            "{path}: must be one of {}",
+            Value::Array(values.clone())
+        ));
+    }
+    if let Some(expected) = schema.get("const")
+        && expected != instance
+    {
+        errors.push(format!("{path}: must equal {expected}"));
+    }
+
+    match instance {
+        Value::Object(object) => validate_object(root, schema, object, path, depth, errors),
+        Value::Array(items) => validate_array(root, schema, items, path, depth, errors),
+        Value::String(text) => {
+            let len = text.chars().count() as u64;
+            if let Some(min) = schema.get("minLength").and_then(Value::as_u64)
+                && len < min
+            {
+                errors.push(format!("{path}: must be at least {min} characters"));
+            }
+            if let Some(max) = schema.get("maxLength").and_then(Value::as_u64)
+                && len > max
+            {
+                errors.push(format!("{path}: must be at most {max} characters"));
+            }
+        }
+        Value::Number(number) => {
+            let value = number.as_f64().unwrap_or_default();
+            let bound = |name: &str| schema.get(name).and_then(Value::as_f64);
+            if let Some(min) = bound("minimum")
+                && value < min
+            {
+                errors.push(format!("{path}: must be >= {min}"));
+            }
+            if let Some(max) = bound("maximum")
+                && value > max
+            {
+                errors.push(format!("{path}: must be <= {max}"));
+            }
+            if let Some(min) = bound("exclusiveMinimum")
+                && value <= min
+            {
+                errors.push(format!("{path}: must be > {min}"));
+            }
+            if let Some(max) = bound("exclusiveMaximum")
+                && value >= max
+            {
+                errors.push(format!("{path}: must be < {max}"));
+            }
+        }
+        _ => {}
+    }
+
+    if let Some(all_of) = schema.get("allOf").and_then(Value::as_array) {
+        for sub in all_of {
+            validate(root, sub, instance, path, depth + 1, errors);
+        }
+    }
+    let matches = |sub: &Value| {
+        let mut sub_errors = Vec::new();
+        validate(root, sub, instance, path, depth + 1, &mut sub_errors);
+        sub_errors.is_empty()
+    };
+    if let Some(any_of) = schema.get("anyOf").and_then(Value::as_array)
+        && !any_of.iter().any(matches)
+    {
+        errors.push(format!("{path}: does not match any anyOf alternative"));
+    }
+    if let Some(one_of) = schema.get("oneOf").and_then(Value::as_array) {
+        let matched = one_of.iter().filter(|sub| matches(sub)).count();
+        if matched != 1 {
+            errors.push(format!(
+                "{path}: must match exactly one oneOf alternative, matched {matched}"
+            ));
+        }
+    }
+    if let Some(not) = schema.get("not")
+        && matches(not)
+    {
+        errors.push(format!("{path}: must not match the `not` schema"));
+    }
+}
+
+fn validate_object(
+    root: &Value,
+    schema: &Map<String, Value>,
+    object: &Map<String, Value>,
+    path: &str,
+    depth: usize,
+    errors: &mut Vec<String>,
+) {
+    if let Some(required) = schema.get("required").and_then(Value::as_array) {
+        for name in required.iter().filter_map(Value::as_str) {
+            if !object.contains_key(name) {
+                errors.push(format!("{path}: missing required property `{name}`"));
+            }
+        }
+    }
+    let properties = schema.get("properties").and_then(Value::as_object);
+    let additional = schema.get("additionalProperties");
+    for (name, value) in object {
+        let property_path = format!("{path}.{name}");
+        match properties.and_then(|properties| properties.get(name)) {
+            Some(property) => validate(root, property, value, &property_path, depth + 1, errors),
+            None => match additional {
+                Some(Value::Bool(false)) => {
+                    errors.push(format!("{path}: unexpected property `{name}`"));
+                }
+                Some(additional) => {
+                    validate(root, additional, value, &property_path, depth + 1, errors);
+                }
+                None => {}
+            },
+        }
+    }
+    let len = object.len() as u64;
+    if let Some(min) = schema.get("minProperties").and_then(Value::as_u64)
+        && len < min
+    {
+        errors.push(format!("{path}: must have at least {min} properties"));
+    }
+    if let Some(max) = schema.get("maxProperties").and_then(Value::as_u64)
+        && len > max
+    {
+        errors.push(format!("{path}: must have at most {max} properties"));
+    }
+}
+
+fn validate_array(
+    root: &Value,
+    schema: &Map<String, Value>,
+    items: &[Value],
+    path: &str,
+    depth: usize,
+    errors: &mut Vec<String>,
+) {
+    let prefix = schema
+        .get("prefixItems")
+        .and_then(Value::as_array)
+        .map_or(&[][..], Vec::as_slice);
+    for (idx, item) in items.iter().enumerate() {
+        let item_schema = match prefix.get(idx) {
+            Some(item_schema) => item_schema,
+            None => match schema.get("items") {
+                Some(item_schema) => item_schema,
+                None => continue,
+            },
+        };
+        validate(
+            root,
+            item_schema,
+            item,
+            &format!("{path}[{idx}]"),
+            depth + 1,
+            errors,
+        );
+    }
+    let len = items.len() as u64;
+    if let Some(min) = schema.get("minItems").and_then(Value::as_u64)
+        && len < min
+    {
+        errors.push(format!("{path}: must have at least {min} items"));
+    }
+    if let Some(max) = schema.get("maxItems").and_then(Value::as_u64)
+        && len > max
+    {
+        errors.push(format!("{path}: must have at most {max} items"));
+    }
+}
+
+fn type_matches(ty: &str, instance: &Value) -> bool {
+    match ty {
+        "object" => instance.is_object(),
+        "array" => instance.is_array(),
+        "string" => instance.is_string(),
+        "boolean" => instance.is_boolean(),
+        "null" => instance.is_null(),
+        "number" => instance.is_number(),
+        "integer" => {
+            instance.is_i64()
+                || instance.is_u64()
+                || instance.as_f64().is_some_and(|value| value.fract() == 0.0)
+        }
+        _ => true,
+    }
+}
+
+fn type_name(instance: &Value) -> &'static str {
+    match instance {
+        Value::Null => "null",
+        Value::Bool(_) => "boolean",
+        Value::Number(_) => "number",
+        Value::String(_) => "string",
+        Value::Array(_) => "array",
+        Value::Object(_) => "object",
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use serde_json::json;
+
+    #[test]
+    fn reports_schema_violations_with_paths() {
+        let schema = json!({
+            "type": "object",
+            "properties": {
+                "name": { "type": "string", "minLength": 1 },
+                "tags": { "type": "array", "items": { "$ref": "#/$defs/tag" }, "maxItems": 2 },
+                "score": { "type": "integer", "minimum": 0 },
+                "kind": { "enum": ["a", "b"] }
+            },
+            "required": ["name", "score"],
+            "additionalProperties": false,
+            "$defs": { "tag": { "type": "string" } }
+        });
+
+        let valid = json!({ "name": "x", "tags": ["t"], "score": 3, "kind": "a" });
+        assert!(json_schema_errors(&schema, &valid).is_empty());
+
+        let invalid = json!({ "name": "", "tags": ["t", 1, "u"], "kind": "c", "extra": true });
+        let mut errors = json_schema_errors(&schema, &invalid);
+        errors.sort();
+        assert_eq!(
+            errors,
+            vec![
+                "$.kind: must be one of [\"a\",\"b\"]",
+                "$.name: must be at least 1 characters",
+                "$.tags: must have at most 2 items",
+                "$.tags[1]: expected string, got number",
+                "$: missing required property `score`",
+                "$: unexpected property `extra`",
+            ]
+        );
+
+        let nullable = json!({ "anyOf": [{ "type": "string" }, { "type": "null" }] });
+        assert!(json_schema_errors(&nullable, &Value::Null).is_empty());
+        assert_eq!(
+            json_schema_errors(&nullable, &json!(1)),
+            vec!["$: does not match any anyOf alternative"]
+        );
+    }
+
+    #[test]
+    fn parses_fenced_and_embedded_json_replies() {
+        assert_eq!(parse_json_reply(" {\"a\":1} "), Some(json!({ "a": 1 })));
+        assert_eq!(
+            parse_json_reply("```json\n{\"a\":1}\n```"),
+            Some(json!({ "a": 1 }))
+        );
+        assert_eq!(
+            parse_json_reply("Here you go: [1, 2] hope it helps"),
+            Some(json!([1, 2]))
+        );
+        assert_eq!(parse_json_reply("no json here"), None);
+    }
+
+    #[test]
+    fn emulates_only_for_providers_without_native_json_schema() {
+        let request = ditto_core::provider_options::request_with_provider_options(
+            GenerateRequest::from(vec![Message::system("be brief"), Message::user("hi")]),
+            ditto_core::provider_options::ProviderOptions {
+                response_format: Some(ResponseFormat::JsonSchema {
+                    json_schema: JsonSchemaFormat {
+                        name: "answer".to_string(),
+                        schema: json!({ "type": "object" }),
+                        strict: Some(true),
+                    },
+                }),
+                ..Default::default()
+            },
+        )
+        .expect("provider options");
+
+        assert!(emulated_json_schema("openai", &request).is_none());
+        let schema = emulated_json_schema("anthropic", &request).expect("emulated");
+        assert_eq!(schema.name, "answer");
+
+        let mut request = request;
+        add_json_schema_instructions(&mut request, &schema);
+        assert_eq!(request.messages.len(), 3);
+        assert_eq!(request.messages[1].role, Role::System);
+        assert_eq!(request.messages[2].role, Role::User);
+    }
+}
@@ -23,6 +23,9 @@ pub struct ModelPricing {
     pub input_usd_micros_per_token_flex: Option<u64>,
     pub output_usd_micros_per_token_flex: Option<u64>,
     pub cache_read_input_usd_micros_per_token_flex: Option<u64>,
+    pub output_usd_micros_per_image: Option<u64>,
+    pub input_usd_micros_per_minute: Option<u64>,
+    pub input_usd_micros_per_query: Option<u64>,
 }
 
 #[derive(Debug, Error)]
@@ -31,7 +34,7 @@ pub enum PricingTableError {
     InvalidRoot,
     #[error("invalid pricing entry for model {model}: expected object")]
     InvalidModelEntry { model: String },
-    #[error("invalid pricing entry for model {model}: missing both input/output cost")]
+    #[error("invalid pricing entry for model {model}: no costs")]
     MissingCosts { model: String },
     #[error("invalid pricing entry for model {model}: invalid cost value for {field}")]
     InvalidCostValue { model: String, field: &'static str },
@@ -46,144 +49,36 @@ impl PricingTable {
     }
 
     pub fn from_litellm_json_value(value: &serde_json::Value) -> Result<Self, PricingTableError> {
+        Self::default().with_litellm_overrides_json_value(value)
+    }
+
+    pub fn with_litellm_overrides_json_str(self, raw: &str) -> Result<Self, PricingTableError> {
+        let value: serde_json::Value = serde_json::from_str(raw)?;
+        self.with_litellm_overrides_json_value(&value)
+    }
+
+    /// Applies negotiated prices on top of the table. Entries use the LiteLLM
+    /// field names; a field an entry leaves out keeps the price already loaded
+    /// for that model, and unknown models are added.
+    pub fn with_litellm_overrides_json_value(
+        mut self,
+        value: &serde_json::Value,
+    ) -> Result<Self, PricingTableError> {
         let Some(root) = value.as_object() else {
             return Err(PricingTableError::InvalidRoot);
         };
 
-        let mut models = HashMap::new();
         for (model, entry) in root {
             let Some(obj) = entry.as_object() else {
                 return Err(PricingTableError::InvalidModelEntry {
                     model: model.clone(),
                 });
             };
-
-            let input = parse_cost_usd_per_token(obj, "input_cost_per_token")
-                .or_else(|| parse_cost_usd_per_1k_tokens(obj, "input_cost_per_1k_tokens"))
-                .map(|usd| usd_to_usd_micros_per_token(usd, model, "input_cost"))
-                .transpose()?;
-
-            let output = parse_cost_usd_per_token(obj, "output_cost_per_token")
-                .or_else(|| parse_cost_usd_per_1k_tokens(obj, "output_cost_per_1k_tokens"))
-                .map(|usd| usd_to_usd_micros_per_token(usd, model, "output_cost"))
-                .transpose()?;
-
-            let cache_read_input = parse_cost_usd_per_token(obj, "cache_read_input_token_cost")
-                .map(|usd| usd_to_usd_micros_per_token(usd, model, "cache_read_input_cost"))
-                .transpose()?;
-
-            let cache_creation_input =
-                parse_cost_usd_per_token(obj, "cache_creation_input_token_cost")
-                    .map(|usd| usd_to_usd_micros_per_token(usd, model, "cache_creation_input_cost"))
-                    .transpose()?;
-
-            let input_priority = parse_cost_usd_per_token(obj, "input_cost_per_token_priority")
-                .or_else(|| parse_cost_usd_per_1k_tokens(obj, "input_cost_per_1k_tokens_priority"))
-                .map(|usd| usd_to_usd_micros_per_token(usd, model, "input_cost_priority"))
-                .transpose()?;
-            let output_priority = parse_cost_usd_per_token(obj, "output_cost_per_token_priority")
-                .or_else(|| parse_cost_usd_per_1k_tokens(obj, "output_cost_per_1k_tokens_priority"))
-                .map(|usd| usd_to_usd_micros_per_token(usd, model, "output_cost_priority"))
-                .transpose()?;
-            let cache_read_input_priority =
-                parse_cost_usd_per_token(obj, "cache_read_input_token_cost_priority")
-                    .map(|usd| {
-                        usd_to_usd_micros_per_token(usd, model, "cache_read_input_cost_priority")
-                    })
-                    .transpose()?;
-
-            let input_flex = parse_cost_usd_per_token(obj, "input_cost_per_token_flex")
-                .or_else(|| parse_cost_usd_per_1k_tokens(obj, "input_cost_per_1k_tokens_flex"))
-                .map(|usd| usd_to_usd_micros_per_token(usd, model, "input_cost_flex"))
-                .transpose()?;
-            let output_flex = parse_cost_usd_per_token(obj, "output_cost_per_token_flex")
-                .or_else(|| parse_cost_usd_per_1k_tokens(obj, "output_cost_per_1k_tokens_flex"))
-                .map(|usd| usd_to_usd_micros_per_token(usd, model, "output_cost_flex"))
-                .transpose()?;
-            let cache_read_input_flex =
-                parse_cost_usd_per_token(obj, "cache_read_input_token_cost_flex")
-                    .map(|usd| {
-                        usd_to_usd_micros_per_token(usd, model, "cache_read_input_cost_flex")
-                    })
-                    .transpose()?;
-
-            let input_tiers = parse_tiered_cost_usd_per_token(
-                obj,
-                "input_cost_per_token_above_",
-                model,
-                "input_cost_above",
-            )?;
-            let output_tiers = parse_tiered_cost_usd_per_token(
-                obj,
-                "output_cost_per_token_above_",
-                model,
-                "output_cost_above",
-            )?;
-            let cache_read_input_tiers = parse_tiered_cost_usd_per_token(
-                obj,
-                "cache_read_input_token_cost_above_",
-                model,
-                "cache_read_input_cost_above",
-            )?;
-            let cache_creation_input_tiers = parse_tiered_cost_usd_per_token(
-                obj,
-                "cache_creation_input_token_cost_above_",
-                model,
-                "cache_creation_input_cost_above",
-            )?;
-
-            let Some(input_usd_micros_per_token) = input else {
-                if output.is_some() {
-                    models.insert(
-                        model.clone(),
-                        ModelPricing {
-                            input_usd_micros_per_token: 0,
-                            input_usd_micros_per_token_tiers: input_tiers,
-                            output_usd_micros_per_token: output.unwrap_or(0),
-                            output_usd_micros_per_token_tiers: output_tiers,
-                            cache_read_input_usd_micros_per_token: cache_read_input,
-                            cache_read_input_usd_micros_per_token_tiers: cache_read_input_tiers,
-                            cache_creation_input_usd_micros_per_token: cache_creation_input,
-                            cache_creation_input_usd_micros_per_token_tiers:
-                                cache_creation_input_tiers,
-                            input_usd_micros_per_token_priority: input_priority,
-                            output_usd_micros_per_token_priority: output_priority,
-                            cache_read_input_usd_micros_per_token_priority:
-                                cache_read_input_priority,
-                            input_usd_micros_per_token_flex: input_flex,
-                            output_usd_micros_per_token_flex: output_flex,
-                            cache_read_input_usd_micros_per_token_flex: cache_read_input_flex,
-                        },
-                    );
-                    continue;
-                }
-                return Err(PricingTableError::MissingCosts {
-                    model: model.clone(),
-                });
-            };
-
-            models.insert(
-                model.clone(),
-                ModelPricing {
-                    input_usd_micros_per_token,
-                    input_usd_micros_per_token_tiers: input_tiers,
-                    output_usd_micros_per_token: output.unwrap_or(0),
-                    output_usd_micros_per_token_tiers: output_tiers,
-                    cache_read_input_usd_micros_per_token: cache_read_input,
-                    cache_read_input_usd_micros_per_token_tiers: cache_read_input_tiers,
-                    cache_creation_input_usd_micros_per_token: cache_creation_input,
-                    cache_creation_input_usd_micros_per_token_tiers: cache_creation_input_tiers,
-                    input_usd_micros_per_token_priority: input_priority,
-                    output_usd_micros_per_token_priority: output_priority,
-                    cache_read_input_usd_micros_per_token_priority: cache_read_input_priority,
-                    input_usd_micros_per_token_flex: input_flex,
-                    output_usd_micros_per_token_flex: output_flex,
-                    cache_read_input_usd_micros_per_token_flex: cache_read_input_flex,
-                },
-            );
+            let pricing = parse_model_pricing(model, obj, self.models.get(model))?;
+            self.models.insert(model.clone(), pricing);
         }
 
-        Ok(Self { models })
+        Ok(self)
     }
 
     pub fn model_pricing(&self, model: &str) -> Option<&ModelPricing> {
@@ -286,8 +181,20 @@ impl PricingTable {
                 )
             };
 
+        // `input_tokens` includes cache reads and writes. Each share is billed
+        // at its own rate when the model prices it, else at the input rate.
         let cached_tokens = cache_input_tokens.unwrap_or(0);
         let cached_tokens = std::cmp::min(cached_tokens, input_tokens);
+        let prices_cache_creation = pricing.cache_creation_input_usd_micros_per_token.is_some()
+            || !pricing
+                .cache_creation_input_usd_micros_per_token_tiers
+                .is_empty();
+        let cache_creation_tokens = if prices_cache_creation {
+            let tokens = cache_creation_input_tokens.unwrap_or(0);
+            std::cmp::min(tokens, input_tokens - cached_tokens)
+        } else {
+            0
+        };
 
         let input_usd_micros_per_token = select_tiered_usd_micros_per_token(
             input_base_usd_micros_per_token,
@@ -301,31 +208,21 @@ impl PricingTable {
             input_tokens,
         );
 
-        let input_cost = if cached_tokens > 0 {
+        let uncached_tokens = input_tokens - cached_tokens - cache_creation_tokens;
+        let mut total = u64::from(uncached_tokens).saturating_mul(input_usd_micros_per_token);
+
+        if cached_tokens > 0 {
             let cache_read_input_usd_micros_per_token = select_tiered_usd_micros_per_token(
                 cache_read_base.unwrap_or(input_usd_micros_per_token),
                 &pricing.cache_read_input_usd_micros_per_token_tiers,
                 input_tokens,
             );
+            total = total.saturating_add(
+                u64::from(cached_tokens).saturating_mul(cache_read_input_usd_micros_per_token),
+            );
+        }
 
-            let non_cached_tokens = input_tokens.saturating_sub(cached_tokens);
-            let non_cached =
-                u64::from(non_cached_tokens).saturating_mul(input_usd_micros_per_token);
-            let cached =
-                u64::from(cached_tokens).saturating_mul(cache_read_input_usd_micros_per_token);
-            non_cached.saturating_add(cached)
-        } else {
-            u64::from(input_tokens).saturating_mul(input_usd_micros_per_token)
-        };
-
-        let output_cost = u64::from(output_tokens).saturating_mul(output_usd_micros_per_token);
-        let mut total = input_cost.saturating_add(output_cost);
-
-        if pricing.cache_creation_input_usd_micros_per_token.is_some()
-            || !pricing
-                .cache_creation_input_usd_micros_per_token_tiers
-                .is_empty()
-        {
+        if cache_creation_tokens > 0 {
             let cache_creation_input_usd_micros_per_token = select_tiered_usd_micros_per_token(
                 pricing
                     .cache_creation_input_usd_micros_per_token
@@ -333,15 +230,231 @@ impl PricingTable {
                 &pricing.cache_creation_input_usd_micros_per_token_tiers,
                 input_tokens,
             );
-            let cache_creation_tokens = cache_creation_input_tokens.unwrap_or(0);
-            let cache_creation_tokens = std::cmp::min(cache_creation_tokens, input_tokens);
             total = total.saturating_add(
                 u64::from(cache_creation_tokens)
                     .saturating_mul(cache_creation_input_usd_micros_per_token),
             );
         }
+
+        total = total
+            .saturating_add(u64::from(output_tokens).saturating_mul(output_usd_micros_per_token));
         Some(total)
     }
+
+    pub fn estimate_image_cost_usd_micros(&self, model: &str, images: u32) -> Option<u64> {
+        let usd_micros_per_image = self.model_pricing(model)?.output_usd_micros_per_image?;
+        Some(u64::from(images).saturating_mul(usd_micros_per_image))
+    }
+
+    pub fn estimate_audio_cost_usd_micros(&self, model: &str, seconds: f64) -> Option<u64> {
+        let usd_micros_per_minute = self.model_pricing(model)?.input_usd_micros_per_minute?;
+        if !seconds.is_finite() || seconds < 0.0 {
+            return None;
+        }
+        let micros = (seconds * usd_micros_per_minute as f64 / 60.0).ceil();
+        Some(if micros >= u64::MAX as f64 {
+            u64::MAX
+        } else {
+            micros as u64
+        })
+    }
+
+    /// Cost of a rerank request billed per query (Cohere's search units).
+    pub fn estimate_rerank_cost_usd_micros(&self, model: &str, queries: u32) -> Option<u64> {
+        let usd_micros_per_query = self.model_pricing(model)?.input_usd_micros_per_query?;
+        Some(u64::from(queries).saturating_mul(usd_micros_per_query))
+    }
+}
+
+fn parse_model_pricing(
+    model: &str,
+    obj: &serde_json::Map<String, serde_json::Value>,
+    base: Option<&ModelPricing>,
+) -> Result<ModelPricing, PricingTableError> {
+    let input = parse_rate(
+        obj,
+        model,
+        "input_cost_per_token",
+        Some("input_cost_per_1k_tokens"),
+        "input_cost",
+    )?;
+    let output = parse_rate(
+        obj,
+        model,
+        "output_cost_per_token",
+        Some("output_cost_per_1k_tokens"),
+        "output_cost",
+    )?;
+    let cache_read_input = parse_rate(
+        obj,
+        model,
+        "cache_read_input_token_cost",
+        None,
+        "cache_read_input_cost",
+    )?;
+    let cache_creation_input = parse_rate(
+        obj,
+        model,
+        "cache_creation_input_token_cost",
+        None,
+        "cache_creation_input_cost",
+    )?;
+
+    let input_priority = parse_rate(
+        obj,
+        model,
+        "input_cost_per_token_priority",
+        Some("input_cost_per_1k_tokens_priority"),
+        "input_cost_priority",
+    )?;
+    let output_priority = parse_rate(
+        obj,
+        model,
+        "output_cost_per_token_priority",
+        Some("output_cost_per_1k_tokens_priority"),
+        "output_cost_priority",
+    )?;
+    let cache_read_input_priority = parse_rate(
+        obj,
+        model,
+        "cache_read_input_token_cost_priority",
+        None,
+        "cache_read_input_cost_priority",
+    )?;
+
+    let input_flex = parse_rate(
+        obj,
+        model,
+        "input_cost_per_token_flex",
+        Some("input_cost_per_1k_tokens_flex"),
+        "input_cost_flex",
+    )?;
+    let output_flex = parse_rate(
+        obj,
+        model,
+        "output_cost_per_token_flex",
+        Some("output_cost_per_1k_tokens_flex"),
+        "output_cost_flex",
+    )?;
+    let cache_read_input_flex = parse_rate(
+        obj,
+        model,
+        "cache_read_input_token_cost_flex",
+        None,
+        "cache_read_input_cost_flex",
+    )?;
+
+    let output_per_image = parse_rate(obj, model, "output_cost_per_image", None, "image_cost")?;
+    let input_per_minute = parse_cost_usd_per_token(obj, "input_cost_per_minute")
+        .or_else(|| parse_cost_usd_per_token(obj, "input_cost_per_second").map(|usd| usd * 60.0))
+        .map(|usd| usd_to_usd_micros_per_token(usd, model, "audio_cost"))
+        .transpose()?;
+    let input_per_query = parse_rate(obj, model, "input_cost_per_query", None, "query_cost")?;
+
+    let input_tiers = parse_tiered_cost_usd_per_token(
+        obj,
+        "input_cost_per_token_above_",
+        model,
+        "input_cost_above",
+    )?;
+    let output_tiers = parse_tiered_cost_usd_per_token(
+        obj,
+        "output_cost_per_token_above_",
+        model,
+        "output_cost_above",
+    )?;
+    let cache_read_input_tiers = parse_tiered_cost_usd_per_token(
+        obj,
+        "cache_read_input_token_cost_above_",
+        model,
+        "cache_read_input_cost_above",
+    )?;
+    let cache_creation_input_tiers = parse_tiered_cost_usd_per_token(
+        obj,
+        "cache_creation_input_token_cost_above_",
+        model,
+        "cache_creation_input_cost_above",
+    )?;
+
+    if base.is_none()
+        && input.is_none()
+        && output.is_none()
+        && output_per_image.is_none()
+        && input_per_minute.is_none()
+        && input_per_query.is_none()
+    {
+        return Err(PricingTableError::MissingCosts {
+            model: model.to_string(),
+        });
+    }
+
+    Ok(ModelPricing {
+        input_usd_micros_per_token: input
+            .or(base.map(|base| base.input_usd_micros_per_token))
+            .unwrap_or(0),
+        input_usd_micros_per_token_tiers: inherit_tiers(
+            input_tiers,
+            base.map(|base| &base.input_usd_micros_per_token_tiers),
+        ),
+        output_usd_micros_per_token: output
+            .or(base.map(|base| base.output_usd_micros_per_token))
+            .unwrap_or(0),
+        output_usd_micros_per_token_tiers: inherit_tiers(
+            output_tiers,
+            base.map(|base| &base.output_usd_micros_per_token_tiers),
+        ),
+        cache_read_input_usd_micros_per_token: cache_read_input
+            .or(base.and_then(|base| base.cache_read_input_usd_micros_per_token)),
+        cache_read_input_usd_micros_per_token_tiers: inherit_tiers(
+            cache_read_input_tiers,
+            base.map(|base| &base.cache_read_input_usd_micros_per_token_tiers),
+        ),
+        cache_creation_input_usd_micros_per_token: cache_creation_input
+            .or(base.and_then(|base| base.cache_creation_input_usd_micros_per_token)),
+        cache_creation_input_usd_micros_per_token_tiers: inherit_tiers(
+            cache_creation_input_tiers,
+            base.map(|base| &base.cache_creation_input_usd_micros_per_token_tiers),
+        ),
+        input_usd_micros_per_token_priority: input_priority
+            .or(base.and_then(|base| base.input_usd_micros_per_token_priority)),
+        output_usd_micros_per_token_priority: output_priority
+            .or(base.and_then(|base| base.output_usd_micros_per_token_priority)),
+        cache_read_input_usd_micros_per_token_priority: cache_read_input_priority
+            .or(base.and_then(|base| base.cache_read_input_usd_micros_per_token_priority)),
+        input_usd_micros_per_token_flex: input_flex
+            .or(base.and_then(|base| base.input_usd_micros_per_token_flex)),
+        output_usd_micros_per_token_flex: output_flex
+            .or(base.and_then(|base| base.output_usd_micros_per_token_flex)),
+        cache_read_input_usd_micros_per_token_flex: cache_read_input_flex
+            .or(base.and_then(|base| base.cache_read_input_usd_micros_per_token_flex)),
+        output_usd_micros_per_image: output_per_image
+            .or(base.and_then(|base| base.output_usd_micros_per_image)),
+        input_usd_micros_per_minute: input_per_minute
+            .or(base.and_then(|base| base.input_usd_micros_per_minute)),
+        input_usd_micros_per_query: input_per_query
+            .or(base.and_then(|base| base.input_usd_micros_per_query)),
+    })
+}
+
+fn inherit_tiers(tiers: Vec<(u32, u64)>, base: Option<&Vec<(u32, u64)>>) -> Vec<(u32, u64)> {
+    if tiers.is_empty() {
+        base.cloned().unwrap_or_default()
+    } else {
+        tiers
+    }
+}
+
+fn parse_rate(
+    obj: &serde_json::Map<String, serde_json::Value>,
+    model: &str,
+    per_token_key: &'static str,
+    per_1k_tokens_key: Option<&'static str>,
+    field: &'static str,
+) -> Result<Option<u64>, PricingTableError> {
+    parse_cost_usd_per_token(obj, per_token_key)
+        .or_else(|| per_1k_tokens_key.and_then(|key| parse_cost_usd_per_1k_tokens(obj, key)))
+        .map(|usd| usd_to_usd_micros_per_token(usd, model, field))
+        .transpose()
 }
 
 fn select_tiered_usd_micros_per_token(base: u64, tiers: &[(u32, u64)], input_tokens: u32) -> u64 {
@@ -515,7 +628,7 @@ mod tests {
                 1,
             )
             .expect("cost cached");
-        assert_eq!(cost_cached, 12 + 4 + 4 + 6);
+        assert_eq!(cost_cached, 8 + 4 + 4 + 6);
     }
 
     #[test]
@@ -537,7 +650,7 @@ mod tests {
         let cost = table
             .estimate_cost_usd_micros_with_cache("tiered-model", 6, Some(2), Some(1), 1)
             .expect("cost");
-        assert_eq!(cost, 41);
+        assert_eq!(cost, 36);
     }
 
     #[test]
@@ -576,4 +689,61 @@ mod tests {
             .expect("priority cached cost");
         assert_eq!(cost_priority_cached, 26);
     }
+
+    #[test]
+    fn applies_negotiated_overrides_and_media_rates() {
+        let raw = r#"{
+          "gpt-4o": {"input_cost_per_token": 0.000005, "output_cost_per_token": 0.000015, "cache_read_input_token_cost": 0.000002},
+          "dall-e-3": {"output_cost_per_image": 0.04},
+          "whisper-1": {"input_cost_per_second": 0.0001},
+          "rerank-v3.5": {"input_cost_per_query": 0.002}
+        }"#;
+        let overrides = r#"{
+          "gpt-4o": {"input_cost_per_token": 0.000004},
+          "whisper-1": {"input_cost_per_minute": 0.003},
+          "internal-llm": {"output_cost_per_1k_tokens": 1.0}
+        }"#;
+
+        let table = PricingTable::from_litellm_json_str(raw).expect("pricing");
+        assert_eq!(
+            table.estimate_cost_usd_micros("gpt-4o", 10, 2),
+            Some(50 + 30)
+        );
+        assert_eq!(
+            table.estimate_audio_cost_usd_micros("whisper-1", 90.5),
+            Some(9050)
+        );
+
+        let table = table
+            .with_litellm_overrides_json_str(overrides)
+            .expect("overrides");
+        let gpt = table.model_pricing("gpt-4o").expect("gpt-4o");
+        assert_eq!(gpt.input_usd_micros_per_token, 4);
+        assert_eq!(gpt.output_usd_micros_per_token, 15);
+        assert_eq!(gpt.cache_read_input_usd_micros_per_token, Some(2));
+        assert_eq!(
+            table.estimate_cost_usd_micros("internal-llm", 10, 3),
+            Some(3000)
+        );
+
+        assert_eq!(
+            table.estimate_image_cost_usd_micros("dall-e-3", 2),
+            Some(80_000)
+        );
+        assert_eq!(table.estimate_image_cost_usd_micros("gpt-4o", 1), None);
+        assert_eq!(
+            table.estimate_rerank_cost_usd_micros("rerank-v3.5", 3),
+            Some(6_000)
+        );
+        assert_eq!(table.estimate_rerank_cost_usd_micros("gpt-4o", 1), None);
+        assert_eq!(
+            table.estimate_audio_cost_usd_micros("whisper-1", 90.5),
+            Some(4525)
+        );
+
+        assert!(matches!(
+            PricingTable::from_litellm_json_str(r#"{"empty": {}}"#),
+            Err(PricingTableError::MissingCosts { .. })
+        ));
+    }
 }
@@ -1,14 +1,148 @@
+use std::borrow::Cow;
 use std::collections::{HashMap, HashSet};
 
 use serde::{Deserialize, Serialize};
 
 use super::GatewayError;
+use super::budget_reset::{BudgetResetConfig, budget_ledger_base_scope, window_ledger_scope};
 
 #[derive(Clone, Debug, Default, Serialize, Deserialize)]
 pub struct BudgetConfig {
     pub total_tokens: Option<u64>,
     #[serde(default)]
     pub total_usd_micros: Option<u64>,
+    /// Reset the budget on a schedule instead of counting spend for the
+    /// scope's whole lifetime.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub reset: Option<BudgetResetConfig>,
+}
+
+impl BudgetConfig {
+    pub fn validate(&self, field: &str) -> Result<(), GatewayError> {
+        match self.reset.as_ref() {
+            Some(reset) => reset.validate(&format!("{field}.reset")),
+            None => Ok(()),
+        }
+    }
+
+    /// The ledger `scope` is charged to at `now_epoch_seconds`: `scope`
+    /// itself for lifetime budgets, or its current window's ledger.
+    pub fn ledger_scope<'a>(&self, scope: &'a str, now_epoch_seconds: u64) -> Cow<'a, str> {
+        match self.reset.as_ref() {
+            Some(reset) => Cow::Owned(window_ledger_scope(
+                scope,
+                reset.window_start(now_epoch_seconds),
+            )),
+            None => Cow::Borrowed(scope),
+        }
+    }
+
+    /// The previous window's ledger, when its unused budget rolls over.
+    pub fn rollover_ledger_scope(&self, scope: &str, now_epoch_seconds: u64) -> Option<String> {
+        let reset = self.reset.as_ref().filter(|reset| reset.rollover)?;
+        let previous = reset.previous_window_start(reset.window_start(now_epoch_seconds))?;
+        Some(window_ledger_scope(scope, previous))
+    }
+
+    /// `total_tokens` plus what the previous window left unused. Nothing
+    /// rolls over from a window without a ledger.
+    pub fn token_limit_with_rollover(&self, previous_spent_tokens: Option<u64>) -> Option<u64> {
+        let limit = self.total_tokens?;
+        let max_rollover = self
+            .reset
+            .as_ref()
+            .and_then(|reset| reset.max_rollover_tokens);
+        Some(limit.saturating_add(rollover(limit, previous_spent_tokens, max_rollover)))
+    }
+
+    /// `total_usd_micros` plus what the previous window left unused.
+    pub fn cost_limit_with_rollover(&self, previous_spent_usd_micros: Option<u64>) -> Option<u64> {
+        let limit = self.total_usd_micros?;
+        let max_rollover = self
+            .reset
+            .as_ref()
+            .and_then(|reset| reset.max_rollover_usd_micros);
+        Some(limit.saturating_add(rollover(limit, previous_spent_usd_micros, max_rollover)))
+    }
+}
+
+/// A scope's budget in its current window (rollover included) and what that
+/// window has spent so far.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Serialize)]
+pub struct BudgetUsage {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub total_tokens: Option<u64>,
+    pub spent_tokens: u64,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub remaining_tokens: Option<u64>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub total_usd_micros: Option<u64>,
+    pub spent_usd_micros: u64,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub remaining_usd_micros: Option<u64>,
+    /// Start of the current window, for budgets with a reset schedule.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub window_start_epoch_seconds: Option<u64>,
+}
+
+impl BudgetUsage {
+    /// Usage of `budget` at `now_epoch_seconds`, given the current and (for
+    /// rollover) previous windows' spend.
+    pub fn new(
+        budget: &BudgetConfig,
+        now_epoch_seconds: u64,
+        spent_tokens: u64,
+        spent_usd_micros: u64,
+        previous_spent_tokens: Option<u64>,
+        previous_spent_usd_micros: Option<u64>,
+    ) -> Self {
+        let total_tokens = budget.token_limit_with_rollover(previous_spent_tokens);
+        let total_usd_micros = budget.cost_limit_with_rollover(previous_spent_usd_micros);
+        Self {
+            total_tokens,
+            spent_tokens,
+            remaining_tokens: total_tokens.map(|total| total.saturating_sub(spent_tokens)),
+            total_usd_micros,
+            spent_usd_micros,
+            remaining_usd_micros: total_usd_micros
+                .map(|total| total.saturating_sub(spent_usd_micros)),
+            window_start_epoch_seconds: budget
+                .reset
+                .as_ref()
+                .map(|reset| reset.window_start(now_epoch_seconds)),
+        }
+    }
+}
+
+fn rollover(limit: u64, previous_spent: Option<u64>, max_rollover: Option<u64>) -> u64 {
+    let unused = previous_spent.map_or(0, |spent| limit.saturating_sub(spent));
+    max_rollover.map_or(unused, |max| unused.min(max))
+}
+
+fn now_epoch_seconds() -> u64 {
+    std::time::SystemTime::now()
+        .duration_since(std::time::UNIX_EPOCH)
+        .map(|duration| duration.as_secs())
+        .unwrap_or(0)
+}
+
+/// Drops a windowed scope's older windows when `ledger` opens a new one,
+/// keeping the latest as the rollover source.
+fn prune_windows(spent: &mut HashMap<String, u64>, ledger: &str) {
+    let base = budget_ledger_base_scope(ledger);
+    if base.len() == ledger.len() || spent.contains_key(ledger) {
+        return;
+    }
+    let latest = spent
+        .keys()
+        .filter(|scope| scope.len() > base.len() && budget_ledger_base_scope(scope) == base)
+        .max()
+        .cloned();
+    spent.retain(|scope, _| {
+        scope.len() == base.len()
+            || budget_ledger_base_scope(scope) != base
+            || latest.as_deref() == Some(scope.as_str())
+    });
 }
 
 #[derive(Clone, Debug, Default)]
@@ -20,10 +154,10 @@ pub struct BudgetTracker {
 impl BudgetTracker {
     fn validate_token_reservation(
         current: u64,
-        budget: &BudgetConfig,
+        limit: Option<u64>,
         tokens: u64,
     ) -> Result<Option<u64>, GatewayError> {
-        let Some(limit) = budget.total_tokens else {
+        let Some(limit) = limit else {
             return Ok(None);
         };
         let attempted = current.saturating_add(tokens);
@@ -35,10 +169,10 @@ impl BudgetTracker {
 
     fn validate_cost_reservation(
         current: u64,
-        budget: &BudgetConfig,
+        limit_usd_micros: Option<u64>,
         usd_micros: u64,
     ) -> Result<Option<u64>, GatewayError> {
-        let Some(limit_usd_micros) = budget.total_usd_micros else {
+        let Some(limit_usd_micros) = limit_usd_micros else {
             return Ok(None);
         };
         let attempted = current.saturating_add(usd_micros);
@@ -51,14 +185,30 @@ impl BudgetTracker {
         Ok(Some(attempted))
     }
 
+    fn token_limit(&self, scope: &str, budget: &BudgetConfig, now: u64) -> Option<u64> {
+        let previous_spent = budget
+            .rollover_ledger_scope(scope, now)
+            .and_then(|previous| self.spent_tokens.get(&previous).copied());
+        budget.token_limit_with_rollover(previous_spent)
+    }
+
+    fn cost_limit(&self, scope: &str, budget: &BudgetConfig, now: u64) -> Option<u64> {
+        let previous_spent = budget
+            .rollover_ledger_scope(scope, now)
+            .and_then(|previous| self.spent_usd_micros.get(&previous).copied());
+        budget.cost_limit_with_rollover(previous_spent)
+    }
+
     pub fn can_spend(
         &self,
         key_id: &str,
         budget: &BudgetConfig,
         tokens: u64,
     ) -> Result<(), GatewayError> {
-        let spent = self.spent_tokens.get(key_id).copied().unwrap_or(0);
-        Self::validate_token_reservation(spent, budget, tokens)?;
+        let now = now_epoch_seconds();
+        let ledger = budget.ledger_scope(key_id, now);
+        let spent = self.spent_tokens.get(ledger.as_ref()).copied().unwrap_or(0);
+        Self::validate_token_reservation(spent, self.token_limit(key_id, budget, now), tokens)?;
         Ok(())
     }
 
@@ -66,23 +216,27 @@ impl BudgetTracker {
     where
         I: IntoIterator<Item = (&'a str, &'a BudgetConfig)>,
     {
+        let now = now_epoch_seconds();
         let mut proposed = HashMap::<String, u64>::new();
 
         for (scope, budget) in scopes {
+            let ledger = budget.ledger_scope(scope, now);
             let current = proposed
-                .get(scope)
+                .get(ledger.as_ref())
                 .copied()
-                .unwrap_or_else(|| self.spent_tokens.get(scope).copied().unwrap_or(0));
-            let Some(next) = Self::validate_token_reservation(current, budget, tokens)? else {
+                .unwrap_or_else(|| self.spent_tokens.get(ledger.as_ref()).copied().unwrap_or(0));
+            let limit = self.token_limit(scope, budget, now);
+            let Some(next) = Self::validate_token_reservation(current, limit, tokens)? else {
                 continue;
             };
-            proposed.insert(scope.to_string(), next);
+            proposed.insert(ledger.into_owned(), next);
         }
 
         for (scope, next) in proposed {
             if next == 0 {
                 self.spent_tokens.remove(&scope);
             } else {
+                prune_windows(&mut self.spent_tokens, &scope);
                 self.spent_tokens.insert(scope, next);
             }
         }
@@ -93,23 +247,26 @@ impl BudgetTracker {
         if budget.total_tokens.is_none() || tokens == 0 {
             return;
         }
-        if let Some(entry) = self.spent_tokens.get_mut(key_id) {
+        let ledger = budget.ledger_scope(key_id, now_epoch_seconds());
+        if let Some(entry) = self.spent_tokens.get_mut(ledger.as_ref()) {
             *entry = entry.saturating_add(tokens);
             return;
         }
-        self.spent_tokens.insert(key_id.to_string(), tokens);
+        prune_windows(&mut self.spent_tokens, &ledger);
+        self.spent_tokens.insert(ledger.into_owned(), tokens);
     }
 
     pub fn refund(&mut self, key_id: &str, budget: &BudgetConfig, tokens: u64) {
         if budget.total_tokens.is_none() || tokens == 0 {
             return;
         }
-        let Some(entry) = self.spent_tokens.get_mut(key_id) else {
+        let ledger = budget.ledger_scope(key_id, now_epoch_seconds());
+        let Some(entry) = self.spent_tokens.get_mut(ledger.as_ref()) else {
             return;
         };
         *entry = entry.saturating_sub(tokens);
         if *entry == 0 {
-            self.spent_tokens.remove(key_id);
+            self.spent_tokens.remove(ledger.as_ref());
         }
     }
 
@@ -149,8 +306,14 @@ impl BudgetTracker {
         budget: &BudgetConfig,
         usd_micros: u64,
     ) -> Result<(), GatewayError> {
-        let spent = self.spent_usd_micros.get(key_id).copied().unwrap_or(0);
-        Self::validate_cost_reservation(spent, budget, usd_micros)?;
+        let now = now_epoch_seconds();
+        let ledger = budget.ledger_scope(key_id, now);
+        let spent = self
+            .spent_usd_micros
+            .get(ledger.as_ref())
+            .copied()
+            .unwrap_or(0);
+        Self::validate_cost_reservation(spent, self.cost_limit(key_id, budget, now), usd_micros)?;
         Ok(())
     }
 
@@ -162,23 +325,29 @@ impl BudgetTracker {
     where
         I: IntoIterator<Item = (&'a str, &'a BudgetConfig)>,
     {
+        let now = now_epoch_seconds();
         let mut proposed = HashMap::<String, u64>::new();
 
         for (scope, budget) in scopes {
-            let current = proposed
-                .get(scope)
-                .copied()
-                .unwrap_or_else(|| self.spent_usd_micros.get(scope).copied().unwrap_or(0));
-            let Some(next) = Self::validate_cost_reservation(current, budget, usd_micros)? else {
+            let ledger = budget.ledger_scope(scope, now);
+            let current = proposed.get(ledger.as_ref()).copied().unwrap_or_else(|| {
+                self.spent_usd_micros
+                    .get(ledger.as_ref())
+                    .copied()
+                    .unwrap_or(0)
+            });
+            let limit = self.cost_limit(scope, budget, now);
+            let Some(next) = Self::validate_cost_reservation(current, limit, usd_micros)? else {
                 continue;
             };
-            proposed.insert(scope.to_string(), next);
+            proposed.insert(ledger.into_owned(), next);
         }
 
         for (scope, next) in proposed {
             if next == 0 {
                 self.spent_usd_micros.remove(&scope);
             } else {
+                prune_windows(&mut self.spent_usd_micros, &scope);
                 self.spent_usd_micros.insert(scope, next);
             }
         }
@@ -189,23 +358,27 @@ impl BudgetTracker {
         if budget.total_usd_micros.is_none() || usd_micros == 0 {
             return;
         }
-        if let Some(entry) = self.spent_usd_micros.get_mut(key_id) {
+        let ledger = budget.ledger_scope(key_id, now_epoch_seconds());
+        if let Some(entry) = self.spent_usd_micros.get_mut(ledger.as_ref()) {
             *entry = entry.saturating_add(usd_micros);
             return;
         }
-        self.spent_usd_micros.insert(key_id.to_string(), usd_micros);
+        prune_windows(&mut self.spent_usd_micros, &ledger);
+        self.spent_usd_micros
+            .insert(ledger.into_owned(), usd_micros);
     }
 
     pub fn refund_cost_usd_micros(&mut self, key_id: &str, budget: &BudgetConfig, usd_micros: u64) {
         if budget.total_usd_micros.is_none() || usd_micros == 0 {
             return;
         }
-        let Some(entry) = self.spent_usd_micros.get_mut(key_id) else {
+        let ledger = budget.ledger_scope(key_id, now_epoch_seconds());
+        let Some(entry) = self.spent_usd_micros.get_mut(ledger.as_ref()) else {
             return;
         };
         *entry = entry.saturating_sub(usd_micros);
         if *entry == 0 {
-            self.spent_usd_micros.remove(key_id);
+            self.spent_usd_micros.remove(ledger.as_ref());
         }
     }
 
@@ -247,10 +420,29 @@ impl BudgetTracker {
         }
     }
 
+    /// `scope`'s usage of `budget` in the current window.
+    pub fn usage(&self, scope: &str, budget: &BudgetConfig) -> BudgetUsage {
+        let now = now_epoch_seconds();
+        let ledger = budget.ledger_scope(scope, now);
+        let previous = budget.rollover_ledger_scope(scope, now);
+        let spent = |ledgers: &HashMap<String, u64>, ledger: Option<&str>| {
+            ledger.and_then(|ledger| ledgers.get(ledger).copied())
+        };
+        BudgetUsage::new(
+            budget,
+            now,
+            spent(&self.spent_tokens, Some(ledger.as_ref())).unwrap_or(0),
+            spent(&self.spent_usd_micros, Some(ledger.as_ref())).unwrap_or(0),
+            spent(&self.spent_tokens, previous.as_deref()),
+            spent(&self.spent_usd_micros, previous.as_deref()),
+        )
+    }
+
     pub fn retain_scopes(&mut self, scopes: &HashSet<String>) {
-        self.spent_tokens.retain(|scope, _| scopes.contains(scope));
+        self.spent_tokens
+            .retain(|scope, _| scopes.contains(budget_ledger_base_scope(scope)));
         self.spent_usd_micros
-            .retain(|scope, _| scopes.contains(scope));
+            .retain(|scope, _| scopes.contains(budget_ledger_base_scope(scope)));
     }
 }
 
@@ -258,12 +450,15 @@ impl BudgetTracker {
 mod tests {
     use super::*;
 
+    use crate::gateway::domain::budget_reset::BudgetPeriod;
+
     #[test]
     fn zero_spend_does_not_create_tracking_entries() {
         let mut tracker = BudgetTracker::default();
         let budget = BudgetConfig {
             total_tokens: Some(100),
             total_usd_micros: Some(1_000),
+            reset: None,
         };
 
         tracker.spend("vk_1", &budget, 0);
@@ -279,6 +474,7 @@ mod tests {
         let budget = BudgetConfig {
             total_tokens: Some(100),
             total_usd_micros: Some(1_000),
+            reset: None,
         };
 
         tracker.spend("vk_1", &budget, 10);
@@ -290,16 +486,40 @@ mod tests {
         assert_eq!(tracker.spent_usd_micros.get("vk_1"), Some(&15));
     }
 
+    #[test]
+    fn usage_reports_spend_and_remaining() {
+        let mut tracker = BudgetTracker::default();
+        let budget = BudgetConfig {
+            total_tokens: Some(100),
+            total_usd_micros: None,
+            reset: None,
+        };
+
+        tracker.spend("vk_1", &budget, 40);
+        let usage = tracker.usage("vk_1", &budget);
+        assert_eq!(usage.total_tokens, Some(100));
+        assert_eq!(usage.spent_tokens, 40);
+        assert_eq!(usage.remaining_tokens, Some(60));
+        assert_eq!(usage.total_usd_micros, None);
+        assert_eq!(usage.remaining_usd_micros, None);
+        assert_eq!(usage.window_start_epoch_seconds, None);
+
+        tracker.spend("vk_1", &budget, 80);
+        assert_eq!(tracker.usage("vk_1", &budget).remaining_tokens, Some(0));
+    }
+
     #[test]
     fn reserve_many_is_atomic_across_scopes() {
         let mut tracker = BudgetTracker::default();
         let wide = BudgetConfig {
             total_tokens: Some(100),
             total_usd_micros: None,
+            reset: None,
         };
         let tight = BudgetConfig {
             total_tokens: Some(5),
             total_usd_micros: None,
+            reset: None,
         };
 
         tracker
@@ -317,10 +537,12 @@ mod tests {
         let wide = BudgetConfig {
             total_tokens: None,
             total_usd_micros: Some(1_000),
+            reset: None,
         };
         let tight = BudgetConfig {
             total_tokens: None,
             total_usd_micros: Some(200),
+            reset: None,
         };
 
         tracker
@@ -331,4 +553,52 @@ mod tests {
         assert_eq!(tracker.spent_usd_micros.get("key"), Some(&150));
         assert_eq!(tracker.spent_usd_micros.get("tenant:t1"), Some(&150));
     }
+
+    #[test]
+    fn windowed_budgets_charge_the_current_window_and_roll_over() {
+        let mut tracker = BudgetTracker::default();
+        let budget = BudgetConfig {
+            total_tokens: Some(100),
+            total_usd_micros: None,
+            reset: Some(BudgetResetConfig {
+                period: BudgetPeriod::Daily,
+                cron: None,
+                timezone: None,
+                rollover: true,
+                max_rollover_tokens: Some(30),
+                max_rollover_usd_micros: None,
+            }),
+        };
+        let now = now_epoch_seconds();
+        let current = budget.ledger_scope("vk_1", now).into_owned();
+        let previous = budget.rollover_ledger_scope("vk_1", now).unwrap();
+        // A lifetime ledger from before the reset was configured, a stale
+        // window and yesterday's window.
+        tracker.spent_tokens.insert("vk_1".to_string(), 500);
+        tracker
+            .spent_tokens
+            .insert(window_ledger_scope("vk_1", 0), 7);
+        tracker.spent_tokens.insert(previous.clone(), 40);
+
+        // Yesterday left 60 unused, capped at 30.
+        tracker.reserve_many([("vk_1", &budget)], 130).unwrap();
+        let err = tracker.reserve_many([("vk_1", &budget)], 1);
+        assert!(matches!(
+            err,
+            Err(GatewayError::BudgetExceeded { limit: 130, .. })
+        ));
+        assert_eq!(tracker.spent_tokens.get(&current), Some(&130));
+        assert_eq!(tracker.spent_tokens.get(&previous), Some(&40));
+        assert_eq!(tracker.spent_tokens.get("vk_1"), Some(&500));
+        assert!(
+            !tracker
+                .spent_tokens
+                .contains_key(&window_ledger_scope("vk_1", 0))
+        );
+
+        tracker.retain_scopes(&HashSet::from(["vk_1".to_string()]));
+        assert_eq!(tracker.spent_tokens.len(), 3);
+        tracker.retain_scopes(&HashSet::new());
+        assert!(tracker.spent_tokens.is_empty());
+    }
 }
@@ -0,0 +1,363 @@
+//! Reset schedules for windowed budgets. A budget with a `reset` charges each
+//! window to its own ledger scope, so earlier windows are never touched and
+//! persistent stores need no reset job.
+
+use serde::{Deserialize, Serialize};
+
+use super::GatewayError;
+use super::spend_report::{SpendBucket, civil_from_days};
+
+const DAY_SECONDS: u64 = 86_400;
+const WINDOW_SCOPE_SEPARATOR: &str = "::window::";
+// Long enough to find a match for schedules like `0 0 29 2 *`.
+const CRON_LOOKBACK_DAYS: u64 = 8 * 366;
+
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum BudgetPeriod {
+    Daily,
+    /// Weeks start on Monday.
+    Weekly,
+    Monthly,
+    /// Windows start at each match of `cron`.
+    Cron,
+}
+
+#[derive(Clone, Debug, PartialEq, Eq, Serialize, Deserialize)]
+pub struct BudgetResetConfig {
+    pub period: BudgetPeriod,
+    /// Five-field cron expression (`minute hour day-of-month month
+    /// day-of-week`), evaluated in `timezone`. Required for `period: cron`.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub cron: Option<String>,
+    /// `UTC` (the default) or a fixed offset such as `+08:00` or `-05:30`.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub timezone: Option<String>,
+    /// Carry the unused part of the previous window's limit into the next
+    /// window. Rollover does not compound: at most one window's limit is
+    /// carried.
+    #[serde(default)]
+    pub rollover: bool,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub max_rollover_tokens: Option<u64>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub max_rollover_usd_micros: Option<u64>,
+}
+
+impl BudgetResetConfig {
+    pub fn validate(&self, field: &str) -> Result<(), GatewayError> {
+        let invalid = |reason: String| GatewayError::InvalidRequest { reason };
+        if let Some(timezone) = self.timezone.as_deref()
+            && parse_utc_offset_seconds(timezone).is_none()
+        {
+            return Err(invalid(format!(
+                "{field}.timezone must be `UTC` or a fixed offset like `+08:00`, got `{timezone}`"
+            )));
+        }
+        match (self.period, self.cron.as_deref()) {
+            (BudgetPeriod::Cron, None) => Err(invalid(format!(
+                "{field}.cron is required when period is `cron`"
+            ))),
+            (BudgetPeriod::Cron, Some(expr)) => {
+                let schedule = CronSchedule::parse(expr).ok_or_else(|| {
+                    invalid(format!(
+                        "{field}.cron is not a valid cron expression: `{expr}`"
+                    ))
+                })?;
+                // 2100-01-01T00:00:00Z: any schedule that can ever match has
+                // matched within the lookback before it.
+                if schedule.latest_at_or_before(4_102_444_800).is_none() {
+                    return Err(invalid(format!("{field}.cron never matches: `{expr}`")));
+                }
+                Ok(())
+            }
+            (_, Some(_)) => Err(invalid(format!(
+                "{field}.cron is only valid when period is `cron`"
+            ))),
+            (_, None) => Ok(()),
+        }
+    }
+
+    /// Start of the window containing `now_epoch_seconds`, in epoch seconds.
+    pub fn window_start(&self, now_epoch_seconds: u64) -> u64 {
+        let offset = self
+            .timezone
+            .as_deref()
+            .and_then(parse_utc_offset_seconds)
+            .unwrap_or(0);
+        let local = now_epoch_seconds.saturating_add_signed(offset);
+        let bucket = |bucket: SpendBucket| bucket.start_ms(local.saturating_mul(1000)) / 1000;
+        let local_start = match self.period {
+            BudgetPeriod::Daily => bucket(SpendBucket::Day),
+            BudgetPeriod::Weekly => bucket(SpendBucket::Week),
+            BudgetPeriod::Monthly => bucket(SpendBucket::Month),
+            BudgetPeriod::Cron => self
+                .cron
+                .as_deref()
+                .and_then(CronSchedule::parse)
+                .and_then(|schedule| schedule.latest_at_or_before(local))
+                .unwrap_or(0),
+        };
+        local_start.saturating_add_signed(-offset)
+    }
+
+    /// Start of the window before the one starting at `window_start`.
+    pub fn previous_window_start(&self, window_start: u64) -> Option<u64> {
+        let previous = window_start.checked_sub(1)?;
+        Some(self.window_start(previous))
+    }
+}
+
+/// The ledger scope a windowed budget charges for the window starting at
+/// `window_start`, e.g. `tenant:acme::window::2024-03-01T00:00:00Z`.
+pub(crate) fn window_ledger_scope(scope: &str, window_start: u64) -> String {
+    let (year, month, day) = civil_from_days(window_start / DAY_SECONDS);
+    let secs_of_day = window_start % DAY_SECONDS;
+    format!(
+        "{scope}{WINDOW_SCOPE_SEPARATOR}{year:04}-{month:02}-{day:02}T{:02}:{:02}:{:02}Z",
+        secs_of_day / 3600,
+        secs_of_day % 3600 / 60,
+        secs_of_day % 60,
+    )
+}
+
+/// The key, tenant, project or user scope a ledger belongs to: the ledger
+/// scope itself, minus any window suffix.
+pub fn budget_ledger_base_scope(ledger_scope: &str) -> &str {
+    ledger_scope
+        .split_once(WINDOW_SCOPE_SEPARATOR)
+        .map(|(scope, _)| scope)
+        .unwrap_or(ledger_scope)
+}
+
+fn parse_utc_offset_seconds(timezone: &str) -> Option<i64> {
+    let timezone = timezone.trim();
+    if timezone.eq_ignore_ascii_case("utc") || timezone == "Z" {
+        return Some(0);
+    }
+    let (sign, rest) = match timezone.as_bytes().first()? {
+        b'+' => (1, &timezone[1..]),
+        b'-' => (-1, &timezone[1..]),
+        _ => return None,
+    };
+    let (hours, minutes) = rest.split_once(':')?;
+    if hours.len() != 2 || minutes.len() != 2 {
+        return None;
+    }
+    let hours = hours.parse::<i64>().ok().filter(|hours| *hours <= 14)?;
+    let minutes = minutes
+        .parse::<i64>()
+        .ok()
+        .filter(|minutes| *minutes < 60)?;
+    Some(sign * (hours * 3600 + minutes * 60))
+}
+
+/// A parsed five-field cron expression: each field is a bitmask of the
+/// values it matches.
+struct CronSchedule {
+    minutes: u64,
+    hours: u64,
+    days_of_month: u64,
+    months: u64,
+    days_of_week: u64,
+    // As in cron(8), a day matches either field when both are restricted.
+    days_of_month_restricted: bool,
+    days_of_week_restricted: bool,
+}
+
+impl CronSchedule {
+    fn parse(expr: &str) -> Option<Self> {
+        let fields = expr.split_whitespace().collect::<Vec<_>>();
+        let [minute, hour, day_of_month, month, day_of_week] = fields.as_slice() else {
+            return None;
+        };
+        let mut days_of_week = parse_cron_field(day_of_week, 0, 7)?;
+        // Both 0 and 7 are Sunday.
+        if days_of_week & (1 << 7) != 0 {
+            days_of_week = (days_of_week & !(1 << 7)) | 1;
+        }
+        Some(Self {
+            minutes: parse_cron_field(minute, 0, 59)?,
+            hours: parse_cron_field(hour, 0, 23)?,
+            days_of_month: parse_cron_field(day_of_month, 1, 31)?,
+            months: parse_cron_field(month, 1, 12)?,
+            days_of_week,
+            days_of_month_restricted: *day_of_month != "*",
+            days_of_week_restricted: *day_of_week != "*",
+        })
+    }
+
+    fn matches_day(&self, days: u64) -> bool {
+        let (_, month, day) = civil_from_days(days);
+        if self.months & (1 << month) == 0 {
+            return false;
+        }
+        // 1970-01-01 was a Thursday; Sunday is 0.
+        let weekday = (days + 4) % 7;
+        let dom = self.days_of_month & (1 << day) != 0;
+        let dow = self.days_of_week & (1 << weekday) != 0;
+        if self.days_of_month_restricted && self.days_of_week_restricted {
+            dom || dow
+        } else {
+            dom && dow
+        }
+    }
+
+    /// The latest match at or before `ts` (epoch seconds in the schedule's
+    /// timezone), truncated to the minute.
+    fn latest_at_or_before(&self, ts: u64) -> Option<u64> {
+        let today = ts / DAY_SECONDS;
+        for back in 0..=CRON_LOOKBACK_DAYS.min(today) {
+            let days = today - back;
+            if !self.matches_day(days) {
+                continue;
+            }
+            let last_minute = if back == 0 {
+                ts % DAY_SECONDS / 60
+            } else {
+                24 * 60 - 1
+            };
+            let matched = (0..=last_minute).rev().find(|minute| {
+                self.hours & (1 << (minute / 60)) != 0 && self.minutes & (1 << (minute % 60)) != 0
+            });
+            if let Some(minute) = matched {
+                return Some(days * DAY_SECONDS + minute * 60);
+            }
+        }
+        None
+    }
+}
+
+/// Parses `*`, `n`, `a-b`, `*/s`, `a-b/s`, `n/s` and comma-separated lists
+/// of them into a bitmask over `min..=max`.
+fn parse_cron_field(field: &str, min: u64, max: u64) -> Option<u64> {
+    let mut mask = 0u64;
+    for part in field.split(',') {
+        let (range, step) = match part.split_once('/') {
+            Some((range, step)) => (range, Some(step.parse::<u64>().ok().filter(|s| *s > 0)?)),
+            None => (part, None),
+        };
+        let (lo, hi) = if range == "*" {
+            (min, max)
+        } else if let Some((lo, hi)) = range.split_once('-') {
+            (lo.parse::<u64>().ok()?, hi.parse::<u64>().ok()?)
+        } else {
+            let value = range.parse::<u64>().ok()?;
+            (value, if step.is_some() { max } else { value })
+        };
+        if lo < min || hi > max || lo > hi {
+            return None;
+        }
+        let step = step.unwrap_or(1) as usize;
+        for value in (lo..=hi).step_by(step) {
+            mask |= 1 << value;
+        }
+    }
+    Some(mask)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    // 2024-02-29T13:00:00Z, a Thursday.
+    const NOW: u64 = 1_709_211_600;
+
+    fn reset(period: BudgetPeriod) -> BudgetResetConfig {
+        BudgetResetConfig {
+            period,
+            cron: None,
+            timezone: None,
+            rollover: false,
+            max_rollover_tokens: None,
+            max_rollover_usd_micros: None,
+        }
+    }
+
+    #[test]
+    fn calendar_windows_start_in_the_configured_timezone() {
+        let daily = reset(BudgetPeriod::Daily);
+        assert_eq!(daily.window_start(NOW), 1_709_164_800);
+        assert_eq!(
+            daily.previous_window_start(1_709_164_800),
+            Some(1_709_078_400)
+        );
+
+        // 13:00Z is already 2024-03-01 in UTC+12, so the month has rolled over.
+        let mut monthly = reset(BudgetPeriod::Monthly);
+        monthly.timezone = Some("+12:00".to_string());
+        let start = monthly.window_start(NOW);
+        assert_eq!(
+            window_ledger_scope("vk-1", start),
+            "vk-1::window::2024-02-29T12:00:00Z"
+        );
+        monthly.timezone = Some("UTC".to_string());
+        assert_eq!(
+            window_ledger_scope("vk-1", monthly.window_start(NOW)),
+            "vk-1::window::2024-02-01T00:00:00Z"
+        );
+
+        let mut weekly = reset(BudgetPeriod::Weekly);
+        weekly.timezone = Some("-05:00".to_string());
+        // Monday 2024-02-26 at local midnight.
+        assert_eq!(weekly.window_start(NOW), 1_708_923_600);
+    }
+
+    #[test]
+    fn cron_windows_start_at_the_latest_match() {
+        let mut cron = reset(BudgetPeriod::Cron);
+        // Every weekday at 09:30.
+        cron.cron = Some("30 9 * * 1-5".to_string());
+        cron.validate("budget.reset").unwrap();
+        let start = cron.window_start(NOW);
+        assert_eq!(
+            window_ledger_scope("vk-1", start),
+            "vk-1::window::2024-02-29T09:30:00Z"
+        );
+        // Before 09:30 on Monday the window started on Friday.
+        let monday_early = 1_708_905_600 + 8 * 3600;
+        assert_eq!(
+            window_ledger_scope("vk-1", cron.window_start(monday_early)),
+            "vk-1::window::2024-02-23T09:30:00Z"
+        );
+
+        // The 1st and 15th, or any Sunday (0 and 7).
+        cron.cron = Some("0 0 1,15 * 7".to_string());
+        assert_eq!(
+            window_ledger_scope("vk-1", cron.window_start(NOW)),
+            "vk-1::window::2024-02-25T00:00:00Z"
+        );
+        cron.cron = Some("0 */6 * * *".to_string());
+        assert_eq!(cron.window_start(NOW), NOW - 3600);
+    }
+
+    #[test]
+    fn validates_timezones_and_cron_expressions() {
+        let mut config = reset(BudgetPeriod::Cron);
+        assert!(config.validate("budget.reset").is_err());
+        for invalid in ["0 0 * *", "60 0 * * *", "0 0 31 2 *", "0 0 * * 1-8/0"] {
+            config.cron = Some(invalid.to_string());
+            assert!(config.validate("budget.reset").is_err(), "{invalid}");
+        }
+
+        let mut daily = reset(BudgetPeriod::Daily);
+        daily.cron = Some("0 0 * * *".to_string());
+        assert!(daily.validate("budget.reset").is_err());
+        daily.cron = None;
+        for timezone in ["Asia/Shanghai", "+8", "+08:60", "+15:00"] {
+            daily.timezone = Some(timezone.to_string());
+            assert!(daily.validate("budget.reset").is_err(), "{timezone}");
+        }
+        daily.timezone = Some("-09:30".to_string());
+        daily.validate("budget.reset").unwrap();
+    }
+
+    #[test]
+    fn base_scope_strips_window_suffix() {
+        assert_eq!(
+            budget_ledger_base_scope("project:acme/web::window::2024-03-01T00:00:00Z"),
+            "project:acme/web"
+        );
+        assert_eq!(budget_ledger_base_scope("vk-1"), "vk-1");
+    }
+}
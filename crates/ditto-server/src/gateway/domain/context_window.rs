@@ -0,0 +1,214 @@
+use std::ops::Range;
+
+use serde::{Deserialize, Serialize};
+use serde_json::Value;
+
+fn default_summary_max_tokens() -> u32 {
+    256
+}
+
+/// Checks a request against the target model's context window before it is
+/// forwarded, so an oversized prompt is rejected or trimmed by the gateway
+/// instead of failing upstream with an opaque 400.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct ContextWindowConfig {
+    /// Context window of the target model; the input estimate plus the
+    /// requested output tokens must fit.
+    pub max_tokens: u32,
+    #[serde(default)]
+    pub strategy: ContextWindowStrategy,
+    /// Model that writes the summary for `summarize_middle`.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub summarizer: Option<ContextSummarizerConfig>,
+}
+
+/// What an oversized request gets: rejected, trimmed by dropping its oldest
+/// messages, or trimmed by replacing a run of middle messages with a summary.
+/// Leading system/developer messages and the last message are always kept.
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum ContextWindowStrategy {
+    #[default]
+    Reject,
+    DropOldest,
+    SummarizeMiddle,
+}
+
+impl ContextWindowStrategy {
+    pub fn as_str(self) -> &'static str {
+        match self {
+            Self::Reject => "reject",
+            Self::DropOldest => "drop_oldest",
+            Self::SummarizeMiddle => "summarize_middle",
+        }
+    }
+}
+
+/// A chat model on a proxy backend that condenses the dropped messages.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct ContextSummarizerConfig {
+    pub backend: String,
+    pub model: String,
+    /// Budget for the summary; reserved when choosing what to summarize.
+    #[serde(default = "default_summary_max_tokens")]
+    pub max_tokens: u32,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub timeout_ms: Option<u64>,
+}
+
+impl ContextWindowConfig {
+    pub fn validate(&self) -> Result<(), String> {
+        if self.max_tokens == 0 {
+            return Err("context_window max_tokens must be greater than 0".to_string());
+        }
+        match self.summarizer.as_ref() {
+            Some(summarizer)
+                if summarizer.backend.trim().is_empty() || summarizer.model.trim().is_empty() =>
+            {
+                Err("context_window summarizer needs a backend and a model".to_string())
+            }
+            None if self.strategy == ContextWindowStrategy::SummarizeMiddle => {
+                Err("context_window summarize_middle needs a summarizer".to_string())
+            }
+            _ => Ok(()),
+        }
+    }
+
+    /// Tokens over the window, or `None` when the request fits.
+    pub fn excess_tokens(&self, input_tokens: u32, max_output_tokens: u32) -> Option<u32> {
+        let needed = input_tokens.saturating_add(max_output_tokens);
+        (needed > self.max_tokens).then(|| needed - self.max_tokens)
+    }
+}
+
+/// The shortest run of `messages` whose removal frees at least `excess`
+/// tokens, starting after the leading system/developer messages and, with
+/// `keep_first`, after the first message following them. The last message is
+/// never included, and tool results that would lose their call are removed
+/// with it. Returns `None` when no such run exists.
+pub fn context_window_trim_range(
+    messages: &[Value],
+    keep_first: bool,
+    excess: u32,
+    message_tokens: impl Fn(&Value) -> u32,
+) -> Option<Range<usize>> {
+    let last = messages.len().checked_sub(1)?;
+    let mut start = messages
+        .iter()
+        .position(|message| !matches!(message_role(message), Some("system" | "developer")))?;
+    if keep_first {
+        start += 1;
+    }
+    if start >= last {
+        return None;
+    }
+    let mut end = start;
+    let mut freed = 0u32;
+    while freed < excess && end < last {
+        freed = freed.saturating_add(message_tokens(&messages[end]));
+        end += 1;
+    }
+    if freed < excess {
+        return None;
+    }
+    while message_role(&messages[end]) == Some("tool") {
+        if end == last {
+            return None;
+        }
+        end += 1;
+    }
+    Some(start..end)
+}
+
+/// Renders messages as `role: text` lines for the summarizer.
+pub fn context_window_transcript(messages: &[Value]) -> String {
+    let mut lines = Vec::new();
+    for message in messages {
+        let role = message_role(message).unwrap_or("unknown");
+        let text = match message.get("content") {
+            Some(Value::String(text)) => text.clone(),
+            Some(Value::Array(parts)) => parts
+                .iter()
+                .filter_map(|part| part.get("text").and_then(Value::as_str))
+                .collect::<Vec<_>>()
+                .join("\n"),
+            _ => String::new(),
+        };
+        if !text.trim().is_empty() {
+            lines.push(format!("{role}: {}", text.trim()));
+        }
+    }
+    lines.join("\n")
+}
+
+fn message_role(message: &Value) -> Option<&str> {
+    message.get("role").and_then(Value::as_str)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn tokens(message: &Value) -> u32 {
+        message
+            .get("content")
+            .and_then(Value::as_str)
+            .map_or(1, |text| text.len() as u32)
+    }
+
+    #[test]
+    fn trim_range_keeps_system_last_and_tool_pairs() {
+        let messages = serde_json::json!([
+            {"role": "system", "content": "sys"},
+            {"role": "user", "content": "aaaa"},
+            {"role": "assistant", "tool_calls": [{"id": "call_1"}]},
+            {"role": "tool", "tool_call_id": "call_1", "content": "bb"},
+            {"role": "assistant", "content": "cccc"},
+            {"role": "user", "content": "last"},
+        ]);
+        let messages = messages.as_array().expect("array");
+
+        assert_eq!(
+            context_window_trim_range(messages, false, 4, tokens),
+            Some(1..2)
+        );
+        // Dropping the tool call takes its result with it.
+        assert_eq!(
+            context_window_trim_range(messages, false, 5, tokens),
+            Some(1..4)
+        );
+        assert_eq!(
+            context_window_trim_range(messages, true, 1, tokens),
+            Some(2..4)
+        );
+        assert_eq!(
+            context_window_trim_range(messages, false, 100, tokens),
+            None
+        );
+
+        assert_eq!(
+            context_window_transcript(&messages[1..5]),
+            "user: aaaa\ntool: bb\nassistant: cccc"
+        );
+    }
+
+    #[test]
+    fn validates_strategy_and_counts_excess() {
+        let mut config: ContextWindowConfig = serde_json::from_value(serde_json::json!({
+            "max_tokens": 100,
+            "strategy": "summarize_middle",
+        }))
+        .expect("deserialize");
+        assert!(config.validate().is_err());
+        config.summarizer = Some(ContextSummarizerConfig {
+            backend: "summary".to_string(),
+            model: "gpt-4o-mini".to_string(),
+            max_tokens: default_summary_max_tokens(),
+            timeout_ms: None,
+        });
+        config.validate().expect("valid");
+
+        assert_eq!(config.excess_tokens(60, 40), None);
+        assert_eq!(config.excess_tokens(90, 40), Some(30));
+    }
+}
@@ -0,0 +1,47 @@
+//! Ownership of fine-tuning jobs created through the gateway: the provider
+//! credentials are shared, so the gateway records which team created a job
+//! and only lets that team's keys see and manage it.
+
+use serde::{Deserialize, Serialize};
+
+use super::VirtualKeyConfig;
+use super::scope::{project_scope_key, tenant_scope_key};
+
+/// A fine-tuning job created through the gateway.
+#[derive(Clone, Debug, PartialEq, Eq, Serialize, Deserialize)]
+pub struct FineTuningJobRecord {
+    /// Provider job id (`ftjob-...`).
+    pub job_id: String,
+    /// Backend the job was created on; later calls for it go there too.
+    pub backend: String,
+    /// [`fine_tuning_owner`] of the creating key.
+    pub owner: String,
+    /// The virtual key that created the job.
+    pub virtual_key_id: String,
+    pub created_at_ms: u64,
+}
+
+/// Who owns the jobs a key creates: its project, else its tenant, else the
+/// key itself. Keys with the same owner manage each other's jobs.
+pub fn fine_tuning_owner(key: &VirtualKeyConfig) -> String {
+    project_scope_key(key.tenant_id.as_deref(), key.project_id.as_deref())
+        .or_else(|| tenant_scope_key(key.tenant_id.as_deref()))
+        .unwrap_or_else(|| format!("key:{}", key.id))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn owner_prefers_project_then_tenant_then_key() {
+        let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+        assert_eq!(fine_tuning_owner(&key), "key:key-1");
+
+        key.tenant_id = Some("acme".to_string());
+        assert_eq!(fine_tuning_owner(&key), "tenant:acme");
+
+        key.project_id = Some(" search ".to_string());
+        assert_eq!(fine_tuning_owner(&key), "tenant:acme:project:search");
+    }
+}
@@ -1,8 +1,14 @@
+use std::collections::BTreeMap;
 use std::sync::OnceLock;
 
 use regex::{Regex, RegexBuilder};
 use serde::{Deserialize, Serialize};
 
+use super::context_window::ContextWindowConfig;
+use super::history_compression::HistoryCompressionConfig;
+use super::moderation::ModerationConfig;
+use super::prompt_injection::PromptInjectionConfig;
+use super::stream_transforms::StreamTransformConfig;
 use super::{GatewayError, GatewayRequest};
 
 #[derive(Clone, Debug, Default, Serialize, Deserialize)]
@@ -21,6 +27,299 @@ pub struct GuardrailsConfig {
     pub allow_models: Vec<String>,
     #[serde(default)]
     pub deny_models: Vec<String>,
+    /// Named hooks run in order at their phase; see [`GuardrailHookConfig`].
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub hooks: Vec<GuardrailHookConfig>,
+    /// Prompt-injection scoring of user text; see [`PromptInjectionConfig`].
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub prompt_injection: Option<PromptInjectionConfig>,
+    /// Moderation-provider checks; see [`ModerationConfig`].
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub moderation: Option<ModerationConfig>,
+    /// Context-window check and trimming; see [`ContextWindowConfig`].
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub context_window: Option<ContextWindowConfig>,
+    /// Summarization of older turns in long conversations; see
+    /// [`HistoryCompressionConfig`].
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub history_compression: Option<HistoryCompressionConfig>,
+    /// Rewrites applied to each event of a streaming response; see
+    /// [`StreamTransformConfig`].
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub stream_transforms: Vec<StreamTransformConfig>,
+}
+
+/// When a hook runs: on the request before the upstream call, on a buffered
+/// non-streaming response, or on each SSE event of a streaming response.
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum GuardrailHookPhase {
+    #[default]
+    PreCall,
+    PostCall,
+    DuringStream,
+}
+
+impl GuardrailHookPhase {
+    pub fn as_str(self) -> &'static str {
+        match self {
+            Self::PreCall => "pre_call",
+            Self::PostCall => "post_call",
+            Self::DuringStream => "during_stream",
+        }
+    }
+}
+
+/// What a matching hook does: reject the request or response, replace the
+/// matched text, or only record the match.
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum GuardrailHookAction {
+    #[default]
+    Block,
+    Modify,
+    Log,
+}
+
+impl GuardrailHookAction {
+    pub fn as_str(self) -> &'static str {
+        match self {
+            Self::Block => "block",
+            Self::Modify => "modify",
+            Self::Log => "log",
+        }
+    }
+}
+
+#[derive(Clone, Debug, Default, Serialize, Deserialize)]
+pub struct GuardrailHookConfig {
+    pub name: String,
+    #[serde(default)]
+    pub phase: GuardrailHookPhase,
+    #[serde(default)]
+    pub action: GuardrailHookAction,
+    /// Case-insensitive substrings that trigger the hook.
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub phrases: Vec<String>,
+    /// Case-insensitive regexes that trigger the hook.
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub regexes: Vec<String>,
+    /// Built-in PII detectors that trigger the hook.
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub pii: Vec<GuardrailPiiEntity>,
+    /// Custom named entities (name to case-insensitive regex), masked as
+    /// `[NAME]` by `modify` hooks.
+    #[serde(default, skip_serializing_if = "BTreeMap::is_empty")]
+    pub entities: BTreeMap<String, String>,
+    /// Text substituted for each match by `modify` hooks. Defaults to the
+    /// entity mask (e.g. `[EMAIL]`) for PII and entities, else `[REDACTED]`.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub replacement: Option<String>,
+    /// Matchers built on first use, so requests do not recompile the regexes.
+    /// Loaded hooks are replaced with the config rather than edited in place.
+    #[serde(skip)]
+    compiled: OnceLock<Result<Vec<HookMatcher>, String>>,
+}
+
+impl GuardrailHookConfig {
+    pub fn new(name: impl Into<String>, phase: GuardrailHookPhase) -> Self {
+        Self {
+            name: name.into(),
+            phase,
+            ..Self::default()
+        }
+    }
+
+    fn validate(&self) -> Result<(), String> {
+        let name = self.name.trim();
+        if name.is_empty() {
+            return Err("hook name must not be empty".to_string());
+        }
+        if self.pii.is_empty()
+            && self.entities.is_empty()
+            && self
+                .phrases
+                .iter()
+                .chain(&self.regexes)
+                .all(|pattern| pattern.trim().is_empty())
+        {
+            return Err(format!(
+                "hook {name} must list phrases, regexes, pii or entities"
+            ));
+        }
+        for (entity, pattern) in &self.entities {
+            if entity.trim().is_empty() || pattern.trim().is_empty() {
+                return Err(format!("hook {name} has an empty entity name or pattern"));
+            }
+        }
+        self.compile_matchers()
+            .map(|_| ())
+            .map_err(|err| format!("hook {name}: {err}"))
+    }
+
+    fn matchers(&self) -> Result<&[HookMatcher], &String> {
+        self.compiled
+            .get_or_init(|| self.compile_matchers())
+            .as_deref()
+    }
+
+    fn compile_matchers(&self) -> Result<Vec<HookMatcher>, String> {
+        let redacted = || "[REDACTED]".to_string();
+        let phrases = self
+            .phrases
+            .iter()
+            .map(|phrase| phrase.trim())
+            .filter(|phrase| !phrase.is_empty())
+            .map(|phrase| (regex::escape(phrase), phrase, redacted()));
+        let regexes = self
+            .regexes
+            .iter()
+            .map(|pattern| pattern.trim())
+            .filter(|pattern| !pattern.is_empty())
+            .map(|pattern| (pattern.to_string(), pattern, redacted()));
+        let entities = self.entities.iter().map(|(name, pattern)| {
+            (
+                pattern.trim().to_string(),
+                pattern.as_str(),
+                format!("[{}]", name.trim().to_uppercase()),
+            )
+        });
+        let mut matchers = phrases
+            .chain(regexes)
+            .chain(entities)
+            .map(|(pattern, raw, mask)| {
+                RegexBuilder::new(&pattern)
+                    .case_insensitive(true)
+                    .build()
+                    .map(|regex| HookMatcher {
+                        regex,
+                        mask,
+                        luhn: false,
+                    })
+                    .map_err(|err| format!("invalid regex {raw}: {err}"))
+            })
+            .collect::<Result<Vec<_>, _>>()?;
+        // Credit cards go first so their digits are not half-masked as phones.
+        let mut pii = self.pii.clone();
+        pii.sort_by_key(|entity| *entity != GuardrailPiiEntity::CreditCard);
+        pii.dedup();
+        matchers.extend(pii.into_iter().map(GuardrailPiiEntity::matcher));
+        Ok(matchers)
+    }
+}
+
+/// Built-in PII detectors for hooks. Credit card numbers must also pass the
+/// Luhn check, so order numbers and other long digit runs are left alone.
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum GuardrailPiiEntity {
+    Email,
+    Phone,
+    CreditCard,
+    Ssn,
+}
+
+impl GuardrailPiiEntity {
+    fn matcher(self) -> HookMatcher {
+        let (regex, mask) = match self {
+            Self::Email => (email_pii_regex(), "[EMAIL]"),
+            Self::Phone => (phone_pii_regex(), "[PHONE]"),
+            Self::CreditCard => (credit_card_pii_regex(), "[CREDIT_CARD]"),
+            Self::Ssn => (ssn_pii_regex(), "[SSN]"),
+        };
+        HookMatcher {
+            regex: regex.clone(),
+            mask: mask.to_string(),
+            luhn: self == Self::CreditCard,
+        }
+    }
+}
+
+#[derive(Clone, Debug)]
+struct HookMatcher {
+    regex: Regex,
+    /// Placeholder used by `modify` hooks without a `replacement`.
+    mask: String,
+    luhn: bool,
+}
+
+impl HookMatcher {
+    fn is_match(&self, text: &str) -> bool {
+        if !self.luhn {
+            return self.regex.is_match(text);
+        }
+        self.regex
+            .find_iter(text)
+            .any(|found| luhn_valid(found.as_str()))
+    }
+
+    fn mask(&self, text: &str, replacement: Option<&str>) -> String {
+        let replacement = replacement.unwrap_or(&self.mask);
+        self.regex
+            .replace_all(text, |caps: &regex::Captures<'_>| {
+                let found = &caps[0];
+                if self.luhn && !luhn_valid(found) {
+                    found.to_string()
+                } else {
+                    replacement.to_string()
+                }
+            })
+            .into_owned()
+    }
+}
+
+fn luhn_valid(candidate: &str) -> bool {
+    let digits = candidate
+        .bytes()
+        .filter(u8::is_ascii_digit)
+        .map(|digit| u32::from(digit - b'0'))
+        .collect::<Vec<_>>();
+    if !(13..=19).contains(&digits.len()) {
+        return false;
+    }
+    let sum: u32 = digits
+        .iter()
+        .rev()
+        .enumerate()
+        .map(|(idx, &digit)| match (idx % 2, digit * 2) {
+            (1, doubled) if doubled > 9 => doubled - 9,
+            (1, doubled) => doubled,
+            _ => digit,
+        })
+        .sum();
+    sum % 10 == 0
+}
+
+/// A hook that matched while running a phase.
+#[derive(Clone, Debug, PartialEq, Eq)]
+pub struct GuardrailHookMatch {
+    pub hook: String,
+    pub action: GuardrailHookAction,
+}
+
+#[derive(Clone, Debug, Default)]
+pub struct GuardrailHookOutcome {
+    /// Name of the `block` hook that stopped the run, if any.
+    pub blocked_by: Option<String>,
+    /// Whether a `modify` hook rewrote the text.
+    pub modified: bool,
+    pub matches: Vec<GuardrailHookMatch>,
+}
+
+impl GuardrailHookOutcome {
+    pub fn block_reason(&self) -> Option<String> {
+        self.blocked_by.as_ref().map(|hook| format!("hook:{hook}"))
+    }
+
+    fn merge(&mut self, other: GuardrailHookOutcome) {
+        self.blocked_by = self.blocked_by.take().or(other.blocked_by);
+        self.modified |= other.modified;
+        for hook_match in other.matches {
+            if !self.matches.contains(&hook_match) {
+                self.matches.push(hook_match);
+            }
+        }
+    }
 }
 
 impl GuardrailsConfig {
@@ -55,9 +354,87 @@ impl GuardrailsConfig {
                 .build()
                 .map_err(|err| format!("invalid banned_regex {pattern}: {err}"))?;
         }
+        let mut names = std::collections::HashSet::new();
+        for hook in &self.hooks {
+            hook.validate()?;
+            if !names.insert(hook.name.trim()) {
+                return Err(format!("duplicate hook name {}", hook.name.trim()));
+            }
+        }
+        if let Some(prompt_injection) = self.prompt_injection.as_ref() {
+            prompt_injection.validate()?;
+        }
+        if let Some(moderation) = self.moderation.as_ref() {
+            moderation.validate()?;
+        }
+        if let Some(context_window) = self.context_window.as_ref() {
+            context_window.validate()?;
+        }
+        if let Some(history_compression) = self.history_compression.as_ref() {
+            history_compression.validate()?;
+        }
+        for transform in &self.stream_transforms {
+            transform.validate()?;
+        }
         Ok(())
     }
 
+    pub fn has_hooks(&self, phase: GuardrailHookPhase) -> bool {
+        self.hooks.iter().any(|hook| hook.phase == phase)
+    }
+
+    /// Runs the hooks of `phase` over `text` in order. `modify` hooks rewrite
+    /// `text` in place (later hooks see the rewritten text); the first
+    /// matching `block` hook stops the run.
+    pub fn run_hooks(&self, phase: GuardrailHookPhase, text: &mut String) -> GuardrailHookOutcome {
+        let mut outcome = GuardrailHookOutcome::default();
+        for hook in self.hooks.iter().filter(|hook| hook.phase == phase) {
+            // Invalid patterns are rejected by `validate`; skip them here rather
+            // than failing a request on a hook that never loaded.
+            let Ok(matchers) = hook.matchers() else {
+                continue;
+            };
+            if !matchers.iter().any(|matcher| matcher.is_match(text)) {
+                continue;
+            }
+            outcome.matches.push(GuardrailHookMatch {
+                hook: hook.name.clone(),
+                action: hook.action,
+            });
+            match hook.action {
+                GuardrailHookAction::Block => {
+                    outcome.blocked_by = Some(hook.name.clone());
+                    return outcome;
+                }
+                GuardrailHookAction::Modify => {
+                    for matcher in matchers {
+                        let rewritten = matcher.mask(text, hook.replacement.as_deref());
+                        *text = rewritten;
+                    }
+                    outcome.modified = true;
+                }
+                GuardrailHookAction::Log => {}
+            }
+        }
+        outcome
+    }
+
+    /// Runs the hooks of `phase` over the message text of an OpenAI-style
+    /// request, response or stream event: every string under a `content`,
+    /// `text`, `prompt`, `input` or `delta` key.
+    pub fn run_hooks_on_json(
+        &self,
+        phase: GuardrailHookPhase,
+        value: &mut serde_json::Value,
+    ) -> GuardrailHookOutcome {
+        let mut outcome = GuardrailHookOutcome::default();
+        visit_hook_text(value, false, &mut |text| {
+            outcome.merge(self.run_hooks(phase, text));
+            outcome.blocked_by.is_none()
+        });
+        outcome
+    }
+
     pub fn has_text_filters(&self) -> bool {
         !self.banned_phrases.is_empty() || !self.banned_regexes.is_empty() || self.block_pii
     }
@@ -129,6 +506,27 @@ impl GuardrailsConfig {
     }
 }
 
+const HOOK_TEXT_KEYS: &[&str] = &["content", "text", "prompt", "input", "delta"];
+
+/// Calls `visit` for each hook-visible string (including strings in arrays
+/// under a text key); stops once it returns false.
+pub(super) fn visit_hook_text(
+    value: &mut serde_json::Value,
+    text_key: bool,
+    visit: &mut impl FnMut(&mut String) -> bool,
+) -> bool {
+    match value {
+        serde_json::Value::String(text) if text_key => visit(text),
+        serde_json::Value::Object(map) => map.iter_mut().all(|(key, value)| {
+            visit_hook_text(value, HOOK_TEXT_KEYS.contains(&key.as_str()), visit)
+        }),
+        serde_json::Value::Array(items) => items
+            .iter_mut()
+            .all(|item| visit_hook_text(item, text_key, visit)),
+        _ => true,
+    }
+}
+
 fn model_matches_pattern(model: &str, pattern: &str) -> bool {
     let pattern = pattern.trim();
     if pattern.is_empty() {
@@ -152,7 +550,177 @@ fn email_pii_regex() -> &'static Regex {
     })
 }
 
+fn phone_pii_regex() -> &'static Regex {
+    static REGEX: OnceLock<Regex> = OnceLock::new();
+    REGEX.get_or_init(|| {
+        Regex::new(
+            r"(?:\+\d{1,3}[\s.-]?\d{2,4}[\s.-]?\d{3,4}[\s.-]?\d{3,4}|\(\d{3}\)\s?\d{3}[\s.-]\d{4}|\b\d{3}[\s.-]\d{3}[\s.-]\d{4})\b",
+        )
+        .expect("phone regex is valid")
+    })
+}
+
+fn credit_card_pii_regex() -> &'static Regex {
+    static REGEX: OnceLock<Regex> = OnceLock::new();
+    REGEX.get_or_init(|| {
+        Regex::new(r"\b(?:\d[ -]?){12,18}\d\b").expect("credit card regex is valid")
+    })
+}
+
 fn ssn_pii_regex() -> &'static Regex {
     static REGEX: OnceLock<Regex> = OnceLock::new();
     REGEX.get_or_init(|| Regex::new(r"\b\d{3}-\d{2}-\d{4}\b").expect("ssn regex is valid"))
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn hooks_modify_text_fields_and_stop_at_block() {
+        let mut mask = GuardrailHookConfig::new("mask", GuardrailHookPhase::PreCall);
+        mask.action = GuardrailHookAction::Modify;
+        mask.phrases = vec!["secret".to_string()];
+        let mut deny = GuardrailHookConfig::new("deny", GuardrailHookPhase::PreCall);
+        deny.regexes = vec![r"forbid+en".to_string()];
+        let mut audit = GuardrailHookConfig::new("audit", GuardrailHookPhase::PostCall);
+        audit.action = GuardrailHookAction::Log;
+        audit.phrases = vec!["secret".to_string()];
+        let guardrails = GuardrailsConfig {
+            hooks: vec![mask, deny, audit],
+            ..GuardrailsConfig::default()
+        };
+        guardrails.validate().expect("valid hooks");
+
+        let mut body = serde_json::json!({
+            "model": "secret-model",
+            "messages": [
+                {"role": "user", "content": "my SECRET is here"},
+                {"role": "user", "content": [{"type": "text", "text": "secret"}]},
+            ],
+        });
+        let outcome = guardrails.run_hooks_on_json(GuardrailHookPhase::PreCall, &mut body);
+        assert!(outcome.modified);
+        assert_eq!(outcome.block_reason(), None);
+        assert_eq!(outcome.matches.len(), 1);
+        assert_eq!(body["model"], "secret-model");
+        assert_eq!(body["messages"][0]["content"], "my [REDACTED] is here");
+        assert_eq!(body["messages"][1]["content"][0]["text"], "[REDACTED]");
+
+        let mut body = serde_json::json!({"input": ["ok", "forbidden"]});
+        let outcome = guardrails.run_hooks_on_json(GuardrailHookPhase::PreCall, &mut body);
+        assert_eq!(outcome.block_reason().as_deref(), Some("hook:deny"));
+
+        let mut text = "a secret".to_string();
+        let outcome = guardrails.run_hooks(GuardrailHookPhase::PostCall, &mut text);
+        assert!(!outcome.modified);
+        assert_eq!(outcome.matches[0].action, GuardrailHookAction::Log);
+        assert_eq!(text, "a secret");
+    }
+
+    #[test]
+    fn pii_hooks_mask_entities_and_skip_invalid_cards() {
+        let mut mask = GuardrailHookConfig::new("pii", GuardrailHookPhase::PreCall);
+        mask.action = GuardrailHookAction::Modify;
+        mask.pii = vec![
+            GuardrailPiiEntity::Email,
+            GuardrailPiiEntity::Phone,
+            GuardrailPiiEntity::CreditCard,
+        ];
+        mask.entities
+            .insert("employee_id".to_string(), r"\bEMP-\d{5}\b".to_string());
+        let mut deny = GuardrailHookConfig::new("no-ssn", GuardrailHookPhase::PostCall);
+        deny.pii = vec![GuardrailPiiEntity::Ssn];
+        let guardrails = GuardrailsConfig {
+            hooks: vec![mask, deny],
+            ..GuardrailsConfig::default()
+        };
+        guardrails.validate().expect("valid hooks");
+
+        let mut text = "mail a@b.io or call +1 415 555 0100 / (415) 555-0100, \
+                        card 4111 1111 1111 1111, order 1234567890123, emp-01234"
+            .to_string();
+        let outcome = guardrails.run_hooks(GuardrailHookPhase::PreCall, &mut text);
+        assert!(outcome.modified);
+        assert_eq!(
+            text,
+            "mail [EMAIL] or call [PHONE] / [PHONE], \
+             card [CREDIT_CARD], order 1234567890123, [EMPLOYEE_ID]"
+        );
+
+        let mut text = "order 1234567890123".to_string();
+        let outcome = guardrails.run_hooks(GuardrailHookPhase::PreCall, &mut text);
+        assert!(outcome.matches.is_empty());
+
+        let mut text = "ssn 123-45-6789".to_string();
+        let outcome = guardrails.run_hooks(GuardrailHookPhase::PostCall, &mut text);
+        assert_eq!(outcome.block_reason().as_deref(), Some("hook:no-ssn"));
+
+        let parsed: GuardrailHookConfig = serde_json::from_value(serde_json::json!({
+            "name": "pii",
+            "pii": ["email", "credit_card"],
+        }))
+        .expect("deserialize");
+        assert_eq!(
+            parsed.pii,
+            vec![GuardrailPiiEntity::Email, GuardrailPiiEntity::CreditCard]
+        );
+    }
+
+    #[test]
+    fn hooks_validate_names_and_patterns() {
+        let hook = |name: &str, regex: &str| GuardrailHookConfig {
+            regexes: vec![regex.to_string()],
+            ..GuardrailHookConfig::new(name, GuardrailHookPhase::DuringStream)
+        };
+        let config = |hooks| GuardrailsConfig {
+            hooks,
+            ..GuardrailsConfig::default()
+        };
+
+        assert!(config(vec![hook("", "x")]).validate().is_err());
+        assert!(config(vec![hook("a", "(")]).validate().is_err());
+        assert!(config(vec![hook("a", "  ")]).validate().is_err());
+        let mut entity = hook("a", " ");
+        entity.entities.insert("id".to_string(), "(".to_string());
+        assert!(config(vec![entity]).validate().is_err());
+        assert!(
+            config(vec![hook("a", "x"), hook("a", "y")])
+                .validate()
+                .is_err()
+        );
+        assert!(
+            config(vec![hook("a", "x"), hook("b", "y")])
+                .validate()
+                .is_ok()
+        );
+
+        let serialized = serde_json::to_value(GuardrailsConfig::default()).expect("serialize");
+        assert!(serialized.get("hooks").is_none());
+    }
+
+    #[test]
+    fn hook_matchers_are_compiled_once() {
+        let mut hook = GuardrailHookConfig::new("mask", GuardrailHookPhase::PreCall);
+        hook.regexes = vec!["sk-[a-z]+".to_string()];
+        let first = hook.matchers().expect("matchers").as_ptr();
+        assert!(std::ptr::eq(
+            first,
+            hook.matchers().expect("matchers").as_ptr()
+        ));
+
+        let config = GuardrailsConfig {
+            hooks: vec![hook],
+            ..GuardrailsConfig::default()
+        };
+        let serialized = serde_json::to_value(&config).expect("serialize");
+        assert!(serialized["hooks"][0].get("compiled").is_none());
+        let mut text = "key sk-abc".to_string();
+        assert!(
+            config
+                .run_hooks(GuardrailHookPhase::PreCall, &mut text)
+                .blocked_by
+                .is_some()
+        );
+    }
+}
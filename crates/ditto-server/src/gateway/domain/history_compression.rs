@@ -0,0 +1,112 @@
+use std::ops::Range;
+
+use serde::{Deserialize, Serialize};
+use serde_json::Value;
+
+use super::context_window::ContextSummarizerConfig;
+
+/// Content prefix of the system message that replaces compressed turns; a
+/// later compression folds an earlier summary into the new one.
+pub const HISTORY_SUMMARY_PREFIX: &str = "Summary of earlier messages: ";
+
+fn default_keep_recent_messages() -> usize {
+    4
+}
+
+/// Summarizes the older turns of a long conversation before it is
+/// forwarded, so long chats (client-provided or assembled from a session)
+/// stay cheap even when they would still fit the context window.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct HistoryCompressionConfig {
+    /// Compress when the input estimate exceeds this many tokens.
+    pub threshold_tokens: u32,
+    /// Most recent messages that are always forwarded verbatim.
+    #[serde(default = "default_keep_recent_messages")]
+    pub keep_recent_messages: usize,
+    /// Model that writes the summary; usually a small, cheap one.
+    pub summarizer: ContextSummarizerConfig,
+}
+
+impl HistoryCompressionConfig {
+    pub fn validate(&self) -> Result<(), String> {
+        if self.threshold_tokens == 0 {
+            return Err("history_compression threshold_tokens must be greater than 0".to_string());
+        }
+        if self.keep_recent_messages == 0 {
+            return Err(
+                "history_compression keep_recent_messages must be greater than 0".to_string(),
+            );
+        }
+        if self.summarizer.backend.trim().is_empty() || self.summarizer.model.trim().is_empty() {
+            return Err("history_compression summarizer needs a backend and a model".to_string());
+        }
+        Ok(())
+    }
+}
+
+/// The messages to replace with a summary: everything after the leading
+/// system/developer messages (earlier summaries included) up to the last
+/// `keep_recent` messages, extended so no kept tool result loses its call.
+/// Returns `None` when fewer than two messages would be compressed.
+pub fn history_compression_range(messages: &[Value], keep_recent: usize) -> Option<Range<usize>> {
+    let last = messages.len().checked_sub(1)?;
+    let start = messages
+        .iter()
+        .position(|message| !is_leading_instruction(message))?;
+    let mut end = messages.len().checked_sub(keep_recent.max(1))?;
+    while end < last && messages[end].get("role").and_then(Value::as_str) == Some("tool") {
+        end += 1;
+    }
+    (end >= start + 2).then_some(start..end)
+}
+
+/// A system/developer message that is not an earlier summary.
+fn is_leading_instruction(message: &Value) -> bool {
+    matches!(
+        message.get("role").and_then(Value::as_str),
+        Some("system" | "developer")
+    ) && !message
+        .get("content")
+        .and_then(Value::as_str)
+        .is_some_and(|content| content.starts_with(HISTORY_SUMMARY_PREFIX))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn range_keeps_recent_messages_and_folds_earlier_summary() {
+        let messages = serde_json::json!([
+            {"role": "system", "content": "sys"},
+            {"role": "system", "content": format!("{HISTORY_SUMMARY_PREFIX}old")},
+            {"role": "user", "content": "q1"},
+            {"role": "assistant", "tool_calls": [{"id": "call_1"}]},
+            {"role": "tool", "tool_call_id": "call_1", "content": "r1"},
+            {"role": "assistant", "content": "a1"},
+            {"role": "user", "content": "q2"},
+        ]);
+        let messages = messages.as_array().expect("array");
+
+        assert_eq!(history_compression_range(messages, 2), Some(1..5));
+        // A kept tool result would lose its call, so it is compressed too.
+        assert_eq!(history_compression_range(messages, 3), Some(1..5));
+        assert_eq!(history_compression_range(messages, 4), Some(1..3));
+        assert_eq!(history_compression_range(messages, 5), None);
+        assert_eq!(history_compression_range(&messages[..1], 1), None);
+    }
+
+    #[test]
+    fn validates_threshold_and_summarizer() {
+        let mut config: HistoryCompressionConfig = serde_json::from_value(serde_json::json!({
+            "threshold_tokens": 4000,
+            "summarizer": {"backend": "cheap", "model": "gpt-4o-mini"},
+        }))
+        .expect("deserialize");
+        assert_eq!(config.keep_recent_messages, 4);
+        config.validate().expect("valid");
+
+        config.summarizer.model = " ".to_string();
+        assert!(config.validate().is_err());
+    }
+}
@@ -10,6 +10,45 @@ pub struct LimitsConfig {
     pub tpm: Option<u32>,
 }
 
+/// What is left of the tightest request and token limit across the scopes a
+/// request was checked against, for `x-ratelimit-*` response headers.
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq)]
+pub struct RateLimitStatus {
+    pub requests: Option<RateLimitRemaining>,
+    pub tokens: Option<RateLimitRemaining>,
+}
+
+#[derive(Clone, Copy, Debug, PartialEq, Eq)]
+pub struct RateLimitRemaining {
+    pub limit: u32,
+    pub remaining: u32,
+}
+
+impl RateLimitStatus {
+    /// Folds in one scope's limits and its usage in the current window,
+    /// keeping whichever scope has less left for each dimension.
+    pub fn observe(&mut self, limits: &LimitsConfig, requests: u32, tokens: u32) {
+        fn tighter(current: &mut Option<RateLimitRemaining>, limit: Option<u32>, used: u32) {
+            let Some(limit) = limit else {
+                return;
+            };
+            let next = RateLimitRemaining {
+                limit,
+                remaining: limit.saturating_sub(used),
+            };
+            if current.is_none_or(|current| next.remaining < current.remaining) {
+                *current = Some(next);
+            }
+        }
+        tighter(&mut self.requests, limits.rpm, requests);
+        tighter(&mut self.tokens, limits.tpm, tokens);
+    }
+
+    pub fn is_empty(&self) -> bool {
+        self.requests.is_none() && self.tokens.is_none()
+    }
+}
+
 #[derive(Clone, Debug, Default)]
 pub struct RateLimiter {
     usage: HashMap<String, MinuteUsage>,
@@ -100,7 +139,7 @@ impl RateLimiter {
         scopes: I,
         tokens: u32,
         minute: u64,
-    ) -> Result<(), GatewayError>
+    ) -> Result<RateLimitStatus, GatewayError>
     where
         I: IntoIterator<Item = (&'a str, &'a LimitsConfig)>,
     {
@@ -108,6 +147,7 @@ impl RateLimiter {
 
         let mut proposed = HashMap::<String, MinuteUsage>::new();
         let mut remove_scopes = Vec::<String>::new();
+        let mut status = RateLimitStatus::default();
 
         for (scope, limits) in scopes {
             if limits.rpm.is_none() && limits.tpm.is_none() {
@@ -120,6 +160,7 @@ impl RateLimiter {
                 .cloned()
                 .unwrap_or_else(|| self.usage_for_scope(scope, minute));
             let next = Self::validate_next_usage(limits, &current, tokens)?;
+            status.observe(limits, next.requests, next.tokens);
             proposed.insert(scope.to_string(), next);
         }
 
@@ -129,7 +170,15 @@ impl RateLimiter {
         for (scope, usage) in proposed {
             self.usage.insert(scope, usage);
         }
-        Ok(())
+        Ok(status)
+    }
+
+    /// What `scope` has left of `limits` in `minute`, without consuming any.
+    pub fn remaining(&self, scope: &str, limits: &LimitsConfig, minute: u64) -> RateLimitStatus {
+        let usage = self.usage_for_scope(scope, minute);
+        let mut status = RateLimitStatus::default();
+        status.observe(limits, usage.requests, usage.tokens);
+        status
     }
 
     pub fn refund(&mut self, scope: &str, tokens: u32, minute: u64) {
@@ -256,6 +305,54 @@ mod tests {
         );
     }
 
+    #[test]
+    fn check_and_consume_many_reports_tightest_remaining() {
+        let mut limiter = RateLimiter::default();
+        let key = LimitsConfig {
+            rpm: Some(10),
+            tpm: None,
+        };
+        let tenant = LimitsConfig {
+            rpm: Some(3),
+            tpm: Some(100),
+        };
+
+        let status = limiter
+            .check_and_consume_many([("key", &key), ("tenant:t1", &tenant)], 30, 42)
+            .unwrap();
+        assert_eq!(
+            status.requests,
+            Some(RateLimitRemaining {
+                limit: 3,
+                remaining: 2,
+            })
+        );
+        assert_eq!(
+            status.tokens,
+            Some(RateLimitRemaining {
+                limit: 100,
+                remaining: 70,
+            })
+        );
+
+        let status = limiter
+            .check_and_consume_many([("key", &key)], 30, 42)
+            .unwrap();
+        assert_eq!(
+            status.requests,
+            Some(RateLimitRemaining {
+                limit: 10,
+                remaining: 8,
+            })
+        );
+        assert_eq!(status.tokens, None);
+
+        let status = limiter
+            .check_and_consume_many([("key", &LimitsConfig::default())], 30, 42)
+            .unwrap();
+        assert!(status.is_empty());
+    }
+
     #[test]
     fn refund_many_releases_same_minute_usage() {
         let mut limiter = RateLimiter::default();
@@ -273,4 +370,35 @@ mod tests {
             .check_and_consume_many([("key", &limited), ("tenant:t1", &limited)], 3, 42)
             .expect("refunded request should restore capacity");
     }
+
+    #[test]
+    fn remaining_reports_current_minute_without_consuming() {
+        let mut limiter = RateLimiter::default();
+        let limits = LimitsConfig {
+            rpm: Some(5),
+            tpm: Some(100),
+        };
+
+        limiter.check_and_consume("key", &limits, 30, 7).unwrap();
+        let expected = RateLimitStatus {
+            requests: Some(RateLimitRemaining {
+                limit: 5,
+                remaining: 4,
+            }),
+            tokens: Some(RateLimitRemaining {
+                limit: 100,
+                remaining: 70,
+            }),
+        };
+        assert_eq!(limiter.remaining("key", &limits, 7), expected);
+        assert_eq!(limiter.remaining("key", &limits, 7), expected);
+
+        let next_minute = limiter.remaining("key", &limits, 8);
+        assert_eq!(next_minute.requests.map(|r| r.remaining), Some(5));
+        assert!(
+            limiter
+                .remaining("key", &LimitsConfig::default(), 7)
+                .is_empty()
+        );
+    }
 }
@@ -1,26 +1,67 @@
 //! Gateway domain layer.
 
 pub mod budget;
+pub mod budget_reset;
 pub mod cache;
+pub mod context_window;
+pub mod fine_tuning;
 pub mod guardrails;
+pub mod history_compression;
 pub mod limits;
+pub mod moderation;
+pub mod prompt_injection;
+pub mod prompt_templates;
+pub mod request_tags;
+pub mod residency;
 pub mod router;
 pub(crate) mod scope;
+pub mod sessions;
+pub mod spend_report;
 pub mod store_ports;
 pub mod store_types;
+pub mod stream_transforms;
+pub mod usage_export;
 
 use super::{VirtualKeyConfig, hash64_fnv1a};
 
 pub use super::{GatewayError, GatewayRequest, GatewayResponse};
-pub use budget::{BudgetConfig, BudgetTracker};
+pub use budget::{BudgetConfig, BudgetTracker, BudgetUsage};
+pub use budget_reset::{BudgetPeriod, BudgetResetConfig, budget_ledger_base_scope};
 pub use cache::{CacheConfig, ResponseCache};
-pub use guardrails::GuardrailsConfig;
-pub use limits::{LimitsConfig, RateLimiter};
-pub use router::{RouteBackend, RouteRule, Router, RouterConfig};
-pub use store_ports::{ProxyRequestIdempotencyStore, ProxyRequestIdempotencyStoreError};
+pub use context_window::{ContextSummarizerConfig, ContextWindowConfig, ContextWindowStrategy};
+pub use fine_tuning::{FineTuningJobRecord, fine_tuning_owner};
+pub use guardrails::{
+    GuardrailHookAction, GuardrailHookConfig, GuardrailHookMatch, GuardrailHookOutcome,
+    GuardrailHookPhase, GuardrailPiiEntity, GuardrailsConfig,
+};
+pub use history_compression::HistoryCompressionConfig;
+pub use limits::{LimitsConfig, RateLimitRemaining, RateLimitStatus, RateLimiter};
+pub use moderation::{ModerationAction, ModerationConfig, ModerationViolation};
+pub use prompt_injection::{
+    PromptInjectionAction, PromptInjectionClassifierConfig, PromptInjectionConfig,
+    PromptInjectionScore,
+};
+pub use prompt_templates::{PromptMessage, PromptRegistry, PromptRenderError, PromptTemplate};
+pub use request_tags::{REQUEST_TAGS_HEADER, parse_request_tags};
+pub use residency::{REGION_HEADER, required_regions, retain_backends_in_regions};
+pub use router::{
+    EXPERIMENT_KEY_HEADER, RouteBackend, RouteRule, RouteShadowConfig, Router, RouterConfig,
+};
+pub use sessions::{SessionConfig, trim_session_messages};
+pub use spend_report::{
+    SpendBucket, SpendEntry, SpendFilter, SpendGroupBy, SpendReport, SpendReportRow,
+};
+pub use store_ports::{
+    FineTuningJobStore, FineTuningJobStoreError, ProxyRequestIdempotencyStore,
+    ProxyRequestIdempotencyStoreError, SessionStore, SessionStoreError,
+};
 pub use store_types::{
     AuditLogRecord, BudgetLedgerRecord, CostLedgerRecord, ProxyRequestFingerprint,
     ProxyRequestIdempotencyBeginOutcome, ProxyRequestIdempotencyRecord,
     ProxyRequestIdempotencyState, ProxyRequestReplayError, ProxyRequestReplayOutcome,
     ProxyRequestReplayResponse, StoredHttpHeader,
 };
+pub use stream_transforms::{
+    StreamEventAction, StreamTransform, StreamTransformConfig, StreamTransformFactory,
+    WatermarkPosition,
+};
@@ -0,0 +1,224 @@
+use std::collections::BTreeMap;
+
+use serde::{Deserialize, Serialize};
+use serde_json::Value;
+
+fn default_check_input() -> bool {
+    true
+}
+
+/// Sends request and/or response text to an OpenAI-compatible
+/// `/v1/moderations` endpoint (OpenAI or a self-hosted classifier speaking the
+/// same format) and acts on the categories it reports.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct ModerationConfig {
+    /// Proxy backend that serves `/v1/moderations`.
+    pub backend: String,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub model: Option<String>,
+    #[serde(default)]
+    pub action: ModerationAction,
+    #[serde(default = "default_check_input")]
+    pub check_input: bool,
+    /// Also moderates non-streaming responses.
+    #[serde(default)]
+    pub check_output: bool,
+    /// Minimum category score that counts as a violation, e.g.
+    /// `{"violence": 0.7}`. When empty, the categories the provider flags
+    /// are used as-is.
+    #[serde(default, skip_serializing_if = "BTreeMap::is_empty")]
+    pub thresholds: BTreeMap<String, f64>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub timeout_ms: Option<u64>,
+}
+
+/// What a violation does: reject the request or response, or forward it with
+/// the categories in the response headers.
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum ModerationAction {
+    #[default]
+    Block,
+    Annotate,
+}
+
+impl ModerationAction {
+    pub fn as_str(self) -> &'static str {
+        match self {
+            Self::Block => "block",
+            Self::Annotate => "annotate",
+        }
+    }
+}
+
+/// A category over its threshold, with the highest score seen for it.
+#[derive(Clone, Debug, PartialEq, Serialize)]
+pub struct ModerationViolation {
+    pub category: String,
+    pub score: f64,
+}
+
+impl ModerationConfig {
+    pub fn new(backend: impl Into<String>) -> Self {
+        Self {
+            backend: backend.into(),
+            model: None,
+            action: ModerationAction::default(),
+            check_input: default_check_input(),
+            check_output: false,
+            thresholds: BTreeMap::new(),
+            timeout_ms: None,
+        }
+    }
+
+    pub fn validate(&self) -> Result<(), String> {
+        if self.backend.trim().is_empty() {
+            return Err("moderation backend must not be empty".to_string());
+        }
+        if !self.check_input && !self.check_output {
+            return Err("moderation must check input, output or both".to_string());
+        }
+        for (category, threshold) in &self.thresholds {
+            if category.trim().is_empty() || !(0.0..=1.0).contains(threshold) {
+                return Err(format!(
+                    "moderation threshold for {category:?} must be between 0 and 1"
+                ));
+            }
+        }
+        Ok(())
+    }
+
+    /// Violations in an OpenAI-style moderation response, sorted by
+    /// category and merged across `results[]`.
+    pub fn violations(&self, response: &Value) -> Vec<ModerationViolation> {
+        let mut found = BTreeMap::<String, f64>::new();
+        let mut record = |category: &str, score: f64| {
+            let entry = found.entry(category.to_string()).or_insert(score);
+            *entry = entry.max(score);
+        };
+        let results = response.get("results").and_then(Value::as_array);
+        for result in results.into_iter().flatten() {
+            let score_of = |category: &str| {
+                result
+                    .get("category_scores")
+                    .and_then(|scores| scores.get(category))
+                    .and_then(Value::as_f64)
+            };
+            if self.thresholds.is_empty() {
+                let categories = result.get("categories").and_then(Value::as_object);
+                for (category, flagged) in categories.into_iter().flatten() {
+                    if flagged.as_bool() == Some(true) {
+                        record(category, score_of(category).unwrap_or(1.0));
+                    }
+                }
+                continue;
+            }
+            for (category, threshold) in &self.thresholds {
+                let category = category.trim();
+                if let Some(score) = score_of(category)
+                    && score >= *threshold
+                {
+                    record(category, score);
+                }
+            }
+        }
+        found
+            .into_iter()
+            .map(|(category, score)| ModerationViolation { category, score })
+            .collect()
+    }
+}
+
+/// Collects the generated text of an OpenAI-style response body:
+/// `choices[].message.content`, `choices[].text` and `output[].content[].text`.
+pub fn moderation_response_text(body: &Value) -> Option<String> {
+    let mut parts = Vec::new();
+    let mut push = |value: Option<&Value>| match value {
+        Some(Value::String(text)) => parts.push(text.clone()),
+        Some(Value::Array(items)) => parts.extend(
+            items
+                .iter()
+                .filter_map(|item| item.get("text").and_then(Value::as_str))
+                .map(str::to_string),
+        ),
+        _ => {}
+    };
+    let choices = body.get("choices").and_then(Value::as_array);
+    for choice in choices.into_iter().flatten() {
+        push(choice.pointer("/message/content"));
+        push(choice.get("text"));
+    }
+    let output = body.get("output").and_then(Value::as_array);
+    for item in output.into_iter().flatten() {
+        push(item.get("content"));
+    }
+    let text = parts.join("\n");
+    (!text.trim().is_empty()).then_some(text)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn violations_use_thresholds_or_provider_flags() {
+        let response = serde_json::json!({
+            "results": [
+                {
+                    "flagged": true,
+                    "categories": {"hate": false, "violence": true},
+                    "category_scores": {"hate": 0.4, "violence": 0.81},
+                },
+                {
+                    "flagged": false,
+                    "categories": {"hate": false, "violence": false},
+                    "category_scores": {"hate": 0.6, "violence": 0.2},
+                },
+            ],
+        });
+
+        let flagged = ModerationConfig::new("moderation").violations(&response);
+        assert_eq!(
+            flagged,
+            vec![ModerationViolation {
+                category: "violence".to_string(),
+                score: 0.81,
+            }]
+        );
+
+        let mut config = ModerationConfig::new("moderation");
+        config.thresholds =
+            BTreeMap::from([("hate".to_string(), 0.5), ("violence".to_string(), 0.9)]);
+        config.validate().expect("valid thresholds");
+        let violations = config.violations(&response);
+        assert_eq!(violations.len(), 1);
+        assert_eq!(violations[0].category, "hate");
+        assert_eq!(violations[0].score, 0.6);
+
+        config.thresholds.insert("sexual".to_string(), 1.5);
+        assert!(config.validate().is_err());
+    }
+
+    #[test]
+    fn response_text_reads_chat_and_responses_bodies() {
+        let chat = serde_json::json!({
+            "choices": [
+                {"message": {"role": "assistant", "content": "first"}},
+                {"message": {"role": "assistant", "content": [{"type": "text", "text": "second"}]}},
+            ],
+        });
+        assert_eq!(
+            moderation_response_text(&chat).as_deref(),
+            Some("first\nsecond")
+        );
+
+        let responses = serde_json::json!({
+            "output": [{"type": "message", "content": [{"type": "output_text", "text": "hi"}]}],
+        });
+        assert_eq!(moderation_response_text(&responses).as_deref(), Some("hi"));
+        assert_eq!(
+            moderation_response_text(&serde_json::json!({"id": "x"})),
+            None
+        );
+    }
+}
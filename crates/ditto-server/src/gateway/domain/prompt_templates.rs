@@ -0,0 +1,262 @@
+use std::collections::BTreeMap;
+
+use serde::{Deserialize, Serialize};
+use serde_json::{Map, Value};
+use thiserror::Error;
+
+/// One published version of a named prompt template. Message contents may
+/// reference request variables as `{{name}}`.
+#[derive(Clone, Debug, PartialEq, Serialize, Deserialize)]
+pub struct PromptTemplate {
+    pub id: String,
+    pub version: u32,
+    pub messages: Vec<PromptMessage>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub model: Option<String>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub description: Option<String>,
+    #[serde(default)]
+    pub created_at_ms: u64,
+}
+
+#[derive(Clone, Debug, PartialEq, Serialize, Deserialize)]
+pub struct PromptMessage {
+    pub role: String,
+    pub content: String,
+}
+
+#[derive(Clone, Debug, PartialEq, Eq, Error)]
+pub enum PromptRenderError {
+    #[error("prompt variable `{0}` is missing")]
+    MissingVariable(String),
+}
+
+impl PromptTemplate {
+    pub fn validate(&self) -> Result<(), String> {
+        if self.id.trim().is_empty() {
+            return Err("prompt id must not be empty".to_string());
+        }
+        if self.messages.is_empty() {
+            return Err("prompt messages must not be empty".to_string());
+        }
+        if let Some(idx) = self
+            .messages
+            .iter()
+            .position(|message| message.role.trim().is_empty())
+        {
+            return Err(format!("messages[{idx}].role must not be empty"));
+        }
+        Ok(())
+    }
+
+    /// Placeholder names referenced by the messages, in first-use order.
+    pub fn variables(&self) -> Vec<String> {
+        let mut names = Vec::new();
+        for message in &self.messages {
+            for_each_placeholder(&message.content, |name| {
+                if !names.iter().any(|known| known == name) {
+                    names.push(name.to_string());
+                }
+            });
+        }
+        names
+    }
+
+    /// Renders the messages as OpenAI chat messages. String variables are
+    /// inserted verbatim and other JSON values in their compact encoding.
+    pub fn render(&self, variables: &Map<String, Value>) -> Result<Vec<Value>, PromptRenderError> {
+        self.messages
+            .iter()
+            .map(|message| {
+                Ok(serde_json::json!({
+                    "role": message.role,
+                    "content": render_content(&message.content, variables)?,
+                }))
+            })
+            .collect()
+    }
+}
+
+fn render_content(
+    content: &str,
+    variables: &Map<String, Value>,
+) -> Result<String, PromptRenderError> {
+    let mut out = String::with_capacity(content.len());
+    let mut rest = content;
+    while let Some((before, name, after)) = next_placeholder(rest) {
+        out.push_str(before);
+        match variables.get(name) {
+            Some(Value::String(value)) => out.push_str(value),
+            Some(value) => out.push_str(&value.to_string()),
+            None => return Err(PromptRenderError::MissingVariable(name.to_string())),
+        }
+        rest = after;
+    }
+    out.push_str(rest);
+    Ok(out)
+}
+
+fn for_each_placeholder(content: &str, mut f: impl FnMut(&str)) {
+    let mut rest = content;
+    while let Some((_, name, after)) = next_placeholder(rest) {
+        f(name);
+        rest = after;
+    }
+}
+
+/// Splits off the first `{{name}}` placeholder. Braces that do not enclose
+/// a valid name are left as literal text.
+fn next_placeholder(content: &str) -> Option<(&str, &str, &str)> {
+    let mut offset = 0;
+    while let Some(start) = content[offset..].find("{{") {
+        let open = offset + start;
+        let inner_start = open + 2;
+        let Some(len) = content[inner_start..].find("}}") else {
+            return None;
+        };
+        let name = content[inner_start..inner_start + len].trim();
+        if is_variable_name(name) {
+            return Some((&content[..open], name, &content[inner_start + len + 2..]));
+        }
+        offset = open + 1;
+    }
+    None
+}
+
+fn is_variable_name(name: &str) -> bool {
+    !name.is_empty()
+        && name
+            .chars()
+            .all(|ch| ch.is_ascii_alphanumeric() || matches!(ch, '_' | '-' | '.'))
+}
+
+/// Every published version of every prompt template, keyed by id.
+#[derive(Clone, Debug, Default)]
+pub struct PromptRegistry {
+    prompts: BTreeMap<String, Vec<PromptTemplate>>,
+}
+
+impl PromptRegistry {
+    pub fn from_templates(templates: Vec<PromptTemplate>) -> Self {
+        let mut prompts: BTreeMap<String, Vec<PromptTemplate>> = BTreeMap::new();
+        for template in templates {
+            prompts
+                .entry(template.id.clone())
+                .or_default()
+                .push(template);
+        }
+        for versions in prompts.values_mut() {
+            versions.sort_by_key(|template| template.version);
+            versions.dedup_by_key(|template| template.version);
+        }
+        Self { prompts }
+    }
+
+    /// All versions, ordered by id then version, as persisted.
+    pub fn templates(&self) -> Vec<PromptTemplate> {
+        self.prompts.values().flatten().cloned().collect()
+    }
+
+    /// Stores `template` as the next version of its id, ignoring the
+    /// version it carries, and returns the stored copy.
+    pub fn publish(&mut self, mut template: PromptTemplate) -> PromptTemplate {
+        let versions = self.prompts.entry(template.id.clone()).or_default();
+        template.version = versions.last().map_or(1, |latest| latest.version + 1);
+        versions.push(template.clone());
+        template
+    }
+
+    /// The requested version, or the latest one when `version` is `None`.
+    pub fn get(&self, id: &str, version: Option<u32>) -> Option<&PromptTemplate> {
+        let versions = self.prompts.get(id)?;
+        match version {
+            Some(version) => versions.iter().find(|template| template.version == version),
+            None => versions.last(),
+        }
+    }
+
+    pub fn versions(&self, id: &str) -> Option<&[PromptTemplate]> {
+        self.prompts.get(id).map(Vec::as_slice)
+    }
+
+    pub fn latest(&self) -> impl Iterator<Item = &PromptTemplate> {
+        self.prompts.values().filter_map(|versions| versions.last())
+    }
+
+    /// Removes every version of `id`; returns how many were removed.
+    pub fn remove(&mut self, id: &str) -> usize {
+        self.prompts.remove(id).map_or(0, |versions| versions.len())
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn template(id: &str, content: &str) -> PromptTemplate {
+        PromptTemplate {
+            id: id.to_string(),
+            version: 0,
+            messages: vec![
+                PromptMessage {
+                    role: "system".to_string(),
+                    content: "You are {{ persona }}.".to_string(),
+                },
+                PromptMessage {
+                    role: "user".to_string(),
+                    content: content.to_string(),
+                },
+            ],
+            model: None,
+            description: None,
+            created_at_ms: 0,
+        }
+    }
+
+    #[test]
+    fn renders_variables_and_reports_missing_ones() {
+        let template = template("greet", "Hi {{name}}, limit {{n}} {{ not valid }} {{");
+        assert_eq!(template.variables(), vec!["persona", "name", "n"]);
+
+        let variables = serde_json::json!({"persona": "terse", "name": "Ada", "n": 3});
+        let messages = template
+            .render(variables.as_object().expect("object"))
+            .expect("render");
+        assert_eq!(
+            messages,
+            vec![
+                serde_json::json!({"role": "system", "content": "You are terse."}),
+                serde_json::json!({"role": "user", "content": "Hi Ada, limit 3 {{ not valid }} {{"}),
+            ]
+        );
+
+        let variables = serde_json::json!({"persona": "terse"});
+        assert_eq!(
+            template.render(variables.as_object().expect("object")),
+            Err(PromptRenderError::MissingVariable("name".to_string()))
+        );
+    }
+
+    #[test]
+    fn registry_versions_templates_per_id() {
+        let mut registry = PromptRegistry::default();
+        assert_eq!(registry.publish(template("a", "v1")).version, 1);
+        assert_eq!(registry.publish(template("a", "v2")).version, 2);
+        assert_eq!(registry.publish(template("b", "v1")).version, 1);
+
+        assert_eq!(registry.get("a", None).map(|t| t.version), Some(2));
+        assert_eq!(
+            registry
+                .get("a", Some(1))
+                .map(|t| t.messages[1].content.as_str()),
+            Some("v1")
+        );
+        assert!(registry.get("a", Some(3)).is_none());
+        assert_eq!(registry.latest().count(), 2);
+
+        let restored = PromptRegistry::from_templates(registry.templates());
+        assert_eq!(restored.versions("a").map(<[_]>::len), Some(2));
+        assert_eq!(registry.remove("a"), 2);
+        assert!(registry.get("a", None).is_none());
+    }
+}
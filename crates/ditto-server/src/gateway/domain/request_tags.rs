@@ -0,0 +1,70 @@
+use serde_json::Value;
+
+/// Request header carrying comma-separated cost attribution tags.
+pub const REQUEST_TAGS_HEADER: &str = "x-ditto-tags";
+
+const MAX_REQUEST_TAGS: usize = 16;
+const MAX_REQUEST_TAG_BYTES: usize = 128;
+
+/// Collects the tags a client attached to a request: the `x-ditto-tags`
+/// header (`feature=summarizer,env=prod`) followed by the body's
+/// `metadata.tags`, either a comma-separated string or (LiteLLM-style) an
+/// array of strings. Tags are trimmed and de-duplicated; empty or oversized
+/// ones are dropped and at most `MAX_REQUEST_TAGS` are kept.
+pub fn parse_request_tags(header: Option<&str>, body: Option<&Value>) -> Vec<String> {
+    let body_tags = body.and_then(|body| body.pointer("/metadata/tags"));
+    let header_tags = header.into_iter().flat_map(|header| header.split(','));
+    let body_tags: Vec<&str> = match body_tags {
+        Some(Value::String(tags)) => tags.split(',').collect(),
+        Some(Value::Array(tags)) => tags.iter().filter_map(Value::as_str).collect(),
+        _ => Vec::new(),
+    };
+
+    let mut tags = Vec::new();
+    for tag in header_tags.chain(body_tags) {
+        let tag = tag.trim();
+        if tag.is_empty() || tag.len() > MAX_REQUEST_TAG_BYTES || tags.iter().any(|t| t == tag) {
+            continue;
+        }
+        if tags.len() == MAX_REQUEST_TAGS {
+            break;
+        }
+        tags.push(tag.to_string());
+    }
+    tags
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn merges_header_and_metadata_tags() {
+        let body = serde_json::json!({"metadata": {"tags": ["env=prod", " team=search ", ""]}});
+        assert_eq!(
+            parse_request_tags(Some("feature=summarizer, env=prod,,"), Some(&body)),
+            vec!["feature=summarizer", "env=prod", "team=search"]
+        );
+
+        let body = serde_json::json!({"metadata": {"tags": "a,b"}});
+        assert_eq!(parse_request_tags(None, Some(&body)), vec!["a", "b"]);
+        assert!(parse_request_tags(None, Some(&serde_json::json!({"metadata": {}}))).is_empty());
+    }
+
+    #[test]
+    fn bounds_tag_count_and_length() {
+        let header = (0..40)
+            .map(|i| format!("t{i}"))
+            .collect::<Vec<_>>()
+            .join(",");
+        let tags = parse_request_tags(Some(&header), None);
+        assert_eq!(tags.len(), MAX_REQUEST_TAGS);
+        assert_eq!(tags[0], "t0");
+
+        let long = "x".repeat(MAX_REQUEST_TAG_BYTES + 1);
+        assert_eq!(
+            parse_request_tags(Some(&format!("{long},ok")), None),
+            vec!["ok"]
+        );
+    }
+}
@@ -0,0 +1,130 @@
+use std::collections::HashMap;
+
+use super::{GatewayError, VirtualKeyConfig};
+
+/// Request header naming the comma-separated regions a request must be
+/// served from (`eu`, `eu,uk`). It can only narrow the calling key's
+/// `regions`, never widen them.
+pub const REGION_HEADER: &str = "x-ditto-region";
+
+/// The regions a request is pinned to: the key's `regions`, narrowed by the
+/// `x-ditto-region` header when present. Empty means unrestricted. Asking
+/// for a region the key does not allow is rejected rather than ignored.
+pub fn required_regions(
+    key: Option<&VirtualKeyConfig>,
+    header: Option<&str>,
+) -> Result<Vec<String>, GatewayError> {
+    let allowed = key
+        .map(|key| normalize_regions(key.regions.iter().map(String::as_str)))
+        .unwrap_or_default();
+    let requested = normalize_regions(header.into_iter().flat_map(|header| header.split(',')));
+    if requested.is_empty() {
+        return Ok(allowed);
+    }
+    if !allowed.is_empty()
+        && let Some(region) = requested.iter().find(|region| !allowed.contains(region))
+    {
+        return Err(GatewayError::InvalidRequest {
+            reason: format!(
+                "{REGION_HEADER} `{region}` is outside the regions allowed for this key ({})",
+                allowed.join(",")
+            ),
+        });
+    }
+    Ok(requested)
+}
+
+/// Keeps the routed `backends` whose `region` label is one of `regions`, in
+/// routing order, so retries and fallbacks stay inside the allowed regions.
+/// Backends without a label never satisfy a region requirement.
+pub fn retain_backends_in_regions(
+    backends: Vec<String>,
+    regions: &[String],
+    backend_regions: &HashMap<String, String>,
+) -> Result<Vec<String>, GatewayError> {
+    if regions.is_empty() {
+        return Ok(backends);
+    }
+    let backends = backends
+        .into_iter()
+        .filter(|backend| {
+            backend_regions
+                .get(backend)
+                .is_some_and(|region| regions.iter().any(|r| r.eq_ignore_ascii_case(region)))
+        })
+        .collect::<Vec<_>>();
+    if backends.is_empty() {
+        return Err(GatewayError::RegionUnavailable {
+            regions: regions.join(","),
+        });
+    }
+    Ok(backends)
+}
+
+fn normalize_regions<'a>(regions: impl Iterator<Item = &'a str>) -> Vec<String> {
+    let mut out = Vec::new();
+    for region in regions {
+        let region = region.trim().to_ascii_lowercase();
+        if !region.is_empty() && !out.contains(&region) {
+            out.push(region);
+        }
+    }
+    out
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn key_with_regions(regions: &[&str]) -> VirtualKeyConfig {
+        let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+        key.regions = regions.iter().map(|region| region.to_string()).collect();
+        key
+    }
+
+    #[test]
+    fn header_narrows_key_regions() {
+        let key = key_with_regions(&["EU", "uk"]);
+        assert_eq!(
+            required_regions(Some(&key), None).unwrap(),
+            vec!["eu", "uk"]
+        );
+        assert_eq!(
+            required_regions(Some(&key), Some(" uk ,")).unwrap(),
+            vec!["uk"]
+        );
+        assert_eq!(required_regions(None, Some("eu")).unwrap(), vec!["eu"]);
+        assert!(required_regions(None, None).unwrap().is_empty());
+
+        let err = required_regions(Some(&key), Some("eu,us")).unwrap_err();
+        assert!(err.to_string().contains("`us` is outside the regions"));
+    }
+
+    #[test]
+    fn retains_only_backends_in_required_regions() {
+        let backend_regions = HashMap::from([
+            ("eu-a".to_string(), "eu".to_string()),
+            ("us-a".to_string(), "us".to_string()),
+            ("eu-b".to_string(), "EU".to_string()),
+        ]);
+        let routed = vec![
+            "us-a".to_string(),
+            "eu-b".to_string(),
+            "unlabeled".to_string(),
+            "eu-a".to_string(),
+        ];
+
+        assert_eq!(
+            retain_backends_in_regions(routed.clone(), &[], &backend_regions).unwrap(),
+            routed
+        );
+        assert_eq!(
+            retain_backends_in_regions(routed.clone(), &["eu".to_string()], &backend_regions)
+                .unwrap(),
+            vec!["eu-b", "eu-a"]
+        );
+        let err = retain_backends_in_regions(routed, &["apac".to_string()], &backend_regions)
+            .unwrap_err();
+        assert!(matches!(err, GatewayError::RegionUnavailable { regions } if regions == "apac"));
+    }
+}
@@ -54,6 +54,9 @@ impl<'de> Deserialize<'de> for RouterConfig {
     }
 }
 
+/// Client-supplied key that pins a request to one arm of a route experiment.
+pub const EXPERIMENT_KEY_HEADER: &str = "x-ditto-experiment-key";
+
 #[derive(Clone, Debug, Serialize, Deserialize)]
 pub struct RouteBackend {
     pub backend: String,
@@ -76,6 +79,27 @@ pub struct RouteRule {
     pub backends: Vec<RouteBackend>,
     #[serde(default, skip_serializing_if = "Option::is_none")]
     pub guardrails: Option<GuardrailsConfig>,
+    /// Names an A/B experiment over `backends`: requests carrying an
+    /// `x-ditto-experiment-key` header keep landing on the same arm, and
+    /// responses are counted per arm under this name.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub experiment: Option<String>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub shadow: Option<RouteShadowConfig>,
+}
+
+/// Mirrors requests routed by a rule to a candidate backend. Shadow
+/// responses are logged and never returned to the client.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct RouteShadowConfig {
+    pub backend: String,
+    /// Fraction of requests to mirror, sampled by request id.
+    #[serde(default = "default_shadow_sample_rate")]
+    pub sample_rate: f64,
+}
+
+fn default_shadow_sample_rate() -> f64 {
+    1.0
 }
 
 fn is_false(value: &bool) -> bool {
@@ -130,6 +154,40 @@ impl Router {
             .find(|rule| !rule.exact && rule.matches(model))
     }
 
+    /// Model names declared by `exact` rules: the configured model groups.
+    pub fn model_groups(&self) -> impl Iterator<Item = &str> {
+        self.config
+            .rules
+            .iter()
+            .filter(|rule| rule.exact)
+            .map(|rule| rule.model_prefix.trim())
+            .filter(|model| !model.is_empty())
+    }
+
+    /// The experiment named by the rule `model` routes through, if any.
+    pub fn experiment_for_model(
+        &self,
+        model: &str,
+        key: Option<&VirtualKeyConfig>,
+    ) -> Option<&str> {
+        self.rule_for_model(model, key)
+            .filter(|rule| !rule.backends.is_empty())
+            .and_then(|rule| rule.experiment.as_deref())
+            .map(str::trim)
+            .filter(|experiment| !experiment.is_empty())
+    }
+
+    /// The shadow backend for the rule `model` routes through, if any.
+    pub fn shadow_for_model(
+        &self,
+        model: &str,
+        key: Option<&VirtualKeyConfig>,
+    ) -> Option<&RouteShadowConfig> {
+        self.rule_for_model(model, key)
+            .and_then(|rule| rule.shadow.as_ref())
+            .filter(|shadow| !shadow.backend.trim().is_empty())
+    }
+
     pub fn select_backend(
         &self,
         request: &GatewayRequest,
@@ -330,6 +388,8 @@ mod tests {
                 },
             ],
             guardrails: None,
+            experiment: None,
+            shadow: None,
         };
         let router = Router::new(RouterConfig {
             default_backends: vec![RouteBackend {
@@ -377,6 +437,8 @@ mod tests {
                     },
                 ],
                 guardrails: None,
+                experiment: None,
+                shadow: None,
             }],
         });
 
@@ -400,6 +462,8 @@ mod tests {
                     backend: "prefix".to_string(),
                     backends: Vec::new(),
                     guardrails: None,
+                    experiment: None,
+                    shadow: None,
                 },
                 RouteRule {
                     model_prefix: "gpt-4o-mini".to_string(),
@@ -407,6 +471,8 @@ mod tests {
                     backend: "exact".to_string(),
                     backends: Vec::new(),
                     guardrails: None,
+                    experiment: None,
+                    shadow: None,
                 },
             ],
         });
@@ -430,6 +496,8 @@ mod tests {
                 backend: "primary".to_string(),
                 backends: Vec::new(),
                 guardrails: None,
+                experiment: None,
+                shadow: None,
             }],
         });
 
@@ -438,4 +506,103 @@ mod tests {
             .expect("route");
         assert_eq!(out, "primary".to_string());
     }
+
+    #[test]
+    fn experiment_applies_to_weighted_rules_only() {
+        let arms = vec![
+            RouteBackend {
+                backend: "control".to_string(),
+                weight: 90.0,
+            },
+            RouteBackend {
+                backend: "candidate".to_string(),
+                weight: 10.0,
+            },
+        ];
+        let router = Router::new(RouterConfig {
+            default_backends: Vec::new(),
+            rules: vec![
+                RouteRule {
+                    model_prefix: "chat".to_string(),
+                    exact: true,
+                    backend: String::new(),
+                    backends: arms.clone(),
+                    guardrails: None,
+                    experiment: Some(" new-model ".to_string()),
+                    shadow: None,
+                },
+                RouteRule {
+                    model_prefix: "single".to_string(),
+                    exact: true,
+                    backend: "control".to_string(),
+                    backends: Vec::new(),
+                    guardrails: None,
+                    experiment: Some("ignored".to_string()),
+                    shadow: None,
+                },
+            ],
+        });
+
+        assert_eq!(router.experiment_for_model("chat", None), Some("new-model"));
+        assert_eq!(router.experiment_for_model("single", None), None);
+        assert_eq!(router.experiment_for_model("other", None), None);
+
+        let mut pinned = VirtualKeyConfig::new("key-1", "vk-1");
+        pinned.route = Some("control".to_string());
+        assert_eq!(router.experiment_for_model("chat", Some(&pinned)), None);
+
+        let seed = Some("new-model:user-42");
+        let first = router
+            .select_backends_for_model_seeded("chat", None, seed)
+            .expect("route");
+        for _ in 0..8 {
+            let again = router
+                .select_backends_for_model_seeded("chat", None, seed)
+                .expect("route");
+            assert_eq!(again, first);
+        }
+        assert_eq!(first.len(), 2);
+    }
+
+    #[test]
+    fn shadow_follows_the_matched_rule() {
+        let shadow = |backend: &str| RouteShadowConfig {
+            backend: backend.to_string(),
+            sample_rate: 0.25,
+        };
+        let router = Router::new(RouterConfig {
+            default_backends: Vec::new(),
+            rules: vec![
+                RouteRule {
+                    model_prefix: "chat".to_string(),
+                    exact: true,
+                    backend: "primary".to_string(),
+                    backends: Vec::new(),
+                    guardrails: None,
+                    experiment: None,
+                    shadow: Some(shadow("candidate")),
+                },
+                RouteRule {
+                    model_prefix: "blank".to_string(),
+                    exact: true,
+                    backend: "primary".to_string(),
+                    backends: Vec::new(),
+                    guardrails: None,
+                    experiment: None,
+                    shadow: Some(shadow(" ")),
+                },
+            ],
+        });
+
+        let config = router.shadow_for_model("chat", None).expect("shadow");
+        assert_eq!(config.backend, "candidate");
+        assert_eq!(config.sample_rate, 0.25);
+        assert!(router.shadow_for_model("blank", None).is_none());
+        assert!(router.shadow_for_model("other", None).is_none());
+
+        let parsed: RouteShadowConfig =
+            serde_json::from_value(serde_json::json!({ "backend": "candidate" }))
+                .expect("shadow config");
+        assert_eq!(parsed.sample_rate, 1.0);
+    }
 }
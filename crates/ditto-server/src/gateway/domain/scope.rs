@@ -27,6 +27,12 @@ pub(crate) fn user_scope_key(tenant_id: Option<&str>, user_id: Option<&str>) ->
     Some(format!("user:{user_id}"))
 }
 
+/// Rate-limit scope of a virtual key's MCP tool calls, kept apart from its
+/// model request limits.
+pub(crate) fn mcp_scope_key(key_id: &str) -> String {
+    format!("mcp:{key_id}")
+}
+
 #[cfg(test)]
 mod tests {
     use super::{normalize_scope_id, project_scope_key, tenant_scope_key, user_scope_key};
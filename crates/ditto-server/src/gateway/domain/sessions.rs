@@ -0,0 +1,128 @@
+//! Server-side conversation sessions: the gateway keeps a chat's prior turns
+//! so a client can send a `session_id` with only its new messages.
+
+use serde_json::Value;
+
+/// Retention and size policy for stored sessions.
+#[derive(Clone, Debug, PartialEq, Eq)]
+pub struct SessionConfig {
+    /// A session expires this long after its last turn.
+    pub ttl_seconds: u64,
+    /// Stored messages per session; older turns are dropped first.
+    pub max_messages: usize,
+    /// Serialized JSON bytes per session; older turns are dropped first.
+    pub max_bytes: usize,
+}
+
+impl Default for SessionConfig {
+    fn default() -> Self {
+        Self {
+            ttl_seconds: 24 * 60 * 60,
+            max_messages: 200,
+            max_bytes: 1024 * 1024,
+        }
+    }
+}
+
+impl SessionConfig {
+    pub(crate) fn ttl_ms(&self) -> u64 {
+        self.ttl_seconds.saturating_mul(1000)
+    }
+}
+
+fn message_role(message: &Value) -> Option<&str> {
+    message.get("role").and_then(Value::as_str)
+}
+
+/// Applies `config`'s size limits to a session's messages. Leading `system`
+/// / `developer` messages are kept; after them the oldest messages are
+/// dropped until both limits hold, and then up to the next `user` message so
+/// the history never opens with an orphaned assistant reply or tool result.
+pub fn trim_session_messages(messages: Vec<Value>, config: &SessionConfig) -> Vec<Value> {
+    let sizes: Vec<usize> = messages
+        .iter()
+        .map(|message| serde_json::to_vec(message).map_or(0, |bytes| bytes.len()))
+        .collect();
+    let pinned = messages
+        .iter()
+        .take_while(|message| matches!(message_role(message), Some("system" | "developer")))
+        .count();
+    let pinned_bytes: usize = sizes[..pinned].iter().sum();
+
+    let mut start = pinned;
+    let mut kept_bytes: usize = sizes[pinned..].iter().sum();
+    while start < messages.len()
+        && (pinned + messages.len() - start > config.max_messages
+            || pinned_bytes + kept_bytes > config.max_bytes)
+    {
+        kept_bytes -= sizes[start];
+        start += 1;
+    }
+    if start > pinned {
+        while start < messages.len() && message_role(&messages[start]) != Some("user") {
+            start += 1;
+        }
+    }
+    if start == pinned {
+        return messages;
+    }
+
+    let mut messages = messages;
+    let tail = messages.split_off(start);
+    messages.truncate(pinned);
+    messages.extend(tail);
+    messages
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use serde_json::json;
+
+    fn turn(n: usize) -> [Value; 2] {
+        [
+            json!({"role": "user", "content": format!("q{n}")}),
+            json!({"role": "assistant", "content": format!("a{n}")}),
+        ]
+    }
+
+    fn contents(messages: &[Value]) -> Vec<&str> {
+        messages
+            .iter()
+            .filter_map(|message| message.get("content").and_then(Value::as_str))
+            .collect()
+    }
+
+    #[test]
+    fn trims_oldest_turns_and_keeps_system_prompt() {
+        let mut messages = vec![json!({"role": "system", "content": "sys"})];
+        messages.extend((0..3).flat_map(turn));
+        let config = SessionConfig {
+            max_messages: 4,
+            ..SessionConfig::default()
+        };
+
+        let trimmed = trim_session_messages(messages.clone(), &config);
+        // Dropping to 4 messages would open with `a1`, so all of turn 1 goes.
+        assert_eq!(contents(&trimmed), vec!["sys", "q2", "a2"]);
+
+        let unchanged = trim_session_messages(messages.clone(), &SessionConfig::default());
+        assert_eq!(unchanged, messages);
+    }
+
+    #[test]
+    fn trims_by_serialized_size() {
+        let messages: Vec<Value> = (0..3).flat_map(turn).collect();
+        let turn_bytes: usize = turn(0)
+            .iter()
+            .map(|message| serde_json::to_vec(message).unwrap().len())
+            .sum();
+        let config = SessionConfig {
+            max_bytes: turn_bytes + 1,
+            ..SessionConfig::default()
+        };
+
+        let trimmed = trim_session_messages(messages, &config);
+        assert_eq!(contents(&trimmed), vec!["q2", "a2"]);
+    }
+}
@@ -0,0 +1,258 @@
+use std::collections::BTreeMap;
+
+use serde::{Deserialize, Serialize};
+
+const DAY_MS: u64 = 86_400_000;
+
+/// Calendar bucket (UTC) for time-series spend reports.
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum SpendBucket {
+    Day,
+    /// ISO weeks, starting on Monday.
+    Week,
+    Month,
+}
+
+impl SpendBucket {
+    /// Start of the bucket that contains `ts_ms`, in epoch milliseconds.
+    pub fn start_ms(self, ts_ms: u64) -> u64 {
+        let days = ts_ms / DAY_MS;
+        let start_days = match self {
+            Self::Day => days,
+            // 1970-01-01 was a Thursday; the epoch's own week is clamped.
+            Self::Week => days.saturating_sub((days + 3) % 7),
+            Self::Month => {
+                let (year, month, _) = civil_from_days(days);
+                days_from_civil(year, month, 1)
+            }
+        };
+        start_days * DAY_MS
+    }
+}
+
+/// The dimension a spend report is grouped by.
+#[derive(Clone, Copy, Debug, PartialEq, Eq)]
+pub enum SpendGroupBy {
+    Key,
+    Tenant,
+    Project,
+    User,
+    Model,
+    Tag,
+}
+
+/// One proxied request, attributed to its key, the key's tenant/project/user
+/// and tags, and the requested model.
+#[derive(Clone, Debug, Default)]
+pub struct SpendEntry<'a> {
+    pub ts_ms: u64,
+    pub key_id: Option<&'a str>,
+    pub tenant_id: Option<&'a str>,
+    pub project_id: Option<&'a str>,
+    pub user_id: Option<&'a str>,
+    pub model: Option<&'a str>,
+    pub tags: Vec<&'a str>,
+    pub input_tokens: u64,
+    pub output_tokens: u64,
+    pub spent_tokens: u64,
+    pub spent_usd_micros: u64,
+}
+
+/// Restricts a report to entries whose attribution matches every set field.
+#[derive(Clone, Debug, Default)]
+pub struct SpendFilter {
+    pub key_id: Option<String>,
+    pub tenant_id: Option<String>,
+    pub project_id: Option<String>,
+    pub user_id: Option<String>,
+    pub model: Option<String>,
+    pub tag: Option<String>,
+}
+
+impl SpendFilter {
+    pub fn matches(&self, entry: &SpendEntry<'_>) -> bool {
+        fn field_matches(filter: Option<&String>, value: Option<&str>) -> bool {
+            filter.is_none_or(|filter| value == Some(filter.as_str()))
+        }
+
+        field_matches(self.key_id.as_ref(), entry.key_id)
+            && field_matches(self.tenant_id.as_ref(), entry.tenant_id)
+            && field_matches(self.project_id.as_ref(), entry.project_id)
+            && field_matches(self.user_id.as_ref(), entry.user_id)
+            && field_matches(self.model.as_ref(), entry.model)
+            && self
+                .tag
+                .as_ref()
+                .is_none_or(|tag| entry.tags.contains(&tag.as_str()))
+    }
+}
+
+#[derive(Clone, Debug, Default, PartialEq, Eq, Serialize)]
+pub struct SpendReportRow {
+    /// The key id, tenant, project, user, model or tag; `None` collects
+    /// requests without one.
+    pub group: Option<String>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub bucket_start_ms: Option<u64>,
+    pub requests: u64,
+    pub input_tokens: u64,
+    pub output_tokens: u64,
+    pub spent_tokens: u64,
+    pub spent_usd_micros: u64,
+}
+
+/// Accumulates entries into one row per group (and bucket). With
+/// [`SpendGroupBy::Tag`], a request with several tags counts towards each.
+#[derive(Clone, Debug)]
+pub struct SpendReport {
+    group_by: SpendGroupBy,
+    bucket: Option<SpendBucket>,
+    rows: BTreeMap<(Option<u64>, Option<String>), SpendReportRow>,
+}
+
+impl SpendReport {
+    pub fn new(group_by: SpendGroupBy, bucket: Option<SpendBucket>) -> Self {
+        Self {
+            group_by,
+            bucket,
+            rows: BTreeMap::new(),
+        }
+    }
+
+    pub fn record(&mut self, entry: &SpendEntry<'_>) {
+        let bucket_start_ms = self.bucket.map(|bucket| bucket.start_ms(entry.ts_ms));
+        let groups = match self.group_by {
+            SpendGroupBy::Key => vec![entry.key_id],
+            SpendGroupBy::Tenant => vec![entry.tenant_id],
+            SpendGroupBy::Project => vec![entry.project_id],
+            SpendGroupBy::User => vec![entry.user_id],
+            SpendGroupBy::Model => vec![entry.model],
+            SpendGroupBy::Tag if entry.tags.is_empty() => vec![None],
+            SpendGroupBy::Tag => entry.tags.iter().copied().map(Some).collect(),
+        };
+        for group in groups {
+            let group = group.map(str::to_string);
+            let row = self
+                .rows
+                .entry((bucket_start_ms, group.clone()))
+                .or_insert_with(|| SpendReportRow {
+                    group,
+                    bucket_start_ms,
+                    ..SpendReportRow::default()
+                });
+            row.requests = row.requests.saturating_add(1);
+            row.input_tokens = row.input_tokens.saturating_add(entry.input_tokens);
+            row.output_tokens = row.output_tokens.saturating_add(entry.output_tokens);
+            row.spent_tokens = row.spent_tokens.saturating_add(entry.spent_tokens);
+            row.spent_usd_micros = row.spent_usd_micros.saturating_add(entry.spent_usd_micros);
+        }
+    }
+
+    /// Rows in bucket order, highest spend first within a bucket.
+    pub fn into_rows(self) -> Vec<SpendReportRow> {
+        let mut rows: Vec<_> = self.rows.into_values().collect();
+        rows.sort_by(|a, b| {
+            a.bucket_start_ms
+                .cmp(&b.bucket_start_ms)
+                .then(b.spent_usd_micros.cmp(&a.spent_usd_micros))
+                .then(b.spent_tokens.cmp(&a.spent_tokens))
+                .then_with(|| a.group.cmp(&b.group))
+        });
+        rows
+    }
+}
+
+// Howard Hinnant's days <-> civil date conversions, for days since 1970-01-01.
+pub(crate) fn civil_from_days(days: u64) -> (u64, u64, u64) {
+    let z = days + 719_468;
+    let era = z / 146_097;
+    let doe = z - era * 146_097;
+    let yoe = (doe - doe / 1460 + doe / 36_524 - doe / 146_096) / 365;
+    let doy = doe - (365 * yoe + yoe / 4 - yoe / 100);
+    let mp = (5 * doy + 2) / 153;
+    let day = doy - (153 * mp + 2) / 5 + 1;
+    let month = if mp < 10 { mp + 3 } else { mp - 9 };
+    let year = yoe + era * 400 + u64::from(month <= 2);
+    (year, month, day)
+}
+
+pub(crate) fn days_from_civil(year: u64, month: u64, day: u64) -> u64 {
+    let year = if month <= 2 { year - 1 } else { year };
+    let era = year / 400;
+    let yoe = year - era * 400;
+    let mp = if month > 2 { month - 3 } else { month + 9 };
+    let doy = (153 * mp + 2) / 5 + day - 1;
+    let doe = yoe * 365 + yoe / 4 - yoe / 100 + doy;
+    era * 146_097 + doe - 719_468
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    // 2024-02-29T13:00:00Z, a Thursday.
+    const TS_MS: u64 = 1_709_211_600_000;
+
+    #[test]
+    fn buckets_start_at_utc_day_iso_week_and_month() {
+        assert_eq!(SpendBucket::Day.start_ms(TS_MS), 1_709_164_800_000);
+        // Monday 2024-02-26.
+        assert_eq!(SpendBucket::Week.start_ms(TS_MS), 1_708_905_600_000);
+        // 2024-02-01.
+        assert_eq!(SpendBucket::Month.start_ms(TS_MS), 1_706_745_600_000);
+        assert_eq!(SpendBucket::Month.start_ms(0), 0);
+        assert_eq!(SpendBucket::Week.start_ms(0), 0);
+    }
+
+    #[test]
+    fn groups_by_tag_and_bucket_with_filters() {
+        let entry = |ts_ms, key_id, tags: &[&'static str], spent_usd_micros| SpendEntry {
+            ts_ms,
+            key_id: Some(key_id),
+            model: Some("gpt-4o-mini"),
+            tags: tags.to_vec(),
+            spent_tokens: 10,
+            spent_usd_micros,
+            ..SpendEntry::default()
+        };
+        let entries = [
+            entry(TS_MS, "key-1", &["search", "prod"], 100),
+            entry(TS_MS + DAY_MS, "key-2", &["prod"], 50),
+            entry(TS_MS, "key-3", &[], 7),
+        ];
+
+        let mut report = SpendReport::new(SpendGroupBy::Tag, None);
+        for entry in &entries {
+            report.record(entry);
+        }
+        let rows = report.into_rows();
+        let summary: Vec<_> = rows
+            .iter()
+            .map(|row| (row.group.as_deref(), row.requests, row.spent_usd_micros))
+            .collect();
+        assert_eq!(
+            summary,
+            vec![
+                (Some("prod"), 2, 150),
+                (Some("search"), 1, 100),
+                (None, 1, 7)
+            ]
+        );
+
+        let filter = SpendFilter {
+            tag: Some("prod".to_string()),
+            ..SpendFilter::default()
+        };
+        let mut report = SpendReport::new(SpendGroupBy::Key, Some(SpendBucket::Day));
+        for entry in entries.iter().filter(|entry| filter.matches(entry)) {
+            report.record(entry);
+        }
+        let rows = report.into_rows();
+        assert_eq!(rows.len(), 2);
+        assert_eq!(rows[0].group.as_deref(), Some("key-1"));
+        assert_eq!(rows[0].bucket_start_ms, Some(1_709_164_800_000));
+        assert_eq!(rows[1].group.as_deref(), Some("key-2"));
+        assert_eq!(rows[1].bucket_start_ms, Some(1_709_164_800_000 + DAY_MS));
+    }
+}
@@ -1,6 +1,8 @@
 use async_trait::async_trait;
+use serde_json::Value;
 use thiserror::Error;
 
+use super::fine_tuning::FineTuningJobRecord;
 use super::store_types::{
     ProxyRequestFingerprint, ProxyRequestIdempotencyBeginOutcome, ProxyRequestIdempotencyRecord,
     ProxyRequestReplayOutcome,
@@ -73,3 +75,70 @@ pub trait ProxyRequestIdempotencyStore: Send + Sync {
         owner_token: &str,
     ) -> Result<bool, ProxyRequestIdempotencyStoreError>;
 }
+
+#[derive(Clone, Debug, Error)]
+#[error("{message}")]
+pub struct SessionStoreError {
+    message: String,
+}
+
+impl SessionStoreError {
+    pub fn new(message: impl Into<String>) -> Self {
+        Self {
+            message: message.into(),
+        }
+    }
+}
+
+/// Persists conversation sessions: the messages of a chat, keyed by the
+/// virtual key id and client `session_id`, until `expires_at_ms`.
+#[async_trait]
+pub trait SessionStore: Send + Sync {
+    async fn load_session_messages(
+        &self,
+        session_key: &str,
+        now_ms: u64,
+    ) -> Result<Option<Vec<Value>>, SessionStoreError>;
+
+    async fn save_session_messages(
+        &self,
+        session_key: &str,
+        messages: &[Value],
+        now_ms: u64,
+        ttl_ms: u64,
+    ) -> Result<(), SessionStoreError>;
+}
+
+#[derive(Clone, Debug, Error)]
+#[error("{message}")]
+pub struct FineTuningJobStoreError {
+    message: String,
+}
+
+impl FineTuningJobStoreError {
+    pub fn new(message: impl Into<String>) -> Self {
+        Self {
+            message: message.into(),
+        }
+    }
+}
+
+/// Persists who created each fine-tuning job, keyed by provider job id.
+#[async_trait]
+pub trait FineTuningJobStore: Send + Sync {
+    async fn load_fine_tuning_job(
+        &self,
+        job_id: &str,
+    ) -> Result<Option<FineTuningJobRecord>, FineTuningJobStoreError>;
+
+    /// Every job recorded for `owner`, newest first.
+    async fn list_fine_tuning_jobs(
+        &self,
+        owner: &str,
+    ) -> Result<Vec<FineTuningJobRecord>, FineTuningJobStoreError>;
+
+    async fn save_fine_tuning_job(
+        &self,
+        record: &FineTuningJobRecord,
+    ) -> Result<(), FineTuningJobStoreError>;
+}
@@ -0,0 +1,507 @@
+use std::collections::HashSet;
+use std::sync::Arc;
+
+use regex::{Regex, RegexBuilder};
+use serde::{Deserialize, Serialize};
+use serde_json::Value;
+
+use super::guardrails::visit_hook_text;
+
+/// Rewrites the events of one streaming response as they pass through the
+/// gateway. A fresh instance is created per response, so implementations can
+/// keep state across events; nothing is buffered beyond the current event.
+pub trait StreamTransform: Send {
+    /// Inspects or rewrites one decoded SSE `data:` payload in place.
+    fn transform(&mut self, event: &mut Value) -> StreamEventAction;
+}
+
+/// Whether the (possibly rewritten) event is forwarded to the client.
+#[derive(Clone, Copy, Debug, PartialEq, Eq)]
+pub enum StreamEventAction {
+    Forward,
+    Drop,
+}
+
+/// Creates the per-response instance of a transform registered in code with
+/// `GatewayHttpState::with_stream_transform`.
+pub type StreamTransformFactory = Arc<dyn Fn() -> Box<dyn StreamTransform> + Send + Sync>;
+
+/// A transform applied to each event of a streaming response, in list order
+/// and after `during_stream` hooks.
+#[derive(Clone, Debug, Serialize, Deserialize)]
+#[serde(tag = "type", rename_all = "snake_case")]
+pub enum StreamTransformConfig {
+    /// Replaces case-insensitive phrase or regex matches in streamed text.
+    /// Matching is per event, so text split across two events is not matched.
+    Redact {
+        #[serde(default, skip_serializing_if = "Vec::is_empty")]
+        phrases: Vec<String>,
+        #[serde(default, skip_serializing_if = "Vec::is_empty")]
+        regexes: Vec<String>,
+        /// Defaults to `[REDACTED]`.
+        #[serde(default, skip_serializing_if = "Option::is_none")]
+        replacement: Option<String>,
+    },
+    /// Adds `text` to the generated output: before the first content delta,
+    /// or with the chunk that carries `finish_reason`.
+    Watermark {
+        text: String,
+        #[serde(default)]
+        position: WatermarkPosition,
+    },
+    /// Removes reasoning output: reasoning fields on chat deltas, reasoning
+    /// events of Responses and Anthropic streams, and `<think>` blocks in
+    /// streamed content.
+    StripReasoning,
+    /// A transform registered in code under `name`.
+    Custom { name: String },
+}
+
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum WatermarkPosition {
+    Prefix,
+    #[default]
+    Suffix,
+}
+
+impl StreamTransformConfig {
+    pub fn validate(&self) -> Result<(), String> {
+        match self {
+            Self::Redact {
+                phrases, regexes, ..
+            } => {
+                if phrases
+                    .iter()
+                    .chain(regexes)
+                    .all(|pattern| pattern.trim().is_empty())
+                {
+                    return Err("redact stream transform must list phrases or regexes".to_string());
+                }
+                redact_regexes(phrases, regexes).map(|_| ())
+            }
+            Self::Watermark { text, .. } if text.is_empty() => {
+                Err("watermark stream transform needs text".to_string())
+            }
+            Self::Custom { name } if name.trim().is_empty() => {
+                Err("custom stream transform needs a name".to_string())
+            }
+            _ => Ok(()),
+        }
+    }
+
+    /// Builds the per-response transform, or `None` for `custom` transforms,
+    /// which are looked up by name instead.
+    pub fn build(&self) -> Option<Box<dyn StreamTransform>> {
+        match self {
+            Self::Redact {
+                phrases,
+                regexes,
+                replacement,
+            } => Some(Box::new(RedactTransform {
+                // Invalid patterns are rejected by `validate`.
+                regexes: redact_regexes(phrases, regexes).ok()?,
+                replacement: replacement
+                    .clone()
+                    .unwrap_or_else(|| "[REDACTED]".to_string()),
+            })),
+            Self::Watermark { text, position } => Some(Box::new(WatermarkTransform {
+                text: text.clone(),
+                position: *position,
+                done: false,
+            })),
+            Self::StripReasoning => Some(Box::new(StripReasoningTransform::default())),
+            Self::Custom { .. } => None,
+        }
+    }
+}
+
+fn redact_regexes(phrases: &[String], regexes: &[String]) -> Result<Vec<Regex>, String> {
+    let phrases = phrases
+        .iter()
+        .map(|phrase| phrase.trim())
+        .filter(|phrase| !phrase.is_empty())
+        .map(|phrase| (regex::escape(phrase), phrase));
+    let regexes = regexes
+        .iter()
+        .map(|pattern| pattern.trim())
+        .filter(|pattern| !pattern.is_empty())
+        .map(|pattern| (pattern.to_string(), pattern));
+    phrases
+        .chain(regexes)
+        .map(|(pattern, raw)| {
+            RegexBuilder::new(&pattern)
+                .case_insensitive(true)
+                .build()
+                .map_err(|err| format!("invalid redact regex {raw}: {err}"))
+        })
+        .collect()
+}
+
+struct RedactTransform {
+    regexes: Vec<Regex>,
+    replacement: String,
+}
+
+impl StreamTransform for RedactTransform {
+    fn transform(&mut self, event: &mut Value) -> StreamEventAction {
+        visit_hook_text(event, false, &mut |text| {
+            for regex in &self.regexes {
+                if regex.is_match(text) {
+                    *text = regex
+                        .replace_all(text, self.replacement.as_str())
+                        .into_owned();
+                }
+            }
+            true
+        });
+        StreamEventAction::Forward
+    }
+}
+
+struct WatermarkTransform {
+    text: String,
+    position: WatermarkPosition,
+    done: bool,
+}
+
+impl StreamTransform for WatermarkTransform {
+    fn transform(&mut self, event: &mut Value) -> StreamEventAction {
+        if self.done {
+            return StreamEventAction::Forward;
+        }
+        match self.position {
+            WatermarkPosition::Prefix => {
+                if let Some(text) = output_text(event) {
+                    text.insert_str(0, &self.text);
+                    self.done = true;
+                }
+            }
+            WatermarkPosition::Suffix => {
+                if let Some(text) = finishing_choice_text(event) {
+                    text.push_str(&self.text);
+                    self.done = true;
+                }
+            }
+        }
+        StreamEventAction::Forward
+    }
+}
+
+#[derive(Default)]
+struct StripReasoningTransform {
+    think: ThinkBlockFilter,
+    /// Anthropic content block indices that carry thinking.
+    thinking_blocks: HashSet<u64>,
+}
+
+impl StreamTransform for StripReasoningTransform {
+    fn transform(&mut self, event: &mut Value) -> StreamEventAction {
+        let event_type = event
+            .get("type")
+            .and_then(Value::as_str)
+            .unwrap_or_default()
+            .to_string();
+
+        // Responses API.
+        if event_type.starts_with("response.reasoning") {
+            return StreamEventAction::Drop;
+        }
+        if matches!(
+            event_type.as_str(),
+            "response.output_item.added" | "response.output_item.done"
+        ) && event.pointer("/item/type").and_then(Value::as_str) == Some("reasoning")
+        {
+            return StreamEventAction::Drop;
+        }
+        if let Some(output) = event
+            .pointer_mut("/response/output")
+            .and_then(Value::as_array_mut)
+        {
+            output.retain(|item| item.get("type").and_then(Value::as_str) != Some("reasoning"));
+        }
+
+        // Anthropic Messages.
+        let block_index = event.get("index").and_then(Value::as_u64);
+        match (event_type.as_str(), block_index) {
+            ("content_block_start", Some(index))
+                if matches!(
+                    event.pointer("/content_block/type").and_then(Value::as_str),
+                    Some("thinking" | "redacted_thinking")
+                ) =>
+            {
+                self.thinking_blocks.insert(index);
+                return StreamEventAction::Drop;
+            }
+            ("content_block_delta", Some(index)) if self.thinking_blocks.contains(&index) => {
+                return StreamEventAction::Drop;
+            }
+            ("content_block_stop", Some(index)) if self.thinking_blocks.remove(&index) => {
+                return StreamEventAction::Drop;
+            }
+            _ => {}
+        }
+
+        // Chat/completions.
+        if let Some(choices) = event.get_mut("choices").and_then(Value::as_array_mut) {
+            for choice in choices {
+                if let Some(delta) = choice.get_mut("delta").and_then(Value::as_object_mut) {
+                    delta.remove("reasoning_content");
+                    delta.remove("reasoning");
+                    delta.remove("reasoning_details");
+                }
+            }
+        }
+
+        if let Some(text) = output_text(event) {
+            *text = self.think.filter(text);
+        }
+        if let Some(text) = finishing_choice_text(event) {
+            text.push_str(&self.think.flush());
+        }
+        StreamEventAction::Forward
+    }
+}
+
+/// Drops `<think>...</think>` spans from text that arrives in pieces. A
+/// trailing fragment that may start a tag is held back until the next piece.
+#[derive(Default)]
+struct ThinkBlockFilter {
+    inside: bool,
+    pending: String,
+}
+
+impl ThinkBlockFilter {
+    const OPEN: &'static str = "<think>";
+    const CLOSE: &'static str = "</think>";
+
+    fn filter(&mut self, text: &str) -> String {
+        let mut input = std::mem::take(&mut self.pending);
+        input.push_str(text);
+        let mut out = String::new();
+        let mut rest = input.as_str();
+        loop {
+            let tag = if self.inside { Self::CLOSE } else { Self::OPEN };
+            if let Some(pos) = rest.find(tag) {
+                if !self.inside {
+                    out.push_str(&rest[..pos]);
+                }
+                rest = &rest[pos + tag.len()..];
+                self.inside = !self.inside;
+                continue;
+            }
+            let held = (1..tag.len())
+                .rev()
+                .find(|len| rest.ends_with(&tag[..*len]))
+                .unwrap_or(0);
+            let (emit, hold) = rest.split_at(rest.len() - held);
+            if !self.inside {
+                out.push_str(emit);
+            }
+            self.pending = hold.to_string();
+            return out;
+        }
+    }
+
+    /// Releases a held-back fragment that turned out not to be a tag.
+    fn flush(&mut self) -> String {
+        if self.inside {
+            self.pending.clear();
+            return String::new();
+        }
+        std::mem::take(&mut self.pending)
+    }
+}
+
+/// The generated text carried by a streaming event: a chat delta's
+/// `content`, a completion's `text`, a Responses `output_text` delta or an
+/// Anthropic `text_delta`.
+fn output_text(event: &mut Value) -> Option<&mut String> {
+    let event_type = event.get("type").and_then(Value::as_str);
+    if event_type == Some("response.output_text.delta") {
+        return string_mut(event.get_mut("delta"));
+    }
+    if event_type == Some("content_block_delta") {
+        let delta = event.get_mut("delta")?;
+        if delta.get("type").and_then(Value::as_str) != Some("text_delta") {
+            return None;
+        }
+        return string_mut(delta.get_mut("text"));
+    }
+    let choice = event.get_mut("choices")?.get_mut(0)?;
+    if choice.get("text").is_some() {
+        return string_mut(choice.get_mut("text"));
+    }
+    let delta = choice.get_mut("delta")?;
+    if delta.get("content").is_some_and(Value::is_string) {
+        return string_mut(delta.get_mut("content"));
+    }
+    None
+}
+
+/// The text of the first choice of a chat/completions chunk that carries
+/// `finish_reason`, created if the chunk has none.
+fn finishing_choice_text(event: &mut Value) -> Option<&mut String> {
+    let choice = event.get_mut("choices")?.get_mut(0)?;
+    if choice.get("finish_reason").is_none_or(Value::is_null) {
+        return None;
+    }
+    let choice = choice.as_object_mut()?;
+    if choice.contains_key("text") {
+        return string_mut(choice.get_mut("text"));
+    }
+    let delta = choice
+        .entry("delta")
+        .or_insert_with(|| Value::Object(Default::default()))
+        .as_object_mut()?;
+    let content = delta
+        .entry("content")
+        .or_insert_with(|| Value::String(String::new()));
+    if content.is_null() {
+        *content = Value::String(String::new());
+    }
+    string_mut(Some(content))
+}
+
+fn string_mut(value: Option<&mut Value>) -> Option<&mut String> {
+    match value? {
+        Value::String(text) => Some(text),
+        _ => None,
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+    use serde_json::json;
+
+    fn run(config: &StreamTransformConfig, events: Vec<Value>) -> Vec<Value> {
+        let mut transform = config.build().expect("built-in transform");
+        events
+            .into_iter()
+            .filter_map(|mut event| {
+                (transform.transform(&mut event) == StreamEventAction::Forward).then_some(event)
+            })
+            .collect()
+    }
+
+    fn chat_chunk(content: &str, finish_reason: Option<&str>) -> Value {
+        json!({
+            "object": "chat.completion.chunk",
+            "choices": [{"index": 0, "delta": {"content": content}, "finish_reason": finish_reason}]
+        })
+    }
+
+    #[test]
+    fn validates_stream_transforms() {
+        let config: StreamTransformConfig =
+            serde_json::from_value(json!({"type": "redact"})).expect("parse");
+        assert!(config.validate().is_err());
+        let config: StreamTransformConfig =
+            serde_json::from_value(json!({"type": "redact", "regexes": ["("]})).expect("parse");
+        assert!(config.validate().is_err());
+        let config: StreamTransformConfig =
+            serde_json::from_value(json!({"type": "strip_reasoning"})).expect("parse");
+        assert!(config.validate().is_ok());
+        let config: StreamTransformConfig =
+            serde_json::from_value(json!({"type": "custom", "name": " "})).expect("parse");
+        assert!(config.validate().is_err());
+    }
+
+    #[test]
+    fn redacts_and_watermarks_chat_chunks() {
+        let redact = StreamTransformConfig::Redact {
+            phrases: vec!["Secret".to_string()],
+            regexes: Vec::new(),
+            replacement: None,
+        };
+        let events = run(&redact, vec![chat_chunk("a SECRET plan", None)]);
+        assert_eq!(
+            events[0]["choices"][0]["delta"]["content"],
+            "a [REDACTED] plan"
+        );
+
+        let suffix = StreamTransformConfig::Watermark {
+            text: " [ai]".to_string(),
+            position: WatermarkPosition::Suffix,
+        };
+        let finish = json!({"choices": [{"index": 0, "delta": {}, "finish_reason": "stop"}]});
+        let events = run(&suffix, vec![chat_chunk("hi", None), finish]);
+        assert_eq!(events[0]["choices"][0]["delta"]["content"], "hi");
+        assert_eq!(events[1]["choices"][0]["delta"]["content"], " [ai]");
+
+        let prefix = StreamTransformConfig::Watermark {
+            text: "[ai] ".to_string(),
+            position: WatermarkPosition::Prefix,
+        };
+        let role = json!({"choices": [{"index": 0, "delta": {"role": "assistant"}}]});
+        let events = run(
+            &prefix,
+            vec![role, chat_chunk("hi", None), chat_chunk("!", None)],
+        );
+        assert_eq!(events[1]["choices"][0]["delta"]["content"], "[ai] hi");
+        assert_eq!(events[2]["choices"][0]["delta"]["content"], "!");
+    }
+
+    #[test]
+    fn strips_reasoning_from_each_stream_shape() {
+        let config = StreamTransformConfig::StripReasoning;
+
+        let chat = json!({
+            "choices": [{"index": 0, "delta": {"reasoning_content": "hmm", "content": "ok"}}]
+        });
+        let events = run(&config, vec![chat]);
+        assert_eq!(events[0]["choices"][0]["delta"], json!({"content": "ok"}));
+
+        let responses = vec![
+            json!({"type": "response.output_item.added", "item": {"type": "reasoning"}}),
+            json!({"type": "response.reasoning_summary_text.delta", "delta": "hmm"}),
+            json!({"type": "response.output_text.delta", "delta": "ok"}),
+            json!({"type": "response.completed", "response": {"output": [
+                {"type": "reasoning"},
+                {"type": "message"}
+            ]}}),
+        ];
+        let events = run(&config, responses);
+        assert_eq!(events.len(), 2);
+        assert_eq!(events[0]["delta"], "ok");
+        assert_eq!(
+            events[1]["response"]["output"],
+            json!([{"type": "message"}])
+        );
+
+        let anthropic = vec![
+            json!({"type": "content_block_start", "index": 0, "content_block": {"type": "thinking"}}),
+            json!({"type": "content_block_delta", "index": 0, "delta": {"type": "thinking_delta", "thinking": "hmm"}}),
+            json!({"type": "content_block_stop", "index": 0}),
+            json!({"type": "content_block_start", "index": 1, "content_block": {"type": "text"}}),
+            json!({"type": "content_block_delta", "index": 1, "delta": {"type": "text_delta", "text": "ok"}}),
+            json!({"type": "content_block_stop", "index": 1}),
+        ];
+        let events = run(&config, anthropic);
+        assert_eq!(events.len(), 3);
+        assert_eq!(events[1]["delta"]["text"], "ok");
+    }
+
+    #[test]
+    fn strips_think_blocks_split_across_chunks() {
+        let events = run(
+            &StreamTransformConfig::StripReasoning,
+            vec![
+                chat_chunk("<thi", None),
+                chat_chunk("nk>plan</th", None),
+                chat_chunk("ink>Answer <", None),
+                chat_chunk("3", Some("stop")),
+            ],
+        );
+        let text = events
+            .iter()
+            .map(|event| {
+                event["choices"][0]["delta"]["content"]
+                    .as_str()
+                    .unwrap_or_default()
+            })
+            .collect::<String>();
+        assert_eq!(text, "Answer <3");
+    }
+}
@@ -0,0 +1,507 @@
+//! Daily usage rollups for data-warehouse export, and their CSV and Parquet
+//! encodings. Both carry [`USAGE_EXPORT_SCHEMA_VERSION`] in every row and in
+//! the object path, so loaders can tell layouts apart when columns change.
+
+use std::collections::BTreeMap;
+
+use super::spend_report::{SpendEntry, civil_from_days};
+
+/// Bump when columns are added, removed or change meaning.
+pub const USAGE_EXPORT_SCHEMA_VERSION: u32 = 1;
+
+const DAY_MS: u64 = 86_400_000;
+
+const COLUMNS: [&str; 12] = [
+    "schema_version",
+    "date",
+    "key_id",
+    "tenant_id",
+    "project_id",
+    "user_id",
+    "model",
+    "requests",
+    "input_tokens",
+    "output_tokens",
+    "spent_tokens",
+    "spent_usd_micros",
+];
+
+/// One UTC day of usage for a key, its tenant/project/user at request time,
+/// and a model.
+#[derive(Clone, Debug, Default, PartialEq, Eq)]
+pub struct UsageRollupRow {
+    /// Days since 1970-01-01.
+    pub day: u64,
+    pub key_id: Option<String>,
+    pub tenant_id: Option<String>,
+    pub project_id: Option<String>,
+    pub user_id: Option<String>,
+    pub model: Option<String>,
+    pub requests: u64,
+    pub input_tokens: u64,
+    pub output_tokens: u64,
+    pub spent_tokens: u64,
+    pub spent_usd_micros: u64,
+}
+
+type RollupKey = (
+    u64,
+    Option<String>,
+    Option<String>,
+    Option<String>,
+    Option<String>,
+    Option<String>,
+);
+
+#[derive(Clone, Debug, Default)]
+pub struct UsageRollup {
+    rows: BTreeMap<RollupKey, UsageRollupRow>,
+}
+
+impl UsageRollup {
+    pub fn record(&mut self, entry: &SpendEntry<'_>) {
+        let owned = |value: Option<&str>| value.map(str::to_string);
+        let key = (
+            entry.ts_ms / DAY_MS,
+            owned(entry.key_id),
+            owned(entry.tenant_id),
+            owned(entry.project_id),
+            owned(entry.user_id),
+            owned(entry.model),
+        );
+        let row = self
+            .rows
+            .entry(key)
+            .or_insert_with_key(|key| UsageRollupRow {
+                day: key.0,
+                key_id: key.1.clone(),
+                tenant_id: key.2.clone(),
+                project_id: key.3.clone(),
+                user_id: key.4.clone(),
+                model: key.5.clone(),
+                ..UsageRollupRow::default()
+            });
+        row.requests = row.requests.saturating_add(1);
+        row.input_tokens = row.input_tokens.saturating_add(entry.input_tokens);
+        row.output_tokens = row.output_tokens.saturating_add(entry.output_tokens);
+        row.spent_tokens = row.spent_tokens.saturating_add(entry.spent_tokens);
+        row.spent_usd_micros = row.spent_usd_micros.saturating_add(entry.spent_usd_micros);
+    }
+
+    /// Rows ordered by day, then key, tenant, project, user and model.
+    pub fn into_rows(self) -> Vec<UsageRollupRow> {
+        self.rows.into_values().collect()
+    }
+}
+
+/// `YYYY-MM-DD` of a day since the epoch.
+pub fn usage_export_date(day: u64) -> String {
+    let (year, month, day) = civil_from_days(day);
+    format!("{year:04}-{month:02}-{day:02}")
+}
+
+/// Object path of one day's export below the destination, Hive-partitioned
+/// by schema version and date.
+pub fn usage_export_object_path(day: u64, extension: &str) -> String {
+    format!(
+        "v{USAGE_EXPORT_SCHEMA_VERSION}/date={}/usage.{extension}",
+        usage_export_date(day)
+    )
+}
+
+pub fn encode_usage_csv(rows: &[UsageRollupRow]) -> Vec<u8> {
+    fn field(value: Option<&str>) -> String {
+        let value = value.unwrap_or_default();
+        if !value.contains([',', '"', '\n', '\r']) {
+            return value.to_string();
+        }
+        format!("\"{}\"", value.replace('"', "\"\""))
+    }
+
+    let mut out = COLUMNS.join(",");
+    out.push('\n');
+    for row in rows {
+        let line = [
+            USAGE_EXPORT_SCHEMA_VERSION.to_string(),
+            usage_export_date(row.day),
+            field(row.key_id.as_deref()),
+            field(row.tenant_id.as_deref()),
+            field(row.project_id.as_deref()),
+            field(row.user_id.as_deref()),
+            field(row.model.as_deref()),
+            row.requests.to_string(),
+            row.input_tokens.to_string(),
+            row.output_tokens.to_string(),
+            row.spent_tokens.to_string(),
+            row.spent_usd_micros.to_string(),
+        ];
+        out.push_str(&line.join(","));
+        out.push('\n');
+    }
+    out.into_bytes()
+}
+
+/// A single-row-group, uncompressed, PLAIN-encoded Parquet file. The schema
+/// version is also stored as `ditto.usage_export.schema_version` file
+/// metadata.
+pub fn encode_usage_parquet(rows: &[UsageRollupRow]) -> Vec<u8> {
+    let strings = |field: fn(&UsageRollupRow) -> Option<&str>| {
+        ParquetColumn::OptionalString(rows.iter().map(field).collect())
+    };
+    let counts = |field: fn(&UsageRollupRow) -> u64| {
+        ParquetColumn::Int64(rows.iter().map(|row| field(row) as i64).collect())
+    };
+    let columns = [
+        ParquetColumn::Int32(vec![USAGE_EXPORT_SCHEMA_VERSION as i32; rows.len()]),
+        ParquetColumn::Date(rows.iter().map(|row| row.day as i32).collect()),
+        strings(|row| row.key_id.as_deref()),
+        strings(|row| row.tenant_id.as_deref()),
+        strings(|row| row.project_id.as_deref()),
+        strings(|row| row.user_id.as_deref()),
+        strings(|row| row.model.as_deref()),
+        counts(|row| row.requests),
+        counts(|row| row.input_tokens),
+        counts(|row| row.output_tokens),
+        counts(|row| row.spent_tokens),
+        counts(|row| row.spent_usd_micros),
+    ];
+
+    let mut out = b"PAR1".to_vec();
+    let mut chunks = Vec::new();
+    if !rows.is_empty() {
+        for (name, column) in COLUMNS.iter().zip(&columns) {
+            let data = column.page_data();
+            let mut header = ThriftWriter::default();
+            header.i32(1, 0); // DATA_PAGE
+            header.i32(2, data.len() as i32);
+            header.i32(3, data.len() as i32);
+            header.begin_struct(5);
+            header.i32(1, rows.len() as i32);
+            header.i32(2, 0); // PLAIN
+            header.i32(3, 3); // RLE definition levels
+            header.i32(4, 3); // RLE repetition levels
+            header.end_struct();
+            header.stop();
+
+            let offset = out.len() as i64;
+            let size = (header.out.len() + data.len()) as i64;
+            out.extend_from_slice(&header.out);
+            out.extend_from_slice(&data);
+            chunks.push((name, column.physical_type(), offset, size));
+        }
+    }
+
+    let mut meta = ThriftWriter::default();
+    meta.i32(1, 1);
+    meta.begin_list(2, THRIFT_STRUCT, COLUMNS.len() + 1);
+    meta.binary(4, b"schema");
+    meta.i32(5, COLUMNS.len() as i32);
+    meta.stop();
+    for (name, column) in COLUMNS.iter().zip(&columns) {
+        meta.i32(1, column.physical_type());
+        meta.i32(
+            3,
+            i32::from(matches!(column, ParquetColumn::OptionalString(_))),
+        );
+        meta.binary(4, name.as_bytes());
+        if let Some(converted_type) = column.converted_type() {
+            meta.i32(6, converted_type);
+        }
+        meta.stop();
+    }
+    meta.end_list();
+    meta.i64(3, rows.len() as i64);
+    meta.begin_list(4, THRIFT_STRUCT, usize::from(!rows.is_empty()));
+    if !rows.is_empty() {
+        meta.begin_list(1, THRIFT_STRUCT, chunks.len());
+        for (name, physical_type, offset, size) in &chunks {
+            meta.i64(2, *offset);
+            meta.begin_struct(3);
+            meta.i32(1, *physical_type);
+            meta.begin_list(2, THRIFT_I32, 2);
+            meta.list_i32(0); // PLAIN
+            meta.list_i32(3); // RLE
+            meta.end_list();
+            meta.begin_list(3, THRIFT_BINARY, 1);
+            meta.list_binary(name.as_bytes());
+            meta.end_list();
+            meta.i32(4, 0); // UNCOMPRESSED
+            meta.i64(5, rows.len() as i64);
+            meta.i64(6, *size);
+            meta.i64(7, *size);
+            meta.i64(9, *offset);
+            meta.end_struct();
+            meta.stop();
+        }
+        meta.end_list();
+        meta.i64(2, chunks.iter().map(|chunk| chunk.3).sum());
+        meta.i64(3, rows.len() as i64);
+        meta.stop();
+    }
+    meta.end_list();
+    meta.begin_list(5, THRIFT_STRUCT, 1);
+    meta.binary(1, b"ditto.usage_export.schema_version");
+    meta.binary(2, USAGE_EXPORT_SCHEMA_VERSION.to_string().as_bytes());
+    meta.stop();
+    meta.end_list();
+    meta.binary(6, b"ditto-gateway");
+    meta.stop();
+
+    out.extend_from_slice(&meta.out);
+    out.extend_from_slice(&(meta.out.len() as u32).to_le_bytes());
+    out.extend_from_slice(b"PAR1");
+    out
+}
+
+enum ParquetColumn<'a> {
+    Int32(Vec<i32>),
+    Date(Vec<i32>),
+    Int64(Vec<i64>),
+    OptionalString(Vec<Option<&'a str>>),
+}
+
+impl ParquetColumn<'_> {
+    fn physical_type(&self) -> i32 {
+        match self {
+            Self::Int32(_) | Self::Date(_) => 1,
+            Self::Int64(_) => 2,
+            Self::OptionalString(_) => 6,
+        }
+    }
+
+    fn converted_type(&self) -> Option<i32> {
+        match self {
+            Self::OptionalString(_) => Some(0), // UTF8
+            Self::Date(_) => Some(6),
+            Self::Int32(_) | Self::Int64(_) => None,
+        }
+    }
+
+    /// Definition levels (optional columns only) followed by the PLAIN
+    /// values of the non-null entries.
+    fn page_data(&self) -> Vec<u8> {
+        let mut data = Vec::new();
+        match self {
+            Self::Int32(values) | Self::Date(values) => {
+                for value in values {
+                    data.extend_from_slice(&value.to_le_bytes());
+                }
+            }
+            Self::Int64(values) => {
+                for value in values {
+                    data.extend_from_slice(&value.to_le_bytes());
+                }
+            }
+            Self::OptionalString(values) => {
+                // RLE runs of bit width 1: a varint `run_len << 1`, then the
+                // level in one byte.
+                let mut levels = Vec::new();
+                let mut idx = 0;
+                while idx < values.len() {
+                    let defined = values[idx].is_some();
+                    let run = values[idx..]
+                        .iter()
+                        .take_while(|value| value.is_some() == defined)
+                        .count();
+                    write_varint(&mut levels, (run as u64) << 1);
+                    levels.push(u8::from(defined));
+                    idx += run;
+                }
+                data.extend_from_slice(&(levels.len() as u32).to_le_bytes());
+                data.extend_from_slice(&levels);
+                for value in values.iter().flatten() {
+                    data.extend_from_slice(&(value.len() as u32).to_le_bytes());
+                    data.extend_from_slice(value.as_bytes());
+                }
+            }
+        }
+        data
+    }
+}
+
+const THRIFT_I32: u8 = 5;
+const THRIFT_I64: u8 = 6;
+const THRIFT_BINARY: u8 = 8;
+const THRIFT_LIST: u8 = 9;
+const THRIFT_STRUCT: u8 = 12;
+
+/// Just enough of the Thrift compact protocol for Parquet page headers and
+/// file metadata. Structs written as list elements start with a fresh field
+/// id and end with [`ThriftWriter::stop`].
+#[derive(Default)]
+struct ThriftWriter {
+    out: Vec<u8>,
+    last_field: i16,
+    stack: Vec<i16>,
+}
+
+impl ThriftWriter {
+    fn field(&mut self, id: i16, kind: u8) {
+        let delta = id - self.last_field;
+        if (1..=15).contains(&delta) {
+            self.out.push(((delta as u8) << 4) | kind);
+        } else {
+            self.out.push(kind);
+            write_varint(&mut self.out, zigzag(i64::from(id)));
+        }
+        self.last_field = id;
+    }
+
+    fn i32(&mut self, id: i16, value: i32) {
+        self.field(id, THRIFT_I32);
+        write_varint(&mut self.out, zigzag(i64::from(value)));
+    }
+
+    fn i64(&mut self, id: i16, value: i64) {
+        self.field(id, THRIFT_I64);
+        write_varint(&mut self.out, zigzag(value));
+    }
+
+    fn binary(&mut self, id: i16, value: &[u8]) {
+        self.field(id, THRIFT_BINARY);
+        self.list_binary(value);
+    }
+
+    fn begin_struct(&mut self, id: i16) {
+        self.field(id, THRIFT_STRUCT);
+        self.stack.push(self.last_field);
+        self.last_field = 0;
+    }
+
+    fn end_struct(&mut self) {
+        self.out.push(0);
+        self.last_field = self.stack.pop().unwrap_or_default();
+    }
+
+    fn begin_list(&mut self, id: i16, element: u8, len: usize) {
+        self.field(id, THRIFT_LIST);
+        if len < 15 {
+            self.out.push(((len as u8) << 4) | element);
+        } else {
+            self.out.push(0xf0 | element);
+            write_varint(&mut self.out, len as u64);
+        }
+        self.stack.push(self.last_field);
+        self.last_field = 0;
+    }
+
+    fn end_list(&mut self) {
+        self.last_field = self.stack.pop().unwrap_or_default();
+    }
+
+    /// Ends a struct list element.
+    fn stop(&mut self) {
+        self.out.push(0);
+        self.last_field = 0;
+    }
+
+    fn list_i32(&mut self, value: i32) {
+        write_varint(&mut self.out, zigzag(i64::from(value)));
+    }
+
+    fn list_binary(&mut self, value: &[u8]) {
+        write_varint(&mut self.out, value.len() as u64);
+        self.out.extend_from_slice(value);
+    }
+}
+
+fn zigzag(value: i64) -> u64 {
+    ((value << 1) ^ (value >> 63)) as u64
+}
+
+fn write_varint(out: &mut Vec<u8>, mut value: u64) {
+    while value >= 0x80 {
+        out.push((value as u8) | 0x80);
+        value >>= 7;
+    }
+    out.push(value as u8);
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    // 2024-02-29T13:00:00Z.
+    const TS_MS: u64 = 1_709_211_600_000;
+
+    fn entry(ts_ms: u64, key_id: &'static str, model: &'static str) -> SpendEntry<'static> {
+        SpendEntry {
+            ts_ms,
+            key_id: Some(key_id),
+            tenant_id: Some("acme"),
+            model: Some(model),
+            input_tokens: 3,
+            output_tokens: 4,
+            spent_tokens: 7,
+            spent_usd_micros: 10,
+            ..SpendEntry::default()
+        }
+    }
+
+    fn sample_rows() -> Vec<UsageRollupRow> {
+        let mut rollup = UsageRollup::default();
+        rollup.record(&entry(TS_MS, "key-1", "gpt-4o-mini"));
+        rollup.record(&entry(TS_MS + 1_000, "key-1", "gpt-4o-mini"));
+        rollup.record(&entry(TS_MS, "key-2", "gpt-4o, \"large\""));
+        rollup.record(&entry(TS_MS + DAY_MS, "key-1", "gpt-4o-mini"));
+        rollup.into_rows()
+    }
+
+    #[test]
+    fn rolls_up_per_day_key_and_model() {
+        let rows = sample_rows();
+        assert_eq!(rows.len(), 3);
+        assert_eq!(usage_export_date(rows[0].day), "2024-02-29");
+        assert_eq!(rows[0].key_id.as_deref(), Some("key-1"));
+        assert_eq!((rows[0].requests, rows[0].spent_tokens), (2, 14));
+        assert_eq!(usage_export_date(rows[2].day), "2024-03-01");
+        assert_eq!(
+            usage_export_object_path(rows[2].day, "parquet"),
+            "v1/date=2024-03-01/usage.parquet"
+        );
+    }
+
+    #[test]
+    fn csv_has_a_versioned_header_and_escapes_fields() {
+        let csv = String::from_utf8(encode_usage_csv(&sample_rows())).unwrap();
+        let lines = csv.lines().collect::<Vec<_>>();
+        assert_eq!(lines[0], COLUMNS.join(","));
+        assert_eq!(
+            lines[1],
+            "1,2024-02-29,key-1,acme,,,gpt-4o-mini,2,6,8,14,20"
+        );
+        assert_eq!(
+            lines[2],
+            "1,2024-02-29,key-2,acme,,,\"gpt-4o, \"\"large\"\"\",1,3,4,7,10"
+        );
+        assert_eq!(lines.len(), 4);
+    }
+
+    #[test]
+    fn parquet_is_framed_and_footer_length_matches() {
+        for rows in [sample_rows(), Vec::new()] {
+            let file = encode_usage_parquet(&rows);
+            assert_eq!(&file[..4], b"PAR1");
+            assert_eq!(&file[file.len() - 4..], b"PAR1");
+            let footer_len =
+                u32::from_le_bytes(file[file.len() - 8..file.len() - 4].try_into().unwrap());
+            let footer_start = file.len() - 8 - footer_len as usize;
+            assert!(footer_start >= 4);
+            let footer = &file[footer_start..file.len() - 8];
+            // The file version field leads the metadata; the created_by
+            // string and the struct stop byte close it.
+            assert_eq!(footer[..2], [0x15, 0x02]);
+            assert!(footer.ends_with(b"ditto-gateway\0"));
+        }
+    }
+
+    #[test]
+    fn definition_levels_are_rle_runs() {
+        let column = ParquetColumn::OptionalString(vec![Some("a"), Some("b"), None, Some("c")]);
+        let data = column.page_data();
+        // Runs: 2 defined, 1 null, 1 defined.
+        assert_eq!(&data[..4], &6u32.to_le_bytes());
+        assert_eq!(&data[4..10], &[4, 1, 2, 0, 2, 1]);
+        assert_eq!(&data[10..15], &[1, 0, 0, 0, b'a']);
+    }
+}
@@ -37,6 +37,10 @@ pub struct LitellmParams {
     pub timeout: Option<f64>,
     #[serde(default)]
     pub stream_timeout: Option<f64>,
+    #[serde(default)]
+    pub weight: Option<f64>,
+    #[serde(default)]
+    pub max_parallel_requests: Option<usize>,
 }
 
 impl LitellmProxyConfig {
@@ -47,7 +51,7 @@ impl LitellmProxyConfig {
             });
         }
 
-        let mut model_groups: BTreeMap<String, Vec<String>> = BTreeMap::new();
+        let mut model_groups: BTreeMap<String, Vec<RouteBackend>> = BTreeMap::new();
         let mut backends = Vec::<BackendConfig>::new();
         for (idx, entry) in self.model_list.into_iter().enumerate() {
             let model_name = entry.model_name.trim().to_string();
@@ -57,6 +61,18 @@ impl LitellmProxyConfig {
                 });
             }
 
+            let weight = match entry.litellm_params.weight {
+                Some(weight) if !weight.is_finite() || weight <= 0.0 => {
+                    return Err(GatewayError::InvalidRequest {
+                        reason: format!(
+                            "litellm config model_name {model_name} has invalid weight {weight}"
+                        ),
+                    });
+                }
+                Some(weight) => weight,
+                None => 1.0,
+            };
+
             let backend_name = format!("litellm_{idx}_{}", sanitize_backend_name(&model_name));
             let backend =
                 backend_from_litellm_entry(&backend_name, &model_name, entry.litellm_params)?;
@@ -64,7 +80,10 @@ impl LitellmProxyConfig {
             model_groups
                 .entry(model_name)
                 .or_default()
-                .push(backend_name.clone());
+                .push(RouteBackend {
+                    backend: backend_name.clone(),
+                    weight,
+                });
             backends.push(backend);
         }
 
@@ -105,27 +124,21 @@ impl LitellmProxyConfig {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         })
     }
 }
 
 fn build_router_from_model_groups(
-    groups: &BTreeMap<String, Vec<String>>,
+    groups: &BTreeMap<String, Vec<RouteBackend>>,
 ) -> Result<(Vec<RouteBackend>, Vec<RouteRule>), GatewayError> {
-    let mut default_backends: Vec<RouteBackend> = Vec::new();
+    let mut default_backends: Vec<RouteBackend> = groups.get("*").cloned().unwrap_or_default();
     let mut rules: Vec<RouteRule> = Vec::new();
 
-    let wildcard_backends = groups.get("*").cloned().unwrap_or_default();
-    if !wildcard_backends.is_empty() {
-        default_backends = wildcard_backends
-            .iter()
-            .map(|name| RouteBackend {
-                backend: name.clone(),
-                weight: 1.0,
-            })
-            .collect();
-    }
-
     for (model_name, backends) in groups {
         if model_name == "*" {
             continue;
@@ -139,9 +152,11 @@ fn build_router_from_model_groups(
             rules.push(RouteRule {
                 model_prefix: model_name.clone(),
                 exact,
-                backend: backends[0].clone(),
+                backend: backends[0].backend.clone(),
                 backends: Vec::new(),
                 guardrails: None,
+                experiment: None,
+                shadow: None,
             });
             continue;
         }
@@ -150,14 +165,10 @@ fn build_router_from_model_groups(
             model_prefix: model_name.clone(),
             exact,
             backend: String::new(),
-            backends: backends
-                .iter()
-                .map(|name| RouteBackend {
-                    backend: name.clone(),
-                    weight: 1.0,
-                })
-                .collect(),
+            backends: backends.clone(),
             guardrails: None,
+            experiment: None,
+            shadow: None,
         });
     }
 
@@ -166,7 +177,7 @@ fn build_router_from_model_groups(
             .values()
             .flat_map(|values| values.iter())
             .next()
-            .cloned()
+            .map(|backend| backend.backend.clone())
             .ok_or_else(|| GatewayError::InvalidRequest {
                 reason: "litellm config produced no backends".to_string(),
             })?;
@@ -226,13 +237,22 @@ fn backend_from_litellm_entry(
     Ok(BackendConfig {
         name: backend_name.to_string(),
         base_url,
-        max_in_flight: None,
+        max_in_flight: params.max_parallel_requests.filter(|limit| *limit > 0),
         timeout_seconds,
+        connect_timeout_seconds: None,
+        first_token_timeout_seconds: None,
         headers,
         query_params,
+        tls: None,
         provider: None,
         provider_config: None,
         model_map,
+        model_info: BTreeMap::new(),
+        embeddings_max_batch: None,
+        structured_output: None,
+        prompt_cache: None,
+        transport: None,
+        region: None,
     })
 }
 
@@ -350,4 +370,59 @@ model_list:
             Some("gpt-4.1-mini")
         );
     }
+
+    #[test]
+    fn imports_model_group_weights_and_parallel_limits() {
+        let raw = r#"
+model_list:
+  - model_name: fast-chat
+    litellm_params:
+      model: azure/gpt-4o-mini
+      api_base: https://example-east.openai.azure.com/openai/deployments/gpt-4o-mini
+      weight: 9
+      max_parallel_requests: 32
+  - model_name: fast-chat
+    litellm_params:
+      model: openai/gpt-4o-mini
+"#;
+
+        let parsed: LitellmProxyConfig = serde_yaml::from_str(raw).expect("parse litellm config");
+        let config = parsed.try_into_gateway_config().expect("convert");
+        let rule = config
+            .router
+            .rules
+            .iter()
+            .find(|rule| rule.model_prefix == "fast-chat")
+            .expect("fast-chat rule");
+        assert!(rule.exact);
+        let weights: Vec<f64> = rule.backends.iter().map(|b| b.weight).collect();
+        assert_eq!(weights, vec![9.0, 1.0]);
+
+        let azure = config
+            .backends
+            .iter()
+            .find(|b| b.name == rule.backends[0].backend)
+            .expect("azure backend");
+        assert_eq!(azure.max_in_flight, Some(32));
+        let openai = config
+            .backends
+            .iter()
+            .find(|b| b.name == rule.backends[1].backend)
+            .expect("openai backend");
+        assert_eq!(openai.max_in_flight, None);
+    }
+
+    #[test]
+    fn rejects_non_positive_model_weight() {
+        let raw = r#"
+model_list:
+  - model_name: fast-chat
+    litellm_params:
+      model: openai/gpt-4o-mini
+      weight: 0
+"#;
+
+        let parsed: LitellmProxyConfig = serde_yaml::from_str(raw).expect("parse litellm config");
+        assert!(parsed.try_into_gateway_config().is_err());
+    }
 }
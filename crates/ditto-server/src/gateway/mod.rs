@@ -1,6 +1,7 @@
 //! Gateway module (feature-gated).
 
 mod adapters;
+mod alerts;
 mod application;
 #[doc(hidden)]
 pub mod budget;
@@ -30,12 +31,14 @@ mod multipart;
 #[doc(hidden)]
 pub mod mysql_store;
 pub mod observability;
+mod observability_callbacks;
 #[cfg(feature = "gateway-otel")]
 pub mod otel;
 pub mod passthrough;
 #[cfg(feature = "gateway-store-postgres")]
 #[doc(hidden)]
 pub mod postgres_store;
+pub mod provisioning;
 #[doc(hidden)]
 pub mod proxy_backend;
 #[cfg(feature = "gateway-proxy-cache")]
@@ -50,6 +53,7 @@ pub mod redis_store;
 mod responses_shim;
 #[doc(hidden)]
 pub mod router;
+mod spend_anomaly;
 #[cfg(feature = "gateway-store-sqlite")]
 #[doc(hidden)]
 pub mod sqlite_store;
@@ -62,6 +66,8 @@ pub mod token_count;
 #[cfg(feature = "gateway-translation")]
 mod translation;
 mod transport;
+#[cfg(feature = "gateway-wasm-plugins")]
+pub mod wasm_plugins;
 
 use std::collections::{BTreeMap, HashMap, HashSet};
 use std::sync::{Arc, Mutex, MutexGuard, RwLock, RwLockReadGuard, RwLockWriteGuard};
@@ -71,7 +77,7 @@ use omne_integrity_primitives::Sha256Hasher;
 use serde::{Deserialize, Serialize};
 use thiserror::Error;
 
-use self::config::normalize_presented_virtual_key_token_key;
+use self::config::presented_virtual_key_token_lookup_keys;
 use domain::RateLimiter;
 use domain::{BudgetTracker, ResponseCache, Router};
 use observability::{Observability, ObservabilitySnapshot};
@@ -96,6 +102,10 @@ pub(crate) fn tenant_scope_key(tenant_id: Option<&str>) -> Option<String> {
     domain::scope::tenant_scope_key(tenant_id)
 }
 
+pub(crate) fn mcp_scope_key(key_id: &str) -> String {
+    domain::scope::mcp_scope_key(key_id)
+}
+
 pub(crate) fn project_scope_key(
     tenant_id: Option<&str>,
     project_id: Option<&str>,
@@ -107,7 +117,7 @@ pub(crate) fn user_scope_key(tenant_id: Option<&str>, user_id: Option<&str>) ->
     domain::scope::user_scope_key(tenant_id, user_id)
 }
 
-pub use adapters::backend::{HttpBackend, ProxyBackend};
+pub use adapters::backend::{HttpBackend, ProxyBackend, ProxyFixtureMode, ProxyFixtures};
 #[cfg(feature = "gateway-proxy-cache")]
 pub use adapters::cache::{
     CachedProxyResponse, ProxyCacheConfig, ProxyCacheEntryMetadata, ProxyCachePurgeSelector,
@@ -122,20 +132,44 @@ pub use adapters::store::{PostgresStore, PostgresStoreError};
 pub use adapters::store::{RedisStore, RedisStoreError};
 #[cfg(feature = "gateway-store-sqlite")]
 pub use adapters::store::{SqliteStore, SqliteStoreError};
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+pub use adapters::store::{VirtualKeyEnvelope, VirtualKeyEnvelopeError};
 #[cfg(feature = "gateway-translation")]
 pub use application::translation::TranslationBackend;
 pub use config::{
-    BackendConfig, GatewayConfig, GatewayObservabilityConfig, GatewayRedactionConfig,
-    GatewaySamplingConfig, VirtualKeyConfig,
+    AlertCondition, AlertRuleConfig, AlertTargetConfig, AlertTargetSink, BackendConfig,
+    BackendHttpVersion, BackendTlsConfig, BackendTransportConfig, CompressionConfig,
+    CompressionEncoding, CorsConfig, GatewayAlertsConfig, GatewayConfig,
+    GatewayObservabilityConfig, GatewayRedactionConfig, GatewaySamplingConfig, KeyTierConfig,
+    McpAccessConfig, ModelInfoConfig, ObservabilityCallbackConfig, ObservabilityCallbackSink,
+    PassthroughRouteConfig, PromptCacheConfig, RequestBodyLimitConfig, SpendAnomalyAction,
+    SpendAnomalyConfig, StructuredOutputConfig, UsageExportConfig, UsageExportFormat,
+    VirtualKeyConfig,
 };
 #[cfg(feature = "gateway-costing")]
 pub use costing::{PricingTable, PricingTableError};
 pub use domain::{
-    AuditLogRecord, BudgetConfig, BudgetLedgerRecord, CacheConfig, CostLedgerRecord,
-    GuardrailsConfig, LimitsConfig, ProxyRequestFingerprint, ProxyRequestIdempotencyBeginOutcome,
+    AuditLogRecord, BudgetConfig, BudgetLedgerRecord, BudgetPeriod, BudgetResetConfig, BudgetUsage,
+    CacheConfig, ContextSummarizerConfig, ContextWindowConfig, ContextWindowStrategy,
+    CostLedgerRecord, EXPERIMENT_KEY_HEADER, FineTuningJobRecord, FineTuningJobStore,
+    FineTuningJobStoreError, GuardrailHookAction, GuardrailHookConfig, GuardrailHookMatch,
+    GuardrailHookOutcome, GuardrailHookPhase, GuardrailPiiEntity, GuardrailsConfig,
+    HistoryCompressionConfig, LimitsConfig, ModerationAction, ModerationConfig,
+    ModerationViolation, PromptInjectionAction, PromptInjectionClassifierConfig,
+    PromptInjectionConfig, PromptInjectionScore, PromptMessage, PromptRegistry, PromptRenderError,
+    PromptTemplate, ProxyRequestFingerprint, ProxyRequestIdempotencyBeginOutcome,
     ProxyRequestIdempotencyRecord, ProxyRequestIdempotencyState, ProxyRequestIdempotencyStore,
     ProxyRequestIdempotencyStoreError, ProxyRequestReplayError, ProxyRequestReplayOutcome,
-    ProxyRequestReplayResponse, RouteBackend, RouteRule, RouterConfig, StoredHttpHeader,
+    ProxyRequestReplayResponse, REGION_HEADER, REQUEST_TAGS_HEADER, RateLimitRemaining,
+    RateLimitStatus, RouteBackend, RouteRule, RouteShadowConfig, RouterConfig, SessionConfig,
+    SessionStore, SessionStoreError, SpendBucket, SpendGroupBy, SpendReportRow, StoredHttpHeader,
+    StreamEventAction, StreamTransform, StreamTransformConfig, StreamTransformFactory,
+    WatermarkPosition,
 };
 pub use passthrough::PassthroughConfig;
 #[cfg(feature = "gateway-routing-advanced")]
@@ -143,6 +177,11 @@ pub use proxy_routing::{
     BackendHealthSnapshot, ProxyCircuitBreakerConfig, ProxyRetryConfig, ProxyRoutingConfig,
 };
 pub use transport::http::GatewayHttpState;
+#[cfg(feature = "gateway-wasm-plugins")]
+pub use wasm_plugins::{
+    WasmPlugin, WasmPluginError, WasmPluginInput, WasmPluginOutput, WasmPluginPhase,
+    WasmPluginRejection,
+};
 
 #[derive(Clone, Serialize, Deserialize)]
 pub struct GatewayRequest {
@@ -411,6 +450,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         let gateway = Gateway::new(config);
         assert!(gateway.virtual_key_by_token("vk-old").is_some());
@@ -441,6 +485,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         let mut gateway = Gateway::new(config);
         gateway.register_backend("primary", TestBackend);
@@ -507,6 +556,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         let mut gateway = Gateway::new(config);
         gateway.register_backend("primary", TestBackend);
@@ -569,6 +623,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         });
 
         let request = GatewayRequest {
@@ -613,6 +672,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         });
         gateway.register_backend("primary", FailingBackend);
 
@@ -662,6 +726,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         let mut gateway = Gateway::new(config);
         gateway.register_backend("primary", FailingBackend);
@@ -703,6 +772,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         let mut gateway = Gateway::new(config);
         gateway.register_backend("primary", TestBackend);
@@ -757,6 +831,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         let mut gateway = Gateway::new(config).with_pricing_table(test_pricing_table());
         gateway.register_backend("primary", TestBackend);
@@ -812,6 +891,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         let mut gateway = Gateway::new(config).with_pricing_table(test_pricing_table());
         gateway.register_backend("primary", TestBackend);
@@ -859,6 +943,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         let mut gateway = Gateway::new(config);
         gateway.register_backend("primary", TestBackend);
@@ -923,6 +1012,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         let mut gateway = Gateway::new(config);
         gateway.register_backend("primary", TestBackend);
@@ -976,6 +1070,8 @@ mod tests {
                 backend: "primary".to_string(),
                 backends: Vec::new(),
                 guardrails: None,
+                experiment: None,
+                shadow: None,
             }],
         });
 
@@ -1051,6 +1147,8 @@ pub enum GatewayError {
     BackendTimeout { message: String },
     #[error("invalid request: {reason}")]
     InvalidRequest { reason: String },
+    #[error("no backend available in required region: {regions}")]
+    RegionUnavailable { regions: String },
 }
 
 #[async_trait]
@@ -1129,17 +1227,88 @@ impl GatewayControlPlane {
             .collect()
     }
 
+    fn backend_regions(&self) -> HashMap<String, String> {
+        self.config
+            .backends
+            .iter()
+            .filter_map(|backend| {
+                let region = backend.region.as_deref()?.trim();
+                (!region.is_empty()).then(|| (backend.name.clone(), region.to_string()))
+            })
+            .collect()
+    }
+
+    fn backend_embeddings_max_batches(&self) -> HashMap<String, usize> {
+        self.config
+            .backends
+            .iter()
+            .filter_map(|backend| {
+                let max_batch = backend.effective_embeddings_max_batch()?;
+                Some((backend.name.clone(), max_batch))
+            })
+            .collect()
+    }
+
+    fn backend_model_info_sources(&self) -> HashMap<String, BackendModelInfoSource> {
+        self.config
+            .backends
+            .iter()
+            .map(|backend| {
+                let provider_config = backend.provider_config.as_ref();
+                let provider = backend
+                    .provider
+                    .as_deref()
+                    .or_else(|| provider_config.and_then(|config| config.provider.as_deref()))
+                    .map(str::trim)
+                    .filter(|provider| !provider.is_empty())
+                    .map(str::to_string);
+                let source = BackendModelInfoSource {
+                    provider,
+                    capabilities: provider_config.and_then(|config| config.capabilities),
+                    model_info: backend.model_info.clone(),
+                };
+                (backend.name.clone(), source)
+            })
+            .collect()
+    }
+
     fn config_snapshot(&self) -> GatewayConfig {
         self.config.clone()
     }
 
+    /// Like [`Router::select_backend`], skipping backends outside the key's
+    /// `regions`.
+    fn select_backend(
+        &self,
+        request: &GatewayRequest,
+        key: &VirtualKeyConfig,
+    ) -> Result<String, GatewayError> {
+        if key.regions.is_empty() {
+            return self.router.select_backend(request, key);
+        }
+        let seed_hash = request.route_seed_hash(&key.id);
+        let backends = self.router.select_backends_for_model_seeded_hash(
+            &request.model,
+            Some(key),
+            Some(seed_hash),
+        )?;
+        let regions = domain::required_regions(Some(key), None)?;
+        domain::retain_backends_in_regions(backends, &regions, &self.backend_regions())?
+            .into_iter()
+            .next()
+            .ok_or_else(|| GatewayError::BackendNotFound {
+                name: "default".to_string(),
+            })
+    }
+
     fn virtual_key_by_token(&self, token: &str) -> Option<&VirtualKeyConfig> {
-        if let Some(token_key) = normalize_presented_virtual_key_token_key(token)
-            && let Some(index) = self.virtual_key_token_index.get(&token_key).copied()
-            && let Some(key) = self.config.virtual_keys.get(index)
-            && key.matches_token(token)
-        {
-            return Some(key);
+        for token_key in presented_virtual_key_token_lookup_keys(token) {
+            if let Some(index) = self.virtual_key_token_index.get(&token_key).copied()
+                && let Some(key) = self.config.virtual_keys.get(index)
+                && key.matches_token(token)
+            {
+                return Some(key);
+            }
         }
         self.config
             .virtual_keys
@@ -1196,6 +1365,15 @@ pub struct Gateway {
     clock: Box<dyn Clock>,
 }
 
+/// The parts of a backend's config that `/v1/models/{model}/info` reports
+/// from.
+#[derive(Clone, Debug, Default)]
+pub(crate) struct BackendModelInfoSource {
+    pub(crate) provider: Option<String>,
+    pub(crate) capabilities: Option<ditto_core::config::ProviderCapabilities>,
+    pub(crate) model_info: BTreeMap<String, ModelInfoConfig>,
+}
+
 #[derive(Clone)]
 pub(crate) struct GatewayRuntimeSnapshot {
     pub(crate) config: GatewayConfig,
@@ -1429,6 +1607,18 @@ impl Gateway {
         self.with_control_plane(GatewayControlPlane::backend_model_maps)
     }
 
+    pub(crate) fn backend_regions(&self) -> HashMap<String, String> {
+        self.with_control_plane(GatewayControlPlane::backend_regions)
+    }
+
+    pub(crate) fn backend_embeddings_max_batches(&self) -> HashMap<String, usize> {
+        self.with_control_plane(GatewayControlPlane::backend_embeddings_max_batches)
+    }
+
+    pub(crate) fn backend_model_info_sources(&self) -> HashMap<String, BackendModelInfoSource> {
+        self.with_control_plane(GatewayControlPlane::backend_model_info_sources)
+    }
+
     pub fn list_virtual_keys(&self) -> Vec<VirtualKeyConfig> {
         self.with_control_plane(GatewayControlPlane::list_virtual_keys)
     }
@@ -1499,6 +1689,7 @@ impl Gateway {
 
         for key in &virtual_keys {
             scopes.insert(key.id.clone());
+            scopes.insert(mcp_scope_key(&key.id));
 
             if let Some(scope) = tenant_scope_key(key.tenant_id.as_deref()) {
                 scopes.insert(scope);
@@ -1601,7 +1792,7 @@ impl Gateway {
             return Err(err);
         }
 
-        let backend_name = match control_plane.router.select_backend(request, &key) {
+        let backend_name = match control_plane.select_backend(request, &key) {
             Ok(backend_name) => backend_name,
             Err(err) => {
                 rollback_rate_limits();
@@ -64,6 +64,24 @@ fn multipart_boundary(content_type: &str) -> Result<String, String> {
 pub(crate) fn parse_multipart_form(
     content_type: &str,
     body: &Bytes,
+) -> Result<Vec<MultipartPart>, String> {
+    parse_multipart_parts(content_type, body, false)
+}
+
+/// Parses the parts that are complete in `prefix`, the first bytes of a body
+/// still being received; a part cut off by the end of `prefix` is skipped
+/// rather than reported as malformed.
+pub(crate) fn parse_multipart_prefix(
+    content_type: &str,
+    prefix: &Bytes,
+) -> Result<Vec<MultipartPart>, String> {
+    parse_multipart_parts(content_type, prefix, true)
+}
+
+fn parse_multipart_parts(
+    content_type: &str,
+    body: &Bytes,
+    truncated: bool,
 ) -> Result<Vec<MultipartPart>, String> {
     let boundary = multipart_boundary(content_type)?;
     let boundary_marker = format!("--{boundary}");
@@ -93,6 +111,8 @@ pub(crate) fn parse_multipart_form(
                 (idx, 4)
             } else if let Some(idx) = find_subslice(bytes, b"\n\n", cursor) {
                 (idx, 2)
+            } else if truncated {
+                break;
             } else {
                 return Err("multipart part missing header separator".to_string());
             };
@@ -139,6 +159,9 @@ pub(crate) fn parse_multipart_form(
         let data_start = headers_end + header_sep_len;
 
         let Some(delim_pos) = find_subslice(bytes, delimiter_bytes, data_start) else {
+            if truncated {
+                break;
+            }
             return Err("multipart part missing trailing boundary".to_string());
         };
         let data_end = delim_pos;
@@ -309,11 +309,11 @@ fn find_sampling_identity_in_object(
 const FNV1A64_OFFSET_BASIS: u64 = 0xcbf29ce484222325;
 const FNV1A64_PRIME: u64 = 0x100000001b3;
 
-fn fnv1a64_init() -> u64 {
+pub(super) fn fnv1a64_init() -> u64 {
     FNV1A64_OFFSET_BASIS
 }
 
-fn fnv1a64_update(mut hash: u64, bytes: &[u8]) -> u64 {
+pub(super) fn fnv1a64_update(mut hash: u64, bytes: &[u8]) -> u64 {
     for byte in bytes {
         hash ^= u64::from(*byte);
         hash = hash.wrapping_mul(FNV1A64_PRIME);
@@ -0,0 +1,514 @@
+//! Per-request LLM traces and their wire formats for the
+//! `observability.callbacks` platforms (Langfuse, Datadog LLM Observability,
+//! Helicone). Queueing and delivery live in the HTTP transport.
+
+use serde::Serialize;
+use serde_json::{Map, Value, json};
+
+use super::config::ObservabilityCallbackSink;
+use super::domain::spend_report::civil_from_days;
+use super::observability::{fnv1a64_init, fnv1a64_update};
+
+/// One proxied request as seen by the gateway.
+#[derive(Clone, Debug, Default, Serialize)]
+pub(crate) struct LlmTrace {
+    pub(crate) request_id: String,
+    pub(crate) virtual_key_id: Option<String>,
+    pub(crate) model: Option<String>,
+    pub(crate) backend: Option<String>,
+    pub(crate) method: String,
+    pub(crate) path: String,
+    pub(crate) status: u16,
+    pub(crate) stream: bool,
+    pub(crate) start_ms: u64,
+    pub(crate) end_ms: u64,
+    /// `messages`, `input` or `prompt` from the request body.
+    pub(crate) prompt: Value,
+    /// The assistant message, completion text or Responses `output`.
+    pub(crate) completion: Value,
+    pub(crate) input_tokens: Option<u64>,
+    pub(crate) output_tokens: Option<u64>,
+    pub(crate) cost_usd: Option<f64>,
+    pub(crate) tags: Vec<String>,
+    pub(crate) error: Option<String>,
+}
+
+/// Completion and usage recovered from a response body.
+#[derive(Debug, Default, PartialEq)]
+pub(crate) struct TraceOutput {
+    pub(crate) completion: Value,
+    pub(crate) input_tokens: Option<u64>,
+    pub(crate) output_tokens: Option<u64>,
+}
+
+/// An HTTP request that delivers traces to a platform.
+#[derive(Debug)]
+pub(crate) struct CallbackRequest {
+    pub(crate) url: String,
+    pub(crate) basic_auth: Option<(String, String)>,
+    pub(crate) headers: Vec<(&'static str, String)>,
+    pub(crate) body: Value,
+}
+
+/// Deterministic per-request sampling, so retries of one request id are
+/// either all traced or all skipped.
+pub(crate) fn should_sample_trace(callback: &str, request_id: &str, rate: f64) -> bool {
+    if rate <= 0.0 {
+        return false;
+    }
+    if rate >= 1.0 {
+        return true;
+    }
+    let hash = fnv1a64_update(fnv1a64_init(), callback.as_bytes());
+    let hash = fnv1a64_update(fnv1a64_update(hash, b"|"), request_id.as_bytes());
+    hash <= (rate * u64::MAX as f64).floor() as u64
+}
+
+pub(crate) fn trace_prompt(request: &Value) -> Value {
+    ["messages", "input", "prompt"]
+        .iter()
+        .find_map(|field| request.get(*field))
+        .cloned()
+        .unwrap_or(Value::Null)
+}
+
+pub(crate) fn trace_output_from_json(response: &Value) -> TraceOutput {
+    let choice = response.pointer("/choices/0");
+    let completion = choice
+        .and_then(|choice| choice.get("message").or_else(|| choice.get("text")))
+        .or_else(|| response.get("output"))
+        .cloned()
+        .unwrap_or(Value::Null);
+    let (input_tokens, output_tokens) = usage_tokens(response.get("usage"));
+    TraceOutput {
+        completion,
+        input_tokens,
+        output_tokens,
+    }
+}
+
+/// Reassembles the completion of an OpenAI chat/completions or Responses
+/// SSE stream. Usage comes from the final chunk when the client asked for
+/// it (`stream_options.include_usage`) or from `response.completed`.
+pub(crate) fn trace_output_from_sse(body: &[u8]) -> TraceOutput {
+    let mut text = String::new();
+    let mut chat = false;
+    let mut usage = (None, None);
+    for line in String::from_utf8_lossy(body).lines() {
+        let Some(data) = line.strip_prefix("data:").map(str::trim) else {
+            continue;
+        };
+        let Ok(event) = serde_json::from_str::<Value>(data) else {
+            continue;
+        };
+        if let Some(choice) = event.pointer("/choices/0") {
+            chat = true;
+            if let Some(delta) = choice
+                .pointer("/delta/content")
+                .or_else(|| choice.get("text"))
+                .and_then(Value::as_str)
+            {
+                text.push_str(delta);
+            }
+        } else if event.get("type").and_then(Value::as_str) == Some("response.output_text.delta")
+            && let Some(delta) = event.get("delta").and_then(Value::as_str)
+        {
+            text.push_str(delta);
+        }
+        let event_usage = event
+            .get("usage")
+            .or_else(|| event.pointer("/response/usage"));
+        if event_usage.is_some_and(|usage| !usage.is_null()) {
+            usage = usage_tokens(event_usage);
+        }
+    }
+    let completion = if chat {
+        json!({ "role": "assistant", "content": text })
+    } else {
+        Value::String(text)
+    };
+    TraceOutput {
+        completion,
+        input_tokens: usage.0,
+        output_tokens: usage.1,
+    }
+}
+
+fn usage_tokens(usage: Option<&Value>) -> (Option<u64>, Option<u64>) {
+    let Some(usage) = usage else {
+        return (None, None);
+    };
+    let field = |names: [&str; 2]| {
+        names
+            .iter()
+            .find_map(|name| usage.get(*name).and_then(Value::as_u64))
+    };
+    (
+        field(["prompt_tokens", "input_tokens"]),
+        field(["completion_tokens", "output_tokens"]),
+    )
+}
+
+/// Encodes a batch for `sink`. Langfuse and Datadog take the whole batch in
+/// one request; Helicone's custom-log endpoint takes one trace per request.
+pub(crate) fn encode_traces(
+    sink: &ObservabilityCallbackSink,
+    traces: &[LlmTrace],
+) -> Vec<CallbackRequest> {
+    match sink {
+        ObservabilityCallbackSink::Langfuse {
+            host,
+            public_key,
+            secret_key,
+        } => vec![CallbackRequest {
+            url: format!("{}/api/public/ingestion", host.trim_end_matches('/')),
+            basic_auth: Some((public_key.clone(), secret_key.clone())),
+            headers: Vec::new(),
+            body: json!({ "batch": traces.iter().flat_map(langfuse_events).collect::<Vec<_>>() }),
+        }],
+        ObservabilityCallbackSink::Datadog {
+            site,
+            api_key,
+            ml_app,
+        } => vec![CallbackRequest {
+            url: format!("https://api.{site}/api/intake/llm-obs/v1/trace/spans"),
+            basic_auth: None,
+            headers: vec![("dd-api-key", api_key.clone())],
+            body: json!({
+                "data": {
+                    "type": "span",
+                    "attributes": {
+                        "ml_app": ml_app,
+                        "tags": ["service:ditto-gateway"],
+                        "spans": traces.iter().map(datadog_span).collect::<Vec<_>>(),
+                    },
+                },
+            }),
+        }],
+        ObservabilityCallbackSink::Helicone { base_url, api_key } => traces
+            .iter()
+            .map(|trace| CallbackRequest {
+                url: format!("{}/custom/v1/log", base_url.trim_end_matches('/')),
+                basic_auth: None,
+                headers: vec![("authorization", format!("Bearer {api_key}"))],
+                body: helicone_log(trace),
+            })
+            .collect(),
+    }
+}
+
+fn trace_metadata(trace: &LlmTrace) -> Value {
+    json!({
+        "request_id": trace.request_id,
+        "virtual_key_id": trace.virtual_key_id,
+        "backend": trace.backend,
+        "method": trace.method,
+        "path": trace.path,
+        "status": trace.status,
+        "stream": trace.stream,
+        "latency_ms": trace.end_ms.saturating_sub(trace.start_ms),
+    })
+}
+
+fn langfuse_events(trace: &LlmTrace) -> [Value; 2] {
+    let start = rfc3339_from_ms(trace.start_ms);
+    let end = rfc3339_from_ms(trace.end_ms);
+    let mut usage = Map::new();
+    if let Some(input) = trace.input_tokens {
+        usage.insert("input".to_string(), input.into());
+    }
+    if let Some(output) = trace.output_tokens {
+        usage.insert("output".to_string(), output.into());
+    }
+    if !usage.is_empty() {
+        usage.insert("unit".to_string(), "TOKENS".into());
+    }
+    if let Some(cost) = trace.cost_usd {
+        usage.insert("totalCost".to_string(), cost.into());
+    }
+    [
+        json!({
+            "id": format!("{}-trace", trace.request_id),
+            "timestamp": start,
+            "type": "trace-create",
+            "body": {
+                "id": trace.request_id,
+                "timestamp": start,
+                "name": trace.path,
+                "userId": trace.virtual_key_id,
+                "input": trace.prompt,
+                "output": trace.completion,
+                "tags": trace.tags,
+                "metadata": trace_metadata(trace),
+            },
+        }),
+        json!({
+            "id": format!("{}-generation", trace.request_id),
+            "timestamp": end,
+            "type": "generation-create",
+            "body": {
+                "id": format!("{}-generation", trace.request_id),
+                "traceId": trace.request_id,
+                "name": trace.path,
+                "startTime": start,
+                "endTime": end,
+                "model": trace.model,
+                "input": trace.prompt,
+                "output": trace.completion,
+                "usage": usage,
+                "level": if trace.error.is_some() { "ERROR" } else { "DEFAULT" },
+                "statusMessage": trace.error,
+                "metadata": trace_metadata(trace),
+            },
+        }),
+    ]
+}
+
+fn datadog_span(trace: &LlmTrace) -> Value {
+    let io = |value: &Value| match value {
+        Value::Array(messages) => json!({ "messages": messages }),
+        Value::Object(message) if message.contains_key("content") => {
+            json!({ "messages": [message] })
+        }
+        Value::String(text) => json!({ "value": text }),
+        Value::Null => json!({}),
+        other => json!({ "value": other.to_string() }),
+    };
+    let mut meta = json!({
+        "kind": "llm",
+        "model_name": trace.model,
+        "model_provider": trace.backend,
+        "input": io(&trace.prompt),
+        "output": io(&trace.completion),
+        "metadata": trace_metadata(trace),
+    });
+    if let Some(error) = trace.error.as_deref() {
+        meta["error"] = json!({ "message": error });
+    }
+    let mut metrics = Map::new();
+    if let Some(input) = trace.input_tokens {
+        metrics.insert("input_tokens".to_string(), input.into());
+    }
+    if let Some(output) = trace.output_tokens {
+        metrics.insert("output_tokens".to_string(), output.into());
+    }
+    if let (Some(input), Some(output)) = (trace.input_tokens, trace.output_tokens) {
+        metrics.insert("total_tokens".to_string(), (input + output).into());
+    }
+    if let Some(cost) = trace.cost_usd {
+        metrics.insert("estimated_total_cost".to_string(), cost.into());
+    }
+    let mut tags = vec![format!("request_id:{}", trace.request_id)];
+    if let Some(key) = trace.virtual_key_id.as_deref() {
+        tags.push(format!("virtual_key_id:{key}"));
+    }
+    tags.extend(trace.tags.iter().cloned());
+    json!({
+        "parent_id": "undefined",
+        "trace_id": fnv1a64_update(fnv1a64_init(), trace.request_id.as_bytes()).to_string(),
+        "span_id": fnv1a64_update(fnv1a64_init(), format!("{}-span", trace.request_id).as_bytes())
+            .to_string(),
+        "name": trace.path,
+        "start_ns": trace.start_ms.saturating_mul(1_000_000),
+        "duration": trace.end_ms.saturating_sub(trace.start_ms).saturating_mul(1_000_000),
+        "status": if trace.error.is_some() { "error" } else { "ok" },
+        "tags": tags,
+        "meta": meta,
+        "metrics": metrics,
+    })
+}
+
+fn helicone_log(trace: &LlmTrace) -> Value {
+    let timing = |ms: u64| json!({ "seconds": ms / 1000, "milliseconds": ms % 1000 });
+    let mut meta = Map::new();
+    meta.insert(
+        "Helicone-Request-Id".to_string(),
+        trace.request_id.clone().into(),
+    );
+    if let Some(key) = trace.virtual_key_id.as_deref() {
+        meta.insert("Helicone-User-Id".to_string(), key.into());
+    }
+    if !trace.tags.is_empty() {
+        meta.insert(
+            "Helicone-Property-Tags".to_string(),
+            trace.tags.join(",").into(),
+        );
+    }
+    let mut usage = Map::new();
+    if let Some(input) = trace.input_tokens {
+        usage.insert("prompt_tokens".to_string(), input.into());
+    }
+    if let Some(output) = trace.output_tokens {
+        usage.insert("completion_tokens".to_string(), output.into());
+    }
+    let response = match trace.error.as_deref() {
+        Some(error) => json!({ "error": { "message": error } }),
+        None => json!({
+            "model": trace.model,
+            "choices": [{ "index": 0, "message": trace.completion }],
+            "usage": usage,
+        }),
+    };
+    json!({
+        "providerRequest": {
+            "url": trace.path,
+            "json": { "model": trace.model, "messages": trace.prompt, "stream": trace.stream },
+            "meta": meta,
+        },
+        "providerResponse": {
+            "json": response,
+            "status": trace.status,
+            "headers": {},
+        },
+        "timing": {
+            "startTime": timing(trace.start_ms),
+            "endTime": timing(trace.end_ms),
+        },
+    })
+}
+
+fn rfc3339_from_ms(ts_ms: u64) -> String {
+    let secs = ts_ms / 1000;
+    let (year, month, day) = civil_from_days(secs / 86_400);
+    let secs_of_day = secs % 86_400;
+    format!(
+        "{year:04}-{month:02}-{day:02}T{:02}:{:02}:{:02}.{:03}Z",
+        secs_of_day / 3600,
+        secs_of_day % 3600 / 60,
+        secs_of_day % 60,
+        ts_ms % 1000
+    )
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn sample_trace() -> LlmTrace {
+        LlmTrace {
+            request_id: "req-1".to_string(),
+            virtual_key_id: Some("key-1".to_string()),
+            model: Some("gpt-4o-mini".to_string()),
+            backend: Some("openai".to_string()),
+            method: "POST".to_string(),
+            path: "/v1/chat/completions".to_string(),
+            status: 200,
+            start_ms: 1_709_211_600_000,
+            end_ms: 1_709_211_600_250,
+            prompt: json!([{ "role": "user", "content": "hi" }]),
+            completion: json!({ "role": "assistant", "content": "hello" }),
+            input_tokens: Some(3),
+            output_tokens: Some(2),
+            cost_usd: Some(0.000_015),
+            tags: vec!["env=prod".to_string()],
+            ..LlmTrace::default()
+        }
+    }
+
+    #[test]
+    fn extracts_completion_and_usage_from_json_and_sse() {
+        let response = json!({
+            "choices": [{ "message": { "role": "assistant", "content": "hello" } }],
+            "usage": { "prompt_tokens": 3, "completion_tokens": 2 },
+        });
+        assert_eq!(
+            trace_output_from_json(&response),
+            TraceOutput {
+                completion: json!({ "role": "assistant", "content": "hello" }),
+                input_tokens: Some(3),
+                output_tokens: Some(2),
+            }
+        );
+
+        let sse = concat!(
+            "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n",
+            "data: {\"choices\":[{\"delta\":{\"content\":\"hel\"}}]}\n\n",
+            ": ping\n\n",
+            "data: {\"choices\":[{\"delta\":{\"content\":\"lo\"}}]}\n\n",
+            "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":2}}\n\n",
+            "data: [DONE]\n\n",
+        );
+        let output = trace_output_from_sse(sse.as_bytes());
+        assert_eq!(
+            output.completion,
+            json!({ "role": "assistant", "content": "hello" })
+        );
+        assert_eq!(
+            (output.input_tokens, output.output_tokens),
+            (Some(3), Some(2))
+        );
+
+        let responses_sse = concat!(
+            "data: {\"type\":\"response.output_text.delta\",\"delta\":\"hi\"}\n\n",
+            "data: {\"type\":\"response.completed\",\"response\":{\"usage\":{\"input_tokens\":4,\"output_tokens\":1}}}\n\n",
+        );
+        let output = trace_output_from_sse(responses_sse.as_bytes());
+        assert_eq!(output.completion, json!("hi"));
+        assert_eq!(
+            (output.input_tokens, output.output_tokens),
+            (Some(4), Some(1))
+        );
+    }
+
+    #[test]
+    fn encodes_langfuse_ingestion_batch() {
+        let sink = ObservabilityCallbackSink::Langfuse {
+            host: "https://langfuse.example/".to_string(),
+            public_key: "pk".to_string(),
+            secret_key: "sk".to_string(),
+        };
+        let requests = encode_traces(&sink, &[sample_trace(), sample_trace()]);
+        assert_eq!(requests.len(), 1);
+        assert_eq!(
+            requests[0].url,
+            "https://langfuse.example/api/public/ingestion"
+        );
+        assert_eq!(
+            requests[0].basic_auth,
+            Some(("pk".to_string(), "sk".to_string()))
+        );
+        let batch = requests[0].body["batch"].as_array().expect("batch");
+        assert_eq!(batch.len(), 4);
+        assert_eq!(batch[0]["type"], "trace-create");
+        assert_eq!(batch[1]["body"]["traceId"], "req-1");
+        assert_eq!(batch[1]["body"]["startTime"], "2024-02-29T13:00:00.000Z");
+        assert_eq!(batch[1]["body"]["endTime"], "2024-02-29T13:00:00.250Z");
+        assert_eq!(batch[1]["body"]["usage"]["input"], 3);
+    }
+
+    #[test]
+    fn encodes_datadog_spans_and_helicone_logs() {
+        let datadog = ObservabilityCallbackSink::Datadog {
+            site: "datadoghq.eu".to_string(),
+            api_key: "dd".to_string(),
+            ml_app: "chat".to_string(),
+        };
+        let requests = encode_traces(&datadog, &[sample_trace()]);
+        assert_eq!(
+            requests[0].url,
+            "https://api.datadoghq.eu/api/intake/llm-obs/v1/trace/spans"
+        );
+        let span = &requests[0].body["data"]["attributes"]["spans"][0];
+        assert_eq!(span["duration"], 250_000_000u64);
+        assert_eq!(span["meta"]["input"]["messages"][0]["content"], "hi");
+        assert_eq!(span["meta"]["output"]["messages"][0]["content"], "hello");
+        assert_eq!(span["metrics"]["total_tokens"], 5);
+
+        let helicone = ObservabilityCallbackSink::Helicone {
+            base_url: "https://api.worker.helicone.ai".to_string(),
+            api_key: "hc".to_string(),
+        };
+        let requests = encode_traces(&helicone, &[sample_trace(), sample_trace()]);
+        assert_eq!(requests.len(), 2);
+        assert_eq!(
+            requests[0].url,
+            "https://api.worker.helicone.ai/custom/v1/log"
+        );
+        let log = &requests[0].body;
+        assert_eq!(
+            log["providerResponse"]["json"]["usage"]["completion_tokens"],
+            2
+        );
+        assert_eq!(log["timing"]["endTime"]["milliseconds"], 250);
+    }
+}
@@ -0,0 +1,325 @@
+//! Declarative provisioning: a resources file of teams and virtual keys,
+//! kept in version control, that `ditto-admin apply` reconciles against the
+//! gateway's keys.
+//!
+//! A team is a tenant: its budget and limits become `tenant_budget` /
+//! `tenant_limits` on every member key, and its `models` are the default
+//! `allow_models` of keys that do not list their own. The file manages the
+//! fields it names; everything else on an existing key (cache, guardrail
+//! rules, passthrough, allowed IPs, ...) is left as configured.
+
+use std::collections::{BTreeMap, BTreeSet};
+
+use serde::{Deserialize, Serialize};
+
+use super::{BudgetConfig, GatewayError, LimitsConfig, VirtualKeyConfig};
+
+#[derive(Clone, Debug, Default, Serialize, Deserialize)]
+pub struct ResourcesFile {
+    #[serde(default)]
+    pub teams: Vec<TeamResource>,
+    #[serde(default)]
+    pub keys: Vec<KeyResource>,
+}
+
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct TeamResource {
+    /// The `tenant_id` of the team's keys.
+    pub id: String,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub budget: Option<BudgetConfig>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub limits: Option<LimitsConfig>,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub models: Vec<String>,
+}
+
+#[derive(Clone, Debug, Serialize, Deserialize)]
+pub struct KeyResource {
+    pub id: String,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub team: Option<String>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub project_id: Option<String>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub user_id: Option<String>,
+    #[serde(default = "default_enabled")]
+    pub enabled: bool,
+    /// Environment variable holding the key's token, so secrets stay out of
+    /// the file. Without it an existing key keeps its token and a new key
+    /// gets a generated one.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub token_env: Option<String>,
+    #[serde(default)]
+    pub budget: BudgetConfig,
+    #[serde(default)]
+    pub limits: LimitsConfig,
+    /// `allow_models`; unset inherits the team's models.
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub models: Option<Vec<String>>,
+    #[serde(default, skip_serializing_if = "Option::is_none")]
+    pub route: Option<String>,
+    #[serde(default, skip_serializing_if = "Vec::is_empty")]
+    pub tags: Vec<String>,
+}
+
+fn default_enabled() -> bool {
+    true
+}
+
+/// What applying a resources file changes, in the order it is applied.
+#[derive(Debug, Default)]
+pub struct ApplyPlan {
+    pub create: Vec<VirtualKeyConfig>,
+    pub update: Vec<VirtualKeyConfig>,
+    /// Ids of keys missing from the file; only filled when pruning.
+    pub delete: Vec<String>,
+    pub unchanged: Vec<String>,
+}
+
+impl ApplyPlan {
+    pub fn is_empty(&self) -> bool {
+        self.create.is_empty() && self.update.is_empty() && self.delete.is_empty()
+    }
+}
+
+impl ResourcesFile {
+    pub fn validate(&self) -> Result<(), GatewayError> {
+        let mut teams = BTreeSet::new();
+        for team in &self.teams {
+            if team.id.trim().is_empty() {
+                return Err(invalid("resources teams[].id must not be empty"));
+            }
+            if !teams.insert(team.id.as_str()) {
+                return Err(invalid(format!(
+                    "resources team {} is declared twice",
+                    team.id
+                )));
+            }
+            if let Some(budget) = team.budget.as_ref() {
+                budget.validate(&format!("teams.{}.budget", team.id))?;
+            }
+        }
+        let mut keys = BTreeSet::new();
+        for key in &self.keys {
+            if key.id.trim().is_empty() {
+                return Err(invalid("resources keys[].id must not be empty"));
+            }
+            if !keys.insert(key.id.as_str()) {
+                return Err(invalid(format!(
+                    "resources key {} is declared twice",
+                    key.id
+                )));
+            }
+            if let Some(team) = key.team.as_deref()
+                && !teams.contains(team)
+            {
+                return Err(invalid(format!(
+                    "resources key {} refers to undeclared team {team}",
+                    key.id
+                )));
+            }
+            key.budget.validate(&format!("keys.{}.budget", key.id))?;
+        }
+        Ok(())
+    }
+
+    /// Diffs the file against `existing` keys. `tokens` holds the tokens read
+    /// from `token_env`, by key id; `new_token` mints tokens for new keys
+    /// that have none. With `prune`, keys missing from the file are deleted.
+    pub fn plan(
+        &self,
+        existing: &[VirtualKeyConfig],
+        tokens: &BTreeMap<String, String>,
+        prune: bool,
+        mut new_token: impl FnMut() -> String,
+    ) -> Result<ApplyPlan, GatewayError> {
+        self.validate()?;
+        let teams = self
+            .teams
+            .iter()
+            .map(|team| (team.id.as_str(), team))
+            .collect::<BTreeMap<_, _>>();
+        let existing = existing
+            .iter()
+            .map(|key| (key.id.as_str(), key))
+            .collect::<BTreeMap<_, _>>();
+
+        let mut plan = ApplyPlan::default();
+        for resource in &self.keys {
+            let team = resource
+                .team
+                .as_deref()
+                .and_then(|id| teams.get(id).copied());
+            let current = existing.get(resource.id.as_str()).copied();
+            let token = match (tokens.get(&resource.id), current) {
+                (Some(token), _) => token.clone(),
+                (None, Some(current)) => current.token.clone(),
+                (None, None) => new_token(),
+            };
+            let desired = resource.to_virtual_key(team, current, token);
+            match current {
+                None => plan.create.push(desired),
+                Some(current) if same_key(current, &desired) => {
+                    plan.unchanged.push(desired.id);
+                }
+                Some(_) => plan.update.push(desired),
+            }
+        }
+        if prune {
+            let declared = self
+                .keys
+                .iter()
+                .map(|key| key.id.as_str())
+                .collect::<BTreeSet<_>>();
+            plan.delete = existing
+                .keys()
+                .filter(|id| !declared.contains(*id))
+                .map(|id| id.to_string())
+                .collect();
+        }
+        Ok(plan)
+    }
+}
+
+impl KeyResource {
+    fn to_virtual_key(
+        &self,
+        team: Option<&TeamResource>,
+        current: Option<&VirtualKeyConfig>,
+        token: String,
+    ) -> VirtualKeyConfig {
+        let mut key = current
+            .cloned()
+            .unwrap_or_else(|| VirtualKeyConfig::new(self.id.clone(), String::new()));
+        key.token = token;
+        key.enabled = self.enabled;
+        key.tenant_id = team.map(|team| team.id.clone());
+        key.project_id = self.project_id.clone();
+        key.user_id = self.user_id.clone();
+        key.tenant_budget = team.and_then(|team| team.budget.clone());
+        key.tenant_limits = team.and_then(|team| team.limits.clone());
+        key.budget = self.budget.clone();
+        key.limits = self.limits.clone();
+        key.guardrails.allow_models = match (self.models.as_ref(), team) {
+            (Some(models), _) => models.clone(),
+            (None, Some(team)) => team.models.clone(),
+            (None, None) => Vec::new(),
+        };
+        key.route = self.route.clone();
+        key.tags = self.tags.clone();
+        key
+    }
+}
+
+fn same_key(a: &VirtualKeyConfig, b: &VirtualKeyConfig) -> bool {
+    serde_json::to_value(a).ok() == serde_json::to_value(b).ok()
+}
+
+fn invalid(reason: impl Into<String>) -> GatewayError {
+    GatewayError::InvalidRequest {
+        reason: reason.into(),
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn resources(raw: serde_json::Value) -> ResourcesFile {
+        serde_json::from_value(raw).expect("resources")
+    }
+
+    #[test]
+    fn plans_create_update_unchanged_and_prune() {
+        let file = resources(serde_json::json!({
+            "teams": [{
+                "id": "team-a",
+                "budget": {"total_usd_micros": 50_000_000},
+                "models": ["gpt-4o-mini"]
+            }],
+            "keys": [
+                {"id": "a-ci", "team": "team-a", "tags": ["ci"]},
+                {"id": "a-app", "team": "team-a", "limits": {"rpm": 60}},
+                {"id": "solo", "models": ["gpt-4.1"]}
+            ]
+        }));
+
+        let mut first = file
+            .plan(&[], &BTreeMap::new(), false, || "sk-ci".into())
+            .unwrap();
+        let mut a_ci = first.create.remove(0);
+        // Fields the file does not manage survive an update.
+        a_ci.guardrails.block_pii = true;
+        let mut a_app = VirtualKeyConfig::new("a-app", "sk-app");
+        a_app.tenant_id = Some("team-a".to_string());
+        let stale = VirtualKeyConfig::new("stale", "sk-stale");
+        let existing = vec![a_ci.clone(), a_app, stale];
+
+        let mut n = 0;
+        let plan = file
+            .plan(&existing, &BTreeMap::new(), true, || {
+                n += 1;
+                format!("sk-new-{n}")
+            })
+            .unwrap();
+        assert_eq!(plan.unchanged, vec!["a-ci".to_string()]);
+        assert_eq!(plan.create.len(), 1);
+        let solo = &plan.create[0];
+        assert_eq!(
+            (solo.id.as_str(), solo.token.as_str()),
+            ("solo", "sk-new-1")
+        );
+        assert_eq!(solo.guardrails.allow_models, vec!["gpt-4.1".to_string()]);
+        assert_eq!(plan.update.len(), 1);
+        let updated = &plan.update[0];
+        assert_eq!(updated.token, "sk-app");
+        assert_eq!(updated.limits.rpm, Some(60));
+        assert_eq!(
+            updated.tenant_budget.as_ref().unwrap().total_usd_micros,
+            Some(50_000_000)
+        );
+        assert_eq!(
+            updated.guardrails.allow_models,
+            vec!["gpt-4o-mini".to_string()]
+        );
+        assert_eq!(plan.delete, vec!["stale".to_string()]);
+
+        // Applying the same file again changes nothing.
+        let mut applied = vec![a_ci];
+        applied.extend(plan.create);
+        applied.extend(plan.update);
+        let plan = file
+            .plan(&applied, &BTreeMap::new(), true, || unreachable!())
+            .unwrap();
+        assert!(plan.is_empty(), "{plan:?}");
+        assert_eq!(plan.unchanged.len(), 3);
+    }
+
+    #[test]
+    fn token_from_env_replaces_the_current_token() {
+        let file = resources(serde_json::json!({
+            "keys": [{"id": "k", "token_env": "K_TOKEN"}]
+        }));
+        let existing = vec![VirtualKeyConfig::new("k", "sk-old")];
+        let tokens = BTreeMap::from([("k".to_string(), "sk-rotated".to_string())]);
+        let plan = file
+            .plan(&existing, &tokens, false, || unreachable!())
+            .unwrap();
+        assert_eq!(plan.update[0].token, "sk-rotated");
+        assert!(plan.delete.is_empty());
+    }
+
+    #[test]
+    fn rejects_duplicates_and_undeclared_teams() {
+        let duplicate = resources(serde_json::json!({
+            "keys": [{"id": "k"}, {"id": "k"}]
+        }));
+        assert!(duplicate.validate().is_err());
+        let unknown_team = resources(serde_json::json!({
+            "keys": [{"id": "k", "team": "nope"}]
+        }));
+        let err = unknown_team.validate().unwrap_err().to_string();
+        assert!(err.contains("undeclared team nope"), "{err}");
+    }
+}
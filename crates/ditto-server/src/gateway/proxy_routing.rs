@@ -325,6 +325,13 @@ impl BackendHealth {
         }
     }
 
+    /// Seconds left in a circuit breaker cooldown, if one is running.
+    pub fn cooldown_remaining_seconds(&self, now_epoch_seconds: u64) -> Option<u64> {
+        self.unhealthy_until_epoch_seconds
+            .filter(|until| *until > now_epoch_seconds)
+            .map(|until| until - now_epoch_seconds)
+    }
+
     pub fn record_success(&mut self) {
         self.consecutive_failures = 0;
         self.unhealthy_until_epoch_seconds = None;
@@ -462,7 +469,9 @@ mod tests {
         );
         assert!(!health.is_healthy(100));
         assert!(!health.is_healthy(104));
+        assert_eq!(health.cooldown_remaining_seconds(104), Some(1));
         assert!(health.is_healthy(105));
+        assert_eq!(health.cooldown_remaining_seconds(105), None);
 
         health.record_success();
         assert!(health.is_healthy(105));
@@ -183,6 +183,21 @@ pub fn responses_request_to_chat_completions(request: &Value) -> Result<Value, S
     if let Some(service_tier) = obj.get("service_tier") {
         out.insert("service_tier".to_string(), service_tier.clone());
     }
+    // Responses asks for logprobs via `include`; chat/completions via `logprobs`.
+    let include_logprobs = obj
+        .get("include")
+        .and_then(Value::as_array)
+        .is_some_and(|include| {
+            include
+                .iter()
+                .any(|item| item.as_str() == Some("message.output_text.logprobs"))
+        });
+    if include_logprobs {
+        out.insert("logprobs".to_string(), Value::Bool(true));
+        if let Some(top_logprobs) = obj.get("top_logprobs") {
+            out.insert("top_logprobs".to_string(), top_logprobs.clone());
+        }
+    }
 
     if let Some(tools) = obj.get("tools") {
         out.insert("tools".to_string(), tools.clone());
@@ -880,4 +895,21 @@ mod tests {
             responses_request_to_chat_completions(&request).expect_err("file_url should fail");
         assert!(err.contains("file_url"));
     }
+
+    #[test]
+    fn responses_request_to_chat_completions_maps_logprobs_include() {
+        let request = json!({
+            "model": "gpt-4o-mini",
+            "input": "hi",
+            "include": ["message.output_text.logprobs"],
+            "top_logprobs": 3
+        });
+        let mapped = responses_request_to_chat_completions(&request).expect("shim request");
+        assert_eq!(mapped.get("logprobs"), Some(&json!(true)));
+        assert_eq!(mapped.get("top_logprobs"), Some(&json!(3)));
+
+        let request = json!({ "model": "gpt-4o-mini", "input": "hi", "top_logprobs": 3 });
+        let mapped = responses_request_to_chat_completions(&request).expect("shim request");
+        assert!(mapped.get("logprobs").is_none());
+    }
 }
@@ -0,0 +1,219 @@
+//! Per-key hourly spend baselines for `observability.alerts.spend_anomaly`.
+//! The transport feeds it each response's `x-ditto-cost` and turns the
+//! flags into alert notifications and, optionally, 429s.
+
+use std::collections::{HashMap, VecDeque};
+
+use super::config::SpendAnomalyConfig;
+
+const HOUR_MS: u64 = 3_600_000;
+
+/// A key whose spend in `hour` crossed the configured multiple of its
+/// baseline.
+#[derive(Clone, Debug, PartialEq)]
+pub(crate) struct SpendAnomaly {
+    pub(crate) virtual_key_id: String,
+    /// Hours since the Unix epoch.
+    pub(crate) hour: u64,
+    pub(crate) hour_spend_usd: f64,
+    pub(crate) baseline_usd: f64,
+    /// The spend the hour had to exceed: `multiplier` × baseline, but never
+    /// below `min_hourly_usd`.
+    pub(crate) threshold_usd: f64,
+}
+
+#[derive(Default)]
+pub(crate) struct SpendAnomalyTracker {
+    keys: HashMap<String, KeySpendHistory>,
+}
+
+struct KeySpendHistory {
+    first_hour: u64,
+    /// `(hour, usd_micros)` for hours with spend, oldest first, covering at
+    /// most the baseline window plus the current hour.
+    hours: VecDeque<(u64, u64)>,
+    flagged: Option<SpendAnomaly>,
+}
+
+impl SpendAnomalyTracker {
+    /// Adds a request's spend to the key's current hour. Returns the anomaly
+    /// the first time that hour crosses its threshold; later requests in the
+    /// same hour only add to the spend.
+    pub(crate) fn record(
+        &mut self,
+        config: &SpendAnomalyConfig,
+        virtual_key_id: &str,
+        usd_micros: u64,
+        now_ms: u64,
+    ) -> Option<SpendAnomaly> {
+        let hour = now_ms / HOUR_MS;
+        let history = self
+            .keys
+            .entry(virtual_key_id.to_string())
+            .or_insert_with(|| KeySpendHistory {
+                first_hour: hour,
+                hours: VecDeque::new(),
+                flagged: None,
+            });
+        match history.hours.back_mut() {
+            Some((last, spend)) if *last == hour => *spend = spend.saturating_add(usd_micros),
+            _ => history.hours.push_back((hour, usd_micros)),
+        }
+        while history
+            .hours
+            .front()
+            .is_some_and(|(oldest, _)| oldest.saturating_add(config.baseline_hours) < hour)
+        {
+            history.hours.pop_front();
+        }
+        if history
+            .flagged
+            .as_ref()
+            .is_some_and(|flagged| flagged.hour == hour)
+        {
+            return None;
+        }
+        let anomaly = history.anomaly(config, virtual_key_id, hour)?;
+        history.flagged = Some(anomaly.clone());
+        Some(anomaly)
+    }
+
+    pub(crate) fn is_flagged(&self, virtual_key_id: &str, now_ms: u64) -> bool {
+        let hour = now_ms / HOUR_MS;
+        self.keys
+            .get(virtual_key_id)
+            .and_then(|history| history.flagged.as_ref())
+            .is_some_and(|flagged| flagged.hour == hour)
+    }
+
+    /// Clears flags raised in earlier hours and returns them, ordered by key.
+    pub(crate) fn expire(&mut self, now_ms: u64) -> Vec<SpendAnomaly> {
+        let hour = now_ms / HOUR_MS;
+        let mut expired = self
+            .keys
+            .values_mut()
+            .filter(|history| {
+                history
+                    .flagged
+                    .as_ref()
+                    .is_some_and(|flagged| flagged.hour < hour)
+            })
+            .filter_map(|history| history.flagged.take())
+            .collect::<Vec<_>>();
+        expired.sort_by(|a, b| a.virtual_key_id.cmp(&b.virtual_key_id));
+        expired
+    }
+}
+
+impl KeySpendHistory {
+    fn anomaly(
+        &self,
+        config: &SpendAnomalyConfig,
+        virtual_key_id: &str,
+        hour: u64,
+    ) -> Option<SpendAnomaly> {
+        let history_hours = hour
+            .saturating_sub(self.first_hour)
+            .min(config.baseline_hours);
+        if history_hours < config.min_history_hours.max(1) {
+            return None;
+        }
+        let spend_in = |range: std::ops::Range<u64>| -> u64 {
+            self.hours
+                .iter()
+                .filter(|(h, _)| range.contains(h))
+                .map(|(_, spend)| *spend)
+                .sum()
+        };
+        let hour_spend_usd = spend_in(hour..hour + 1) as f64 / 1_000_000.0;
+        let baseline_usd =
+            spend_in(hour - history_hours..hour) as f64 / 1_000_000.0 / history_hours as f64;
+        let threshold_usd = (config.multiplier * baseline_usd).max(config.min_hourly_usd);
+        (hour_spend_usd > threshold_usd).then(|| SpendAnomaly {
+            virtual_key_id: virtual_key_id.to_string(),
+            hour,
+            hour_spend_usd,
+            baseline_usd,
+            threshold_usd,
+        })
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    use crate::gateway::config::SpendAnomalyAction;
+
+    fn config() -> SpendAnomalyConfig {
+        SpendAnomalyConfig {
+            multiplier: 3.0,
+            min_hourly_usd: 1.0,
+            baseline_hours: 4,
+            min_history_hours: 2,
+            action: SpendAnomalyAction::Flag,
+            targets: Vec::new(),
+        }
+    }
+
+    #[test]
+    fn flags_hours_above_multiple_of_baseline_once() {
+        let config = config();
+        let mut tracker = SpendAnomalyTracker::default();
+        // Hours 10..=13 spend $1/hour: the first two only build history.
+        for hour in 10..14 {
+            assert_eq!(
+                tracker.record(&config, "key-1", 1_000_000, hour * HOUR_MS),
+                None
+            );
+        }
+        // Hour 14: baseline $1/hour over 4 hours, threshold $3.
+        let now = 14 * HOUR_MS;
+        assert_eq!(tracker.record(&config, "key-1", 3_000_000, now), None);
+        assert!(!tracker.is_flagged("key-1", now));
+        let anomaly = tracker
+            .record(&config, "key-1", 500_000, now + 1_000)
+            .expect("anomaly");
+        assert_eq!(anomaly.hour, 14);
+        assert_eq!(anomaly.hour_spend_usd, 3.5);
+        assert_eq!(anomaly.baseline_usd, 1.0);
+        assert_eq!(anomaly.threshold_usd, 3.0);
+        assert!(tracker.is_flagged("key-1", now + 2_000));
+        assert_eq!(
+            tracker.record(&config, "key-1", 5_000_000, now + 3_000),
+            None
+        );
+
+        assert!(tracker.expire(now + 4_000).is_empty());
+        let expired = tracker.expire(15 * HOUR_MS);
+        assert_eq!(expired, vec![anomaly]);
+        assert!(!tracker.is_flagged("key-1", 15 * HOUR_MS));
+    }
+
+    #[test]
+    fn new_and_small_keys_are_not_flagged() {
+        let config = config();
+        let mut tracker = SpendAnomalyTracker::default();
+        // Still learning its baseline after one hour.
+        tracker.record(&config, "new", 10_000, 10 * HOUR_MS);
+        assert_eq!(
+            tracker.record(&config, "new", 50_000_000, 11 * HOUR_MS),
+            None
+        );
+
+        // 100x its baseline, but below `min_hourly_usd`.
+        tracker.record(&config, "small", 1_000, 10 * HOUR_MS);
+        assert_eq!(
+            tracker.record(&config, "small", 900_000, 13 * HOUR_MS),
+            None
+        );
+
+        // A dormant key keeps its history, so a sudden burst is caught.
+        tracker.record(&config, "dormant", 1_000, 10 * HOUR_MS);
+        let anomaly = tracker
+            .record(&config, "dormant", 2_000_000, 30 * HOUR_MS)
+            .expect("anomaly");
+        assert_eq!(anomaly.baseline_usd, 0.0);
+        assert_eq!(anomaly.threshold_usd, 1.0);
+    }
+}
@@ -26,6 +26,45 @@ pub fn estimate_input_tokens(path_and_query: &str, model: &str, request: &Value)
     Some(clamp_usize_to_u32(tokens))
 }
 
+/// A prompt token count and the encoding that produced it.
+#[derive(Clone, Copy, Debug, PartialEq, Eq)]
+pub struct PromptTokenCount {
+    pub tokens: u32,
+    /// tiktoken encoding name, e.g. `o200k_base`.
+    pub tokenizer: &'static str,
+    /// Whether the encoding is the model's own. Models tiktoken does not know
+    /// (Anthropic, Gemini, open-weight models) are approximated with
+    /// `cl100k_base`.
+    pub exact: bool,
+}
+
+/// Counts the prompt tokens of a chat `messages` array, or of a `prompt`
+/// string or string array, for `model`. A `provider/` prefix on the model is
+/// ignored.
+pub fn count_prompt_tokens(model: &str, request: &Value) -> Option<PromptTokenCount> {
+    let model = model.rsplit('/').next().unwrap_or(model).trim();
+    let known = tokenizer::get_tokenizer(model);
+    let exact = known.is_some();
+    let bpe = bpe_for_model(model);
+    let tokens = if request.get("messages").is_some() {
+        count_chat_completions_input_tokens(model, bpe, request)?
+    } else {
+        count_string_or_array_tokens(bpe, request.get("prompt")?)?
+    };
+    Some(PromptTokenCount {
+        tokens: clamp_usize_to_u32(tokens),
+        tokenizer: tokenizer_name(known.unwrap_or(tokenizer::Tokenizer::Cl100kBase)),
+        exact,
+    })
+}
+
+/// Counts the tokens of generated `text` for `model`, e.g. streamed output
+/// whose usage the provider did not report.
+pub fn count_text_tokens(model: &str, text: &str) -> u32 {
+    let model = model.rsplit('/').next().unwrap_or(model).trim();
+    clamp_usize_to_u32(bpe_for_model(model).encode_with_special_tokens(text).len())
+}
+
 fn strip_query(path_and_query: &str) -> &str {
     path_and_query
         .split_once('?')
@@ -46,6 +85,18 @@ fn bpe_for_model(model: &str) -> &'static CoreBPE {
     }
 }
 
+fn tokenizer_name(tokenizer: tokenizer::Tokenizer) -> &'static str {
+    match tokenizer {
+        tokenizer::Tokenizer::O200kHarmony => "o200k_harmony",
+        tokenizer::Tokenizer::O200kBase => "o200k_base",
+        tokenizer::Tokenizer::Cl100kBase => "cl100k_base",
+        tokenizer::Tokenizer::R50kBase => "r50k_base",
+        tokenizer::Tokenizer::P50kBase => "p50k_base",
+        tokenizer::Tokenizer::P50kEdit => "p50k_edit",
+        tokenizer::Tokenizer::Gpt2 => "gpt2",
+    }
+}
+
 fn count_chat_completions_input_tokens(
     model: &str,
     bpe: &CoreBPE,
@@ -409,4 +460,27 @@ mod tests {
         let tokens = estimate_input_tokens("/v1/rerank", "gpt-4o-mini", &request).expect("tokens");
         assert_eq!(tokens, expected as u32);
     }
+
+    #[test]
+    fn counts_prompt_tokens_with_model_tokenizer_or_approximation() {
+        let request = serde_json::json!({
+            "messages": [{"role":"user","content":"hello"}],
+        });
+        let openai = count_prompt_tokens("openai/gpt-4o-mini", &request).expect("count");
+        assert_eq!(openai.tokenizer, "o200k_base");
+        assert!(openai.exact);
+        assert_eq!(
+            Some(openai.tokens),
+            estimate_input_tokens("/v1/chat/completions", "gpt-4o-mini", &request)
+        );
+
+        let claude = count_prompt_tokens("claude-3-5-sonnet-latest", &request).expect("count");
+        assert_eq!(claude.tokenizer, "cl100k_base");
+        assert!(!claude.exact);
+
+        let prompt = serde_json::json!({"prompt": "hello world"});
+        let count = count_prompt_tokens("gpt-4o", &prompt).expect("count");
+        assert_eq!(count.tokens, 2);
+        assert_eq!(count_prompt_tokens("gpt-4o", &serde_json::json!({})), None);
+    }
 }
@@ -272,7 +272,7 @@ pub(super) async fn handle_a2a_invoke(
 
     let mut outgoing_headers = headers.clone();
     sanitize_proxy_headers(&mut outgoing_headers, strip_authorization);
-    apply_backend_headers(&mut outgoing_headers, agent.backend.headers());
+    apply_backend_headers(&mut outgoing_headers, &agent.backend.headers());
 
     let upstream = match proxy_a2a_request(&agent, outgoing_headers, body.clone(), method).await {
         Ok(resp) => resp,
@@ -13,6 +13,13 @@ use super::*;
     feature = "gateway-store-mysql",
     feature = "gateway-store-redis"
 ))]
+use crate::gateway::domain::budget_ledger_base_scope;
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
 use omne_integrity_primitives::hash_sha256_json_chain;
 
 // inlined from admin/handlers.rs
@@ -59,7 +66,7 @@ fn default_audit_export_limit() -> usize {
     feature = "gateway-store-mysql",
     feature = "gateway-store-redis"
 ))]
-const MAX_ADMIN_LEDGER_LIMIT: usize = 10_000;
+pub(super) const MAX_ADMIN_LEDGER_LIMIT: usize = 10_000;
 
 #[cfg(any(
     feature = "gateway-store-sqlite",
@@ -67,7 +74,12 @@ const MAX_ADMIN_LEDGER_LIMIT: usize = 10_000;
     feature = "gateway-store-mysql",
     feature = "gateway-store-redis"
 ))]
-fn apply_admin_list_window<T>(items: &mut Vec<T>, offset: usize, limit: Option<usize>, max: usize) {
+pub(super) fn apply_admin_list_window<T>(
+    items: &mut Vec<T>,
+    offset: usize,
+    limit: Option<usize>,
+    max: usize,
+) {
     if offset > 0 {
         if offset >= items.len() {
             items.clear();
@@ -187,6 +199,7 @@ pub(super) async fn purge_proxy_cache(
         ))]
         append_admin_audit_log(
             &state,
+            &admin,
             "admin.proxy_cache.purge",
             serde_json::json!({
                 "all": true,
@@ -257,6 +270,7 @@ pub(super) async fn purge_proxy_cache(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "admin.proxy_cache.purge",
         serde_json::json!({
             "all": false,
@@ -287,6 +301,9 @@ pub(super) struct AuditQuery {
     limit: usize,
     #[serde(default)]
     since_ts_ms: Option<u64>,
+    /// Keeps records whose `tags` (request and key tags) contain this tag.
+    #[serde(default)]
+    tag: Option<String>,
 }
 
 #[cfg(any(
@@ -340,6 +357,9 @@ pub(super) async fn list_audit_logs(
                     == Some(tenant_id)
             });
         }
+        if let Some(tag) = query.tag.as_deref() {
+            logs.retain(|log| audit_log_has_tag(log, tag));
+        }
         for log in &mut logs {
             log.payload = state.redactor.redact(std::mem::take(&mut log.payload));
         }
@@ -366,6 +386,9 @@ pub(super) async fn list_audit_logs(
                     == Some(tenant_id)
             });
         }
+        if let Some(tag) = query.tag.as_deref() {
+            logs.retain(|log| audit_log_has_tag(log, tag));
+        }
         for log in &mut logs {
             log.payload = state.redactor.redact(std::mem::take(&mut log.payload));
         }
@@ -392,6 +415,9 @@ pub(super) async fn list_audit_logs(
                     == Some(tenant_id)
             });
         }
+        if let Some(tag) = query.tag.as_deref() {
+            logs.retain(|log| audit_log_has_tag(log, tag));
+        }
         for log in &mut logs {
             log.payload = state.redactor.redact(std::mem::take(&mut log.payload));
         }
@@ -418,6 +444,9 @@ pub(super) async fn list_audit_logs(
                     == Some(tenant_id)
             });
         }
+        if let Some(tag) = query.tag.as_deref() {
+            logs.retain(|log| audit_log_has_tag(log, tag));
+        }
         for log in &mut logs {
             log.payload = state.redactor.redact(std::mem::take(&mut log.payload));
         }
@@ -431,6 +460,19 @@ pub(super) async fn list_audit_logs(
     ))
 }
 
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+fn audit_log_has_tag(log: &AuditLogRecord, tag: &str) -> bool {
+    log.payload
+        .get("tags")
+        .and_then(serde_json::Value::as_array)
+        .is_some_and(|tags| tags.iter().any(|candidate| candidate.as_str() == Some(tag)))
+}
+
 #[cfg(any(
     feature = "gateway-store-sqlite",
     feature = "gateway-store-postgres",
@@ -737,7 +779,7 @@ pub(super) async fn list_budget_ledgers(
             )
         })?;
         if let Some(scopes) = tenant_scopes.as_ref() {
-            ledgers.retain(|ledger| scopes.contains(ledger.key_id.as_str()));
+            ledgers.retain(|ledger| scopes.contains(budget_ledger_base_scope(&ledger.key_id)));
         }
         if let Some(key_prefix) = key_prefix {
             ledgers.retain(|ledger| ledger.key_id.starts_with(key_prefix));
@@ -761,7 +803,7 @@ pub(super) async fn list_budget_ledgers(
             )
         })?;
         if let Some(scopes) = tenant_scopes.as_ref() {
-            ledgers.retain(|ledger| scopes.contains(ledger.key_id.as_str()));
+            ledgers.retain(|ledger| scopes.contains(budget_ledger_base_scope(&ledger.key_id)));
         }
         if let Some(key_prefix) = key_prefix {
             ledgers.retain(|ledger| ledger.key_id.starts_with(key_prefix));
@@ -785,7 +827,7 @@ pub(super) async fn list_budget_ledgers(
             )
         })?;
         if let Some(scopes) = tenant_scopes.as_ref() {
-            ledgers.retain(|ledger| scopes.contains(ledger.key_id.as_str()));
+            ledgers.retain(|ledger| scopes.contains(budget_ledger_base_scope(&ledger.key_id)));
         }
         if let Some(key_prefix) = key_prefix {
             ledgers.retain(|ledger| ledger.key_id.starts_with(key_prefix));
@@ -809,7 +851,7 @@ pub(super) async fn list_budget_ledgers(
             )
         })?;
         if let Some(scopes) = tenant_scopes.as_ref() {
-            ledgers.retain(|ledger| scopes.contains(ledger.key_id.as_str()));
+            ledgers.retain(|ledger| scopes.contains(budget_ledger_base_scope(&ledger.key_id)));
         }
         if let Some(key_prefix) = key_prefix {
             ledgers.retain(|ledger| ledger.key_id.starts_with(key_prefix));
@@ -894,7 +936,7 @@ fn group_budget_ledgers_by_project(
 
     let mut grouped = BTreeMap::<Option<String>, (u64, u64, usize, u64)>::new();
     for ledger in ledgers {
-        let ledger_key_id = ledger.key_id.as_str();
+        let ledger_key_id = budget_ledger_base_scope(&ledger.key_id);
         let project_id = if let Some(project_id) = key_to_project.get(ledger_key_id).copied() {
             project_id.map(|id| id.to_string())
         } else if ledger_key_id.starts_with("tenant:")
@@ -947,7 +989,7 @@ fn group_budget_ledgers_by_user(
 
     let mut grouped = BTreeMap::<Option<String>, (u64, u64, usize, u64)>::new();
     for ledger in ledgers {
-        let ledger_key_id = ledger.key_id.as_str();
+        let ledger_key_id = budget_ledger_base_scope(&ledger.key_id);
         let user_id = if let Some(user_id) = key_to_user.get(ledger_key_id).copied() {
             user_id.map(|id| id.to_string())
         } else if ledger_key_id.starts_with("tenant:")
@@ -1000,7 +1042,7 @@ fn group_budget_ledgers_by_tenant(
 
     let mut grouped = BTreeMap::<Option<String>, (u64, u64, usize, u64)>::new();
     for ledger in ledgers {
-        let ledger_key_id = ledger.key_id.as_str();
+        let ledger_key_id = budget_ledger_base_scope(&ledger.key_id);
         let tenant_id = if let Some(tenant_id) = key_to_tenant.get(ledger_key_id).copied() {
             tenant_id.map(|id| id.to_string())
         } else if ledger_key_id.starts_with("tenant:")
@@ -1289,7 +1331,7 @@ pub(super) async fn list_cost_ledgers(
             )
         })?;
         if let Some(scopes) = tenant_scopes.as_ref() {
-            ledgers.retain(|ledger| scopes.contains(ledger.key_id.as_str()));
+            ledgers.retain(|ledger| scopes.contains(budget_ledger_base_scope(&ledger.key_id)));
         }
         if let Some(key_prefix) = key_prefix {
             ledgers.retain(|ledger| ledger.key_id.starts_with(key_prefix));
@@ -1313,7 +1355,7 @@ pub(super) async fn list_cost_ledgers(
             )
         })?;
         if let Some(scopes) = tenant_scopes.as_ref() {
-            ledgers.retain(|ledger| scopes.contains(ledger.key_id.as_str()));
+            ledgers.retain(|ledger| scopes.contains(budget_ledger_base_scope(&ledger.key_id)));
         }
         if let Some(key_prefix) = key_prefix {
             ledgers.retain(|ledger| ledger.key_id.starts_with(key_prefix));
@@ -1337,7 +1379,7 @@ pub(super) async fn list_cost_ledgers(
             )
         })?;
         if let Some(scopes) = tenant_scopes.as_ref() {
-            ledgers.retain(|ledger| scopes.contains(ledger.key_id.as_str()));
+            ledgers.retain(|ledger| scopes.contains(budget_ledger_base_scope(&ledger.key_id)));
         }
         if let Some(key_prefix) = key_prefix {
             ledgers.retain(|ledger| ledger.key_id.starts_with(key_prefix));
@@ -1361,7 +1403,7 @@ pub(super) async fn list_cost_ledgers(
             )
         })?;
         if let Some(scopes) = tenant_scopes.as_ref() {
-            ledgers.retain(|ledger| scopes.contains(ledger.key_id.as_str()));
+            ledgers.retain(|ledger| scopes.contains(budget_ledger_base_scope(&ledger.key_id)));
         }
         if let Some(key_prefix) = key_prefix {
             ledgers.retain(|ledger| ledger.key_id.starts_with(key_prefix));
@@ -1458,7 +1500,7 @@ fn group_cost_ledgers_by_project(
 
     let mut grouped = BTreeMap::<Option<String>, (u64, u64, usize, u64)>::new();
     for ledger in ledgers {
-        let ledger_key_id = ledger.key_id.as_str();
+        let ledger_key_id = budget_ledger_base_scope(&ledger.key_id);
         let project_id = if let Some(project_id) = key_to_project.get(ledger_key_id).copied() {
             project_id.map(|id| id.to_string())
         } else if ledger_key_id.starts_with("tenant:")
@@ -1514,7 +1556,7 @@ fn group_cost_ledgers_by_user(
 
     let mut grouped = BTreeMap::<Option<String>, (u64, u64, usize, u64)>::new();
     for ledger in ledgers {
-        let ledger_key_id = ledger.key_id.as_str();
+        let ledger_key_id = budget_ledger_base_scope(&ledger.key_id);
         let user_id = if let Some(user_id) = key_to_user.get(ledger_key_id).copied() {
             user_id.map(|id| id.to_string())
         } else if ledger_key_id.starts_with("tenant:")
@@ -1570,7 +1612,7 @@ fn group_cost_ledgers_by_tenant(
 
     let mut grouped = BTreeMap::<Option<String>, (u64, u64, usize, u64)>::new();
     for ledger in ledgers {
-        let ledger_key_id = ledger.key_id.as_str();
+        let ledger_key_id = budget_ledger_base_scope(&ledger.key_id);
         let tenant_id = if let Some(tenant_id) = key_to_tenant.get(ledger_key_id).copied() {
             tenant_id.map(|id| id.to_string())
         } else if ledger_key_id.starts_with("tenant:")
@@ -1907,6 +1949,7 @@ pub(super) async fn reset_backend(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "admin.backend.reset",
         serde_json::json!({
             "backend": &name,
@@ -2041,6 +2084,7 @@ pub(super) async fn upsert_key(
         .collect::<std::collections::HashSet<_>>();
     crate::gateway::config::validate_virtual_key_payload(&key, 0, &backend_names)
         .map_err(map_gateway_error)?;
+    validate_key_tier(&state, &key).map_err(map_gateway_error)?;
     let (inserted, _) = apply_control_plane_change(&state, "admin.key.upsert", |gateway| {
         Ok(gateway.upsert_virtual_key(key.clone()))
     })
@@ -2065,6 +2109,7 @@ pub(super) async fn upsert_key(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "admin.key.upsert",
         serde_json::json!({
             "key_id": &key.id,
@@ -2112,6 +2157,7 @@ pub(super) async fn upsert_key_with_id(
         .collect::<std::collections::HashSet<_>>();
     crate::gateway::config::validate_virtual_key_payload(&key, 0, &backend_names)
         .map_err(map_gateway_error)?;
+    validate_key_tier(&state, &key).map_err(map_gateway_error)?;
     let (inserted, _) = apply_control_plane_change(&state, "admin.key.upsert", |gateway| {
         Ok(gateway.upsert_virtual_key(key.clone()))
     })
@@ -2136,6 +2182,7 @@ pub(super) async fn upsert_key_with_id(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "admin.key.upsert",
         serde_json::json!({
             "key_id": &key.id,
@@ -2204,6 +2251,7 @@ pub(super) async fn delete_key(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "admin.key.delete",
         serde_json::json!({
             "key_id": &id,
@@ -2680,7 +2728,7 @@ pub(super) fn map_gateway_error(err: GatewayError) -> (StatusCode, Json<ErrorRes
         ),
         GatewayError::GuardrailRejected { reason } => error_response(
             StatusCode::FORBIDDEN,
-            "guardrail_rejected",
+            guardrail_error_code(&reason),
             format!("guardrail rejected: {reason}"),
         ),
         GatewayError::BudgetExceeded { limit, attempted } => error_response(
@@ -2712,6 +2760,11 @@ pub(super) fn map_gateway_error(err: GatewayError) -> (StatusCode, Json<ErrorRes
         GatewayError::InvalidRequest { reason } => {
             error_response(StatusCode::BAD_REQUEST, "invalid_request", reason)
         }
+        GatewayError::RegionUnavailable { regions } => error_response(
+            StatusCode::BAD_REQUEST,
+            "region_unavailable",
+            format!("no backend available in required region: {regions}"),
+        ),
     }
 }
 
@@ -2746,6 +2799,11 @@ mod admin_auth_tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
         GatewayHttpState::new(crate::gateway::Gateway::new(config))
     }
@@ -12,6 +12,17 @@ pub(super) struct AdminContext {
     pub(super) can_manage_secrets: bool,
 }
 
+impl AdminContext {
+    /// Who performed an admin mutation, as recorded in the audit log. Admin
+    /// tokens are not named, so this identifies the token class, not a person.
+    pub(super) fn audit_actor(&self) -> String {
+        match self.tenant_id.as_deref() {
+            Some(tenant_id) => format!("tenant:{tenant_id}"),
+            None => "admin".to_string(),
+        }
+    }
+}
+
 pub(super) fn ensure_admin_read(
     state: &GatewayHttpState,
     headers: &HeaderMap,
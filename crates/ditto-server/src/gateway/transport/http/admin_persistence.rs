@@ -149,10 +149,17 @@ fn report_admin_audit_append_failure(store: &str, kind: &str, err: &impl std::fm
 ))]
 pub(super) async fn append_admin_audit_log(
     state: &GatewayHttpState,
+    admin: &AdminContext,
     kind: &str,
-    payload: serde_json::Value,
+    mut payload: serde_json::Value,
 ) -> Result<(), (StatusCode, Json<ErrorResponse>)> {
     let selected_target = selected_control_plane_persistence_target(state)?;
+    if let Some(fields) = payload.as_object_mut() {
+        fields.insert(
+            "actor".to_string(),
+            serde_json::Value::String(admin.audit_actor()),
+        );
+    }
     let Some(payload) = state.prepare_observability_event(
         crate::gateway::observability::GatewayObservabilitySink::Audit,
         payload,
@@ -235,10 +242,12 @@ fn persist_state_file(
     path: &StdPath,
     keys: &[VirtualKeyConfig],
     router: &RouterConfig,
+    prompts: Vec<PromptTemplate>,
 ) -> Result<(), (StatusCode, Json<ErrorResponse>)> {
     GatewayStateFile {
         virtual_keys: keys.to_vec(),
         router: Some(router.clone()),
+        prompts,
     }
     .save(path)
     .map_err(|err| {
@@ -267,7 +276,12 @@ async fn persist_control_plane_state(
                 .state_file
                 .as_ref()
                 .expect("selected state file target must exist");
-            persist_state_file(path.as_path(), &stored_keys, router)?;
+            persist_state_file(
+                path.as_path(),
+                &stored_keys,
+                router,
+                state.prompt_registry_snapshot().templates(),
+            )?;
         }
         #[cfg(feature = "gateway-store-sqlite")]
         Some(ControlPlanePersistenceTarget::Store(GatewayStoreTarget::Sqlite)) => {
@@ -410,6 +424,28 @@ pub(super) async fn apply_control_plane_change<T>(
     }
 }
 
+/// Applies a prompt registry change. Prompts share the state file with the
+/// control plane; with a database store they are kept in memory only.
+pub(super) async fn apply_prompt_change<T>(
+    state: &GatewayHttpState,
+    mutate: impl FnOnce(&mut PromptRegistry) -> Result<T, (StatusCode, Json<ErrorResponse>)>,
+) -> Result<T, (StatusCode, Json<ErrorResponse>)> {
+    let _write_guard = state.lock_control_plane_writes().await;
+    let mut staged = state.prompt_registry_snapshot();
+    let value = mutate(&mut staged)?;
+
+    if let Some(path) = state.admin.state_file.as_ref() {
+        persist_state_file(
+            path.as_path(),
+            &state.list_virtual_keys_snapshot(),
+            &state.router_config_snapshot(),
+            staged.templates(),
+        )?;
+    }
+    state.replace_prompt_registry(staged);
+    Ok(value)
+}
+
 #[cfg(test)]
 mod tests {
     use super::*;
@@ -445,6 +481,7 @@ mod tests {
         key.budget = BudgetConfig {
             total_tokens: Some(5),
             total_usd_micros: None,
+            reset: None,
         };
 
         let config = GatewayConfig {
@@ -460,6 +497,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let mut gateway = Gateway::new(config);
@@ -519,6 +561,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let mut gateway = Gateway::new(config);
@@ -593,6 +640,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let mut gateway = Gateway::new(config);
@@ -637,6 +689,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let mut gateway = Gateway::new(config);
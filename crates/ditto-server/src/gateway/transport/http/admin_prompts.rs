@@ -0,0 +1,261 @@
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+use super::admin_persistence::append_admin_audit_log;
+use super::admin_persistence::apply_prompt_change;
+use super::*;
+use crate::gateway::PromptMessage;
+
+#[derive(Debug, Deserialize)]
+pub(super) struct PublishPromptRequest {
+    id: String,
+    messages: Vec<PromptMessage>,
+    #[serde(default)]
+    model: Option<String>,
+    #[serde(default)]
+    description: Option<String>,
+}
+
+/// A template as returned by the admin API, with the variables it expects.
+#[derive(Debug, Serialize)]
+pub(super) struct PromptTemplateResponse {
+    #[serde(flatten)]
+    template: PromptTemplate,
+    variables: Vec<String>,
+}
+
+impl From<PromptTemplate> for PromptTemplateResponse {
+    fn from(template: PromptTemplate) -> Self {
+        Self {
+            variables: template.variables(),
+            template,
+        }
+    }
+}
+
+impl GatewayHttpState {
+    pub(super) fn prompt_registry_snapshot(&self) -> PromptRegistry {
+        self.prompts
+            .read()
+            .expect("gateway http prompt registry poisoned; refusing to continue")
+            .clone()
+    }
+
+    pub(super) fn replace_prompt_registry(&self, registry: PromptRegistry) {
+        *self
+            .prompts
+            .write()
+            .expect("gateway http prompt registry poisoned; refusing to continue") = registry;
+    }
+
+    pub(super) fn prompt_template(&self, id: &str, version: Option<u32>) -> Option<PromptTemplate> {
+        self.prompts
+            .read()
+            .expect("gateway http prompt registry poisoned; refusing to continue")
+            .get(id, version)
+            .cloned()
+    }
+}
+
+fn ensure_global_prompt_admin(
+    admin: &AdminContext,
+) -> Result<(), (StatusCode, Json<ErrorResponse>)> {
+    if admin.tenant_id.is_some() {
+        return Err(error_response(
+            StatusCode::FORBIDDEN,
+            "forbidden",
+            "tenant-scoped admin tokens cannot manage prompt templates",
+        ));
+    }
+    Ok(())
+}
+
+fn prompt_not_found(id: &str) -> (StatusCode, Json<ErrorResponse>) {
+    error_response(
+        StatusCode::NOT_FOUND,
+        "not_found",
+        format!("prompt template not found: {id}"),
+    )
+}
+
+pub(super) async fn list_prompts(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+) -> Result<Json<Vec<PromptTemplateResponse>>, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_read(&state, &headers)?;
+    ensure_global_prompt_admin(&admin)?;
+
+    let prompts = state
+        .prompt_registry_snapshot()
+        .latest()
+        .cloned()
+        .map(PromptTemplateResponse::from)
+        .collect();
+    Ok(Json(prompts))
+}
+
+pub(super) async fn publish_prompt(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Json(request): Json<PublishPromptRequest>,
+) -> Result<impl IntoResponse, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_write(&state, &headers)?;
+    ensure_global_prompt_admin(&admin)?;
+
+    let template = PromptTemplate {
+        id: request.id.trim().to_string(),
+        version: 0,
+        messages: request.messages,
+        model: request
+            .model
+            .map(|model| model.trim().to_string())
+            .filter(|model| !model.is_empty()),
+        description: request.description,
+        created_at_ms: SystemTime::now()
+            .duration_since(UNIX_EPOCH)
+            .map(|duration| duration.as_millis() as u64)
+            .unwrap_or(0),
+    };
+    template
+        .validate()
+        .map_err(|err| error_response(StatusCode::BAD_REQUEST, "invalid_request", err))?;
+
+    let published = apply_prompt_change(&state, |registry| Ok(registry.publish(template))).await?;
+
+    #[cfg(feature = "sdk")]
+    emit_devtools_log(
+        &state,
+        "admin.prompt.publish",
+        serde_json::json!({
+            "prompt_id": &published.id,
+            "version": published.version,
+        }),
+    );
+
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    append_admin_audit_log(
+        &state,
+        &admin,
+        "admin.prompt.publish",
+        serde_json::json!({
+            "prompt_id": &published.id,
+            "version": published.version,
+            "model": published.model.as_deref(),
+        }),
+    )
+    .await?;
+
+    Ok((
+        StatusCode::CREATED,
+        Json(PromptTemplateResponse::from(published)),
+    ))
+}
+
+pub(super) async fn get_prompt(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Path(id): Path<String>,
+) -> Result<Json<PromptTemplateResponse>, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_read(&state, &headers)?;
+    ensure_global_prompt_admin(&admin)?;
+
+    let template = state
+        .prompt_template(&id, None)
+        .ok_or_else(|| prompt_not_found(&id))?;
+    Ok(Json(template.into()))
+}
+
+pub(super) async fn list_prompt_versions(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Path(id): Path<String>,
+) -> Result<Json<Vec<PromptTemplateResponse>>, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_read(&state, &headers)?;
+    ensure_global_prompt_admin(&admin)?;
+
+    let registry = state.prompt_registry_snapshot();
+    let versions = registry
+        .versions(&id)
+        .ok_or_else(|| prompt_not_found(&id))?;
+    Ok(Json(
+        versions
+            .iter()
+            .rev()
+            .cloned()
+            .map(PromptTemplateResponse::from)
+            .collect(),
+    ))
+}
+
+pub(super) async fn get_prompt_version(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Path((id, version)): Path<(String, u32)>,
+) -> Result<Json<PromptTemplateResponse>, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_read(&state, &headers)?;
+    ensure_global_prompt_admin(&admin)?;
+
+    let template = state.prompt_template(&id, Some(version)).ok_or_else(|| {
+        error_response(
+            StatusCode::NOT_FOUND,
+            "not_found",
+            format!("prompt template version not found: {id}@{version}"),
+        )
+    })?;
+    Ok(Json(template.into()))
+}
+
+pub(super) async fn delete_prompt(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Path(id): Path<String>,
+) -> Result<impl IntoResponse, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_write(&state, &headers)?;
+    ensure_global_prompt_admin(&admin)?;
+
+    let removed = apply_prompt_change(&state, |registry| match registry.remove(&id) {
+        0 => Err(prompt_not_found(&id)),
+        removed => Ok(removed),
+    })
+    .await?;
+
+    #[cfg(feature = "sdk")]
+    emit_devtools_log(
+        &state,
+        "admin.prompt.delete",
+        serde_json::json!({
+            "prompt_id": &id,
+            "versions": removed,
+        }),
+    );
+
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    append_admin_audit_log(
+        &state,
+        &admin,
+        "admin.prompt.delete",
+        serde_json::json!({
+            "prompt_id": &id,
+            "versions": removed,
+        }),
+    )
+    .await?;
+
+    #[cfg(not(feature = "sdk"))]
+    let _ = removed;
+
+    Ok(StatusCode::NO_CONTENT)
+}
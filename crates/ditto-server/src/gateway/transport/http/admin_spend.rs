@@ -0,0 +1,326 @@
+#![cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+
+use super::admin::{MAX_ADMIN_LEDGER_LIMIT, apply_admin_list_window};
+use super::*;
+
+use std::collections::HashSet;
+
+use crate::gateway::domain::spend_report::{
+    SpendBucket, SpendEntry, SpendFilter, SpendGroupBy, SpendReport, SpendReportRow,
+};
+
+/// Audit records read per store round-trip (the store-side maximum).
+const SPEND_AUDIT_PAGE: usize = 10_000;
+/// Audit records scanned per report before it is cut short.
+const MAX_SPEND_AUDIT_RECORDS: usize = 200_000;
+
+#[derive(Debug, Deserialize)]
+pub(super) struct SpendQuery {
+    #[serde(default)]
+    bucket: Option<SpendBucket>,
+    #[serde(default)]
+    since_ts_ms: Option<u64>,
+    #[serde(default)]
+    before_ts_ms: Option<u64>,
+    #[serde(default)]
+    key_id: Option<String>,
+    #[serde(default)]
+    tenant_id: Option<String>,
+    #[serde(default)]
+    project_id: Option<String>,
+    #[serde(default)]
+    user_id: Option<String>,
+    #[serde(default)]
+    model: Option<String>,
+    #[serde(default)]
+    tag: Option<String>,
+    #[serde(default)]
+    limit: Option<usize>,
+    #[serde(default)]
+    offset: usize,
+}
+
+type SpendResponse =
+    Result<(HeaderMap, Json<Vec<SpendReportRow>>), (StatusCode, Json<ErrorResponse>)>;
+
+pub(super) async fn list_key_spend(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Query(query): Query<SpendQuery>,
+) -> SpendResponse {
+    spend_report(&state, &headers, query, SpendGroupBy::Key).await
+}
+
+pub(super) async fn list_tenant_spend(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Query(query): Query<SpendQuery>,
+) -> SpendResponse {
+    spend_report(&state, &headers, query, SpendGroupBy::Tenant).await
+}
+
+pub(super) async fn list_project_spend(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Query(query): Query<SpendQuery>,
+) -> SpendResponse {
+    spend_report(&state, &headers, query, SpendGroupBy::Project).await
+}
+
+pub(super) async fn list_user_spend(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Query(query): Query<SpendQuery>,
+) -> SpendResponse {
+    spend_report(&state, &headers, query, SpendGroupBy::User).await
+}
+
+pub(super) async fn list_model_spend(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Query(query): Query<SpendQuery>,
+) -> SpendResponse {
+    spend_report(&state, &headers, query, SpendGroupBy::Model).await
+}
+
+pub(super) async fn list_tag_spend(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Query(query): Query<SpendQuery>,
+) -> SpendResponse {
+    spend_report(&state, &headers, query, SpendGroupBy::Tag).await
+}
+
+/// Aggregates the `proxy` and `gateway` audit records in
+/// `[since_ts_ms, before_ts_ms)` by `group_by` (and `bucket`). When the window
+/// holds more than `MAX_SPEND_AUDIT_RECORDS` records, the newest ones are used
+/// and the response carries `x-ditto-spend-truncated: true`.
+async fn spend_report(
+    state: &GatewayHttpState,
+    headers: &HeaderMap,
+    query: SpendQuery,
+    group_by: SpendGroupBy,
+) -> SpendResponse {
+    let admin = ensure_admin_read(state, headers)?;
+
+    let mut filter = SpendFilter {
+        key_id: query.key_id,
+        tenant_id: query.tenant_id,
+        project_id: query.project_id,
+        user_id: query.user_id,
+        model: query.model,
+        tag: query.tag,
+    };
+    if let Some(tenant_id) = admin.tenant_id.as_deref() {
+        if filter
+            .tenant_id
+            .as_deref()
+            .is_some_and(|requested| requested != tenant_id)
+        {
+            return Err(error_response(
+                StatusCode::FORBIDDEN,
+                "forbidden",
+                "tenant-scoped admin tokens can only report their own tenant",
+            ));
+        }
+        filter.tenant_id = Some(tenant_id.to_string());
+    }
+
+    let (mut rows, truncated) = aggregate_spend(
+        state,
+        &filter,
+        group_by,
+        query.bucket,
+        query.since_ts_ms,
+        query.before_ts_ms,
+    )
+    .await?;
+    apply_admin_list_window(&mut rows, query.offset, query.limit, MAX_ADMIN_LEDGER_LIMIT);
+
+    let mut response_headers = HeaderMap::new();
+    if truncated {
+        response_headers.insert(
+            "x-ditto-spend-truncated",
+            axum::http::HeaderValue::from_static("true"),
+        );
+    }
+    Ok((response_headers, Json(rows)))
+}
+
+/// Scans the audit records in `[since_ts_ms, before_ts_ms)` that match
+/// `filter` into one row per `group_by` (and `bucket`). The flag is set when
+/// the scan stopped at `MAX_SPEND_AUDIT_RECORDS`.
+pub(super) async fn aggregate_spend(
+    state: &GatewayHttpState,
+    filter: &SpendFilter,
+    group_by: SpendGroupBy,
+    bucket: Option<SpendBucket>,
+    since_ts_ms: Option<u64>,
+    before_ts_ms: Option<u64>,
+) -> Result<(Vec<SpendReportRow>, bool), (StatusCode, Json<ErrorResponse>)> {
+    let mut report = SpendReport::new(group_by, bucket);
+    let truncated = scan_spend_entries(
+        state,
+        since_ts_ms,
+        before_ts_ms,
+        MAX_SPEND_AUDIT_RECORDS,
+        |entry| {
+            if filter.matches(entry) {
+                report.record(entry);
+            }
+        },
+    )
+    .await?;
+    Ok((report.into_rows(), truncated))
+}
+
+/// Feeds every proxy and gateway audit record in `[since_ts_ms,
+/// before_ts_ms)` to `record`, newest first, as a spend entry. Returns
+/// whether the scan stopped after `max_records` records.
+pub(super) async fn scan_spend_entries(
+    state: &GatewayHttpState,
+    since_ts_ms: Option<u64>,
+    before_ts_ms: Option<u64>,
+    max_records: usize,
+    mut record: impl FnMut(&SpendEntry<'_>),
+) -> Result<bool, (StatusCode, Json<ErrorResponse>)> {
+    let keys = state.list_virtual_keys_snapshot();
+    let keys: HashMap<&str, &VirtualKeyConfig> =
+        keys.iter().map(|key| (key.id.as_str(), key)).collect();
+
+    let mut seen = HashSet::<i64>::new();
+    let mut before_ts_ms = before_ts_ms;
+    loop {
+        let page = list_spend_audit_logs(state, since_ts_ms, before_ts_ms).await?;
+        let full_page = page.len() >= SPEND_AUDIT_PAGE;
+        let oldest_ts_ms = page.last().map(|record| record.ts_ms);
+        let mut fresh = 0usize;
+        for audit in &page {
+            if !seen.insert(audit.id) {
+                continue;
+            }
+            fresh += 1;
+            if audit.kind != "proxy" && audit.kind != "gateway" {
+                continue;
+            }
+            record(&spend_entry(audit, &keys));
+        }
+        if !full_page {
+            return Ok(false);
+        }
+        if fresh == 0 || seen.len() >= max_records {
+            return Ok(true);
+        }
+        // Records sharing the oldest millisecond may straddle pages; `seen`
+        // drops the ones read twice.
+        before_ts_ms = oldest_ts_ms.map(|ts_ms| ts_ms.saturating_add(1));
+    }
+}
+
+fn spend_entry<'a>(
+    record: &'a AuditLogRecord,
+    keys: &HashMap<&str, &'a VirtualKeyConfig>,
+) -> SpendEntry<'a> {
+    let payload = &record.payload;
+    let str_field = move |name: &str| payload.get(name).and_then(Value::as_str);
+    let u64_field = move |name: &str| payload.get(name).and_then(Value::as_u64);
+    let key_id = str_field("virtual_key_id");
+    let key = key_id.and_then(|key_id| keys.get(key_id).copied());
+    let tags = match payload.get("tags").and_then(Value::as_array) {
+        Some(tags) => tags.iter().filter_map(Value::as_str).collect(),
+        None => key.map_or_else(Vec::new, |key| {
+            key.tags.iter().map(String::as_str).collect()
+        }),
+    };
+
+    let (input_tokens, output_tokens, spent_tokens, spent_usd_micros) = if record.kind == "gateway"
+    {
+        (0, 0, u64_field("tokens"), None)
+    } else {
+        // The responses shim records only its charge; everything else records
+        // what was actually spent (null when the request failed).
+        let spent_tokens = match payload.get("spent_tokens") {
+            Some(value) => value.as_u64(),
+            None => u64_field("charge_tokens"),
+        };
+        let spent_usd_micros = match payload.get("spent_cost_usd_micros") {
+            Some(value) => value.as_u64(),
+            None => u64_field("charge_cost_usd_micros"),
+        };
+        (
+            u64_field("input_tokens").unwrap_or_default(),
+            u64_field("output_tokens").unwrap_or_default(),
+            spent_tokens,
+            spent_usd_micros,
+        )
+    };
+
+    // Audit payloads carry the key's scope as of the request; records written
+    // before a field existed fall back to the key's current config.
+    SpendEntry {
+        ts_ms: record.ts_ms,
+        key_id,
+        tenant_id: str_field("tenant_id").or(key.and_then(|key| key.tenant_id.as_deref())),
+        project_id: str_field("project_id").or(key.and_then(|key| key.project_id.as_deref())),
+        user_id: str_field("user_id").or(key.and_then(|key| key.user_id.as_deref())),
+        model: str_field("model"),
+        tags,
+        input_tokens,
+        output_tokens,
+        spent_tokens: spent_tokens.unwrap_or_default(),
+        spent_usd_micros: spent_usd_micros.unwrap_or_default(),
+    }
+}
+
+async fn list_spend_audit_logs(
+    state: &GatewayHttpState,
+    since_ts_ms: Option<u64>,
+    before_ts_ms: Option<u64>,
+) -> Result<Vec<AuditLogRecord>, (StatusCode, Json<ErrorResponse>)> {
+    let storage_error =
+        |err: String| error_response(StatusCode::INTERNAL_SERVER_ERROR, "storage_error", err);
+
+    #[cfg(feature = "gateway-store-sqlite")]
+    if let Some(store) = state.stores.sqlite.as_ref() {
+        return store
+            .list_audit_logs_window(SPEND_AUDIT_PAGE, since_ts_ms, before_ts_ms)
+            .await
+            .map_err(|err| storage_error(err.to_string()));
+    }
+
+    #[cfg(feature = "gateway-store-postgres")]
+    if let Some(store) = state.stores.postgres.as_ref() {
+        return store
+            .list_audit_logs_window(SPEND_AUDIT_PAGE, since_ts_ms, before_ts_ms)
+            .await
+            .map_err(|err| storage_error(err.to_string()));
+    }
+
+    #[cfg(feature = "gateway-store-mysql")]
+    if let Some(store) = state.stores.mysql.as_ref() {
+        return store
+            .list_audit_logs_window(SPEND_AUDIT_PAGE, since_ts_ms, before_ts_ms)
+            .await
+            .map_err(|err| storage_error(err.to_string()));
+    }
+
+    #[cfg(feature = "gateway-store-redis")]
+    if let Some(store) = state.stores.redis.as_ref() {
+        return store
+            .list_audit_logs_window(SPEND_AUDIT_PAGE, since_ts_ms, before_ts_ms)
+            .await
+            .map_err(|err| storage_error(err.to_string()));
+    }
+
+    let _ = (storage_error, since_ts_ms, before_ts_ms);
+    Err(error_response(
+        StatusCode::BAD_REQUEST,
+        "not_configured",
+        "store not configured",
+    ))
+}
@@ -0,0 +1,405 @@
+use super::*;
+
+use std::collections::{HashSet, VecDeque};
+use std::time::{Duration, Instant};
+
+use crate::gateway::alerts::{
+    AlertNotification, AlertSample, AlertStatus, encode_alert, observe_rule,
+};
+use crate::gateway::spend_anomaly::{SpendAnomaly, SpendAnomalyTracker};
+use crate::gateway::{GatewayAlertsConfig, SpendAnomalyAction};
+
+type ProxyError = (StatusCode, Json<OpenAiErrorResponse>);
+
+const DEFAULT_ALERT_TIMEOUT_SECS: u64 = 10;
+/// Upper bound on retained request samples, so a traffic spike cannot grow
+/// the window without limit; the oldest samples go first.
+const MAX_ALERT_SAMPLES: usize = 100_000;
+
+/// `observability.alerts`: request samples kept for the longest rule window,
+/// the firing state of each rule and per-key spend baselines.
+pub(super) struct GatewayAlerts {
+    config: GatewayAlertsConfig,
+    retention_ms: u64,
+    samples: StdMutex<VecDeque<AlertSample>>,
+    evaluator: StdMutex<AlertEvaluatorState>,
+    spend: StdMutex<SpendAnomalyTracker>,
+    client: reqwest::Client,
+}
+
+#[derive(Default)]
+struct AlertEvaluatorState {
+    firing: HashSet<String>,
+    /// Backends currently in cooldown and when the evaluator first saw them
+    /// there.
+    cooldown_since_ms: HashMap<String, u64>,
+}
+
+impl GatewayAlerts {
+    pub(super) fn from_config(config: &GatewayAlertsConfig) -> Option<Arc<Self>> {
+        if config.rules.is_empty() && config.spend_anomaly.is_none() {
+            return None;
+        }
+        let retention_ms = config
+            .rules
+            .iter()
+            .map(|rule| rule.window_seconds.saturating_mul(1000))
+            .max()
+            .unwrap_or(0);
+        Some(Arc::new(Self {
+            config: config.clone(),
+            retention_ms,
+            samples: StdMutex::new(VecDeque::new()),
+            evaluator: StdMutex::new(AlertEvaluatorState::default()),
+            spend: StdMutex::new(SpendAnomalyTracker::default()),
+            client: reqwest::Client::builder().build().unwrap_or_default(),
+        }))
+    }
+
+    fn record(&self, sample: AlertSample) {
+        let mut samples = lock_unpoisoned(&self.samples);
+        let cutoff = sample.ts_ms.saturating_sub(self.retention_ms);
+        while samples
+            .front()
+            .is_some_and(|oldest| oldest.ts_ms < cutoff || samples.len() >= MAX_ALERT_SAMPLES)
+        {
+            samples.pop_front();
+        }
+        samples.push_back(sample);
+    }
+}
+
+/// 5xx and 429 answers count as provider errors; other 4xx are the caller's.
+fn is_alert_error(status: StatusCode) -> bool {
+    status.is_server_error() || status == StatusCode::TOO_MANY_REQUESTS
+}
+
+/// Records the final proxy result for alert rules and the key's spend
+/// baseline. Time to first byte is taken when the first body chunk is sent,
+/// so it measures the stream's first token rather than the whole response; a
+/// body dropped before its first chunk is recorded without one.
+pub(super) fn record_alert_sample(
+    state: &GatewayHttpState,
+    started: Instant,
+    virtual_key_id: Option<&str>,
+    response: Result<axum::response::Response, ProxyError>,
+) -> Result<axum::response::Response, ProxyError> {
+    let Some(alerts) = state.proxy.alerts.clone() else {
+        return response;
+    };
+    if let (Ok(response), Some(virtual_key_id)) = (response.as_ref(), virtual_key_id) {
+        record_key_spend(state, &alerts, virtual_key_id, response.headers());
+    }
+    if alerts.config.rules.is_empty() {
+        return response;
+    }
+    let response = match response {
+        Ok(response) => response,
+        Err((status, err)) => {
+            alerts.record(AlertSample {
+                ts_ms: now_epoch_millis(),
+                backend: None,
+                error: is_alert_error(status),
+                ttft_ms: None,
+            });
+            return Err((status, err));
+        }
+    };
+    let sample = AlertSample {
+        ts_ms: now_epoch_millis(),
+        backend: extract_header(response.headers(), "x-ditto-backend"),
+        error: is_alert_error(response.status()),
+        ttft_ms: None,
+    };
+    if sample.error {
+        alerts.record(sample);
+        return Ok(response);
+    }
+
+    let (parts, body) = response.into_parts();
+    let mut pending = PendingAlertSample {
+        alerts,
+        sample: Some(sample),
+    };
+    let stream = body.into_data_stream().map(move |chunk| {
+        if let Some(mut sample) = pending.sample.take() {
+            sample.ttft_ms = Some(started.elapsed().as_millis() as u64);
+            sample.error = chunk.is_err();
+            pending.alerts.record(sample);
+        }
+        chunk
+    });
+    Ok(axum::response::Response::from_parts(
+        parts,
+        Body::from_stream(stream),
+    ))
+}
+
+struct PendingAlertSample {
+    alerts: Arc<GatewayAlerts>,
+    sample: Option<AlertSample>,
+}
+
+impl Drop for PendingAlertSample {
+    fn drop(&mut self) {
+        if let Some(sample) = self.sample.take() {
+            self.alerts.record(sample);
+        }
+    }
+}
+
+/// Evaluates every rule once and notifies targets of the rules that started
+/// or stopped firing since the previous evaluation. Rules without enough
+/// samples keep their state.
+pub(super) async fn evaluate_alerts(state: &GatewayHttpState, alerts: &GatewayAlerts) {
+    let now_ms = now_epoch_millis();
+    let in_cooldown = backends_in_cooldown(state).await;
+    let notifications = {
+        let samples = lock_unpoisoned(&alerts.samples);
+        let mut evaluator = lock_unpoisoned(&alerts.evaluator);
+        evaluator
+            .cooldown_since_ms
+            .retain(|backend, _| in_cooldown.contains(backend));
+        for backend in in_cooldown {
+            evaluator.cooldown_since_ms.entry(backend).or_insert(now_ms);
+        }
+
+        let mut notifications = Vec::new();
+        for rule in &alerts.config.rules {
+            let Some(value) = observe_rule(rule, &samples, &evaluator.cooldown_since_ms, now_ms)
+            else {
+                continue;
+            };
+            let firing = value > rule.threshold;
+            let changed = if firing {
+                evaluator.firing.insert(rule.name.clone())
+            } else {
+                evaluator.firing.remove(&rule.name)
+            };
+            if !changed {
+                continue;
+            }
+            let notification = AlertNotification {
+                rule: rule.name.clone(),
+                status: if firing {
+                    AlertStatus::Firing
+                } else {
+                    AlertStatus::Resolved
+                },
+                condition: rule.condition.as_str(),
+                backend: rule.backend.clone(),
+                virtual_key_id: None,
+                value,
+                threshold: rule.threshold,
+                window_seconds: rule.window_seconds,
+                ts_ms: now_ms,
+            };
+            notifications.push((rule, notification));
+        }
+        notifications
+    };
+
+    for (rule, notification) in notifications {
+        notify_alert(state, alerts, &rule.targets, &notification).await;
+    }
+
+    let expired = lock_unpoisoned(&alerts.spend).expire(now_ms);
+    if let Some(spend_anomaly) = alerts.config.spend_anomaly.as_ref() {
+        for anomaly in expired {
+            let notification = spend_anomaly_notification(&anomaly, AlertStatus::Resolved, now_ms);
+            notify_alert(state, alerts, &spend_anomaly.targets, &notification).await;
+        }
+    }
+}
+
+/// Logs `notification` as `proxy.alert` and delivers it to the named
+/// targets, logging `proxy.alert_error` for each failed delivery.
+async fn notify_alert(
+    state: &GatewayHttpState,
+    alerts: &GatewayAlerts,
+    target_names: &[String],
+    notification: &AlertNotification,
+) {
+    emit_json_log(
+        state,
+        "proxy.alert",
+        serde_json::to_value(notification).unwrap_or(Value::Null),
+    );
+    for target in alerts.config.targets.iter().filter(|target| {
+        target_names
+            .iter()
+            .any(|name| name.trim() == target.name.trim())
+    }) {
+        let request = encode_alert(&target.sink, notification);
+        let timeout =
+            Duration::from_secs(target.timeout_seconds.unwrap_or(DEFAULT_ALERT_TIMEOUT_SECS));
+        let mut builder = alerts
+            .client
+            .post(&request.url)
+            .timeout(timeout)
+            .json(&request.body);
+        for (name, value) in request.headers {
+            builder = builder.header(name, value);
+        }
+        let error = match builder.send().await {
+            Ok(response) if response.status().is_success() => continue,
+            Ok(response) => format!("status {}", response.status().as_u16()),
+            Err(err) => err.to_string(),
+        };
+        emit_json_log(
+            state,
+            "proxy.alert_error",
+            serde_json::json!({
+                "rule": notification.rule,
+                "target": target.name,
+                "type": target.sink.kind(),
+                "error": error,
+            }),
+        );
+    }
+}
+
+fn spend_anomaly_notification(
+    anomaly: &SpendAnomaly,
+    status: AlertStatus,
+    ts_ms: u64,
+) -> AlertNotification {
+    AlertNotification {
+        rule: "spend_anomaly".to_string(),
+        status,
+        condition: "hourly_spend_usd",
+        backend: None,
+        virtual_key_id: Some(anomaly.virtual_key_id.clone()),
+        value: anomaly.hour_spend_usd,
+        threshold: anomaly.threshold_usd,
+        window_seconds: 3600,
+        ts_ms,
+    }
+}
+
+/// Adds the response's `x-ditto-cost` to the key's hourly spend. The first
+/// time an hour turns anomalous the key is flagged (and, with `throttle`,
+/// blocked until the hour ends) and the targets are notified in the
+/// background.
+fn record_key_spend(
+    state: &GatewayHttpState,
+    alerts: &Arc<GatewayAlerts>,
+    virtual_key_id: &str,
+    headers: &HeaderMap,
+) {
+    let Some(config) = alerts.config.spend_anomaly.as_ref() else {
+        return;
+    };
+    let Some(usd_micros) = extract_header(headers, "x-ditto-cost")
+        .and_then(|cost| cost.parse::<f64>().ok())
+        .filter(|usd| usd.is_finite() && *usd > 0.0)
+        .map(|usd| (usd * 1_000_000.0).round() as u64)
+    else {
+        return;
+    };
+    let now_ms = now_epoch_millis();
+    let Some(anomaly) =
+        lock_unpoisoned(&alerts.spend).record(config, virtual_key_id, usd_micros, now_ms)
+    else {
+        return;
+    };
+    let payload = serde_json::json!({
+        "virtual_key_id": &anomaly.virtual_key_id,
+        "hour_spend_usd": anomaly.hour_spend_usd,
+        "baseline_usd": anomaly.baseline_usd,
+        "threshold_usd": anomaly.threshold_usd,
+        "action": config.action,
+    });
+    let notification = spend_anomaly_notification(&anomaly, AlertStatus::Firing, now_ms);
+    let state = state.clone();
+    let alerts = alerts.clone();
+    tokio::spawn(async move {
+        #[cfg(any(
+            feature = "gateway-store-sqlite",
+            feature = "gateway-store-postgres",
+            feature = "gateway-store-mysql",
+            feature = "gateway-store-redis"
+        ))]
+        let _ = append_audit_log(&state, "proxy.spend_anomaly", payload.clone()).await;
+        emit_json_log(&state, "proxy.spend_anomaly", payload);
+        if let Some(config) = alerts.config.spend_anomaly.as_ref() {
+            notify_alert(&state, &alerts, &config.targets, &notification).await;
+        }
+    });
+}
+
+/// Rejects keys flagged with `action: throttle` for the rest of the hour in
+/// which their spend turned anomalous.
+pub(super) fn ensure_spend_not_throttled(
+    state: &GatewayHttpState,
+    key: &VirtualKeyConfig,
+) -> Result<(), ProxyError> {
+    let Some(alerts) = state.proxy.alerts.as_ref() else {
+        return Ok(());
+    };
+    if alerts
+        .config
+        .spend_anomaly
+        .as_ref()
+        .is_none_or(|config| config.action != SpendAnomalyAction::Throttle)
+        || !lock_unpoisoned(&alerts.spend).is_flagged(&key.id, now_epoch_millis())
+    {
+        return Ok(());
+    }
+    emit_json_log(
+        state,
+        "proxy.blocked",
+        serde_json::json!({
+            "virtual_key_id": key.id.as_str(),
+            "reason": "spend_anomaly",
+        }),
+    );
+    Err(openai_error(
+        StatusCode::TOO_MANY_REQUESTS,
+        "rate_limit_error",
+        Some("spend_anomaly"),
+        "virtual key throttled: its spend this hour is far above its usual pattern",
+    ))
+}
+
+#[cfg(feature = "gateway-routing-advanced")]
+async fn backends_in_cooldown(state: &GatewayHttpState) -> Vec<String> {
+    let Some(health) = state.proxy.backend_health.as_ref() else {
+        return Vec::new();
+    };
+    let now = now_epoch_seconds();
+    health
+        .lock()
+        .await
+        .iter()
+        .filter(|(_, health)| !health.is_healthy(now))
+        .map(|(backend, _)| backend.clone())
+        .collect()
+}
+
+#[cfg(not(feature = "gateway-routing-advanced"))]
+async fn backends_in_cooldown(_state: &GatewayHttpState) -> Vec<String> {
+    Vec::new()
+}
+
+/// Like the secret refresh task, this holds a state clone taken before its
+/// handle is stored and stops once the last router state is dropped.
+pub(super) fn start_gateway_alerts(state: &GatewayHttpState) -> Option<Arc<AbortOnDrop>> {
+    let alerts = state.proxy.alerts.clone()?;
+    let state = state.clone();
+    let interval = Duration::from_secs(alerts.config.interval_seconds.max(1));
+    let task = tokio::spawn(async move {
+        loop {
+            tokio::time::sleep(interval).await;
+            evaluate_alerts(&state, &alerts).await;
+        }
+    });
+    Some(Arc::new(AbortOnDrop::new(task.abort_handle())))
+}
+
+fn now_epoch_millis() -> u64 {
+    SystemTime::now()
+        .duration_since(UNIX_EPOCH)
+        .map(|duration| duration.as_millis() as u64)
+        .unwrap_or(0)
+}
@@ -108,8 +108,10 @@ pub(super) async fn handle_anthropic_messages(
     {
         headers.insert("authorization", token);
     }
-    if let Some(value) = parts.headers.get("x-request-id") {
-        headers.insert("x-request-id", value.clone());
+    for name in ["x-request-id", "idempotency-key"] {
+        if let Some(value) = parts.headers.get(name) {
+            headers.insert(name, value.clone());
+        }
     }
 
     let mut openai_req = axum::http::Request::builder()
@@ -124,6 +126,7 @@ pub(super) async fn handle_anthropic_messages(
             )
         })?;
     *openai_req.headers_mut() = headers;
+    forward_client_access_context(&parts, &mut openai_req);
     if !gateway_uses_virtual_keys(&state) && forwarded_auth_present {
         enable_internal_upstream_auth_passthrough(&mut openai_req);
     }
@@ -0,0 +1,76 @@
+use super::*;
+
+use std::time::Duration;
+
+/// Proxy backends whose `headers` / `query_params` reference `secret://...`,
+/// kept unresolved so each refresh asks the secret manager again.
+#[derive(Clone)]
+pub(super) struct BackendSecretRefresh {
+    backends: Arc<Vec<BackendConfig>>,
+    env: Env,
+    interval: Duration,
+}
+
+impl BackendSecretRefresh {
+    pub(super) fn new(backends: &[BackendConfig], env: Env, interval: Duration) -> Option<Self> {
+        let backends = backends
+            .iter()
+            .filter(|backend| backend.has_credential_secrets())
+            .cloned()
+            .collect::<Vec<_>>();
+        if backends.is_empty() {
+            return None;
+        }
+        Some(Self {
+            backends: Arc::new(backends),
+            env,
+            interval: interval.max(Duration::from_secs(1)),
+        })
+    }
+}
+
+/// Re-resolves every watched backend once. A backend whose secrets fail to
+/// resolve keeps serving with its current credentials; the first failure is
+/// returned after the remaining backends were refreshed.
+pub(super) async fn refresh_backend_secrets(
+    state: &GatewayHttpState,
+    refresh: &BackendSecretRefresh,
+) -> Result<(), GatewayError> {
+    let mut first_error = None;
+    for config in refresh.backends.iter() {
+        let Some(backend) = state.backends.proxy_backends.get(&config.name) else {
+            continue;
+        };
+        let mut resolved = config.clone();
+        let result = match resolved.resolve_credential_secrets(&refresh.env).await {
+            Ok(()) => backend.replace_credentials(&resolved.headers, &resolved.query_params),
+            Err(err) => Err(err),
+        };
+        if let Err(err) = result {
+            emit_json_log(
+                state,
+                "proxy.secret_refresh",
+                serde_json::json!({
+                    "backend": &config.name,
+                    "error": err.to_string(),
+                }),
+            );
+            first_error.get_or_insert(err);
+        }
+    }
+    first_error.map_or(Ok(()), Err)
+}
+
+/// The task holds a state clone taken before the handle is stored, so it does
+/// not keep itself alive; it stops once the last router state is dropped.
+pub(super) fn start_backend_secret_refresh(state: &GatewayHttpState) -> Option<Arc<AbortOnDrop>> {
+    let refresh = state.proxy.secret_refresh.clone()?;
+    let state = state.clone();
+    let task = tokio::spawn(async move {
+        loop {
+            tokio::time::sleep(refresh.interval).await;
+            let _ = refresh_backend_secrets(&state, &refresh).await;
+        }
+    });
+    Some(Arc::new(AbortOnDrop::new(task.abort_handle())))
+}
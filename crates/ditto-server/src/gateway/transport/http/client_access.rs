@@ -0,0 +1,104 @@
+use super::*;
+
+use std::net::{IpAddr, SocketAddr};
+
+use axum::extract::ConnectInfo;
+
+fn client_ip(state: &GatewayHttpState, parts: &axum::http::request::Parts) -> Option<IpAddr> {
+    // Only the last hop is used: it is the one appended by the proxy we trust,
+    // whereas everything to its left came from the client and can be forged.
+    if state.proxy.trust_forwarded_for
+        && let Some(ip) = parts
+            .headers
+            .get_all("x-forwarded-for")
+            .iter()
+            .last()
+            .and_then(|value| value.to_str().ok())
+            .and_then(|value| value.rsplit(',').next())
+            .and_then(|hop| hop.trim().parse::<IpAddr>().ok())
+    {
+        return Some(ip);
+    }
+    parts
+        .extensions
+        .get::<ConnectInfo<SocketAddr>>()
+        .map(|ConnectInfo(addr)| addr.ip())
+}
+
+/// Carries what the allowlist check needs onto a request rebuilt by a protocol
+/// adapter (Anthropic, Google GenAI) before it re-enters the OpenAI proxy.
+pub(super) fn forward_client_access_context(
+    parts: &axum::http::request::Parts,
+    req: &mut axum::http::Request<Body>,
+) {
+    for name in ["origin", "x-forwarded-for"] {
+        req.headers_mut().remove(name);
+        for value in parts.headers.get_all(name) {
+            req.headers_mut().append(name, value.clone());
+        }
+    }
+    if let Some(connect_info) = parts.extensions.get::<ConnectInfo<SocketAddr>>() {
+        req.extensions_mut().insert(*connect_info);
+    }
+    // Lets the translated request report its rate limits on the outer response.
+    if let Some(slot) = parts.extensions.get::<RateLimitHeadersSlot>() {
+        req.extensions_mut().insert(slot.clone());
+    }
+}
+
+pub(super) async fn ensure_virtual_key_client_access(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+    key: &VirtualKeyConfig,
+) -> Result<(), (StatusCode, Json<OpenAiErrorResponse>)> {
+    if key.allowed_ips.is_empty() && key.allowed_origins.is_empty() {
+        return Ok(());
+    }
+
+    let client_ip = client_ip(state, parts);
+    let origin = extract_header(&parts.headers, "origin");
+    let (reason, message) = if !key.allows_client_ip(client_ip) {
+        (
+            "ip_not_allowed",
+            "client ip is not allowed for this virtual key",
+        )
+    } else if !key.allows_origin(origin.as_deref()) {
+        (
+            "origin_not_allowed",
+            "origin is not allowed for this virtual key",
+        )
+    } else {
+        return Ok(());
+    };
+
+    let payload = serde_json::json!({
+        "virtual_key_id": key.id.as_str(),
+        "reason": reason,
+        "client_ip": client_ip.map(|ip| ip.to_string()),
+        "origin": origin,
+        "path": parts.uri.path(),
+    });
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    let _ = append_audit_log(state, "proxy.blocked", payload.clone()).await;
+    emit_json_log(state, "proxy.blocked", payload);
+
+    #[cfg(feature = "gateway-metrics-prometheus")]
+    if let Some(metrics) = state.proxy.metrics.as_ref() {
+        metrics
+            .lock()
+            .await
+            .record_proxy_access_denied(Some(&key.id), reason);
+    }
+
+    Err(openai_error(
+        StatusCode::FORBIDDEN,
+        "policy_error",
+        Some(reason),
+        message,
+    ))
+}
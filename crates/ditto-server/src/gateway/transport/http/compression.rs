@@ -0,0 +1,227 @@
+use super::*;
+
+use std::io::Write;
+
+use axum::body::HttpBody;
+use axum::extract::Request;
+use axum::http::header::{self, HeaderValue};
+use axum::middleware::Next;
+use axum::response::Response;
+
+use crate::gateway::{CompressionConfig, CompressionEncoding};
+
+/// Bodies larger than this stay uncompressed instead of being held in memory
+/// twice while encoding.
+const COMPRESSION_MAX_BODY_BYTES: u64 = 32 * 1024 * 1024;
+/// Bodies up to this size are encoded inline; larger ones move to the
+/// blocking pool so a multi-megabyte brotli pass does not stall the worker.
+const COMPRESSION_INLINE_MAX_BYTES: usize = 64 * 1024;
+const BROTLI_QUALITY: u32 = 5;
+const BROTLI_WINDOW_BITS: u32 = 22;
+
+pub(super) async fn handle_compression(
+    State(rules): State<Arc<Vec<CompressionConfig>>>,
+    req: Request,
+    next: Next,
+) -> Response {
+    let Some(rule) = rules
+        .iter()
+        .find(|rule| rule.matches_path(req.uri().path()))
+    else {
+        return next.run(req).await;
+    };
+    let encoding = negotiate_encoding(req.headers(), &rule.encodings);
+    let mut response = next.run(req).await;
+    if !is_compressible(&response, rule.min_bytes) {
+        return response;
+    }
+    response
+        .headers_mut()
+        .append(header::VARY, HeaderValue::from_static("accept-encoding"));
+    let Some(encoding) = encoding else {
+        return response;
+    };
+
+    let (mut parts, body) = response.into_parts();
+    let Ok(bytes) = axum::body::to_bytes(body, COMPRESSION_MAX_BODY_BYTES as usize).await else {
+        // Only a failing handler body gets here; it would have aborted the
+        // response mid-flight anyway.
+        return StatusCode::INTERNAL_SERVER_ERROR.into_response();
+    };
+    let (bytes, compressed) = if bytes.len() <= COMPRESSION_INLINE_MAX_BYTES {
+        let compressed = compress(encoding, &bytes);
+        (bytes, compressed)
+    } else {
+        match tokio::task::spawn_blocking(move || {
+            let compressed = compress(encoding, &bytes);
+            (bytes, compressed)
+        })
+        .await
+        {
+            Ok(encoded) => encoded,
+            Err(_) => return StatusCode::INTERNAL_SERVER_ERROR.into_response(),
+        }
+    };
+    let headers = &mut parts.headers;
+    let body = match compressed {
+        Ok(compressed) if compressed.len() < bytes.len() => {
+            headers.insert(
+                header::CONTENT_ENCODING,
+                HeaderValue::from_static(encoding.as_str()),
+            );
+            headers.remove(header::CONTENT_LENGTH);
+            Body::from(compressed)
+        }
+        _ => Body::from(bytes),
+    };
+    Response::from_parts(parts, body)
+}
+
+/// Only complete JSON bodies qualify: SSE and other streamed bodies have no
+/// exact size and must not be delayed until they end.
+fn is_compressible(response: &Response, min_bytes: usize) -> bool {
+    let status = response.status();
+    if status == StatusCode::NO_CONTENT || status == StatusCode::NOT_MODIFIED {
+        return false;
+    }
+    let headers = response.headers();
+    if headers.contains_key(header::CONTENT_ENCODING) {
+        return false;
+    }
+    let is_json = headers
+        .get(header::CONTENT_TYPE)
+        .and_then(|value| value.to_str().ok())
+        .map(|value| value.trim().to_ascii_lowercase())
+        .is_some_and(|value| {
+            let mime = value.split(';').next().unwrap_or_default().trim();
+            mime == "application/json" || mime.ends_with("+json")
+        });
+    if !is_json {
+        return false;
+    }
+    response
+        .body()
+        .size_hint()
+        .exact()
+        .is_some_and(|len| len >= min_bytes as u64 && len <= COMPRESSION_MAX_BODY_BYTES)
+}
+
+/// Picks the first server-preferred encoding the client accepts with a
+/// non-zero q-value (`*` covers encodings not listed explicitly).
+fn negotiate_encoding(
+    headers: &HeaderMap,
+    preferred: &[CompressionEncoding],
+) -> Option<CompressionEncoding> {
+    let accepted = headers
+        .get_all(header::ACCEPT_ENCODING)
+        .iter()
+        .filter_map(|value| value.to_str().ok())
+        .flat_map(|value| value.split(','))
+        .filter_map(|entry| {
+            let mut params = entry.split(';');
+            let coding = params.next()?.trim().to_ascii_lowercase();
+            if coding.is_empty() {
+                return None;
+            }
+            let q = params
+                .filter_map(|param| param.trim().strip_prefix("q="))
+                .find_map(|q| q.trim().parse::<f32>().ok())
+                .unwrap_or(1.0);
+            Some((coding, q))
+        })
+        .collect::<Vec<_>>();
+
+    let q_for = |coding: &str| {
+        accepted
+            .iter()
+            .find(|(name, _)| name == coding)
+            .or_else(|| accepted.iter().find(|(name, _)| name == "*"))
+            .map(|(_, q)| *q)
+    };
+    preferred
+        .iter()
+        .copied()
+        .find(|encoding| q_for(encoding.as_str()).is_some_and(|q| q > 0.0))
+}
+
+fn compress(encoding: CompressionEncoding, bytes: &[u8]) -> std::io::Result<Vec<u8>> {
+    match encoding {
+        CompressionEncoding::Gzip => {
+            let mut encoder = flate2::write::GzEncoder::new(
+                Vec::with_capacity(bytes.len() / 4),
+                flate2::Compression::default(),
+            );
+            encoder.write_all(bytes)?;
+            encoder.finish()
+        }
+        CompressionEncoding::Br => {
+            let mut encoder = brotli::CompressorWriter::new(
+                Vec::with_capacity(bytes.len() / 4),
+                4096,
+                BROTLI_QUALITY,
+                BROTLI_WINDOW_BITS,
+            );
+            encoder.write_all(bytes)?;
+            Ok(encoder.into_inner())
+        }
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    use std::io::Read;
+
+    fn accept(value: &str) -> HeaderMap {
+        let mut headers = HeaderMap::new();
+        headers.insert(
+            header::ACCEPT_ENCODING,
+            HeaderValue::from_str(value).unwrap(),
+        );
+        headers
+    }
+
+    #[test]
+    fn negotiates_server_preference_among_accepted_encodings() {
+        let preferred = [CompressionEncoding::Br, CompressionEncoding::Gzip];
+        assert_eq!(
+            negotiate_encoding(&accept("gzip, deflate, br"), &preferred),
+            Some(CompressionEncoding::Br)
+        );
+        assert_eq!(
+            negotiate_encoding(&accept("gzip;q=0.5, br;q=0"), &preferred),
+            Some(CompressionEncoding::Gzip)
+        );
+        assert_eq!(
+            negotiate_encoding(&accept("*;q=0.1"), &[CompressionEncoding::Gzip]),
+            Some(CompressionEncoding::Gzip)
+        );
+        assert_eq!(negotiate_encoding(&accept("identity"), &preferred), None);
+        assert_eq!(negotiate_encoding(&HeaderMap::new(), &preferred), None);
+        assert_eq!(negotiate_encoding(&accept("gzip"), &[]), None);
+    }
+
+    #[test]
+    fn compressed_bodies_round_trip() {
+        let body = serde_json::to_vec(&serde_json::json!({
+            "data": vec!["spend report row"; 200],
+        }))
+        .unwrap();
+
+        let gzip = compress(CompressionEncoding::Gzip, &body).unwrap();
+        let mut decoded = Vec::new();
+        flate2::read::GzDecoder::new(gzip.as_slice())
+            .read_to_end(&mut decoded)
+            .unwrap();
+        assert_eq!(decoded, body);
+
+        let br = compress(CompressionEncoding::Br, &body).unwrap();
+        assert!(br.len() < body.len());
+        let mut decoded = Vec::new();
+        brotli::Decompressor::new(br.as_slice(), 4096)
+            .read_to_end(&mut decoded)
+            .unwrap();
+        assert_eq!(decoded, body);
+    }
+}
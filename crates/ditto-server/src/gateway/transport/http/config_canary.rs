@@ -0,0 +1,319 @@
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+use super::admin_persistence::append_admin_audit_log;
+use super::admin_persistence::apply_control_plane_change;
+use super::config_versions::{now_epoch_millis_u64, router_sha256};
+use super::control_plane::{RouterCanary, RouterCanaryInfo};
+use super::*;
+
+#[derive(Debug, Deserialize)]
+pub(super) struct ConfigCanaryStartRequest {
+    router: RouterConfig,
+    #[serde(default)]
+    percent: f64,
+    #[serde(default)]
+    virtual_key_ids: Vec<String>,
+    #[serde(default)]
+    dry_run: bool,
+}
+
+#[derive(Debug, Default, Deserialize)]
+pub(super) struct ConfigCanaryFinishRequest {
+    #[serde(default)]
+    dry_run: bool,
+}
+
+#[derive(Debug, Serialize)]
+pub(super) struct ConfigCanaryResponse {
+    #[serde(flatten)]
+    info: RouterCanaryInfo,
+    router: RouterConfig,
+}
+
+#[derive(Debug, Serialize)]
+pub(super) struct ConfigCanaryStartResponse {
+    dry_run: bool,
+    replaced: bool,
+    canary: RouterCanaryInfo,
+}
+
+#[derive(Debug, Serialize)]
+pub(super) struct ConfigCanaryPromoteResponse {
+    dry_run: bool,
+    noop: bool,
+    canary: RouterCanaryInfo,
+    previous_version: ConfigVersionInfo,
+    current_version: ConfigVersionInfo,
+}
+
+#[derive(Debug, Serialize)]
+pub(super) struct ConfigCanaryRollbackResponse {
+    dry_run: bool,
+    canary: RouterCanaryInfo,
+}
+
+fn ensure_global_admin(
+    admin: &AdminContext,
+    action: &str,
+) -> Result<(), (StatusCode, Json<ErrorResponse>)> {
+    if admin.tenant_id.is_some() {
+        return Err(error_response(
+            StatusCode::FORBIDDEN,
+            "forbidden",
+            format!("tenant-scoped admin tokens cannot {action} the router canary"),
+        ));
+    }
+    Ok(())
+}
+
+fn no_active_canary() -> (StatusCode, Json<ErrorResponse>) {
+    error_response(
+        StatusCode::NOT_FOUND,
+        "not_found",
+        "no router canary is active",
+    )
+}
+
+pub(super) async fn get_config_canary(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+) -> Result<Json<ConfigCanaryResponse>, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_read(&state, &headers)?;
+    ensure_global_admin(&admin, "read")?;
+
+    let canary = state
+        .router_canary_snapshot()
+        .ok_or_else(no_active_canary)?;
+    Ok(Json(ConfigCanaryResponse {
+        info: canary.info,
+        router: canary.router_config,
+    }))
+}
+
+/// Starts (or replaces) the router canary: the candidate router serves the
+/// listed virtual keys plus `percent` of the remaining keys, bucketed by key
+/// id, while everyone else keeps the current router.
+pub(super) async fn start_config_canary(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Json(payload): Json<ConfigCanaryStartRequest>,
+) -> Result<Json<ConfigCanaryStartResponse>, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_write(&state, &headers)?;
+    ensure_global_admin(&admin, "modify")?;
+
+    if !payload.percent.is_finite() || !(0.0..=100.0).contains(&payload.percent) {
+        return Err(error_response(
+            StatusCode::BAD_REQUEST,
+            "invalid_request",
+            "percent must be between 0 and 100",
+        ));
+    }
+    let mut virtual_key_ids = payload
+        .virtual_key_ids
+        .iter()
+        .map(|id| id.trim())
+        .filter(|id| !id.is_empty())
+        .map(str::to_string)
+        .collect::<Vec<_>>();
+    virtual_key_ids.sort();
+    virtual_key_ids.dedup();
+    if payload.percent == 0.0 && virtual_key_ids.is_empty() {
+        return Err(error_response(
+            StatusCode::BAD_REQUEST,
+            "invalid_request",
+            "router canary needs a percent above 0 or at least one virtual_key_ids entry",
+        ));
+    }
+
+    let backend_names = state
+        .backend_names_snapshot()
+        .into_iter()
+        .collect::<std::collections::HashSet<_>>();
+    crate::gateway::config::validate_router_payload(&payload.router, &backend_names)
+        .map_err(map_gateway_error)?;
+
+    let base_version_id = state
+        .config_versions
+        .lock()
+        .await
+        .current_info()
+        .map(|info| info.version_id);
+    let info = RouterCanaryInfo {
+        percent: payload.percent,
+        virtual_key_ids,
+        router_rule_count: payload.router.rules.len(),
+        router_sha256: router_sha256(&payload.router),
+        base_version_id,
+        created_at_ms: now_epoch_millis_u64(),
+    };
+
+    let write_guard = state.lock_control_plane_writes().await;
+    let replaced = state.router_canary_snapshot().is_some();
+    if payload.dry_run {
+        return Ok(Json(ConfigCanaryStartResponse {
+            dry_run: true,
+            replaced,
+            canary: info,
+        }));
+    }
+    state.replace_router_canary(Some(RouterCanary::new(info.clone(), payload.router)));
+    drop(write_guard);
+
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    append_admin_audit_log(
+        &state,
+        &admin,
+        "admin.config.canary.start",
+        serde_json::json!({
+            "percent": info.percent,
+            "virtual_key_ids": &info.virtual_key_ids,
+            "router_rule_count": info.router_rule_count,
+            "router_sha256": &info.router_sha256,
+            "base_version_id": &info.base_version_id,
+            "replaced": replaced,
+        }),
+    )
+    .await?;
+
+    Ok(Json(ConfigCanaryStartResponse {
+        dry_run: false,
+        replaced,
+        canary: info,
+    }))
+}
+
+/// Makes the canary router the router for all traffic, recording a new config
+/// version, and ends the canary.
+pub(super) async fn promote_config_canary(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    payload: Option<Json<ConfigCanaryFinishRequest>>,
+) -> Result<Json<ConfigCanaryPromoteResponse>, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_write(&state, &headers)?;
+    ensure_global_admin(&admin, "promote")?;
+    let payload = payload.map(|Json(payload)| payload).unwrap_or_default();
+
+    let canary = state
+        .router_canary_snapshot()
+        .ok_or_else(no_active_canary)?;
+    let current_version = {
+        let history = state.config_versions.lock().await;
+        history.current_info()
+    };
+    let Some(current_version) = current_version else {
+        return Err(error_response(
+            StatusCode::NOT_FOUND,
+            "not_found",
+            "config version history is empty",
+        ));
+    };
+
+    if payload.dry_run {
+        return Ok(Json(ConfigCanaryPromoteResponse {
+            dry_run: true,
+            noop: true,
+            canary: canary.info,
+            previous_version: current_version.clone(),
+            current_version,
+        }));
+    }
+
+    let reason = "admin.config.canary.promote";
+    let (_, next_version) = apply_control_plane_change(&state, reason, |gateway| {
+        gateway.replace_router_config(canary.router_config.clone());
+        Ok(())
+    })
+    .await?;
+    {
+        let _write_guard = state.lock_control_plane_writes().await;
+        let unchanged = state.router_canary_snapshot().is_some_and(|active| {
+            active.info.router_sha256 == canary.info.router_sha256
+                && active.info.created_at_ms == canary.info.created_at_ms
+        });
+        if unchanged {
+            state.replace_router_canary(None);
+        }
+    }
+
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    append_admin_audit_log(
+        &state,
+        &admin,
+        "admin.config.canary.promote",
+        serde_json::json!({
+            "previous_version_id": &current_version.version_id,
+            "result_version_id": &next_version.version_id,
+            "router_rule_count": next_version.router_rule_count,
+            "router_sha256": &next_version.router_sha256,
+        }),
+    )
+    .await?;
+
+    Ok(Json(ConfigCanaryPromoteResponse {
+        dry_run: false,
+        noop: false,
+        canary: canary.info,
+        previous_version: current_version,
+        current_version: next_version,
+    }))
+}
+
+/// Ends the canary without touching the stable router, returning every key
+/// to the current config.
+pub(super) async fn rollback_config_canary(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    payload: Option<Json<ConfigCanaryFinishRequest>>,
+) -> Result<Json<ConfigCanaryRollbackResponse>, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_write(&state, &headers)?;
+    ensure_global_admin(&admin, "rollback")?;
+    let payload = payload.map(|Json(payload)| payload).unwrap_or_default();
+
+    let write_guard = state.lock_control_plane_writes().await;
+    let canary = state
+        .router_canary_snapshot()
+        .ok_or_else(no_active_canary)?;
+    if !payload.dry_run {
+        state.replace_router_canary(None);
+    }
+    drop(write_guard);
+
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    if !payload.dry_run {
+        append_admin_audit_log(
+            &state,
+            &admin,
+            "admin.config.canary.rollback",
+            serde_json::json!({
+                "percent": canary.info.percent,
+                "router_sha256": &canary.info.router_sha256,
+                "base_version_id": &canary.info.base_version_id,
+            }),
+        )
+        .await?;
+    }
+
+    Ok(Json(ConfigCanaryRollbackResponse {
+        dry_run: payload.dry_run,
+        canary: canary.info,
+    }))
+}
@@ -79,7 +79,7 @@ impl ConfigVersionHistory {
         info
     }
 
-    fn current_info(&self) -> Option<ConfigVersionInfo> {
+    pub(super) fn current_info(&self) -> Option<ConfigVersionInfo> {
         self.entries.back().map(|snapshot| snapshot.info.clone())
     }
 
@@ -99,7 +99,7 @@ impl ConfigVersionHistory {
     }
 }
 
-fn now_epoch_millis_u64() -> u64 {
+pub(super) fn now_epoch_millis_u64() -> u64 {
     SystemTime::now()
         .duration_since(UNIX_EPOCH)
         .map(|duration| duration.as_millis() as u64)
@@ -114,7 +114,7 @@ fn virtual_keys_sha256(virtual_keys: &[VirtualKeyConfig]) -> String {
     hasher.finalize().to_string()
 }
 
-fn router_sha256(router: &RouterConfig) -> String {
+pub(super) fn router_sha256(router: &RouterConfig) -> String {
     let payload = serde_json::to_vec(router).unwrap_or_default();
     let mut hasher = Sha256Hasher::new();
     hasher.update(b"ditto-gateway-router-version-v1|");
@@ -666,6 +666,7 @@ pub(super) async fn upsert_config_router(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "admin.config.router.upsert",
         serde_json::json!({
             "previous_version_id": current_version.version_id,
@@ -931,6 +932,7 @@ pub(super) async fn rollback_config_version(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "admin.config.rollback",
         serde_json::json!({
             "target_version_id": version_id,
@@ -0,0 +1,249 @@
+use super::*;
+
+use axum::http::HeaderValue;
+
+use crate::gateway::domain::context_window::{
+    ContextSummarizerConfig, ContextWindowConfig, ContextWindowStrategy, context_window_transcript,
+    context_window_trim_range,
+};
+use crate::gateway::domain::history_compression::HISTORY_SUMMARY_PREFIX;
+
+const CONTEXT_SUMMARIZER_PROMPT: &str = "Summarize the following conversation excerpt so it can \
+replace the original messages. Keep facts, decisions, names, numbers and open questions; omit \
+pleasantries. Reply with the summary only.";
+
+/// How an oversized request was trimmed, carried to the response headers.
+#[derive(Clone, Copy, Debug)]
+pub(super) struct ContextWindowVerdict {
+    pub(super) strategy: ContextWindowStrategy,
+    pub(super) removed_messages: usize,
+}
+
+impl ContextWindowVerdict {
+    pub(super) fn insert_headers(self, headers: &mut HeaderMap) {
+        headers.insert(
+            "x-ditto-context-strategy",
+            HeaderValue::from_static(self.strategy.as_str()),
+        );
+        headers.insert(
+            "x-ditto-context-removed-messages",
+            HeaderValue::from(self.removed_messages),
+        );
+    }
+}
+
+/// Checks `body` against the context window and, when it does not fit,
+/// rejects it or returns the trimmed body with the strategy that was applied.
+/// Only chat completions `messages` can be trimmed; other requests that do
+/// not fit are rejected. When the summarizer fails, `summarize_middle` falls
+/// back to `drop_oldest`.
+#[allow(clippy::too_many_arguments)]
+pub(super) async fn enforce_context_window(
+    state: &GatewayHttpState,
+    config: &ContextWindowConfig,
+    request_id: &str,
+    virtual_key_id: Option<&str>,
+    path_and_query: &str,
+    model: Option<&str>,
+    body: &Value,
+    input_tokens: u32,
+    max_output_tokens: u32,
+) -> Result<Option<(Value, ContextWindowVerdict)>, (StatusCode, Json<OpenAiErrorResponse>)> {
+    let Some(excess) = config.excess_tokens(input_tokens, max_output_tokens) else {
+        return Ok(None);
+    };
+    let reject = || {
+        openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("context_length_exceeded"),
+            format!(
+                "maximum context length is {} tokens, but {} tokens were requested \
+                 ({input_tokens} in the messages, {max_output_tokens} in the completion)",
+                config.max_tokens,
+                input_tokens.saturating_add(max_output_tokens),
+            ),
+        )
+    };
+
+    let path = path_and_query.split('?').next().unwrap_or_default();
+    let mut body = body.clone();
+    let messages = body
+        .get_mut("messages")
+        .and_then(Value::as_array_mut)
+        .filter(|_| {
+            config.strategy != ContextWindowStrategy::Reject
+                && path.trim_end_matches('/') == "/v1/chat/completions"
+        });
+    let Some(messages) = messages else {
+        log_context_window(
+            state,
+            config,
+            request_id,
+            virtual_key_id,
+            excess,
+            None,
+            None,
+        );
+        return Err(reject());
+    };
+    let message_tokens = |message: &Value| context_message_tokens(model, message);
+
+    let mut summarizer_error = None;
+    if config.strategy == ContextWindowStrategy::SummarizeMiddle
+        && let Some(summarizer) = config.summarizer.as_ref()
+        && let Some(range) = context_window_trim_range(
+            messages,
+            true,
+            excess.saturating_add(summarizer.max_tokens),
+            message_tokens,
+        )
+    {
+        let transcript = context_window_transcript(&messages[range.clone()]);
+        match summarize_messages(state, summarizer, &transcript).await {
+            Ok(summary) => {
+                let removed_messages = range.len();
+                messages.splice(
+                    range,
+                    [serde_json::json!({
+                        "role": "system",
+                        "content": format!("{HISTORY_SUMMARY_PREFIX}{summary}"),
+                    })],
+                );
+                let verdict = ContextWindowVerdict {
+                    strategy: ContextWindowStrategy::SummarizeMiddle,
+                    removed_messages,
+                };
+                log_context_window(
+                    state,
+                    config,
+                    request_id,
+                    virtual_key_id,
+                    excess,
+                    Some(verdict),
+                    None,
+                );
+                return Ok(Some((body, verdict)));
+            }
+            Err(err) => summarizer_error = Some(err),
+        }
+    }
+
+    let Some(range) = context_window_trim_range(messages, false, excess, message_tokens) else {
+        log_context_window(
+            state,
+            config,
+            request_id,
+            virtual_key_id,
+            excess,
+            None,
+            summarizer_error,
+        );
+        return Err(reject());
+    };
+    let verdict = ContextWindowVerdict {
+        strategy: ContextWindowStrategy::DropOldest,
+        removed_messages: messages.drain(range).count(),
+    };
+    log_context_window(
+        state,
+        config,
+        request_id,
+        virtual_key_id,
+        excess,
+        Some(verdict),
+        summarizer_error,
+    );
+    Ok(Some((body, verdict)))
+}
+
+/// Tokens of one chat message, counted like a single-message request so the
+/// estimate errs on the high side.
+pub(super) fn context_message_tokens(model: Option<&str>, message: &Value) -> u32 {
+    #[cfg(feature = "gateway-tokenizer")]
+    if let Some(tokens) = model.and_then(|model| {
+        token_count::estimate_input_tokens(
+            "/v1/chat/completions",
+            model,
+            &serde_json::json!({ "messages": [message] }),
+        )
+    }) {
+        return tokens;
+    }
+    let _ = model;
+    estimate_tokens_from_bytes(&Bytes::from(message.to_string()))
+}
+
+fn log_context_window(
+    state: &GatewayHttpState,
+    config: &ContextWindowConfig,
+    request_id: &str,
+    virtual_key_id: Option<&str>,
+    excess_tokens: u32,
+    verdict: Option<ContextWindowVerdict>,
+    summarizer_error: Option<String>,
+) {
+    emit_json_log(
+        state,
+        "proxy.context_window",
+        serde_json::json!({
+            "request_id": request_id,
+            "virtual_key_id": virtual_key_id,
+            "max_tokens": config.max_tokens,
+            "excess_tokens": excess_tokens,
+            "strategy": config.strategy.as_str(),
+            "applied": verdict.map_or("reject", |verdict| verdict.strategy.as_str()),
+            "removed_messages": verdict.map(|verdict| verdict.removed_messages),
+            "summarizer_error": summarizer_error,
+        }),
+    );
+}
+
+pub(super) async fn summarize_messages(
+    state: &GatewayHttpState,
+    summarizer: &ContextSummarizerConfig,
+    transcript: &str,
+) -> Result<String, String> {
+    let backend_name = summarizer.backend.trim();
+    let backend = state
+        .backends
+        .proxy_backends
+        .get(backend_name)
+        .ok_or_else(|| format!("unknown summarizer backend {backend_name}"))?;
+    let body = serde_json::json!({
+        "model": summarizer.model.trim(),
+        "temperature": 0,
+        "max_tokens": summarizer.max_tokens,
+        "messages": [
+            {"role": "system", "content": CONTEXT_SUMMARIZER_PROMPT},
+            {"role": "user", "content": transcript},
+        ],
+    });
+    let mut headers = HeaderMap::new();
+    apply_backend_headers(&mut headers, &backend.headers());
+    headers.insert("content-type", HeaderValue::from_static("application/json"));
+
+    let response = backend
+        .request_with_timeout(
+            reqwest::Method::POST,
+            "/v1/chat/completions",
+            headers,
+            Some(Bytes::from(body.to_string())),
+            summarizer.timeout_ms.map(std::time::Duration::from_millis),
+        )
+        .await
+        .map_err(|err| err.to_string())?;
+    if !response.status().is_success() {
+        return Err(format!("summarizer returned {}", response.status()));
+    }
+    let bytes = read_reqwest_body_bytes_limited(response, state.proxy.max_body_bytes)
+        .await
+        .map_err(|err| err.to_string())?;
+    let json: Value = serde_json::from_slice(&bytes).map_err(|err| err.to_string())?;
+    json.pointer("/choices/0/message/content")
+        .and_then(Value::as_str)
+        .map(str::trim)
+        .filter(|summary| !summary.is_empty())
+        .map(str::to_string)
+        .ok_or_else(|| "summarizer response has no message content".to_string())
+}
@@ -1,7 +1,12 @@
 use std::collections::{BTreeMap, BTreeSet, HashMap};
 
+use serde::Serialize;
+
+use crate::gateway::observability::{fnv1a64_init, fnv1a64_update};
 use crate::gateway::router::Router as GatewayRouter;
-use crate::gateway::{Gateway, GatewayError, RouterConfig, VirtualKeyConfig};
+use crate::gateway::{
+    BackendModelInfoSource, Gateway, GatewayError, RouterConfig, VirtualKeyConfig,
+};
 
 use super::{GatewayHttpState, GatewayRuntimeBackends};
 
@@ -13,6 +18,55 @@ pub(super) struct GatewayControlPlaneSnapshot {
     pub(super) router: GatewayRouter,
     pub(super) backend_names: Vec<String>,
     pub(super) backend_model_maps: HashMap<String, BTreeMap<String, String>>,
+    pub(super) backend_regions: HashMap<String, String>,
+    pub(super) backend_embeddings_max_batches: HashMap<String, usize>,
+    pub(super) backend_model_info: HashMap<String, BackendModelInfoSource>,
+    pub(super) router_canary: Option<RouterCanary>,
+}
+
+/// A candidate router config serving a share of virtual keys until it is
+/// promoted or rolled back. Canaries live in memory only and survive
+/// control-plane changes to the stable config.
+#[derive(Clone, Debug)]
+pub(super) struct RouterCanary {
+    pub(super) info: RouterCanaryInfo,
+    pub(super) router_config: RouterConfig,
+    router: GatewayRouter,
+}
+
+#[derive(Clone, Debug, Serialize)]
+pub(super) struct RouterCanaryInfo {
+    pub(super) percent: f64,
+    pub(super) virtual_key_ids: Vec<String>,
+    pub(super) router_rule_count: usize,
+    pub(super) router_sha256: String,
+    pub(super) base_version_id: Option<String>,
+    pub(super) created_at_ms: u64,
+}
+
+impl RouterCanary {
+    pub(super) fn new(info: RouterCanaryInfo, router_config: RouterConfig) -> Self {
+        Self {
+            info,
+            router: GatewayRouter::new(router_config.clone()),
+            router_config,
+        }
+    }
+
+    /// Keys listed explicitly always take the canary; the rest are bucketed by
+    /// key id so a key stays on one side for the canary's lifetime. Requests
+    /// without a virtual key stay on the stable router.
+    fn selects(&self, key: Option<&VirtualKeyConfig>) -> bool {
+        let Some(key) = key else {
+            return false;
+        };
+        if self.info.virtual_key_ids.iter().any(|id| id == &key.id) {
+            return true;
+        }
+        let hash = fnv1a64_update(fnv1a64_init(), b"config-canary|");
+        let bucket = fnv1a64_update(hash, key.id.as_bytes()) % 10_000;
+        (bucket as f64) < self.info.percent * 100.0
+    }
 }
 
 impl GatewayControlPlaneSnapshot {
@@ -42,6 +96,9 @@ impl GatewayControlPlaneSnapshot {
             .into_iter()
             .filter(|(name, _)| backend_names.iter().any(|candidate| candidate == name))
             .collect();
+        let backend_regions = gateway.backend_regions();
+        let backend_embeddings_max_batches = gateway.backend_embeddings_max_batches();
+        let backend_model_info = gateway.backend_model_info_sources();
 
         Self {
             virtual_keys,
@@ -50,6 +107,17 @@ impl GatewayControlPlaneSnapshot {
             router,
             backend_names,
             backend_model_maps,
+            backend_regions,
+            backend_embeddings_max_batches,
+            backend_model_info,
+            router_canary: None,
+        }
+    }
+
+    fn router_for(&self, key: Option<&VirtualKeyConfig>) -> &GatewayRouter {
+        match self.router_canary.as_ref() {
+            Some(canary) if canary.selects(key) => &canary.router,
+            _ => &self.router,
         }
     }
 
@@ -58,13 +126,13 @@ impl GatewayControlPlaneSnapshot {
     }
 
     fn virtual_key_by_token(&self, token: &str) -> Option<&VirtualKeyConfig> {
-        if let Some(token_key) =
-            crate::gateway::config::normalize_presented_virtual_key_token_key(token)
-            && let Some(index) = self.virtual_key_token_index.get(&token_key).copied()
-            && let Some(key) = self.virtual_keys.get(index)
-            && key.matches_token(token)
-        {
-            return Some(key);
+        for token_key in crate::gateway::config::presented_virtual_key_token_lookup_keys(token) {
+            if let Some(index) = self.virtual_key_token_index.get(&token_key).copied()
+                && let Some(key) = self.virtual_keys.get(index)
+                && key.matches_token(token)
+            {
+                return Some(key);
+            }
         }
         self.virtual_keys
             .iter()
@@ -119,6 +187,37 @@ impl GatewayHttpState {
         })
     }
 
+    pub(crate) fn backend_embeddings_max_batch(&self, backend_name: &str) -> Option<usize> {
+        self.with_control_plane(|snapshot| {
+            snapshot
+                .backend_embeddings_max_batches
+                .get(backend_name)
+                .copied()
+        })
+    }
+
+    /// Model names the gateway config defines for `key`: the model groups of
+    /// the router serving it plus the `model_map` aliases (other than `*`) of
+    /// every backend, or only of `key.route` when the key is pinned.
+    pub(crate) fn configured_model_aliases(&self, key: &VirtualKeyConfig) -> BTreeSet<String> {
+        self.with_control_plane(|snapshot| {
+            let model_maps = snapshot
+                .backend_model_maps
+                .iter()
+                .filter(|(backend, _)| key.route.as_ref().is_none_or(|route| route == *backend))
+                .flat_map(|(_, model_map)| model_map.keys())
+                .map(String::as_str)
+                .filter(|alias| *alias != "*");
+            let model_groups = key
+                .route
+                .is_none()
+                .then(|| snapshot.router_for(Some(key)).model_groups())
+                .into_iter()
+                .flatten();
+            model_groups.chain(model_maps).map(str::to_string).collect()
+        })
+    }
+
     pub(crate) fn guardrails_for_model(
         &self,
         model: Option<&str>,
@@ -128,7 +227,7 @@ impl GatewayHttpState {
             model
                 .and_then(|model_id| {
                     snapshot
-                        .router
+                        .router_for(Some(key))
                         .rule_for_model(model_id, Some(key))
                         .and_then(|rule| rule.guardrails.as_ref())
                 })
@@ -137,6 +236,32 @@ impl GatewayHttpState {
         })
     }
 
+    pub(crate) fn route_experiment_for_model(
+        &self,
+        model: &str,
+        key: Option<&VirtualKeyConfig>,
+    ) -> Option<String> {
+        self.with_control_plane(|snapshot| {
+            snapshot
+                .router_for(key)
+                .experiment_for_model(model, key)
+                .map(str::to_string)
+        })
+    }
+
+    pub(crate) fn route_shadow_for_model(
+        &self,
+        model: &str,
+        key: Option<&VirtualKeyConfig>,
+    ) -> Option<crate::gateway::RouteShadowConfig> {
+        self.with_control_plane(|snapshot| {
+            snapshot
+                .router_for(key)
+                .shadow_for_model(model, key)
+                .cloned()
+        })
+    }
+
     pub(crate) fn select_backends_for_model_seeded(
         &self,
         model: &str,
@@ -145,12 +270,30 @@ impl GatewayHttpState {
     ) -> Result<Vec<String>, GatewayError> {
         self.with_control_plane(|snapshot| {
             snapshot
-                .router
+                .router_for(key)
                 .select_backends_for_model_seeded(model, key, seed)
         })
     }
 
-    #[cfg(feature = "gateway-costing")]
+    /// Narrows routed `backends` to the required `regions`; see
+    /// [`retain_backends_in_regions`](crate::gateway::domain::retain_backends_in_regions).
+    pub(crate) fn retain_backends_in_regions(
+        &self,
+        backends: Vec<String>,
+        regions: &[String],
+    ) -> Result<Vec<String>, GatewayError> {
+        if regions.is_empty() {
+            return Ok(backends);
+        }
+        self.with_control_plane(|snapshot| {
+            crate::gateway::domain::retain_backends_in_regions(
+                backends,
+                regions,
+                &snapshot.backend_regions,
+            )
+        })
+    }
+
     pub(crate) fn mapped_backend_model(
         &self,
         backend_name: &str,
@@ -169,9 +312,29 @@ impl GatewayHttpState {
         })
     }
 
+    pub(crate) fn backend_model_info_source(
+        &self,
+        backend_name: &str,
+    ) -> Option<BackendModelInfoSource> {
+        self.with_control_plane(|snapshot| snapshot.backend_model_info.get(backend_name).cloned())
+    }
+
+    pub(super) fn router_canary_snapshot(&self) -> Option<RouterCanary> {
+        self.with_control_plane(|snapshot| snapshot.router_canary.clone())
+    }
+
+    pub(super) fn replace_router_canary(&self, canary: Option<RouterCanary>) {
+        let mut slot = self
+            .control_plane
+            .write()
+            .expect("gateway http control plane poisoned; refusing to continue");
+        slot.router_canary = canary;
+    }
+
     pub(crate) fn sync_control_plane_from_gateway(&self) {
-        let snapshot =
+        let mut snapshot =
             GatewayControlPlaneSnapshot::from_gateway_state(&self.gateway, &self.backends);
+        snapshot.router_canary = self.router_canary_snapshot();
         self.replace_control_plane_snapshot(snapshot);
     }
 }
@@ -0,0 +1,94 @@
+use super::*;
+
+use axum::extract::Request;
+use axum::http::Method;
+use axum::http::header::{self, HeaderName, HeaderValue};
+use axum::middleware::Next;
+use axum::response::Response;
+
+use crate::gateway::CorsConfig;
+
+pub(super) async fn handle_cors(
+    State(rules): State<Arc<Vec<CorsConfig>>>,
+    req: Request,
+    next: Next,
+) -> Response {
+    let Some(origin) = req.headers().get(header::ORIGIN).cloned() else {
+        return next.run(req).await;
+    };
+    let Some(rule) = rules
+        .iter()
+        .find(|rule| rule.matches_path(req.uri().path()))
+    else {
+        return next.run(req).await;
+    };
+    let allowed = origin
+        .to_str()
+        .is_ok_and(|origin| rule.allows_origin(origin));
+
+    if is_preflight(&req) {
+        // Answer preflights here: the proxy routes only accept their own
+        // methods and would reject an unauthenticated OPTIONS request.
+        if !allowed {
+            return StatusCode::FORBIDDEN.into_response();
+        }
+        let mut response = StatusCode::NO_CONTENT.into_response();
+        let headers = response.headers_mut();
+        insert_allow_origin(headers, origin);
+        insert_list(
+            headers,
+            header::ACCESS_CONTROL_ALLOW_METHODS,
+            &rule.allowed_methods,
+        );
+        insert_list(
+            headers,
+            header::ACCESS_CONTROL_ALLOW_HEADERS,
+            &rule.allowed_headers,
+        );
+        if let Some(max_age) = rule.max_age_seconds {
+            headers.insert(header::ACCESS_CONTROL_MAX_AGE, HeaderValue::from(max_age));
+        }
+        return response;
+    }
+
+    let mut response = next.run(req).await;
+    if allowed {
+        let headers = response.headers_mut();
+        insert_allow_origin(headers, origin);
+        insert_list(
+            headers,
+            header::ACCESS_CONTROL_EXPOSE_HEADERS,
+            &rule.expose_headers,
+        );
+    }
+    response
+}
+
+fn is_preflight(req: &Request) -> bool {
+    req.method() == Method::OPTIONS
+        && req
+            .headers()
+            .contains_key(header::ACCESS_CONTROL_REQUEST_METHOD)
+}
+
+fn insert_allow_origin(headers: &mut HeaderMap, origin: HeaderValue) {
+    // Echo the matched origin; `Vary` keeps shared caches from serving it to
+    // other origins.
+    headers.insert(header::ACCESS_CONTROL_ALLOW_ORIGIN, origin);
+    headers.append(header::VARY, HeaderValue::from_static("origin"));
+}
+
+fn insert_list(headers: &mut HeaderMap, name: HeaderName, values: &[String]) {
+    let joined = values
+        .iter()
+        .map(|value| value.trim())
+        .filter(|value| !value.is_empty())
+        .collect::<Vec<_>>()
+        .join(", ");
+    if joined.is_empty() {
+        return;
+    }
+    if let Ok(value) = HeaderValue::from_str(&joined) {
+        headers.insert(name, value);
+    }
+}
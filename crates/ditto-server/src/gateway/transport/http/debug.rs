@@ -0,0 +1,501 @@
+//! Runtime debug endpoints (`observability.debug_endpoints`): process and
+//! tokio runtime stats, an OS thread dump, the requests in flight and the
+//! live routing state, for diagnosing memory growth and stuck streams in
+//! production. They are only mounted when the flag is on and an admin or
+//! admin read token is configured, and tenant-scoped admin tokens cannot
+//! read them. Request paths are listed without their query string.
+
+use super::*;
+
+use std::time::Instant;
+
+use axum::body::HttpBody;
+use axum::extract::Request;
+use axum::middleware::Next;
+use axum::response::Response;
+
+use super::config_versions::now_epoch_millis_u64;
+
+const DEBUG_ENDPOINTS: &[(&str, &str)] = &[
+    (
+        "/debug/runtime",
+        "process memory, threads, fds and tokio runtime stats",
+    ),
+    (
+        "/debug/threads",
+        "OS thread dump: name, state and wait channel",
+    ),
+    (
+        "/debug/requests",
+        "requests in flight, oldest first, with stream idle time",
+    ),
+    (
+        "/debug/routing",
+        "router config, canary and per-backend load and health",
+    ),
+];
+
+/// Requests being served, registered by [`track_in_flight_requests`]. A
+/// request leaves once its response body has been sent or dropped.
+pub(super) struct InFlightRequests {
+    started: Instant,
+    next_id: AtomicU64,
+    requests: StdMutex<BTreeMap<u64, Arc<InFlightRequest>>>,
+}
+
+struct InFlightRequest {
+    method: String,
+    path: String,
+    request_id: Option<String>,
+    started: Instant,
+    started_ts_ms: u64,
+    response: StdMutex<Option<InFlightResponse>>,
+    bytes_sent: AtomicU64,
+    /// Milliseconds after `started` of the last response chunk.
+    last_activity_ms: AtomicU64,
+}
+
+#[derive(Clone)]
+struct InFlightResponse {
+    status: u16,
+    backend: Option<String>,
+    streaming: bool,
+}
+
+#[derive(Serialize)]
+struct InFlightRequestSnapshot {
+    id: u64,
+    method: String,
+    path: String,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    request_id: Option<String>,
+    started_ts_ms: u64,
+    age_ms: u64,
+    /// Time since the last response chunk (or the request start when no
+    /// response has been produced yet).
+    idle_ms: u64,
+    /// None while the handler has not produced the response headers.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    status: Option<u16>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    backend: Option<String>,
+    streaming: bool,
+    bytes_sent: u64,
+}
+
+impl InFlightRequests {
+    pub(super) fn new() -> Self {
+        Self {
+            started: Instant::now(),
+            next_id: AtomicU64::new(1),
+            requests: StdMutex::new(BTreeMap::new()),
+        }
+    }
+
+    fn register(self: &Arc<Self>, req: &Request) -> InFlightGuard {
+        let id = self.next_id.fetch_add(1, Ordering::Relaxed);
+        let entry = Arc::new(InFlightRequest {
+            method: req.method().to_string(),
+            path: req.uri().path().to_string(),
+            request_id: extract_header(req.headers(), "x-request-id"),
+            started: Instant::now(),
+            started_ts_ms: now_epoch_millis_u64(),
+            response: StdMutex::new(None),
+            bytes_sent: AtomicU64::new(0),
+            last_activity_ms: AtomicU64::new(0),
+        });
+        lock_unpoisoned(&self.requests).insert(id, entry.clone());
+        InFlightGuard {
+            tracker: self.clone(),
+            id,
+            entry,
+        }
+    }
+
+    fn snapshot(&self) -> Vec<InFlightRequestSnapshot> {
+        let entries = lock_unpoisoned(&self.requests)
+            .iter()
+            .map(|(id, entry)| (*id, entry.clone()))
+            .collect::<Vec<_>>();
+        entries
+            .into_iter()
+            .map(|(id, entry)| entry.snapshot(id))
+            .collect()
+    }
+}
+
+impl InFlightRequest {
+    fn snapshot(&self, id: u64) -> InFlightRequestSnapshot {
+        let age_ms = self.started.elapsed().as_millis() as u64;
+        let response = lock_unpoisoned(&self.response).clone();
+        InFlightRequestSnapshot {
+            id,
+            method: self.method.clone(),
+            path: self.path.clone(),
+            request_id: self.request_id.clone(),
+            started_ts_ms: self.started_ts_ms,
+            age_ms,
+            idle_ms: age_ms.saturating_sub(self.last_activity_ms.load(Ordering::Relaxed)),
+            status: response.as_ref().map(|response| response.status),
+            backend: response
+                .as_ref()
+                .and_then(|response| response.backend.clone()),
+            streaming: response.is_some_and(|response| response.streaming),
+            bytes_sent: self.bytes_sent.load(Ordering::Relaxed),
+        }
+    }
+
+    fn touch(&self) {
+        self.last_activity_ms
+            .store(self.started.elapsed().as_millis() as u64, Ordering::Relaxed);
+    }
+}
+
+struct InFlightGuard {
+    tracker: Arc<InFlightRequests>,
+    id: u64,
+    entry: Arc<InFlightRequest>,
+}
+
+impl Drop for InFlightGuard {
+    fn drop(&mut self) {
+        lock_unpoisoned(&self.tracker.requests).remove(&self.id);
+    }
+}
+
+/// Registers every request outside `/debug` until its response body is
+/// done, counting the bytes sent and when the last chunk went out. Bodies of
+/// a known size are already complete and leave the list once the handler
+/// returns; they are passed through unwrapped so their exact size (which
+/// compression and `content-length` rely on) is kept.
+pub(super) async fn track_in_flight_requests(
+    State(tracker): State<Arc<InFlightRequests>>,
+    req: Request,
+    next: Next,
+) -> Response {
+    if req.uri().path().starts_with("/debug") {
+        return next.run(req).await;
+    }
+    // Registered before the handler runs, so a request whose client goes
+    // away mid-handler is removed with the dropped future.
+    let guard = tracker.register(&req);
+    let response = next.run(req).await;
+
+    let streaming = response
+        .headers()
+        .get(axum::http::header::CONTENT_TYPE)
+        .and_then(|value| value.to_str().ok())
+        .is_some_and(|value| value.starts_with("text/event-stream"));
+    *lock_unpoisoned(&guard.entry.response) = Some(InFlightResponse {
+        status: response.status().as_u16(),
+        backend: extract_header(response.headers(), "x-ditto-backend"),
+        streaming,
+    });
+    guard.entry.touch();
+    if let Some(len) = response.body().size_hint().exact() {
+        guard.entry.bytes_sent.fetch_add(len, Ordering::Relaxed);
+        return response;
+    }
+
+    let (parts, body) = response.into_parts();
+    let stream = body.into_data_stream().map(move |chunk| {
+        if let Ok(bytes) = &chunk {
+            guard
+                .entry
+                .bytes_sent
+                .fetch_add(bytes.len() as u64, Ordering::Relaxed);
+            guard.entry.touch();
+        }
+        chunk
+    });
+    Response::from_parts(parts, Body::from_stream(stream))
+}
+
+fn ensure_debug_access(
+    state: &GatewayHttpState,
+    headers: &HeaderMap,
+) -> Result<Arc<InFlightRequests>, (StatusCode, Json<ErrorResponse>)> {
+    let admin = ensure_admin_read(state, headers)?;
+    if admin.tenant_id.is_some() {
+        return Err(error_response(
+            StatusCode::FORBIDDEN,
+            "forbidden",
+            "tenant-scoped admin tokens cannot access debug endpoints",
+        ));
+    }
+    state.proxy.in_flight.clone().ok_or_else(|| {
+        error_response(
+            StatusCode::BAD_REQUEST,
+            "not_configured",
+            "observability.debug_endpoints is not enabled",
+        )
+    })
+}
+
+pub(super) async fn debug_index(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+) -> Result<Json<Value>, (StatusCode, Json<ErrorResponse>)> {
+    ensure_debug_access(&state, &headers)?;
+    let endpoints = DEBUG_ENDPOINTS
+        .iter()
+        .map(|(path, description)| serde_json::json!({"path": path, "description": description}))
+        .collect::<Vec<_>>();
+    Ok(Json(serde_json::json!({ "endpoints": endpoints })))
+}
+
+pub(super) async fn debug_runtime(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+) -> Result<Json<Value>, (StatusCode, Json<ErrorResponse>)> {
+    let tracker = ensure_debug_access(&state, &headers)?;
+    let requests = tracker.snapshot();
+    let streaming = requests.iter().filter(|request| request.streaming).count();
+    let tokio = tokio::runtime::Handle::try_current().ok().map(|handle| {
+        let metrics = handle.metrics();
+        serde_json::json!({
+            "workers": metrics.num_workers(),
+            "alive_tasks": metrics.num_alive_tasks(),
+            "global_queue_depth": metrics.global_queue_depth(),
+        })
+    });
+    Ok(Json(serde_json::json!({
+        "pid": std::process::id(),
+        "uptime_ms": tracker.started.elapsed().as_millis() as u64,
+        "process": read_process_stats(),
+        "tokio": tokio,
+        "requests": {
+            "in_flight": requests.len(),
+            "streaming": streaming,
+        },
+    })))
+}
+
+pub(super) async fn debug_threads(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+) -> Result<Json<Value>, (StatusCode, Json<ErrorResponse>)> {
+    ensure_debug_access(&state, &headers)?;
+    let Some(threads) = read_threads() else {
+        return Err(error_response(
+            StatusCode::NOT_IMPLEMENTED,
+            "not_supported",
+            "thread dumps read /proc and are only available on Linux",
+        ));
+    };
+    Ok(Json(serde_json::json!({
+        "count": threads.len(),
+        "threads": threads,
+    })))
+}
+
+#[derive(Debug, Default, Deserialize)]
+pub(super) struct DebugRequestsQuery {
+    #[serde(default)]
+    min_age_ms: Option<u64>,
+    #[serde(default)]
+    min_idle_ms: Option<u64>,
+}
+
+pub(super) async fn debug_requests(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+    Query(query): Query<DebugRequestsQuery>,
+) -> Result<Json<Value>, (StatusCode, Json<ErrorResponse>)> {
+    let tracker = ensure_debug_access(&state, &headers)?;
+    let mut requests = tracker
+        .snapshot()
+        .into_iter()
+        .filter(|request| request.age_ms >= query.min_age_ms.unwrap_or(0))
+        .filter(|request| request.idle_ms >= query.min_idle_ms.unwrap_or(0))
+        .collect::<Vec<_>>();
+    requests.sort_by(|a, b| b.age_ms.cmp(&a.age_ms));
+    Ok(Json(serde_json::json!({
+        "count": requests.len(),
+        "requests": requests,
+    })))
+}
+
+pub(super) async fn debug_routing(
+    State(state): State<GatewayHttpState>,
+    headers: HeaderMap,
+) -> Result<Json<Value>, (StatusCode, Json<ErrorResponse>)> {
+    let tracker = ensure_debug_access(&state, &headers)?;
+    let requests = tracker.snapshot();
+    let config = state.gateway.config_snapshot();
+    #[cfg(feature = "gateway-routing-advanced")]
+    let health = match state.proxy.backend_health.as_ref() {
+        Some(health) => Some(health.lock().await.clone()),
+        None => None,
+    };
+
+    let mut backends = Vec::new();
+    for name in state.backend_names_snapshot() {
+        let max_in_flight = config
+            .backends
+            .iter()
+            .find(|backend| backend.name == name)
+            .and_then(|backend| backend.max_in_flight);
+        let permits_in_use = match (max_in_flight, state.proxy.backend_backpressure.get(&name)) {
+            (Some(max), Some(limit)) => Some(max.max(1).saturating_sub(limit.available_permits())),
+            _ => None,
+        };
+        let responding = requests
+            .iter()
+            .filter(|request| request.backend.as_deref() == Some(name.as_str()))
+            .collect::<Vec<_>>();
+        #[allow(unused_mut)]
+        let mut backend = serde_json::json!({
+            "name": &name,
+            "max_in_flight": max_in_flight,
+            "permits_in_use": permits_in_use,
+            "responses_in_flight": responding.len(),
+            "streams_in_flight": responding.iter().filter(|request| request.streaming).count(),
+        });
+        #[cfg(feature = "gateway-routing-advanced")]
+        if let Some(health) = health.as_ref() {
+            let snapshot = health
+                .get(name.as_str())
+                .map(|entry| entry.snapshot(&name))
+                .unwrap_or_else(|| BackendHealth::default().snapshot(&name));
+            backend["health"] = serde_json::to_value(snapshot).unwrap_or(Value::Null);
+        }
+        backends.push(backend);
+    }
+
+    Ok(Json(serde_json::json!({
+        "router": state.router_config_snapshot(),
+        "canary": state.router_canary_snapshot().map(|canary| canary.info),
+        "proxy_permits_available": state
+            .proxy
+            .backpressure
+            .as_ref()
+            .map(|limit| limit.available_permits()),
+        "backends": backends,
+    })))
+}
+
+#[derive(Debug, Default, PartialEq, Serialize)]
+struct ProcessStats {
+    rss_bytes: Option<u64>,
+    peak_rss_bytes: Option<u64>,
+    virtual_bytes: Option<u64>,
+    threads: Option<u64>,
+    open_fds: Option<u64>,
+}
+
+/// Reads `/proc/self`; None where it does not exist.
+fn read_process_stats() -> Option<ProcessStats> {
+    let status = std::fs::read_to_string("/proc/self/status").ok()?;
+    let mut stats = parse_proc_status(&status);
+    stats.open_fds = std::fs::read_dir("/proc/self/fd")
+        .ok()
+        .map(|entries| entries.count() as u64);
+    Some(stats)
+}
+
+fn parse_proc_status(status: &str) -> ProcessStats {
+    let mut stats = ProcessStats::default();
+    for line in status.lines() {
+        let Some((field, value)) = line.split_once(':') else {
+            continue;
+        };
+        let value = value.trim();
+        let kib = || {
+            value
+                .strip_suffix("kB")
+                .and_then(|kib| kib.trim().parse::<u64>().ok())
+                .map(|kib| kib * 1024)
+        };
+        match field {
+            "VmRSS" => stats.rss_bytes = kib(),
+            "VmHWM" => stats.peak_rss_bytes = kib(),
+            "VmSize" => stats.virtual_bytes = kib(),
+            "Threads" => stats.threads = value.parse().ok(),
+            _ => {}
+        }
+    }
+    stats
+}
+
+#[derive(Debug, PartialEq, Serialize)]
+struct ThreadInfo {
+    tid: u64,
+    name: String,
+    /// Scheduler state: `R` running, `S` sleeping, `D` uninterruptible
+    /// wait, ...
+    state: String,
+    /// Kernel function the thread is blocked in, if any.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    wchan: Option<String>,
+}
+
+/// Lists the process threads from `/proc/self/task`; None off Linux.
+fn read_threads() -> Option<Vec<ThreadInfo>> {
+    let entries = std::fs::read_dir("/proc/self/task").ok()?;
+    let mut threads = entries
+        .filter_map(Result::ok)
+        .filter_map(|entry| {
+            let tid = entry.file_name().to_str()?.parse::<u64>().ok()?;
+            let stat = std::fs::read_to_string(entry.path().join("stat")).ok()?;
+            let (name, state) = parse_task_stat(&stat)?;
+            let wchan = std::fs::read_to_string(entry.path().join("wchan"))
+                .ok()
+                .map(|wchan| wchan.trim().to_string())
+                .filter(|wchan| !wchan.is_empty() && wchan != "0");
+            Some(ThreadInfo {
+                tid,
+                name,
+                state,
+                wchan,
+            })
+        })
+        .collect::<Vec<_>>();
+    threads.sort_by_key(|thread| thread.tid);
+    Some(threads)
+}
+
+/// Splits `<tid> (<comm>) <state> ...`; the name may itself contain spaces
+/// and parentheses, so it runs up to the last `)`.
+fn parse_task_stat(stat: &str) -> Option<(String, String)> {
+    let open = stat.find('(')?;
+    let close = stat.rfind(')')?;
+    let name = stat.get(open + 1..close)?.to_string();
+    let state = stat
+        .get(close + 1..)?
+        .split_whitespace()
+        .next()?
+        .to_string();
+    Some((name, state))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn parses_proc_status_memory_fields() {
+        let stats = parse_proc_status(
+            "Name:\tditto-gateway\nVmSize:\t  812344 kB\nVmHWM:\t  204800 kB\nVmRSS:\t  102400 kB\nThreads:\t17\n",
+        );
+        assert_eq!(
+            stats,
+            ProcessStats {
+                rss_bytes: Some(102_400 * 1024),
+                peak_rss_bytes: Some(204_800 * 1024),
+                virtual_bytes: Some(812_344 * 1024),
+                threads: Some(17),
+                open_fds: None,
+            }
+        );
+    }
+
+    #[test]
+    fn parses_task_stat_with_odd_thread_names() {
+        assert_eq!(
+            parse_task_stat("4242 (tokio-rt (worker) 3) S 1 4242 4242 0 -1"),
+            Some(("tokio-rt (worker) 3".to_string(), "S".to_string()))
+        );
+        assert_eq!(parse_task_stat("garbage"), None);
+    }
+}
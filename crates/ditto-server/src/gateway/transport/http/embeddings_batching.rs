@@ -0,0 +1,217 @@
+//! Splitting of `/v1/embeddings` requests whose `input` array is longer than
+//! the backend accepts in one call (`backends[].embeddings_max_batch`). The
+//! batches are sent concurrently and their `data` is merged back in input
+//! order, with `usage` summed, so callers see one ordinary response.
+
+use super::*;
+
+/// Batches of one request that are in flight at the same time.
+const EMBEDDINGS_BATCH_CONCURRENCY: usize = 4;
+
+/// `body` split into request bodies of at most `max_batch` inputs each, or
+/// `None` when it does not need splitting. Only arrays of strings or of
+/// token arrays are split; a flat token array is a single input.
+pub(super) fn split_embeddings_request(body: &Value, max_batch: usize) -> Option<Vec<Value>> {
+    let inputs = body.get("input")?.as_array()?;
+    if max_batch == 0
+        || inputs.len() <= max_batch
+        || !inputs
+            .iter()
+            .all(|input| input.is_string() || input.is_array())
+    {
+        return None;
+    }
+    let mut template = body.as_object()?.clone();
+    template.remove("input");
+    Some(
+        inputs
+            .chunks(max_batch)
+            .map(|chunk| {
+                let mut batch = template.clone();
+                batch.insert("input".to_string(), Value::Array(chunk.to_vec()));
+                Value::Object(batch)
+            })
+            .collect(),
+    )
+}
+
+/// Merges the batch responses into the first one: each `data[].index` is
+/// offset by the inputs of the batches before it, `data` is sorted by index
+/// and numeric `usage` fields are summed. Returns `None` when a batch is not
+/// an embeddings response.
+pub(super) fn merge_embeddings_responses(responses: Vec<Value>, max_batch: usize) -> Option<Value> {
+    let mut merged = None;
+    let mut data = Vec::new();
+    let mut usage = serde_json::Map::new();
+    for (batch, response) in responses.into_iter().enumerate() {
+        let Value::Object(mut response) = response else {
+            return None;
+        };
+        let Some(Value::Array(items)) = response.remove("data") else {
+            return None;
+        };
+        let offset = batch * max_batch;
+        for (position, mut item) in items.into_iter().enumerate() {
+            let index = item
+                .get("index")
+                .and_then(Value::as_u64)
+                .map_or(position, |index| index as usize);
+            if let Some(item) = item.as_object_mut() {
+                item.insert("index".to_string(), Value::from(offset + index));
+            }
+            data.push(item);
+        }
+        if let Some(Value::Object(batch_usage)) = response.remove("usage") {
+            for (field, value) in batch_usage {
+                let Some(count) = value.as_u64() else {
+                    continue;
+                };
+                let total = usage.get(&field).and_then(Value::as_u64).unwrap_or(0);
+                usage.insert(field, Value::from(total.saturating_add(count)));
+            }
+        }
+        merged.get_or_insert(response);
+    }
+    let mut merged = merged?;
+    data.sort_by_key(|item| item.get("index").and_then(Value::as_u64));
+    merged.insert("data".to_string(), Value::Array(data));
+    if !usage.is_empty() {
+        merged.insert("usage".to_string(), Value::Object(usage));
+    }
+    Some(Value::Object(merged))
+}
+
+/// Sends `batches` to `backend`, at most [`EMBEDDINGS_BATCH_CONCURRENCY`] at
+/// a time, and returns one merged response. The first batch that fails is
+/// returned as it came, so retries and fallback treat it like any other
+/// upstream failure; the batches still in flight are dropped.
+#[allow(clippy::too_many_arguments)]
+pub(super) async fn send_embeddings_batches(
+    backend: &ProxyBackend,
+    method: reqwest::Method,
+    path_and_query: &str,
+    headers: HeaderMap,
+    batches: Vec<Value>,
+    max_batch: usize,
+    timeout: Option<std::time::Duration>,
+    max_body_bytes: usize,
+) -> Result<reqwest::Response, GatewayError> {
+    let mut responses = stream::iter(batches)
+        .map(|batch| {
+            let method = method.clone();
+            let headers = headers.clone();
+            async move {
+                let body = Bytes::from(batch.to_string());
+                backend
+                    .request_with_timeout(method, path_and_query, headers, Some(body), timeout)
+                    .await
+            }
+        })
+        .buffered(EMBEDDINGS_BATCH_CONCURRENCY);
+
+    let mut first_headers = None;
+    let mut bodies = Vec::new();
+    while let Some(response) = responses.next().await {
+        let response = response?;
+        if !response.status().is_success() {
+            return Ok(response);
+        }
+        if first_headers.is_none() {
+            first_headers = Some(response.headers().clone());
+        }
+        let bytes = read_reqwest_body_bytes_limited(response, max_body_bytes)
+            .await
+            .map_err(|err| GatewayError::Backend {
+                message: format!("embeddings batch response failed: {err}"),
+            })?;
+        let json =
+            serde_json::from_slice::<Value>(&bytes).map_err(|err| GatewayError::Backend {
+                message: format!("embeddings batch response is not JSON: {err}"),
+            })?;
+        bodies.push(json);
+    }
+
+    let merged =
+        merge_embeddings_responses(bodies, max_batch).ok_or_else(|| GatewayError::Backend {
+            message: "embeddings batch response has no data array".to_string(),
+        })?;
+    let mut builder = axum::http::Response::builder().status(StatusCode::OK);
+    for (name, value) in first_headers.iter().flatten() {
+        if !matches!(
+            name.as_str(),
+            "content-length" | "content-encoding" | "transfer-encoding"
+        ) {
+            builder = builder.header(name, value);
+        }
+    }
+    let response = builder
+        .body(merged.to_string())
+        .map_err(|err| GatewayError::Backend {
+            message: format!("invalid merged embeddings response: {err}"),
+        })?;
+    Ok(reqwest::Response::from(response))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn splits_only_arrays_of_inputs_over_the_limit() {
+        let body = serde_json::json!({"model": "m", "input": ["a", "b", "c"], "dimensions": 8});
+        let batches = split_embeddings_request(&body, 2).expect("split");
+        assert_eq!(
+            batches,
+            vec![
+                serde_json::json!({"model": "m", "input": ["a", "b"], "dimensions": 8}),
+                serde_json::json!({"model": "m", "input": ["c"], "dimensions": 8}),
+            ]
+        );
+
+        assert_eq!(split_embeddings_request(&body, 3), None);
+        assert_eq!(split_embeddings_request(&body, 0), None);
+        let tokens = serde_json::json!({"model": "m", "input": [1, 2, 3]});
+        assert_eq!(split_embeddings_request(&tokens, 2), None);
+        let text = serde_json::json!({"model": "m", "input": "abc"});
+        assert_eq!(split_embeddings_request(&text, 2), None);
+    }
+
+    #[test]
+    fn merges_data_in_order_and_sums_usage() {
+        let responses = vec![
+            serde_json::json!({
+                "object": "list",
+                "model": "m",
+                "data": [
+                    {"object": "embedding", "index": 1, "embedding": [0.2]},
+                    {"object": "embedding", "index": 0, "embedding": [0.1]},
+                ],
+                "usage": {"prompt_tokens": 4, "total_tokens": 4},
+            }),
+            serde_json::json!({
+                "object": "list",
+                "model": "m",
+                "data": [{"object": "embedding", "embedding": [0.3]}],
+                "usage": {"prompt_tokens": 2, "total_tokens": 2},
+            }),
+        ];
+
+        let merged = merge_embeddings_responses(responses, 2).expect("merge");
+        assert_eq!(merged["model"], "m");
+        assert_eq!(merged["usage"]["prompt_tokens"], 6);
+        assert_eq!(merged["usage"]["total_tokens"], 6);
+        let indexes: Vec<u64> = merged["data"]
+            .as_array()
+            .expect("data")
+            .iter()
+            .map(|item| item["index"].as_u64().expect("index"))
+            .collect();
+        assert_eq!(indexes, vec![0, 1, 2]);
+        assert_eq!(merged["data"][0]["embedding"], serde_json::json!([0.1]));
+
+        assert_eq!(
+            merge_embeddings_responses(vec![serde_json::json!({"error": "x"})], 2),
+            None
+        );
+    }
+}
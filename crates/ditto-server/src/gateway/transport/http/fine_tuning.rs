@@ -0,0 +1,317 @@
+//! Fine-tuning jobs (`/v1/fine_tuning/jobs`) behind shared provider
+//! credentials. Only keys with `fine_tuning` may use these endpoints; every
+//! job created through the gateway is recorded with its owner and backend,
+//! and a key only lists, retrieves or cancels the jobs of its own owner.
+
+use super::*;
+
+use super::proxy_gateway_context::resolve_openai_compat_proxy_gateway_preamble;
+use crate::gateway::{FineTuningJobRecord, FineTuningJobStore, fine_tuning_owner};
+
+type ProxyError = (StatusCode, Json<OpenAiErrorResponse>);
+
+const FINE_TUNING_JOBS_PATH: &str = "/v1/fine_tuning/jobs";
+
+/// Request extension of a fine-tuning request whose key and job ownership
+/// have been checked, with the backend that holds the job (`None` leaves the
+/// choice to the router).
+#[derive(Clone, Debug)]
+pub(super) struct FineTuningJobRoute(pub(super) Option<String>);
+
+#[derive(Debug, PartialEq, Eq)]
+enum FineTuningOperation<'a> {
+    Create,
+    List,
+    /// Retrieve, cancel, events, checkpoints and other per-job calls.
+    Job(&'a str),
+}
+
+fn fine_tuning_operation<'a>(
+    method: &axum::http::Method,
+    path: &'a str,
+) -> Option<FineTuningOperation<'a>> {
+    let rest = path
+        .trim_end_matches('/')
+        .strip_prefix(FINE_TUNING_JOBS_PATH)?;
+    if rest.is_empty() {
+        return Some(if method == axum::http::Method::POST {
+            FineTuningOperation::Create
+        } else {
+            FineTuningOperation::List
+        });
+    }
+    let job_id = rest.strip_prefix('/')?.split('/').next()?;
+    (!job_id.is_empty()).then_some(FineTuningOperation::Job(job_id))
+}
+
+fn fine_tuning_job_not_found(job_id: &str) -> ProxyError {
+    openai_error(
+        StatusCode::NOT_FOUND,
+        "invalid_request_error",
+        Some("fine_tuning_job_not_found"),
+        format!("fine-tuning job {job_id} not found"),
+    )
+}
+
+fn fine_tuning_store_unavailable(err: impl std::fmt::Display) -> ProxyError {
+    openai_error(
+        StatusCode::SERVICE_UNAVAILABLE,
+        "api_error",
+        Some("fine_tuning_store_unavailable"),
+        format!("fine-tuning job store unavailable: {err}"),
+    )
+}
+
+pub(super) async fn maybe_handle_fine_tuning_jobs(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+    body: &Bytes,
+    request_id: &str,
+    path_and_query: &str,
+) -> Result<Option<axum::response::Response>, ProxyError> {
+    let path = path_and_query.split('?').next().unwrap_or_default();
+    let Some(operation) = fine_tuning_operation(&parts.method, path) else {
+        return Ok(None);
+    };
+    if parts.extensions.get::<FineTuningJobRoute>().is_some() {
+        return Ok(None);
+    }
+    // Trusted in-process passthrough requests carry no key to scope by.
+    let Some(key) = resolve_openai_compat_proxy_gateway_preamble(state, parts)
+        .await?
+        .key
+    else {
+        return Ok(None);
+    };
+    if !key.fine_tuning {
+        return Err(openai_error(
+            StatusCode::FORBIDDEN,
+            "policy_error",
+            Some("fine_tuning_not_allowed"),
+            "virtual key is not allowed to use fine-tuning jobs",
+        ));
+    }
+
+    let owner = fine_tuning_owner(&key);
+    let store = state.fine_tuning_job_store();
+    let response = match operation {
+        FineTuningOperation::Create => {
+            let response = forward_fine_tuning_request(state, parts, body, None).await?;
+            record_created_job(state, store.as_ref(), &key.id, owner, request_id, response).await?
+        }
+        FineTuningOperation::List => {
+            list_owned_jobs(state, parts, body, store.as_ref(), &owner).await?
+        }
+        FineTuningOperation::Job(job_id) => {
+            let record = store
+                .load_fine_tuning_job(job_id)
+                .await
+                .map_err(fine_tuning_store_unavailable)?
+                .filter(|record| record.owner == owner)
+                .ok_or_else(|| fine_tuning_job_not_found(job_id))?;
+            forward_fine_tuning_request(state, parts, body, Some(record.backend)).await?
+        }
+    };
+    Ok(Some(response))
+}
+
+async fn forward_fine_tuning_request(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+    body: &Bytes,
+    backend: Option<String>,
+) -> Result<axum::response::Response, ProxyError> {
+    let mut req = axum::http::Request::new(Body::from(body.clone()));
+    *req.method_mut() = parts.method.clone();
+    *req.uri_mut() = parts.uri.clone();
+    *req.headers_mut() = parts.headers.clone();
+    *req.extensions_mut() = parts.extensions.clone();
+    req.extensions_mut().insert(FineTuningJobRoute(backend));
+    req.extensions_mut().insert(ForwardedProxyRequest {
+        client_supplied_request_id: parts.headers.contains_key("x-request-id"),
+    });
+
+    let fut = Box::pin(handle_openai_compat_proxy(
+        State(state.clone()),
+        Path(String::new()),
+        req,
+    ));
+    fut.await
+}
+
+/// Reads a successful JSON reply so it can be inspected and rebuilt.
+async fn buffer_json_response(
+    state: &GatewayHttpState,
+    response: axum::response::Response,
+) -> Result<(axum::http::response::Parts, Value), ProxyError> {
+    let (mut parts, body) = response.into_parts();
+    let invalid = |err: String| {
+        openai_error(
+            StatusCode::BAD_GATEWAY,
+            "api_error",
+            Some("backend_error"),
+            format!("invalid fine-tuning response: {err}"),
+        )
+    };
+    let bytes = to_bytes(body, state.proxy.usage_max_body_bytes)
+        .await
+        .map_err(|err| invalid(err.to_string()))?;
+    let json = serde_json::from_slice(&bytes).map_err(|err| invalid(err.to_string()))?;
+    parts.headers.remove("content-length");
+    Ok((parts, json))
+}
+
+async fn record_created_job(
+    state: &GatewayHttpState,
+    store: &dyn FineTuningJobStore,
+    virtual_key_id: &str,
+    owner: String,
+    request_id: &str,
+    response: axum::response::Response,
+) -> Result<axum::response::Response, ProxyError> {
+    if !response.status().is_success() {
+        return Ok(response);
+    }
+    let backend = extract_header(response.headers(), "x-ditto-backend");
+    let (parts, json) = buffer_json_response(state, response).await?;
+    let job_id = json.get("id").and_then(Value::as_str);
+    if let (Some(job_id), Some(backend)) = (job_id, backend) {
+        let record = FineTuningJobRecord {
+            job_id: job_id.to_string(),
+            backend,
+            owner,
+            virtual_key_id: virtual_key_id.to_string(),
+            created_at_ms: SystemTime::now()
+                .duration_since(UNIX_EPOCH)
+                .map(|duration| duration.as_millis() as u64)
+                .unwrap_or(0),
+        };
+        // The job exists upstream either way, so a store failure is logged
+        // rather than hiding the job id from its creator.
+        let error = store.save_fine_tuning_job(&record).await.err();
+        emit_json_log(
+            state,
+            "proxy.fine_tuning_job",
+            serde_json::json!({
+                "request_id": request_id,
+                "virtual_key_id": virtual_key_id,
+                "job_id": &record.job_id,
+                "backend": &record.backend,
+                "owner": &record.owner,
+                "store_error": error.map(|err| err.to_string()),
+            }),
+        );
+    }
+    Ok(axum::response::Response::from_parts(
+        parts,
+        Body::from(json.to_string()),
+    ))
+}
+
+/// Lists the owner's jobs from every backend that holds one of them, newest
+/// first, dropping the jobs of other owners. Each backend gets the client's
+/// query, so a page can be shorter or longer than `limit`; an `after` cursor
+/// continues on the backend of that job only, and `has_more` is set when any
+/// backend has more.
+async fn list_owned_jobs(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+    body: &Bytes,
+    store: &dyn FineTuningJobStore,
+    owner: &str,
+) -> Result<axum::response::Response, ProxyError> {
+    let records = store
+        .list_fine_tuning_jobs(owner)
+        .await
+        .map_err(fine_tuning_store_unavailable)?;
+    let owned: HashMap<&str, &str> = records
+        .iter()
+        .map(|record| (record.job_id.as_str(), record.backend.as_str()))
+        .collect();
+    let mut backends: Vec<&str> = Vec::new();
+    if let Some(after) = extract_query_param(&parts.uri, "after") {
+        let backend = owned
+            .get(after.as_str())
+            .ok_or_else(|| fine_tuning_job_not_found(&after))?;
+        backends.push(*backend);
+    } else {
+        for record in &records {
+            if !backends.contains(&record.backend.as_str()) {
+                backends.push(&record.backend);
+            }
+        }
+    }
+
+    let mut merged: Option<(axum::http::response::Parts, Value)> = None;
+    let mut jobs = Vec::new();
+    let mut has_more = false;
+    for backend in &backends {
+        let response =
+            forward_fine_tuning_request(state, parts, body, Some(backend.to_string())).await?;
+        if !response.status().is_success() {
+            return Ok(response);
+        }
+        let (response_parts, mut json) = buffer_json_response(state, response).await?;
+        has_more |= json
+            .get("has_more")
+            .and_then(Value::as_bool)
+            .unwrap_or(false);
+        if let Some(Value::Array(data)) = json.get_mut("data") {
+            jobs.extend(std::mem::take(data).into_iter().filter(|job| {
+                job.get("id")
+                    .and_then(Value::as_str)
+                    .is_some_and(|job_id| owned.contains_key(job_id))
+            }));
+        }
+        merged.get_or_insert((response_parts, json));
+    }
+    let Some((mut response_parts, mut json)) = merged else {
+        // No job recorded for the owner.
+        return Ok(Json(serde_json::json!({
+            "object": "list",
+            "data": [],
+            "has_more": false,
+        }))
+        .into_response());
+    };
+    if backends.len() > 1 {
+        response_parts.headers.remove("x-ditto-backend");
+    }
+    let created_at = |job: &Value| job.get("created_at").and_then(Value::as_u64).unwrap_or(0);
+    jobs.sort_by(|a, b| created_at(b).cmp(&created_at(a)));
+    json["data"] = Value::Array(jobs);
+    json["has_more"] = Value::Bool(has_more);
+    Ok(axum::response::Response::from_parts(
+        response_parts,
+        Body::from(json.to_string()),
+    ))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn classifies_fine_tuning_paths() {
+        let post = axum::http::Method::POST;
+        let get = axum::http::Method::GET;
+        assert_eq!(
+            fine_tuning_operation(&post, "/v1/fine_tuning/jobs"),
+            Some(FineTuningOperation::Create)
+        );
+        assert_eq!(
+            fine_tuning_operation(&get, "/v1/fine_tuning/jobs/"),
+            Some(FineTuningOperation::List)
+        );
+        assert_eq!(
+            fine_tuning_operation(&post, "/v1/fine_tuning/jobs/ftjob-1/cancel"),
+            Some(FineTuningOperation::Job("ftjob-1"))
+        );
+        assert_eq!(
+            fine_tuning_operation(&get, "/v1/fine_tuning/jobs/ftjob-1/events"),
+            Some(FineTuningOperation::Job("ftjob-1"))
+        );
+        assert_eq!(fine_tuning_operation(&get, "/v1/fine_tuning/jobsx"), None);
+        assert_eq!(fine_tuning_operation(&get, "/v1/models"), None);
+    }
+}
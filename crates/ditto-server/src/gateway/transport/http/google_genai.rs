@@ -74,8 +74,10 @@ pub(super) async fn handle_google_genai(
     {
         headers.insert("authorization", token);
     }
-    if let Some(value) = parts.headers.get("x-request-id") {
-        headers.insert("x-request-id", value.clone());
+    for name in ["x-request-id", "idempotency-key"] {
+        if let Some(value) = parts.headers.get(name) {
+            headers.insert(name, value.clone());
+        }
     }
 
     let mut openai_req = axum::http::Request::builder()
@@ -84,6 +86,7 @@ pub(super) async fn handle_google_genai(
         .body(Body::from(openai_bytes))
         .map_err(|err| google_error(StatusCode::BAD_REQUEST, err.to_string()))?;
     *openai_req.headers_mut() = headers;
+    forward_client_access_context(&parts, &mut openai_req);
     if !gateway_uses_virtual_keys(&state) && forwarded_auth_present {
         enable_internal_upstream_auth_passthrough(&mut openai_req);
     }
@@ -325,8 +328,10 @@ async fn handle_cloudcode_generate_content_inner(
     {
         headers.insert("authorization", token);
     }
-    if let Some(value) = parts.headers.get("x-request-id") {
-        headers.insert("x-request-id", value.clone());
+    for name in ["x-request-id", "idempotency-key"] {
+        if let Some(value) = parts.headers.get(name) {
+            headers.insert(name, value.clone());
+        }
     }
 
     let mut openai_req = axum::http::Request::builder()
@@ -335,6 +340,7 @@ async fn handle_cloudcode_generate_content_inner(
         .body(Body::from(openai_bytes))
         .map_err(|err| google_error(StatusCode::BAD_REQUEST, err.to_string()))?;
     *openai_req.headers_mut() = headers;
+    forward_client_access_context(&parts, &mut openai_req);
     if !gateway_uses_virtual_keys(&state) && forwarded_auth_present {
         enable_internal_upstream_auth_passthrough(&mut openai_req);
     }
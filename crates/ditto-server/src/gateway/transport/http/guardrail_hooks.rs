@@ -0,0 +1,364 @@
+use super::*;
+
+use crate::gateway::domain::moderation::{
+    ModerationAction, ModerationConfig, moderation_response_text,
+};
+use crate::gateway::{
+    GuardrailHookOutcome, GuardrailHookPhase, StreamEventAction, StreamTransform,
+    StreamTransformConfig,
+};
+
+/// What the response-side hooks need once the request context has been
+/// resolved; owned so it can travel with a streaming body.
+#[derive(Clone)]
+pub(super) struct GuardrailHookContext {
+    pub(super) state: GatewayHttpState,
+    pub(super) guardrails: GuardrailsConfig,
+    pub(super) prompt_injection: Option<PromptInjectionVerdict>,
+    pub(super) moderation: Option<ModerationVerdict>,
+    pub(super) context_window: Option<ContextWindowVerdict>,
+    pub(super) history_compression: Option<HistoryCompressionVerdict>,
+    pub(super) request_id: String,
+    pub(super) virtual_key_id: Option<String>,
+    #[cfg(feature = "gateway-metrics-prometheus")]
+    pub(super) model: Option<String>,
+    #[cfg(feature = "gateway-metrics-prometheus")]
+    pub(super) metrics_path: String,
+}
+
+impl GuardrailHookContext {
+    /// Whether `guardrails` has hooks, stream transforms or output moderation
+    /// that need to see the response; requests without them skip the context
+    /// so responses pass through untouched.
+    pub(super) fn has_response_hooks(guardrails: &GuardrailsConfig) -> bool {
+        guardrails.has_hooks(GuardrailHookPhase::PostCall)
+            || guardrails.has_hooks(GuardrailHookPhase::DuringStream)
+            || !guardrails.stream_transforms.is_empty()
+            || output_moderation(guardrails).is_some()
+    }
+
+    /// Builds this response's stream transforms in config order. `custom`
+    /// transforms that were never registered are logged and skipped.
+    fn stream_transforms(&self) -> Vec<Box<dyn StreamTransform>> {
+        self.guardrails
+            .stream_transforms
+            .iter()
+            .filter_map(|config| match config {
+                StreamTransformConfig::Custom { name } => {
+                    let factory = self.state.proxy.stream_transforms.get(name.trim());
+                    if factory.is_none() {
+                        emit_json_log(
+                            &self.state,
+                            "proxy.stream_transform_missing",
+                            serde_json::json!({
+                                "request_id": &self.request_id,
+                                "virtual_key_id": &self.virtual_key_id,
+                                "name": name,
+                            }),
+                        );
+                    }
+                    factory.map(|factory| factory())
+                }
+                config => config.build(),
+            })
+            .collect()
+    }
+
+    async fn record(&self, phase: GuardrailHookPhase, outcome: &GuardrailHookOutcome) {
+        log_guardrail_hook_matches(
+            &self.state,
+            &self.request_id,
+            self.virtual_key_id.as_deref(),
+            phase,
+            outcome,
+        );
+        if outcome.blocked_by.is_some() {
+            self.record_blocked().await;
+        }
+    }
+
+    async fn record_blocked(&self) {
+        self.state.record_guardrail_blocked();
+        #[cfg(feature = "gateway-metrics-prometheus")]
+        if let Some(metrics) = self.state.proxy.metrics.as_ref() {
+            metrics.lock().await.record_proxy_guardrail_blocked(
+                self.virtual_key_id.as_deref(),
+                self.model.as_deref(),
+                &self.metrics_path,
+            );
+        }
+    }
+}
+
+fn output_moderation(guardrails: &GuardrailsConfig) -> Option<&ModerationConfig> {
+    guardrails
+        .moderation
+        .as_ref()
+        .filter(|moderation| moderation.check_output)
+}
+
+pub(super) fn log_guardrail_hook_matches(
+    state: &GatewayHttpState,
+    request_id: &str,
+    virtual_key_id: Option<&str>,
+    phase: GuardrailHookPhase,
+    outcome: &GuardrailHookOutcome,
+) {
+    for hook_match in &outcome.matches {
+        emit_json_log(
+            state,
+            "proxy.guardrail",
+            serde_json::json!({
+                "request_id": request_id,
+                "virtual_key_id": virtual_key_id,
+                "hook": &hook_match.hook,
+                "phase": phase.as_str(),
+                "action": hook_match.action.as_str(),
+            }),
+        );
+    }
+}
+
+/// Runs post-call hooks on a buffered JSON response, or wraps an SSE body so
+/// streaming hooks and stream transforms see each event before the client
+/// does. Output moderation
+/// runs after the post-call hooks. Also sets the prompt-injection,
+/// moderation, context-window and history-compression headers.
+pub(super) async fn apply_response_guardrail_hooks(
+    hooks: Option<&GuardrailHookContext>,
+    mut response: axum::response::Response,
+) -> Result<axum::response::Response, (StatusCode, Json<OpenAiErrorResponse>)> {
+    let Some(hooks) = hooks else {
+        return Ok(response);
+    };
+    if let Some(verdict) = hooks.prompt_injection {
+        verdict.insert_headers(response.headers_mut());
+    }
+    if let Some(verdict) = hooks.moderation.as_ref() {
+        verdict.insert_headers(response.headers_mut());
+    }
+    if let Some(verdict) = hooks.context_window {
+        verdict.insert_headers(response.headers_mut());
+    }
+    if let Some(verdict) = hooks.history_compression.as_ref() {
+        verdict.apply_to_response(&mut response);
+    }
+    let content_type = response
+        .headers()
+        .get("content-type")
+        .and_then(|value| value.to_str().ok())
+        .unwrap_or_default()
+        .to_ascii_lowercase();
+
+    if content_type.starts_with("text/event-stream") {
+        if !hooks.guardrails.has_hooks(GuardrailHookPhase::DuringStream)
+            && hooks.guardrails.stream_transforms.is_empty()
+        {
+            return Ok(response);
+        }
+        let (mut parts, body) = response.into_parts();
+        parts.headers.remove("content-length");
+        let stream = guard_sse_stream(hooks.clone(), body.into_data_stream());
+        return Ok(axum::response::Response::from_parts(
+            parts,
+            Body::from_stream(stream),
+        ));
+    }
+
+    let moderation = output_moderation(&hooks.guardrails);
+    if !response.status().is_success()
+        || !content_type.starts_with("application/json")
+        || (!hooks.guardrails.has_hooks(GuardrailHookPhase::PostCall) && moderation.is_none())
+    {
+        return Ok(response);
+    }
+
+    let (mut parts, body) = response.into_parts();
+    let bytes = to_bytes(body, hooks.state.proxy.max_body_bytes)
+        .await
+        .map_err(|err| {
+            openai_error(
+                StatusCode::BAD_GATEWAY,
+                "api_error",
+                Some("guardrail_response_unavailable"),
+                format!("failed to buffer response for guardrail hooks: {err}"),
+            )
+        })?;
+    let Ok(mut json) = serde_json::from_slice::<Value>(&bytes) else {
+        return Ok(axum::response::Response::from_parts(
+            parts,
+            Body::from(bytes),
+        ));
+    };
+
+    let outcome = hooks
+        .guardrails
+        .run_hooks_on_json(GuardrailHookPhase::PostCall, &mut json);
+    hooks.record(GuardrailHookPhase::PostCall, &outcome).await;
+    if let Some(reason) = outcome.block_reason() {
+        return Err(guardrail_error(reason));
+    }
+    if let Some(config) = moderation
+        && let Some(text) = moderation_response_text(&json)
+    {
+        let violations = moderate_text(
+            &hooks.state,
+            config,
+            &hooks.request_id,
+            hooks.virtual_key_id.as_deref(),
+            "output",
+            &text,
+        )
+        .await;
+        if !violations.is_empty() && config.action == ModerationAction::Block {
+            hooks.record_blocked().await;
+            return Err(guardrail_error(moderation_block_reason(&violations)));
+        }
+        if !violations.is_empty() {
+            ModerationVerdict::new(&violations)
+                .merge(hooks.moderation.as_ref())
+                .insert_headers(&mut parts.headers);
+        }
+    }
+    if !outcome.modified {
+        return Ok(axum::response::Response::from_parts(
+            parts,
+            Body::from(bytes),
+        ));
+    }
+
+    parts.headers.remove("content-length");
+    let bytes = serde_json::to_vec(&json).unwrap_or_else(|_| json.to_string().into_bytes());
+    Ok(axum::response::Response::from_parts(
+        parts,
+        Body::from(bytes),
+    ))
+}
+
+fn guard_sse_stream(
+    hooks: GuardrailHookContext,
+    upstream: axum::body::BodyDataStream,
+) -> impl futures_util::Stream<Item = Result<Bytes, std::io::Error>> + Send + 'static {
+    struct GuardedSseState {
+        hooks: GuardrailHookContext,
+        transforms: Vec<Box<dyn StreamTransform>>,
+        upstream: axum::body::BodyDataStream,
+        buffer: bytes::BytesMut,
+        done: bool,
+    }
+
+    let state = GuardedSseState {
+        transforms: hooks.stream_transforms(),
+        hooks,
+        upstream,
+        buffer: bytes::BytesMut::new(),
+        done: false,
+    };
+
+    // Hooks see one SSE event at a time, so a phrase split across two chunks
+    // of generated text is not matched.
+    stream::unfold(state, |mut state| async move {
+        loop {
+            if state.done {
+                return None;
+            }
+            if let Some(end) = find_sse_event_end(&state.buffer) {
+                let event = state.buffer.split_to(end).freeze();
+                let (event, blocked) =
+                    guard_sse_event(&state.hooks, &mut state.transforms, event).await;
+                state.done = blocked;
+                return Some((Ok(event), state));
+            }
+            match state.upstream.next().await {
+                Some(Ok(chunk)) => state.buffer.extend_from_slice(&chunk),
+                Some(Err(err)) => {
+                    state.done = true;
+                    return Some((Err(std::io::Error::other(err.to_string())), state));
+                }
+                None => {
+                    state.done = true;
+                    if state.buffer.is_empty() {
+                        return None;
+                    }
+                    let event = state.buffer.split().freeze();
+                    let (event, _) =
+                        guard_sse_event(&state.hooks, &mut state.transforms, event).await;
+                    return Some((Ok(event), state));
+                }
+            }
+        }
+    })
+}
+
+/// Returns the offset just past the first blank-line event delimiter.
+fn find_sse_event_end(buf: &[u8]) -> Option<usize> {
+    let mut idx = 0usize;
+    while idx + 1 < buf.len() {
+        if buf[idx] == b'\n' && buf[idx + 1] == b'\n' {
+            return Some(idx + 2);
+        }
+        if buf[idx..].starts_with(b"\r\n\r\n") {
+            return Some(idx + 4);
+        }
+        idx += 1;
+    }
+    None
+}
+
+/// Returns the event to forward (empty when a transform drops it) and whether
+/// the stream must end after it.
+async fn guard_sse_event(
+    hooks: &GuardrailHookContext,
+    transforms: &mut [Box<dyn StreamTransform>],
+    event: Bytes,
+) -> (Bytes, bool) {
+    let Ok(text) = std::str::from_utf8(&event) else {
+        return (event, false);
+    };
+    let data = text
+        .lines()
+        .filter_map(|line| line.strip_prefix("data:"))
+        .map(str::trim)
+        .collect::<Vec<_>>()
+        .join("\n");
+    let Ok(mut json) = serde_json::from_str::<Value>(&data) else {
+        return (event, false);
+    };
+
+    let outcome = hooks
+        .guardrails
+        .run_hooks_on_json(GuardrailHookPhase::DuringStream, &mut json);
+    hooks
+        .record(GuardrailHookPhase::DuringStream, &outcome)
+        .await;
+    if let Some(reason) = outcome.block_reason() {
+        let (_, Json(error)) = guardrail_error(reason);
+        let error = serde_json::to_string(&error).unwrap_or_default();
+        return (
+            Bytes::from(format!("data: {error}\n\ndata: [DONE]\n\n")),
+            true,
+        );
+    }
+    for transform in transforms.iter_mut() {
+        if transform.transform(&mut json) == StreamEventAction::Drop {
+            return (Bytes::new(), false);
+        }
+    }
+    if !outcome.modified && transforms.is_empty() {
+        return (event, false);
+    }
+
+    // Keep `event:`/`id:` lines and replace the data lines with the rewritten
+    // payload.
+    let mut rewritten = String::with_capacity(event.len());
+    for line in text.lines() {
+        if !line.is_empty() && !line.starts_with("data:") {
+            rewritten.push_str(line);
+            rewritten.push('\n');
+        }
+    }
+    rewritten.push_str("data: ");
+    rewritten.push_str(&json.to_string());
+    rewritten.push_str("\n\n");
+    (Bytes::from(rewritten), false)
+}
@@ -0,0 +1,210 @@
+use super::*;
+
+use std::collections::BTreeSet;
+
+/// Name of the model group made of `router.default_backends`.
+const DEFAULT_MODEL_GROUP: &str = "*";
+
+#[derive(Debug, Serialize)]
+pub(super) struct ModelGroupReadiness {
+    name: String,
+    required: bool,
+    status: &'static str,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    detail: Option<String>,
+    backends: Vec<ReadinessCheck>,
+}
+
+#[derive(Debug, Serialize)]
+pub(super) struct DeepReadinessResponse {
+    status: &'static str,
+    checks: Vec<ReadinessCheck>,
+    model_groups: Vec<ModelGroupReadiness>,
+}
+
+/// `/health/readiness`: ready when every configured store answers and each
+/// required model group has at least one healthy backend. Unlike `/ready`, a
+/// single unhealthy deployment does not fail the probe while a sibling in the
+/// same group can still serve.
+pub(super) async fn health_readiness(
+    State(state): State<GatewayHttpState>,
+) -> (StatusCode, Json<DeepReadinessResponse>) {
+    let checks = store_readiness_checks(&state).await;
+
+    let groups = router_model_groups(&state.router_config_snapshot());
+    let backend_names = groups.values().flatten().cloned().collect::<BTreeSet<_>>();
+    let backends = backend_readiness(&state, &backend_names).await;
+
+    let required = &state.admin.readiness_model_groups;
+    let mut model_groups = groups
+        .into_iter()
+        .map(|(name, members)| {
+            let backends = members
+                .iter()
+                .filter_map(|backend| backends.get(backend))
+                .cloned()
+                .collect::<Vec<_>>();
+            let healthy = backends.iter().any(|check| check.status == "ok");
+            ModelGroupReadiness {
+                required: required.is_empty() || required.contains(&name),
+                name,
+                status: if healthy { "ok" } else { "error" },
+                detail: (!healthy).then(|| "no healthy backend in model group".to_string()),
+                backends,
+            }
+        })
+        .collect::<Vec<_>>();
+    for name in required {
+        if !model_groups.iter().any(|group| &group.name == name) {
+            model_groups.push(ModelGroupReadiness {
+                name: name.clone(),
+                required: true,
+                status: "error",
+                detail: Some("model group is not routed by router config".to_string()),
+                backends: Vec::new(),
+            });
+        }
+    }
+
+    let is_ready = checks.iter().all(|check| check.status == "ok")
+        && model_groups
+            .iter()
+            .all(|group| !group.required || group.status == "ok");
+    let status = if is_ready {
+        StatusCode::OK
+    } else {
+        StatusCode::SERVICE_UNAVAILABLE
+    };
+    let body = DeepReadinessResponse {
+        status: if is_ready { "ready" } else { "not_ready" },
+        checks,
+        model_groups,
+    };
+
+    (status, Json(body))
+}
+
+/// Groups backends by the router entry that selects them: one group per rule
+/// (keyed by `model_prefix`) plus `*` for the default backends.
+fn router_model_groups(router: &RouterConfig) -> BTreeMap<String, BTreeSet<String>> {
+    let mut groups: BTreeMap<String, BTreeSet<String>> = BTreeMap::new();
+    if !router.default_backends.is_empty() {
+        groups.insert(
+            DEFAULT_MODEL_GROUP.to_string(),
+            router
+                .default_backends
+                .iter()
+                .map(|backend| backend.backend.clone())
+                .collect(),
+        );
+    }
+    for rule in &router.rules {
+        let members = groups.entry(rule.model_prefix.clone()).or_default();
+        if rule.backends.is_empty() {
+            let backend = rule.backend.trim();
+            if !backend.is_empty() {
+                members.insert(backend.to_string());
+            }
+        } else {
+            members.extend(rule.backends.iter().map(|backend| backend.backend.clone()));
+        }
+    }
+    groups
+}
+
+async fn backend_readiness(
+    state: &GatewayHttpState,
+    backend_names: &BTreeSet<String>,
+) -> BTreeMap<String, ReadinessCheck> {
+    let configured = state.backend_names_snapshot();
+
+    #[cfg(feature = "gateway-routing-advanced")]
+    let health_checks_enabled = state
+        .proxy
+        .routing
+        .as_ref()
+        .is_some_and(|config| config.health_check.enabled);
+    #[cfg(feature = "gateway-routing-advanced")]
+    let snapshots = match state.proxy.backend_health.as_ref() {
+        Some(health) => {
+            let now = now_epoch_seconds();
+            let health = health.lock().await;
+            backend_names
+                .iter()
+                .filter_map(|name| {
+                    let entry = health.get(name)?;
+                    Some((name.clone(), (entry.is_healthy(now), entry.snapshot(name))))
+                })
+                .collect::<HashMap<_, _>>()
+        }
+        None => HashMap::new(),
+    };
+
+    backend_names
+        .iter()
+        .map(|name| {
+            let result = if configured.contains(name) {
+                #[cfg(feature = "gateway-routing-advanced")]
+                {
+                    probe_backend_health(
+                        snapshots.get(name),
+                        health_checks_enabled && state.backends.proxy_backends.contains_key(name),
+                    )
+                }
+                #[cfg(not(feature = "gateway-routing-advanced"))]
+                {
+                    Ok(())
+                }
+            } else {
+                Err("backend is not configured".to_string())
+            };
+            (name.clone(), ReadinessCheck::from_result(name, result))
+        })
+        .collect()
+}
+
+#[cfg(feature = "gateway-routing-advanced")]
+fn probe_backend_health(
+    snapshot: Option<&(bool, BackendHealthSnapshot)>,
+    health_checked: bool,
+) -> Result<(), String> {
+    match snapshot {
+        Some((true, snapshot)) if !health_checked || snapshot.health_check_healthy.is_some() => {
+            Ok(())
+        }
+        Some((true, _)) | None if health_checked => {
+            Err("backend health check has not completed yet".to_string())
+        }
+        None => Ok(()),
+        Some((_, snapshot)) => Err(snapshot
+            .health_check_last_error
+            .clone()
+            .or_else(|| snapshot.last_error.clone())
+            .unwrap_or_else(|| "backend circuit breaker is open".to_string())),
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn router_model_groups_merges_rules_and_default_backends() {
+        let router: RouterConfig = serde_json::from_value(serde_json::json!({
+            "default_backends": [{ "backend": "primary" }],
+            "rules": [
+                { "model_prefix": "gpt-4o", "backends": [{ "backend": "a" }, { "backend": "b" }] },
+                { "model_prefix": "claude-", "backend": "c" },
+                { "model_prefix": "gpt-4o", "exact": true, "backend": "d" }
+            ]
+        }))
+        .expect("router");
+
+        let groups = router_model_groups(&router);
+        let names = |group: &str| groups[group].iter().map(String::as_str).collect::<Vec<_>>();
+        assert_eq!(groups.len(), 3);
+        assert_eq!(names("*"), vec!["primary"]);
+        assert_eq!(names("gpt-4o"), vec!["a", "b", "d"]);
+        assert_eq!(names("claude-"), vec!["c"]);
+    }
+}
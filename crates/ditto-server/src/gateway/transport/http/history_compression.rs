@@ -0,0 +1,108 @@
+use super::*;
+
+use axum::http::HeaderValue;
+
+use super::context_window::{context_message_tokens, summarize_messages};
+use crate::gateway::domain::context_window::context_window_transcript;
+use crate::gateway::domain::history_compression::{
+    HISTORY_SUMMARY_PREFIX, HistoryCompressionConfig, history_compression_range,
+};
+
+/// What history compression replaced, carried to the response headers. It is
+/// also left in the response extensions so a conversation session stores
+/// the compressed messages and later turns start from the summary.
+#[derive(Clone, Debug)]
+pub(super) struct HistoryCompressionVerdict {
+    pub(super) compressed_messages: usize,
+    pub(super) saved_tokens: u32,
+    /// The `messages` that were forwarded upstream.
+    pub(super) messages: Arc<Vec<Value>>,
+}
+
+impl HistoryCompressionVerdict {
+    pub(super) fn apply_to_response(&self, response: &mut axum::response::Response) {
+        let headers = response.headers_mut();
+        headers.insert(
+            "x-ditto-history-compressed-messages",
+            HeaderValue::from(self.compressed_messages),
+        );
+        headers.insert(
+            "x-ditto-history-saved-tokens",
+            HeaderValue::from(self.saved_tokens),
+        );
+        response.extensions_mut().insert(self.clone());
+    }
+}
+
+/// Replaces the older turns of a chat completions request whose input
+/// estimate exceeds the threshold with one summary message. Returns `None`
+/// when nothing was compressed; a summarizer failure is logged and the
+/// request is forwarded as it came.
+#[allow(clippy::too_many_arguments)]
+pub(super) async fn compress_history(
+    state: &GatewayHttpState,
+    config: &HistoryCompressionConfig,
+    request_id: &str,
+    virtual_key_id: Option<&str>,
+    path_and_query: &str,
+    model: Option<&str>,
+    body: &Value,
+    input_tokens: u32,
+) -> Option<(Value, HistoryCompressionVerdict)> {
+    let path = path_and_query.split('?').next().unwrap_or_default();
+    if input_tokens <= config.threshold_tokens
+        || path.trim_end_matches('/') != "/v1/chat/completions"
+    {
+        return None;
+    }
+    let mut body = body.clone();
+    let messages = body.get_mut("messages").and_then(Value::as_array_mut)?;
+    let range = history_compression_range(messages, config.keep_recent_messages)?;
+    let compressed_tokens: u32 = messages[range.clone()]
+        .iter()
+        .map(|message| context_message_tokens(model, message))
+        .sum();
+    // A summary this long would not save anything.
+    if compressed_tokens <= config.summarizer.max_tokens {
+        return None;
+    }
+
+    let transcript = context_window_transcript(&messages[range.clone()]);
+    let log = |verdict: Option<&HistoryCompressionVerdict>, error: Option<String>| {
+        emit_json_log(
+            state,
+            "proxy.history_compression",
+            serde_json::json!({
+                "request_id": request_id,
+                "virtual_key_id": virtual_key_id,
+                "threshold_tokens": config.threshold_tokens,
+                "input_tokens": input_tokens,
+                "compressed_messages": verdict.map(|verdict| verdict.compressed_messages),
+                "saved_tokens": verdict.map(|verdict| verdict.saved_tokens),
+                "summarizer_error": error,
+            }),
+        );
+    };
+    let summary = match summarize_messages(state, &config.summarizer, &transcript).await {
+        Ok(summary) => summary,
+        Err(err) => {
+            log(None, Some(err));
+            return None;
+        }
+    };
+    let summary = serde_json::json!({
+        "role": "system",
+        "content": format!("{HISTORY_SUMMARY_PREFIX}{summary}"),
+    });
+    let saved_tokens = compressed_tokens.saturating_sub(context_message_tokens(model, &summary));
+    let compressed_messages = range.len();
+    messages.splice(range, [summary]);
+
+    let verdict = HistoryCompressionVerdict {
+        compressed_messages,
+        saved_tokens,
+        messages: Arc::new(messages.clone()),
+    };
+    log(Some(&verdict), None);
+    Some((body, verdict))
+}
@@ -0,0 +1,366 @@
+//! Self-service quota endpoints a virtual key calls about itself
+//! (`/v1/key/info`, `/v1/key/usage`), so applications can show their users
+//! what is left of their budget and rate limits without an admin token.
+
+use super::*;
+
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+use super::admin_spend::aggregate_spend;
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+use super::proxy_budget_reservations::persistent_budget_usage;
+use crate::gateway::{BudgetUsage, RateLimitStatus, SpendReportRow};
+
+type ProxyError = (StatusCode, Json<OpenAiErrorResponse>);
+
+const DEFAULT_USAGE_DAYS: u64 = 7;
+const MAX_USAGE_DAYS: u64 = 90;
+const DAY_MS: u64 = 86_400_000;
+
+#[derive(Debug, Serialize)]
+pub(super) struct KeyInfoResponse {
+    key_id: String,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    tenant_id: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    project_id: Option<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    user_id: Option<String>,
+    /// The key's `allow_models`; empty means any model the key routes to.
+    models: Vec<String>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    route: Option<String>,
+    rate_limits: Vec<KeyRateLimit>,
+    budgets: Vec<KeyBudget>,
+}
+
+/// One scope's rate limits and, when counted in this process, what is left of
+/// them in the current minute.
+#[derive(Debug, Serialize)]
+pub(super) struct KeyRateLimit {
+    scope: &'static str,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    rpm: Option<u32>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    tpm: Option<u32>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    remaining_requests: Option<u32>,
+    #[serde(skip_serializing_if = "Option::is_none")]
+    remaining_tokens: Option<u32>,
+}
+
+#[derive(Debug, Serialize)]
+pub(super) struct KeyBudget {
+    scope: &'static str,
+    #[serde(flatten)]
+    usage: BudgetUsage,
+}
+
+#[derive(Debug, Deserialize)]
+pub(super) struct KeyUsageQuery {
+    #[serde(default)]
+    days: Option<u64>,
+}
+
+#[derive(Debug, Default, Serialize)]
+pub(super) struct KeyUsageResponse {
+    key_id: String,
+    since_ts_ms: u64,
+    requests: u64,
+    input_tokens: u64,
+    output_tokens: u64,
+    spent_tokens: u64,
+    spent_usd_micros: u64,
+    /// Per day and model (`group`), in day order.
+    daily: Vec<SpendReportRow>,
+    /// Set when the window held more audit records than a report scans.
+    truncated: bool,
+}
+
+/// The enabled key the request authenticates as. Spend throttling is not
+/// checked: a throttled key can still look up why.
+async fn authenticate_key(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+) -> Result<VirtualKeyConfig, ProxyError> {
+    let token = extract_virtual_key(&parts.headers).ok_or_else(|| {
+        openai_error(
+            StatusCode::UNAUTHORIZED,
+            "authentication_error",
+            Some("invalid_api_key"),
+            "missing virtual key",
+        )
+    })?;
+    let key = state
+        .virtual_key_by_token(&token)
+        .filter(|key| key.enabled)
+        .ok_or_else(|| {
+            openai_error(
+                StatusCode::UNAUTHORIZED,
+                "authentication_error",
+                Some("invalid_api_key"),
+                "unauthorized virtual key",
+            )
+        })?;
+    ensure_virtual_key_client_access(state, parts, &key).await?;
+    Ok(key)
+}
+
+/// The key's own scope and the tenant, project and user scopes it shares,
+/// each with its limits and budget as configured on the key.
+fn key_scopes(
+    key: &VirtualKeyConfig,
+) -> Vec<(
+    &'static str,
+    String,
+    Option<&super::LimitsConfig>,
+    Option<&super::BudgetConfig>,
+)> {
+    let mut scopes = vec![("key", key.id.clone(), Some(&key.limits), Some(&key.budget))];
+    if let Some(scope) = crate::gateway::tenant_scope_key(key.tenant_id.as_deref()) {
+        scopes.push((
+            "tenant",
+            scope,
+            key.tenant_limits.as_ref(),
+            key.tenant_budget.as_ref(),
+        ));
+    }
+    if let Some(scope) =
+        crate::gateway::project_scope_key(key.tenant_id.as_deref(), key.project_id.as_deref())
+    {
+        scopes.push((
+            "project",
+            scope,
+            key.project_limits.as_ref(),
+            key.project_budget.as_ref(),
+        ));
+    }
+    if let Some(scope) =
+        crate::gateway::user_scope_key(key.tenant_id.as_deref(), key.user_id.as_deref())
+    {
+        scopes.push((
+            "user",
+            scope,
+            key.user_limits.as_ref(),
+            key.user_budget.as_ref(),
+        ));
+    }
+    scopes
+}
+
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+fn uses_persistent_budget(state: &GatewayHttpState) -> bool {
+    #[cfg(feature = "gateway-store-sqlite")]
+    if state.stores.sqlite.is_some() {
+        return true;
+    }
+    #[cfg(feature = "gateway-store-postgres")]
+    if state.stores.postgres.is_some() {
+        return true;
+    }
+    #[cfg(feature = "gateway-store-mysql")]
+    if state.stores.mysql.is_some() {
+        return true;
+    }
+    uses_redis_rate_limits(state)
+}
+
+fn uses_redis_rate_limits(state: &GatewayHttpState) -> bool {
+    #[cfg(feature = "gateway-store-redis")]
+    if state.stores.redis.is_some() {
+        return true;
+    }
+    let _ = state;
+    false
+}
+
+async fn budget_usage(
+    state: &GatewayHttpState,
+    scope: &str,
+    budget: &super::BudgetConfig,
+) -> Result<BudgetUsage, ProxyError> {
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    if uses_persistent_budget(state) {
+        return persistent_budget_usage(state, scope, budget)
+            .await
+            .map_err(|err| {
+                openai_error(
+                    StatusCode::INTERNAL_SERVER_ERROR,
+                    "api_error",
+                    Some("storage_error"),
+                    err,
+                )
+            });
+    }
+    Ok(state.budget_usage(scope, budget))
+}
+
+pub(super) async fn handle_key_info(
+    State(state): State<GatewayHttpState>,
+    req: axum::http::Request<Body>,
+) -> Result<Json<KeyInfoResponse>, ProxyError> {
+    let (parts, _body) = req.into_parts();
+    let key = authenticate_key(&state, &parts).await?;
+
+    // Redis counts requests per route in a sliding window, so only the limits
+    // themselves are reported there.
+    let local_rate_limits = !uses_redis_rate_limits(&state);
+    let minute = now_epoch_seconds() / 60;
+    let mut rate_limits = Vec::new();
+    let mut budgets = Vec::new();
+    for (name, scope, limits, budget) in key_scopes(&key) {
+        if let Some(limits) = limits.filter(|limits| limits.rpm.is_some() || limits.tpm.is_some()) {
+            let status = if local_rate_limits {
+                state.rate_limit_remaining(&scope, limits, minute)
+            } else {
+                RateLimitStatus::default()
+            };
+            rate_limits.push(KeyRateLimit {
+                scope: name,
+                rpm: limits.rpm,
+                tpm: limits.tpm,
+                remaining_requests: status.requests.map(|status| status.remaining),
+                remaining_tokens: status.tokens.map(|status| status.remaining),
+            });
+        }
+        if let Some(budget) = budget
+            .filter(|budget| budget.total_tokens.is_some() || budget.total_usd_micros.is_some())
+        {
+            budgets.push(KeyBudget {
+                scope: name,
+                usage: budget_usage(&state, &scope, budget).await?,
+            });
+        }
+    }
+
+    Ok(Json(KeyInfoResponse {
+        key_id: key.id,
+        tenant_id: key.tenant_id,
+        project_id: key.project_id,
+        user_id: key.user_id,
+        models: key.guardrails.allow_models,
+        route: key.route,
+        rate_limits,
+        budgets,
+    }))
+}
+
+/// The key's spend over the last `days` UTC days (today included), from the
+/// audit log.
+pub(super) async fn handle_key_usage(
+    State(state): State<GatewayHttpState>,
+    Query(query): Query<KeyUsageQuery>,
+    req: axum::http::Request<Body>,
+) -> Result<Json<KeyUsageResponse>, ProxyError> {
+    let (parts, _body) = req.into_parts();
+    let key = authenticate_key(&state, &parts).await?;
+
+    let days = query.days.unwrap_or(DEFAULT_USAGE_DAYS);
+    if !(1..=MAX_USAGE_DAYS).contains(&days) {
+        return Err(openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("invalid_days"),
+            format!("days must be between 1 and {MAX_USAGE_DAYS}"),
+        ));
+    }
+    let now_ms = now_epoch_millis();
+    let since_ts_ms =
+        crate::gateway::SpendBucket::Day.start_ms(now_ms.saturating_sub((days - 1) * DAY_MS));
+
+    let mut usage = KeyUsageResponse {
+        key_id: key.id,
+        since_ts_ms,
+        ..KeyUsageResponse::default()
+    };
+    (usage.daily, usage.truncated) = key_spend_rows(&state, &usage.key_id, since_ts_ms).await?;
+    for row in &usage.daily {
+        usage.requests += row.requests;
+        usage.input_tokens += row.input_tokens;
+        usage.output_tokens += row.output_tokens;
+        usage.spent_tokens += row.spent_tokens;
+        usage.spent_usd_micros += row.spent_usd_micros;
+    }
+    Ok(Json(usage))
+}
+
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+async fn key_spend_rows(
+    state: &GatewayHttpState,
+    key_id: &str,
+    since_ts_ms: u64,
+) -> Result<(Vec<SpendReportRow>, bool), ProxyError> {
+    use crate::gateway::domain::spend_report::SpendFilter;
+
+    let filter = SpendFilter {
+        key_id: Some(key_id.to_string()),
+        ..SpendFilter::default()
+    };
+    aggregate_spend(
+        state,
+        &filter,
+        crate::gateway::SpendGroupBy::Model,
+        Some(crate::gateway::SpendBucket::Day),
+        Some(since_ts_ms),
+        None,
+    )
+    .await
+    .map_err(|(status, Json(body))| {
+        let kind = if status.is_server_error() {
+            "api_error"
+        } else {
+            "invalid_request_error"
+        };
+        openai_error(status, kind, Some(body.error.code), body.error.message)
+    })
+}
+
+#[cfg(not(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+)))]
+async fn key_spend_rows(
+    _state: &GatewayHttpState,
+    _key_id: &str,
+    _since_ts_ms: u64,
+) -> Result<(Vec<SpendReportRow>, bool), ProxyError> {
+    Err(openai_error(
+        StatusCode::BAD_REQUEST,
+        "invalid_request_error",
+        Some("not_configured"),
+        "store not configured",
+    ))
+}
+
+fn now_epoch_millis() -> u64 {
+    SystemTime::now()
+        .duration_since(UNIX_EPOCH)
+        .map(|duration| duration.as_millis() as u64)
+        .unwrap_or(0)
+}
@@ -287,6 +287,7 @@ async fn litellm_key_generate(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "litellm.key.generate",
         serde_json::json!({
             "key_id": &virtual_key.id,
@@ -366,6 +367,9 @@ fn litellm_presented_token_or_stored(key: &VirtualKeyConfig, presented: &str) ->
     if key
         .token
         .starts_with(crate::gateway::config::VIRTUAL_KEY_TOKEN_HASH_PREFIX)
+        || key
+            .token
+            .starts_with(crate::gateway::config::VIRTUAL_KEY_SALTED_TOKEN_HASH_PREFIX)
     {
         presented.to_string()
     } else {
@@ -533,6 +537,7 @@ async fn litellm_key_update(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "litellm.key.update",
         serde_json::json!({
             "key_id": &key.id,
@@ -677,6 +682,7 @@ async fn litellm_key_delete(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "litellm.key.delete",
         serde_json::json!({
             "deleted": deleted_key_ids.len(),
@@ -1107,6 +1113,7 @@ async fn litellm_key_regenerate_inner(
     ))]
     append_admin_audit_log(
         &state,
+        &admin,
         "litellm.key.regenerate",
         serde_json::json!({
             "key_id": &key.id,
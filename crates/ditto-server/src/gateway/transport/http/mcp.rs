@@ -406,9 +406,10 @@ pub(super) async fn handle_mcp_tools_list(
 ) -> axum::response::Response {
     let (parts, body) = req.into_parts();
 
-    if let Err(resp) = enforce_mcp_auth(&state, &parts.headers).await {
-        return resp;
-    }
+    let key = match enforce_mcp_auth(&state, &parts).await {
+        Ok(key) => key,
+        Err(resp) => return resp,
+    };
 
     if parts.method != axum::http::Method::POST && parts.method != axum::http::Method::GET {
         return StatusCode::METHOD_NOT_ALLOWED.into_response();
@@ -444,6 +445,7 @@ pub(super) async fn handle_mcp_tools_list(
     let cursor = payload.as_ref().and_then(|p| p.cursor.clone());
     let server_ids = match resolve_requested_mcp_servers(
         &state,
+        &key,
         payload.and_then(|p| p.servers),
         &parts.headers,
         None,
@@ -452,7 +454,7 @@ pub(super) async fn handle_mcp_tools_list(
         Err(resp) => return *resp,
     };
 
-    match mcp_list_tools(&state, &server_ids, cursor).await {
+    match mcp_list_tools(&state, Some(&key), &server_ids, cursor).await {
         Ok(result) => Json(result).into_response(),
         Err(err) => map_mcp_gateway_error(err).into_response(),
     }
@@ -464,9 +466,10 @@ pub(super) async fn handle_mcp_tools_call(
 ) -> axum::response::Response {
     let (parts, body) = req.into_parts();
 
-    if let Err(resp) = enforce_mcp_auth(&state, &parts.headers).await {
-        return resp;
-    }
+    let key = match enforce_mcp_auth(&state, &parts).await {
+        Ok(key) => key,
+        Err(resp) => return resp,
+    };
 
     if parts.method != axum::http::Method::POST {
         return StatusCode::METHOD_NOT_ALLOWED.into_response();
@@ -493,6 +496,7 @@ pub(super) async fn handle_mcp_tools_call(
 
     let server_ids = match resolve_requested_mcp_servers(
         &state,
+        &key,
         parsed.server_id.clone().map(|id| vec![id]),
         &parts.headers,
         None,
@@ -501,7 +505,15 @@ pub(super) async fn handle_mcp_tools_call(
         Err(resp) => return *resp,
     };
 
-    match mcp_call_tool(&state, &server_ids, &parsed.name, parsed.arguments).await {
+    match mcp_call_tool(
+        &state,
+        Some(&key),
+        &server_ids,
+        &parsed.name,
+        parsed.arguments,
+    )
+    .await
+    {
         Ok(result) => Json(result).into_response(),
         Err(err) => map_mcp_gateway_error(err).into_response(),
     }
@@ -514,9 +526,10 @@ async fn handle_mcp_impl(
 ) -> axum::response::Response {
     let (parts, body) = req.into_parts();
 
-    if let Err(resp) = enforce_mcp_auth(&state, &parts.headers).await {
-        return resp;
-    }
+    let key = match enforce_mcp_auth(&state, &parts).await {
+        Ok(key) => key,
+        Err(resp) => return resp,
+    };
 
     if parts.method != axum::http::Method::POST {
         return StatusCode::METHOD_NOT_ALLOWED.into_response();
@@ -585,6 +598,7 @@ async fn handle_mcp_impl(
         "tools/list" => {
             let server_ids = match resolve_requested_mcp_servers_jsonrpc(
                 &state,
+                &key,
                 &parts.headers,
                 selector.as_deref(),
             ) {
@@ -599,7 +613,7 @@ async fn handle_mcp_impl(
                 .and_then(|params| params.get("cursor"))
                 .and_then(|value| value.as_str())
                 .map(|value| value.to_string());
-            match mcp_list_tools(&state, &server_ids, cursor).await {
+            match mcp_list_tools(&state, Some(&key), &server_ids, cursor).await {
                 Ok(result) => Json(mcp_jsonrpc_result(id, result)).into_response(),
                 Err(GatewayError::InvalidRequest { reason }) => {
                     Json(mcp_jsonrpc_error(id, -32602, &reason)).into_response()
@@ -618,6 +632,7 @@ async fn handle_mcp_impl(
             let arguments = params.get("arguments").cloned().unwrap_or(Value::Null);
             let server_ids = match resolve_requested_mcp_servers_jsonrpc(
                 &state,
+                &key,
                 &parts.headers,
                 selector.as_deref(),
             ) {
@@ -626,7 +641,7 @@ async fn handle_mcp_impl(
                     return Json(mcp_jsonrpc_error(id, -32602, &reason)).into_response();
                 }
             };
-            match mcp_call_tool(&state, &server_ids, name, arguments).await {
+            match mcp_call_tool(&state, Some(&key), &server_ids, name, arguments).await {
                 Ok(result) => Json(mcp_jsonrpc_result(id, result)).into_response(),
                 Err(GatewayError::InvalidRequest { reason }) => {
                     Json(mcp_jsonrpc_error(id, -32602, &reason)).into_response()
@@ -640,18 +655,14 @@ async fn handle_mcp_impl(
 
 async fn enforce_mcp_auth(
     state: &GatewayHttpState,
-    headers: &HeaderMap,
-) -> Result<(), axum::response::Response> {
-    let token =
-        extract_virtual_key(headers).ok_or_else(|| StatusCode::UNAUTHORIZED.into_response())?;
-    let key = state
-        .virtual_key_by_token(&token)
+    parts: &axum::http::request::Parts,
+) -> Result<VirtualKeyConfig, axum::response::Response> {
+    let key = mcp_virtual_key(state, parts)
+        .await
+        .map_err(IntoResponse::into_response)?
         .ok_or_else(|| StatusCode::UNAUTHORIZED.into_response())?;
-    if !key.enabled {
-        return Err(StatusCode::UNAUTHORIZED.into_response());
-    }
     state.record_request();
-    Ok(())
+    Ok(key)
 }
 
 fn map_mcp_gateway_error(err: GatewayError) -> (StatusCode, Json<ErrorResponse>) {
@@ -666,7 +677,7 @@ fn map_mcp_gateway_error(err: GatewayError) -> (StatusCode, Json<ErrorResponse>)
         ),
         GatewayError::GuardrailRejected { reason } => error_response(
             StatusCode::FORBIDDEN,
-            "guardrail_rejected",
+            guardrail_error_code(&reason),
             format!("guardrail rejected: {reason}"),
         ),
         GatewayError::BudgetExceeded { limit, attempted } => error_response(
@@ -698,11 +709,17 @@ fn map_mcp_gateway_error(err: GatewayError) -> (StatusCode, Json<ErrorResponse>)
         GatewayError::InvalidRequest { reason } => {
             error_response(StatusCode::BAD_REQUEST, "invalid_request", reason)
         }
+        GatewayError::RegionUnavailable { regions } => error_response(
+            StatusCode::BAD_REQUEST,
+            "region_unavailable",
+            format!("no backend available in required region: {regions}"),
+        ),
     }
 }
 
 fn resolve_requested_mcp_servers(
     state: &GatewayHttpState,
+    key: &VirtualKeyConfig,
     servers: Option<Vec<String>>,
     headers: &HeaderMap,
     selector: Option<String>,
@@ -716,19 +733,23 @@ fn resolve_requested_mcp_servers(
 
     let header_selector = extract_header(headers, "x-mcp-servers");
 
-    let mut requested = if let Some(servers) = servers {
-        servers
-    } else if let Some(selector) = selector.or(header_selector) {
-        selector
-            .split(',')
-            .map(|value| value.trim().to_string())
-            .filter(|value| !value.is_empty())
-            .collect()
+    let requested = if let Some(servers) = servers {
+        Some(servers)
     } else {
-        let mut all: Vec<String> = state.backends.mcp_servers.keys().cloned().collect();
-        all.sort();
-        all
+        selector.or(header_selector).map(|selector| {
+            selector
+                .split(',')
+                .map(|value| value.trim().to_string())
+                .filter(|value| !value.is_empty())
+                .collect()
+        })
     };
+    let mut requested = mcp_servers_for_key(state, Some(key), requested).map_err(|message| {
+        Box::new(
+            error_response(StatusCode::FORBIDDEN, "mcp_server_not_allowed", message)
+                .into_response(),
+        )
+    })?;
 
     requested.sort();
     requested.dedup();
@@ -759,6 +780,7 @@ fn resolve_requested_mcp_servers(
 
 fn resolve_requested_mcp_servers_jsonrpc(
     state: &GatewayHttpState,
+    key: &VirtualKeyConfig,
     headers: &HeaderMap,
     selector: Option<&str>,
 ) -> Result<Vec<String>, String> {
@@ -770,17 +792,14 @@ fn resolve_requested_mcp_servers_jsonrpc(
 
     let header_selector = extract_header(headers, "x-mcp-servers");
 
-    let mut requested = if let Some(selector) = selector.or(header_selector) {
+    let requested = selector.or(header_selector).map(|selector| {
         selector
             .split(',')
             .map(|value| value.trim().to_string())
             .filter(|value| !value.is_empty())
             .collect()
-    } else {
-        let mut all: Vec<String> = state.backends.mcp_servers.keys().cloned().collect();
-        all.sort();
-        all
-    };
+    });
+    let mut requested = mcp_servers_for_key(state, Some(key), requested)?;
 
     requested.sort();
     requested.dedup();
@@ -802,6 +821,7 @@ fn resolve_requested_mcp_servers_jsonrpc(
 
 pub(super) async fn mcp_list_tools(
     state: &GatewayHttpState,
+    key: Option<&VirtualKeyConfig>,
     server_ids: &[String],
     cursor: Option<String>,
 ) -> Result<Value, GatewayError> {
@@ -836,6 +856,13 @@ pub(super) async fn mcp_list_tools(
         }
         for tool in result.tools {
             let mut tool = tool;
+            let allowed = key.is_none_or(|key| match tool.get("name").and_then(Value::as_str) {
+                Some(name) => key.mcp.allows_tool(&server_id, name),
+                None => key.mcp.allowed_tools.is_empty(),
+            });
+            if !allowed {
+                continue;
+            }
             if prefix_names
                 && let Some(obj) = tool.as_object_mut()
                 && let Some(Value::String(name)) = obj.get("name").cloned()
@@ -860,6 +887,7 @@ pub(super) async fn mcp_list_tools(
 
 pub(super) async fn mcp_call_tool(
     state: &GatewayHttpState,
+    key: Option<&VirtualKeyConfig>,
     server_ids: &[String],
     name: &str,
     arguments: Value,
@@ -909,6 +937,29 @@ pub(super) async fn mcp_call_tool(
                 reason: format!("unknown MCP server: {server_id}"),
             })?;
 
+    if key.is_some_and(|key| !key.mcp.allows_tool(server_id, tool_name)) {
+        let err = GatewayError::GuardrailRejected {
+            reason: format!("mcp_tool_not_allowed:{server_id}/{tool_name}"),
+        };
+        log_mcp_tool_call(state, key, server_id, tool_name, "denied", None, Some(&err));
+        return Err(err);
+    }
+    if let Some(key) = key
+        && let Err(err) = consume_mcp_call_rate_limit(state, key).await
+    {
+        state.record_rate_limited();
+        log_mcp_tool_call(
+            state,
+            Some(key),
+            server_id,
+            tool_name,
+            "rate_limited",
+            None,
+            Some(&err),
+        );
+        return Err(err);
+    }
+
     let req = serde_json::json!({
         "jsonrpc": "2.0",
         "id": 1,
@@ -918,15 +969,30 @@ pub(super) async fn mcp_call_tool(
             "arguments": arguments,
         },
     });
-    let resp = server.jsonrpc(req).await?;
-
-    if let Some(err) = resp.get("error") {
-        return Err(GatewayError::Backend {
-            message: format!("mcp tool call failed: {err}"),
+    let started = Instant::now();
+    let result = server
+        .jsonrpc(req)
+        .await
+        .and_then(|resp| match resp.get("error") {
+            Some(err) => Err(GatewayError::Backend {
+                message: format!("mcp tool call failed: {err}"),
+            }),
+            None => Ok(resp.get("result").cloned().unwrap_or(Value::Null)),
         });
+    let duration_ms = Some(started.elapsed().as_millis());
+    match &result {
+        Ok(_) => log_mcp_tool_call(state, key, server_id, tool_name, "ok", duration_ms, None),
+        Err(err) => log_mcp_tool_call(
+            state,
+            key,
+            server_id,
+            tool_name,
+            "error",
+            duration_ms,
+            Some(err),
+        ),
     }
-
-    Ok(resp.get("result").cloned().unwrap_or(Value::Null))
+    result
 }
 
 fn mcp_jsonrpc_result(id: Value, result: Value) -> Value {
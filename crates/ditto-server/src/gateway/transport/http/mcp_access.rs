@@ -0,0 +1,129 @@
+//! Per-key governance of MCP tool use: the key's `mcp.servers` and
+//! `mcp.allowed_tools`, its `mcp.calls_per_minute` limit, and one
+//! `mcp.tool_call` log record per call.
+
+use super::*;
+
+/// The enabled virtual key an MCP request acts for, held to the key's
+/// `allowed_ips` / `allowed_origins` like the proxy routes.
+pub(super) async fn mcp_virtual_key(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+) -> Result<Option<VirtualKeyConfig>, (StatusCode, Json<OpenAiErrorResponse>)> {
+    let Some(key) = extract_virtual_key(&parts.headers)
+        .and_then(|token| state.virtual_key_by_token(&token))
+        .filter(|key| key.enabled)
+    else {
+        return Ok(None);
+    };
+    ensure_virtual_key_client_access(state, parts, &key).await?;
+    Ok(Some(key))
+}
+
+/// The virtual key a `{"type":"mcp"}` tool loop acts for. Without virtual
+/// keys configured the loop runs unscoped, like the rest of the proxy.
+pub(super) async fn mcp_tool_loop_key(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+) -> Result<Option<VirtualKeyConfig>, (StatusCode, Json<OpenAiErrorResponse>)> {
+    if !gateway_uses_virtual_keys(state) {
+        return Ok(None);
+    }
+    let key = mcp_virtual_key(state, parts).await?.ok_or_else(|| {
+        openai_error(
+            StatusCode::UNAUTHORIZED,
+            "authentication_error",
+            Some("invalid_api_key"),
+            "unauthorized virtual key",
+        )
+    })?;
+    Ok(Some(key))
+}
+
+/// The servers a request may use: every configured server the key may use
+/// when none were asked for, else the requested ones, all of which must be
+/// allowed. Unknown servers are left to the caller.
+pub(super) fn mcp_servers_for_key(
+    state: &GatewayHttpState,
+    key: Option<&VirtualKeyConfig>,
+    requested: Option<Vec<String>>,
+) -> Result<Vec<String>, String> {
+    let allows_server = |server_id: &str| key.is_none_or(|key| key.mcp.allows_server(server_id));
+    let Some(requested) = requested else {
+        let mut all: Vec<String> = state
+            .backends
+            .mcp_servers
+            .keys()
+            .filter(|server_id| allows_server(server_id))
+            .cloned()
+            .collect();
+        all.sort();
+        return Ok(all);
+    };
+    match requested.iter().find(|server_id| {
+        state.backends.mcp_servers.contains_key(*server_id) && !allows_server(server_id)
+    }) {
+        Some(server_id) => Err(format!(
+            "mcp server not allowed for this virtual key: {server_id}"
+        )),
+        None => Ok(requested),
+    }
+}
+
+/// Counts one tool call against the key's `mcp.calls_per_minute`. With the
+/// redis store the count is shared by every replica, like `limits.rpm`.
+pub(super) async fn consume_mcp_call_rate_limit(
+    state: &GatewayHttpState,
+    key: &VirtualKeyConfig,
+) -> Result<(), GatewayError> {
+    let Some(calls_per_minute) = key.mcp.calls_per_minute else {
+        return Ok(());
+    };
+    let limits = LimitsConfig {
+        rpm: Some(calls_per_minute),
+        tpm: None,
+    };
+    let scope = crate::gateway::mcp_scope_key(&key.id);
+    let now_epoch_seconds = SystemTime::now()
+        .duration_since(UNIX_EPOCH)
+        .map(|duration| duration.as_secs())
+        .unwrap_or(0);
+    #[cfg(feature = "gateway-store-redis")]
+    if let Some(store) = state.stores.redis.as_ref() {
+        return store
+            .check_and_consume_rate_limits_many(
+                [(scope.as_str(), &limits)],
+                "/mcp/tools/call",
+                0,
+                now_epoch_seconds,
+            )
+            .await
+            .map(|_| ());
+    }
+    state
+        .check_and_consume_rate_limits([(scope.as_str(), &limits)], 0, now_epoch_seconds / 60)
+        .map(|_| ())
+}
+
+pub(super) fn log_mcp_tool_call(
+    state: &GatewayHttpState,
+    key: Option<&VirtualKeyConfig>,
+    server_id: &str,
+    tool: &str,
+    outcome: &str,
+    duration_ms: Option<u128>,
+    error: Option<&GatewayError>,
+) {
+    emit_json_log(
+        state,
+        "mcp.tool_call",
+        serde_json::json!({
+            "virtual_key_id": key.map(|key| key.id.as_str()),
+            "server_id": server_id,
+            "tool": tool,
+            "outcome": outcome,
+            "duration_ms": duration_ms,
+            "error": error.map(|err| err.to_string()),
+        }),
+    );
+}
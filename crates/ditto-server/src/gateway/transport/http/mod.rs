@@ -3,14 +3,34 @@ mod a2a;
 mod admin;
 mod admin_auth;
 mod admin_persistence;
+mod admin_prompts;
+mod admin_spend;
+mod alerts;
 mod anthropic;
+mod backend_secret_refresh;
+mod client_access;
+mod compression;
+mod config_canary;
 mod config_versions;
+mod context_window;
 mod control_plane;
+mod cors;
+mod debug;
+mod embeddings_batching;
+mod fine_tuning;
 mod google_genai;
+mod guardrail_hooks;
+mod health;
+mod history_compression;
+mod key_self_service;
 mod litellm_keys;
 mod mcp;
+mod mcp_access;
+mod moderation;
+mod observability_callbacks;
 mod openai_compat_proxy_cost_budget;
 mod openai_compat_proxy_costing;
+mod openai_compat_proxy_deadline;
 mod openai_compat_proxy_handler;
 mod openai_compat_proxy_mcp;
 mod openai_compat_proxy_path_normalize;
@@ -21,32 +41,88 @@ mod openai_compat_proxy_rate_limit;
 mod openai_compat_proxy_request_dedup;
 mod openai_compat_proxy_request_schema;
 mod openai_compat_proxy_streaming_multipart;
+mod openai_model_info;
 mod openai_models;
+mod openapi;
+mod passthrough_routes;
+mod prompt_injection;
+mod prompt_templates;
 mod proxy_backend;
 mod proxy_budget_reservations;
 mod proxy_gateway_context;
 mod proxy_map_openai_gateway_error;
+mod proxy_sse_keepalive;
+mod proxy_sse_scanner;
+mod request_body_limit;
 mod request_extractors;
+mod route_experiments;
 mod router;
+mod sessions;
+mod shadow_traffic;
+mod tier_admission;
+mod token_counter;
 mod translation_backend;
+mod upstream_overload;
+mod usage_export;
+#[cfg(feature = "gateway-wasm-plugins")]
+mod wasm_plugins;
 pub use self::a2a::A2aAgentState;
 use self::admin::{error_response, map_gateway_error};
-use self::admin_auth::{ensure_admin_read, ensure_admin_secret_access, ensure_admin_write};
+use self::admin_auth::{
+    AdminContext, ensure_admin_read, ensure_admin_secret_access, ensure_admin_write,
+};
+use self::alerts::{
+    GatewayAlerts, ensure_spend_not_throttled, evaluate_alerts, record_alert_sample,
+    start_gateway_alerts,
+};
+use self::backend_secret_refresh::{
+    BackendSecretRefresh, refresh_backend_secrets, start_backend_secret_refresh,
+};
+use self::client_access::{ensure_virtual_key_client_access, forward_client_access_context};
+use self::config_canary::{
+    get_config_canary, promote_config_canary, rollback_config_canary, start_config_canary,
+};
 use self::config_versions::{
     ConfigVersionHistory, ConfigVersionInfo, diff_config_versions, export_config,
     get_config_version, get_config_version_by_id, list_config_versions, rollback_config_version,
     upsert_config_router, validate_config_payload,
 };
+use self::context_window::{ContextWindowVerdict, enforce_context_window};
 use self::control_plane::GatewayControlPlaneSnapshot;
+use self::debug::InFlightRequests;
+use self::embeddings_batching::{send_embeddings_batches, split_embeddings_request};
+use self::fine_tuning::{FineTuningJobRoute, maybe_handle_fine_tuning_jobs};
+use self::guardrail_hooks::{
+    GuardrailHookContext, apply_response_guardrail_hooks, log_guardrail_hook_matches,
+};
+use self::history_compression::{HistoryCompressionVerdict, compress_history};
 pub use self::mcp::McpServerState;
 use self::mcp::{mcp_call_tool, mcp_list_tools};
+use self::mcp_access::{
+    consume_mcp_call_rate_limit, log_mcp_tool_call, mcp_servers_for_key, mcp_tool_loop_key,
+    mcp_virtual_key,
+};
+use self::moderation::{ModerationVerdict, moderate_text, moderation_block_reason};
+use self::observability_callbacks::{
+    CallbackTraceRequest, ObservabilityCallbacks, begin_callback_trace, record_callback_trace,
+};
 #[cfg(feature = "gateway-costing")]
 use self::openai_compat_proxy_cost_budget::{
     CostBudgetEndpointPolicy, cost_budget_endpoint_policy,
 };
 #[cfg(feature = "gateway-costing")]
-use self::openai_compat_proxy_costing::estimate_charge_cost_usd_micros;
-use self::openai_compat_proxy_handler::handle_openai_compat_proxy;
+use self::openai_compat_proxy_costing::{
+    estimate_charge_cost_usd_micros, estimate_media_cost_usd_micros, insert_cost_header,
+};
+use self::openai_compat_proxy_deadline::{
+    ProxyRequestDeadline, cap_timeout, insert_request_timeout, request_deadline_exceeded_error,
+    send_with_first_token_timeout,
+};
+#[cfg(feature = "gateway-translation")]
+use self::openai_compat_proxy_deadline::{
+    open_translation_stream, run_with_timeout, translation_timeout_error,
+};
+use self::openai_compat_proxy_handler::{ForwardedProxyRequest, handle_openai_compat_proxy};
 use self::openai_compat_proxy_mcp::maybe_handle_mcp_tools_chat_completions;
 use self::openai_compat_proxy_path_normalize::normalize_openai_compat_path_and_query;
 #[allow(unused_imports)]
@@ -58,6 +134,9 @@ use self::openai_compat_proxy_proxy_cache_hit::maybe_handle_proxy_cache_hit;
 use self::openai_compat_proxy_proxy_failure::{
     ProxyFailureContext, finalize_openai_compat_proxy_failure,
 };
+use self::openai_compat_proxy_rate_limit::{
+    RateLimitHeadersSlot, record_rate_limit_outcome, record_upstream_overload,
+};
 #[cfg(feature = "gateway-store-redis")]
 use self::openai_compat_proxy_rate_limit::{normalize_rate_limit_route, redis_rate_limit_scopes};
 use self::openai_compat_proxy_request_dedup::{
@@ -71,6 +150,10 @@ use self::openai_compat_proxy_request_schema::{
 use self::openai_compat_proxy_streaming_multipart::{
     handle_openai_compat_proxy_streaming_multipart, should_stream_large_multipart_request,
 };
+pub use self::openapi::openapi_document;
+use self::passthrough_routes::{is_passthrough_route_request, select_proxy_backends};
+use self::prompt_injection::{PromptInjectionVerdict, score_prompt_injection_request};
+use self::prompt_templates::render_prompt_request;
 use self::proxy_backend::attempt_proxy_backend;
 #[cfg(any(
     feature = "gateway-store-sqlite",
@@ -100,14 +183,40 @@ use self::proxy_gateway_context::{
     ResolveOpenAiCompatProxyGatewayContextRequest, ResolvedGatewayContext,
     resolve_openai_compat_proxy_gateway_context,
 };
-use self::proxy_map_openai_gateway_error::map_openai_gateway_error;
+use self::proxy_map_openai_gateway_error::{
+    guardrail_error, guardrail_error_code, map_openai_gateway_error,
+};
+use self::proxy_sse_keepalive::{DEFAULT_SSE_KEEPALIVE_INTERVAL, with_sse_keepalive};
+use self::proxy_sse_scanner::SseEventScanner;
+use self::request_body_limit::read_request_body_limited;
 use self::request_extractors::{
     extract_bearer, extract_header, extract_litellm_api_key, extract_query_param,
     extract_virtual_key,
 };
+use self::route_experiments::{
+    ExperimentObservation, experiment_route_seed, record_experiment_response,
+};
 pub use self::router::router;
+use self::sessions::maybe_handle_session_chat_completions;
+use self::shadow_traffic::{ShadowRequest, mirror_shadow_request};
+use self::tier_admission::{
+    TierAdmissionControl, admit_tiered_request, apply_tier_admission_headers,
+    take_reserved_proxy_permits, validate_key_tier,
+};
 #[cfg(feature = "gateway-translation")]
 use self::translation_backend::attempt_translation_backend;
+use self::upstream_overload::{
+    is_overload_status, is_upstream_overload, upstream_retry_after_seconds,
+};
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+use self::usage_export::{export_usage_day, start_usage_export};
+#[cfg(feature = "gateway-wasm-plugins")]
+use self::wasm_plugins::{apply_wasm_request_plugins, apply_wasm_response_plugins};
 use http_kit::read_reqwest_body_bytes_limited;
 #[cfg(feature = "gateway-proxy-cache")]
 use omne_integrity_primitives::Sha256Hasher;
@@ -138,7 +247,7 @@ use serde::{Deserialize, Serialize};
 use serde_json::Value;
 use tokio::sync::{Mutex, OwnedSemaphorePermit, Semaphore};
 
-#[cfg(feature = "gateway-routing-advanced")]
+use ditto_core::config::Env;
 use ditto_core::utils::task::AbortOnDrop;
 
 #[cfg(feature = "gateway-translation")]
@@ -181,6 +290,9 @@ use super::{RedisStore, RedisStoreError};
 #[cfg(feature = "gateway-tokenizer")]
 use super::token_count;
 
+#[cfg(feature = "gateway-wasm-plugins")]
+use crate::gateway::WasmPlugin;
+
 #[cfg(any(
     feature = "gateway-store-sqlite",
     feature = "gateway-store-postgres",
@@ -217,12 +329,17 @@ use super::responses_shim;
 #[cfg(feature = "gateway-translation")]
 use super::translation;
 use super::{
-    BudgetConfig, Gateway, GatewayError, GatewayPreparedRequest, GatewayRequest, GatewayResponse,
-    GatewayStateFile, LimitsConfig, ObservabilitySnapshot, ProxyBackend, RouterConfig,
+    BackendConfig, BudgetConfig, Gateway, GatewayError, GatewayPreparedRequest, GatewayRequest,
+    GatewayResponse, GatewayStateFile, GuardrailsConfig, LimitsConfig, ObservabilitySnapshot,
+    PromptRegistry, PromptTemplate, ProxyBackend, RouterConfig, StreamTransformFactory,
     VirtualKeyConfig, lock_unpoisoned,
 };
-use crate::gateway::ProxyRequestIdempotencyStore;
-use crate::gateway::adapters::store::LocalProxyRequestIdempotencyStore;
+use crate::gateway::adapters::store::{
+    LocalFineTuningJobStore, LocalProxyRequestIdempotencyStore, LocalSessionStore,
+};
+use crate::gateway::{
+    FineTuningJobStore, ProxyRequestIdempotencyStore, SessionConfig, SessionStore,
+};
 
 static REQUEST_ID_SEQ: AtomicU64 = AtomicU64::new(0);
 
@@ -240,6 +357,7 @@ struct GatewayAdminState {
     admin_tenant_tokens: Vec<AdminTenantToken>,
     state_file: Option<PathBuf>,
     json_logs: bool,
+    readiness_model_groups: Vec<String>,
     #[cfg(feature = "sdk")]
     devtools: Option<DevtoolsLogger>,
 }
@@ -287,8 +405,11 @@ struct GatewayProxyRuntimeState {
     cache_config: Option<ProxyCacheConfig>,
     max_body_bytes: usize,
     usage_max_body_bytes: usize,
+    sse_keepalive_interval: Option<std::time::Duration>,
     backpressure: Option<Arc<Semaphore>>,
+    max_in_flight: Option<usize>,
     backend_backpressure: Arc<HashMap<String, Arc<Semaphore>>>,
+    tier_admission: Option<Arc<TierAdmissionControl>>,
     #[cfg(feature = "gateway-metrics-prometheus")]
     metrics: Option<Arc<Mutex<PrometheusMetrics>>>,
     #[cfg(feature = "gateway-routing-advanced")]
@@ -297,11 +418,36 @@ struct GatewayProxyRuntimeState {
     backend_health: Option<Arc<Mutex<HashMap<String, BackendHealth>>>>,
     #[cfg(feature = "gateway-routing-advanced")]
     health_check_task: Option<Arc<AbortOnDrop>>,
+    secret_refresh: Option<BackendSecretRefresh>,
+    secret_refresh_task: Option<Arc<AbortOnDrop>>,
     request_dedup: Arc<LocalProxyRequestIdempotencyStore>,
+    sessions: Option<SessionConfig>,
+    local_sessions: Arc<LocalSessionStore>,
+    local_fine_tuning_jobs: Arc<LocalFineTuningJobStore>,
+    trust_forwarded_for: bool,
+    stream_transforms: Arc<HashMap<String, StreamTransformFactory>>,
+    #[cfg(feature = "gateway-wasm-plugins")]
+    wasm_plugins: Arc<Vec<WasmPlugin>>,
+    callbacks: Arc<ObservabilityCallbacks>,
+    alerts: Option<Arc<GatewayAlerts>>,
+    alerts_task: Option<Arc<AbortOnDrop>>,
+    in_flight: Option<Arc<InFlightRequests>>,
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    usage_export_task: Option<Arc<AbortOnDrop>>,
 }
 
 impl GatewayProxyRuntimeState {
-    fn new(backend_backpressure: HashMap<String, Arc<Semaphore>>) -> Self {
+    fn new(
+        backend_backpressure: HashMap<String, Arc<Semaphore>>,
+        tier_admission: Option<Arc<TierAdmissionControl>>,
+        callbacks: ObservabilityCallbacks,
+        alerts: Option<Arc<GatewayAlerts>>,
+    ) -> Self {
         Self {
             #[cfg(feature = "gateway-costing")]
             pricing: None,
@@ -311,8 +457,11 @@ impl GatewayProxyRuntimeState {
             cache_config: None,
             max_body_bytes: 64 * 1024 * 1024,
             usage_max_body_bytes: 1024 * 1024,
+            sse_keepalive_interval: Some(DEFAULT_SSE_KEEPALIVE_INTERVAL),
             backpressure: None,
+            max_in_flight: None,
             backend_backpressure: Arc::new(backend_backpressure),
+            tier_admission,
             #[cfg(feature = "gateway-metrics-prometheus")]
             metrics: None,
             #[cfg(feature = "gateway-routing-advanced")]
@@ -321,7 +470,27 @@ impl GatewayProxyRuntimeState {
             backend_health: None,
             #[cfg(feature = "gateway-routing-advanced")]
             health_check_task: None,
+            secret_refresh: None,
+            secret_refresh_task: None,
             request_dedup: Arc::new(LocalProxyRequestIdempotencyStore::default()),
+            sessions: None,
+            local_sessions: Arc::new(LocalSessionStore::default()),
+            local_fine_tuning_jobs: Arc::new(LocalFineTuningJobStore::default()),
+            trust_forwarded_for: false,
+            stream_transforms: Arc::new(HashMap::new()),
+            #[cfg(feature = "gateway-wasm-plugins")]
+            wasm_plugins: Arc::new(Vec::new()),
+            callbacks: Arc::new(callbacks),
+            alerts,
+            alerts_task: None,
+            in_flight: None,
+            #[cfg(any(
+                feature = "gateway-store-sqlite",
+                feature = "gateway-store-postgres",
+                feature = "gateway-store-mysql",
+                feature = "gateway-store-redis"
+            ))]
+            usage_export_task: None,
         }
     }
 }
@@ -335,6 +504,7 @@ pub struct GatewayHttpState {
     budget: Arc<StdMutex<BudgetTracker>>,
     observability: Arc<StdMutex<Observability>>,
     config_versions: Arc<Mutex<ConfigVersionHistory>>,
+    prompts: Arc<RwLock<PromptRegistry>>,
     #[allow(dead_code)]
     redactor: Arc<GatewayRedactor>,
     observability_policy: Arc<GatewayObservabilityPolicy>,
@@ -385,12 +555,18 @@ impl GatewayHttpState {
                 initial_virtual_keys,
                 initial_router,
             ))),
+            prompts: Arc::new(RwLock::new(PromptRegistry::default())),
             redactor,
             observability_policy,
             backends: runtime_backends,
             admin: GatewayAdminState::default(),
             stores: GatewayPersistenceState::default(),
-            proxy: GatewayProxyRuntimeState::new(proxy_backend_backpressure),
+            proxy: GatewayProxyRuntimeState::new(
+                proxy_backend_backpressure,
+                TierAdmissionControl::from_config(&initial_config),
+                ObservabilityCallbacks::from_config(&initial_config.observability.callbacks),
+                GatewayAlerts::from_config(&initial_config.observability.alerts),
+            ),
         }
     }
 
@@ -427,6 +603,13 @@ impl GatewayHttpState {
         lock_unpoisoned(&self.observability).snapshot()
     }
 
+    /// Ships records still queued in background workers (observability
+    /// callback traces). Call once the server has drained in-flight requests;
+    /// records enqueued afterwards are not waited for.
+    pub async fn flush_pending_records(&self) {
+        self.proxy.callbacks.flush().await;
+    }
+
     pub(crate) fn prepare_observability_event(
         &self,
         sink: GatewayObservabilitySink,
@@ -445,7 +628,7 @@ impl GatewayHttpState {
         scopes: I,
         tokens: u32,
         minute: u64,
-    ) -> Result<(), GatewayError>
+    ) -> Result<crate::gateway::RateLimitStatus, GatewayError>
     where
         I: IntoIterator<Item = (&'a str, &'a super::LimitsConfig)>,
     {
@@ -459,6 +642,23 @@ impl GatewayHttpState {
         lock_unpoisoned(&self.limits).refund_many(scopes, tokens, minute);
     }
 
+    pub(crate) fn rate_limit_remaining(
+        &self,
+        scope: &str,
+        limits: &super::LimitsConfig,
+        minute: u64,
+    ) -> crate::gateway::RateLimitStatus {
+        lock_unpoisoned(&self.limits).remaining(scope, limits, minute)
+    }
+
+    pub(crate) fn budget_usage(
+        &self,
+        scope: &str,
+        budget: &super::BudgetConfig,
+    ) -> crate::gateway::BudgetUsage {
+        lock_unpoisoned(&self.budget).usage(scope, budget)
+    }
+
     pub(crate) fn reserve_budget_tokens<'a, I>(
         &self,
         scopes: I,
@@ -603,6 +803,33 @@ impl GatewayHttpState {
         self
     }
 
+    /// Interval between `: ping` comments sent on SSE responses while waiting
+    /// for the first upstream chunk (default 15s); `0` disables them.
+    pub fn with_proxy_sse_keepalive_seconds(mut self, seconds: u64) -> Self {
+        self.proxy.sse_keepalive_interval =
+            (seconds > 0).then(|| std::time::Duration::from_secs(seconds));
+        self
+    }
+
+    /// Registers a transform that keys enable with a `custom` stream
+    /// transform of the same name; `factory` runs once per streaming response.
+    pub fn with_stream_transform(
+        mut self,
+        name: impl Into<String>,
+        factory: StreamTransformFactory,
+    ) -> Self {
+        Arc::make_mut(&mut self.proxy.stream_transforms).insert(name.into(), factory);
+        self
+    }
+
+    /// Policy plugins run, in order, before each proxied request is
+    /// authenticated and after each buffered JSON response.
+    #[cfg(feature = "gateway-wasm-plugins")]
+    pub fn with_wasm_plugins(mut self, plugins: Vec<WasmPlugin>) -> Self {
+        self.proxy.wasm_plugins = Arc::new(plugins);
+        self
+    }
+
     #[cfg(feature = "gateway-translation")]
     pub fn with_translation_backends(
         mut self,
@@ -618,6 +845,12 @@ impl GatewayHttpState {
         self
     }
 
+    /// Seeds the prompt registry, e.g. with the `prompts` of a state file.
+    pub fn with_prompt_templates(self, templates: Vec<PromptTemplate>) -> Self {
+        self.replace_prompt_registry(PromptRegistry::from_templates(templates));
+        self
+    }
+
     fn proxy_request_idempotency_store(&self) -> Arc<dyn ProxyRequestIdempotencyStore> {
         #[cfg(feature = "gateway-store-redis")]
         if let Some(store) = self.stores.redis.as_ref() {
@@ -639,6 +872,41 @@ impl GatewayHttpState {
         self.proxy.request_dedup.clone()
     }
 
+    /// Enables conversation sessions (`session_id` on `/v1/chat/completions`).
+    /// Sessions live in Redis or Postgres when one is attached, else in
+    /// process memory.
+    pub fn with_sessions(mut self, config: SessionConfig) -> Self {
+        self.proxy.sessions = Some(config);
+        self
+    }
+
+    fn session_store(&self) -> Arc<dyn SessionStore> {
+        #[cfg(feature = "gateway-store-redis")]
+        if let Some(store) = self.stores.redis.as_ref() {
+            return Arc::new(store.clone());
+        }
+        #[cfg(feature = "gateway-store-postgres")]
+        if let Some(store) = self.stores.postgres.as_ref() {
+            return Arc::new(store.clone());
+        }
+
+        self.proxy.local_sessions.clone()
+    }
+
+    /// Fine-tuning job ownership lives where sessions do.
+    fn fine_tuning_job_store(&self) -> Arc<dyn FineTuningJobStore> {
+        #[cfg(feature = "gateway-store-redis")]
+        if let Some(store) = self.stores.redis.as_ref() {
+            return Arc::new(store.clone());
+        }
+        #[cfg(feature = "gateway-store-postgres")]
+        if let Some(store) = self.stores.postgres.as_ref() {
+            return Arc::new(store.clone());
+        }
+
+        self.proxy.local_fine_tuning_jobs.clone()
+    }
+
     #[cfg(feature = "gateway-store-sqlite")]
     pub fn with_sqlite_store(mut self, store: SqliteStore) -> Self {
         self.stores.sqlite = Some(store);
@@ -674,6 +942,13 @@ impl GatewayHttpState {
         self
     }
 
+    /// Limits the model groups `/health/readiness` requires to have a healthy
+    /// backend. Empty (the default) requires every group in the router.
+    pub fn with_readiness_model_groups(mut self, groups: Vec<String>) -> Self {
+        self.admin.readiness_model_groups = groups;
+        self
+    }
+
     #[cfg(feature = "gateway-proxy-cache")]
     pub fn with_proxy_cache(mut self, config: ProxyCacheConfig) -> Self {
         self.proxy.cache = Some(Arc::new(Mutex::new(ProxyResponseCache::new(
@@ -683,8 +958,70 @@ impl GatewayHttpState {
         self
     }
 
+    /// Re-resolves `secret://...` in proxy backend `headers` / `query_params`
+    /// every `interval` (at least 1s) once the router starts, so rotated
+    /// provider keys apply without a restart. `backends` must hold expanded
+    /// `${ENV}` values with their secrets still unresolved; backends without
+    /// secret references are skipped.
+    pub fn with_backend_secret_refresh(
+        mut self,
+        backends: &[BackendConfig],
+        env: Env,
+        interval: std::time::Duration,
+    ) -> Self {
+        self.proxy.secret_refresh = BackendSecretRefresh::new(backends, env, interval);
+        self
+    }
+
+    /// Runs one backend secret refresh now. Backends that fail keep their
+    /// current credentials; the first failure is returned.
+    pub async fn refresh_backend_secrets(&self) -> Result<(), GatewayError> {
+        match self.proxy.secret_refresh.as_ref() {
+            Some(refresh) => refresh_backend_secrets(self, refresh).await,
+            None => Ok(()),
+        }
+    }
+
+    /// Evaluates `observability.alerts` rules once now, notifying targets of
+    /// rules that started or stopped firing. The router also does this every
+    /// `interval_seconds` once started.
+    pub async fn evaluate_alerts(&self) {
+        if let Some(alerts) = self.proxy.alerts.as_ref() {
+            evaluate_alerts(self, alerts).await;
+        }
+    }
+
+    /// Exports the UTC day containing `ts_ms` to `observability.usage_export`
+    /// now, e.g. to backfill a day the scheduled export missed, and returns
+    /// where it was written.
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    pub async fn export_usage(&self, ts_ms: u64) -> Result<String, GatewayError> {
+        let config = self
+            .gateway
+            .config_snapshot()
+            .observability
+            .usage_export
+            .ok_or_else(|| GatewayError::InvalidRequest {
+                reason: "observability.usage_export is not configured".to_string(),
+            })?;
+        export_usage_day(self, &config, ts_ms / 86_400_000)
+            .await
+            .map_err(|reason| GatewayError::InvalidRequest { reason })
+    }
+
+    pub fn with_trusted_forwarded_for(mut self) -> Self {
+        self.proxy.trust_forwarded_for = true;
+        self
+    }
+
     pub fn with_proxy_max_in_flight(mut self, max_in_flight: usize) -> Self {
         self.proxy.backpressure = Some(Arc::new(Semaphore::new(max_in_flight.max(1))));
+        self.proxy.max_in_flight = Some(max_in_flight.max(1));
         self
     }
 
@@ -736,7 +1073,7 @@ struct HealthResponse {
     status: &'static str,
 }
 
-#[derive(Debug, Serialize)]
+#[derive(Clone, Debug, Serialize)]
 struct ReadinessCheck {
     name: String,
     status: &'static str,
@@ -744,6 +1081,23 @@ struct ReadinessCheck {
     detail: Option<String>,
 }
 
+impl ReadinessCheck {
+    fn from_result<E: std::fmt::Display>(name: &str, result: Result<(), E>) -> Self {
+        match result {
+            Ok(()) => Self {
+                name: name.to_string(),
+                status: "ok",
+                detail: None,
+            },
+            Err(err) => Self {
+                name: name.to_string(),
+                status: "error",
+                detail: Some(err.to_string()),
+            },
+        }
+    }
+}
+
 #[derive(Debug, Serialize)]
 struct ReadinessResponse {
     status: &'static str,
@@ -757,86 +1111,10 @@ async fn health() -> Json<HealthResponse> {
 }
 
 async fn ready(State(state): State<GatewayHttpState>) -> (StatusCode, Json<ReadinessResponse>) {
-    #[cfg(any(
-        feature = "gateway-store-sqlite",
-        feature = "gateway-store-postgres",
-        feature = "gateway-store-mysql",
-        feature = "gateway-store-redis",
-        feature = "gateway-routing-advanced"
-    ))]
-    let mut checks: Vec<ReadinessCheck> = Vec::new();
-    #[cfg(not(any(
-        feature = "gateway-store-sqlite",
-        feature = "gateway-store-postgres",
-        feature = "gateway-store-mysql",
-        feature = "gateway-store-redis",
-        feature = "gateway-routing-advanced"
-    )))]
-    let checks: Vec<ReadinessCheck> = Vec::new();
-
-    #[cfg(feature = "gateway-store-sqlite")]
-    if let Some(store) = state.stores.sqlite.as_ref() {
-        match store.ping().await {
-            Ok(()) => checks.push(ReadinessCheck {
-                name: "store.sqlite".to_string(),
-                status: "ok",
-                detail: None,
-            }),
-            Err(err) => checks.push(ReadinessCheck {
-                name: "store.sqlite".to_string(),
-                status: "error",
-                detail: Some(err.to_string()),
-            }),
-        }
-    }
-
-    #[cfg(feature = "gateway-store-postgres")]
-    if let Some(store) = state.stores.postgres.as_ref() {
-        match store.ping().await {
-            Ok(()) => checks.push(ReadinessCheck {
-                name: "store.postgres".to_string(),
-                status: "ok",
-                detail: None,
-            }),
-            Err(err) => checks.push(ReadinessCheck {
-                name: "store.postgres".to_string(),
-                status: "error",
-                detail: Some(err.to_string()),
-            }),
-        }
-    }
-
-    #[cfg(feature = "gateway-store-mysql")]
-    if let Some(store) = state.stores.mysql.as_ref() {
-        match store.ping().await {
-            Ok(()) => checks.push(ReadinessCheck {
-                name: "store.mysql".to_string(),
-                status: "ok",
-                detail: None,
-            }),
-            Err(err) => checks.push(ReadinessCheck {
-                name: "store.mysql".to_string(),
-                status: "error",
-                detail: Some(err.to_string()),
-            }),
-        }
-    }
-
-    #[cfg(feature = "gateway-store-redis")]
-    if let Some(store) = state.stores.redis.as_ref() {
-        match store.ping().await {
-            Ok(()) => checks.push(ReadinessCheck {
-                name: "store.redis".to_string(),
-                status: "ok",
-                detail: None,
-            }),
-            Err(err) => checks.push(ReadinessCheck {
-                name: "store.redis".to_string(),
-                status: "error",
-                detail: Some(err.to_string()),
-            }),
-        }
-    }
+    #[cfg(feature = "gateway-routing-advanced")]
+    let mut checks = store_readiness_checks(&state).await;
+    #[cfg(not(feature = "gateway-routing-advanced"))]
+    let checks = store_readiness_checks(&state).await;
 
     #[cfg(feature = "gateway-routing-advanced")]
     if let Some(config) = state.proxy.routing.as_ref()
@@ -895,15 +1173,6 @@ async fn ready(State(state): State<GatewayHttpState>) -> (StatusCode, Json<Readi
         }
     }
 
-    #[cfg(not(any(
-        feature = "gateway-store-sqlite",
-        feature = "gateway-store-postgres",
-        feature = "gateway-store-mysql",
-        feature = "gateway-store-redis",
-        feature = "gateway-routing-advanced"
-    )))]
-    let _ = &state;
-
     let is_ready = checks.iter().all(|check| check.status == "ok");
     let status = if is_ready {
         StatusCode::OK
@@ -918,6 +1187,66 @@ async fn ready(State(state): State<GatewayHttpState>) -> (StatusCode, Json<Readi
     (status, Json(body))
 }
 
+/// Pings every configured store; shared by `/ready` and `/health/readiness`.
+async fn store_readiness_checks(state: &GatewayHttpState) -> Vec<ReadinessCheck> {
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    let mut checks: Vec<ReadinessCheck> = Vec::new();
+    #[cfg(not(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    )))]
+    let checks: Vec<ReadinessCheck> = Vec::new();
+
+    #[cfg(feature = "gateway-store-sqlite")]
+    if let Some(store) = state.stores.sqlite.as_ref() {
+        checks.push(ReadinessCheck::from_result(
+            "store.sqlite",
+            store.ping().await,
+        ));
+    }
+
+    #[cfg(feature = "gateway-store-postgres")]
+    if let Some(store) = state.stores.postgres.as_ref() {
+        checks.push(ReadinessCheck::from_result(
+            "store.postgres",
+            store.ping().await,
+        ));
+    }
+
+    #[cfg(feature = "gateway-store-mysql")]
+    if let Some(store) = state.stores.mysql.as_ref() {
+        checks.push(ReadinessCheck::from_result(
+            "store.mysql",
+            store.ping().await,
+        ));
+    }
+
+    #[cfg(feature = "gateway-store-redis")]
+    if let Some(store) = state.stores.redis.as_ref() {
+        checks.push(ReadinessCheck::from_result(
+            "store.redis",
+            store.ping().await,
+        ));
+    }
+
+    #[cfg(not(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    )))]
+    let _ = state;
+
+    checks
+}
+
 async fn metrics(State(state): State<GatewayHttpState>) -> Json<ObservabilitySnapshot> {
     Json(state.observability_snapshot())
 }
@@ -1138,6 +1467,7 @@ async fn handle_gateway(
                 "model": &model,
                 "tokens": tokens,
                 "ok": result.is_ok(),
+                "tags": request_tags(&headers, None),
             }),
         )
         .await
@@ -1171,12 +1501,16 @@ async fn handle_gateway(
     }
 }
 
+/// OpenAI's error object. `code` and `param` are always present (as `null`
+/// when unset) so SDKs that read them keep working.
 #[derive(Clone, Debug, Serialize, Deserialize)]
 pub(super) struct OpenAiErrorDetail {
     message: String,
     #[serde(rename = "type")]
     kind: String,
-    #[serde(skip_serializing_if = "Option::is_none")]
+    #[serde(default)]
+    param: Option<String>,
+    #[serde(default)]
     code: Option<String>,
 }
 
@@ -1197,6 +1531,7 @@ fn openai_error(
             error: OpenAiErrorDetail {
                 message: message.to_string(),
                 kind: kind.into(),
+                param: None,
                 code: code.map(ToString::to_string),
             },
         }),
@@ -1354,16 +1689,49 @@ struct ObservedUsage {
     total_tokens: Option<u64>,
 }
 
+impl ObservedUsage {
+    /// Keeps earlier values for fields a later stream event leaves out:
+    /// Anthropic reports input usage on `message_start` and output usage on
+    /// `message_delta`.
+    fn or_earlier(self, earlier: Self) -> Self {
+        let input_tokens = self.input_tokens.or(earlier.input_tokens);
+        let output_tokens = self.output_tokens.or(earlier.output_tokens);
+        Self {
+            input_tokens,
+            cache_input_tokens: self.cache_input_tokens.or(earlier.cache_input_tokens),
+            cache_creation_input_tokens: self
+                .cache_creation_input_tokens
+                .or(earlier.cache_creation_input_tokens),
+            output_tokens,
+            reasoning_tokens: self.reasoning_tokens.or(earlier.reasoning_tokens),
+            total_tokens: self.total_tokens.or_else(|| {
+                input_tokens
+                    .and_then(|input| output_tokens.map(|output| input.saturating_add(output)))
+            }),
+        }
+    }
+}
+
 #[derive(serde::Deserialize)]
 struct OpenAiUsageEnvelope {
     usage: Option<OpenAiUsagePayload>,
+    /// Anthropic `message_start` stream events nest usage in the message.
+    #[serde(default)]
+    message: Option<AnthropicMessageUsageEnvelope>,
+}
+
+#[derive(serde::Deserialize)]
+struct AnthropicMessageUsageEnvelope {
+    usage: Option<OpenAiUsagePayload>,
 }
 
 #[derive(serde::Deserialize)]
 struct OpenAiUsagePayload {
     #[serde(default)]
     total_tokens: Option<u64>,
-    #[serde(default, alias = "prompt_tokens")]
+    #[serde(default)]
+    prompt_tokens: Option<u64>,
+    #[serde(default)]
     input_tokens: Option<u64>,
     #[serde(default, alias = "completion_tokens")]
     output_tokens: Option<u64>,
@@ -1377,6 +1745,8 @@ struct OpenAiUsagePayload {
     completion_tokens_details: Option<OpenAiOutputTokenDetails>,
     #[serde(default)]
     cache_creation_input_tokens: Option<u64>,
+    #[serde(default)]
+    cache_read_input_tokens: Option<u64>,
 }
 
 #[derive(serde::Deserialize)]
@@ -1398,11 +1768,25 @@ fn extract_openai_usage_from_bytes(bytes: &Bytes) -> Option<ObservedUsage> {
 }
 
 fn extract_openai_usage_from_slice(bytes: &[u8]) -> Option<ObservedUsage> {
-    let usage = serde_json::from_slice::<OpenAiUsageEnvelope>(bytes)
-        .ok()?
-        .usage?;
-
-    let input_tokens = usage.input_tokens;
+    let envelope = serde_json::from_slice::<OpenAiUsageEnvelope>(bytes).ok()?;
+    let usage = envelope
+        .usage
+        .or_else(|| envelope.message.and_then(|message| message.usage))?;
+
+    // Anthropic's `input_tokens` leaves out the cache reads and writes listed
+    // beside it; OpenAI-style prompt counts already include them.
+    let anthropic_style = usage.prompt_tokens.is_none()
+        && usage.input_tokens_details.is_none()
+        && (usage.cache_read_input_tokens.is_some() || usage.cache_creation_input_tokens.is_some());
+    let input_tokens = usage.prompt_tokens.or(usage.input_tokens).map(|input| {
+        if anthropic_style {
+            input
+                .saturating_add(usage.cache_read_input_tokens.unwrap_or(0))
+                .saturating_add(usage.cache_creation_input_tokens.unwrap_or(0))
+        } else {
+            input
+        }
+    });
     let output_tokens = usage.output_tokens;
     let reasoning_tokens = usage.reasoning_tokens.or_else(|| {
         usage
@@ -1422,7 +1806,8 @@ fn extract_openai_usage_from_slice(bytes: &[u8]) -> Option<ObservedUsage> {
     let cache_input_tokens = usage
         .input_tokens_details
         .as_ref()
-        .and_then(|details| details.cached_tokens);
+        .and_then(|details| details.cached_tokens)
+        .or(usage.cache_read_input_tokens);
     let cache_creation_input_tokens = usage.cache_creation_input_tokens.or_else(|| {
         usage
             .input_tokens_details
@@ -1440,6 +1825,63 @@ fn extract_openai_usage_from_slice(bytes: &[u8]) -> Option<ObservedUsage> {
     })
 }
 
+/// Counts the generated text bytes carried by one SSE event: chat/completions
+/// choice deltas, Responses `*.delta` events and Anthropic content block
+/// deltas. Used to estimate output tokens for streams that end before the
+/// upstream reports usage.
+fn extract_streamed_output_bytes_from_slice(bytes: &[u8]) -> usize {
+    fn str_len(value: Option<&serde_json::Value>) -> usize {
+        value
+            .and_then(|value| value.as_str())
+            .map(str::len)
+            .unwrap_or(0)
+    }
+
+    let Ok(event) = serde_json::from_slice::<serde_json::Value>(bytes) else {
+        return 0;
+    };
+
+    let mut total = 0usize;
+    if let Some(choices) = event.get("choices").and_then(|value| value.as_array()) {
+        for choice in choices {
+            total = total.saturating_add(str_len(choice.get("text")));
+            let Some(delta) = choice.get("delta") else {
+                continue;
+            };
+            for key in ["content", "reasoning_content", "refusal"] {
+                total = total.saturating_add(str_len(delta.get(key)));
+            }
+            if let Some(tool_calls) = delta.get("tool_calls").and_then(|value| value.as_array()) {
+                for tool_call in tool_calls {
+                    total = total.saturating_add(str_len(
+                        tool_call
+                            .get("function")
+                            .and_then(|function| function.get("arguments")),
+                    ));
+                }
+            }
+        }
+    }
+
+    match event.get("delta") {
+        Some(serde_json::Value::String(delta))
+            if event
+                .get("type")
+                .and_then(|value| value.as_str())
+                .is_some_and(|kind| kind.ends_with(".delta")) =>
+        {
+            total = total.saturating_add(delta.len());
+        }
+        Some(delta @ serde_json::Value::Object(_)) => {
+            for key in ["text", "partial_json", "thinking"] {
+                total = total.saturating_add(str_len(delta.get(key)));
+            }
+        }
+        _ => {}
+    }
+    total
+}
+
 fn sanitize_proxy_headers(headers: &mut HeaderMap, strip_authorization: bool) {
     if strip_authorization {
         headers.remove("authorization");
@@ -1448,6 +1890,9 @@ fn sanitize_proxy_headers(headers: &mut HeaderMap, strip_authorization: bool) {
     }
     headers.remove("proxy-authorization");
     headers.remove("x-forwarded-authorization");
+    // The upstream client negotiates its own encoding and decodes the body, so
+    // usage accounting, caching and guardrails always see plain bytes.
+    headers.remove("accept-encoding");
     headers.remove("connection");
     headers.remove("keep-alive");
     headers.remove("proxy-authenticate");
@@ -1460,6 +1905,8 @@ fn sanitize_proxy_headers(headers: &mut HeaderMap, strip_authorization: bool) {
     headers.remove("x-ditto-protocol");
     headers.remove("x-ditto-cache-bypass");
     headers.remove("x-ditto-bypass-cache");
+    headers.remove("x-ditto-tags");
+    headers.remove("x-ditto-region");
     headers.remove("content-length");
 }
 
@@ -1564,6 +2011,34 @@ fn enrich_audit_payload_virtual_key_scope(state: &GatewayHttpState, payload: &mu
     {
         fields.insert("user_id".to_string(), Value::String(user_id));
     }
+    if !key.tags.is_empty() {
+        // Request tags come first; the key's tags are appended to them.
+        let tags = fields
+            .entry("tags")
+            .or_insert_with(|| Value::Array(Vec::new()));
+        if let Some(tags) = tags.as_array_mut() {
+            for tag in key.tags {
+                if !tags.iter().any(|existing| existing.as_str() == Some(&tag)) {
+                    tags.push(Value::String(tag));
+                }
+            }
+        }
+    }
+}
+
+/// Cost attribution tags the client attached to the request (see
+/// [`parse_request_tags`](crate::gateway::domain::parse_request_tags)).
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+fn request_tags(headers: &HeaderMap, parsed_json: Option<&Value>) -> Vec<String> {
+    crate::gateway::domain::parse_request_tags(
+        extract_header(headers, crate::gateway::domain::REQUEST_TAGS_HEADER).as_deref(),
+        parsed_json,
+    )
 }
 
 fn ensure_virtual_key_tokens_exportable(
@@ -1678,13 +2153,20 @@ type ProxyBodyStream = BoxStream<'static, Result<Bytes, std::io::Error>>;
 struct ProxyPermits {
     _proxy: Option<OwnedSemaphorePermit>,
     _backend: Option<OwnedSemaphorePermit>,
+    /// Wakes requests queued by tier admission once the permits are dropped.
+    released: Option<Arc<tokio::sync::Notify>>,
 }
 
 impl ProxyPermits {
-    fn new(proxy: Option<OwnedSemaphorePermit>, backend: Option<OwnedSemaphorePermit>) -> Self {
+    fn new(
+        proxy: Option<OwnedSemaphorePermit>,
+        backend: Option<OwnedSemaphorePermit>,
+        released: Option<Arc<tokio::sync::Notify>>,
+    ) -> Self {
         Self {
             _proxy: proxy,
             _backend: backend,
+            released,
         }
     }
 
@@ -1696,6 +2178,20 @@ impl ProxyPermits {
         Self {
             _proxy: self._proxy.take(),
             _backend: self._backend.take(),
+            released: self.released.take(),
+        }
+    }
+}
+
+impl Drop for ProxyPermits {
+    fn drop(&mut self) {
+        if self.is_empty() {
+            return;
+        }
+        self._proxy.take();
+        self._backend.take();
+        if let Some(released) = self.released.take() {
+            released.notify_waiters();
         }
     }
 }
@@ -2115,7 +2611,10 @@ async fn proxy_response(
             }
         });
 
-        let mut response = axum::response::Response::new(Body::from_stream(stream));
+        let mut response = axum::response::Response::new(Body::from_stream(with_sse_keepalive(
+            stream,
+            _state.proxy.sse_keepalive_interval,
+        )));
         *response.status_mut() = status;
         *response.headers_mut() = headers;
         response
@@ -2365,7 +2864,10 @@ async fn responses_shim_response(
             }
         });
 
-        let mut response = axum::response::Response::new(Body::from_stream(stream));
+        let mut response = axum::response::Response::new(Body::from_stream(with_sse_keepalive(
+            stream,
+            _state.proxy.sse_keepalive_interval,
+        )));
         *response.status_mut() = status;
         *response.headers_mut() = headers;
         Ok(response)
@@ -2645,7 +3147,7 @@ fn start_proxy_health_checks(state: &GatewayHttpState) -> Option<Arc<AbortOnDrop
                     let path = path.clone();
                     async move {
                         let mut headers = HeaderMap::new();
-                        apply_backend_headers(&mut headers, backend.headers());
+                        apply_backend_headers(&mut headers, &backend.headers());
                         let result = backend
                             .request_with_timeout(
                                 reqwest::Method::GET,
@@ -2747,6 +3249,7 @@ fn proxy_cache_header_affects_upstream(header: &str) -> bool {
             | "x-ditto-protocol"
             | "x-ditto-cache-bypass"
             | "x-ditto-bypass-cache"
+            | "x-ditto-tags"
             | "content-length"
             | "x-request-id"
             | "traceparent"
@@ -2882,6 +3385,11 @@ mod sanitize_proxy_headers_tests {
             "x-ditto-protocol",
             axum::http::HeaderValue::from_static("google"),
         );
+        headers.insert(
+            "accept-encoding",
+            axum::http::HeaderValue::from_static("gzip, br"),
+        );
+        headers.insert("x-ditto-region", axum::http::HeaderValue::from_static("eu"));
         headers.insert("x-test", axum::http::HeaderValue::from_static("ok"));
 
         sanitize_proxy_headers(&mut headers, false);
@@ -2899,6 +3407,8 @@ mod sanitize_proxy_headers_tests {
             "upgrade",
             "content-length",
             "x-ditto-protocol",
+            "accept-encoding",
+            "x-ditto-region",
         ] {
             assert!(headers.get(name).is_none(), "{name} should be removed");
         }
@@ -2983,7 +3493,7 @@ mod proxy_cache_key_tests {
 // inlined from proxy/usage_parsing_tests.rs
 #[cfg(test)]
 mod usage_parsing_tests {
-    use super::extract_openai_usage_from_bytes;
+    use super::{extract_openai_usage_from_bytes, extract_streamed_output_bytes_from_slice};
     use bytes::Bytes;
     use serde_json::json;
 
@@ -3030,6 +3540,78 @@ mod usage_parsing_tests {
         assert_eq!(usage.output_tokens, Some(5));
         assert_eq!(usage.total_tokens, Some(9));
     }
+
+    #[test]
+    fn parses_anthropic_usage_with_cache_reads_and_writes() {
+        let response = json!({
+            "type": "message",
+            "usage": {
+                "input_tokens": 10,
+                "cache_read_input_tokens": 100,
+                "cache_creation_input_tokens": 20,
+                "output_tokens": 5
+            }
+        });
+
+        let bytes = Bytes::from(response.to_string());
+        let usage = extract_openai_usage_from_bytes(&bytes).expect("usage");
+        assert_eq!(usage.input_tokens, Some(130));
+        assert_eq!(usage.cache_input_tokens, Some(100));
+        assert_eq!(usage.cache_creation_input_tokens, Some(20));
+        assert_eq!(usage.total_tokens, Some(135));
+
+        let start = json!({
+            "type": "message_start",
+            "message": {"usage": {"input_tokens": 3, "cache_read_input_tokens": 7, "output_tokens": 1}}
+        });
+        let delta = json!({"type": "message_delta", "usage": {"output_tokens": 4}});
+        let start =
+            extract_openai_usage_from_bytes(&Bytes::from(start.to_string())).expect("start");
+        let delta =
+            extract_openai_usage_from_bytes(&Bytes::from(delta.to_string())).expect("delta");
+        let usage = delta.or_earlier(start);
+        assert_eq!(usage.input_tokens, Some(10));
+        assert_eq!(usage.cache_input_tokens, Some(7));
+        assert_eq!(usage.output_tokens, Some(4));
+        assert_eq!(usage.total_tokens, Some(14));
+    }
+
+    #[test]
+    fn counts_streamed_output_bytes_across_event_shapes() {
+        let chat = json!({
+            "choices": [{
+                "index": 0,
+                "delta": {
+                    "content": "hello",
+                    "tool_calls": [{"index": 0, "function": {"arguments": "{\"a\":1}"}}]
+                }
+            }]
+        });
+        let completions = json!({"choices": [{"index": 0, "text": "abc"}]});
+        let responses = json!({"type": "response.output_text.delta", "delta": "four"});
+        let anthropic = json!({
+            "type": "content_block_delta",
+            "index": 0,
+            "delta": {"type": "text_delta", "text": "hi"}
+        });
+        let usage_only = json!({"choices": [], "usage": {"total_tokens": 3}});
+
+        for (event, expected) in [
+            (chat, 12),
+            (completions, 3),
+            (responses, 4),
+            (anthropic, 2),
+            (usage_only, 0),
+        ] {
+            let bytes = event.to_string();
+            assert_eq!(
+                extract_streamed_output_bytes_from_slice(bytes.as_bytes()),
+                expected,
+                "{bytes}"
+            );
+        }
+        assert_eq!(extract_streamed_output_bytes_from_slice(b"not json"), 0);
+    }
 }
 // end inline: proxy/usage_parsing_tests.rs
 
@@ -3049,6 +3631,11 @@ mod audit_log_tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         }))
         .with_sqlite_store(SqliteStore::new(broken_path));
 
@@ -0,0 +1,128 @@
+use super::*;
+
+use axum::http::HeaderValue;
+
+use crate::gateway::domain::moderation::{ModerationConfig, ModerationViolation};
+
+/// Categories flagged by `annotate` moderation, carried to the response
+/// headers.
+#[derive(Clone, Debug, Default)]
+pub(super) struct ModerationVerdict {
+    pub(super) categories: Vec<String>,
+}
+
+impl ModerationVerdict {
+    pub(super) fn new(violations: &[ModerationViolation]) -> Self {
+        Self {
+            categories: violations
+                .iter()
+                .map(|violation| violation.category.clone())
+                .collect(),
+        }
+    }
+
+    pub(super) fn merge(mut self, other: Option<&ModerationVerdict>) -> Self {
+        if let Some(other) = other {
+            self.categories.extend(other.categories.iter().cloned());
+            self.categories.sort();
+            self.categories.dedup();
+        }
+        self
+    }
+
+    pub(super) fn insert_headers(&self, headers: &mut HeaderMap) {
+        if self.categories.is_empty() {
+            return;
+        }
+        headers.insert("x-ditto-moderation", HeaderValue::from_static("flagged"));
+        if let Ok(value) = HeaderValue::from_str(&self.categories.join(",")) {
+            headers.insert("x-ditto-moderation-categories", value);
+        }
+    }
+}
+
+/// The rejection reason for a `block` violation.
+pub(super) fn moderation_block_reason(violations: &[ModerationViolation]) -> String {
+    let categories = violations
+        .iter()
+        .map(|violation| violation.category.as_str())
+        .collect::<Vec<_>>();
+    format!("moderation:{}", categories.join(","))
+}
+
+/// Moderates `text` and records violations in the JSON log and, with a
+/// store, the audit log. A failing provider is logged and treated as a pass.
+pub(super) async fn moderate_text(
+    state: &GatewayHttpState,
+    config: &ModerationConfig,
+    request_id: &str,
+    virtual_key_id: Option<&str>,
+    target: &str,
+    text: &str,
+) -> Vec<ModerationViolation> {
+    let (violations, error) = match request_moderation(state, config, text).await {
+        Ok(response) => (config.violations(&response), None),
+        Err(err) => (Vec::new(), Some(err)),
+    };
+    if violations.is_empty() && error.is_none() {
+        return violations;
+    }
+
+    let payload = serde_json::json!({
+        "request_id": request_id,
+        "virtual_key_id": virtual_key_id,
+        "target": target,
+        "action": config.action.as_str(),
+        "violations": &violations,
+        "error": error,
+    });
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    if !violations.is_empty() {
+        let _ = append_audit_log(state, "proxy.moderation", payload.clone()).await;
+    }
+    emit_json_log(state, "proxy.moderation", payload);
+    violations
+}
+
+async fn request_moderation(
+    state: &GatewayHttpState,
+    config: &ModerationConfig,
+    text: &str,
+) -> Result<Value, String> {
+    let backend_name = config.backend.trim();
+    let backend = state
+        .backends
+        .proxy_backends
+        .get(backend_name)
+        .ok_or_else(|| format!("unknown moderation backend {backend_name}"))?;
+    let mut body = serde_json::json!({ "input": text });
+    if let Some(model) = config.model.as_deref() {
+        body["model"] = Value::String(model.trim().to_string());
+    }
+    let mut headers = HeaderMap::new();
+    apply_backend_headers(&mut headers, &backend.headers());
+    headers.insert("content-type", HeaderValue::from_static("application/json"));
+
+    let response = backend
+        .request_with_timeout(
+            reqwest::Method::POST,
+            "/v1/moderations",
+            headers,
+            Some(Bytes::from(body.to_string())),
+            config.timeout_ms.map(std::time::Duration::from_millis),
+        )
+        .await
+        .map_err(|err| err.to_string())?;
+    if !response.status().is_success() {
+        return Err(format!("moderation returned {}", response.status()));
+    }
+    let bytes = read_reqwest_body_bytes_limited(response, state.proxy.max_body_bytes)
+        .await
+        .map_err(|err| err.to_string())?;
+    serde_json::from_slice(&bytes).map_err(|err| err.to_string())
+}
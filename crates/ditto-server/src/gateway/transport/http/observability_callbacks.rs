@@ -0,0 +1,375 @@
+use super::*;
+
+use std::sync::OnceLock;
+use std::time::Duration;
+
+use tokio::sync::{mpsc, oneshot};
+use tokio::time::MissedTickBehavior;
+
+use crate::gateway::ObservabilityCallbackConfig;
+use crate::gateway::observability_callbacks::{
+    LlmTrace, encode_traces, should_sample_trace, trace_output_from_json, trace_output_from_sse,
+    trace_prompt,
+};
+
+type ProxyError = (StatusCode, Json<OpenAiErrorResponse>);
+
+const DEFAULT_CALLBACK_TIMEOUT_SECS: u64 = 10;
+
+/// The configured `observability.callbacks`, each with a bounded queue that
+/// is drained by its own background task.
+#[derive(Default)]
+pub(super) struct ObservabilityCallbacks {
+    sinks: Vec<Arc<CallbackSink>>,
+}
+
+struct CallbackSink {
+    config: ObservabilityCallbackConfig,
+    // Created on first use: the state can be built outside a Tokio runtime.
+    queue: OnceLock<mpsc::Sender<CallbackMessage>>,
+    dropped: AtomicU64,
+}
+
+enum CallbackMessage {
+    Trace(LlmTrace),
+    /// Ships the pending batch, then answers; queue order guarantees every
+    /// trace enqueued before it is included.
+    Flush(oneshot::Sender<()>),
+}
+
+impl ObservabilityCallbacks {
+    pub(super) fn from_config(configs: &[ObservabilityCallbackConfig]) -> Self {
+        Self {
+            sinks: configs
+                .iter()
+                .map(|config| {
+                    Arc::new(CallbackSink {
+                        config: config.clone(),
+                        queue: OnceLock::new(),
+                        dropped: AtomicU64::new(0),
+                    })
+                })
+                .collect(),
+        }
+    }
+
+    /// Waits until every trace queued so far has been shipped (or failed).
+    /// Used on shutdown, after the server has stopped taking requests.
+    pub(super) async fn flush(&self) {
+        for sink in &self.sinks {
+            let Some(queue) = sink.queue.get() else {
+                continue;
+            };
+            let (done, flushed) = oneshot::channel();
+            if queue.send(CallbackMessage::Flush(done)).await.is_ok() {
+                let _ = flushed.await;
+            }
+        }
+    }
+}
+
+impl CallbackSink {
+    fn enqueue(self: &Arc<Self>, state: &GatewayHttpState, trace: LlmTrace) {
+        let Ok(runtime) = tokio::runtime::Handle::try_current() else {
+            return;
+        };
+        let queue = self.queue.get_or_init(|| {
+            let (sender, receiver) = mpsc::channel(self.config.max_queue);
+            runtime.spawn(run_callback_worker(state.clone(), self.clone(), receiver));
+            sender
+        });
+        let request_id = trace.request_id.clone();
+        if queue.try_send(CallbackMessage::Trace(trace)).is_err() {
+            let dropped = self.dropped.fetch_add(1, Ordering::Relaxed) + 1;
+            emit_json_log(
+                state,
+                "proxy.callback_dropped",
+                serde_json::json!({
+                    "request_id": request_id,
+                    "callback": self.config.name,
+                    "dropped_total": dropped,
+                }),
+            );
+        }
+    }
+}
+
+async fn run_callback_worker(
+    state: GatewayHttpState,
+    sink: Arc<CallbackSink>,
+    mut queue: mpsc::Receiver<CallbackMessage>,
+) {
+    let timeout = Duration::from_secs(
+        sink.config
+            .timeout_seconds
+            .unwrap_or(DEFAULT_CALLBACK_TIMEOUT_SECS),
+    );
+    let client = reqwest::Client::builder()
+        .timeout(timeout)
+        .build()
+        .unwrap_or_default();
+    let batch_size = sink.config.batch_size;
+    let mut flush = tokio::time::interval(Duration::from_millis(sink.config.flush_interval_ms));
+    flush.set_missed_tick_behavior(MissedTickBehavior::Delay);
+    let mut batch = Vec::with_capacity(batch_size);
+    loop {
+        tokio::select! {
+            message = queue.recv() => match message {
+                Some(CallbackMessage::Trace(trace)) => {
+                    batch.push(trace);
+                    if batch.len() < batch_size {
+                        continue;
+                    }
+                }
+                Some(CallbackMessage::Flush(done)) => {
+                    if !batch.is_empty() {
+                        ship_callback_batch(&state, &sink, &client, std::mem::take(&mut batch))
+                            .await;
+                    }
+                    let _ = done.send(());
+                    continue;
+                }
+                None => break,
+            },
+            _ = flush.tick() => {
+                if batch.is_empty() {
+                    continue;
+                }
+            }
+        }
+        // Delivery is sequential; while a batch is in flight new traces
+        // wait in the queue and are dropped once it is full.
+        ship_callback_batch(&state, &sink, &client, std::mem::take(&mut batch)).await;
+    }
+    if !batch.is_empty() {
+        ship_callback_batch(&state, &sink, &client, batch).await;
+    }
+}
+
+async fn ship_callback_batch(
+    state: &GatewayHttpState,
+    sink: &CallbackSink,
+    client: &reqwest::Client,
+    batch: Vec<LlmTrace>,
+) {
+    for request in encode_traces(&sink.config.sink, &batch) {
+        let mut builder = client.post(&request.url).json(&request.body);
+        if let Some((username, password)) = request.basic_auth {
+            builder = builder.basic_auth(username, Some(password));
+        }
+        for (name, value) in request.headers {
+            builder = builder.header(name, value);
+        }
+        let error = match builder.send().await {
+            Ok(response) if response.status().is_success() => continue,
+            Ok(response) => format!("status {}", response.status().as_u16()),
+            Err(err) => err.to_string(),
+        };
+        emit_json_log(
+            state,
+            "proxy.callback_error",
+            serde_json::json!({
+                "callback": sink.config.name,
+                "type": sink.config.sink.kind(),
+                "traces": batch.len(),
+                "error": error,
+            }),
+        );
+    }
+}
+
+/// The request half of a trace, captured before the upstream call and
+/// completed once the response body has been sent.
+pub(super) struct CallbackTraceContext {
+    state: GatewayHttpState,
+    sinks: Vec<Arc<CallbackSink>>,
+    trace: LlmTrace,
+}
+
+pub(super) struct CallbackTraceRequest<'a> {
+    pub(super) state: &'a GatewayHttpState,
+    pub(super) request_id: &'a str,
+    pub(super) method: &'a axum::http::Method,
+    pub(super) path_and_query: &'a str,
+    pub(super) model: Option<&'a str>,
+    pub(super) virtual_key_id: Option<&'a str>,
+    pub(super) key_callbacks: &'a [String],
+    pub(super) headers: &'a HeaderMap,
+    pub(super) parsed_json: Option<&'a Value>,
+}
+
+/// Picks the callbacks that trace this request: every `global` callback
+/// plus the ones the key opted into, after per-callback sampling.
+pub(super) fn begin_callback_trace(
+    request: CallbackTraceRequest<'_>,
+) -> Option<CallbackTraceContext> {
+    let CallbackTraceRequest {
+        state,
+        request_id,
+        method,
+        path_and_query,
+        model,
+        virtual_key_id,
+        key_callbacks,
+        headers,
+        parsed_json,
+    } = request;
+    let sinks: Vec<_> = state
+        .proxy
+        .callbacks
+        .sinks
+        .iter()
+        .filter(|sink| {
+            sink.config.global
+                || key_callbacks
+                    .iter()
+                    .any(|name| name.trim() == sink.config.name.trim())
+        })
+        .filter(|sink| should_sample_trace(&sink.config.name, request_id, sink.config.sample_rate))
+        .cloned()
+        .collect();
+    if sinks.is_empty() {
+        return None;
+    }
+    let path = path_and_query
+        .split_once('?')
+        .map(|(path, _)| path)
+        .unwrap_or(path_and_query);
+    Some(CallbackTraceContext {
+        state: state.clone(),
+        sinks,
+        trace: LlmTrace {
+            request_id: request_id.to_string(),
+            virtual_key_id: virtual_key_id.map(str::to_string),
+            model: model.map(str::to_string),
+            method: method.to_string(),
+            path: path.to_string(),
+            start_ms: now_epoch_millis(),
+            prompt: parsed_json.map(trace_prompt).unwrap_or(Value::Null),
+            tags: crate::gateway::domain::parse_request_tags(
+                extract_header(headers, crate::gateway::REQUEST_TAGS_HEADER).as_deref(),
+                parsed_json,
+            ),
+            ..LlmTrace::default()
+        },
+    })
+}
+
+/// Completes the trace from the final proxy result. Successful bodies are
+/// teed as they stream to the client (up to the usage buffering limit), and
+/// the trace is queued when the body is dropped, so aborted streams are
+/// traced too.
+pub(super) fn record_callback_trace(
+    context: Option<CallbackTraceContext>,
+    response: Result<axum::response::Response, ProxyError>,
+) -> Result<axum::response::Response, ProxyError> {
+    let Some(mut context) = context else {
+        return response;
+    };
+    let response = match response {
+        Ok(response) => response,
+        Err((status, Json(err))) => {
+            context.trace.status = status.as_u16();
+            context.trace.error = Some(err.error.message.clone());
+            context.finish(&[]);
+            return Err((status, Json(err)));
+        }
+    };
+
+    let headers = response.headers();
+    context.trace.status = response.status().as_u16();
+    context.trace.backend = extract_header(headers, "x-ditto-backend");
+    context.trace.cost_usd =
+        extract_header(headers, "x-ditto-cost").and_then(|cost| cost.parse().ok());
+    context.trace.stream = headers
+        .get("content-type")
+        .and_then(|value| value.to_str().ok())
+        .is_some_and(|ct| ct.to_ascii_lowercase().starts_with("text/event-stream"));
+
+    let max_bytes = context.state.proxy.usage_max_body_bytes;
+    let (parts, body) = response.into_parts();
+    let mut tee = TracedBody {
+        context: Some(context),
+        buffer: Vec::new(),
+        max_bytes,
+    };
+    let stream = body.into_data_stream().map(move |chunk| {
+        if let Ok(bytes) = chunk.as_ref() {
+            tee.push(bytes);
+        }
+        chunk
+    });
+    Ok(axum::response::Response::from_parts(
+        parts,
+        Body::from_stream(stream),
+    ))
+}
+
+struct TracedBody {
+    context: Option<CallbackTraceContext>,
+    buffer: Vec<u8>,
+    max_bytes: usize,
+}
+
+impl TracedBody {
+    fn push(&mut self, bytes: &[u8]) {
+        let room = self.max_bytes.saturating_sub(self.buffer.len());
+        self.buffer
+            .extend_from_slice(&bytes[..bytes.len().min(room)]);
+    }
+}
+
+impl Drop for TracedBody {
+    fn drop(&mut self) {
+        if let Some(context) = self.context.take() {
+            context.finish(&self.buffer);
+        }
+    }
+}
+
+impl CallbackTraceContext {
+    fn finish(self, body: &[u8]) {
+        let Self {
+            state,
+            sinks,
+            mut trace,
+        } = self;
+        trace.end_ms = now_epoch_millis();
+        if !body.is_empty() {
+            let output = if trace.stream {
+                Some(trace_output_from_sse(body))
+            } else {
+                serde_json::from_slice::<Value>(body).ok().map(|json| {
+                    if trace.error.is_none() && !(200..300).contains(&trace.status) {
+                        trace.error = json
+                            .pointer("/error/message")
+                            .and_then(Value::as_str)
+                            .map(str::to_string);
+                    }
+                    trace_output_from_json(&json)
+                })
+            };
+            if let Some(output) = output {
+                trace.completion = output.completion;
+                trace.input_tokens = output.input_tokens;
+                trace.output_tokens = output.output_tokens;
+            }
+        }
+        if trace.error.is_none() && !(200..300).contains(&trace.status) {
+            trace.error = Some(format!("upstream status {}", trace.status));
+        }
+        trace.prompt = state.redactor.redact(trace.prompt);
+        trace.completion = state.redactor.redact(trace.completion);
+
+        for sink in &sinks {
+            sink.enqueue(&state, trace.clone());
+        }
+    }
+}
+
+fn now_epoch_millis() -> u64 {
+    SystemTime::now()
+        .duration_since(UNIX_EPOCH)
+        .map(|duration| duration.as_millis() as u64)
+        .unwrap_or(0)
+}
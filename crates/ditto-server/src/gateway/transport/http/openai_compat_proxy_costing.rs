@@ -1,5 +1,8 @@
 #[cfg(feature = "gateway-costing")]
-use super::{GatewayHttpState, max_option_u64};
+use axum::http::{HeaderMap, HeaderValue};
+
+#[cfg(feature = "gateway-costing")]
+use super::{GatewayHttpState, PricingTable, max_option_u64};
 
 #[cfg(feature = "gateway-costing")]
 pub(super) fn estimate_charge_cost_usd_micros(
@@ -42,3 +45,65 @@ pub(super) fn estimate_charge_cost_usd_micros(
 
     cost
 }
+
+/// Per-image, per-minute or per-query cost of an images, audio transcription or
+/// rerank response, for upstreams that report no input and output tokens.
+#[cfg(feature = "gateway-costing")]
+pub(super) fn estimate_media_cost_usd_micros(
+    pricing: &PricingTable,
+    model: &str,
+    path_and_query: &str,
+    body: &[u8],
+) -> Option<u64> {
+    let path = path_and_query.split('?').next().unwrap_or_default();
+    let response: serde_json::Value = serde_json::from_slice(body).ok()?;
+    if path.starts_with("/v1/images/") {
+        let images = response.get("data")?.as_array()?.len();
+        return pricing
+            .estimate_image_cost_usd_micros(model, u32::try_from(images).unwrap_or(u32::MAX));
+    }
+    if path.starts_with("/v1/audio/transcriptions") || path.starts_with("/v1/audio/translations") {
+        let seconds = response
+            .pointer("/usage/seconds")
+            .or_else(|| response.get("duration"))
+            .and_then(serde_json::Value::as_f64)?;
+        return pricing.estimate_audio_cost_usd_micros(model, seconds);
+    }
+    if path == "/v1/rerank" {
+        // Cohere bills search units; Jina and others report only total tokens.
+        let search_units = response
+            .pointer("/meta/billed_units/search_units")
+            .and_then(serde_json::Value::as_u64)
+            .unwrap_or(1);
+        let tokens = response
+            .pointer("/usage/total_tokens")
+            .or_else(|| response.pointer("/meta/billed_units/input_tokens"))
+            .and_then(serde_json::Value::as_u64);
+        return pricing
+            .estimate_rerank_cost_usd_micros(model, u32::try_from(search_units).unwrap_or(u32::MAX))
+            .or_else(|| {
+                pricing.estimate_cost_usd_micros(
+                    model,
+                    u32::try_from(tokens?).unwrap_or(u32::MAX),
+                    0,
+                )
+            });
+    }
+    None
+}
+
+/// Sets `x-ditto-cost` to the USD amount charged for the request.
+#[cfg(feature = "gateway-costing")]
+pub(super) fn insert_cost_header(headers: &mut HeaderMap, cost_usd_micros: Option<u64>) {
+    let Some(cost_usd_micros) = cost_usd_micros else {
+        return;
+    };
+    let value = format!(
+        "{}.{:06}",
+        cost_usd_micros / 1_000_000,
+        cost_usd_micros % 1_000_000
+    );
+    if let Ok(value) = HeaderValue::from_str(&value) {
+        headers.insert("x-ditto-cost", value);
+    }
+}
@@ -0,0 +1,285 @@
+use super::*;
+
+use std::time::{Duration, Instant};
+
+const REQUEST_TIMEOUT_HEADER: &str = "x-request-timeout";
+
+/// Deadline for a whole proxied request, taken from the client's
+/// `x-request-timeout` header (seconds, fractions allowed). Every routing
+/// attempt, retry and fallback draws on the same budget.
+#[derive(Clone, Copy, Debug)]
+pub(super) struct ProxyRequestDeadline(Instant);
+
+impl ProxyRequestDeadline {
+    pub(super) fn from_headers(
+        headers: &HeaderMap,
+    ) -> Result<Option<Self>, (StatusCode, Json<OpenAiErrorResponse>)> {
+        let Some(value) = headers.get(REQUEST_TIMEOUT_HEADER) else {
+            return Ok(None);
+        };
+        let timeout = value
+            .to_str()
+            .ok()
+            .and_then(|value| value.trim().parse::<f64>().ok())
+            .filter(|seconds| *seconds > 0.0)
+            .and_then(|seconds| Duration::try_from_secs_f64(seconds).ok())
+            .ok_or_else(|| {
+                openai_error(
+                    StatusCode::BAD_REQUEST,
+                    "invalid_request_error",
+                    Some("invalid_request_timeout"),
+                    "x-request-timeout must be a positive number of seconds",
+                )
+            })?;
+        Ok(Instant::now().checked_add(timeout).map(Self))
+    }
+
+    /// Time left, or `None` once the deadline has passed.
+    pub(super) fn remaining(self) -> Option<Duration> {
+        self.0
+            .checked_duration_since(Instant::now())
+            .filter(|remaining| !remaining.is_zero())
+    }
+
+    pub(super) fn is_expired(self) -> bool {
+        self.remaining().is_none()
+    }
+}
+
+/// Caps a configured timeout at the time left before `deadline`.
+pub(super) fn cap_timeout(
+    timeout: Option<Duration>,
+    deadline: Option<ProxyRequestDeadline>,
+) -> Option<Duration> {
+    let Some(deadline) = deadline else {
+        return timeout;
+    };
+    let remaining = deadline.remaining().unwrap_or(Duration::ZERO);
+    Some(timeout.map_or(remaining, |timeout| timeout.min(remaining)))
+}
+
+pub(super) fn request_deadline_exceeded_error() -> (StatusCode, Json<OpenAiErrorResponse>) {
+    openai_error(
+        StatusCode::GATEWAY_TIMEOUT,
+        "api_error",
+        Some("request_timeout"),
+        "x-request-timeout elapsed before any backend answered",
+    )
+}
+
+/// Replaces the client's `x-request-timeout` with the time left, so a chained
+/// gateway or upstream sees the same deadline rather than a fresh one.
+pub(super) fn insert_request_timeout(
+    headers: &mut HeaderMap,
+    deadline: Option<ProxyRequestDeadline>,
+) {
+    headers.remove(REQUEST_TIMEOUT_HEADER);
+    let Some(remaining) = deadline.and_then(ProxyRequestDeadline::remaining) else {
+        return;
+    };
+    if let Ok(value) = axum::http::HeaderValue::from_str(&format!("{:.3}", remaining.as_secs_f64()))
+    {
+        headers.insert(REQUEST_TIMEOUT_HEADER, value);
+    }
+}
+
+/// Sends a streaming request and waits for the first chunk of a successful
+/// SSE response, allowing `first_token_timeout` for both. The chunk is
+/// returned so the caller can replay it ahead of the rest of the body.
+pub(super) async fn send_with_first_token_timeout<F>(
+    send: F,
+    first_token_timeout: Option<Duration>,
+) -> Result<(reqwest::Response, Option<Bytes>), GatewayError>
+where
+    F: Future<Output = Result<reqwest::Response, GatewayError>>,
+{
+    let Some(first_token_timeout) = first_token_timeout else {
+        return Ok((send.await?, None));
+    };
+    let started = Instant::now();
+    let mut response = tokio::time::timeout(first_token_timeout, send)
+        .await
+        .map_err(|_| first_token_timeout_error(first_token_timeout))??;
+
+    let is_event_stream = response
+        .headers()
+        .get("content-type")
+        .and_then(|value| value.to_str().ok())
+        .is_some_and(|value| value.to_ascii_lowercase().starts_with("text/event-stream"));
+    if !response.status().is_success() || !is_event_stream {
+        return Ok((response, None));
+    }
+
+    let remaining = first_token_timeout.saturating_sub(started.elapsed());
+    match tokio::time::timeout(remaining, response.chunk()).await {
+        Ok(Ok(chunk)) => Ok((response, chunk)),
+        Ok(Err(err)) => {
+            let message = format!("backend request failed: {err}");
+            Err(if err.is_timeout() {
+                GatewayError::BackendTimeout { message }
+            } else {
+                GatewayError::Backend { message }
+            })
+        }
+        Err(_) => Err(first_token_timeout_error(first_token_timeout)),
+    }
+}
+
+fn first_token_timeout_error(timeout: Duration) -> GatewayError {
+    GatewayError::BackendTimeout {
+        message: format!(
+            "backend sent no data within the {}ms first token timeout",
+            timeout.as_millis()
+        ),
+    }
+}
+
+/// Runs `future` within `timeout`; `None` means it timed out.
+#[cfg(feature = "gateway-translation")]
+pub(super) async fn run_with_timeout<F: Future>(
+    timeout: Option<Duration>,
+    future: F,
+) -> Option<F::Output> {
+    match timeout {
+        Some(timeout) => tokio::time::timeout(timeout, future).await.ok(),
+        None => Some(future.await),
+    }
+}
+
+#[cfg(feature = "gateway-translation")]
+pub(super) fn translation_timeout_error(
+    deadline: Option<ProxyRequestDeadline>,
+) -> (StatusCode, Json<OpenAiErrorResponse>) {
+    if deadline.is_some_and(ProxyRequestDeadline::is_expired) {
+        return request_deadline_exceeded_error();
+    }
+    openai_error(
+        StatusCode::GATEWAY_TIMEOUT,
+        "api_error",
+        Some("backend_timeout"),
+        "translation backend timed out",
+    )
+}
+
+/// Opens a translated stream and waits for its first chunk within the
+/// first token timeout, then ends the stream with an error once the total
+/// timeout has elapsed. Both are measured from the call.
+#[cfg(feature = "gateway-translation")]
+pub(super) async fn open_translation_stream<F>(
+    open: F,
+    request_timeout: Option<Duration>,
+    first_token_timeout: Option<Duration>,
+) -> Option<ditto_core::error::Result<ditto_core::llm_core::model::StreamResult>>
+where
+    F: Future<Output = ditto_core::error::Result<ditto_core::llm_core::model::StreamResult>>,
+{
+    let started = Instant::now();
+    let first_token_timeout = match (first_token_timeout, request_timeout) {
+        (Some(first), Some(total)) => Some(first.min(total)),
+        (first, total) => first.or(total),
+    };
+
+    let mut stream = match run_with_timeout(first_token_timeout, open).await? {
+        Ok(stream) => stream,
+        Err(err) => return Some(Err(err)),
+    };
+    let remaining = first_token_timeout.map(|timeout| timeout.saturating_sub(started.elapsed()));
+    let first = run_with_timeout(remaining, stream.next()).await?;
+    let stream = futures_util::stream::iter(first).chain(stream).boxed();
+
+    let Some(deadline) = request_timeout.and_then(|timeout| started.checked_add(timeout)) else {
+        return Some(Ok(stream));
+    };
+    let deadline = tokio::time::Instant::from_std(deadline);
+    Some(Ok(futures_util::stream::unfold(
+        Some(stream),
+        move |stream| async move {
+            let mut stream = stream?;
+            match tokio::time::timeout_at(deadline, stream.next()).await {
+                Ok(Some(item)) => Some((item, Some(stream))),
+                Ok(None) => None,
+                Err(_) => Some((
+                    Err(ditto_core::error::DittoError::Io(std::io::Error::new(
+                        std::io::ErrorKind::TimedOut,
+                        "translation stream exceeded the backend timeout",
+                    ))),
+                    None,
+                )),
+            }
+        },
+    )
+    .boxed()))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn headers_with_timeout(value: &str) -> HeaderMap {
+        let mut headers = HeaderMap::new();
+        headers.insert(
+            REQUEST_TIMEOUT_HEADER,
+            axum::http::HeaderValue::from_str(value).expect("header value"),
+        );
+        headers
+    }
+
+    #[test]
+    fn parses_request_timeout_header() {
+        assert!(
+            ProxyRequestDeadline::from_headers(&HeaderMap::new())
+                .expect("no header")
+                .is_none()
+        );
+
+        let deadline = ProxyRequestDeadline::from_headers(&headers_with_timeout(" 1.5 "))
+            .expect("valid header")
+            .expect("deadline");
+        let remaining = deadline.remaining().expect("remaining");
+        assert!(remaining <= Duration::from_millis(1500));
+        assert!(remaining > Duration::from_secs(1));
+
+        for invalid in ["0", "-1", "soon", "NaN"] {
+            let (status, _) = ProxyRequestDeadline::from_headers(&headers_with_timeout(invalid))
+                .expect_err("invalid header");
+            assert_eq!(status, StatusCode::BAD_REQUEST);
+        }
+    }
+
+    #[test]
+    fn caps_timeouts_at_remaining_deadline() {
+        let configured = Some(Duration::from_secs(30));
+        assert_eq!(cap_timeout(configured, None), configured);
+        assert_eq!(cap_timeout(None, None), None);
+
+        let deadline = ProxyRequestDeadline(Instant::now() + Duration::from_secs(5));
+        let capped = cap_timeout(configured, Some(deadline)).expect("capped");
+        assert!(capped <= Duration::from_secs(5));
+        assert!(cap_timeout(None, Some(deadline)).is_some());
+        assert_eq!(
+            cap_timeout(Some(Duration::from_secs(1)), Some(deadline)),
+            Some(Duration::from_secs(1))
+        );
+
+        let expired = ProxyRequestDeadline(Instant::now());
+        assert!(expired.is_expired());
+        assert_eq!(cap_timeout(configured, Some(expired)), Some(Duration::ZERO));
+    }
+
+    #[test]
+    fn rewrites_request_timeout_header_with_remaining_budget() {
+        let mut headers = headers_with_timeout("60");
+        insert_request_timeout(&mut headers, None);
+        assert!(headers.get(REQUEST_TIMEOUT_HEADER).is_none());
+
+        let deadline = ProxyRequestDeadline(Instant::now() + Duration::from_secs(2));
+        let mut headers = headers_with_timeout("60");
+        insert_request_timeout(&mut headers, Some(deadline));
+        let forwarded: f64 = headers
+            .get(REQUEST_TIMEOUT_HEADER)
+            .and_then(|value| value.to_str().ok())
+            .and_then(|value| value.parse().ok())
+            .expect("forwarded timeout");
+        assert!(forwarded > 1.0 && forwarded <= 2.0);
+    }
+}
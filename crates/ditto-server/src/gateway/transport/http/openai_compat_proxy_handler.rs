@@ -11,22 +11,44 @@ fn is_non_billable_openai_meta_path(path_and_query: &str) -> bool {
     )
 }
 
+/// Marks a request the gateway re-enters the proxy with on a client's
+/// behalf (a session with its history prepended, a scoped fine-tuning call).
+/// The outer request already ran the WASM request plugins, and only a
+/// client's own `x-request-id` (not one the gateway set on the forwarded
+/// request) opts it into request dedup and retries of non-idempotent calls.
+#[derive(Clone, Copy)]
+pub(super) struct ForwardedProxyRequest {
+    pub(super) client_supplied_request_id: bool,
+}
+
 pub(super) async fn handle_openai_compat_proxy(
     State(state): State<GatewayHttpState>,
     Path(_path): Path<String>,
     req: axum::http::Request<Body>,
 ) -> Result<axum::response::Response, (StatusCode, Json<OpenAiErrorResponse>)> {
     let max_body_bytes = state.proxy.max_body_bytes;
-    let (parts, incoming_body) = req.into_parts();
-    let client_supplied_request_id = parts.headers.contains_key("x-request-id");
+    #[allow(unused_mut)]
+    let (mut parts, incoming_body) = req.into_parts();
+    let forwarded = parts.extensions.get::<ForwardedProxyRequest>().copied();
+    let client_supplied_request_id = match forwarded {
+        Some(forwarded) => forwarded.client_supplied_request_id,
+        None => parts.headers.contains_key("x-request-id"),
+    };
     let request_id =
         extract_header(&parts.headers, "x-request-id").unwrap_or_else(generate_request_id);
+    let idempotency_key = extract_header(&parts.headers, "idempotency-key");
+    let deadline = ProxyRequestDeadline::from_headers(&parts.headers)?;
     let path_and_query = parts
         .uri
         .path_and_query()
         .map(|pq| pq.as_str())
         .unwrap_or_else(|| parts.uri.path());
-    let normalized_path_and_query = normalize_openai_compat_path_and_query(path_and_query);
+    // Pass-through routes forward provider paths verbatim.
+    let normalized_path_and_query = if is_passthrough_route_request(&parts) {
+        std::borrow::Cow::Borrowed(path_and_query)
+    } else {
+        normalize_openai_compat_path_and_query(path_and_query)
+    };
     let path_and_query = normalized_path_and_query.as_ref();
     #[cfg(feature = "gateway-metrics-prometheus")]
     let metrics_path = super::super::metrics_prometheus::normalize_proxy_path_label(path_and_query);
@@ -52,12 +74,12 @@ pub(super) async fn handle_openai_compat_proxy(
     #[cfg(feature = "gateway-otel")]
     let _proxy_span_guard = proxy_span.enter();
     if should_stream_large_multipart_request(&parts, path_and_query, max_body_bytes) {
-        if client_supplied_request_id && !parts.method.is_safe() {
+        if (client_supplied_request_id || idempotency_key.is_some()) && !parts.method.is_safe() {
             return Err(openai_error(
                 StatusCode::BAD_REQUEST,
                 "invalid_request_error",
                 Some("unsupported_idempotency"),
-                "x-request-id idempotency is not supported for streaming multipart proxy requests",
+                "x-request-id / Idempotency-Key idempotency is not supported for streaming multipart proxy requests",
             ));
         }
         let path_and_query = path_and_query.to_string();
@@ -83,11 +105,7 @@ pub(super) async fn handle_openai_compat_proxy(
         } else {
             None
         };
-        to_bytes(incoming_body, max_body_bytes)
-            .await
-            .map_err(|err| {
-                openai_error(StatusCode::BAD_REQUEST, "invalid_request_error", None, err)
-            })?
+        read_request_body_limited(incoming_body, max_body_bytes).await?
     };
 
     let content_type_is_json = parts
@@ -113,6 +131,49 @@ pub(super) async fn handle_openai_compat_proxy(
         None
     };
 
+    #[cfg(feature = "gateway-wasm-plugins")]
+    let (body, parsed_json) = if forwarded.is_some() {
+        (body, parsed_json)
+    } else {
+        apply_wasm_request_plugins(
+            &state,
+            &request_id,
+            &parts.method,
+            path_and_query,
+            &mut parts.headers,
+            body,
+            parsed_json,
+        )
+        .await?
+    };
+
+    if let Some(response) =
+        maybe_handle_fine_tuning_jobs(&state, &parts, &body, &request_id, path_and_query).await?
+    {
+        return Ok(response);
+    }
+
+    if let Some(response) = maybe_handle_session_chat_completions(
+        &state,
+        &parts,
+        &parsed_json,
+        &request_id,
+        path_and_query,
+    )
+    .await?
+    {
+        return Ok(response);
+    }
+
+    let (body, parsed_json) = render_prompt_request(
+        &state,
+        &request_id,
+        path_and_query,
+        &mut parts.headers,
+        body,
+        parsed_json,
+    )?;
+
     let _stream_requested = parsed_json
         .as_ref()
         .and_then(|value| value.get("stream"))
@@ -170,6 +231,7 @@ pub(super) async fn handle_openai_compat_proxy(
     } else {
         input_tokens_estimate.saturating_add(max_output_tokens)
     };
+    let charge_input_tokens = charge_tokens.min(input_tokens_estimate);
 
     #[cfg(feature = "gateway-store-sqlite")]
     let use_sqlite_budget = state.stores.sqlite.is_some();
@@ -201,6 +263,8 @@ pub(super) async fn handle_openai_compat_proxy(
 
     let ResolvedGatewayContext {
         virtual_key_id,
+        key_callbacks,
+        key_tier,
         #[cfg(feature = "gateway-translation")]
         response_owner,
         limits,
@@ -212,7 +276,15 @@ pub(super) async fn handle_openai_compat_proxy(
         project_limits_scope,
         user_limits_scope,
         backend_candidates,
+        experiment,
+        shadow,
         strip_authorization,
+        guardrails,
+        hooked_request,
+        prompt_injection,
+        moderation,
+        context_window,
+        history_compression,
         charge_cost_usd_micros,
         local_rate_limit_reserved,
         local_token_budget_reserved,
@@ -242,6 +314,35 @@ pub(super) async fn handle_openai_compat_proxy(
     )
     .await?;
 
+    // Pre-call `modify` hooks, history compression and context-window trimming
+    // rewrite the request that is sent upstream.
+    let (body, parsed_json) = match hooked_request {
+        Some((body, body_json)) => (body, Some(body_json)),
+        None => (body, parsed_json),
+    };
+    let guardrail_hooks = guardrails
+        .filter(|guardrails| {
+            prompt_injection.is_some()
+                || moderation.is_some()
+                || context_window.is_some()
+                || history_compression.is_some()
+                || GuardrailHookContext::has_response_hooks(guardrails)
+        })
+        .map(|guardrails| GuardrailHookContext {
+            state: state.clone(),
+            guardrails,
+            prompt_injection,
+            moderation,
+            context_window,
+            history_compression,
+            request_id: request_id.clone(),
+            virtual_key_id: virtual_key_id.clone(),
+            #[cfg(feature = "gateway-metrics-prometheus")]
+            model: model.clone(),
+            #[cfg(feature = "gateway-metrics-prometheus")]
+            metrics_path: metrics_path.clone(),
+        });
+
     let mut request_dedup_leader =
         match prepare_proxy_request_dedup(PrepareProxyRequestDedupInput {
             state: &state,
@@ -251,6 +352,7 @@ pub(super) async fn handle_openai_compat_proxy(
             body: &body,
             request_id: &request_id,
             client_supplied_request_id,
+            idempotency_key: idempotency_key.as_deref(),
             virtual_key_id: virtual_key_id.as_deref(),
         })
         .await?
@@ -261,6 +363,20 @@ pub(super) async fn handle_openai_compat_proxy(
         };
 
     state.record_request();
+    let alert_started = Instant::now();
+    let callback_trace = begin_callback_trace(CallbackTraceRequest {
+        state: &state,
+        request_id: &request_id,
+        method: &parts.method,
+        path_and_query,
+        model: model.as_deref(),
+        virtual_key_id: virtual_key_id.as_deref(),
+        key_callbacks: &key_callbacks,
+        headers: &parts.headers,
+        parsed_json: parsed_json.as_ref(),
+    });
+    let experiment =
+        experiment.map(|experiment| ExperimentObservation::new(experiment, &backend_candidates));
 
     #[cfg(not(feature = "gateway-store-redis"))]
     let _ = (
@@ -285,14 +401,18 @@ pub(super) async fn handle_openai_compat_proxy(
 
     #[cfg(feature = "gateway-store-redis")]
     if let Some(store) = state.stores.redis.as_ref()
-        && let Err(err) = store
-            .check_and_consume_rate_limits_many(
-                redis_rate_limit_scopes.iter().copied(),
-                &rate_limit_route,
-                charge_tokens,
-                _now_epoch_seconds,
-            )
-            .await
+        && let Err(err) = record_rate_limit_outcome(
+            &parts.extensions,
+            store
+                .check_and_consume_rate_limits_many(
+                    redis_rate_limit_scopes.iter().copied(),
+                    &rate_limit_route,
+                    charge_tokens,
+                    _now_epoch_seconds,
+                )
+                .await,
+            minute,
+        )
     {
         let is_rate_limited = matches!(err, GatewayError::RateLimited { .. });
         if is_rate_limited {
@@ -400,8 +520,19 @@ pub(super) async fn handle_openai_compat_proxy(
         )
         .await
         {
-            return finish_proxy_request_dedup_result(request_dedup_leader.take(), Ok(response))
-                .await;
+            let response = apply_response_guardrail_hooks(guardrail_hooks.as_ref(), response).await;
+            #[cfg(feature = "gateway-wasm-plugins")]
+            let response = apply_wasm_response_plugins(
+                &state,
+                &request_id,
+                &parts.method,
+                path_and_query,
+                response,
+            )
+            .await;
+            let response = record_callback_trace(callback_trace, response);
+            let response = record_experiment_response(&state, experiment, response).await;
+            return finish_proxy_request_dedup_result(request_dedup_leader.take(), response).await;
         }
     }
 
@@ -564,6 +695,31 @@ pub(super) async fn handle_openai_compat_proxy(
             "body_len": body.len(),
         }),
     );
+    if let Some(shadow) = shadow.as_ref() {
+        mirror_shadow_request(ShadowRequest {
+            state: &state,
+            shadow,
+            parts: &parts,
+            body: &body,
+            parsed_json: parsed_json.as_ref(),
+            request_id: &request_id,
+            path_and_query,
+            model: model.as_deref(),
+            strip_authorization,
+        });
+    }
+
+    // Tier admission runs once budgets are reserved, so a shed request takes
+    // the failure path below and gives them back.
+    let mut backend_candidates = backend_candidates;
+    let tier_admission = admit_tiered_request(
+        &state,
+        key_tier.as_deref(),
+        &mut backend_candidates,
+        &mut parts.extensions,
+        deadline,
+    )
+    .await;
 
     #[cfg(feature = "gateway-routing-advanced")]
     let retry_config = state
@@ -581,7 +737,9 @@ pub(super) async fn handle_openai_compat_proxy(
     #[cfg(not(feature = "gateway-routing-advanced"))]
     let max_attempts = backend_candidates.len();
 
-    let mut last_err: Option<(StatusCode, Json<OpenAiErrorResponse>)> = None;
+    let mut last_err: Option<(StatusCode, Json<OpenAiErrorResponse>)> = tier_admission
+        .as_ref()
+        .and_then(|admission| admission.shed_error());
     let mut attempted_backends: Vec<String> = Vec::new();
 
     let attempt_params = ProxyAttemptParams {
@@ -592,11 +750,14 @@ pub(super) async fn handle_openai_compat_proxy(
         model: &model,
         service_tier: &service_tier,
         request_id: &request_id,
+        // Either header makes a non-idempotent request safe to resend.
         #[cfg(feature = "gateway-routing-advanced")]
-        client_supplied_request_id,
+        client_supplied_request_id: client_supplied_request_id || idempotency_key.is_some(),
         path_and_query,
         now_epoch_seconds: _now_epoch_seconds,
         charge_tokens,
+        charge_input_tokens,
+        deadline,
         stream_requested: _stream_requested,
         strip_authorization,
         use_persistent_budget,
@@ -655,6 +816,10 @@ pub(super) async fn handle_openai_compat_proxy(
         if idx >= max_attempts {
             break;
         }
+        if deadline.is_some_and(ProxyRequestDeadline::is_expired) {
+            last_err = Some(request_deadline_exceeded_error());
+            break;
+        }
 
         attempted_backends.push(backend_name.clone());
 
@@ -674,9 +839,29 @@ pub(super) async fn handle_openai_compat_proxy(
             .await?
             {
                 BackendAttemptOutcome::Response(response) => {
+                    let response =
+                        apply_response_guardrail_hooks(guardrail_hooks.as_ref(), response).await;
+                    #[cfg(feature = "gateway-wasm-plugins")]
+                    let response = apply_wasm_response_plugins(
+                        &state,
+                        &request_id,
+                        &parts.method,
+                        path_and_query,
+                        response,
+                    )
+                    .await;
+                    let response = record_callback_trace(callback_trace, response);
+                    let response = record_alert_sample(
+                        &state,
+                        alert_started,
+                        virtual_key_id.as_deref(),
+                        response,
+                    );
+                    let response = record_experiment_response(&state, experiment, response).await;
+                    let response = apply_tier_admission_headers(tier_admission.as_ref(), response);
                     return finish_proxy_request_dedup_result(
                         request_dedup_leader.take(),
-                        Ok(response),
+                        response,
                     )
                     .await;
                 }
@@ -696,11 +881,24 @@ pub(super) async fn handle_openai_compat_proxy(
         match attempt_proxy_backend(attempt_params, &backend_name, idx, &attempted_backends).await?
         {
             BackendAttemptOutcome::Response(response) => {
-                return finish_proxy_request_dedup_result(
-                    request_dedup_leader.take(),
-                    Ok(response),
+                let response =
+                    apply_response_guardrail_hooks(guardrail_hooks.as_ref(), response).await;
+                #[cfg(feature = "gateway-wasm-plugins")]
+                let response = apply_wasm_response_plugins(
+                    &state,
+                    &request_id,
+                    &parts.method,
+                    path_and_query,
+                    response,
                 )
                 .await;
+                let response = record_callback_trace(callback_trace, response);
+                let response =
+                    record_alert_sample(&state, alert_started, virtual_key_id.as_deref(), response);
+                let response = record_experiment_response(&state, experiment, response).await;
+                let response = apply_tier_admission_headers(tier_admission.as_ref(), response);
+                return finish_proxy_request_dedup_result(request_dedup_leader.take(), response)
+                    .await;
             }
             BackendAttemptOutcome::Continue(err) => {
                 if let Some(err) = err {
@@ -777,26 +975,27 @@ pub(super) async fn handle_openai_compat_proxy(
     #[cfg(not(feature = "gateway-metrics-prometheus"))]
     let proxy_metrics = None;
 
-    finish_proxy_request_dedup_result(
-        request_dedup_leader.take(),
-        Err(finalize_openai_compat_proxy_failure(
-            &state,
-            ProxyFailureContext {
-                request_id: &request_id,
-                method: &parts.method,
-                path_and_query,
-                model: &model,
-                virtual_key_id: virtual_key_id.as_deref(),
-                attempted_backends: &attempted_backends,
-                body_len: body.len(),
-                charge_tokens,
-                charge_cost_usd_micros,
-                last_err,
-                metrics: proxy_metrics,
-            },
-        )
-        .await),
+    let failure = finalize_openai_compat_proxy_failure(
+        &state,
+        ProxyFailureContext {
+            request_id: &request_id,
+            method: &parts.method,
+            path_and_query,
+            model: &model,
+            virtual_key_id: virtual_key_id.as_deref(),
+            attempted_backends: &attempted_backends,
+            body_len: body.len(),
+            charge_tokens,
+            charge_cost_usd_micros,
+            last_err,
+            metrics: proxy_metrics,
+        },
     )
-    .await
+    .await;
+    let response = record_callback_trace(callback_trace, Err(failure));
+    let response = record_alert_sample(&state, alert_started, virtual_key_id.as_deref(), response);
+    let response = record_experiment_response(&state, experiment, response).await;
+    let response = apply_tier_admission_headers(tier_admission.as_ref(), response);
+    finish_proxy_request_dedup_result(request_dedup_leader.take(), response).await
 }
 // end inline: ../../http/openai_compat_proxy.rs
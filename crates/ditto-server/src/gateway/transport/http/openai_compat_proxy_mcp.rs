@@ -60,15 +60,17 @@ async fn maybe_handle_mcp_tools_chat_completions_impl(
         .all(|cfg| cfg.require_approval.as_deref() == Some("never"));
     let max_steps = resolve_mcp_max_steps(&mcp_tool_cfgs)?;
 
+    let key = mcp_tool_loop_key(state, parts).await?;
+    let key = key.as_ref();
     let requested_servers = resolve_mcp_servers_from_tool_cfgs(&mcp_tool_cfgs);
-    let server_ids = match requested_servers {
-        Some(servers) => servers,
-        None => {
-            let mut all: Vec<String> = state.backends.mcp_servers.keys().cloned().collect();
-            all.sort();
-            all
-        }
-    };
+    let server_ids = mcp_servers_for_key(state, key, requested_servers).map_err(|message| {
+        openai_error(
+            StatusCode::FORBIDDEN,
+            "policy_error",
+            Some("mcp_server_not_allowed"),
+            message,
+        )
+    })?;
 
     if server_ids.is_empty() {
         return Err(openai_error(
@@ -79,7 +81,7 @@ async fn maybe_handle_mcp_tools_chat_completions_impl(
         ));
     }
 
-    let mcp_tools_value = mcp_list_tools(state, &server_ids, None)
+    let mcp_tools_value = mcp_list_tools(state, key, &server_ids, None)
         .await
         .map_err(map_openai_gateway_error)?;
     let mut mcp_tools = mcp_tools_value
@@ -256,7 +258,7 @@ async fn maybe_handle_mcp_tools_chat_completions_impl(
         }
 
         for call in &tool_calls {
-            let result = mcp_call_tool(state, &server_ids, &call.name, call.arguments.clone())
+            let result = mcp_call_tool(state, key, &server_ids, &call.name, call.arguments.clone())
                 .await
                 .unwrap_or_else(|err| Value::String(format!("MCP tool call failed: {err}")));
             let content = mcp_tool_result_to_text(&result);
@@ -305,15 +307,17 @@ async fn maybe_handle_mcp_tools_responses(
         .all(|cfg| cfg.require_approval.as_deref() == Some("never"));
     let max_steps = resolve_mcp_max_steps(&mcp_tool_cfgs)?;
 
+    let key = mcp_tool_loop_key(state, parts).await?;
+    let key = key.as_ref();
     let requested_servers = resolve_mcp_servers_from_tool_cfgs(&mcp_tool_cfgs);
-    let server_ids = match requested_servers {
-        Some(servers) => servers,
-        None => {
-            let mut all: Vec<String> = state.backends.mcp_servers.keys().cloned().collect();
-            all.sort();
-            all
-        }
-    };
+    let server_ids = mcp_servers_for_key(state, key, requested_servers).map_err(|message| {
+        openai_error(
+            StatusCode::FORBIDDEN,
+            "policy_error",
+            Some("mcp_server_not_allowed"),
+            message,
+        )
+    })?;
 
     if server_ids.is_empty() {
         return Err(openai_error(
@@ -324,7 +328,7 @@ async fn maybe_handle_mcp_tools_responses(
         ));
     }
 
-    let mcp_tools_value = mcp_list_tools(state, &server_ids, None)
+    let mcp_tools_value = mcp_list_tools(state, key, &server_ids, None)
         .await
         .map_err(map_openai_gateway_error)?;
     let mut mcp_tools = mcp_tools_value
@@ -403,7 +407,7 @@ async fn maybe_handle_mcp_tools_responses(
         )));
     }
 
-    let tool_results = execute_mcp_tool_calls(state, &server_ids, &tool_calls).await;
+    let tool_results = execute_mcp_tool_calls(state, key, &server_ids, &tool_calls).await;
 
     let initial_is_shim = initial_headers.contains_key("x-ditto-shim");
     let response_id = initial_json
@@ -421,6 +425,7 @@ async fn maybe_handle_mcp_tools_responses(
             McpResponsesToolLoopParams {
                 request_id,
                 request_json,
+                key,
                 server_ids: &server_ids,
                 tools_for_llm: tools_for_llm.clone(),
                 initial_tool_calls: &tool_calls,
@@ -546,7 +551,7 @@ async fn maybe_handle_mcp_tools_responses(
             return Ok(Some(rebuild_response(status, headers, body)));
         };
 
-        tool_results = execute_mcp_tool_calls(state, &server_ids, &next_tool_calls).await;
+        tool_results = execute_mcp_tool_calls(state, key, &server_ids, &next_tool_calls).await;
         tool_calls = next_tool_calls;
         prev_response_id = next_response_id;
         tool_rounds_executed = tool_rounds_executed.saturating_add(1);
@@ -963,12 +968,13 @@ fn extract_responses_tool_calls(
 
 async fn execute_mcp_tool_calls(
     state: &GatewayHttpState,
+    key: Option<&VirtualKeyConfig>,
     server_ids: &[String],
     tool_calls: &[ResponsesToolCall],
 ) -> Vec<String> {
     let mut out = Vec::with_capacity(tool_calls.len());
     for call in tool_calls {
-        let result = mcp_call_tool(state, server_ids, &call.name, call.arguments.clone())
+        let result = mcp_call_tool(state, key, server_ids, &call.name, call.arguments.clone())
             .await
             .unwrap_or_else(|err| Value::String(format!("MCP tool call failed: {err}")));
         out.push(mcp_tool_result_to_text(&result));
@@ -979,6 +985,7 @@ async fn execute_mcp_tool_calls(
 struct McpResponsesToolLoopParams<'a> {
     request_id: &'a str,
     request_json: &'a Value,
+    key: Option<&'a VirtualKeyConfig>,
     server_ids: &'a [String],
     tools_for_llm: Vec<Value>,
     initial_tool_calls: &'a [ResponsesToolCall],
@@ -1238,10 +1245,15 @@ async fn follow_up_via_chat_completions_to_responses(
         }
 
         for call in &tool_calls {
-            let result =
-                mcp_call_tool(state, params.server_ids, &call.name, call.arguments.clone())
-                    .await
-                    .unwrap_or_else(|err| Value::String(format!("MCP tool call failed: {err}")));
+            let result = mcp_call_tool(
+                state,
+                params.key,
+                params.server_ids,
+                &call.name,
+                call.arguments.clone(),
+            )
+            .await
+            .unwrap_or_else(|err| Value::String(format!("MCP tool call failed: {err}")));
             let content = mcp_tool_result_to_text(&result);
             push_message_with_limit(
                 &mut messages,
@@ -15,7 +15,8 @@ fn normalize_openai_compat_path(path: &str) -> std::borrow::Cow<'_, str> {
         "/audio/translations" => std::borrow::Cow::Borrowed("/v1/audio/translations"),
         "/audio/speech" => std::borrow::Cow::Borrowed("/v1/audio/speech"),
         "/files" => std::borrow::Cow::Borrowed("/v1/files"),
-        "/rerank" => std::borrow::Cow::Borrowed("/v1/rerank"),
+        // Cohere serves the same rerank API under `/v2`.
+        "/rerank" | "/v2/rerank" => std::borrow::Cow::Borrowed("/v1/rerank"),
         "/batches" => std::borrow::Cow::Borrowed("/v1/batches"),
         "/models" => std::borrow::Cow::Borrowed("/v1/models"),
         "/responses" => std::borrow::Cow::Borrowed("/v1/responses"),
@@ -14,6 +14,10 @@ pub(super) struct ProxyAttemptParams<'a> {
     pub(super) path_and_query: &'a str,
     pub(super) now_epoch_seconds: u64,
     pub(super) charge_tokens: u32,
+    /// The prompt share of `charge_tokens`, used to settle streams that end
+    /// before the upstream reports usage.
+    pub(super) charge_input_tokens: u32,
+    pub(super) deadline: Option<ProxyRequestDeadline>,
     pub(super) stream_requested: bool,
     pub(super) strip_authorization: bool,
     pub(super) use_persistent_budget: bool,
@@ -1,5 +1,186 @@
+use super::*;
+
+use axum::extract::Request;
+use axum::http::{Extensions, HeaderName, HeaderValue};
+use axum::middleware::Next;
+use axum::response::Response;
+
 #[cfg(feature = "gateway-store-redis")]
 use crate::gateway::LimitsConfig;
+use crate::gateway::{RateLimitRemaining, RateLimitStatus};
+
+use super::upstream_overload::DEFAULT_UPSTREAM_RETRY_AFTER_SECONDS;
+
+/// Filled in by the proxy once a request has been checked against the
+/// gateway's rpm/tpm limits, and by backend attempts that hit a provider
+/// overload; [`handle_rate_limit_headers`] turns it into `x-ratelimit-*` /
+/// `retry-after` headers on whatever response goes back.
+#[derive(Clone, Default)]
+pub(super) struct RateLimitHeadersSlot(Arc<StdMutex<RecordedRateLimitHeaders>>);
+
+#[derive(Clone, Copy, Default)]
+struct RecordedRateLimitHeaders {
+    rate_limit: Option<RecordedRateLimit>,
+    upstream_overload: Option<RecordedUpstreamOverload>,
+}
+
+#[derive(Clone, Copy)]
+struct RecordedRateLimit {
+    status: RateLimitStatus,
+    minute: u64,
+    rejected: bool,
+}
+
+#[derive(Clone, Copy)]
+struct RecordedUpstreamOverload {
+    status: StatusCode,
+    retry_after_seconds: Option<u64>,
+}
+
+pub(super) async fn handle_rate_limit_headers(mut req: Request, next: Next) -> Response {
+    let slot = RateLimitHeadersSlot::default();
+    req.extensions_mut().insert(slot.clone());
+    let mut response = next.run(req).await;
+    let recorded = *lock_unpoisoned(&slot.0);
+    if let Some(overload) = recorded.upstream_overload {
+        normalize_upstream_overload(&mut response, &overload);
+    }
+    if let Some(recorded) = recorded.rate_limit {
+        write_rate_limit_headers(response.headers_mut(), &recorded, now_epoch_seconds());
+    }
+    response
+}
+
+/// Notes that a backend attempt failed with a provider overload. The last one
+/// recorded wins, so a fallback backend that also overloads supplies the
+/// `retry-after`.
+pub(super) fn record_upstream_overload(
+    extensions: &Extensions,
+    status: StatusCode,
+    retry_after_seconds: Option<u64>,
+) {
+    if let Some(slot) = extensions.get::<RateLimitHeadersSlot>() {
+        lock_unpoisoned(&slot.0).upstream_overload = Some(RecordedUpstreamOverload {
+            status,
+            retry_after_seconds,
+        });
+    }
+}
+
+/// Only a response that still carries the overload (passed through as-is, or
+/// the routing error left after the last attempt) is rewritten; one from a
+/// backend that later succeeded is not.
+fn normalize_upstream_overload(response: &mut Response, overload: &RecordedUpstreamOverload) {
+    if response.status() != overload.status && response.status() != StatusCode::TOO_MANY_REQUESTS {
+        return;
+    }
+    *response.status_mut() = StatusCode::TOO_MANY_REQUESTS;
+    let retry_after = overload
+        .retry_after_seconds
+        .unwrap_or(DEFAULT_UPSTREAM_RETRY_AFTER_SECONDS)
+        .max(1);
+    let headers = response.headers_mut();
+    headers.remove("retry-after-ms");
+    headers.insert(
+        axum::http::header::RETRY_AFTER,
+        HeaderValue::from(retry_after),
+    );
+}
+
+/// Notes a rate limit check's outcome for the response headers and passes
+/// its error through. Rejections only know the limit that was hit.
+pub(super) fn record_rate_limit_outcome(
+    extensions: &Extensions,
+    checked: Result<RateLimitStatus, GatewayError>,
+    minute: u64,
+) -> Result<(), GatewayError> {
+    let (status, rejected) = match &checked {
+        Ok(status) => (*status, false),
+        Err(GatewayError::RateLimited { limit }) => (rejected_rate_limit_status(limit), true),
+        Err(_) => return checked.map(|_| ()),
+    };
+    if let Some(slot) = extensions.get::<RateLimitHeadersSlot>()
+        && (!status.is_empty() || rejected)
+    {
+        lock_unpoisoned(&slot.0).rate_limit = Some(RecordedRateLimit {
+            status,
+            minute,
+            rejected,
+        });
+    }
+    checked.map(|_| ())
+}
+
+/// `limit` is the `rpm>N` / `tpm>N` label carried by
+/// [`GatewayError::RateLimited`].
+fn rejected_rate_limit_status(limit: &str) -> RateLimitStatus {
+    let exhausted = limit
+        .split_once('>')
+        .and_then(|(dimension, value)| Some((dimension, value.parse::<u32>().ok()?)));
+    let mut status = RateLimitStatus::default();
+    match exhausted {
+        Some(("rpm", limit)) => {
+            status.requests = Some(RateLimitRemaining {
+                limit,
+                remaining: 0,
+            });
+        }
+        Some(("tpm", limit)) => {
+            status.tokens = Some(RateLimitRemaining {
+                limit,
+                remaining: 0,
+            });
+        }
+        _ => {}
+    }
+    status
+}
+
+/// Gateway limits replace any `x-ratelimit-*` headers the upstream sent:
+/// they are the ones the caller's key is held to.
+fn write_rate_limit_headers(headers: &mut HeaderMap, recorded: &RecordedRateLimit, now_s: u64) {
+    let reset_seconds = recorded
+        .minute
+        .saturating_add(1)
+        .saturating_mul(60)
+        .saturating_sub(now_s);
+    let reset = HeaderValue::from_str(&format!("{reset_seconds}s"))
+        .expect("duration is a valid header value");
+    let dimensions = [
+        (
+            recorded.status.requests,
+            "x-ratelimit-limit-requests",
+            "x-ratelimit-remaining-requests",
+            "x-ratelimit-reset-requests",
+        ),
+        (
+            recorded.status.tokens,
+            "x-ratelimit-limit-tokens",
+            "x-ratelimit-remaining-tokens",
+            "x-ratelimit-reset-tokens",
+        ),
+    ];
+    for (remaining, limit_name, remaining_name, reset_name) in dimensions {
+        let Some(remaining) = remaining else {
+            continue;
+        };
+        headers.insert(
+            HeaderName::from_static(limit_name),
+            HeaderValue::from(remaining.limit),
+        );
+        headers.insert(
+            HeaderName::from_static(remaining_name),
+            HeaderValue::from(remaining.remaining),
+        );
+        headers.insert(HeaderName::from_static(reset_name), reset.clone());
+    }
+    if recorded.rejected {
+        headers.insert(
+            axum::http::header::RETRY_AFTER,
+            HeaderValue::from(reset_seconds.max(1)),
+        );
+    }
+}
 
 #[cfg(feature = "gateway-store-redis")]
 pub(super) fn normalize_rate_limit_route(path_and_query: &str) -> String {
@@ -72,3 +253,67 @@ pub(super) fn redis_rate_limit_scopes<'a>(
     }
     scopes
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn writes_tightest_limits_and_retry_after_on_rejection() {
+        let mut headers = HeaderMap::new();
+        headers.insert("x-ratelimit-limit-requests", HeaderValue::from(5000));
+        let recorded = RecordedRateLimit {
+            status: RateLimitStatus {
+                requests: Some(RateLimitRemaining {
+                    limit: 60,
+                    remaining: 12,
+                }),
+                tokens: None,
+            },
+            minute: 100,
+            rejected: false,
+        };
+        write_rate_limit_headers(&mut headers, &recorded, 100 * 60 + 45);
+        assert_eq!(headers["x-ratelimit-limit-requests"], "60");
+        assert_eq!(headers["x-ratelimit-remaining-requests"], "12");
+        assert_eq!(headers["x-ratelimit-reset-requests"], "15s");
+        assert!(!headers.contains_key("x-ratelimit-limit-tokens"));
+        assert!(!headers.contains_key("retry-after"));
+
+        let mut headers = HeaderMap::new();
+        let recorded = RecordedRateLimit {
+            status: rejected_rate_limit_status("tpm>1000"),
+            minute: 100,
+            rejected: true,
+        };
+        write_rate_limit_headers(&mut headers, &recorded, 100 * 60 + 50);
+        assert_eq!(headers["x-ratelimit-limit-tokens"], "1000");
+        assert_eq!(headers["x-ratelimit-remaining-tokens"], "0");
+        assert_eq!(headers["x-ratelimit-reset-tokens"], "10s");
+        assert!(!headers.contains_key("x-ratelimit-limit-requests"));
+        assert_eq!(headers["retry-after"], "10");
+    }
+
+    #[test]
+    fn normalizes_final_upstream_overload_to_429() {
+        let overloaded = RecordedUpstreamOverload {
+            status: StatusCode::from_u16(529).unwrap(),
+            retry_after_seconds: Some(7),
+        };
+        let mut response = Response::new(Body::empty());
+        *response.status_mut() = overloaded.status;
+        response
+            .headers_mut()
+            .insert("retry-after-ms", HeaderValue::from(6500));
+        normalize_upstream_overload(&mut response, &overloaded);
+        assert_eq!(response.status(), StatusCode::TOO_MANY_REQUESTS);
+        assert_eq!(response.headers()["retry-after"], "7");
+        assert!(!response.headers().contains_key("retry-after-ms"));
+
+        // A fallback backend answered after the overload.
+        let mut response = Response::new(Body::empty());
+        normalize_upstream_overload(&mut response, &overloaded);
+        assert_eq!(response.status(), StatusCode::OK);
+        assert!(!response.headers().contains_key("retry-after"));
+    }
+}
@@ -141,6 +141,9 @@ pub(super) struct PrepareProxyRequestDedupInput<'a> {
     pub body: &'a Bytes,
     pub request_id: &'a str,
     pub client_supplied_request_id: bool,
+    /// `Idempotency-Key` header; takes precedence over `x-request-id` as the
+    /// dedup key.
+    pub idempotency_key: Option<&'a str>,
     pub virtual_key_id: Option<&'a str>,
 }
 
@@ -155,10 +158,11 @@ pub(super) async fn prepare_proxy_request_dedup(
         body,
         request_id,
         client_supplied_request_id,
+        idempotency_key,
         virtual_key_id,
     } = input;
 
-    if !client_supplied_request_id || method.is_safe() {
+    if (!client_supplied_request_id && idempotency_key.is_none()) || method.is_safe() {
         return Ok(ProxyRequestDedupDecision::Disabled);
     }
 
@@ -166,8 +170,14 @@ pub(super) async fn prepare_proxy_request_dedup(
     let local_fallback = local_proxy_request_dedup_persistence(state);
     let mut tried_local_fallback = !has_persistent_proxy_request_dedup_store(state);
     let dedup_subject_scope = request_dedup_subject_scope(headers);
-    let scoped_request_id =
-        scoped_proxy_request_id(request_id, virtual_key_id, dedup_subject_scope.as_deref());
+    let scoped_request_id = match idempotency_key {
+        Some(idempotency_key) => scoped_proxy_idempotency_key(
+            idempotency_key,
+            virtual_key_id,
+            dedup_subject_scope.as_deref(),
+        ),
+        None => scoped_proxy_request_id(request_id, virtual_key_id, dedup_subject_scope.as_deref()),
+    };
     let (fingerprint, fingerprint_key) =
         request_dedup_fingerprint(method, path_and_query, virtual_key_id, headers, body);
     let owner_token = format!("dedup-{}", generate_request_id());
@@ -194,6 +204,7 @@ pub(super) async fn prepare_proxy_request_dedup(
                         "method": method.as_str(),
                         "path": path_and_query,
                         "virtual_key_id": virtual_key_id,
+                        "idempotency_key": idempotency_key.is_some(),
                     }),
                 );
                 return Ok(ProxyRequestDedupDecision::Leader(
@@ -214,6 +225,7 @@ pub(super) async fn prepare_proxy_request_dedup(
                         "method": method.as_str(),
                         "path": path_and_query,
                         "virtual_key_id": virtual_key_id,
+                        "idempotency_key": idempotency_key.is_some(),
                     }),
                 );
                 return Ok(ProxyRequestDedupDecision::Replay(
@@ -229,13 +241,25 @@ pub(super) async fn prepare_proxy_request_dedup(
                         "method": method.as_str(),
                         "path": path_and_query,
                         "virtual_key_id": virtual_key_id,
+                        "idempotency_key": idempotency_key.is_some(),
                     }),
                 );
+                let (code, message) = if idempotency_key.is_some() {
+                    (
+                        "idempotency_key_conflict",
+                        "Idempotency-Key was already used for a different request",
+                    )
+                } else {
+                    (
+                        "request_id_conflict",
+                        "x-request-id was already used for a different request",
+                    )
+                };
                 return Ok(ProxyRequestDedupDecision::Replay(Err(openai_error(
                     StatusCode::CONFLICT,
                     "invalid_request_error",
-                    Some("request_id_conflict"),
-                    "x-request-id was already used for a different request",
+                    Some(code),
+                    message,
                 ))));
             }
             Ok(ProxyRequestIdempotencyBeginOutcome::InFlight { .. }) => {
@@ -337,19 +361,35 @@ fn has_persistent_proxy_request_dedup_store(_state: &GatewayHttpState) -> bool {
     false
 }
 
+fn request_dedup_scope<'a>(
+    virtual_key_id: Option<&'a str>,
+    dedup_subject_scope: Option<&'a str>,
+) -> &'a str {
+    virtual_key_id
+        .map(str::trim)
+        .filter(|value| !value.is_empty())
+        .or(dedup_subject_scope)
+        .unwrap_or("_global")
+}
+
 fn scoped_proxy_request_id(
     request_id: &str,
     virtual_key_id: Option<&str>,
     dedup_subject_scope: Option<&str>,
 ) -> String {
-    let scope = virtual_key_id
-        .map(str::trim)
-        .filter(|value| !value.is_empty())
-        .or(dedup_subject_scope)
-        .unwrap_or("_global");
+    let scope = request_dedup_scope(virtual_key_id, dedup_subject_scope);
     format!("ditto-proxy-request-dedup-v1|{scope}|{request_id}")
 }
 
+fn scoped_proxy_idempotency_key(
+    idempotency_key: &str,
+    virtual_key_id: Option<&str>,
+    dedup_subject_scope: Option<&str>,
+) -> String {
+    let scope = request_dedup_scope(virtual_key_id, dedup_subject_scope);
+    format!("ditto-proxy-idempotency-key-v1|{scope}|{idempotency_key}")
+}
+
 fn request_dedup_fingerprint(
     method: &axum::http::Method,
     path_and_query: &str,
@@ -462,6 +502,7 @@ fn request_dedup_header_affects_upstream(header: &str) -> bool {
             | "x-ditto-bypass-cache"
             | "content-length"
             | "x-request-id"
+            | "idempotency-key"
             | "traceparent"
             | "tracestate"
             | "baggage"
@@ -576,6 +617,7 @@ fn error_tuple_from_replay_error(
             error: OpenAiErrorDetail {
                 message: error.message,
                 kind: error.kind,
+                param: None,
                 code: error.code,
             },
         }),
@@ -883,6 +925,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         }))
     }
 
@@ -901,6 +948,7 @@ mod tests {
             body: &Bytes::from_static(br#"{"prompt":"hello"}"#),
             request_id,
             client_supplied_request_id: true,
+            idempotency_key: None,
             virtual_key_id: Some("key-a"),
         })
         .await
@@ -915,6 +963,7 @@ mod tests {
             body: &Bytes::from_static(br#"{"prompt":"world"}"#),
             request_id,
             client_supplied_request_id: true,
+            idempotency_key: None,
             virtual_key_id: Some("key-b"),
         })
         .await
@@ -937,6 +986,7 @@ mod tests {
             body: &Bytes::from_static(br#"{"prompt":"hello"}"#),
             request_id,
             client_supplied_request_id: true,
+            idempotency_key: None,
             virtual_key_id: Some("key-a"),
         })
         .await
@@ -951,6 +1001,7 @@ mod tests {
             body: &Bytes::from_static(br#"{"prompt":"different"}"#),
             request_id,
             client_supplied_request_id: true,
+            idempotency_key: None,
             virtual_key_id: Some("key-a"),
         })
         .await
@@ -971,6 +1022,50 @@ mod tests {
         assert_eq!(replay.1.error.code.as_deref(), Some("request_id_conflict"));
     }
 
+    #[tokio::test]
+    async fn idempotency_key_dedup_ignores_generated_request_ids() {
+        let state = test_gateway_http_state();
+        let method = axum::http::Method::POST;
+        let headers = HeaderMap::new();
+
+        let first = prepare_proxy_request_dedup(PrepareProxyRequestDedupInput {
+            state: &state,
+            method: &method,
+            path_and_query: "/v1/chat/completions",
+            headers: &headers,
+            body: &Bytes::from_static(br#"{"prompt":"hello"}"#),
+            request_id: "generated-1",
+            client_supplied_request_id: false,
+            idempotency_key: Some("idem-1"),
+            virtual_key_id: Some("key-a"),
+        })
+        .await
+        .expect("first request should acquire dedup leadership");
+        assert!(matches!(first, ProxyRequestDedupDecision::Leader(_)));
+
+        let second = prepare_proxy_request_dedup(PrepareProxyRequestDedupInput {
+            state: &state,
+            method: &method,
+            path_and_query: "/v1/chat/completions",
+            headers: &headers,
+            body: &Bytes::from_static(br#"{"prompt":"different"}"#),
+            request_id: "generated-2",
+            client_supplied_request_id: false,
+            idempotency_key: Some("idem-1"),
+            virtual_key_id: Some("key-a"),
+        })
+        .await
+        .expect("same idempotency key should return a dedup decision");
+        let ProxyRequestDedupDecision::Replay(Err(replay)) = second else {
+            panic!("expected idempotency key conflict");
+        };
+        assert_eq!(replay.0, StatusCode::CONFLICT);
+        assert_eq!(
+            replay.1.error.code.as_deref(),
+            Some("idempotency_key_conflict")
+        );
+    }
+
     #[tokio::test]
     async fn request_id_dedup_is_isolated_by_auth_subject_without_virtual_key() {
         let state = test_gateway_http_state();
@@ -990,6 +1085,7 @@ mod tests {
             body: &Bytes::from_static(br#"{"prompt":"hello"}"#),
             request_id,
             client_supplied_request_id: true,
+            idempotency_key: None,
             virtual_key_id: None,
         })
         .await
@@ -1009,6 +1105,7 @@ mod tests {
             body: &Bytes::from_static(br#"{"prompt":"hello"}"#),
             request_id,
             client_supplied_request_id: true,
+            idempotency_key: None,
             virtual_key_id: None,
         })
         .await
@@ -50,32 +50,80 @@ fn estimate_tokens_from_length(len: usize) -> u32 {
     }
 }
 
-async fn buffer_streaming_multipart_body(
-    body: Body,
-    content_length: usize,
-) -> Result<Bytes, (StatusCode, Json<OpenAiErrorResponse>)> {
-    let mut buffered = bytes::BytesMut::with_capacity(content_length.min(64 * 1024));
-    let mut stream = body.into_data_stream();
+/// Upload bytes read before the upstream request starts: enough for the form
+/// fields that precede the file part without holding the file itself.
+const STREAMING_MULTIPART_PREFIX_BYTES: usize = 64 * 1024;
+
+/// A large multipart upload. It stays `Streaming` (the leading bytes read
+/// plus the unread client stream) until a check needs the complete body.
+enum StreamingMultipartBody {
+    Buffered(Bytes),
+    Streaming {
+        prefix: Bytes,
+        rest: axum::body::BodyDataStream,
+    },
+}
 
-    while let Some(chunk) = stream.next().await {
-        let chunk = chunk.map_err(|err| {
-            openai_error(
-                StatusCode::BAD_REQUEST,
-                "invalid_request_error",
-                Some("invalid_request"),
-                format!("failed to read multipart request body: {err}"),
-            )
-        })?;
-        buffered.extend_from_slice(&chunk);
+impl StreamingMultipartBody {
+    async fn read_prefix(
+        body: Body,
+        content_length: usize,
+    ) -> Result<Self, (StatusCode, Json<OpenAiErrorResponse>)> {
+        let mut prefix =
+            bytes::BytesMut::with_capacity(content_length.min(STREAMING_MULTIPART_PREFIX_BYTES));
+        let mut stream = body.into_data_stream();
+        while prefix.len() < STREAMING_MULTIPART_PREFIX_BYTES {
+            match stream.next().await {
+                Some(chunk) => prefix.extend_from_slice(&chunk.map_err(multipart_read_error)?),
+                None => return Ok(Self::Buffered(prefix.freeze())),
+            }
+        }
+        Ok(Self::Streaming {
+            prefix: prefix.freeze(),
+            rest: stream,
+        })
     }
 
-    Ok(buffered.freeze())
+    /// Reads the rest of the upload, if any, and returns the complete body.
+    async fn buffer(&mut self) -> Result<&Bytes, (StatusCode, Json<OpenAiErrorResponse>)> {
+        if let Self::Streaming { prefix, rest } = self {
+            let mut buffered = bytes::BytesMut::from(prefix.as_ref());
+            while let Some(chunk) = rest.next().await {
+                buffered.extend_from_slice(&chunk.map_err(multipart_read_error)?);
+            }
+            *self = Self::Buffered(buffered.freeze());
+        }
+        match self {
+            Self::Buffered(body) => Ok(body),
+            Self::Streaming { .. } => unreachable!("multipart body was just buffered"),
+        }
+    }
+
+    fn into_reqwest_body(self) -> reqwest::Body {
+        match self {
+            Self::Buffered(body) => reqwest::Body::from(body),
+            Self::Streaming { prefix, rest } => reqwest::Body::wrap_stream(
+                stream::once(async move { Ok::<_, axum::Error>(prefix) }).chain(rest),
+            ),
+        }
+    }
+}
+
+fn multipart_read_error(err: axum::Error) -> (StatusCode, Json<OpenAiErrorResponse>) {
+    openai_error(
+        StatusCode::BAD_REQUEST,
+        "invalid_request_error",
+        Some("invalid_request"),
+        format!("failed to read multipart request body: {err}"),
+    )
 }
 
-fn multipart_request_model(
+/// Looks for the `model` field in the parts received so far and only buffers
+/// the whole upload when it is not among them.
+async fn multipart_request_model(
     path_and_query: &str,
     content_type: Option<&str>,
-    body: &Bytes,
+    body: &mut StreamingMultipartBody,
 ) -> Result<Option<String>, (StatusCode, Json<OpenAiErrorResponse>)> {
     let path = path_and_query
         .split_once('?')
@@ -90,8 +138,16 @@ fn multipart_request_model(
         return Ok(None);
     };
 
-    let parts =
-        super::super::multipart::parse_multipart_form(content_type, body).map_err(|err| {
+    loop {
+        let parts = match body {
+            StreamingMultipartBody::Buffered(bytes) => {
+                super::super::multipart::parse_multipart_form(content_type, bytes)
+            }
+            StreamingMultipartBody::Streaming { prefix, .. } => {
+                super::super::multipart::parse_multipart_prefix(content_type, prefix)
+            }
+        }
+        .map_err(|err| {
             openai_error(
                 StatusCode::BAD_REQUEST,
                 "invalid_request_error",
@@ -99,17 +155,21 @@ fn multipart_request_model(
                 err,
             )
         })?;
-    for part in parts {
-        if part.name == "model" && part.filename.is_none() {
-            let model = String::from_utf8_lossy(part.data.as_ref())
-                .trim()
-                .to_string();
-            if !model.is_empty() {
-                return Ok(Some(model));
+        for part in parts {
+            if part.name == "model" && part.filename.is_none() {
+                let model = String::from_utf8_lossy(part.data.as_ref())
+                    .trim()
+                    .to_string();
+                if !model.is_empty() {
+                    return Ok(Some(model));
+                }
             }
         }
+        if matches!(body, StreamingMultipartBody::Buffered(_)) {
+            return Ok(None);
+        }
+        body.buffer().await?;
     }
-    Ok(None)
 }
 
 // end inline: streaming_multipart/preamble.rs
@@ -128,7 +188,7 @@ struct ResolvedStreamingMultipartGatewayContext {
     strip_authorization: bool,
     local_rate_limit_reserved: bool,
     local_token_budget_reserved: bool,
-    buffered_body: Bytes,
+    upload: StreamingMultipartBody,
     model: Option<String>,
 }
 
@@ -250,7 +310,7 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
         strip_authorization,
         local_rate_limit_reserved,
         local_token_budget_reserved,
-        buffered_body,
+        upload,
         model,
     } = {
         state.record_request();
@@ -375,9 +435,9 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
             }
         }
 
-        let buffered_body = buffer_streaming_multipart_body(body, content_length).await?;
+        let mut upload = StreamingMultipartBody::read_prefix(body, content_length).await?;
         let model =
-            multipart_request_model(&path_and_query, content_type.as_deref(), &buffered_body)?;
+            multipart_request_model(&path_and_query, content_type.as_deref(), &mut upload).await?;
 
         if let Some(key) = key.as_ref() {
             let guardrails = state.guardrails_for_model(model.as_deref(), key);
@@ -386,12 +446,7 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
                 && let Some(reason) = guardrails.check_model(model_id)
             {
                 state.record_guardrail_blocked();
-                let err = openai_error(
-                    StatusCode::FORBIDDEN,
-                    "policy_error",
-                    Some("guardrail_rejected"),
-                    reason,
-                );
+                let err = guardrail_error(reason);
                 #[cfg(feature = "gateway-metrics-prometheus")]
                 if let Some(metrics) = state.proxy.metrics.as_ref() {
                     let duration = metrics_timer_start.elapsed();
@@ -417,12 +472,7 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
                 && charge_tokens > limit
             {
                 state.record_guardrail_blocked();
-                let err = openai_error(
-                    StatusCode::FORBIDDEN,
-                    "policy_error",
-                    Some("guardrail_rejected"),
-                    format!("input_tokens>{limit}"),
-                );
+                let err = guardrail_error(format!("input_tokens>{limit}"));
                 #[cfg(feature = "gateway-metrics-prometheus")]
                 if let Some(metrics) = state.proxy.metrics.as_ref() {
                     let duration = metrics_timer_start.elapsed();
@@ -448,7 +498,7 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
                 && let Some(reason) = validate_openai_multipart_request_schema(
                     &path_and_query,
                     content_type.as_deref(),
-                    &buffered_body,
+                    upload.buffer().await?,
                 )
             {
                 state.record_guardrail_blocked();
@@ -480,16 +530,11 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
             }
 
             if guardrails.has_text_filters()
-                && let Ok(text) = std::str::from_utf8(&buffered_body)
+                && let Ok(text) = std::str::from_utf8(upload.buffer().await?)
                 && let Some(reason) = guardrails.check_text(text)
             {
                 state.record_guardrail_blocked();
-                let err = openai_error(
-                    StatusCode::FORBIDDEN,
-                    "policy_error",
-                    Some("guardrail_rejected"),
-                    reason,
-                );
+                let err = guardrail_error(reason);
                 #[cfg(feature = "gateway-metrics-prometheus")]
                 if let Some(metrics) = state.proxy.metrics.as_ref() {
                     let duration = metrics_timer_start.elapsed();
@@ -528,9 +573,13 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
                 rate_limit_scopes.push((scope.as_str(), limits));
             }
             local_rate_limit_reserved = !rate_limit_scopes.is_empty();
-            if let Err(err) = state.check_and_consume_rate_limits(
-                rate_limit_scopes.into_iter(),
-                charge_tokens,
+            if let Err(err) = record_rate_limit_outcome(
+                &parts.extensions,
+                state.check_and_consume_rate_limits(
+                    rate_limit_scopes.into_iter(),
+                    charge_tokens,
+                    minute,
+                ),
                 minute,
             ) {
                 state.record_rate_limited();
@@ -616,7 +665,9 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
             local_token_budget_reserved = !budget_scopes.is_empty();
         }
 
-        let backends = match state.select_backends_for_model_seeded(
+        let backends = match select_proxy_backends(
+            &state,
+            &parts,
             model.as_deref().unwrap_or_default(),
             key.as_ref(),
             Some(&request_id),
@@ -666,7 +717,7 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
             strip_authorization,
             local_rate_limit_reserved,
             local_token_budget_reserved,
-            buffered_body,
+            upload,
             model,
         }
     };
@@ -694,14 +745,18 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
     #[cfg(feature = "gateway-store-redis")]
     if use_redis_budget
         && let Some(store) = state.stores.redis.as_ref()
-        && let Err(err) = store
-            .check_and_consume_rate_limits_many(
-                redis_rate_limit_scopes.iter().copied(),
-                &rate_limit_route,
-                charge_tokens,
-                now_epoch_seconds,
-            )
-            .await
+        && let Err(err) = record_rate_limit_outcome(
+            &parts.extensions,
+            store
+                .check_and_consume_rate_limits_many(
+                    redis_rate_limit_scopes.iter().copied(),
+                    &rate_limit_route,
+                    charge_tokens,
+                    now_epoch_seconds,
+                )
+                .await,
+            minute,
+        )
     {
         let is_rate_limited = matches!(err, GatewayError::RateLimited { .. });
         if is_rate_limited {
@@ -1132,10 +1187,10 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
 
     let mut outgoing_headers = parts.headers.clone();
     sanitize_proxy_headers(&mut outgoing_headers, strip_authorization);
-    apply_backend_headers(&mut outgoing_headers, backend.headers());
+    apply_backend_headers(&mut outgoing_headers, &backend.headers());
     insert_request_id(&mut outgoing_headers, &request_id);
 
-    let outgoing_body = reqwest::Body::from(buffered_body.clone());
+    let outgoing_body = upload.into_reqwest_body();
 
     #[cfg(feature = "gateway-metrics-prometheus")]
     let backend_timer_start = Instant::now();
@@ -1401,6 +1456,7 @@ pub(super) async fn handle_openai_compat_proxy_streaming_multipart(
             "charge_cost_usd_micros": charge_cost_usd_micros,
             "spent_cost_usd_micros": spent_cost_usd_micros,
             "body_len": content_length,
+            "tags": request_tags(&parts.headers, None),
         });
         append_audit_log(&state, "proxy", payload)
             .await
@@ -1464,11 +1520,20 @@ mod tests {
             base_url,
             max_in_flight: None,
             timeout_seconds: None,
+            connect_timeout_seconds: None,
+            first_token_timeout_seconds: None,
             headers: BTreeMap::new(),
             query_params: BTreeMap::new(),
+            tls: None,
             provider: None,
             provider_config: None,
             model_map: BTreeMap::new(),
+            model_info: BTreeMap::new(),
+            embeddings_max_batch: None,
+            structured_output: None,
+            prompt_cache: None,
+            transport: None,
+            region: None,
         }
     }
 
@@ -1581,6 +1646,11 @@ Content-Type: audio/wav\r\n\r\n\
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let mut proxy_backends = HashMap::new();
@@ -1669,6 +1739,11 @@ Content-Type: audio/wav\r\n\r\n\
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let mut proxy_backends = HashMap::new();
@@ -1741,11 +1816,18 @@ Content-Type: audio/wav\r\n\r\n\
                     backend: "whisper".to_string(),
                     backends: Vec::new(),
                     guardrails: None,
+                    experiment: None,
+                    shadow: None,
                 }],
             },
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let mut proxy_backends = HashMap::new();
@@ -1804,6 +1886,11 @@ Content-Type: audio/wav\r\n\r\n\
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_max_body_bytes(16);
@@ -1853,6 +1940,11 @@ Content-Type: audio/wav\r\n\r\n\
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         };
 
         let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_max_body_bytes(16);
@@ -0,0 +1,365 @@
+//! `GET /v1/models/{model}/info`: context window, output limit, modalities,
+//! tool calling and prices of a model the key may use. Each field comes from
+//! the backend's `model_info` config first, then the backend's provider
+//! capabilities and the pricing table, and finally the built-in model catalog
+//! of the compiled-in providers. A guardrail `context_window` smaller than
+//! the model's is reported instead, since that is what the key gets.
+
+use super::*;
+
+use ditto_core::catalog::{
+    BehaviorSupport, OperationKind, ProviderModelDescriptor, ProviderPluginDescriptor,
+    builtin_registry,
+};
+use ditto_core::config::ProviderCapabilities;
+use serde::Serialize;
+
+use super::openai_models::model_is_allowed_for_key;
+use crate::gateway::ModelInfoConfig;
+
+#[derive(Debug, Default, PartialEq, Serialize)]
+struct ModelInfo {
+    context_window: Option<u64>,
+    max_output_tokens: Option<u64>,
+    modalities: ModelModalities,
+    supports_tool_calling: Option<bool>,
+    pricing: Option<ModelPrices>,
+}
+
+#[derive(Debug, Default, PartialEq, Serialize)]
+struct ModelModalities {
+    input: Vec<String>,
+    output: Vec<String>,
+}
+
+#[derive(Clone, Copy, Debug, Default, PartialEq, Serialize)]
+struct ModelPrices {
+    input_usd_per_million_tokens: Option<f64>,
+    output_usd_per_million_tokens: Option<f64>,
+}
+
+/// A model's entry in the built-in catalog.
+struct CatalogModel {
+    provider: &'static str,
+    model: &'static ProviderModelDescriptor,
+    tool_calls: BehaviorSupport,
+}
+
+/// `/models/*` and `/v1/models/*`: `GET {model}/info` is answered by the
+/// gateway, every other request is proxied as before.
+pub(super) async fn handle_openai_models_subpath(
+    State(state): State<GatewayHttpState>,
+    Path(path): Path<String>,
+    req: axum::http::Request<Body>,
+) -> Result<axum::response::Response, (StatusCode, Json<OpenAiErrorResponse>)> {
+    let model = path
+        .trim_start_matches('/')
+        .strip_suffix("/info")
+        .filter(|model| !model.is_empty());
+    match model {
+        Some(model) if req.method() == axum::http::Method::GET => {
+            let model = model.to_string();
+            handle_openai_model_info(state, model, req).await
+        }
+        _ => handle_openai_compat_proxy(State(state), Path(path), req).await,
+    }
+}
+
+async fn handle_openai_model_info(
+    state: GatewayHttpState,
+    model: String,
+    req: axum::http::Request<Body>,
+) -> Result<axum::response::Response, (StatusCode, Json<OpenAiErrorResponse>)> {
+    let (parts, _body) = req.into_parts();
+
+    let request_id =
+        extract_header(&parts.headers, "x-request-id").unwrap_or_else(generate_request_id);
+
+    let token = extract_virtual_key(&parts.headers).ok_or_else(|| {
+        openai_error(
+            StatusCode::UNAUTHORIZED,
+            "authentication_error",
+            Some("invalid_api_key"),
+            "missing virtual key",
+        )
+    })?;
+    let key = state
+        .virtual_key_by_token(&token)
+        .filter(|key| key.enabled)
+        .ok_or_else(|| {
+            openai_error(
+                StatusCode::UNAUTHORIZED,
+                "authentication_error",
+                Some("invalid_api_key"),
+                "unauthorized virtual key",
+            )
+        })?;
+    ensure_virtual_key_client_access(&state, &parts, &key).await?;
+
+    // Models the key may not use look the same as models that do not exist.
+    let not_found = || {
+        openai_error(
+            StatusCode::NOT_FOUND,
+            "invalid_request_error",
+            Some("model_not_found"),
+            format!("model not found: {model}"),
+        )
+    };
+    if !model_is_allowed_for_key(&state, &key, &request_id, &model) {
+        return Err(not_found());
+    }
+    let backend = state
+        .select_backends_for_model_seeded(&model, Some(&key), Some(&request_id))
+        .ok()
+        .and_then(|backends| backends.into_iter().next())
+        .ok_or_else(not_found)?;
+    let upstream_model = state
+        .mapped_backend_model(&backend, &model)
+        .unwrap_or_else(|| model.clone());
+
+    #[allow(unused_mut)]
+    let mut source = state
+        .backend_model_info_source(&backend)
+        .unwrap_or_default();
+    #[cfg(feature = "gateway-translation")]
+    if source.provider.is_none() {
+        source.provider = state
+            .backends
+            .translation_backends
+            .get(&backend)
+            .map(|backend| backend.provider.clone());
+    }
+    let config = source
+        .model_info
+        .get(&model)
+        .or_else(|| source.model_info.get(&upstream_model));
+    let guardrail_context_window = state
+        .guardrails_for_model(Some(&model), &key)
+        .context_window
+        .map(|context_window| u64::from(context_window.max_tokens));
+    let catalog = catalog_model(source.provider.as_deref(), &upstream_model);
+    #[cfg(feature = "gateway-costing")]
+    let table_prices = state.proxy.pricing.as_ref().and_then(|pricing| {
+        pricing
+            .model_pricing(&model)
+            .or_else(|| pricing.model_pricing(&upstream_model))
+            .map(|pricing| ModelPrices {
+                // Micro-USD per token is USD per million tokens.
+                input_usd_per_million_tokens: Some(pricing.input_usd_micros_per_token as f64),
+                output_usd_per_million_tokens: Some(pricing.output_usd_micros_per_token as f64),
+            })
+    });
+    #[cfg(not(feature = "gateway-costing"))]
+    let table_prices = None;
+
+    let info = resolve_model_info(
+        config,
+        guardrail_context_window,
+        source.capabilities,
+        table_prices,
+        catalog.as_ref(),
+    );
+    let provider = source
+        .provider
+        .or_else(|| catalog.as_ref().map(|entry| entry.provider.to_string()));
+
+    let mut body = serde_json::json!({
+        "id": &model,
+        "object": "model.info",
+        "backend": &backend,
+        "upstream_model": &upstream_model,
+        "provider": provider,
+    });
+    if let (Some(body), Ok(serde_json::Value::Object(info))) =
+        (body.as_object_mut(), serde_json::to_value(&info))
+    {
+        body.extend(info);
+    }
+
+    let mut response = Json(body).into_response();
+    if let Ok(value) = axum::http::HeaderValue::from_str(&request_id) {
+        response.headers_mut().insert("x-request-id", value);
+    }
+    Ok(response)
+}
+
+/// Looks `model` up under the backend's provider first, then under every
+/// compiled-in provider, since proxied backends often leave `provider` unset.
+fn catalog_model(provider: Option<&str>, model: &str) -> Option<CatalogModel> {
+    let registry = builtin_registry();
+    let entry = |plugin: &'static ProviderPluginDescriptor| {
+        let descriptor = plugin.model(model)?;
+        let tool_calls = [OperationKind::CHAT_COMPLETION, OperationKind::RESPONSE]
+            .into_iter()
+            .find_map(|operation| plugin.behavior(descriptor.id, operation))
+            .map_or(BehaviorSupport::Unknown, |behavior| behavior.tool_calls);
+        Some(CatalogModel {
+            provider: plugin.id,
+            model: descriptor,
+            tool_calls,
+        })
+    };
+    provider
+        .and_then(|provider| registry.plugin(provider))
+        .and_then(entry)
+        .or_else(|| registry.plugins().iter().find_map(entry))
+}
+
+fn resolve_model_info(
+    config: Option<&ModelInfoConfig>,
+    guardrail_context_window: Option<u64>,
+    capabilities: Option<ProviderCapabilities>,
+    table_prices: Option<ModelPrices>,
+    catalog: Option<&CatalogModel>,
+) -> ModelInfo {
+    let descriptor = catalog.map(|entry| entry.model);
+    let modalities = |configured: Option<&Vec<String>>, catalog: Option<&[&str]>| match configured
+        .filter(|modalities| !modalities.is_empty())
+    {
+        Some(modalities) => modalities.clone(),
+        None => catalog
+            .unwrap_or_default()
+            .iter()
+            .map(|modality| modality.to_string())
+            .collect::<Vec<_>>(),
+    };
+
+    let mut input = modalities(
+        config.map(|config| &config.input_modalities),
+        descriptor.map(|descriptor| descriptor.input_modalities),
+    );
+    let mut output = modalities(
+        config.map(|config| &config.output_modalities),
+        descriptor.map(|descriptor| descriptor.output_modalities),
+    );
+    // Provider capabilities only say whether images are accepted.
+    if let Some(capabilities) = capabilities.filter(|_| input.is_empty()) {
+        input.push("text".to_string());
+        if capabilities.vision {
+            input.push("image".to_string());
+        }
+    }
+    if output.is_empty() && !input.is_empty() {
+        output.push("text".to_string());
+    }
+
+    let catalog_tool_calls = catalog.and_then(|entry| match entry.tool_calls {
+        BehaviorSupport::Supported => Some(true),
+        BehaviorSupport::Unsupported => Some(false),
+        BehaviorSupport::Unknown => None,
+    });
+    let table_prices = table_prices.unwrap_or_default();
+    let prices = ModelPrices {
+        input_usd_per_million_tokens: config
+            .and_then(|config| config.input_usd_per_million_tokens)
+            .or(table_prices.input_usd_per_million_tokens),
+        output_usd_per_million_tokens: config
+            .and_then(|config| config.output_usd_per_million_tokens)
+            .or(table_prices.output_usd_per_million_tokens),
+    };
+
+    let model_context_window = config
+        .and_then(|config| config.context_window)
+        .or_else(|| descriptor.and_then(|descriptor| descriptor.context_window));
+    let context_window = match (model_context_window, guardrail_context_window) {
+        (Some(window), Some(limit)) => Some(window.min(limit)),
+        (window, limit) => window.or(limit),
+    };
+
+    ModelInfo {
+        context_window,
+        max_output_tokens: config
+            .and_then(|config| config.max_output_tokens)
+            .or_else(|| descriptor.and_then(|descriptor| descriptor.max_output_tokens)),
+        modalities: ModelModalities { input, output },
+        supports_tool_calling: config
+            .and_then(|config| config.supports_tool_calling)
+            .or(capabilities.map(|capabilities| capabilities.tools))
+            .or(catalog_tool_calls),
+        pricing: (prices != ModelPrices::default()).then_some(prices),
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    const MODEL: ProviderModelDescriptor = ProviderModelDescriptor {
+        id: "demo-chat",
+        display_name: "Demo Chat",
+        aliases: &[],
+        brand: None,
+        family: None,
+        summary: None,
+        supported_operations: &[OperationKind::CHAT_COMPLETION],
+        capability_statuses: &[],
+        context_window: Some(128_000),
+        max_output_tokens: Some(16_384),
+        input_modalities: &["text", "image"],
+        output_modalities: &["text"],
+    };
+
+    fn catalog() -> CatalogModel {
+        CatalogModel {
+            provider: "demo",
+            model: &MODEL,
+            tool_calls: BehaviorSupport::Supported,
+        }
+    }
+
+    #[test]
+    fn config_overrides_catalog_per_field() {
+        let config = ModelInfoConfig {
+            max_output_tokens: Some(4_096),
+            output_usd_per_million_tokens: Some(2.5),
+            ..ModelInfoConfig::default()
+        };
+        let table_prices = ModelPrices {
+            input_usd_per_million_tokens: Some(0.5),
+            output_usd_per_million_tokens: Some(1.5),
+        };
+        let info = resolve_model_info(
+            Some(&config),
+            Some(32_000),
+            None,
+            Some(table_prices),
+            Some(&catalog()),
+        );
+        assert_eq!(
+            info,
+            ModelInfo {
+                context_window: Some(32_000),
+                max_output_tokens: Some(4_096),
+                modalities: ModelModalities {
+                    input: vec!["text".to_string(), "image".to_string()],
+                    output: vec!["text".to_string()],
+                },
+                supports_tool_calling: Some(true),
+                pricing: Some(ModelPrices {
+                    input_usd_per_million_tokens: Some(0.5),
+                    output_usd_per_million_tokens: Some(2.5),
+                }),
+            }
+        );
+    }
+
+    #[test]
+    fn falls_back_to_provider_capabilities_without_catalog_entry() {
+        let capabilities = ProviderCapabilities {
+            tools: false,
+            vision: true,
+            ..ProviderCapabilities::default()
+        };
+        let info = resolve_model_info(None, None, Some(capabilities), None, None);
+        assert_eq!(info.context_window, None);
+        assert_eq!(info.modalities.input, vec!["text", "image"]);
+        assert_eq!(info.modalities.output, vec!["text"]);
+        assert_eq!(info.supports_tool_calling, Some(false));
+        assert_eq!(info.pricing, None);
+
+        assert_eq!(
+            resolve_model_info(None, None, None, None, None),
+            ModelInfo::default()
+        );
+    }
+}
@@ -13,6 +13,23 @@ fn translation_model_is_routable(
         .is_ok_and(|backends| backends.iter().any(|candidate| candidate == backend_name))
 }
 
+/// Whether `key` can use `model`: it routes to a backend and the guardrails
+/// for that route (the key's, or its rule's override) allow it.
+pub(super) fn model_is_allowed_for_key(
+    state: &GatewayHttpState,
+    key: &VirtualKeyConfig,
+    request_id: &str,
+    model: &str,
+) -> bool {
+    state
+        .select_backends_for_model_seeded(model, Some(key), Some(request_id))
+        .is_ok()
+        && state
+            .guardrails_for_model(Some(model), key)
+            .check_model(model)
+            .is_none()
+}
+
 pub(super) async fn handle_openai_models_list(
     State(state): State<GatewayHttpState>,
     req: axum::http::Request<Body>,
@@ -24,7 +41,6 @@ pub(super) async fn handle_openai_models_list(
 
     let request_id =
         extract_header(&parts.headers, "x-request-id").unwrap_or_else(generate_request_id);
-    #[cfg(feature = "gateway-translation")]
     let created = now_epoch_seconds();
 
     let token = extract_virtual_key(&parts.headers).ok_or_else(|| {
@@ -46,6 +62,7 @@ pub(super) async fn handle_openai_models_list(
                 "unauthorized virtual key",
             )
         })?;
+    ensure_virtual_key_client_access(&state, &parts, &key).await?;
     let strip_authorization = true;
     let key_route = key.route.clone();
 
@@ -90,7 +107,7 @@ pub(super) async fn handle_openai_models_list(
     let had_proxy_backends = !backends.is_empty();
     let results = futures_util::future::join_all(backends.into_iter().map(|(name, backend)| {
         let mut headers = base_headers.clone();
-        apply_backend_headers(&mut headers, backend.headers());
+        apply_backend_headers(&mut headers, &backend.headers());
         let timeout = std::time::Duration::from_secs(PER_BACKEND_TIMEOUT_SECS);
         async move {
             let response = backend
@@ -109,6 +126,7 @@ pub(super) async fn handle_openai_models_list(
 
     let mut models_by_id: std::collections::BTreeMap<String, serde_json::Value> =
         std::collections::BTreeMap::new();
+    let mut discovered_models = false;
     for (backend_name, result) in results {
         let response = match result {
             Ok(response) => response,
@@ -135,6 +153,10 @@ pub(super) async fn handle_openai_models_list(
             let Some(id) = model.get("id").and_then(serde_json::Value::as_str) else {
                 continue;
             };
+            discovered_models = true;
+            if !model_is_allowed_for_key(&state, &key, &request_id, id) {
+                continue;
+            }
             models_by_id.entry(id.to_string()).or_insert(model);
         }
         emit_json_log(
@@ -168,7 +190,10 @@ pub(super) async fn handle_openai_models_list(
             let models =
                 super::translation::collect_models_from_translation_backend(&backend_name, backend);
             for (id, owned_by) in models {
-                if !translation_model_is_routable(&state, &key, &request_id, &id, &backend_name) {
+                discovered_models = true;
+                if !translation_model_is_routable(&state, &key, &request_id, &id, &backend_name)
+                    || !model_is_allowed_for_key(&state, &key, &request_id, &id)
+                {
                     continue;
                 }
                 models_by_id.entry(id.to_string()).or_insert_with(|| {
@@ -179,7 +204,25 @@ pub(super) async fn handle_openai_models_list(
         }
     }
 
-    if models_by_id.is_empty() {
+    // Model groups and aliases work even when no upstream lists them.
+    for alias in state.configured_model_aliases(&key) {
+        discovered_models = true;
+        if !model_is_allowed_for_key(&state, &key, &request_id, &alias) {
+            continue;
+        }
+        models_by_id.entry(alias.clone()).or_insert_with(|| {
+            serde_json::json!({
+                "id": alias,
+                "object": "model",
+                "created": created,
+                "owned_by": "ditto",
+            })
+        });
+    }
+
+    // A key allowed none of the discovered models gets an empty list, not an
+    // upstream error.
+    if !discovered_models {
         if !had_proxy_backends {
             return Err(openai_error(
                 StatusCode::BAD_GATEWAY,
@@ -0,0 +1,1295 @@
+//! `GET /openapi.json`: an OpenAPI 3.1 description of every route the gateway
+//! can serve, generated from [`ROUTE_GROUPS`]. Payloads forwarded to
+//! upstreams stay permissive JSON objects, as in the frozen v0.1 contract;
+//! what the document pins down is the gateway's own surface: paths, auth,
+//! required features, Ditto headers and error codes.
+//!
+//! The catalog lists routes whether or not this build or configuration
+//! serves them; `x-ditto-requires` names the feature an operation needs.
+//! Routes added to the router must be added here too (checked by a test).
+
+use super::*;
+
+#[derive(Clone, Copy, Debug, PartialEq, Eq)]
+enum RouteAuth {
+    Public,
+    VirtualKey,
+    AdminRead,
+    AdminWrite,
+}
+
+use RouteAuth::{AdminRead, AdminWrite, Public, VirtualKey};
+
+type RouteEntry = (&'static [&'static str], &'static str, &'static str);
+
+/// Routes sharing a tag, auth and required feature, as (methods, path in the
+/// router's `:param` / `*rest` syntax, summary). In an alias group the third
+/// field is the `/v1` path the route mirrors.
+struct RouteGroup {
+    tag: &'static str,
+    auth: RouteAuth,
+    requires: Option<&'static str>,
+    aliases: bool,
+    routes: &'static [RouteEntry],
+}
+
+impl RouteGroup {
+    const fn new(tag: &'static str, auth: RouteAuth, routes: &'static [RouteEntry]) -> Self {
+        Self {
+            tag,
+            auth,
+            requires: None,
+            aliases: false,
+            routes,
+        }
+    }
+
+    const fn requires(mut self, feature: &'static str) -> Self {
+        self.requires = Some(feature);
+        self
+    }
+
+    const fn aliases(mut self) -> Self {
+        self.aliases = true;
+        self
+    }
+}
+
+struct RouteSpec {
+    methods: &'static [&'static str],
+    path: &'static str,
+    tag: &'static str,
+    auth: RouteAuth,
+    summary: &'static str,
+    requires: Option<&'static str>,
+    alias_of: Option<&'static str>,
+}
+
+const GET: &[&str] = &["get"];
+const POST: &[&str] = &["post"];
+const PUT: &[&str] = &["put"];
+const DELETE: &[&str] = &["delete"];
+const GET_POST: &[&str] = &["get", "post"];
+const GET_DELETE: &[&str] = &["get", "delete"];
+const PUT_DELETE: &[&str] = &["put", "delete"];
+const MCP: &[&str] = &["get", "post", "delete"];
+const ANY: &[&str] = &["get", "post", "put", "patch", "delete"];
+
+const STORE: &str =
+    "gateway-store-sqlite | gateway-store-postgres | gateway-store-mysql | gateway-store-redis";
+const COSTING_STORE: &str = "gateway-costing + (gateway-store-sqlite | gateway-store-postgres \
+                             | gateway-store-mysql | gateway-store-redis)";
+
+const ROUTE_GROUPS: &[RouteGroup] = &[
+    RouteGroup::new(
+        "health",
+        Public,
+        &[
+            (GET, "/health", "Liveness probe"),
+            (
+                GET,
+                "/ready",
+                "Readiness of configured stores and backend health checks",
+            ),
+            (GET, "/health/liveness", "Liveness probe (same as /health)"),
+            (GET, "/health/readiness", "Readiness per model group"),
+            (GET, "/metrics", "Counter snapshot as JSON"),
+            (GET, "/openapi.json", "This document"),
+        ],
+    ),
+    RouteGroup::new(
+        "health",
+        Public,
+        &[(GET, "/metrics/prometheus", "Prometheus metrics")],
+    )
+    .requires("gateway-metrics-prometheus"),
+    RouteGroup::new(
+        "gateway",
+        VirtualKey,
+        &[(POST, "/v1/gateway", "Native gateway request")],
+    ),
+    RouteGroup::new(
+        "openai",
+        VirtualKey,
+        &[
+            (POST, "/v1/chat/completions", "Chat completions"),
+            (POST, "/v1/completions", "Legacy completions"),
+            (POST, "/v1/embeddings", "Embeddings"),
+            (POST, "/v1/moderations", "Moderations"),
+            (POST, "/v1/images/generations", "Image generation"),
+            (POST, "/v1/images/edits", "Image edits"),
+            (POST, "/v1/audio/transcriptions", "Audio transcription"),
+            (POST, "/v1/audio/translations", "Audio translation"),
+            (POST, "/v1/audio/speech", "Text to speech"),
+            (POST, "/v1/responses", "Responses API"),
+            (
+                POST,
+                "/v1/responses/compact",
+                "Compact a Responses conversation",
+            ),
+            (
+                POST,
+                "/v1/responses/input_tokens",
+                "Count Responses input tokens",
+            ),
+            (
+                GET_DELETE,
+                "/v1/responses/:response_id",
+                "Retrieve or delete a stored response",
+            ),
+            (GET_POST, "/v1/files", "List or upload files"),
+            (
+                GET_DELETE,
+                "/v1/files/:file_id",
+                "Retrieve or delete a file",
+            ),
+            (GET, "/v1/files/:file_id/content", "Download file content"),
+            (GET_POST, "/v1/batches", "List or create batches"),
+            (GET, "/v1/batches/:batch_id", "Retrieve a batch"),
+            (POST, "/v1/batches/:batch_id/cancel", "Cancel a batch"),
+            (
+                GET_POST,
+                "/v1/fine_tuning/jobs",
+                "List or create the project's fine-tuning jobs",
+            ),
+            (
+                GET,
+                "/v1/fine_tuning/jobs/:job_id",
+                "Retrieve a fine-tuning job",
+            ),
+            (
+                POST,
+                "/v1/fine_tuning/jobs/:job_id/cancel",
+                "Cancel a fine-tuning job",
+            ),
+            (GET_POST, "/v1/videos", "List or create videos"),
+            (GET, "/v1/videos/:video_id", "Retrieve a video"),
+            (POST, "/v1/rerank", "Rerank documents"),
+            (GET, "/v1/models", "Models the key can use"),
+            (GET, "/v1/models/*path", "Model details"),
+            (
+                ANY,
+                "/v1/*path",
+                "Passthrough of any other OpenAI-compatible path",
+            ),
+            (
+                POST,
+                "/utils/token_counter",
+                "Count prompt tokens for a model",
+            ),
+        ],
+    ),
+    RouteGroup::new(
+        "openai",
+        VirtualKey,
+        &[
+            (POST, "/chat/completions", "/v1/chat/completions"),
+            (POST, "/completions", "/v1/completions"),
+            (POST, "/embeddings", "/v1/embeddings"),
+            (POST, "/moderations", "/v1/moderations"),
+            (POST, "/images/generations", "/v1/images/generations"),
+            (POST, "/audio/transcriptions", "/v1/audio/transcriptions"),
+            (POST, "/audio/translations", "/v1/audio/translations"),
+            (POST, "/audio/speech", "/v1/audio/speech"),
+            (GET_POST, "/files", "/v1/files"),
+            (ANY, "/files/*path", "/v1/files"),
+            (POST, "/rerank", "/v1/rerank"),
+            (POST, "/v2/rerank", "/v1/rerank"),
+            (GET_POST, "/batches", "/v1/batches"),
+            (ANY, "/batches/*path", "/v1/batches"),
+            (GET, "/models", "/v1/models"),
+            (GET, "/models/*path", "/v1/models/*path"),
+            (POST, "/responses", "/v1/responses"),
+            (POST, "/responses/compact", "/v1/responses/compact"),
+            (ANY, "/responses/*path", "/v1/responses"),
+        ],
+    )
+    .aliases(),
+    RouteGroup::new(
+        "anthropic",
+        VirtualKey,
+        &[
+            (POST, "/v1/messages", "Anthropic Messages"),
+            (
+                POST,
+                "/v1/messages/count_tokens",
+                "Anthropic token counting",
+            ),
+        ],
+    ),
+    RouteGroup::new(
+        "anthropic",
+        VirtualKey,
+        &[
+            (POST, "/messages", "/v1/messages"),
+            (POST, "/messages/count_tokens", "/v1/messages/count_tokens"),
+        ],
+    )
+    .aliases(),
+    RouteGroup::new(
+        "google",
+        VirtualKey,
+        &[(
+            POST,
+            "/v1beta/models/*path",
+            "Gemini `{model}:generateContent` and streaming",
+        )],
+    ),
+    RouteGroup::new(
+        "a2a",
+        VirtualKey,
+        &[
+            (
+                GET,
+                "/a2a/:agent_id/.well-known/agent-card.json",
+                "A2A agent card",
+            ),
+            (POST, "/a2a/:agent_id", "A2A JSON-RPC invoke"),
+            (POST, "/a2a/:agent_id/message/send", "A2A message/send"),
+            (POST, "/a2a/:agent_id/message/stream", "A2A message/stream"),
+            (POST, "/v1/a2a/:agent_id/message/send", "A2A message/send"),
+            (
+                POST,
+                "/v1/a2a/:agent_id/message/stream",
+                "A2A message/stream",
+            ),
+        ],
+    ),
+    RouteGroup::new(
+        "mcp",
+        VirtualKey,
+        &[
+            (
+                GET_POST,
+                "/mcp/tools/list",
+                "List the tools of every MCP server",
+            ),
+            (POST, "/mcp/tools/call", "Call an MCP tool"),
+            (MCP, "/mcp", "MCP streamable HTTP endpoint over all servers"),
+            (
+                MCP,
+                "/mcp/",
+                "MCP streamable HTTP endpoint over all servers",
+            ),
+            (MCP, "/mcp/*subpath", "MCP endpoint of one server"),
+            (
+                MCP,
+                "/:mcp_servers/mcp",
+                "MCP endpoint over comma-separated servers",
+            ),
+            (
+                MCP,
+                "/:mcp_servers/mcp/*path",
+                "MCP endpoint over comma-separated servers",
+            ),
+        ],
+    ),
+    RouteGroup::new(
+        "key",
+        VirtualKey,
+        &[(
+            GET,
+            "/v1/key/info",
+            "The calling key's limits, budgets and what is left",
+        )],
+    ),
+    RouteGroup::new(
+        "key",
+        VirtualKey,
+        &[(GET, "/v1/key/usage", "The calling key's daily spend")],
+    )
+    .requires(STORE),
+    RouteGroup::new(
+        "admin",
+        AdminRead,
+        &[
+            (GET, "/admin/config/canary", "Current config canary"),
+            (GET, "/admin/config/version", "Active config version"),
+            (GET, "/admin/config/versions", "Config version history"),
+            (
+                GET,
+                "/admin/config/versions/:version_id",
+                "One config version",
+            ),
+            (GET, "/admin/config/export", "Export the active config"),
+            (
+                POST,
+                "/admin/config/validate",
+                "Validate a config without applying it",
+            ),
+            (GET, "/admin/config/diff", "Diff two config versions"),
+            (GET, "/admin/keys", "List virtual keys"),
+            (GET, "/admin/prompts", "List prompt templates"),
+            (
+                GET,
+                "/admin/prompts/:id",
+                "Latest version of a prompt template",
+            ),
+            (
+                GET,
+                "/admin/prompts/:id/versions",
+                "Versions of a prompt template",
+            ),
+            (
+                GET,
+                "/admin/prompts/:id/versions/:version",
+                "One prompt template version",
+            ),
+        ],
+    ),
+    RouteGroup::new(
+        "admin",
+        AdminWrite,
+        &[
+            (PUT, "/admin/config/canary", "Start a config canary"),
+            (
+                POST,
+                "/admin/config/canary/promote",
+                "Promote the config canary",
+            ),
+            (
+                POST,
+                "/admin/config/canary/rollback",
+                "Roll back the config canary",
+            ),
+            (PUT, "/admin/config/router", "Replace the router config"),
+            (
+                POST,
+                "/admin/config/rollback",
+                "Roll back to a config version",
+            ),
+            (POST, "/admin/keys", "Create or update a virtual key"),
+            (
+                PUT_DELETE,
+                "/admin/keys/:id",
+                "Upsert or delete a virtual key",
+            ),
+            (POST, "/admin/prompts", "Publish a prompt template version"),
+            (DELETE, "/admin/prompts/:id", "Delete a prompt template"),
+        ],
+    ),
+    RouteGroup::new(
+        "admin",
+        AdminWrite,
+        &[(POST, "/admin/proxy_cache/purge", "Purge the proxy cache")],
+    )
+    .requires("gateway-proxy-cache"),
+    RouteGroup::new(
+        "admin",
+        AdminRead,
+        &[(GET, "/admin/backends", "Backend health and cooldown state")],
+    )
+    .requires("gateway-routing-advanced"),
+    RouteGroup::new(
+        "admin",
+        AdminWrite,
+        &[(
+            POST,
+            "/admin/backends/:name/reset",
+            "Clear a backend's health state",
+        )],
+    )
+    .requires("gateway-routing-advanced"),
+    RouteGroup::new(
+        "admin",
+        AdminRead,
+        &[
+            (GET, "/admin/audit", "Audit log"),
+            (GET, "/admin/audit/export", "Hash-chained audit export"),
+            (GET, "/admin/budgets", "Budget ledgers per key"),
+            (GET, "/admin/budgets/tenants", "Budget ledgers per tenant"),
+            (GET, "/admin/budgets/projects", "Budget ledgers per project"),
+            (GET, "/admin/budgets/users", "Budget ledgers per user"),
+            (GET, "/admin/spend", "Spend report per key"),
+            (GET, "/admin/spend/tenants", "Spend report per tenant"),
+            (GET, "/admin/spend/projects", "Spend report per project"),
+            (GET, "/admin/spend/users", "Spend report per user"),
+            (GET, "/admin/spend/models", "Spend report per model"),
+            (GET, "/admin/spend/tags", "Spend report per request tag"),
+        ],
+    )
+    .requires(STORE),
+    RouteGroup::new(
+        "admin",
+        AdminWrite,
+        &[(
+            POST,
+            "/admin/reservations/reap",
+            "Reap stale budget reservations",
+        )],
+    )
+    .requires(STORE),
+    RouteGroup::new(
+        "admin",
+        AdminRead,
+        &[
+            (GET, "/admin/costs", "Cost ledgers per key"),
+            (GET, "/admin/costs/tenants", "Cost ledgers per tenant"),
+            (GET, "/admin/costs/projects", "Cost ledgers per project"),
+            (GET, "/admin/costs/users", "Cost ledgers per user"),
+        ],
+    )
+    .requires(COSTING_STORE),
+    RouteGroup::new(
+        "debug",
+        AdminRead,
+        &[
+            (GET, "/debug", "List the debug endpoints"),
+            (
+                GET,
+                "/debug/runtime",
+                "Process memory and tokio runtime stats",
+            ),
+            (GET, "/debug/threads", "OS thread dump"),
+            (GET, "/debug/requests", "Requests in flight, oldest first"),
+            (
+                GET,
+                "/debug/routing",
+                "Live router, canary and backend load",
+            ),
+        ],
+    ),
+    RouteGroup::new(
+        "litellm",
+        AdminRead,
+        &[
+            (GET, "/key/info", "LiteLLM-compatible key info"),
+            (GET, "/key/list", "LiteLLM-compatible key listing"),
+        ],
+    ),
+    RouteGroup::new(
+        "litellm",
+        AdminWrite,
+        &[
+            (POST, "/key/generate", "LiteLLM-compatible key generation"),
+            (POST, "/key/update", "LiteLLM-compatible key update"),
+            (POST, "/key/delete", "LiteLLM-compatible key deletion"),
+            (
+                POST,
+                "/key/regenerate",
+                "LiteLLM-compatible key regeneration",
+            ),
+            (
+                POST,
+                "/key/:key/regenerate",
+                "LiteLLM-compatible key regeneration",
+            ),
+        ],
+    ),
+];
+
+fn route_catalog() -> impl Iterator<Item = RouteSpec> {
+    ROUTE_GROUPS.iter().flat_map(|group| {
+        group
+            .routes
+            .iter()
+            .map(|&(methods, path, summary)| RouteSpec {
+                methods,
+                path,
+                tag: group.tag,
+                auth: group.auth,
+                summary: if group.aliases { "" } else { summary },
+                requires: group.requires,
+                alias_of: group.aliases.then_some(summary),
+            })
+    })
+}
+
+const TAGS: &[(&str, &str)] = &[
+    ("health", "Probes, metrics and this document; no auth"),
+    ("gateway", "Ditto's native request shape"),
+    (
+        "openai",
+        "OpenAI-compatible proxy; payloads are forwarded or translated per backend",
+    ),
+    ("anthropic", "Anthropic Messages compatible endpoints"),
+    ("google", "Gemini (Google GenAI) compatible endpoints"),
+    ("a2a", "Agents registered in `a2a_agents`"),
+    ("mcp", "MCP servers registered in `mcp_servers`"),
+    (
+        "key",
+        "Self-service endpoints a virtual key calls about itself",
+    ),
+    (
+        "admin",
+        "Admin API; mounted only when an admin token is configured",
+    ),
+    ("litellm", "LiteLLM-compatible key management"),
+    (
+        "debug",
+        "Runtime diagnostics; mounted only with `observability.debug_endpoints` and an admin token",
+    ),
+];
+
+/// Headers a client may send to proxy endpoints.
+const REQUEST_HEADERS: &[(&str, &str)] = &[
+    (
+        "x-request-id",
+        "Request id to propagate; generated when absent.",
+    ),
+    (
+        "idempotency-key",
+        "Repeats of a non-safe request replay the first response for 24 hours.",
+    ),
+    (
+        "x-request-timeout",
+        "Deadline in seconds (fractions allowed) across all backend attempts.",
+    ),
+    (
+        "x-ditto-tags",
+        "Comma-separated request tags for spend reports and audit; not forwarded.",
+    ),
+    (
+        "x-ditto-region",
+        "Comma-separated regions to serve the request in, within the key's.",
+    ),
+    (
+        "x-ditto-cache-bypass",
+        "Skips the proxy cache when present.",
+    ),
+    ("x-ditto-bypass-cache", "Same as `x-ditto-cache-bypass`."),
+    (
+        "x-ditto-experiment-key",
+        "Sticky key for A/B experiment arm assignment.",
+    ),
+];
+
+/// Headers proxy endpoints may return.
+const RESPONSE_HEADERS: &[(&str, &str)] = &[
+    (
+        "x-request-id",
+        "Request id, reused from the request or generated.",
+    ),
+    (
+        "x-ditto-request-id",
+        "Same as `x-request-id`; grep logs and audit by it.",
+    ),
+    ("x-ditto-backend", "Backend that served the response."),
+    (
+        "x-ditto-translation",
+        "Backend whose native API the request was translated to.",
+    ),
+    (
+        "x-ditto-shim",
+        "`responses_via_chat_completions` when Responses was emulated.",
+    ),
+    (
+        "x-ditto-cost",
+        "Cost of the request in USD, six decimals (needs pricing).",
+    ),
+    ("x-ditto-cache", "`hit` when served from the proxy cache."),
+    (
+        "x-ditto-cache-key",
+        "Proxy cache key of a cacheable request.",
+    ),
+    (
+        "x-ditto-cache-source",
+        "`memory` or `redis` on a cache hit.",
+    ),
+    (
+        "x-ditto-request-dedup",
+        "`leader` on a first response, `replay` on a replayed repeat.",
+    ),
+    (
+        "x-ditto-experiment",
+        "A/B experiment that assigned the request.",
+    ),
+    (
+        "x-ditto-experiment-arm",
+        "Experiment arm that served the response.",
+    ),
+    ("x-ditto-tier", "Key tier the request was admitted under."),
+    (
+        "x-ditto-admission",
+        "Tier admission decision: `admitted`, `queued`, `overflow` or `shed`.",
+    ),
+    (
+        "x-ditto-queue-ms",
+        "Milliseconds the request waited for tier admission.",
+    ),
+    (
+        "x-ditto-moderation",
+        "`flagged` when moderation annotated the request.",
+    ),
+    (
+        "x-ditto-moderation-categories",
+        "Comma-separated flagged moderation categories.",
+    ),
+    (
+        "x-ditto-prompt-injection",
+        "`flagged` when the prompt injection score crossed `tag`.",
+    ),
+    (
+        "x-ditto-prompt-injection-score",
+        "Prompt injection score, two decimals.",
+    ),
+    (
+        "x-ditto-context-strategy",
+        "Context window strategy applied.",
+    ),
+    (
+        "x-ditto-context-removed-messages",
+        "Messages removed to fit the context window.",
+    ),
+    (
+        "x-ditto-history-compressed-messages",
+        "Messages replaced by history compression.",
+    ),
+    (
+        "x-ditto-history-saved-tokens",
+        "Estimated input tokens saved by history compression.",
+    ),
+    (
+        "x-ratelimit-limit-requests",
+        "The key's requests per minute.",
+    ),
+    (
+        "x-ratelimit-remaining-requests",
+        "Requests left this minute.",
+    ),
+    (
+        "x-ratelimit-reset-requests",
+        "Time until the request window resets.",
+    ),
+    ("x-ratelimit-limit-tokens", "The key's tokens per minute."),
+    ("x-ratelimit-remaining-tokens", "Tokens left this minute."),
+    (
+        "x-ratelimit-reset-tokens",
+        "Time until the token window resets.",
+    ),
+];
+
+/// Errors the gateway itself returns in OpenAI's error envelope, as (HTTP
+/// status, `code`, meaning); `type` follows from the status. Upstream errors
+/// pass through unchanged and are not listed.
+const PROXY_ERROR_CODES: &[(u16, &str, &str)] = &[
+    (400, "invalid_request", "Malformed or unsupported request"),
+    (400, "invalid_json", "Request body is not valid JSON"),
+    (
+        400,
+        "context_length_exceeded",
+        "Prompt does not fit the model's context window",
+    ),
+    (
+        400,
+        "region_unavailable",
+        "No backend in the requested regions",
+    ),
+    (
+        400,
+        "invalid_request_timeout",
+        "`x-request-timeout` is not a positive number",
+    ),
+    (
+        400,
+        "cost_budget_unsupported_endpoint",
+        "Endpoint cannot be priced under a USD budget",
+    ),
+    (
+        400,
+        "prompt_variable_missing",
+        "Prompt template variable not supplied",
+    ),
+    (
+        400,
+        "sessions_not_enabled",
+        "`session_id` sent but sessions are not configured",
+    ),
+    (400, "invalid_mcp_request", "Malformed MCP request"),
+    (
+        401,
+        "invalid_api_key",
+        "Missing, unknown or disabled virtual key",
+    ),
+    (
+        402,
+        "budget_exceeded",
+        "Token budget of a scope is exhausted",
+    ),
+    (
+        402,
+        "cost_budget_exceeded",
+        "USD budget of a scope is exhausted",
+    ),
+    (
+        403,
+        "guardrail_blocked",
+        "Blocked by guardrails, moderation or injection detection",
+    ),
+    (
+        403,
+        "model_not_allowed",
+        "Model is not in the key's `allow_models`",
+    ),
+    (
+        403,
+        "mcp_server_not_allowed",
+        "MCP server is not allowed for the key",
+    ),
+    (
+        403,
+        "fine_tuning_not_allowed",
+        "Key may not manage fine-tuning jobs",
+    ),
+    (404, "model_not_found", "Unknown model"),
+    (404, "prompt_not_found", "Unknown prompt template"),
+    (404, "file_not_found", "Unknown file"),
+    (404, "batch_not_found", "Unknown batch"),
+    (404, "response_not_found", "Unknown stored response"),
+    (
+        404,
+        "fine_tuning_job_not_found",
+        "Unknown fine-tuning job, or one of another project",
+    ),
+    (404, "video_not_found", "Unknown video"),
+    (
+        409,
+        "idempotency_key_conflict",
+        "`Idempotency-Key` reused for a different request",
+    ),
+    (
+        409,
+        "request_id_conflict",
+        "`x-request-id` reused for a different request",
+    ),
+    (
+        413,
+        "request_too_large",
+        "Request body exceeds the configured limit",
+    ),
+    (429, "rate_limited", "RPM/TPM limit of a scope reached"),
+    (429, "inflight_limit", "Gateway concurrency limit reached"),
+    (
+        429,
+        "inflight_limit_backend",
+        "Backend concurrency limit reached",
+    ),
+    (
+        429,
+        "tier_capacity",
+        "Key tier over its capacity share past its queue timeout",
+    ),
+    (
+        429,
+        "tier_queue_full",
+        "Key tier queue already holds max_queue_len requests",
+    ),
+    (
+        429,
+        "spend_anomaly",
+        "Key throttled for anomalous spend this hour",
+    ),
+    (500, "storage_error", "Store failure"),
+    (
+        500,
+        "pricing_not_configured",
+        "USD budget configured without pricing",
+    ),
+    (
+        501,
+        "unsupported_endpoint",
+        "Endpoint not supported by the backend's provider",
+    ),
+    (502, "backend_error", "Every backend attempt failed"),
+    (502, "backend_not_found", "Route names an unknown backend"),
+    (
+        502,
+        "invalid_backend_response",
+        "Backend answered with an unreadable response",
+    ),
+    (
+        502,
+        "structured_output_invalid",
+        "Response does not match the requested JSON schema",
+    ),
+    (502, "upstream_failure", "Upstream failed before answering"),
+    (
+        503,
+        "session_store_unavailable",
+        "Session store unavailable",
+    ),
+    (
+        503,
+        "request_dedup_unavailable",
+        "Request dedup store unavailable",
+    ),
+    (
+        503,
+        "fine_tuning_store_unavailable",
+        "Fine-tuning job store unavailable",
+    ),
+    (504, "backend_timeout", "Backend did not answer in time"),
+    (
+        504,
+        "request_timeout",
+        "`x-request-timeout` elapsed before any backend answered",
+    ),
+];
+
+/// Codes of the admin API's `{"error": {"code", "message"}}` envelope.
+const ADMIN_ERROR_CODES: &[(u16, &str, &str)] = &[
+    (400, "invalid_request", "Malformed or invalid request"),
+    (400, "invalid_json", "Request body is not valid JSON"),
+    (400, "not_configured", "Feature or store not configured"),
+    (401, "unauthorized", "Missing or unknown admin token"),
+    (
+        403,
+        "forbidden",
+        "Token lacks write access or the tenant scope",
+    ),
+    (404, "not_found", "Unknown resource"),
+    (
+        409,
+        "conflict",
+        "Resource changed concurrently or already exists",
+    ),
+    (409, "secret_unavailable", "Secret could not be resolved"),
+    (500, "storage_error", "Store failure"),
+    (500, "encode_error", "Response could not be encoded"),
+];
+
+/// The OpenAI error `type` the gateway pairs with a status.
+fn proxy_error_type(status: u16) -> &'static str {
+    match status {
+        401 => "authentication_error",
+        402 => "insufficient_quota",
+        403 => "policy_error",
+        429 => "rate_limit_error",
+        500 | 502..=599 => "api_error",
+        _ => "invalid_request_error",
+    }
+}
+
+pub(super) async fn handle_openapi() -> Json<serde_json::Value> {
+    Json(openapi_document())
+}
+
+/// The OpenAPI 3.1 document served at `/openapi.json`.
+pub fn openapi_document() -> serde_json::Value {
+    let mut paths = serde_json::Map::new();
+    for route in route_catalog() {
+        let path = openapi_path(route.path);
+        let item = paths
+            .entry(path.clone())
+            .or_insert_with(|| serde_json::json!({}));
+        let params = path_parameters(route.path);
+        if !params.is_empty() {
+            item["parameters"] = serde_json::Value::Array(params);
+        }
+        for method in route.methods {
+            item[*method] = operation(&route, method);
+        }
+    }
+
+    let parameters = REQUEST_HEADERS
+        .iter()
+        .map(|(name, description)| {
+            (
+                header_component(name),
+                serde_json::json!({
+                    "name": name,
+                    "in": "header",
+                    "required": false,
+                    "description": description,
+                    "schema": {"type": "string"},
+                }),
+            )
+        })
+        .collect::<serde_json::Map<_, _>>();
+    let mut headers = RESPONSE_HEADERS
+        .iter()
+        .map(|(name, description)| {
+            (
+                header_component(name),
+                serde_json::json!({"description": description, "schema": {"type": "string"}}),
+            )
+        })
+        .collect::<serde_json::Map<_, _>>();
+    headers.insert(
+        "RetryAfter".to_string(),
+        serde_json::json!({
+            "description": "Seconds to wait before retrying.",
+            "schema": {"type": "integer"},
+        }),
+    );
+    headers.insert(
+        "XDittoSpendTruncated".to_string(),
+        serde_json::json!({
+            "description": "`true` when the report window held more audit records than a report scans.",
+            "schema": {"type": "string"},
+        }),
+    );
+
+    let proxy_codes = PROXY_ERROR_CODES
+        .iter()
+        .map(|(status, code, description)| {
+            serde_json::json!({
+                "code": code,
+                "type": proxy_error_type(*status),
+                "status": status,
+                "description": description,
+            })
+        })
+        .collect::<Vec<_>>();
+    let admin_codes = ADMIN_ERROR_CODES
+        .iter()
+        .map(|(status, code, description)| {
+            serde_json::json!({"code": code, "status": status, "description": description})
+        })
+        .collect::<Vec<_>>();
+
+    serde_json::json!({
+        "openapi": "3.1.0",
+        "info": {
+            "title": "Ditto Gateway",
+            "version": env!("CARGO_PKG_VERSION"),
+            "description": "Routes served by the Ditto LLM gateway. Proxy payloads follow the upstream APIs (OpenAI, Anthropic, Gemini) and are described as open JSON objects; `x-ditto-requires` names the build feature an operation needs, and `x-ditto-error-codes` lists the error codes the gateway itself returns.",
+        },
+        "tags": TAGS
+            .iter()
+            .map(|(name, description)| serde_json::json!({"name": name, "description": description}))
+            .collect::<Vec<_>>(),
+        "paths": paths,
+        "components": {
+            "securitySchemes": {
+                "virtualKey": {
+                    "type": "http",
+                    "scheme": "bearer",
+                    "description": "Virtual key as `Authorization: Bearer <key>`.",
+                },
+                "virtualKeyHeader": {"type": "apiKey", "in": "header", "name": "x-ditto-virtual-key"},
+                "apiKeyHeader": {"type": "apiKey", "in": "header", "name": "x-api-key"},
+                "adminBearer": {
+                    "type": "http",
+                    "scheme": "bearer",
+                    "description": "Admin token as `Authorization: Bearer <token>`.",
+                },
+                "adminToken": {"type": "apiKey", "in": "header", "name": "x-admin-token"},
+            },
+            "parameters": parameters,
+            "headers": headers,
+            "schemas": {
+                "OpenAiErrorResponse": {
+                    "type": "object",
+                    "required": ["error"],
+                    "properties": {
+                        "error": {
+                            "type": "object",
+                            "required": ["message", "type", "param", "code"],
+                            "properties": {
+                                "message": {"type": "string"},
+                                "type": {"type": "string"},
+                                "param": {"type": ["string", "null"]},
+                                "code": {
+                                    "type": ["string", "null"],
+                                    "description": "See `x-ditto-error-codes.proxy`.",
+                                },
+                            },
+                        },
+                    },
+                },
+                "AdminErrorResponse": {
+                    "type": "object",
+                    "required": ["error"],
+                    "properties": {
+                        "error": {
+                            "type": "object",
+                            "required": ["code", "message"],
+                            "properties": {
+                                "code": {
+                                    "type": "string",
+                                    "description": "See `x-ditto-error-codes.admin`.",
+                                },
+                                "message": {"type": "string"},
+                            },
+                        },
+                    },
+                },
+            },
+            "responses": {
+                "ProxyError": error_response("Gateway or upstream error", "OpenAiErrorResponse"),
+                "RateLimited": {
+                    "description": "Rate, concurrency or spend-anomaly limit reached",
+                    "headers": {"retry-after": {"$ref": "#/components/headers/RetryAfter"}},
+                    "content": {
+                        "application/json": {
+                            "schema": {"$ref": "#/components/schemas/OpenAiErrorResponse"},
+                        },
+                    },
+                },
+                "AdminError": error_response("Admin API error", "AdminErrorResponse"),
+            },
+        },
+        "x-ditto-error-codes": {"proxy": proxy_codes, "admin": admin_codes},
+    })
+}
+
+fn operation(route: &RouteSpec, method: &str) -> serde_json::Value {
+    let summary = match route.alias_of {
+        Some(of) => format!("Alias of `{}`", openapi_path(of)),
+        None => route.summary.to_string(),
+    };
+    let proxied = matches!(route.tag, "gateway" | "openai" | "anthropic" | "google");
+    let mut ok = serde_json::json!({"description": "Success"});
+    let mut content = serde_json::json!({"application/json": {"schema": {}}});
+    if accepts_stream(route.path) {
+        content["text/event-stream"] = serde_json::json!({"schema": {"type": "string"}});
+    }
+    ok["content"] = content;
+    if proxied {
+        ok["headers"] = RESPONSE_HEADERS
+            .iter()
+            .map(|(name, _)| {
+                (
+                    name.to_string(),
+                    serde_json::json!({"$ref": format!("#/components/headers/{}", header_component(name))}),
+                )
+            })
+            .collect::<serde_json::Map<_, _>>()
+            .into();
+    } else if route.path.starts_with("/admin/spend") {
+        ok["headers"] = serde_json::json!({
+            "x-ditto-spend-truncated": {"$ref": "#/components/headers/XDittoSpendTruncated"},
+        });
+    }
+
+    let mut responses = serde_json::json!({"200": ok});
+    let security = match route.auth {
+        RouteAuth::Public => serde_json::json!([]),
+        RouteAuth::VirtualKey => {
+            let proxy_error = serde_json::json!({"$ref": "#/components/responses/ProxyError"});
+            for status in ["401", "402", "403", "4XX", "5XX"] {
+                responses[status] = proxy_error.clone();
+            }
+            responses["429"] = serde_json::json!({"$ref": "#/components/responses/RateLimited"});
+            serde_json::json!([{"virtualKey": []}, {"virtualKeyHeader": []}, {"apiKeyHeader": []}])
+        }
+        RouteAuth::AdminRead | RouteAuth::AdminWrite => {
+            let admin_error = serde_json::json!({"$ref": "#/components/responses/AdminError"});
+            for status in ["401", "403", "4XX", "5XX"] {
+                responses[status] = admin_error.clone();
+            }
+            serde_json::json!([{"adminBearer": []}, {"adminToken": []}])
+        }
+    };
+
+    let mut op = serde_json::json!({
+        "operationId": operation_id(method, route.path),
+        "summary": summary,
+        "tags": [route.tag],
+        "security": security,
+        "responses": responses,
+    });
+    match route.auth {
+        RouteAuth::AdminRead => op["x-ditto-admin-access"] = "read".into(),
+        RouteAuth::AdminWrite => op["x-ditto-admin-access"] = "write".into(),
+        RouteAuth::Public | RouteAuth::VirtualKey => {}
+    }
+    if proxied {
+        op["parameters"] = REQUEST_HEADERS
+            .iter()
+            .map(|(name, _)| {
+                serde_json::json!({"$ref": format!("#/components/parameters/{}", header_component(name))})
+            })
+            .collect();
+    }
+    if matches!(method, "post" | "put" | "patch") {
+        let content_type = if is_multipart(route.path, method) {
+            "multipart/form-data"
+        } else {
+            "application/json"
+        };
+        op["requestBody"] = serde_json::json!({
+            "required": route.auth != RouteAuth::Public,
+            "content": {content_type: {"schema": {"type": "object"}}},
+        });
+    }
+    if let Some(feature) = route.requires {
+        op["x-ditto-requires"] = feature.into();
+    }
+    if let Some(of) = route.alias_of {
+        op["x-ditto-alias-of"] = openapi_path(of).into();
+    }
+    op
+}
+
+fn error_response(description: &str, schema: &str) -> serde_json::Value {
+    serde_json::json!({
+        "description": description,
+        "content": {
+            "application/json": {"schema": {"$ref": format!("#/components/schemas/{schema}")}},
+        },
+    })
+}
+
+/// `/a2a/:agent_id/*path` → `/a2a/{agent_id}/{path}`.
+fn openapi_path(path: &str) -> String {
+    path.split('/')
+        .map(|segment| match segment.strip_prefix([':', '*']) {
+            Some(name) => format!("{{{name}}}"),
+            None => segment.to_string(),
+        })
+        .collect::<Vec<_>>()
+        .join("/")
+}
+
+fn path_parameters(path: &str) -> Vec<serde_json::Value> {
+    path.split('/')
+        .filter_map(|segment| {
+            if let Some(name) = segment.strip_prefix(':') {
+                return Some(serde_json::json!({
+                    "name": name,
+                    "in": "path",
+                    "required": true,
+                    "schema": {"type": "string"},
+                }));
+            }
+            segment.strip_prefix('*').map(|name| {
+                serde_json::json!({
+                    "name": name,
+                    "in": "path",
+                    "required": true,
+                    "description": "Rest of the path; may contain `/`.",
+                    "schema": {"type": "string"},
+                })
+            })
+        })
+        .collect()
+}
+
+/// `post` + `/v1/chat/completions` → `post_v1_chat_completions`. A trailing
+/// `/` is kept apart from the path without it (`/mcp/` vs `/mcp`).
+fn operation_id(method: &str, path: &str) -> String {
+    let mut id = method.to_string();
+    for word in path
+        .split(|ch: char| !ch.is_ascii_alphanumeric())
+        .filter(|word| !word.is_empty())
+    {
+        id.push('_');
+        id.push_str(word);
+    }
+    if path.len() > 1 && path.ends_with('/') {
+        id.push_str("_slash");
+    }
+    id
+}
+
+/// `x-ditto-backend` → `XDittoBackend`.
+fn header_component(name: &str) -> String {
+    name.split('-')
+        .map(|word| {
+            let mut chars = word.chars();
+            match chars.next() {
+                Some(first) => first.to_ascii_uppercase().to_string() + chars.as_str(),
+                None => String::new(),
+            }
+        })
+        .collect()
+}
+
+fn accepts_stream(path: &str) -> bool {
+    matches!(
+        path,
+        "/v1/chat/completions"
+            | "/v1/completions"
+            | "/v1/responses"
+            | "/v1/messages"
+            | "/v1beta/models/*path"
+            | "/a2a/:agent_id/message/stream"
+            | "/v1/a2a/:agent_id/message/stream"
+            | "/chat/completions"
+            | "/completions"
+            | "/responses"
+            | "/messages"
+    )
+}
+
+fn is_multipart(path: &str, method: &str) -> bool {
+    method == "post"
+        && (path.ends_with("/audio/transcriptions")
+            || path.ends_with("/audio/translations")
+            || path.ends_with("/images/edits")
+            || path == "/v1/files"
+            || path == "/files")
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    use std::collections::BTreeSet;
+
+    /// Paths of `.route("...", ...)` calls in router source.
+    fn routed_paths(source: &str) -> Vec<String> {
+        source
+            .split(".route(")
+            .skip(1)
+            .filter_map(|rest| {
+                let rest = rest.trim_start().strip_prefix('"')?;
+                rest.split('"').next().map(str::to_string)
+            })
+            .collect()
+    }
+
+    #[test]
+    fn catalog_covers_every_routed_path() {
+        let catalog = route_catalog()
+            .map(|route| route.path)
+            .collect::<BTreeSet<_>>();
+        let routed = routed_paths(include_str!("router.rs"))
+            .into_iter()
+            .chain(routed_paths(include_str!("litellm_keys.rs")))
+            .collect::<Vec<_>>();
+        assert!(routed.len() > 80, "{routed:?}");
+        for path in routed {
+            assert!(
+                catalog.contains(path.as_str()),
+                "{path} is routed but missing from ROUTE_GROUPS"
+            );
+        }
+    }
+
+    #[test]
+    fn document_has_unique_operations_and_declared_path_params() {
+        let doc = openapi_document();
+        assert_eq!(doc["openapi"], "3.1.0");
+        let mut ids = BTreeSet::new();
+        for (path, item) in doc["paths"].as_object().unwrap() {
+            let declared = item["parameters"]
+                .as_array()
+                .map(|params| {
+                    params
+                        .iter()
+                        .map(|param| format!("{{{}}}", param["name"].as_str().unwrap()))
+                        .collect::<BTreeSet<_>>()
+                })
+                .unwrap_or_default();
+            let templated = path
+                .split('/')
+                .filter(|segment| segment.starts_with('{'))
+                .map(str::to_string)
+                .collect::<BTreeSet<_>>();
+            assert_eq!(declared, templated, "{path}");
+            for (method, op) in item.as_object().unwrap() {
+                if method == "parameters" {
+                    continue;
+                }
+                let id = op["operationId"].as_str().unwrap().to_string();
+                assert!(ids.insert(id.clone()), "duplicate operationId {id}");
+            }
+        }
+        let chat = &doc["paths"]["/v1/chat/completions"]["post"];
+        assert_eq!(
+            chat["responses"]["200"]["headers"]["x-ditto-backend"]["$ref"],
+            "#/components/headers/XDittoBackend"
+        );
+        assert_eq!(
+            doc["paths"]["/admin/keys"]["post"]["x-ditto-admin-access"],
+            "write"
+        );
+        assert_eq!(
+            doc["paths"]["/chat/completions"]["post"]["x-ditto-alias-of"],
+            "/v1/chat/completions"
+        );
+    }
+}
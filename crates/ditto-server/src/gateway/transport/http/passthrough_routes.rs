@@ -0,0 +1,104 @@
+use super::*;
+
+use axum::Router;
+use axum::extract::Request;
+use axum::routing::any;
+
+use crate::gateway::PassthroughRouteConfig;
+
+/// Request extension naming the backend of the pass-through route a request
+/// arrived on.
+#[derive(Clone, Debug)]
+struct PassthroughRouteBackend(String);
+
+/// Serves each route's prefix and everything below it.
+pub(super) fn attach_passthrough_routes(
+    mut router: Router<GatewayHttpState>,
+    routes: &[PassthroughRouteConfig],
+) -> Router<GatewayHttpState> {
+    for route in routes {
+        let route = Arc::new(route.clone());
+        let prefix = route.normalized_path_prefix().to_string();
+        let handler = move |State(state): State<GatewayHttpState>, req: Request| {
+            handle_passthrough_route(state, route.clone(), req)
+        };
+        router = router
+            .route(&prefix, any(handler.clone()))
+            .route(&format!("{prefix}/*path"), any(handler));
+    }
+    router
+}
+
+/// Strips the route prefix and hands the request to the OpenAI-compatible
+/// proxy, which authenticates the virtual key, applies limits and budgets and
+/// forwards it to the route's backend without model routing.
+async fn handle_passthrough_route(
+    state: GatewayHttpState,
+    route: Arc<PassthroughRouteConfig>,
+    mut req: Request,
+) -> Result<axum::response::Response, (StatusCode, Json<OpenAiErrorResponse>)> {
+    let backend = route.backend.trim();
+    if !state.backends.proxy_backends.contains_key(backend) {
+        return Err(openai_error(
+            StatusCode::BAD_GATEWAY,
+            "api_error",
+            Some("passthrough_backend_unavailable"),
+            format!("pass-through backend {backend} is not an HTTP proxy backend"),
+        ));
+    }
+    let path_and_query = req
+        .uri()
+        .path_and_query()
+        .map(|pq| pq.as_str())
+        .unwrap_or_else(|| req.uri().path());
+    let Some(upstream_path_and_query) = route.upstream_path_and_query(path_and_query) else {
+        return Err(openai_error(
+            StatusCode::NOT_FOUND,
+            "invalid_request_error",
+            Some("not_found"),
+            format!("no pass-through route for {path_and_query}"),
+        ));
+    };
+    *req.uri_mut() = upstream_path_and_query.parse().map_err(|err| {
+        openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("invalid_request"),
+            format!("invalid pass-through path: {err}"),
+        )
+    })?;
+    req.extensions_mut()
+        .insert(PassthroughRouteBackend(backend.to_string()));
+    handle_openai_compat_proxy(State(state), Path(String::new()), req).await
+}
+
+pub(super) fn is_passthrough_route_request(parts: &axum::http::request::Parts) -> bool {
+    parts.extensions.get::<PassthroughRouteBackend>().is_some()
+}
+
+/// The backends to try for a proxy request: the route's backend for
+/// pass-through routes, the backend holding the job for per-job fine-tuning
+/// calls, otherwise the router's choice for `model`, pinned by the
+/// experiment key when the route runs an experiment. Either way only backends
+/// in the regions required by the key or `x-ditto-region` remain.
+pub(super) fn select_proxy_backends(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+    model: &str,
+    key: Option<&VirtualKeyConfig>,
+    seed: Option<&str>,
+) -> Result<Vec<String>, GatewayError> {
+    let regions = crate::gateway::domain::required_regions(
+        key,
+        extract_header(&parts.headers, crate::gateway::REGION_HEADER).as_deref(),
+    )?;
+    let backends = if let Some(PassthroughRouteBackend(backend)) = parts.extensions.get() {
+        vec![backend.clone()]
+    } else if let Some(FineTuningJobRoute(Some(backend))) = parts.extensions.get() {
+        vec![backend.clone()]
+    } else {
+        let experiment_seed = experiment_route_seed(state, parts, model, key);
+        state.select_backends_for_model_seeded(model, key, experiment_seed.as_deref().or(seed))?
+    };
+    state.retain_backends_in_regions(backends, &regions)
+}
@@ -0,0 +1,109 @@
+use super::*;
+
+type ProxyError = (StatusCode, Json<OpenAiErrorResponse>);
+
+fn invalid_prompt_request(message: &str) -> ProxyError {
+    openai_error(
+        StatusCode::BAD_REQUEST,
+        "invalid_request_error",
+        Some("invalid_prompt_request"),
+        message,
+    )
+}
+
+/// Expands a chat completions request that names a stored prompt
+/// (`prompt_id`, optional `prompt_version` and `prompt_variables`) into the
+/// rendered template messages followed by any `messages` the client sent.
+/// The template's `model` applies when the request does not set one.
+pub(super) fn render_prompt_request(
+    state: &GatewayHttpState,
+    request_id: &str,
+    path_and_query: &str,
+    headers: &mut HeaderMap,
+    body: Bytes,
+    parsed_json: Option<Value>,
+) -> Result<(Bytes, Option<Value>), ProxyError> {
+    let path = path_and_query
+        .split_once('?')
+        .map(|(path, _)| path)
+        .unwrap_or(path_and_query);
+    if path.trim_end_matches('/') != "/v1/chat/completions" {
+        return Ok((body, parsed_json));
+    }
+    let mut request = match parsed_json {
+        Some(Value::Object(request)) => request,
+        other => return Ok((body, other)),
+    };
+    let prompt_id = match request.remove("prompt_id") {
+        None => return Ok((body, Some(Value::Object(request)))),
+        Some(Value::String(id)) if !id.trim().is_empty() => id.trim().to_string(),
+        Some(_) => {
+            return Err(invalid_prompt_request(
+                "prompt_id must be a non-empty string",
+            ));
+        }
+    };
+    let version = match request.remove("prompt_version") {
+        None | Some(Value::Null) => None,
+        Some(version) => Some(
+            version
+                .as_u64()
+                .and_then(|version| u32::try_from(version).ok())
+                .ok_or_else(|| invalid_prompt_request("prompt_version must be an integer"))?,
+        ),
+    };
+    let variables = match request.remove("prompt_variables") {
+        None | Some(Value::Null) => serde_json::Map::new(),
+        Some(Value::Object(variables)) => variables,
+        Some(_) => return Err(invalid_prompt_request("prompt_variables must be an object")),
+    };
+
+    let template = state.prompt_template(&prompt_id, version).ok_or_else(|| {
+        openai_error(
+            StatusCode::NOT_FOUND,
+            "invalid_request_error",
+            Some("prompt_not_found"),
+            match version {
+                Some(version) => format!("prompt template not found: {prompt_id}@{version}"),
+                None => format!("prompt template not found: {prompt_id}"),
+            },
+        )
+    })?;
+    let mut messages = template.render(&variables).map_err(|err| {
+        openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("prompt_variable_missing"),
+            err,
+        )
+    })?;
+    match request.remove("messages") {
+        None | Some(Value::Null) => {}
+        Some(Value::Array(extra)) => messages.extend(extra),
+        Some(_) => return Err(invalid_prompt_request("messages must be an array")),
+    }
+
+    emit_json_log(
+        state,
+        "proxy.prompt",
+        serde_json::json!({
+            "request_id": request_id,
+            "prompt_id": &template.id,
+            "prompt_version": template.version,
+            "messages": &messages,
+        }),
+    );
+
+    request.insert("messages".to_string(), Value::Array(messages));
+    if let Some(model) = template.model
+        && !request.contains_key("model")
+    {
+        request.insert("model".to_string(), Value::String(model));
+    }
+    let rendered = Value::Object(request);
+    headers.remove("content-length");
+    Ok((
+        Bytes::from(serde_json::to_vec(&rendered).unwrap_or_default()),
+        Some(rendered),
+    ))
+}
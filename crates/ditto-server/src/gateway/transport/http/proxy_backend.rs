@@ -19,6 +19,8 @@ pub(super) async fn attempt_proxy_backend(
     let path_and_query = params.path_and_query;
     let _now_epoch_seconds = params.now_epoch_seconds;
     let charge_tokens = params.charge_tokens;
+    let charge_input_tokens = params.charge_input_tokens;
+    let deadline = params.deadline;
     let _stream_requested = params.stream_requested;
     let strip_authorization = params.strip_authorization;
     let use_persistent_budget = params.use_persistent_budget;
@@ -104,11 +106,14 @@ pub(super) async fn attempt_proxy_backend(
         }
     };
 
-    let mut proxy_permits = match try_acquire_proxy_permits(state, &backend_name)? {
-        ProxyPermitOutcome::Acquired(permits) => permits,
-        ProxyPermitOutcome::BackendRateLimited(err) => {
-            return Ok(BackendAttemptOutcome::Continue(Some(err)));
-        }
+    let mut proxy_permits = match take_reserved_proxy_permits(parts, &backend_name) {
+        Some(permits) => permits,
+        None => match try_acquire_proxy_permits(state, &backend_name)? {
+            ProxyPermitOutcome::Acquired(permits) => permits,
+            ProxyPermitOutcome::BackendRateLimited(err) => {
+                return Ok(BackendAttemptOutcome::Continue(Some(err)));
+            }
+        },
     };
 
     state.record_backend_call();
@@ -126,8 +131,9 @@ pub(super) async fn attempt_proxy_backend(
 
     let mut outgoing_headers = parts.headers.clone();
     sanitize_proxy_headers(&mut outgoing_headers, strip_authorization);
-    apply_backend_headers(&mut outgoing_headers, backend.headers());
+    apply_backend_headers(&mut outgoing_headers, &backend.headers());
     insert_request_id(&mut outgoing_headers, &request_id);
+    insert_request_timeout(&mut outgoing_headers, deadline);
 
     let (outgoing_body, upstream_model) = if let (Some(request_model), Some(parsed_json)) =
         (model.as_deref(), parsed_json.as_ref())
@@ -173,17 +179,53 @@ pub(super) async fn attempt_proxy_backend(
             "upstream_model": upstream_model.as_deref(),
             "virtual_key_id": virtual_key_id.as_deref(),
             "body_len": body.len(),
+            "body": parsed_json.as_ref(),
         }),
     );
 
-    let upstream_response = match backend
-        .request(
-            parts.method.clone(),
-            path_and_query,
-            outgoing_headers,
-            Some(outgoing_body),
-        )
-        .await
+    // Embeddings with more inputs than the backend takes in one call go out
+    // as concurrent batches and come back as one merged response.
+    let embeddings_batches = state
+        .backend_embeddings_max_batch(&backend_name)
+        .filter(|_| path_and_query.split('?').next() == Some("/v1/embeddings"))
+        .and_then(|max_batch| {
+            let body_json = serde_json::from_slice::<serde_json::Value>(&outgoing_body).ok()?;
+            Some((split_embeddings_request(&body_json, max_batch)?, max_batch))
+        });
+    let request_timeout = cap_timeout(backend.request_timeout(), deadline);
+    let upstream_request = async {
+        match embeddings_batches {
+            Some((batches, max_batch)) => {
+                send_embeddings_batches(
+                    &backend,
+                    parts.method.clone(),
+                    path_and_query,
+                    outgoing_headers,
+                    batches,
+                    max_batch,
+                    request_timeout,
+                    state.proxy.max_body_bytes,
+                )
+                .await
+            }
+            None => {
+                backend
+                    .request_with_timeout(
+                        parts.method.clone(),
+                        path_and_query,
+                        outgoing_headers,
+                        Some(outgoing_body),
+                        request_timeout,
+                    )
+                    .await
+            }
+        }
+    };
+    let (upstream_response, first_chunk) = match send_with_first_token_timeout(
+        upstream_request,
+        cap_timeout(backend.first_token_timeout(), deadline).filter(|_| _stream_requested),
+    )
+    .await
     {
         Ok(response) => response,
         Err(err) => {
@@ -267,6 +309,7 @@ pub(super) async fn attempt_proxy_backend(
     let status = upstream_response.status();
 
     if responses_shim::should_attempt_responses_shim(&parts.method, path_and_query, status)
+        && !is_passthrough_route_request(parts)
         && let Some(parsed_json) = parsed_json.as_ref()
     {
         let _ = proxy_permits.take();
@@ -333,8 +376,9 @@ pub(super) async fn attempt_proxy_backend(
 
         let mut shim_headers = parts.headers.clone();
         sanitize_proxy_headers(&mut shim_headers, strip_authorization);
-        apply_backend_headers(&mut shim_headers, backend.headers());
+        apply_backend_headers(&mut shim_headers, &backend.headers());
         insert_request_id(&mut shim_headers, &request_id);
+        insert_request_timeout(&mut shim_headers, deadline);
         if _stream_requested {
             shim_headers.insert(
                 axum::http::header::ACCEPT,
@@ -359,11 +403,12 @@ pub(super) async fn attempt_proxy_backend(
         }
 
         let shim_response = match backend
-            .request(
+            .request_with_timeout(
                 parts.method.clone(),
                 "/v1/chat/completions",
                 shim_headers,
                 Some(chat_body_bytes),
+                cap_timeout(backend.request_timeout(), deadline),
             )
             .await
         {
@@ -494,6 +539,12 @@ pub(super) async fn attempt_proxy_backend(
             record_proxy_backend_success(state, &backend_name).await;
         }
 
+        if is_upstream_overload(status, shim_response.headers()) {
+            let retry_after =
+                upstream_retry_after_seconds(state, &backend_name, shim_response.headers()).await;
+            record_upstream_overload(&parts.extensions, status, retry_after);
+        }
+
         #[cfg(feature = "gateway-routing-advanced")]
         if decision.should_attempt_next_backend(idx, max_attempts) {
             return Ok(BackendAttemptOutcome::Continue(Some(
@@ -717,6 +768,7 @@ pub(super) async fn attempt_proxy_backend(
                 "charge_cost_usd_micros": charge_cost_usd_micros,
                 "body_len": body.len(),
                 "shim": "responses_via_chat_completions",
+                "tags": request_tags(&parts.headers, parsed_json.as_ref()),
             });
 
             append_audit_log(state, "proxy", payload)
@@ -867,6 +919,12 @@ pub(super) async fn attempt_proxy_backend(
         record_proxy_backend_success(state, &backend_name).await;
     }
 
+    if is_upstream_overload(status, upstream_response.headers()) {
+        let retry_after =
+            upstream_retry_after_seconds(state, &backend_name, upstream_response.headers()).await;
+        record_upstream_overload(&parts.extensions, status, retry_after);
+    }
+
     #[cfg(feature = "gateway-routing-advanced")]
     if decision.should_attempt_next_backend(idx, max_attempts) {
         return Ok(BackendAttemptOutcome::Continue(Some(
@@ -915,115 +973,57 @@ pub(super) async fn attempt_proxy_backend(
     if is_event_stream {
         // inlined from proxy_backend/stream.rs
         {
-            const SSE_USAGE_TRACKER_MAX_BUFFER_BYTES: usize = 512 * 1024;
-            const SSE_USAGE_TRACKER_TAIL_BYTES: usize = 128 * 1024;
             const PROXY_SSE_ABORT_FINALIZER_WORKERS: usize = 2;
             const PROXY_SSE_ABORT_FINALIZER_QUEUE_CAPACITY: usize = 1024;
 
             #[derive(Default)]
             struct SseUsageTracker {
-                buffer: bytes::BytesMut,
+                scanner: SseEventScanner,
                 observed_usage: Option<ObservedUsage>,
+                streamed_output_bytes: usize,
             }
 
             impl SseUsageTracker {
                 fn ingest(&mut self, chunk: &Bytes) {
-                    self.buffer.extend_from_slice(chunk.as_ref());
-
-                    loop {
-                        let Some((pos, delimiter_len)) = find_sse_delimiter(self.buffer.as_ref())
-                        else {
-                            break;
-                        };
-
-                        let event_bytes = self.buffer.split_to(pos);
-                        let _ = self.buffer.split_to(delimiter_len);
-
-                        let Some(data) = extract_sse_data(event_bytes.as_ref()) else {
-                            continue;
-                        };
-                        let trimmed = trim_ascii_whitespace(&data);
-                        if trimmed == b"[DONE]" {
-                            continue;
+                    let Self {
+                        scanner,
+                        observed_usage,
+                        streamed_output_bytes,
+                    } = self;
+                    scanner.feed(chunk.as_ref(), |data| {
+                        let trimmed = data.trim_ascii();
+                        if trimmed == b"[DONE]" || !trimmed.starts_with(b"{") {
+                            return;
                         }
-
-                        if trimmed.starts_with(b"{")
-                            && let Some(usage) = extract_openai_usage_from_slice(trimmed)
-                        {
-                            self.observed_usage = Some(usage);
+                        if let Some(usage) = extract_openai_usage_from_slice(trimmed) {
+                            *observed_usage = Some(match *observed_usage {
+                                Some(earlier) => usage.or_earlier(earlier),
+                                None => usage,
+                            });
                         }
-                    }
-
-                    if self.buffer.len() > SSE_USAGE_TRACKER_MAX_BUFFER_BYTES {
-                        let keep_from = self
-                            .buffer
-                            .len()
-                            .saturating_sub(SSE_USAGE_TRACKER_TAIL_BYTES);
-                        self.buffer = self.buffer.split_off(keep_from);
-                    }
+                        if observed_usage.is_none() {
+                            *streamed_output_bytes = streamed_output_bytes
+                                .saturating_add(extract_streamed_output_bytes_from_slice(trimmed));
+                        }
+                    });
                 }
 
                 fn observed_usage(&self) -> Option<ObservedUsage> {
                     self.observed_usage
                 }
-            }
 
-            fn find_sse_delimiter(buf: &[u8]) -> Option<(usize, usize)> {
-                if buf.len() < 2 {
-                    return None;
-                }
-
-                // Use a single forward scan so mixed newline styles still split at the earliest
-                // event boundary instead of whichever delimiter pattern we searched first.
-                let mut idx = 0usize;
-                while idx + 1 < buf.len() {
-                    if buf[idx] == b'\n' && buf[idx + 1] == b'\n' {
-                        return Some((idx, 2));
-                    }
-                    if idx + 3 < buf.len()
-                        && buf[idx] == b'\r'
-                        && buf[idx + 1] == b'\n'
-                        && buf[idx + 2] == b'\r'
-                        && buf[idx + 3] == b'\n'
-                    {
-                        return Some((idx, 4));
-                    }
-                    idx += 1;
-                }
-
-                None
-            }
-
-            fn extract_sse_data(event: &[u8]) -> Option<Vec<u8>> {
-                let mut out = Vec::<u8>::new();
-                for line in event.split(|b| *b == b'\n') {
-                    let line = line.strip_suffix(b"\r").unwrap_or(line);
-                    let Some(rest) = line.strip_prefix(b"data:") else {
-                        continue;
-                    };
-                    let rest = trim_ascii_whitespace(rest);
-                    if rest.is_empty() {
-                        continue;
-                    }
-                    if !out.is_empty() {
-                        out.push(b'\n');
+                /// Usage for a stream that stopped before the upstream reported
+                /// any: the prompt estimate plus the output streamed so far.
+                fn partial_usage(&self, input_tokens: u32) -> ObservedUsage {
+                    let input_tokens = u64::from(input_tokens);
+                    let output_tokens = (self.streamed_output_bytes as u64).saturating_add(3) / 4;
+                    ObservedUsage {
+                        input_tokens: Some(input_tokens),
+                        output_tokens: Some(output_tokens),
+                        total_tokens: Some(input_tokens.saturating_add(output_tokens)),
+                        ..ObservedUsage::default()
                     }
-                    out.extend_from_slice(rest);
                 }
-                (!out.is_empty()).then_some(out)
-            }
-
-            fn trim_ascii_whitespace(bytes: &[u8]) -> &[u8] {
-                let start = bytes
-                    .iter()
-                    .position(|b| !b.is_ascii_whitespace())
-                    .unwrap_or(bytes.len());
-                let end = bytes
-                    .iter()
-                    .rposition(|b| !b.is_ascii_whitespace())
-                    .map(|pos| pos + 1)
-                    .unwrap_or(start);
-                &bytes[start..end]
             }
 
             #[derive(Clone, Copy, Debug)]
@@ -1063,6 +1063,7 @@ pub(super) async fn attempt_proxy_backend(
                 backend_model_map: BTreeMap<String, String>,
                 status: u16,
                 charge_tokens: u32,
+                charge_input_tokens: u32,
                 charge_cost_usd_micros: Option<u64>,
                 spend_tokens: bool,
                 local_token_budget_reserved: bool,
@@ -1115,6 +1116,13 @@ pub(super) async fn attempt_proxy_backend(
                     feature = "sdk"
                 ))]
                 request_body_len: usize,
+                #[cfg(any(
+                    feature = "gateway-store-sqlite",
+                    feature = "gateway-store-postgres",
+                    feature = "gateway-store-mysql",
+                    feature = "gateway-store-redis"
+                ))]
+                request_tags: Vec<String>,
             }
 
             impl ProxySseFinalizer {
@@ -1450,6 +1458,7 @@ pub(super) async fn attempt_proxy_backend(
                             "spent_cost_usd_micros": spent_cost_usd_micros,
                             "body_len": self.request_body_len,
                             "stream": true,
+                            "tags": &self.request_tags,
                         });
                         if let Err(err) = append_audit_log(&self.state, "proxy", payload).await {
                             emit_json_log(
@@ -1483,6 +1492,7 @@ pub(super) async fn attempt_proxy_backend(
                             "reasoning_tokens": observed_usage.and_then(|usage| usage.reasoning_tokens),
                             "total_tokens": observed_usage.and_then(|usage| usage.total_tokens),
                             "spent_tokens": spent_tokens,
+                            "client_disconnected": matches!(end, StreamEnd::Aborted),
                         }),
                     );
 
@@ -1634,7 +1644,12 @@ pub(super) async fn attempt_proxy_backend(
                     let Some(finalizer) = self.finalizer.take() else {
                         return;
                     };
-                    let observed = self.tracker.observed_usage();
+                    // The client went away mid-stream. Dropping `upstream` with
+                    // this state cancels the upstream request, so settle on what
+                    // was actually generated rather than the full estimate.
+                    let observed = Some(self.tracker.observed_usage().unwrap_or_else(|| {
+                        self.tracker.partial_usage(finalizer.charge_input_tokens)
+                    }));
                     let bytes_sent = self.bytes_sent;
                     enqueue_proxy_sse_abort_finalize(finalizer, observed, bytes_sent);
                 }
@@ -1674,9 +1689,12 @@ pub(super) async fn attempt_proxy_backend(
                 tracing::Span::current().record("status", tracing::field::display(status.as_u16()));
             }
 
-            let upstream_stream: ProxyBodyStream = upstream_response
-                .bytes_stream()
-                .map(|chunk| chunk.map_err(std::io::Error::other))
+            let upstream_stream: ProxyBodyStream = futures_util::stream::iter(first_chunk.map(Ok))
+                .chain(
+                    upstream_response
+                        .bytes_stream()
+                        .map(|chunk| chunk.map_err(std::io::Error::other)),
+                )
                 .boxed();
 
             #[cfg(any(
@@ -1728,6 +1746,7 @@ pub(super) async fn attempt_proxy_backend(
                 backend_model_map: backend_model_map.clone(),
                 status: status.as_u16(),
                 charge_tokens,
+                charge_input_tokens,
                 charge_cost_usd_micros,
                 spend_tokens,
                 local_token_budget_reserved,
@@ -1780,6 +1799,13 @@ pub(super) async fn attempt_proxy_backend(
                     feature = "sdk"
                 ))]
                 request_body_len: body.len(),
+                #[cfg(any(
+                    feature = "gateway-store-sqlite",
+                    feature = "gateway-store-postgres",
+                    feature = "gateway-store-mysql",
+                    feature = "gateway-store-redis"
+                ))]
+                request_tags: request_tags(&parts.headers, parsed_json.as_ref()),
             };
 
             #[cfg(feature = "gateway-metrics-prometheus")]
@@ -1790,6 +1816,7 @@ pub(super) async fn attempt_proxy_backend(
                     .record_proxy_stream_open(&backend_name, metrics_path);
             }
 
+            let sse_keepalive_interval = state.proxy.sse_keepalive_interval;
             let state = ProxySseStreamState {
                 upstream: upstream_stream,
                 tracker: SseUsageTracker::default(),
@@ -1829,7 +1856,9 @@ pub(super) async fn attempt_proxy_backend(
                 }
             });
 
-            let mut response = axum::response::Response::new(Body::from_stream(stream));
+            let mut response = axum::response::Response::new(Body::from_stream(
+                with_sse_keepalive(stream, sse_keepalive_interval),
+            ));
             *response.status_mut() = status;
             *response.headers_mut() = headers;
             return Ok(BackendAttemptOutcome::Response(response));
@@ -1986,8 +2015,8 @@ pub(super) async fn attempt_proxy_backend(
                         .unwrap_or(request_model)
                 })
                 .and_then(|cost_model| {
-                    state.proxy.pricing.as_ref().and_then(|pricing| {
-                        let usage = observed_usage?;
+                    let pricing = state.proxy.pricing.as_ref()?;
+                    let token_cost = observed_usage.and_then(|usage| {
                         let input = usage.input_tokens?;
                         let output = usage.output_tokens?;
                         pricing.estimate_cost_usd_micros_with_cache_for_service_tier(
@@ -1998,6 +2027,17 @@ pub(super) async fn attempt_proxy_backend(
                             clamp_u64_to_u32(output),
                             service_tier.as_deref(),
                         )
+                    });
+                    token_cost.or_else(|| match &response_body {
+                        ProxyResponseBody::Bytes(bytes) if should_attempt_buffer_for_usage => {
+                            estimate_media_cost_usd_micros(
+                                pricing,
+                                cost_model,
+                                path_and_query,
+                                bytes,
+                            )
+                        }
+                        _ => None,
                     })
                 })
                 .or(charge_cost_usd_micros)
@@ -2178,6 +2218,7 @@ pub(super) async fn attempt_proxy_backend(
                 "charge_cost_usd_micros": charge_cost_usd_micros,
                 "spent_cost_usd_micros": spent_cost_usd_micros,
                 "body_len": body.len(),
+                "tags": request_tags(&parts.headers, parsed_json.as_ref()),
             });
             append_audit_log(state, "proxy", payload)
                 .await
@@ -2249,6 +2290,8 @@ pub(super) async fn attempt_proxy_backend(
 
         let mut headers = upstream_headers;
         apply_proxy_response_headers(&mut headers, &backend_name, &request_id, false);
+        #[cfg(feature = "gateway-costing")]
+        insert_cost_header(&mut headers, spent_cost_usd_micros);
         #[cfg(feature = "gateway-proxy-cache")]
         if let Some(cache_key) = proxy_cache_key.as_deref()
             && let Ok(value) = axum::http::HeaderValue::from_str(cache_key)
@@ -2464,6 +2507,14 @@ fn openai_status_routing_error(
         }
     };
 
+    if is_overload_status(status) {
+        return openai_error(
+            StatusCode::TOO_MANY_REQUESTS,
+            "rate_limit_error",
+            Some("backend_error"),
+            message,
+        );
+    }
     openai_error(status, "api_error", Some("backend_error"), message)
 }
 
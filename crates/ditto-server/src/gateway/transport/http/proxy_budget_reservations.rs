@@ -47,10 +47,12 @@ pub(super) async fn reserve_proxy_token_budgets_for_request(
     let token_budget_reserved = if use_persistent_budget {
         if let (Some(virtual_key_id), Some(budget)) = (virtual_key_id, budget) {
             if let Some(limit) = budget.total_tokens {
+                let (budget_scope, limit) =
+                    persistent_budget_window(state, virtual_key_id, budget, limit, false).await;
                 let ctx = ProxyBudgetReservationContext {
                     state,
                     reservation_id: request_id,
-                    budget_scope: virtual_key_id,
+                    budget_scope: &budget_scope,
                     request_id,
                     virtual_key_id,
                     path_and_query,
@@ -78,10 +80,12 @@ pub(super) async fn reserve_proxy_token_budgets_for_request(
             && let Some(limit) = budget.total_tokens
         {
             let reservation_id = format!("{request_id}::budget::{scope}");
+            let (budget_scope, limit) =
+                persistent_budget_window(state, scope, budget, limit, false).await;
             let ctx = ProxyBudgetReservationContext {
                 state,
                 reservation_id: &reservation_id,
-                budget_scope: scope,
+                budget_scope: &budget_scope,
                 request_id,
                 virtual_key_id,
                 path_and_query,
@@ -99,10 +103,12 @@ pub(super) async fn reserve_proxy_token_budgets_for_request(
             && let Some(limit) = budget.total_tokens
         {
             let reservation_id = format!("{request_id}::budget::{scope}");
+            let (budget_scope, limit) =
+                persistent_budget_window(state, scope, budget, limit, false).await;
             let ctx = ProxyBudgetReservationContext {
                 state,
                 reservation_id: &reservation_id,
-                budget_scope: scope,
+                budget_scope: &budget_scope,
                 request_id,
                 virtual_key_id,
                 path_and_query,
@@ -120,10 +126,12 @@ pub(super) async fn reserve_proxy_token_budgets_for_request(
             && let Some(limit) = budget.total_tokens
         {
             let reservation_id = format!("{request_id}::budget::{scope}");
+            let (budget_scope, limit) =
+                persistent_budget_window(state, scope, budget, limit, false).await;
             let ctx = ProxyBudgetReservationContext {
                 state,
                 reservation_id: &reservation_id,
-                budget_scope: scope,
+                budget_scope: &budget_scope,
                 request_id,
                 virtual_key_id,
                 path_and_query,
@@ -183,10 +191,13 @@ pub(super) async fn reserve_proxy_cost_budgets_for_request(
                     ));
                 };
 
+                let (budget_scope, limit_usd_micros) =
+                    persistent_budget_window(state, virtual_key_id, budget, limit_usd_micros, true)
+                        .await;
                 let ctx = ProxyBudgetReservationContext {
                     state,
                     reservation_id: request_id,
-                    budget_scope: virtual_key_id,
+                    budget_scope: &budget_scope,
                     request_id,
                     virtual_key_id,
                     path_and_query,
@@ -220,17 +231,17 @@ pub(super) async fn reserve_proxy_cost_budgets_for_request(
         if let Some((scope, budget)) = tenant_budget_scope.as_ref()
             && let Some(limit) = budget.total_usd_micros
         {
-            cost_scopes.push((scope.clone(), limit));
+            cost_scopes.push(persistent_budget_window(state, scope, budget, limit, true).await);
         }
         if let Some((scope, budget)) = project_budget_scope.as_ref()
             && let Some(limit) = budget.total_usd_micros
         {
-            cost_scopes.push((scope.clone(), limit));
+            cost_scopes.push(persistent_budget_window(state, scope, budget, limit, true).await);
         }
         if let Some((scope, budget)) = user_budget_scope.as_ref()
             && let Some(limit) = budget.total_usd_micros
         {
-            cost_scopes.push((scope.clone(), limit));
+            cost_scopes.push(persistent_budget_window(state, scope, budget, limit, true).await);
         }
 
         if !cost_scopes.is_empty() {
@@ -608,6 +619,165 @@ fn report_budget_storage_error(state: &GatewayHttpState, operation: &str, target
     );
 }
 
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+/// The ledger a persistent reservation for `scope` is charged to under
+/// `budget`'s reset schedule, and `limit` plus whatever the previous window
+/// left unused when it rolls over.
+async fn persistent_budget_window(
+    state: &GatewayHttpState,
+    scope: &str,
+    budget: &super::BudgetConfig,
+    limit: u64,
+    cost: bool,
+) -> (String, u64) {
+    let now = now_epoch_seconds();
+    let ledger_scope = budget.ledger_scope(scope, now).into_owned();
+    let Some(previous) = budget.rollover_ledger_scope(scope, now) else {
+        return (ledger_scope, limit);
+    };
+    let operation = if cost {
+        "get_cost_ledger"
+    } else {
+        "get_budget_ledger"
+    };
+    // A failed lookup only costs the rollover; the window itself is enforced.
+    let previous_spent = match persistent_ledger_spent(state, &previous, cost).await {
+        Ok(spent) => spent,
+        Err(err) => {
+            let err = budget_storage_error(operation, &previous, err);
+            report_budget_storage_error(state, operation, &previous, &err);
+            None
+        }
+    };
+    let limit = if cost {
+        budget.cost_limit_with_rollover(previous_spent)
+    } else {
+        budget.token_limit_with_rollover(previous_spent)
+    };
+    (ledger_scope, limit.unwrap_or_default())
+}
+
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+/// `scope`'s usage of `budget` from the persistent token and cost ledgers.
+/// Only the dimensions `budget` limits are looked up.
+pub(super) async fn persistent_budget_usage(
+    state: &GatewayHttpState,
+    scope: &str,
+    budget: &super::BudgetConfig,
+) -> Result<crate::gateway::BudgetUsage, String> {
+    let now = now_epoch_seconds();
+    let ledger_scope = budget.ledger_scope(scope, now);
+    let previous = budget.rollover_ledger_scope(scope, now);
+    let mut spent = [None, None];
+    let mut previous_spent = [None, None];
+    for (index, cost) in [false, true].into_iter().enumerate() {
+        let limited = if cost {
+            budget.total_usd_micros.is_some()
+        } else {
+            budget.total_tokens.is_some()
+        };
+        if !limited {
+            continue;
+        }
+        spent[index] = persistent_ledger_spent(state, &ledger_scope, cost).await?;
+        if let Some(previous) = previous.as_deref() {
+            previous_spent[index] = persistent_ledger_spent(state, previous, cost).await?;
+        }
+    }
+    Ok(crate::gateway::BudgetUsage::new(
+        budget,
+        now,
+        spent[0].unwrap_or_default(),
+        spent[1].unwrap_or_default(),
+        previous_spent[0],
+        previous_spent[1],
+    ))
+}
+
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+async fn persistent_ledger_spent(
+    state: &GatewayHttpState,
+    ledger_scope: &str,
+    cost: bool,
+) -> Result<Option<u64>, String> {
+    #[cfg(feature = "gateway-store-sqlite")]
+    if let Some(store) = state.stores.sqlite.as_ref() {
+        let spent = if cost {
+            store
+                .get_cost_ledger(ledger_scope)
+                .await
+                .map(|ledger| ledger.map(|ledger| ledger.spent_usd_micros))
+        } else {
+            store
+                .get_budget_ledger(ledger_scope)
+                .await
+                .map(|ledger| ledger.map(|ledger| ledger.spent_tokens))
+        };
+        return spent.map_err(|err| err.to_string());
+    }
+    #[cfg(feature = "gateway-store-postgres")]
+    if let Some(store) = state.stores.postgres.as_ref() {
+        let spent = if cost {
+            store
+                .get_cost_ledger(ledger_scope)
+                .await
+                .map(|ledger| ledger.map(|ledger| ledger.spent_usd_micros))
+        } else {
+            store
+                .get_budget_ledger(ledger_scope)
+                .await
+                .map(|ledger| ledger.map(|ledger| ledger.spent_tokens))
+        };
+        return spent.map_err(|err| err.to_string());
+    }
+    #[cfg(feature = "gateway-store-mysql")]
+    if let Some(store) = state.stores.mysql.as_ref() {
+        let spent = if cost {
+            store
+                .get_cost_ledger(ledger_scope)
+                .await
+                .map(|ledger| ledger.map(|ledger| ledger.spent_usd_micros))
+        } else {
+            store
+                .get_budget_ledger(ledger_scope)
+                .await
+                .map(|ledger| ledger.map(|ledger| ledger.spent_tokens))
+        };
+        return spent.map_err(|err| err.to_string());
+    }
+    #[cfg(feature = "gateway-store-redis")]
+    if let Some(store) = state.stores.redis.as_ref() {
+        let spent = if cost {
+            store
+                .get_cost_ledger(ledger_scope)
+                .await
+                .map(|ledger| ledger.map(|ledger| ledger.spent_usd_micros))
+        } else {
+            store
+                .get_budget_ledger(ledger_scope)
+                .await
+                .map(|ledger| ledger.map(|ledger| ledger.spent_tokens))
+        };
+        return spent.map_err(|err| err.to_string());
+    }
+    Ok(None)
+}
+
 #[cfg(any(
     feature = "gateway-store-sqlite",
     feature = "gateway-store-postgres",
@@ -1376,6 +1546,11 @@ mod tests {
             a2a_agents: Vec::new(),
             mcp_servers: Vec::new(),
             observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
         }))
         .with_sqlite_store(store);
 
@@ -1429,5 +1604,10 @@ pub(super) fn try_acquire_proxy_permits(
     Ok(ProxyPermitOutcome::Acquired(ProxyPermits::new(
         proxy_permit,
         backend_permit,
+        state
+            .proxy
+            .tier_admission
+            .as_ref()
+            .map(|control| control.release_signal()),
     )))
 }
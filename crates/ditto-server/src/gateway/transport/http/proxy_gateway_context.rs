@@ -1,8 +1,14 @@
 use super::*;
 
+use crate::gateway::GuardrailHookPhase;
+use crate::gateway::domain::moderation::ModerationAction;
+use crate::gateway::domain::prompt_injection::{PromptInjectionAction, prompt_injection_user_text};
+
 #[derive(Debug, Clone)]
 pub(super) struct ResolvedGatewayContext {
     pub(super) virtual_key_id: Option<String>,
+    pub(super) key_callbacks: Vec<String>,
+    pub(super) key_tier: Option<String>,
     #[cfg(feature = "gateway-translation")]
     pub(super) response_owner: super::translation::TranslationResponseOwner,
     pub(super) limits: Option<super::LimitsConfig>,
@@ -14,7 +20,15 @@ pub(super) struct ResolvedGatewayContext {
     pub(super) project_limits_scope: Option<(String, super::LimitsConfig)>,
     pub(super) user_limits_scope: Option<(String, super::LimitsConfig)>,
     pub(super) backend_candidates: Vec<String>,
+    pub(super) experiment: Option<String>,
+    pub(super) shadow: Option<crate::gateway::RouteShadowConfig>,
     pub(super) strip_authorization: bool,
+    pub(super) guardrails: Option<super::GuardrailsConfig>,
+    pub(super) hooked_request: Option<(Bytes, serde_json::Value)>,
+    pub(super) prompt_injection: Option<PromptInjectionVerdict>,
+    pub(super) moderation: Option<ModerationVerdict>,
+    pub(super) context_window: Option<ContextWindowVerdict>,
+    pub(super) history_compression: Option<HistoryCompressionVerdict>,
     pub(super) charge_cost_usd_micros: Option<u64>,
     pub(super) local_rate_limit_reserved: bool,
     pub(super) local_token_budget_reserved: bool,
@@ -60,6 +74,12 @@ struct ResolvedGatewayContextLocals {
     project_limits_scope: Option<(String, super::LimitsConfig)>,
     user_limits_scope: Option<(String, super::LimitsConfig)>,
     backend_candidates: Vec<String>,
+    guardrails: Option<super::GuardrailsConfig>,
+    hooked_request: Option<(Bytes, serde_json::Value)>,
+    prompt_injection: Option<PromptInjectionVerdict>,
+    moderation: Option<ModerationVerdict>,
+    context_window: Option<ContextWindowVerdict>,
+    history_compression: Option<HistoryCompressionVerdict>,
     charge_cost_usd_micros: Option<u64>,
     local_rate_limit_reserved: bool,
     local_token_budget_reserved: bool,
@@ -144,6 +164,8 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_preamble(
                 "virtual key disabled",
             ));
         }
+        ensure_virtual_key_client_access(state, parts, &key).await?;
+        ensure_spend_not_throttled(state, &key)?;
         Some(key)
     } else {
         None
@@ -185,6 +207,20 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
     let gateway_preamble = resolve_openai_compat_proxy_gateway_preamble(state, parts).await?;
     let strip_authorization = gateway_preamble.strip_authorization;
     let key = gateway_preamble.key;
+    let key_callbacks = key
+        .as_ref()
+        .map(|key| key.callbacks.clone())
+        .unwrap_or_default();
+    let key_tier = key.as_ref().and_then(|key| key.tier.clone());
+    let (experiment, shadow) = if is_passthrough_route_request(parts) {
+        (None, None)
+    } else {
+        let routed_model = routed_model.as_deref();
+        (
+            routed_model.and_then(|model| state.route_experiment_for_model(model, key.as_ref())),
+            routed_model.and_then(|model| state.route_shadow_for_model(model, key.as_ref())),
+        )
+    };
     #[cfg(feature = "gateway-translation")]
     let response_owner = key
         .as_ref()
@@ -310,12 +346,7 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
                 && let Some(reason) = guardrails.check_model(model_id)
             {
                 state.record_guardrail_blocked();
-                let err = openai_error(
-                    StatusCode::FORBIDDEN,
-                    "policy_error",
-                    Some("guardrail_rejected"),
-                    reason,
-                );
+                let err = guardrail_error(reason);
                 #[cfg(feature = "gateway-metrics-prometheus")]
                 if let Some(metrics) = state.proxy.metrics.as_ref() {
                     let duration = metrics_timer_start.elapsed();
@@ -341,12 +372,7 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
                 && input_tokens_estimate > limit
             {
                 state.record_guardrail_blocked();
-                let err = openai_error(
-                    StatusCode::FORBIDDEN,
-                    "policy_error",
-                    Some("guardrail_rejected"),
-                    format!("input_tokens>{limit}"),
-                );
+                let err = guardrail_error(format!("input_tokens>{limit}"));
                 #[cfg(feature = "gateway-metrics-prometheus")]
                 if let Some(metrics) = state.proxy.metrics.as_ref() {
                     let duration = metrics_timer_start.elapsed();
@@ -418,12 +444,7 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
                 && let Some(reason) = guardrails.check_text(text)
             {
                 state.record_guardrail_blocked();
-                let err = openai_error(
-                    StatusCode::FORBIDDEN,
-                    "policy_error",
-                    Some("guardrail_rejected"),
-                    reason,
-                );
+                let err = guardrail_error(reason);
                 #[cfg(feature = "gateway-metrics-prometheus")]
                 if let Some(metrics) = state.proxy.metrics.as_ref() {
                     let duration = metrics_timer_start.elapsed();
@@ -445,15 +466,194 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
                 return Err(err);
             }
 
-            let budget = Some(key.budget.clone());
+            let mut hooked_request = None;
+            if guardrails.has_hooks(GuardrailHookPhase::PreCall)
+                && let Some(body_json) = parsed_json.as_ref()
+            {
+                let mut body_json = body_json.clone();
+                let outcome =
+                    guardrails.run_hooks_on_json(GuardrailHookPhase::PreCall, &mut body_json);
+                log_guardrail_hook_matches(
+                    state,
+                    request_id,
+                    Some(&key.id),
+                    GuardrailHookPhase::PreCall,
+                    &outcome,
+                );
+                if let Some(reason) = outcome.block_reason() {
+                    state.record_guardrail_blocked();
+                    let err = guardrail_error(reason);
+                    #[cfg(feature = "gateway-metrics-prometheus")]
+                    if let Some(metrics) = state.proxy.metrics.as_ref() {
+                        let duration = metrics_timer_start.elapsed();
+                        let status = err.0.as_u16();
+                        let mut metrics = metrics.lock().await;
+                        metrics.record_proxy_request(Some(&key.id), model.as_deref(), metrics_path);
+                        metrics.record_proxy_guardrail_blocked(
+                            Some(&key.id),
+                            model.as_deref(),
+                            metrics_path,
+                        );
+                        metrics.record_proxy_response_status_by_path(metrics_path, status);
+                        if let Some(model) = model.as_deref() {
+                            metrics.record_proxy_response_status_by_model(model, status);
+                            metrics.observe_proxy_request_duration_by_model(model, duration);
+                        }
+                        metrics.observe_proxy_request_duration(metrics_path, duration);
+                    }
+                    return Err(err);
+                }
+                if outcome.modified
+                    && let Ok(bytes) = serde_json::to_vec(&body_json)
+                {
+                    hooked_request = Some((Bytes::from(bytes), body_json));
+                }
+            }
 
-            let backends = state
-                .select_backends_for_model_seeded(
-                    routed_model.as_deref().unwrap_or_default(),
-                    Some(key),
-                    Some(request_id),
+            // Compress long histories first, so the context-window check sees
+            // the summarized request.
+            let mut history_compression = None;
+            let mut context_input_tokens = input_tokens_estimate;
+            if let Some(config) = guardrails.history_compression.as_ref()
+                && let Some(body_json) = hooked_request
+                    .as_ref()
+                    .map(|(_, body_json)| body_json)
+                    .or(parsed_json.as_ref())
+                && let Some((body_json, verdict)) = compress_history(
+                    state,
+                    config,
+                    request_id,
+                    Some(&key.id),
+                    path_and_query,
+                    model.as_deref(),
+                    body_json,
+                    input_tokens_estimate,
                 )
-                .map_err(map_openai_gateway_error)?;
+                .await
+                && let Ok(bytes) = serde_json::to_vec(&body_json)
+            {
+                context_input_tokens = input_tokens_estimate.saturating_sub(verdict.saved_tokens);
+                hooked_request = Some((Bytes::from(bytes), body_json));
+                history_compression = Some(verdict);
+            }
+
+            let mut context_window = None;
+            if let Some(config) = guardrails.context_window.as_ref()
+                && let Some(body_json) = hooked_request
+                    .as_ref()
+                    .map(|(_, body_json)| body_json)
+                    .or(parsed_json.as_ref())
+                && let Some((body_json, verdict)) = enforce_context_window(
+                    state,
+                    config,
+                    request_id,
+                    Some(&key.id),
+                    path_and_query,
+                    model.as_deref(),
+                    body_json,
+                    context_input_tokens,
+                    max_output_tokens,
+                )
+                .await?
+                && let Ok(bytes) = serde_json::to_vec(&body_json)
+            {
+                hooked_request = Some((Bytes::from(bytes), body_json));
+                context_window = Some(verdict);
+            }
+
+            // Score and moderate what goes upstream, i.e. after pre-call hooks,
+            // history compression and context-window trimming rewrote it.
+            let upstream_json = hooked_request
+                .as_ref()
+                .map(|(_, body_json)| body_json)
+                .or(parsed_json.as_ref());
+            let mut prompt_injection = None;
+            if let Some(config) = guardrails.prompt_injection.as_ref()
+                && let Some(body_json) = upstream_json
+            {
+                prompt_injection = score_prompt_injection_request(
+                    state,
+                    config,
+                    request_id,
+                    Some(&key.id),
+                    body_json,
+                )
+                .await;
+                if let Some(verdict) = prompt_injection
+                    && verdict.flagged
+                    && config.action == PromptInjectionAction::Block
+                {
+                    state.record_guardrail_blocked();
+                    let err = guardrail_error(format!("prompt_injection:{:.2}", verdict.score));
+                    #[cfg(feature = "gateway-metrics-prometheus")]
+                    if let Some(metrics) = state.proxy.metrics.as_ref() {
+                        let duration = metrics_timer_start.elapsed();
+                        let status = err.0.as_u16();
+                        let mut metrics = metrics.lock().await;
+                        metrics.record_proxy_request(Some(&key.id), model.as_deref(), metrics_path);
+                        metrics.record_proxy_guardrail_blocked(
+                            Some(&key.id),
+                            model.as_deref(),
+                            metrics_path,
+                        );
+                        metrics.record_proxy_response_status_by_path(metrics_path, status);
+                        if let Some(model) = model.as_deref() {
+                            metrics.record_proxy_response_status_by_model(model, status);
+                            metrics.observe_proxy_request_duration_by_model(model, duration);
+                        }
+                        metrics.observe_proxy_request_duration(metrics_path, duration);
+                    }
+                    return Err(err);
+                }
+            }
+
+            let mut moderation = None;
+            if let Some(config) = guardrails
+                .moderation
+                .as_ref()
+                .filter(|config| config.check_input)
+                && let Some(text) = upstream_json.and_then(prompt_injection_user_text)
+            {
+                let violations =
+                    moderate_text(state, config, request_id, Some(&key.id), "input", &text).await;
+                if !violations.is_empty() && config.action == ModerationAction::Block {
+                    state.record_guardrail_blocked();
+                    let err = guardrail_error(moderation_block_reason(&violations));
+                    #[cfg(feature = "gateway-metrics-prometheus")]
+                    if let Some(metrics) = state.proxy.metrics.as_ref() {
+                        let duration = metrics_timer_start.elapsed();
+                        let status = err.0.as_u16();
+                        let mut metrics = metrics.lock().await;
+                        metrics.record_proxy_request(Some(&key.id), model.as_deref(), metrics_path);
+                        metrics.record_proxy_guardrail_blocked(
+                            Some(&key.id),
+                            model.as_deref(),
+                            metrics_path,
+                        );
+                        metrics.record_proxy_response_status_by_path(metrics_path, status);
+                        if let Some(model) = model.as_deref() {
+                            metrics.record_proxy_response_status_by_model(model, status);
+                            metrics.observe_proxy_request_duration_by_model(model, duration);
+                        }
+                        metrics.observe_proxy_request_duration(metrics_path, duration);
+                    }
+                    return Err(err);
+                }
+                if !violations.is_empty() {
+                    moderation = Some(ModerationVerdict::new(&violations));
+                }
+            }
+
+            let budget = Some(key.budget.clone());
+
+            let backends = select_proxy_backends(
+                state,
+                parts,
+                routed_model.as_deref().unwrap_or_default(),
+                Some(key),
+                Some(request_id),
+            )
+            .map_err(map_openai_gateway_error)?;
 
             #[cfg(feature = "gateway-costing")]
             let charge_cost_usd_micros = {
@@ -535,9 +735,13 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
                 if let Some((scope, limits)) = user_limits_scope.as_ref() {
                     rate_limit_scopes.push((scope.as_str(), limits));
                 }
-                if let Err(err) = state.check_and_consume_rate_limits(
-                    rate_limit_scopes.into_iter(),
-                    charge_tokens,
+                if let Err(err) = record_rate_limit_outcome(
+                    &parts.extensions,
+                    state.check_and_consume_rate_limits(
+                        rate_limit_scopes.into_iter(),
+                        charge_tokens,
+                        minute,
+                    ),
                     minute,
                 ) {
                     state.record_rate_limited();
@@ -705,6 +909,12 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
                 project_limits_scope,
                 user_limits_scope,
                 backend_candidates: backends,
+                guardrails: Some(guardrails),
+                hooked_request,
+                prompt_injection,
+                moderation,
+                context_window,
+                history_compression,
                 charge_cost_usd_micros,
                 local_rate_limit_reserved,
                 local_token_budget_reserved,
@@ -712,13 +922,14 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
                 local_cost_budget_reserved,
             }
         } else {
-            let backends = state
-                .select_backends_for_model_seeded(
-                    routed_model.as_deref().unwrap_or_default(),
-                    None,
-                    Some(request_id),
-                )
-                .map_err(map_openai_gateway_error)?;
+            let backends = select_proxy_backends(
+                state,
+                parts,
+                routed_model.as_deref().unwrap_or_default(),
+                None,
+                Some(request_id),
+            )
+            .map_err(map_openai_gateway_error)?;
 
             #[cfg(feature = "gateway-costing")]
             let charge_cost_usd_micros = estimate_charge_cost_usd_micros(
@@ -743,6 +954,12 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
                 project_limits_scope: None,
                 user_limits_scope: None,
                 backend_candidates: backends,
+                guardrails: None,
+                hooked_request: None,
+                prompt_injection: None,
+                moderation: None,
+                context_window: None,
+                history_compression: None,
                 charge_cost_usd_micros,
                 local_rate_limit_reserved: false,
                 local_token_budget_reserved: false,
@@ -754,6 +971,8 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
 
     Ok(ResolvedGatewayContext {
         virtual_key_id: resolved.virtual_key_id,
+        key_callbacks,
+        key_tier,
         #[cfg(feature = "gateway-translation")]
         response_owner,
         limits: resolved.limits,
@@ -765,7 +984,15 @@ pub(super) async fn resolve_openai_compat_proxy_gateway_context(
         project_limits_scope: resolved.project_limits_scope,
         user_limits_scope: resolved.user_limits_scope,
         backend_candidates: resolved.backend_candidates,
+        experiment,
+        shadow,
         strip_authorization,
+        guardrails: resolved.guardrails,
+        hooked_request: resolved.hooked_request,
+        prompt_injection: resolved.prompt_injection,
+        moderation: resolved.moderation,
+        context_window: resolved.context_window,
+        history_compression: resolved.history_compression,
         charge_cost_usd_micros: resolved.charge_cost_usd_micros,
         local_rate_limit_reserved: resolved.local_rate_limit_reserved,
         local_token_budget_reserved: resolved.local_token_budget_reserved,
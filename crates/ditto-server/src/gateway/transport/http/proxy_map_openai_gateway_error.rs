@@ -16,12 +16,7 @@ pub(super) fn map_openai_gateway_error(
             Some("rate_limited"),
             format!("rate limit exceeded: {limit}"),
         ),
-        GatewayError::GuardrailRejected { reason } => openai_error(
-            StatusCode::FORBIDDEN,
-            "policy_error",
-            Some("guardrail_rejected"),
-            format!("guardrail rejected: {reason}"),
-        ),
+        GatewayError::GuardrailRejected { reason } => guardrail_error(reason),
         GatewayError::BudgetExceeded { limit, attempted } => openai_error(
             StatusCode::PAYMENT_REQUIRED,
             "insufficient_quota",
@@ -63,5 +58,90 @@ pub(super) fn map_openai_gateway_error(
             Some("invalid_request"),
             reason,
         ),
+        GatewayError::RegionUnavailable { regions } => openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("region_unavailable"),
+            format!("no backend available in required region: {regions}"),
+        ),
+    }
+}
+
+/// Stable `error.code` for a guardrail block: model allow/deny list hits are
+/// `model_not_allowed`, MCP tools outside the key's `mcp.allowed_tools` are
+/// `mcp_tool_not_allowed`, everything else (filters, hooks, moderation,
+/// prompt injection) is `guardrail_blocked`.
+pub(super) fn guardrail_error_code(reason: &str) -> &'static str {
+    if reason.starts_with("deny_model:") || reason.starts_with("model_not_allowed:") {
+        "model_not_allowed"
+    } else if reason.starts_with("mcp_tool_not_allowed:") {
+        "mcp_tool_not_allowed"
+    } else {
+        "guardrail_blocked"
+    }
+}
+
+/// The 403 for a request or response a guardrail blocked, with `reason` as
+/// the message.
+pub(super) fn guardrail_error(
+    reason: impl std::fmt::Display,
+) -> (StatusCode, Json<OpenAiErrorResponse>) {
+    let reason = reason.to_string();
+    let code = guardrail_error_code(&reason);
+    let (status, Json(mut body)) =
+        openai_error(StatusCode::FORBIDDEN, "policy_error", Some(code), &reason);
+    if code == "model_not_allowed" {
+        body.error.param = Some("model".to_string());
+    }
+    (status, Json(body))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn gateway_errors_use_openai_envelope_with_stable_codes() {
+        let body = |err: GatewayError| {
+            let (status, Json(body)) = map_openai_gateway_error(err);
+            (status, serde_json::to_value(body).expect("serialize"))
+        };
+
+        let (status, denied) = body(GatewayError::GuardrailRejected {
+            reason: "model_not_allowed:gpt-4o".to_string(),
+        });
+        assert_eq!(status, StatusCode::FORBIDDEN);
+        assert_eq!(
+            denied,
+            serde_json::json!({
+                "error": {
+                    "message": "model_not_allowed:gpt-4o",
+                    "type": "policy_error",
+                    "param": "model",
+                    "code": "model_not_allowed",
+                }
+            })
+        );
+
+        let (_, blocked) = body(GatewayError::GuardrailRejected {
+            reason: "banned_regex:secret".to_string(),
+        });
+        assert_eq!(blocked["error"]["code"], "guardrail_blocked");
+
+        let (status, tool) = body(GatewayError::GuardrailRejected {
+            reason: "mcp_tool_not_allowed:github/delete_repo".to_string(),
+        });
+        assert_eq!(status, StatusCode::FORBIDDEN);
+        assert_eq!(tool["error"]["code"], "mcp_tool_not_allowed");
+        assert!(blocked["error"]["param"].is_null());
+
+        let (status, budget) = body(GatewayError::BudgetExceeded {
+            limit: 10,
+            attempted: 11,
+        });
+        assert_eq!(status, StatusCode::PAYMENT_REQUIRED);
+        assert_eq!(budget["error"]["code"], "budget_exceeded");
+        assert_eq!(budget["error"]["type"], "insufficient_quota");
+        assert!(budget["error"]["param"].is_null());
     }
 }
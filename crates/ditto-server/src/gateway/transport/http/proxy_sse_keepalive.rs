@@ -0,0 +1,86 @@
+use super::*;
+
+use std::time::Duration;
+
+pub(super) const DEFAULT_SSE_KEEPALIVE_INTERVAL: Duration = Duration::from_secs(15);
+
+const SSE_KEEPALIVE_PING: &[u8] = b": ping\n\n";
+
+/// Emits an SSE `: ping` comment every `interval` until `stream` yields its
+/// first chunk, so idle timeouts in proxies and client libraries don't drop
+/// requests that are still waiting on the first token. Comment lines are
+/// ignored by SSE parsers; later chunks pass through untouched.
+pub(super) fn with_sse_keepalive<S>(stream: S, interval: Option<Duration>) -> ProxyBodyStream
+where
+    S: futures_util::Stream<Item = Result<Bytes, std::io::Error>> + Send + 'static,
+{
+    let Some(interval) = interval else {
+        return stream.boxed();
+    };
+
+    let stream = stream.boxed();
+    futures_util::stream::unfold(
+        (stream, false),
+        move |(mut stream, first_chunk_seen)| async move {
+            if first_chunk_seen {
+                return stream.next().await.map(|item| (item, (stream, true)));
+            }
+            match tokio::time::timeout(interval, stream.next()).await {
+                Ok(item) => item.map(|item| (item, (stream, true))),
+                Err(_) => Some((Ok(Bytes::from_static(SSE_KEEPALIVE_PING)), (stream, false))),
+            }
+        },
+    )
+    .boxed()
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[tokio::test]
+    async fn pings_until_first_chunk_then_passes_through() {
+        let upstream =
+            futures_util::stream::iter([b"data: 1\n\n", b"data: 2\n\n"]).then(|chunk| async move {
+                if chunk.starts_with(b"data: 1") {
+                    tokio::time::sleep(Duration::from_millis(120)).await;
+                }
+                Ok::<_, std::io::Error>(Bytes::from_static(chunk))
+            });
+
+        let chunks: Vec<Bytes> = with_sse_keepalive(upstream, Some(Duration::from_millis(50)))
+            .map(|chunk| chunk.expect("chunk"))
+            .collect()
+            .await;
+
+        let (pings, data) = chunks.split_at(chunks.len() - 2);
+        assert!(!pings.is_empty());
+        assert!(
+            pings
+                .iter()
+                .all(|chunk| chunk.as_ref() == SSE_KEEPALIVE_PING)
+        );
+        assert_eq!(
+            data,
+            [
+                Bytes::from_static(b"data: 1\n\n"),
+                Bytes::from_static(b"data: 2\n\n"),
+            ]
+        );
+    }
+
+    #[tokio::test]
+    async fn disabled_keepalive_leaves_stream_unchanged() {
+        let upstream = futures_util::stream::once(async {
+            tokio::time::sleep(Duration::from_millis(20)).await;
+            Ok::<_, std::io::Error>(Bytes::from_static(b"data: [DONE]\n\n"))
+        });
+
+        let chunks: Vec<Bytes> = with_sse_keepalive(upstream, None)
+            .map(|chunk| chunk.expect("chunk"))
+            .collect()
+            .await;
+
+        assert_eq!(chunks, vec![Bytes::from_static(b"data: [DONE]\n\n")]);
+    }
+}
@@ -0,0 +1,354 @@
+use bytes::BytesMut;
+use std::sync::Mutex;
+
+/// Upper bound for an event that straddles chunk boundaries. Past this the
+/// scanner keeps only the last [`SSE_SCANNER_TAIL_BYTES`] (usage is reported
+/// in the final events, so the head of a runaway event is never needed).
+const SSE_SCANNER_MAX_PARTIAL_BYTES: usize = 512 * 1024;
+const SSE_SCANNER_TAIL_BYTES: usize = 128 * 1024;
+
+/// Carry buffers are recycled across streams instead of being allocated per
+/// stream; oversized buffers are dropped so one long event cannot pin memory.
+const SSE_BUFFER_POOL_MAX_BUFFERS: usize = 256;
+const SSE_BUFFER_POOL_MAX_CAPACITY: usize = 64 * 1024;
+const SSE_BUFFER_INITIAL_CAPACITY: usize = 4 * 1024;
+
+static SSE_BUFFER_POOL: Mutex<Vec<BytesMut>> = Mutex::new(Vec::new());
+
+fn take_pooled_buffer() -> BytesMut {
+    SSE_BUFFER_POOL
+        .lock()
+        .ok()
+        .and_then(|mut pool| pool.pop())
+        .unwrap_or_else(|| BytesMut::with_capacity(SSE_BUFFER_INITIAL_CAPACITY))
+}
+
+fn return_pooled_buffer(mut buffer: BytesMut) {
+    if buffer.capacity() == 0 || buffer.capacity() > SSE_BUFFER_POOL_MAX_CAPACITY {
+        return;
+    }
+    buffer.clear();
+    if let Ok(mut pool) = SSE_BUFFER_POOL.lock()
+        && pool.len() < SSE_BUFFER_POOL_MAX_BUFFERS
+    {
+        pool.push(buffer);
+    }
+}
+
+/// Incremental SSE event scanner for the proxy streaming path.
+///
+/// Chunks are parsed where they are: events that end inside a chunk are read
+/// straight from the upstream bytes, and only the unterminated tail of a chunk
+/// is copied into a (pooled) carry buffer. Carried bytes are never rescanned,
+/// so per-stream cost is linear in the bytes forwarded and an idle stream whose
+/// upstream frames align with events holds no buffer at all.
+#[derive(Default)]
+pub(super) struct SseEventScanner {
+    partial: BytesMut,
+    /// Prefix of `partial` already searched for a delimiter.
+    scanned: usize,
+    /// Reused when an event carries more than one `data:` line.
+    data: Vec<u8>,
+}
+
+impl SseEventScanner {
+    /// Calls `on_data` with the `data:` payload (lines joined by `\n`) of every
+    /// event completed by `chunk`. Events without data are skipped.
+    pub(super) fn feed(&mut self, mut chunk: &[u8], mut on_data: impl FnMut(&[u8])) {
+        if !self.partial.is_empty() {
+            let carried = self.partial.len();
+            self.partial.extend_from_slice(chunk);
+            let Some((pos, delimiter_len)) = find_sse_delimiter(&self.partial, self.scanned) else {
+                self.scanned = self.partial.len().saturating_sub(3);
+                self.cap_partial();
+                return;
+            };
+            emit_sse_data(&self.partial[..pos], &mut self.data, &mut on_data);
+            // The carried bytes held no complete delimiter, so this event ends
+            // inside `chunk`; resume on the chunk itself.
+            let end = pos + delimiter_len;
+            debug_assert!(end > carried);
+            chunk = &chunk[end.saturating_sub(carried).min(chunk.len())..];
+            self.partial.clear();
+        }
+
+        let mut start = 0usize;
+        while let Some((pos, delimiter_len)) = find_sse_delimiter(&chunk[start..], 0) {
+            emit_sse_data(&chunk[start..start + pos], &mut self.data, &mut on_data);
+            start += pos + delimiter_len;
+        }
+
+        let rest = &chunk[start..];
+        if rest.is_empty() {
+            self.release_partial();
+            return;
+        }
+        let rest = &rest[rest.len().saturating_sub(SSE_SCANNER_MAX_PARTIAL_BYTES)..];
+        if self.partial.capacity() == 0 {
+            self.partial = take_pooled_buffer();
+        }
+        self.partial.extend_from_slice(rest);
+        self.scanned = rest.len().saturating_sub(3);
+    }
+
+    /// Capacity held for an unterminated event (0 once it is returned to the pool).
+    #[cfg(test)]
+    fn carried_capacity(&self) -> usize {
+        self.partial.capacity()
+    }
+
+    fn cap_partial(&mut self) {
+        if self.partial.len() <= SSE_SCANNER_MAX_PARTIAL_BYTES {
+            return;
+        }
+        let keep_from = self.partial.len() - SSE_SCANNER_TAIL_BYTES;
+        let _ = self.partial.split_to(keep_from);
+        self.scanned = 0;
+    }
+
+    fn release_partial(&mut self) {
+        self.scanned = 0;
+        return_pooled_buffer(std::mem::take(&mut self.partial));
+    }
+}
+
+impl Drop for SseEventScanner {
+    fn drop(&mut self) {
+        return_pooled_buffer(std::mem::take(&mut self.partial));
+    }
+}
+
+/// Earliest `\n\n` or `\r\n\r\n` at or after `from`, as `(position, length)`.
+fn find_sse_delimiter(buf: &[u8], from: usize) -> Option<(usize, usize)> {
+    let mut idx = from;
+    while let Some(offset) = buf.get(idx..)?.iter().position(|b| *b == b'\n') {
+        let newline = idx + offset;
+        if buf.get(newline + 1) == Some(&b'\n') {
+            return Some((newline, 2));
+        }
+        if newline > from
+            && buf[newline - 1] == b'\r'
+            && buf.get(newline + 1..newline + 3) == Some(b"\r\n".as_slice())
+        {
+            return Some((newline - 1, 4));
+        }
+        idx = newline + 1;
+    }
+    None
+}
+
+fn emit_sse_data(event: &[u8], scratch: &mut Vec<u8>, on_data: &mut impl FnMut(&[u8])) {
+    let mut lines = event
+        .split(|b| *b == b'\n')
+        .filter_map(|line| {
+            let line = line.strip_suffix(b"\r").unwrap_or(line);
+            line.strip_prefix(b"data:").map(<[u8]>::trim_ascii)
+        })
+        .filter(|data| !data.is_empty());
+    let Some(first) = lines.next() else {
+        return;
+    };
+    let Some(second) = lines.next() else {
+        on_data(first);
+        return;
+    };
+
+    scratch.clear();
+    scratch.extend_from_slice(first);
+    for line in std::iter::once(second).chain(lines) {
+        scratch.push(b'\n');
+        scratch.extend_from_slice(line);
+    }
+    on_data(scratch);
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    use std::time::{Duration, Instant};
+
+    /// The accumulate-then-rescan tracker this scanner replaced, kept as the
+    /// reference for equivalence checks and the benchmark baseline.
+    #[derive(Default)]
+    struct AccumulatingScanner {
+        buffer: BytesMut,
+    }
+
+    impl AccumulatingScanner {
+        fn feed(&mut self, chunk: &[u8], mut on_data: impl FnMut(&[u8])) {
+            self.buffer.extend_from_slice(chunk);
+            while let Some((pos, delimiter_len)) = find_sse_delimiter(&self.buffer, 0) {
+                let event = self.buffer.split_to(pos);
+                let _ = self.buffer.split_to(delimiter_len);
+                let mut out = Vec::<u8>::new();
+                for line in event.as_ref().split(|b| *b == b'\n') {
+                    let line = line.strip_suffix(b"\r").unwrap_or(line);
+                    let Some(rest) = line.strip_prefix(b"data:") else {
+                        continue;
+                    };
+                    let rest = rest.trim_ascii();
+                    if rest.is_empty() {
+                        continue;
+                    }
+                    if !out.is_empty() {
+                        out.push(b'\n');
+                    }
+                    out.extend_from_slice(rest);
+                }
+                if !out.is_empty() {
+                    on_data(&out);
+                }
+            }
+        }
+    }
+
+    fn sample_stream(events: usize) -> Vec<u8> {
+        let mut out = Vec::new();
+        for idx in 0..events {
+            match idx % 7 {
+                3 => out.extend_from_slice(b": ping\r\n\r\n"),
+                5 => out.extend_from_slice(
+                    format!("event: delta\r\ndata: {{\"i\":{idx},\r\ndata: \"x\":1}}\r\n\r\n")
+                        .as_bytes(),
+                ),
+                _ => out.extend_from_slice(
+                    format!(
+                        "data: {{\"choices\":[{{\"delta\":{{\"content\":\"token {idx}\"}}}}]}}\n\n"
+                    )
+                    .as_bytes(),
+                ),
+            }
+        }
+        out.extend_from_slice(b"data: [DONE]\n\n");
+        out
+    }
+
+    fn collect<F>(stream: &[u8], chunk_len: usize, mut feed: F) -> Vec<Vec<u8>>
+    where
+        F: FnMut(&[u8], &mut dyn FnMut(&[u8])),
+    {
+        let mut events = Vec::new();
+        for chunk in stream.chunks(chunk_len) {
+            feed(chunk, &mut |data| events.push(data.to_vec()));
+        }
+        events
+    }
+
+    #[test]
+    fn matches_accumulating_scanner_for_every_chunking() {
+        let stream = sample_stream(40);
+        let expected = collect(&stream, stream.len(), |chunk, on_data| {
+            AccumulatingScanner::default().feed(chunk, on_data)
+        });
+        assert_eq!(expected.len(), 35);
+        assert_eq!(expected[4], b"{\"i\":5,\n\"x\":1}".to_vec());
+
+        for chunk_len in 1..=64 {
+            let mut scanner = SseEventScanner::default();
+            let events = collect(&stream, chunk_len, |chunk, on_data| {
+                scanner.feed(chunk, on_data)
+            });
+            assert_eq!(events, expected, "chunk_len={chunk_len}");
+        }
+    }
+
+    #[test]
+    fn releases_carry_buffer_once_events_complete() {
+        let mut scanner = SseEventScanner::default();
+        let mut events = Vec::new();
+        scanner.feed(b"data: {\"a\":1}\n\ndata: {\"b\"", |data| {
+            events.push(data.to_vec())
+        });
+        assert!(scanner.carried_capacity() > 0);
+        scanner.feed(b":2}\r\n\r\n", |data| events.push(data.to_vec()));
+        assert_eq!(scanner.carried_capacity(), 0);
+        assert_eq!(events, vec![b"{\"a\":1}".to_vec(), b"{\"b\":2}".to_vec()]);
+    }
+
+    #[test]
+    fn caps_runaway_events() {
+        let mut scanner = SseEventScanner::default();
+        let filler = vec![b'x'; 64 * 1024];
+        scanner.feed(b"data: ", |_| {});
+        for _ in 0..16 {
+            scanner.feed(&filler, |_| {});
+        }
+        assert!(scanner.partial.len() <= SSE_SCANNER_MAX_PARTIAL_BYTES);
+
+        let mut events = Vec::new();
+        scanner.feed(b"\n\ndata: {\"usage\":{}}\n\n", |data| {
+            events.push(data.to_vec())
+        });
+        assert_eq!(events, vec![b"{\"usage\":{}}".to_vec()]);
+    }
+
+    /// Throughput and retained carry memory of the scanner against the old
+    /// accumulating tracker, for upstreams that frame one event per chunk and
+    /// for ones that split events across small TCP-sized reads. Run with
+    /// `cargo test --release -p ditto-server --features gateway
+    /// proxy_sse_scanner -- --ignored --nocapture`.
+    #[test]
+    #[ignore]
+    fn bench_scanner_against_accumulating_tracker() {
+        const STREAMS: usize = 64;
+        let stream = sample_stream(2_000);
+        // A long tool-call argument or reasoning event delivered in small reads.
+        let mut large_events = Vec::new();
+        for _ in 0..4 {
+            large_events.extend_from_slice(b"data: {\"arguments\":\"");
+            large_events.extend(std::iter::repeat_n(b'a', 96 * 1024));
+            large_events.extend_from_slice(b"\"}\n\n");
+        }
+
+        for (layout, stream, chunk_len) in [
+            ("small-reads", &stream, 64),
+            ("split-1460", &stream, 1460),
+            ("large-events", &large_events, 1460),
+        ] {
+            let chunks = stream.chunks(chunk_len).collect::<Vec<_>>();
+            let mut accumulating = (0..STREAMS)
+                .map(|_| AccumulatingScanner::default())
+                .collect::<Vec<_>>();
+            let started = Instant::now();
+            let mut baseline_events = 0usize;
+            for chunk in &chunks {
+                for scanner in &mut accumulating {
+                    scanner.feed(chunk, |_| baseline_events += 1);
+                }
+            }
+            let baseline = started.elapsed();
+            let baseline_retained = accumulating
+                .iter()
+                .map(|scanner| scanner.buffer.capacity())
+                .sum::<usize>();
+
+            let mut scanners = (0..STREAMS)
+                .map(|_| SseEventScanner::default())
+                .collect::<Vec<_>>();
+            let started = Instant::now();
+            let mut events = 0usize;
+            for chunk in &chunks {
+                for scanner in &mut scanners {
+                    scanner.feed(chunk, |_| events += 1);
+                }
+            }
+            let elapsed = started.elapsed();
+            let retained = scanners
+                .iter()
+                .map(SseEventScanner::carried_capacity)
+                .sum::<usize>();
+            assert_eq!(events, baseline_events);
+
+            let mib = (stream.len() * STREAMS) as f64 / (1024.0 * 1024.0);
+            let rate = |elapsed: Duration| mib / elapsed.as_secs_f64().max(f64::EPSILON);
+            println!(
+                "{layout}: accumulating {:.0} MiB/s, {} KiB retained; scanner {:.0} MiB/s, {} KiB retained ({STREAMS} streams)",
+                rate(baseline),
+                baseline_retained / 1024,
+                rate(elapsed),
+                retained / 1024,
+            );
+        }
+    }
+}
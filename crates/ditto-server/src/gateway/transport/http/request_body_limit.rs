@@ -0,0 +1,116 @@
+use super::*;
+
+use axum::body::HttpBody;
+use axum::extract::Request;
+use axum::http::header;
+use axum::middleware::Next;
+use axum::response::Response;
+
+use crate::gateway::RequestBodyLimitConfig;
+
+/// Body error for a chunked request that grows past its route limit; body
+/// readers look for it to answer 413 instead of a generic read failure.
+#[derive(Debug)]
+pub(super) struct RequestBodyLimitExceeded {
+    limit: u64,
+}
+
+impl std::fmt::Display for RequestBodyLimitExceeded {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        write!(f, "request body exceeds the {} byte limit", self.limit)
+    }
+}
+
+impl std::error::Error for RequestBodyLimitExceeded {}
+
+pub(super) async fn handle_request_body_limit(
+    State(rules): State<Arc<Vec<RequestBodyLimitConfig>>>,
+    req: Request,
+    next: Next,
+) -> Response {
+    let Some(limit) = rules
+        .iter()
+        .find(|rule| rule.matches_path(req.uri().path()))
+        .map(|rule| rule.max_bytes)
+    else {
+        return next.run(req).await;
+    };
+
+    let declared = req
+        .headers()
+        .get(header::CONTENT_LENGTH)
+        .and_then(|value| value.to_str().ok())
+        .and_then(|value| value.trim().parse::<u64>().ok());
+    match declared {
+        Some(len) if len > limit => request_body_too_large(limit).into_response(),
+        // hyper rejects bodies longer than their declared length.
+        Some(_) => next.run(req).await,
+        None => {
+            let (parts, body) = req.into_parts();
+            let mut seen = 0u64;
+            let body = Body::from_stream(body.into_data_stream().map(
+                move |chunk| -> Result<Bytes, axum::BoxError> {
+                    let chunk = chunk?;
+                    seen = seen.saturating_add(chunk.len() as u64);
+                    if seen > limit {
+                        return Err(Box::new(RequestBodyLimitExceeded { limit }));
+                    }
+                    Ok(chunk)
+                },
+            ));
+            next.run(Request::from_parts(parts, body)).await
+        }
+    }
+}
+
+fn request_body_too_large(limit: u64) -> (StatusCode, Json<OpenAiErrorResponse>) {
+    openai_error(
+        StatusCode::PAYLOAD_TOO_LARGE,
+        "invalid_request_error",
+        Some("request_too_large"),
+        RequestBodyLimitExceeded { limit }.to_string(),
+    )
+}
+
+/// Buffers a request body of at most `limit` bytes. Overflowing `limit`, or a
+/// route limit enforced by [`handle_request_body_limit`], yields 413 with the
+/// limit that applied; other read failures are 400.
+pub(super) async fn read_request_body_limited(
+    body: Body,
+    limit: usize,
+) -> Result<Bytes, (StatusCode, Json<OpenAiErrorResponse>)> {
+    let too_large = || request_body_too_large(limit as u64);
+    let size_hint = HttpBody::size_hint(&body);
+    if size_hint.lower() > limit as u64 {
+        return Err(too_large());
+    }
+
+    let mut buffered = bytes::BytesMut::with_capacity(size_hint.lower() as usize);
+    let mut stream = body.into_data_stream();
+    while let Some(chunk) = stream.next().await {
+        let chunk = chunk.map_err(|err| request_body_read_error(&err))?;
+        if buffered.len().saturating_add(chunk.len()) > limit {
+            return Err(too_large());
+        }
+        buffered.extend_from_slice(&chunk);
+    }
+    Ok(buffered.freeze())
+}
+
+fn request_body_read_error(
+    err: &(dyn std::error::Error + 'static),
+) -> (StatusCode, Json<OpenAiErrorResponse>) {
+    let mut source = Some(err);
+    while let Some(current) = source {
+        if let Some(exceeded) = current.downcast_ref::<RequestBodyLimitExceeded>() {
+            return request_body_too_large(exceeded.limit);
+        }
+        source = current.source();
+    }
+    openai_error(
+        StatusCode::BAD_REQUEST,
+        "invalid_request_error",
+        Some("invalid_request"),
+        format!("failed to read request body: {err}"),
+    )
+}
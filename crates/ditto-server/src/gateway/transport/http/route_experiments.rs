@@ -0,0 +1,83 @@
+use super::*;
+
+use crate::gateway::EXPERIMENT_KEY_HEADER;
+
+type ProxyError = (StatusCode, Json<OpenAiErrorResponse>);
+
+/// Seeds backend selection for a request that takes part in a route
+/// experiment, so every request with the same experiment key lands on the
+/// same arm. Requests without a key are assigned per request.
+pub(super) fn experiment_route_seed(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+    model: &str,
+    key: Option<&VirtualKeyConfig>,
+) -> Option<String> {
+    let experiment = state.route_experiment_for_model(model, key)?;
+    let experiment_key = extract_header(&parts.headers, EXPERIMENT_KEY_HEADER)?;
+    Some(format!("experiment:{experiment}:{experiment_key}"))
+}
+
+/// A proxied request assigned to an experiment arm, kept until its response
+/// is known.
+pub(super) struct ExperimentObservation {
+    experiment: String,
+    assigned_arm: Option<String>,
+    #[cfg(feature = "gateway-metrics-prometheus")]
+    started: Instant,
+}
+
+impl ExperimentObservation {
+    pub(super) fn new(experiment: String, backend_candidates: &[String]) -> Self {
+        Self {
+            experiment,
+            assigned_arm: backend_candidates.first().cloned(),
+            #[cfg(feature = "gateway-metrics-prometheus")]
+            started: Instant::now(),
+        }
+    }
+}
+
+/// Tags the response with its experiment and counts it against the arm that
+/// served it (the assigned arm when no backend answered).
+pub(super) async fn record_experiment_response(
+    state: &GatewayHttpState,
+    observation: Option<ExperimentObservation>,
+    response: Result<axum::response::Response, ProxyError>,
+) -> Result<axum::response::Response, ProxyError> {
+    let Some(observation) = observation else {
+        return response;
+    };
+    let (status, served_arm) = match &response {
+        Ok(response) => (
+            response.status().as_u16(),
+            extract_header(response.headers(), "x-ditto-backend"),
+        ),
+        Err((status, _)) => (status.as_u16(), None),
+    };
+    let arm = served_arm.or(observation.assigned_arm).unwrap_or_default();
+
+    #[cfg(feature = "gateway-metrics-prometheus")]
+    if let Some(metrics) = state.proxy.metrics.as_ref() {
+        metrics.lock().await.record_proxy_experiment_response(
+            &observation.experiment,
+            &arm,
+            status,
+            observation.started.elapsed(),
+        );
+    }
+    #[cfg(not(feature = "gateway-metrics-prometheus"))]
+    let _ = (state, status);
+
+    response.map(|mut response| {
+        if let Ok(value) = axum::http::HeaderValue::from_str(&observation.experiment) {
+            response.headers_mut().insert("x-ditto-experiment", value);
+        }
+        if let Ok(value) = axum::http::HeaderValue::from_str(&arm) {
+            response
+                .headers_mut()
+                .insert("x-ditto-experiment-arm", value);
+        }
+        response
+    })
+}
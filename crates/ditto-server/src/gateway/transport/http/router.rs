@@ -26,15 +26,43 @@ use super::admin::{list_backends, reset_backend};
 use super::admin::{
     list_cost_ledgers, list_project_cost_ledgers, list_tenant_cost_ledgers, list_user_cost_ledgers,
 };
+use super::admin_prompts::{
+    delete_prompt, get_prompt, get_prompt_version, list_prompt_versions, list_prompts,
+    publish_prompt,
+};
+#[cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+use super::admin_spend::{
+    list_key_spend, list_model_spend, list_project_spend, list_tag_spend, list_tenant_spend,
+    list_user_spend,
+};
 use super::anthropic::{handle_anthropic_count_tokens, handle_anthropic_messages};
+use super::compression::handle_compression;
+use super::cors::handle_cors;
+use super::debug::{
+    InFlightRequests, debug_index, debug_requests, debug_routing, debug_runtime, debug_threads,
+    track_in_flight_requests,
+};
 use super::google_genai::{handle_fallback, handle_google_genai};
+use super::health::health_readiness;
+use super::key_self_service::{handle_key_info, handle_key_usage};
 use super::litellm_keys::litellm_key_router;
 use super::mcp::{
     handle_mcp_namespaced_root, handle_mcp_namespaced_subpath, handle_mcp_root, handle_mcp_subpath,
     handle_mcp_tools_call, handle_mcp_tools_list,
 };
 use super::openai_compat_proxy_path_normalize::handle_openai_compat_proxy_root;
+use super::openai_compat_proxy_rate_limit::handle_rate_limit_headers;
+use super::openai_model_info::handle_openai_models_subpath;
 use super::openai_models::handle_openai_models_list;
+use super::openapi::handle_openapi;
+use super::passthrough_routes::attach_passthrough_routes;
+use super::request_body_limit::handle_request_body_limit;
+use super::token_counter::handle_token_counter;
 use super::*;
 
 use axum::Router;
@@ -44,7 +72,10 @@ fn base_http_router() -> Router<GatewayHttpState> {
     Router::new()
         .route("/health", get(health))
         .route("/ready", get(ready))
+        .route("/health/liveness", get(health))
+        .route("/health/readiness", get(health_readiness))
         .route("/metrics", get(metrics))
+        .route("/openapi.json", get(handle_openapi))
         .route("/v1/gateway", post(handle_gateway))
         .route(
             "/a2a/:agent_id/.well-known/agent-card.json",
@@ -79,11 +110,13 @@ fn base_http_router() -> Router<GatewayHttpState> {
         .route("/files", any(handle_openai_compat_proxy_root))
         .route("/files/*path", any(handle_openai_compat_proxy))
         .route("/rerank", any(handle_openai_compat_proxy_root))
+        .route("/v2/rerank", any(handle_openai_compat_proxy_root))
         .route("/batches", any(handle_openai_compat_proxy_root))
         .route("/batches/*path", any(handle_openai_compat_proxy))
         .route("/models", get(handle_openai_models_list))
-        .route("/models/*path", any(handle_openai_compat_proxy))
+        .route("/models/*path", any(handle_openai_models_subpath))
         .route("/v1/models", get(handle_openai_models_list))
+        .route("/v1/models/*path", any(handle_openai_models_subpath))
         .route("/responses", any(handle_openai_compat_proxy_root))
         .route("/responses/compact", any(handle_openai_compat_proxy_root))
         .route("/responses/*path", any(handle_openai_compat_proxy))
@@ -97,7 +130,10 @@ fn base_http_router() -> Router<GatewayHttpState> {
             "/v1/messages/count_tokens",
             post(handle_anthropic_count_tokens),
         )
+        .route("/utils/token_counter", post(handle_token_counter))
         .route("/v1beta/models/*path", post(handle_google_genai))
+        .route("/v1/key/info", get(handle_key_info))
+        .route("/v1/key/usage", get(handle_key_usage))
         .route("/v1/*path", any(handle_openai_compat_proxy))
         .fallback(handle_fallback)
 }
@@ -112,12 +148,26 @@ fn attach_prometheus_http_routes(router: Router<GatewayHttpState>) -> Router<Gat
     router
 }
 
+fn attach_debug_http_routes(router: Router<GatewayHttpState>) -> Router<GatewayHttpState> {
+    router
+        .route("/debug", get(debug_index))
+        .route("/debug/runtime", get(debug_runtime))
+        .route("/debug/threads", get(debug_threads))
+        .route("/debug/requests", get(debug_requests))
+        .route("/debug/routing", get(debug_routing))
+}
+
 fn attach_admin_http_routes(
     mut router: Router<GatewayHttpState>,
     state: &GatewayHttpState,
 ) -> Router<GatewayHttpState> {
     if state.admin.admin_token.is_some() || state.admin.admin_read_token.is_some() {
+        let mut canary_router = get(get_config_canary);
+        if state.admin.admin_token.is_some() {
+            canary_router = canary_router.put(start_config_canary);
+        }
         router = router
+            .route("/admin/config/canary", canary_router)
             .route("/admin/config/version", get(get_config_version))
             .route("/admin/config/versions", get(list_config_versions))
             .route("/admin/config/export", get(export_config))
@@ -131,7 +181,12 @@ fn attach_admin_http_routes(
     if state.admin.admin_token.is_some() {
         router = router
             .route("/admin/config/router", put(upsert_config_router))
-            .route("/admin/config/rollback", post(rollback_config_version));
+            .route("/admin/config/rollback", post(rollback_config_version))
+            .route("/admin/config/canary/promote", post(promote_config_canary))
+            .route(
+                "/admin/config/canary/rollback",
+                post(rollback_config_canary),
+            );
     }
 
     let mut keys_router = get(list_keys);
@@ -147,6 +202,21 @@ fn attach_admin_http_routes(
         );
     }
 
+    let mut prompts_router = get(list_prompts);
+    let mut prompt_router = get(get_prompt);
+    if state.has_admin_write_tokens() {
+        prompts_router = prompts_router.post(publish_prompt);
+        prompt_router = prompt_router.delete(delete_prompt);
+    }
+    router = router
+        .route("/admin/prompts", prompts_router)
+        .route("/admin/prompts/:id", prompt_router)
+        .route("/admin/prompts/:id/versions", get(list_prompt_versions))
+        .route(
+            "/admin/prompts/:id/versions/:version",
+            get(get_prompt_version),
+        );
+
     #[cfg(feature = "gateway-proxy-cache")]
     if state.proxy.cache.is_some() && state.admin.admin_token.is_some() {
         router = router.route("/admin/proxy_cache/purge", post(purge_proxy_cache));
@@ -183,7 +253,13 @@ fn attach_admin_http_routes(
             .route("/admin/budgets", get(list_budget_ledgers))
             .route("/admin/budgets/tenants", get(list_tenant_budget_ledgers))
             .route("/admin/budgets/projects", get(list_project_budget_ledgers))
-            .route("/admin/budgets/users", get(list_user_budget_ledgers));
+            .route("/admin/budgets/users", get(list_user_budget_ledgers))
+            .route("/admin/spend", get(list_key_spend))
+            .route("/admin/spend/tenants", get(list_tenant_spend))
+            .route("/admin/spend/projects", get(list_project_spend))
+            .route("/admin/spend/users", get(list_user_spend))
+            .route("/admin/spend/models", get(list_model_spend))
+            .route("/admin/spend/tags", get(list_tag_spend));
 
         #[cfg(feature = "gateway-costing")]
         {
@@ -202,14 +278,24 @@ fn attach_admin_http_routes(
     router.merge(litellm_key_router())
 }
 
-#[cfg(feature = "gateway-routing-advanced")]
 fn start_gateway_background_tasks(state: &mut GatewayHttpState) {
-    state.proxy.health_check_task = start_proxy_health_checks(state);
+    #[cfg(feature = "gateway-routing-advanced")]
+    {
+        state.proxy.health_check_task = start_proxy_health_checks(state);
+    }
+    state.proxy.secret_refresh_task = start_backend_secret_refresh(state);
+    state.proxy.alerts_task = start_gateway_alerts(state);
+    #[cfg(any(
+        feature = "gateway-store-sqlite",
+        feature = "gateway-store-postgres",
+        feature = "gateway-store-mysql",
+        feature = "gateway-store-redis"
+    ))]
+    {
+        state.proxy.usage_export_task = start_usage_export(state);
+    }
 }
 
-#[cfg(not(feature = "gateway-routing-advanced"))]
-fn start_gateway_background_tasks(_state: &mut GatewayHttpState) {}
-
 pub fn router(state: GatewayHttpState) -> Router {
     let mut state = state;
     let mut router = attach_prometheus_http_routes(base_http_router());
@@ -218,6 +304,49 @@ pub fn router(state: GatewayHttpState) -> Router {
         router = attach_admin_http_routes(router, &state);
     }
 
+    let config = state.gateway.config_snapshot();
+    // Debug endpoints need a full (non-tenant) admin or admin read token.
+    if config.observability.debug_endpoints
+        && (state.admin.admin_token.is_some() || state.admin.admin_read_token.is_some())
+    {
+        router = attach_debug_http_routes(router);
+        state.proxy.in_flight = Some(Arc::new(InFlightRequests::new()));
+    }
+    router = attach_passthrough_routes(router, &config.passthrough_routes);
+    // The layers below are built from the startup config. Config versions
+    // only swap `virtual_keys` and `router`, so changing these rules needs a
+    // restart (documented per section in the config reference).
+    let cors = config.cors;
+    let compression = config.compression;
+    let request_body_limits = config.request_body_limits;
     start_gateway_background_tasks(&mut state);
-    router.with_state(state)
+    let in_flight = state.proxy.in_flight.clone();
+    let mut router = router
+        .with_state(state)
+        .layer(axum::middleware::from_fn(handle_rate_limit_headers));
+    if let Some(in_flight) = in_flight {
+        router = router.layer(axum::middleware::from_fn_with_state(
+            in_flight,
+            track_in_flight_requests,
+        ));
+    }
+    if !request_body_limits.is_empty() {
+        router = router.layer(axum::middleware::from_fn_with_state(
+            Arc::new(request_body_limits),
+            handle_request_body_limit,
+        ));
+    }
+    if !compression.is_empty() {
+        router = router.layer(axum::middleware::from_fn_with_state(
+            Arc::new(compression),
+            handle_compression,
+        ));
+    }
+    if cors.is_empty() {
+        return router;
+    }
+    router.layer(axum::middleware::from_fn_with_state(
+        Arc::new(cors),
+        handle_cors,
+    ))
 }
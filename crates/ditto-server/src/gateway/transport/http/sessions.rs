@@ -0,0 +1,291 @@
+//! Server-side conversation sessions on `/v1/chat/completions`. A request
+//! with a `session_id` carries only its new messages; the stored history of
+//! that session (scoped to the virtual key) is prepended before the request
+//! is proxied, and the new messages plus the assistant reply are stored once
+//! the reply has been sent to the client.
+
+use super::*;
+
+use super::config_versions::now_epoch_millis_u64;
+use crate::gateway::domain::trim_session_messages;
+use crate::gateway::observability_callbacks::{trace_output_from_json, trace_output_from_sse};
+use crate::gateway::{SessionConfig, SessionStore};
+
+type ProxyError = (StatusCode, Json<OpenAiErrorResponse>);
+
+const MAX_SESSION_ID_BYTES: usize = 256;
+
+fn invalid_session_request(message: impl std::fmt::Display) -> ProxyError {
+    openai_error(
+        StatusCode::BAD_REQUEST,
+        "invalid_request_error",
+        Some("invalid_session_request"),
+        message,
+    )
+}
+
+pub(super) async fn maybe_handle_session_chat_completions(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+    parsed_json: &Option<Value>,
+    request_id: &str,
+    path_and_query: &str,
+) -> Result<Option<axum::response::Response>, ProxyError> {
+    let path = path_and_query
+        .split_once('?')
+        .map(|(path, _)| path)
+        .unwrap_or(path_and_query);
+    if path.trim_end_matches('/') != "/v1/chat/completions" {
+        return Ok(None);
+    }
+    let Some(Value::Object(request)) = parsed_json.as_ref() else {
+        return Ok(None);
+    };
+    let session_id = match request.get("session_id") {
+        None => return Ok(None),
+        Some(Value::String(id))
+            if !id.trim().is_empty() && id.trim().len() <= MAX_SESSION_ID_BYTES =>
+        {
+            id.trim().to_string()
+        }
+        Some(_) => {
+            return Err(invalid_session_request(format!(
+                "session_id must be a non-empty string of at most {MAX_SESSION_ID_BYTES} bytes"
+            )));
+        }
+    };
+    let Some(config) = state.proxy.sessions.clone() else {
+        return Err(openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("sessions_not_enabled"),
+            "conversation sessions are not enabled on this gateway",
+        ));
+    };
+    let key = extract_virtual_key(&parts.headers)
+        .and_then(|token| state.virtual_key_by_token(&token))
+        .filter(|key| key.enabled)
+        .ok_or_else(|| {
+            openai_error(
+                StatusCode::UNAUTHORIZED,
+                "authentication_error",
+                Some("invalid_api_key"),
+                "unauthorized virtual key",
+            )
+        })?;
+    let new_messages = match request.get("messages") {
+        Some(Value::Array(messages)) if !messages.is_empty() => messages.clone(),
+        _ => {
+            return Err(invalid_session_request(
+                "messages must be a non-empty array",
+            ));
+        }
+    };
+
+    let store = state.session_store();
+    let session_key = format!("{}:{session_id}", key.id);
+    let mut messages = store
+        .load_session_messages(&session_key, now_epoch_millis_u64())
+        .await
+        .map_err(|err| {
+            openai_error(
+                StatusCode::SERVICE_UNAVAILABLE,
+                "api_error",
+                Some("session_store_unavailable"),
+                format!("session store unavailable: {err}"),
+            )
+        })?
+        .unwrap_or_default();
+    emit_json_log(
+        state,
+        "proxy.session",
+        serde_json::json!({
+            "request_id": request_id,
+            "virtual_key_id": &key.id,
+            "session_id": &session_id,
+            "history_messages": messages.len(),
+            "new_messages": new_messages.len(),
+        }),
+    );
+    messages.extend(new_messages);
+
+    let mut forwarded = request.clone();
+    forwarded.remove("session_id");
+    forwarded.insert("messages".to_string(), Value::Array(messages.clone()));
+    let response = forward_session_request(state, parts, request_id, &forwarded).await?;
+    if !response.status().is_success() {
+        return Ok(Some(response));
+    }
+    // Keep the summary history compression wrote, unless the forwarded
+    // messages include a rendered prompt template, which is never stored.
+    if !request.contains_key("prompt_id")
+        && let Some(verdict) = response.extensions().get::<HistoryCompressionVerdict>()
+    {
+        messages = verdict.messages.to_vec();
+    }
+
+    let turn = SessionTurn {
+        state: state.clone(),
+        store,
+        config,
+        session_key,
+        request_id: request_id.to_string(),
+        messages,
+    };
+    Ok(Some(record_session_turn(response, turn)))
+}
+
+async fn forward_session_request(
+    state: &GatewayHttpState,
+    parts: &axum::http::request::Parts,
+    request_id: &str,
+    body_json: &serde_json::Map<String, Value>,
+) -> Result<axum::response::Response, ProxyError> {
+    let bytes = serde_json::to_vec(body_json).map_err(|err| {
+        openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("invalid_json"),
+            err,
+        )
+    })?;
+
+    let mut headers = parts.headers.clone();
+    headers.remove("content-length");
+    if let Ok(value) = axum::http::HeaderValue::from_str(request_id) {
+        headers.insert("x-request-id", value);
+    }
+    headers.insert(
+        "content-type",
+        axum::http::HeaderValue::from_static("application/json"),
+    );
+
+    let mut req = axum::http::Request::new(Body::from(bytes));
+    *req.method_mut() = parts.method.clone();
+    *req.uri_mut() = parts.uri.clone();
+    *req.headers_mut() = headers;
+    forward_client_access_context(parts, &mut req);
+    req.extensions_mut().insert(ForwardedProxyRequest {
+        client_supplied_request_id: parts.headers.contains_key("x-request-id"),
+    });
+
+    let fut = Box::pin(handle_openai_compat_proxy(
+        State(state.clone()),
+        Path(String::new()),
+        req,
+    ));
+    fut.await
+}
+
+/// One request's turn, stored once its reply has been read. The history is
+/// read when the request arrives and overwritten when the reply is in, so of
+/// two turns on the same session in flight at once only the later one is
+/// kept.
+struct SessionTurn {
+    state: GatewayHttpState,
+    store: Arc<dyn SessionStore>,
+    config: SessionConfig,
+    session_key: String,
+    request_id: String,
+    /// The history plus this request's messages, or their compressed form.
+    messages: Vec<Value>,
+}
+
+#[derive(Default)]
+struct SessionCapture {
+    buffer: Vec<u8>,
+    truncated: bool,
+    failed: bool,
+}
+
+/// Tees the reply as it streams to the client and stores the turn after the
+/// last chunk, before the body ends. A stream the client abandons never
+/// reaches that point, so no partial turn is stored.
+fn record_session_turn(
+    response: axum::response::Response,
+    turn: SessionTurn,
+) -> axum::response::Response {
+    let is_stream = response
+        .headers()
+        .get("content-type")
+        .and_then(|value| value.to_str().ok())
+        .is_some_and(|ct| ct.to_ascii_lowercase().starts_with("text/event-stream"));
+    let max_bytes = turn.state.proxy.usage_max_body_bytes;
+    let capture = Arc::new(StdMutex::new(SessionCapture::default()));
+
+    let tee = capture.clone();
+    let (parts, body) = response.into_parts();
+    let body = body.into_data_stream().map(move |chunk| {
+        let mut capture = lock_unpoisoned(&tee);
+        match chunk.as_ref() {
+            Ok(bytes) if capture.buffer.len() + bytes.len() <= max_bytes => {
+                capture.buffer.extend_from_slice(bytes);
+            }
+            Ok(_) => capture.truncated = true,
+            Err(_) => capture.failed = true,
+        }
+        chunk
+    });
+    let finish = stream::once(async move {
+        let capture = std::mem::take(&mut *lock_unpoisoned(&capture));
+        turn.finish(capture, is_stream).await;
+    })
+    .filter_map(|()| futures_util::future::ready(None::<Result<Bytes, axum::Error>>));
+
+    axum::response::Response::from_parts(parts, Body::from_stream(body.chain(finish)))
+}
+
+impl SessionTurn {
+    async fn finish(self, capture: SessionCapture, is_stream: bool) {
+        let reply = if capture.failed || capture.truncated {
+            None
+        } else if is_stream {
+            capture
+                .buffer
+                .windows(b"[DONE]".len())
+                .any(|window| window == b"[DONE]")
+                .then(|| trace_output_from_sse(&capture.buffer).completion)
+        } else {
+            serde_json::from_slice::<Value>(&capture.buffer)
+                .ok()
+                .map(|json| trace_output_from_json(&json).completion)
+        };
+        let Some(Value::Object(mut reply)) = reply.filter(|reply| reply.get("role").is_some())
+        else {
+            emit_json_log(
+                &self.state,
+                "proxy.session_skipped",
+                serde_json::json!({
+                    "request_id": &self.request_id,
+                    "truncated": capture.truncated,
+                    "error": capture.failed,
+                }),
+            );
+            return;
+        };
+        reply.retain(|_, value| !value.is_null());
+
+        let mut messages = self.messages;
+        messages.push(Value::Object(reply));
+        let messages = trim_session_messages(messages, &self.config);
+        if let Err(err) = self
+            .store
+            .save_session_messages(
+                &self.session_key,
+                &messages,
+                now_epoch_millis_u64(),
+                self.config.ttl_ms(),
+            )
+            .await
+        {
+            emit_json_log(
+                &self.state,
+                "proxy.session_error",
+                serde_json::json!({
+                    "request_id": &self.request_id,
+                    "error": err.to_string(),
+                }),
+            );
+        }
+    }
+}
@@ -0,0 +1,139 @@
+use super::*;
+
+use std::time::Instant;
+
+use crate::gateway::RouteShadowConfig;
+use crate::gateway::observability_callbacks::{
+    should_sample_trace, trace_output_from_json, trace_output_from_sse,
+};
+
+pub(super) struct ShadowRequest<'a> {
+    pub(super) state: &'a GatewayHttpState,
+    pub(super) shadow: &'a RouteShadowConfig,
+    pub(super) parts: &'a axum::http::request::Parts,
+    pub(super) body: &'a Bytes,
+    pub(super) parsed_json: Option<&'a Value>,
+    pub(super) request_id: &'a str,
+    pub(super) path_and_query: &'a str,
+    pub(super) model: Option<&'a str>,
+    pub(super) strip_authorization: bool,
+}
+
+/// Mirrors a sampled request to the route's shadow backend in the
+/// background. The shadow response is only logged (`proxy.shadow`); it never
+/// reaches the client, and shadow calls are not charged to the key.
+pub(super) fn mirror_shadow_request(request: ShadowRequest<'_>) {
+    let ShadowRequest {
+        state,
+        shadow,
+        parts,
+        body,
+        parsed_json,
+        request_id,
+        path_and_query,
+        model,
+        strip_authorization,
+    } = request;
+    if !should_sample_trace("shadow", request_id, shadow.sample_rate) {
+        return;
+    }
+    let backend_name = shadow.backend.clone();
+    let Some(backend) = state.backends.proxy_backends.get(&backend_name).cloned() else {
+        emit_json_log(
+            state,
+            "proxy.shadow",
+            serde_json::json!({
+                "request_id": request_id,
+                "backend": &backend_name,
+                "model": model,
+                "error": format!("shadow backend is not a proxy backend: {backend_name}"),
+            }),
+        );
+        return;
+    };
+
+    let mut headers = parts.headers.clone();
+    sanitize_proxy_headers(&mut headers, strip_authorization);
+    apply_backend_headers(&mut headers, &backend.headers());
+    insert_request_id(&mut headers, request_id);
+
+    let upstream_model = model.map(|model| {
+        state
+            .mapped_backend_model(&backend_name, model)
+            .unwrap_or_else(|| model.to_string())
+    });
+    let body = match (upstream_model.as_deref(), parsed_json) {
+        (Some(upstream_model), Some(Value::Object(obj))) if Some(upstream_model) != model => {
+            let mut obj = obj.clone();
+            obj.insert(
+                "model".to_string(),
+                Value::String(upstream_model.to_string()),
+            );
+            serde_json::to_vec(&Value::Object(obj))
+                .map(Bytes::from)
+                .unwrap_or_else(|_| body.clone())
+        }
+        _ => body.clone(),
+    };
+
+    let state = state.clone();
+    let method = parts.method.clone();
+    let path_and_query = path_and_query.to_string();
+    let request_id = request_id.to_string();
+    let model = model.map(str::to_string);
+    tokio::spawn(async move {
+        let started = Instant::now();
+        let mut payload = serde_json::json!({
+            "request_id": &request_id,
+            "backend": &backend_name,
+            "model": &model,
+            "upstream_model": &upstream_model,
+        });
+        let result = backend
+            .request_with_timeout(
+                method,
+                &path_and_query,
+                headers,
+                Some(body),
+                backend.request_timeout(),
+            )
+            .await;
+        match result {
+            Ok(response) => {
+                let status = response.status().as_u16();
+                let stream = response
+                    .headers()
+                    .get("content-type")
+                    .and_then(|value| value.to_str().ok())
+                    .is_some_and(|ct| ct.to_ascii_lowercase().starts_with("text/event-stream"));
+                payload["status"] = Value::from(status);
+                match read_reqwest_body_bytes_limited(response, state.proxy.usage_max_body_bytes)
+                    .await
+                {
+                    Ok(bytes) => {
+                        let output = if stream {
+                            Some(trace_output_from_sse(&bytes))
+                        } else {
+                            serde_json::from_slice::<Value>(&bytes)
+                                .ok()
+                                .map(|json| trace_output_from_json(&json))
+                        };
+                        if let Some(output) = output {
+                            payload["completion"] = state.redactor.redact(output.completion);
+                            payload["input_tokens"] = Value::from(output.input_tokens);
+                            payload["output_tokens"] = Value::from(output.output_tokens);
+                        }
+                    }
+                    Err(err) => {
+                        payload["error"] = Value::String(format!("shadow response error: {err}"));
+                    }
+                }
+            }
+            Err(err) => {
+                payload["error"] = Value::String(err.to_string());
+            }
+        }
+        payload["duration_ms"] = Value::from(started.elapsed().as_millis() as u64);
+        emit_json_log(&state, "proxy.shadow", payload);
+    });
+}
@@ -0,0 +1,463 @@
+//! Preemptive admission control for key tiers (`tiers[]`,
+//! `virtual_keys[].tier`). A request of a tier only takes a proxy slot while
+//! the in-flight limits it draws on are below the tier's share, so a burst
+//! of batch traffic leaves the headroom to interactive keys instead of
+//! filling `--proxy-max-in-flight` first. Past its share a request moves to
+//! the tier's overflow backends, waits for a slot to free up, or is shed.
+//!
+//! Admission takes the permits itself and checks the share with them held,
+//! so concurrent requests cannot overshoot it; the first attempt of the
+//! request then uses those permits. Waiting requests sit in a bounded queue
+//! per tier and are admitted in order: first-come first-served within a
+//! tier, tiers with a larger share first.
+
+use super::*;
+
+use std::time::{Duration, Instant};
+
+use tokio::sync::{Notify, oneshot};
+
+use crate::gateway::{GatewayConfig, KeyTierConfig};
+
+const TIER_HEADER: &str = "x-ditto-tier";
+const ADMISSION_HEADER: &str = "x-ditto-admission";
+const QUEUE_MS_HEADER: &str = "x-ditto-queue-ms";
+
+/// Queued requests recheck capacity at least this often, since not every
+/// permit holder signals its release.
+const QUEUE_POLL_INTERVAL: Duration = Duration::from_millis(50);
+
+type ProxyError = (StatusCode, Json<OpenAiErrorResponse>);
+
+/// Tier definitions, highest priority first, and the limits their shares
+/// apply to, built from the startup config when `tiers` is set.
+pub(super) struct TierAdmissionControl {
+    tiers: Vec<KeyTierConfig>,
+    rank_by_name: HashMap<String, usize>,
+    backend_max_in_flight: HashMap<String, usize>,
+    released: Arc<Notify>,
+    /// One queue per entry of `tiers`. Admission decisions for tiered
+    /// requests are made under this lock.
+    queues: StdMutex<Vec<VecDeque<TierWaiter>>>,
+    next_waiter_id: AtomicU64,
+}
+
+struct TierWaiter {
+    id: u64,
+    backend_candidates: Vec<String>,
+    admitted: oneshot::Sender<TierReservation>,
+}
+
+/// Permits taken for a request together with the backends it may use; the
+/// first of them is the one the permits were taken for.
+struct TierReservation {
+    overflow: bool,
+    backend_candidates: Vec<String>,
+    permits: ProxyPermits,
+}
+
+impl TierAdmissionControl {
+    pub(super) fn from_config(config: &GatewayConfig) -> Option<Arc<Self>> {
+        if config.tiers.is_empty() {
+            return None;
+        }
+        let mut tiers = config
+            .tiers
+            .iter()
+            .map(|tier| KeyTierConfig {
+                name: tier.name.trim().to_string(),
+                ..tier.clone()
+            })
+            .collect::<Vec<_>>();
+        // Stable, so equal shares keep their config order.
+        tiers.sort_by(|a, b| b.max_utilization.total_cmp(&a.max_utilization));
+        Some(Arc::new(Self {
+            rank_by_name: tiers
+                .iter()
+                .enumerate()
+                .map(|(rank, tier)| (tier.name.clone(), rank))
+                .collect(),
+            queues: StdMutex::new(tiers.iter().map(|_| VecDeque::new()).collect()),
+            tiers,
+            backend_max_in_flight: config
+                .backends
+                .iter()
+                .filter_map(|backend| Some((backend.name.clone(), backend.max_in_flight?.max(1))))
+                .collect(),
+            released: Arc::new(Notify::new()),
+            next_waiter_id: AtomicU64::new(0),
+        }))
+    }
+
+    /// Woken whenever proxy permits are dropped, see [`ProxyPermits`].
+    pub(super) fn release_signal(&self) -> Arc<Notify> {
+        self.released.clone()
+    }
+
+    /// Takes the permits for a request of `tier` on the first backend it may
+    /// use, in the order: its routed backends, then its overflow backends.
+    fn try_reserve(
+        &self,
+        state: &GatewayHttpState,
+        tier: &KeyTierConfig,
+        backend_candidates: &[String],
+    ) -> Option<TierReservation> {
+        let proxy_permit = match state.proxy.backpressure.as_ref() {
+            Some(limit) => Some(acquire_within_share(
+                limit,
+                state.proxy.max_in_flight,
+                tier,
+            )?),
+            None => None,
+        };
+        let mut overflow = false;
+        let (backend, backend_permit) = match self.reserve_backend(state, tier, backend_candidates)
+        {
+            Some(reserved) => reserved,
+            None => {
+                overflow = true;
+                self.reserve_backend(state, tier, &tier.overflow_backends)?
+            }
+        };
+        let pool = if overflow {
+            &tier.overflow_backends
+        } else {
+            backend_candidates
+        };
+        let mut reserved_candidates = vec![backend.clone()];
+        reserved_candidates.extend(
+            pool.iter()
+                .filter(|candidate| **candidate != backend)
+                .filter(|candidate| self.backend_share_available(state, tier, candidate))
+                .cloned(),
+        );
+        Some(TierReservation {
+            overflow,
+            backend_candidates: reserved_candidates,
+            permits: ProxyPermits::new(proxy_permit, backend_permit, Some(self.release_signal())),
+        })
+    }
+
+    fn reserve_backend(
+        &self,
+        state: &GatewayHttpState,
+        tier: &KeyTierConfig,
+        backends: &[String],
+    ) -> Option<(String, Option<OwnedSemaphorePermit>)> {
+        backends.iter().find_map(|backend| {
+            let Some(limit) = state.proxy.backend_backpressure.get(backend) else {
+                return Some((backend.clone(), None));
+            };
+            let max = self.backend_max_in_flight.get(backend).copied();
+            let permit = acquire_within_share(limit, max, tier)?;
+            Some((backend.clone(), Some(permit)))
+        })
+    }
+
+    fn backend_share_available(
+        &self,
+        state: &GatewayHttpState,
+        tier: &KeyTierConfig,
+        backend: &str,
+    ) -> bool {
+        match (
+            state.proxy.backend_backpressure.get(backend),
+            self.backend_max_in_flight.get(backend),
+        ) {
+            (Some(limit), Some(max)) => share_available(limit, *max, tier),
+            _ => true,
+        }
+    }
+
+    /// Admits queued requests in priority order for as long as the one at the
+    /// front can be admitted; a lower tier never passes a higher one.
+    fn dispatch(&self, state: &GatewayHttpState, queues: &mut [VecDeque<TierWaiter>]) {
+        for (rank, queue) in queues.iter_mut().enumerate() {
+            while let Some(waiter) = queue.front() {
+                if waiter.admitted.is_closed() {
+                    queue.pop_front();
+                    continue;
+                }
+                let Some(reservation) =
+                    self.try_reserve(state, &self.tiers[rank], &waiter.backend_candidates)
+                else {
+                    return;
+                };
+                if let Some(waiter) = queue.pop_front() {
+                    // A waiter that gave up in the meantime drops the permits.
+                    let _ = waiter.admitted.send(reservation);
+                }
+            }
+        }
+    }
+}
+
+#[derive(Clone, Copy, Debug, PartialEq, Eq)]
+pub(super) enum TierDecision {
+    Admitted,
+    Queued,
+    Overflow,
+    Shed,
+}
+
+impl TierDecision {
+    pub(super) fn as_str(self) -> &'static str {
+        match self {
+            Self::Admitted => "admitted",
+            Self::Queued => "queued",
+            Self::Overflow => "overflow",
+            Self::Shed => "shed",
+        }
+    }
+}
+
+pub(super) struct TierAdmission {
+    tier: String,
+    decision: TierDecision,
+    queue_full: bool,
+    queue_wait: Duration,
+}
+
+impl TierAdmission {
+    /// The 429 a shed request fails with.
+    pub(super) fn shed_error(&self) -> Option<ProxyError> {
+        if self.decision != TierDecision::Shed {
+            return None;
+        }
+        Some(if self.queue_full {
+            openai_error(
+                StatusCode::TOO_MANY_REQUESTS,
+                "rate_limit_error",
+                Some("tier_queue_full"),
+                format!("tier {} has too many queued requests", self.tier),
+            )
+        } else {
+            openai_error(
+                StatusCode::TOO_MANY_REQUESTS,
+                "rate_limit_error",
+                Some("tier_capacity"),
+                format!("tier {} is over its share of proxy capacity", self.tier),
+            )
+        })
+    }
+}
+
+/// The permits tier admission took, used by the request's first attempt on
+/// that backend instead of acquiring its own (see [`take_reserved_proxy_permits`]).
+#[derive(Clone)]
+pub(super) struct ReservedProxyPermits(Arc<StdMutex<Option<(String, ProxyPermits)>>>);
+
+/// Hands the permits reserved by tier admission to an attempt on `backend`.
+/// Whichever attempt comes first releases them, so a request that skips the
+/// reserved backend does not hold two sets of permits.
+pub(super) fn take_reserved_proxy_permits(
+    parts: &axum::http::request::Parts,
+    backend: &str,
+) -> Option<ProxyPermits> {
+    let reserved = parts.extensions.get::<ReservedProxyPermits>()?;
+    let (reserved_backend, permits) = lock_unpoisoned(&reserved.0).take()?;
+    (reserved_backend == backend).then_some(permits)
+}
+
+/// Admits a request of `tier` against its capacity share. The admitted
+/// backends replace `backend_candidates` (the tier's overflow backends on
+/// overflow) and the permits for the first are stored in `extensions`; a
+/// shed request is left without candidates, see [`TierAdmission::shed_error`].
+/// Requests without a tier (or with one removed from the config) are not
+/// held back and yield `None`.
+pub(super) async fn admit_tiered_request(
+    state: &GatewayHttpState,
+    tier: Option<&str>,
+    backend_candidates: &mut Vec<String>,
+    extensions: &mut axum::http::Extensions,
+    deadline: Option<ProxyRequestDeadline>,
+) -> Option<TierAdmission> {
+    let control = state.proxy.tier_admission.as_ref()?;
+    let rank = *control.rank_by_name.get(tier?.trim())?;
+    let tier = &control.tiers[rank];
+
+    let started = Instant::now();
+    let queue_timeout = cap_timeout(Some(Duration::from_millis(tier.queue_timeout_ms)), deadline)
+        .unwrap_or_default();
+    let (mut queue_full, mut queued) = (false, false);
+    let reservation = 'admit: {
+        let mut admitted = {
+            let mut queues = lock_unpoisoned(&control.queues);
+            // Requests already waiting at this priority or above go first.
+            if queues[..=rank].iter().all(VecDeque::is_empty)
+                && let Some(reservation) = control.try_reserve(state, tier, backend_candidates)
+            {
+                break 'admit Some(reservation);
+            }
+            if queue_timeout.is_zero() {
+                break 'admit None;
+            }
+            queues[rank].retain(|waiter| !waiter.admitted.is_closed());
+            if queues[rank].len() >= tier.max_queue_len {
+                queue_full = true;
+                break 'admit None;
+            }
+            let (admitted, receiver) = oneshot::channel();
+            let id = control.next_waiter_id.fetch_add(1, Ordering::Relaxed);
+            queues[rank].push_back(TierWaiter {
+                id,
+                backend_candidates: backend_candidates.clone(),
+                admitted,
+            });
+            queued = true;
+            (id, receiver)
+        };
+
+        loop {
+            let mut released = std::pin::pin!(control.released.notified());
+            released.as_mut().enable();
+            control.dispatch(state, &mut lock_unpoisoned(&control.queues));
+
+            let remaining = queue_timeout.saturating_sub(started.elapsed());
+            if remaining.is_zero() {
+                lock_unpoisoned(&control.queues)[rank].retain(|waiter| waiter.id != admitted.0);
+                break 'admit admitted.1.try_recv().ok();
+            }
+            tokio::select! {
+                reservation = &mut admitted.1 => break 'admit reservation.ok(),
+                _ = tokio::time::timeout(remaining.min(QUEUE_POLL_INTERVAL), released) => {}
+            }
+        }
+    };
+
+    let decision = match reservation {
+        Some(reservation) => {
+            let decision = if reservation.overflow {
+                TierDecision::Overflow
+            } else if queued {
+                TierDecision::Queued
+            } else {
+                TierDecision::Admitted
+            };
+            let backend = reservation.backend_candidates[0].clone();
+            *backend_candidates = reservation.backend_candidates;
+            extensions.insert(ReservedProxyPermits(Arc::new(StdMutex::new(Some((
+                backend,
+                reservation.permits,
+            ))))));
+            decision
+        }
+        None => {
+            backend_candidates.clear();
+            TierDecision::Shed
+        }
+    };
+
+    let admission = TierAdmission {
+        tier: tier.name.clone(),
+        decision,
+        queue_full,
+        queue_wait: started.elapsed(),
+    };
+    #[cfg(feature = "gateway-metrics-prometheus")]
+    if let Some(metrics) = state.proxy.metrics.as_ref() {
+        metrics.lock().await.record_proxy_tier_admission(
+            &admission.tier,
+            decision.as_str(),
+            admission.queue_wait,
+        );
+    }
+    Some(admission)
+}
+
+/// Takes a permit from `limit` if, with it held, the tier stays within its
+/// share of `max_in_flight` (any free permit when the limit is unknown).
+fn acquire_within_share(
+    limit: &Arc<Semaphore>,
+    max_in_flight: Option<usize>,
+    tier: &KeyTierConfig,
+) -> Option<OwnedSemaphorePermit> {
+    let permit = limit.clone().try_acquire_owned().ok()?;
+    let Some(max_in_flight) = max_in_flight else {
+        return Some(permit);
+    };
+    let in_flight = max_in_flight.saturating_sub(limit.available_permits());
+    (in_flight <= tier.share_of(max_in_flight)).then_some(permit)
+}
+
+fn share_available(limit: &Semaphore, max_in_flight: usize, tier: &KeyTierConfig) -> bool {
+    let in_flight = max_in_flight.saturating_sub(limit.available_permits());
+    in_flight < tier.share_of(max_in_flight)
+}
+
+/// Rejects a virtual key upserted through the admin API with a tier the
+/// config does not define.
+pub(super) fn validate_key_tier(
+    state: &GatewayHttpState,
+    key: &VirtualKeyConfig,
+) -> Result<(), GatewayError> {
+    let Some(tier) = key.tier.as_deref() else {
+        return Ok(());
+    };
+    let known = state
+        .proxy
+        .tier_admission
+        .as_ref()
+        .is_some_and(|control| control.rank_by_name.contains_key(tier.trim()));
+    if known {
+        return Ok(());
+    }
+    Err(GatewayError::InvalidRequest {
+        reason: format!("virtual key `{}` references unknown tier `{tier}`", key.id),
+    })
+}
+
+/// Reports the tier decision on the response. Shed requests get the headers
+/// as well, so their 429 is told apart from the global in-flight limit.
+pub(super) fn apply_tier_admission_headers(
+    admission: Option<&TierAdmission>,
+    response: Result<axum::response::Response, ProxyError>,
+) -> Result<axum::response::Response, ProxyError> {
+    let Some(admission) = admission else {
+        return response;
+    };
+    let mut response = match response {
+        Ok(response) => response,
+        Err(err) if admission.decision == TierDecision::Shed => err.into_response(),
+        Err(err) => return Err(err),
+    };
+    let headers = response.headers_mut();
+    if let Ok(value) = axum::http::HeaderValue::from_str(&admission.tier) {
+        headers.insert(TIER_HEADER, value);
+    }
+    headers.insert(
+        ADMISSION_HEADER,
+        axum::http::HeaderValue::from_static(admission.decision.as_str()),
+    );
+    headers.insert(
+        QUEUE_MS_HEADER,
+        axum::http::HeaderValue::from(admission.queue_wait.as_millis() as u64),
+    );
+    Ok(response)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn share_counts_permits_in_use() {
+        let tier = KeyTierConfig::new("batch", 0.5);
+        let limit = Arc::new(Semaphore::new(4));
+        assert!(share_available(&limit, 4, &tier));
+        let first = acquire_within_share(&limit, Some(4), &tier).expect("first permit");
+        assert!(share_available(&limit, 4, &tier));
+        let _second = acquire_within_share(&limit, Some(4), &tier).expect("second permit");
+        assert!(!share_available(&limit, 4, &tier));
+        assert!(acquire_within_share(&limit, Some(4), &tier).is_none());
+        // The refused permit was given back.
+        assert_eq!(limit.available_permits(), 2);
+        assert!(share_available(
+            &limit,
+            4,
+            &KeyTierConfig::new("interactive", 1.0)
+        ));
+        drop(first);
+        assert!(acquire_within_share(&limit, Some(4), &tier).is_some());
+    }
+}
@@ -0,0 +1,117 @@
+use super::*;
+
+#[derive(Debug, Serialize)]
+pub(super) struct TokenCounterResponse {
+    total_tokens: u32,
+    request_model: String,
+    model_used: String,
+    tokenizer_type: &'static str,
+    exact: bool,
+}
+
+/// `POST /utils/token_counter` (LiteLLM-compatible): counts the prompt tokens
+/// of `messages` or `prompt` for the model the request would be routed to,
+/// after backend `model_map` aliases, so clients can size requests without
+/// shipping tokenizers.
+pub(super) async fn handle_token_counter(
+    State(state): State<GatewayHttpState>,
+    req: axum::http::Request<Body>,
+) -> Result<Json<TokenCounterResponse>, (StatusCode, Json<OpenAiErrorResponse>)> {
+    const MAX_BODY_BYTES: usize = 4 * 1024 * 1024;
+
+    let (parts, body) = req.into_parts();
+    let key = if gateway_uses_virtual_keys(&state) {
+        let token = extract_virtual_key(&parts.headers).ok_or_else(|| {
+            openai_error(
+                StatusCode::UNAUTHORIZED,
+                "authentication_error",
+                Some("invalid_api_key"),
+                "missing virtual key",
+            )
+        })?;
+        let key = state
+            .virtual_key_by_token(&token)
+            .filter(|key| key.enabled)
+            .ok_or_else(|| {
+                openai_error(
+                    StatusCode::UNAUTHORIZED,
+                    "authentication_error",
+                    Some("invalid_api_key"),
+                    "unauthorized virtual key",
+                )
+            })?;
+        ensure_virtual_key_client_access(&state, &parts, &key).await?;
+        Some(key)
+    } else {
+        None
+    };
+
+    let body = to_bytes(body, MAX_BODY_BYTES).await.map_err(|err| {
+        openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("invalid_request"),
+            err,
+        )
+    })?;
+    let request: Value = serde_json::from_slice(&body).map_err(|err| {
+        openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("invalid_json"),
+            format!("invalid JSON: {err}"),
+        )
+    })?;
+    let request_model = request
+        .get("model")
+        .and_then(Value::as_str)
+        .map(str::trim)
+        .filter(|model| !model.is_empty())
+        .ok_or_else(|| {
+            openai_error(
+                StatusCode::BAD_REQUEST,
+                "invalid_request_error",
+                Some("invalid_request"),
+                "model is required",
+            )
+        })?
+        .to_string();
+    let Some(counted) = request
+        .get("messages")
+        .filter(|messages| messages.is_array())
+        .or_else(|| request.get("prompt"))
+    else {
+        return Err(openai_error(
+            StatusCode::BAD_REQUEST,
+            "invalid_request_error",
+            Some("invalid_request"),
+            "messages or prompt is required",
+        ));
+    };
+
+    let model_used = state
+        .select_backends_for_model_seeded(&request_model, key.as_ref(), None)
+        .ok()
+        .and_then(|backends| backends.into_iter().next())
+        .and_then(|backend| state.mapped_backend_model(&backend, &request_model))
+        .unwrap_or_else(|| request_model.clone());
+
+    #[cfg(feature = "gateway-tokenizer")]
+    if let Some(count) = token_count::count_prompt_tokens(&model_used, &request) {
+        return Ok(Json(TokenCounterResponse {
+            total_tokens: count.tokens,
+            request_model,
+            model_used,
+            tokenizer_type: count.tokenizer,
+            exact: count.exact,
+        }));
+    }
+
+    Ok(Json(TokenCounterResponse {
+        total_tokens: estimate_tokens_from_bytes(&Bytes::from(counted.to_string())),
+        request_model,
+        model_used,
+        tokenizer_type: "bytes_estimate",
+        exact: false,
+    }))
+}
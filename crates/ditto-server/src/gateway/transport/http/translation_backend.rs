@@ -36,6 +36,7 @@ pub(super) async fn attempt_translation_backend(
     let path_and_query = params.path_and_query;
     let _now_epoch_seconds = params.now_epoch_seconds;
     let charge_tokens = params.charge_tokens;
+    let deadline = params.deadline;
     let _stream_requested = params.stream_requested;
     let use_persistent_budget = params.use_persistent_budget;
     #[cfg(not(all(
@@ -144,11 +145,14 @@ pub(super) async fn attempt_translation_backend(
         ))));
     }
 
-    let mut proxy_permits = match try_acquire_proxy_permits(state, backend_name)? {
-        ProxyPermitOutcome::Acquired(permits) => permits,
-        ProxyPermitOutcome::BackendRateLimited(err) => {
-            return Ok(BackendAttemptOutcome::Continue(Some(err)));
-        }
+    let mut proxy_permits = match take_reserved_proxy_permits(parts, backend_name) {
+        Some(permits) => permits,
+        None => match try_acquire_proxy_permits(state, backend_name)? {
+            ProxyPermitOutcome::Acquired(permits) => permits,
+            ProxyPermitOutcome::BackendRateLimited(err) => {
+                return Ok(BackendAttemptOutcome::Continue(Some(err)));
+            }
+        },
     };
 
     state.record_backend_call();
@@ -2391,11 +2395,14 @@ pub(super) async fn attempt_translation_backend(
                     translation::responses_request_to_generate_request(parsed_json)
                 };
 
-                let generate_request = match generate_request {
-                    Ok(mut request) => {
-                        request.model = Some(mapped_model);
-                        request
-                    }
+                let generate_request = generate_request.and_then(|mut request| {
+                    request.model = Some(mapped_model);
+                    translation_backend.apply_prompt_cache(&mut request)?;
+                    Ok(request)
+                });
+
+                let mut generate_request = match generate_request {
+                    Ok(request) => request,
                     Err(err) => {
                         break 'translation_backend_attempt Err(openai_error(
                             StatusCode::BAD_REQUEST,
@@ -2406,6 +2413,20 @@ pub(super) async fn attempt_translation_backend(
                     }
                 };
 
+                if let Err(err) = translation::inline_remote_images(
+                    translation_backend.model.provider(),
+                    &mut generate_request,
+                )
+                .await
+                {
+                    break 'translation_backend_attempt Err(openai_error(
+                        StatusCode::BAD_REQUEST,
+                        "invalid_request_error",
+                        Some("invalid_image_url"),
+                        err,
+                    ));
+                }
+
                 let fallback_response_id = if translation::is_chat_completions_path(path_and_query)
                 {
                     format!("chatcmpl_{request_id}")
@@ -2416,21 +2437,75 @@ pub(super) async fn attempt_translation_backend(
                 };
 
                 let include_usage = _stream_requested
-                    && translation::is_chat_completions_path(path_and_query)
+                    && (translation::is_chat_completions_path(path_and_query)
+                        || translation::is_completions_path(path_and_query))
                     && parsed_json
                         .get("stream_options")
                         .and_then(|value| value.get("include_usage"))
                         .and_then(|value| value.as_bool())
                         .unwrap_or(false);
 
+                let emulated_json_schema = translation::emulated_json_schema(
+                    translation_backend.model.provider(),
+                    &generate_request,
+                );
+
                 if _stream_requested {
-                    let stream = match translation_backend.model.stream(generate_request).await {
-                        Ok(stream) => stream,
-                        Err(err) => {
+                    // A stream cannot be re-asked once it has been forwarded,
+                    // so emulation stops at prompting with the schema.
+                    if let Some(schema) = emulated_json_schema.as_ref() {
+                        translation::add_json_schema_instructions(&mut generate_request, schema);
+                    }
+                    #[cfg(feature = "gateway-tokenizer")]
+                    let estimated_prompt_tokens = token_count::estimate_input_tokens(
+                        path_and_query,
+                        &original_model,
+                        parsed_json,
+                    )
+                    .unwrap_or_else(|| estimate_tokens_from_bytes(body));
+                    #[cfg(not(feature = "gateway-tokenizer"))]
+                    let estimated_prompt_tokens = estimate_tokens_from_bytes(body);
+                    let usage_params = translation::StreamUsageParams {
+                        include_usage,
+                        estimated_prompt_tokens,
+                        #[cfg(feature = "gateway-costing")]
+                        cost_usd_micros: state.proxy.pricing.clone().map(|pricing| {
+                            let model = original_model.clone();
+                            let service_tier = service_tier.clone();
+                            let cost_usd_micros: translation::StreamUsageCostFn =
+                                Arc::new(move |usage: &ditto_core::contracts::Usage| {
+                                    pricing.estimate_cost_usd_micros_with_cache_for_service_tier(
+                                        &model,
+                                        clamp_u64_to_u32(usage.input_tokens?),
+                                        usage.cache_input_tokens.map(clamp_u64_to_u32),
+                                        usage.cache_creation_input_tokens.map(clamp_u64_to_u32),
+                                        clamp_u64_to_u32(usage.output_tokens?),
+                                        service_tier.as_deref(),
+                                    )
+                                });
+                            cost_usd_micros
+                        }),
+                        #[cfg(not(feature = "gateway-costing"))]
+                        cost_usd_micros: None,
+                    };
+                    let stream = match open_translation_stream(
+                        translation_backend.model.stream(generate_request),
+                        cap_timeout(translation_backend.request_timeout(), deadline),
+                        cap_timeout(translation_backend.first_token_timeout(), deadline),
+                    )
+                    .await
+                    {
+                        Some(Ok(stream)) => stream,
+                        Some(Err(err)) => {
                             break 'translation_backend_attempt Err(
                                 openai_translation_provider_error(err),
                             );
                         }
+                        None => {
+                            break 'translation_backend_attempt Err(translation_timeout_error(
+                                deadline,
+                            ));
+                        }
                     };
 
                     let stream = if translation::is_chat_completions_path(path_and_query) {
@@ -2439,7 +2514,7 @@ pub(super) async fn attempt_translation_backend(
                             fallback_response_id.clone(),
                             original_model.clone(),
                             _now_epoch_seconds,
-                            include_usage,
+                            usage_params,
                         )
                     } else if translation::is_completions_path(path_and_query) {
                         translation::stream_to_completions_sse(
@@ -2447,6 +2522,7 @@ pub(super) async fn attempt_translation_backend(
                             fallback_response_id.clone(),
                             original_model.clone(),
                             _now_epoch_seconds,
+                            usage_params,
                         )
                     } else {
                         translation::stream_to_responses_sse(
@@ -2462,6 +2538,7 @@ pub(super) async fn attempt_translation_backend(
                                 input_items: responses_input_items.unwrap_or_default(),
                                 response_owner: response_owner.clone(),
                                 response_store_backend: translation_backend.clone(),
+                                usage: usage_params,
                             },
                         )
                     };
@@ -2480,7 +2557,7 @@ pub(super) async fn attempt_translation_backend(
                     apply_proxy_response_headers(&mut headers, backend_name, request_id, false);
 
                     let stream = ProxyBodyStreamWithPermit {
-                        inner: stream.boxed(),
+                        inner: with_sse_keepalive(stream, state.proxy.sse_keepalive_interval),
                         _permits: proxy_permits.take(),
                     };
                     let mut response = axum::response::Response::new(Body::from_stream(stream));
@@ -2488,14 +2565,54 @@ pub(super) async fn attempt_translation_backend(
                     *response.headers_mut() = headers;
                     Ok((response, default_spend))
                 } else {
-                    let generated = match translation_backend.model.generate(generate_request).await
-                    {
-                        Ok(generated) => generated,
-                        Err(err) => {
+                    let generated = run_with_timeout(
+                        cap_timeout(translation_backend.request_timeout(), deadline),
+                        async {
+                            match emulated_json_schema.as_ref() {
+                                Some(schema) => {
+                                    translation::generate_with_emulated_json_schema(
+                                        translation_backend.model.as_ref(),
+                                        generate_request,
+                                        schema,
+                                        translation_backend.structured_output_max_attempts(),
+                                    )
+                                    .await
+                                }
+                                None => translation_backend
+                                    .model
+                                    .generate(generate_request)
+                                    .await
+                                    .map_err(translation::StructuredOutputError::Provider),
+                            }
+                        },
+                    )
+                    .await;
+                    let generated = match generated {
+                        None => {
+                            break 'translation_backend_attempt Err(translation_timeout_error(
+                                deadline,
+                            ));
+                        }
+                        Some(Ok(generated)) => generated,
+                        Some(Err(translation::StructuredOutputError::Provider(err))) => {
                             break 'translation_backend_attempt Err(
                                 openai_translation_provider_error(err),
                             );
                         }
+                        Some(Err(translation::StructuredOutputError::Invalid {
+                            attempts,
+                            errors,
+                        })) => {
+                            break 'translation_backend_attempt Err(openai_error(
+                                StatusCode::BAD_GATEWAY,
+                                "api_error",
+                                Some("structured_output_invalid"),
+                                format!(
+                                    "response did not match response_format json_schema after {attempts} attempt(s): {}",
+                                    errors.join("; ")
+                                ),
+                            ));
+                        }
                     };
 
                     let provider_response_id =
@@ -2611,9 +2728,22 @@ pub(super) async fn attempt_translation_backend(
         let (response, spend) = match result {
             Ok((response, spend)) => (response, spend),
             Err(err) => {
+                // Provider errors arrive without their headers, so only the
+                // circuit breaker can say how long to wait.
+                if err.0 == StatusCode::TOO_MANY_REQUESTS {
+                    let retry_after =
+                        upstream_retry_after_seconds(state, backend_name, &HeaderMap::new()).await;
+                    record_upstream_overload(&parts.extensions, err.0, retry_after);
+                }
                 return Ok(BackendAttemptOutcome::Continue(Some(err)));
             }
         };
+        #[cfg(feature = "gateway-costing")]
+        let response = {
+            let mut response = response;
+            insert_cost_header(response.headers_mut(), spend.cost_usd_micros);
+            response
+        };
 
         let status = StatusCode::OK;
         let spend_tokens = true;
@@ -2800,6 +2930,7 @@ pub(super) async fn attempt_translation_backend(
                 "spent_cost_usd_micros": spent_cost_usd_micros,
                 "body_len": body.len(),
                 "mode": "translation",
+                "tags": request_tags(&parts.headers, parsed_json.as_ref()),
             });
             append_audit_log(state, "proxy", payload)
                 .await
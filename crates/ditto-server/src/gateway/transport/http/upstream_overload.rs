@@ -0,0 +1,275 @@
+//! Provider overload signals (429s, Anthropic's 529, AWS throttling
+//! exceptions) and the `Retry-After` they imply. Attempts record them on the
+//! request; `handle_rate_limit_headers` turns a final response that still
+//! carries one into a plain 429.
+
+use super::*;
+
+use axum::http::header;
+
+use crate::gateway::domain::spend_report::days_from_civil;
+
+/// Anthropic's "overloaded" status.
+const STATUS_OVERLOADED: u16 = 529;
+
+/// Used when neither the provider nor the circuit breaker says how long to
+/// wait.
+pub(super) const DEFAULT_UPSTREAM_RETRY_AFTER_SECONDS: u64 = 1;
+
+pub(super) fn is_overload_status(status: StatusCode) -> bool {
+    status == StatusCode::TOO_MANY_REQUESTS || status.as_u16() == STATUS_OVERLOADED
+}
+
+pub(super) fn is_upstream_overload(status: StatusCode, headers: &HeaderMap) -> bool {
+    is_overload_status(status)
+        || headers
+            .get("x-amzn-errortype")
+            .and_then(|value| value.to_str().ok())
+            .is_some_and(|error_type| {
+                error_type.starts_with("ThrottlingException")
+                    || error_type.starts_with("TooManyRequestsException")
+            })
+}
+
+/// The provider's retry hint, else what is left of the backend's circuit
+/// breaker cooldown.
+pub(super) async fn upstream_retry_after_seconds(
+    state: &GatewayHttpState,
+    backend: &str,
+    headers: &HeaderMap,
+) -> Option<u64> {
+    let now_ms = now_epoch_millis();
+    if let Some(seconds) = provider_retry_after_seconds(headers, now_ms) {
+        return Some(seconds);
+    }
+    #[cfg(feature = "gateway-routing-advanced")]
+    if let Some(health) = state.proxy.backend_health.as_ref() {
+        return health
+            .lock()
+            .await
+            .get(backend)
+            .and_then(|health| health.cooldown_remaining_seconds(now_ms / 1000));
+    }
+    #[cfg(not(feature = "gateway-routing-advanced"))]
+    let _ = (state, backend);
+    None
+}
+
+/// Reads, in order: `retry-after-ms`, `retry-after` (seconds or an HTTP
+/// date), then the OpenAI and Anthropic reset headers of whichever limit ran
+/// out (the latest reset when none says so).
+fn provider_retry_after_seconds(headers: &HeaderMap, now_ms: u64) -> Option<u64> {
+    let header = |name: &str| {
+        headers
+            .get(name)
+            .and_then(|value| value.to_str().ok())
+            .map(str::trim)
+    };
+
+    if let Some(ms) = header("retry-after-ms").and_then(|value| value.parse::<u64>().ok()) {
+        return Some(ms.div_ceil(1000));
+    }
+    if let Some(value) = header(header::RETRY_AFTER.as_str()) {
+        if let Ok(seconds) = value.parse::<u64>() {
+            return Some(seconds);
+        }
+        if let Some(at_ms) = parse_http_date_ms(value) {
+            return Some(at_ms.saturating_sub(now_ms).div_ceil(1000));
+        }
+    }
+
+    let resets = [
+        (
+            "x-ratelimit-remaining-requests",
+            header("x-ratelimit-reset-requests").and_then(parse_duration_ms),
+        ),
+        (
+            "x-ratelimit-remaining-tokens",
+            header("x-ratelimit-reset-tokens").and_then(parse_duration_ms),
+        ),
+        (
+            "anthropic-ratelimit-requests-remaining",
+            header("anthropic-ratelimit-requests-reset")
+                .and_then(parse_rfc3339_ms)
+                .map(|at_ms| at_ms.saturating_sub(now_ms)),
+        ),
+        (
+            "anthropic-ratelimit-tokens-remaining",
+            header("anthropic-ratelimit-tokens-reset")
+                .and_then(parse_rfc3339_ms)
+                .map(|at_ms| at_ms.saturating_sub(now_ms)),
+        ),
+    ];
+    let exhausted = resets
+        .iter()
+        .filter(|(remaining, _)| header(remaining) == Some("0"))
+        .filter_map(|(_, reset_ms)| *reset_ms)
+        .max();
+    exhausted
+        .or_else(|| resets.iter().filter_map(|(_, reset_ms)| *reset_ms).max())
+        .map(|ms| ms.div_ceil(1000))
+}
+
+fn now_epoch_millis() -> u64 {
+    SystemTime::now()
+        .duration_since(UNIX_EPOCH)
+        .map(|duration| duration.as_millis() as u64)
+        .unwrap_or(0)
+}
+
+/// OpenAI's reset durations: `20ms`, `1s`, `6m0s`, `1h2m3.5s`.
+fn parse_duration_ms(value: &str) -> Option<u64> {
+    if value.is_empty() {
+        return None;
+    }
+    let mut total_ms = 0f64;
+    let mut rest = value;
+    while !rest.is_empty() {
+        let number_len = rest
+            .find(|c: char| !c.is_ascii_digit() && c != '.')
+            .unwrap_or(rest.len());
+        let number = rest[..number_len].parse::<f64>().ok()?;
+        rest = &rest[number_len..];
+        let unit_len = rest
+            .find(|c: char| c.is_ascii_digit() || c == '.')
+            .unwrap_or(rest.len());
+        let unit_ms = match &rest[..unit_len] {
+            "ms" => 1.0,
+            "s" => 1_000.0,
+            "m" => 60_000.0,
+            "h" => 3_600_000.0,
+            _ => return None,
+        };
+        rest = &rest[unit_len..];
+        total_ms += number * unit_ms;
+    }
+    Some(total_ms.ceil() as u64)
+}
+
+/// `2024-02-29T13:00:30Z`, with optional fractional seconds and a numeric
+/// offset in place of `Z`.
+fn parse_rfc3339_ms(value: &str) -> Option<u64> {
+    let (date, time) = value.split_once(['T', 't'])?;
+    let mut date_parts = date.splitn(3, '-');
+    let year = date_parts.next()?.parse::<u64>().ok()?;
+    let month = date_parts.next()?.parse::<u64>().ok()?;
+    let day = date_parts.next()?.parse::<u64>().ok()?;
+    let (clock, offset_s) = if let Some(clock) = time.strip_suffix(['Z', 'z']) {
+        (clock, 0i64)
+    } else {
+        let split = time.rfind(['+', '-'])?;
+        let (hours, minutes) = time[split + 1..].split_once(':')?;
+        let offset = hours.parse::<i64>().ok()? * 3600 + minutes.parse::<i64>().ok()? * 60;
+        let sign = if time.as_bytes()[split] == b'-' {
+            -1
+        } else {
+            1
+        };
+        (&time[..split], sign * offset)
+    };
+    let mut clock_parts = clock.splitn(3, ':');
+    let hour = clock_parts.next()?.parse::<u64>().ok()?;
+    let minute = clock_parts.next()?.parse::<u64>().ok()?;
+    let seconds = clock_parts.next()?.parse::<f64>().ok()?;
+    if !(1..=12).contains(&month) || !(1..=31).contains(&day) || year < 1970 {
+        return None;
+    }
+    let local_ms = (days_from_civil(year, month, day) * 86_400 + hour * 3600 + minute * 60) * 1000
+        + (seconds * 1000.0) as u64;
+    u64::try_from(i64::try_from(local_ms).ok()? - offset_s * 1000).ok()
+}
+
+/// IMF-fixdate, the only `Retry-After` date format senders may use:
+/// `Sun, 06 Nov 1994 08:49:37 GMT`.
+fn parse_http_date_ms(value: &str) -> Option<u64> {
+    const MONTHS: [&str; 12] = [
+        "Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec",
+    ];
+    let (_, rest) = value.split_once(", ")?;
+    let mut fields = rest.split(' ');
+    let day = fields.next()?.parse::<u64>().ok()?;
+    let month = fields.next()?;
+    let month = MONTHS.iter().position(|name| *name == month)? as u64 + 1;
+    let year = fields.next()?.parse::<u64>().ok()?;
+    let clock = fields.next()?;
+    if fields.next()? != "GMT" {
+        return None;
+    }
+    parse_rfc3339_ms(&format!("{year:04}-{month:02}-{day:02}T{clock}Z"))
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    // 2024-02-29T13:00:00Z.
+    const NOW_MS: u64 = 1_709_211_600_000;
+
+    fn headers(pairs: &[(&'static str, &'static str)]) -> HeaderMap {
+        pairs
+            .iter()
+            .map(|(name, value)| {
+                (
+                    axum::http::HeaderName::from_static(name),
+                    axum::http::HeaderValue::from_static(value),
+                )
+            })
+            .collect()
+    }
+
+    #[test]
+    fn detects_provider_overload_signals() {
+        let empty = HeaderMap::new();
+        assert!(is_upstream_overload(StatusCode::TOO_MANY_REQUESTS, &empty));
+        assert!(is_upstream_overload(
+            StatusCode::from_u16(529).unwrap(),
+            &empty
+        ));
+        assert!(is_upstream_overload(
+            StatusCode::BAD_REQUEST,
+            &headers(&[("x-amzn-errortype", "ThrottlingException:http://internal/")]),
+        ));
+        assert!(!is_upstream_overload(
+            StatusCode::SERVICE_UNAVAILABLE,
+            &empty
+        ));
+    }
+
+    #[test]
+    fn reads_retry_after_from_provider_headers() {
+        let retry_after = |pairs| provider_retry_after_seconds(&headers(pairs), NOW_MS);
+        assert_eq!(retry_after(&[("retry-after-ms", "1500")]), Some(2));
+        assert_eq!(retry_after(&[("retry-after", "7")]), Some(7));
+        assert_eq!(
+            retry_after(&[("retry-after", "Thu, 29 Feb 2024 13:00:42 GMT")]),
+            Some(42)
+        );
+        // OpenAI: the exhausted dimension wins over a later token reset.
+        assert_eq!(
+            retry_after(&[
+                ("x-ratelimit-remaining-requests", "0"),
+                ("x-ratelimit-reset-requests", "1m2.5s"),
+                ("x-ratelimit-remaining-tokens", "900"),
+                ("x-ratelimit-reset-tokens", "6m0s"),
+            ]),
+            Some(63)
+        );
+        assert_eq!(
+            retry_after(&[("x-ratelimit-reset-tokens", "20ms")]),
+            Some(1)
+        );
+        assert_eq!(
+            retry_after(&[("anthropic-ratelimit-requests-reset", "2024-02-29T13:00:30Z")]),
+            Some(30)
+        );
+        assert_eq!(
+            retry_after(&[(
+                "anthropic-ratelimit-tokens-reset",
+                "2024-02-29T14:00:05+01:00"
+            )]),
+            Some(5)
+        );
+        assert_eq!(retry_after(&[("retry-after", "soon")]), None);
+        assert_eq!(retry_after(&[]), None);
+    }
+}
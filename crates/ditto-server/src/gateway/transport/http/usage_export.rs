@@ -0,0 +1,169 @@
+#![cfg(any(
+    feature = "gateway-store-sqlite",
+    feature = "gateway-store-postgres",
+    feature = "gateway-store-mysql",
+    feature = "gateway-store-redis"
+))]
+
+//! Scheduled usage export (`observability.usage_export`): once a UTC day is
+//! over, its rollup is read from the audit log and written to the configured
+//! destination. Exports are keyed by date, so re-exporting a day overwrites
+//! it with the same rows.
+
+use super::*;
+
+use std::time::Duration;
+
+use super::admin_spend::scan_spend_entries;
+use crate::gateway::domain::usage_export::{
+    UsageRollup, encode_usage_csv, encode_usage_parquet, usage_export_date,
+    usage_export_object_path,
+};
+use crate::gateway::{UsageExportConfig, UsageExportFormat};
+
+const DAY_MS: u64 = 86_400_000;
+
+/// Exports `day` (days since the epoch) and returns where it was written.
+pub(super) async fn export_usage_day(
+    state: &GatewayHttpState,
+    config: &UsageExportConfig,
+    day: u64,
+) -> Result<String, String> {
+    let since_ts_ms = day * DAY_MS;
+    let mut rollup = UsageRollup::default();
+    scan_spend_entries(
+        state,
+        Some(since_ts_ms),
+        Some(since_ts_ms + DAY_MS),
+        usize::MAX,
+        |entry| rollup.record(entry),
+    )
+    .await
+    .map_err(|(_, Json(body))| body.error.message)?;
+    let rows = rollup.into_rows();
+    let bytes = match config.format {
+        UsageExportFormat::Csv => encode_usage_csv(&rows),
+        UsageExportFormat::Parquet => encode_usage_parquet(&rows),
+    };
+
+    let destination = config.destination.trim().trim_end_matches('/');
+    let location = format!(
+        "{destination}/{}",
+        usage_export_object_path(day, config.format.extension())
+    );
+    if destination.starts_with("s3://") || destination.starts_with("gs://") {
+        upload_usage_export(&location, config.format, &bytes).await?;
+    } else {
+        write_local_usage_export(&location, &bytes)
+            .await
+            .map_err(|err| format!("failed to write {location}: {err}"))?;
+    }
+    emit_json_log(
+        state,
+        "usage_export",
+        serde_json::json!({
+            "date": usage_export_date(day),
+            "location": &location,
+            "rows": rows.len(),
+        }),
+    );
+    Ok(location)
+}
+
+/// Writes next to the target and renames, so loaders never see half a file.
+async fn write_local_usage_export(path: &str, bytes: &[u8]) -> std::io::Result<()> {
+    let path = std::path::Path::new(path);
+    if let Some(parent) = path.parent() {
+        tokio::fs::create_dir_all(parent).await?;
+    }
+    let tmp = path.with_extension("tmp");
+    tokio::fs::write(&tmp, bytes).await?;
+    tokio::fs::rename(&tmp, path).await
+}
+
+/// Uploads through the `aws` / `gsutil` CLI, like `ditto-audit-export`, so
+/// the usual credential chains apply.
+async fn upload_usage_export(
+    location: &str,
+    format: UsageExportFormat,
+    bytes: &[u8],
+) -> Result<(), String> {
+    let tmp = std::env::temp_dir().join(format!(
+        "ditto-usage-export-{}-{}.{}",
+        std::process::id(),
+        now_epoch_millis(),
+        format.extension()
+    ));
+    tokio::fs::write(&tmp, bytes)
+        .await
+        .map_err(|err| format!("failed to stage usage export: {err}"))?;
+
+    let (program, mut cmd) = if location.starts_with("s3://") {
+        let mut cmd = tokio::process::Command::new("aws");
+        cmd.arg("s3")
+            .arg("cp")
+            .arg(&tmp)
+            .arg(location)
+            .arg("--content-type")
+            .arg(format.content_type());
+        ("aws s3 cp", cmd)
+    } else {
+        let mut cmd = tokio::process::Command::new("gsutil");
+        cmd.arg("-h")
+            .arg(format!("Content-Type:{}", format.content_type()))
+            .arg("cp")
+            .arg(&tmp)
+            .arg(location);
+        ("gsutil cp", cmd)
+    };
+    let output = cmd.output().await;
+    let _ = tokio::fs::remove_file(&tmp).await;
+    let output = output.map_err(|err| format!("failed to run {program}: {err}"))?;
+    if !output.status.success() {
+        return Err(format!(
+            "{program} failed (exit {}): {}",
+            output.status.code().unwrap_or(-1),
+            String::from_utf8_lossy(&output.stderr).trim()
+        ));
+    }
+    Ok(())
+}
+
+/// Exports the last `backfill_days` completed days at startup, then each day
+/// once it is over. A failed day is retried on the next tick before later
+/// days. Like the alerts task, this stops once the last router state is
+/// dropped.
+pub(super) fn start_usage_export(state: &GatewayHttpState) -> Option<Arc<AbortOnDrop>> {
+    let config = state.gateway.config_snapshot().observability.usage_export?;
+    let state = state.clone();
+    let interval = Duration::from_secs(config.interval_seconds.max(1));
+    let task = tokio::spawn(async move {
+        let mut next_day = (now_epoch_millis() / DAY_MS).saturating_sub(config.backfill_days);
+        loop {
+            let today = now_epoch_millis() / DAY_MS;
+            while next_day < today {
+                if let Err(err) = export_usage_day(&state, &config, next_day).await {
+                    emit_json_log(
+                        &state,
+                        "usage_export",
+                        serde_json::json!({
+                            "date": usage_export_date(next_day),
+                            "error": err,
+                        }),
+                    );
+                    break;
+                }
+                next_day += 1;
+            }
+            tokio::time::sleep(interval).await;
+        }
+    });
+    Some(Arc::new(AbortOnDrop::new(task.abort_handle())))
+}
+
+fn now_epoch_millis() -> u64 {
+    SystemTime::now()
+        .duration_since(UNIX_EPOCH)
+        .map(|duration| duration.as_millis() as u64)
+        .unwrap_or(0)
+}
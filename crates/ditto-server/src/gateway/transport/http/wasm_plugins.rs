@@ -0,0 +1,249 @@
+use super::*;
+
+use axum::http::{HeaderName, HeaderValue};
+
+use crate::gateway::{WasmPlugin, WasmPluginInput, WasmPluginOutput, WasmPluginPhase};
+
+type ProxyError = (StatusCode, Json<OpenAiErrorResponse>);
+
+/// Runs the `pre_call` plugins in load order before the request is
+/// authenticated, so plugins can reject it, add or drop headers (including
+/// the credentials the gateway then checks) and replace the JSON body.
+pub(super) async fn apply_wasm_request_plugins(
+    state: &GatewayHttpState,
+    request_id: &str,
+    method: &axum::http::Method,
+    path_and_query: &str,
+    headers: &mut HeaderMap,
+    mut body: Bytes,
+    mut parsed_json: Option<Value>,
+) -> Result<(Bytes, Option<Value>), ProxyError> {
+    for plugin in state.proxy.wasm_plugins.iter() {
+        if !plugin.handles(WasmPluginPhase::PreCall) {
+            continue;
+        }
+        let input = WasmPluginInput {
+            phase: WasmPluginPhase::PreCall,
+            request_id: request_id.to_string(),
+            method: method.to_string(),
+            path: path_and_query.to_string(),
+            status: None,
+            headers: plugin_headers(headers),
+            body: parsed_json.clone().unwrap_or(Value::Null),
+        };
+        let output = run_wasm_plugin(state, plugin, input).await?;
+        apply_plugin_headers(state, request_id, plugin, &output, headers)?;
+        if let Some(replacement) = output.body {
+            body = Bytes::from(serde_json::to_vec(&replacement).unwrap_or_default());
+            headers.insert("content-type", HeaderValue::from_static("application/json"));
+            headers.remove("content-length");
+            parsed_json = Some(replacement);
+        }
+    }
+    Ok((body, parsed_json))
+}
+
+/// Runs the `post_call` plugins on a buffered JSON response. Streaming and
+/// non-JSON responses pass through untouched.
+pub(super) async fn apply_wasm_response_plugins(
+    state: &GatewayHttpState,
+    request_id: &str,
+    method: &axum::http::Method,
+    path_and_query: &str,
+    response: Result<axum::response::Response, ProxyError>,
+) -> Result<axum::response::Response, ProxyError> {
+    let response = response?;
+    if !state
+        .proxy
+        .wasm_plugins
+        .iter()
+        .any(|plugin| plugin.handles(WasmPluginPhase::PostCall))
+    {
+        return Ok(response);
+    }
+    let is_json = response
+        .headers()
+        .get("content-type")
+        .and_then(|value| value.to_str().ok())
+        .is_some_and(|ct| ct.to_ascii_lowercase().starts_with("application/json"));
+    if !is_json {
+        return Ok(response);
+    }
+
+    let (mut parts, body) = response.into_parts();
+    let bytes = to_bytes(body, state.proxy.max_body_bytes)
+        .await
+        .map_err(|err| {
+            openai_error(
+                StatusCode::BAD_GATEWAY,
+                "api_error",
+                Some("wasm_plugin_response_unavailable"),
+                format!("failed to buffer response for wasm plugins: {err}"),
+            )
+        })?;
+    let Ok(mut json) = serde_json::from_slice::<Value>(&bytes) else {
+        return Ok(axum::response::Response::from_parts(
+            parts,
+            Body::from(bytes),
+        ));
+    };
+
+    let mut modified = false;
+    for plugin in state.proxy.wasm_plugins.iter() {
+        if !plugin.handles(WasmPluginPhase::PostCall) {
+            continue;
+        }
+        let input = WasmPluginInput {
+            phase: WasmPluginPhase::PostCall,
+            request_id: request_id.to_string(),
+            method: method.to_string(),
+            path: path_and_query.to_string(),
+            status: Some(parts.status.as_u16()),
+            headers: plugin_headers(&parts.headers),
+            body: json.clone(),
+        };
+        let output = run_wasm_plugin(state, plugin, input).await?;
+        apply_plugin_headers(state, request_id, plugin, &output, &mut parts.headers)?;
+        if let Some(replacement) = output.body {
+            json = replacement;
+            modified = true;
+        }
+    }
+    if !modified {
+        return Ok(axum::response::Response::from_parts(
+            parts,
+            Body::from(bytes),
+        ));
+    }
+
+    parts.headers.remove("content-length");
+    let bytes = serde_json::to_vec(&json).unwrap_or_else(|_| json.to_string().into_bytes());
+    Ok(axum::response::Response::from_parts(
+        parts,
+        Body::from(bytes),
+    ))
+}
+
+/// Runs one hook off the async runtime. Plugin failures fail the request
+/// closed; rejections become the plugin's status and message.
+async fn run_wasm_plugin(
+    state: &GatewayHttpState,
+    plugin: &WasmPlugin,
+    input: WasmPluginInput,
+) -> Result<WasmPluginOutput, ProxyError> {
+    let request_id = input.request_id.clone();
+    let phase = input.phase;
+    let task_plugin = plugin.clone();
+    let result = match tokio::task::spawn_blocking(move || task_plugin.run(&input)).await {
+        Ok(result) => result.map_err(|err| err.to_string()),
+        Err(err) => Err(format!("wasm plugin {} failed: {err}", plugin.name())),
+    };
+    let output = match result {
+        Ok(output) => output,
+        Err(message) => {
+            emit_json_log(
+                state,
+                "proxy.wasm_plugin_error",
+                serde_json::json!({
+                    "request_id": &request_id,
+                    "plugin": plugin.name(),
+                    "phase": phase,
+                    "error": &message,
+                }),
+            );
+            return Err(wasm_plugin_failed(message));
+        }
+    };
+    if let Some(rejection) = output.reject.as_ref() {
+        emit_json_log(
+            state,
+            "proxy.wasm_plugin_rejected",
+            serde_json::json!({
+                "request_id": &request_id,
+                "plugin": plugin.name(),
+                "phase": phase,
+                "status": rejection.status,
+            }),
+        );
+        let status = StatusCode::from_u16(rejection.status)
+            .ok()
+            .filter(|status| status.is_client_error() || status.is_server_error())
+            .unwrap_or(StatusCode::FORBIDDEN);
+        let message = if rejection.message.trim().is_empty() {
+            format!("rejected by wasm plugin {}", plugin.name())
+        } else {
+            rejection.message.clone()
+        };
+        return Err(openai_error(
+            status,
+            "policy_error",
+            Some("wasm_plugin_rejected"),
+            message,
+        ));
+    }
+    Ok(output)
+}
+
+fn apply_plugin_headers(
+    state: &GatewayHttpState,
+    request_id: &str,
+    plugin: &WasmPlugin,
+    output: &WasmPluginOutput,
+    headers: &mut HeaderMap,
+) -> Result<(), ProxyError> {
+    for name in &output.remove_headers {
+        headers.remove(name.trim());
+    }
+    for (name, value) in &output.set_headers {
+        let header = HeaderName::from_bytes(name.trim().as_bytes())
+            .map_err(|err| err.to_string())
+            .and_then(|name| {
+                HeaderValue::from_str(value)
+                    .map(|value| (name, value))
+                    .map_err(|err| err.to_string())
+            });
+        match header {
+            Ok((name, value)) => {
+                headers.insert(name, value);
+            }
+            Err(err) => {
+                let message = format!(
+                    "wasm plugin {} set invalid header {name}: {err}",
+                    plugin.name()
+                );
+                emit_json_log(
+                    state,
+                    "proxy.wasm_plugin_error",
+                    serde_json::json!({
+                        "request_id": request_id,
+                        "plugin": plugin.name(),
+                        "error": &message,
+                    }),
+                );
+                return Err(wasm_plugin_failed(message));
+            }
+        }
+    }
+    Ok(())
+}
+
+fn plugin_headers(headers: &HeaderMap) -> BTreeMap<String, String> {
+    headers
+        .iter()
+        .filter_map(|(name, value)| {
+            value
+                .to_str()
+                .ok()
+                .map(|value| (name.as_str().to_string(), value.to_string()))
+        })
+        .collect()
+}
+
+fn wasm_plugin_failed(message: String) -> ProxyError {
+    openai_error(
+        StatusCode::INTERNAL_SERVER_ERROR,
+        "api_error",
+        Some("wasm_plugin_failed"),
+        message,
+    )
+}
@@ -0,0 +1,380 @@
+//! WebAssembly policy plugins run at the proxy's pre-call and post-call hook
+//! points, so custom policies can ship without forking the gateway.
+//!
+//! Host ABI (version 1). A plugin is a core wasm module with no imports that
+//! exports:
+//!
+//! - `memory`: its linear memory;
+//! - `ditto_alloc(len: i32) -> i32`: returns a pointer to `len` writable bytes;
+//! - `ditto_on_request(ptr: i32, len: i32) -> i64` and/or
+//!   `ditto_on_response(ptr: i32, len: i32) -> i64`: receive a UTF-8 JSON
+//!   [`WasmPluginInput`] at `ptr..ptr + len` and return
+//!   `(out_ptr << 32) | out_len` pointing at a JSON [`WasmPluginOutput`], or
+//!   `0` to leave the call unchanged.
+//!
+//! Every call runs in a fresh instance with bounded fuel and memory, so
+//! plugins cannot keep state between requests.
+
+use std::collections::BTreeMap;
+use std::path::Path;
+
+use serde::{Deserialize, Serialize};
+use serde_json::Value;
+use thiserror::Error;
+
+const FUEL_PER_CALL: u64 = 100_000_000;
+const MAX_MEMORY_BYTES: usize = 64 * 1024 * 1024;
+
+#[derive(Debug, Error)]
+pub enum WasmPluginError {
+    #[error("failed to load wasm plugin {name}: {message}")]
+    Load { name: String, message: String },
+    #[error("wasm plugin {name} failed: {message}")]
+    Call { name: String, message: String },
+}
+
+#[derive(Clone, Copy, Debug, PartialEq, Eq, Serialize, Deserialize)]
+#[serde(rename_all = "snake_case")]
+pub enum WasmPluginPhase {
+    /// Before authentication and routing; plugins see the client request.
+    PreCall,
+    /// After a non-streaming JSON response comes back from the backend.
+    PostCall,
+}
+
+impl WasmPluginPhase {
+    fn export_name(self) -> &'static str {
+        match self {
+            Self::PreCall => "ditto_on_request",
+            Self::PostCall => "ditto_on_response",
+        }
+    }
+}
+
+/// What the gateway passes to a plugin hook.
+#[derive(Clone, Debug, Serialize)]
+pub struct WasmPluginInput {
+    pub phase: WasmPluginPhase,
+    pub request_id: String,
+    pub method: String,
+    /// Request path and query.
+    pub path: String,
+    /// Response status; only set for `post_call`.
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub status: Option<u16>,
+    /// Request headers for `pre_call`, response headers for `post_call`.
+    /// Header names are lowercase; values that are not valid UTF-8 are left
+    /// out.
+    pub headers: BTreeMap<String, String>,
+    /// The JSON body, or `null` when the body is empty or not JSON.
+    pub body: Value,
+}
+
+/// A plugin's verdict; every field is optional.
+#[derive(Clone, Debug, Default, PartialEq, Deserialize)]
+#[serde(default)]
+pub struct WasmPluginOutput {
+    /// Rejects the call with this status and message instead of continuing.
+    pub reject: Option<WasmPluginRejection>,
+    pub set_headers: BTreeMap<String, String>,
+    pub remove_headers: Vec<String>,
+    /// Replaces the JSON body.
+    pub body: Option<Value>,
+}
+
+#[derive(Clone, Debug, PartialEq, Deserialize)]
+pub struct WasmPluginRejection {
+    #[serde(default = "default_rejection_status")]
+    pub status: u16,
+    #[serde(default)]
+    pub message: String,
+}
+
+fn default_rejection_status() -> u16 {
+    403
+}
+
+struct WasmPluginStoreState {
+    limits: wasmtime::StoreLimits,
+}
+
+/// A compiled plugin; cheap to clone.
+#[derive(Clone)]
+pub struct WasmPlugin {
+    name: String,
+    engine: wasmtime::Engine,
+    instance_pre: wasmtime::InstancePre<WasmPluginStoreState>,
+    on_request: bool,
+    on_response: bool,
+}
+
+impl std::fmt::Debug for WasmPlugin {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        f.debug_struct("WasmPlugin")
+            .field("name", &self.name)
+            .field("on_request", &self.on_request)
+            .field("on_response", &self.on_response)
+            .finish_non_exhaustive()
+    }
+}
+
+impl WasmPlugin {
+    /// Loads a `.wasm` (or `.wat`) file; the plugin is named after the file
+    /// stem.
+    pub fn from_file(path: impl AsRef<Path>) -> Result<Self, WasmPluginError> {
+        let path = path.as_ref();
+        let name = path
+            .file_stem()
+            .and_then(|stem| stem.to_str())
+            .unwrap_or("plugin")
+            .to_string();
+        let bytes = std::fs::read(path).map_err(|err| WasmPluginError::Load {
+            name: name.clone(),
+            message: format!("{}: {err}", path.display()),
+        })?;
+        Self::from_bytes(name, &bytes)
+    }
+
+    /// Compiles a plugin from wasm binary or text and checks its exports
+    /// against the host ABI.
+    pub fn from_bytes(name: impl Into<String>, wasm: &[u8]) -> Result<Self, WasmPluginError> {
+        let name = name.into();
+        let load_error = |message: String| WasmPluginError::Load {
+            name: name.clone(),
+            message,
+        };
+
+        let mut config = wasmtime::Config::new();
+        config.consume_fuel(true);
+        let engine = wasmtime::Engine::new(&config).map_err(|err| load_error(err.to_string()))?;
+        let module =
+            wasmtime::Module::new(&engine, wasm).map_err(|err| load_error(err.to_string()))?;
+
+        let exports = module
+            .exports()
+            .map(|export| export.name().to_string())
+            .collect::<Vec<_>>();
+        let has_export = |name: &str| exports.iter().any(|export| export == name);
+        for required in ["memory", "ditto_alloc"] {
+            if !has_export(required) {
+                return Err(load_error(format!("missing export `{required}`")));
+            }
+        }
+        let on_request = has_export(WasmPluginPhase::PreCall.export_name());
+        let on_response = has_export(WasmPluginPhase::PostCall.export_name());
+        if !on_request && !on_response {
+            return Err(load_error(
+                "exports neither `ditto_on_request` nor `ditto_on_response`".to_string(),
+            ));
+        }
+
+        let linker = wasmtime::Linker::new(&engine);
+        let instance_pre = linker
+            .instantiate_pre(&module)
+            .map_err(|err| load_error(format!("plugins must not import anything: {err}")))?;
+
+        Ok(Self {
+            name,
+            engine,
+            instance_pre,
+            on_request,
+            on_response,
+        })
+    }
+
+    pub fn name(&self) -> &str {
+        &self.name
+    }
+
+    /// Whether the plugin exports the hook for `phase`.
+    pub fn handles(&self, phase: WasmPluginPhase) -> bool {
+        match phase {
+            WasmPluginPhase::PreCall => self.on_request,
+            WasmPluginPhase::PostCall => self.on_response,
+        }
+    }
+
+    /// Runs the plugin's hook for `input.phase` in a fresh instance. Plugins
+    /// that don't export the hook, or return `0`, produce an empty output.
+    pub fn run(&self, input: &WasmPluginInput) -> Result<WasmPluginOutput, WasmPluginError> {
+        if !self.handles(input.phase) {
+            return Ok(WasmPluginOutput::default());
+        }
+        let call_error = |message: String| WasmPluginError::Call {
+            name: self.name.clone(),
+            message,
+        };
+        let hook_name = input.phase.export_name();
+        let input = serde_json::to_vec(input).map_err(|err| call_error(err.to_string()))?;
+        let input_len = u32::try_from(input.len())
+            .map_err(|_| call_error("input exceeds 4 GiB".to_string()))?;
+
+        let mut store = wasmtime::Store::new(
+            &self.engine,
+            WasmPluginStoreState {
+                limits: wasmtime::StoreLimitsBuilder::new()
+                    .memory_size(MAX_MEMORY_BYTES)
+                    .build(),
+            },
+        );
+        store.limiter(|state| &mut state.limits);
+        store
+            .set_fuel(FUEL_PER_CALL)
+            .map_err(|err| call_error(err.to_string()))?;
+
+        let instance = self
+            .instance_pre
+            .instantiate(&mut store)
+            .map_err(|err| call_error(err.to_string()))?;
+        let memory = instance
+            .get_memory(&mut store, "memory")
+            .ok_or_else(|| call_error("export `memory` is not a memory".to_string()))?;
+        let alloc = instance
+            .get_typed_func::<u32, u32>(&mut store, "ditto_alloc")
+            .map_err(|err| call_error(err.to_string()))?;
+        let hook = instance
+            .get_typed_func::<(u32, u32), u64>(&mut store, hook_name)
+            .map_err(|err| call_error(err.to_string()))?;
+
+        let input_ptr = alloc
+            .call(&mut store, input_len)
+            .map_err(|err| call_error(err.to_string()))?;
+        memory
+            .write(&mut store, input_ptr as usize, &input)
+            .map_err(|err| call_error(format!("ditto_alloc returned {input_ptr}: {err}")))?;
+        let packed = hook
+            .call(&mut store, (input_ptr, input_len))
+            .map_err(|err| call_error(err.to_string()))?;
+        if packed == 0 {
+            return Ok(WasmPluginOutput::default());
+        }
+
+        let output_ptr = (packed >> 32) as usize;
+        let output_len = (packed & 0xffff_ffff) as usize;
+        let output = memory
+            .data(&store)
+            .get(output_ptr..output_ptr.saturating_add(output_len))
+            .ok_or_else(|| call_error("output is out of bounds".to_string()))?;
+        serde_json::from_slice(output).map_err(|err| call_error(format!("invalid output: {err}")))
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    /// A plugin whose request hook always returns `output`.
+    fn fixed_output_plugin(output: &str) -> String {
+        format!(
+            r#"(module
+  (memory (export "memory") 1)
+  (data (i32.const 1024) "{data}")
+  (func (export "ditto_alloc") (param i32) (result i32) (i32.const 4096))
+  (func (export "ditto_on_request") (param i32 i32) (result i64)
+    (i64.or (i64.shl (i64.const 1024) (i64.const 32)) (i64.const {len}))))"#,
+            data = output.replace('"', "\\\""),
+            len = output.len(),
+        )
+    }
+
+    fn request_input() -> WasmPluginInput {
+        WasmPluginInput {
+            phase: WasmPluginPhase::PreCall,
+            request_id: "req-1".to_string(),
+            method: "POST".to_string(),
+            path: "/v1/chat/completions".to_string(),
+            status: None,
+            headers: BTreeMap::from([("x-team".to_string(), "search".to_string())]),
+            body: serde_json::json!({"model": "gpt-4o-mini"}),
+        }
+    }
+
+    #[test]
+    fn request_hook_output_is_decoded() {
+        let wat = fixed_output_plugin(
+            r#"{"set_headers":{"x-policy":"on"},"remove_headers":["x-team"],"body":{"model":"small"}}"#,
+        );
+        let plugin = WasmPlugin::from_bytes("policy", wat.as_bytes()).expect("plugin");
+        assert!(plugin.handles(WasmPluginPhase::PreCall));
+        assert!(!plugin.handles(WasmPluginPhase::PostCall));
+
+        let output = plugin.run(&request_input()).expect("run");
+        assert_eq!(
+            output,
+            WasmPluginOutput {
+                reject: None,
+                set_headers: BTreeMap::from([("x-policy".to_string(), "on".to_string())]),
+                remove_headers: vec!["x-team".to_string()],
+                body: Some(serde_json::json!({"model": "small"})),
+            }
+        );
+
+        let mut input = request_input();
+        input.phase = WasmPluginPhase::PostCall;
+        input.status = Some(200);
+        assert_eq!(
+            plugin.run(&input).expect("run"),
+            WasmPluginOutput::default()
+        );
+    }
+
+    #[test]
+    fn rejection_defaults_to_forbidden() {
+        let wat = fixed_output_plugin(r#"{"reject":{"message":"missing x-signature"}}"#);
+        let plugin = WasmPlugin::from_bytes("auth", wat.as_bytes()).expect("plugin");
+
+        let output = plugin.run(&request_input()).expect("run");
+        assert_eq!(
+            output.reject,
+            Some(WasmPluginRejection {
+                status: 403,
+                message: "missing x-signature".to_string(),
+            })
+        );
+    }
+
+    #[test]
+    fn zero_result_leaves_call_unchanged() {
+        let wat = r#"(module
+  (memory (export "memory") 1)
+  (func (export "ditto_alloc") (param i32) (result i32) (i32.const 0))
+  (func (export "ditto_on_request") (param i32 i32) (result i64) (i64.const 0)))"#;
+        let plugin = WasmPlugin::from_bytes("noop", wat.as_bytes()).expect("plugin");
+
+        assert_eq!(
+            plugin.run(&request_input()).expect("run"),
+            WasmPluginOutput::default()
+        );
+    }
+
+    #[test]
+    fn runaway_plugin_runs_out_of_fuel() {
+        let wat = r#"(module
+  (memory (export "memory") 1)
+  (func (export "ditto_alloc") (param i32) (result i32) (i32.const 0))
+  (func (export "ditto_on_request") (param i32 i32) (result i64)
+    (loop $spin (br $spin))
+    (i64.const 0)))"#;
+        let plugin = WasmPlugin::from_bytes("spin", wat.as_bytes()).expect("plugin");
+
+        let err = plugin.run(&request_input()).expect_err("out of fuel");
+        assert!(matches!(err, WasmPluginError::Call { .. }), "{err}");
+    }
+
+    #[test]
+    fn modules_must_follow_the_host_abi() {
+        let no_hooks = r#"(module
+  (memory (export "memory") 1)
+  (func (export "ditto_alloc") (param i32) (result i32) (i32.const 0)))"#;
+        let err = WasmPlugin::from_bytes("empty", no_hooks.as_bytes()).expect_err("no hooks");
+        assert!(err.to_string().contains("ditto_on_request"), "{err}");
+
+        let imports = r#"(module
+  (import "env" "log" (func (param i32 i32)))
+  (memory (export "memory") 1)
+  (func (export "ditto_alloc") (param i32) (result i32) (i32.const 0))
+  (func (export "ditto_on_request") (param i32 i32) (result i64) (i64.const 0)))"#;
+        let err = WasmPlugin::from_bytes("imports", imports.as_bytes()).expect_err("imports");
+        assert!(err.to_string().contains("must not import"), "{err}");
+    }
+}
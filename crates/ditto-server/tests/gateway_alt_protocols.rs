@@ -21,11 +21,20 @@ fn backend_config(name: &str, base_url: String, auth: &str) -> BackendConfig {
         base_url,
         max_in_flight: None,
         timeout_seconds: None,
+        connect_timeout_seconds: None,
+        first_token_timeout_seconds: None,
         headers,
         query_params: BTreeMap::new(),
+        tls: None,
         provider: None,
         provider_config: None,
         model_map: BTreeMap::new(),
+        model_info: BTreeMap::new(),
+        embeddings_max_batch: None,
+        structured_output: None,
+        prompt_cache: None,
+        transport: None,
+        region: None,
     }
 }
 
@@ -35,11 +44,20 @@ fn backend_config_no_auth(name: &str, base_url: String) -> BackendConfig {
         base_url,
         max_in_flight: None,
         timeout_seconds: None,
+        connect_timeout_seconds: None,
+        first_token_timeout_seconds: None,
         headers: BTreeMap::new(),
         query_params: BTreeMap::new(),
+        tls: None,
         provider: None,
         provider_config: None,
         model_map: BTreeMap::new(),
+        model_info: BTreeMap::new(),
+        embeddings_max_batch: None,
+        structured_output: None,
+        prompt_cache: None,
+        transport: None,
+        region: None,
     }
 }
 
@@ -107,6 +125,11 @@ async fn anthropic_messages_proxy_translates_to_openai_chat_completions() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -217,6 +240,11 @@ async fn anthropic_messages_proxy_forwards_x_api_key_when_virtual_keys_disabled(
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -294,6 +322,11 @@ async fn anthropic_messages_proxy_accepts_messages_alias() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -346,6 +379,11 @@ async fn anthropic_count_tokens_accepts_messages_alias() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let gateway = Gateway::new(config);
     let state = GatewayHttpState::new(gateway);
@@ -418,6 +456,11 @@ async fn anthropic_messages_streaming_translates_openai_sse() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -507,6 +550,11 @@ async fn google_generate_content_forwards_x_goog_api_key_when_virtual_keys_disab
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -576,6 +624,11 @@ async fn google_generate_content_forwards_query_key_when_virtual_keys_disabled()
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -648,6 +701,11 @@ async fn google_generate_content_translates_to_openai_chat_completions() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -744,6 +802,11 @@ async fn google_generate_content_accepts_virtual_key_via_query_param() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -832,6 +895,11 @@ async fn cloudcode_generate_content_wraps_google_format() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -30,11 +30,20 @@ fn backend_config(name: &str, base_url: String, auth: &str) -> BackendConfig {
         base_url,
         max_in_flight: None,
         timeout_seconds: None,
+        connect_timeout_seconds: None,
+        first_token_timeout_seconds: None,
         headers,
         query_params: BTreeMap::new(),
+        tls: None,
         provider: None,
         provider_config: None,
         model_map: BTreeMap::new(),
+        model_info: BTreeMap::new(),
+        embeddings_max_batch: None,
+        structured_output: None,
+        prompt_cache: None,
+        transport: None,
+        region: None,
     }
 }
 
@@ -113,6 +122,11 @@ async fn gateway_contract_v0_1_endpoints_match_types() -> Result<(), Box<dyn std
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config)?;
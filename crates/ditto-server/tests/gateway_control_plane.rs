@@ -110,6 +110,14 @@ fn base_key() -> VirtualKeyConfig {
         guardrails: GuardrailsConfig::default(),
         passthrough: PassthroughConfig::default(),
         route: None,
+        allowed_ips: Vec::new(),
+        allowed_origins: Vec::new(),
+        tags: Vec::new(),
+        callbacks: Vec::new(),
+        regions: Vec::new(),
+        mcp: Default::default(),
+        fine_tuning: false,
+        tier: None,
     }
 }
 
@@ -127,6 +135,11 @@ fn base_config(key: VirtualKeyConfig) -> GatewayConfig {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     }
 }
 
@@ -136,11 +149,20 @@ fn provider_backend_config(name: &str, provider: &str) -> BackendConfig {
         base_url: String::new(),
         max_in_flight: None,
         timeout_seconds: None,
+        connect_timeout_seconds: None,
+        first_token_timeout_seconds: None,
         headers: Default::default(),
         query_params: Default::default(),
+        tls: None,
         provider: Some(provider.to_string()),
         provider_config: None,
         model_map: Default::default(),
+        model_info: Default::default(),
+        embeddings_max_batch: None,
+        structured_output: None,
+        prompt_cache: None,
+        transport: None,
+        region: None,
     }
 }
 
@@ -506,6 +528,7 @@ async fn budget_limit_blocks_request() {
     key.budget = BudgetConfig {
         total_tokens: Some(5),
         total_usd_micros: None,
+        reset: None,
     };
     let config = base_config(key);
     let clock = Box::new(FixedClock { now: 360 });
@@ -526,6 +549,7 @@ async fn project_budget_limit_blocks_request() {
     key.project_budget = Some(BudgetConfig {
         total_tokens: Some(5),
         total_usd_micros: None,
+        reset: None,
     });
     let config = base_config(key);
     let clock = Box::new(FixedClock { now: 360 });
@@ -546,6 +570,7 @@ async fn project_budget_is_shared_across_virtual_keys() {
     key_1.project_budget = Some(BudgetConfig {
         total_tokens: Some(10),
         total_usd_micros: None,
+        reset: None,
     });
 
     let mut key_2 = VirtualKeyConfig::new("key-2", "vk-2");
@@ -553,6 +578,7 @@ async fn project_budget_is_shared_across_virtual_keys() {
     key_2.project_budget = Some(BudgetConfig {
         total_tokens: Some(10),
         total_usd_micros: None,
+        reset: None,
     });
 
     let config = GatewayConfig {
@@ -568,6 +594,11 @@ async fn project_budget_is_shared_across_virtual_keys() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let clock = Box::new(FixedClock { now: 360 });
     let mut gateway = Gateway::with_clock(config, clock);
@@ -591,6 +622,7 @@ async fn project_budget_is_isolated_by_tenant_namespace() {
     key_1.project_budget = Some(BudgetConfig {
         total_tokens: Some(15),
         total_usd_micros: None,
+        reset: None,
     });
 
     let mut key_2 = VirtualKeyConfig::new("key-2", "vk-2");
@@ -599,6 +631,7 @@ async fn project_budget_is_isolated_by_tenant_namespace() {
     key_2.project_budget = Some(BudgetConfig {
         total_tokens: Some(15),
         total_usd_micros: None,
+        reset: None,
     });
 
     let config = GatewayConfig {
@@ -614,6 +647,11 @@ async fn project_budget_is_isolated_by_tenant_namespace() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let clock = Box::new(FixedClock { now: 360 });
     let mut gateway = Gateway::with_clock(config, clock);
@@ -636,6 +674,7 @@ async fn user_budget_limit_blocks_request() {
     key.user_budget = Some(BudgetConfig {
         total_tokens: Some(5),
         total_usd_micros: None,
+        reset: None,
     });
     let config = base_config(key);
     let clock = Box::new(FixedClock { now: 360 });
@@ -657,6 +696,7 @@ async fn user_budget_is_isolated_by_tenant_namespace() {
     key_1.user_budget = Some(BudgetConfig {
         total_tokens: Some(10),
         total_usd_micros: None,
+        reset: None,
     });
 
     let mut key_2 = VirtualKeyConfig::new("key-2", "vk-2");
@@ -665,6 +705,7 @@ async fn user_budget_is_isolated_by_tenant_namespace() {
     key_2.user_budget = Some(BudgetConfig {
         total_tokens: Some(10),
         total_usd_micros: None,
+        reset: None,
     });
 
     let config = GatewayConfig {
@@ -680,6 +721,11 @@ async fn user_budget_is_isolated_by_tenant_namespace() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let clock = Box::new(FixedClock { now: 360 });
     let mut gateway = Gateway::with_clock(config, clock);
@@ -709,6 +755,8 @@ async fn router_switches_backend_by_model_prefix() {
             backend: "secondary".to_string(),
             backends: Vec::new(),
             guardrails: None,
+            experiment: None,
+            shadow: None,
         }],
     };
     let clock = Box::new(FixedClock { now: 420 });
@@ -745,6 +793,8 @@ async fn cache_key_does_not_cross_backend_routes() {
             backend: "secondary".to_string(),
             backends: Vec::new(),
             guardrails: None,
+            experiment: None,
+            shadow: None,
         }],
     };
 
@@ -790,6 +840,12 @@ async fn guardrail_override_applies_for_route_rule() {
         max_input_tokens: None,
         allow_models: Vec::new(),
         deny_models: Vec::new(),
+        hooks: Vec::new(),
+        prompt_injection: None,
+        moderation: None,
+        context_window: None,
+        history_compression: None,
+        stream_transforms: Vec::new(),
     };
 
     let mut config = base_config(key);
@@ -804,6 +860,8 @@ async fn guardrail_override_applies_for_route_rule() {
             backend: "primary".to_string(),
             backends: Vec::new(),
             guardrails: Some(GuardrailsConfig::default()),
+            experiment: None,
+            shadow: None,
         }],
     };
 
@@ -836,6 +894,12 @@ async fn guardrail_rejects_banned_phrase() {
         max_input_tokens: None,
         allow_models: Vec::new(),
         deny_models: Vec::new(),
+        hooks: Vec::new(),
+        prompt_injection: None,
+        moderation: None,
+        context_window: None,
+        history_compression: None,
+        stream_transforms: Vec::new(),
     };
     let config = base_config(key);
     let clock = Box::new(FixedClock { now: 480 });
@@ -861,6 +925,12 @@ async fn guardrail_rejects_banned_regex() {
         max_input_tokens: None,
         allow_models: Vec::new(),
         deny_models: Vec::new(),
+        hooks: Vec::new(),
+        prompt_injection: None,
+        moderation: None,
+        context_window: None,
+        history_compression: None,
+        stream_transforms: Vec::new(),
     };
     let config = base_config(key);
     let clock = Box::new(FixedClock { now: 490 });
@@ -886,6 +956,12 @@ async fn guardrail_rejects_pii() {
         max_input_tokens: None,
         allow_models: Vec::new(),
         deny_models: Vec::new(),
+        hooks: Vec::new(),
+        prompt_injection: None,
+        moderation: None,
+        context_window: None,
+        history_compression: None,
+        stream_transforms: Vec::new(),
     };
     let config = base_config(key);
     let clock = Box::new(FixedClock { now: 495 });
@@ -911,6 +987,12 @@ async fn guardrail_rejects_denied_model() {
         max_input_tokens: None,
         allow_models: Vec::new(),
         deny_models: vec!["gpt-4o-mini".to_string()],
+        hooks: Vec::new(),
+        prompt_injection: None,
+        moderation: None,
+        context_window: None,
+        history_compression: None,
+        stream_transforms: Vec::new(),
     };
     let config = base_config(key);
     let clock = Box::new(FixedClock { now: 500 });
@@ -935,6 +1017,12 @@ async fn guardrail_rejects_not_allowed_model() {
         max_input_tokens: None,
         allow_models: vec!["gpt-*".to_string()],
         deny_models: Vec::new(),
+        hooks: Vec::new(),
+        prompt_injection: None,
+        moderation: None,
+        context_window: None,
+        history_compression: None,
+        stream_transforms: Vec::new(),
     };
     let config = base_config(key);
     let clock = Box::new(FixedClock { now: 520 });
@@ -21,11 +21,20 @@ fn backend_config(name: &str, base_url: String, auth: &str) -> BackendConfig {
         base_url,
         max_in_flight: None,
         timeout_seconds: None,
+        connect_timeout_seconds: None,
+        first_token_timeout_seconds: None,
         headers,
         query_params: BTreeMap::new(),
+        tls: None,
         provider: None,
         provider_config: None,
         model_map: BTreeMap::new(),
+        model_info: BTreeMap::new(),
+        embeddings_max_batch: None,
+        structured_output: None,
+        prompt_cache: None,
+        transport: None,
+        region: None,
     }
 }
 
@@ -83,6 +92,11 @@ async fn cost_budget_blocks_proxy_request() -> ditto_core::error::Result<()> {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -158,6 +172,11 @@ async fn cost_budget_rejects_unsupported_endpoint() -> ditto_core::error::Result
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -234,6 +253,11 @@ async fn cost_budget_allows_get_models() -> ditto_core::error::Result<()> {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -304,6 +328,11 @@ async fn cost_budget_rejects_large_multipart_audio_request() -> ditto_core::erro
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -361,6 +390,7 @@ async fn project_cost_budget_blocks_proxy_request() -> ditto_core::error::Result
     key.project_budget = Some(BudgetConfig {
         total_tokens: None,
         total_usd_micros: Some(500_000),
+        reset: None,
     });
 
     let config = GatewayConfig {
@@ -380,6 +410,11 @@ async fn project_cost_budget_blocks_proxy_request() -> ditto_core::error::Result
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -486,6 +521,11 @@ async fn service_tier_pricing_blocks_proxy_request() -> ditto_core::error::Resul
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -583,6 +623,11 @@ async fn backend_model_map_pricing_blocks_proxy_request() -> ditto_core::error::
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -668,6 +713,11 @@ async fn cache_read_pricing_allows_second_request() -> ditto_core::error::Result
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -762,6 +812,11 @@ async fn cache_creation_pricing_blocks_second_request() -> ditto_core::error::Re
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -806,3 +861,263 @@ async fn cache_creation_pricing_blocks_second_request() -> ditto_core::error::Re
     mock.assert_calls(1);
     Ok(())
 }
+
+#[tokio::test]
+async fn proxy_response_reports_cost_with_overrides_and_image_rates()
+-> ditto_core::error::Result<()> {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return Ok(());
+    }
+    let upstream = MockServer::start();
+    let chat_mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(
+                json!({
+                    "id": "ok",
+                    "usage": {
+                        "prompt_tokens": 100,
+                        "completion_tokens": 20,
+                        "total_tokens": 120
+                    }
+                })
+                .to_string(),
+            );
+    });
+    let images_mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/images/generations");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(
+                json!({
+                    "created": 1,
+                    "data": [
+                        {"url": "https://example.com/1.png"},
+                        {"url": "https://example.com/2.png"}
+                    ]
+                })
+                .to_string(),
+            );
+    });
+
+    let pricing = PricingTable::from_litellm_json_str(
+        r#"{
+          "gpt-4o-mini": {"input_cost_per_token": 0.000001, "output_cost_per_token": 0.000002},
+          "dall-e-3": {"output_cost_per_image": 0.04}
+        }"#,
+    )
+    .expect("pricing")
+    .with_litellm_overrides_json_str(r#"{"gpt-4o-mini": {"input_cost_per_token": 0.0000005}}"#)
+    .expect("overrides");
+
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway)
+        .with_proxy_backends(proxy_backends)
+        .with_pricing_table(pricing);
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/chat/completions")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({
+                "model": "gpt-4o-mini",
+                "messages": [{"role":"user","content":"hi"}]
+            })
+            .to_string(),
+        ))
+        .unwrap();
+    let response = app.clone().oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(
+        response
+            .headers()
+            .get("x-ditto-cost")
+            .and_then(|value| value.to_str().ok()),
+        Some("0.000090")
+    );
+
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/images/generations")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({"model": "dall-e-3", "prompt": "a cat", "n": 2}).to_string(),
+        ))
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(
+        response
+            .headers()
+            .get("x-ditto-cost")
+            .and_then(|value| value.to_str().ok()),
+        Some("0.080000")
+    );
+
+    chat_mock.assert_calls(1);
+    images_mock.assert_calls(1);
+    Ok(())
+}
+
+#[tokio::test]
+async fn rerank_response_reports_cost_per_search_unit_or_token()
+-> ditto_core::error::Result<()> {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return Ok(());
+    }
+    let upstream = MockServer::start();
+    let cohere_mock = upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/rerank")
+            .body_includes("rerank-v3.5");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(
+                json!({
+                    "id": "rr-1",
+                    "results": [{"index": 1, "relevance_score": 0.9}],
+                    "meta": {"billed_units": {"search_units": 2}}
+                })
+                .to_string(),
+            );
+    });
+    let jina_mock = upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/rerank")
+            .body_includes("jina-reranker-v2-base-multilingual");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(
+                json!({
+                    "model": "jina-reranker-v2-base-multilingual",
+                    "results": [{"index": 0, "relevance_score": 0.7}],
+                    "usage": {"total_tokens": 40}
+                })
+                .to_string(),
+            );
+    });
+
+    let pricing = PricingTable::from_litellm_json_str(
+        r#"{
+          "rerank-v3.5": {"input_cost_per_query": 0.002},
+          "jina-reranker-v2-base-multilingual": {"input_cost_per_token": 0.00005, "output_cost_per_token": 0.00005}
+        }"#,
+    )
+    .expect("pricing");
+
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway)
+        .with_proxy_backends(proxy_backends)
+        .with_pricing_table(pricing);
+    let app = ditto_server::gateway::http::router(state);
+
+    // Cohere's v2 path is served by the same rerank route.
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v2/rerank")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({
+                "model": "rerank-v3.5",
+                "query": "capital of france",
+                "documents": ["Berlin", "Paris"],
+                "top_n": 1
+            })
+            .to_string(),
+        ))
+        .unwrap();
+    let response = app.clone().oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(
+        response
+            .headers()
+            .get("x-ditto-cost")
+            .and_then(|value| value.to_str().ok()),
+        Some("0.004000")
+    );
+
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/rerank")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({
+                "model": "jina-reranker-v2-base-multilingual",
+                "query": "capital of france",
+                "documents": [{"text": "Paris"}],
+                "return_documents": false
+            })
+            .to_string(),
+        ))
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(
+        response
+            .headers()
+            .get("x-ditto-cost")
+            .and_then(|value| value.to_str().ok()),
+        Some("0.002000")
+    );
+
+    cohere_mock.assert_calls(1);
+    jina_mock.assert_calls(1);
+    Ok(())
+}
@@ -9,9 +9,10 @@ use axum::http::{Request, StatusCode};
 use ditto_server::gateway::SqliteStore;
 use ditto_server::gateway::observability::ObservabilitySnapshot;
 use ditto_server::gateway::{
-    Backend, BudgetConfig, CacheConfig, Gateway, GatewayConfig, GatewayError, GatewayHttpState,
-    GatewayRequest, GatewayResponse, GatewayStateFile, GuardrailsConfig, LimitsConfig,
-    PassthroughConfig, ProxyBackend, RouteBackend, RouterConfig, VirtualKeyConfig,
+    Backend, BudgetConfig, CacheConfig, CorsConfig, Gateway, GatewayConfig, GatewayError,
+    GatewayHttpState, GatewayRequest, GatewayResponse, GatewayStateFile, GuardrailsConfig,
+    LimitsConfig, PassthroughConfig, ProxyBackend, RouteBackend, RouterConfig, UsageExportConfig,
+    UsageExportFormat, VirtualKeyConfig,
 };
 use httpmock::Method::POST;
 use httpmock::MockServer;
@@ -52,6 +53,14 @@ fn base_key() -> VirtualKeyConfig {
         guardrails: GuardrailsConfig::default(),
         passthrough: PassthroughConfig::default(),
         route: None,
+        allowed_ips: Vec::new(),
+        allowed_origins: Vec::new(),
+        tags: Vec::new(),
+        callbacks: Vec::new(),
+        regions: Vec::new(),
+        mcp: Default::default(),
+        fine_tuning: false,
+        tier: None,
     }
 }
 
@@ -69,6 +78,11 @@ fn base_config() -> GatewayConfig {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     }
 }
 
@@ -162,6 +176,207 @@ async fn gateway_http_health_and_readiness_endpoints() -> ditto_core::error::Res
     Ok(())
 }
 
+#[tokio::test]
+async fn gateway_http_serves_openapi_document() -> ditto_core::error::Result<()> {
+    let state = GatewayHttpState::new(Gateway::new(base_config()));
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = Request::builder()
+        .method("GET")
+        .uri("/openapi.json")
+        .body(Body::empty())
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let doc: serde_json::Value = serde_json::from_slice(&body)?;
+    assert_eq!(doc["openapi"], "3.1.0");
+    assert_eq!(doc, ditto_server::gateway::http::openapi_document());
+
+    let chat = &doc["paths"]["/v1/chat/completions"]["post"];
+    assert_eq!(chat["operationId"], "post_v1_chat_completions");
+    assert_eq!(chat["security"][0], json!({"virtualKey": []}));
+    assert!(
+        chat["responses"]["200"]["content"]
+            .get("text/event-stream")
+            .is_some()
+    );
+    let health = &doc["paths"]["/health"]["get"];
+    assert_eq!(health["security"], json!([]));
+    let keys = &doc["paths"]["/admin/keys/{id}"];
+    assert_eq!(keys["parameters"][0]["name"], "id");
+    assert_eq!(keys["delete"]["x-ditto-admin-access"], "write");
+    assert!(doc["components"]["headers"].get("XDittoCost").is_some());
+    let budget_exceeded = doc["x-ditto-error-codes"]["proxy"]
+        .as_array()
+        .expect("proxy error codes")
+        .iter()
+        .find(|code| code["code"] == "budget_exceeded")
+        .expect("budget_exceeded");
+    assert_eq!(budget_exceeded["status"], 402);
+    assert_eq!(budget_exceeded["type"], "insufficient_quota");
+
+    Ok(())
+}
+
+#[tokio::test]
+async fn gateway_http_deep_readiness_reports_model_groups() -> ditto_core::error::Result<()> {
+    let mut config = base_config();
+    config.router = serde_json::from_value(json!({
+        "default_backends": [{ "backend": "primary" }],
+        "rules": [
+            { "model_prefix": "gpt-4o", "backends": [{ "backend": "primary" }, { "backend": "missing" }] },
+            { "model_prefix": "claude-", "backend": "missing" }
+        ]
+    }))?;
+    let mut gateway = Gateway::new(config);
+    gateway.register_backend("primary", EchoBackend);
+    let state = GatewayHttpState::new(gateway);
+
+    let probe = |state: GatewayHttpState, uri: &'static str| async move {
+        let response = ditto_server::gateway::http::router(state)
+            .oneshot(Request::builder().uri(uri).body(Body::empty()).unwrap())
+            .await
+            .unwrap();
+        let status = response.status();
+        let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+        let body: serde_json::Value = serde_json::from_slice(&body).unwrap();
+        (status, body)
+    };
+
+    let (status, liveness) = probe(state.clone(), "/health/liveness").await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(liveness["status"], "ok");
+
+    let (status, readiness) = probe(state.clone(), "/health/readiness").await;
+    assert_eq!(status, StatusCode::SERVICE_UNAVAILABLE);
+    assert_eq!(readiness["status"], "not_ready");
+    let groups = readiness["model_groups"].as_array().expect("model_groups");
+    let group = |name: &str| {
+        groups
+            .iter()
+            .find(|group| group["name"] == name)
+            .unwrap_or_else(|| panic!("missing group {name}"))
+    };
+    assert_eq!(group("*")["status"], "ok");
+    assert_eq!(group("gpt-4o")["status"], "ok");
+    assert_eq!(group("gpt-4o")["backends"][0]["name"], "missing");
+    assert_eq!(group("gpt-4o")["backends"][0]["status"], "error");
+    assert_eq!(group("claude-")["status"], "error");
+    assert_eq!(group("claude-")["required"], true);
+
+    let (status, readiness) = probe(
+        state.with_readiness_model_groups(vec!["gpt-4o".to_string()]),
+        "/health/readiness",
+    )
+    .await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(readiness["status"], "ready");
+    assert_eq!(
+        readiness["model_groups"]
+            .as_array()
+            .and_then(|groups| groups.iter().find(|group| group["name"] == "claude-"))
+            .map(|group| group["required"].clone()),
+        Some(json!(false))
+    );
+
+    Ok(())
+}
+
+#[tokio::test]
+async fn gateway_http_cors_answers_preflight_and_tags_responses() -> ditto_core::error::Result<()> {
+    let mut cors = CorsConfig::new("/v1/", vec!["https://app.example.com".to_string()]);
+    cors.expose_headers = vec!["x-request-id".to_string()];
+    cors.max_age_seconds = Some(600);
+    let mut config = base_config();
+    config.cors = vec![cors];
+    let mut gateway = Gateway::new(config);
+    gateway.register_backend("primary", EchoBackend);
+    let app = ditto_server::gateway::http::router(GatewayHttpState::new(gateway));
+
+    let preflight = |origin: &str| {
+        Request::builder()
+            .method("OPTIONS")
+            .uri("/v1/chat/completions")
+            .header("origin", origin)
+            .header("access-control-request-method", "POST")
+            .header(
+                "access-control-request-headers",
+                "authorization, content-type",
+            )
+            .body(Body::empty())
+            .unwrap()
+    };
+    let response = app
+        .clone()
+        .oneshot(preflight("https://app.example.com"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::NO_CONTENT);
+    let headers = response.headers();
+    assert_eq!(
+        headers["access-control-allow-origin"],
+        "https://app.example.com"
+    );
+    assert!(
+        headers["access-control-allow-headers"]
+            .to_str()
+            .unwrap()
+            .contains("authorization")
+    );
+    assert_eq!(headers["access-control-max-age"], "600");
+
+    let response = app
+        .clone()
+        .oneshot(preflight("https://evil.example.com"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::FORBIDDEN);
+    assert!(
+        !response
+            .headers()
+            .contains_key("access-control-allow-origin")
+    );
+
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/gateway")
+        .header("origin", "https://app.example.com")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({"model": "gpt-4o-mini", "prompt": "hi", "input_tokens": 1, "max_output_tokens": 2})
+                .to_string(),
+        ))
+        .unwrap();
+    let response = app.clone().oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(
+        response.headers()["access-control-allow-origin"],
+        "https://app.example.com"
+    );
+    assert_eq!(
+        response.headers()["access-control-expose-headers"],
+        "x-request-id"
+    );
+
+    let request = Request::builder()
+        .method("GET")
+        .uri("/health")
+        .header("origin", "https://app.example.com")
+        .body(Body::empty())
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert!(
+        !response
+            .headers()
+            .contains_key("access-control-allow-origin")
+    );
+
+    Ok(())
+}
+
 #[cfg(feature = "gateway-store-sqlite")]
 #[tokio::test]
 async fn gateway_http_ready_reports_sqlite_store_failures() -> ditto_core::error::Result<()> {
@@ -1474,6 +1689,104 @@ async fn gateway_http_litellm_key_routes_are_disabled_without_admin_token()
     Ok(())
 }
 
+#[tokio::test]
+async fn gateway_http_debug_endpoints_require_flag_and_admin_token() -> ditto_core::error::Result<()>
+{
+    let debug_get = |uri: &str, token: Option<&str>| {
+        let mut request = Request::builder().method("GET").uri(uri);
+        if let Some(token) = token {
+            request = request.header("x-admin-token", token);
+        }
+        request.body(Body::empty()).unwrap()
+    };
+
+    let mut gateway = Gateway::new(base_config());
+    gateway.register_backend("primary", EchoBackend);
+    let app = ditto_server::gateway::http::router(
+        GatewayHttpState::new(gateway).with_admin_token("admin"),
+    );
+    let response = app
+        .oneshot(debug_get("/debug/runtime", Some("admin")))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::NOT_FOUND);
+
+    let mut config = base_config();
+    config.observability.debug_endpoints = true;
+    let mut gateway = Gateway::new(config);
+    gateway.register_backend("primary", EchoBackend);
+    let app = ditto_server::gateway::http::router(
+        GatewayHttpState::new(gateway)
+            .with_admin_read_token("admin-read")
+            .with_admin_tenant_read_token("t1", "tenant-read"),
+    );
+
+    for (token, status) in [
+        (None, StatusCode::UNAUTHORIZED),
+        (Some("wrong"), StatusCode::UNAUTHORIZED),
+        (Some("tenant-read"), StatusCode::FORBIDDEN),
+    ] {
+        let response = app
+            .clone()
+            .oneshot(debug_get("/debug/runtime", token))
+            .await
+            .unwrap();
+        assert_eq!(response.status(), status, "{token:?}");
+    }
+
+    let response = app
+        .clone()
+        .oneshot(debug_get("/debug/runtime", Some("admin-read")))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let runtime: serde_json::Value = serde_json::from_slice(&body)?;
+    assert_eq!(runtime["pid"], std::process::id());
+    assert_eq!(runtime["requests"]["in_flight"], 0);
+
+    // Tracking keeps the exact size of complete bodies.
+    let response = app
+        .clone()
+        .oneshot(debug_get("/health", None))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert!(
+        axum::body::HttpBody::size_hint(response.body())
+            .exact()
+            .is_some()
+    );
+
+    let response = app
+        .clone()
+        .oneshot(debug_get("/debug/routing", Some("admin-read")))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let routing: serde_json::Value = serde_json::from_slice(&body)?;
+    assert_eq!(
+        routing["router"]["default_backends"][0]["backend"],
+        "primary"
+    );
+    assert!(routing["backends"].is_array());
+
+    let response = app
+        .oneshot(debug_get(
+            "/debug/requests?min_age_ms=0",
+            Some("admin-read"),
+        ))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let requests: serde_json::Value = serde_json::from_slice(&body)?;
+    assert_eq!(requests["count"], 0);
+
+    Ok(())
+}
+
 #[tokio::test]
 async fn gateway_http_litellm_key_generate_info_delete_round_trip() -> ditto_core::error::Result<()>
 {
@@ -1932,6 +2245,7 @@ async fn gateway_http_include_tokens_rejects_reloaded_hashed_virtual_keys()
     let state_file = GatewayStateFile {
         virtual_keys: vec![base_key()],
         router: Some(base_config().router.clone()),
+        prompts: Vec::new(),
     };
     state_file.save(&state_path).expect("save state file");
 
@@ -1943,6 +2257,11 @@ async fn gateway_http_include_tokens_rejects_reloaded_hashed_virtual_keys()
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
     gateway.register_backend("primary", EchoBackend);
 
@@ -2090,6 +2409,427 @@ async fn gateway_http_tenant_admin_sees_proxy_audit_logs_for_owned_virtual_keys(
     Ok(())
 }
 
+#[cfg(feature = "gateway-store-sqlite")]
+#[tokio::test]
+async fn gateway_http_admin_spend_groups_by_tag_and_tenant() -> ditto_core::error::Result<()> {
+    let dir = tempfile::tempdir().expect("tempdir");
+    let store = SqliteStore::new(dir.path().join("gateway.sqlite"));
+    store.init().await.expect("init");
+
+    let mut config = base_config();
+    config.virtual_keys[0].tenant_id = Some("tenant-1".to_string());
+    config.virtual_keys[0].tags = vec!["search".to_string(), "prod".to_string()];
+    let mut other = VirtualKeyConfig::new("key-2", "vk-2");
+    other.tenant_id = Some("tenant-2".to_string());
+    other.tags = vec!["prod".to_string()];
+    config.virtual_keys.push(other);
+
+    let mut gateway = Gateway::new(config);
+    gateway.register_backend("primary", EchoBackend);
+    let state = GatewayHttpState::new(gateway)
+        .with_admin_token("admin-token")
+        .with_admin_tenant_read_token("tenant-1", "tenant-read")
+        .with_sqlite_store(store);
+    let app = ditto_server::gateway::http::router(state);
+
+    for token in ["vk-1", "vk-1", "vk-2"] {
+        let request = Request::builder()
+            .method("POST")
+            .uri("/v1/gateway")
+            .header("authorization", format!("Bearer {token}"))
+            .header("content-type", "application/json")
+            .body(Body::from(
+                json!({
+                    "model": "gpt-4o-mini",
+                    "prompt": "hi",
+                    "input_tokens": 1,
+                    "max_output_tokens": 2
+                })
+                .to_string(),
+            ))
+            .unwrap();
+        let response = app.clone().oneshot(request).await.unwrap();
+        assert_eq!(response.status(), StatusCode::OK);
+    }
+
+    let spend = |uri: &str, token: &str| {
+        Request::builder()
+            .method("GET")
+            .uri(uri)
+            .header("x-admin-token", token)
+            .body(Body::empty())
+            .unwrap()
+    };
+
+    let response = app
+        .clone()
+        .oneshot(spend("/admin/spend/tags", "admin-token"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let rows: serde_json::Value = serde_json::from_slice(&body)?;
+    let rows = rows
+        .as_array()
+        .expect("spend rows")
+        .iter()
+        .map(|row| {
+            (
+                row["group"].as_str(),
+                row["requests"].as_u64(),
+                row["spent_tokens"].as_u64(),
+            )
+        })
+        .collect::<Vec<_>>();
+    assert_eq!(
+        rows,
+        vec![
+            (Some("prod"), Some(3), Some(9)),
+            (Some("search"), Some(2), Some(6)),
+        ]
+    );
+
+    let response = app
+        .clone()
+        .oneshot(spend("/admin/spend/tenants?bucket=day", "tenant-read"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let rows: serde_json::Value = serde_json::from_slice(&body)?;
+    let rows = rows.as_array().expect("spend rows");
+    assert_eq!(rows.len(), 1);
+    assert_eq!(rows[0]["group"], "tenant-1");
+    assert_eq!(rows[0]["requests"], 2);
+    assert!(rows[0]["bucket_start_ms"].is_u64());
+
+    let response = app
+        .oneshot(spend("/admin/spend?tenant_id=tenant-2", "tenant-read"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::FORBIDDEN);
+
+    Ok(())
+}
+
+#[cfg(feature = "gateway-store-sqlite")]
+#[tokio::test]
+async fn gateway_http_key_usage_reports_only_the_calling_key() -> ditto_core::error::Result<()> {
+    let dir = tempfile::tempdir().expect("tempdir");
+    let store = SqliteStore::new(dir.path().join("gateway.sqlite"));
+    store.init().await.expect("init");
+
+    let mut config = base_config();
+    config
+        .virtual_keys
+        .push(VirtualKeyConfig::new("key-2", "vk-2"));
+
+    let mut gateway = Gateway::new(config);
+    gateway.register_backend("primary", EchoBackend);
+    let state = GatewayHttpState::new(gateway).with_sqlite_store(store);
+    let app = ditto_server::gateway::http::router(state);
+
+    for token in ["vk-1", "vk-1", "vk-2"] {
+        let request = Request::builder()
+            .method("POST")
+            .uri("/v1/gateway")
+            .header("authorization", format!("Bearer {token}"))
+            .header("content-type", "application/json")
+            .body(Body::from(
+                json!({
+                    "model": "gpt-4o-mini",
+                    "prompt": "hi",
+                    "input_tokens": 1,
+                    "max_output_tokens": 2
+                })
+                .to_string(),
+            ))
+            .unwrap();
+        let response = app.clone().oneshot(request).await.unwrap();
+        assert_eq!(response.status(), StatusCode::OK);
+    }
+
+    let usage = |uri: &str| {
+        Request::builder()
+            .method("GET")
+            .uri(uri)
+            .header("authorization", "Bearer vk-1")
+            .body(Body::empty())
+            .unwrap()
+    };
+
+    let response = app.clone().oneshot(usage("/v1/key/usage")).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let usage_body: serde_json::Value = serde_json::from_slice(&body)?;
+    assert_eq!(usage_body["key_id"], "key-1");
+    assert_eq!(usage_body["requests"], 2);
+    assert_eq!(usage_body["spent_tokens"], 6);
+    assert_eq!(usage_body["truncated"], false);
+    let daily = usage_body["daily"].as_array().expect("daily rows");
+    assert_eq!(daily.len(), 1);
+    assert_eq!(daily[0]["group"], "gpt-4o-mini");
+    assert!(
+        daily[0]["bucket_start_ms"].as_u64().expect("bucket")
+            >= usage_body["since_ts_ms"].as_u64().expect("since")
+    );
+
+    let response = app.oneshot(usage("/v1/key/usage?days=365")).await.unwrap();
+    assert_eq!(response.status(), StatusCode::BAD_REQUEST);
+
+    Ok(())
+}
+
+#[cfg(feature = "gateway-store-sqlite")]
+#[tokio::test]
+async fn gateway_http_usage_export_writes_daily_csv_rollup() -> ditto_core::error::Result<()> {
+    let dir = tempfile::tempdir().expect("tempdir");
+    let store = SqliteStore::new(dir.path().join("gateway.sqlite"));
+    store.init().await.expect("init");
+    let export_dir = dir.path().join("exports");
+
+    let mut config = base_config();
+    config.virtual_keys[0].tenant_id = Some("tenant-1".to_string());
+    config
+        .virtual_keys
+        .push(VirtualKeyConfig::new("key-2", "vk-2"));
+    config.observability.usage_export = Some(UsageExportConfig {
+        destination: export_dir.display().to_string(),
+        format: UsageExportFormat::Csv,
+        interval_seconds: 3600,
+        backfill_days: 0,
+    });
+
+    let mut gateway = Gateway::new(config);
+    gateway.register_backend("primary", EchoBackend);
+    let state = GatewayHttpState::new(gateway).with_sqlite_store(store);
+    let app = ditto_server::gateway::http::router(state.clone());
+
+    for token in ["vk-1", "vk-1", "vk-2"] {
+        let request = Request::builder()
+            .method("POST")
+            .uri("/v1/gateway")
+            .header("authorization", format!("Bearer {token}"))
+            .header("content-type", "application/json")
+            .body(Body::from(
+                json!({
+                    "model": "gpt-4o-mini",
+                    "prompt": "hi",
+                    "input_tokens": 1,
+                    "max_output_tokens": 2
+                })
+                .to_string(),
+            ))
+            .unwrap();
+        let response = app.clone().oneshot(request).await.unwrap();
+        assert_eq!(response.status(), StatusCode::OK);
+    }
+
+    let now_ms = std::time::SystemTime::now()
+        .duration_since(std::time::UNIX_EPOCH)
+        .expect("clock")
+        .as_millis() as u64;
+    let location = state.export_usage(now_ms).await?;
+    assert!(location.starts_with(&export_dir.display().to_string()));
+    assert!(location.contains("/v1/date="), "{location}");
+    assert!(location.ends_with("/usage.csv"), "{location}");
+
+    let csv = std::fs::read_to_string(&location).expect("export file");
+    let mut lines = csv.lines();
+    assert_eq!(
+        lines.next(),
+        Some(
+            "schema_version,date,key_id,tenant_id,project_id,user_id,model,requests,\
+             input_tokens,output_tokens,spent_tokens,spent_usd_micros"
+        )
+    );
+    let rows = lines
+        .map(|line| {
+            let fields = line.split(',').collect::<Vec<_>>();
+            (
+                fields[0].to_string(),
+                fields[2].to_string(),
+                fields[3].to_string(),
+                fields[6].to_string(),
+                fields[7].to_string(),
+                fields[10].to_string(),
+            )
+        })
+        .collect::<Vec<_>>();
+    let row = |key: &str, tenant: &str, requests: &str, spent: &str| {
+        (
+            "1".to_string(),
+            key.to_string(),
+            tenant.to_string(),
+            "gpt-4o-mini".to_string(),
+            requests.to_string(),
+            spent.to_string(),
+        )
+    };
+    assert_eq!(
+        rows,
+        vec![
+            row("key-1", "tenant-1", "2", "6"),
+            row("key-2", "", "1", "3")
+        ]
+    );
+
+    Ok(())
+}
+
+#[cfg(feature = "gateway-store-sqlite")]
+#[tokio::test]
+async fn gateway_http_request_tags_are_recorded_for_spend_and_audit()
+-> ditto_core::error::Result<()> {
+    let dir = tempfile::tempdir().expect("tempdir");
+    let store = SqliteStore::new(dir.path().join("gateway.sqlite"));
+    store.init().await.expect("init");
+
+    let mut config = base_config();
+    config.virtual_keys[0].tags = vec!["team=search".to_string()];
+    let mut gateway = Gateway::new(config);
+    gateway.register_backend("primary", EchoBackend);
+    let state = GatewayHttpState::new(gateway)
+        .with_admin_token("admin-token")
+        .with_sqlite_store(store);
+    let app = ditto_server::gateway::http::router(state);
+
+    for tags in [Some("feature=summarizer, env=prod"), None] {
+        let mut request = Request::builder()
+            .method("POST")
+            .uri("/v1/gateway")
+            .header("authorization", "Bearer vk-1")
+            .header("content-type", "application/json");
+        if let Some(tags) = tags {
+            request = request.header("x-ditto-tags", tags);
+        }
+        let request = request
+            .body(Body::from(
+                json!({
+                    "model": "gpt-4o-mini",
+                    "prompt": "hi",
+                    "input_tokens": 1,
+                    "max_output_tokens": 2
+                })
+                .to_string(),
+            ))
+            .unwrap();
+        let response = app.clone().oneshot(request).await.unwrap();
+        assert_eq!(response.status(), StatusCode::OK);
+    }
+
+    let request = Request::builder()
+        .method("GET")
+        .uri("/admin/audit?limit=10&tag=env%3Dprod")
+        .header("x-admin-token", "admin-token")
+        .body(Body::empty())
+        .unwrap();
+    let response = app.clone().oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let logs: serde_json::Value = serde_json::from_slice(&body)?;
+    let logs = logs.as_array().expect("audit logs");
+    assert_eq!(logs.len(), 1);
+    assert_eq!(
+        logs[0]["payload"]["tags"],
+        json!(["feature=summarizer", "env=prod", "team=search"])
+    );
+
+    let request = Request::builder()
+        .method("GET")
+        .uri("/admin/spend/tags")
+        .header("x-admin-token", "admin-token")
+        .body(Body::empty())
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let rows: serde_json::Value = serde_json::from_slice(&body)?;
+    let rows = rows
+        .as_array()
+        .expect("spend rows")
+        .iter()
+        .map(|row| (row["group"].as_str(), row["requests"].as_u64()))
+        .collect::<Vec<_>>();
+    assert_eq!(
+        rows,
+        vec![
+            (Some("team=search"), Some(2)),
+            (Some("env=prod"), Some(1)),
+            (Some("feature=summarizer"), Some(1)),
+        ]
+    );
+
+    Ok(())
+}
+
+#[cfg(feature = "gateway-store-sqlite")]
+#[tokio::test]
+async fn gateway_http_admin_audit_logs_record_actor() -> ditto_core::error::Result<()> {
+    let dir = tempfile::tempdir().expect("tempdir");
+    let store = SqliteStore::new(dir.path().join("gateway.sqlite"));
+    store.init().await.expect("init");
+
+    let mut gateway = Gateway::new(base_config());
+    gateway.register_backend("primary", EchoBackend);
+    let state = GatewayHttpState::new(gateway)
+        .with_admin_token("admin-token")
+        .with_admin_tenant_token("tenant-1", "tenant-admin")
+        .with_sqlite_store(store);
+    let app = ditto_server::gateway::http::router(state);
+
+    for (token, id, tenant_id) in [
+        ("admin-token", "key-admin", None),
+        ("tenant-admin", "key-tenant", Some("tenant-1")),
+    ] {
+        let mut key = VirtualKeyConfig::new(id, format!("vk-{id}"));
+        key.tenant_id = tenant_id.map(str::to_string);
+        let request = Request::builder()
+            .method("POST")
+            .uri("/admin/keys")
+            .header("x-admin-token", token)
+            .header("content-type", "application/json")
+            .body(Body::from(serde_json::to_vec(&key)?))
+            .unwrap();
+        let response = app.clone().oneshot(request).await.unwrap();
+        assert_eq!(response.status(), StatusCode::CREATED);
+    }
+
+    let audit_request = Request::builder()
+        .method("GET")
+        .uri("/admin/audit?limit=10")
+        .header("x-admin-token", "admin-token")
+        .body(Body::empty())
+        .unwrap();
+    let audit_response = app.oneshot(audit_request).await.unwrap();
+    assert_eq!(audit_response.status(), StatusCode::OK);
+    let audit_body = to_bytes(audit_response.into_body(), usize::MAX)
+        .await
+        .unwrap();
+    let audit_json: serde_json::Value = serde_json::from_slice(&audit_body)?;
+    let actors = audit_json
+        .as_array()
+        .expect("audit logs")
+        .iter()
+        .filter(|log| log["kind"] == "admin.key.upsert")
+        .map(|log| {
+            (
+                log.pointer("/payload/key_id").and_then(|v| v.as_str()),
+                log.pointer("/payload/actor").and_then(|v| v.as_str()),
+            )
+        })
+        .collect::<Vec<_>>();
+    assert_eq!(
+        actors,
+        vec![
+            (Some("key-tenant"), Some("tenant:tenant-1")),
+            (Some("key-admin"), Some("admin")),
+        ]
+    );
+
+    Ok(())
+}
+
 #[cfg(feature = "gateway-store-sqlite")]
 #[tokio::test]
 async fn gateway_http_audit_export_jsonl_has_hash_chain() -> ditto_core::error::Result<()> {
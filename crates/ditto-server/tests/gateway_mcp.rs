@@ -5,7 +5,8 @@ use std::collections::HashMap;
 use axum::body::{Body, to_bytes};
 use axum::http::{Request, StatusCode};
 use ditto_server::gateway::{
-    Gateway, GatewayConfig, GatewayHttpState, RouteBackend, RouterConfig, VirtualKeyConfig,
+    Gateway, GatewayConfig, GatewayHttpState, McpAccessConfig, RouteBackend, RouterConfig,
+    VirtualKeyConfig,
 };
 use httpmock::Method::POST;
 use httpmock::MockServer;
@@ -26,6 +27,11 @@ fn base_config() -> GatewayConfig {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     }
 }
 
@@ -1212,3 +1218,151 @@ async fn gateway_mcp_tools_list_http_get_maps_upstream_500_to_502() -> ditto_cor
     upstream_mock.assert();
     Ok(())
 }
+
+#[tokio::test]
+async fn gateway_mcp_enforces_virtual_key_tool_access_and_call_rate() -> ditto_core::error::Result<()>
+{
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return Ok(());
+    }
+
+    let local = MockServer::start();
+    let list_local = local.mock(|when, then| {
+        when.method(POST).path("/mcp").body_includes("tools/list");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(
+                json!({
+                    "jsonrpc": "2.0",
+                    "id": 1,
+                    "result": { "tools": [{ "name": "hello" }, { "name": "shell" }] }
+                })
+                .to_string(),
+            );
+    });
+    let call_local = local.mock(|when, then| {
+        when.method(POST).path("/mcp").body_includes("tools/call");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(json!({ "jsonrpc": "2.0", "id": 1, "result": { "ok": true } }).to_string());
+    });
+    let admin = MockServer::start();
+    let admin_mock = admin.mock(|when, then| {
+        when.method(POST).path("/mcp");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(json!({ "jsonrpc": "2.0", "id": 1, "result": { "tools": [] } }).to_string());
+    });
+
+    let mut mcp_servers = HashMap::new();
+    mcp_servers.insert(
+        "local".to_string(),
+        ditto_server::gateway::http::McpServerState::new("local".to_string(), local.url("/mcp"))
+            .expect("mcp state local"),
+    );
+    mcp_servers.insert(
+        "admin".to_string(),
+        ditto_server::gateway::http::McpServerState::new("admin".to_string(), admin.url("/mcp"))
+            .expect("mcp state admin"),
+    );
+
+    let mut config = base_config();
+    config.virtual_keys[0].mcp = McpAccessConfig {
+        servers: vec!["local".to_string()],
+        allowed_tools: vec!["local/hello".to_string()],
+        calls_per_minute: Some(1),
+    };
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway).with_mcp_servers(mcp_servers);
+    let app = ditto_server::gateway::http::router(state);
+
+    let send = |uri: &'static str, servers: Option<&'static str>, body: Value| {
+        let app = app.clone();
+        async move {
+            let mut builder = with_virtual_key(Request::builder())
+                .method("POST")
+                .uri(uri)
+                .header("content-type", "application/json");
+            if let Some(servers) = servers {
+                builder = builder.header("x-mcp-servers", servers);
+            }
+            let response = app
+                .oneshot(builder.body(Body::from(body.to_string())).unwrap())
+                .await
+                .unwrap();
+            let status = response.status();
+            let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+            (status, serde_json::from_slice::<Value>(&bytes).unwrap_or(Value::Null))
+        }
+    };
+
+    // Only the key's servers are listed, and only its allowed tools.
+    let (status, payload) = send("/mcp/tools/list", None, json!({})).await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(payload["tools"], json!([{ "name": "hello" }]));
+
+    let (status, payload) = send("/mcp/tools/list", Some("admin"), json!({})).await;
+    assert_eq!(status, StatusCode::FORBIDDEN);
+    assert_eq!(payload["error"]["code"], "mcp_server_not_allowed");
+
+    let (status, payload) = send("/mcp/tools/call", None, json!({ "name": "shell" })).await;
+    assert_eq!(status, StatusCode::FORBIDDEN);
+    assert_eq!(payload["error"]["code"], "mcp_tool_not_allowed");
+
+    let (status, payload) = send("/mcp/tools/call", None, json!({ "name": "hello" })).await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(payload, json!({ "ok": true }));
+
+    let (status, payload) = send("/mcp/tools/call", None, json!({ "name": "hello" })).await;
+    assert_eq!(status, StatusCode::TOO_MANY_REQUESTS);
+    assert_eq!(payload["error"]["code"], "rate_limited");
+
+    list_local.assert_calls(1);
+    call_local.assert_calls(1);
+    admin_mock.assert_calls(0);
+    Ok(())
+}
+
+#[tokio::test]
+async fn gateway_mcp_enforces_virtual_key_ip_allowlist() -> ditto_core::error::Result<()> {
+    let mut config = base_config();
+    config.virtual_keys[0].allowed_ips = vec!["10.0.0.0/8".to_string()];
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway).with_mcp_servers(HashMap::new());
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = |peer: &str| {
+        let mut request = with_virtual_key(Request::builder())
+            .method("POST")
+            .uri("/mcp")
+            .header("content-type", "application/json")
+            .body(Body::from(
+                json!({
+                    "jsonrpc": "2.0",
+                    "id": 1,
+                    "method": "initialize",
+                })
+                .to_string(),
+            ))
+            .unwrap();
+        let peer: std::net::SocketAddr = peer.parse().expect("peer");
+        request
+            .extensions_mut()
+            .insert(axum::extract::ConnectInfo(peer));
+        request
+    };
+
+    let response = app
+        .clone()
+        .oneshot(request("203.0.113.5:4000"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::FORBIDDEN);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let payload: Value = serde_json::from_slice(&bytes)?;
+    assert_eq!(payload["error"]["code"], "ip_not_allowed");
+
+    let response = app.oneshot(request("10.1.2.3:4000")).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    Ok(())
+}
@@ -5,8 +5,15 @@ use std::collections::{BTreeMap, HashMap};
 use axum::body::{Body, to_bytes};
 use axum::http::{Request, StatusCode};
 use ditto_server::gateway::{
-    BackendConfig, BudgetConfig, Gateway, GatewayConfig, GatewayHttpState, GuardrailsConfig,
-    ProxyBackend, RouteBackend, RouteRule, RouterConfig, VirtualKeyConfig,
+    BackendConfig, BudgetConfig, CompressionConfig, ContextSummarizerConfig, ContextWindowConfig,
+    ContextWindowStrategy, Gateway, GatewayConfig, GatewayHttpState, GuardrailHookAction,
+    GuardrailHookConfig, GuardrailHookPhase, GuardrailPiiEntity, GuardrailsConfig,
+    HistoryCompressionConfig, KeyTierConfig, ModerationAction, ModerationConfig,
+    PassthroughRouteConfig, PromptInjectionAction, PromptInjectionClassifierConfig,
+    PromptInjectionConfig, PromptMessage, PromptTemplate, ProxyBackend, ProxyFixtureMode,
+    ProxyFixtures, RequestBodyLimitConfig, RouteBackend, RouteRule, RouteShadowConfig,
+    RouterConfig, SessionConfig, StreamEventAction, StreamTransform, StreamTransformConfig,
+    VirtualKeyConfig, WatermarkPosition,
 };
 use httpmock::Method::POST;
 use httpmock::MockServer;
@@ -35,11 +42,20 @@ fn backend_config(name: &str, base_url: String, auth: &str) -> BackendConfig {
         base_url,
         max_in_flight: None,
         timeout_seconds: None,
+        connect_timeout_seconds: None,
+        first_token_timeout_seconds: None,
         headers,
         query_params: BTreeMap::new(),
+        tls: None,
         provider: None,
         provider_config: None,
         model_map: BTreeMap::new(),
+        model_info: BTreeMap::new(),
+        embeddings_max_batch: None,
+        structured_output: None,
+        prompt_cache: None,
+        transport: None,
+        region: None,
     }
 }
 
@@ -50,6 +66,7 @@ include!("gateway_openai_proxy/auth_headers.rs");
 include!("gateway_openai_proxy/models.rs");
 include!("gateway_openai_proxy/basic_proxying_litellm_aliases.rs");
 include!("gateway_openai_proxy/routing.rs");
+include!("gateway_openai_proxy/config_canary.rs");
 include!("gateway_openai_proxy/request_dedup.rs");
 include!("gateway_openai_proxy/validation.rs");
 include!("gateway_openai_proxy/validation_litellm_aliases.rs");
@@ -57,3 +74,13 @@ include!("gateway_openai_proxy/mcp.rs");
 include!("gateway_openai_proxy/mcp_multi_step.rs");
 include!("gateway_openai_proxy/mcp_tools_cache.rs");
 include!("gateway_openai_proxy/proxy_cache.rs");
+include!("gateway_openai_proxy/fixtures.rs");
+include!("gateway_openai_proxy/shutdown_flush.rs");
+include!("gateway_openai_proxy/compression.rs");
+include!("gateway_openai_proxy/request_body_limits.rs");
+include!("gateway_openai_proxy/key_tiers.rs");
+include!("gateway_openai_proxy/secret_refresh.rs");
+include!("gateway_openai_proxy/alerts.rs");
+include!("gateway_openai_proxy/sessions.rs");
+include!("gateway_openai_proxy/fine_tuning.rs");
+include!("gateway_openai_proxy/key_self_service.rs");
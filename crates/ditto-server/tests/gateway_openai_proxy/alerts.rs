@@ -0,0 +1,120 @@
+#[tokio::test]
+async fn openai_compat_proxy_alert_rules_fire_and_resolve_on_error_rate() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let mut failing = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(503)
+            .header("content-type", "application/json")
+            .body(r#"{"error":{"message":"overloaded"}}"#);
+    });
+    let receiver = MockServer::start();
+    let fired = receiver.mock(|when, then| {
+        when.method(POST)
+            .path("/hook")
+            .header("x-alert-token", "t-1")
+            .body_contains(r#""status":"firing""#)
+            .body_contains(r#""rule":"primary-errors""#);
+        then.status(204);
+    });
+    let resolved = receiver.mock(|when, then| {
+        when.method(POST)
+            .path("/hook")
+            .body_contains(r#""status":"resolved""#);
+        then.status(204);
+    });
+
+    let observability: ditto_server::gateway::GatewayObservabilityConfig =
+        serde_json::from_value(json!({
+            "alerts": {
+                "interval_seconds": 3600,
+                "targets": [{
+                    "name": "oncall",
+                    "type": "webhook",
+                    "url": receiver.url("/hook"),
+                    "headers": {"x-alert-token": "t-1"}
+                }],
+                "rules": [{
+                    "name": "primary-errors",
+                    "condition": "error_rate",
+                    "threshold": 0.5,
+                    "backend": "primary",
+                    "min_requests": 2,
+                    "targets": ["oncall"]
+                }]
+            }
+        }))
+        .expect("alerts config");
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability,
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    config.validate().expect("valid config");
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state.clone());
+
+    let send = |app: axum::Router| async move {
+        let request = Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("content-type", "application/json")
+            .body(Body::from(
+                json!({"model": "gpt-4o-mini", "messages": [{"role": "user", "content": "hi"}]})
+                    .to_string(),
+            ))
+            .unwrap();
+        let response = app.oneshot(request).await.unwrap();
+        let status = response.status();
+        to_bytes(response.into_body(), usize::MAX).await.unwrap();
+        status
+    };
+
+    // Below `min_requests` the rule is not judged yet.
+    assert_eq!(send(app.clone()).await, StatusCode::SERVICE_UNAVAILABLE);
+    state.evaluate_alerts().await;
+    fired.assert_calls(0);
+
+    assert_eq!(send(app.clone()).await, StatusCode::SERVICE_UNAVAILABLE);
+    state.evaluate_alerts().await;
+    fired.assert_calls(1);
+    // Still firing: targets are only told about transitions.
+    state.evaluate_alerts().await;
+    fired.assert_calls(1);
+
+    failing.delete();
+    upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"chatcmpl-1","object":"chat.completion"}"#);
+    });
+    for _ in 0..3 {
+        assert_eq!(send(app.clone()).await, StatusCode::OK);
+    }
+    state.evaluate_alerts().await;
+    resolved.assert_calls(1);
+    fired.assert_calls(1);
+}
@@ -28,6 +28,11 @@ async fn openai_compat_proxy_accepts_x_litellm_api_key_for_virtual_key_auth() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -53,3 +58,169 @@ async fn openai_compat_proxy_accepts_x_litellm_api_key_for_virtual_key_auth() {
     assert_eq!(bytes, r#"{"id":"ok"}"#);
     mock.assert();
 }
+
+#[tokio::test]
+async fn openai_compat_proxy_enforces_virtual_key_ip_and_origin_allowlists() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"ok"}"#);
+    });
+
+    let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+    key.allowed_ips = vec!["10.0.0.0/8".to_string()];
+    key.allowed_origins = vec!["https://app.example.com".to_string()];
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![key],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let body = json!({
+        "model": "gpt-4o-mini",
+        "messages": [{"role":"user","content":"hi"}]
+    });
+    let request = |peer: &str, origin: Option<&str>| {
+        let mut builder = Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("x-forwarded-for", "10.0.0.1")
+            .header("content-type", "application/json");
+        if let Some(origin) = origin {
+            builder = builder.header("origin", origin);
+        }
+        let mut request = builder.body(Body::from(body.to_string())).unwrap();
+        let peer: std::net::SocketAddr = peer.parse().expect("peer");
+        request
+            .extensions_mut()
+            .insert(axum::extract::ConnectInfo(peer));
+        request
+    };
+
+    let response = app
+        .clone()
+        .oneshot(request("203.0.113.5:4000", Some("https://app.example.com")))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::FORBIDDEN);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let value: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(value["error"]["code"], "ip_not_allowed");
+
+    let response = app
+        .clone()
+        .oneshot(request("10.1.2.3:4000", Some("https://evil.example.com")))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::FORBIDDEN);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let value: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(value["error"]["code"], "origin_not_allowed");
+    mock.assert_hits(0);
+
+    let response = app
+        .oneshot(request("10.1.2.3:4000", Some("https://app.example.com")))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    mock.assert();
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_trusted_forwarded_for_uses_the_last_hop() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"ok"}"#);
+    });
+
+    let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+    key.allowed_ips = vec!["10.0.0.0/8".to_string()];
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![key],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway)
+        .with_proxy_backends(proxy_backends)
+        .with_trusted_forwarded_for();
+    let app = ditto_server::gateway::http::router(state);
+
+    let body = json!({
+        "model": "gpt-4o-mini",
+        "messages": [{"role":"user","content":"hi"}]
+    });
+    let request = |forwarded_for: &str| {
+        Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("x-forwarded-for", forwarded_for)
+            .header("content-type", "application/json")
+            .body(Body::from(body.to_string()))
+            .unwrap()
+    };
+
+    // The client forged an allowed address; the proxy appended its real one.
+    let response = app
+        .clone()
+        .oneshot(request("10.0.0.1, 203.0.113.5"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::FORBIDDEN);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let value: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(value["error"]["code"], "ip_not_allowed");
+    mock.assert_hits(0);
+
+    let response = app.oneshot(request("203.0.113.5, 10.1.2.3")).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    mock.assert();
+}
@@ -22,12 +22,20 @@ async fn openai_compat_proxy_forwards_chat_completions_and_injects_upstream_auth
         )],
         virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -62,6 +70,292 @@ async fn openai_compat_proxy_forwards_chat_completions_and_injects_upstream_auth
     mock.assert();
 }
 
+#[tokio::test]
+async fn openai_compat_proxy_splits_embeddings_input_into_backend_batches() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let batch = |inputs: &[&str], start: usize| {
+        let data: Vec<serde_json::Value> = (0..inputs.len())
+            .map(|index| {
+                json!({"object": "embedding", "index": index, "embedding": [(start + index) as f64]})
+            })
+            .collect();
+        let body = json!({
+            "object": "list",
+            "model": "text-embedding-3-small",
+            "data": data,
+            "usage": {"prompt_tokens": inputs.len() * 3, "total_tokens": inputs.len() * 3},
+        });
+        let inputs = json!({"model": "text-embedding-3-small", "input": inputs, "dimensions": 8});
+        upstream.mock(move |when, then| {
+            when.method(POST)
+                .path("/v1/embeddings")
+                .header("authorization", "Bearer sk-test")
+                .json_body(inputs);
+            then.status(200)
+                .header("content-type", "application/json")
+                .body(body.to_string());
+        })
+    };
+    let batches = [
+        batch(&["a", "b"], 0),
+        batch(&["c", "d"], 2),
+        batch(&["e"], 4),
+    ];
+
+    let mut backend = backend_config("primary", upstream.base_url(), "Bearer sk-test");
+    backend.embeddings_max_batch = Some(2);
+    let config = GatewayConfig {
+        backends: vec![backend],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let body = json!({
+        "model": "text-embedding-3-small",
+        "input": ["a", "b", "c", "d", "e"],
+        "dimensions": 8,
+    });
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/embeddings")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(body.to_string()))
+        .unwrap();
+
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let parsed: serde_json::Value = serde_json::from_slice(&bytes).expect("json");
+    assert_eq!(parsed["model"], "text-embedding-3-small");
+    assert_eq!(
+        parsed["usage"],
+        json!({"prompt_tokens": 15, "total_tokens": 15})
+    );
+    let data = parsed["data"].as_array().expect("data");
+    assert_eq!(data.len(), 5);
+    for (position, item) in data.iter().enumerate() {
+        assert_eq!(item["index"], position);
+        assert_eq!(item["embedding"], json!([position as f64]));
+    }
+    for mock in &batches {
+        mock.assert();
+    }
+}
+
+#[tokio::test]
+async fn passthrough_routes_forward_provider_paths_with_backend_credentials() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let anthropic_upstream = MockServer::start();
+    let anthropic_mock = anthropic_upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/messages/batches")
+            .query_param("beta", "true")
+            .header("x-api-key", "sk-ant")
+            .header("anthropic-version", "2023-06-01");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"msgbatch_1","usage":{"input_tokens":3,"output_tokens":2}}"#);
+    });
+
+    let mut anthropic = backend_config("anthropic", anthropic_upstream.base_url(), "unused");
+    anthropic.headers = BTreeMap::from([("x-api-key".to_string(), "sk-ant".to_string())]);
+    let mut limited_key = VirtualKeyConfig::new("key-2", "vk-2");
+    limited_key.budget.total_tokens = Some(1);
+    let config = GatewayConfig {
+        backends: vec![
+            backend_config("primary", upstream.base_url(), "Bearer sk-test"),
+            anthropic,
+        ],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1"), limited_key],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: vec![PassthroughRouteConfig::new("/anthropic", "anthropic")],
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    config.validate().expect("valid config");
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = |key: Option<&str>| {
+        let mut builder = Request::builder()
+            .method("POST")
+            .uri("/anthropic/v1/messages/batches?beta=true")
+            .header("anthropic-version", "2023-06-01")
+            .header("content-type", "application/json");
+        if let Some(key) = key {
+            builder = builder.header("x-api-key", key);
+        }
+        builder.body(Body::from(r#"{"requests":[]}"#)).unwrap()
+    };
+
+    let response = app.clone().oneshot(request(Some("vk-1"))).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let parsed: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(parsed["id"], "msgbatch_1");
+
+    let response = app.clone().oneshot(request(None)).await.unwrap();
+    assert_eq!(response.status(), StatusCode::UNAUTHORIZED);
+
+    let response = app.oneshot(request(Some("vk-2"))).await.unwrap();
+    assert_eq!(response.status(), StatusCode::PAYMENT_REQUIRED);
+
+    anthropic_mock.assert_hits(1);
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_renders_stored_prompt_templates() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/chat/completions")
+            .json_body(json!({
+                "model": "gpt-4o-mini",
+                "messages": [
+                    {"role": "system", "content": "Answer in French."},
+                    {"role": "user", "content": "Summarize: release notes"},
+                    {"role": "user", "content": "Keep it short"}
+                ]
+            }));
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"ok"}"#);
+    });
+
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let template = |version: u32, system: &str| PromptTemplate {
+        id: "summarize".to_string(),
+        version,
+        messages: vec![
+            PromptMessage {
+                role: "system".to_string(),
+                content: system.to_string(),
+            },
+            PromptMessage {
+                role: "user".to_string(),
+                content: "Summarize: {{topic}}".to_string(),
+            },
+        ],
+        model: Some("gpt-4o-mini".to_string()),
+        description: None,
+        created_at_ms: 0,
+    };
+    let state = GatewayHttpState::new(gateway)
+        .with_proxy_backends(proxy_backends)
+        .with_prompt_templates(vec![
+            template(1, "Answer in French."),
+            template(2, "Answer in German."),
+        ]);
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = |body: serde_json::Value| {
+        Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("content-type", "application/json")
+            .body(Body::from(body.to_string()))
+            .unwrap()
+    };
+
+    let response = app
+        .clone()
+        .oneshot(request(json!({
+            "prompt_id": "summarize",
+            "prompt_version": 1,
+            "prompt_variables": {"topic": "release notes"},
+            "messages": [{"role": "user", "content": "Keep it short"}]
+        })))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+
+    let response = app
+        .clone()
+        .oneshot(request(json!({"prompt_id": "summarize"})))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::BAD_REQUEST);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let parsed: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(parsed["error"]["code"], "prompt_variable_missing");
+
+    let response = app
+        .oneshot(request(
+            json!({"prompt_id": "summarize", "prompt_version": 3}),
+        ))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::NOT_FOUND);
+
+    mock.assert_hits(1);
+}
+
 #[cfg(feature = "gateway-store-sqlite")]
 #[tokio::test]
 async fn openai_compat_proxy_fails_closed_when_audit_store_append_fails() {
@@ -96,6 +390,11 @@ async fn openai_compat_proxy_fails_closed_when_audit_store_append_fails() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -154,12 +453,20 @@ async fn openai_compat_proxy_forwards_chat_completions_without_v1_prefix() {
         )],
         virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -222,12 +529,20 @@ async fn openai_compat_proxy_accepts_virtual_key_via_x_api_key_header() {
         )],
         virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -278,12 +593,20 @@ async fn openai_compat_proxy_appends_backend_query_params() {
         backends: vec![backend],
         virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -335,12 +658,20 @@ async fn openai_compat_proxy_applies_backend_model_map() {
         backends: vec![backend],
         virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -390,12 +721,20 @@ async fn openai_compat_proxy_enforces_max_in_flight() {
         )],
         virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -457,12 +796,20 @@ async fn openai_compat_proxy_enforces_backend_max_in_flight() {
         backends: vec![backend],
         virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -522,12 +869,20 @@ async fn openai_compat_proxy_respects_backend_timeout_seconds() {
         backends: vec![backend],
         virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -617,12 +972,20 @@ async fn openai_compat_proxy_spends_usage_tokens_when_available() {
         )],
         virtual_keys: vec![key],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -646,8 +1009,8 @@ async fn openai_compat_proxy_spends_usage_tokens_when_available() {
 }
 
 #[tokio::test]
-async fn openai_compat_proxy_project_budget_is_shared_across_virtual_keys() -> ditto_core::error::Result<()>
-{
+async fn openai_compat_proxy_project_budget_is_shared_across_virtual_keys()
+-> ditto_core::error::Result<()> {
     if ditto_core::utils::test_support::should_skip_httpmock() {
         return Ok(());
     }
@@ -695,6 +1058,7 @@ async fn openai_compat_proxy_project_budget_is_shared_across_virtual_keys() -> d
     key_1.project_budget = Some(BudgetConfig {
         total_tokens: Some(budget_total),
         total_usd_micros: None,
+        reset: None,
     });
 
     let mut key_2 = VirtualKeyConfig::new("key-2", "vk-2");
@@ -702,6 +1066,7 @@ async fn openai_compat_proxy_project_budget_is_shared_across_virtual_keys() -> d
     key_2.project_budget = Some(BudgetConfig {
         total_tokens: Some(budget_total),
         total_usd_micros: None,
+        reset: None,
     });
 
     let config = GatewayConfig {
@@ -712,12 +1077,20 @@ async fn openai_compat_proxy_project_budget_is_shared_across_virtual_keys() -> d
         )],
         virtual_keys: vec![key_1, key_2],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -758,8 +1131,8 @@ async fn openai_compat_proxy_project_budget_is_shared_across_virtual_keys() -> d
 }
 
 #[tokio::test]
-async fn openai_compat_proxy_tenant_budget_is_shared_across_virtual_keys() -> ditto_core::error::Result<()>
-{
+async fn openai_compat_proxy_tenant_budget_is_shared_across_virtual_keys()
+-> ditto_core::error::Result<()> {
     if ditto_core::utils::test_support::should_skip_httpmock() {
         return Ok(());
     }
@@ -807,6 +1180,7 @@ async fn openai_compat_proxy_tenant_budget_is_shared_across_virtual_keys() -> di
     key_1.tenant_budget = Some(BudgetConfig {
         total_tokens: Some(budget_total),
         total_usd_micros: None,
+        reset: None,
     });
 
     let mut key_2 = VirtualKeyConfig::new("key-2", "vk-2");
@@ -814,6 +1188,7 @@ async fn openai_compat_proxy_tenant_budget_is_shared_across_virtual_keys() -> di
     key_2.tenant_budget = Some(BudgetConfig {
         total_tokens: Some(budget_total),
         total_usd_micros: None,
+        reset: None,
     });
 
     let config = GatewayConfig {
@@ -824,12 +1199,20 @@ async fn openai_compat_proxy_tenant_budget_is_shared_across_virtual_keys() -> di
         )],
         virtual_keys: vec![key_1, key_2],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -892,12 +1275,20 @@ async fn openai_compat_proxy_streams_text_event_stream() {
         )],
         virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -933,8 +1324,8 @@ async fn openai_compat_proxy_streams_text_event_stream() {
 }
 
 #[tokio::test]
-async fn openai_compat_proxy_stream_usage_settles_budget_using_usage_chunk() -> ditto_core::error::Result<()>
-{
+async fn openai_compat_proxy_stream_usage_settles_budget_using_usage_chunk()
+-> ditto_core::error::Result<()> {
     if ditto_core::utils::test_support::should_skip_httpmock() {
         return Ok(());
     }
@@ -1013,19 +1404,34 @@ async fn openai_compat_proxy_stream_usage_settles_budget_using_usage_chunk() ->
     });
 
     let mut key = VirtualKeyConfig::new("key-1", "vk-1");
-    key.budget.total_tokens =
-        Some(u64::from(input_tokens_estimate).saturating_mul(2).saturating_sub(1));
+    key.budget.total_tokens = Some(
+        u64::from(input_tokens_estimate)
+            .saturating_mul(2)
+            .saturating_sub(1),
+    );
 
     let config = GatewayConfig {
-        backends: vec![backend_config("primary", upstream.base_url(), "Bearer sk-test")],
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
         virtual_keys: vec![key],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -1062,7 +1468,145 @@ async fn openai_compat_proxy_stream_usage_settles_budget_using_usage_chunk() ->
 }
 
 #[tokio::test]
-async fn openai_compat_proxy_large_multipart_requests_stream_to_upstream() -> ditto_core::error::Result<()> {
+async fn openai_compat_proxy_stream_client_disconnect_settles_partial_usage()
+-> ditto_core::error::Result<()> {
+    use futures_util::StreamExt;
+
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return Ok(());
+    }
+    let upstream = MockServer::start();
+
+    let max_tokens: u32 = 4096;
+    let body = json!({
+        "model": "gpt-4o-mini",
+        "stream": true,
+        "max_tokens": max_tokens,
+        "messages": [{
+            "role": "user",
+            "content": "hello ".repeat(256),
+        }],
+    });
+    let body_string = body.to_string();
+
+    let input_tokens_estimate: u32 = {
+        #[cfg(feature = "gateway-tokenizer")]
+        {
+            ditto_server::gateway::token_count::estimate_input_tokens(
+                "/v1/chat/completions",
+                "gpt-4o-mini",
+                &body,
+            )
+            .unwrap_or_else(|| (body_string.len().saturating_add(3) / 4) as u32)
+        }
+        #[cfg(not(feature = "gateway-tokenizer"))]
+        {
+            (body_string.len().saturating_add(3) / 4) as u32
+        }
+    };
+
+    let chunk = json!({
+        "id": "chatcmpl-test",
+        "object": "chat.completion.chunk",
+        "created": 0,
+        "model": "gpt-4o-mini",
+        "choices": [{
+            "index": 0,
+            "delta": { "content": "hi" },
+            "finish_reason": serde_json::Value::Null,
+        }],
+    })
+    .to_string();
+    let sse_body = format!("data: {chunk}\n\n");
+
+    let mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "text/event-stream")
+            .body(sse_body.clone());
+    });
+
+    // Room for one full reservation plus the prompt of an abandoned stream,
+    // but not for two full reservations.
+    let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+    key.budget.total_tokens = Some(
+        u64::from(input_tokens_estimate)
+            .saturating_mul(2)
+            .saturating_add(u64::from(max_tokens))
+            .saturating_add(16),
+    );
+
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![key],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = |request_id: &str| {
+        Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("content-type", "application/json")
+            .header("x-request-id", request_id)
+            .body(Body::from(body_string.clone()))
+            .unwrap()
+    };
+
+    let response_1 = app.clone().oneshot(request("req-1")).await.unwrap();
+    assert_eq!(response_1.status(), StatusCode::OK);
+    let mut stream_1 = response_1.into_body().into_data_stream();
+    let first = stream_1.next().await.expect("first chunk").expect("chunk");
+    assert!(!first.is_empty());
+    drop(stream_1);
+
+    // The abandoned stream settles on a background finalizer.
+    let mut status = StatusCode::PAYMENT_REQUIRED;
+    for attempt in 0..50 {
+        let response_2 = app
+            .clone()
+            .oneshot(request(&format!("req-2-{attempt}")))
+            .await
+            .unwrap();
+        status = response_2.status();
+        let _ = to_bytes(response_2.into_body(), usize::MAX).await.unwrap();
+        if status == StatusCode::OK {
+            break;
+        }
+        tokio::time::sleep(std::time::Duration::from_millis(20)).await;
+    }
+    assert_eq!(status, StatusCode::OK);
+
+    mock.assert_calls(2);
+    Ok(())
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_large_multipart_requests_stream_to_upstream()
+-> ditto_core::error::Result<()> {
     if ditto_core::utils::test_support::should_skip_httpmock() {
         return Ok(());
     }
@@ -1080,15 +1624,27 @@ async fn openai_compat_proxy_large_multipart_requests_stream_to_upstream() -> di
     key.guardrails.validate_schema = true;
 
     let config = GatewayConfig {
-        backends: vec![backend_config("primary", upstream.base_url(), "Bearer sk-test")],
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
         virtual_keys: vec![key],
         router: RouterConfig {
-            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
             rules: Vec::new(),
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
@@ -0,0 +1,103 @@
+#[tokio::test]
+async fn openai_compat_proxy_decodes_upstream_and_compresses_for_client() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let completion = json!({
+        "id": "chatcmpl-1",
+        "object": "chat.completion",
+        "choices": [{
+            "index": 0,
+            "message": {"role": "assistant", "content": "lorem ipsum ".repeat(200)},
+            "finish_reason": "stop"
+        }],
+        "usage": {"prompt_tokens": 3, "completion_tokens": 400, "total_tokens": 403}
+    })
+    .to_string();
+    let mut gzipped = flate2::write::GzEncoder::new(Vec::new(), flate2::Compression::default());
+    std::io::Write::write_all(&mut gzipped, completion.as_bytes()).unwrap();
+    let gzipped = gzipped.finish().unwrap();
+
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .header("content-encoding", "gzip")
+            .body(gzipped.clone());
+    });
+
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: vec![CompressionConfig::new("/v1/")],
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = |accept_encoding: Option<&str>| {
+        let mut builder = Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("content-type", "application/json");
+        if let Some(accept_encoding) = accept_encoding {
+            builder = builder.header("accept-encoding", accept_encoding);
+        }
+        builder
+            .body(Body::from(
+                json!({"model": "gpt-4o-mini", "messages": [{"role": "user", "content": "hi"}]})
+                    .to_string(),
+            ))
+            .unwrap()
+    };
+
+    let response = app
+        .clone()
+        .oneshot(request(Some("gzip, br")))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(
+        response
+            .headers()
+            .get("content-encoding")
+            .and_then(|value| value.to_str().ok()),
+        Some("br")
+    );
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    assert!(body.len() < completion.len());
+    let mut decoded = Vec::new();
+    std::io::Read::read_to_end(
+        &mut brotli::Decompressor::new(body.as_ref(), 4096),
+        &mut decoded,
+    )
+    .unwrap();
+    assert_eq!(decoded, completion.as_bytes());
+
+    let response = app.oneshot(request(None)).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert!(response.headers().get("content-encoding").is_none());
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    assert_eq!(body.as_ref(), completion.as_bytes());
+    mock.assert_calls(2);
+}
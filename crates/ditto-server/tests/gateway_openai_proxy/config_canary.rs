@@ -0,0 +1,186 @@
+#[tokio::test]
+async fn openai_compat_proxy_router_canary_serves_selected_keys_until_promoted() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let stable = MockServer::start();
+    let candidate = MockServer::start();
+
+    let stable_mock = stable.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"backend":"stable"}"#);
+    });
+    let candidate_mock = candidate.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"backend":"candidate"}"#);
+    });
+
+    let config = GatewayConfig {
+        backends: vec![
+            backend_config("stable", stable.base_url(), "Bearer sk-stable"),
+            backend_config("candidate", candidate.base_url(), "Bearer sk-candidate"),
+        ],
+        virtual_keys: vec![
+            VirtualKeyConfig::new("key-1", "vk-1"),
+            VirtualKeyConfig::new("key-2", "vk-2"),
+        ],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "stable".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway)
+        .with_proxy_backends(proxy_backends)
+        .with_admin_token("admin-token");
+    let app = ditto_server::gateway::http::router(state);
+
+    let chat = |token: &str| {
+        Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", format!("Bearer {token}"))
+            .header("content-type", "application/json")
+            .body(Body::from(
+                json!({
+                    "model": "chat",
+                    "messages": [{"role": "user", "content": "hi"}]
+                })
+                .to_string(),
+            ))
+            .unwrap()
+    };
+    let admin = |method: &str, uri: &str, body: serde_json::Value| {
+        Request::builder()
+            .method(method)
+            .uri(uri)
+            .header("x-admin-token", "admin-token")
+            .header("content-type", "application/json")
+            .body(Body::from(body.to_string()))
+            .unwrap()
+    };
+    let served_by = |response: &axum::response::Response| {
+        response
+            .headers()
+            .get("x-ditto-backend")
+            .and_then(|value| value.to_str().ok())
+            .map(str::to_string)
+    };
+    let candidate_router = json!({
+        "default_backends": [{ "backend": "candidate", "weight": 1.0 }],
+        "rules": []
+    });
+
+    let invalid = app
+        .clone()
+        .oneshot(admin(
+            "PUT",
+            "/admin/config/canary",
+            json!({ "router": candidate_router, "percent": 150 }),
+        ))
+        .await
+        .unwrap();
+    assert_eq!(invalid.status(), StatusCode::BAD_REQUEST);
+
+    let started = app
+        .clone()
+        .oneshot(admin(
+            "PUT",
+            "/admin/config/canary",
+            json!({ "router": candidate_router, "virtual_key_ids": ["key-1"] }),
+        ))
+        .await
+        .unwrap();
+    assert_eq!(started.status(), StatusCode::OK);
+
+    let response = app.clone().oneshot(chat("vk-1")).await.unwrap();
+    assert_eq!(served_by(&response).as_deref(), Some("candidate"));
+    let response = app.clone().oneshot(chat("vk-2")).await.unwrap();
+    assert_eq!(served_by(&response).as_deref(), Some("stable"));
+
+    let status = app
+        .clone()
+        .oneshot(admin("GET", "/admin/config/canary", json!({})))
+        .await
+        .unwrap();
+    assert_eq!(status.status(), StatusCode::OK);
+    let status = to_bytes(status.into_body(), usize::MAX).await.unwrap();
+    let status: serde_json::Value = serde_json::from_slice(&status).unwrap();
+    assert_eq!(status["virtual_key_ids"], json!(["key-1"]));
+    assert_eq!(
+        status["router"]["default_backends"][0]["backend"],
+        "candidate"
+    );
+
+    let promoted = app
+        .clone()
+        .oneshot(admin("POST", "/admin/config/canary/promote", json!({})))
+        .await
+        .unwrap();
+    assert_eq!(promoted.status(), StatusCode::OK);
+    let promoted = to_bytes(promoted.into_body(), usize::MAX).await.unwrap();
+    let promoted: serde_json::Value = serde_json::from_slice(&promoted).unwrap();
+    assert_ne!(
+        promoted["current_version"]["version_id"],
+        promoted["previous_version"]["version_id"]
+    );
+    assert_eq!(
+        promoted["current_version"]["reason"],
+        "admin.config.canary.promote"
+    );
+
+    let response = app.clone().oneshot(chat("vk-2")).await.unwrap();
+    assert_eq!(served_by(&response).as_deref(), Some("candidate"));
+    let missing = app
+        .clone()
+        .oneshot(admin("GET", "/admin/config/canary", json!({})))
+        .await
+        .unwrap();
+    assert_eq!(missing.status(), StatusCode::NOT_FOUND);
+
+    let stable_router = json!({
+        "default_backends": [{ "backend": "stable", "weight": 1.0 }],
+        "rules": []
+    });
+    let started = app
+        .clone()
+        .oneshot(admin(
+            "PUT",
+            "/admin/config/canary",
+            json!({ "router": stable_router, "percent": 100 }),
+        ))
+        .await
+        .unwrap();
+    assert_eq!(started.status(), StatusCode::OK);
+    let response = app.clone().oneshot(chat("vk-1")).await.unwrap();
+    assert_eq!(served_by(&response).as_deref(), Some("stable"));
+
+    let rolled_back = app
+        .clone()
+        .oneshot(admin("POST", "/admin/config/canary/rollback", json!({})))
+        .await
+        .unwrap();
+    assert_eq!(rolled_back.status(), StatusCode::OK);
+    let response = app.clone().oneshot(chat("vk-1")).await.unwrap();
+    assert_eq!(served_by(&response).as_deref(), Some("candidate"));
+
+    stable_mock.assert_calls(2);
+    candidate_mock.assert_calls(3);
+}
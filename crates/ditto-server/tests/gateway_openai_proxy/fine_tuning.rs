@@ -0,0 +1,330 @@
+#[tokio::test]
+async fn openai_compat_proxy_scopes_fine_tuning_jobs_to_the_creating_project() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let create = upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/fine_tuning/jobs")
+            .header("authorization", "Bearer sk-test");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"object":"fine_tuning.job","id":"ftjob-1","status":"queued"}"#);
+    });
+    let list = upstream.mock(|when, then| {
+        when.method(httpmock::Method::GET).path("/v1/fine_tuning/jobs");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(
+                r#"{"object":"list","data":[{"id":"ftjob-1","status":"running"},{"id":"ftjob-other","status":"running"}],"has_more":false}"#,
+            );
+    });
+    let cancel = upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/fine_tuning/jobs/ftjob-1/cancel");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"object":"fine_tuning.job","id":"ftjob-1","status":"cancelled"}"#);
+    });
+
+    let team_key = |id: &str, token: &str, project: &str| {
+        let mut key = VirtualKeyConfig::new(id, token);
+        key.project_id = Some(project.to_string());
+        key.fine_tuning = true;
+        key
+    };
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![
+            team_key("ml-1", "vk-ml-1", "ml"),
+            team_key("ml-2", "vk-ml-2", "ml"),
+            team_key("search", "vk-search", "search"),
+            VirtualKeyConfig::new("plain", "vk-plain"),
+        ],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let send = |token: &'static str, method: &'static str, uri: &'static str| {
+        let app = app.clone();
+        async move {
+            let body = if method == "POST" {
+                json!({"model": "gpt-4o-mini-2024-07-18", "training_file": "file-abc"}).to_string()
+            } else {
+                String::new()
+            };
+            let request = Request::builder()
+                .method(method)
+                .uri(uri)
+                .header("authorization", format!("Bearer {token}"))
+                .header("content-type", "application/json")
+                .body(Body::from(body))
+                .unwrap();
+            let response = app.oneshot(request).await.unwrap();
+            let status = response.status();
+            let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+            let parsed: serde_json::Value = serde_json::from_slice(&bytes).expect("json");
+            (status, parsed)
+        }
+    };
+
+    let (status, body) = send("vk-plain", "POST", "/v1/fine_tuning/jobs").await;
+    assert_eq!(status, StatusCode::FORBIDDEN);
+    assert_eq!(body["error"]["code"], "fine_tuning_not_allowed");
+
+    let (status, body) = send("vk-ml-1", "POST", "/v1/fine_tuning/jobs").await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(body["id"], "ftjob-1");
+
+    // Another key of the same project sees and manages the job; jobs the
+    // gateway did not record for the project are dropped from the list.
+    let (status, body) = send("vk-ml-2", "GET", "/v1/fine_tuning/jobs").await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(
+        body["data"],
+        json!([{"id": "ftjob-1", "status": "running"}])
+    );
+
+    // A project without recorded jobs is answered without asking upstream.
+    let (status, body) = send("vk-search", "GET", "/v1/fine_tuning/jobs").await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(body["data"], json!([]));
+
+    let (status, body) = send("vk-search", "POST", "/v1/fine_tuning/jobs/ftjob-1/cancel").await;
+    assert_eq!(status, StatusCode::NOT_FOUND);
+    assert_eq!(body["error"]["code"], "fine_tuning_job_not_found");
+
+    let (status, body) = send("vk-ml-2", "POST", "/v1/fine_tuning/jobs/ftjob-1/cancel").await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(body["status"], "cancelled");
+
+    create.assert_hits(1);
+    list.assert_hits(1);
+    cancel.assert_hits(1);
+}
+
+#[cfg(feature = "gateway-routing-advanced")]
+#[tokio::test]
+async fn openai_compat_proxy_does_not_retry_fine_tuning_job_creation_without_request_id() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let primary = MockServer::start();
+    let secondary = MockServer::start();
+    let failing_create = |server: &MockServer| {
+        server.mock(|when, then| {
+            when.method(POST).path("/v1/fine_tuning/jobs");
+            then.status(500)
+                .header("content-type", "application/json")
+                .body(r#"{"error":{"message":"overloaded","type":"server_error"}}"#);
+        })
+    };
+    let primary_mock = failing_create(&primary);
+    let secondary_mock = failing_create(&secondary);
+
+    let mut key = VirtualKeyConfig::new("ml", "vk-ml");
+    key.fine_tuning = true;
+    let config = GatewayConfig {
+        backends: vec![
+            backend_config("primary", primary.base_url(), "Bearer sk-primary"),
+            backend_config("secondary", secondary.base_url(), "Bearer sk-secondary"),
+        ],
+        virtual_keys: vec![key],
+        router: RouterConfig {
+            default_backends: vec![
+                RouteBackend {
+                    backend: "primary".to_string(),
+                    weight: 1.0,
+                },
+                RouteBackend {
+                    backend: "secondary".to_string(),
+                    weight: 1.0,
+                },
+            ],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config))
+        .with_proxy_backends(proxy_backends)
+        .with_proxy_routing(ditto_server::gateway::ProxyRoutingConfig {
+            retry: ditto_server::gateway::ProxyRetryConfig {
+                enabled: true,
+                retry_status_codes: vec![500],
+                fallback_status_codes: Vec::new(),
+                max_attempts: Some(2),
+                ..Default::default()
+            },
+            circuit_breaker: ditto_server::gateway::ProxyCircuitBreakerConfig::default(),
+            ..Default::default()
+        });
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/fine_tuning/jobs")
+        .header("authorization", "Bearer vk-ml")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({"model": "gpt-4o-mini-2024-07-18", "training_file": "file-abc"}).to_string(),
+        ))
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::INTERNAL_SERVER_ERROR);
+
+    // Without a client `x-request-id` a retried create could start a second
+    // paid job, so only one backend is tried.
+    assert_eq!(primary_mock.calls() + secondary_mock.calls(), 1);
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_lists_fine_tuning_jobs_from_every_backend_holding_them() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let primary = MockServer::start();
+    let secondary = MockServer::start();
+    let mock_jobs = |server: &MockServer, created: &'static str, listed: &'static str| {
+        let create = server.mock(|when, then| {
+            when.method(POST).path("/v1/fine_tuning/jobs");
+            then.status(200)
+                .header("content-type", "application/json")
+                .body(created);
+        });
+        let list = server.mock(|when, then| {
+            when.method(httpmock::Method::GET)
+                .path("/v1/fine_tuning/jobs");
+            then.status(200)
+                .header("content-type", "application/json")
+                .body(listed);
+        });
+        (create, list)
+    };
+    let (primary_create, primary_list) = mock_jobs(
+        &primary,
+        r#"{"object":"fine_tuning.job","id":"ftjob-a","created_at":100,"status":"queued"}"#,
+        r#"{"object":"list","data":[{"id":"ftjob-other","created_at":300},{"id":"ftjob-a","created_at":100}],"has_more":false}"#,
+    );
+    let (secondary_create, secondary_list) = mock_jobs(
+        &secondary,
+        r#"{"object":"fine_tuning.job","id":"ftjob-b","created_at":200,"status":"queued"}"#,
+        r#"{"object":"list","data":[{"id":"ftjob-b","created_at":200}],"has_more":true}"#,
+    );
+
+    let mut key = VirtualKeyConfig::new("ml", "vk-ml");
+    key.fine_tuning = true;
+    let config = GatewayConfig {
+        backends: vec![
+            backend_config("primary", primary.base_url(), "Bearer sk-primary"),
+            backend_config("secondary", secondary.base_url(), "Bearer sk-secondary"),
+        ],
+        virtual_keys: vec![key],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: vec![RouteRule {
+                model_prefix: "davinci-".to_string(),
+                exact: false,
+                backend: "secondary".to_string(),
+                backends: Vec::new(),
+                guardrails: None,
+                experiment: None,
+                shadow: None,
+            }],
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let send = |method: &'static str, uri: &'static str, body: String| {
+        let app = app.clone();
+        async move {
+            let request = Request::builder()
+                .method(method)
+                .uri(uri)
+                .header("authorization", "Bearer vk-ml")
+                .header("content-type", "application/json")
+                .body(Body::from(body))
+                .unwrap();
+            let response = app.oneshot(request).await.unwrap();
+            let status = response.status();
+            let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+            let parsed: serde_json::Value = serde_json::from_slice(&bytes).expect("json");
+            (status, parsed)
+        }
+    };
+    let create_body =
+        |model: &str| json!({"model": model, "training_file": "file-abc"}).to_string();
+
+    let (status, body) = send(
+        "POST",
+        "/v1/fine_tuning/jobs",
+        create_body("gpt-4o-mini-2024-07-18"),
+    )
+    .await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(body["id"], "ftjob-a");
+    let (status, body) = send("POST", "/v1/fine_tuning/jobs", create_body("davinci-002")).await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(body["id"], "ftjob-b");
+
+    let (status, body) = send("GET", "/v1/fine_tuning/jobs", String::new()).await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(
+        body["data"],
+        json!([
+            {"id": "ftjob-b", "created_at": 200},
+            {"id": "ftjob-a", "created_at": 100},
+        ])
+    );
+    assert_eq!(body["has_more"], true);
+
+    // A cursor continues on the backend of the job it names.
+    let (status, _) = send("GET", "/v1/fine_tuning/jobs?after=ftjob-b", String::new()).await;
+    assert_eq!(status, StatusCode::OK);
+
+    primary_create.assert_hits(1);
+    secondary_create.assert_hits(1);
+    primary_list.assert_hits(1);
+    secondary_list.assert_hits(2);
+}
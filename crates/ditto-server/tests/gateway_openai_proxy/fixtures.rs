@@ -0,0 +1,95 @@
+#[tokio::test]
+async fn openai_compat_proxy_replays_recorded_fixtures_without_upstream() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let upstream_mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .header("x-request-id", "req-upstream")
+            .body(r#"{"id":"chatcmpl-recorded","object":"chat.completion"}"#);
+    });
+    let fixtures_dir = tempfile::tempdir().expect("tempdir");
+
+    let app_with = |base_url: String, mode: ProxyFixtureMode| {
+        let config = GatewayConfig {
+            backends: vec![backend_config("primary", base_url, "Bearer sk-test")],
+            virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+            router: RouterConfig {
+                default_backends: vec![RouteBackend {
+                    backend: "primary".to_string(),
+                    weight: 1.0,
+                }],
+                rules: Vec::new(),
+            },
+            a2a_agents: Vec::new(),
+            mcp_servers: Vec::new(),
+            observability: Default::default(),
+            cors: Vec::new(),
+            passthrough_routes: Vec::new(),
+            compression: Vec::new(),
+            request_body_limits: Vec::new(),
+            tiers: Vec::new(),
+        };
+        let fixtures = std::sync::Arc::new(ProxyFixtures::new(fixtures_dir.path(), mode));
+        let proxy_backends = build_proxy_backends(&config)
+            .expect("proxy backends")
+            .into_iter()
+            .map(|(name, client)| (name, client.with_fixtures(fixtures.clone())))
+            .collect();
+        let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+        ditto_server::gateway::http::router(state)
+    };
+    let chat = |body: serde_json::Value| {
+        Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("content-type", "application/json")
+            .body(Body::from(body.to_string()))
+            .unwrap()
+    };
+    let body = json!({
+        "model": "gpt-4o-mini",
+        "messages": [{"role": "user", "content": "hi"}]
+    });
+
+    let recorder = app_with(upstream.base_url(), ProxyFixtureMode::Record);
+    let recorded = recorder.oneshot(chat(body.clone())).await.unwrap();
+    assert_eq!(recorded.status(), StatusCode::OK);
+    let recorded = to_bytes(recorded.into_body(), usize::MAX).await.unwrap();
+    upstream_mock.assert_calls(1);
+
+    let files = std::fs::read_dir(fixtures_dir.path())
+        .expect("fixtures dir")
+        .filter_map(Result::ok)
+        .filter(|entry| entry.path().extension().is_some_and(|ext| ext == "json"))
+        .count();
+    assert_eq!(files, 1);
+
+    // Replay never dials the backend, so an unroutable base URL still answers.
+    let replayer = app_with("http://127.0.0.1:9".to_string(), ProxyFixtureMode::Replay);
+    let replayed = replayer.clone().oneshot(chat(body)).await.unwrap();
+    assert_eq!(replayed.status(), StatusCode::OK);
+    assert_eq!(
+        replayed
+            .headers()
+            .get("content-type")
+            .and_then(|value| value.to_str().ok()),
+        Some("application/json")
+    );
+    let replayed = to_bytes(replayed.into_body(), usize::MAX).await.unwrap();
+    assert_eq!(replayed, recorded);
+
+    let missing = replayer
+        .oneshot(chat(json!({
+            "model": "gpt-4o-mini",
+            "messages": [{"role": "user", "content": "never recorded"}]
+        })))
+        .await
+        .unwrap();
+    assert_eq!(missing.status(), StatusCode::BAD_GATEWAY);
+    upstream_mock.assert_calls(1);
+}
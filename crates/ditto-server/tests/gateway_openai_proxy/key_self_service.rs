@@ -0,0 +1,122 @@
+#[tokio::test]
+async fn virtual_key_reads_its_own_limits_and_budget() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(
+                r#"{"id":"ok","usage":{"prompt_tokens":3,"completion_tokens":4,"total_tokens":7}}"#,
+            );
+    });
+
+    let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+    key.tenant_id = Some("acme".to_string());
+    key.limits.rpm = Some(5);
+    key.budget.total_tokens = Some(10_000);
+    key.guardrails.allow_models = vec!["gpt-4o-mini".to_string()];
+
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![key],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/chat/completions")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({
+                "model": "gpt-4o-mini",
+                "messages": [{"role": "user", "content": "hi"}]
+            })
+            .to_string(),
+        ))
+        .unwrap();
+    let response = app.clone().oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    mock.assert_calls(1);
+
+    let get = |uri: &str, token: &str| {
+        Request::builder()
+            .method("GET")
+            .uri(uri)
+            .header("authorization", format!("Bearer {token}"))
+            .body(Body::empty())
+            .unwrap()
+    };
+
+    let response = app
+        .clone()
+        .oneshot(get("/v1/key/info", "vk-1"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let info: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(info["key_id"], "key-1");
+    assert_eq!(info["tenant_id"], "acme");
+    assert_eq!(info["models"], json!(["gpt-4o-mini"]));
+    assert_eq!(
+        info["rate_limits"],
+        json!([{"scope": "key", "rpm": 5, "remaining_requests": 4}])
+    );
+    let budget = &info["budgets"][0];
+    assert_eq!(budget["scope"], "key");
+    assert_eq!(budget["total_tokens"], 10_000);
+    let spent = budget["spent_tokens"].as_u64().unwrap();
+    assert!(spent > 0, "{info}");
+    assert_eq!(budget["remaining_tokens"], 10_000 - spent);
+
+    // Looking up quota does not consume it.
+    let response = app
+        .clone()
+        .oneshot(get("/v1/key/info", "vk-1"))
+        .await
+        .unwrap();
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let info: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(info["rate_limits"][0]["remaining_requests"], 4);
+
+    let response = app
+        .clone()
+        .oneshot(get("/v1/key/info", "vk-unknown"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::UNAUTHORIZED);
+
+    // Spend history is read from the audit log, which needs a store.
+    let response = app.oneshot(get("/v1/key/usage", "vk-1")).await.unwrap();
+    assert_eq!(response.status(), StatusCode::BAD_REQUEST);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let error: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(error["error"]["code"], "not_configured");
+}
@@ -0,0 +1,237 @@
+fn key_tier_test_config(
+    primary: &MockServer,
+    cheap: &MockServer,
+    batch: KeyTierConfig,
+) -> GatewayConfig {
+    let mut primary_backend = backend_config("primary", primary.base_url(), "Bearer sk-test");
+    primary_backend.max_in_flight = Some(2);
+    let mut batch_key = VirtualKeyConfig::new("key-batch", "vk-batch");
+    batch_key.tier = Some(batch.name.clone());
+    GatewayConfig {
+        backends: vec![
+            primary_backend,
+            backend_config("cheap", cheap.base_url(), "Bearer sk-test"),
+        ],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1"), batch_key],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: vec![batch],
+    }
+}
+
+fn key_tier_chat_request(token: &str) -> Request<Body> {
+    let body = json!({
+        "model": "gpt-4o-mini",
+        "messages": [{"role": "user", "content": "hi"}]
+    });
+    Request::builder()
+        .method("POST")
+        .uri("/v1/chat/completions")
+        .header("authorization", format!("Bearer {token}"))
+        .header("content-type", "application/json")
+        .body(Body::from(body.to_string()))
+        .unwrap()
+}
+
+/// Sends an untiered request that holds a primary slot until its body is read.
+fn spawn_untiered_request(app: axum::Router) -> tokio::task::JoinHandle<axum::response::Response> {
+    tokio::spawn(async move {
+        let response = app.oneshot(key_tier_chat_request("vk-1")).await.unwrap();
+        let (parts, body) = response.into_parts();
+        let bytes = to_bytes(body, usize::MAX).await.unwrap();
+        axum::response::Response::from_parts(parts, Body::from(bytes))
+    })
+}
+
+fn tier_admission_header(response: &axum::response::Response) -> Option<&str> {
+    response
+        .headers()
+        .get("x-ditto-admission")
+        .and_then(|value| value.to_str().ok())
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_key_tier_overflows_past_its_share() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let primary = MockServer::start();
+    let primary_mock = primary.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .delay(std::time::Duration::from_millis(300))
+            .header("content-type", "application/json")
+            .body(r#"{"id":"primary"}"#);
+    });
+    let cheap = MockServer::start();
+    let cheap_mock = cheap.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"cheap"}"#);
+    });
+
+    let mut batch = KeyTierConfig::new("batch", 0.5);
+    batch.overflow_backends = vec!["cheap".to_string()];
+    let config = key_tier_test_config(&primary, &cheap, batch);
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    // With the primary idle the batch tier is admitted to it.
+    let response = app
+        .clone()
+        .oneshot(key_tier_chat_request("vk-batch"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(tier_admission_header(&response), Some("admitted"));
+    assert_eq!(
+        response
+            .headers()
+            .get("x-ditto-tier")
+            .and_then(|value| value.to_str().ok()),
+        Some("batch")
+    );
+    to_bytes(response.into_body(), usize::MAX).await.unwrap();
+
+    // An untiered request holds one of the primary's two slots, which is
+    // the batch tier's whole share.
+    let interactive = spawn_untiered_request(app.clone());
+    tokio::time::sleep(std::time::Duration::from_millis(100)).await;
+    let response = app
+        .clone()
+        .oneshot(key_tier_chat_request("vk-batch"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(tier_admission_header(&response), Some("overflow"));
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    assert_eq!(bytes, r#"{"id":"cheap"}"#);
+
+    let response = interactive.await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert!(response.headers().get("x-ditto-admission").is_none());
+    primary_mock.assert_calls(2);
+    cheap_mock.assert_calls(1);
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_key_tier_queues_then_sheds() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let primary = MockServer::start();
+    let primary_mock = primary.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .delay(std::time::Duration::from_millis(300))
+            .header("content-type", "application/json")
+            .body(r#"{"id":"primary"}"#);
+    });
+    let cheap = MockServer::start();
+
+    let mut batch = KeyTierConfig::new("batch", 0.5);
+    batch.queue_timeout_ms = 2_000;
+    let config = key_tier_test_config(&primary, &cheap, batch);
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    // The batch request waits for the untiered one to finish.
+    let interactive = spawn_untiered_request(app.clone());
+    tokio::time::sleep(std::time::Duration::from_millis(100)).await;
+    let response = app
+        .clone()
+        .oneshot(key_tier_chat_request("vk-batch"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(tier_admission_header(&response), Some("queued"));
+    let queue_ms: u64 = response
+        .headers()
+        .get("x-ditto-queue-ms")
+        .and_then(|value| value.to_str().ok())
+        .and_then(|value| value.parse().ok())
+        .expect("queue ms");
+    assert!(queue_ms >= 100, "queue_ms = {queue_ms}");
+    to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    assert_eq!(interactive.await.unwrap().status(), StatusCode::OK);
+
+    // A deadline shorter than the wait sheds it instead.
+    let interactive = spawn_untiered_request(app.clone());
+    tokio::time::sleep(std::time::Duration::from_millis(100)).await;
+    let mut request = key_tier_chat_request("vk-batch");
+    request
+        .headers_mut()
+        .insert("x-request-timeout", "0.05".parse().unwrap());
+    let response = app.clone().oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::TOO_MANY_REQUESTS);
+    assert_eq!(tier_admission_header(&response), Some("shed"));
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let value: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(value["error"]["code"], "tier_capacity");
+    assert_eq!(interactive.await.unwrap().status(), StatusCode::OK);
+
+    primary_mock.assert_calls(3);
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_key_tier_sheds_past_its_queue_length() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let primary = MockServer::start();
+    let primary_mock = primary.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .delay(std::time::Duration::from_millis(300))
+            .header("content-type", "application/json")
+            .body(r#"{"id":"primary"}"#);
+    });
+    let cheap = MockServer::start();
+
+    let mut batch = KeyTierConfig::new("batch", 0.5);
+    batch.queue_timeout_ms = 2_000;
+    batch.max_queue_len = 1;
+    let config = key_tier_test_config(&primary, &cheap, batch);
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    // The first batch request takes the only queue slot; the second is shed
+    // without waiting.
+    let interactive = spawn_untiered_request(app.clone());
+    tokio::time::sleep(std::time::Duration::from_millis(100)).await;
+    let queued = tokio::spawn(app.clone().oneshot(key_tier_chat_request("vk-batch")));
+    tokio::time::sleep(std::time::Duration::from_millis(50)).await;
+    let response = app
+        .clone()
+        .oneshot(key_tier_chat_request("vk-batch"))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::TOO_MANY_REQUESTS);
+    assert_eq!(tier_admission_header(&response), Some("shed"));
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let value: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(value["error"]["code"], "tier_queue_full");
+
+    let response = queued.await.unwrap().unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    assert_eq!(tier_admission_header(&response), Some("queued"));
+    to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    assert_eq!(interactive.await.unwrap().status(), StatusCode::OK);
+    primary_mock.assert_calls(2);
+}
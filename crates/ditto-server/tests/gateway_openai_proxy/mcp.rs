@@ -78,6 +78,11 @@ async fn openai_compat_proxy_rewrites_mcp_tools_into_openai_functions() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -123,6 +128,98 @@ async fn openai_compat_proxy_rewrites_mcp_tools_into_openai_functions() {
     openai_mock.assert();
 }
 
+#[tokio::test]
+async fn openai_compat_proxy_mcp_tools_run_unscoped_without_virtual_keys() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+
+    let mcp_upstream = MockServer::start();
+    let mcp_mock = mcp_upstream.mock(|when, then| {
+        when.method(POST).path("/mcp").body_includes("tools/list");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(
+                json!({
+                    "jsonrpc": "2.0",
+                    "id": 1,
+                    "result": { "tools": [{ "name": "hello" }] }
+                })
+                .to_string(),
+            );
+    });
+
+    let openai_upstream = MockServer::start();
+    let openai_mock = openai_upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/chat/completions")
+            .header("authorization", "Bearer sk-test")
+            .body_includes(r#""name":"hello""#);
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"ok"}"#);
+    });
+
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            openai_upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: Vec::new(),
+        router: RouterConfig {
+            default_backends: vec![RouteBackend { backend: "primary".to_string(), weight: 1.0 }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+
+    let mut mcp_servers = HashMap::new();
+    mcp_servers.insert(
+        "local".to_string(),
+        ditto_server::gateway::http::McpServerState::new(
+            "local".to_string(),
+            mcp_upstream.url("/mcp"),
+        )
+        .expect("mcp state"),
+    );
+
+    let state = GatewayHttpState::new(gateway)
+        .with_proxy_backends(proxy_backends)
+        .with_mcp_servers(mcp_servers);
+    let app = ditto_server::gateway::http::router(state);
+
+    let body = json!({
+        "model": "gpt-4o-mini",
+        "messages": [{"role":"user","content":"hi"}],
+        "tools": [{
+            "type": "mcp",
+            "server_url": "litellm_proxy/mcp/local",
+        }]
+    });
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/chat/completions")
+        .header("content-type", "application/json")
+        .body(Body::from(body.to_string()))
+        .unwrap();
+
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+
+    mcp_mock.assert();
+    openai_mock.assert();
+}
+
 #[tokio::test]
 async fn openai_compat_proxy_auto_executes_mcp_tool_calls_when_require_approval_never() {
     if ditto_core::utils::test_support::should_skip_httpmock() {
@@ -290,6 +387,11 @@ async fn openai_compat_proxy_auto_executes_mcp_tool_calls_when_require_approval_
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -438,6 +540,11 @@ async fn openai_compat_proxy_rejects_invalid_chat_tool_arguments_json_during_mcp
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -578,6 +685,11 @@ async fn openai_compat_proxy_rewrites_mcp_tools_into_openai_functions_for_respon
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -772,6 +884,11 @@ async fn openai_compat_proxy_auto_executes_mcp_tool_calls_for_responses_when_req
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -912,6 +1029,11 @@ async fn openai_compat_proxy_rejects_invalid_responses_tool_arguments_json_durin
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -1158,6 +1280,11 @@ async fn openai_compat_proxy_auto_executes_mcp_tool_calls_for_responses_via_shim
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -33,6 +33,11 @@ async fn openai_compat_proxy_model_map_wildcard_rewrites_model() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -58,3 +63,68 @@ async fn openai_compat_proxy_model_map_wildcard_rewrites_model() {
     assert_eq!(bytes, r#"{"id":"ok"}"#);
     mock.assert();
 }
+
+#[tokio::test]
+async fn token_counter_counts_with_the_mapped_model() {
+    let mut backend = backend_config(
+        "primary",
+        "http://127.0.0.1:9".to_string(),
+        "Bearer sk-test",
+    );
+    backend
+        .model_map
+        .insert("fast".to_string(), "gpt-4o-mini".to_string());
+
+    let config = GatewayConfig {
+        backends: vec![backend],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway);
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = |token: Option<&str>| {
+        let mut builder = Request::builder()
+            .method("POST")
+            .uri("/utils/token_counter")
+            .header("content-type", "application/json");
+        if let Some(token) = token {
+            builder = builder.header("authorization", format!("Bearer {token}"));
+        }
+        let body = json!({
+            "model": "fast",
+            "messages": [{"role": "user", "content": "hello there"}],
+        });
+        builder.body(Body::from(body.to_string())).unwrap()
+    };
+
+    let response = app.clone().oneshot(request(None)).await.unwrap();
+    assert_eq!(response.status(), StatusCode::UNAUTHORIZED);
+
+    let response = app.oneshot(request(Some("vk-1"))).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let value: serde_json::Value = serde_json::from_slice(&bytes).expect("json");
+    assert_eq!(value["request_model"], "fast");
+    assert_eq!(value["model_used"], "gpt-4o-mini");
+    assert!(value["total_tokens"].as_u64().unwrap_or(0) > 0);
+    #[cfg(feature = "gateway-tokenizer")]
+    assert_eq!(value["tokenizer_type"], "o200k_base");
+    #[cfg(not(feature = "gateway-tokenizer"))]
+    assert_eq!(value["tokenizer_type"], "bytes_estimate");
+}
@@ -39,6 +39,7 @@ impl LanguageModel for TranslationModelsStub {
             usage: Usage::default(),
             warnings: Vec::new(),
             provider_metadata: None,
+            logprobs: None,
         })
     }
 
@@ -104,6 +105,11 @@ async fn openai_models_list_merges_across_backends() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -139,6 +145,195 @@ async fn openai_models_list_merges_across_backends() {
     mock_b.assert();
 }
 
+#[tokio::test]
+async fn openai_models_list_shows_model_groups_the_key_may_use() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(httpmock::Method::GET).path("/v1/models");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(
+                json!({
+                    "object": "list",
+                    "data": [
+                        {"id": "gpt-4o", "object": "model"},
+                        {"id": "o1", "object": "model"}
+                    ]
+                })
+                .to_string(),
+            );
+    });
+
+    let mut backend = backend_config("a", upstream.base_url(), "Bearer sk-a");
+    backend.model_map.insert("fast-chat".to_string(), "gpt-4o-mini".to_string());
+    backend.model_map.insert("*".to_string(), "gpt-4o".to_string());
+    let model_group = |name: &str| RouteRule {
+        model_prefix: name.to_string(),
+        exact: true,
+        backend: String::new(),
+        backends: vec![RouteBackend { backend: "a".to_string(), weight: 1.0 }],
+        guardrails: None,
+        experiment: None,
+        shadow: None,
+    };
+
+    let mut restricted = VirtualKeyConfig::new("key-1", "vk-1");
+    restricted.guardrails.allow_models =
+        vec!["gpt-*".to_string(), "fast-*".to_string(), "smart-*".to_string()];
+    let mut denied = VirtualKeyConfig::new("key-2", "vk-2");
+    denied.guardrails.deny_models = vec!["smart-chat".to_string()];
+
+    let config = GatewayConfig {
+        backends: vec![backend],
+        virtual_keys: vec![restricted, denied],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend { backend: "a".to_string(), weight: 1.0 }],
+            rules: vec![model_group("smart-chat"), model_group("internal-eval")],
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let list = |token: &'static str| {
+        let app = app.clone();
+        async move {
+            let request = Request::builder()
+                .method("GET")
+                .uri("/v1/models")
+                .header("authorization", format!("Bearer {token}"))
+                .body(Body::empty())
+                .unwrap();
+            let response = app.oneshot(request).await.unwrap();
+            assert_eq!(response.status(), StatusCode::OK);
+            let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+            serde_json::from_slice::<serde_json::Value>(&bytes).expect("json")
+        }
+    };
+    let ids = |json: &serde_json::Value| -> Vec<String> {
+        json["data"]
+            .as_array()
+            .into_iter()
+            .flatten()
+            .filter_map(|model| model["id"].as_str().map(str::to_string))
+            .collect()
+    };
+
+    let restricted = list("vk-1").await;
+    assert_eq!(ids(&restricted), vec!["fast-chat", "gpt-4o", "smart-chat"]);
+    assert_eq!(restricted["data"][0]["owned_by"], "ditto");
+    assert_eq!(restricted["data"][0]["object"], "model");
+
+    let denied = list("vk-2").await;
+    assert_eq!(ids(&denied), vec!["fast-chat", "gpt-4o", "internal-eval", "o1"]);
+
+    mock.assert_calls(2);
+}
+
+#[tokio::test]
+async fn openai_model_info_merges_config_with_provider_capabilities() {
+    let mut backend = backend_config("a", "http://127.0.0.1:9".to_string(), "Bearer sk-a");
+    backend.model_map.insert("fast-chat".to_string(), "gpt-4o-mini".to_string());
+    backend.provider_config = Some(ditto_core::config::ProviderConfig {
+        capabilities: Some(ditto_core::config::ProviderCapabilities {
+            tools: true,
+            vision: true,
+            ..Default::default()
+        }),
+        ..Default::default()
+    });
+    backend.model_info.insert(
+        "gpt-4o-mini".to_string(),
+        ditto_server::gateway::ModelInfoConfig {
+            context_window: Some(128_000),
+            max_output_tokens: Some(16_384),
+            input_modalities: vec!["text".to_string(), "image".to_string()],
+            input_usd_per_million_tokens: Some(0.15),
+            output_usd_per_million_tokens: Some(0.6),
+            ..Default::default()
+        },
+    );
+
+    let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+    key.guardrails.deny_models = vec!["secret-*".to_string()];
+    key.guardrails.context_window = Some(ContextWindowConfig {
+        max_tokens: 32_000,
+        strategy: ContextWindowStrategy::Reject,
+        summarizer: None,
+    });
+
+    let config = GatewayConfig {
+        backends: vec![backend],
+        virtual_keys: vec![key],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend { backend: "a".to_string(), weight: 1.0 }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let info = |uri: &'static str| {
+        let app = app.clone();
+        async move {
+            let request = Request::builder()
+                .method("GET")
+                .uri(uri)
+                .header("authorization", "Bearer vk-1")
+                .body(Body::empty())
+                .unwrap();
+            let response = app.oneshot(request).await.unwrap();
+            let status = response.status();
+            let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+            (status, serde_json::from_slice::<serde_json::Value>(&bytes).expect("json"))
+        }
+    };
+
+    let (status, json) = info("/v1/models/fast-chat/info").await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(json["id"], "fast-chat");
+    assert_eq!(json["backend"], "a");
+    assert_eq!(json["upstream_model"], "gpt-4o-mini");
+    // The key's guardrails cap the context window below the model's.
+    assert_eq!(json["context_window"], 32_000);
+    assert_eq!(json["max_output_tokens"], 16_384);
+    assert_eq!(json["modalities"]["input"], json!(["text", "image"]));
+    assert_eq!(json["modalities"]["output"], json!(["text"]));
+    assert_eq!(json["supports_tool_calling"], true);
+    assert_eq!(json["pricing"]["input_usd_per_million_tokens"], 0.15);
+    assert_eq!(json["pricing"]["output_usd_per_million_tokens"], 0.6);
+
+    let (status, json) = info("/models/fast-chat/info").await;
+    assert_eq!(status, StatusCode::OK);
+    assert_eq!(json["upstream_model"], "gpt-4o-mini");
+
+    let (status, json) = info("/v1/models/secret-model/info").await;
+    assert_eq!(status, StatusCode::NOT_FOUND);
+    assert_eq!(json["error"]["code"], "model_not_found");
+}
+
 #[cfg(feature = "gateway-translation")]
 #[tokio::test]
 async fn openai_models_list_respects_translation_route() {
@@ -177,6 +372,11 @@ async fn openai_models_list_respects_translation_route() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -263,6 +463,11 @@ async fn openai_models_list_excludes_translation_models_when_key_route_targets_p
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -349,6 +554,11 @@ async fn openai_models_list_skips_backends_with_oversized_responses() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -431,11 +641,18 @@ async fn openai_models_retrieve_uses_model_path_for_routing() {
                     weight: 1.0,
                 }],
                 guardrails: None,
+                experiment: None,
+                shadow: None,
             }],
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
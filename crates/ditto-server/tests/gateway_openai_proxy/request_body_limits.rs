@@ -0,0 +1,205 @@
+fn request_body_limit_test_config(
+    upstream: &MockServer,
+    request_body_limits: Vec<RequestBodyLimitConfig>,
+) -> GatewayConfig {
+    GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits,
+        tiers: Vec::new(),
+    }
+}
+
+async fn assert_request_too_large(response: axum::response::Response, limit: u64) {
+    assert_eq!(response.status(), StatusCode::PAYLOAD_TOO_LARGE);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let value: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(value["error"]["code"], "request_too_large");
+    assert_eq!(
+        value["error"]["message"],
+        format!("request body exceeds the {limit} byte limit")
+    );
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_rejects_bodies_over_route_limit() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"ok"}"#);
+    });
+
+    let config = request_body_limit_test_config(
+        &upstream,
+        vec![RequestBodyLimitConfig::new("/v1/chat/", 64)],
+    );
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state);
+
+    let body = json!({
+        "model": "gpt-4o-mini",
+        "messages": [{"role": "user", "content": "x".repeat(128)}]
+    })
+    .to_string();
+    let request = |body: Body, content_length: Option<usize>| {
+        let mut builder = Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("content-type", "application/json");
+        if let Some(content_length) = content_length {
+            builder = builder.header("content-length", content_length.to_string());
+        }
+        builder.body(body).unwrap()
+    };
+
+    let response = app
+        .clone()
+        .oneshot(request(Body::from(body.clone()), Some(body.len())))
+        .await
+        .unwrap();
+    assert_request_too_large(response, 64).await;
+
+    let chunks = body
+        .as_bytes()
+        .chunks(16)
+        .map(|chunk| Ok::<_, std::io::Error>(bytes::Bytes::copy_from_slice(chunk)))
+        .collect::<Vec<_>>();
+    let response = app
+        .clone()
+        .oneshot(request(
+            Body::from_stream(futures_util::stream::iter(chunks)),
+            None,
+        ))
+        .await
+        .unwrap();
+    assert_request_too_large(response, 64).await;
+
+    let small = json!({"model": "gpt-4o-mini", "messages": []}).to_string();
+    let response = app
+        .oneshot(request(Body::from(small.clone()), Some(small.len())))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    mock.assert_calls(1);
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_rejects_bodies_over_buffer_limit() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200);
+    });
+
+    let config = request_body_limit_test_config(&upstream, Vec::new());
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config))
+        .with_proxy_backends(proxy_backends)
+        .with_proxy_max_body_bytes(32);
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/chat/completions")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({"model": "gpt-4o-mini", "messages": [{"role": "user", "content": "hello there"}]})
+                .to_string(),
+        ))
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_request_too_large(response, 32).await;
+    mock.assert_calls(0);
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_streams_large_multipart_uploads() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let boundary = "BOUNDARY";
+    let audio = "a".repeat(256 * 1024);
+    let body = format!(
+        "--{boundary}\r\n\
+Content-Disposition: form-data; name=\"model\"\r\n\
+\r\n\
+whisper-1\r\n\
+--{boundary}\r\n\
+Content-Disposition: form-data; name=\"file\"; filename=\"speech.wav\"\r\n\
+Content-Type: audio/wav\r\n\
+\r\n\
+{audio}end-of-audio\r\n\
+--{boundary}--\r\n"
+    );
+
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/audio/transcriptions")
+            .body_contains("whisper-1")
+            .body_contains("end-of-audio");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"text":"hello"}"#);
+    });
+
+    let config = request_body_limit_test_config(
+        &upstream,
+        vec![RequestBodyLimitConfig::new("/v1/audio/", 1024 * 1024)],
+    );
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config))
+        .with_proxy_backends(proxy_backends)
+        .with_proxy_max_body_bytes(1024);
+    let app = ditto_server::gateway::http::router(state);
+
+    let chunks = body
+        .as_bytes()
+        .chunks(8 * 1024)
+        .map(|chunk| Ok::<_, std::io::Error>(bytes::Bytes::copy_from_slice(chunk)))
+        .collect::<Vec<_>>();
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/audio/transcriptions")
+        .header("authorization", "Bearer vk-1")
+        .header(
+            "content-type",
+            format!("multipart/form-data; boundary={boundary}"),
+        )
+        .header("content-length", body.len().to_string())
+        .body(Body::from_stream(futures_util::stream::iter(chunks)))
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    assert_eq!(bytes.as_ref(), br#"{"text":"hello"}"#);
+    mock.assert_calls(1);
+}
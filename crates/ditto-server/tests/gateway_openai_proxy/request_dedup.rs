@@ -154,6 +154,11 @@ async fn openai_compat_proxy_replays_completed_request_by_client_request_id() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -236,6 +241,11 @@ async fn openai_compat_proxy_replays_streamed_request_by_client_request_id() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -333,6 +343,11 @@ async fn openai_compat_proxy_coalesces_in_flight_duplicates_by_client_request_id
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -414,6 +429,11 @@ async fn openai_compat_proxy_rejects_conflicting_request_reuse_by_client_request
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -490,6 +510,11 @@ async fn openai_compat_proxy_rejects_request_reuse_when_upstream_headers_change(
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -608,6 +633,11 @@ async fn openai_compat_proxy_does_not_replay_failed_request_by_client_request_id
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -674,6 +704,11 @@ async fn openai_compat_proxy_replays_when_only_trace_headers_change() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -719,6 +754,99 @@ async fn openai_compat_proxy_replays_when_only_trace_headers_change() {
     mock.assert_calls(1);
 }
 
+#[cfg(feature = "gateway-store-sqlite")]
+#[tokio::test]
+async fn openai_compat_proxy_replays_by_idempotency_key_across_request_ids() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/responses")
+            .header("authorization", "Bearer sk-test");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"resp-idempotent"}"#);
+    });
+
+    let dir = tempfile::tempdir().expect("tempdir");
+    let db_path = dir.path().join("gateway.sqlite");
+    let store = ditto_server::gateway::SqliteStore::new(&db_path);
+    store.init().await.expect("init sqlite");
+
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let gateway = Gateway::new(config);
+    let state = GatewayHttpState::new(gateway)
+        .with_proxy_backends(proxy_backends)
+        .with_sqlite_store(store);
+    let app = ditto_server::gateway::http::router(state);
+
+    let first = app
+        .clone()
+        .oneshot(dedup_test_request_with_header(
+            "req-idempotent-1",
+            "hi",
+            Some(("idempotency-key", "idem-1")),
+        ))
+        .await
+        .unwrap();
+    assert_eq!(first.status(), StatusCode::OK);
+    assert_eq!(
+        first
+            .headers()
+            .get("x-ditto-request-dedup")
+            .and_then(|value| value.to_str().ok()),
+        Some("leader")
+    );
+
+    // A client retry usually carries a fresh x-request-id.
+    let second = app
+        .oneshot(dedup_test_request_with_header(
+            "req-idempotent-2",
+            "hi",
+            Some(("idempotency-key", "idem-1")),
+        ))
+        .await
+        .unwrap();
+    assert_eq!(second.status(), StatusCode::OK);
+    assert_eq!(
+        second
+            .headers()
+            .get("x-ditto-request-dedup")
+            .and_then(|value| value.to_str().ok()),
+        Some("replay")
+    );
+    let second_body = to_bytes(second.into_body(), usize::MAX).await.unwrap();
+    assert_eq!(second_body, r#"{"id":"resp-idempotent"}"#);
+
+    mock.assert_calls(1);
+}
+
 #[cfg(feature = "gateway-store-sqlite")]
 #[tokio::test]
 async fn openai_compat_proxy_replays_chunked_non_sse_request_without_content_length() {
@@ -745,6 +873,11 @@ async fn openai_compat_proxy_replays_chunked_non_sse_request_without_content_len
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -841,6 +974,11 @@ async fn openai_compat_proxy_marks_large_stream_replay_unavailable_by_client_req
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -911,6 +1049,11 @@ async fn openai_compat_proxy_marks_large_chunked_response_replay_unavailable_wit
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
     let gateway = Gateway::new(config);
@@ -0,0 +1,92 @@
+#[tokio::test]
+async fn openai_compat_proxy_refresh_picks_up_rotated_backend_secrets() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let old_key = upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/chat/completions")
+            .header("authorization", "Bearer sk-old");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"chatcmpl-1","object":"chat.completion"}"#);
+    });
+    let new_key = upstream.mock(|when, then| {
+        when.method(POST)
+            .path("/v1/chat/completions")
+            .header("authorization", "Bearer sk-new");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"chatcmpl-2","object":"chat.completion"}"#);
+    });
+
+    let dir = tempfile::tempdir().expect("tempdir");
+    let secret_path = dir.path().join("openai_api_key");
+    std::fs::write(&secret_path, "Bearer sk-old").expect("write secret");
+    let unresolved = backend_config(
+        "primary",
+        upstream.base_url(),
+        &format!("secret://file?path={}", secret_path.display()),
+    );
+    let env = ditto_core::config::Env::default();
+    let mut resolved = unresolved.clone();
+    resolved
+        .resolve_secrets(&env)
+        .await
+        .expect("resolve secrets");
+
+    let config = GatewayConfig {
+        backends: vec![resolved],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config))
+        .with_proxy_backends(proxy_backends)
+        .with_backend_secret_refresh(&[unresolved], env, std::time::Duration::from_secs(3600));
+    let app = ditto_server::gateway::http::router(state.clone());
+
+    let send = |app: axum::Router| async move {
+        let request = Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("content-type", "application/json")
+            .body(Body::from(
+                json!({"model": "gpt-4o-mini", "messages": [{"role": "user", "content": "hi"}]})
+                    .to_string(),
+            ))
+            .unwrap();
+        app.oneshot(request).await.unwrap().status()
+    };
+
+    assert_eq!(send(app.clone()).await, StatusCode::OK);
+    old_key.assert_calls(1);
+
+    std::fs::write(&secret_path, "Bearer sk-new").expect("rotate secret");
+    state.refresh_backend_secrets().await.expect("refresh");
+    assert_eq!(send(app.clone()).await, StatusCode::OK);
+    new_key.assert_calls(1);
+
+    // A failed refresh keeps serving with the last resolved key.
+    std::fs::remove_file(&secret_path).expect("remove secret");
+    assert!(state.refresh_backend_secrets().await.is_err());
+    assert_eq!(send(app).await, StatusCode::OK);
+    new_key.assert_calls(2);
+    old_key.assert_calls(1);
+}
@@ -0,0 +1,239 @@
+#[tokio::test]
+async fn openai_compat_proxy_assembles_session_history_per_virtual_key() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let system = json!({"role": "system", "content": "Be brief."});
+    let q1 = json!({"role": "user", "content": "q1"});
+    let a1 = json!({"role": "assistant", "content": "a1"});
+    let q2 = json!({"role": "user", "content": "q2"});
+    let a2 = json!({"role": "assistant", "content": "a2"});
+    let q3 = json!({"role": "user", "content": "q3"});
+
+    let upstream = MockServer::start();
+    let first = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions").json_body(json!({
+            "model": "gpt-4o-mini",
+            "messages": [system, q1],
+        }));
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"c1","choices":[{"index":0,"message":{"role":"assistant","content":"a1","refusal":null},"finish_reason":"stop"}]}"#);
+    });
+    let second = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions").json_body(json!({
+            "model": "gpt-4o-mini",
+            "stream": true,
+            "messages": [system, q1, a1, q2],
+        }));
+        then.status(200)
+            .header("content-type", "text/event-stream")
+            .body(concat!(
+                "data: {\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"a\"}}]}\n\n",
+                "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"2\"}}]}\n\n",
+                "data: [DONE]\n\n",
+            ));
+    });
+    let third = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions").json_body(json!({
+            "model": "gpt-4o-mini",
+            "messages": [system, q1, a1, q2, a2, q3],
+        }));
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"c3","choices":[{"index":0,"message":{"role":"assistant","content":"a3"}}]}"#);
+    });
+    let other_key = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions").json_body(json!({
+            "model": "gpt-4o-mini",
+            "messages": [q3],
+        }));
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"c4","choices":[{"index":0,"message":{"role":"assistant","content":"a4"}}]}"#);
+    });
+
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![
+            VirtualKeyConfig::new("key-1", "vk-1"),
+            VirtualKeyConfig::new("key-2", "vk-2"),
+        ],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let build_state = || {
+        GatewayHttpState::new(Gateway::new(config.clone()))
+            .with_proxy_backends(proxy_backends.clone())
+    };
+    let app =
+        ditto_server::gateway::http::router(build_state().with_sessions(SessionConfig::default()));
+
+    let request = |token: &str, body: serde_json::Value| {
+        Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", format!("Bearer {token}"))
+            .header("content-type", "application/json")
+            .body(Body::from(body.to_string()))
+            .unwrap()
+    };
+    let send = |token: &'static str, body: serde_json::Value| {
+        let app = app.clone();
+        async move {
+            let response = app.oneshot(request(token, body)).await.unwrap();
+            let status = response.status();
+            let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+            (status, bytes)
+        }
+    };
+
+    let (status, _) = send(
+        "vk-1",
+        json!({"model": "gpt-4o-mini", "session_id": "chat-1", "messages": [system, q1]}),
+    )
+    .await;
+    assert_eq!(status, StatusCode::OK);
+
+    let (status, body) = send(
+        "vk-1",
+        json!({"model": "gpt-4o-mini", "session_id": "chat-1", "stream": true, "messages": [q2]}),
+    )
+    .await;
+    assert_eq!(status, StatusCode::OK);
+    assert!(String::from_utf8_lossy(&body).ends_with("data: [DONE]\n\n"));
+
+    let (status, _) = send(
+        "vk-1",
+        json!({"model": "gpt-4o-mini", "session_id": "chat-1", "messages": [q3]}),
+    )
+    .await;
+    assert_eq!(status, StatusCode::OK);
+
+    // Sessions are scoped to the virtual key: the same id starts fresh.
+    let (status, _) = send(
+        "vk-2",
+        json!({"model": "gpt-4o-mini", "session_id": "chat-1", "messages": [q3]}),
+    )
+    .await;
+    assert_eq!(status, StatusCode::OK);
+
+    let (status, body) = send(
+        "vk-1",
+        json!({"model": "gpt-4o-mini", "session_id": "", "messages": [q3]}),
+    )
+    .await;
+    assert_eq!(status, StatusCode::BAD_REQUEST);
+    let parsed: serde_json::Value = serde_json::from_slice(&body).unwrap();
+    assert_eq!(parsed["error"]["code"], "invalid_session_request");
+
+    let disabled = ditto_server::gateway::http::router(build_state());
+    let response = disabled
+        .oneshot(request(
+            "vk-1",
+            json!({"model": "gpt-4o-mini", "session_id": "chat-1", "messages": [q3]}),
+        ))
+        .await
+        .unwrap();
+    assert_eq!(response.status(), StatusCode::BAD_REQUEST);
+    let bytes = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let parsed: serde_json::Value = serde_json::from_slice(&bytes).unwrap();
+    assert_eq!(parsed["error"]["code"], "sessions_not_enabled");
+
+    first.assert_hits(1);
+    second.assert_hits(1);
+    third.assert_hits(1);
+    other_key.assert_hits(1);
+}
+
+#[tokio::test]
+async fn openai_compat_proxy_session_turns_keep_the_client_address() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    let mock = upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"c1","choices":[{"index":0,"message":{"role":"assistant","content":"a1"}}]}"#);
+    });
+
+    let mut key = VirtualKeyConfig::new("key-1", "vk-1");
+    key.allowed_ips = vec!["10.0.0.0/8".to_string()];
+    let config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![key],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config))
+        .with_proxy_backends(proxy_backends)
+        .with_sessions(SessionConfig::default());
+    let app = ditto_server::gateway::http::router(state);
+
+    let request = |peer: &str| {
+        let body = json!({
+            "model": "gpt-4o-mini",
+            "session_id": "chat-1",
+            "messages": [{"role": "user", "content": "q1"}],
+        });
+        let mut request = Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("authorization", "Bearer vk-1")
+            .header("content-type", "application/json")
+            .body(Body::from(body.to_string()))
+            .unwrap();
+        let peer: std::net::SocketAddr = peer.parse().expect("peer");
+        request
+            .extensions_mut()
+            .insert(axum::extract::ConnectInfo(peer));
+        request
+    };
+
+    // The forwarded turn is checked against the caller's address, not none.
+    let response = app.clone().oneshot(request("10.1.2.3:4000")).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    to_bytes(response.into_body(), usize::MAX).await.unwrap();
+
+    let response = app.oneshot(request("203.0.113.5:4000")).await.unwrap();
+    assert_eq!(response.status(), StatusCode::FORBIDDEN);
+    mock.assert_hits(1);
+}
@@ -0,0 +1,75 @@
+#[tokio::test]
+async fn openai_compat_proxy_flush_ships_queued_callback_traces() {
+    if ditto_core::utils::test_support::should_skip_httpmock() {
+        return;
+    }
+    let upstream = MockServer::start();
+    upstream.mock(|when, then| {
+        when.method(POST).path("/v1/chat/completions");
+        then.status(200)
+            .header("content-type", "application/json")
+            .body(r#"{"id":"chatcmpl-1","object":"chat.completion"}"#);
+    });
+    let sink = MockServer::start();
+    let sink_mock = sink.mock(|when, then| {
+        when.method(POST).path("/custom/v1/log");
+        then.status(200);
+    });
+
+    let mut config = GatewayConfig {
+        backends: vec![backend_config(
+            "primary",
+            upstream.base_url(),
+            "Bearer sk-test",
+        )],
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+    // A large batch and a long interval keep the trace queued until flushed.
+    config.observability.callbacks = vec![
+        serde_json::from_value(json!({
+            "name": "helicone",
+            "type": "helicone",
+            "base_url": sink.base_url(),
+            "api_key": "hk-test",
+            "batch_size": 100,
+            "flush_interval_ms": 3_600_000
+        }))
+        .expect("callback config"),
+    ];
+    let proxy_backends = build_proxy_backends(&config).expect("proxy backends");
+    let state = GatewayHttpState::new(Gateway::new(config)).with_proxy_backends(proxy_backends);
+    let app = ditto_server::gateway::http::router(state.clone());
+
+    let request = Request::builder()
+        .method("POST")
+        .uri("/v1/chat/completions")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({"model": "gpt-4o-mini", "messages": [{"role": "user", "content": "hi"}]})
+                .to_string(),
+        ))
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    sink_mock.assert_calls(0);
+
+    state.flush_pending_records().await;
+    sink_mock.assert_calls(1);
+}
@@ -43,6 +43,11 @@ async fn admin_key_mutations_persist_virtual_keys_to_sqlite_store() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let mut gateway = Gateway::new(config);
     gateway.register_backend("primary", EchoBackend);
@@ -65,7 +70,7 @@ async fn admin_key_mutations_persist_virtual_keys_to_sqlite_store() {
     let loaded = store.load_virtual_keys().await.expect("load");
     assert_eq!(loaded.len(), 1);
     assert_eq!(loaded[0].id, "key-1");
-    assert!(loaded[0].token.starts_with("sha256:"));
+    assert!(loaded[0].token.starts_with("salted-sha256:"));
     let persisted_config = GatewayConfig {
         backends: Vec::new(),
         virtual_keys: loaded.clone(),
@@ -79,6 +84,11 @@ async fn admin_key_mutations_persist_virtual_keys_to_sqlite_store() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     assert!(persisted_config.virtual_key("vk-1").is_some());
 
@@ -115,6 +125,11 @@ async fn admin_router_mutation_persists_router_to_sqlite_store() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     let mut gateway = Gateway::new(config);
     gateway.register_backend("primary", EchoBackend);
@@ -42,6 +42,11 @@ async fn admin_key_mutations_persist_virtual_keys_to_state_file() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let mut gateway = Gateway::new(config);
@@ -66,7 +71,7 @@ async fn admin_key_mutations_persist_virtual_keys_to_state_file() {
     let loaded = GatewayStateFile::load(&state_path).expect("state file load");
     assert_eq!(loaded.virtual_keys.len(), 1);
     assert_eq!(loaded.virtual_keys[0].id, "key-1");
-    assert!(loaded.virtual_keys[0].token.starts_with("sha256:"));
+    assert!(loaded.virtual_keys[0].token.starts_with("salted-sha256:"));
     assert_eq!(
         loaded
             .router
@@ -81,6 +86,11 @@ async fn admin_key_mutations_persist_virtual_keys_to_state_file() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
     assert!(persisted_config.virtual_key("vk-1").is_some());
 
@@ -121,6 +131,11 @@ async fn admin_router_mutation_persists_router_to_state_file() {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     };
 
     let mut gateway = Gateway::new(config);
@@ -165,7 +180,7 @@ async fn admin_router_mutation_persists_router_to_state_file() {
 
     let loaded = GatewayStateFile::load(&state_path).expect("state file load");
     assert_eq!(loaded.virtual_keys.len(), 1);
-    assert!(loaded.virtual_keys[0].token.starts_with("sha256:"));
+    assert!(loaded.virtual_keys[0].token.starts_with("salted-sha256:"));
     assert_eq!(
         loaded.router.as_ref().map(|router| router.rules.len()),
         Some(1)
@@ -180,6 +195,100 @@ async fn admin_router_mutation_persists_router_to_state_file() {
     );
 }
 
+#[tokio::test]
+async fn admin_prompt_publishes_persist_prompts_to_state_file() {
+    let dir = tempfile::tempdir().expect("tempdir");
+    let state_path = dir.path().join("gateway-state.json");
+
+    let config = GatewayConfig {
+        backends: Vec::new(),
+        virtual_keys: Vec::new(),
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    };
+
+    let mut gateway = Gateway::new(config);
+    gateway.register_backend("primary", EchoBackend);
+    let state = GatewayHttpState::new(gateway)
+        .with_admin_token("adm")
+        .with_state_file(state_path.clone());
+    let app = ditto_server::gateway::http::router(state);
+
+    for content in ["Hello {{name}}", "Hi {{name}}"] {
+        let request = Request::builder()
+            .method("POST")
+            .uri("/admin/prompts")
+            .header("authorization", "Bearer adm")
+            .header("content-type", "application/json")
+            .body(Body::from(
+                serde_json::json!({
+                    "id": "greet",
+                    "messages": [{"role": "user", "content": content}],
+                })
+                .to_string(),
+            ))
+            .unwrap();
+        let response = app.clone().oneshot(request).await.unwrap();
+        assert_eq!(response.status(), StatusCode::CREATED);
+    }
+
+    let key = ditto_server::gateway::VirtualKeyConfig::new("key-1", "vk-1");
+    let request = Request::builder()
+        .method("POST")
+        .uri("/admin/keys")
+        .header("authorization", "Bearer adm")
+        .header("content-type", "application/json")
+        .body(Body::from(serde_json::to_string(&key).expect("json")))
+        .unwrap();
+    let response = app.clone().oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::CREATED);
+
+    let loaded = GatewayStateFile::load(&state_path).expect("state file load");
+    assert_eq!(loaded.virtual_keys.len(), 1);
+    let versions: Vec<(&str, u32)> = loaded
+        .prompts
+        .iter()
+        .map(|prompt| (prompt.id.as_str(), prompt.version))
+        .collect();
+    assert_eq!(versions, vec![("greet", 1), ("greet", 2)]);
+    assert_eq!(loaded.prompts[1].messages[0].content, "Hi {{name}}");
+
+    let request = Request::builder()
+        .method("GET")
+        .uri("/admin/prompts/greet/versions/1")
+        .header("authorization", "Bearer adm")
+        .body(Body::empty())
+        .unwrap();
+    let response = app.clone().oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+
+    let request = Request::builder()
+        .method("DELETE")
+        .uri("/admin/prompts/greet")
+        .header("authorization", "Bearer adm")
+        .body(Body::empty())
+        .unwrap();
+    let response = app.oneshot(request).await.unwrap();
+    assert_eq!(response.status(), StatusCode::NO_CONTENT);
+
+    let loaded = GatewayStateFile::load(&state_path).expect("state file load");
+    assert!(loaded.prompts.is_empty());
+    assert_eq!(loaded.virtual_keys.len(), 1);
+}
+
 #[test]
 fn state_file_load_is_backward_compatible_without_router_field() {
     let dir = tempfile::tempdir().expect("tempdir");
@@ -67,6 +67,7 @@ impl LanguageModel for FakeModel {
             },
             warnings: Vec::new(),
             provider_metadata: Some(json!({ "id": "resp_fake" })),
+            logprobs: None,
         })
     }
 
@@ -132,6 +133,66 @@ impl LanguageModel for FakeCompactionModel {
             },
             warnings: Vec::new(),
             provider_metadata: Some(json!({ "id": "resp_fake_compact" })),
+            logprobs: None,
+        })
+    }
+
+    async fn stream(&self, _request: GenerateRequest) -> ditto_core::error::Result<StreamResult> {
+        let chunks: Vec<ditto_core::error::Result<StreamChunk>> = Vec::new();
+        Ok(futures_util::stream::iter(chunks).boxed())
+    }
+}
+
+/// An "anthropic" model (no native json_schema) that replies with `replies`
+/// in order and records the message count of every request.
+struct FakeStructuredOutputModel {
+    replies: std::sync::Mutex<Vec<&'static str>>,
+    message_counts: std::sync::Mutex<Vec<usize>>,
+}
+
+impl FakeStructuredOutputModel {
+    fn new(replies: Vec<&'static str>) -> Self {
+        Self {
+            replies: std::sync::Mutex::new(replies),
+            message_counts: std::sync::Mutex::new(Vec::new()),
+        }
+    }
+}
+
+#[async_trait]
+impl LanguageModel for FakeStructuredOutputModel {
+    fn provider(&self) -> &str {
+        "anthropic"
+    }
+
+    fn model_id(&self) -> &str {
+        "fake-structured"
+    }
+
+    async fn generate(
+        &self,
+        request: GenerateRequest,
+    ) -> ditto_core::error::Result<GenerateResponse> {
+        self.message_counts
+            .lock()
+            .unwrap()
+            .push(request.messages.len());
+        let text = self.replies.lock().unwrap().remove(0);
+        Ok(GenerateResponse {
+            content: vec![ContentPart::Text {
+                text: text.to_string(),
+            }],
+            finish_reason: FinishReason::Stop,
+            usage: Usage {
+                input_tokens: Some(10),
+                cache_input_tokens: None,
+                cache_creation_input_tokens: None,
+                output_tokens: Some(5),
+                total_tokens: Some(15),
+            },
+            warnings: Vec::new(),
+            provider_metadata: None,
+            logprobs: None,
         })
     }
 
@@ -666,6 +727,11 @@ fn base_gateway() -> Gateway {
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     })
 }
 
@@ -1083,6 +1149,11 @@ async fn gateway_translation_models_list_respects_virtual_key_route()
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
 
     let mut primary_map = BTreeMap::new();
@@ -1162,6 +1233,11 @@ async fn gateway_translation_models_list_hides_unroutable_translation_backends()
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
 
     let mut primary_map = BTreeMap::new();
@@ -1317,11 +1393,18 @@ async fn gateway_translation_models_retrieve_uses_model_path_for_routing()
                     weight: 1.0,
                 }],
                 guardrails: None,
+                experiment: None,
+                shadow: None,
             }],
         },
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
 
     let mut primary_map = BTreeMap::new();
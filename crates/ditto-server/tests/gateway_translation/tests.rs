@@ -349,6 +349,11 @@ async fn gateway_translation_responses_remain_isolated_by_virtual_key()
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
     let mut translation_backends = HashMap::new();
     translation_backends.insert(
@@ -552,6 +557,11 @@ async fn gateway_translation_responses_retrieve_and_delete_are_backend_scoped()
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
 
     let mut translation_backends = HashMap::new();
@@ -689,6 +699,11 @@ async fn gateway_translation_bare_response_id_is_not_scanned_across_backends()
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
 
     let mut translation_backends = HashMap::new();
@@ -765,6 +780,11 @@ async fn gateway_translation_responses_retrieve_and_delete_are_virtual_key_scope
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
 
     let mut translation_backends = HashMap::new();
@@ -878,6 +898,11 @@ async fn gateway_translation_responses_retrieve_and_delete_are_virtual_key_scope
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
 
     let mut translation_backends = HashMap::new();
@@ -1054,6 +1079,11 @@ async fn gateway_translation_owned_resource_ids_are_scoped_and_owner_bound(
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
     let mut translation_backends = HashMap::new();
     translation_backends.insert(
@@ -1315,6 +1345,11 @@ async fn gateway_translation_responses_scoped_ids_isolate_same_provider_backends
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
     let mut translation_backends = HashMap::new();
     translation_backends.insert(
@@ -1470,6 +1505,11 @@ async fn gateway_translation_responses_reject_other_virtual_keys()
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
     let mut translation_backends = HashMap::new();
     translation_backends.insert(
@@ -1949,6 +1989,11 @@ async fn gateway_translation_spends_tenant_budget_scope() -> ditto_core::error::
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
     let mut translation_backends = HashMap::new();
     translation_backends.insert(
@@ -2021,6 +2066,11 @@ async fn gateway_translation_invalid_request_does_not_consume_tenant_budget_scop
         a2a_agents: Vec::new(),
         mcp_servers: Vec::new(),
         observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
     });
     let mut translation_backends = HashMap::new();
     translation_backends.insert(
@@ -3114,3 +3164,74 @@ async fn build_language_model_supports_cohere_from_config() -> ditto_core::error
     mock.assert();
     Ok(())
 }
+
+#[tokio::test]
+async fn gateway_translation_emulates_json_schema_with_a_retry() -> ditto_core::error::Result<()>
+{
+    let model = Arc::new(FakeStructuredOutputModel::new(vec![
+        "Sure! {\"city\": \"Paris\"}",
+        "```json\n{\"city\": \"Paris\", \"population\": 2100000}\n```",
+        "not json",
+        "still not json",
+    ]));
+    let mut translation_backends = HashMap::new();
+    translation_backends.insert(
+        "primary".to_string(),
+        TranslationBackend::new("anthropic", model.clone()),
+    );
+    let state = GatewayHttpState::new(base_gateway())
+        .with_proxy_backends(HashMap::new())
+        .with_translation_backends(translation_backends);
+    let app = authorized_test_app(state);
+
+    let chat_request = || {
+        let payload = json!({
+            "model": "gpt-4o-mini",
+            "messages": [{"role": "user", "content": "Largest city in France?"}],
+            "response_format": {
+                "type": "json_schema",
+                "json_schema": {
+                    "name": "city",
+                    "strict": true,
+                    "schema": {
+                        "type": "object",
+                        "properties": {
+                            "city": {"type": "string"},
+                            "population": {"type": "integer"}
+                        },
+                        "required": ["city", "population"],
+                        "additionalProperties": false
+                    }
+                }
+            }
+        });
+        Request::builder()
+            .method("POST")
+            .uri("/v1/chat/completions")
+            .header("content-type", "application/json")
+            .body(Body::from(payload.to_string()))
+            .unwrap()
+    };
+
+    let response = app.clone().oneshot(chat_request()).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let parsed: serde_json::Value = serde_json::from_slice(&body)?;
+    let content = parsed["choices"][0]["message"]["content"]
+        .as_str()
+        .expect("content");
+    assert_eq!(
+        serde_json::from_str::<serde_json::Value>(content)?,
+        json!({"city": "Paris", "population": 2100000})
+    );
+    assert_eq!(parsed["usage"]["total_tokens"], json!(30));
+    // Schema instructions + user, then the failed reply and the correction.
+    assert_eq!(*model.message_counts.lock().unwrap(), vec![2, 4]);
+
+    let response = app.oneshot(chat_request()).await.unwrap();
+    assert_eq!(response.status(), StatusCode::BAD_GATEWAY);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let parsed: serde_json::Value = serde_json::from_slice(&body)?;
+    assert_eq!(parsed["error"]["code"], json!("structured_output_invalid"));
+    Ok(())
+}
@@ -0,0 +1,121 @@
+#![cfg(all(
+    feature = "gateway",
+    feature = "gateway-translation",
+    feature = "provider-mock"
+))]
+
+use std::collections::HashMap;
+
+use axum::body::{Body, to_bytes};
+use axum::http::{Request, StatusCode};
+use ditto_core::config::{Env, MockProviderConfig, ProviderConfig};
+use ditto_core::runtime::build_language_model;
+use ditto_server::gateway::{
+    Gateway, GatewayConfig, GatewayHttpState, RouteBackend, RouterConfig, TranslationBackend,
+    VirtualKeyConfig,
+};
+use serde_json::json;
+use tower::util::ServiceExt;
+
+async fn mock_app(mock: MockProviderConfig) -> axum::Router {
+    let provider_config = ProviderConfig {
+        default_model: Some("mock-model".to_string()),
+        mock: Some(mock),
+        ..ProviderConfig::default()
+    };
+    let model = build_language_model("mock", &provider_config, &Env::default())
+        .await
+        .expect("mock model");
+    let mut translation_backends = HashMap::new();
+    translation_backends.insert(
+        "primary".to_string(),
+        TranslationBackend::new("mock", model).with_provider_config(provider_config),
+    );
+
+    let gateway = Gateway::new(GatewayConfig {
+        backends: Vec::new(),
+        virtual_keys: vec![VirtualKeyConfig::new("key-1", "vk-1")],
+        router: RouterConfig {
+            default_backends: vec![RouteBackend {
+                backend: "primary".to_string(),
+                weight: 1.0,
+            }],
+            rules: Vec::new(),
+        },
+        a2a_agents: Vec::new(),
+        mcp_servers: Vec::new(),
+        observability: Default::default(),
+        cors: Vec::new(),
+        passthrough_routes: Vec::new(),
+        compression: Vec::new(),
+        request_body_limits: Vec::new(),
+        tiers: Vec::new(),
+    });
+    let state = GatewayHttpState::new(gateway)
+        .with_proxy_backends(HashMap::new())
+        .with_translation_backends(translation_backends);
+    ditto_server::gateway::http::router(state)
+}
+
+fn chat_request(stream: bool) -> Request<Body> {
+    Request::builder()
+        .method("POST")
+        .uri("/v1/chat/completions")
+        .header("authorization", "Bearer vk-1")
+        .header("content-type", "application/json")
+        .body(Body::from(
+            json!({
+                "model": "mock-model",
+                "stream": stream,
+                "messages": [{"role": "user", "content": "ping"}]
+            })
+            .to_string(),
+        ))
+        .unwrap()
+}
+
+#[tokio::test]
+async fn mock_provider_serves_templated_chat_completions() {
+    let app = mock_app(MockProviderConfig {
+        responses: vec!["{{model}} says pong to {{prompt}}".to_string()],
+        stream_chunk_chars: Some(4),
+        ..MockProviderConfig::default()
+    })
+    .await;
+
+    let response = app.clone().oneshot(chat_request(false)).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let body: serde_json::Value = serde_json::from_slice(&body).unwrap();
+    assert_eq!(
+        body["choices"][0]["message"]["content"],
+        "mock-model says pong to ping"
+    );
+    assert_eq!(body["usage"]["prompt_tokens"], 1);
+
+    let response = app.oneshot(chat_request(true)).await.unwrap();
+    assert_eq!(response.status(), StatusCode::OK);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let text = String::from_utf8_lossy(&body);
+    assert!(text.contains("\"content\":\"mock\""));
+    assert!(text.contains("\"content\":\"-mod\""));
+    assert!(text.contains("[DONE]"));
+}
+
+#[tokio::test]
+async fn mock_provider_injects_configured_errors() {
+    let app = mock_app(MockProviderConfig {
+        error_percent: Some(100),
+        error_status: Some(429),
+        error_message: Some("slow down".to_string()),
+        ..MockProviderConfig::default()
+    })
+    .await;
+
+    let response = app.oneshot(chat_request(false)).await.unwrap();
+    assert_eq!(response.status(), StatusCode::TOO_MANY_REQUESTS);
+    let body = to_bytes(response.into_body(), usize::MAX).await.unwrap();
+    let body: serde_json::Value = serde_json::from_slice(&body).unwrap();
+    assert_eq!(body["error"]["type"], "rate_limit_error");
+    assert_eq!(body["error"]["message"], "slow down");
+}
@@ -35,6 +35,7 @@ async fn generic_openai_compatible_provider_aliases_build_models() -> ditto_core
         "fireworks",
         "perplexity",
         "ollama",
+        "vllm",
     ];
 
     for alias in aliases {
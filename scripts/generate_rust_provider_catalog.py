@@ -853,6 +853,51 @@ def supported_operations(model: Json) -> list[str]:
     return out
 
 
+def model_token_limit(model: Json, keys: tuple[str, ...]) -> int | None:
+    for key in keys:
+        value = model.get(key)
+        if isinstance(value, bool):
+            continue
+        if isinstance(value, int) and value > 0:
+            return value
+        if isinstance(value, str) and value.strip().isdigit() and int(value.strip()) > 0:
+            return int(value.strip())
+    return None
+
+
+def model_context_window(model: Json) -> int | None:
+    return model_token_limit(model, ('context_window_tokens', 'context_window', 'input_token_limit'))
+
+
+def model_max_output_tokens(model: Json) -> int | None:
+    return model_token_limit(model, ('max_output_tokens', 'output_token_limit'))
+
+
+def model_modalities(model: Json, direction: str) -> list[str]:
+    listed = model.get(f'{direction}_modalities')
+    support_table = model.get('modalities')
+    described = model.get(direction)
+    if isinstance(listed, list):
+        raw = [str(value) for value in listed]
+    elif isinstance(support_table, dict):
+        wanted = {'input_and_output', f'{direction}_only'}
+        raw = [str(name) for name, support in support_table.items() if support in wanted]
+    elif isinstance(described, str):
+        raw = described.split(',')
+    else:
+        raw = []
+    out: list[str] = []
+    for value in raw:
+        value = value.strip().lower()
+        if value and value not in out:
+            out.append(value)
+    return out
+
+
+def rust_option_u64(value: int | None) -> str:
+    return 'None' if value is None else f'Some({value})'
+
+
 def render_query_params(query_params: list[tuple[str, str]]) -> str:
     if not query_params:
         return '&[]'
@@ -975,6 +1020,10 @@ def render_model_descriptors(provider_id: str, models: Json) -> str:
             f'        summary: {rust_option_string(summary if isinstance(summary, str) and summary else None)},\n'
             f'        supported_operations: {render_operation_slice(operations)},\n'
             f'        capability_statuses: {render_capability_status_slice(operations)},\n'
+            f'        context_window: {rust_option_u64(model_context_window(model))},\n'
+            f'        max_output_tokens: {rust_option_u64(model_max_output_tokens(model))},\n'
+            f'        input_modalities: {render_string_slice(model_modalities(model, "input"))},\n'
+            f'        output_modalities: {render_string_slice(model_modalities(model, "output"))},\n'
             '    }'
         )
     return '&[\n' + ',\n'.join(entries) + '\n]'
@@ -0,0 +1,38 @@
+package ditto
+
+import "os"
+
+const envAdminToken = "DITTO_ADMIN_TOKEN"
+
+// AdminClient calls the gateway admin API (`/admin/*`). It shares transport,
+// timeout, and header handling with Client but authenticates with an admin
+// token instead of a virtual key. Read-only admin tokens can use the list
+// endpoints; mutations need a write token.
+type AdminClient struct {
+	c *Client
+}
+
+// NewAdminClient builds an AdminClient that sends token as
+// `Authorization: Bearer <token>`. To use the `x-admin-token` header instead,
+// pass an empty token and WithHeader("x-admin-token", token).
+func NewAdminClient(token string, opts ...Option) *AdminClient {
+	return &AdminClient{c: NewClient(append(opts, WithToken(token))...)}
+}
+
+// NewAdminClientFromEnv builds an AdminClient from DITTO_BASE_URL and
+// DITTO_ADMIN_TOKEN, then applies opts on top.
+func NewAdminClientFromEnv(opts ...Option) *AdminClient {
+	var envOpts []Option
+	if baseURL := os.Getenv(envBaseURL); baseURL != "" {
+		envOpts = append(envOpts, WithBaseURL(baseURL))
+	}
+	if token := os.Getenv(envAdminToken); token != "" {
+		envOpts = append(envOpts, WithToken(token))
+	}
+	return &AdminClient{c: NewClient(append(envOpts, opts...)...)}
+}
+
+// BaseURL returns the normalized gateway root URL.
+func (a *AdminClient) BaseURL() string {
+	return a.c.baseURL
+}
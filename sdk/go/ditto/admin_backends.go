@@ -0,0 +1,55 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BackendHealth is the circuit breaker and health check state of one
+// backend. The state lives in the gateway process, so each replica reports
+// its own view.
+type BackendHealth struct {
+	Backend             string `json:"backend"`
+	ConsecutiveFailures uint32 `json:"consecutive_failures"`
+	// UnhealthyUntilEpochSeconds is set while the circuit breaker keeps the
+	// backend out of rotation.
+	UnhealthyUntilEpochSeconds *uint64 `json:"unhealthy_until_epoch_seconds"`
+	LastError                  string  `json:"last_error,omitempty"`
+	LastFailureTsMs            uint64  `json:"last_failure_ts_ms,omitempty"`
+	// HealthCheckHealthy is nil until the first active health check.
+	HealthCheckHealthy   *bool  `json:"health_check_healthy,omitempty"`
+	HealthCheckLastError string `json:"health_check_last_error,omitempty"`
+	HealthCheckLastTsMs  uint64 `json:"health_check_last_ts_ms,omitempty"`
+}
+
+// Healthy reports whether the gateway would route to the backend at now: the
+// last health check did not fail and no circuit breaker cooldown is running.
+func (h *BackendHealth) Healthy(now time.Time) bool {
+	if h.HealthCheckHealthy != nil && !*h.HealthCheckHealthy {
+		return false
+	}
+	return h.UnhealthyUntilEpochSeconds == nil || uint64(now.Unix()) >= *h.UnhealthyUntilEpochSeconds
+}
+
+// ListBackends calls `GET /admin/backends`. It needs the gateway built with
+// `gateway-routing-advanced`; tenant-scoped admin tokens are rejected.
+func (a *AdminClient) ListBackends(ctx context.Context, opts ...RequestOption) ([]BackendHealth, error) {
+	var out []BackendHealth
+	if err := a.c.doJSON(ctx, http.MethodGet, "/admin/backends", nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResetBackend calls `POST /admin/backends/{name}/reset`, clearing the
+// failure count and any cooldown so the backend is routable again.
+func (a *AdminClient) ResetBackend(ctx context.Context, name string, opts ...RequestOption) (*BackendHealth, error) {
+	var out BackendHealth
+	path := "/admin/backends/" + url.PathEscape(name) + "/reset"
+	if err := a.c.doJSON(ctx, http.MethodPost, path, nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
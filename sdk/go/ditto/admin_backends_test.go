@@ -0,0 +1,54 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAdminBackends(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/backends":
+			_, _ = w.Write([]byte(`[
+				{"backend":"backup","consecutive_failures":0,"unhealthy_until_epoch_seconds":null},
+				{"backend":"primary","consecutive_failures":3,"unhealthy_until_epoch_seconds":2000,"last_error":"status 503","last_failure_ts_ms":1970000,"health_check_healthy":true}
+			]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/backends/primary/reset":
+			_, _ = w.Write([]byte(`{"backend":"primary","consecutive_failures":0,"unhealthy_until_epoch_seconds":null}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	a := NewAdminClient("admin", WithBaseURL(srv.URL))
+
+	backends, err := a.ListBackends(ctx)
+	if err != nil || len(backends) != 2 {
+		t.Fatalf("ListBackends = %+v, %v", backends, err)
+	}
+	primary := backends[1]
+	if primary.ConsecutiveFailures != 3 || primary.LastError != "status 503" || primary.Healthy(time.Unix(1999, 0)) {
+		t.Fatalf("primary = %+v", primary)
+	}
+	if !primary.Healthy(time.Unix(2000, 0)) || !backends[0].Healthy(time.Unix(0, 0)) {
+		t.Fatal("expected healthy after cooldown")
+	}
+
+	reset, err := a.ResetBackend(ctx, "primary")
+	if err != nil || reset.ConsecutiveFailures != 0 || reset.UnhealthyUntilEpochSeconds != nil {
+		t.Fatalf("ResetBackend = %+v, %v", reset, err)
+	}
+}
+
+func TestBackendHealthCheckFailed(t *testing.T) {
+	h := BackendHealth{Backend: "b", HealthCheckHealthy: Ptr(false)}
+	if h.Healthy(time.Now()) {
+		t.Fatal("failed health check should be unhealthy")
+	}
+}
@@ -0,0 +1,151 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+)
+
+// RouterConfig mirrors the gateway router: weighted default backends plus
+// model rules matched in order.
+type RouterConfig struct {
+	DefaultBackends []RouteBackend `json:"default_backends,omitempty"`
+	Rules           []RouteRule    `json:"rules,omitempty"`
+}
+
+// RouteBackend is one weighted routing candidate. A zero Weight is omitted
+// so the gateway default of 1 applies.
+type RouteBackend struct {
+	Backend string  `json:"backend"`
+	Weight  float64 `json:"weight,omitempty"`
+}
+
+// RouteRule routes models matching ModelPrefix (or exactly ModelPrefix when
+// Exact is set) to Backends, or to the single Backend.
+type RouteRule struct {
+	ModelPrefix string            `json:"model_prefix"`
+	Exact       bool              `json:"exact,omitempty"`
+	Backend     string            `json:"backend,omitempty"`
+	Backends    []RouteBackend    `json:"backends,omitempty"`
+	Guardrails  *GuardrailsConfig `json:"guardrails,omitempty"`
+}
+
+// ConfigVersion describes one control-plane config version. The history is
+// kept in the gateway process and restarts from a `bootstrap` version.
+type ConfigVersion struct {
+	VersionID                 string `json:"version_id"`
+	CreatedAtMs               uint64 `json:"created_at_ms"`
+	Reason                    string `json:"reason"`
+	VirtualKeyCount           int    `json:"virtual_key_count"`
+	VirtualKeysSHA256         string `json:"virtual_keys_sha256"`
+	RouterDefaultBackendCount int    `json:"router_default_backend_count"`
+	RouterRuleCount           int    `json:"router_rule_count"`
+	RouterSHA256              string `json:"router_sha256"`
+}
+
+// ConfigValidateRequest is the payload of `POST /admin/config/validate`.
+// The expected hashes, when set, must match the computed ones.
+type ConfigValidateRequest struct {
+	VirtualKeys               []VirtualKeyConfig `json:"virtual_keys,omitempty"`
+	Router                    *RouterConfig      `json:"router,omitempty"`
+	ExpectedVirtualKeysSHA256 string             `json:"expected_virtual_keys_sha256,omitempty"`
+	ExpectedRouterSHA256      string             `json:"expected_router_sha256,omitempty"`
+}
+
+// ConfigValidation is the result of ValidateConfig. Router fields are only
+// set when the request carried a router.
+type ConfigValidation struct {
+	Valid                     bool                    `json:"valid"`
+	VirtualKeyCount           int                     `json:"virtual_key_count"`
+	ComputedVirtualKeysSHA256 string                  `json:"computed_virtual_keys_sha256"`
+	RouterDefaultBackendCount int                     `json:"router_default_backend_count,omitempty"`
+	RouterRuleCount           int                     `json:"router_rule_count,omitempty"`
+	ComputedRouterSHA256      string                  `json:"computed_router_sha256,omitempty"`
+	Issues                    []ConfigValidationIssue `json:"issues"`
+}
+
+// ConfigValidationIssue is one validation problem, e.g. `duplicate_id` or
+// `invalid_router`. Path points at the offending field when known.
+type ConfigValidationIssue struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Path    string `json:"path,omitempty"`
+}
+
+// RouterUpdate is the result of UpdateRouter. Noop is set for dry runs and
+// when the router was already identical.
+type RouterUpdate struct {
+	DryRun             bool          `json:"dry_run"`
+	Noop               bool          `json:"noop"`
+	RouterChanged      bool          `json:"router_changed"`
+	TargetRouterSHA256 string        `json:"target_router_sha256"`
+	PreviousVersion    ConfigVersion `json:"previous_version"`
+	CurrentVersion     ConfigVersion `json:"current_version"`
+}
+
+// ConfigRollback is the result of RollbackConfig.
+type ConfigRollback struct {
+	DryRun                bool          `json:"dry_run"`
+	Noop                  bool          `json:"noop"`
+	RolledBackToVersionID string        `json:"rolled_back_to_version_id"`
+	TargetVersion         ConfigVersion `json:"target_version"`
+	CurrentVersion        ConfigVersion `json:"current_version"`
+}
+
+// ConfigVersion calls `GET /admin/config/version`, e.g. to confirm that a
+// router update or rollback took effect.
+func (a *AdminClient) ConfigVersion(ctx context.Context, opts ...RequestOption) (*ConfigVersion, error) {
+	var out ConfigVersion
+	if err := a.c.doJSON(ctx, http.MethodGet, "/admin/config/version", nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListConfigVersions calls `GET /admin/config/versions`, newest first.
+func (a *AdminClient) ListConfigVersions(ctx context.Context, opts ...RequestOption) ([]ConfigVersion, error) {
+	var out []ConfigVersion
+	if err := a.c.doJSON(ctx, http.MethodGet, "/admin/config/versions", nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ValidateConfig calls `POST /admin/config/validate`. It checks keys and
+// router references against the running gateway without changing anything;
+// problems are reported in Issues, not as an error.
+func (a *AdminClient) ValidateConfig(ctx context.Context, req *ConfigValidateRequest, opts ...RequestOption) (*ConfigValidation, error) {
+	var out ConfigValidation
+	if err := a.c.doJSON(ctx, http.MethodPost, "/admin/config/validate", req, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateRouter calls `PUT /admin/config/router`, swapping the live router
+// without a restart. Requests already routed keep their backend. Backends
+// must already be configured; dryRun only previews the change.
+func (a *AdminClient) UpdateRouter(ctx context.Context, router *RouterConfig, dryRun bool, opts ...RequestOption) (*RouterUpdate, error) {
+	in := struct {
+		Router *RouterConfig `json:"router"`
+		DryRun bool          `json:"dry_run"`
+	}{router, dryRun}
+	var out RouterUpdate
+	if err := a.c.doJSON(ctx, http.MethodPut, "/admin/config/router", in, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RollbackConfig calls `POST /admin/config/rollback`, restoring the virtual
+// keys and router of versionID as a new version.
+func (a *AdminClient) RollbackConfig(ctx context.Context, versionID string, dryRun bool, opts ...RequestOption) (*ConfigRollback, error) {
+	in := struct {
+		VersionID string `json:"version_id"`
+		DryRun    bool   `json:"dry_run"`
+	}{versionID, dryRun}
+	var out ConfigRollback
+	if err := a.c.doJSON(ctx, http.MethodPost, "/admin/config/rollback", in, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,84 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testConfigVersion = `{"version_id":"cfgv-00000000000000000002","created_at_ms":1700000000000,"reason":"admin.config.router.upsert","virtual_key_count":1,"virtual_keys_sha256":"k","router_default_backend_count":1,"router_rule_count":1,"router_sha256":"r2"}`
+
+func TestAdminConfigRouterUpdate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/config/version":
+			_, _ = w.Write([]byte(testConfigVersion))
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/config/validate":
+			_, _ = w.Write([]byte(`{"valid":false,"virtual_key_count":0,"computed_virtual_keys_sha256":"e","router_default_backend_count":1,"router_rule_count":0,"computed_router_sha256":"r","issues":[{"code":"invalid_router","message":"unknown backend: missing","path":"router"}]}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/admin/config/router":
+			var body map[string]json.RawMessage
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			want := `{"default_backends":[{"backend":"primary"}],"rules":[{"model_prefix":"gpt-4o","exact":true,"backends":[{"backend":"a","weight":3},{"backend":"b"}]}]}`
+			if string(body["router"]) != want || string(body["dry_run"]) != "false" {
+				t.Errorf("body = %s %s", body["router"], body["dry_run"])
+			}
+			_, _ = w.Write([]byte(`{"dry_run":false,"noop":false,"router_changed":true,"target_router_sha256":"r2","previous_version":` + testConfigVersion + `,"current_version":` + testConfigVersion + `}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	a := NewAdminClient("admin", WithBaseURL(srv.URL))
+
+	validation, err := a.ValidateConfig(ctx, &ConfigValidateRequest{Router: &RouterConfig{DefaultBackends: []RouteBackend{{Backend: "missing"}}}})
+	if err != nil || validation.Valid || len(validation.Issues) != 1 || validation.Issues[0].Code != "invalid_router" {
+		t.Fatalf("ValidateConfig = %+v, %v", validation, err)
+	}
+
+	router := &RouterConfig{
+		DefaultBackends: []RouteBackend{{Backend: "primary"}},
+		Rules: []RouteRule{{
+			ModelPrefix: "gpt-4o",
+			Exact:       true,
+			Backends:    []RouteBackend{{Backend: "a", Weight: 3}, {Backend: "b"}},
+		}},
+	}
+	update, err := a.UpdateRouter(ctx, router, false)
+	if err != nil || !update.RouterChanged || update.Noop || update.CurrentVersion.RouterSHA256 != "r2" {
+		t.Fatalf("UpdateRouter = %+v, %v", update, err)
+	}
+
+	version, err := a.ConfigVersion(ctx)
+	if err != nil || version.VersionID != "cfgv-00000000000000000002" || version.RouterRuleCount != 1 {
+		t.Fatalf("ConfigVersion = %+v, %v", version, err)
+	}
+}
+
+func TestAdminConfigRollback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			VersionID string `json:"version_id"`
+			DryRun    bool   `json:"dry_run"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if r.URL.Path != "/admin/config/rollback" || body.VersionID != "cfgv-00000000000000000001" || !body.DryRun {
+			t.Errorf("unexpected %s %+v", r.URL.Path, body)
+		}
+		_, _ = w.Write([]byte(`{"dry_run":true,"noop":true,"rolled_back_to_version_id":"cfgv-00000000000000000001","target_version":` + testConfigVersion + `,"current_version":` + testConfigVersion + `}`))
+	}))
+	defer srv.Close()
+
+	rollback, err := NewAdminClient("admin", WithBaseURL(srv.URL)).RollbackConfig(context.Background(), "cfgv-00000000000000000001", true)
+	if err != nil || !rollback.DryRun || rollback.RolledBackToVersionID != "cfgv-00000000000000000001" {
+		t.Fatalf("RollbackConfig = %+v, %v", rollback, err)
+	}
+}
@@ -0,0 +1,364 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// VirtualKeyConfig mirrors the gateway's virtual key record. Build new keys
+// with NewVirtualKey so defaults match the gateway's own.
+type VirtualKeyConfig struct {
+	ID string `json:"id"`
+	// Token is the key secret. List responses return "redacted" unless
+	// ListKeysOptions.IncludeTokens is set.
+	Token   string `json:"token"`
+	Enabled bool   `json:"enabled"`
+
+	TenantID  string `json:"tenant_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+
+	TenantBudget  *BudgetConfig `json:"tenant_budget,omitempty"`
+	ProjectBudget *BudgetConfig `json:"project_budget,omitempty"`
+	UserBudget    *BudgetConfig `json:"user_budget,omitempty"`
+	TenantLimits  *LimitsConfig `json:"tenant_limits,omitempty"`
+	ProjectLimits *LimitsConfig `json:"project_limits,omitempty"`
+	UserLimits    *LimitsConfig `json:"user_limits,omitempty"`
+
+	Limits      LimitsConfig      `json:"limits"`
+	Budget      BudgetConfig      `json:"budget"`
+	Cache       CacheConfig       `json:"cache"`
+	Guardrails  GuardrailsConfig  `json:"guardrails"`
+	Passthrough PassthroughConfig `json:"passthrough"`
+	// Route pins the key to a named router entry.
+	Route *string `json:"route"`
+	// AllowedIPs and AllowedOrigins restrict which clients may use the key:
+	// IPs or CIDR ranges, and exact Origin values. Empty means unrestricted.
+	AllowedIPs     []string `json:"allowed_ips,omitempty"`
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+	// Tags label the key's spend (cost center, feature, environment) for
+	// AdminClient.Spend with SpendByTag.
+	Tags []string `json:"tags,omitempty"`
+	// Callbacks names the non-global observability callbacks (Langfuse,
+	// Datadog, Helicone) that also receive this key's traces.
+	Callbacks []string `json:"callbacks,omitempty"`
+	// Regions pins the key's requests to backends whose region label matches
+	// (data residency, e.g. "eu"). Empty means any backend.
+	Regions []string `json:"regions,omitempty"`
+	// MCP limits which MCP servers and tools the key may use and how often it
+	// may call them. Nil is unrestricted.
+	MCP *MCPAccessConfig `json:"mcp,omitempty"`
+	// FineTuning lets the key create and manage fine-tuning jobs; it only
+	// sees the jobs of keys in the same project or tenant.
+	FineTuning bool `json:"fine_tuning,omitempty"`
+}
+
+// NewVirtualKey returns an enabled key with the gateway defaults: no limits
+// or budget, cache off, and passthrough allowed.
+func NewVirtualKey(id, token string) *VirtualKeyConfig {
+	return &VirtualKeyConfig{
+		ID:          id,
+		Token:       token,
+		Enabled:     true,
+		Passthrough: PassthroughConfig{Allow: true, BypassCache: true},
+	}
+}
+
+// MCPAccessConfig scopes a key's MCP tool use, through `/mcp*` and
+// `{"type":"mcp"}` tools alike.
+type MCPAccessConfig struct {
+	// Servers lists the server ids the key may use; empty means every server.
+	Servers []string `json:"servers,omitempty"`
+	// AllowedTools entries are "<server_id>/<tool>", "<server_id>/*" or a bare
+	// tool name on any allowed server; empty means every tool.
+	AllowedTools []string `json:"allowed_tools,omitempty"`
+	// CallsPerMinute caps tool calls across all servers; nil is unlimited.
+	CallsPerMinute *uint32 `json:"calls_per_minute,omitempty"`
+}
+
+// LimitsConfig holds per-minute request and token limits; nil is unlimited.
+type LimitsConfig struct {
+	RPM *uint32 `json:"rpm"`
+	TPM *uint32 `json:"tpm"`
+}
+
+// BudgetConfig holds lifetime token and USD (micros) budgets; nil is
+// unlimited.
+type BudgetConfig struct {
+	TotalTokens    *uint64 `json:"total_tokens"`
+	TotalUSDMicros *uint64 `json:"total_usd_micros,omitempty"`
+}
+
+// CacheConfig configures the control-plane cache for the key. Zero sizes
+// fall back to the gateway defaults.
+type CacheConfig struct {
+	Enabled           bool    `json:"enabled"`
+	TTLSeconds        *uint64 `json:"ttl_seconds"`
+	MaxEntries        int     `json:"max_entries,omitempty"`
+	MaxBodyBytes      int     `json:"max_body_bytes,omitempty"`
+	MaxTotalBodyBytes int     `json:"max_total_body_bytes,omitempty"`
+}
+
+// GuardrailsConfig restricts what a key may send. AllowModels and
+// DenyModels accept exact ids or `prefix*` patterns.
+type GuardrailsConfig struct {
+	BannedPhrases  []string `json:"banned_phrases,omitempty"`
+	BannedRegexes  []string `json:"banned_regexes,omitempty"`
+	BlockPII       bool     `json:"block_pii,omitempty"`
+	ValidateSchema bool     `json:"validate_schema,omitempty"`
+	MaxInputTokens *uint32  `json:"max_input_tokens,omitempty"`
+	AllowModels    []string `json:"allow_models,omitempty"`
+	DenyModels     []string `json:"deny_models,omitempty"`
+	// Hooks run in order at their phase; see GuardrailHook.
+	Hooks []GuardrailHook `json:"hooks,omitempty"`
+	// PromptInjection scores user text; nil disables it.
+	PromptInjection *PromptInjectionConfig `json:"prompt_injection,omitempty"`
+	// Moderation sends text to a moderation backend; nil disables it.
+	Moderation *ModerationConfig `json:"moderation,omitempty"`
+	// ContextWindow rejects or trims requests that do not fit the model's
+	// context window; nil disables it.
+	ContextWindow *ContextWindowConfig `json:"context_window,omitempty"`
+	// HistoryCompression summarizes the older turns of long conversations;
+	// nil disables it.
+	HistoryCompression *HistoryCompressionConfig `json:"history_compression,omitempty"`
+}
+
+// Guardrail hook phases and actions. An empty Phase means pre-call and an
+// empty Action means block.
+const (
+	GuardrailPhasePreCall      = "pre_call"
+	GuardrailPhasePostCall     = "post_call"
+	GuardrailPhaseDuringStream = "during_stream"
+
+	GuardrailActionBlock  = "block"
+	GuardrailActionModify = "modify"
+	GuardrailActionLog    = "log"
+)
+
+// Built-in PII detectors for GuardrailHook.PII. Credit card numbers must
+// also pass the Luhn check.
+const (
+	GuardrailPIIEmail      = "email"
+	GuardrailPIIPhone      = "phone"
+	GuardrailPIICreditCard = "credit_card"
+	GuardrailPIISSN        = "ssn"
+)
+
+// GuardrailHook is a named check on message text. It matches Phrases
+// (substrings), Regexes, PII detectors or Entities (name to regex), all
+// case-insensitive. Modify hooks substitute Replacement for each match;
+// without one, PII and entities become `[EMAIL]`, `[EMPLOYEE_ID]` and so on,
+// everything else `[REDACTED]`.
+type GuardrailHook struct {
+	Name        string            `json:"name"`
+	Phase       string            `json:"phase,omitempty"`
+	Action      string            `json:"action,omitempty"`
+	Phrases     []string          `json:"phrases,omitempty"`
+	Regexes     []string          `json:"regexes,omitempty"`
+	PII         []string          `json:"pii,omitempty"`
+	Entities    map[string]string `json:"entities,omitempty"`
+	Replacement string            `json:"replacement,omitempty"`
+}
+
+// Prompt-injection actions. An empty Action means block.
+const (
+	PromptInjectionActionBlock = "block"
+	PromptInjectionActionTag   = "tag"
+)
+
+// PromptInjectionConfig scores the user messages of a request with built-in
+// heuristics and, when Classifier is set, a classifier model; the higher
+// score wins. Scores at or above Threshold (0.5 when zero) are blocked with
+// ErrCodeGuardrailBlocked or tagged via response headers.
+type PromptInjectionConfig struct {
+	Action     string                     `json:"action,omitempty"`
+	Threshold  float64                    `json:"threshold,omitempty"`
+	Classifier *PromptInjectionClassifier `json:"classifier,omitempty"`
+}
+
+// PromptInjectionClassifier names a chat model on a gateway backend that
+// replies with `{"score": <0..1>}`.
+type PromptInjectionClassifier struct {
+	Backend   string `json:"backend"`
+	Model     string `json:"model"`
+	TimeoutMs uint64 `json:"timeout_ms,omitempty"`
+}
+
+// Moderation actions. An empty Action means block.
+const (
+	ModerationActionBlock    = "block"
+	ModerationActionAnnotate = "annotate"
+)
+
+// ModerationConfig sends user text (and, with CheckOutput, non-streaming
+// responses) to the `/v1/moderations` endpoint of Backend. Thresholds maps
+// categories to minimum scores; when empty the provider's own flags apply.
+// CheckInput is a pointer because the gateway defaults it to true.
+type ModerationConfig struct {
+	Backend     string             `json:"backend"`
+	Model       string             `json:"model,omitempty"`
+	Action      string             `json:"action,omitempty"`
+	CheckInput  *bool              `json:"check_input,omitempty"`
+	CheckOutput bool               `json:"check_output,omitempty"`
+	Thresholds  map[string]float64 `json:"thresholds,omitempty"`
+	TimeoutMs   uint64             `json:"timeout_ms,omitempty"`
+}
+
+// Context-window strategies. An empty Strategy means reject.
+const (
+	ContextStrategyReject          = "reject"
+	ContextStrategyDropOldest      = "drop_oldest"
+	ContextStrategySummarizeMiddle = "summarize_middle"
+)
+
+// ContextWindowConfig checks that the input estimate plus the requested
+// output tokens fit in MaxTokens before a request is forwarded. Summarizer is
+// required for ContextStrategySummarizeMiddle.
+type ContextWindowConfig struct {
+	MaxTokens  uint32             `json:"max_tokens"`
+	Strategy   string             `json:"strategy,omitempty"`
+	Summarizer *ContextSummarizer `json:"summarizer,omitempty"`
+}
+
+// ContextSummarizer is the chat model on a proxy backend that summarizes
+// the middle of an oversized conversation. A zero MaxTokens uses the
+// gateway default of 256.
+type ContextSummarizer struct {
+	Backend   string `json:"backend"`
+	Model     string `json:"model"`
+	MaxTokens uint32 `json:"max_tokens,omitempty"`
+	TimeoutMs uint64 `json:"timeout_ms,omitempty"`
+}
+
+// HistoryCompressionConfig replaces the older turns of a chat request whose
+// input estimate exceeds ThresholdTokens with a summary written by
+// Summarizer. The last KeepRecentMessages are always kept; zero uses the
+// gateway default of 4.
+type HistoryCompressionConfig struct {
+	ThresholdTokens    uint32            `json:"threshold_tokens"`
+	KeepRecentMessages int               `json:"keep_recent_messages,omitempty"`
+	Summarizer         ContextSummarizer `json:"summarizer"`
+}
+
+// PassthroughConfig controls raw passthrough requests for the key.
+type PassthroughConfig struct {
+	Allow       bool `json:"allow"`
+	BypassCache bool `json:"bypass_cache"`
+}
+
+// ListKeysOptions filters `GET /admin/keys`. Results are sorted by id.
+type ListKeysOptions struct {
+	// IncludeTokens returns real secrets; it needs a write admin token.
+	IncludeTokens bool
+	TenantID      string
+	ProjectID     string
+	UserID        string
+	Enabled       *bool
+	IDPrefix      string
+	Limit         int
+	Offset        int
+}
+
+func (o *ListKeysOptions) query() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	if o.IncludeTokens {
+		q.Set("include_tokens", "true")
+	}
+	if o.TenantID != "" {
+		q.Set("tenant_id", o.TenantID)
+	}
+	if o.ProjectID != "" {
+		q.Set("project_id", o.ProjectID)
+	}
+	if o.UserID != "" {
+		q.Set("user_id", o.UserID)
+	}
+	if o.Enabled != nil {
+		q.Set("enabled", strconv.FormatBool(*o.Enabled))
+	}
+	if o.IDPrefix != "" {
+		q.Set("id_prefix", o.IDPrefix)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	return q
+}
+
+// ListKeys calls `GET /admin/keys`.
+func (a *AdminClient) ListKeys(ctx context.Context, list *ListKeysOptions, opts ...RequestOption) ([]VirtualKeyConfig, error) {
+	var out []VirtualKeyConfig
+	if err := a.c.doJSON(ctx, http.MethodGet, withQuery("/admin/keys", list.query()), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpsertKey calls `POST /admin/keys`, creating the key or replacing the one
+// with the same id. The whole record is replaced, so send every field.
+func (a *AdminClient) UpsertKey(ctx context.Context, key *VirtualKeyConfig, opts ...RequestOption) (*VirtualKeyConfig, error) {
+	var out VirtualKeyConfig
+	if err := a.c.doJSON(ctx, http.MethodPost, "/admin/keys", key, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PutKey calls `PUT /admin/keys/{id}`; id overrides key.ID.
+func (a *AdminClient) PutKey(ctx context.Context, id string, key *VirtualKeyConfig, opts ...RequestOption) (*VirtualKeyConfig, error) {
+	var out VirtualKeyConfig
+	if err := a.c.doJSON(ctx, http.MethodPut, "/admin/keys/"+url.PathEscape(id), key, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteKey calls `DELETE /admin/keys/{id}`. A missing key is a 404
+// *APIError.
+func (a *AdminClient) DeleteKey(ctx context.Context, id string, opts ...RequestOption) error {
+	return a.c.doJSON(ctx, http.MethodDelete, "/admin/keys/"+url.PathEscape(id), nil, nil, opts)
+}
+
+// RegenerateKeyRequest is the body of `POST /key/regenerate`.
+type RegenerateKeyRequest struct {
+	// NewKey is the replacement secret; it must start with `sk-`. Empty asks
+	// the gateway to generate one.
+	NewKey string `json:"new_key,omitempty"`
+}
+
+// RegeneratedKey is the result of a key rotation.
+type RegeneratedKey struct {
+	// Key is the new secret; it is only returned once.
+	Key      string `json:"key"`
+	KeyAlias string `json:"key_alias,omitempty"`
+	UserID   string `json:"user_id,omitempty"`
+	TeamID   string `json:"team_id,omitempty"`
+}
+
+// RegenerateKey rotates the secret of the key currently authenticated by
+// token, keeping its id, limits, and budgets. The old secret stops working
+// as soon as the call returns. It uses the LiteLLM-compatible
+// `POST /key/regenerate` and needs a write admin token.
+func (a *AdminClient) RegenerateKey(ctx context.Context, token string, req *RegenerateKeyRequest, opts ...RequestOption) (*RegeneratedKey, error) {
+	body := struct {
+		Key string `json:"key"`
+		RegenerateKeyRequest
+	}{Key: token}
+	if req != nil {
+		body.RegenerateKeyRequest = *req
+	}
+
+	var out RegeneratedKey
+	if err := a.c.doJSON(ctx, http.MethodPost, "/key/regenerate", &body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
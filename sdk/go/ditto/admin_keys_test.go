@@ -0,0 +1,120 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminKeysCRUD(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("authorization"); got != "Bearer admin-secret" {
+			t.Errorf("authorization = %q", got)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /admin/keys":
+			if r.URL.RawQuery != "" && r.URL.RawQuery != "enabled=true&id_prefix=team-&limit=2&tenant_id=acme" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":"team-a","token":"redacted","enabled":true,"tenant_id":"acme","limits":{"rpm":60,"tpm":null},"budget":{"total_tokens":null},"cache":{"enabled":false,"ttl_seconds":null,"max_entries":1024,"max_body_bytes":1048576,"max_total_body_bytes":67108864},"guardrails":{"allow_models":["gpt-4o*"]},"passthrough":{"allow":true,"bypass_cache":true},"route":null}]`))
+		case "POST /admin/keys", "PUT /admin/keys/team-b":
+			raw, _ := io.ReadAll(r.Body)
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			for _, required := range []string{"limits", "budget", "cache", "guardrails", "passthrough"} {
+				if _, ok := fields[required]; !ok {
+					t.Errorf("missing required field %q in %s", required, raw)
+				}
+			}
+			for _, omitted := range []string{"tenant_id", "allowed_ips", "allowed_origins", "mcp"} {
+				if _, ok := fields[omitted]; ok {
+					t.Errorf("unset %s should be omitted: %s", omitted, raw)
+				}
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(raw)
+		case "DELETE /admin/keys/team-b":
+			w.WriteHeader(http.StatusNoContent)
+		case "DELETE /admin/keys/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":"not_found","message":"virtual key not found"}}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	a := NewAdminClient("admin-secret", WithBaseURL(srv.URL))
+
+	keys, err := a.ListKeys(ctx, &ListKeysOptions{TenantID: "acme", Enabled: Ptr(true), IDPrefix: "team-", Limit: 2})
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("ListKeys = %+v, %v", keys, err)
+	}
+	if k := keys[0]; k.Limits.RPM == nil || *k.Limits.RPM != 60 || k.Limits.TPM != nil || k.Guardrails.AllowModels[0] != "gpt-4o*" {
+		t.Fatalf("key = %+v", k)
+	}
+	if _, err := a.ListKeys(ctx, nil); err != nil {
+		t.Fatalf("ListKeys(nil): %v", err)
+	}
+
+	key := NewVirtualKey("team-b", "vk-team-b")
+	key.Limits.RPM = Ptr[uint32](120)
+	key.Guardrails.AllowModels = []string{"gpt-4o-mini"}
+	created, err := a.UpsertKey(ctx, key)
+	if err != nil || created.ID != "team-b" || !created.Enabled || !created.Passthrough.Allow || *created.Limits.RPM != 120 {
+		t.Fatalf("UpsertKey = %+v, %v", created, err)
+	}
+	if _, err := a.PutKey(ctx, "team-b", key); err != nil {
+		t.Fatalf("PutKey: %v", err)
+	}
+
+	if err := a.DeleteKey(ctx, "team-b"); err != nil {
+		t.Fatalf("DeleteKey: %v", err)
+	}
+	err = a.DeleteKey(ctx, "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "not_found" {
+		t.Fatalf("DeleteKey(missing) = %v", err)
+	}
+}
+
+func TestAdminRegenerateKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/key/regenerate" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body["key"] != "sk-old" {
+			t.Errorf("key = %q", body["key"])
+		}
+		newKey := body["new_key"]
+		if newKey == "" {
+			newKey = "sk-generated"
+		}
+		_, _ = w.Write([]byte(`{"key":"` + newKey + `","token":"` + newKey + `","key_alias":"team-a","key_name":"team-a"}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	a := NewAdminClient("admin-secret", WithBaseURL(srv.URL))
+
+	rotated, err := a.RegenerateKey(ctx, "sk-old", nil)
+	if err != nil || rotated.Key != "sk-generated" || rotated.KeyAlias != "team-a" {
+		t.Fatalf("RegenerateKey = %+v, %v", rotated, err)
+	}
+	rotated, err = a.RegenerateKey(ctx, "sk-old", &RegenerateKeyRequest{NewKey: "sk-new"})
+	if err != nil || rotated.Key != "sk-new" {
+		t.Fatalf("RegenerateKey(new) = %+v, %v", rotated, err)
+	}
+}
@@ -0,0 +1,111 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// BudgetLedger is the persisted token spend of one budget scope. KeyID is a
+// virtual key id, or a shared scope such as `tenant:<id>`, `project:<id>`, or
+// `user:<id>` (the latter two prefixed with `tenant:<id>:` for tenant keys).
+type BudgetLedger struct {
+	KeyID          string `json:"key_id"`
+	SpentTokens    uint64 `json:"spent_tokens"`
+	ReservedTokens uint64 `json:"reserved_tokens"`
+	UpdatedAtMs    uint64 `json:"updated_at_ms"`
+}
+
+// CostLedger is the persisted USD spend (micros) of one budget scope.
+type CostLedger struct {
+	KeyID             string `json:"key_id"`
+	SpentUSDMicros    uint64 `json:"spent_usd_micros"`
+	ReservedUSDMicros uint64 `json:"reserved_usd_micros"`
+	UpdatedAtMs       uint64 `json:"updated_at_ms"`
+}
+
+// LedgerRollup aggregates key ledgers by the tenant, project, or user
+// attribution of the keys; only the field for the requested grouping is set.
+// Token rollups fill SpentTokens/ReservedTokens, cost rollups the USD fields.
+type LedgerRollup struct {
+	TenantID          string `json:"tenant_id,omitempty"`
+	ProjectID         string `json:"project_id,omitempty"`
+	UserID            string `json:"user_id,omitempty"`
+	SpentTokens       uint64 `json:"spent_tokens,omitempty"`
+	ReservedTokens    uint64 `json:"reserved_tokens,omitempty"`
+	SpentUSDMicros    uint64 `json:"spent_usd_micros,omitempty"`
+	ReservedUSDMicros uint64 `json:"reserved_usd_micros,omitempty"`
+	KeyCount          int    `json:"key_count"`
+	UpdatedAtMs       uint64 `json:"updated_at_ms"`
+}
+
+// LedgerListOptions filters `GET /admin/budgets` and `GET /admin/costs`.
+type LedgerListOptions struct {
+	// KeyPrefix matches the start of KeyID, e.g. "tenant:".
+	KeyPrefix string
+	Limit     int
+	Offset    int
+}
+
+func (o *LedgerListOptions) query() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	if o.KeyPrefix != "" {
+		q.Set("key_prefix", o.KeyPrefix)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	return q
+}
+
+// Ledger rollup groupings.
+const (
+	LedgerByTenant  = "tenants"
+	LedgerByProject = "projects"
+	LedgerByUser    = "users"
+)
+
+// ListBudgets calls `GET /admin/budgets`. It needs a gateway store.
+func (a *AdminClient) ListBudgets(ctx context.Context, list *LedgerListOptions, opts ...RequestOption) ([]BudgetLedger, error) {
+	var out []BudgetLedger
+	if err := a.c.doJSON(ctx, http.MethodGet, withQuery("/admin/budgets", list.query()), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCosts calls `GET /admin/costs`. It needs a gateway store and costing.
+func (a *AdminClient) ListCosts(ctx context.Context, list *LedgerListOptions, opts ...RequestOption) ([]CostLedger, error) {
+	var out []CostLedger
+	if err := a.c.doJSON(ctx, http.MethodGet, withQuery("/admin/costs", list.query()), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BudgetRollup calls `GET /admin/budgets/{by}` with by one of LedgerByTenant,
+// LedgerByProject, or LedgerByUser.
+func (a *AdminClient) BudgetRollup(ctx context.Context, by string, opts ...RequestOption) ([]LedgerRollup, error) {
+	var out []LedgerRollup
+	if err := a.c.doJSON(ctx, http.MethodGet, "/admin/budgets/"+url.PathEscape(by), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CostRollup calls `GET /admin/costs/{by}` with by one of LedgerByTenant,
+// LedgerByProject, or LedgerByUser.
+func (a *AdminClient) CostRollup(ctx context.Context, by string, opts ...RequestOption) ([]LedgerRollup, error) {
+	var out []LedgerRollup
+	if err := a.c.doJSON(ctx, http.MethodGet, "/admin/costs/"+url.PathEscape(by), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,50 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminLedgers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin/budgets":
+			if r.URL.RawQuery != "" && r.URL.RawQuery != "key_prefix=tenant%3A&limit=50" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"key_id":"tenant:acme","spent_tokens":1200,"reserved_tokens":300,"updated_at_ms":1}]`))
+		case "/admin/costs":
+			_, _ = w.Write([]byte(`[{"key_id":"team-a","spent_usd_micros":2500000,"reserved_usd_micros":0,"updated_at_ms":2}]`))
+		case "/admin/budgets/tenants":
+			_, _ = w.Write([]byte(`[{"tenant_id":"acme","spent_tokens":900,"reserved_tokens":0,"key_count":3,"updated_at_ms":3}]`))
+		case "/admin/costs/projects":
+			_, _ = w.Write([]byte(`[{"project_id":"search","spent_usd_micros":10,"reserved_usd_micros":5,"key_count":1,"updated_at_ms":4}]`))
+		default:
+			t.Errorf("unexpected %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	a := NewAdminClient("admin", WithBaseURL(srv.URL))
+
+	budgets, err := a.ListBudgets(ctx, &LedgerListOptions{KeyPrefix: "tenant:", Limit: 50})
+	if err != nil || len(budgets) != 1 || budgets[0].SpentTokens != 1200 || budgets[0].ReservedTokens != 300 {
+		t.Fatalf("ListBudgets = %+v, %v", budgets, err)
+	}
+	costs, err := a.ListCosts(ctx, nil)
+	if err != nil || len(costs) != 1 || costs[0].SpentUSDMicros != 2_500_000 {
+		t.Fatalf("ListCosts = %+v, %v", costs, err)
+	}
+	tenants, err := a.BudgetRollup(ctx, LedgerByTenant)
+	if err != nil || tenants[0].TenantID != "acme" || tenants[0].KeyCount != 3 {
+		t.Fatalf("BudgetRollup = %+v, %v", tenants, err)
+	}
+	projects, err := a.CostRollup(ctx, LedgerByProject)
+	if err != nil || projects[0].ProjectID != "search" || projects[0].ReservedUSDMicros != 5 {
+		t.Fatalf("CostRollup = %+v, %v", projects, err)
+	}
+}
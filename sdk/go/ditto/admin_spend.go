@@ -0,0 +1,103 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SpendRow is one group (and time bucket) of a spend report. Group is the key
+// id, tenant, project, user, model, or tag; nil collects requests without one.
+type SpendRow struct {
+	Group *string `json:"group"`
+	// BucketStartMs is the UTC start of the bucket; zero without a bucket.
+	BucketStartMs  uint64 `json:"bucket_start_ms,omitempty"`
+	Requests       uint64 `json:"requests"`
+	InputTokens    uint64 `json:"input_tokens"`
+	OutputTokens   uint64 `json:"output_tokens"`
+	SpentTokens    uint64 `json:"spent_tokens"`
+	SpentUSDMicros uint64 `json:"spent_usd_micros"`
+}
+
+// Spend report groupings. SpendByTenant groups by team.
+const (
+	SpendByKey     = ""
+	SpendByTenant  = "tenants"
+	SpendByProject = "projects"
+	SpendByUser    = "users"
+	SpendByModel   = "models"
+	SpendByTag     = "tags"
+)
+
+// Spend report buckets.
+const (
+	SpendBucketDay   = "day"
+	SpendBucketWeek  = "week"
+	SpendBucketMonth = "month"
+)
+
+// SpendOptions filters and buckets `GET /admin/spend`. The time window is
+// [SinceMs, BeforeMs) in epoch milliseconds; zero leaves a side open.
+type SpendOptions struct {
+	Bucket    string
+	SinceMs   uint64
+	BeforeMs  uint64
+	KeyID     string
+	TenantID  string
+	ProjectID string
+	UserID    string
+	Model     string
+	Tag       string
+	Limit     int
+	Offset    int
+}
+
+func (o *SpendOptions) query() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	for name, value := range map[string]string{
+		"bucket":     o.Bucket,
+		"key_id":     o.KeyID,
+		"tenant_id":  o.TenantID,
+		"project_id": o.ProjectID,
+		"user_id":    o.UserID,
+		"model":      o.Model,
+		"tag":        o.Tag,
+	} {
+		if value != "" {
+			q.Set(name, value)
+		}
+	}
+	if o.SinceMs > 0 {
+		q.Set("since_ts_ms", strconv.FormatUint(o.SinceMs, 10))
+	}
+	if o.BeforeMs > 0 {
+		q.Set("before_ts_ms", strconv.FormatUint(o.BeforeMs, 10))
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	return q
+}
+
+// Spend calls `GET /admin/spend/{by}` with by one of the SpendBy constants.
+// It needs a gateway store. When the window holds more audit records than
+// the gateway scans per report, only the newest are counted and the
+// `x-ditto-spend-truncated` response header (see WithResponseMeta) is set.
+func (a *AdminClient) Spend(ctx context.Context, by string, spend *SpendOptions, opts ...RequestOption) ([]SpendRow, error) {
+	path := "/admin/spend"
+	if by != SpendByKey {
+		path += "/" + url.PathEscape(by)
+	}
+	var out []SpendRow
+	if err := a.c.doJSON(ctx, http.MethodGet, withQuery(path, spend.query()), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,41 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminSpend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin/spend":
+			if r.URL.RawQuery != "" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"group":"key-1","requests":2,"input_tokens":10,"output_tokens":4,"spent_tokens":14,"spent_usd_micros":90},{"group":null,"requests":1,"input_tokens":0,"output_tokens":0,"spent_tokens":3,"spent_usd_micros":0}]`))
+		case "/admin/spend/tags":
+			if got, want := r.URL.RawQuery, "bucket=day&limit=10&since_ts_ms=1000&tenant_id=acme"; got != want {
+				t.Errorf("query = %s, want %s", got, want)
+			}
+			_, _ = w.Write([]byte(`[{"group":"prod","bucket_start_ms":86400000,"requests":3,"input_tokens":0,"output_tokens":0,"spent_tokens":9,"spent_usd_micros":0}]`))
+		default:
+			t.Errorf("unexpected %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	a := NewAdminClient("admin", WithBaseURL(srv.URL))
+
+	keys, err := a.Spend(ctx, SpendByKey, nil)
+	if err != nil || len(keys) != 2 || *keys[0].Group != "key-1" || keys[0].SpentUSDMicros != 90 || keys[1].Group != nil {
+		t.Fatalf("Spend(key) = %+v, %v", keys, err)
+	}
+	tags, err := a.Spend(ctx, SpendByTag, &SpendOptions{Bucket: SpendBucketDay, SinceMs: 1000, TenantID: "acme", Limit: 10})
+	if err != nil || len(tags) != 1 || *tags[0].Group != "prod" || tags[0].BucketStartMs != 86_400_000 || tags[0].Requests != 3 {
+		t.Fatalf("Spend(tag) = %+v, %v", tags, err)
+	}
+}
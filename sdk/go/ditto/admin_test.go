@@ -0,0 +1,17 @@
+package ditto
+
+import "testing"
+
+func TestNewAdminClientFromEnv(t *testing.T) {
+	t.Setenv(envBaseURL, "http://gateway.internal:9000/")
+	t.Setenv(envToken, "vk-env")
+	t.Setenv(envAdminToken, "admin-env")
+
+	a := NewAdminClientFromEnv()
+	if a.BaseURL() != "http://gateway.internal:9000" || a.c.token != "admin-env" {
+		t.Fatalf("admin client = %q, %q", a.BaseURL(), a.c.token)
+	}
+	if a = NewAdminClient("admin-explicit", WithToken("ignored")); a.c.token != "admin-explicit" {
+		t.Fatalf("token = %q", a.c.token)
+	}
+}
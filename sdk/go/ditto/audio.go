@@ -0,0 +1,114 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+)
+
+// Audio transcription response formats.
+const (
+	AudioResponseJSON        = "json"
+	AudioResponseText        = "text"
+	AudioResponseSRT         = "srt"
+	AudioResponseVTT         = "vtt"
+	AudioResponseVerboseJSON = "verbose_json"
+)
+
+// AudioTranscriptionRequest is the multipart body of
+// `POST /v1/audio/transcriptions` and `POST /v1/audio/translations`.
+// File is streamed to the gateway as it is read; it is not buffered.
+type AudioTranscriptionRequest struct {
+	Model string
+	// File is the audio content and FileName its name (the extension is
+	// used upstream to detect the format).
+	File     io.Reader
+	FileName string
+	// Language is an ISO-639-1 hint; ignored by translations.
+	Language       string
+	Prompt         string
+	ResponseFormat string
+	Temperature    *float64
+	// TimestampGranularities requests "word" and/or "segment" timestamps
+	// (verbose_json only).
+	TimestampGranularities []string
+}
+
+// AudioTranscription is the result of a transcription or translation. For
+// text, srt, and vtt response formats only Text is set, holding the raw body.
+type AudioTranscription struct {
+	Text     string          `json:"text"`
+	Language string          `json:"language,omitempty"`
+	Duration float64         `json:"duration,omitempty"`
+	Segments json.RawMessage `json:"segments,omitempty"`
+	Words    json.RawMessage `json:"words,omitempty"`
+}
+
+// AudioTranscriptions calls `POST /v1/audio/transcriptions`.
+func (c *Client) AudioTranscriptions(ctx context.Context, req *AudioTranscriptionRequest, opts ...RequestOption) (*AudioTranscription, error) {
+	return c.audioUpload(ctx, "/v1/audio/transcriptions", req, true, opts)
+}
+
+// AudioTranslations calls `POST /v1/audio/translations`, which transcribes
+// into English.
+func (c *Client) AudioTranslations(ctx context.Context, req *AudioTranscriptionRequest, opts ...RequestOption) (*AudioTranscription, error) {
+	return c.audioUpload(ctx, "/v1/audio/translations", req, false, opts)
+}
+
+func (c *Client) audioUpload(
+	ctx context.Context,
+	path string,
+	req *AudioTranscriptionRequest,
+	withLanguage bool,
+	opts []RequestOption,
+) (*AudioTranscription, error) {
+	if req.File == nil {
+		return nil, errors.New("ditto: audio request requires File")
+	}
+	filename := req.FileName
+	if filename == "" {
+		filename = "audio"
+	}
+
+	body, contentType := streamMultipart(func(mw *multipart.Writer) error {
+		fields := [][2]string{
+			{"model", req.Model},
+			{"prompt", req.Prompt},
+			{"response_format", req.ResponseFormat},
+		}
+		if withLanguage {
+			fields = append(fields, [2]string{"language", req.Language})
+		}
+		if req.Temperature != nil {
+			fields = append(fields, [2]string{"temperature", strconv.FormatFloat(*req.Temperature, 'f', -1, 64)})
+		}
+		for _, granularity := range req.TimestampGranularities {
+			fields = append(fields, [2]string{"timestamp_granularities[]", granularity})
+		}
+		if err := writeFields(mw, fields); err != nil {
+			return err
+		}
+		return writeFile(mw, "file", filename, req.File)
+	})
+	defer body.Close()
+
+	resp, err := c.doRaw(ctx, http.MethodPost, path, body, contentType, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.header.Get("content-type"))
+	if mediaType != "application/json" {
+		return &AudioTranscription{Text: string(resp.body)}, nil
+	}
+	var out AudioTranscription
+	if err := decodeJSON(resp.body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
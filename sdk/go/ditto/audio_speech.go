@@ -0,0 +1,54 @@
+package ditto
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// AudioSpeechRequest is the body of `POST /v1/audio/speech`.
+type AudioSpeechRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+	Voice string `json:"voice"`
+	// ResponseFormat is mp3 (default), opus, aac, flac, wav, or pcm.
+	ResponseFormat string   `json:"response_format,omitempty"`
+	Speed          *float64 `json:"speed,omitempty"`
+	Instructions   string   `json:"instructions,omitempty"`
+}
+
+// AudioSpeech is a streamed speech response. Read audio from it as bytes
+// arrive and Close it when done.
+type AudioSpeech struct {
+	// ContentType is the audio media type reported by the upstream.
+	ContentType string
+	body        io.ReadCloser
+	cancel      context.CancelFunc
+}
+
+// Read reads audio bytes.
+func (s *AudioSpeech) Read(p []byte) (int, error) {
+	return s.body.Read(p)
+}
+
+// Close releases the connection.
+func (s *AudioSpeech) Close() error {
+	err := s.body.Close()
+	s.cancel()
+	return err
+}
+
+// AudioSpeech calls `POST /v1/audio/speech` and returns as soon as the
+// response headers arrive, so playback can start before synthesis finishes.
+// Like other streams it is bounded only by ctx or WithRequestTimeout.
+func (c *Client) AudioSpeech(ctx context.Context, req *AudioSpeechRequest, opts ...RequestOption) (*AudioSpeech, error) {
+	resp, cancel, err := c.doStream(ctx, http.MethodPost, "/v1/audio/speech", req, "*/*", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &AudioSpeech{
+		ContentType: resp.Header.Get("content-type"),
+		body:        resp.Body,
+		cancel:      cancel,
+	}, nil
+}
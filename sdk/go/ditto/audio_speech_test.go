@@ -0,0 +1,52 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAudioSpeechStreamsBody(t *testing.T) {
+	firstChunk := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body AudioSpeechRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body.Voice != "alloy" || body.Input != "hello" {
+			t.Errorf("unexpected body: %+v", body)
+		}
+		w.Header().Set("content-type", "audio/mpeg")
+		_, _ = w.Write([]byte("ID3"))
+		w.(http.Flusher).Flush()
+		<-firstChunk
+		_, _ = w.Write([]byte("-rest"))
+	}))
+	defer srv.Close()
+
+	speech, err := NewClient(WithBaseURL(srv.URL)).AudioSpeech(context.Background(), &AudioSpeechRequest{
+		Model: "tts-1",
+		Input: "hello",
+		Voice: "alloy",
+	})
+	if err != nil {
+		t.Fatalf("AudioSpeech: %v", err)
+	}
+	defer speech.Close()
+	if speech.ContentType != "audio/mpeg" {
+		t.Fatalf("content type = %q", speech.ContentType)
+	}
+
+	buf := make([]byte, 3)
+	if _, err := io.ReadFull(speech, buf); err != nil || string(buf) != "ID3" {
+		t.Fatalf("first chunk = %q, %v", buf, err)
+	}
+	close(firstChunk)
+	rest, err := io.ReadAll(speech)
+	if err != nil || string(rest) != "-rest" {
+		t.Fatalf("rest = %q, %v", rest, err)
+	}
+}
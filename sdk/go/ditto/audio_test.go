@@ -0,0 +1,85 @@
+package ditto
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAudioTranscriptionsStreamsMultipart(t *testing.T) {
+	audio := strings.Repeat("RIFF", 64*1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/transcriptions" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if r.ContentLength != -1 {
+			t.Errorf("expected a streamed (chunked) body, got content-length %d", r.ContentLength)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse multipart: %v", err)
+		}
+		if r.FormValue("model") != "whisper-1" || r.FormValue("language") != "en" || r.FormValue("temperature") != "0.5" {
+			t.Errorf("unexpected fields: %v", r.MultipartForm.Value)
+		}
+		if got := r.MultipartForm.Value["timestamp_granularities[]"]; len(got) != 2 {
+			t.Errorf("timestamp_granularities[] = %v", got)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("form file: %v", err)
+		}
+		data, _ := io.ReadAll(file)
+		if header.Filename != "clip.wav" || string(data) != audio {
+			t.Errorf("file %q: %d bytes", header.Filename, len(data))
+		}
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{"text":"hello","language":"english","duration":12.5}`))
+	}))
+	defer srv.Close()
+
+	out, err := NewClient(WithBaseURL(srv.URL)).AudioTranscriptions(context.Background(), &AudioTranscriptionRequest{
+		Model:                  "whisper-1",
+		File:                   strings.NewReader(audio),
+		FileName:               "clip.wav",
+		Language:               "en",
+		Temperature:            Ptr(0.5),
+		ResponseFormat:         AudioResponseVerboseJSON,
+		TimestampGranularities: []string{"word", "segment"},
+	})
+	if err != nil {
+		t.Fatalf("AudioTranscriptions: %v", err)
+	}
+	if out.Text != "hello" || out.Duration != 12.5 {
+		t.Fatalf("unexpected transcription: %+v", out)
+	}
+}
+
+func TestAudioTranslationsTextFormat(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("parse multipart: %v", err)
+		}
+		if _, ok := r.MultipartForm.Value["language"]; ok {
+			t.Errorf("translations should not send language")
+		}
+		w.Header().Set("content-type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("1\n00:00:00,000 --> 00:00:01,000\nhello\n"))
+	}))
+	defer srv.Close()
+
+	out, err := NewClient(WithBaseURL(srv.URL)).AudioTranslations(context.Background(), &AudioTranscriptionRequest{
+		Model:          "whisper-1",
+		File:           strings.NewReader("x"),
+		Language:       "fr",
+		ResponseFormat: AudioResponseSRT,
+	})
+	if err != nil {
+		t.Fatalf("AudioTranslations: %v", err)
+	}
+	if !strings.Contains(out.Text, "hello") {
+		t.Fatalf("text = %q", out.Text)
+	}
+}
@@ -0,0 +1,99 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Batch endpoints that a batch input file may target.
+const (
+	BatchEndpointChatCompletions = "/v1/chat/completions"
+	BatchEndpointEmbeddings      = "/v1/embeddings"
+	BatchEndpointResponses       = "/v1/responses"
+)
+
+// BatchCreateRequest is the body of `POST /v1/batches`. InputFileID refers to
+// a JSONL file uploaded with purpose "batch".
+type BatchCreateRequest struct {
+	InputFileID      string            `json:"input_file_id"`
+	Endpoint         string            `json:"endpoint"`
+	CompletionWindow string            `json:"completion_window"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// Batch is a batch job.
+type Batch struct {
+	ID               string            `json:"id"`
+	Object           string            `json:"object"`
+	Endpoint         string            `json:"endpoint"`
+	InputFileID      string            `json:"input_file_id"`
+	CompletionWindow string            `json:"completion_window"`
+	Status           string            `json:"status"`
+	OutputFileID     string            `json:"output_file_id,omitempty"`
+	ErrorFileID      string            `json:"error_file_id,omitempty"`
+	CreatedAt        int64             `json:"created_at"`
+	InProgressAt     int64             `json:"in_progress_at,omitempty"`
+	ExpiresAt        int64             `json:"expires_at,omitempty"`
+	CompletedAt      int64             `json:"completed_at,omitempty"`
+	FailedAt         int64             `json:"failed_at,omitempty"`
+	CancelledAt      int64             `json:"cancelled_at,omitempty"`
+	RequestCounts    *BatchCounts      `json:"request_counts,omitempty"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// BatchCounts summarizes request progress within a batch.
+type BatchCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+// Done reports whether the batch reached a terminal status.
+func (b *Batch) Done() bool {
+	switch b.Status {
+	case "completed", "failed", "expired", "cancelled":
+		return true
+	}
+	return false
+}
+
+// CreateBatch calls `POST /v1/batches`. CompletionWindow defaults to "24h".
+func (c *Client) CreateBatch(ctx context.Context, req *BatchCreateRequest, opts ...RequestOption) (*Batch, error) {
+	body := *req
+	if body.CompletionWindow == "" {
+		body.CompletionWindow = "24h"
+	}
+	var out Batch
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/batches", &body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RetrieveBatch calls `GET /v1/batches/{id}`.
+func (c *Client) RetrieveBatch(ctx context.Context, id string, opts ...RequestOption) (*Batch, error) {
+	var out Batch
+	if err := c.doJSON(ctx, http.MethodGet, "/v1/batches/"+url.PathEscape(id), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelBatch calls `POST /v1/batches/{id}/cancel`.
+func (c *Client) CancelBatch(ctx context.Context, id string, opts ...RequestOption) (*Batch, error) {
+	var out Batch
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/batches/"+url.PathEscape(id)+"/cancel", nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListBatches calls `GET /v1/batches`.
+func (c *Client) ListBatches(ctx context.Context, list *ListOptions, opts ...RequestOption) (*List[Batch], error) {
+	var out List[Batch]
+	if err := c.doJSON(ctx, http.MethodGet, withQuery("/v1/batches", list.query()), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
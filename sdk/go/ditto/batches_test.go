@@ -0,0 +1,60 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBatchesLifecycle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/batches":
+			var body BatchCreateRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body.CompletionWindow != "24h" || body.Endpoint != BatchEndpointChatCompletions {
+				t.Errorf("unexpected body: %+v", body)
+			}
+			_, _ = w.Write([]byte(`{"id":"batch_1","object":"batch","status":"validating","input_file_id":"file_1"}`))
+		case "GET /v1/batches/batch_1":
+			_, _ = w.Write([]byte(`{"id":"batch_1","status":"completed","output_file_id":"file_2","request_counts":{"total":2,"completed":2,"failed":0}}`))
+		case "POST /v1/batches/batch_1/cancel":
+			_, _ = w.Write([]byte(`{"id":"batch_1","status":"cancelling"}`))
+		case "GET /v1/batches":
+			if r.URL.RawQuery != "" && r.URL.RawQuery != "after=batch_0&limit=10" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"batch_1"}],"has_more":true,"last_id":"batch_1"}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL))
+
+	batch, err := c.CreateBatch(ctx, &BatchCreateRequest{InputFileID: "file_1", Endpoint: BatchEndpointChatCompletions})
+	if err != nil || batch.ID != "batch_1" || batch.Done() {
+		t.Fatalf("CreateBatch = %+v, %v", batch, err)
+	}
+	batch, err = c.RetrieveBatch(ctx, "batch_1")
+	if err != nil || !batch.Done() || batch.OutputFileID != "file_2" || batch.RequestCounts.Completed != 2 {
+		t.Fatalf("RetrieveBatch = %+v, %v", batch, err)
+	}
+	if batch, err = c.CancelBatch(ctx, "batch_1"); err != nil || batch.Status != "cancelling" {
+		t.Fatalf("CancelBatch = %+v, %v", batch, err)
+	}
+	page, err := c.ListBatches(ctx, &ListOptions{Limit: 10, After: "batch_0"})
+	if err != nil || len(page.Data) != 1 || !page.HasMore || page.LastID != "batch_1" {
+		t.Fatalf("ListBatches = %+v, %v", page, err)
+	}
+	if _, err := c.ListBatches(ctx, nil); err != nil {
+		t.Fatalf("ListBatches(nil): %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package ditto
+
+// Proxy cache headers. HeaderCacheBypass is sent by the client (any value
+// enables it); the others are set by the gateway on cache hits.
+const (
+	HeaderCacheBypass = "x-ditto-cache-bypass"
+	HeaderCache       = "x-ditto-cache"
+	HeaderCacheKey    = "x-ditto-cache-key"
+	HeaderCacheSource = "x-ditto-cache-source"
+)
+
+// WithCacheBypass skips the gateway proxy cache for one call: the response
+// is neither served from nor written to the cache.
+func WithCacheBypass() RequestOption {
+	return WithRequestHeader(HeaderCacheBypass, "1")
+}
+
+// CacheHit reports whether the gateway proxy cache served the response.
+// Streaming responses replayed from cache report a hit as well.
+func (m *ResponseMeta) CacheHit() bool {
+	return m.get(HeaderCache) == "hit"
+}
+
+// CacheKey returns the proxy cache key of a cached response, usable with
+// `POST /admin/proxy_cache/purge`.
+func (m *ResponseMeta) CacheKey() string {
+	return m.get(HeaderCacheKey)
+}
+
+// CacheSource returns `memory` or `redis` for a cache hit.
+func (m *ResponseMeta) CacheSource() string {
+	return m.get(HeaderCacheSource)
+}
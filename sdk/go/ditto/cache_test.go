@@ -0,0 +1,65 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCacheBypassAndHit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(HeaderCacheBypass) == "" {
+			w.Header().Set(HeaderCache, "hit")
+			w.Header().Set(HeaderCacheKey, "ditto-proxy-cache-v2-abc")
+			w.Header().Set(HeaderCacheSource, "redis")
+		}
+		_, _ = w.Write([]byte(`{"id":"c1","choices":[]}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL))
+	req := &ChatCompletionRequest{Model: "m", Messages: []ChatMessage{UserMessage("hi")}}
+
+	var hit ResponseMeta
+	if _, err := c.ChatCompletions(ctx, req, WithResponseMeta(&hit)); err != nil {
+		t.Fatalf("ChatCompletions: %v", err)
+	}
+	if !hit.CacheHit() || hit.CacheKey() != "ditto-proxy-cache-v2-abc" || hit.CacheSource() != "redis" {
+		t.Fatalf("hit meta = %+v", hit)
+	}
+
+	var miss ResponseMeta
+	if _, err := c.ChatCompletions(ctx, req, WithCacheBypass(), WithResponseMeta(&miss)); err != nil {
+		t.Fatalf("ChatCompletions: %v", err)
+	}
+	if miss.CacheHit() || miss.CacheKey() != "" {
+		t.Fatalf("bypass meta = %+v", miss)
+	}
+}
+
+func TestStreamCacheHit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		w.Header().Set(HeaderCache, "hit")
+		_, _ = w.Write([]byte("data: {\"id\":\"c1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"hi\"}}]}\n\ndata: [DONE]\n\n"))
+	}))
+	defer srv.Close()
+
+	var meta ResponseMeta
+	c := NewClient(WithBaseURL(srv.URL))
+	stream, err := c.ChatCompletionsStream(context.Background(), &ChatCompletionRequest{Model: "m"}, WithResponseMeta(&meta))
+	if err != nil {
+		t.Fatalf("ChatCompletionsStream: %v", err)
+	}
+	defer stream.Close()
+	if !meta.CacheHit() {
+		t.Fatal("expected cache hit before reading the stream")
+	}
+	for stream.Next() {
+	}
+	if err := stream.Err(); err != nil || stream.Response().FirstContent() != "hi" {
+		t.Fatalf("stream = %q, %v", stream.Response().FirstContent(), err)
+	}
+}
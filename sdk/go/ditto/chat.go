@@ -0,0 +1,201 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// Chat message roles.
+const (
+	RoleSystem    = "system"
+	RoleDeveloper = "developer"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+)
+
+// ChatMessage is one entry of a chat completions `messages` array, and the
+// `message` of a non-streaming choice.
+type ChatMessage struct {
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	Name       string     `json:"name,omitempty"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+	// ReasoningContent carries model reasoning preserved by gateway
+	// translations for providers that expose it.
+	ReasoningContent string `json:"reasoning_content,omitempty"`
+}
+
+// SystemMessage returns a system-role message.
+func SystemMessage(content string) ChatMessage {
+	return ChatMessage{Role: RoleSystem, Content: content}
+}
+
+// UserMessage returns a user-role message.
+func UserMessage(content string) ChatMessage {
+	return ChatMessage{Role: RoleUser, Content: content}
+}
+
+// AssistantMessage returns an assistant-role message.
+func AssistantMessage(content string) ChatMessage {
+	return ChatMessage{Role: RoleAssistant, Content: content}
+}
+
+// ToolMessage returns the result of the tool call identified by toolCallID.
+func ToolMessage(toolCallID, content string) ChatMessage {
+	return ChatMessage{Role: RoleTool, ToolCallID: toolCallID, Content: content}
+}
+
+// Tool declares a function the model may call.
+type Tool struct {
+	Type     string              `json:"type"`
+	Function *FunctionDefinition `json:"function,omitempty"`
+}
+
+// FunctionDefinition describes a callable function. Parameters is a JSON
+// Schema object.
+type FunctionDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+// FunctionTool returns a `{"type":"function"}` tool.
+func FunctionTool(name, description string, parameters json.RawMessage) Tool {
+	return Tool{
+		Type: "function",
+		Function: &FunctionDefinition{
+			Name:        name,
+			Description: description,
+			Parameters:  parameters,
+		},
+	}
+}
+
+// ToolCall is a function invocation requested by the model.
+type ToolCall struct {
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall holds the function name and its JSON-encoded arguments.
+type FunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
+}
+
+// ResponseFormat selects `text`, `json_object`, or `json_schema` output.
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// JSONSchema is the `json_schema` payload of a ResponseFormat.
+type JSONSchema struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+// ChatCompletionRequest is the body of `POST /v1/chat/completions`.
+//
+// Model may be a provider model id or a gateway model alias; the gateway
+// resolves it through the router configured for the virtual key.
+type ChatCompletionRequest struct {
+	Model               string          `json:"model"`
+	Messages            []ChatMessage   `json:"messages"`
+	Temperature         *float64        `json:"temperature,omitempty"`
+	TopP                *float64        `json:"top_p,omitempty"`
+	MaxTokens           *int            `json:"max_tokens,omitempty"`
+	MaxCompletionTokens *int            `json:"max_completion_tokens,omitempty"`
+	N                   *int            `json:"n,omitempty"`
+	Stop                []string        `json:"stop,omitempty"`
+	PresencePenalty     *float64        `json:"presence_penalty,omitempty"`
+	FrequencyPenalty    *float64        `json:"frequency_penalty,omitempty"`
+	Seed                *int64          `json:"seed,omitempty"`
+	User                string          `json:"user,omitempty"`
+	Tools               []Tool          `json:"tools,omitempty"`
+	ToolChoice          any             `json:"tool_choice,omitempty"`
+	ParallelToolCalls   *bool           `json:"parallel_tool_calls,omitempty"`
+	ResponseFormat      *ResponseFormat `json:"response_format,omitempty"`
+	Stream              bool            `json:"stream,omitempty"`
+	StreamOptions       *StreamOptions  `json:"stream_options,omitempty"`
+	// SessionID continues a gateway conversation session: send only the new
+	// messages and the gateway prepends the stored history (requires a
+	// gateway started with --sessions).
+	SessionID string `json:"session_id,omitempty"`
+}
+
+// ChatCompletionResponse is a non-streaming chat completions response.
+type ChatCompletionResponse struct {
+	ID                string                 `json:"id"`
+	Object            string                 `json:"object"`
+	Created           int64                  `json:"created"`
+	Model             string                 `json:"model"`
+	SystemFingerprint string                 `json:"system_fingerprint,omitempty"`
+	Choices           []ChatCompletionChoice `json:"choices"`
+	Usage             *Usage                 `json:"usage,omitempty"`
+}
+
+// ChatCompletionChoice is one generated alternative.
+type ChatCompletionChoice struct {
+	Index        int             `json:"index"`
+	Message      ChatMessage     `json:"message"`
+	FinishReason string          `json:"finish_reason"`
+	Logprobs     json.RawMessage `json:"logprobs,omitempty"`
+}
+
+// Usage reports token accounting for a completion.
+type Usage struct {
+	PromptTokens            int                      `json:"prompt_tokens"`
+	CompletionTokens        int                      `json:"completion_tokens"`
+	TotalTokens             int                      `json:"total_tokens"`
+	PromptTokensDetails     *PromptTokensDetails     `json:"prompt_tokens_details,omitempty"`
+	CompletionTokensDetails *CompletionTokensDetails `json:"completion_tokens_details,omitempty"`
+	// Cost is the gateway's USD price of the usage, when pricing is
+	// configured. Translated streams report it in the final usage chunk.
+	Cost float64 `json:"cost,omitempty"`
+}
+
+// PromptTokensDetails breaks down prompt tokens.
+type PromptTokensDetails struct {
+	CachedTokens int `json:"cached_tokens,omitempty"`
+}
+
+// CompletionTokensDetails breaks down completion tokens.
+type CompletionTokensDetails struct {
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
+}
+
+// FirstContent returns the content of the first choice, or "" when the
+// response has no choices.
+func (r *ChatCompletionResponse) FirstContent() string {
+	if r == nil || len(r.Choices) == 0 {
+		return ""
+	}
+	return r.Choices[0].Message.Content
+}
+
+// ChatCompletions calls `POST /v1/chat/completions` and waits for the full
+// response. req.Stream and req.StreamOptions are ignored; use
+// ChatCompletionsStream for SSE.
+func (c *Client) ChatCompletions(
+	ctx context.Context,
+	req *ChatCompletionRequest,
+	opts ...RequestOption,
+) (*ChatCompletionResponse, error) {
+	body := *req
+	body.Stream = false
+	body.StreamOptions = nil
+
+	var out ChatCompletionResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/chat/completions", &body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,275 @@
+package ditto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// StreamOptions is the `stream_options` field of a streaming request.
+type StreamOptions struct {
+	// IncludeUsage asks for a final chunk carrying Usage and no choices.
+	IncludeUsage bool `json:"include_usage,omitempty"`
+}
+
+// ChatCompletionChunk is one `chat.completion.chunk` SSE event.
+type ChatCompletionChunk struct {
+	ID                string                      `json:"id"`
+	Object            string                      `json:"object"`
+	Created           int64                       `json:"created"`
+	Model             string                      `json:"model"`
+	SystemFingerprint string                      `json:"system_fingerprint,omitempty"`
+	Choices           []ChatCompletionChunkChoice `json:"choices"`
+	Usage             *Usage                      `json:"usage,omitempty"`
+}
+
+// ChatCompletionChunkChoice is the per-choice delta of a chunk.
+type ChatCompletionChunkChoice struct {
+	Index        int                 `json:"index"`
+	Delta        ChatCompletionDelta `json:"delta"`
+	FinishReason string              `json:"finish_reason,omitempty"`
+	Logprobs     json.RawMessage     `json:"logprobs,omitempty"`
+}
+
+// ChatCompletionDelta holds the incremental message fields of a chunk.
+type ChatCompletionDelta struct {
+	Role             string          `json:"role,omitempty"`
+	Content          string          `json:"content,omitempty"`
+	ReasoningContent string          `json:"reasoning_content,omitempty"`
+	ToolCalls        []ToolCallDelta `json:"tool_calls,omitempty"`
+}
+
+// ToolCallDelta is a tool-call fragment. Fragments sharing an Index belong to
+// the same call: the first carries ID and name, later ones append Arguments.
+type ToolCallDelta struct {
+	Index    int          `json:"index"`
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	Function FunctionCall `json:"function"`
+}
+
+// ChatCompletionStream iterates the chunks of a streaming chat completion:
+//
+//	stream, err := client.ChatCompletionsStream(ctx, req)
+//	if err != nil { ... }
+//	defer stream.Close()
+//	for stream.Next() {
+//		fmt.Print(stream.Current().Choices[0].Delta.Content)
+//	}
+//	if err := stream.Err(); err != nil { ... }
+//	final := stream.Response()
+//
+// Every chunk is also folded into an accumulator, so Response returns the
+// assembled message (including tool calls) once iteration finishes.
+type ChatCompletionStream struct {
+	eventStream
+	current *ChatCompletionChunk
+	acc     ChatCompletionAccumulator
+}
+
+// ChatCompletionsStream calls `POST /v1/chat/completions` with `stream: true`
+// and returns a stream positioned before the first chunk. Cancelling ctx
+// aborts the stream and closes the upstream connection.
+func (c *Client) ChatCompletionsStream(
+	ctx context.Context,
+	req *ChatCompletionRequest,
+	opts ...RequestOption,
+) (*ChatCompletionStream, error) {
+	body := *req
+	body.Stream = true
+
+	resp, cancel, err := c.doStream(ctx, http.MethodPost, "/v1/chat/completions", &body, "text/event-stream", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ChatCompletionStream{eventStream: newEventStream(ctx, resp.Body, cancel)}, nil
+}
+
+// Next advances to the next chunk. It returns false at `[DONE]`, at end of
+// body, or on error; check Err afterwards.
+func (s *ChatCompletionStream) Next() bool {
+	s.current = nil
+	event, ok := s.nextEvent()
+	if !ok {
+		return false
+	}
+	if string(event.Data) == "[DONE]" {
+		s.finish(nil)
+		return false
+	}
+	if streamErr := parseStreamError(event.Data); streamErr != nil {
+		s.finish(streamErr)
+		return false
+	}
+
+	var chunk ChatCompletionChunk
+	if err := json.Unmarshal(event.Data, &chunk); err != nil {
+		s.finish(fmt.Errorf("ditto: decode stream chunk: %w", err))
+		return false
+	}
+	s.acc.Add(&chunk)
+	s.current = &chunk
+	return true
+}
+
+// Current returns the chunk read by the last successful Next.
+func (s *ChatCompletionStream) Current() *ChatCompletionChunk {
+	return s.current
+}
+
+// Response returns the message assembled from every chunk seen so far.
+func (s *ChatCompletionStream) Response() *ChatCompletionResponse {
+	return s.acc.Response()
+}
+
+// StreamError is an error event sent inside an otherwise successful stream,
+// e.g. when the upstream fails after the gateway has started responding.
+type StreamError struct {
+	Type    string
+	Code    string
+	Message string
+}
+
+func (e *StreamError) Error() string {
+	if e.Type != "" {
+		return fmt.Sprintf("ditto: stream error %s: %s", e.Type, e.Message)
+	}
+	return "ditto: stream error: " + e.Message
+}
+
+func parseStreamError(data []byte) *StreamError {
+	if !bytes.Contains(data, []byte(`"error"`)) {
+		return nil
+	}
+	var envelope errorEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Error == nil {
+		return nil
+	}
+	return &StreamError{
+		Type:    envelope.Error.Type,
+		Code:    rawCode(envelope.Error.Code),
+		Message: envelope.Error.Message,
+	}
+}
+
+// ChatCompletionAccumulator folds streamed chunks back into a
+// ChatCompletionResponse. The zero value is ready to use.
+type ChatCompletionAccumulator struct {
+	id                string
+	model             string
+	created           int64
+	systemFingerprint string
+	usage             *Usage
+	choices           map[int]*accumulatedChoice
+}
+
+type accumulatedChoice struct {
+	role         string
+	content      strings.Builder
+	reasoning    strings.Builder
+	finishReason string
+	toolCalls    map[int]*ToolCall
+}
+
+// Add merges one chunk.
+func (a *ChatCompletionAccumulator) Add(chunk *ChatCompletionChunk) {
+	if chunk.ID != "" {
+		a.id = chunk.ID
+	}
+	if chunk.Model != "" {
+		a.model = chunk.Model
+	}
+	if chunk.Created != 0 {
+		a.created = chunk.Created
+	}
+	if chunk.SystemFingerprint != "" {
+		a.systemFingerprint = chunk.SystemFingerprint
+	}
+	if chunk.Usage != nil {
+		a.usage = chunk.Usage
+	}
+	if a.choices == nil {
+		a.choices = make(map[int]*accumulatedChoice)
+	}
+
+	for _, choice := range chunk.Choices {
+		acc := a.choices[choice.Index]
+		if acc == nil {
+			acc = &accumulatedChoice{toolCalls: make(map[int]*ToolCall)}
+			a.choices[choice.Index] = acc
+		}
+		if choice.Delta.Role != "" {
+			acc.role = choice.Delta.Role
+		}
+		acc.content.WriteString(choice.Delta.Content)
+		acc.reasoning.WriteString(choice.Delta.ReasoningContent)
+		if choice.FinishReason != "" {
+			acc.finishReason = choice.FinishReason
+		}
+		for _, delta := range choice.Delta.ToolCalls {
+			call := acc.toolCalls[delta.Index]
+			if call == nil {
+				call = &ToolCall{Type: "function"}
+				acc.toolCalls[delta.Index] = call
+			}
+			if delta.ID != "" {
+				call.ID = delta.ID
+			}
+			if delta.Type != "" {
+				call.Type = delta.Type
+			}
+			if delta.Function.Name != "" {
+				call.Function.Name = delta.Function.Name
+			}
+			call.Function.Arguments += delta.Function.Arguments
+		}
+	}
+}
+
+// Response returns the assembled response. Choices are ordered by index and
+// tool calls by their stream index.
+func (a *ChatCompletionAccumulator) Response() *ChatCompletionResponse {
+	resp := &ChatCompletionResponse{
+		ID:                a.id,
+		Object:            "chat.completion",
+		Created:           a.created,
+		Model:             a.model,
+		SystemFingerprint: a.systemFingerprint,
+		Usage:             a.usage,
+		Choices:           []ChatCompletionChoice{},
+	}
+	for _, index := range sortedKeys(a.choices) {
+		acc := a.choices[index]
+		role := acc.role
+		if role == "" {
+			role = RoleAssistant
+		}
+		msg := ChatMessage{
+			Role:             role,
+			Content:          acc.content.String(),
+			ReasoningContent: acc.reasoning.String(),
+		}
+		for _, callIndex := range sortedKeys(acc.toolCalls) {
+			msg.ToolCalls = append(msg.ToolCalls, *acc.toolCalls[callIndex])
+		}
+		resp.Choices = append(resp.Choices, ChatCompletionChoice{
+			Index:        index,
+			Message:      msg,
+			FinishReason: acc.finishReason,
+		})
+	}
+	return resp
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
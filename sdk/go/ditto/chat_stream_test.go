@@ -0,0 +1,151 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func sseServer(t *testing.T, events ...string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if body["stream"] != true {
+			t.Errorf("stream = %v", body["stream"])
+		}
+		if got := r.Header.Get("accept"); got != "text/event-stream" {
+			t.Errorf("accept = %q", got)
+		}
+		w.Header().Set("content-type", "text/event-stream")
+		for _, event := range events {
+			fmt.Fprintf(w, "data: %s\n\n", event)
+			w.(http.Flusher).Flush()
+		}
+	}))
+}
+
+func TestChatCompletionsStreamAccumulatesContentAndToolCalls(t *testing.T) {
+	srv := sseServer(t,
+		`{"id":"c1","model":"m","created":1,"choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"}}]}`,
+		`{"id":"c1","choices":[{"index":0,"delta":{"content":"lo"}}]}`,
+		`{"id":"c1","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"lookup","arguments":"{\"q\":"}}]}}]}`,
+		`{"id":"c1","choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","function":{"name":"other","arguments":"{}"}}]}}]}`,
+		`{"id":"c1","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"go\"}"}}]}}]}`,
+		`{"id":"c1","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+		`{"id":"c1","choices":[],"usage":{"prompt_tokens":3,"completion_tokens":5,"total_tokens":8,"cost":0.0002}}`,
+		`[DONE]`,
+		`{"id":"ignored","choices":[{"index":0,"delta":{"content":"after done"}}]}`,
+	)
+	defer srv.Close()
+
+	stream, err := NewClient(WithBaseURL(srv.URL)).ChatCompletionsStream(context.Background(), &ChatCompletionRequest{
+		Model:         "m",
+		Messages:      []ChatMessage{UserMessage("hi")},
+		StreamOptions: &StreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		t.Fatalf("ChatCompletionsStream: %v", err)
+	}
+	defer stream.Close()
+
+	var chunks int
+	for stream.Next() {
+		chunks++
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	if chunks != 7 {
+		t.Fatalf("chunks = %d, want 7", chunks)
+	}
+
+	resp := stream.Response()
+	if resp.ID != "c1" || resp.FirstContent() != "Hello" || resp.Choices[0].FinishReason != "tool_calls" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	calls := resp.Choices[0].Message.ToolCalls
+	if len(calls) != 2 || calls[0].ID != "call_1" || calls[0].Function.Arguments != `{"q":"go"}` || calls[1].Function.Name != "other" {
+		t.Fatalf("unexpected tool calls: %+v", calls)
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens != 8 || resp.Usage.Cost != 0.0002 {
+		t.Fatalf("unexpected usage: %+v", resp.Usage)
+	}
+}
+
+func TestChatCompletionsStreamSurfacesInlineError(t *testing.T) {
+	srv := sseServer(t,
+		`{"id":"c1","choices":[{"index":0,"delta":{"content":"partial"}}]}`,
+		`{"error":{"message":"upstream reset","type":"api_error"}}`,
+	)
+	defer srv.Close()
+
+	stream, err := NewClient(WithBaseURL(srv.URL)).ChatCompletionsStream(context.Background(), &ChatCompletionRequest{Model: "m"})
+	if err != nil {
+		t.Fatalf("ChatCompletionsStream: %v", err)
+	}
+	defer stream.Close()
+
+	for stream.Next() {
+	}
+	var streamErr *StreamError
+	if !errors.As(stream.Err(), &streamErr) || streamErr.Message != "upstream reset" {
+		t.Fatalf("expected StreamError, got %v", stream.Err())
+	}
+	if stream.Response().FirstContent() != "partial" {
+		t.Fatalf("partial content should be kept")
+	}
+}
+
+func TestChatCompletionsStreamHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"invalid virtual key","type":"authentication_error"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(WithBaseURL(srv.URL)).ChatCompletionsStream(context.Background(), &ChatCompletionRequest{Model: "m"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401 APIError, got %v", err)
+	}
+}
+
+func TestChatCompletionsStreamContextCancel(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"a\"}}]}\n\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := NewClient(WithBaseURL(srv.URL)).ChatCompletionsStream(ctx, &ChatCompletionRequest{Model: "m"})
+	if err != nil {
+		t.Fatalf("ChatCompletionsStream: %v", err)
+	}
+	defer stream.Close()
+
+	if !stream.Next() {
+		t.Fatalf("expected first chunk, err=%v", stream.Err())
+	}
+	cancel()
+	if stream.Next() {
+		t.Fatalf("expected stream to stop after cancel")
+	}
+	if !errors.Is(stream.Err(), context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", stream.Err())
+	}
+}
@@ -0,0 +1,83 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChatCompletions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["model"] != "gpt-4o-mini" {
+			t.Errorf("model = %v", body["model"])
+		}
+		if _, ok := body["stream"]; ok {
+			t.Errorf("stream should be omitted, got %v", body["stream"])
+		}
+		if body["temperature"] != 0.2 {
+			t.Errorf("temperature = %v", body["temperature"])
+		}
+		if _, ok := body["top_p"]; ok {
+			t.Errorf("unset optional fields should be omitted")
+		}
+		if body["session_id"] != "chat-1" {
+			t.Errorf("session_id = %v", body["session_id"])
+		}
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "chatcmpl-1",
+			"object": "chat.completion",
+			"created": 1700000000,
+			"model": "gpt-4o-mini",
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "Hello!"}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 9, "completion_tokens": 2, "total_tokens": 11, "prompt_tokens_details": {"cached_tokens": 4}}
+		}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithToken("vk"))
+	resp, err := c.ChatCompletions(context.Background(), &ChatCompletionRequest{
+		Model:       "gpt-4o-mini",
+		Messages:    []ChatMessage{UserMessage("hi")},
+		Temperature: Ptr(0.2),
+		Stream:      true,
+		SessionID:   "chat-1",
+	})
+	if err != nil {
+		t.Fatalf("ChatCompletions: %v", err)
+	}
+	if resp.FirstContent() != "Hello!" || resp.Choices[0].FinishReason != "stop" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if resp.Usage == nil || resp.Usage.TotalTokens != 11 || resp.Usage.PromptTokensDetails.CachedTokens != 4 {
+		t.Fatalf("unexpected usage: %+v", resp.Usage)
+	}
+}
+
+func TestChatMessageToolCallRoundTrip(t *testing.T) {
+	raw := `{"role":"assistant","content":"","tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}]}`
+	var msg ChatMessage
+	if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(msg.ToolCalls) != 1 || msg.ToolCalls[0].Function.Name != "get_weather" {
+		t.Fatalf("unexpected tool calls: %+v", msg.ToolCalls)
+	}
+
+	out, err := json.Marshal(ToolMessage("call_1", `{"temp_c":21}`))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(out) != `{"role":"tool","content":"{\"temp_c\":21}","tool_call_id":"call_1"}` {
+		t.Fatalf("tool message = %s", out)
+	}
+}
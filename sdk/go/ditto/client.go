@@ -0,0 +1,362 @@
+package ditto
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/omne42/ditto-llm/sdk/go/ditto/plugin"
+)
+
+const (
+	// DefaultBaseURL is the address `ditto-gateway` listens on by default.
+	DefaultBaseURL = "http://127.0.0.1:8080"
+	// DefaultTimeout bounds non-streaming calls when no timeout is configured.
+	DefaultTimeout = 60 * time.Second
+
+	// HeaderRequestID is the request correlation header understood by the gateway.
+	HeaderRequestID = "x-request-id"
+
+	envBaseURL = "DITTO_BASE_URL"
+	envToken   = "DITTO_VK_TOKEN"
+)
+
+// Client calls a `ditto-gateway` deployment. A Client is safe for concurrent
+// use; configure it once with Option values and share it.
+type Client struct {
+	baseURL    string
+	token      string
+	timeout    time.Duration
+	httpClient *http.Client
+	header     http.Header
+	auth       plugin.AuthProvider
+	router     plugin.Router
+	loggers    []plugin.Logger
+	guardrails []plugin.Guardrail
+
+	retry            RetryPolicy
+	baseURLs         []string
+	loadBalancing    LoadBalancing
+	endpointCooldown time.Duration
+	endpoints        *endpointPool
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL sets the gateway root URL (without the `/v1` suffix).
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = normalizeBaseURL(baseURL)
+		c.baseURLs = nil
+	}
+}
+
+// WithToken sets the virtual key sent as `Authorization: Bearer <token>`.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithTimeout bounds every non-streaming call. Zero disables the bound.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithHTTPClient replaces the underlying *http.Client, e.g. to tune transports.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithHeader adds a header to every request sent by the client.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// NewClient builds a Client. Without options it targets DefaultBaseURL with
+// no token.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:          DefaultBaseURL,
+		timeout:          DefaultTimeout,
+		httpClient:       http.DefaultClient,
+		header:           make(http.Header),
+		endpointCooldown: DefaultEndpointCooldown,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if len(c.baseURLs) > 1 {
+		c.endpoints = newEndpointPool(c.baseURLs, c.loadBalancing, c.endpointCooldown)
+	}
+	return c
+}
+
+// NewClientFromEnv builds a Client from DITTO_BASE_URL and DITTO_VK_TOKEN,
+// then applies opts on top. DITTO_BASE_URL may list several comma-separated
+// URLs, as with WithBaseURLs.
+func NewClientFromEnv(opts ...Option) *Client {
+	var envOpts []Option
+	if baseURL := os.Getenv(envBaseURL); strings.Contains(baseURL, ",") {
+		envOpts = append(envOpts, WithBaseURLs(strings.Split(baseURL, ",")...))
+	} else if baseURL != "" {
+		envOpts = append(envOpts, WithBaseURL(baseURL))
+	}
+	if token := os.Getenv(envToken); token != "" {
+		envOpts = append(envOpts, WithToken(token))
+	}
+	return NewClient(append(envOpts, opts...)...)
+}
+
+// BaseURL returns the normalized gateway root URL, the first one when
+// several are set with WithBaseURLs.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// RequestOption adjusts a single call.
+type RequestOption func(*requestConfig)
+
+type requestConfig struct {
+	header  http.Header
+	timeout *time.Duration
+	meta    *ResponseMeta
+	retry   *RetryPolicy
+}
+
+// WithRequestID sets the `x-request-id` header for one call so it can be
+// correlated with gateway logs and audit records.
+func WithRequestID(id string) RequestOption {
+	return func(rc *requestConfig) {
+		rc.header.Set(HeaderRequestID, id)
+	}
+}
+
+// WithRequestHeader sets an extra header for one call.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(rc *requestConfig) {
+		rc.header.Set(key, value)
+	}
+}
+
+// WithRequestTimeout overrides the client timeout for one call.
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(rc *requestConfig) {
+		rc.timeout = &timeout
+	}
+}
+
+// NewRequestID returns a random id suitable for WithRequestID.
+func NewRequestID() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return fmt.Sprintf("go-%d", time.Now().UnixNano())
+	}
+	return fmt.Sprintf("go-%d-%s", time.Now().UnixMilli(), hex.EncodeToString(buf[:]))
+}
+
+// Ptr returns a pointer to v, for optional request fields.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+func normalizeBaseURL(baseURL string) string {
+	return strings.TrimRight(strings.TrimSpace(baseURL), "/")
+}
+
+func (c *Client) newRequestConfig(opts []RequestOption) *requestConfig {
+	rc := &requestConfig{header: make(http.Header)}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	return rc
+}
+
+func (c *Client) newRequest(
+	ctx context.Context,
+	method, url string,
+	body io.Reader,
+	rc *requestConfig,
+) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("ditto: build request: %w", err)
+	}
+	for key, values := range c.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if c.token != "" {
+		req.Header.Set("authorization", "Bearer "+c.token)
+	}
+	for key, values := range rc.header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	if c.auth != nil {
+		if err := c.auth.Authorize(ctx, req); err != nil {
+			return nil, fmt.Errorf("ditto: authorize request: %w", err)
+		}
+	}
+	return req, nil
+}
+
+// doJSON sends in as a JSON body (nil for none) and decodes a 2xx JSON
+// response into out (nil to discard).
+func (c *Client) doJSON(
+	ctx context.Context,
+	method, path string,
+	in, out any,
+	opts []RequestOption,
+) error {
+	var (
+		body        io.Reader
+		contentType string
+	)
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("ditto: encode request: %w", err)
+		}
+		if err := c.checkRequest(ctx, plugin.Call{Method: method, Path: path}, payload); err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+		contentType = "application/json"
+	}
+
+	resp, err := c.doRaw(ctx, method, path, body, contentType, opts)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	return decodeJSON(resp.body, out)
+}
+
+// decodeJSON decodes a response body into out; an empty body is a no-op.
+func decodeJSON(body []byte, out any) error {
+	if len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("ditto: decode response: %w", err)
+	}
+	return nil
+}
+
+// rawResponse is a fully read 2xx response.
+type rawResponse struct {
+	header http.Header
+	body   []byte
+}
+
+// doRaw sends body with the given content type (empty for none) and returns
+// the fully read 2xx response; other statuses become *APIError. The client
+// timeout covers the upload as well as the response.
+func (c *Client) doRaw(
+	ctx context.Context,
+	method, path string,
+	body io.Reader,
+	contentType string,
+	opts []RequestOption,
+) (*rawResponse, error) {
+	rc := c.newRequestConfig(opts)
+	timeout := c.timeout
+	if rc.timeout != nil {
+		timeout = *rc.timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	resp, cancelAttempt, err := c.send(ctx, method, path, body, rc, func(req *http.Request) {
+		if contentType != "" {
+			req.Header.Set("content-type", contentType)
+		}
+		if req.Header.Get("accept") == "" {
+			req.Header.Set("accept", "application/json")
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cancelAttempt()
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ditto: read response: %w", err)
+	}
+	if err := c.checkResponse(ctx, plugin.Call{Method: method, Path: path}, respBody); err != nil {
+		return nil, err
+	}
+	return &rawResponse{header: resp.Header, body: respBody}, nil
+}
+
+// doStream sends in as a JSON body (nil for none) and returns the 2xx
+// streaming response. The caller owns resp.Body and must call the returned
+// cancel func once done.
+func (c *Client) doStream(
+	ctx context.Context,
+	method, path string,
+	in any,
+	accept string,
+	opts []RequestOption,
+) (*http.Response, context.CancelFunc, error) {
+	rc := c.newRequestConfig(opts)
+	// Client-wide timeouts would cut long generations short, so streams are
+	// only bounded by ctx or an explicit WithRequestTimeout.
+	cancel := context.CancelFunc(func() {})
+	if rc.timeout != nil && *rc.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, *rc.timeout)
+	}
+
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			cancel()
+			return nil, nil, fmt.Errorf("ditto: encode request: %w", err)
+		}
+		if err := c.checkRequest(ctx, plugin.Call{Method: method, Path: path}, payload); err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		body = bytes.NewReader(payload)
+	}
+	resp, cancelAttempt, err := c.send(ctx, method, path, body, rc, func(req *http.Request) {
+		if in != nil {
+			req.Header.Set("content-type", "application/json")
+		}
+		req.Header.Set("accept", accept)
+	})
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return resp, func() {
+		cancelAttempt()
+		cancel()
+	}, nil
+}
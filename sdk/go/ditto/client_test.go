@@ -0,0 +1,99 @@
+package ditto
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientFromEnvReadsBaseURLAndToken(t *testing.T) {
+	t.Setenv(envBaseURL, "http://gateway.internal:9000//")
+	t.Setenv(envToken, "vk-env")
+
+	c := NewClientFromEnv()
+	if got := c.BaseURL(); got != "http://gateway.internal:9000" {
+		t.Fatalf("BaseURL() = %q", got)
+	}
+	if c.token != "vk-env" {
+		t.Fatalf("token = %q", c.token)
+	}
+
+	c = NewClientFromEnv(WithToken("vk-override"))
+	if c.token != "vk-override" {
+		t.Fatalf("explicit option should win over env, got %q", c.token)
+	}
+}
+
+func TestRequestHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("authorization"); got != "Bearer vk-test" {
+			t.Errorf("authorization = %q", got)
+		}
+		if got := r.Header.Get("x-team"); got != "search" {
+			t.Errorf("x-team = %q", got)
+		}
+		if got := r.Header.Get(HeaderRequestID); got != "req-1" {
+			t.Errorf("x-request-id = %q", got)
+		}
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithToken("vk-test"), WithHeader("x-team", "search"))
+	if err := c.doJSON(context.Background(), http.MethodGet, "/v1/anything", nil, nil, []RequestOption{WithRequestID("req-1")}); err != nil {
+		t.Fatalf("doJSON: %v", err)
+	}
+}
+
+func TestAPIErrorParsesOpenAIEnvelope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderRequestID, "req-429")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"message":"rate limit exceeded","type":"rate_limit_error","code":"rate_limited"}}`))
+	}))
+	defer srv.Close()
+
+	err := NewClient(WithBaseURL(srv.URL)).doJSON(context.Background(), http.MethodGet, "/v1/x", nil, nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Type != "rate_limit_error" ||
+		apiErr.Code != "rate_limited" || apiErr.Message != "rate limit exceeded" || apiErr.RequestID != "req-429" {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+}
+
+func TestAPIErrorFallsBackToBodyText(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream exploded", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := NewClient(WithBaseURL(srv.URL)).doJSON(context.Background(), http.MethodGet, "/v1/x", nil, nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "upstream exploded" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(apiErr.Error(), "HTTP 502") {
+		t.Fatalf("Error() = %q", apiErr.Error())
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithTimeout(time.Hour))
+	err := c.doJSON(context.Background(), http.MethodGet, "/v1/x", nil, nil, []RequestOption{WithRequestTimeout(20 * time.Millisecond)})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
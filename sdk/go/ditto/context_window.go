@@ -0,0 +1,53 @@
+package ditto
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Context-window headers, set by the gateway when `guardrails.context_window`
+// trimmed the request to fit the model's context window.
+const (
+	HeaderContextStrategy        = "x-ditto-context-strategy"
+	HeaderContextRemovedMessages = "x-ditto-context-removed-messages"
+)
+
+// History-compression headers, set by the gateway when
+// `guardrails.history_compression` summarized older turns of the request.
+const (
+	HeaderHistoryCompressedMessages = "x-ditto-history-compressed-messages"
+	HeaderHistorySavedTokens        = "x-ditto-history-saved-tokens"
+)
+
+// ContextStrategy returns the strategy the gateway applied to fit the
+// request (ContextStrategyDropOldest or ContextStrategySummarizeMiddle), or
+// "" when the request was forwarded unchanged.
+func (m *ResponseMeta) ContextStrategy() string {
+	return m.get(HeaderContextStrategy)
+}
+
+// ContextRemovedMessages returns how many messages the gateway dropped or
+// summarized to fit the request, or 0 when none were.
+func (m *ResponseMeta) ContextRemovedMessages() int {
+	return m.headerInt(HeaderContextRemovedMessages)
+}
+
+// HistoryCompressedMessages returns how many messages the gateway replaced
+// with a summary, or 0 when the history was forwarded unchanged.
+func (m *ResponseMeta) HistoryCompressedMessages() int {
+	return m.headerInt(HeaderHistoryCompressedMessages)
+}
+
+// HistorySavedTokens returns the gateway's estimate of the input tokens the
+// summary saved, or 0 when nothing was compressed.
+func (m *ResponseMeta) HistorySavedTokens() int {
+	return m.headerInt(HeaderHistorySavedTokens)
+}
+
+func (m *ResponseMeta) headerInt(name string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(m.get(name)))
+	if err != nil {
+		return 0
+	}
+	return n
+}
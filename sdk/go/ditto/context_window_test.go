@@ -0,0 +1,32 @@
+package ditto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestResponseMetaContextStrategy(t *testing.T) {
+	meta := &ResponseMeta{Header: http.Header{}}
+	if meta.ContextStrategy() != "" || meta.ContextRemovedMessages() != 0 {
+		t.Fatalf("strategy = %q, removed = %d", meta.ContextStrategy(), meta.ContextRemovedMessages())
+	}
+
+	meta.Header.Set(HeaderContextStrategy, ContextStrategySummarizeMiddle)
+	meta.Header.Set(HeaderContextRemovedMessages, "4")
+	if meta.ContextStrategy() != ContextStrategySummarizeMiddle || meta.ContextRemovedMessages() != 4 {
+		t.Fatalf("strategy = %q, removed = %d", meta.ContextStrategy(), meta.ContextRemovedMessages())
+	}
+}
+
+func TestResponseMetaHistoryCompression(t *testing.T) {
+	meta := &ResponseMeta{Header: http.Header{}}
+	if meta.HistoryCompressedMessages() != 0 || meta.HistorySavedTokens() != 0 {
+		t.Fatalf("compressed = %d, saved = %d", meta.HistoryCompressedMessages(), meta.HistorySavedTokens())
+	}
+
+	meta.Header.Set(HeaderHistoryCompressedMessages, "6")
+	meta.Header.Set(HeaderHistorySavedTokens, "1830")
+	if meta.HistoryCompressedMessages() != 6 || meta.HistorySavedTokens() != 1830 {
+		t.Fatalf("compressed = %d, saved = %d", meta.HistoryCompressedMessages(), meta.HistorySavedTokens())
+	}
+}
@@ -0,0 +1,20 @@
+package ditto
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HeaderCost carries the USD cost the gateway charged for the request; it
+// is set when the gateway has a pricing table for the model.
+const HeaderCost = "x-ditto-cost"
+
+// Cost returns the USD cost the gateway charged for the request, or false
+// when the response carried none (no pricing, or a passthrough stream).
+func (m *ResponseMeta) Cost() (float64, bool) {
+	cost, err := strconv.ParseFloat(strings.TrimSpace(m.get(HeaderCost)), 64)
+	if err != nil {
+		return 0, false
+	}
+	return cost, true
+}
@@ -0,0 +1,18 @@
+package ditto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestResponseMetaCost(t *testing.T) {
+	meta := &ResponseMeta{Header: http.Header{}}
+	if cost, ok := meta.Cost(); ok {
+		t.Fatalf("cost = %v, want none", cost)
+	}
+
+	meta.Header.Set(HeaderCost, "0.080000")
+	if cost, ok := meta.Cost(); !ok || cost != 0.08 {
+		t.Fatalf("cost = %v, %v", cost, ok)
+	}
+}
@@ -0,0 +1,17 @@
+// Package ditto is a typed Go client for the ditto-gateway HTTP surface.
+//
+// The client speaks the OpenAI-compatible `/v1/*` endpoints exposed by
+// `ditto-gateway` and authenticates with a virtual key:
+//
+//	client := ditto.NewClient(
+//		ditto.WithBaseURL("http://127.0.0.1:8080"),
+//		ditto.WithToken(os.Getenv("DITTO_VK_TOKEN")),
+//	)
+//	resp, err := client.ChatCompletions(ctx, &ditto.ChatCompletionRequest{
+//		Model:    "gpt-4o-mini",
+//		Messages: []ditto.ChatMessage{ditto.UserMessage("Say hello in one sentence.")},
+//	}, ditto.WithRequestID("go-example"))
+//
+// Non-2xx responses are returned as *APIError, which carries the parsed
+// OpenAI-style error envelope and the gateway request id.
+package ditto
@@ -0,0 +1,118 @@
+package ditto
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// EmbeddingRequest is the body of `POST /v1/embeddings`. Model may be a
+// gateway alias; embeddings share routing, spend tracking, and rate limits
+// with chat for the same virtual key.
+type EmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+	// EncodingFormat is "float" (default) or "base64". Both decode into
+	// Embedding.Vector.
+	EncodingFormat string `json:"encoding_format,omitempty"`
+	Dimensions     *int   `json:"dimensions,omitempty"`
+	User           string `json:"user,omitempty"`
+}
+
+// EmbeddingResponse is the response of `POST /v1/embeddings`.
+type EmbeddingResponse struct {
+	Object string           `json:"object"`
+	Model  string           `json:"model"`
+	Data   []Embedding      `json:"data"`
+	Usage  *EmbeddingsUsage `json:"usage,omitempty"`
+}
+
+// EmbeddingsUsage reports prompt tokens consumed by an embeddings call.
+type EmbeddingsUsage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+// Embedding is one input's vector. Index matches the position in
+// EmbeddingRequest.Input.
+type Embedding struct {
+	Object string
+	Index  int
+	Vector []float64
+}
+
+// UnmarshalJSON accepts `embedding` as a float array or as a base64 string of
+// little-endian float32 values.
+func (e *Embedding) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Object    string          `json:"object"`
+		Index     int             `json:"index"`
+		Embedding json.RawMessage `json:"embedding"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	e.Object = raw.Object
+	e.Index = raw.Index
+	e.Vector = nil
+
+	if len(raw.Embedding) == 0 || string(raw.Embedding) == "null" {
+		return nil
+	}
+	if raw.Embedding[0] != '"' {
+		return json.Unmarshal(raw.Embedding, &e.Vector)
+	}
+
+	var encoded string
+	if err := json.Unmarshal(raw.Embedding, &encoded); err != nil {
+		return err
+	}
+	buf, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("ditto: decode base64 embedding: %w", err)
+	}
+	if len(buf)%4 != 0 {
+		return fmt.Errorf("ditto: base64 embedding has %d bytes, not a multiple of 4", len(buf))
+	}
+	e.Vector = make([]float64, len(buf)/4)
+	for i := range e.Vector {
+		e.Vector[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:])))
+	}
+	return nil
+}
+
+// MarshalJSON encodes the embedding in the float-array wire shape.
+func (e Embedding) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Object    string    `json:"object,omitempty"`
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	}{e.Object, e.Index, e.Vector})
+}
+
+// Vectors returns the embeddings ordered by Index.
+func (r *EmbeddingResponse) Vectors() [][]float64 {
+	if r == nil {
+		return nil
+	}
+	out := make([][]float64, len(r.Data))
+	for _, item := range r.Data {
+		if item.Index >= 0 && item.Index < len(out) {
+			out[item.Index] = item.Vector
+		}
+	}
+	return out
+}
+
+// Embeddings calls `POST /v1/embeddings`.
+func (c *Client) Embeddings(ctx context.Context, req *EmbeddingRequest, opts ...RequestOption) (*EmbeddingResponse, error) {
+	var out EmbeddingResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/embeddings", req, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
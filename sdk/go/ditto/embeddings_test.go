@@ -0,0 +1,66 @@
+package ditto
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEmbeddings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var body EmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body.Model != "text-embedding-3-small" || len(body.Input) != 2 {
+			t.Errorf("unexpected body: %+v", body)
+		}
+		_, _ = w.Write([]byte(`{"object":"list","model":"text-embedding-3-small","data":[
+			{"object":"embedding","index":1,"embedding":[0.5,0.25]},
+			{"object":"embedding","index":0,"embedding":[1,2]}
+		],"usage":{"prompt_tokens":4,"total_tokens":4}}`))
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(WithBaseURL(srv.URL)).Embeddings(context.Background(), &EmbeddingRequest{
+		Model: "text-embedding-3-small",
+		Input: []string{"a", "b"},
+	})
+	if err != nil {
+		t.Fatalf("Embeddings: %v", err)
+	}
+	vectors := resp.Vectors()
+	if len(vectors) != 2 || vectors[0][1] != 2 || vectors[1][0] != 0.5 {
+		t.Fatalf("unexpected vectors: %v", vectors)
+	}
+	if resp.Usage.PromptTokens != 4 {
+		t.Fatalf("unexpected usage: %+v", resp.Usage)
+	}
+}
+
+func TestEmbeddingDecodesBase64(t *testing.T) {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(1.5))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(-2))
+	raw := `{"object":"embedding","index":0,"embedding":"` + base64.StdEncoding.EncodeToString(buf) + `"}`
+
+	var e Embedding
+	if err := json.Unmarshal([]byte(raw), &e); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(e.Vector) != 2 || e.Vector[0] != 1.5 || e.Vector[1] != -2 {
+		t.Fatalf("vector = %v", e.Vector)
+	}
+
+	if err := json.Unmarshal([]byte(`{"embedding":"AAA="}`), &e); err == nil {
+		t.Fatalf("expected error for truncated base64 payload")
+	}
+}
@@ -0,0 +1,161 @@
+package ditto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIError is returned for non-2xx gateway responses. Gateway and upstream
+// failures use the OpenAI error envelope
+// `{"error":{"message","type","param","code"}}`; when the body does not match,
+// Message holds the raw body text.
+type APIError struct {
+	StatusCode int
+	Type       string
+	Code       string
+	// Param names the request field the error is about (for example `model`
+	// with ErrCodeModelNotAllowed), if any.
+	Param   string
+	Message string
+	// RequestID is the `x-request-id` echoed by the gateway, if any.
+	RequestID string
+	// RetryAfter is the `retry-after` delay of a 429 or 503, if one was sent.
+	RetryAfter time.Duration
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "ditto: HTTP %d", e.StatusCode)
+	if e.Type != "" {
+		fmt.Fprintf(&b, " %s", e.Type)
+	}
+	if e.Code != "" {
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " [request_id=%s]", e.RequestID)
+	}
+	return b.String()
+}
+
+type errorEnvelope struct {
+	Error *struct {
+		Message string          `json:"message"`
+		Type    string          `json:"type"`
+		Param   *string         `json:"param"`
+		Code    json.RawMessage `json:"code"`
+	} `json:"error"`
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(HeaderRequestID),
+		RetryAfter: parseRetryAfter(resp.Header, time.Now()),
+		Body:       body,
+	}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error != nil {
+		apiErr.Message = envelope.Error.Message
+		apiErr.Type = envelope.Error.Type
+		apiErr.Code = rawCode(envelope.Error.Code)
+		if envelope.Error.Param != nil {
+			apiErr.Param = *envelope.Error.Param
+		}
+		return apiErr
+	}
+
+	apiErr.Message = strings.TrimSpace(string(body))
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	return apiErr
+}
+
+// rawCode accepts both string and numeric `code` values; upstream providers
+// are not consistent about which one they send.
+func rawCode(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// Gateway error codes reported in APIError.Code.
+const (
+	ErrCodeRateLimited        = "rate_limited"
+	ErrCodeBudgetExceeded     = "budget_exceeded"
+	ErrCodeCostBudgetExceeded = "cost_budget_exceeded"
+	// ErrCodeInflightLimit and ErrCodeInflightLimitBackend are 429s from
+	// concurrency backpressure (`--proxy-max-in-flight`, or every candidate
+	// backend at `max_in_flight`). The gateway rejects them at once rather
+	// than queueing, so they clear as soon as in-flight requests finish.
+	ErrCodeInflightLimit        = "inflight_limit"
+	ErrCodeInflightLimitBackend = "inflight_limit_backend"
+	// ErrCodeIPNotAllowed and ErrCodeOriginNotAllowed are 403s from a key's
+	// AllowedIPs or AllowedOrigins.
+	ErrCodeIPNotAllowed     = "ip_not_allowed"
+	ErrCodeOriginNotAllowed = "origin_not_allowed"
+	// ErrCodeGuardrailBlocked is a 403 from a key's Guardrails, including
+	// hooks (message `hook:<name>`), moderation and prompt injection.
+	// Streaming hooks report it in an error event instead.
+	ErrCodeGuardrailBlocked = "guardrail_blocked"
+	// ErrCodeModelNotAllowed is a 403 because the requested model is outside
+	// the key's allow list or on its deny list; Param is `model`.
+	ErrCodeModelNotAllowed = "model_not_allowed"
+	// Deprecated: gateways before stable error codes reported every guardrail
+	// block as guardrail_rejected; use IsGuardrailBlocked.
+	ErrCodeGuardrailRejected = "guardrail_rejected"
+)
+
+// IsGuardrailBlocked reports whether err is a gateway policy rejection (403):
+// a guardrail block or a model the key may not use.
+func IsGuardrailBlocked(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case ErrCodeGuardrailBlocked, ErrCodeModelNotAllowed, ErrCodeGuardrailRejected:
+		return true
+	}
+	return false
+}
+
+// IsRateLimited reports whether err is a 429: a gateway RPM/TPM rejection
+// (ErrCodeRateLimited), in-flight backpressure, or an upstream rate limit
+// passed through. Unlike a
+// budget rejection it is retryable; RetryAfter carries the upstream hint when
+// one was sent.
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsBudgetExceeded reports whether err is a gateway rejection (402) because a
+// token or USD budget of the virtual key, or of its tenant/project/user, is
+// spent.
+func IsBudgetExceeded(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Code == ErrCodeBudgetExceeded || apiErr.Code == ErrCodeCostBudgetExceeded
+}
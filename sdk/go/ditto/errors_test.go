@@ -0,0 +1,52 @@
+package ditto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsBudgetExceeded(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		_, _ = w.Write([]byte(`{"error":{"message":"cost budget exceeded: limit_usd_micros=1 attempted_usd_micros=2","type":"insufficient_quota","code":"cost_budget_exceeded"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(WithBaseURL(srv.URL)).ChatCompletions(context.Background(), &ChatCompletionRequest{Model: "m"})
+	if !IsBudgetExceeded(err) {
+		t.Fatalf("IsBudgetExceeded(%v) = false", err)
+	}
+	if !IsBudgetExceeded(fmt.Errorf("wrapped: %w", err)) {
+		t.Fatal("wrapped error should match")
+	}
+	if IsBudgetExceeded(&APIError{StatusCode: http.StatusTooManyRequests, Code: "rate_limited"}) || IsBudgetExceeded(nil) {
+		t.Fatal("unexpected match")
+	}
+}
+
+func TestIsGuardrailBlocked(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"message":"model_not_allowed:o1","type":"policy_error","param":"model","code":"model_not_allowed"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(WithBaseURL(srv.URL)).ChatCompletions(context.Background(), &ChatCompletionRequest{Model: "o1"})
+	if !IsGuardrailBlocked(err) {
+		t.Fatalf("IsGuardrailBlocked(%v) = false", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrCodeModelNotAllowed || apiErr.Param != "model" || apiErr.Type != "policy_error" {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+	if !IsGuardrailBlocked(&APIError{StatusCode: http.StatusForbidden, Code: ErrCodeGuardrailBlocked}) {
+		t.Fatal("guardrail_blocked should match")
+	}
+	if IsGuardrailBlocked(&APIError{StatusCode: http.StatusForbidden, Code: ErrCodeIPNotAllowed}) || IsGuardrailBlocked(nil) {
+		t.Fatal("unexpected match")
+	}
+}
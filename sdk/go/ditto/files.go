@@ -0,0 +1,147 @@
+package ditto
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// File purposes.
+const (
+	FilePurposeBatch      = "batch"
+	FilePurposeFineTune   = "fine-tune"
+	FilePurposeAssistants = "assistants"
+	FilePurposeVision     = "vision"
+	FilePurposeUserData   = "user_data"
+)
+
+// FileUploadRequest is the multipart body of `POST /v1/files`. File is
+// streamed to the gateway as it is read.
+type FileUploadRequest struct {
+	File     io.Reader
+	FileName string
+	Purpose  string
+}
+
+// FileObject describes an uploaded file.
+type FileObject struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	Bytes     int64  `json:"bytes"`
+	CreatedAt int64  `json:"created_at"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+	Filename  string `json:"filename"`
+	Purpose   string `json:"purpose"`
+	Status    string `json:"status,omitempty"`
+}
+
+// FileDeleted is the response of `DELETE /v1/files/{id}`.
+type FileDeleted struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
+
+// FileListOptions filters `GET /v1/files`.
+type FileListOptions struct {
+	ListOptions
+	Purpose string
+	// Order is "asc" or "desc" by created_at.
+	Order string
+}
+
+// UploadFile calls `POST /v1/files`. The body is sent chunked, so the gateway
+// buffers it up to `--proxy-max-body-bytes` (64 MiB by default) and rejects
+// larger uploads with a 413 *APIError.
+func (c *Client) UploadFile(ctx context.Context, req *FileUploadRequest, opts ...RequestOption) (*FileObject, error) {
+	if req.File == nil {
+		return nil, errors.New("ditto: file upload requires File")
+	}
+	if req.Purpose == "" {
+		return nil, errors.New("ditto: file upload requires Purpose")
+	}
+	filename := req.FileName
+	if filename == "" {
+		filename = "file"
+	}
+
+	body, contentType := streamMultipart(func(mw *multipart.Writer) error {
+		if err := writeFields(mw, [][2]string{{"purpose", req.Purpose}}); err != nil {
+			return err
+		}
+		return writeFile(mw, "file", filename, req.File)
+	})
+	defer body.Close()
+
+	var out FileObject
+	resp, err := c.doRaw(ctx, http.MethodPost, "/v1/files", body, contentType, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeJSON(resp.body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListFiles calls `GET /v1/files`.
+func (c *Client) ListFiles(ctx context.Context, list *FileListOptions, opts ...RequestOption) (*List[FileObject], error) {
+	q := url.Values{}
+	if list != nil {
+		q = list.ListOptions.query()
+		if list.Purpose != "" {
+			q.Set("purpose", list.Purpose)
+		}
+		if list.Order != "" {
+			q.Set("order", list.Order)
+		}
+	}
+	var out List[FileObject]
+	if err := c.doJSON(ctx, http.MethodGet, withQuery("/v1/files", q), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RetrieveFile calls `GET /v1/files/{id}`.
+func (c *Client) RetrieveFile(ctx context.Context, id string, opts ...RequestOption) (*FileObject, error) {
+	var out FileObject
+	if err := c.doJSON(ctx, http.MethodGet, "/v1/files/"+url.PathEscape(id), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteFile calls `DELETE /v1/files/{id}`.
+func (c *Client) DeleteFile(ctx context.Context, id string, opts ...RequestOption) (*FileDeleted, error) {
+	var out FileDeleted
+	if err := c.doJSON(ctx, http.MethodDelete, "/v1/files/"+url.PathEscape(id), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// FileContent calls `GET /v1/files/{id}/content` and returns the body as a
+// stream (e.g. batch output JSONL). The caller must Close it.
+func (c *Client) FileContent(ctx context.Context, id string, opts ...RequestOption) (io.ReadCloser, error) {
+	resp, cancel, err := c.doStream(ctx, http.MethodGet, "/v1/files/"+url.PathEscape(id)+"/content", nil, "*/*", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &cancelReadCloser{ReadCloser: resp.Body, cancel: cancel}, nil
+}
+
+// cancelReadCloser releases the request context when the body is closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.cancel()
+	return err
+}
@@ -0,0 +1,106 @@
+package ditto
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFilesLifecycle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/files":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("parse multipart: %v", err)
+			}
+			if got := r.FormValue("purpose"); got != FilePurposeBatch {
+				t.Errorf("purpose = %q", got)
+			}
+			f, header, err := r.FormFile("file")
+			if err != nil {
+				t.Fatalf("form file: %v", err)
+			}
+			data, _ := io.ReadAll(f)
+			if header.Filename != "input.jsonl" || string(data) != "{}\n" {
+				t.Errorf("file %q = %q", header.Filename, data)
+			}
+			_, _ = w.Write([]byte(`{"id":"file_1","object":"file","bytes":3,"filename":"input.jsonl","purpose":"batch"}`))
+		case "GET /v1/files":
+			if r.URL.RawQuery != "" && r.URL.RawQuery != "limit=5&order=desc&purpose=batch" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"file_1"}],"has_more":false}`))
+		case "GET /v1/files/file_1":
+			_, _ = w.Write([]byte(`{"id":"file_1","bytes":3,"status":"processed"}`))
+		case "GET /v1/files/file_1/content":
+			w.Header().Set("content-type", "application/octet-stream")
+			_, _ = w.Write([]byte("line1\nline2\n"))
+		case "DELETE /v1/files/file_1":
+			_, _ = w.Write([]byte(`{"id":"file_1","object":"file","deleted":true}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL))
+
+	file, err := c.UploadFile(ctx, &FileUploadRequest{
+		File:     strings.NewReader("{}\n"),
+		FileName: "input.jsonl",
+		Purpose:  FilePurposeBatch,
+	})
+	if err != nil || file.ID != "file_1" || file.Bytes != 3 {
+		t.Fatalf("UploadFile = %+v, %v", file, err)
+	}
+	page, err := c.ListFiles(ctx, &FileListOptions{ListOptions: ListOptions{Limit: 5}, Purpose: FilePurposeBatch, Order: "desc"})
+	if err != nil || len(page.Data) != 1 || page.HasMore {
+		t.Fatalf("ListFiles = %+v, %v", page, err)
+	}
+	if _, err := c.ListFiles(ctx, nil); err != nil {
+		t.Fatalf("ListFiles(nil): %v", err)
+	}
+	if file, err = c.RetrieveFile(ctx, "file_1"); err != nil || file.Status != "processed" {
+		t.Fatalf("RetrieveFile = %+v, %v", file, err)
+	}
+
+	content, err := c.FileContent(ctx, "file_1")
+	if err != nil {
+		t.Fatalf("FileContent: %v", err)
+	}
+	data, err := io.ReadAll(content)
+	_ = content.Close()
+	if err != nil || string(data) != "line1\nline2\n" {
+		t.Fatalf("content = %q, %v", data, err)
+	}
+
+	deleted, err := c.DeleteFile(ctx, "file_1")
+	if err != nil || !deleted.Deleted {
+		t.Fatalf("DeleteFile = %+v, %v", deleted, err)
+	}
+}
+
+func TestUploadFileRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"length limit exceeded","type":"invalid_request_error"}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	_, err := c.UploadFile(context.Background(), &FileUploadRequest{File: strings.NewReader("x"), Purpose: FilePurposeBatch})
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("err = %v", err)
+	}
+	if _, err := c.UploadFile(context.Background(), &FileUploadRequest{Purpose: FilePurposeBatch}); err == nil {
+		t.Fatal("expected error without File")
+	}
+}
@@ -0,0 +1,89 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// FineTuningJobCreateRequest is the body of `POST /v1/fine_tuning/jobs`.
+// TrainingFile and ValidationFile refer to JSONL files uploaded with purpose
+// "fine-tune". Method carries the provider's tuning method and
+// hyperparameters as-is.
+type FineTuningJobCreateRequest struct {
+	Model          string            `json:"model"`
+	TrainingFile   string            `json:"training_file"`
+	ValidationFile string            `json:"validation_file,omitempty"`
+	Suffix         string            `json:"suffix,omitempty"`
+	Seed           *int              `json:"seed,omitempty"`
+	Method         json.RawMessage   `json:"method,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+}
+
+// FineTuningJob is a fine-tuning job. Through the gateway a virtual key only
+// sees the jobs created by keys of its own project or tenant.
+type FineTuningJob struct {
+	ID              string            `json:"id"`
+	Object          string            `json:"object"`
+	Model           string            `json:"model"`
+	FineTunedModel  string            `json:"fine_tuned_model,omitempty"`
+	Status          string            `json:"status"`
+	TrainingFile    string            `json:"training_file"`
+	ValidationFile  string            `json:"validation_file,omitempty"`
+	ResultFiles     []string          `json:"result_files,omitempty"`
+	TrainedTokens   int64             `json:"trained_tokens,omitempty"`
+	CreatedAt       int64             `json:"created_at"`
+	FinishedAt      int64             `json:"finished_at,omitempty"`
+	EstimatedFinish int64             `json:"estimated_finish,omitempty"`
+	Error           json.RawMessage   `json:"error,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+// Done reports whether the job reached a terminal status.
+func (j *FineTuningJob) Done() bool {
+	switch j.Status {
+	case "succeeded", "failed", "cancelled":
+		return true
+	}
+	return false
+}
+
+// CreateFineTuningJob calls `POST /v1/fine_tuning/jobs`. The key needs the
+// gateway's fine_tuning permission.
+func (c *Client) CreateFineTuningJob(ctx context.Context, req *FineTuningJobCreateRequest, opts ...RequestOption) (*FineTuningJob, error) {
+	var out FineTuningJob
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/fine_tuning/jobs", req, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RetrieveFineTuningJob calls `GET /v1/fine_tuning/jobs/{id}`.
+func (c *Client) RetrieveFineTuningJob(ctx context.Context, id string, opts ...RequestOption) (*FineTuningJob, error) {
+	var out FineTuningJob
+	if err := c.doJSON(ctx, http.MethodGet, "/v1/fine_tuning/jobs/"+url.PathEscape(id), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CancelFineTuningJob calls `POST /v1/fine_tuning/jobs/{id}/cancel`.
+func (c *Client) CancelFineTuningJob(ctx context.Context, id string, opts ...RequestOption) (*FineTuningJob, error) {
+	var out FineTuningJob
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/fine_tuning/jobs/"+url.PathEscape(id)+"/cancel", nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListFineTuningJobs calls `GET /v1/fine_tuning/jobs`. The gateway drops the
+// jobs of other owners, so a page may hold fewer than Limit jobs even when
+// HasMore is set.
+func (c *Client) ListFineTuningJobs(ctx context.Context, list *ListOptions, opts ...RequestOption) (*List[FineTuningJob], error) {
+	var out List[FineTuningJob]
+	if err := c.doJSON(ctx, http.MethodGet, withQuery("/v1/fine_tuning/jobs", list.query()), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
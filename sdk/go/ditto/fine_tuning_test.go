@@ -0,0 +1,60 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFineTuningJobsLifecycle(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/fine_tuning/jobs":
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body["model"] != "gpt-4o-mini-2024-07-18" || body["training_file"] != "file_1" {
+				t.Errorf("unexpected body: %v", body)
+			}
+			if _, ok := body["validation_file"]; ok {
+				t.Errorf("empty validation_file sent: %v", body)
+			}
+			_, _ = w.Write([]byte(`{"id":"ftjob-1","object":"fine_tuning.job","status":"queued","training_file":"file_1"}`))
+		case "GET /v1/fine_tuning/jobs/ftjob-1":
+			_, _ = w.Write([]byte(`{"id":"ftjob-1","status":"succeeded","fine_tuned_model":"ft:gpt-4o-mini:acme::1","result_files":["file_2"]}`))
+		case "POST /v1/fine_tuning/jobs/ftjob-1/cancel":
+			_, _ = w.Write([]byte(`{"id":"ftjob-1","status":"cancelled"}`))
+		case "GET /v1/fine_tuning/jobs":
+			if r.URL.RawQuery != "limit=5" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"ftjob-1","status":"running"}],"has_more":true}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL))
+
+	job, err := c.CreateFineTuningJob(ctx, &FineTuningJobCreateRequest{Model: "gpt-4o-mini-2024-07-18", TrainingFile: "file_1"})
+	if err != nil || job.ID != "ftjob-1" || job.Done() {
+		t.Fatalf("CreateFineTuningJob = %+v, %v", job, err)
+	}
+	job, err = c.RetrieveFineTuningJob(ctx, "ftjob-1")
+	if err != nil || !job.Done() || job.FineTunedModel != "ft:gpt-4o-mini:acme::1" || len(job.ResultFiles) != 1 {
+		t.Fatalf("RetrieveFineTuningJob = %+v, %v", job, err)
+	}
+	if job, err = c.CancelFineTuningJob(ctx, "ftjob-1"); err != nil || job.Status != "cancelled" {
+		t.Fatalf("CancelFineTuningJob = %+v, %v", job, err)
+	}
+	page, err := c.ListFineTuningJobs(ctx, &ListOptions{Limit: 5})
+	if err != nil || len(page.Data) != 1 || !page.HasMore {
+		t.Fatalf("ListFineTuningJobs = %+v, %v", page, err)
+	}
+}
@@ -0,0 +1,92 @@
+package ditto
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Image sizes accepted by OpenAI-style image models. Which sizes a routed
+// provider accepts depends on that provider.
+const (
+	ImageSize256       = "256x256"
+	ImageSize512       = "512x512"
+	ImageSize1024      = "1024x1024"
+	ImageSize1024x1536 = "1024x1536"
+	ImageSize1536x1024 = "1536x1024"
+	ImageSize1792x1024 = "1792x1024"
+	ImageSize1024x1792 = "1024x1792"
+	ImageSizeAuto      = "auto"
+)
+
+// Image quality levels.
+const (
+	ImageQualityStandard = "standard"
+	ImageQualityHD       = "hd"
+	ImageQualityLow      = "low"
+	ImageQualityMedium   = "medium"
+	ImageQualityHigh     = "high"
+	ImageQualityAuto     = "auto"
+)
+
+// Image response formats.
+const (
+	ImageResponseURL     = "url"
+	ImageResponseB64JSON = "b64_json"
+)
+
+// ImageGenerationRequest is the body of `POST /v1/images/generations`.
+type ImageGenerationRequest struct {
+	Model          string `json:"model"`
+	Prompt         string `json:"prompt"`
+	N              *int   `json:"n,omitempty"`
+	Size           string `json:"size,omitempty"`
+	Quality        string `json:"quality,omitempty"`
+	Style          string `json:"style,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+	Background     string `json:"background,omitempty"`
+	OutputFormat   string `json:"output_format,omitempty"`
+	User           string `json:"user,omitempty"`
+}
+
+// ImageResponse is the response of an image generation call.
+type ImageResponse struct {
+	Created int64       `json:"created"`
+	Data    []ImageData `json:"data"`
+	Usage   *struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+		TotalTokens  int `json:"total_tokens"`
+	} `json:"usage,omitempty"`
+}
+
+// ImageData is one generated image, as a URL or base64 payload.
+type ImageData struct {
+	URL           string `json:"url,omitempty"`
+	B64JSON       string `json:"b64_json,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+}
+
+// Bytes decodes B64JSON. It fails for URL-only results, which must be
+// downloaded by the caller.
+func (d *ImageData) Bytes() ([]byte, error) {
+	if d.B64JSON == "" {
+		return nil, errors.New("ditto: image has no b64_json payload")
+	}
+	buf, err := base64.StdEncoding.DecodeString(d.B64JSON)
+	if err != nil {
+		return nil, fmt.Errorf("ditto: decode image: %w", err)
+	}
+	return buf, nil
+}
+
+// ImagesGenerate calls `POST /v1/images/generations`.
+func (c *Client) ImagesGenerate(ctx context.Context, req *ImageGenerationRequest, opts ...RequestOption) (*ImageResponse, error) {
+	var out ImageResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/images/generations", req, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,49 @@
+package ditto
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestImagesGenerate(t *testing.T) {
+	png := []byte("\x89PNG fake")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/images/generations" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body["size"] != ImageSize1024 || body["quality"] != ImageQualityHD || body["response_format"] != ImageResponseB64JSON {
+			t.Errorf("unexpected body: %v", body)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"created": 1,
+			"data":    []map[string]any{{"b64_json": base64.StdEncoding.EncodeToString(png), "revised_prompt": "a cat"}},
+		})
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(WithBaseURL(srv.URL)).ImagesGenerate(context.Background(), &ImageGenerationRequest{
+		Model:          "dall-e-3",
+		Prompt:         "cat",
+		Size:           ImageSize1024,
+		Quality:        ImageQualityHD,
+		ResponseFormat: ImageResponseB64JSON,
+	})
+	if err != nil {
+		t.Fatalf("ImagesGenerate: %v", err)
+	}
+	got, err := resp.Data[0].Bytes()
+	if err != nil || string(got) != string(png) {
+		t.Fatalf("Bytes() = %q, %v", got, err)
+	}
+	if _, err := (&ImageData{URL: "https://example.com/a.png"}).Bytes(); err == nil {
+		t.Fatalf("expected error for URL-only image")
+	}
+}
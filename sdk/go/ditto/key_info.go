@@ -0,0 +1,84 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// KeyInfo is the response of `GET /v1/key/info`: the calling virtual key's
+// own limits and budgets. Models is the key's allow-list; empty means any
+// model the key routes to.
+type KeyInfo struct {
+	KeyID      string         `json:"key_id"`
+	TenantID   string         `json:"tenant_id,omitempty"`
+	ProjectID  string         `json:"project_id,omitempty"`
+	UserID     string         `json:"user_id,omitempty"`
+	Models     []string       `json:"models"`
+	Route      string         `json:"route,omitempty"`
+	RateLimits []KeyRateLimit `json:"rate_limits"`
+	Budgets    []KeyBudget    `json:"budgets"`
+}
+
+// KeyRateLimit is one scope's ("key", "tenant", "project" or "user")
+// per-minute limits. The Remaining fields are what is left this minute; they
+// are nil when the limit is unset or counted in Redis.
+type KeyRateLimit struct {
+	Scope             string  `json:"scope"`
+	RPM               *uint32 `json:"rpm,omitempty"`
+	TPM               *uint32 `json:"tpm,omitempty"`
+	RemainingRequests *uint32 `json:"remaining_requests,omitempty"`
+	RemainingTokens   *uint32 `json:"remaining_tokens,omitempty"`
+}
+
+// KeyBudget is one scope's budget in its current window. Totals include
+// rollover; nil totals are unlimited.
+type KeyBudget struct {
+	Scope                   string  `json:"scope"`
+	TotalTokens             *uint64 `json:"total_tokens,omitempty"`
+	SpentTokens             uint64  `json:"spent_tokens"`
+	RemainingTokens         *uint64 `json:"remaining_tokens,omitempty"`
+	TotalUSDMicros          *uint64 `json:"total_usd_micros,omitempty"`
+	SpentUSDMicros          uint64  `json:"spent_usd_micros"`
+	RemainingUSDMicros      *uint64 `json:"remaining_usd_micros,omitempty"`
+	WindowStartEpochSeconds uint64  `json:"window_start_epoch_seconds,omitempty"`
+}
+
+// KeyUsage is the response of `GET /v1/key/usage`: the calling key's spend
+// since SinceMs, with Daily holding one row per UTC day and model (Group).
+type KeyUsage struct {
+	KeyID          string     `json:"key_id"`
+	SinceMs        uint64     `json:"since_ts_ms"`
+	Requests       uint64     `json:"requests"`
+	InputTokens    uint64     `json:"input_tokens"`
+	OutputTokens   uint64     `json:"output_tokens"`
+	SpentTokens    uint64     `json:"spent_tokens"`
+	SpentUSDMicros uint64     `json:"spent_usd_micros"`
+	Daily          []SpendRow `json:"daily"`
+	Truncated      bool       `json:"truncated"`
+}
+
+// KeyInfo calls `GET /v1/key/info` with the client's own virtual key; no
+// admin token is needed.
+func (c *Client) KeyInfo(ctx context.Context, opts ...RequestOption) (*KeyInfo, error) {
+	var out KeyInfo
+	if err := c.doJSON(ctx, http.MethodGet, "/v1/key/info", nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// KeyUsage calls `GET /v1/key/usage` for the last days UTC days, today
+// included (zero uses the gateway default of 7). It needs a gateway store.
+func (c *Client) KeyUsage(ctx context.Context, days int, opts ...RequestOption) (*KeyUsage, error) {
+	q := url.Values{}
+	if days > 0 {
+		q.Set("days", strconv.Itoa(days))
+	}
+	var out KeyUsage
+	if err := c.doJSON(ctx, http.MethodGet, withQuery("/v1/key/usage", q), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
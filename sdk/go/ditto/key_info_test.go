@@ -0,0 +1,48 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKeyInfoAndUsage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer vk-1" {
+			t.Errorf("authorization = %q", got)
+		}
+		switch r.URL.Path {
+		case "/v1/key/info":
+			_, _ = w.Write([]byte(`{"key_id":"key-1","tenant_id":"acme","models":["gpt-4o-mini"],"rate_limits":[{"scope":"key","rpm":5,"remaining_requests":4}],"budgets":[{"scope":"tenant","total_tokens":1000,"spent_tokens":250,"remaining_tokens":750,"spent_usd_micros":0}]}`))
+		case "/v1/key/usage":
+			if r.URL.RawQuery != "days=30" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"key_id":"key-1","since_ts_ms":86400000,"requests":2,"spent_tokens":6,"daily":[{"group":"gpt-4o-mini","bucket_start_ms":86400000,"requests":2,"input_tokens":2,"output_tokens":4,"spent_tokens":6,"spent_usd_micros":0}],"truncated":false}`))
+		default:
+			t.Errorf("unexpected %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL), WithToken("vk-1"))
+
+	info, err := c.KeyInfo(ctx)
+	if err != nil || info.KeyID != "key-1" || len(info.Models) != 1 {
+		t.Fatalf("KeyInfo = %+v, %v", info, err)
+	}
+	if limit := info.RateLimits[0]; *limit.RPM != 5 || *limit.RemainingRequests != 4 || limit.TPM != nil {
+		t.Fatalf("rate limit = %+v", limit)
+	}
+	if budget := info.Budgets[0]; budget.Scope != "tenant" || *budget.RemainingTokens != 750 || budget.TotalUSDMicros != nil {
+		t.Fatalf("budget = %+v", budget)
+	}
+
+	usage, err := c.KeyUsage(ctx, 30)
+	if err != nil || usage.SpentTokens != 6 || len(usage.Daily) != 1 || *usage.Daily[0].Group != "gpt-4o-mini" {
+		t.Fatalf("KeyUsage = %+v, %v", usage, err)
+	}
+}
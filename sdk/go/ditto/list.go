@@ -0,0 +1,45 @@
+package ditto
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// List is an OpenAI-style cursor page (`{"object":"list","data":[...]}`).
+type List[T any] struct {
+	Object  string `json:"object"`
+	Data    []T    `json:"data"`
+	FirstID string `json:"first_id,omitempty"`
+	LastID  string `json:"last_id,omitempty"`
+	HasMore bool   `json:"has_more,omitempty"`
+}
+
+// ListOptions are the cursor parameters shared by list endpoints.
+type ListOptions struct {
+	// Limit caps the page size; zero uses the upstream default.
+	Limit int
+	// After is the id of the last item of the previous page.
+	After string
+}
+
+func (o *ListOptions) query() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.After != "" {
+		q.Set("after", o.After)
+	}
+	return q
+}
+
+// withQuery appends q to path when it is non-empty.
+func withQuery(path string, q url.Values) string {
+	if len(q) == 0 {
+		return path
+	}
+	return path + "?" + q.Encode()
+}
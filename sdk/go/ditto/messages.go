@@ -0,0 +1,178 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// AnthropicVersion is sent as `anthropic-version` on Messages calls. The
+// gateway translates the request itself, but the header keeps the same
+// client usable against Anthropic-native upstreams behind a passthrough route.
+const AnthropicVersion = "2023-06-01"
+
+// Content block types used by the Messages API.
+const (
+	ContentBlockText       = "text"
+	ContentBlockImage      = "image"
+	ContentBlockToolUse    = "tool_use"
+	ContentBlockToolResult = "tool_result"
+	ContentBlockThinking   = "thinking"
+)
+
+// ContentBlock is one Anthropic content block. Only the fields relevant to
+// Type are populated.
+type ContentBlock struct {
+	Type string `json:"type"`
+
+	// text
+	Text string `json:"text,omitempty"`
+
+	// image
+	Source *ImageSource `json:"source,omitempty"`
+
+	// tool_use
+	ID    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
+
+	// tool_result
+	ToolUseID string         `json:"tool_use_id,omitempty"`
+	Content   []ContentBlock `json:"content,omitempty"`
+	IsError   bool           `json:"is_error,omitempty"`
+
+	// thinking
+	Thinking  string `json:"thinking,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// ImageSource is the `source` of an image block: either base64 data with a
+// media type, or a URL.
+type ImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// TextBlock returns a text content block.
+func TextBlock(text string) ContentBlock {
+	return ContentBlock{Type: ContentBlockText, Text: text}
+}
+
+// ToolResultBlock returns the result of the tool_use block toolUseID.
+func ToolResultBlock(toolUseID, content string, isError bool) ContentBlock {
+	return ContentBlock{
+		Type:      ContentBlockToolResult,
+		ToolUseID: toolUseID,
+		Content:   []ContentBlock{TextBlock(content)},
+		IsError:   isError,
+	}
+}
+
+// MessageParam is one entry of a Messages `messages` array.
+type MessageParam struct {
+	Role    string         `json:"role"`
+	Content []ContentBlock `json:"content"`
+}
+
+// UserMessageParam returns a user turn made of text.
+func UserMessageParam(text string) MessageParam {
+	return MessageParam{Role: RoleUser, Content: []ContentBlock{TextBlock(text)}}
+}
+
+// MessageTool declares a client tool for the Messages API.
+type MessageTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+// MessageToolChoice is `auto`, `any`, `none`, or `tool` (with Name).
+type MessageToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+// MessagesRequest is the body of `POST /v1/messages`. The gateway converts it
+// to chat completions, so it shares routing, virtual-key budgets, and rate
+// limits with the OpenAI-compatible surface.
+type MessagesRequest struct {
+	Model         string             `json:"model"`
+	MaxTokens     int                `json:"max_tokens"`
+	Messages      []MessageParam     `json:"messages"`
+	System        []ContentBlock     `json:"system,omitempty"`
+	Temperature   *float64           `json:"temperature,omitempty"`
+	TopP          *float64           `json:"top_p,omitempty"`
+	TopK          *int               `json:"top_k,omitempty"`
+	StopSequences []string           `json:"stop_sequences,omitempty"`
+	Tools         []MessageTool      `json:"tools,omitempty"`
+	ToolChoice    *MessageToolChoice `json:"tool_choice,omitempty"`
+	Stream        bool               `json:"stream,omitempty"`
+}
+
+// Message is a Messages API response (`type: "message"`).
+type Message struct {
+	ID           string         `json:"id"`
+	Type         string         `json:"type"`
+	Role         string         `json:"role"`
+	Model        string         `json:"model"`
+	Content      []ContentBlock `json:"content"`
+	StopReason   string         `json:"stop_reason,omitempty"`
+	StopSequence string         `json:"stop_sequence,omitempty"`
+	Usage        MessageUsage   `json:"usage"`
+}
+
+// MessageUsage reports token accounting for a Messages response.
+type MessageUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Text concatenates the text blocks of the message.
+func (m *Message) Text() string {
+	if m == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, block := range m.Content {
+		if block.Type == ContentBlockText {
+			b.WriteString(block.Text)
+		}
+	}
+	return b.String()
+}
+
+// MessageTokenCount is the response of `POST /v1/messages/count_tokens`.
+type MessageTokenCount struct {
+	InputTokens int `json:"input_tokens"`
+}
+
+// Messages calls the gateway's Anthropic-compatible `POST /v1/messages` and
+// waits for the full response. req.Stream is ignored; use MessagesStream.
+func (c *Client) Messages(ctx context.Context, req *MessagesRequest, opts ...RequestOption) (*Message, error) {
+	body := *req
+	body.Stream = false
+
+	var out Message
+	opts = append([]RequestOption{WithRequestHeader("anthropic-version", AnthropicVersion)}, opts...)
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/messages", &body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CountMessageTokens calls `POST /v1/messages/count_tokens`. The gateway's
+// count is a best-effort estimate.
+func (c *Client) CountMessageTokens(ctx context.Context, req *MessagesRequest, opts ...RequestOption) (*MessageTokenCount, error) {
+	body := *req
+	body.Stream = false
+
+	var out MessageTokenCount
+	opts = append([]RequestOption{WithRequestHeader("anthropic-version", AnthropicVersion)}, opts...)
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/messages/count_tokens", &body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
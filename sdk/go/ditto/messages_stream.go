@@ -0,0 +1,193 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Messages stream event types.
+const (
+	MessageEventStart             = "message_start"
+	MessageEventDelta             = "message_delta"
+	MessageEventStop              = "message_stop"
+	MessageEventContentBlockStart = "content_block_start"
+	MessageEventContentBlockDelta = "content_block_delta"
+	MessageEventContentBlockStop  = "content_block_stop"
+	MessageEventPing              = "ping"
+	MessageEventError             = "error"
+)
+
+// MessageStreamEvent is one event of a streaming Messages response.
+type MessageStreamEvent struct {
+	Type         string              `json:"type"`
+	Message      *Message            `json:"message,omitempty"`
+	Index        int                 `json:"index"`
+	ContentBlock *ContentBlock       `json:"content_block,omitempty"`
+	Delta        *MessageStreamDelta `json:"delta,omitempty"`
+	Usage        *MessageUsage       `json:"usage,omitempty"`
+	Error        *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// MessageStreamDelta is the `delta` of content_block_delta (text, partial
+// tool input JSON, thinking) and message_delta (stop reason) events.
+type MessageStreamDelta struct {
+	Type         string `json:"type,omitempty"`
+	Text         string `json:"text,omitempty"`
+	PartialJSON  string `json:"partial_json,omitempty"`
+	Thinking     string `json:"thinking,omitempty"`
+	Signature    string `json:"signature,omitempty"`
+	StopReason   string `json:"stop_reason,omitempty"`
+	StopSequence string `json:"stop_sequence,omitempty"`
+}
+
+// MessageStream iterates the events of a streaming Messages response and
+// assembles the final Message. `ping` events are skipped.
+type MessageStream struct {
+	eventStream
+	current *MessageStreamEvent
+	message Message
+	inputs  map[int]*strings.Builder
+}
+
+// MessagesStream calls `POST /v1/messages` with `stream: true`.
+func (c *Client) MessagesStream(ctx context.Context, req *MessagesRequest, opts ...RequestOption) (*MessageStream, error) {
+	body := *req
+	body.Stream = true
+
+	opts = append([]RequestOption{WithRequestHeader("anthropic-version", AnthropicVersion)}, opts...)
+	resp, cancel, err := c.doStream(ctx, http.MethodPost, "/v1/messages", &body, "text/event-stream", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageStream{
+		eventStream: newEventStream(ctx, resp.Body, cancel),
+		inputs:      make(map[int]*strings.Builder),
+	}, nil
+}
+
+// Next advances to the next event. It returns false after message_stop, at
+// end of body, or on error; check Err afterwards.
+func (s *MessageStream) Next() bool {
+	s.current = nil
+	for {
+		sse, ok := s.nextEvent()
+		if !ok {
+			return false
+		}
+
+		var event MessageStreamEvent
+		if err := json.Unmarshal(sse.Data, &event); err != nil {
+			s.finish(fmt.Errorf("ditto: decode stream event: %w", err))
+			return false
+		}
+		if event.Type == "" {
+			event.Type = sse.Event
+		}
+
+		switch event.Type {
+		case MessageEventPing:
+			continue
+		case MessageEventError:
+			streamErr := &StreamError{Message: "unknown stream error"}
+			if event.Error != nil {
+				streamErr.Type = event.Error.Type
+				streamErr.Message = event.Error.Message
+			}
+			s.finish(streamErr)
+			return false
+		}
+
+		s.apply(&event)
+		s.current = &event
+		if event.Type == MessageEventStop {
+			s.finish(nil)
+		}
+		return true
+	}
+}
+
+// Current returns the event read by the last successful Next.
+func (s *MessageStream) Current() *MessageStreamEvent {
+	return s.current
+}
+
+// Message returns the message assembled from the events seen so far.
+func (s *MessageStream) Message() *Message {
+	out := s.message
+	out.Content = append([]ContentBlock(nil), s.message.Content...)
+	for index, input := range s.inputs {
+		if index < len(out.Content) {
+			out.Content[index].Input = toolInput(input.String())
+		}
+	}
+	return &out
+}
+
+func (s *MessageStream) apply(event *MessageStreamEvent) {
+	switch event.Type {
+	case MessageEventStart:
+		if event.Message != nil {
+			s.message = *event.Message
+			s.message.Content = append([]ContentBlock(nil), event.Message.Content...)
+		}
+	case MessageEventContentBlockStart:
+		if event.ContentBlock == nil {
+			return
+		}
+		for len(s.message.Content) <= event.Index {
+			s.message.Content = append(s.message.Content, ContentBlock{})
+		}
+		s.message.Content[event.Index] = *event.ContentBlock
+		if event.ContentBlock.Type == ContentBlockToolUse {
+			s.inputs[event.Index] = &strings.Builder{}
+		}
+	case MessageEventContentBlockDelta:
+		if event.Delta == nil || event.Index >= len(s.message.Content) {
+			return
+		}
+		block := &s.message.Content[event.Index]
+		block.Text += event.Delta.Text
+		block.Thinking += event.Delta.Thinking
+		if event.Delta.Signature != "" {
+			block.Signature = event.Delta.Signature
+		}
+		if event.Delta.PartialJSON != "" {
+			input := s.inputs[event.Index]
+			if input == nil {
+				input = &strings.Builder{}
+				s.inputs[event.Index] = input
+			}
+			input.WriteString(event.Delta.PartialJSON)
+		}
+	case MessageEventDelta:
+		if event.Delta != nil {
+			if event.Delta.StopReason != "" {
+				s.message.StopReason = event.Delta.StopReason
+			}
+			if event.Delta.StopSequence != "" {
+				s.message.StopSequence = event.Delta.StopSequence
+			}
+		}
+		if event.Usage != nil {
+			if event.Usage.InputTokens != 0 {
+				s.message.Usage.InputTokens = event.Usage.InputTokens
+			}
+			s.message.Usage.OutputTokens = event.Usage.OutputTokens
+		}
+	}
+}
+
+// toolInput turns accumulated partial JSON into a tool_use input, using an
+// empty object for tools invoked without arguments.
+func toolInput(partial string) json.RawMessage {
+	if strings.TrimSpace(partial) == "" {
+		return json.RawMessage(`{}`)
+	}
+	return json.RawMessage(partial)
+}
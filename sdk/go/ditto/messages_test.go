@@ -0,0 +1,133 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMessages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/messages" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.Header.Get("anthropic-version"); got != AnthropicVersion {
+			t.Errorf("anthropic-version = %q", got)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body["max_tokens"] != float64(256) {
+			t.Errorf("max_tokens = %v", body["max_tokens"])
+		}
+		_, _ = w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude","content":[{"type":"text","text":"Hi "},{"type":"tool_use","id":"toolu_1","name":"lookup","input":{"q":"go"}},{"type":"text","text":"there"}],"stop_reason":"tool_use","usage":{"input_tokens":5,"output_tokens":7}}`))
+	}))
+	defer srv.Close()
+
+	msg, err := NewClient(WithBaseURL(srv.URL)).Messages(context.Background(), &MessagesRequest{
+		Model:     "claude",
+		MaxTokens: 256,
+		System:    []ContentBlock{TextBlock("be brief")},
+		Messages:  []MessageParam{UserMessageParam("hi")},
+	})
+	if err != nil {
+		t.Fatalf("Messages: %v", err)
+	}
+	if msg.Text() != "Hi there" || msg.StopReason != "tool_use" || msg.Usage.OutputTokens != 7 {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+	if string(msg.Content[1].Input) != `{"q":"go"}` {
+		t.Fatalf("tool input = %s", msg.Content[1].Input)
+	}
+}
+
+func TestMessagesAnthropicErrorEnvelope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"type":"error","error":{"type":"invalid_request_error","message":"missing field ` + "`model`" + `"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(WithBaseURL(srv.URL)).Messages(context.Background(), &MessagesRequest{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Type != "invalid_request_error" || apiErr.Message != "missing field `model`" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMessagesStream(t *testing.T) {
+	events := []string{
+		`event: message_start` + "\n" + `data: {"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude","content":[],"usage":{"input_tokens":5,"output_tokens":0}}}`,
+		`event: ping` + "\n" + `data: {"type":"ping"}`,
+		`event: content_block_start` + "\n" + `data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`event: content_block_delta` + "\n" + `data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}`,
+		`event: content_block_delta` + "\n" + `data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"lo"}}`,
+		`event: content_block_stop` + "\n" + `data: {"type":"content_block_stop","index":0}`,
+		`event: content_block_start` + "\n" + `data: {"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"lookup","input":{}}}`,
+		`event: content_block_delta` + "\n" + `data: {"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"q\":"}}`,
+		`event: content_block_delta` + "\n" + `data: {"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"go\"}"}}`,
+		`event: content_block_stop` + "\n" + `data: {"type":"content_block_stop","index":1}`,
+		`event: message_delta` + "\n" + `data: {"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":9}}`,
+		`event: message_stop` + "\n" + `data: {"type":"message_stop"}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		for _, event := range events {
+			fmt.Fprintf(w, "%s\n\n", event)
+		}
+	}))
+	defer srv.Close()
+
+	stream, err := NewClient(WithBaseURL(srv.URL)).MessagesStream(context.Background(), &MessagesRequest{Model: "claude", MaxTokens: 16})
+	if err != nil {
+		t.Fatalf("MessagesStream: %v", err)
+	}
+	defer stream.Close()
+
+	var types []string
+	for stream.Next() {
+		types = append(types, stream.Current().Type)
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	if len(types) != len(events)-1 || types[len(types)-1] != MessageEventStop {
+		t.Fatalf("unexpected events: %v", types)
+	}
+
+	msg := stream.Message()
+	if msg.ID != "msg_1" || msg.Text() != "Hello" || msg.StopReason != "tool_use" {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+	if msg.Usage.InputTokens != 5 || msg.Usage.OutputTokens != 9 {
+		t.Fatalf("unexpected usage: %+v", msg.Usage)
+	}
+	if string(msg.Content[1].Input) != `{"q":"go"}` {
+		t.Fatalf("tool input = %s", msg.Content[1].Input)
+	}
+}
+
+func TestMessagesStreamErrorEvent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		fmt.Fprint(w, "event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n")
+	}))
+	defer srv.Close()
+
+	stream, err := NewClient(WithBaseURL(srv.URL)).MessagesStream(context.Background(), &MessagesRequest{Model: "claude", MaxTokens: 16})
+	if err != nil {
+		t.Fatalf("MessagesStream: %v", err)
+	}
+	defer stream.Close()
+	for stream.Next() {
+	}
+	var streamErr *StreamError
+	if !errors.As(stream.Err(), &streamErr) || streamErr.Type != "overloaded_error" {
+		t.Fatalf("expected overloaded StreamError, got %v", stream.Err())
+	}
+}
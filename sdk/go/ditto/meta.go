@@ -0,0 +1,49 @@
+package ditto
+
+import "net/http"
+
+// HeaderBackend names the backend that served a proxied request.
+const HeaderBackend = "x-ditto-backend"
+
+// ResponseMeta captures the HTTP status and headers of one call. It is
+// filled as soon as response headers arrive, for error responses too.
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+}
+
+// WithResponseMeta records the response status and headers into meta:
+//
+//	var meta ditto.ResponseMeta
+//	resp, err := client.ChatCompletions(ctx, req, ditto.WithResponseMeta(&meta))
+//	if meta.CacheHit() { ... }
+func WithResponseMeta(meta *ResponseMeta) RequestOption {
+	return func(rc *requestConfig) {
+		rc.meta = meta
+	}
+}
+
+func (m *ResponseMeta) record(resp *http.Response) {
+	if m == nil {
+		return
+	}
+	m.StatusCode = resp.StatusCode
+	m.Header = resp.Header.Clone()
+}
+
+// RequestID returns the `x-request-id` echoed by the gateway.
+func (m *ResponseMeta) RequestID() string {
+	return m.get(HeaderRequestID)
+}
+
+// Backend returns the backend that served a proxied request.
+func (m *ResponseMeta) Backend() string {
+	return m.get(HeaderBackend)
+}
+
+func (m *ResponseMeta) get(key string) string {
+	if m == nil || m.Header == nil {
+		return ""
+	}
+	return m.Header.Get(key)
+}
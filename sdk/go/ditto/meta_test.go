@@ -0,0 +1,46 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithResponseMeta(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderRequestID, r.Header.Get(HeaderRequestID))
+		w.Header().Set(HeaderBackend, "primary")
+		if r.URL.Path == "/v1/chat/completions" {
+			_, _ = w.Write([]byte(`{"id":"c1","choices":[]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"message":"not found"}}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c := NewClient(WithBaseURL(srv.URL))
+
+	var meta ResponseMeta
+	if _, err := c.ChatCompletions(ctx, &ChatCompletionRequest{Model: "m"}, WithRequestID("req-1"), WithResponseMeta(&meta)); err != nil {
+		t.Fatalf("ChatCompletions: %v", err)
+	}
+	if meta.StatusCode != http.StatusOK || meta.RequestID() != "req-1" || meta.Backend() != "primary" {
+		t.Fatalf("meta = %+v", meta)
+	}
+
+	var errMeta ResponseMeta
+	if _, err := c.RetrieveBatch(ctx, "missing", WithResponseMeta(&errMeta)); err == nil {
+		t.Fatal("expected error")
+	}
+	if errMeta.StatusCode != http.StatusNotFound || errMeta.Backend() != "primary" {
+		t.Fatalf("error meta = %+v", errMeta)
+	}
+
+	var empty *ResponseMeta
+	if empty.RequestID() != "" || empty.CacheHit() {
+		t.Fatal("nil meta should report nothing")
+	}
+}
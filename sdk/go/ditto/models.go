@@ -0,0 +1,73 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Model is an entry of `GET /v1/models`. Gateway model groups and aliases
+// report OwnedBy "ditto".
+type Model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Created int64  `json:"created,omitempty"`
+	OwnedBy string `json:"owned_by,omitempty"`
+}
+
+// ListModels calls `GET /v1/models`. The gateway lists only the models the
+// client's virtual key can use, so the result can back a model picker.
+func (c *Client) ListModels(ctx context.Context, opts ...RequestOption) (*List[Model], error) {
+	var out List[Model]
+	if err := c.doJSON(ctx, http.MethodGet, "/v1/models", nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RetrieveModel calls `GET /v1/models/{id}`.
+func (c *Client) RetrieveModel(ctx context.Context, id string, opts ...RequestOption) (*Model, error) {
+	var out Model
+	if err := c.doJSON(ctx, http.MethodGet, "/v1/models/"+url.PathEscape(id), nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ModelInfo is the response of `GET /v1/models/{id}/info`. Nil fields are
+// unknown to the gateway: neither its config nor its built-in model catalog
+// describe them.
+type ModelInfo struct {
+	ID                  string          `json:"id"`
+	Object              string          `json:"object"`
+	Backend             string          `json:"backend"`
+	UpstreamModel       string          `json:"upstream_model"`
+	Provider            *string         `json:"provider"`
+	ContextWindow       *int64          `json:"context_window"`
+	MaxOutputTokens     *int64          `json:"max_output_tokens"`
+	Modalities          ModelModalities `json:"modalities"`
+	SupportsToolCalling *bool           `json:"supports_tool_calling"`
+	Pricing             *ModelPricing   `json:"pricing"`
+}
+
+// ModelModalities lists lower-case modality names such as "text" and "image".
+type ModelModalities struct {
+	Input  []string `json:"input"`
+	Output []string `json:"output"`
+}
+
+// ModelPricing is in USD per million tokens.
+type ModelPricing struct {
+	InputUSDPerMillionTokens  *float64 `json:"input_usd_per_million_tokens"`
+	OutputUSDPerMillionTokens *float64 `json:"output_usd_per_million_tokens"`
+}
+
+// RetrieveModelInfo calls `GET /v1/models/{id}/info` for the limits,
+// modalities, tool support and prices of a model the virtual key can use.
+func (c *Client) RetrieveModelInfo(ctx context.Context, id string, opts ...RequestOption) (*ModelInfo, error) {
+	var out ModelInfo
+	if err := c.doJSON(ctx, http.MethodGet, "/v1/models/"+url.PathEscape(id)+"/info", nil, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,66 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListModels(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/models":
+			_, _ = w.Write([]byte(`{"object":"list","data":[
+				{"id":"fast-chat","object":"model","created":1700000000,"owned_by":"ditto"},
+				{"id":"gpt-4o","object":"model","owned_by":"openai"}
+			]}`))
+		case "/v1/models/fast-chat":
+			_, _ = w.Write([]byte(`{"id":"fast-chat","object":"model","owned_by":"ditto"}`))
+		default:
+			t.Errorf("path = %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	models, err := c.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels: %v", err)
+	}
+	if len(models.Data) != 2 || models.Data[0].ID != "fast-chat" || models.Data[0].OwnedBy != "ditto" || models.Data[0].Created != 1700000000 {
+		t.Fatalf("unexpected models: %+v", models)
+	}
+	model, err := c.RetrieveModel(context.Background(), "fast-chat")
+	if err != nil || model.ID != "fast-chat" {
+		t.Fatalf("RetrieveModel = %+v, %v", model, err)
+	}
+}
+
+func TestRetrieveModelInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models/fast-chat/info" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"fast-chat","object":"model.info","backend":"a","upstream_model":"gpt-4o-mini",
+			"provider":null,"context_window":128000,"max_output_tokens":null,
+			"modalities":{"input":["text","image"],"output":["text"]},
+			"supports_tool_calling":true,"pricing":{"input_usd_per_million_tokens":0.15,"output_usd_per_million_tokens":null}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	info, err := c.RetrieveModelInfo(context.Background(), "fast-chat")
+	if err != nil {
+		t.Fatalf("RetrieveModelInfo: %v", err)
+	}
+	if info.UpstreamModel != "gpt-4o-mini" || info.Provider != nil || info.ContextWindow == nil || *info.ContextWindow != 128000 || info.MaxOutputTokens != nil {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+	if len(info.Modalities.Input) != 2 || info.SupportsToolCalling == nil || !*info.SupportsToolCalling {
+		t.Fatalf("unexpected capabilities: %+v", info)
+	}
+	if info.Pricing == nil || info.Pricing.InputUSDPerMillionTokens == nil || *info.Pricing.InputUSDPerMillionTokens != 0.15 || info.Pricing.OutputUSDPerMillionTokens != nil {
+		t.Fatalf("unexpected pricing: %+v", info.Pricing)
+	}
+}
@@ -0,0 +1,20 @@
+package ditto
+
+import "strings"
+
+// Moderation headers, set by the gateway when an `annotate` moderation
+// guardrail flagged the request or response.
+const (
+	HeaderModeration           = "x-ditto-moderation"
+	HeaderModerationCategories = "x-ditto-moderation-categories"
+)
+
+// ModerationCategories returns the categories an `annotate` moderation
+// guardrail flagged, or nil when nothing was flagged.
+func (m *ResponseMeta) ModerationCategories() []string {
+	raw := strings.TrimSpace(m.get(HeaderModerationCategories))
+	if m.get(HeaderModeration) != "flagged" || raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
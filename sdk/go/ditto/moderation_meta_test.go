@@ -0,0 +1,20 @@
+package ditto
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestResponseMetaModerationCategories(t *testing.T) {
+	meta := &ResponseMeta{Header: http.Header{}}
+	if got := meta.ModerationCategories(); got != nil {
+		t.Fatalf("categories = %v", got)
+	}
+
+	meta.Header.Set(HeaderModeration, "flagged")
+	meta.Header.Set(HeaderModerationCategories, "hate,violence")
+	if got := meta.ModerationCategories(); !reflect.DeepEqual(got, []string{"hate", "violence"}) {
+		t.Fatalf("categories = %v", got)
+	}
+}
@@ -0,0 +1,60 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+)
+
+// ModerationRequest is the body of `POST /v1/moderations`. Model selects the
+// moderation model or gateway alias; leave it empty for the upstream default.
+type ModerationRequest struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+// ModerationResponse is the response of `POST /v1/moderations`.
+type ModerationResponse struct {
+	ID      string             `json:"id"`
+	Model   string             `json:"model"`
+	Results []ModerationResult `json:"results"`
+}
+
+// ModerationResult is the verdict for one input.
+type ModerationResult struct {
+	Flagged        bool               `json:"flagged"`
+	Categories     map[string]bool    `json:"categories"`
+	CategoryScores map[string]float64 `json:"category_scores"`
+}
+
+// Flagged reports whether any input was flagged.
+func (r *ModerationResponse) Flagged() bool {
+	if r == nil {
+		return false
+	}
+	for _, result := range r.Results {
+		if result.Flagged {
+			return true
+		}
+	}
+	return false
+}
+
+// FlaggedCategories returns the categories set to true for the result.
+func (r *ModerationResult) FlaggedCategories() []string {
+	var out []string
+	for category, flagged := range r.Categories {
+		if flagged {
+			out = append(out, category)
+		}
+	}
+	return out
+}
+
+// Moderations calls `POST /v1/moderations`.
+func (c *Client) Moderations(ctx context.Context, req *ModerationRequest, opts ...RequestOption) (*ModerationResponse, error) {
+	var out ModerationResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/moderations", req, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,35 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestModerations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/moderations" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"modr-1","model":"omni-moderation-latest","results":[
+			{"flagged":false,"categories":{"violence":false},"category_scores":{"violence":0.01}},
+			{"flagged":true,"categories":{"violence":true,"harassment":false},"category_scores":{"violence":0.97}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(WithBaseURL(srv.URL)).Moderations(context.Background(), &ModerationRequest{
+		Model: "omni-moderation-latest",
+		Input: []string{"fine", "not fine"},
+	})
+	if err != nil {
+		t.Fatalf("Moderations: %v", err)
+	}
+	if !resp.Flagged() {
+		t.Fatalf("expected flagged response")
+	}
+	if got := resp.Results[1].FlaggedCategories(); len(got) != 1 || got[0] != "violence" {
+		t.Fatalf("FlaggedCategories() = %v", got)
+	}
+}
@@ -0,0 +1,47 @@
+package ditto
+
+import (
+	"io"
+	"mime/multipart"
+)
+
+// streamMultipart returns a reader producing the multipart body written by
+// write, plus its content type. The body is generated on the fly through a
+// pipe, so large uploads are never held in memory. If the consumer stops
+// reading (e.g. the request fails), the writer goroutine is unblocked with
+// the pipe's close error.
+func streamMultipart(write func(*multipart.Writer) error) (io.ReadCloser, string) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		err := write(mw)
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr, mw.FormDataContentType()
+}
+
+// writeFields writes non-empty form fields in order.
+func writeFields(mw *multipart.Writer, fields [][2]string) error {
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+		if err := mw.WriteField(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile copies r into a form file part.
+func writeFile(mw *multipart.Writer, field, filename string, r io.Reader) error {
+	part, err := mw.CreateFormFile(field, filename)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, r)
+	return err
+}
@@ -0,0 +1,67 @@
+// Package plugin defines the extension points of the ditto Go client:
+// auth providers, routers, loggers, and guardrails.
+//
+// Extensions register themselves by name from an init function, the way
+// database/sql drivers do, so a program opts in with a blank import:
+//
+//	import _ "example.com/acme/dittoext" // calls plugin.Register("acme-sso", ...)
+//
+//	client := ditto.NewClient(ditto.WithPlugin("acme-sso"))
+//
+// The interfaces only depend on the standard library and are kept stable
+// across SDK releases; new hooks are added as new interfaces.
+package plugin
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Call identifies one outgoing gateway call.
+type Call struct {
+	Method string
+	// Path is the gateway path, e.g. "/v1/chat/completions".
+	Path string
+}
+
+// AuthProvider sets credentials on each outgoing request, e.g. short-lived
+// tokens from an SSO provider. It runs after the client's token and headers
+// are applied, so it can override either.
+type AuthProvider interface {
+	Authorize(ctx context.Context, req *http.Request) error
+}
+
+// Router picks the gateway root URL (without the `/v1` suffix) for a call,
+// e.g. to pin admin calls to one region.
+type Router interface {
+	Route(ctx context.Context, call Call) (baseURL string, err error)
+}
+
+// Logger observes every call once its response headers arrive or it fails.
+type Logger interface {
+	LogCall(ctx context.Context, event CallEvent)
+}
+
+// CallEvent describes a finished (or, for streams, started) call.
+type CallEvent struct {
+	Call
+	// StatusCode is zero when no response was received.
+	StatusCode int
+	// RequestID is the `x-request-id` echoed by the gateway.
+	RequestID string
+	// Duration runs until the response headers arrive or the call fails.
+	Duration time.Duration
+	// Err is the transport error, if any; non-2xx statuses are not errors
+	// here.
+	Err error
+}
+
+// Guardrail inspects JSON request bodies before they are sent and buffered
+// 2xx response bodies before they are decoded. A non-nil error aborts the
+// call and is returned to the caller. Multipart uploads and streaming
+// responses are not inspected.
+type Guardrail interface {
+	CheckRequest(ctx context.Context, call Call, body []byte) error
+	CheckResponse(ctx context.Context, call Call, body []byte) error
+}
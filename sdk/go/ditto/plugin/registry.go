@@ -0,0 +1,59 @@
+package plugin
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]any)
+)
+
+// Register makes ext available under name. ext must implement at least one
+// of AuthProvider, Router, Logger, and Guardrail; the client attaches every
+// one it implements. Register panics if name is empty or already taken, or
+// if ext implements none of them, so mistakes surface at program start.
+func Register(name string, ext any) {
+	if name == "" {
+		panic("plugin: Register with empty name")
+	}
+	if !implementsAny(ext) {
+		panic(fmt.Sprintf("plugin: %s (%T) implements no plugin interface", name, ext))
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic("plugin: Register called twice for " + name)
+	}
+	registry[name] = ext
+}
+
+// Lookup returns the extension registered under name.
+func Lookup(name string) (any, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	ext, ok := registry[name]
+	return ext, ok
+}
+
+// Names returns the registered names in sorted order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func implementsAny(ext any) bool {
+	switch ext.(type) {
+	case AuthProvider, Router, Logger, Guardrail:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+type headerAuth struct{}
+
+func (headerAuth) Authorize(_ context.Context, req *http.Request) error {
+	req.Header.Set("x-api-key", "secret")
+	return nil
+}
+
+func mustPanic(t *testing.T, name string, fn func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s: expected panic", name)
+		}
+	}()
+	fn()
+}
+
+func TestRegisterAndLookup(t *testing.T) {
+	Register("test-header-auth", headerAuth{})
+
+	ext, ok := Lookup("test-header-auth")
+	if !ok {
+		t.Fatal("Lookup: not found")
+	}
+	if _, isAuth := ext.(AuthProvider); !isAuth {
+		t.Fatalf("Lookup returned %T", ext)
+	}
+	if _, ok := Lookup("missing"); ok {
+		t.Fatal("Lookup(missing) should fail")
+	}
+	if !slices.Contains(Names(), "test-header-auth") {
+		t.Fatalf("Names() = %v", Names())
+	}
+
+	mustPanic(t, "duplicate", func() { Register("test-header-auth", headerAuth{}) })
+	mustPanic(t, "empty name", func() { Register("", headerAuth{}) })
+	mustPanic(t, "no interface", func() { Register("test-string", "not a plugin") })
+}
@@ -0,0 +1,117 @@
+package ditto
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/omne42/ditto-llm/sdk/go/ditto/plugin"
+)
+
+// WithAuthProvider sets credentials on every request through p, after the
+// token and client headers are applied.
+func WithAuthProvider(p plugin.AuthProvider) Option {
+	return func(c *Client) {
+		c.auth = p
+	}
+}
+
+// WithRouter lets r pick the gateway root URL for each call instead of the
+// configured base URL.
+func WithRouter(r plugin.Router) Option {
+	return func(c *Client) {
+		c.router = r
+	}
+}
+
+// WithLogger adds a logger that observes every call.
+func WithLogger(l plugin.Logger) Option {
+	return func(c *Client) {
+		c.loggers = append(c.loggers, l)
+	}
+}
+
+// WithGuardrail adds a guardrail that checks JSON request bodies and
+// buffered responses; guardrails run in the order they were added.
+func WithGuardrail(g plugin.Guardrail) Option {
+	return func(c *Client) {
+		c.guardrails = append(c.guardrails, g)
+	}
+}
+
+// WithPlugin attaches the extension registered under name with
+// plugin.Register, once for each plugin interface it implements. It panics
+// when nothing is registered under name, which usually means the
+// extension's package was not imported.
+func WithPlugin(name string) Option {
+	ext, ok := plugin.Lookup(name)
+	if !ok {
+		panic("ditto: no plugin registered as " + name)
+	}
+	return func(c *Client) {
+		if p, ok := ext.(plugin.AuthProvider); ok {
+			WithAuthProvider(p)(c)
+		}
+		if r, ok := ext.(plugin.Router); ok {
+			WithRouter(r)(c)
+		}
+		if l, ok := ext.(plugin.Logger); ok {
+			WithLogger(l)(c)
+		}
+		if g, ok := ext.(plugin.Guardrail); ok {
+			WithGuardrail(g)(c)
+		}
+	}
+}
+
+func (c *Client) routeBaseURL(ctx context.Context, call plugin.Call) (string, error) {
+	if c.router == nil {
+		return c.baseURL, nil
+	}
+	baseURL, err := c.router.Route(ctx, call)
+	if err != nil {
+		return "", fmt.Errorf("ditto: route %s %s: %w", call.Method, call.Path, err)
+	}
+	return normalizeBaseURL(baseURL), nil
+}
+
+func (c *Client) checkRequest(ctx context.Context, call plugin.Call, body []byte) error {
+	for _, g := range c.guardrails {
+		if err := g.CheckRequest(ctx, call, body); err != nil {
+			return fmt.Errorf("ditto: request guardrail: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) checkResponse(ctx context.Context, call plugin.Call, body []byte) error {
+	for _, g := range c.guardrails {
+		if err := g.CheckResponse(ctx, call, body); err != nil {
+			return fmt.Errorf("ditto: response guardrail: %w", err)
+		}
+	}
+	return nil
+}
+
+// logCall reports a call to the loggers; resp is nil when the call failed
+// before a response arrived.
+func (c *Client) logCall(
+	ctx context.Context,
+	call plugin.Call,
+	start time.Time,
+	resp *http.Response,
+	err error,
+) {
+	if len(c.loggers) == 0 {
+		return
+	}
+	event := plugin.CallEvent{Call: call, Duration: time.Since(start), Err: err}
+	if resp != nil {
+		event.StatusCode = resp.StatusCode
+		event.RequestID = resp.Header.Get(HeaderRequestID)
+	}
+	for _, l := range c.loggers {
+		l.LogCall(ctx, event)
+	}
+}
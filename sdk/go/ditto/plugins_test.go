@@ -0,0 +1,98 @@
+package ditto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/omne42/ditto-llm/sdk/go/ditto/plugin"
+)
+
+var errBlocked = errors.New("blocked phrase")
+
+// testExtension implements every plugin interface.
+type testExtension struct {
+	baseURL string
+	events  []plugin.CallEvent
+}
+
+func (e *testExtension) Authorize(_ context.Context, req *http.Request) error {
+	req.Header.Set("authorization", "Bearer sso-token")
+	return nil
+}
+
+func (e *testExtension) Route(_ context.Context, _ plugin.Call) (string, error) {
+	return e.baseURL, nil
+}
+
+func (e *testExtension) LogCall(_ context.Context, event plugin.CallEvent) {
+	e.events = append(e.events, event)
+}
+
+func (e *testExtension) CheckRequest(_ context.Context, _ plugin.Call, body []byte) error {
+	if bytes.Contains(body, []byte("forbidden")) {
+		return errBlocked
+	}
+	return nil
+}
+
+func (e *testExtension) CheckResponse(_ context.Context, _ plugin.Call, body []byte) error {
+	if bytes.Contains(body, []byte("leak")) {
+		return errBlocked
+	}
+	return nil
+}
+
+func TestWithPluginAttachesRegisteredExtension(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("authorization"); got != "Bearer sso-token" {
+			t.Errorf("authorization = %q", got)
+		}
+		w.Header().Set(HeaderRequestID, "req-plugin")
+		w.Header().Set("content-type", "application/json")
+		if r.URL.Path == "/v1/leak" {
+			_, _ = w.Write([]byte(`{"secret":"leak"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	ext := &testExtension{baseURL: srv.URL + "/"}
+	plugin.Register("test-extension", ext)
+	c := NewClient(WithBaseURL("http://unused.invalid"), WithToken("vk-static"), WithPlugin("test-extension"))
+	ctx := context.Background()
+
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/ok", map[string]string{"q": "hi"}, nil, nil); err != nil {
+		t.Fatalf("doJSON: %v", err)
+	}
+	if len(ext.events) != 1 || ext.events[0].Path != "/v1/ok" || ext.events[0].StatusCode != http.StatusOK ||
+		ext.events[0].RequestID != "req-plugin" {
+		t.Fatalf("events = %+v", ext.events)
+	}
+
+	err := c.doJSON(ctx, http.MethodPost, "/v1/ok", map[string]string{"q": "forbidden"}, nil, nil)
+	if !errors.Is(err, errBlocked) {
+		t.Fatalf("request guardrail: %v", err)
+	}
+	if len(ext.events) != 1 {
+		t.Fatalf("blocked request should not be sent, events = %+v", ext.events)
+	}
+
+	err = c.doJSON(ctx, http.MethodGet, "/v1/leak", nil, nil, nil)
+	if !errors.Is(err, errBlocked) {
+		t.Fatalf("response guardrail: %v", err)
+	}
+}
+
+func TestWithPluginPanicsForUnknownName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	WithPlugin("never-registered")
+}
@@ -0,0 +1,29 @@
+package ditto
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Prompt-injection headers, set by the gateway when the virtual key has
+// `guardrails.prompt_injection` configured and the request carried user text.
+const (
+	HeaderPromptInjectionScore = "x-ditto-prompt-injection-score"
+	HeaderPromptInjection      = "x-ditto-prompt-injection"
+)
+
+// PromptInjectionScore returns the gateway's prompt-injection score (0 to
+// 1) for the request, or false when the response carried none.
+func (m *ResponseMeta) PromptInjectionScore() (float64, bool) {
+	score, err := strconv.ParseFloat(strings.TrimSpace(m.get(HeaderPromptInjectionScore)), 64)
+	if err != nil {
+		return 0, false
+	}
+	return score, true
+}
+
+// PromptInjectionFlagged reports whether a `tag` prompt-injection guardrail
+// flagged the request. Flagged requests under `block` are rejected instead.
+func (m *ResponseMeta) PromptInjectionFlagged() bool {
+	return m.get(HeaderPromptInjection) == "flagged"
+}
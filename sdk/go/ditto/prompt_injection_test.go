@@ -0,0 +1,24 @@
+package ditto
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestResponseMetaPromptInjection(t *testing.T) {
+	meta := &ResponseMeta{Header: http.Header{}}
+	if _, ok := meta.PromptInjectionScore(); ok || meta.PromptInjectionFlagged() {
+		t.Fatal("empty meta should report no score")
+	}
+
+	meta.Header.Set(HeaderPromptInjectionScore, "0.95")
+	meta.Header.Set(HeaderPromptInjection, "flagged")
+	if score, ok := meta.PromptInjectionScore(); !ok || score != 0.95 || !meta.PromptInjectionFlagged() {
+		t.Fatalf("score = %v %v, flagged = %v", score, ok, meta.PromptInjectionFlagged())
+	}
+
+	var empty *ResponseMeta
+	if _, ok := empty.PromptInjectionScore(); ok {
+		t.Fatal("nil meta should report no score")
+	}
+}
@@ -0,0 +1,76 @@
+package ditto
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit holds the OpenAI-style `x-ratelimit-*` headers of a response.
+// The gateway passes them through from the upstream provider; it does not
+// emit them for its own virtual key limits. Absent values are left zero.
+type RateLimit struct {
+	LimitRequests     int
+	RemainingRequests int
+	ResetRequests     time.Duration
+	LimitTokens       int
+	RemainingTokens   int
+	ResetTokens       time.Duration
+}
+
+// RateLimit parses the `x-ratelimit-*` headers, or returns nil when the
+// response carried none.
+func (m *ResponseMeta) RateLimit() *RateLimit {
+	if m == nil || m.Header == nil {
+		return nil
+	}
+	var (
+		rl    RateLimit
+		found bool
+	)
+	atoi := func(key string) int {
+		v, err := strconv.Atoi(strings.TrimSpace(m.Header.Get(key)))
+		if err != nil {
+			return 0
+		}
+		found = true
+		return v
+	}
+	duration := func(key string) time.Duration {
+		d, err := time.ParseDuration(strings.TrimSpace(m.Header.Get(key)))
+		if err != nil {
+			return 0
+		}
+		found = true
+		return d
+	}
+	rl.LimitRequests = atoi("x-ratelimit-limit-requests")
+	rl.RemainingRequests = atoi("x-ratelimit-remaining-requests")
+	rl.ResetRequests = duration("x-ratelimit-reset-requests")
+	rl.LimitTokens = atoi("x-ratelimit-limit-tokens")
+	rl.RemainingTokens = atoi("x-ratelimit-remaining-tokens")
+	rl.ResetTokens = duration("x-ratelimit-reset-tokens")
+	if !found {
+		return nil
+	}
+	return &rl
+}
+
+// parseRetryAfter reads `retry-after` as delay seconds or an HTTP date.
+func parseRetryAfter(h http.Header, now time.Time) time.Duration {
+	v := strings.TrimSpace(h.Get("retry-after"))
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("retry-after", "7")
+		w.Header().Set("x-ratelimit-limit-tokens", "30000")
+		w.Header().Set("x-ratelimit-remaining-tokens", "0")
+		w.Header().Set("x-ratelimit-reset-tokens", "6m0s")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"message":"rate limit exceeded: tpm>30000","type":"rate_limit_error","code":"rate_limited"}}`))
+	}))
+	defer srv.Close()
+
+	var meta ResponseMeta
+	_, err := NewClient(WithBaseURL(srv.URL)).ChatCompletions(context.Background(), &ChatCompletionRequest{Model: "m"}, WithResponseMeta(&meta))
+	if !IsRateLimited(err) || IsBudgetExceeded(err) {
+		t.Fatalf("IsRateLimited(%v) = false", err)
+	}
+	if !IsRateLimited(&APIError{StatusCode: http.StatusTooManyRequests, Code: ErrCodeInflightLimitBackend}) {
+		t.Fatal("backpressure 429 should be rate limited")
+	}
+	apiErr := err.(*APIError)
+	if apiErr.Code != ErrCodeRateLimited || apiErr.RetryAfter != 7*time.Second {
+		t.Fatalf("err = %+v", apiErr)
+	}
+
+	rl := meta.RateLimit()
+	if rl == nil || rl.LimitTokens != 30000 || rl.RemainingTokens != 0 || rl.ResetTokens != 6*time.Minute || rl.LimitRequests != 0 {
+		t.Fatalf("RateLimit() = %+v", rl)
+	}
+}
+
+func TestRateLimitAbsent(t *testing.T) {
+	if rl := (&ResponseMeta{Header: http.Header{}}).RateLimit(); rl != nil {
+		t.Fatalf("RateLimit() = %+v, want nil", rl)
+	}
+	if rl := (*ResponseMeta)(nil).RateLimit(); rl != nil {
+		t.Fatalf("nil RateLimit() = %+v", rl)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tc := range []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	} {
+		h := http.Header{}
+		if tc.value != "" {
+			h.Set("retry-after", tc.value)
+		}
+		if got := parseRetryAfter(h, now); got != tc.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tc.value, got, tc.want)
+		}
+	}
+}
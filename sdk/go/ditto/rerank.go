@@ -0,0 +1,66 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+)
+
+// RerankRequest is the body of `POST /v1/rerank`, in the shape Cohere and
+// Jina share. Model may be a gateway alias; reranks share routing, spend
+// tracking, and rate limits with chat for the same virtual key.
+type RerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	// TopN limits the results to the best N documents.
+	TopN            *int  `json:"top_n,omitempty"`
+	ReturnDocuments *bool `json:"return_documents,omitempty"`
+}
+
+// RerankResponse is the response of `POST /v1/rerank`. Results are ordered
+// by relevance, best first.
+type RerankResponse struct {
+	ID      string         `json:"id,omitempty"`
+	Model   string         `json:"model,omitempty"`
+	Results []RerankResult `json:"results"`
+	// Usage is set by Jina-style upstreams, Meta by Cohere.
+	Usage *RerankUsage `json:"usage,omitempty"`
+	Meta  *RerankMeta  `json:"meta,omitempty"`
+}
+
+// RerankResult scores one document. Index is its position in
+// RerankRequest.Documents; Document is set when ReturnDocuments was true.
+type RerankResult struct {
+	Index          int             `json:"index"`
+	RelevanceScore float64         `json:"relevance_score"`
+	Document       *RerankDocument `json:"document,omitempty"`
+}
+
+// RerankDocument is a document echoed back in a rerank result.
+type RerankDocument struct {
+	Text string `json:"text"`
+}
+
+// RerankUsage reports tokens consumed by a rerank call.
+type RerankUsage struct {
+	TotalTokens int `json:"total_tokens"`
+}
+
+// RerankMeta carries Cohere's billing information.
+type RerankMeta struct {
+	BilledUnits *RerankBilledUnits `json:"billed_units,omitempty"`
+}
+
+// RerankBilledUnits is what Cohere charged for a rerank call.
+type RerankBilledUnits struct {
+	SearchUnits int `json:"search_units"`
+}
+
+// Rerank calls `POST /v1/rerank`.
+func (c *Client) Rerank(ctx context.Context, req *RerankRequest, opts ...RequestOption) (*RerankResponse, error) {
+	var out RerankResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/rerank", req, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,48 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRerank(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/rerank" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body["model"] != "rerank-v3.5" || body["top_n"] != float64(1) {
+			t.Errorf("unexpected body: %v", body)
+		}
+		if _, ok := body["return_documents"]; ok {
+			t.Errorf("return_documents should be omitted: %v", body)
+		}
+		_, _ = w.Write([]byte(`{"id":"rr-1","results":[
+			{"index":1,"relevance_score":0.9,"document":{"text":"Paris"}}
+		],"meta":{"billed_units":{"search_units":1}}}`))
+	}))
+	defer srv.Close()
+
+	topN := 1
+	resp, err := NewClient(WithBaseURL(srv.URL)).Rerank(context.Background(), &RerankRequest{
+		Model:     "rerank-v3.5",
+		Query:     "capital of france",
+		Documents: []string{"Berlin", "Paris"},
+		TopN:      &topN,
+	})
+	if err != nil {
+		t.Fatalf("Rerank: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Index != 1 || resp.Results[0].Document.Text != "Paris" {
+		t.Fatalf("unexpected results: %+v", resp.Results)
+	}
+	if resp.Meta.BilledUnits.SearchUnits != 1 || resp.Usage != nil {
+		t.Fatalf("unexpected billing: %+v %+v", resp.Meta, resp.Usage)
+	}
+}
@@ -0,0 +1,170 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Responses output item types.
+const (
+	ResponseItemMessage            = "message"
+	ResponseItemFunctionCall       = "function_call"
+	ResponseItemFunctionCallOutput = "function_call_output"
+	ResponseItemReasoning          = "reasoning"
+)
+
+// ResponseInputItem is one entry of a Responses `input` array: a message
+// (Role + Content), a function_call replayed from a previous turn, or a
+// function_call_output answering one.
+type ResponseInputItem struct {
+	Type      string `json:"type,omitempty"`
+	Role      string `json:"role,omitempty"`
+	Content   string `json:"content,omitempty"`
+	CallID    string `json:"call_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+	Output    string `json:"output,omitempty"`
+}
+
+// ResponseInputMessage returns a message input item.
+func ResponseInputMessage(role, content string) ResponseInputItem {
+	return ResponseInputItem{Type: ResponseItemMessage, Role: role, Content: content}
+}
+
+// FunctionCallOutput returns the result of the function call callID.
+func FunctionCallOutput(callID, output string) ResponseInputItem {
+	return ResponseInputItem{Type: ResponseItemFunctionCallOutput, CallID: callID, Output: output}
+}
+
+// ResponseTool declares a function tool in the flat Responses shape.
+type ResponseTool struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+	Strict      *bool           `json:"strict,omitempty"`
+}
+
+// ResponseReasoning configures reasoning effort for models that support it.
+type ResponseReasoning struct {
+	Effort string `json:"effort,omitempty"`
+}
+
+// ResponseRequest is the body of `POST /v1/responses`. For upstreams without
+// a native Responses API the gateway translates the call onto chat
+// completions (the "responses shim").
+type ResponseRequest struct {
+	Model              string              `json:"model"`
+	Input              []ResponseInputItem `json:"input"`
+	Instructions       string              `json:"instructions,omitempty"`
+	Tools              []ResponseTool      `json:"tools,omitempty"`
+	ToolChoice         any                 `json:"tool_choice,omitempty"`
+	Temperature        *float64            `json:"temperature,omitempty"`
+	TopP               *float64            `json:"top_p,omitempty"`
+	MaxOutputTokens    *int                `json:"max_output_tokens,omitempty"`
+	PreviousResponseID string              `json:"previous_response_id,omitempty"`
+	Store              *bool               `json:"store,omitempty"`
+	Reasoning          *ResponseReasoning  `json:"reasoning,omitempty"`
+	Stream             bool                `json:"stream,omitempty"`
+}
+
+// Response is a Responses API `response` object.
+type Response struct {
+	ID                string               `json:"id"`
+	Object            string               `json:"object"`
+	Status            string               `json:"status"`
+	Model             string               `json:"model,omitempty"`
+	Output            []ResponseOutputItem `json:"output"`
+	OutputText        string               `json:"output_text,omitempty"`
+	Usage             *ResponseUsage       `json:"usage,omitempty"`
+	IncompleteDetails *struct {
+		Reason string `json:"reason"`
+	} `json:"incomplete_details,omitempty"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// ResponseOutputItem is one output item: a message or a function_call.
+type ResponseOutputItem struct {
+	Type      string                  `json:"type"`
+	ID        string                  `json:"id,omitempty"`
+	Status    string                  `json:"status,omitempty"`
+	Role      string                  `json:"role,omitempty"`
+	Content   []ResponseOutputContent `json:"content,omitempty"`
+	CallID    string                  `json:"call_id,omitempty"`
+	Name      string                  `json:"name,omitempty"`
+	Arguments string                  `json:"arguments,omitempty"`
+}
+
+// ResponseOutputContent is a content part of a message output item.
+type ResponseOutputContent struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+// ResponseUsage reports token accounting for a response.
+type ResponseUsage struct {
+	InputTokens        int `json:"input_tokens"`
+	OutputTokens       int `json:"output_tokens"`
+	TotalTokens        int `json:"total_tokens"`
+	InputTokensDetails *struct {
+		CachedTokens int `json:"cached_tokens,omitempty"`
+	} `json:"input_tokens_details,omitempty"`
+	OutputTokensDetails *struct {
+		ReasoningTokens int `json:"reasoning_tokens,omitempty"`
+	} `json:"output_tokens_details,omitempty"`
+}
+
+// Text returns output_text, or the concatenated output_text parts of the
+// message items when the field is absent.
+func (r *Response) Text() string {
+	if r == nil {
+		return ""
+	}
+	if r.OutputText != "" {
+		return r.OutputText
+	}
+	var b strings.Builder
+	for _, item := range r.Output {
+		if item.Type != ResponseItemMessage {
+			continue
+		}
+		for _, part := range item.Content {
+			if part.Type == "output_text" {
+				b.WriteString(part.Text)
+			}
+		}
+	}
+	return b.String()
+}
+
+// FunctionCalls returns the function_call output items.
+func (r *Response) FunctionCalls() []ResponseOutputItem {
+	if r == nil {
+		return nil
+	}
+	var calls []ResponseOutputItem
+	for _, item := range r.Output {
+		if item.Type == ResponseItemFunctionCall {
+			calls = append(calls, item)
+		}
+	}
+	return calls
+}
+
+// Responses calls `POST /v1/responses` and waits for the full response.
+// req.Stream is ignored; use ResponsesStream.
+func (c *Client) Responses(ctx context.Context, req *ResponseRequest, opts ...RequestOption) (*Response, error) {
+	body := *req
+	body.Stream = false
+
+	var out Response
+	if err := c.doJSON(ctx, http.MethodPost, "/v1/responses", &body, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
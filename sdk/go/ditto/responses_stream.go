@@ -0,0 +1,133 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Responses stream event types.
+const (
+	ResponseEventCreated         = "response.created"
+	ResponseEventOutputTextDelta = "response.output_text.delta"
+	ResponseEventReasoningDelta  = "response.reasoning_text.delta"
+	ResponseEventOutputItemDone  = "response.output_item.done"
+	ResponseEventCompleted       = "response.completed"
+	ResponseEventIncomplete      = "response.incomplete"
+	ResponseEventFailed          = "response.failed"
+	ResponseEventError           = "error"
+)
+
+// ResponseStreamEvent is one typed Responses SSE event.
+type ResponseStreamEvent struct {
+	Type           string              `json:"type"`
+	SequenceNumber int                 `json:"sequence_number,omitempty"`
+	OutputIndex    int                 `json:"output_index,omitempty"`
+	ItemID         string              `json:"item_id,omitempty"`
+	Delta          string              `json:"delta,omitempty"`
+	Item           *ResponseOutputItem `json:"item,omitempty"`
+	Response       *Response           `json:"response,omitempty"`
+	Code           string              `json:"code,omitempty"`
+	Message        string              `json:"message,omitempty"`
+}
+
+// ResponseStream iterates a streaming Responses call. Iteration ends at the
+// terminal response.completed / response.incomplete event; response.failed
+// and error events surface as *StreamError.
+type ResponseStream struct {
+	eventStream
+	current  *ResponseStreamEvent
+	final    *Response
+	text     strings.Builder
+	items    []ResponseOutputItem
+	latestID string
+}
+
+// ResponsesStream calls `POST /v1/responses` with `stream: true`.
+func (c *Client) ResponsesStream(ctx context.Context, req *ResponseRequest, opts ...RequestOption) (*ResponseStream, error) {
+	body := *req
+	body.Stream = true
+
+	resp, cancel, err := c.doStream(ctx, http.MethodPost, "/v1/responses", &body, "text/event-stream", opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ResponseStream{eventStream: newEventStream(ctx, resp.Body, cancel)}, nil
+}
+
+// Next advances to the next event; check Err once it returns false.
+func (s *ResponseStream) Next() bool {
+	s.current = nil
+	sse, ok := s.nextEvent()
+	if !ok {
+		return false
+	}
+	if string(sse.Data) == "[DONE]" {
+		s.finish(nil)
+		return false
+	}
+
+	var event ResponseStreamEvent
+	if err := json.Unmarshal(sse.Data, &event); err != nil {
+		s.finish(fmt.Errorf("ditto: decode stream event: %w", err))
+		return false
+	}
+	if event.Type == "" {
+		event.Type = sse.Event
+	}
+
+	switch event.Type {
+	case ResponseEventError:
+		s.finish(&StreamError{Type: "error", Code: event.Code, Message: event.Message})
+		return false
+	case ResponseEventFailed:
+		streamErr := &StreamError{Type: ResponseEventFailed, Message: "response failed"}
+		if event.Response != nil {
+			s.final = event.Response
+			if event.Response.Error != nil {
+				streamErr.Code = event.Response.Error.Code
+				streamErr.Message = event.Response.Error.Message
+			}
+		}
+		s.finish(streamErr)
+		return false
+	case ResponseEventCreated:
+		if event.Response != nil {
+			s.latestID = event.Response.ID
+		}
+	case ResponseEventOutputTextDelta:
+		s.text.WriteString(event.Delta)
+	case ResponseEventOutputItemDone:
+		if event.Item != nil {
+			s.items = append(s.items, *event.Item)
+		}
+	case ResponseEventCompleted, ResponseEventIncomplete:
+		s.final = event.Response
+		s.finish(nil)
+	}
+
+	s.current = &event
+	return true
+}
+
+// Current returns the event read by the last successful Next.
+func (s *ResponseStream) Current() *ResponseStreamEvent {
+	return s.current
+}
+
+// Response returns the terminal response object when the stream reached it,
+// otherwise a partial response assembled from the deltas seen so far.
+func (s *ResponseStream) Response() *Response {
+	if s.final != nil {
+		return s.final
+	}
+	return &Response{
+		ID:         s.latestID,
+		Object:     "response",
+		Status:     "in_progress",
+		Output:     append([]ResponseOutputItem(nil), s.items...),
+		OutputText: s.text.String(),
+	}
+}
@@ -0,0 +1,116 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/responses" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var body struct {
+			Input []map[string]any `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(body.Input) != 2 || body.Input[1]["type"] != "function_call_output" || body.Input[1]["call_id"] != "call_1" {
+			t.Errorf("unexpected input: %v", body.Input)
+		}
+		_, _ = w.Write([]byte(`{"id":"resp_1","object":"response","status":"completed","output":[
+			{"type":"message","role":"assistant","content":[{"type":"output_text","text":"done"}]},
+			{"type":"function_call","call_id":"call_2","name":"lookup","arguments":"{}"}
+		],"usage":{"input_tokens":3,"output_tokens":4,"total_tokens":7}}`))
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(WithBaseURL(srv.URL)).Responses(context.Background(), &ResponseRequest{
+		Model: "gpt-4o-mini",
+		Input: []ResponseInputItem{
+			ResponseInputMessage(RoleUser, "hi"),
+			FunctionCallOutput("call_1", `{"ok":true}`),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Responses: %v", err)
+	}
+	if resp.Text() != "done" || resp.Usage.TotalTokens != 7 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if calls := resp.FunctionCalls(); len(calls) != 1 || calls[0].CallID != "call_2" {
+		t.Fatalf("unexpected function calls: %+v", calls)
+	}
+}
+
+func TestResponsesStream(t *testing.T) {
+	events := []string{
+		`{"type":"response.created","response":{"id":"resp_1","object":"response","status":"in_progress","output":[]}}`,
+		`{"type":"response.output_text.delta","delta":"Hel"}`,
+		`{"type":"response.output_text.delta","delta":"lo"}`,
+		`{"type":"response.output_item.done","item":{"type":"function_call","call_id":"call_1","name":"lookup","arguments":"{}"}}`,
+		`{"type":"response.completed","response":{"id":"resp_1","object":"response","status":"completed","output_text":"Hello","output":[]}}`,
+		`{"type":"response.output_text.delta","delta":"ignored"}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		for _, event := range events {
+			fmt.Fprintf(w, "data: %s\n\n", event)
+		}
+	}))
+	defer srv.Close()
+
+	stream, err := NewClient(WithBaseURL(srv.URL)).ResponsesStream(context.Background(), &ResponseRequest{Model: "m"})
+	if err != nil {
+		t.Fatalf("ResponsesStream: %v", err)
+	}
+	defer stream.Close()
+
+	var deltas string
+	var count int
+	for stream.Next() {
+		count++
+		if stream.Current().Type == ResponseEventOutputTextDelta {
+			deltas += stream.Current().Delta
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	if count != 5 || deltas != "Hello" {
+		t.Fatalf("count=%d deltas=%q", count, deltas)
+	}
+	if resp := stream.Response(); resp.Status != "completed" || resp.Text() != "Hello" {
+		t.Fatalf("unexpected final response: %+v", resp)
+	}
+}
+
+func TestResponsesStreamFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"type\":\"response.output_text.delta\",\"delta\":\"par\"}\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"response.failed\",\"response\":{\"id\":\"resp_1\",\"status\":\"failed\",\"error\":{\"code\":\"server_error\",\"message\":\"upstream closed\"}}}\n\n")
+	}))
+	defer srv.Close()
+
+	stream, err := NewClient(WithBaseURL(srv.URL)).ResponsesStream(context.Background(), &ResponseRequest{Model: "m"})
+	if err != nil {
+		t.Fatalf("ResponsesStream: %v", err)
+	}
+	defer stream.Close()
+	for stream.Next() {
+	}
+	var streamErr *StreamError
+	if !errors.As(stream.Err(), &streamErr) || streamErr.Code != "server_error" || streamErr.Message != "upstream closed" {
+		t.Fatalf("unexpected error: %v", stream.Err())
+	}
+	if stream.Response().Status != "failed" {
+		t.Fatalf("final response should be the failed response")
+	}
+}
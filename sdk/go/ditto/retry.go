@@ -0,0 +1,378 @@
+package ditto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/omne42/ditto-llm/sdk/go/ditto/plugin"
+)
+
+const (
+	// DefaultRetryInitialBackoff is the first retry delay when
+	// RetryPolicy.InitialBackoff is unset.
+	DefaultRetryInitialBackoff = 250 * time.Millisecond
+	// DefaultRetryMaxBackoff caps retry delays when RetryPolicy.MaxBackoff
+	// is unset.
+	DefaultRetryMaxBackoff = 8 * time.Second
+	// DefaultEndpointCooldown is how long a base URL that failed to answer
+	// is skipped by other calls.
+	DefaultEndpointCooldown = 30 * time.Second
+
+	headerIdempotencyKey = "idempotency-key"
+)
+
+// ErrAttemptTimeout is the cause of an attempt that got no response headers
+// within RetryPolicy.AttemptTimeout.
+var ErrAttemptTimeout = errors.New("ditto: attempt timed out")
+
+// RetryPolicy retries failed calls, moving to another base URL when several
+// are configured. Calls are only retried before any response body is
+// handed to the caller: a stream that fails midway is not replayed, and
+// streaming multipart uploads (audio, files) are sent once.
+//
+// A retried call that is not a GET carries an `idempotency-key` (generated
+// unless the caller set one), so the gateway replays the first response
+// instead of calling and billing the upstream twice.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts per call, the first included;
+	// values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles for
+	// each later retry up to MaxBackoff. Each delay is jittered down to half
+	// its value. A `retry-after` sent with a 429 or 503 is used instead, and
+	// one longer than MaxBackoff ends the retries.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// AttemptTimeout bounds how long each attempt waits for response
+	// headers, so a hung gateway node is abandoned for the next one while
+	// the client timeout still bounds the whole call. Zero disables it.
+	AttemptTimeout time.Duration
+	// Retryable reports whether a failed attempt is retried; nil means
+	// IsRetryable.
+	Retryable func(err error) bool
+}
+
+// WithRetry sets the retry policy of every call.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithRequestRetry overrides the client retry policy for one call;
+// RetryPolicy{} disables retries.
+func WithRequestRetry(policy RetryPolicy) RequestOption {
+	return func(rc *requestConfig) {
+		rc.retry = &policy
+	}
+}
+
+// IsRetryable reports whether err is worth another attempt: transport
+// errors, attempt timeouts, and 408, 429, 500, 502, 503 and 504 responses.
+// Budget (402), auth and validation errors are not.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var urlErr *url.Error
+	return errors.Is(err, ErrAttemptTimeout) || errors.As(err, &urlErr)
+}
+
+// LoadBalancing picks the base URL of each call when several are set with
+// WithBaseURLs.
+type LoadBalancing int
+
+const (
+	// LoadBalanceFailover sends calls to the first healthy base URL, in
+	// the order given, and only uses the next ones when it fails.
+	LoadBalanceFailover LoadBalancing = iota
+	// LoadBalanceRoundRobin spreads calls across the healthy base URLs.
+	LoadBalanceRoundRobin
+)
+
+// WithBaseURLs sets several gateway root URLs to fail over between (or
+// balance across, see WithLoadBalancing). A base URL that fails to answer
+// is skipped for DefaultEndpointCooldown (see WithEndpointCooldown);
+// combine with WithRetry so the failed call itself moves on to the next
+// one. A plugin.Router set with WithRouter takes precedence.
+func WithBaseURLs(baseURLs ...string) Option {
+	return func(c *Client) {
+		c.baseURLs = nil
+		for _, baseURL := range baseURLs {
+			if baseURL = normalizeBaseURL(baseURL); baseURL != "" {
+				c.baseURLs = append(c.baseURLs, baseURL)
+			}
+		}
+		if len(c.baseURLs) > 0 {
+			c.baseURL = c.baseURLs[0]
+		}
+	}
+}
+
+// WithLoadBalancing selects how calls are spread across WithBaseURLs;
+// the default is LoadBalanceFailover.
+func WithLoadBalancing(lb LoadBalancing) Option {
+	return func(c *Client) {
+		c.loadBalancing = lb
+	}
+}
+
+// WithEndpointCooldown sets how long a base URL that failed to answer is
+// skipped by other calls.
+func WithEndpointCooldown(cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.endpointCooldown = cooldown
+	}
+}
+
+// endpointPool tracks the health of the WithBaseURLs endpoints.
+type endpointPool struct {
+	urls     []string
+	strategy LoadBalancing
+	cooldown time.Duration
+
+	mu        sync.Mutex
+	downUntil []time.Time
+	next      int
+}
+
+func newEndpointPool(urls []string, strategy LoadBalancing, cooldown time.Duration) *endpointPool {
+	return &endpointPool{
+		urls:      urls,
+		strategy:  strategy,
+		cooldown:  cooldown,
+		downUntil: make([]time.Time, len(urls)),
+	}
+}
+
+// pick returns the endpoint of the next attempt: the first healthy one in
+// strategy order that this call has not tried yet, else the untried one
+// that recovers soonest. Once every endpoint was tried, all are candidates
+// again.
+func (p *endpointPool) pick(tried []bool) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := len(p.urls)
+	start := 0
+	if p.strategy == LoadBalanceRoundRobin {
+		start = p.next
+		p.next = (p.next + 1) % n
+	}
+	untried := false
+	for _, t := range tried {
+		untried = untried || !t
+	}
+	now := time.Now()
+	best := -1
+	for k := range n {
+		i := (start + k) % n
+		if untried && tried[i] {
+			continue
+		}
+		if !now.Before(p.downUntil[i]) {
+			return i
+		}
+		if best < 0 || p.downUntil[i].Before(p.downUntil[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+func (p *endpointPool) markDown(i int) {
+	p.mu.Lock()
+	p.downUntil[i] = time.Now().Add(p.cooldown)
+	p.mu.Unlock()
+}
+
+func (p *endpointPool) markUp(i int) {
+	p.mu.Lock()
+	p.downUntil[i] = time.Time{}
+	p.mu.Unlock()
+}
+
+// send performs one call under the retry policy and returns the first 2xx
+// response; the last failure is returned otherwise, with non-2xx responses
+// read into an *APIError. prepare sets per-call headers on each attempt.
+// The caller closes resp.Body, then calls the returned cancel func.
+func (c *Client) send(
+	ctx context.Context,
+	method, path string,
+	body io.Reader,
+	rc *requestConfig,
+	prepare func(*http.Request),
+) (*http.Response, context.CancelFunc, error) {
+	policy := c.retry
+	if rc.retry != nil {
+		policy = *rc.retry
+	}
+	attempts := max(policy.MaxAttempts, 1)
+	seeker, seekable := body.(io.Seeker)
+	if body != nil && !seekable {
+		attempts = 1
+	}
+	if attempts > 1 && method != http.MethodGet && rc.header.Get(headerIdempotencyKey) == "" {
+		rc.header.Set(headerIdempotencyKey, NewRequestID())
+	}
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	call := plugin.Call{Method: method, Path: path}
+	var tried []bool
+	if c.endpoints != nil {
+		tried = make([]bool, len(c.endpoints.urls))
+	}
+	var (
+		lastErr      error
+		lastEndpoint = -1
+		nodeFailed   bool
+	)
+	for attempt := 0; attempt < attempts; attempt++ {
+		endpoint := -1
+		baseURL := c.baseURL
+		if c.router != nil {
+			var err error
+			if baseURL, err = c.routeBaseURL(ctx, call); err != nil {
+				return nil, nil, err
+			}
+		} else if c.endpoints != nil {
+			endpoint = c.endpoints.pick(tried)
+			tried[endpoint] = true
+			baseURL = c.endpoints.urls[endpoint]
+		}
+		if attempt > 0 {
+			// Moving past a node that did not answer needs no backoff.
+			failover := nodeFailed && endpoint != lastEndpoint
+			if !failover {
+				delay, ok := policy.backoff(attempt, lastErr)
+				if !ok {
+					return nil, nil, lastErr
+				}
+				if err := sleepCtx(ctx, delay); err != nil {
+					return nil, nil, fmt.Errorf("ditto: %s %s: %w", method, path, err)
+				}
+			}
+			if seeker != nil {
+				if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+					return nil, nil, lastErr
+				}
+			}
+		}
+
+		attemptCtx, cancel := context.WithCancel(ctx)
+		req, err := c.newRequest(attemptCtx, method, baseURL+path, body, rc)
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		prepare(req)
+		resp, err := c.roundTrip(attemptCtx, call, req, cancel, policy.AttemptTimeout)
+		var apiErr *APIError
+		if err == nil {
+			rc.meta.record(resp)
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				if endpoint >= 0 {
+					c.endpoints.markUp(endpoint)
+				}
+				return resp, cancel, nil
+			}
+			respBody, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			apiErr = newAPIError(resp, respBody)
+			err = apiErr
+		}
+		cancel()
+
+		nodeFailed = apiErr == nil
+		if nodeFailed && endpoint >= 0 && ctx.Err() == nil {
+			c.endpoints.markDown(endpoint)
+		}
+		lastErr, lastEndpoint = err, endpoint
+		if ctx.Err() != nil || !retryable(err) {
+			break
+		}
+	}
+	return nil, nil, lastErr
+}
+
+// roundTrip sends req, cancelling it through cancel when no response
+// headers arrive within timeout. Only transport failures are errors; any
+// response is returned as-is.
+func (c *Client) roundTrip(
+	ctx context.Context,
+	call plugin.Call,
+	req *http.Request,
+	cancel context.CancelFunc,
+	timeout time.Duration,
+) (*http.Response, error) {
+	var timer *time.Timer
+	if timeout > 0 {
+		timer = time.AfterFunc(timeout, cancel)
+	}
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if timer != nil && !timer.Stop() {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		resp, err = nil, fmt.Errorf("%w after %s", ErrAttemptTimeout, timeout)
+	}
+	c.logCall(ctx, call, start, resp, err)
+	if err != nil {
+		return nil, fmt.Errorf("ditto: %s %s: %w", call.Method, call.Path, err)
+	}
+	return resp, nil
+}
+
+// backoff returns the delay before retry number attempt (1-based),
+// honouring the `retry-after` of the failed attempt. It reports false when
+// the server asks for a longer wait than MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, lastErr error) (time.Duration, bool) {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = DefaultRetryInitialBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+	var apiErr *APIError
+	if errors.As(lastErr, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, apiErr.RetryAfter <= maxBackoff
+	}
+	delay := initial << min(attempt-1, 30)
+	if delay <= 0 || delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay/2 + rand.N(delay/2+1), true
+}
+
+func sleepCtx(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,198 @@
+package ditto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const okChat = `{"choices":[{"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func chatRequest() *ChatCompletionRequest {
+	return &ChatCompletionRequest{Model: "m", Messages: []ChatMessage{UserMessage("hi")}}
+}
+
+func TestRetryRetriesWithStableIdempotencyKey(t *testing.T) {
+	var calls atomic.Int32
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("idempotency-key"))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"message":"upstream unavailable","type":"api_error"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(okChat))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithRetry(fastRetry))
+	resp, err := c.ChatCompletions(context.Background(), chatRequest())
+	if err != nil || resp.FirstContent() != "ok" {
+		t.Fatalf("ChatCompletions: %v", err)
+	}
+	if calls.Load() != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("calls = %d, idempotency keys = %q", calls.Load(), keys)
+	}
+
+	// A caller-supplied key is kept across attempts.
+	calls.Store(0)
+	keys = nil
+	if _, err := c.ChatCompletions(context.Background(), chatRequest(), WithRequestHeader("Idempotency-Key", "mine")); err != nil {
+		t.Fatalf("ChatCompletions: %v", err)
+	}
+	if keys[0] != "mine" || keys[1] != "mine" {
+		t.Fatalf("idempotency keys = %q", keys)
+	}
+}
+
+func TestRetryStopsOnNonRetryableErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		header map[string]string
+	}{
+		{"budget", http.StatusPaymentRequired, nil},
+		{"bad request", http.StatusBadRequest, nil},
+		{"retry-after beyond max backoff", http.StatusTooManyRequests, map[string]string{"retry-after": "30"}},
+	} {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			for k, v := range tc.header {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(tc.status)
+			_, _ = w.Write([]byte(`{"error":{"message":"no","type":"invalid_request_error"}}`))
+		}))
+		_, err := NewClient(WithBaseURL(srv.URL), WithRetry(fastRetry)).ChatCompletions(context.Background(), chatRequest())
+		srv.Close()
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tc.status || calls.Load() != 1 {
+			t.Errorf("%s: err = %v, calls = %d", tc.name, err, calls.Load())
+		}
+	}
+}
+
+func TestRetryDisabledPerRequest(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithRetry(fastRetry))
+	if _, err := c.ChatCompletions(context.Background(), chatRequest(), WithRequestRetry(RetryPolicy{})); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("calls = %d", calls.Load())
+	}
+	calls.Store(0)
+	if _, err := c.ChatCompletions(context.Background(), chatRequest()); !IsRetryable(err) {
+		t.Fatalf("err = %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("calls = %d, want MaxAttempts", calls.Load())
+	}
+}
+
+func TestFailoverSkipsUnresponsiveBaseURL(t *testing.T) {
+	var hungCalls, okCalls atomic.Int32
+	release := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hungCalls.Add(1)
+		<-release
+	}))
+	defer hung.Close()
+	defer close(release)
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		okCalls.Add(1)
+		_, _ = w.Write([]byte(okChat))
+	}))
+	defer ok.Close()
+
+	policy := fastRetry
+	policy.InitialBackoff = time.Hour // failing over after a node failure must not back off
+	policy.MaxBackoff = time.Hour
+	policy.AttemptTimeout = 50 * time.Millisecond
+	c := NewClient(WithBaseURLs(hung.URL, ok.URL), WithRetry(policy))
+	for i := range 3 {
+		if _, err := c.ChatCompletions(context.Background(), chatRequest()); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if hungCalls.Load() != 1 || okCalls.Load() != 3 {
+		t.Fatalf("hung calls = %d, ok calls = %d; want the hung node skipped while cooling down", hungCalls.Load(), okCalls.Load())
+	}
+}
+
+func TestRetryAttemptTimeout(t *testing.T) {
+	release := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer hung.Close()
+	defer close(release)
+
+	c := NewClient(WithBaseURL(hung.URL), WithRetry(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, AttemptTimeout: 20 * time.Millisecond}))
+	_, err := c.ChatCompletions(context.Background(), chatRequest())
+	if !errors.Is(err, ErrAttemptTimeout) || !IsRetryable(err) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestRoundRobinSpreadsCalls(t *testing.T) {
+	var hits [2]atomic.Int32
+	var urls []string
+	for i := range hits {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits[i].Add(1)
+			_, _ = w.Write([]byte(okChat))
+		}))
+		defer srv.Close()
+		urls = append(urls, srv.URL)
+	}
+
+	c := NewClient(WithBaseURLs(urls...), WithLoadBalancing(LoadBalanceRoundRobin))
+	for range 4 {
+		if _, err := c.ChatCompletions(context.Background(), chatRequest()); err != nil {
+			t.Fatalf("ChatCompletions: %v", err)
+		}
+	}
+	if hits[0].Load() != 2 || hits[1].Load() != 2 || c.BaseURL() != urls[0] {
+		t.Fatalf("hits = %d/%d", hits[0].Load(), hits[1].Load())
+	}
+}
+
+func TestRetryStreamBeforeFirstByte(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("content-type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"hi\"}}]}\n\ndata: [DONE]\n\n")
+	}))
+	defer srv.Close()
+
+	stream, err := NewClient(WithBaseURL(srv.URL), WithRetry(fastRetry)).ChatCompletionsStream(context.Background(), chatRequest())
+	if err != nil {
+		t.Fatalf("ChatCompletionsStream: %v", err)
+	}
+	defer stream.Close()
+	for stream.Next() {
+	}
+	if err := stream.Err(); err != nil || stream.Response().FirstContent() != "hi" || calls.Load() != 2 {
+		t.Fatalf("err = %v, calls = %d", err, calls.Load())
+	}
+}
@@ -0,0 +1,199 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultRunnerMaxTurns bounds the model calls of one Runner.Run when
+// Runner.MaxTurns is unset.
+const DefaultRunnerMaxTurns = 8
+
+// ErrMaxTurns is returned by Runner.Run when the model still asks for tools
+// after MaxTurns calls. The returned RunResult holds the transcript so far.
+var ErrMaxTurns = errors.New("ditto: tool loop exceeded max turns")
+
+// ToolFunc handles one call of a registered tool. args is the JSON-encoded
+// arguments the model sent; the returned string is sent back as the tool
+// message content.
+type ToolFunc func(ctx context.Context, args json.RawMessage) (string, error)
+
+// Runner executes chat completions tool-calling loops: it offers the
+// registered tools to the model, runs the Go function of every tool call the
+// model makes, sends the results back, and repeats until the model answers
+// without tool calls.
+//
+// Register tools before the first Run; a Runner is then safe for concurrent
+// Run calls.
+type Runner struct {
+	client *Client
+	tools  []Tool
+	funcs  map[string]ToolFunc
+	// MaxTurns bounds the model calls of one Run; 0 means
+	// DefaultRunnerMaxTurns.
+	MaxTurns int
+}
+
+// NewRunner returns a Runner that calls the model through client.
+func NewRunner(client *Client) *Runner {
+	return &Runner{client: client, funcs: make(map[string]ToolFunc)}
+}
+
+// Register adds a function tool. parameters is its JSON Schema; fn runs for
+// every call of the tool. Registering a name again replaces it.
+func (r *Runner) Register(name, description string, parameters json.RawMessage, fn ToolFunc) *Runner {
+	tool := FunctionTool(name, description, parameters)
+	if _, ok := r.funcs[name]; ok {
+		for i := range r.tools {
+			if r.tools[i].Function.Name == name {
+				r.tools[i] = tool
+			}
+		}
+	} else {
+		r.tools = append(r.tools, tool)
+	}
+	r.funcs[name] = fn
+	return r
+}
+
+// RegisterFunc adds a function tool whose arguments are decoded into A. A
+// string result is sent as-is; any other result is sent JSON-encoded.
+func RegisterFunc[A any, R any](
+	r *Runner,
+	name, description string,
+	parameters json.RawMessage,
+	fn func(ctx context.Context, args A) (R, error),
+) *Runner {
+	return r.Register(name, description, parameters, func(ctx context.Context, raw json.RawMessage) (string, error) {
+		var args A
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return "", fmt.Errorf("invalid arguments: %w", err)
+			}
+		}
+		out, err := fn(ctx, args)
+		if err != nil {
+			return "", err
+		}
+		if s, ok := any(out).(string); ok {
+			return s, nil
+		}
+		encoded, err := json.Marshal(out)
+		if err != nil {
+			return "", fmt.Errorf("encode result: %w", err)
+		}
+		return string(encoded), nil
+	})
+}
+
+// RunResult is the outcome of Runner.Run.
+type RunResult struct {
+	// Response is the last model response: the final answer, or the last
+	// tool-calling turn when Run stopped early.
+	Response *ChatCompletionResponse
+	// Messages is the full conversation: the request messages, then every
+	// assistant message and tool result, ending with the final answer.
+	Messages []ChatMessage
+	// Turns counts the model calls made.
+	Turns int
+	// Usage sums the usage of every turn that reported it.
+	Usage Usage
+}
+
+// Content returns the final answer text.
+func (r *RunResult) Content() string {
+	if r == nil {
+		return ""
+	}
+	return r.Response.FirstContent()
+}
+
+// Run sends req, handling tool calls until the model answers without them.
+// The registered tools are appended to req.Tools; tool calls of tools that
+// req declares but the Runner does not know are answered with an error
+// message, as are calls whose function fails, so the model can recover. Run
+// only stops early when ctx is done, a call fails, or MaxTurns is reached.
+//
+// opts apply to every turn. A request id or idempotency key set through them
+// gets a `-<turn>` suffix from the second turn on, so the gateway does not
+// replay the first turn.
+func (r *Runner) Run(ctx context.Context, req *ChatCompletionRequest, opts ...RequestOption) (*RunResult, error) {
+	maxTurns := r.MaxTurns
+	if maxTurns <= 0 {
+		maxTurns = DefaultRunnerMaxTurns
+	}
+	body := *req
+	body.Tools = append(append([]Tool(nil), req.Tools...), r.tools...)
+	result := &RunResult{Messages: append([]ChatMessage(nil), req.Messages...)}
+
+	for result.Turns < maxTurns {
+		body.Messages = result.Messages
+		turnOpts := append(append([]RequestOption(nil), opts...), perTurnRequestKeys(result.Turns+1))
+		resp, err := r.client.ChatCompletions(ctx, &body, turnOpts...)
+		if err != nil {
+			return result, err
+		}
+		result.Turns++
+		result.Response = resp
+		result.Usage.add(resp.Usage)
+		if len(resp.Choices) == 0 {
+			return result, errors.New("ditto: chat completion returned no choices")
+		}
+		msg := resp.Choices[0].Message
+		result.Messages = append(result.Messages, msg)
+		if len(msg.ToolCalls) == 0 {
+			return result, nil
+		}
+		for _, call := range msg.ToolCalls {
+			content, err := r.call(ctx, call)
+			if err != nil {
+				return result, err
+			}
+			result.Messages = append(result.Messages, ToolMessage(call.ID, content))
+		}
+	}
+	return result, ErrMaxTurns
+}
+
+// call runs one tool call. Only a done ctx is returned as an error; other
+// failures become the tool message content.
+func (r *Runner) call(ctx context.Context, call ToolCall) (string, error) {
+	fn, ok := r.funcs[call.Function.Name]
+	if !ok {
+		return fmt.Sprintf("error: unknown tool %q", call.Function.Name), nil
+	}
+	content, err := fn(ctx, json.RawMessage(call.Function.Arguments))
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return "", ctxErr
+	}
+	if err != nil {
+		return "error: " + err.Error(), nil
+	}
+	return content, nil
+}
+
+// perTurnRequestKeys keeps caller-supplied dedup keys distinct per turn.
+func perTurnRequestKeys(turn int) RequestOption {
+	return func(rc *requestConfig) {
+		if turn <= 1 {
+			return
+		}
+		for _, key := range []string{HeaderRequestID, "idempotency-key"} {
+			if id := rc.header.Get(key); id != "" {
+				rc.header.Set(key, fmt.Sprintf("%s-%d", id, turn))
+			}
+		}
+	}
+}
+
+func (u *Usage) add(other *Usage) {
+	if other == nil {
+		return
+	}
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.TotalTokens += other.TotalTokens
+	u.Cost += other.Cost
+}
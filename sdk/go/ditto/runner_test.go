@@ -0,0 +1,157 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRunnerToolLoop(t *testing.T) {
+	turn := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		turn++
+		var body ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		wantID := "run-1"
+		if turn > 1 {
+			wantID = "run-1-2"
+		}
+		if got := r.Header.Get(HeaderRequestID); got != wantID {
+			t.Errorf("turn %d: x-request-id = %q, want %q", turn, got, wantID)
+		}
+		if len(body.Tools) != 2 || body.Tools[0].Function.Name != "get_weather" {
+			t.Errorf("turn %d: tools = %+v", turn, body.Tools)
+		}
+		w.Header().Set("content-type", "application/json")
+		switch turn {
+		case 1:
+			if len(body.Messages) != 1 {
+				t.Errorf("turn 1: messages = %+v", body.Messages)
+			}
+			_, _ = w.Write([]byte(`{
+				"choices": [{"message": {"role": "assistant", "content": "", "tool_calls": [
+					{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}},
+					{"id": "call_2", "type": "function", "function": {"name": "lookup", "arguments": "{}"}},
+					{"id": "call_3", "type": "function", "function": {"name": "fails", "arguments": "{}"}}
+				]}, "finish_reason": "tool_calls"}],
+				"usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}
+			}`))
+		case 2:
+			if len(body.Messages) != 5 {
+				t.Fatalf("turn 2: messages = %+v", body.Messages)
+			}
+			results := map[string]string{}
+			for _, msg := range body.Messages[2:] {
+				if msg.Role != "tool" {
+					t.Errorf("turn 2: unexpected message %+v", msg)
+				}
+				results[msg.ToolCallID] = msg.Content
+			}
+			if results["call_1"] != `{"city":"Paris","temp_c":21}` {
+				t.Errorf("call_1 result = %q", results["call_1"])
+			}
+			if results["call_2"] != `error: unknown tool "lookup"` {
+				t.Errorf("call_2 result = %q", results["call_2"])
+			}
+			if results["call_3"] != "error: backend down" {
+				t.Errorf("call_3 result = %q", results["call_3"])
+			}
+			_, _ = w.Write([]byte(`{
+				"choices": [{"message": {"role": "assistant", "content": "21°C in Paris."}, "finish_reason": "stop"}],
+				"usage": {"prompt_tokens": 30, "completion_tokens": 6, "total_tokens": 36}
+			}`))
+		default:
+			t.Errorf("unexpected turn %d", turn)
+		}
+	}))
+	defer srv.Close()
+
+	type weatherArgs struct {
+		City string `json:"city"`
+	}
+	type weather struct {
+		City  string `json:"city"`
+		TempC int    `json:"temp_c"`
+	}
+	runner := NewRunner(NewClient(WithBaseURL(srv.URL), WithToken("vk")))
+	RegisterFunc(runner, "get_weather", "Current weather", json.RawMessage(`{"type":"object"}`),
+		func(_ context.Context, args weatherArgs) (weather, error) {
+			return weather{City: args.City, TempC: 21}, nil
+		})
+	runner.Register("fails", "Always fails", nil, func(context.Context, json.RawMessage) (string, error) {
+		return "", errors.New("backend down")
+	})
+
+	result, err := runner.Run(context.Background(), &ChatCompletionRequest{
+		Model:    "gpt-4o-mini",
+		Messages: []ChatMessage{UserMessage("Weather in Paris?")},
+	}, WithRequestID("run-1"))
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Turns != 2 || result.Content() != "21°C in Paris." {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Messages) != 6 || result.Messages[5].Role != "assistant" {
+		t.Fatalf("unexpected transcript: %+v", result.Messages)
+	}
+	if result.Usage.TotalTokens != 51 || result.Usage.PromptTokens != 40 {
+		t.Fatalf("unexpected usage: %+v", result.Usage)
+	}
+}
+
+func TestRunnerMaxTurns(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "tool_calls": [
+			{"id": "call", "type": "function", "function": {"name": "again", "arguments": "{}"}}
+		]}, "finish_reason": "tool_calls"}]}`))
+	}))
+	defer srv.Close()
+
+	runner := NewRunner(NewClient(WithBaseURL(srv.URL)))
+	runner.MaxTurns = 3
+	runner.Register("again", "", nil, func(context.Context, json.RawMessage) (string, error) {
+		return "ok", nil
+	})
+	result, err := runner.Run(context.Background(), &ChatCompletionRequest{
+		Model:    "gpt-4o-mini",
+		Messages: []ChatMessage{UserMessage("loop")},
+	})
+	if !errors.Is(err, ErrMaxTurns) {
+		t.Fatalf("err = %v, want ErrMaxTurns", err)
+	}
+	if calls != 3 || result.Turns != 3 || len(result.Messages) != 7 {
+		t.Fatalf("calls = %d, result = %+v", calls, result)
+	}
+}
+
+func TestRunnerStopsOnAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error": {"message": "slow down", "type": "rate_limit_error", "code": "rate_limited"}}`))
+	}))
+	defer srv.Close()
+
+	runner := NewRunner(NewClient(WithBaseURL(srv.URL)))
+	result, err := runner.Run(context.Background(), &ChatCompletionRequest{
+		Model:    "gpt-4o-mini",
+		Messages: []ChatMessage{UserMessage("hi")},
+	})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("err = %v", err)
+	}
+	if result.Turns != 0 || !strings.Contains(err.Error(), "slow down") {
+		t.Fatalf("unexpected result %+v / %v", result, err)
+	}
+}
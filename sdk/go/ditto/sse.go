@@ -0,0 +1,143 @@
+package ditto
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+// sseEvent is one dispatched `text/event-stream` event.
+type sseEvent struct {
+	Event string
+	Data  []byte
+}
+
+// sseReader decodes a `text/event-stream` body. It follows the WHATWG
+// dispatch rules the gateway relies on: `data:` lines are joined with "\n",
+// comment lines (`:`) are skipped, and a blank line dispatches the event.
+type sseReader struct {
+	r *bufio.Reader
+}
+
+func newSSEReader(r io.Reader) *sseReader {
+	return &sseReader{r: bufio.NewReaderSize(r, 64*1024)}
+}
+
+// Next returns the next event with a non-empty data payload. It returns
+// io.EOF once the body ends; a trailing event without a blank line is still
+// dispatched.
+func (s *sseReader) Next() (sseEvent, error) {
+	var (
+		event   string
+		data    bytes.Buffer
+		hasData bool
+	)
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF && hasData {
+				return sseEvent{Event: event, Data: data.Bytes()}, nil
+			}
+			return sseEvent{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if hasData {
+				return sseEvent{Event: event, Data: data.Bytes()}, nil
+			}
+			event = ""
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		}
+	}
+}
+
+// eventStream owns a streaming response body and yields its SSE events. The
+// typed streams embed it and decode the payloads.
+type eventStream struct {
+	ctx    context.Context
+	body   io.ReadCloser
+	cancel context.CancelFunc
+	reader *sseReader
+	err    error
+	done   bool
+}
+
+func newEventStream(ctx context.Context, body io.ReadCloser, cancel context.CancelFunc) eventStream {
+	return eventStream{
+		ctx:    ctx,
+		body:   body,
+		cancel: cancel,
+		reader: newSSEReader(body),
+	}
+}
+
+// nextEvent returns the next event with non-blank data, or false once the
+// body ends, ctx is cancelled, or the stream was finished.
+func (s *eventStream) nextEvent() (sseEvent, bool) {
+	for !s.done {
+		event, err := s.reader.Next()
+		if err != nil {
+			if ctxErr := s.ctx.Err(); ctxErr != nil {
+				err = ctxErr
+			}
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			s.finish(err)
+			break
+		}
+		event.Data = bytes.TrimSpace(event.Data)
+		if len(event.Data) == 0 {
+			continue
+		}
+		return event, true
+	}
+	return sseEvent{}, false
+}
+
+func (s *eventStream) finish(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+	s.done = true
+}
+
+// Err returns the error that stopped iteration, if any.
+func (s *eventStream) Err() error {
+	return s.err
+}
+
+// Close releases the connection. It is safe to call more than once.
+func (s *eventStream) Close() error {
+	s.done = true
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	if s.body == nil {
+		return nil
+	}
+	err := s.body.Close()
+	s.body = nil
+	return err
+}
@@ -0,0 +1,39 @@
+package ditto
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSSEReaderDispatchRules(t *testing.T) {
+	input := ": keepalive\r\n" +
+		"event: delta\r\n" +
+		"data: {\"a\":\r\n" +
+		"data: 1}\r\n" +
+		"\r\n" +
+		"\n" +
+		"data:no-space\n" +
+		"\n" +
+		"data: trailing"
+
+	r := newSSEReader(strings.NewReader(input))
+	want := []sseEvent{
+		{Event: "delta", Data: []byte("{\"a\":\n1}")},
+		{Data: []byte("no-space")},
+		{Data: []byte("trailing")},
+	}
+	for i, w := range want {
+		got, err := r.Next()
+		if err != nil {
+			t.Fatalf("event %d: %v", i, err)
+		}
+		if got.Event != w.Event || string(got.Data) != string(w.Data) {
+			t.Fatalf("event %d = %q/%q, want %q/%q", i, got.Event, got.Data, w.Event, w.Data)
+		}
+	}
+	if _, err := r.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package ditto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// JSONSchemaFormat returns a strict `json_schema` ResponseFormat, the usual
+// companion of ParseInto.
+func JSONSchemaFormat(name string, schema json.RawMessage) *ResponseFormat {
+	return &ResponseFormat{
+		Type:       "json_schema",
+		JSONSchema: &JSONSchema{Name: name, Schema: schema, Strict: Ptr(true)},
+	}
+}
+
+// ParseInto decodes the first choice of a structured-output chat completion
+// into T. A response cut off by the token limit is reported as such rather
+// than as invalid JSON; a Markdown code fence around the JSON is tolerated.
+func ParseInto[T any](resp *ChatCompletionResponse) (T, error) {
+	var out T
+	if resp == nil || len(resp.Choices) == 0 {
+		return out, errors.New("ditto: chat completion returned no choices")
+	}
+	choice := resp.Choices[0]
+	if choice.FinishReason == "length" {
+		return out, errors.New("ditto: structured output truncated (finish_reason=length)")
+	}
+	err := decodeStructured(choice.Message.Content, &out)
+	return out, err
+}
+
+// ParseResponseInto decodes the output text of a Responses API response
+// into T, like ParseInto.
+func ParseResponseInto[T any](resp *Response) (T, error) {
+	var out T
+	if resp == nil {
+		return out, errors.New("ditto: nil response")
+	}
+	err := decodeStructured(resp.Text(), &out)
+	return out, err
+}
+
+func decodeStructured(content string, out any) error {
+	text := strings.TrimSpace(content)
+	if rest, ok := strings.CutPrefix(text, "```"); ok {
+		rest = strings.TrimPrefix(rest, "json")
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(rest), "```"))
+	}
+	if text == "" {
+		return errors.New("ditto: structured output is empty")
+	}
+	if err := json.Unmarshal([]byte(text), out); err != nil {
+		return fmt.Errorf("ditto: decode structured output: %w", err)
+	}
+	return nil
+}
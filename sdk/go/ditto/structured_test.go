@@ -0,0 +1,96 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type cityInfo struct {
+	City       string `json:"city"`
+	Population int    `json:"population"`
+}
+
+func TestParseInto(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		format, _ := body["response_format"].(map[string]any)
+		schema, _ := format["json_schema"].(map[string]any)
+		if format["type"] != "json_schema" || schema["name"] != "city" || schema["strict"] != true {
+			t.Errorf("response_format = %v", body["response_format"])
+		}
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"choices": [{"message": {"role": "assistant", "content": "{\"city\":\"Paris\",\"population\":2100000}"}, "finish_reason": "stop"}]
+		}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	resp, err := c.ChatCompletions(context.Background(), &ChatCompletionRequest{
+		Model:    "gpt-4o-mini",
+		Messages: []ChatMessage{UserMessage("Describe Paris")},
+		ResponseFormat: JSONSchemaFormat("city", json.RawMessage(
+			`{"type":"object","properties":{"city":{"type":"string"},"population":{"type":"integer"}},"required":["city","population"]}`,
+		)),
+	})
+	if err != nil {
+		t.Fatalf("ChatCompletions: %v", err)
+	}
+	info, err := ParseInto[cityInfo](resp)
+	if err != nil {
+		t.Fatalf("ParseInto: %v", err)
+	}
+	if info != (cityInfo{City: "Paris", Population: 2100000}) {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+}
+
+func TestParseIntoErrors(t *testing.T) {
+	fenced := &ChatCompletionResponse{Choices: []ChatCompletionChoice{{
+		Message: ChatMessage{Role: "assistant", Content: "```json\n{\"city\":\"Oslo\"}\n```"},
+	}}}
+	if info, err := ParseInto[cityInfo](fenced); err != nil || info.City != "Oslo" {
+		t.Fatalf("fenced: %+v, %v", info, err)
+	}
+
+	cases := map[string]struct {
+		resp *ChatCompletionResponse
+		want string
+	}{
+		"no choices": {&ChatCompletionResponse{}, "no choices"},
+		"truncated": {&ChatCompletionResponse{Choices: []ChatCompletionChoice{{
+			Message:      ChatMessage{Content: `{"city":"Pa`},
+			FinishReason: "length",
+		}}}, "truncated"},
+		"empty": {&ChatCompletionResponse{Choices: []ChatCompletionChoice{{
+			Message: ChatMessage{Content: "  "},
+		}}}, "empty"},
+		"invalid": {&ChatCompletionResponse{Choices: []ChatCompletionChoice{{
+			Message: ChatMessage{Content: "Sure! Paris."},
+		}}}, "decode structured output"},
+	}
+	for name, tc := range cases {
+		_, err := ParseInto[cityInfo](tc.resp)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestParseResponseInto(t *testing.T) {
+	resp := &Response{Output: []ResponseOutputItem{{
+		Type:    ResponseItemMessage,
+		Content: []ResponseOutputContent{{Type: "output_text", Text: `{"city":"Rome","population":2800000}`}},
+	}}}
+	info, err := ParseResponseInto[cityInfo](resp)
+	if err != nil || info.City != "Rome" || info.Population != 2800000 {
+		t.Fatalf("ParseResponseInto: %+v, %v", info, err)
+	}
+}
@@ -0,0 +1,14 @@
+package ditto
+
+import "strings"
+
+// HeaderTags carries comma-separated cost attribution tags for a request.
+const HeaderTags = "x-ditto-tags"
+
+// WithTags attaches cost attribution tags (e.g. "feature=summarizer",
+// "env=prod") to one call. The gateway records them, together with the
+// virtual key's Tags, on the request's usage record, where `/admin/spend/tags`
+// and `/admin/audit?tag=` pick them up. Tags must not contain commas.
+func WithTags(tags ...string) RequestOption {
+	return WithRequestHeader(HeaderTags, strings.Join(tags, ","))
+}
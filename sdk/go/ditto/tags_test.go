@@ -0,0 +1,24 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithTags(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(HeaderTags); got != "feature=summarizer,env=prod" {
+			t.Errorf("%s = %q", HeaderTags, got)
+		}
+		_, _ = w.Write([]byte(`{"id":"c1","choices":[]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL))
+	req := &ChatCompletionRequest{Model: "m", Messages: []ChatMessage{UserMessage("hi")}}
+	if _, err := c.ChatCompletions(context.Background(), req, WithTags("feature=summarizer", "env=prod")); err != nil {
+		t.Fatalf("ChatCompletions: %v", err)
+	}
+}
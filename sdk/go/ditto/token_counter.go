@@ -0,0 +1,36 @@
+package ditto
+
+import (
+	"context"
+	"net/http"
+)
+
+// TokenCountRequest is the body of `POST /utils/token_counter`. Set either
+// Messages or Prompt; Model may be a gateway alias.
+type TokenCountRequest struct {
+	Model    string        `json:"model"`
+	Messages []ChatMessage `json:"messages,omitempty"`
+	Prompt   string        `json:"prompt,omitempty"`
+}
+
+// TokenCountResponse is the response of `POST /utils/token_counter`.
+// ModelUsed is the upstream model the alias resolved to. Exact is false when
+// the gateway approximated the tokenizer (non-OpenAI models) or estimated
+// from bytes (TokenizerType "bytes_estimate").
+type TokenCountResponse struct {
+	TotalTokens   int    `json:"total_tokens"`
+	RequestModel  string `json:"request_model"`
+	ModelUsed     string `json:"model_used"`
+	TokenizerType string `json:"tokenizer_type"`
+	Exact         bool   `json:"exact"`
+}
+
+// CountTokens calls `POST /utils/token_counter` to count prompt tokens
+// without sending the request upstream.
+func (c *Client) CountTokens(ctx context.Context, req *TokenCountRequest, opts ...RequestOption) (*TokenCountResponse, error) {
+	var out TokenCountResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/utils/token_counter", req, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
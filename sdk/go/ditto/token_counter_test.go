@@ -0,0 +1,37 @@
+package ditto
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCountTokens(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/utils/token_counter" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		if body["model"] != "fast" || body["prompt"] != nil {
+			t.Errorf("body = %v", body)
+		}
+		_, _ = w.Write([]byte(`{"total_tokens":9,"request_model":"fast","model_used":"gpt-4o-mini","tokenizer_type":"o200k_base","exact":true}`))
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(WithBaseURL(srv.URL)).CountTokens(context.Background(), &TokenCountRequest{
+		Model:    "fast",
+		Messages: []ChatMessage{UserMessage("hello there")},
+	})
+	if err != nil {
+		t.Fatalf("CountTokens: %v", err)
+	}
+	if resp.TotalTokens != 9 || resp.ModelUsed != "gpt-4o-mini" || !resp.Exact {
+		t.Fatalf("resp = %+v", resp)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/omne42/ditto-llm/sdk/go
+
+go 1.23